@@ -92,10 +92,12 @@ func TestSeedDemoDatabasePopulatesOperationalScreensAndReports(t *testing.T) {
 		t.Fatalf("demo report should contain chart series and top products")
 	}
 
-	inventory, err := reporting.GetInventoryReport(dto.ReportingPeriodRequest{
-		FromOccurredOn: "2026-07-01",
-		ToOccurredOn:   "2026-07-20",
-		Granularity:    "DAY",
+	inventory, err := reporting.GetInventoryReport(dto.InventoryReportRequest{
+		ReportingPeriodRequest: dto.ReportingPeriodRequest{
+			FromOccurredOn: "2026-07-01",
+			ToOccurredOn:   "2026-07-20",
+			Granularity:    "DAY",
+		},
 	})
 	if err != nil {
 		t.Fatalf("get demo inventory report: %v", err)
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// backupTimestampLayout keeps automatic backup names sortable and unique to
+// the millisecond. The name is the only record of when a backup was taken.
+const backupTimestampLayout = "20060102T150405.000Z"
+
+var backupFilePattern = regexp.MustCompile(`^sweeters-(\d{8}T\d{6}\.\d{3}Z)\.db$`)
+
+// BackupFile is one automatic backup in a backup directory.
+type BackupFile struct {
+	Name      string
+	Path      string
+	CreatedAt time.Time
+	SizeBytes int64
+}
+
+// BackupFileName returns the automatic backup name for createdAt.
+func BackupFileName(createdAt time.Time) string {
+	return "sweeters-" + createdAt.UTC().Format(backupTimestampLayout) + ".db"
+}
+
+// WriteBackup exports a validated snapshot into directory, creating the
+// directory when needed. It never replaces an existing backup.
+func (d *Database) WriteBackup(directory string, createdAt time.Time) (BackupFile, error) {
+	if directory == "" {
+		return BackupFile{}, errors.New("backup directory is empty")
+	}
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return BackupFile{}, fmt.Errorf("create backup directory: %w", err)
+	}
+	path := filepath.Join(directory, BackupFileName(createdAt))
+	if _, err := d.export(path, createdAt); err != nil {
+		return BackupFile{}, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return BackupFile{}, fmt.Errorf("inspect backup: %w", err)
+	}
+	return BackupFile{
+		Name:      info.Name(),
+		Path:      path,
+		CreatedAt: createdAt.UTC().Truncate(time.Millisecond),
+		SizeBytes: info.Size(),
+	}, nil
+}
+
+// ListBackupFiles returns the automatic backups in directory, newest first.
+// Files that do not follow the automatic naming scheme are ignored, so manual
+// exports and restore safety copies stored alongside are never pruned.
+func ListBackupFiles(directory string) ([]BackupFile, error) {
+	entries, err := os.ReadDir(directory)
+	if errors.Is(err, os.ErrNotExist) {
+		return []BackupFile{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read backup directory: %w", err)
+	}
+	backups := make([]BackupFile, 0, len(entries))
+	for _, entry := range entries {
+		match := backupFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil || !entry.Type().IsRegular() {
+			continue
+		}
+		createdAt, err := time.Parse(backupTimestampLayout, match[1])
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("inspect backup %s: %w", entry.Name(), err)
+		}
+		backups = append(backups, BackupFile{
+			Name:      entry.Name(),
+			Path:      filepath.Join(directory, entry.Name()),
+			CreatedAt: createdAt,
+			SizeBytes: info.Size(),
+		})
+	}
+	sort.Slice(backups, func(left, right int) bool {
+		return backups[left].CreatedAt.After(backups[right].CreatedAt)
+	})
+	return backups, nil
+}
+
+// RemoveBackupFile deletes one automatic backup and its manifest by name.
+// Names outside the automatic scheme are refused so pruning cannot reach
+// unrelated files.
+func RemoveBackupFile(directory, name string) error {
+	if !backupFilePattern.MatchString(name) {
+		return fmt.Errorf("not an automatic backup: %q", name)
+	}
+	path := filepath.Join(directory, name)
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove backup: %w", err)
+	}
+	if err := os.Remove(ManifestPath(path)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove backup manifest: %w", err)
+	}
+	return nil
+}
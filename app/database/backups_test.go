@@ -0,0 +1,72 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteBackupListsNewestFirstAndIgnoresForeignFiles(t *testing.T) {
+	db := newBackupTestDatabase(t, filepath.Join(t.TempDir(), "live.db"))
+	directory := filepath.Join(t.TempDir(), "backups")
+
+	older := time.Date(2026, 10, 17, 9, 30, 0, 123_000_000, time.UTC)
+	newer := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	for _, createdAt := range []time.Time{older, newer} {
+		backup, err := db.WriteBackup(directory, createdAt)
+		if err != nil {
+			t.Fatalf("write backup: %v", err)
+		}
+		if backup.Name != BackupFileName(createdAt) || backup.SizeBytes == 0 {
+			t.Fatalf("backup = %#v", backup)
+		}
+		if err := validateDatabaseFile(backup.Path); err != nil {
+			t.Fatalf("validate backup: %v", err)
+		}
+	}
+	if _, err := db.WriteBackup(directory, newer); err == nil {
+		t.Fatal("backup overwrote an existing snapshot")
+	}
+	if err := os.WriteFile(filepath.Join(directory, "manual-export.db"), []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := ListBackupFiles(directory)
+	if err != nil {
+		t.Fatalf("list backups: %v", err)
+	}
+	if len(backups) != 2 || !backups[0].CreatedAt.Equal(newer) || !backups[1].CreatedAt.Equal(older) {
+		t.Fatalf("backups = %#v", backups)
+	}
+
+	if err := RemoveBackupFile(directory, "manual-export.db"); err == nil {
+		t.Fatal("removed a file outside the automatic backup scheme")
+	}
+	if manifest, found, err := ReadBackupManifest(backups[1].Path); err != nil || !found || !manifest.CreatedAt.Equal(older) {
+		t.Fatalf("backup manifest = %#v, %t, %v", manifest, found, err)
+	}
+	if err := RemoveBackupFile(directory, backups[1].Name); err != nil {
+		t.Fatalf("remove backup: %v", err)
+	}
+	if _, err := os.Stat(ManifestPath(backups[1].Path)); !os.IsNotExist(err) {
+		t.Fatalf("pruned backup left its manifest: %v", err)
+	}
+	remaining, err := ListBackupFiles(directory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].Name != backups[0].Name {
+		t.Fatalf("remaining backups = %#v", remaining)
+	}
+	if _, err := os.Stat(filepath.Join(directory, "manual-export.db")); err != nil {
+		t.Fatalf("manual export was touched: %v", err)
+	}
+}
+
+func TestListBackupFilesTreatsMissingDirectoryAsEmpty(t *testing.T) {
+	backups, err := ListBackupFiles(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || backups == nil || len(backups) != 0 {
+		t.Fatalf("missing directory backups = %#v, %v", backups, err)
+	}
+}
@@ -14,12 +14,19 @@ import (
 
 type Database struct {
 	conn *sql.DB
+	// path is the file the connection was opened from. Restore stages its
+	// candidate beside it so activation is a same-directory rename.
+	path string
+	// appVersion is recorded in backup manifests.
+	appVersion string
 }
 
 // OpenOptions configures connection behavior that needs to differ in bounded
 // integration tests. Production callers should start with DefaultOpenOptions.
 type OpenOptions struct {
 	BusyTimeout time.Duration
+	// AppVersion identifies the build in backup manifests.
+	AppVersion string
 }
 
 func DefaultOpenOptions() OpenOptions {
@@ -35,7 +42,7 @@ func NewDatabaseWithOptions(dbPath string, options OpenOptions) (*Database, erro
 	if err != nil {
 		return nil, err
 	}
-	database := &Database{conn: db}
+	database := &Database{conn: db, path: dbPath, appVersion: options.AppVersion}
 	if err := migrateDatabase(db, schemaFS, "schemas"); err != nil {
 		_ = db.Close()
 		return nil, err
@@ -24,7 +24,7 @@ func TestNewDatabaseCreatesStrictV2Baseline(t *testing.T) {
 		"busy_timeout":   5000,
 		"synchronous":    1,
 		"application_id": applicationID,
-		"user_version":   2,
+		"user_version":   16,
 	}
 	for name, want := range pragmas {
 		var got int
@@ -48,8 +48,8 @@ func TestNewDatabaseCreatesStrictV2Baseline(t *testing.T) {
 	if err := db.conn.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&migrations); err != nil {
 		t.Fatal(err)
 	}
-	if migrations != 2 {
-		t.Fatalf("migration count = %d, want 2", migrations)
+	if migrations != 16 {
+		t.Fatalf("migration count = %d, want 16", migrations)
 	}
 
 	var domainTables, strictTables int
@@ -60,8 +60,8 @@ func TestNewDatabaseCreatesStrictV2Baseline(t *testing.T) {
 	`).Scan(&domainTables, &strictTables); err != nil {
 		t.Fatal(err)
 	}
-	if domainTables != 17 || strictTables != domainTables {
-		t.Fatalf("domain tables = %d and strict tables = %d, want 17 strict tables", domainTables, strictTables)
+	if domainTables != 39 || strictTables != domainTables {
+		t.Fatalf("domain tables = %d and strict tables = %d, want 39 strict tables", domainTables, strictTables)
 	}
 }
 
@@ -173,8 +173,8 @@ func TestNewDatabaseHandlesConcurrentFirstOpen(t *testing.T) {
 	if err := db.conn.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&migrations); err != nil {
 		t.Fatal(err)
 	}
-	if migrations != 2 {
-		t.Fatalf("migration count after concurrent open = %d, want 2", migrations)
+	if migrations != 16 {
+		t.Fatalf("migration count after concurrent open = %d, want 16", migrations)
 	}
 }
 
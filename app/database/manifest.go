@@ -0,0 +1,313 @@
+package database
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// manifestSuffix names the sidecar written beside every exported backup. The
+// manifest is advisory: verification always re-reads the backup itself.
+const manifestSuffix = ".manifest.json"
+
+const backupManifestFormat = 1
+
+// BackupCompatibility is what restoring a candidate would mean for this build.
+type BackupCompatibility string
+
+const (
+	// BackupCurrent has exactly this build's migrations and restores as-is.
+	BackupCurrent BackupCompatibility = "CURRENT"
+	// BackupUpgradable has an older, intact migration prefix; restore applies
+	// the remaining forward migrations to the staged copy.
+	BackupUpgradable BackupCompatibility = "UPGRADABLE"
+	// BackupTooNew was written by a newer build and cannot be restored.
+	BackupTooNew BackupCompatibility = "TOO_NEW"
+	// BackupInvalid is not a usable Sweeters database.
+	BackupInvalid BackupCompatibility = "INVALID"
+)
+
+type ManifestMigration struct {
+	Version  int    `json:"version"`
+	Name     string `json:"name"`
+	Checksum string `json:"checksum"`
+}
+
+// BackupManifest describes one backup so copies can be told apart without
+// opening them. Schema, ledger, and count facts are read from the snapshot
+// itself after it is written, never from the live database.
+type BackupManifest struct {
+	Format              int                 `json:"format"`
+	AppVersion          string              `json:"appVersion"`
+	CreatedAt           time.Time           `json:"createdAt"`
+	SchemaVersion       int                 `json:"schemaVersion"`
+	Migrations          []ManifestMigration `json:"migrations"`
+	FileSHA256          string              `json:"fileSha256"`
+	FileSizeBytes       int64               `json:"fileSizeBytes"`
+	LastPostingSequence int64               `json:"lastPostingSequence"`
+	DocumentCounts      map[string]int64    `json:"documentCounts"`
+}
+
+// BackupVerification reports whether a candidate could be restored. Contents
+// holds the facts read from the candidate; Manifest is its sidecar, if any.
+type BackupVerification struct {
+	Path                   string
+	Compatibility          BackupCompatibility
+	Problem                string
+	SupportedSchemaVersion int
+	Contents               BackupManifest
+	ManifestFound          bool
+	ManifestMatches        bool
+	Manifest               BackupManifest
+}
+
+// ManifestPath returns the sidecar path for a backup file.
+func ManifestPath(backupPath string) string {
+	return backupPath + manifestSuffix
+}
+
+// SupportedSchemaVersion is the highest migration version this build knows.
+func SupportedSchemaVersion() (int, error) {
+	migrations, err := loadMigrations(schemaFS, "schemas")
+	if err != nil {
+		return 0, err
+	}
+	return len(migrations), nil
+}
+
+// ReadBackupManifest loads the sidecar of backupPath. A missing sidecar is
+// reported with found=false and no error.
+func ReadBackupManifest(backupPath string) (BackupManifest, bool, error) {
+	content, err := os.ReadFile(ManifestPath(backupPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return BackupManifest{}, false, nil
+	}
+	if err != nil {
+		return BackupManifest{}, false, fmt.Errorf("read backup manifest: %w", err)
+	}
+	var manifest BackupManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return BackupManifest{}, false, fmt.Errorf("parse backup manifest: %w", err)
+	}
+	if manifest.Format != backupManifestFormat {
+		return BackupManifest{}, false, fmt.Errorf("unsupported backup manifest format %d", manifest.Format)
+	}
+	return manifest, true, nil
+}
+
+// VerifyBackup checks a candidate without modifying it or the live database.
+// The candidate is copied through a read-only connection into a private
+// temporary directory, and every check runs against that copy. Only failures
+// to read the candidate at all are returned as errors; an unusable candidate
+// is a verification result.
+func VerifyBackup(candidatePath string) (BackupVerification, error) {
+	if candidatePath == "" {
+		return BackupVerification{}, errors.New("backup path is empty")
+	}
+	absPath, err := filepath.Abs(candidatePath)
+	if err != nil {
+		return BackupVerification{}, fmt.Errorf("resolve backup path: %w", err)
+	}
+	if info, err := os.Stat(absPath); err != nil {
+		return BackupVerification{}, fmt.Errorf("inspect backup: %w", err)
+	} else if !info.Mode().IsRegular() {
+		return BackupVerification{}, fmt.Errorf("backup is not a file: %s", absPath)
+	}
+	migrations, err := loadMigrations(schemaFS, "schemas")
+	if err != nil {
+		return BackupVerification{}, err
+	}
+	checksum, size, err := fileSHA256(absPath)
+	if err != nil {
+		return BackupVerification{}, err
+	}
+	verification := BackupVerification{
+		Path:                   absPath,
+		SupportedSchemaVersion: len(migrations),
+		Contents:               BackupManifest{FileSHA256: checksum, FileSizeBytes: size},
+	}
+	manifest, found, err := ReadBackupManifest(absPath)
+	if err != nil {
+		verification.Compatibility = BackupInvalid
+		verification.Problem = err.Error()
+		return verification, nil
+	}
+	verification.ManifestFound = found
+	verification.Manifest = manifest
+	verification.ManifestMatches = found && manifest.FileSHA256 == checksum && manifest.FileSizeBytes == size
+
+	directory, err := os.MkdirTemp("", "sweeters-verify-*")
+	if err != nil {
+		return BackupVerification{}, fmt.Errorf("reserve verification directory: %w", err)
+	}
+	defer os.RemoveAll(directory)
+	copyPath := filepath.Join(directory, "candidate.db")
+	if err := copyDatabaseFile(absPath, copyPath); err != nil {
+		verification.Compatibility = BackupInvalid
+		verification.Problem = fmt.Sprintf("read backup: %v", err)
+		return verification, nil
+	}
+
+	compatibility, contents, problem := classifyBackupCopy(copyPath, migrations)
+	verification.Compatibility = compatibility
+	verification.Problem = problem
+	contents.FileSHA256 = checksum
+	contents.FileSizeBytes = size
+	verification.Contents = contents
+	return verification, nil
+}
+
+// classifyBackupCopy reads and, when older, forward-migrates a private copy.
+// The copy is disposable, so upgrading it proves the restore path will work.
+func classifyBackupCopy(copyPath string, migrations []migration) (BackupCompatibility, BackupManifest, string) {
+	db, err := openConnection(copyPath, DefaultOpenOptions())
+	if err != nil {
+		return BackupInvalid, BackupManifest{}, err.Error()
+	}
+	defer db.Close()
+
+	if err := validateMigrationHistory(db, migrations, false); err != nil {
+		contents := BackupManifest{}
+		if errors.Is(err, ErrDatabaseTooNew) {
+			contents.SchemaVersion, _ = readPragmaInt(db, "user_version")
+			return BackupTooNew, contents, err.Error()
+		}
+		return BackupInvalid, contents, err.Error()
+	}
+	contents, err := describeDatabase(db)
+	if err != nil {
+		return BackupInvalid, contents, err.Error()
+	}
+	compatibility := BackupCurrent
+	if contents.SchemaVersion < len(migrations) {
+		compatibility = BackupUpgradable
+		if err := migrateDatabase(db, schemaFS, "schemas"); err != nil {
+			return BackupInvalid, contents, fmt.Sprintf("upgrade backup: %v", err)
+		}
+	}
+	if err := validateDatabase(db); err != nil {
+		return BackupInvalid, contents, err.Error()
+	}
+	if err := validateLedgerProjection(db); err != nil {
+		return BackupInvalid, contents, err.Error()
+	}
+	return compatibility, contents, ""
+}
+
+// describeDatabase reads the manifest facts stored inside a database. File
+// checksum, creation time, and application version are filled by the caller.
+func describeDatabase(db *sql.DB) (BackupManifest, error) {
+	manifest := BackupManifest{
+		Format:         backupManifestFormat,
+		Migrations:     []ManifestMigration{},
+		DocumentCounts: map[string]int64{},
+	}
+	version, err := readPragmaInt(db, "user_version")
+	if err != nil {
+		return manifest, err
+	}
+	manifest.SchemaVersion = version
+
+	rows, err := db.Query(`SELECT version, name, checksum FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return manifest, fmt.Errorf("read migrations: %w", err)
+	}
+	for rows.Next() {
+		var item ManifestMigration
+		if err := rows.Scan(&item.Version, &item.Name, &item.Checksum); err != nil {
+			rows.Close()
+			return manifest, fmt.Errorf("read migrations: %w", err)
+		}
+		manifest.Migrations = append(manifest.Migrations, item)
+	}
+	if err := rows.Close(); err != nil {
+		return manifest, fmt.Errorf("read migrations: %w", err)
+	}
+
+	if err := db.QueryRow(`
+		SELECT COALESCE(MAX(posting_sequence), 0) FROM stock_documents
+	`).Scan(&manifest.LastPostingSequence); err != nil {
+		return manifest, fmt.Errorf("read posting sequence: %w", err)
+	}
+	counts, err := db.Query(`SELECT kind, COUNT(*) FROM stock_documents GROUP BY kind ORDER BY kind`)
+	if err != nil {
+		return manifest, fmt.Errorf("count documents: %w", err)
+	}
+	defer counts.Close()
+	for counts.Next() {
+		var kind string
+		var count int64
+		if err := counts.Scan(&kind, &count); err != nil {
+			return manifest, fmt.Errorf("count documents: %w", err)
+		}
+		manifest.DocumentCounts[kind] = count
+	}
+	if err := counts.Err(); err != nil {
+		return manifest, fmt.Errorf("count documents: %w", err)
+	}
+	return manifest, nil
+}
+
+func describeDatabaseFile(dbPath string) (BackupManifest, error) {
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	manifest, err := describeDatabase(db)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	if err := db.Close(); err != nil {
+		return BackupManifest{}, err
+	}
+	manifest.FileSHA256, manifest.FileSizeBytes, err = fileSHA256(dbPath)
+	return manifest, err
+}
+
+// writeBackupManifest publishes the sidecar with a same-directory rename so a
+// reader never sees a partial manifest.
+func writeBackupManifest(backupPath string, manifest BackupManifest) error {
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode backup manifest: %w", err)
+	}
+	temporary, err := os.CreateTemp(filepath.Dir(backupPath), ".sweeters-manifest-*.json")
+	if err != nil {
+		return fmt.Errorf("reserve backup manifest: %w", err)
+	}
+	temporaryPath := temporary.Name()
+	defer os.Remove(temporaryPath)
+	if _, err := temporary.Write(append(content, '\n')); err != nil {
+		_ = temporary.Close()
+		return fmt.Errorf("write backup manifest: %w", err)
+	}
+	if err := temporary.Close(); err != nil {
+		return fmt.Errorf("write backup manifest: %w", err)
+	}
+	if err := os.Rename(temporaryPath, ManifestPath(backupPath)); err != nil {
+		return fmt.Errorf("publish backup manifest: %w", err)
+	}
+	return nil
+}
+
+func fileSHA256(path string) (string, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", 0, fmt.Errorf("open backup: %w", err)
+	}
+	defer file.Close()
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, fmt.Errorf("hash backup: %w", err)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), size, nil
+}
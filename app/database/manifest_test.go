@@ -0,0 +1,147 @@
+package database
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportWritesManifestDescribingSnapshot(t *testing.T) {
+	directory := t.TempDir()
+	options := DefaultOpenOptions()
+	options.AppVersion = "1.4.0"
+	db, err := NewDatabaseWithOptions(filepath.Join(directory, "live.db"), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	insertTestDocument(t, db, "PURCHASE", 1, nil, nil, nil, "manifest-purchase-1")
+	insertTestDocument(t, db, "PURCHASE", 2, nil, nil, nil, "manifest-purchase-2")
+
+	backupPath := filepath.Join(directory, "export.db")
+	if err := db.Export(backupPath); err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	manifest, found, err := ReadBackupManifest(backupPath)
+	if err != nil || !found {
+		t.Fatalf("read manifest = %v, found %t", err, found)
+	}
+	checksum, size, err := fileSHA256(backupPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	supported, err := SupportedSchemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.AppVersion != "1.4.0" || manifest.CreatedAt.IsZero() ||
+		manifest.SchemaVersion != supported || len(manifest.Migrations) != supported ||
+		manifest.FileSHA256 != checksum || manifest.FileSizeBytes != size ||
+		manifest.LastPostingSequence != 2 || manifest.DocumentCounts["PURCHASE"] != 2 {
+		t.Fatalf("manifest = %#v", manifest)
+	}
+	for _, migration := range manifest.Migrations {
+		if len(migration.Checksum) != 64 || migration.Name == "" {
+			t.Fatalf("manifest migration = %#v", migration)
+		}
+	}
+}
+
+func TestVerifyBackupReportsCompatibilityWithoutTouchingCandidate(t *testing.T) {
+	directory := t.TempDir()
+	live := newBackupTestDatabase(t, filepath.Join(directory, "live.db"))
+	insertTestDocument(t, live, "PURCHASE", 1, nil, nil, nil, "verify-purchase")
+	current := filepath.Join(directory, "current.db")
+	if err := live.Export(current); err != nil {
+		t.Fatal(err)
+	}
+
+	verification := mustVerifyBackup(t, current)
+	if verification.Compatibility != BackupCurrent || verification.Problem != "" ||
+		!verification.ManifestFound || !verification.ManifestMatches ||
+		verification.Contents.LastPostingSequence != 1 ||
+		verification.Contents.SchemaVersion != verification.SupportedSchemaVersion {
+		t.Fatalf("current verification = %#v", verification)
+	}
+
+	manifest, _, err := ReadBackupManifest(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest.FileSHA256 = strings.Repeat("0", 64)
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ManifestPath(current), content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if verification := mustVerifyBackup(t, current); verification.Compatibility != BackupCurrent || verification.ManifestMatches {
+		t.Fatalf("mismatched manifest verification = %#v", verification)
+	}
+
+	older := filepath.Join(directory, "older.db")
+	olderDB := openMigrationTestDatabaseAt(t, older)
+	if err := migrateDatabase(olderDB, embeddedBaselineOnlyFS(t), "schemas"); err != nil {
+		t.Fatal(err)
+	}
+	if err := olderDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+	before, _, err := fileSHA256(older)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verification = mustVerifyBackup(t, older)
+	if verification.Compatibility != BackupUpgradable || verification.ManifestFound ||
+		verification.Contents.SchemaVersion != 1 {
+		t.Fatalf("older verification = %#v", verification)
+	}
+	if after, _, err := fileSHA256(older); err != nil || after != before {
+		t.Fatalf("verification modified the candidate: %v", err)
+	}
+
+	newer := filepath.Join(directory, "newer.db")
+	if err := live.Export(newer); err != nil {
+		t.Fatal(err)
+	}
+	newerDB := openMigrationTestDatabaseAt(t, newer)
+	if _, err := newerDB.Exec(`
+		INSERT INTO schema_migrations (version, name, checksum, applied_at_unix_ms)
+		VALUES (?, '9999_future.sql', ?, 1)
+	`, verification.SupportedSchemaVersion+1, strings.Repeat("f", 64)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newerDB.Exec(`PRAGMA user_version = 99`); err != nil {
+		t.Fatal(err)
+	}
+	if err := newerDB.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if verification := mustVerifyBackup(t, newer); verification.Compatibility != BackupTooNew || verification.Problem == "" {
+		t.Fatalf("newer verification = %#v", verification)
+	}
+
+	garbage := filepath.Join(directory, "garbage.db")
+	if err := os.WriteFile(garbage, []byte("not a database"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if verification := mustVerifyBackup(t, garbage); verification.Compatibility != BackupInvalid || verification.Problem == "" {
+		t.Fatalf("garbage verification = %#v", verification)
+	}
+
+	if _, err := VerifyBackup(filepath.Join(directory, "missing.db")); err == nil {
+		t.Fatal("verified a missing file")
+	}
+}
+
+func mustVerifyBackup(t *testing.T, path string) BackupVerification {
+	t.Helper()
+	verification, err := VerifyBackup(path)
+	if err != nil {
+		t.Fatalf("verify %s: %v", filepath.Base(path), err)
+	}
+	return verification
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"database/sql"
+	"database/sql/driver"
 	"embed"
 	"errors"
 	"fmt"
@@ -327,7 +328,7 @@ func validateMigrationHistory(db *sql.DB, migrations []migration, requireComplet
 }
 
 func ensureMigrationApplied(db *sql.DB, item migration) error {
-	return withImmediateTransaction(db, func(conn *sql.Conn) error {
+	return withMigrationTransaction(db, func(conn *sql.Conn) error {
 		var name, checksum string
 		err := conn.QueryRowContext(context.Background(), `
 			SELECT name, checksum FROM schema_migrations WHERE version = ?
@@ -375,6 +376,40 @@ func withImmediateTransaction(db *sql.DB, operation func(*sql.Conn) error) error
 		return err
 	}
 	defer conn.Close()
+	return runImmediateTransaction(ctx, conn, operation)
+}
+
+// withMigrationTransaction runs a migration with foreign keys disabled, as
+// SQLite requires for rebuilding a referenced table. Every foreign key is
+// checked before commit, and a connection whose enforcement cannot be restored
+// is discarded instead of returning to the pool.
+func withMigrationTransaction(db *sql.DB, operation func(*sql.Conn) error) (err error) {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err := conn.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return err
+	}
+	defer func() {
+		if _, restoreErr := conn.ExecContext(ctx, "PRAGMA foreign_keys = ON"); restoreErr != nil {
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+			if err == nil {
+				err = fmt.Errorf("restore foreign key enforcement: %w", restoreErr)
+			}
+		}
+	}()
+	return runImmediateTransaction(ctx, conn, func(conn *sql.Conn) error {
+		if err := operation(conn); err != nil {
+			return err
+		}
+		return checkForeignKeys(conn)
+	})
+}
+
+func runImmediateTransaction(ctx context.Context, conn *sql.Conn, operation func(*sql.Conn) error) error {
 	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE"); err != nil {
 		return err
 	}
@@ -435,7 +470,13 @@ func validateDatabase(db *sql.DB) error {
 		return err
 	}
 
-	foreignKeys, err := db.Query("PRAGMA foreign_key_check")
+	return checkForeignKeys(db)
+}
+
+func checkForeignKeys(queryer interface {
+	QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+}) error {
+	foreignKeys, err := queryer.QueryContext(context.Background(), "PRAGMA foreign_key_check")
 	if err != nil {
 		return err
 	}
@@ -141,20 +141,133 @@ func TestRecipeOutputGuardForwardMigration(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	if version != 2 {
-		t.Fatalf("user_version = %d, want 2", version)
+	if version != 16 {
+		t.Fatalf("user_version = %d, want 16", version)
 	}
 	var count int
 	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count); err != nil {
 		t.Fatal(err)
 	}
-	if count != 2 {
-		t.Fatalf("migration count = %d, want 2", count)
+	if count != 16 {
+		t.Fatalf("migration count = %d, want 16", count)
 	}
 	expectExecError(t, db, `UPDATE items SET is_producible = 0, updated_at_ms = 2 WHERE id = ?`, outputID)
 	expectExecError(t, db, `UPDATE items SET archived_at_ms = 2, updated_at_ms = 2 WHERE id = ?`, outputID)
 }
 
+func TestBackupSettingsMigrationKeepsExistingSettingsRow(t *testing.T) {
+	db := openMigrationTestDatabase(t)
+	if err := migrateDatabase(db, embeddedBaselineOnlyFS(t), "schemas"); err != nil {
+		t.Fatalf("apply embedded baseline: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE app_settings SET business_name = 'Kept bakery', updated_at_ms = 5`); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrateDatabase(db, schemaFS, "schemas"); err != nil {
+		t.Fatalf("apply forward migrations: %v", err)
+	}
+	var name string
+	var enabled, interval, keepDaily, keepWeekly, updatedAt int64
+	if err := db.QueryRow(`
+		SELECT business_name, backup_enabled, backup_interval_minutes,
+			backup_keep_daily, backup_keep_weekly, updated_at_ms
+		FROM app_settings
+	`).Scan(&name, &enabled, &interval, &keepDaily, &keepWeekly, &updatedAt); err != nil {
+		t.Fatal(err)
+	}
+	if name != "Kept bakery" || updatedAt != 5 || enabled != 1 || interval != 1440 || keepDaily != 7 || keepWeekly != 4 {
+		t.Fatalf("migrated settings = %q/%d enabled=%d interval=%d keep=%d/%d",
+			name, updatedAt, enabled, interval, keepDaily, keepWeekly)
+	}
+	expectExecError(t, db, `UPDATE app_settings SET backup_keep_daily = 0`)
+	expectExecError(t, db, `UPDATE app_settings SET backup_interval_minutes = 10081`)
+}
+
+func TestCustomerReturnsMigrationRebuildsLedgerTablesInPlace(t *testing.T) {
+	db := openMigrationTestDatabase(t)
+	if err := migrateDatabase(db, embeddedBaselineOnlyFS(t), "schemas"); err != nil {
+		t.Fatalf("apply embedded baseline: %v", err)
+	}
+	statements := []string{
+		`INSERT INTO items (
+			id, name, normalized_name, base_unit_code,
+			is_purchasable, is_producible, is_sellable, created_at_ms, updated_at_ms
+		) VALUES (7, 'Flour', 'flour', 'g', 1, 0, 1, 1, 1)`,
+		`INSERT INTO stock_documents (
+			id, kind, idempotency_key, posting_sequence, occurred_on, posted_at_ms,
+			currency_code, currency_minor_digits
+		) VALUES (11, 'PURCHASE', 'purchase', 1, '2026-07-14', 1, 'BRL', 2)`,
+		`INSERT INTO stock_document_lines (
+			id, document_id, line_order, item_id, direction, quantity_atomic,
+			entered_unit_code, conversion_numerator_atomic, conversion_denominator,
+			inventory_value_micro, commercial_total_minor
+		) VALUES (21, 11, 1, 7, 'IN', 100, 'g', 1000, 1, 100000, 100)`,
+		`INSERT INTO inventory_lots (
+			id, item_id, source_line_id, initial_quantity_atomic, originated_on, created_at_ms
+		) VALUES (31, 7, 21, 100, '2026-07-14', 1)`,
+		`INSERT INTO stock_documents (
+			id, kind, idempotency_key, posting_sequence, occurred_on, posted_at_ms,
+			currency_code, currency_minor_digits
+		) VALUES (12, 'SALE', 'sale', 2, '2026-07-14', 2, 'BRL', 2)`,
+		`INSERT INTO stock_document_lines (
+			id, document_id, line_order, item_id, direction, quantity_atomic,
+			entered_unit_code, conversion_numerator_atomic, conversion_denominator,
+			inventory_value_micro, commercial_total_minor
+		) VALUES (22, 12, 1, 7, 'OUT', 40, 'g', 1000, 1, 40000, 90)`,
+		`INSERT INTO lot_allocations (id, line_id, lot_id, quantity_atomic, created_at_ms)
+			VALUES (41, 22, 31, 40, 2)`,
+		`UPDATE inventory_balances
+			SET quantity_atomic = 60, inventory_value_micro = 60000, last_document_id = 12
+			WHERE item_id = 7`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := migrateDatabase(db, schemaFS, "schemas"); err != nil {
+		t.Fatalf("apply forward migrations: %v", err)
+	}
+	var documents, allocations int
+	var lastDocumentID int64
+	if err := db.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM stock_documents WHERE id IN (11, 12)),
+			(SELECT COUNT(*) FROM lot_allocations WHERE id = 41 AND line_id = 22 AND lot_id = 31),
+			(SELECT last_document_id FROM inventory_balances WHERE item_id = 7)
+	`).Scan(&documents, &allocations, &lastDocumentID); err != nil {
+		t.Fatal(err)
+	}
+	if documents != 2 || allocations != 1 || lastDocumentID != 12 {
+		t.Fatalf("migrated ledger = %d documents, %d allocations, last document %d", documents, allocations, lastDocumentID)
+	}
+	if err := checkForeignKeys(db); err != nil {
+		t.Fatalf("foreign keys after rebuild: %v", err)
+	}
+	for _, name := range []string{"stock_documents_next", "lot_allocations_next"} {
+		if databaseObjectExists(t, db, "table", name) {
+			t.Fatalf("rebuild left %s behind", name)
+		}
+	}
+	var foreignKeys int
+	if err := db.QueryRow(`PRAGMA foreign_keys`).Scan(&foreignKeys); err != nil {
+		t.Fatal(err)
+	}
+	if foreignKeys != 1 {
+		t.Fatal("migration left foreign keys disabled")
+	}
+	expectExecError(t, db, `UPDATE stock_documents SET notes = 'changed' WHERE id = 12`)
+	expectExecError(t, db, `DELETE FROM lot_allocations WHERE id = 41`)
+	expectExecError(t, db, `
+		INSERT INTO stock_document_lines (
+			document_id, line_order, item_id, direction, quantity_atomic,
+			entered_unit_code, conversion_numerator_atomic, conversion_denominator,
+			inventory_value_micro, commercial_total_minor
+		) VALUES (99, 1, 7, 'IN', 1, 'g', 1000, 1, 1, 1)
+	`)
+}
+
 func TestRecipeChainMigrationRejectsExistingGapAtomically(t *testing.T) {
 	db := openMigrationTestDatabase(t)
 	if err := migrateDatabase(db, embeddedBaselineOnlyFS(t), "schemas"); err != nil {
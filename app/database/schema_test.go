@@ -143,6 +143,54 @@ func TestArchiveTimestampMustMatchOptimisticVersion(t *testing.T) {
 	}
 }
 
+func TestItemCategoriesKeepActiveItemsInActiveCategories(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	result, err := db.conn.Exec(`
+		INSERT INTO item_categories (name, normalized_name, created_at_ms, updated_at_ms)
+		VALUES ('Bolos', 'bolos', 1, 1)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	categoryID, _ := result.LastInsertId()
+	itemID := insertTestItem(t, db, "Bolo de cenoura", "bolo de cenoura", "each", false, true, true)
+	if _, err := db.conn.Exec(`UPDATE items SET category_id = ? WHERE id = ?`, categoryID, itemID); err != nil {
+		t.Fatalf("assign active category: %v", err)
+	}
+
+	expectExecError(t, db.conn, `
+		INSERT INTO item_categories (name, normalized_name, created_at_ms, updated_at_ms)
+		VALUES ('BOLOS', 'bolos', 1, 1)
+	`)
+	expectExecError(t, db.conn, `UPDATE items SET category_id = 999 WHERE id = ?`, itemID)
+	expectExecError(t, db.conn, `DELETE FROM item_categories WHERE id = ?`, categoryID)
+	expectExecError(t, db.conn, `
+		UPDATE item_categories SET archived_at_ms = 2, updated_at_ms = 2 WHERE id = ?
+	`, categoryID)
+	expectExecError(t, db.conn, `UPDATE item_categories SET archived_at_ms = 3 WHERE id = ?`, categoryID)
+
+	if _, err := db.conn.Exec(`
+		UPDATE items SET archived_at_ms = 2, updated_at_ms = 2 WHERE id = ?
+	`, itemID); err != nil {
+		t.Fatalf("archive categorized item: %v", err)
+	}
+	if _, err := db.conn.Exec(`
+		UPDATE item_categories SET archived_at_ms = 2, updated_at_ms = 2 WHERE id = ?
+	`, categoryID); err != nil {
+		t.Fatalf("archive category without active items: %v", err)
+	}
+	expectExecError(t, db.conn, `
+		UPDATE items SET archived_at_ms = NULL, updated_at_ms = 3 WHERE id = ?
+	`, itemID)
+	expectExecError(t, db.conn, `
+		INSERT INTO items (
+			name, normalized_name, base_unit_code, category_id,
+			is_purchasable, is_producible, is_sellable,
+			created_at_ms, updated_at_ms
+		) VALUES ('Bolo de fuba', 'bolo de fuba', 'each', ?, 0, 1, 1, 3, 3)
+	`, categoryID)
+}
+
 func TestRecipeSchemaPreservesPublishedRevisionHistory(t *testing.T) {
 	db := openSchemaTestDatabase(t)
 	ingredientID := insertTestItem(t, db, "Flour", "flour", "g", true, false, false)
@@ -506,6 +554,1040 @@ func TestLotsAndAllocationsPreventOverconsumptionAndSupportExactRestore(t *testi
 	expectExecError(t, db.conn, `DELETE FROM inventory_lots WHERE id = ?`, lotID)
 }
 
+func TestCustomerReturnSchemaRestoresPartialAllocations(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	itemID := insertTestItem(t, db, "Jam", "jam", "g", true, false, true)
+	purchaseID := insertTestDocument(t, db, "PURCHASE", 1, nil, nil, nil, "purchase-jam")
+	purchaseLineID := insertTestLine(t, db, purchaseID, 1, itemID, "IN", 100, "g", 100000, 100, nil)
+	lotID := insertTestLot(t, db, itemID, purchaseLineID, 100, 1)
+	saleID := insertTestDocument(t, db, "SALE", 2, nil, nil, nil, "sale-jam")
+	saleLineID := insertTestLine(t, db, saleID, 1, itemID, "OUT", 60, "g", 60000, 300, nil)
+	result, err := db.conn.Exec(`
+		INSERT INTO lot_allocations (line_id, lot_id, quantity_atomic, created_at_ms)
+		VALUES (?, ?, 60, 2)
+	`, saleLineID, lotID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allocationID, _ := result.LastInsertId()
+
+	insertReturn := func(sequence int64, key string, target any) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO stock_documents (
+				kind, idempotency_key, posting_sequence, occurred_on, posted_at_ms,
+				currency_code, currency_minor_digits, reason_code, returns_document_id
+			) VALUES ('RETURN', ?, ?, '2026-07-15', ?, 'BRL', 2, 'CUSTOMER_RETURN', ?)
+		`, key, sequence, sequence, target)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+	insertReturnLine := func(documentID, quantity, value, refund int64) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO stock_document_lines (
+				document_id, line_order, item_id, direction, quantity_atomic,
+				entered_unit_code, conversion_numerator_atomic, conversion_denominator,
+				inventory_value_micro, commercial_total_minor, returns_line_id
+			) VALUES (?, 1, ?, 'IN', ?, 'g', 1000, 1, ?, ?, ?)
+		`, documentID, itemID, quantity, value, refund, saleLineID)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+
+	if _, err := insertReturn(3, "return-purchase", purchaseID); err == nil {
+		t.Fatal("return accepted a purchase target")
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_documents (
+			kind, idempotency_key, posting_sequence, occurred_on, posted_at_ms,
+			currency_code, currency_minor_digits, reason_code, returns_document_id
+		) VALUES ('RETURN', 'return-bad-reason', 3, '2026-07-15', 3, 'BRL', 2, 'WASTE', ?)
+	`, saleID)
+
+	firstID, err := insertReturn(3, "return-1", saleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertReturnLine(firstID, 61, 61000, 300); err == nil {
+		t.Fatal("return line exceeded the sold quantity")
+	}
+	firstLineID, err := insertReturnLine(firstID, 20, 20000, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.conn.Exec(`
+		INSERT INTO lot_allocations (
+			line_id, lot_id, quantity_atomic, restores_allocation_id, created_at_ms
+		) VALUES (?, ?, 20, ?, 3)
+	`, firstLineID, lotID, allocationID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO inventory_lots (
+			item_id, source_line_id, initial_quantity_atomic, originated_on, created_at_ms
+		) VALUES (?, ?, 20, '2026-07-15', 3)
+	`, itemID, firstLineID)
+
+	secondID, err := insertReturn(4, "return-2", saleID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertReturnLine(secondID, 40, 40000, 201); err == nil {
+		t.Fatal("returns refunded more than the sale line total")
+	}
+	secondLineID, err := insertReturnLine(secondID, 40, 40000, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO lot_allocations (
+			line_id, lot_id, quantity_atomic, restores_allocation_id, created_at_ms
+		) VALUES (?, ?, 41, ?, 4)
+	`, secondLineID, lotID, allocationID)
+	if _, err := db.conn.Exec(`
+		INSERT INTO lot_allocations (
+			line_id, lot_id, quantity_atomic, restores_allocation_id, created_at_ms
+		) VALUES (?, ?, 40, ?, 4)
+	`, secondLineID, lotID, allocationID); err != nil {
+		t.Fatal(err)
+	}
+
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_documents (
+			kind, idempotency_key, posting_sequence, occurred_on, posted_at_ms,
+			currency_code, currency_minor_digits, reason_code, reverses_document_id
+		) VALUES ('REVERSAL', 'reverse-return', 5, '2026-07-15', 5, 'BRL', 2, 'EXACT_REVERSAL', ?)
+	`, firstID)
+}
+
+func TestSupplierReturnSchemaConsumesOnlyThePurchaseLot(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	itemID := insertTestItem(t, db, "Flour", "flour", "g", true, false, false)
+	purchaseID := insertTestDocument(t, db, "PURCHASE", 1, nil, nil, nil, "purchase-flour")
+	purchaseLineID := insertTestLine(t, db, purchaseID, 1, itemID, "IN", 100, "g", 100000, 500, nil)
+	lotID := insertTestLot(t, db, itemID, purchaseLineID, 100, 1)
+	otherID := insertTestDocument(t, db, "PURCHASE", 2, nil, nil, nil, "purchase-flour-2")
+	otherLineID := insertTestLine(t, db, otherID, 1, itemID, "IN", 100, "g", 100000, 500, nil)
+	otherLotID := insertTestLot(t, db, itemID, otherLineID, 100, 2)
+
+	insertReturn := func(sequence int64, key, reason string, target int64) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO stock_documents (
+				kind, idempotency_key, posting_sequence, occurred_on, posted_at_ms,
+				currency_code, currency_minor_digits, reason_code, returns_document_id
+			) VALUES ('RETURN', ?, ?, '2026-07-15', ?, 'BRL', 2, ?, ?)
+		`, key, sequence, sequence, reason, target)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+	insertReturnLine := func(documentID int64, direction string, quantity, value, credit int64) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO stock_document_lines (
+				document_id, line_order, item_id, direction, quantity_atomic,
+				entered_unit_code, conversion_numerator_atomic, conversion_denominator,
+				inventory_value_micro, commercial_total_minor, returns_line_id
+			) VALUES (?, 1, ?, ?, ?, 'g', 1000, 1, ?, ?, ?)
+		`, documentID, itemID, direction, quantity, value, credit, purchaseLineID)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+
+	saleID := insertTestDocument(t, db, "SALE", 3, nil, nil, nil, "sale-flour")
+	if _, err := insertReturn(4, "supplier-return-sale", "SUPPLIER_RETURN", saleID); err == nil {
+		t.Fatal("supplier return accepted a sale target")
+	}
+	if _, err := insertReturn(4, "customer-return-purchase", "CUSTOMER_RETURN", purchaseID); err == nil {
+		t.Fatal("customer return accepted a purchase target")
+	}
+	returnID, err := insertReturn(4, "supplier-return", "SUPPLIER_RETURN", purchaseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertReturnLine(returnID, "IN", 10, 10000, 50); err == nil {
+		t.Fatal("supplier return accepted an inbound line")
+	}
+	if _, err := insertReturnLine(returnID, "OUT", 101, 101000, 500); err == nil {
+		t.Fatal("supplier return exceeded the purchased quantity")
+	}
+	if _, err := insertReturnLine(returnID, "OUT", 10, 10000, 501); err == nil {
+		t.Fatal("supplier return credited more than the purchase line total")
+	}
+	lineID, err := insertReturnLine(returnID, "OUT", 100, 100001, 500)
+	if err != nil {
+		t.Fatalf("supplier return line emptying the balance: %v", err)
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO lot_allocations (line_id, lot_id, quantity_atomic, created_at_ms)
+		VALUES (?, ?, 100, 4)
+	`, lineID, otherLotID)
+	if _, err := db.conn.Exec(`
+		INSERT INTO lot_allocations (line_id, lot_id, quantity_atomic, created_at_ms)
+		VALUES (?, ?, 100, 4)
+	`, lineID, lotID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_documents (
+			kind, idempotency_key, posting_sequence, occurred_on, posted_at_ms,
+			currency_code, currency_minor_digits, reason_code, reverses_document_id
+		) VALUES ('REVERSAL', 'reverse-supplier-return', 5, '2026-07-15', 5, 'BRL', 2, 'EXACT_REVERSAL', ?)
+	`, returnID)
+}
+
+func TestTransferSchemaMovesLotsBetweenActiveLocations(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	itemID := insertTestItem(t, db, "Flour", "flour", "g", true, false, false)
+	purchaseID := insertTestDocument(t, db, "PURCHASE", 1, nil, nil, nil, "purchase-flour")
+	purchaseLineID := insertTestLine(t, db, purchaseID, 1, itemID, "IN", 100, "g", 100000, 500, nil)
+	lotID := insertTestLot(t, db, itemID, purchaseLineID, 100, 1)
+	var locationID int64
+	if err := db.conn.QueryRow(`SELECT location_id FROM inventory_lots WHERE id = ?`, lotID).Scan(&locationID); err != nil || locationID != 1 {
+		t.Fatalf("purchase lot location = %d, %v; want default location", locationID, err)
+	}
+	result, err := db.conn.Exec(`
+		INSERT INTO stock_locations (name, normalized_name, created_at_ms, updated_at_ms)
+		VALUES ('Fridge', 'fridge', 1, 1)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fridgeID, err := result.LastInsertId()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_documents (
+			kind, idempotency_key, posting_sequence, occurred_on, posted_at_ms,
+			currency_code, currency_minor_digits, reason_code
+		) VALUES ('TRANSFER', 'transfer-with-reason', 2, '2026-07-15', 2, 'BRL', 2, 'DAMAGE')
+	`)
+	transferID := insertTestDocument(t, db, "TRANSFER", 2, nil, nil, nil, "transfer-flour")
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_document_lines (
+			document_id, line_order, item_id, direction, quantity_atomic,
+			entered_unit_code, conversion_numerator_atomic, conversion_denominator,
+			inventory_value_micro
+		) VALUES (?, 1, ?, 'IN', 40, 'g', 1000, 1, 40000)
+	`, transferID, itemID)
+	outLineID := insertTestLine(t, db, transferID, 1, itemID, "OUT", 40, "g", 40000, nil, nil)
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_document_lines (
+			document_id, line_order, item_id, direction, quantity_atomic,
+			entered_unit_code, conversion_numerator_atomic, conversion_denominator,
+			inventory_value_micro
+		) VALUES (?, 2, ?, 'IN', 40, 'g', 1000, 1, 40001)
+	`, transferID, itemID)
+	inLineID := insertTestLine(t, db, transferID, 2, itemID, "IN", 40, "g", 40000, nil, nil)
+	if _, err := db.conn.Exec(`
+		INSERT INTO lot_allocations (line_id, lot_id, quantity_atomic, created_at_ms)
+		VALUES (?, ?, 40, 2)
+	`, outLineID, lotID); err != nil {
+		t.Fatal(err)
+	}
+
+	insertTransferLot := func(location, from any) error {
+		_, err := db.conn.Exec(`
+			INSERT INTO inventory_lots (
+				item_id, source_line_id, initial_quantity_atomic, originated_on,
+				created_at_ms, location_id, transferred_from_lot_id
+			) VALUES (?, ?, 40, '2026-07-14', 2, ?, ?)
+		`, itemID, inLineID, location, from)
+		return err
+	}
+	if err := insertTransferLot(fridgeID, nil); err == nil {
+		t.Fatal("transfer lot accepted without its source lot")
+	}
+	if err := insertTransferLot(1, lotID); err == nil {
+		t.Fatal("transfer lot accepted the source location as destination")
+	}
+	if err := insertTransferLot(fridgeID, lotID); err != nil {
+		t.Fatalf("transfer lot: %v", err)
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_documents (
+			kind, idempotency_key, posting_sequence, occurred_on, posted_at_ms,
+			currency_code, currency_minor_digits, reason_code, reverses_document_id
+		) VALUES ('REVERSAL', 'reverse-transfer', 3, '2026-07-15', 3, 'BRL', 2, 'EXACT_REVERSAL', ?)
+	`, transferID)
+	expectExecError(t, db.conn, `UPDATE stock_locations SET archived_at_ms = 5, updated_at_ms = 5 WHERE id = ?`, fridgeID)
+	expectExecError(t, db.conn, `UPDATE stock_locations SET archived_at_ms = 5, updated_at_ms = 5 WHERE id = 1`)
+}
+
+func TestPurchaseOrderSchemaTracksReceiptsWithoutTouchingStock(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	itemID := insertTestItem(t, db, "Flour", "flour", "g", true, false, false)
+	unsellableID := insertTestItem(t, db, "Cake", "cake", "g", false, true, true)
+	result, err := db.conn.Exec(`
+		INSERT INTO counterparties (name, created_at_ms, updated_at_ms)
+		VALUES ('Mill', 1, 1)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	supplierID, _ := result.LastInsertId()
+	expectExecError(t, db.conn, `
+		INSERT INTO purchase_orders (supplier_id, status, ordered_on, created_at_ms, updated_at_ms)
+		VALUES (?, 'DRAFT', '2026-07-14', 1, 1)
+	`, supplierID)
+	if _, err := db.conn.Exec(`
+		INSERT INTO counterparty_roles (counterparty_id, role, created_at_ms)
+		VALUES (?, 'SUPPLIER', 1)
+	`, supplierID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO purchase_orders (supplier_id, status, ordered_on, created_at_ms, updated_at_ms)
+		VALUES (?, 'SENT', '2026-07-14', 1, 1)
+	`, supplierID)
+	result, err = db.conn.Exec(`
+		INSERT INTO purchase_orders (supplier_id, status, ordered_on, created_at_ms, updated_at_ms)
+		VALUES (?, 'DRAFT', '2026-07-14', 1, 1)
+	`, supplierID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orderID, _ := result.LastInsertId()
+	insertOrderLine := func(item int64) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO purchase_order_lines (
+				order_id, line_order, item_id, quantity_atomic, entered_unit_code,
+				conversion_numerator_atomic, conversion_denominator, expected_total_minor
+			) VALUES (?, 1, ?, 1000, 'g', 1000, 1, 500)
+		`, orderID, item)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+	if _, err := insertOrderLine(unsellableID); err == nil {
+		t.Fatal("purchase order line accepted a non-purchasable item")
+	}
+	lineID, err := insertOrderLine(itemID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE purchase_order_lines SET received_quantity_atomic = 10 WHERE id = ?`, lineID)
+	expectExecError(t, db.conn, `UPDATE purchase_orders SET status = 'CLOSED', updated_at_ms = 2 WHERE id = ?`, orderID)
+	if _, err := db.conn.Exec(`UPDATE purchase_orders SET status = 'SENT', updated_at_ms = 2 WHERE id = ?`, orderID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE purchase_orders SET notes = 'late', updated_at_ms = 3 WHERE id = ?`, orderID)
+	expectExecError(t, db.conn, `UPDATE purchase_order_lines SET quantity_atomic = 2000 WHERE id = ?`, lineID)
+	expectExecError(t, db.conn, `DELETE FROM purchase_order_lines WHERE id = ?`, lineID)
+
+	anonymousID := insertTestDocument(t, db, "PURCHASE", 1, nil, nil, nil, "anonymous-purchase")
+	expectExecError(t, db.conn, `INSERT INTO purchase_order_receipts (document_id, order_id) VALUES (?, ?)`, anonymousID, orderID)
+	receiptID := insertTestDocument(t, db, "PURCHASE", 2, nil, nil, supplierID, "order-receipt")
+	if _, err := db.conn.Exec(`INSERT INTO purchase_order_receipts (document_id, order_id) VALUES (?, ?)`, receiptID, orderID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.conn.Exec(`UPDATE purchase_order_lines SET received_quantity_atomic = 400 WHERE id = ?`, lineID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE purchase_order_lines SET received_quantity_atomic = 300 WHERE id = ?`, lineID)
+	expectExecError(t, db.conn, `UPDATE purchase_order_lines SET received_quantity_atomic = 1001 WHERE id = ?`, lineID)
+	if _, err := db.conn.Exec(`UPDATE purchase_orders SET status = 'PARTIALLY_RECEIVED', updated_at_ms = 3 WHERE id = ?`, orderID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE purchase_orders SET status = 'CANCELLED', updated_at_ms = 4 WHERE id = ?`, orderID)
+	expectExecError(t, db.conn, `DELETE FROM purchase_order_receipts WHERE document_id = ?`, receiptID)
+	expectExecError(t, db.conn, `DELETE FROM purchase_orders WHERE id = ?`, orderID)
+}
+
+func TestCustomerOrderSchemaFulfilsOnlyThroughASaleToItsCustomer(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	cakeID := insertTestItem(t, db, "Cake", "cake", "g", false, true, true)
+	flourID := insertTestItem(t, db, "Flour", "flour", "g", true, false, false)
+	result, err := db.conn.Exec(`
+		INSERT INTO counterparties (name, created_at_ms, updated_at_ms)
+		VALUES ('Ana', 1, 1)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	customerID, _ := result.LastInsertId()
+	insertOrder := func(status string) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO customer_orders (
+				customer_id, status, ordered_on, due_on, deposit_minor, created_at_ms, updated_at_ms
+			) VALUES (?, ?, '2026-07-10', '2026-07-14', 2000, 1, 1)
+		`, customerID, status)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+	if _, err := insertOrder("OPEN"); err == nil {
+		t.Fatal("customer order accepted a counterparty without the customer role")
+	}
+	if _, err := db.conn.Exec(`
+		INSERT INTO counterparty_roles (counterparty_id, role, created_at_ms)
+		VALUES (?, 'CUSTOMER', 1)
+	`, customerID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertOrder("FULFILLED"); err == nil {
+		t.Fatal("customer order started fulfilled")
+	}
+	orderID, err := insertOrder("OPEN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO customer_orders (customer_id, status, ordered_on, due_on, created_at_ms, updated_at_ms)
+		VALUES (?, 'OPEN', '2026-07-10', '2026-07-09', 1, 1)
+	`, customerID)
+	insertOrderLine := func(item int64) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO customer_order_lines (
+				order_id, line_order, item_id, quantity_atomic, entered_unit_code,
+				conversion_numerator_atomic, conversion_denominator, agreed_total_minor
+			) VALUES (?, 1, ?, 1000, 'g', 1000, 1, 9000)
+		`, orderID, item)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+	if _, err := insertOrderLine(flourID); err == nil {
+		t.Fatal("customer order line accepted a non-sellable item")
+	}
+	lineID, err := insertOrderLine(cakeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE customer_order_lines SET quantity_atomic = 2000 WHERE id = ?`, lineID)
+	expectExecError(t, db.conn, `UPDATE customer_orders SET status = 'FULFILLED', updated_at_ms = 2 WHERE id = ?`, orderID)
+
+	anonymousSaleID := insertTestDocument(t, db, "SALE", 1, nil, nil, nil, "anonymous-sale")
+	expectExecError(t, db.conn, `
+		UPDATE customer_orders SET status = 'FULFILLED', sale_document_id = ?, updated_at_ms = 2 WHERE id = ?
+	`, anonymousSaleID, orderID)
+	saleID := insertTestDocument(t, db, "SALE", 2, nil, nil, customerID, "order-sale")
+	expectExecError(t, db.conn, `
+		UPDATE customer_orders SET status = 'FULFILLED', sale_document_id = ?, deposit_minor = 0, updated_at_ms = 2 WHERE id = ?
+	`, saleID, orderID)
+	if _, err := db.conn.Exec(`
+		UPDATE customer_orders SET status = 'FULFILLED', sale_document_id = ?, updated_at_ms = 2 WHERE id = ?
+	`, saleID, orderID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE customer_orders SET notes = 'late', updated_at_ms = 3 WHERE id = ?`, orderID)
+	expectExecError(t, db.conn, `DELETE FROM customer_order_lines WHERE id = ?`, lineID)
+	expectExecError(t, db.conn, `DELETE FROM customer_orders WHERE id = ?`, orderID)
+}
+
+func TestDraftSchemaRejectsInvalidPayloadsAndPostedDraftIDs(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	insertDraft := func(kind, id, payload string) error {
+		_, err := db.conn.Exec(`
+			INSERT INTO drafts (kind, draft_id, payload_json, created_at_ms, updated_at_ms)
+			VALUES (?, ?, ?, 1, 1)
+		`, kind, id, payload)
+		return err
+	}
+	if err := insertDraft("TRANSFER", "draft-1", `{}`); err == nil {
+		t.Fatal("draft accepted a kind without a posting form")
+	}
+	if err := insertDraft("PURCHASE", " draft-1", `{}`); err == nil {
+		t.Fatal("draft accepted an untrimmed id")
+	}
+	if err := insertDraft("PURCHASE", "draft-1", `{"lines": [`); err == nil {
+		t.Fatal("draft accepted malformed JSON")
+	}
+	if err := insertDraft("PURCHASE", "draft-1", `[]`); err == nil {
+		t.Fatal("draft accepted a payload that is not an object")
+	}
+	if err := insertDraft("PURCHASE", "draft-1", `{"lines": []}`); err != nil {
+		t.Fatal(err)
+	}
+	if err := insertDraft("PURCHASE", "draft-1", `{}`); err == nil {
+		t.Fatal("draft accepted a duplicate kind and id")
+	}
+	expectExecError(t, db.conn, `UPDATE drafts SET kind = 'SALE' WHERE draft_id = 'draft-1'`)
+	expectExecError(t, db.conn, `UPDATE drafts SET payload_json = '{}', updated_at_ms = 0 WHERE draft_id = 'draft-1'`)
+	if _, err := db.conn.Exec(`UPDATE drafts SET payload_json = '{}', updated_at_ms = 2 WHERE draft_id = 'draft-1'`); err != nil {
+		t.Fatal(err)
+	}
+
+	insertTestDocument(t, db, "PURCHASE", 1, nil, nil, nil, "draft-1")
+	expectExecError(t, db.conn, `UPDATE drafts SET updated_at_ms = 3 WHERE draft_id = 'draft-1'`)
+	if err := insertDraft("SALE", "draft-1", `{}`); err == nil {
+		t.Fatal("draft reused the idempotency key of a posted document")
+	}
+	if _, err := db.conn.Exec(`DELETE FROM drafts WHERE draft_id = 'draft-1'`); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStockCountSchemaFreezesLinesAndClosesWithItsOwnAdjustment(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	flourID := insertTestItem(t, db, "Flour", "flour", "g", true, false, false)
+	sugarID := insertTestItem(t, db, "Sugar", "sugar", "g", true, false, false)
+	purchaseID := insertTestDocument(t, db, "PURCHASE", 1, nil, nil, nil, "purchase-1")
+	sourceLineID := insertTestLine(t, db, purchaseID, 1, flourID, "IN", 1000, "g", 1000, 1, nil)
+	result, err := db.conn.Exec(`
+		INSERT INTO inventory_lots (
+			item_id, source_line_id, initial_quantity_atomic, originated_on, created_at_ms
+		) VALUES (?, ?, 1000, '2026-07-14', 1)
+	`, flourID, sourceLineID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lotID, _ := result.LastInsertId()
+
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_counts (status, counted_on, frozen_posting_sequence, created_at_ms, updated_at_ms)
+		VALUES ('OPEN', '2026-10-18', 2, 1, 1)
+	`)
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_counts (status, counted_on, frozen_posting_sequence, closing_idempotency_key, created_at_ms, updated_at_ms)
+		VALUES ('CLOSED', '2026-10-18', 1, 'count-1', 1, 1)
+	`)
+	if _, err := db.conn.Exec(`
+		INSERT INTO stock_counts (id, status, counted_on, frozen_posting_sequence, created_at_ms, updated_at_ms)
+		VALUES (1, 'OPEN', '2026-10-18', 1, 1, 1)
+	`); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_counts (status, counted_on, frozen_posting_sequence, created_at_ms, updated_at_ms)
+		VALUES ('OPEN', '2026-10-18', 1, 1, 1)
+	`)
+
+	if _, err := db.conn.Exec(`
+		INSERT INTO stock_count_lines (count_id, item_id, lot_id, expected_quantity_atomic, expected_value_micro)
+		VALUES (1, ?, ?, 1000, 1000)
+	`, flourID, lotID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_count_lines (count_id, item_id, lot_id, expected_quantity_atomic, expected_value_micro)
+		VALUES (1, ?, NULL, 1000, 1000)
+	`, flourID)
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_count_lines (count_id, item_id, lot_id, expected_quantity_atomic, expected_value_micro)
+		VALUES (1, ?, ?, 1000, 1000)
+	`, sugarID, lotID)
+	expectExecError(t, db.conn, `
+		INSERT INTO stock_count_lines (count_id, item_id, expected_quantity_atomic, expected_value_micro)
+		VALUES (1, ?, 0, 5)
+	`, sugarID)
+	if _, err := db.conn.Exec(`
+		INSERT INTO stock_count_lines (count_id, item_id, expected_quantity_atomic, expected_value_micro)
+		VALUES (1, ?, 0, 0)
+	`, sugarID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE stock_count_lines SET expected_quantity_atomic = 900 WHERE lot_id = ?`, lotID)
+	if _, err := db.conn.Exec(`UPDATE stock_count_lines SET observed_quantity_atomic = 900 WHERE lot_id = ?`, lotID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `DELETE FROM stock_count_lines WHERE item_id = ?`, sugarID)
+
+	wasteID := insertTestDocument(t, db, "ADJUSTMENT", 2, "WASTE", nil, nil, "count-1")
+	expectExecError(t, db.conn, `
+		UPDATE stock_counts SET status = 'CLOSED', closing_idempotency_key = 'count-1',
+		       adjustment_document_id = ?, updated_at_ms = 2
+		WHERE id = 1
+	`, wasteID)
+	countAdjustmentID := insertTestDocument(t, db, "ADJUSTMENT", 3, "PHYSICAL_COUNT", nil, nil, "count-2")
+	expectExecError(t, db.conn, `UPDATE stock_counts SET frozen_posting_sequence = 0, updated_at_ms = 2 WHERE id = 1`)
+	if _, err := db.conn.Exec(`
+		UPDATE stock_counts SET status = 'CLOSED', closing_idempotency_key = 'count-2',
+		       adjustment_document_id = ?, updated_at_ms = 2
+		WHERE id = 1
+	`, countAdjustmentID); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE stock_counts SET notes = 'late', updated_at_ms = 3 WHERE id = 1`)
+	expectExecError(t, db.conn, `UPDATE stock_count_lines SET observed_quantity_atomic = 950 WHERE lot_id = ?`, lotID)
+	expectExecError(t, db.conn, `DELETE FROM stock_counts WHERE id = 1`)
+}
+
+func TestSalePaymentSchemaBoundsPaymentsByTheOutstandingBalance(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	cakeID := insertTestItem(t, db, "Cake", "cake", "g", false, true, true)
+	result, err := db.conn.Exec(`
+		INSERT INTO counterparties (name, created_at_ms, updated_at_ms)
+		VALUES ('Ana', 1, 1)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	customerID, _ := result.LastInsertId()
+	if _, err := db.conn.Exec(`
+		INSERT INTO counterparty_roles (counterparty_id, role, created_at_ms)
+		VALUES (?, 'CUSTOMER', 1)
+	`, customerID); err != nil {
+		t.Fatal(err)
+	}
+	purchaseID := insertTestDocument(t, db, "PURCHASE", 1, nil, nil, nil, "purchase-1")
+	anonymousSaleID := insertTestDocument(t, db, "SALE", 2, nil, nil, nil, "anonymous-sale")
+	insertTestLine(t, db, anonymousSaleID, 1, cakeID, "OUT", 100, "g", 1000, 5000, nil)
+	saleID := insertTestDocument(t, db, "SALE", 3, nil, nil, customerID, "customer-sale")
+	insertTestLine(t, db, saleID, 1, cakeID, "OUT", 100, "g", 1000, 9000, nil)
+	insertPayment := func(key string, sale int64, method string, amount int64, receivedOn string, reverses any) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO sale_payments (
+				idempotency_key, sale_document_id, method, amount_minor, received_on,
+				reverses_payment_id, recorded_at_ms
+			) VALUES (?, ?, ?, ?, ?, ?, 1)
+		`, key, sale, method, amount, receivedOn, reverses)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+
+	if _, err := insertPayment("payment-1", purchaseID, "CASH", 100, "2026-07-14", nil); err == nil {
+		t.Fatal("payment accepted a purchase")
+	}
+	if _, err := insertPayment("payment-1", saleID, "CASH", 100, "2026-07-13", nil); err == nil {
+		t.Fatal("payment accepted a date before its sale")
+	}
+	if _, err := insertPayment("payment-1", saleID, "CHEQUE", 100, "2026-07-14", nil); err == nil {
+		t.Fatal("payment accepted an unknown method")
+	}
+	if _, err := insertPayment("payment-1", anonymousSaleID, "ON_ACCOUNT", 100, "2026-07-14", nil); err == nil {
+		t.Fatal("on-account payment accepted a sale without a customer")
+	}
+	cashID, err := insertPayment("payment-1", saleID, "CASH", 4000, "2026-07-14", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertPayment("payment-2", saleID, "PIX", 5001, "2026-07-14", nil); err == nil {
+		t.Fatal("payment exceeded the outstanding balance")
+	}
+	if _, err := insertPayment("payment-2", saleID, "ON_ACCOUNT", 5000, "2026-07-14", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertPayment("payment-3", saleID, "ON_ACCOUNT", 1, "2026-07-14", nil); err == nil {
+		t.Fatal("on-account entries exceeded the outstanding balance")
+	}
+	if _, err := insertPayment("payment-3", saleID, "PIX", 5000, "2026-07-20", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := insertPayment("reversal-1", saleID, "CASH", 3999, "2026-07-20", cashID); err == nil {
+		t.Fatal("payment reversal changed the amount")
+	}
+	if _, err := insertPayment("reversal-1", saleID, "PIX", 4000, "2026-07-20", cashID); err == nil {
+		t.Fatal("payment reversal changed the method")
+	}
+	if _, err := insertPayment("reversal-1", saleID, "CASH", 4000, "2026-07-13", cashID); err == nil {
+		t.Fatal("payment reversal preceded its payment")
+	}
+	reversalID, err := insertPayment("reversal-1", saleID, "CASH", 4000, "2026-07-20", cashID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertPayment("reversal-2", saleID, "CASH", 4000, "2026-07-20", cashID); err == nil {
+		t.Fatal("payment was reversed twice")
+	}
+	if _, err := insertPayment("reversal-2", saleID, "CASH", 4000, "2026-07-20", reversalID); err == nil {
+		t.Fatal("payment reversal was reversed")
+	}
+	if _, err := insertPayment("payment-4", saleID, "CARD", 4000, "2026-07-20", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	insertTestDocument(t, db, "REVERSAL", 4, "EXACT_REVERSAL", anonymousSaleID, nil, "reverse-anonymous")
+	if _, err := insertPayment("payment-5", anonymousSaleID, "CASH", 100, "2026-07-14", nil); err == nil {
+		t.Fatal("payment accepted a reversed sale")
+	}
+	expectExecError(t, db.conn, `UPDATE sale_payments SET amount_minor = 1 WHERE id = ?`, cashID)
+	expectExecError(t, db.conn, `DELETE FROM sale_payments WHERE id = ?`, cashID)
+}
+
+func TestPurchasePayableSchemaSealsInstallmentsAtThePurchaseTotal(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	flourID := insertTestItem(t, db, "Flour", "flour", "g", true, false, false)
+	result, err := db.conn.Exec(`
+		INSERT INTO counterparties (name, created_at_ms, updated_at_ms)
+		VALUES ('Mill', 1, 1)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	supplierID, _ := result.LastInsertId()
+	if _, err := db.conn.Exec(`
+		INSERT INTO counterparty_roles (counterparty_id, role, created_at_ms)
+		VALUES (?, 'SUPPLIER', 1)
+	`, supplierID); err != nil {
+		t.Fatal(err)
+	}
+	anonymousID := insertTestDocument(t, db, "PURCHASE", 1, nil, nil, nil, "anonymous-purchase")
+	insertTestLine(t, db, anonymousID, 1, flourID, "IN", 100, "g", 1000, 500, nil)
+	purchaseID := insertTestDocument(t, db, "PURCHASE", 2, nil, nil, supplierID, "supplier-purchase")
+	insertTestLine(t, db, purchaseID, 1, flourID, "IN", 100, "g", 1000, 9000, nil)
+	insertInstallment := func(purchase int64, number int, dueOn string, amount int64) error {
+		_, err := db.conn.Exec(`
+			INSERT INTO purchase_payable_installments (
+				purchase_document_id, installment_number, due_on, amount_minor
+			) VALUES (?, ?, ?, ?)
+		`, purchase, number, dueOn, amount)
+		return err
+	}
+	sealPayable := func(purchase int64, count int, total int64) error {
+		_, err := db.conn.Exec(`
+			INSERT INTO purchase_payables (purchase_document_id, installment_count, total_minor, created_at_ms)
+			VALUES (?, ?, ?, 1)
+		`, purchase, count, total)
+		return err
+	}
+	insertPayment := func(key, method string, amount int64, paidOn string, reverses any) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO supplier_payments (
+				idempotency_key, purchase_document_id, method, amount_minor, paid_on,
+				reverses_payment_id, recorded_at_ms
+			) VALUES (?, ?, ?, ?, ?, ?, 1)
+		`, key, purchaseID, method, amount, paidOn, reverses)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+
+	if err := insertInstallment(anonymousID, 1, "2026-07-20", 500); err == nil {
+		t.Fatal("installment accepted a purchase without a supplier")
+	}
+	if err := insertInstallment(purchaseID, 1, "2026-07-13", 4000); err == nil {
+		t.Fatal("installment accepted a due date before its purchase")
+	}
+	if err := insertInstallment(purchaseID, 2, "2026-07-20", 4000); err == nil {
+		t.Fatal("installment skipped a number")
+	}
+	if err := insertInstallment(purchaseID, 1, "2026-07-20", 4000); err != nil {
+		t.Fatal(err)
+	}
+	if err := insertInstallment(purchaseID, 2, "2026-07-19", 4000); err == nil {
+		t.Fatal("installment was due before the previous one")
+	}
+	if err := insertInstallment(purchaseID, 2, "2026-08-20", 5001); err == nil {
+		t.Fatal("installments exceeded the purchase total")
+	}
+	if err := insertInstallment(purchaseID, 2, "2026-08-20", 4000); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertPayment("supplier-payment-1", "PIX", 100, "2026-07-20", nil); err == nil {
+		t.Fatal("supplier payment accepted an unsealed payable")
+	}
+	if err := sealPayable(purchaseID, 2, 8000); err == nil {
+		t.Fatal("payable was sealed below the purchase total")
+	}
+	if err := insertInstallment(purchaseID, 3, "2026-08-20", 1000); err != nil {
+		t.Fatal(err)
+	}
+	if err := sealPayable(purchaseID, 2, 9000); err == nil {
+		t.Fatal("payable was sealed with the wrong installment count")
+	}
+	if err := sealPayable(purchaseID, 3, 9000); err != nil {
+		t.Fatal(err)
+	}
+	if err := insertInstallment(purchaseID, 4, "2026-09-20", 1); err == nil {
+		t.Fatal("installment was added to a sealed payable")
+	}
+
+	if _, err := insertPayment("supplier-payment-1", "ON_ACCOUNT", 100, "2026-07-20", nil); err == nil {
+		t.Fatal("supplier payment accepted an on-account method")
+	}
+	if _, err := insertPayment("supplier-payment-1", "PIX", 100, "2026-07-13", nil); err == nil {
+		t.Fatal("supplier payment accepted a date before its purchase")
+	}
+	pixID, err := insertPayment("supplier-payment-1", "PIX", 4000, "2026-07-20", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertPayment("supplier-payment-2", "CASH", 5001, "2026-07-20", nil); err == nil {
+		t.Fatal("supplier payment exceeded the outstanding payable")
+	}
+	if _, err := insertPayment("supplier-reversal-1", "PIX", 3999, "2026-07-20", pixID); err == nil {
+		t.Fatal("supplier payment reversal changed the amount")
+	}
+	reversalID, err := insertPayment("supplier-reversal-1", "PIX", 4000, "2026-07-21", pixID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertPayment("supplier-reversal-2", "PIX", 4000, "2026-07-21", reversalID); err == nil {
+		t.Fatal("supplier payment reversal was reversed")
+	}
+	if _, err := insertPayment("supplier-payment-2", "CASH", 8000, "2026-07-21", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	insertTestDocument(t, db, "REVERSAL", 3, "EXACT_REVERSAL", purchaseID, nil, "reverse-purchase")
+	if _, err := insertPayment("supplier-payment-3", "CASH", 1, "2026-07-22", nil); err == nil {
+		t.Fatal("supplier payment accepted a reversed purchase")
+	}
+	expectExecError(t, db.conn, `UPDATE purchase_payable_installments SET amount_minor = 1 WHERE purchase_document_id = ?`, purchaseID)
+	expectExecError(t, db.conn, `DELETE FROM purchase_payables WHERE purchase_document_id = ?`, purchaseID)
+	expectExecError(t, db.conn, `DELETE FROM supplier_payments WHERE id = ?`, pixID)
+}
+
+func TestRegisterSessionSchemaLinksPaymentsAndChecksTheClosing(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	cakeID := insertTestItem(t, db, "Cake", "cake", "g", false, true, true)
+	saleID := insertTestDocument(t, db, "SALE", 1, nil, nil, nil, "register-sale")
+	insertTestLine(t, db, saleID, 1, cakeID, "OUT", 100, "g", 1000, 9000, nil)
+	insertPayment := func(key, method string, amount int64, reverses any) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO sale_payments (
+				idempotency_key, sale_document_id, method, amount_minor, received_on,
+				reverses_payment_id, recorded_at_ms
+			) VALUES (?, ?, ?, ?, '2026-07-14', ?, 20)
+		`, key, saleID, method, amount, reverses)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+	openSession := func(key string, openedAt int64) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO register_sessions (idempotency_key, business_date, opening_float_minor, opened_at_ms)
+			VALUES (?, '2026-07-14', 1000, ?)
+		`, key, openedAt)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+	insertMovement := func(key string, session int64, kind string, amount, recordedAt int64) error {
+		_, err := db.conn.Exec(`
+			INSERT INTO register_cash_movements (
+				idempotency_key, register_session_id, kind, amount_minor, notes, recorded_at_ms
+			) VALUES (?, ?, ?, ?, 'Change', ?)
+		`, key, session, kind, amount, recordedAt)
+		return err
+	}
+	closeSession := func(session, cash, pix, cashIn, cashOut, expected int64, closedAt int64) error {
+		_, err := db.conn.Exec(`
+			INSERT INTO register_closings (
+				register_session_id, closed_on, cash_minor, pix_minor, card_minor, on_account_minor,
+				cash_in_minor, cash_out_minor, expected_cash_minor, counted_cash_minor, closed_at_ms
+			) VALUES (?, '2026-07-14', ?, ?, 0, 0, ?, ?, ?, 3000, ?)
+		`, session, cash, pix, cashIn, cashOut, expected, closedAt)
+		return err
+	}
+
+	unlinkedID, err := insertPayment("payment-0", "CASH", 100, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sessionID, err := openSession("session-1", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := openSession("session-2", 11); err == nil {
+		t.Fatal("a second register session opened")
+	}
+	cashID, err := insertPayment("payment-1", "CASH", 2500, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertPayment("payment-2", "PIX", 700, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertPayment("reversal-0", "CASH", 100, unlinkedID); err != nil {
+		t.Fatal(err)
+	}
+	var linked int
+	if err := db.conn.QueryRow(`
+		SELECT COUNT(*) FROM register_session_payments WHERE register_session_id = ?
+	`, sessionID).Scan(&linked); err != nil || linked != 3 {
+		t.Fatalf("linked payments = %d, %v", linked, err)
+	}
+	if err := insertMovement("movement-1", sessionID, "CASH_IN", 500, 9); err == nil {
+		t.Fatal("cash movement preceded its session")
+	}
+	if err := insertMovement("movement-1", sessionID, "REFUND", 500, 30); err == nil {
+		t.Fatal("cash movement accepted an unknown kind")
+	}
+	if err := insertMovement("movement-1", sessionID, "CASH_IN", 500, 30); err != nil {
+		t.Fatal(err)
+	}
+	if err := insertMovement("movement-2", sessionID, "CASH_OUT", 200, 30); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := closeSession(sessionID, 2500, 700, 500, 200, 3800, 40); err == nil {
+		t.Fatal("closing ignored a reversal recorded in the session")
+	}
+	if err := closeSession(sessionID, 2400, 700, 500, 200, 3600, 40); err == nil {
+		t.Fatal("closing misstated the expected cash")
+	}
+	if err := closeSession(sessionID, 2400, 700, 500, 200, 3700, 9); err == nil {
+		t.Fatal("closing preceded its session")
+	}
+	if err := closeSession(sessionID, 2400, 700, 500, 200, 3700, 40); err != nil {
+		t.Fatal(err)
+	}
+	if err := insertMovement("movement-3", sessionID, "CASH_OUT", 100, 50); err == nil {
+		t.Fatal("cash movement was recorded on a closed session")
+	}
+	if _, err := insertPayment("reversal-1", "CASH", 2500, cashID); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.conn.QueryRow(`SELECT COUNT(*) FROM register_session_payments`).Scan(&linked); err != nil || linked != 3 {
+		t.Fatalf("payments linked after closing = %d, %v", linked, err)
+	}
+	if _, err := openSession("session-2", 39); err == nil {
+		t.Fatal("register session opened before the previous one closed")
+	}
+	if _, err := openSession("session-2", 40); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE register_closings SET counted_cash_minor = 3600 WHERE register_session_id = ?`, sessionID)
+	expectExecError(t, db.conn, `DELETE FROM register_session_payments WHERE sale_payment_id = ?`, cashID)
+	expectExecError(t, db.conn, `DELETE FROM register_sessions WHERE id = ?`, sessionID)
+}
+
+func TestExpenseSchemaAcceptsOneCorrectionPerExpense(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	result, err := db.conn.Exec(`
+		INSERT INTO counterparties (name, created_at_ms, updated_at_ms)
+		VALUES ('Landlord', 1, 1)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	landlordID, _ := result.LastInsertId()
+	insertExpense := func(key string, amount int64, supplier, corrects any, recordedAt int64) (int64, error) {
+		result, err := db.conn.Exec(`
+			INSERT INTO expenses (
+				idempotency_key, category, amount_minor, occurred_on, supplier_id,
+				corrects_expense_id, recorded_at_ms
+			) VALUES (?, 'RENT', ?, '2026-07-01', ?, ?, ?)
+		`, key, amount, supplier, corrects, recordedAt)
+		if err != nil {
+			return 0, err
+		}
+		return result.LastInsertId()
+	}
+
+	if _, err := insertExpense("rent-0", 0, nil, nil, 10); err == nil {
+		t.Fatal("a zero expense was recorded without correcting another")
+	}
+	if _, err := insertExpense("rent-supplier", 150_000, landlordID, nil, 10); err == nil {
+		t.Fatal("an expense named a counterparty without the supplier role")
+	}
+	if _, err := db.conn.Exec(`
+		INSERT INTO counterparty_roles (counterparty_id, role, created_at_ms)
+		VALUES (?, 'SUPPLIER', 1)
+	`, landlordID); err != nil {
+		t.Fatal(err)
+	}
+	rentID, err := insertExpense("rent-1", 150_000, landlordID, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertExpense("rent-early", 140_000, nil, rentID, 9); err == nil {
+		t.Fatal("a correction was recorded before the expense it corrects")
+	}
+	correctionID, err := insertExpense("rent-2", 140_000, landlordID, rentID, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := insertExpense("rent-3", 130_000, nil, rentID, 30); err == nil {
+		t.Fatal("an expense was corrected twice")
+	}
+	if _, err := insertExpense("rent-void", 0, nil, correctionID, 30); err != nil {
+		t.Fatalf("void correction: %v", err)
+	}
+	expectExecError(t, db.conn, `UPDATE expenses SET amount_minor = 1 WHERE id = ?`, rentID)
+	expectExecError(t, db.conn, `DELETE FROM expenses WHERE id = ?`, rentID)
+}
+
+func TestPriceListSchemaChecksTiersAndCustomerAssignments(t *testing.T) {
+	db := openSchemaTestDatabase(t)
+	flourID := insertTestItem(t, db, "Flour", "flour", "g", true, false, true)
+	sugarID := insertTestItem(t, db, "Sugar", "sugar", "g", true, false, true)
+	result, err := db.conn.Exec(`
+		INSERT INTO item_packagings (
+			item_id, name, normalized_name, entered_unit_code,
+			conversion_numerator_atomic, conversion_denominator,
+			created_at_ms, updated_at_ms
+		) VALUES (?, 'Sugar bag', 'sugar bag', 'kg', 1000000, 1, 1, 1)
+	`, sugarID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sugarBagID, _ := result.LastInsertId()
+	if _, err := db.conn.Exec(`
+		INSERT INTO price_lists (name, normalized_name, valid_from, valid_to, created_at_ms, updated_at_ms)
+		VALUES ('Backwards', 'backwards', '2026-10-31', '2026-10-01', 1, 1)
+	`); err == nil {
+		t.Fatal("a price list ended before it started")
+	}
+	result, err = db.conn.Exec(`
+		INSERT INTO price_lists (name, normalized_name, valid_from, created_at_ms, updated_at_ms)
+		VALUES ('Cafés', 'cafés', '2026-10-01', 1, 1)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listID, _ := result.LastInsertId()
+	insertPrice := func(itemID int64, packagingID any, minQuantity int64) error {
+		_, err := db.conn.Exec(`
+			INSERT INTO price_list_prices (
+				price_list_id, item_id, packaging_id, min_quantity_atomic, unit_price_minor
+			) VALUES (?, ?, ?, ?, 5)
+		`, listID, itemID, packagingID, minQuantity)
+		return err
+	}
+	if err := insertPrice(flourID, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := insertPrice(flourID, nil, 0); err == nil {
+		t.Fatal("a tier was priced twice")
+	}
+	if err := insertPrice(flourID, sugarBagID, 0); err == nil {
+		t.Fatal("a price named another item's packaging")
+	}
+	if err := insertPrice(sugarID, sugarBagID, 0); err != nil {
+		t.Fatal(err)
+	}
+	expectExecError(t, db.conn, `UPDATE price_list_prices SET unit_price_minor = 6`)
+
+	result, err = db.conn.Exec(`
+		INSERT INTO counterparties (name, created_at_ms, updated_at_ms)
+		VALUES ('Café', 1, 1)
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cafeID, _ := result.LastInsertId()
+	assign := `INSERT INTO customer_price_lists (customer_id, price_list_id, assigned_at_ms) VALUES (?, ?, 2)`
+	expectExecError(t, db.conn, assign, cafeID, listID)
+	if _, err := db.conn.Exec(`
+		INSERT INTO counterparty_roles (counterparty_id, role, created_at_ms)
+		VALUES (?, 'CUSTOMER', 1)
+	`, cafeID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.conn.Exec(assign, cafeID, listID); err != nil {
+		t.Fatalf("assign price list: %v", err)
+	}
+	expectExecError(t, db.conn, assign, cafeID, listID)
+	expectExecError(t, db.conn, `UPDATE price_lists SET archived_at_ms = 3, updated_at_ms = 3 WHERE id = ?`, listID)
+	if _, err := db.conn.Exec(`DELETE FROM customer_price_lists WHERE customer_id = ?`, cafeID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.conn.Exec(`UPDATE price_lists SET archived_at_ms = 3, updated_at_ms = 3 WHERE id = ?`, listID); err != nil {
+		t.Fatalf("archive unassigned price list: %v", err)
+	}
+	expectExecError(t, db.conn, assign, cafeID, listID)
+	expectExecError(t, db.conn, `DELETE FROM price_lists WHERE id = ?`, listID)
+}
+
 func TestLotAllocationCannotConsumeALaterPostingLot(t *testing.T) {
 	db := openSchemaTestDatabase(t)
 	itemID := insertTestItem(t, db, "Cream", "cream", "ml", true, false, true)
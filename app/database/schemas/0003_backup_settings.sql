@@ -0,0 +1,19 @@
+-- Automatic backup policy lives in the typed settings row so it is restored,
+-- exported, and versioned together with the rest of the business settings.
+-- Defaults take a daily backup and keep one week of dailies plus a month of
+-- weeklies.
+ALTER TABLE app_settings
+    ADD COLUMN backup_enabled INTEGER NOT NULL DEFAULT 1
+    CHECK (backup_enabled IN (0, 1));
+
+ALTER TABLE app_settings
+    ADD COLUMN backup_interval_minutes INTEGER NOT NULL DEFAULT 1440
+    CHECK (backup_interval_minutes BETWEEN 15 AND 10080);
+
+ALTER TABLE app_settings
+    ADD COLUMN backup_keep_daily INTEGER NOT NULL DEFAULT 7
+    CHECK (backup_keep_daily BETWEEN 1 AND 366);
+
+ALTER TABLE app_settings
+    ADD COLUMN backup_keep_weekly INTEGER NOT NULL DEFAULT 4
+    CHECK (backup_keep_weekly BETWEEN 0 AND 520);
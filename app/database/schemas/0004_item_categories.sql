@@ -0,0 +1,82 @@
+CREATE TABLE item_categories (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    normalized_name TEXT NOT NULL UNIQUE CHECK (length(trim(normalized_name)) > 0),
+    created_at_ms INTEGER NOT NULL CHECK (created_at_ms >= 0),
+    updated_at_ms INTEGER NOT NULL CHECK (updated_at_ms >= created_at_ms),
+    archived_at_ms INTEGER CHECK (archived_at_ms >= updated_at_ms)
+) STRICT;
+
+ALTER TABLE items ADD COLUMN category_id INTEGER REFERENCES item_categories(id)
+    ON UPDATE RESTRICT ON DELETE RESTRICT;
+
+CREATE INDEX item_categories_active_name
+    ON item_categories (archived_at_ms, normalized_name);
+CREATE INDEX items_category
+    ON items (category_id) WHERE category_id IS NOT NULL;
+
+CREATE TRIGGER item_categories_no_delete
+BEFORE DELETE ON item_categories
+BEGIN
+    SELECT RAISE(ABORT, 'item categories must be archived, not deleted');
+END;
+
+CREATE TRIGGER item_categories_archive_version_insert
+BEFORE INSERT ON item_categories
+WHEN NEW.archived_at_ms IS NOT NULL
+ AND NEW.archived_at_ms <> NEW.updated_at_ms
+BEGIN
+    SELECT RAISE(ABORT, 'item category archive timestamp must equal its optimistic version');
+END;
+
+CREATE TRIGGER item_categories_archive_version_update
+BEFORE UPDATE OF archived_at_ms, updated_at_ms ON item_categories
+WHEN NEW.archived_at_ms IS NOT NULL
+ AND NEW.archived_at_ms <> NEW.updated_at_ms
+BEGIN
+    SELECT RAISE(ABORT, 'item category archive timestamp must equal its optimistic version');
+END;
+
+-- An active item never points at an archived category, so archiving a
+-- category requires moving or archiving its active items first, and an item
+-- cannot be assigned to or restored into an archived category.
+CREATE TRIGGER item_categories_preserve_active_items
+BEFORE UPDATE OF archived_at_ms ON item_categories
+WHEN NEW.archived_at_ms IS NOT NULL
+ AND EXISTS (
+    SELECT 1
+    FROM items item
+    WHERE item.category_id = OLD.id
+      AND item.archived_at_ms IS NULL
+ )
+BEGIN
+    SELECT RAISE(ABORT, 'category with active items must remain active');
+END;
+
+CREATE TRIGGER items_validate_category_insert
+BEFORE INSERT ON items
+WHEN NEW.category_id IS NOT NULL
+ AND NEW.archived_at_ms IS NULL
+ AND NOT EXISTS (
+    SELECT 1
+    FROM item_categories category
+    WHERE category.id = NEW.category_id
+      AND category.archived_at_ms IS NULL
+ )
+BEGIN
+    SELECT RAISE(ABORT, 'active item category must be active');
+END;
+
+CREATE TRIGGER items_validate_category_update
+BEFORE UPDATE OF category_id, archived_at_ms ON items
+WHEN NEW.category_id IS NOT NULL
+ AND NEW.archived_at_ms IS NULL
+ AND NOT EXISTS (
+    SELECT 1
+    FROM item_categories category
+    WHERE category.id = NEW.category_id
+      AND category.archived_at_ms IS NULL
+ )
+BEGIN
+    SELECT RAISE(ABORT, 'active item category must be active');
+END;
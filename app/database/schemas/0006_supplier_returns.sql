@@ -0,0 +1,378 @@
+-- Supplier returns reuse the RETURN document kind with the reason
+-- SUPPLIER_RETURN. They reference an unreversed purchase, and each OUT line
+-- consumes the lot created by one purchase line. Only triggers change, so
+-- no table is rebuilt.
+--
+-- A supplier return removes the purchase line's unit cost unless it empties
+-- the item, in which case it removes the remaining balance value. Its value
+-- is therefore not bounded by the purchase line; quantity and credited total
+-- still are.
+
+DROP TRIGGER stock_documents_validate_insert;
+
+CREATE TRIGGER stock_documents_validate_insert
+BEFORE INSERT ON stock_documents
+BEGIN
+    SELECT CASE
+        WHEN NOT (
+            (NEW.kind = 'PURCHASE' AND COALESCE(NEW.reason_code, 'FREE_STOCK') = 'FREE_STOCK')
+            OR (NEW.kind = 'SALE' AND COALESCE(NEW.reason_code, 'PROMOTION') IN ('PROMOTION', 'SAMPLE'))
+            OR (NEW.kind = 'PRODUCTION' AND NEW.reason_code IS NULL)
+            OR (NEW.kind = 'ADJUSTMENT' AND COALESCE(NEW.reason_code, '') IN (
+                'OPENING_BALANCE',
+                'FREE_STOCK',
+                'PHYSICAL_COUNT',
+                'WASTE',
+                'EXPIRY',
+                'DAMAGE',
+                'SAMPLE',
+                'DOCUMENTED_CORRECTION'
+            ))
+            OR (NEW.kind = 'REVERSAL' AND NEW.reason_code IS 'EXACT_REVERSAL')
+            OR (NEW.kind = 'RETURN' AND COALESCE(NEW.reason_code, '') IN (
+                'CUSTOMER_RETURN',
+                'SUPPLIER_RETURN'
+            ))
+        )
+        THEN RAISE(ABORT, 'document reason does not match its kind')
+    END;
+    SELECT CASE
+        WHEN NEW.posting_sequence <= COALESCE((SELECT MAX(posting_sequence) FROM stock_documents), 0)
+        THEN RAISE(ABORT, 'posting sequence must increase monotonically')
+    END;
+    SELECT CASE
+        WHEN NEW.currency_code <> (SELECT currency_code FROM app_settings WHERE id = 1)
+          OR NEW.currency_minor_digits <> (
+              SELECT currency_minor_digits FROM app_settings WHERE id = 1
+          )
+        THEN RAISE(ABORT, 'document currency must match application settings')
+    END;
+    SELECT CASE
+        WHEN NEW.counterparty_id IS NOT NULL
+         AND NEW.kind NOT IN ('PURCHASE', 'SALE', 'RETURN')
+        THEN RAISE(ABORT, 'counterparty is not eligible for this document kind')
+    END;
+    SELECT CASE
+        WHEN NEW.counterparty_id IS NOT NULL
+         AND NEW.kind IN ('PURCHASE', 'SALE')
+         AND NOT EXISTS (
+             SELECT 1
+             FROM counterparties counterparty
+             JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+             WHERE counterparty.id = NEW.counterparty_id
+               AND counterparty.archived_at_ms IS NULL
+               AND role.role = CASE NEW.kind
+                   WHEN 'PURCHASE' THEN 'SUPPLIER'
+                   WHEN 'SALE' THEN 'CUSTOMER'
+               END
+         )
+        THEN RAISE(ABORT, 'counterparty is not eligible for this document kind')
+    END;
+    SELECT CASE
+        WHEN NEW.kind = 'REVERSAL'
+         AND NOT EXISTS (
+             SELECT 1 FROM stock_documents target
+             WHERE target.id = NEW.reverses_document_id
+               AND target.kind NOT IN ('REVERSAL', 'RETURN')
+         )
+        THEN RAISE(ABORT, 'a reversal must target a non-reversal, non-return document')
+    END;
+    SELECT CASE
+        WHEN NEW.kind = 'RETURN'
+         AND NOT EXISTS (
+             SELECT 1 FROM stock_documents target
+             WHERE target.id = NEW.returns_document_id
+               AND target.kind = CASE NEW.reason_code
+                   WHEN 'CUSTOMER_RETURN' THEN 'SALE'
+                   WHEN 'SUPPLIER_RETURN' THEN 'PURCHASE'
+               END
+               AND target.counterparty_id IS NEW.counterparty_id
+               AND NOT EXISTS (
+                   SELECT 1 FROM stock_documents reversal
+                   WHERE reversal.reverses_document_id = target.id
+               )
+         )
+        THEN RAISE(ABORT, 'a return must reference an unreversed sale or purchase and keep its counterparty')
+    END;
+END;
+
+DROP TRIGGER stock_document_lines_validate_insert;
+
+CREATE TRIGGER stock_document_lines_validate_insert
+BEFORE INSERT ON stock_document_lines
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1
+            FROM items item
+            JOIN measurement_units base_unit ON base_unit.code = item.base_unit_code
+            JOIN measurement_units entered_unit ON entered_unit.code = NEW.entered_unit_code
+            JOIN stock_documents document ON document.id = NEW.document_id
+            WHERE item.id = NEW.item_id
+              AND base_unit.dimension = entered_unit.dimension
+              AND (
+                  document.kind IN ('REVERSAL', 'RETURN')
+                  OR (
+                      item.archived_at_ms IS NULL
+                      AND (
+                          (document.kind = 'PURCHASE' AND item.is_purchasable = 1)
+                          OR (document.kind = 'SALE' AND item.is_sellable = 1)
+                          OR (document.kind = 'PRODUCTION' AND (
+                              (NEW.direction = 'IN' AND item.is_producible = 1)
+                              OR NEW.direction = 'OUT'
+                          ))
+                          OR document.kind = 'ADJUSTMENT'
+                      )
+                  )
+              )
+        )
+        THEN RAISE(ABORT, 'item or entered unit is invalid for this document line')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1
+            FROM stock_document_lines line
+            WHERE line.document_id = NEW.document_id
+              AND line.item_id = NEW.item_id
+              AND line.direction <> NEW.direction
+        )
+        THEN RAISE(ABORT, 'a document cannot move one item in both directions')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND (
+                  (document.kind = 'PURCHASE' AND (
+                      NEW.direction <> 'IN' OR NEW.commercial_total_minor IS NULL
+                  ))
+                  OR (document.kind = 'SALE' AND (
+                      NEW.direction <> 'OUT' OR NEW.commercial_total_minor IS NULL
+                  ))
+                  OR (document.kind = 'RETURN' AND (
+                      NEW.direction <> CASE document.reason_code
+                          WHEN 'CUSTOMER_RETURN' THEN 'IN'
+                          ELSE 'OUT'
+                      END
+                      OR NEW.commercial_total_minor IS NULL
+                  ))
+                  OR (document.kind IN ('PRODUCTION', 'ADJUSTMENT')
+                      AND NEW.commercial_total_minor IS NOT NULL)
+                  OR (document.kind <> 'REVERSAL' AND NEW.reverses_line_id IS NOT NULL)
+                  OR (document.kind = 'REVERSAL' AND NEW.reverses_line_id IS NULL)
+                  OR (document.kind <> 'RETURN' AND NEW.returns_line_id IS NOT NULL)
+                  OR (document.kind = 'RETURN' AND NEW.returns_line_id IS NULL)
+              )
+        )
+        THEN RAISE(ABORT, 'line shape does not match its document kind')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'PURCHASE'
+              AND NEW.commercial_total_minor = 0
+              AND document.reason_code IS NOT 'FREE_STOCK'
+        )
+        THEN RAISE(ABORT, 'zero-cost purchase requires FREE_STOCK')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'SALE'
+              AND NEW.commercial_total_minor = 0
+              AND NOT (
+                  document.reason_code IS 'PROMOTION'
+                  OR document.reason_code IS 'SAMPLE'
+              )
+        )
+        THEN RAISE(ABORT, 'zero-price sale requires PROMOTION or SAMPLE')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'ADJUSTMENT'
+              AND (
+                  (document.reason_code IN ('OPENING_BALANCE', 'FREE_STOCK')
+                      AND NEW.direction <> 'IN')
+                  OR (document.reason_code IN ('WASTE', 'EXPIRY', 'DAMAGE', 'SAMPLE')
+                      AND NEW.direction <> 'OUT')
+              )
+        )
+        THEN RAISE(ABORT, 'adjustment direction does not match its reason')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'PRODUCTION'
+              AND NEW.direction = 'IN'
+        )
+         AND EXISTS (
+             SELECT 1
+             FROM stock_document_lines other
+             WHERE other.document_id = NEW.document_id
+               AND other.direction = 'IN'
+         )
+        THEN RAISE(ABORT, 'production can have only one output line')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1
+            FROM stock_documents document
+            JOIN stock_document_lines target
+              ON target.id = NEW.reverses_line_id
+             AND target.document_id = document.reverses_document_id
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'REVERSAL'
+              AND NEW.item_id = target.item_id
+              AND NEW.direction <> target.direction
+              AND NEW.quantity_atomic = target.quantity_atomic
+              AND NEW.entered_unit_code = target.entered_unit_code
+              AND NEW.entered_packaging_name IS target.entered_packaging_name
+              AND NEW.conversion_numerator_atomic = target.conversion_numerator_atomic
+              AND NEW.conversion_denominator = target.conversion_denominator
+              AND NEW.inventory_value_micro = target.inventory_value_micro
+              AND NEW.commercial_total_minor IS target.commercial_total_minor
+        ) = 0
+         AND EXISTS (
+             SELECT 1 FROM stock_documents
+             WHERE id = NEW.document_id AND kind = 'REVERSAL'
+         )
+        THEN RAISE(ABORT, 'reversal line must exactly invert a target line')
+    END;
+    SELECT CASE
+        WHEN NEW.returns_line_id IS NOT NULL
+         AND NOT EXISTS (
+             SELECT 1
+             FROM stock_documents document
+             JOIN stock_document_lines target
+               ON target.id = NEW.returns_line_id
+              AND target.document_id = document.returns_document_id
+             WHERE document.id = NEW.document_id
+               AND target.direction <> NEW.direction
+               AND NEW.item_id = target.item_id
+               AND NEW.entered_unit_code = target.entered_unit_code
+               AND NEW.entered_packaging_name IS target.entered_packaging_name
+               AND NEW.conversion_numerator_atomic = target.conversion_numerator_atomic
+               AND NEW.conversion_denominator = target.conversion_denominator
+         )
+        THEN RAISE(ABORT, 'return line must match a line of the returned document')
+    END;
+    SELECT CASE
+        WHEN NEW.returns_line_id IS NOT NULL
+         AND EXISTS (
+             SELECT 1
+             FROM stock_document_lines target
+             WHERE target.id = NEW.returns_line_id
+               AND (
+                   NEW.quantity_atomic + (
+                       SELECT COALESCE(SUM(returned.quantity_atomic), 0)
+                       FROM stock_document_lines returned
+                       WHERE returned.returns_line_id = target.id
+                   ) > target.quantity_atomic
+                   OR (NEW.direction = 'IN' AND NEW.inventory_value_micro + (
+                       SELECT COALESCE(SUM(returned.inventory_value_micro), 0)
+                       FROM stock_document_lines returned
+                       WHERE returned.returns_line_id = target.id
+                   ) > target.inventory_value_micro)
+                   OR NEW.commercial_total_minor + (
+                       SELECT COALESCE(SUM(returned.commercial_total_minor), 0)
+                       FROM stock_document_lines returned
+                       WHERE returned.returns_line_id = target.id
+                   ) > target.commercial_total_minor
+               )
+         )
+        THEN RAISE(ABORT, 'returns cannot exceed the quantity, value, or total of the returned line')
+    END;
+END;
+
+DROP TRIGGER lot_allocations_validate_insert;
+
+CREATE TRIGGER lot_allocations_validate_insert
+BEFORE INSERT ON lot_allocations
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1
+            FROM stock_document_lines line
+            JOIN inventory_lots lot ON lot.id = NEW.lot_id
+            WHERE line.id = NEW.line_id
+              AND line.item_id = lot.item_id
+        )
+        THEN RAISE(ABORT, 'allocation item must match its lot')
+    END;
+    SELECT CASE
+        WHEN NEW.restores_allocation_id IS NULL
+         AND NOT EXISTS (
+             SELECT 1
+             FROM stock_document_lines line
+             JOIN inventory_lots lot ON lot.id = NEW.lot_id
+             JOIN stock_document_lines source ON source.id = lot.source_line_id
+             JOIN stock_documents consuming_document ON consuming_document.id = line.document_id
+             JOIN stock_documents source_document ON source_document.id = source.document_id
+             WHERE line.id = NEW.line_id
+               AND line.direction = 'OUT'
+               AND consuming_document.posting_sequence > source_document.posting_sequence
+               AND (
+                   consuming_document.kind NOT IN ('REVERSAL', 'RETURN')
+                   OR source.id = line.reverses_line_id
+                   OR source.id = line.returns_line_id
+               )
+         )
+        THEN RAISE(ABORT, 'normal allocation must consume an earlier-document lot')
+    END;
+    SELECT CASE
+        WHEN NEW.restores_allocation_id IS NOT NULL
+         AND NOT EXISTS (
+             SELECT 1
+             FROM lot_allocations original
+             JOIN stock_document_lines original_line ON original_line.id = original.line_id
+             JOIN stock_document_lines restoring_line ON restoring_line.id = NEW.line_id
+             JOIN stock_documents restoring_document
+               ON restoring_document.id = restoring_line.document_id
+             WHERE original.id = NEW.restores_allocation_id
+               AND original.restores_allocation_id IS NULL
+               AND original.lot_id = NEW.lot_id
+               AND restoring_line.direction = 'IN'
+               AND (
+                   (restoring_document.kind = 'REVERSAL'
+                       AND restoring_line.reverses_line_id = original_line.id
+                       AND original.quantity_atomic = NEW.quantity_atomic)
+                   OR (restoring_document.kind = 'RETURN'
+                       AND restoring_line.returns_line_id = original_line.id)
+               )
+         )
+        THEN RAISE(ABORT, 'restoration must reverse or return an original allocation')
+    END;
+    SELECT CASE
+        WHEN NEW.restores_allocation_id IS NOT NULL
+         AND NEW.quantity_atomic + (
+             SELECT COALESCE(SUM(restoration.quantity_atomic), 0)
+             FROM lot_allocations restoration
+             WHERE restoration.restores_allocation_id = NEW.restores_allocation_id
+         ) > (
+             SELECT original.quantity_atomic
+             FROM lot_allocations original
+             WHERE original.id = NEW.restores_allocation_id
+         )
+        THEN RAISE(ABORT, 'restorations cannot exceed their original allocation')
+    END;
+    SELECT CASE
+        WHEN (
+            SELECT COALESCE(SUM(
+                CASE WHEN restores_allocation_id IS NULL
+                    THEN quantity_atomic ELSE -quantity_atomic END
+            ), 0)
+            FROM lot_allocations
+            WHERE lot_id = NEW.lot_id
+        ) + CASE WHEN NEW.restores_allocation_id IS NULL
+            THEN NEW.quantity_atomic ELSE -NEW.quantity_atomic END
+        NOT BETWEEN 0 AND (
+            SELECT initial_quantity_atomic FROM inventory_lots WHERE id = NEW.lot_id
+        )
+        THEN RAISE(ABORT, 'allocation would make lot consumption invalid')
+    END;
+END;
@@ -0,0 +1,465 @@
+-- Stock locations partition lots between physical places. Every existing
+-- lot moves to the seeded default location, and inbound postings that do not
+-- name a location keep using it. Balances and valuation stay per item; a
+-- location's quantity is the remaining quantity of its lots.
+--
+-- TRANSFER documents move lot quantity between locations. Each transfer pair
+-- is an OUT line at an odd line order that consumes one source lot, followed
+-- by an IN line that mirrors its quantity, unit snapshot, and value and
+-- creates one lot at the destination. The new lot copies the source lot's
+-- code and dates and records it in transferred_from_lot_id, so inventory
+-- value and expiry never change when stock moves. Only triggers and added
+-- columns change, so no table is rebuilt.
+
+CREATE TABLE stock_locations (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    normalized_name TEXT NOT NULL UNIQUE CHECK (length(trim(normalized_name)) > 0),
+    created_at_ms INTEGER NOT NULL CHECK (created_at_ms >= 0),
+    updated_at_ms INTEGER NOT NULL CHECK (updated_at_ms >= created_at_ms),
+    archived_at_ms INTEGER CHECK (archived_at_ms >= updated_at_ms)
+) STRICT;
+
+INSERT INTO stock_locations (id, name, normalized_name, created_at_ms, updated_at_ms)
+VALUES (1, 'Principal', 'principal', 0, 0);
+
+ALTER TABLE inventory_lots ADD COLUMN location_id INTEGER NOT NULL DEFAULT 1
+    REFERENCES stock_locations(id) ON UPDATE RESTRICT ON DELETE RESTRICT;
+ALTER TABLE inventory_lots ADD COLUMN transferred_from_lot_id INTEGER
+    REFERENCES inventory_lots(id) ON UPDATE RESTRICT ON DELETE RESTRICT;
+
+CREATE INDEX stock_locations_active_name
+    ON stock_locations (archived_at_ms, normalized_name);
+CREATE INDEX inventory_lots_location_fefo
+    ON inventory_lots (location_id, item_id, expires_on, id);
+CREATE INDEX inventory_lots_transferred_from
+    ON inventory_lots (transferred_from_lot_id) WHERE transferred_from_lot_id IS NOT NULL;
+
+-- Remaining lot quantity per location and item. Location quantities always
+-- add up to the item's inventory balance quantity.
+CREATE VIEW inventory_location_balances AS
+SELECT
+    lot.location_id,
+    lot.item_id,
+    CAST(SUM(lot.initial_quantity_atomic - COALESCE(consumption.net_quantity_atomic, 0)) AS INTEGER)
+        AS quantity_atomic
+FROM inventory_lots lot
+LEFT JOIN (
+    SELECT
+        lot_id,
+        SUM(
+            CASE WHEN restores_allocation_id IS NULL
+                THEN quantity_atomic ELSE -quantity_atomic END
+        ) AS net_quantity_atomic
+    FROM lot_allocations
+    GROUP BY lot_id
+) consumption ON consumption.lot_id = lot.id
+GROUP BY lot.location_id, lot.item_id;
+
+CREATE TRIGGER stock_locations_no_delete
+BEFORE DELETE ON stock_locations
+BEGIN
+    SELECT RAISE(ABORT, 'stock locations must be archived, not deleted');
+END;
+
+CREATE TRIGGER stock_locations_archive_version_insert
+BEFORE INSERT ON stock_locations
+WHEN NEW.archived_at_ms IS NOT NULL
+ AND NEW.archived_at_ms <> NEW.updated_at_ms
+BEGIN
+    SELECT RAISE(ABORT, 'stock location archive timestamp must equal its optimistic version');
+END;
+
+CREATE TRIGGER stock_locations_archive_version_update
+BEFORE UPDATE OF archived_at_ms, updated_at_ms ON stock_locations
+WHEN NEW.archived_at_ms IS NOT NULL
+ AND NEW.archived_at_ms <> NEW.updated_at_ms
+BEGIN
+    SELECT RAISE(ABORT, 'stock location archive timestamp must equal its optimistic version');
+END;
+
+-- The default location receives every inbound posting without an explicit
+-- location, and an archived location must not hide available stock.
+CREATE TRIGGER stock_locations_preserve_stock
+BEFORE UPDATE OF archived_at_ms ON stock_locations
+WHEN NEW.archived_at_ms IS NOT NULL
+ AND (
+    OLD.id = 1
+    OR EXISTS (
+        SELECT 1
+        FROM inventory_lots lot
+        WHERE lot.location_id = OLD.id
+          AND lot.initial_quantity_atomic > COALESCE((
+              SELECT SUM(
+                  CASE WHEN allocation.restores_allocation_id IS NULL
+                      THEN allocation.quantity_atomic ELSE -allocation.quantity_atomic END
+              )
+              FROM lot_allocations allocation
+              WHERE allocation.lot_id = lot.id
+          ), 0)
+    )
+ )
+BEGIN
+    SELECT RAISE(ABORT, 'default location or location with stock must remain active');
+END;
+
+DROP TRIGGER stock_documents_validate_insert;
+
+CREATE TRIGGER stock_documents_validate_insert
+BEFORE INSERT ON stock_documents
+BEGIN
+    SELECT CASE
+        WHEN NOT (
+            (NEW.kind = 'PURCHASE' AND COALESCE(NEW.reason_code, 'FREE_STOCK') = 'FREE_STOCK')
+            OR (NEW.kind = 'SALE' AND COALESCE(NEW.reason_code, 'PROMOTION') IN ('PROMOTION', 'SAMPLE'))
+            OR (NEW.kind = 'PRODUCTION' AND NEW.reason_code IS NULL)
+            OR (NEW.kind = 'ADJUSTMENT' AND COALESCE(NEW.reason_code, '') IN (
+                'OPENING_BALANCE',
+                'FREE_STOCK',
+                'PHYSICAL_COUNT',
+                'WASTE',
+                'EXPIRY',
+                'DAMAGE',
+                'SAMPLE',
+                'DOCUMENTED_CORRECTION'
+            ))
+            OR (NEW.kind = 'REVERSAL' AND NEW.reason_code IS 'EXACT_REVERSAL')
+            OR (NEW.kind = 'RETURN' AND COALESCE(NEW.reason_code, '') IN (
+                'CUSTOMER_RETURN',
+                'SUPPLIER_RETURN'
+            ))
+            OR (NEW.kind = 'TRANSFER' AND NEW.reason_code IS NULL)
+        )
+        THEN RAISE(ABORT, 'document reason does not match its kind')
+    END;
+    SELECT CASE
+        WHEN NEW.posting_sequence <= COALESCE((SELECT MAX(posting_sequence) FROM stock_documents), 0)
+        THEN RAISE(ABORT, 'posting sequence must increase monotonically')
+    END;
+    SELECT CASE
+        WHEN NEW.currency_code <> (SELECT currency_code FROM app_settings WHERE id = 1)
+          OR NEW.currency_minor_digits <> (
+              SELECT currency_minor_digits FROM app_settings WHERE id = 1
+          )
+        THEN RAISE(ABORT, 'document currency must match application settings')
+    END;
+    SELECT CASE
+        WHEN NEW.counterparty_id IS NOT NULL
+         AND NEW.kind NOT IN ('PURCHASE', 'SALE', 'RETURN')
+        THEN RAISE(ABORT, 'counterparty is not eligible for this document kind')
+    END;
+    SELECT CASE
+        WHEN NEW.counterparty_id IS NOT NULL
+         AND NEW.kind IN ('PURCHASE', 'SALE')
+         AND NOT EXISTS (
+             SELECT 1
+             FROM counterparties counterparty
+             JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+             WHERE counterparty.id = NEW.counterparty_id
+               AND counterparty.archived_at_ms IS NULL
+               AND role.role = CASE NEW.kind
+                   WHEN 'PURCHASE' THEN 'SUPPLIER'
+                   WHEN 'SALE' THEN 'CUSTOMER'
+               END
+         )
+        THEN RAISE(ABORT, 'counterparty is not eligible for this document kind')
+    END;
+    SELECT CASE
+        WHEN NEW.kind = 'REVERSAL'
+         AND NOT EXISTS (
+             SELECT 1 FROM stock_documents target
+             WHERE target.id = NEW.reverses_document_id
+               AND target.kind NOT IN ('REVERSAL', 'RETURN', 'TRANSFER')
+         )
+        THEN RAISE(ABORT, 'a reversal must target a purchase, sale, production, or adjustment')
+    END;
+    SELECT CASE
+        WHEN NEW.kind = 'RETURN'
+         AND NOT EXISTS (
+             SELECT 1 FROM stock_documents target
+             WHERE target.id = NEW.returns_document_id
+               AND target.kind = CASE NEW.reason_code
+                   WHEN 'CUSTOMER_RETURN' THEN 'SALE'
+                   WHEN 'SUPPLIER_RETURN' THEN 'PURCHASE'
+               END
+               AND target.counterparty_id IS NEW.counterparty_id
+               AND NOT EXISTS (
+                   SELECT 1 FROM stock_documents reversal
+                   WHERE reversal.reverses_document_id = target.id
+               )
+         )
+        THEN RAISE(ABORT, 'a return must reference an unreversed sale or purchase and keep its counterparty')
+    END;
+END;
+
+DROP TRIGGER stock_document_lines_validate_insert;
+
+CREATE TRIGGER stock_document_lines_validate_insert
+BEFORE INSERT ON stock_document_lines
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1
+            FROM items item
+            JOIN measurement_units base_unit ON base_unit.code = item.base_unit_code
+            JOIN measurement_units entered_unit ON entered_unit.code = NEW.entered_unit_code
+            JOIN stock_documents document ON document.id = NEW.document_id
+            WHERE item.id = NEW.item_id
+              AND base_unit.dimension = entered_unit.dimension
+              AND (
+                  document.kind IN ('REVERSAL', 'RETURN', 'TRANSFER')
+                  OR (
+                      item.archived_at_ms IS NULL
+                      AND (
+                          (document.kind = 'PURCHASE' AND item.is_purchasable = 1)
+                          OR (document.kind = 'SALE' AND item.is_sellable = 1)
+                          OR (document.kind = 'PRODUCTION' AND (
+                              (NEW.direction = 'IN' AND item.is_producible = 1)
+                              OR NEW.direction = 'OUT'
+                          ))
+                          OR document.kind = 'ADJUSTMENT'
+                      )
+                  )
+              )
+        )
+        THEN RAISE(ABORT, 'item or entered unit is invalid for this document line')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1
+            FROM stock_document_lines line
+            JOIN stock_documents document ON document.id = line.document_id
+            WHERE line.document_id = NEW.document_id
+              AND line.item_id = NEW.item_id
+              AND line.direction <> NEW.direction
+              AND document.kind <> 'TRANSFER'
+        )
+        THEN RAISE(ABORT, 'a document cannot move one item in both directions')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND (
+                  (document.kind = 'PURCHASE' AND (
+                      NEW.direction <> 'IN' OR NEW.commercial_total_minor IS NULL
+                  ))
+                  OR (document.kind = 'SALE' AND (
+                      NEW.direction <> 'OUT' OR NEW.commercial_total_minor IS NULL
+                  ))
+                  OR (document.kind = 'RETURN' AND (
+                      NEW.direction <> CASE document.reason_code
+                          WHEN 'CUSTOMER_RETURN' THEN 'IN'
+                          ELSE 'OUT'
+                      END
+                      OR NEW.commercial_total_minor IS NULL
+                  ))
+                  OR (document.kind IN ('PRODUCTION', 'ADJUSTMENT', 'TRANSFER')
+                      AND NEW.commercial_total_minor IS NOT NULL)
+                  OR (document.kind = 'TRANSFER' AND NEW.direction <> CASE NEW.line_order % 2
+                      WHEN 1 THEN 'OUT'
+                      ELSE 'IN'
+                  END)
+                  OR (document.kind <> 'REVERSAL' AND NEW.reverses_line_id IS NOT NULL)
+                  OR (document.kind = 'REVERSAL' AND NEW.reverses_line_id IS NULL)
+                  OR (document.kind <> 'RETURN' AND NEW.returns_line_id IS NOT NULL)
+                  OR (document.kind = 'RETURN' AND NEW.returns_line_id IS NULL)
+              )
+        )
+        THEN RAISE(ABORT, 'line shape does not match its document kind')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'PURCHASE'
+              AND NEW.commercial_total_minor = 0
+              AND document.reason_code IS NOT 'FREE_STOCK'
+        )
+        THEN RAISE(ABORT, 'zero-cost purchase requires FREE_STOCK')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'SALE'
+              AND NEW.commercial_total_minor = 0
+              AND NOT (
+                  document.reason_code IS 'PROMOTION'
+                  OR document.reason_code IS 'SAMPLE'
+              )
+        )
+        THEN RAISE(ABORT, 'zero-price sale requires PROMOTION or SAMPLE')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'ADJUSTMENT'
+              AND (
+                  (document.reason_code IN ('OPENING_BALANCE', 'FREE_STOCK')
+                      AND NEW.direction <> 'IN')
+                  OR (document.reason_code IN ('WASTE', 'EXPIRY', 'DAMAGE', 'SAMPLE')
+                      AND NEW.direction <> 'OUT')
+              )
+        )
+        THEN RAISE(ABORT, 'adjustment direction does not match its reason')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'PRODUCTION'
+              AND NEW.direction = 'IN'
+        )
+         AND EXISTS (
+             SELECT 1
+             FROM stock_document_lines other
+             WHERE other.document_id = NEW.document_id
+               AND other.direction = 'IN'
+         )
+        THEN RAISE(ABORT, 'production can have only one output line')
+    END;
+    SELECT CASE
+        WHEN NEW.direction = 'IN'
+         AND EXISTS (
+             SELECT 1 FROM stock_documents document
+             WHERE document.id = NEW.document_id
+               AND document.kind = 'TRANSFER'
+         )
+         AND NOT EXISTS (
+             SELECT 1
+             FROM stock_document_lines source
+             WHERE source.document_id = NEW.document_id
+               AND source.line_order = NEW.line_order - 1
+               AND source.direction = 'OUT'
+               AND source.item_id = NEW.item_id
+               AND source.quantity_atomic = NEW.quantity_atomic
+               AND source.entered_unit_code = NEW.entered_unit_code
+               AND source.entered_packaging_name IS NEW.entered_packaging_name
+               AND source.conversion_numerator_atomic = NEW.conversion_numerator_atomic
+               AND source.conversion_denominator = NEW.conversion_denominator
+               AND source.inventory_value_micro = NEW.inventory_value_micro
+         )
+        THEN RAISE(ABORT, 'transfer inbound line must mirror the preceding outbound line')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1
+            FROM stock_documents document
+            JOIN stock_document_lines target
+              ON target.id = NEW.reverses_line_id
+             AND target.document_id = document.reverses_document_id
+            WHERE document.id = NEW.document_id
+              AND document.kind = 'REVERSAL'
+              AND NEW.item_id = target.item_id
+              AND NEW.direction <> target.direction
+              AND NEW.quantity_atomic = target.quantity_atomic
+              AND NEW.entered_unit_code = target.entered_unit_code
+              AND NEW.entered_packaging_name IS target.entered_packaging_name
+              AND NEW.conversion_numerator_atomic = target.conversion_numerator_atomic
+              AND NEW.conversion_denominator = target.conversion_denominator
+              AND NEW.inventory_value_micro = target.inventory_value_micro
+              AND NEW.commercial_total_minor IS target.commercial_total_minor
+        ) = 0
+         AND EXISTS (
+             SELECT 1 FROM stock_documents
+             WHERE id = NEW.document_id AND kind = 'REVERSAL'
+         )
+        THEN RAISE(ABORT, 'reversal line must exactly invert a target line')
+    END;
+    SELECT CASE
+        WHEN NEW.returns_line_id IS NOT NULL
+         AND NOT EXISTS (
+             SELECT 1
+             FROM stock_documents document
+             JOIN stock_document_lines target
+               ON target.id = NEW.returns_line_id
+              AND target.document_id = document.returns_document_id
+             WHERE document.id = NEW.document_id
+               AND target.direction <> NEW.direction
+               AND NEW.item_id = target.item_id
+               AND NEW.entered_unit_code = target.entered_unit_code
+               AND NEW.entered_packaging_name IS target.entered_packaging_name
+               AND NEW.conversion_numerator_atomic = target.conversion_numerator_atomic
+               AND NEW.conversion_denominator = target.conversion_denominator
+         )
+        THEN RAISE(ABORT, 'return line must match a line of the returned document')
+    END;
+    SELECT CASE
+        WHEN NEW.returns_line_id IS NOT NULL
+         AND EXISTS (
+             SELECT 1
+             FROM stock_document_lines target
+             WHERE target.id = NEW.returns_line_id
+               AND (
+                   NEW.quantity_atomic + (
+                       SELECT COALESCE(SUM(returned.quantity_atomic), 0)
+                       FROM stock_document_lines returned
+                       WHERE returned.returns_line_id = target.id
+                   ) > target.quantity_atomic
+                   OR (NEW.direction = 'IN' AND NEW.inventory_value_micro + (
+                       SELECT COALESCE(SUM(returned.inventory_value_micro), 0)
+                       FROM stock_document_lines returned
+                       WHERE returned.returns_line_id = target.id
+                   ) > target.inventory_value_micro)
+                   OR NEW.commercial_total_minor + (
+                       SELECT COALESCE(SUM(returned.commercial_total_minor), 0)
+                       FROM stock_document_lines returned
+                       WHERE returned.returns_line_id = target.id
+                   ) > target.commercial_total_minor
+               )
+         )
+        THEN RAISE(ABORT, 'returns cannot exceed the quantity, value, or total of the returned line')
+    END;
+END;
+
+DROP TRIGGER inventory_lots_validate_insert;
+
+CREATE TRIGGER inventory_lots_validate_insert
+BEFORE INSERT ON inventory_lots
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1
+            FROM stock_document_lines line
+            JOIN stock_documents document ON document.id = line.document_id
+            WHERE line.id = NEW.source_line_id
+              AND document.kind NOT IN ('REVERSAL', 'RETURN')
+              AND line.direction = 'IN'
+              AND line.item_id = NEW.item_id
+              AND line.quantity_atomic = NEW.initial_quantity_atomic
+              AND (document.kind = 'TRANSFER') = (NEW.transferred_from_lot_id IS NOT NULL)
+        )
+        THEN RAISE(ABORT, 'lot must exactly represent a normal inbound line')
+    END;
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM stock_locations location
+            WHERE location.id = NEW.location_id
+              AND location.archived_at_ms IS NULL
+        )
+        THEN RAISE(ABORT, 'new lots require an active location')
+    END;
+    SELECT CASE
+        WHEN NEW.transferred_from_lot_id IS NOT NULL
+         AND NOT EXISTS (
+             SELECT 1
+             FROM inventory_lots source_lot
+             JOIN lot_allocations allocation ON allocation.lot_id = source_lot.id
+             JOIN stock_document_lines out_line ON out_line.id = allocation.line_id
+             JOIN stock_document_lines in_line ON in_line.id = NEW.source_line_id
+             WHERE source_lot.id = NEW.transferred_from_lot_id
+               AND source_lot.item_id = NEW.item_id
+               AND source_lot.location_id <> NEW.location_id
+               AND source_lot.lot_code IS NEW.lot_code
+               AND source_lot.originated_on = NEW.originated_on
+               AND source_lot.expires_on IS NEW.expires_on
+               AND out_line.document_id = in_line.document_id
+               AND out_line.line_order = in_line.line_order - 1
+               AND allocation.restores_allocation_id IS NULL
+               AND allocation.quantity_atomic = NEW.initial_quantity_atomic
+         )
+        THEN RAISE(ABORT, 'transfer lot must copy the lot consumed by its outbound line')
+    END;
+END;
@@ -0,0 +1,232 @@
+-- Purchase orders record what was ordered from a supplier before the goods
+-- arrive. They are not stock documents: no order table is read by balances,
+-- lots, or valuation, and an order never posts by itself. Each receipt posts
+-- an ordinary PURCHASE document for the order's supplier and, in the same
+-- transaction, links it in purchase_order_receipts and advances the received
+-- quantity of the order lines it fulfils.
+--
+-- Lines keep the entered unit or packaging snapshot and the expected
+-- commercial total of the whole ordered quantity. They can only change while
+-- the order is a DRAFT. Received quantities only grow, never beyond the
+-- ordered quantity, and only while the order is SENT or PARTIALLY_RECEIVED.
+-- Orders are cancelled or closed, never deleted.
+
+CREATE TABLE purchase_orders (
+    id INTEGER PRIMARY KEY,
+    supplier_id INTEGER NOT NULL REFERENCES counterparties(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    status TEXT NOT NULL CHECK (
+        status IN ('DRAFT', 'SENT', 'PARTIALLY_RECEIVED', 'CLOSED', 'CANCELLED')
+    ),
+    ordered_on TEXT NOT NULL CHECK (
+        length(ordered_on) = 10
+        AND ordered_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+    ),
+    expected_on TEXT CHECK (
+        expected_on IS NULL OR (
+            length(expected_on) = 10
+            AND expected_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+            AND expected_on >= ordered_on
+        )
+    ),
+    notes TEXT CHECK (notes IS NULL OR length(trim(notes)) > 0),
+    created_at_ms INTEGER NOT NULL CHECK (created_at_ms >= 0),
+    updated_at_ms INTEGER NOT NULL CHECK (updated_at_ms >= created_at_ms)
+) STRICT;
+
+CREATE TABLE purchase_order_lines (
+    id INTEGER PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES purchase_orders(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    line_order INTEGER NOT NULL CHECK (line_order > 0),
+    item_id INTEGER NOT NULL REFERENCES items(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    quantity_atomic INTEGER NOT NULL CHECK (quantity_atomic > 0),
+    entered_unit_code TEXT NOT NULL REFERENCES measurement_units(code)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    entered_packaging_name TEXT CHECK (
+        entered_packaging_name IS NULL OR length(trim(entered_packaging_name)) > 0
+    ),
+    conversion_numerator_atomic INTEGER NOT NULL CHECK (conversion_numerator_atomic > 0),
+    conversion_denominator INTEGER NOT NULL CHECK (conversion_denominator > 0),
+    expected_total_minor INTEGER NOT NULL CHECK (expected_total_minor > 0),
+    received_quantity_atomic INTEGER NOT NULL DEFAULT 0 CHECK (
+        received_quantity_atomic >= 0 AND received_quantity_atomic <= quantity_atomic
+    ),
+    UNIQUE (order_id, line_order)
+) STRICT;
+
+CREATE TABLE purchase_order_receipts (
+    document_id INTEGER PRIMARY KEY REFERENCES stock_documents(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    order_id INTEGER NOT NULL REFERENCES purchase_orders(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT
+) STRICT;
+
+CREATE INDEX purchase_orders_status_ordered
+    ON purchase_orders (status, ordered_on, id);
+CREATE INDEX purchase_orders_supplier
+    ON purchase_orders (supplier_id, ordered_on);
+CREATE INDEX purchase_order_lines_item
+    ON purchase_order_lines (item_id);
+CREATE INDEX purchase_order_receipts_order
+    ON purchase_order_receipts (order_id, document_id);
+
+CREATE TRIGGER purchase_orders_no_delete
+BEFORE DELETE ON purchase_orders
+BEGIN
+    SELECT RAISE(ABORT, 'purchase orders must be cancelled or closed, not deleted');
+END;
+
+CREATE TRIGGER purchase_orders_validate_insert
+BEFORE INSERT ON purchase_orders
+BEGIN
+    SELECT CASE
+        WHEN NEW.status <> 'DRAFT'
+        THEN RAISE(ABORT, 'a purchase order starts as a draft')
+    END;
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1
+            FROM counterparties counterparty
+            JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+            WHERE counterparty.id = NEW.supplier_id
+              AND counterparty.archived_at_ms IS NULL
+              AND role.role = 'SUPPLIER'
+        )
+        THEN RAISE(ABORT, 'purchase order supplier must be an active supplier')
+    END;
+END;
+
+CREATE TRIGGER purchase_orders_validate_update
+BEFORE UPDATE ON purchase_orders
+BEGIN
+    SELECT CASE
+        WHEN NEW.created_at_ms <> OLD.created_at_ms
+          OR NEW.updated_at_ms < OLD.updated_at_ms
+        THEN RAISE(ABORT, 'purchase order versions only advance')
+    END;
+    SELECT CASE
+        WHEN OLD.status <> 'DRAFT' AND (
+            NEW.supplier_id <> OLD.supplier_id
+            OR NEW.ordered_on <> OLD.ordered_on
+            OR NEW.expected_on IS NOT OLD.expected_on
+            OR NEW.notes IS NOT OLD.notes
+        )
+        THEN RAISE(ABORT, 'only a draft purchase order can be edited')
+    END;
+    SELECT CASE
+        WHEN NEW.status <> OLD.status AND NOT (
+            (OLD.status = 'DRAFT' AND NEW.status IN ('SENT', 'CANCELLED'))
+            OR (OLD.status = 'SENT' AND NEW.status IN ('PARTIALLY_RECEIVED', 'CLOSED', 'CANCELLED'))
+            OR (OLD.status = 'PARTIALLY_RECEIVED' AND NEW.status = 'CLOSED')
+        )
+        THEN RAISE(ABORT, 'purchase order status change is not allowed')
+    END;
+    SELECT CASE
+        WHEN NEW.supplier_id <> OLD.supplier_id AND NOT EXISTS (
+            SELECT 1
+            FROM counterparties counterparty
+            JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+            WHERE counterparty.id = NEW.supplier_id
+              AND counterparty.archived_at_ms IS NULL
+              AND role.role = 'SUPPLIER'
+        )
+        THEN RAISE(ABORT, 'purchase order supplier must be an active supplier')
+    END;
+END;
+
+CREATE TRIGGER purchase_order_lines_validate_insert
+BEFORE INSERT ON purchase_order_lines
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM purchase_orders purchase_order
+            WHERE purchase_order.id = NEW.order_id AND purchase_order.status = 'DRAFT'
+        )
+        THEN RAISE(ABORT, 'only a draft purchase order can be edited')
+    END;
+    SELECT CASE
+        WHEN NEW.received_quantity_atomic <> 0
+        THEN RAISE(ABORT, 'a new purchase order line has received nothing')
+    END;
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1
+            FROM items item
+            JOIN measurement_units base_unit ON base_unit.code = item.base_unit_code
+            JOIN measurement_units entered_unit ON entered_unit.code = NEW.entered_unit_code
+            WHERE item.id = NEW.item_id
+              AND item.archived_at_ms IS NULL
+              AND item.is_purchasable = 1
+              AND base_unit.dimension = entered_unit.dimension
+        )
+        THEN RAISE(ABORT, 'item or entered unit is invalid for this purchase order line')
+    END;
+END;
+
+CREATE TRIGGER purchase_order_lines_validate_update
+BEFORE UPDATE ON purchase_order_lines
+BEGIN
+    SELECT CASE
+        WHEN NEW.order_id <> OLD.order_id
+          OR NEW.line_order <> OLD.line_order
+          OR NEW.item_id <> OLD.item_id
+          OR NEW.quantity_atomic <> OLD.quantity_atomic
+          OR NEW.entered_unit_code <> OLD.entered_unit_code
+          OR NEW.entered_packaging_name IS NOT OLD.entered_packaging_name
+          OR NEW.conversion_numerator_atomic <> OLD.conversion_numerator_atomic
+          OR NEW.conversion_denominator <> OLD.conversion_denominator
+          OR NEW.expected_total_minor <> OLD.expected_total_minor
+        THEN RAISE(ABORT, 'purchase order lines are replaced, not edited')
+    END;
+    SELECT CASE
+        WHEN NEW.received_quantity_atomic < OLD.received_quantity_atomic
+        THEN RAISE(ABORT, 'received quantity only grows')
+    END;
+    SELECT CASE
+        WHEN NEW.received_quantity_atomic <> OLD.received_quantity_atomic AND NOT EXISTS (
+            SELECT 1 FROM purchase_orders purchase_order
+            WHERE purchase_order.id = NEW.order_id
+              AND purchase_order.status IN ('SENT', 'PARTIALLY_RECEIVED')
+        )
+        THEN RAISE(ABORT, 'only a sent purchase order can be received')
+    END;
+END;
+
+CREATE TRIGGER purchase_order_lines_no_delete
+BEFORE DELETE ON purchase_order_lines
+WHEN NOT EXISTS (
+    SELECT 1 FROM purchase_orders purchase_order
+    WHERE purchase_order.id = OLD.order_id AND purchase_order.status = 'DRAFT'
+)
+BEGIN
+    SELECT RAISE(ABORT, 'only a draft purchase order can be edited');
+END;
+
+CREATE TRIGGER purchase_order_receipts_validate_insert
+BEFORE INSERT ON purchase_order_receipts
+WHEN NOT EXISTS (
+    SELECT 1
+    FROM stock_documents document
+    JOIN purchase_orders purchase_order ON purchase_order.id = NEW.order_id
+    WHERE document.id = NEW.document_id
+      AND document.kind = 'PURCHASE'
+      AND document.counterparty_id = purchase_order.supplier_id
+      AND purchase_order.status IN ('SENT', 'PARTIALLY_RECEIVED')
+)
+BEGIN
+    SELECT RAISE(ABORT, 'a receipt must be a purchase from the supplier of a sent order');
+END;
+
+CREATE TRIGGER purchase_order_receipts_no_update
+BEFORE UPDATE ON purchase_order_receipts
+BEGIN
+    SELECT RAISE(ABORT, 'purchase order receipts are immutable');
+END;
+
+CREATE TRIGGER purchase_order_receipts_no_delete
+BEFORE DELETE ON purchase_order_receipts
+BEGIN
+    SELECT RAISE(ABORT, 'purchase order receipts are immutable');
+END;
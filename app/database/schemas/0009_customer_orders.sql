@@ -0,0 +1,172 @@
+-- Customer orders record what a customer ordered ahead of its due date, at
+-- agreed prices and with any deposit already taken. They are not stock
+-- documents: no order table is read by balances, lots, or valuation. An open
+-- order is fulfilled by posting exactly one SALE to the same customer, and the
+-- same transaction records that sale on the order and marks it FULFILLED. The
+-- sale follows the ordinary no-negative-stock rules, so items made to order
+-- must be produced before the order is fulfilled.
+--
+-- Lines keep the entered unit or packaging snapshot and the agreed commercial
+-- total of the whole line. Orders and lines only change while OPEN; fulfilled
+-- and cancelled orders are final and are never deleted.
+
+CREATE TABLE customer_orders (
+    id INTEGER PRIMARY KEY,
+    customer_id INTEGER NOT NULL REFERENCES counterparties(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    status TEXT NOT NULL CHECK (status IN ('OPEN', 'FULFILLED', 'CANCELLED')),
+    ordered_on TEXT NOT NULL CHECK (
+        length(ordered_on) = 10
+        AND ordered_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+    ),
+    due_on TEXT NOT NULL CHECK (
+        length(due_on) = 10
+        AND due_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+        AND due_on >= ordered_on
+    ),
+    deposit_minor INTEGER NOT NULL DEFAULT 0 CHECK (deposit_minor >= 0),
+    notes TEXT CHECK (notes IS NULL OR length(trim(notes)) > 0),
+    sale_document_id INTEGER UNIQUE REFERENCES stock_documents(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    created_at_ms INTEGER NOT NULL CHECK (created_at_ms >= 0),
+    updated_at_ms INTEGER NOT NULL CHECK (updated_at_ms >= created_at_ms),
+    CHECK ((status = 'FULFILLED') = (sale_document_id IS NOT NULL))
+) STRICT;
+
+CREATE TABLE customer_order_lines (
+    id INTEGER PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES customer_orders(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    line_order INTEGER NOT NULL CHECK (line_order > 0),
+    item_id INTEGER NOT NULL REFERENCES items(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    quantity_atomic INTEGER NOT NULL CHECK (quantity_atomic > 0),
+    entered_unit_code TEXT NOT NULL REFERENCES measurement_units(code)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    entered_packaging_name TEXT CHECK (
+        entered_packaging_name IS NULL OR length(trim(entered_packaging_name)) > 0
+    ),
+    conversion_numerator_atomic INTEGER NOT NULL CHECK (conversion_numerator_atomic > 0),
+    conversion_denominator INTEGER NOT NULL CHECK (conversion_denominator > 0),
+    agreed_total_minor INTEGER NOT NULL CHECK (agreed_total_minor > 0),
+    UNIQUE (order_id, line_order)
+) STRICT;
+
+CREATE INDEX customer_orders_due
+    ON customer_orders (due_on, status, id);
+CREATE INDEX customer_orders_customer
+    ON customer_orders (customer_id, due_on);
+CREATE INDEX customer_order_lines_item
+    ON customer_order_lines (item_id);
+
+CREATE TRIGGER customer_orders_no_delete
+BEFORE DELETE ON customer_orders
+BEGIN
+    SELECT RAISE(ABORT, 'customer orders must be cancelled or fulfilled, not deleted');
+END;
+
+CREATE TRIGGER customer_orders_validate_insert
+BEFORE INSERT ON customer_orders
+BEGIN
+    SELECT CASE
+        WHEN NEW.status <> 'OPEN'
+        THEN RAISE(ABORT, 'a customer order starts open')
+    END;
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1
+            FROM counterparties counterparty
+            JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+            WHERE counterparty.id = NEW.customer_id
+              AND counterparty.archived_at_ms IS NULL
+              AND role.role = 'CUSTOMER'
+        )
+        THEN RAISE(ABORT, 'customer order customer must be an active customer')
+    END;
+END;
+
+CREATE TRIGGER customer_orders_validate_update
+BEFORE UPDATE ON customer_orders
+BEGIN
+    SELECT CASE
+        WHEN NEW.created_at_ms <> OLD.created_at_ms
+          OR NEW.updated_at_ms < OLD.updated_at_ms
+        THEN RAISE(ABORT, 'customer order versions only advance')
+    END;
+    SELECT CASE
+        WHEN OLD.status <> 'OPEN'
+        THEN RAISE(ABORT, 'fulfilled and cancelled customer orders are final')
+    END;
+    SELECT CASE
+        WHEN NEW.status <> 'OPEN' AND (
+            NEW.customer_id <> OLD.customer_id
+            OR NEW.ordered_on <> OLD.ordered_on
+            OR NEW.due_on <> OLD.due_on
+            OR NEW.deposit_minor <> OLD.deposit_minor
+            OR NEW.notes IS NOT OLD.notes
+        )
+        THEN RAISE(ABORT, 'customer order status changes alone')
+    END;
+    SELECT CASE
+        WHEN NEW.customer_id <> OLD.customer_id AND NOT EXISTS (
+            SELECT 1
+            FROM counterparties counterparty
+            JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+            WHERE counterparty.id = NEW.customer_id
+              AND counterparty.archived_at_ms IS NULL
+              AND role.role = 'CUSTOMER'
+        )
+        THEN RAISE(ABORT, 'customer order customer must be an active customer')
+    END;
+    SELECT CASE
+        WHEN NEW.status = 'FULFILLED' AND NOT EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.sale_document_id
+              AND document.kind = 'SALE'
+              AND document.counterparty_id = NEW.customer_id
+              AND document.occurred_on >= NEW.ordered_on
+        )
+        THEN RAISE(ABORT, 'a customer order is fulfilled by a sale to its customer')
+    END;
+END;
+
+CREATE TRIGGER customer_order_lines_validate_insert
+BEFORE INSERT ON customer_order_lines
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM customer_orders customer_order
+            WHERE customer_order.id = NEW.order_id AND customer_order.status = 'OPEN'
+        )
+        THEN RAISE(ABORT, 'only an open customer order can be edited')
+    END;
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1
+            FROM items item
+            JOIN measurement_units base_unit ON base_unit.code = item.base_unit_code
+            JOIN measurement_units entered_unit ON entered_unit.code = NEW.entered_unit_code
+            WHERE item.id = NEW.item_id
+              AND item.archived_at_ms IS NULL
+              AND item.is_sellable = 1
+              AND base_unit.dimension = entered_unit.dimension
+        )
+        THEN RAISE(ABORT, 'item or entered unit is invalid for this customer order line')
+    END;
+END;
+
+CREATE TRIGGER customer_order_lines_no_update
+BEFORE UPDATE ON customer_order_lines
+BEGIN
+    SELECT RAISE(ABORT, 'customer order lines are replaced, not edited');
+END;
+
+CREATE TRIGGER customer_order_lines_no_delete
+BEFORE DELETE ON customer_order_lines
+WHEN NOT EXISTS (
+    SELECT 1 FROM customer_orders customer_order
+    WHERE customer_order.id = OLD.order_id AND customer_order.status = 'OPEN'
+)
+BEGIN
+    SELECT RAISE(ABORT, 'only an open customer order can be edited');
+END;
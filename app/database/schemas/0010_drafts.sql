@@ -0,0 +1,59 @@
+-- Drafts keep a half-entered posting form across restarts. A draft is the
+-- form's request as a JSON object, saved under the kind of document it will
+-- post and a draft id chosen by the client. Drafts are never read by
+-- balances, lots, valuation, or reports.
+--
+-- Posting a draft posts one stock document whose idempotency key is the draft
+-- id and then deletes the draft. A draft id that already keys a stock
+-- document can therefore never be saved again, so a posted draft cannot come
+-- back and post twice. Saves advance updated_at_ms, which is the optimistic
+-- version of the draft.
+
+CREATE TABLE drafts (
+    kind TEXT NOT NULL CHECK (kind IN ('PURCHASE', 'SALE', 'ADJUSTMENT', 'PRODUCTION')),
+    draft_id TEXT NOT NULL CHECK (
+        length(trim(draft_id)) > 0 AND draft_id = trim(draft_id)
+    ),
+    payload_json TEXT NOT NULL CHECK (
+        json_valid(payload_json) AND json_type(payload_json) = 'object'
+    ),
+    created_at_ms INTEGER NOT NULL CHECK (created_at_ms >= 0),
+    updated_at_ms INTEGER NOT NULL CHECK (updated_at_ms >= created_at_ms),
+    PRIMARY KEY (kind, draft_id)
+) STRICT;
+
+CREATE INDEX drafts_recent
+    ON drafts (updated_at_ms DESC, kind, draft_id);
+
+CREATE TRIGGER drafts_validate_insert
+BEFORE INSERT ON drafts
+BEGIN
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.idempotency_key = NEW.draft_id
+        )
+        THEN RAISE(ABORT, 'a posted draft cannot be saved again')
+    END;
+END;
+
+CREATE TRIGGER drafts_validate_update
+BEFORE UPDATE ON drafts
+BEGIN
+    SELECT CASE
+        WHEN NEW.kind <> OLD.kind OR NEW.draft_id <> OLD.draft_id
+        THEN RAISE(ABORT, 'draft kind and id are immutable')
+    END;
+    SELECT CASE
+        WHEN NEW.created_at_ms <> OLD.created_at_ms
+          OR NEW.updated_at_ms < OLD.updated_at_ms
+        THEN RAISE(ABORT, 'draft versions only advance')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.idempotency_key = NEW.draft_id
+        )
+        THEN RAISE(ABORT, 'a posted draft cannot be saved again')
+    END;
+END;
@@ -0,0 +1,161 @@
+-- Stock counts record a physical count of the shelves against the stock the
+-- ledger expects. Starting a count freezes, per item or per lot, the expected
+-- quantity and value at the highest posting sequence; the user then enters
+-- observed quantities while the count is OPEN. Counts are not stock
+-- documents and are never read by balances, lots, or valuation.
+--
+-- Closing posts the counted variances as one ADJUSTMENT with reason
+-- PHYSICAL_COUNT, keyed by the closing idempotency key, whose lines carry
+-- their expected and observed quantities in adjustment_line_details. A count
+-- without variances closes without a document. At most one count is OPEN at a
+-- time; CLOSED and CANCELLED counts are final and never deleted.
+
+CREATE TABLE stock_counts (
+    id INTEGER PRIMARY KEY,
+    status TEXT NOT NULL CHECK (status IN ('OPEN', 'CLOSED', 'CANCELLED')),
+    counted_on TEXT NOT NULL CHECK (
+        length(counted_on) = 10
+        AND counted_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+    ),
+    frozen_posting_sequence INTEGER NOT NULL CHECK (frozen_posting_sequence >= 0),
+    notes TEXT CHECK (notes IS NULL OR length(trim(notes)) > 0),
+    closing_idempotency_key TEXT UNIQUE CHECK (
+        closing_idempotency_key IS NULL OR length(trim(closing_idempotency_key)) > 0
+    ),
+    adjustment_document_id INTEGER UNIQUE REFERENCES stock_documents(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    created_at_ms INTEGER NOT NULL CHECK (created_at_ms >= 0),
+    updated_at_ms INTEGER NOT NULL CHECK (updated_at_ms >= created_at_ms),
+    CHECK ((status = 'CLOSED') = (closing_idempotency_key IS NOT NULL)),
+    CHECK (adjustment_document_id IS NULL OR status = 'CLOSED')
+) STRICT;
+
+CREATE TABLE stock_count_lines (
+    id INTEGER PRIMARY KEY,
+    count_id INTEGER NOT NULL REFERENCES stock_counts(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    item_id INTEGER NOT NULL REFERENCES items(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    lot_id INTEGER REFERENCES inventory_lots(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    expected_quantity_atomic INTEGER NOT NULL CHECK (expected_quantity_atomic >= 0),
+    expected_value_micro INTEGER NOT NULL CHECK (expected_value_micro >= 0),
+    observed_quantity_atomic INTEGER CHECK (
+        observed_quantity_atomic IS NULL OR observed_quantity_atomic >= 0
+    ),
+    CHECK (expected_quantity_atomic > 0 OR expected_value_micro = 0)
+) STRICT;
+
+CREATE UNIQUE INDEX stock_counts_one_open
+    ON stock_counts (status) WHERE status = 'OPEN';
+CREATE INDEX stock_counts_counted
+    ON stock_counts (counted_on, id);
+CREATE UNIQUE INDEX stock_count_lines_target
+    ON stock_count_lines (count_id, item_id, COALESCE(lot_id, 0));
+CREATE INDEX stock_count_lines_item
+    ON stock_count_lines (item_id);
+
+CREATE TRIGGER stock_counts_no_delete
+BEFORE DELETE ON stock_counts
+BEGIN
+    SELECT RAISE(ABORT, 'stock counts must be closed or cancelled, not deleted');
+END;
+
+CREATE TRIGGER stock_counts_validate_insert
+BEFORE INSERT ON stock_counts
+BEGIN
+    SELECT CASE
+        WHEN NEW.status <> 'OPEN'
+        THEN RAISE(ABORT, 'a stock count starts open')
+    END;
+    SELECT CASE
+        WHEN NEW.frozen_posting_sequence > (
+            SELECT COALESCE(MAX(posting_sequence), 0) FROM stock_documents
+        )
+        THEN RAISE(ABORT, 'a stock count cannot freeze an unposted sequence')
+    END;
+END;
+
+CREATE TRIGGER stock_counts_validate_update
+BEFORE UPDATE ON stock_counts
+BEGIN
+    SELECT CASE
+        WHEN NEW.created_at_ms <> OLD.created_at_ms
+          OR NEW.updated_at_ms < OLD.updated_at_ms
+        THEN RAISE(ABORT, 'stock count versions only advance')
+    END;
+    SELECT CASE
+        WHEN OLD.status <> 'OPEN'
+        THEN RAISE(ABORT, 'a closed or cancelled stock count is final')
+    END;
+    SELECT CASE
+        WHEN NEW.counted_on <> OLD.counted_on
+          OR NEW.frozen_posting_sequence <> OLD.frozen_posting_sequence
+        THEN RAISE(ABORT, 'a stock count keeps its date and freeze point')
+    END;
+    SELECT CASE
+        WHEN NEW.adjustment_document_id IS NOT NULL AND NOT EXISTS (
+            SELECT 1 FROM stock_documents document
+            WHERE document.id = NEW.adjustment_document_id
+              AND document.kind = 'ADJUSTMENT'
+              AND document.reason_code = 'PHYSICAL_COUNT'
+              AND document.idempotency_key = NEW.closing_idempotency_key
+              AND document.posting_sequence > NEW.frozen_posting_sequence
+        )
+        THEN RAISE(ABORT, 'a stock count closes with its own physical-count adjustment')
+    END;
+END;
+
+CREATE TRIGGER stock_count_lines_validate_insert
+BEFORE INSERT ON stock_count_lines
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM stock_counts stock_count
+            WHERE stock_count.id = NEW.count_id AND stock_count.status = 'OPEN'
+        )
+        THEN RAISE(ABORT, 'only an open stock count can gain lines')
+    END;
+    SELECT CASE
+        WHEN NEW.lot_id IS NOT NULL AND NOT EXISTS (
+            SELECT 1 FROM inventory_lots lot
+            WHERE lot.id = NEW.lot_id AND lot.item_id = NEW.item_id
+        )
+        THEN RAISE(ABORT, 'a counted lot must belong to the counted item')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_count_lines line
+            WHERE line.count_id = NEW.count_id
+              AND line.item_id = NEW.item_id
+              AND (line.lot_id IS NULL) <> (NEW.lot_id IS NULL)
+        )
+        THEN RAISE(ABORT, 'an item is counted either as a whole or by lot')
+    END;
+END;
+
+CREATE TRIGGER stock_count_lines_validate_update
+BEFORE UPDATE ON stock_count_lines
+BEGIN
+    SELECT CASE
+        WHEN NEW.count_id <> OLD.count_id
+          OR NEW.item_id <> OLD.item_id
+          OR NEW.lot_id IS NOT OLD.lot_id
+          OR NEW.expected_quantity_atomic <> OLD.expected_quantity_atomic
+          OR NEW.expected_value_micro <> OLD.expected_value_micro
+        THEN RAISE(ABORT, 'expected stock count quantities are frozen')
+    END;
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM stock_counts stock_count
+            WHERE stock_count.id = NEW.count_id AND stock_count.status = 'OPEN'
+        )
+        THEN RAISE(ABORT, 'only an open stock count can be counted')
+    END;
+END;
+
+CREATE TRIGGER stock_count_lines_no_delete
+BEFORE DELETE ON stock_count_lines
+BEGIN
+    SELECT RAISE(ABORT, 'stock count lines are never deleted');
+END;
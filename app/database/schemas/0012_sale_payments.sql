@@ -0,0 +1,119 @@
+-- Sale payments record how a posted SALE was paid: zero or more payments per
+-- sale, each with a method and an amount in minor units. They are not stock
+-- documents and no inventory table reads or writes them; payments only read
+-- the sale, its lines, and its customer returns.
+--
+-- Payments are append-only like the ledger. A reversal is a new row that names
+-- one earlier non-reversal payment and repeats its sale, method, and amount; a
+-- payment is reversed at most once.
+--
+-- A sale's receivable is its commercial total less its unreversed customer
+-- returns, and nothing when the sale itself was reversed. CASH, PIX, and CARD
+-- settle the receivable; ON_ACCOUNT only records the part charged to the
+-- customer's account and leaves it outstanding. A new payment never takes the
+-- settled total past the receivable, and on-account entries never exceed what
+-- is still outstanding. A later return can leave a sale over-settled, which is
+-- a credit owed to the customer.
+
+CREATE TABLE sale_payments (
+    id INTEGER PRIMARY KEY,
+    idempotency_key TEXT NOT NULL UNIQUE CHECK (length(trim(idempotency_key)) > 0),
+    sale_document_id INTEGER NOT NULL REFERENCES stock_documents(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    method TEXT NOT NULL CHECK (method IN ('CASH', 'PIX', 'CARD', 'ON_ACCOUNT')),
+    amount_minor INTEGER NOT NULL CHECK (amount_minor > 0),
+    received_on TEXT NOT NULL CHECK (
+        length(received_on) = 10
+        AND received_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+    ),
+    notes TEXT CHECK (notes IS NULL OR length(trim(notes)) > 0),
+    reverses_payment_id INTEGER UNIQUE REFERENCES sale_payments(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    recorded_at_ms INTEGER NOT NULL CHECK (recorded_at_ms >= 0),
+    CHECK (reverses_payment_id IS NULL OR reverses_payment_id <> id)
+) STRICT;
+
+CREATE INDEX sale_payments_sale
+    ON sale_payments (sale_document_id, id);
+
+CREATE TRIGGER sale_payments_validate_insert
+BEFORE INSERT ON sale_payments
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM stock_documents sale
+            WHERE sale.id = NEW.sale_document_id
+              AND sale.kind = 'SALE'
+              AND sale.occurred_on <= NEW.received_on
+        )
+        THEN RAISE(ABORT, 'a payment must belong to a sale and follow its business date')
+    END;
+    SELECT CASE
+        WHEN NEW.reverses_payment_id IS NOT NULL AND NOT EXISTS (
+            SELECT 1 FROM sale_payments target
+            WHERE target.id = NEW.reverses_payment_id
+              AND target.reverses_payment_id IS NULL
+              AND target.sale_document_id = NEW.sale_document_id
+              AND target.method = NEW.method
+              AND target.amount_minor = NEW.amount_minor
+              AND target.received_on <= NEW.received_on
+        )
+        THEN RAISE(ABORT, 'a payment reversal must repeat an earlier non-reversal payment')
+    END;
+    SELECT CASE
+        WHEN NEW.reverses_payment_id IS NULL AND EXISTS (
+            SELECT 1 FROM stock_documents reversal
+            WHERE reversal.reverses_document_id = NEW.sale_document_id
+        )
+        THEN RAISE(ABORT, 'a reversed sale accepts no payments')
+    END;
+    SELECT CASE
+        WHEN NEW.reverses_payment_id IS NULL
+         AND NEW.method = 'ON_ACCOUNT'
+         AND (SELECT counterparty_id FROM stock_documents WHERE id = NEW.sale_document_id) IS NULL
+        THEN RAISE(ABORT, 'an on-account payment requires a sale to a customer')
+    END;
+    SELECT CASE
+        WHEN NEW.reverses_payment_id IS NULL AND NEW.amount_minor
+            + CASE WHEN NEW.method = 'ON_ACCOUNT' THEN (
+                SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+                    THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+                FROM sale_payments payment
+                WHERE payment.sale_document_id = NEW.sale_document_id
+                  AND payment.method = 'ON_ACCOUNT'
+            ) ELSE 0 END
+            > (
+                SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+                FROM stock_document_lines line
+                WHERE line.document_id = NEW.sale_document_id
+            ) - (
+                SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+                FROM stock_documents customer_return
+                JOIN stock_document_lines line ON line.document_id = customer_return.id
+                WHERE customer_return.returns_document_id = NEW.sale_document_id
+                  AND NOT EXISTS (
+                      SELECT 1 FROM stock_documents reversal
+                      WHERE reversal.reverses_document_id = customer_return.id
+                  )
+            ) - (
+                SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+                    THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+                FROM sale_payments payment
+                WHERE payment.sale_document_id = NEW.sale_document_id
+                  AND payment.method <> 'ON_ACCOUNT'
+            )
+        THEN RAISE(ABORT, 'a payment cannot exceed the outstanding balance of its sale')
+    END;
+END;
+
+CREATE TRIGGER sale_payments_no_update
+BEFORE UPDATE ON sale_payments
+BEGIN
+    SELECT RAISE(ABORT, 'sale payments are immutable');
+END;
+
+CREATE TRIGGER sale_payments_no_delete
+BEFORE DELETE ON sale_payments
+BEGIN
+    SELECT RAISE(ABORT, 'sale payments are reversed, not deleted');
+END;
@@ -0,0 +1,224 @@
+-- Purchase payables record when a posted PURCHASE is due to its supplier and
+-- what was paid. They are not stock documents and no inventory table reads or
+-- writes them; payables only read the purchase, its lines, and its supplier
+-- returns.
+--
+-- A payable is scheduled once per purchase. Its installments are inserted
+-- first, numbered from 1, each due no earlier than the purchase or the
+-- installment before it, and never summing past the purchase total. The
+-- purchase_payables row is inserted last and seals them: it only accepts the
+-- exact installment count and a total equal to both the installments and the
+-- purchase's commercial total, after which no installment can be added.
+--
+-- Supplier payments are append-only like sale payments. A reversal names one
+-- earlier non-reversal payment and repeats its purchase, method, and amount,
+-- and a payment is reversed at most once. A new payment never takes the paid
+-- total past the purchase total less its supplier return credits, and a
+-- reversed purchase accepts no payment.
+
+CREATE TABLE purchase_payable_installments (
+    id INTEGER PRIMARY KEY,
+    purchase_document_id INTEGER NOT NULL REFERENCES stock_documents(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    installment_number INTEGER NOT NULL CHECK (installment_number > 0),
+    due_on TEXT NOT NULL CHECK (
+        length(due_on) = 10
+        AND due_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+    ),
+    amount_minor INTEGER NOT NULL CHECK (amount_minor > 0),
+    UNIQUE (purchase_document_id, installment_number)
+) STRICT;
+
+CREATE TABLE purchase_payables (
+    purchase_document_id INTEGER PRIMARY KEY REFERENCES stock_documents(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    installment_count INTEGER NOT NULL CHECK (installment_count > 0),
+    total_minor INTEGER NOT NULL CHECK (total_minor > 0),
+    created_at_ms INTEGER NOT NULL CHECK (created_at_ms >= 0)
+) STRICT;
+
+CREATE TABLE supplier_payments (
+    id INTEGER PRIMARY KEY,
+    idempotency_key TEXT NOT NULL UNIQUE CHECK (length(trim(idempotency_key)) > 0),
+    purchase_document_id INTEGER NOT NULL REFERENCES purchase_payables(purchase_document_id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    method TEXT NOT NULL CHECK (method IN ('CASH', 'PIX', 'CARD')),
+    amount_minor INTEGER NOT NULL CHECK (amount_minor > 0),
+    paid_on TEXT NOT NULL CHECK (
+        length(paid_on) = 10
+        AND paid_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+    ),
+    notes TEXT CHECK (notes IS NULL OR length(trim(notes)) > 0),
+    reverses_payment_id INTEGER UNIQUE REFERENCES supplier_payments(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    recorded_at_ms INTEGER NOT NULL CHECK (recorded_at_ms >= 0),
+    CHECK (reverses_payment_id IS NULL OR reverses_payment_id <> id)
+) STRICT;
+
+CREATE INDEX purchase_payable_installments_due
+    ON purchase_payable_installments (due_on, purchase_document_id);
+CREATE INDEX supplier_payments_purchase
+    ON supplier_payments (purchase_document_id, id);
+
+CREATE TRIGGER purchase_payable_installments_validate_insert
+BEFORE INSERT ON purchase_payable_installments
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM stock_documents purchase
+            WHERE purchase.id = NEW.purchase_document_id
+              AND purchase.kind = 'PURCHASE'
+              AND purchase.counterparty_id IS NOT NULL
+              AND purchase.occurred_on <= NEW.due_on
+        )
+        THEN RAISE(ABORT, 'an installment must belong to a supplier purchase and follow its business date')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM stock_documents reversal
+            WHERE reversal.reverses_document_id = NEW.purchase_document_id
+        )
+        THEN RAISE(ABORT, 'a reversed purchase has no payable')
+    END;
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM purchase_payables payable
+            WHERE payable.purchase_document_id = NEW.purchase_document_id
+        )
+        THEN RAISE(ABORT, 'a scheduled payable is immutable')
+    END;
+    SELECT CASE
+        WHEN NEW.installment_number <> 1 + (
+            SELECT COUNT(*) FROM purchase_payable_installments installment
+            WHERE installment.purchase_document_id = NEW.purchase_document_id
+        )
+          OR NEW.due_on < COALESCE((
+            SELECT MAX(installment.due_on) FROM purchase_payable_installments installment
+            WHERE installment.purchase_document_id = NEW.purchase_document_id
+        ), NEW.due_on)
+        THEN RAISE(ABORT, 'installments are numbered and due in order')
+    END;
+    SELECT CASE
+        WHEN NEW.amount_minor + (
+            SELECT COALESCE(SUM(installment.amount_minor), 0)
+            FROM purchase_payable_installments installment
+            WHERE installment.purchase_document_id = NEW.purchase_document_id
+        ) > (
+            SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+            FROM stock_document_lines line
+            WHERE line.document_id = NEW.purchase_document_id
+        )
+        THEN RAISE(ABORT, 'installments cannot exceed the purchase total')
+    END;
+END;
+
+CREATE TRIGGER purchase_payable_installments_no_update
+BEFORE UPDATE ON purchase_payable_installments
+BEGIN
+    SELECT RAISE(ABORT, 'payable installments are immutable');
+END;
+
+CREATE TRIGGER purchase_payable_installments_no_delete
+BEFORE DELETE ON purchase_payable_installments
+BEGIN
+    SELECT RAISE(ABORT, 'payable installments are immutable');
+END;
+
+CREATE TRIGGER purchase_payables_validate_insert
+BEFORE INSERT ON purchase_payables
+BEGIN
+    SELECT CASE
+        WHEN NEW.installment_count <> (
+            SELECT COUNT(*) FROM purchase_payable_installments installment
+            WHERE installment.purchase_document_id = NEW.purchase_document_id
+        )
+          OR NEW.total_minor <> (
+            SELECT COALESCE(SUM(installment.amount_minor), 0)
+            FROM purchase_payable_installments installment
+            WHERE installment.purchase_document_id = NEW.purchase_document_id
+        )
+          OR NEW.total_minor <> (
+            SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+            FROM stock_document_lines line
+            WHERE line.document_id = NEW.purchase_document_id
+        )
+        THEN RAISE(ABORT, 'installments must add up to the purchase total')
+    END;
+END;
+
+CREATE TRIGGER purchase_payables_no_update
+BEFORE UPDATE ON purchase_payables
+BEGIN
+    SELECT RAISE(ABORT, 'purchase payables are immutable');
+END;
+
+CREATE TRIGGER purchase_payables_no_delete
+BEFORE DELETE ON purchase_payables
+BEGIN
+    SELECT RAISE(ABORT, 'purchase payables are immutable');
+END;
+
+CREATE TRIGGER supplier_payments_validate_insert
+BEFORE INSERT ON supplier_payments
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM stock_documents purchase
+            WHERE purchase.id = NEW.purchase_document_id
+              AND purchase.occurred_on <= NEW.paid_on
+        )
+        THEN RAISE(ABORT, 'a supplier payment must follow the business date of its purchase')
+    END;
+    SELECT CASE
+        WHEN NEW.reverses_payment_id IS NOT NULL AND NOT EXISTS (
+            SELECT 1 FROM supplier_payments target
+            WHERE target.id = NEW.reverses_payment_id
+              AND target.reverses_payment_id IS NULL
+              AND target.purchase_document_id = NEW.purchase_document_id
+              AND target.method = NEW.method
+              AND target.amount_minor = NEW.amount_minor
+              AND target.paid_on <= NEW.paid_on
+        )
+        THEN RAISE(ABORT, 'a supplier payment reversal must repeat an earlier non-reversal payment')
+    END;
+    SELECT CASE
+        WHEN NEW.reverses_payment_id IS NULL AND EXISTS (
+            SELECT 1 FROM stock_documents reversal
+            WHERE reversal.reverses_document_id = NEW.purchase_document_id
+        )
+        THEN RAISE(ABORT, 'a reversed purchase accepts no payments')
+    END;
+    SELECT CASE
+        WHEN NEW.reverses_payment_id IS NULL AND NEW.amount_minor > (
+                SELECT total_minor FROM purchase_payables
+                WHERE purchase_document_id = NEW.purchase_document_id
+            ) - (
+                SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+                FROM stock_documents supplier_return
+                JOIN stock_document_lines line ON line.document_id = supplier_return.id
+                WHERE supplier_return.returns_document_id = NEW.purchase_document_id
+                  AND NOT EXISTS (
+                      SELECT 1 FROM stock_documents reversal
+                      WHERE reversal.reverses_document_id = supplier_return.id
+                  )
+            ) - (
+                SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+                    THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+                FROM supplier_payments payment
+                WHERE payment.purchase_document_id = NEW.purchase_document_id
+            )
+        THEN RAISE(ABORT, 'a supplier payment cannot exceed the outstanding payable')
+    END;
+END;
+
+CREATE TRIGGER supplier_payments_no_update
+BEFORE UPDATE ON supplier_payments
+BEGIN
+    SELECT RAISE(ABORT, 'supplier payments are immutable');
+END;
+
+CREATE TRIGGER supplier_payments_no_delete
+BEFORE DELETE ON supplier_payments
+BEGIN
+    SELECT RAISE(ABORT, 'supplier payments are reversed, not deleted');
+END;
@@ -0,0 +1,240 @@
+-- Register sessions track the cash drawer between opening and closing. They
+-- are not stock documents and no inventory table reads or writes them.
+--
+-- At most one session is open at a time, and a session opens no earlier than
+-- the previous one closed. Every sale payment recorded while a session is open
+-- is linked to it by trigger, so the session knows exactly which payments
+-- passed through the register. Manual cash-in and cash-out movements are only
+-- recorded on the open session.
+--
+-- A session is closed by inserting its register_closings row, which freezes
+-- the net payments per method, the cash movements, and the expected cash
+-- beside the counted cash. The trigger recomputes every total from the linked
+-- payments and movements, and once the closing exists nothing more can be
+-- linked or recorded on the session.
+
+CREATE TABLE register_sessions (
+    id INTEGER PRIMARY KEY,
+    idempotency_key TEXT NOT NULL UNIQUE CHECK (length(trim(idempotency_key)) > 0),
+    business_date TEXT NOT NULL CHECK (
+        length(business_date) = 10
+        AND business_date GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+    ),
+    opening_float_minor INTEGER NOT NULL CHECK (opening_float_minor >= 0),
+    notes TEXT CHECK (notes IS NULL OR length(trim(notes)) > 0),
+    opened_at_ms INTEGER NOT NULL CHECK (opened_at_ms >= 0)
+) STRICT;
+
+CREATE TABLE register_cash_movements (
+    id INTEGER PRIMARY KEY,
+    idempotency_key TEXT NOT NULL UNIQUE CHECK (length(trim(idempotency_key)) > 0),
+    register_session_id INTEGER NOT NULL REFERENCES register_sessions(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    kind TEXT NOT NULL CHECK (kind IN ('CASH_IN', 'CASH_OUT')),
+    amount_minor INTEGER NOT NULL CHECK (amount_minor > 0),
+    notes TEXT NOT NULL CHECK (length(trim(notes)) > 0),
+    recorded_at_ms INTEGER NOT NULL CHECK (recorded_at_ms >= 0)
+) STRICT;
+
+CREATE TABLE register_session_payments (
+    sale_payment_id INTEGER PRIMARY KEY REFERENCES sale_payments(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    register_session_id INTEGER NOT NULL REFERENCES register_sessions(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT
+) STRICT;
+
+CREATE TABLE register_closings (
+    register_session_id INTEGER PRIMARY KEY REFERENCES register_sessions(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    closed_on TEXT NOT NULL CHECK (
+        length(closed_on) = 10
+        AND closed_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+    ),
+    cash_minor INTEGER NOT NULL,
+    pix_minor INTEGER NOT NULL,
+    card_minor INTEGER NOT NULL,
+    on_account_minor INTEGER NOT NULL,
+    cash_in_minor INTEGER NOT NULL CHECK (cash_in_minor >= 0),
+    cash_out_minor INTEGER NOT NULL CHECK (cash_out_minor >= 0),
+    expected_cash_minor INTEGER NOT NULL,
+    counted_cash_minor INTEGER NOT NULL CHECK (counted_cash_minor >= 0),
+    notes TEXT CHECK (notes IS NULL OR length(trim(notes)) > 0),
+    closed_at_ms INTEGER NOT NULL CHECK (closed_at_ms >= 0)
+) STRICT;
+
+CREATE INDEX register_sessions_business_date
+    ON register_sessions (business_date, id);
+CREATE INDEX register_cash_movements_session
+    ON register_cash_movements (register_session_id, id);
+CREATE INDEX register_session_payments_session
+    ON register_session_payments (register_session_id, sale_payment_id);
+
+CREATE TRIGGER register_sessions_validate_insert
+BEFORE INSERT ON register_sessions
+BEGIN
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM register_sessions session
+            WHERE NOT EXISTS (
+                SELECT 1 FROM register_closings closing
+                WHERE closing.register_session_id = session.id
+            )
+        )
+        THEN RAISE(ABORT, 'a register session is already open')
+    END;
+    SELECT CASE
+        WHEN NEW.opened_at_ms < COALESCE((SELECT MAX(closed_at_ms) FROM register_closings), 0)
+        THEN RAISE(ABORT, 'a register session opens after the previous one closed')
+    END;
+END;
+
+CREATE TRIGGER register_sessions_no_update
+BEFORE UPDATE ON register_sessions
+BEGIN
+    SELECT RAISE(ABORT, 'register sessions are immutable');
+END;
+
+CREATE TRIGGER register_sessions_no_delete
+BEFORE DELETE ON register_sessions
+BEGIN
+    SELECT RAISE(ABORT, 'register sessions are immutable');
+END;
+
+CREATE TRIGGER register_cash_movements_validate_insert
+BEFORE INSERT ON register_cash_movements
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM register_sessions session
+            WHERE session.id = NEW.register_session_id
+              AND session.opened_at_ms <= NEW.recorded_at_ms
+        )
+          OR EXISTS (
+            SELECT 1 FROM register_closings closing
+            WHERE closing.register_session_id = NEW.register_session_id
+        )
+        THEN RAISE(ABORT, 'cash movements are recorded on the open register session')
+    END;
+END;
+
+CREATE TRIGGER register_cash_movements_no_update
+BEFORE UPDATE ON register_cash_movements
+BEGIN
+    SELECT RAISE(ABORT, 'cash movements are immutable');
+END;
+
+CREATE TRIGGER register_cash_movements_no_delete
+BEFORE DELETE ON register_cash_movements
+BEGIN
+    SELECT RAISE(ABORT, 'cash movements are immutable');
+END;
+
+CREATE TRIGGER register_session_payments_validate_insert
+BEFORE INSERT ON register_session_payments
+BEGIN
+    SELECT CASE
+        WHEN EXISTS (
+            SELECT 1 FROM register_closings closing
+            WHERE closing.register_session_id = NEW.register_session_id
+        )
+        THEN RAISE(ABORT, 'payments are linked to the open register session')
+    END;
+END;
+
+CREATE TRIGGER register_session_payments_no_update
+BEFORE UPDATE ON register_session_payments
+BEGIN
+    SELECT RAISE(ABORT, 'register session payments are immutable');
+END;
+
+CREATE TRIGGER register_session_payments_no_delete
+BEFORE DELETE ON register_session_payments
+BEGIN
+    SELECT RAISE(ABORT, 'register session payments are immutable');
+END;
+
+CREATE TRIGGER sale_payments_link_register_session
+AFTER INSERT ON sale_payments
+BEGIN
+    INSERT INTO register_session_payments (sale_payment_id, register_session_id)
+    SELECT NEW.id, session.id
+    FROM register_sessions session
+    WHERE NOT EXISTS (
+        SELECT 1 FROM register_closings closing
+        WHERE closing.register_session_id = session.id
+    );
+END;
+
+CREATE TRIGGER register_closings_validate_insert
+BEFORE INSERT ON register_closings
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM register_sessions session
+            WHERE session.id = NEW.register_session_id
+              AND session.opened_at_ms <= NEW.closed_at_ms
+              AND session.business_date <= NEW.closed_on
+        )
+        THEN RAISE(ABORT, 'a register session closes after it opened')
+    END;
+    SELECT CASE
+        WHEN NEW.cash_minor <> (
+            SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+                THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+            FROM register_session_payments link
+            JOIN sale_payments payment ON payment.id = link.sale_payment_id
+            WHERE link.register_session_id = NEW.register_session_id AND payment.method = 'CASH'
+        )
+          OR NEW.pix_minor <> (
+            SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+                THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+            FROM register_session_payments link
+            JOIN sale_payments payment ON payment.id = link.sale_payment_id
+            WHERE link.register_session_id = NEW.register_session_id AND payment.method = 'PIX'
+        )
+          OR NEW.card_minor <> (
+            SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+                THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+            FROM register_session_payments link
+            JOIN sale_payments payment ON payment.id = link.sale_payment_id
+            WHERE link.register_session_id = NEW.register_session_id AND payment.method = 'CARD'
+        )
+          OR NEW.on_account_minor <> (
+            SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+                THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+            FROM register_session_payments link
+            JOIN sale_payments payment ON payment.id = link.sale_payment_id
+            WHERE link.register_session_id = NEW.register_session_id AND payment.method = 'ON_ACCOUNT'
+        )
+        THEN RAISE(ABORT, 'register closing totals must match the session payments')
+    END;
+    SELECT CASE
+        WHEN NEW.cash_in_minor <> (
+            SELECT COALESCE(SUM(movement.amount_minor), 0)
+            FROM register_cash_movements movement
+            WHERE movement.register_session_id = NEW.register_session_id AND movement.kind = 'CASH_IN'
+        )
+          OR NEW.cash_out_minor <> (
+            SELECT COALESCE(SUM(movement.amount_minor), 0)
+            FROM register_cash_movements movement
+            WHERE movement.register_session_id = NEW.register_session_id AND movement.kind = 'CASH_OUT'
+        )
+          OR NEW.expected_cash_minor <> (
+            SELECT session.opening_float_minor FROM register_sessions session
+            WHERE session.id = NEW.register_session_id
+        ) + NEW.cash_minor + NEW.cash_in_minor - NEW.cash_out_minor
+        THEN RAISE(ABORT, 'register closing totals must match the session cash movements')
+    END;
+END;
+
+CREATE TRIGGER register_closings_no_update
+BEFORE UPDATE ON register_closings
+BEGIN
+    SELECT RAISE(ABORT, 'register closings are immutable');
+END;
+
+CREATE TRIGGER register_closings_no_delete
+BEFORE DELETE ON register_closings
+BEGIN
+    SELECT RAISE(ABORT, 'register closings are immutable');
+END;
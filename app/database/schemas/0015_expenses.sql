@@ -0,0 +1,70 @@
+-- Expenses record money spent on running the business outside inventory:
+-- rent, utilities, packaging not tracked as stock, and wages. They are not
+-- stock documents and no inventory table reads or writes them.
+--
+-- Expenses are append-only. A correction is a new row naming the expense it
+-- replaces; it carries the corrected category, amount, date, supplier, and
+-- notes, and a zero amount voids the expense. Each expense is corrected at
+-- most once, so the current ledger is every row that no correction names.
+-- Only corrections may have a zero amount, and a supplier must be an active
+-- counterparty with the SUPPLIER role.
+
+CREATE TABLE expenses (
+    id INTEGER PRIMARY KEY,
+    idempotency_key TEXT NOT NULL UNIQUE CHECK (length(trim(idempotency_key)) > 0),
+    category TEXT NOT NULL CHECK (
+        category IN ('RENT', 'GAS', 'ELECTRICITY', 'WATER', 'PACKAGING', 'WAGES', 'OTHER')
+    ),
+    amount_minor INTEGER NOT NULL CHECK (amount_minor >= 0),
+    occurred_on TEXT NOT NULL CHECK (
+        length(occurred_on) = 10
+        AND occurred_on GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+    ),
+    supplier_id INTEGER REFERENCES counterparties(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    notes TEXT CHECK (notes IS NULL OR length(trim(notes)) > 0),
+    corrects_expense_id INTEGER UNIQUE REFERENCES expenses(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    recorded_at_ms INTEGER NOT NULL CHECK (recorded_at_ms >= 0),
+    CHECK (amount_minor > 0 OR corrects_expense_id IS NOT NULL),
+    CHECK (corrects_expense_id IS NULL OR corrects_expense_id <> id)
+) STRICT;
+
+CREATE INDEX expenses_occurred_on
+    ON expenses (occurred_on, id);
+
+CREATE TRIGGER expenses_validate_insert
+BEFORE INSERT ON expenses
+BEGIN
+    SELECT CASE
+        WHEN NEW.supplier_id IS NOT NULL AND NOT EXISTS (
+            SELECT 1
+            FROM counterparties counterparty
+            JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+            WHERE counterparty.id = NEW.supplier_id
+              AND counterparty.archived_at_ms IS NULL
+              AND role.role = 'SUPPLIER'
+        )
+        THEN RAISE(ABORT, 'an expense supplier must be an active supplier')
+    END;
+    SELECT CASE
+        WHEN NEW.corrects_expense_id IS NOT NULL AND NOT EXISTS (
+            SELECT 1 FROM expenses target
+            WHERE target.id = NEW.corrects_expense_id
+              AND target.recorded_at_ms <= NEW.recorded_at_ms
+        )
+        THEN RAISE(ABORT, 'a correction must follow the expense it corrects')
+    END;
+END;
+
+CREATE TRIGGER expenses_no_update
+BEFORE UPDATE ON expenses
+BEGIN
+    SELECT RAISE(ABORT, 'expenses are immutable');
+END;
+
+CREATE TRIGGER expenses_no_delete
+BEFORE DELETE ON expenses
+BEGIN
+    SELECT RAISE(ABORT, 'expenses are immutable');
+END;
@@ -0,0 +1,142 @@
+-- Price lists hold the prices agreed with groups of customers, such as cafés
+-- buying wholesale, next to the single default sale price of each item. A
+-- list may be limited to a validity window of business dates, both bounds
+-- inclusive and either one open.
+--
+-- A price is per base unit of the item when packaging_id is NULL, otherwise
+-- per one of that item's packagings. Quantity tiers are prices of the same
+-- item and packaging with a higher min_quantity_atomic; the largest tier not
+-- above the sold quantity applies. Prices are replaced as a whole when the
+-- list is edited.
+--
+-- A customer has at most one assigned list. Lists only suggest line totals:
+-- posting copies the totals onto the sale lines and never reads these tables,
+-- so editing or archiving a list leaves posted sales unchanged.
+
+CREATE TABLE price_lists (
+    id INTEGER PRIMARY KEY,
+    name TEXT NOT NULL CHECK (length(trim(name)) > 0),
+    normalized_name TEXT NOT NULL UNIQUE CHECK (length(trim(normalized_name)) > 0),
+    valid_from TEXT CHECK (
+        valid_from IS NULL OR (
+            length(valid_from) = 10
+            AND valid_from GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+        )
+    ),
+    valid_to TEXT CHECK (
+        valid_to IS NULL OR (
+            length(valid_to) = 10
+            AND valid_to GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]'
+        )
+    ),
+    created_at_ms INTEGER NOT NULL CHECK (created_at_ms >= 0),
+    updated_at_ms INTEGER NOT NULL CHECK (updated_at_ms >= created_at_ms),
+    archived_at_ms INTEGER CHECK (archived_at_ms >= updated_at_ms),
+    CHECK (valid_from IS NULL OR valid_to IS NULL OR valid_to >= valid_from)
+) STRICT;
+
+CREATE TABLE price_list_prices (
+    id INTEGER PRIMARY KEY,
+    price_list_id INTEGER NOT NULL REFERENCES price_lists(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    item_id INTEGER NOT NULL REFERENCES items(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    packaging_id INTEGER REFERENCES item_packagings(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    min_quantity_atomic INTEGER NOT NULL CHECK (min_quantity_atomic >= 0),
+    unit_price_minor INTEGER NOT NULL CHECK (unit_price_minor >= 0)
+) STRICT;
+
+CREATE UNIQUE INDEX price_list_prices_tier
+    ON price_list_prices (price_list_id, item_id, COALESCE(packaging_id, 0), min_quantity_atomic);
+
+CREATE TABLE customer_price_lists (
+    customer_id INTEGER PRIMARY KEY REFERENCES counterparties(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    price_list_id INTEGER NOT NULL REFERENCES price_lists(id)
+        ON UPDATE RESTRICT ON DELETE RESTRICT,
+    assigned_at_ms INTEGER NOT NULL CHECK (assigned_at_ms >= 0)
+) STRICT;
+
+CREATE INDEX customer_price_lists_list
+    ON customer_price_lists (price_list_id);
+
+CREATE TRIGGER price_lists_no_delete
+BEFORE DELETE ON price_lists
+BEGIN
+    SELECT RAISE(ABORT, 'price lists must be archived, not deleted');
+END;
+
+CREATE TRIGGER price_lists_archive_version_insert
+BEFORE INSERT ON price_lists
+WHEN NEW.archived_at_ms IS NOT NULL
+ AND NEW.archived_at_ms <> NEW.updated_at_ms
+BEGIN
+    SELECT RAISE(ABORT, 'price list archive timestamp must equal its optimistic version');
+END;
+
+CREATE TRIGGER price_lists_archive_version_update
+BEFORE UPDATE OF archived_at_ms, updated_at_ms ON price_lists
+WHEN NEW.archived_at_ms IS NOT NULL
+ AND NEW.archived_at_ms <> NEW.updated_at_ms
+BEGIN
+    SELECT RAISE(ABORT, 'price list archive timestamp must equal its optimistic version');
+END;
+
+-- An assigned list stays active, so archiving a list requires moving its
+-- customers to another list or unassigning them first.
+CREATE TRIGGER price_lists_preserve_assignments
+BEFORE UPDATE OF archived_at_ms ON price_lists
+WHEN NEW.archived_at_ms IS NOT NULL
+ AND EXISTS (
+    SELECT 1 FROM customer_price_lists WHERE price_list_id = OLD.id
+ )
+BEGIN
+    SELECT RAISE(ABORT, 'price list assigned to customers must remain active');
+END;
+
+CREATE TRIGGER price_list_prices_validate_packaging_insert
+BEFORE INSERT ON price_list_prices
+WHEN NEW.packaging_id IS NOT NULL
+ AND NOT EXISTS (
+    SELECT 1 FROM item_packagings
+    WHERE id = NEW.packaging_id AND item_id = NEW.item_id
+ )
+BEGIN
+    SELECT RAISE(ABORT, 'priced packaging must belong to the priced item');
+END;
+
+CREATE TRIGGER price_list_prices_no_update
+BEFORE UPDATE ON price_list_prices
+BEGIN
+    SELECT RAISE(ABORT, 'price list prices are replaced, not updated');
+END;
+
+CREATE TRIGGER customer_price_lists_validate_insert
+BEFORE INSERT ON customer_price_lists
+BEGIN
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1
+            FROM counterparties counterparty
+            JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+            WHERE counterparty.id = NEW.customer_id
+              AND counterparty.archived_at_ms IS NULL
+              AND role.role = 'CUSTOMER'
+        )
+        THEN RAISE(ABORT, 'a price list is assigned to an active customer')
+    END;
+    SELECT CASE
+        WHEN NOT EXISTS (
+            SELECT 1 FROM price_lists
+            WHERE id = NEW.price_list_id AND archived_at_ms IS NULL
+        )
+        THEN RAISE(ABORT, 'an assigned price list must be active')
+    END;
+END;
+
+CREATE TRIGGER customer_price_lists_no_update
+BEFORE UPDATE ON customer_price_lists
+BEGIN
+    SELECT RAISE(ABORT, 'customer price lists are reassigned, not updated');
+END;
@@ -5,55 +5,307 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 )
 
-var ErrRestoreRequiresRestart = errors.New("database restore is disabled until restart-safe activation is implemented")
+var (
+	ErrRestoreUnavailable = errors.New("database restore requires a file-backed database")
+	ErrProjectionMismatch = errors.New("inventory balances do not match posted ledger lines")
+)
+
+// pendingRestoreSuffix names the validated candidate staged beside the live
+// file. Its presence is the only signal that the next start must activate it.
+const pendingRestoreSuffix = ".restore-pending"
+
+// RestoreActivation reports what ActivatePendingRestore did before the live
+// database was opened.
+type RestoreActivation struct {
+	Activated        bool
+	SafetyBackupPath string
+}
 
+// Export writes a validated VACUUM INTO snapshot to destPath together with a
+// manifest sidecar describing it.
 func (d *Database) Export(destPath string) error {
+	_, err := d.export(destPath, time.Now())
+	return err
+}
+
+func (d *Database) export(destPath string, createdAt time.Time) (BackupManifest, error) {
 	if destPath == "" {
-		return errors.New("backup destination is empty")
+		return BackupManifest{}, errors.New("backup destination is empty")
 	}
 	absPath, err := filepath.Abs(destPath)
 	if err != nil {
-		return fmt.Errorf("resolve backup destination: %w", err)
+		return BackupManifest{}, fmt.Errorf("resolve backup destination: %w", err)
 	}
 	if _, err := os.Stat(absPath); err == nil {
-		return fmt.Errorf("backup destination already exists: %s", absPath)
+		return BackupManifest{}, fmt.Errorf("backup destination already exists: %s", absPath)
 	} else if !errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("inspect backup destination: %w", err)
+		return BackupManifest{}, fmt.Errorf("inspect backup destination: %w", err)
 	}
 
 	temporary, err := os.CreateTemp(filepath.Dir(absPath), ".sweeters-backup-*.db")
 	if err != nil {
-		return fmt.Errorf("reserve backup path: %w", err)
+		return BackupManifest{}, fmt.Errorf("reserve backup path: %w", err)
 	}
 	temporaryPath := temporary.Name()
 	if err := temporary.Close(); err != nil {
 		_ = os.Remove(temporaryPath)
-		return fmt.Errorf("close backup placeholder: %w", err)
+		return BackupManifest{}, fmt.Errorf("close backup placeholder: %w", err)
 	}
 	if err := os.Remove(temporaryPath); err != nil {
-		return fmt.Errorf("prepare backup path: %w", err)
+		return BackupManifest{}, fmt.Errorf("prepare backup path: %w", err)
 	}
 	defer os.Remove(temporaryPath)
 
 	if _, err := d.ExecContext(context.Background(), "VACUUM INTO ?", temporaryPath); err != nil {
-		return fmt.Errorf("export database: %w", err)
+		return BackupManifest{}, fmt.Errorf("export database: %w", err)
 	}
 	if err := validateDatabaseFile(temporaryPath); err != nil {
-		return fmt.Errorf("validate exported database: %w", err)
+		return BackupManifest{}, fmt.Errorf("validate exported database: %w", err)
+	}
+	manifest, err := describeDatabaseFile(temporaryPath)
+	if err != nil {
+		return BackupManifest{}, fmt.Errorf("describe exported database: %w", err)
+	}
+	manifest.AppVersion = d.appVersion
+	manifest.CreatedAt = createdAt.UTC().Truncate(time.Millisecond)
+	// The manifest is published first so a visible backup always has one; a
+	// failed rename below removes the orphaned sidecar again.
+	if err := writeBackupManifest(absPath, manifest); err != nil {
+		return BackupManifest{}, err
 	}
 	if err := os.Rename(temporaryPath, absPath); err != nil {
-		return fmt.Errorf("publish backup: %w", err)
+		_ = os.Remove(ManifestPath(absPath))
+		return BackupManifest{}, fmt.Errorf("publish backup: %w", err)
 	}
-	return nil
+	return manifest, nil
 }
 
+// Import stages a restore candidate beside the live database. The candidate is
+// copied with VACUUM INTO so its own file and WAL are never modified, then the
+// copy is validated completely. The live database and this connection are left
+// untouched; the process must close every store and call
+// ActivatePendingRestore before it opens the database again.
 func (d *Database) Import(srcPath string) error {
-	_ = srcPath
-	return ErrRestoreRequiresRestart
+	if d.path == "" || d.path == ":memory:" || strings.HasPrefix(d.path, "file:") {
+		return ErrRestoreUnavailable
+	}
+	if srcPath == "" {
+		return errors.New("restore source is empty")
+	}
+	absSource, err := filepath.Abs(srcPath)
+	if err != nil {
+		return fmt.Errorf("resolve restore source: %w", err)
+	}
+	absLive, err := filepath.Abs(d.path)
+	if err != nil {
+		return fmt.Errorf("resolve live database: %w", err)
+	}
+	if absSource == absLive {
+		return errors.New("restore source is the live database")
+	}
+	if info, err := os.Stat(absSource); err != nil {
+		return fmt.Errorf("inspect restore source: %w", err)
+	} else if !info.Mode().IsRegular() {
+		return fmt.Errorf("restore source is not a file: %s", absSource)
+	}
+
+	temporary, err := os.CreateTemp(filepath.Dir(absLive), ".sweeters-restore-*.db")
+	if err != nil {
+		return fmt.Errorf("reserve restore staging path: %w", err)
+	}
+	temporaryPath := temporary.Name()
+	if err := temporary.Close(); err != nil {
+		_ = os.Remove(temporaryPath)
+		return fmt.Errorf("close restore placeholder: %w", err)
+	}
+	if err := os.Remove(temporaryPath); err != nil {
+		return fmt.Errorf("prepare restore staging path: %w", err)
+	}
+	defer os.Remove(temporaryPath)
+
+	if err := copyDatabaseFile(absSource, temporaryPath); err != nil {
+		return fmt.Errorf("stage restore candidate: %w", err)
+	}
+	if err := upgradeDatabaseFile(temporaryPath); err != nil {
+		return fmt.Errorf("upgrade restore candidate: %w", err)
+	}
+	if err := validateRestoreCandidate(temporaryPath); err != nil {
+		return fmt.Errorf("validate restore candidate: %w", err)
+	}
+	if err := os.Rename(temporaryPath, absLive+pendingRestoreSuffix); err != nil {
+		return fmt.Errorf("stage restore candidate: %w", err)
+	}
+	return nil
+}
+
+// HasPendingRestore reports whether a validated candidate waits for activation.
+func HasPendingRestore(dbPath string) (bool, error) {
+	_, err := os.Stat(dbPath + pendingRestoreSuffix)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	return false, fmt.Errorf("inspect pending restore: %w", err)
+}
+
+// ActivatePendingRestore replaces dbPath with a staged candidate. It must run
+// before any connection to dbPath is opened. The candidate is validated again,
+// the current file is copied to a verified safety backup named after now, and
+// only then is the candidate renamed over the live file. A candidate that no
+// longer validates is discarded and the live file is left as it was.
+func ActivatePendingRestore(dbPath string, now time.Time) (RestoreActivation, error) {
+	pending, err := HasPendingRestore(dbPath)
+	if err != nil || !pending {
+		return RestoreActivation{}, err
+	}
+	stagedPath := dbPath + pendingRestoreSuffix
+	if err := validateRestoreCandidate(stagedPath); err != nil {
+		_ = os.Remove(stagedPath)
+		return RestoreActivation{}, fmt.Errorf("validate staged restore: %w", err)
+	}
+
+	activation := RestoreActivation{Activated: true}
+	if _, err := os.Stat(dbPath); err == nil {
+		extension := filepath.Ext(dbPath)
+		activation.SafetyBackupPath = fmt.Sprintf("%s.pre-restore-%s%s",
+			strings.TrimSuffix(dbPath, extension), now.UTC().Format("20060102T150405.000Z"), extension)
+		if err := copyDatabaseFile(dbPath, activation.SafetyBackupPath); err != nil {
+			return RestoreActivation{}, fmt.Errorf("write safety backup: %w", err)
+		}
+		if err := validateDatabaseFile(activation.SafetyBackupPath); err != nil {
+			return RestoreActivation{}, fmt.Errorf("validate safety backup: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return RestoreActivation{}, fmt.Errorf("inspect live database: %w", err)
+	}
+
+	// The safety backup already contains any committed WAL frames. Leftover
+	// sidecars must not be replayed into the restored file.
+	for _, sidecar := range []string{dbPath + "-wal", dbPath + "-shm"} {
+		if err := os.Remove(sidecar); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return RestoreActivation{}, fmt.Errorf("remove live database sidecar: %w", err)
+		}
+	}
+	if err := os.Rename(stagedPath, dbPath); err != nil {
+		return RestoreActivation{}, fmt.Errorf("activate restored database: %w", err)
+	}
+	return activation, nil
+}
+
+// copyDatabaseFile writes a self-contained copy of sourcePath, including its
+// committed WAL frames, without writing to the source.
+func copyDatabaseFile(sourcePath, destPath string) error {
+	sourceURI, err := readOnlyFileURI(sourcePath)
+	if err != nil {
+		return err
+	}
+	source, err := sql.Open("sqlite", sourceURI)
+	if err != nil {
+		return err
+	}
+	source.SetMaxOpenConns(1)
+	defer source.Close()
+	_, err = source.ExecContext(context.Background(), "VACUUM INTO ?", destPath)
+	return err
+}
+
+// upgradeDatabaseFile applies any missing forward migrations to a private
+// staged copy and leaves it as a single self-contained file, so a backup taken
+// by an older build restores into the current schema. A newer or altered
+// migration history is refused exactly as on startup.
+func upgradeDatabaseFile(dbPath string) error {
+	db, err := openConnection(dbPath, DefaultOpenOptions())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := migrateDatabase(db, schemaFS, "schemas"); err != nil {
+		return err
+	}
+	if _, err := db.Exec("PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		return err
+	}
+	if _, err := db.Exec("PRAGMA journal_mode = DELETE"); err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+// readOnlyFileURI is needed because SQLite honors mode=ro only in URI form.
+func readOnlyFileURI(dbPath string) (string, error) {
+	absPath, err := filepath.Abs(dbPath)
+	if err != nil {
+		return "", err
+	}
+	slashed := filepath.ToSlash(absPath)
+	if !strings.HasPrefix(slashed, "/") {
+		slashed = "/" + slashed
+	}
+	return (&url.URL{Scheme: "file", Path: slashed, RawQuery: "mode=ro"}).String(), nil
+}
+
+func validateRestoreCandidate(dbPath string) error {
+	if err := validateDatabaseFile(dbPath); err != nil {
+		return err
+	}
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		return err
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+	return validateLedgerProjection(db)
+}
+
+// validateLedgerProjection checks that every stored item balance equals the
+// signed sum of its posted lines. Lot and allocation detail is checked by the
+// application reconciliation; this guard is enough to refuse a file whose
+// projections were edited or truncated outside the posting transaction.
+func validateLedgerProjection(db *sql.DB) error {
+	var mismatched int
+	var firstItemID sql.NullInt64
+	err := db.QueryRow(`
+		SELECT COUNT(*), MIN(balance.item_id)
+		FROM inventory_balances balance
+		LEFT JOIN (
+			SELECT
+				item_id,
+				SUM(CASE direction WHEN 'IN' THEN quantity_atomic ELSE -quantity_atomic END) AS quantity_atomic,
+				SUM(CASE direction WHEN 'IN' THEN inventory_value_micro ELSE -inventory_value_micro END) AS inventory_value_micro
+			FROM stock_document_lines
+			GROUP BY item_id
+		) posted ON posted.item_id = balance.item_id
+		WHERE balance.quantity_atomic <> COALESCE(posted.quantity_atomic, 0)
+		   OR balance.inventory_value_micro <> COALESCE(posted.inventory_value_micro, 0)
+	`).Scan(&mismatched, &firstItemID)
+	if err != nil {
+		return fmt.Errorf("read ledger projection: %w", err)
+	}
+	if mismatched != 0 {
+		return fmt.Errorf("%w: %d item(s), first item %d", ErrProjectionMismatch, mismatched, firstItemID.Int64)
+	}
+	var orphaned int
+	if err := db.QueryRow(`
+		SELECT COUNT(DISTINCT line.item_id)
+		FROM stock_document_lines line
+		LEFT JOIN inventory_balances balance ON balance.item_id = line.item_id
+		WHERE balance.item_id IS NULL
+	`).Scan(&orphaned); err != nil {
+		return fmt.Errorf("read ledger projection: %w", err)
+	}
+	if orphaned != 0 {
+		return fmt.Errorf("%w: %d posted item(s) without a balance", ErrProjectionMismatch, orphaned)
+	}
+	return nil
 }
 
 func validateDatabaseFile(dbPath string) error {
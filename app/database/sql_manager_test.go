@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestDatabaseExportIncludesLatestCommittedWALData(t *testing.T) {
@@ -106,44 +107,196 @@ func TestDatabaseExportRemovesTemporaryFileAfterValidationFailure(t *testing.T)
 	}
 }
 
-func TestDatabaseImportRequiresRestartAndLeavesConnectionOpen(t *testing.T) {
-	dbPath := filepath.Join(t.TempDir(), "active.db")
+func TestDatabaseImportStagesCandidateWithoutTouchingLiveDatabase(t *testing.T) {
+	directory := t.TempDir()
+	dbPath := filepath.Join(directory, "app.db")
 	db := newBackupTestDatabase(t, dbPath)
 	originalConnection := db.conn
+	setBackupBusinessName(t, db, "Live before import")
+
+	candidatePath := filepath.Join(t.TempDir(), "candidate.db")
+	candidate := newBackupTestDatabase(t, candidatePath)
+	setBackupBusinessName(t, candidate, "Restored candidate")
 
-	const beforeImport = "Live before import"
-	if _, err := db.conn.Exec(`UPDATE app_settings SET business_name = ? WHERE id = 1`, beforeImport); err != nil {
+	if err := db.Import(candidatePath); err != nil {
+		t.Fatalf("import candidate: %v", err)
+	}
+	if db.conn != originalConnection {
+		t.Fatal("Import replaced the live connection")
+	}
+	if got := backupBusinessName(t, db); got != "Live before import" {
+		t.Fatalf("live business name after import = %q", got)
+	}
+	if got := backupBusinessName(t, candidate); got != "Restored candidate" {
+		t.Fatalf("candidate business name after import = %q", got)
+	}
+	pending, err := HasPendingRestore(dbPath)
+	if err != nil || !pending {
+		t.Fatalf("pending restore = %t, %v; want staged candidate", pending, err)
+	}
+	temporaryFiles, err := filepath.Glob(filepath.Join(directory, ".sweeters-restore-*.db"))
+	if err != nil {
 		t.Fatal(err)
 	}
+	if len(temporaryFiles) != 0 {
+		t.Fatalf("temporary restore files were not removed: %v", temporaryFiles)
+	}
 
-	for _, source := range []string{"", filepath.Join(t.TempDir(), "missing.db"), dbPath} {
-		err := db.Import(source)
-		if !errors.Is(err, ErrRestoreRequiresRestart) {
-			t.Fatalf("Import(%q) error = %v, want ErrRestoreRequiresRestart", source, err)
-		}
-		if db.conn != originalConnection {
-			t.Fatalf("Import(%q) replaced the live connection", source)
-		}
-		var businessName string
-		if err := db.conn.QueryRow(`SELECT business_name FROM app_settings WHERE id = 1`).Scan(&businessName); err != nil {
-			t.Fatalf("query live database after Import(%q): %v", source, err)
-		}
-		if businessName != beforeImport {
-			t.Fatalf("business name after Import(%q) = %q, want %q", source, businessName, beforeImport)
+	setBackupBusinessName(t, db, "Live after import")
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	activation, err := ActivatePendingRestore(dbPath, time.Date(2026, 7, 20, 12, 30, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("activate restore: %v", err)
+	}
+	wantSafety := filepath.Join(directory, "app.pre-restore-20260720T123000.000Z.db")
+	if !activation.Activated || activation.SafetyBackupPath != wantSafety {
+		t.Fatalf("activation = %#v, want safety backup %s", activation, wantSafety)
+	}
+	if pending, err := HasPendingRestore(dbPath); err != nil || pending {
+		t.Fatalf("pending restore after activation = %t, %v", pending, err)
+	}
+
+	safety := newBackupTestDatabase(t, activation.SafetyBackupPath)
+	if got := backupBusinessName(t, safety); got != "Live after import" {
+		t.Fatalf("safety backup business name = %q, want latest live write", got)
+	}
+	restored := newBackupTestDatabase(t, dbPath)
+	if got := backupBusinessName(t, restored); got != "Restored candidate" {
+		t.Fatalf("restored business name = %q", got)
+	}
+}
+
+func TestDatabaseImportUpgradesOlderSchemaCandidate(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	db := newBackupTestDatabase(t, dbPath)
+
+	candidatePath := filepath.Join(t.TempDir(), "older.db")
+	candidate := openMigrationTestDatabaseAt(t, candidatePath)
+	if err := migrateDatabase(candidate, embeddedBaselineOnlyFS(t), "schemas"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := candidate.Exec(`UPDATE app_settings SET business_name = 'Older build'`); err != nil {
+		t.Fatal(err)
+	}
+	if err := candidate.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Import(candidatePath); err != nil {
+		t.Fatalf("import older candidate: %v", err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ActivatePendingRestore(dbPath, time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("activate restore: %v", err)
+	}
+	restored := newBackupTestDatabase(t, dbPath)
+	if got := backupBusinessName(t, restored); got != "Older build" {
+		t.Fatalf("restored business name = %q", got)
+	}
+	version, err := readPragmaInt(restored.conn, "user_version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if supported, _ := SupportedSchemaVersion(); version != supported {
+		t.Fatalf("restored user_version = %d, want %d", version, supported)
+	}
+	if _, err := os.Stat(dbPath + pendingRestoreSuffix + "-wal"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("staged candidate left a WAL sidecar: %v", err)
+	}
+}
+
+func TestDatabaseImportRejectsProjectionThatDisagreesWithLedger(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	db := newBackupTestDatabase(t, dbPath)
+
+	candidatePath := filepath.Join(t.TempDir(), "drifted.db")
+	candidate := newBackupTestDatabase(t, candidatePath)
+	if _, err := candidate.conn.Exec(`
+		INSERT INTO items (
+			name, normalized_name, base_unit_code,
+			is_purchasable, is_producible, is_sellable,
+			created_at_ms, updated_at_ms
+		) VALUES ('Flour', 'flour', 'g', 1, 0, 0, 1, 1)
+	`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := candidate.conn.Exec(`UPDATE inventory_balances SET quantity_atomic = 5`); err != nil {
+		t.Fatal(err)
+	}
+
+	err := db.Import(candidatePath)
+	if !errors.Is(err, ErrProjectionMismatch) {
+		t.Fatalf("Import error = %v, want ErrProjectionMismatch", err)
+	}
+	if pending, err := HasPendingRestore(dbPath); err != nil || pending {
+		t.Fatalf("pending restore after rejected import = %t, %v", pending, err)
+	}
+}
+
+func TestDatabaseImportRejectsUnusableSources(t *testing.T) {
+	directory := t.TempDir()
+	dbPath := filepath.Join(directory, "app.db")
+	db := newBackupTestDatabase(t, dbPath)
+
+	notDatabase := filepath.Join(directory, "notes.txt")
+	if err := os.WriteFile(notDatabase, []byte("not a database"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, source := range []string{"", filepath.Join(directory, "missing.db"), dbPath, notDatabase} {
+		if err := db.Import(source); err == nil {
+			t.Fatalf("Import(%q) returned nil error", source)
 		}
 	}
+	if pending, err := HasPendingRestore(dbPath); err != nil || pending {
+		t.Fatalf("pending restore after rejected sources = %t, %v", pending, err)
+	}
 
-	const afterImport = "Live and writable after import"
-	if _, err := db.conn.Exec(`UPDATE app_settings SET business_name = ? WHERE id = 1`, afterImport); err != nil {
-		t.Fatalf("write live database after Import: %v", err)
+	memory, err := NewDatabase(":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memory.Close()
+	if err := memory.Import(dbPath); !errors.Is(err, ErrRestoreUnavailable) {
+		t.Fatalf("in-memory Import error = %v, want ErrRestoreUnavailable", err)
+	}
+}
+
+func TestActivatePendingRestoreWithoutCandidateLeavesDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "app.db")
+	db := newBackupTestDatabase(t, dbPath)
+	setBackupBusinessName(t, db, "Untouched")
+
+	activation, err := ActivatePendingRestore(dbPath, time.Now())
+	if err != nil || activation.Activated || activation.SafetyBackupPath != "" {
+		t.Fatalf("activation without candidate = %#v, %v", activation, err)
 	}
-	var businessName string
-	if err := db.conn.QueryRow(`SELECT business_name FROM app_settings WHERE id = 1`).Scan(&businessName); err != nil {
+	if got := backupBusinessName(t, db); got != "Untouched" {
+		t.Fatalf("business name = %q", got)
+	}
+}
+
+func setBackupBusinessName(t *testing.T, db *Database, name string) {
+	t.Helper()
+	if _, err := db.conn.Exec(`
+		UPDATE app_settings
+		SET business_name = ?, updated_at_ms = updated_at_ms + 1
+		WHERE id = 1
+	`, name); err != nil {
 		t.Fatal(err)
 	}
-	if businessName != afterImport {
-		t.Fatalf("business name after final write = %q, want %q", businessName, afterImport)
+}
+
+func backupBusinessName(t *testing.T, db *Database) string {
+	t.Helper()
+	var name string
+	if err := db.conn.QueryRow(`SELECT business_name FROM app_settings WHERE id = 1`).Scan(&name); err != nil {
+		t.Fatal(err)
 	}
+	return name
 }
 
 func newBackupTestDatabase(t *testing.T, path string) *Database {
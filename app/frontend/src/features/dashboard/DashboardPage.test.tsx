@@ -68,6 +68,8 @@ describe("DashboardPage", () => {
       currencyCode: "BRL",
       currencyMinorDigits: 2,
       totalSalesCount: 12,
+      returnCount: 0,
+      refundMinor: 0,
       commercialTotalMinor: 245_000,
       growthBasisPoints: 1_250,
       salesRevenueSeries: [
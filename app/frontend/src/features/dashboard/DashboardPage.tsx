@@ -251,7 +251,7 @@ const DashboardPage = () => {
   const categoriesData =
     dashboardMetrics.categoryMixReport?.available === true
       ? dashboardMetrics.categoryMixReport.rows.map((row) => ({
-          name: row.categoryName,
+          name: row.categoryId == null ? "Sem categoria" : row.categoryName,
           value: row.shareBasisPoints / 100,
         }))
       : [];
@@ -1,9 +1,49 @@
-import { useState } from "react";
-import { ExportDatabase } from "../../gateways/desktopBridge";
+import { useEffect, useState } from "react";
+import {
+  ExportDatabase,
+  ImportDatabase,
+  ListBackups,
+  VerifyBackup,
+} from "../../gateways/desktopBridge";
+
+const compatibilityLabels = {
+  CURRENT: "Compativel",
+  UPGRADABLE: "Compativel (sera atualizado)",
+  TOO_NEW: "Versao mais nova que o aplicativo",
+  INVALID: "Invalido",
+};
 
 const DatabasePage = () => {
   const [status, setStatus] = useState("");
   const [loading, setLoading] = useState(false);
+  const [backups, setBackups] = useState([]);
+  const [verifications, setVerifications] = useState({});
+
+  const loadBackups = async () => {
+    try {
+      setBackups(await ListBackups());
+    } catch (error) {
+      console.error(error);
+      setStatus("Erro ao listar backups.");
+    }
+  };
+
+  useEffect(() => {
+    loadBackups();
+  }, []);
+
+  const handleVerify = async (backup) => {
+    try {
+      setLoading(true);
+      const verification = await VerifyBackup(backup.path);
+      setVerifications((current) => ({ ...current, [backup.path]: verification }));
+    } catch (error) {
+      console.error(error);
+      setStatus("Erro ao verificar backup.");
+    } finally {
+      setLoading(false);
+    }
+  };
 
   const handleExport = async () => {
     try {
@@ -20,13 +60,27 @@ const DatabasePage = () => {
     }
   };
 
+  const handleImport = async () => {
+    try {
+      setLoading(true);
+      setStatus("Validando backup...");
+      await ImportDatabase();
+      setStatus("Backup validado. O aplicativo sera reiniciado para concluir a restauracao.");
+    } catch (error) {
+      console.error(error);
+      const message = `${error?.message || ""}`.toLowerCase();
+      setStatus(message.includes("cancel") ? "Operacao cancelada." : "Backup invalido ou incompativel.");
+    } finally {
+      setLoading(false);
+    }
+  };
+
   return (
     <div className="p-8">
       <div className="mb-6">
         <h1 className="text-2xl font-bold text-slate-900">Backup da base</h1>
         <p className="text-slate-500">
-          Exporte a base usando o dialog nativo do sistema. A restauracao permanece desativada ate
-          que a validacao segura do arquivo esteja implementada.
+          Exporte a base usando o dialog nativo do sistema ou restaure um backup validado.
         </p>
       </div>
 
@@ -49,19 +103,75 @@ const DatabasePage = () => {
         <div className="rounded-xl border border-slate-200 bg-white p-6 shadow-sm">
           <h2 className="text-lg font-semibold text-slate-900">Importar</h2>
           <p className="mt-1 text-sm text-slate-500">
-            A restauracao exige validacao, backup de seguranca, troca atomica e reinicio do
-            aplicativo; esse fluxo ainda nao esta disponivel.
+            O arquivo e validado antes de qualquer troca. A base atual recebe um backup de
+            seguranca e o aplicativo reinicia para ativar a restauracao.
           </p>
 
           <button
-            disabled
-            className="mt-4 cursor-not-allowed rounded-lg bg-slate-300 px-4 py-2 text-sm font-semibold text-slate-600"
+            onClick={handleImport}
+            disabled={loading}
+            className="mt-4 rounded-lg bg-pink-600 px-4 py-2 text-sm font-semibold text-white transition hover:bg-pink-700 disabled:opacity-60"
           >
-            Indisponivel
+            Importar
           </button>
         </div>
       </div>
 
+      <div className="mt-6 rounded-xl border border-slate-200 bg-white p-6 shadow-sm">
+        <h2 className="text-lg font-semibold text-slate-900">Backups automaticos</h2>
+        <p className="mt-1 text-sm text-slate-500">
+          Verificar abre uma copia do arquivo sem alterar o backup nem a base atual.
+        </p>
+        {backups.length === 0 ? (
+          <p className="mt-4 text-sm text-slate-500">Nenhum backup automatico encontrado.</p>
+        ) : (
+          <table className="mt-4 w-full text-left text-sm">
+            <thead className="text-slate-500">
+              <tr>
+                <th className="py-2">Data</th>
+                <th className="py-2">Versao</th>
+                <th className="py-2">Documentos</th>
+                <th className="py-2">Verificacao</th>
+                <th className="py-2" />
+              </tr>
+            </thead>
+            <tbody>
+              {backups.map((backup) => {
+                const verification = verifications[backup.path];
+                const documents = backup.manifest
+                  ? Object.values(backup.manifest.documentCounts).reduce((sum, count) => sum + count, 0)
+                  : null;
+                return (
+                  <tr key={backup.path} className="border-t border-slate-100">
+                    <td className="py-2">{new Date(backup.createdAtMs).toLocaleString()}</td>
+                    <td className="py-2">
+                      {backup.manifest ? `${backup.manifest.appVersion} / schema ${backup.manifest.schemaVersion}` : "-"}
+                    </td>
+                    <td className="py-2">{documents ?? "-"}</td>
+                    <td className="py-2">
+                      {verification
+                        ? `${compatibilityLabels[verification.compatibility]}${
+                            verification.manifestFound && !verification.manifestMatches ? " (manifesto divergente)" : ""
+                          }`
+                        : "-"}
+                    </td>
+                    <td className="py-2 text-right">
+                      <button
+                        onClick={() => handleVerify(backup)}
+                        disabled={loading}
+                        className="rounded-lg border border-slate-300 px-3 py-1 text-sm font-semibold text-slate-700 transition hover:bg-slate-50 disabled:opacity-60"
+                      >
+                        Verificar
+                      </button>
+                    </td>
+                  </tr>
+                );
+              })}
+            </tbody>
+          </table>
+        )}
+      </div>
+
       {status && (
         <div className="mt-6 rounded-lg border border-slate-200 bg-white px-4 py-3 text-sm text-slate-700">
           {status}
@@ -28,6 +28,7 @@ describe("EnterprisePage", () => {
       currencyMinorDigits: 2,
       hourlyLaborCost: 5000,
       defaultGrossMargin: 3000,
+      backup: { enabled: true, intervalMinutes: 1440, keepDaily: 7, keepWeekly: 4 },
       createdAtMs: 1_700_000_000_000,
       updatedAtMs: 1_700_000_000_001,
     });
@@ -59,6 +60,7 @@ describe("EnterprisePage", () => {
 
     expect(await screen.findByDisplayValue("Sweet Shop")).toBeInTheDocument();
     expect(screen.getByText("Supplier Co")).toBeInTheDocument();
+    expect(screen.getByDisplayValue("1440")).toBeInTheDocument();
     expect(screen.getAllByText("Fornecedor").length).toBeGreaterThan(0);
     expect(gatewayMocks.settingsGateway.getSettings).toHaveBeenCalledOnce();
     expect(gatewayMocks.counterpartyGateway.listCounterparties).toHaveBeenCalledWith({
@@ -23,6 +23,10 @@ interface SettingsFormState {
   currencyMinorDigits: string;
   hourlyLaborCost: string;
   defaultGrossMargin: string;
+  backupEnabled: boolean;
+  backupIntervalMinutes: string;
+  backupKeepDaily: string;
+  backupKeepWeekly: string;
 }
 
 interface CounterpartyFormState {
@@ -42,6 +46,10 @@ const emptySettingsForm: SettingsFormState = {
   currencyMinorDigits: "2",
   hourlyLaborCost: "",
   defaultGrossMargin: "",
+  backupEnabled: true,
+  backupIntervalMinutes: "1440",
+  backupKeepDaily: "7",
+  backupKeepWeekly: "4",
 };
 
 const emptyCounterpartyForm: CounterpartyFormState = {
@@ -106,6 +114,10 @@ const settingsToForm = (settings: SettingsResponse): SettingsFormState => ({
   currencyMinorDigits: String(settings.currencyMinorDigits),
   hourlyLaborCost: minorToDecimal(settings.hourlyLaborCost, settings.currencyMinorDigits),
   defaultGrossMargin: basisPointsToPercent(settings.defaultGrossMargin),
+  backupEnabled: settings.backup.enabled,
+  backupIntervalMinutes: String(settings.backup.intervalMinutes),
+  backupKeepDaily: String(settings.backup.keepDaily),
+  backupKeepWeekly: String(settings.backup.keepWeekly),
 });
 
 const rolesFromForm = (form: CounterpartyFormState): CounterpartyRole[] =>
@@ -163,6 +175,12 @@ function EnterprisePage() {
         currencyMinorDigits,
         hourlyLaborCost: parseMoneyMinor(settingsForm.hourlyLaborCost),
         defaultGrossMargin: parseBasisPoints(settingsForm.defaultGrossMargin),
+        backup: {
+          enabled: settingsForm.backupEnabled,
+          intervalMinutes: Number.parseInt(settingsForm.backupIntervalMinutes, 10),
+          keepDaily: Number.parseInt(settingsForm.backupKeepDaily, 10),
+          keepWeekly: Number.parseInt(settingsForm.backupKeepWeekly, 10),
+        },
         expectedUpdatedAtMs: settings.updatedAtMs,
       });
       setSettings(updated);
@@ -414,6 +432,51 @@ function EnterprisePage() {
                   />
                 </label>
               </div>
+              <label className="flex items-center gap-2 rounded-xl bg-slate-100 px-3 py-2 text-sm font-semibold text-slate-700">
+                <input
+                  type="checkbox"
+                  checked={settingsForm.backupEnabled}
+                  onChange={(event) =>
+                    setSettingsForm({ ...settingsForm, backupEnabled: event.target.checked })
+                  }
+                />
+                Backup automatico
+              </label>
+              <div className="grid grid-cols-3 gap-3">
+                <label className="block text-sm font-semibold text-slate-700">
+                  Intervalo (min)
+                  <input
+                    value={settingsForm.backupIntervalMinutes}
+                    onChange={(event) =>
+                      setSettingsForm({
+                        ...settingsForm,
+                        backupIntervalMinutes: event.target.value,
+                      })
+                    }
+                    className="mt-2 w-full rounded-xl border border-slate-300 px-3 py-2 outline-none focus:ring-2 focus:ring-pink-500"
+                  />
+                </label>
+                <label className="block text-sm font-semibold text-slate-700">
+                  Diarios
+                  <input
+                    value={settingsForm.backupKeepDaily}
+                    onChange={(event) =>
+                      setSettingsForm({ ...settingsForm, backupKeepDaily: event.target.value })
+                    }
+                    className="mt-2 w-full rounded-xl border border-slate-300 px-3 py-2 outline-none focus:ring-2 focus:ring-pink-500"
+                  />
+                </label>
+                <label className="block text-sm font-semibold text-slate-700">
+                  Semanais
+                  <input
+                    value={settingsForm.backupKeepWeekly}
+                    onChange={(event) =>
+                      setSettingsForm({ ...settingsForm, backupKeepWeekly: event.target.value })
+                    }
+                    className="mt-2 w-full rounded-xl border border-slate-300 px-3 py-2 outline-none focus:ring-2 focus:ring-pink-500"
+                  />
+                </label>
+              </div>
               <button
                 type="button"
                 onClick={saveSettings}
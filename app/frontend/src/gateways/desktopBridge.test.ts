@@ -3,14 +3,30 @@ import {
   adjustmentGateway,
   catalogGateway,
   counterpartyGateway,
+  customerOrderGateway,
+  draftGateway,
+  expenseGateway,
   inventoryGateway,
+  locationGateway,
+  payableGateway,
+  paymentGateway,
+  priceListGateway,
+  pricingGateway,
+  productionPlanGateway,
   purchaseGateway,
+  purchaseOrderGateway,
   referenceDataGateway,
   recipeGateway,
+  registerGateway,
   reportingGateway,
+  returnGateway,
   reversalGateway,
   saleGateway,
   settingsGateway,
+  shoppingListGateway,
+  stockCountGateway,
+  supplierReturnGateway,
+  transferGateway,
 } from "./desktopBridge";
 
 const originalBridge = window.go;
@@ -35,6 +51,7 @@ describe("desktop bridge", () => {
       timezone: "America/Sao_Paulo",
       currencyCode: "BRL",
       currencyMinorDigits: 2,
+      backup: { enabled: true, intervalMinutes: 1440, keepDaily: 7, keepWeekly: 4 },
       createdAtMs: 1_700_000_000_000,
       updatedAtMs: 1_700_000_000_001,
     };
@@ -57,6 +74,7 @@ describe("desktop bridge", () => {
       timezone: "America/Sao_Paulo",
       currencyCode: "BRL",
       currencyMinorDigits: 2,
+      backup: { ...settings.backup, intervalMinutes: 720 },
       expectedUpdatedAtMs: settings.updatedAtMs,
     };
     await expect(settingsGateway.updateSettings(request)).resolves.toEqual({
@@ -367,6 +385,66 @@ describe("desktop bridge", () => {
     expect(postPurchase).toHaveBeenCalledWith(request);
   });
 
+  it("forwards purchase order calls to the purchase order handler", async () => {
+    const order = {
+      id: 4,
+      supplierId: 3,
+      status: "SENT",
+      orderedOn: "2026-07-20",
+      createdAtMs: 1_700_000_000_000,
+      updatedAtMs: 1_700_000_000_001,
+      lines: [
+        {
+          id: 9,
+          lineOrder: 1,
+          itemId: 7,
+          quantityAtomic: 300,
+          enteredUnitCode: "g",
+          conversionNumeratorAtomic: 1,
+          conversionDenominator: 1,
+          expectedTotalMinor: 1_000,
+          receivedQuantityAtomic: 0,
+          outstandingQuantityAtomic: 300,
+        },
+      ],
+    };
+    const receipt = {
+      order: { ...order, status: "PARTIALLY_RECEIVED" },
+      purchase: { id: 12, lines: [{ commercialTotalMinor: 333 }] },
+    };
+    const sendPurchaseOrder = vi.fn().mockResolvedValue(order);
+    const receivePurchaseOrder = vi.fn().mockResolvedValue(receipt);
+    const listPurchaseOrders = vi.fn().mockResolvedValue({ items: [order] });
+    window.go = {
+      service: {
+        PurchaseOrderHandler: {
+          SendPurchaseOrder: sendPurchaseOrder,
+          ReceivePurchaseOrder: receivePurchaseOrder,
+          ListPurchaseOrders: listPurchaseOrders,
+        },
+      },
+    };
+
+    const versioned = { expectedUpdatedAtMs: 1_700_000_000_000 };
+    const receiveRequest = {
+      expectedUpdatedAtMs: 1_700_000_000_001,
+      idempotencyKey: "po-4-receipt-1",
+      occurredOn: "2026-07-21",
+      lines: [{ lineId: 9, quantityAtomic: 100 }],
+    };
+    await expect(purchaseOrderGateway.sendPurchaseOrder(4, versioned)).resolves.toEqual(order);
+    await expect(purchaseOrderGateway.receivePurchaseOrder(4, receiveRequest)).resolves.toEqual(
+      receipt,
+    );
+    await expect(purchaseOrderGateway.listPurchaseOrders({ status: "SENT" })).resolves.toEqual({
+      items: [order],
+    });
+
+    expect(sendPurchaseOrder).toHaveBeenCalledWith(4, versioned);
+    expect(receivePurchaseOrder).toHaveBeenCalledWith(4, receiveRequest);
+    expect(listPurchaseOrders).toHaveBeenCalledWith({ status: "SENT" });
+  });
+
   it("forwards adjustment listing and posting calls to the V2 adjustment handler", async () => {
     const response = {
       id: 41,
@@ -427,6 +505,101 @@ describe("desktop bridge", () => {
     expect(postAdjustment).toHaveBeenCalledWith(request);
   });
 
+  it("forwards expiry write-off calls to the adjustment handler", async () => {
+    const lots = [
+      {
+        lotId: 60,
+        itemId: 10,
+        itemName: "Farinha",
+        lotCode: "L-1",
+        expiresOn: "2026-07-01",
+        remainingQuantityAtomic: 250,
+        inventoryValueMicro: 1_250_000,
+      },
+    ];
+    const response = {
+      id: 31,
+      idempotencyKey: "expiry-1",
+      postingSequence: 6,
+      occurredOn: "2026-07-16",
+      postedAtMs: 1_000,
+      currencyCode: "BRL",
+      currencyMinorDigits: 2,
+      reasonCode: "EXPIRY" as const,
+      lines: [],
+    };
+    const listExpiredLots = vi.fn().mockResolvedValue(lots);
+    const postExpiredLots = vi.fn().mockResolvedValue(response);
+    window.go = {
+      service: {
+        AdjustmentHandler: {
+          ListExpiredLots: listExpiredLots,
+          PostExpiredLots: postExpiredLots,
+        },
+      },
+    };
+
+    const request = { idempotencyKey: "expiry-1", occurredOn: "2026-07-16", excludedLotIds: [61] };
+
+    await expect(adjustmentGateway.listExpiredLots("2026-07-16")).resolves.toEqual(lots);
+    await expect(adjustmentGateway.postExpiredLots(request)).resolves.toEqual(response);
+    expect(listExpiredLots).toHaveBeenCalledWith("2026-07-16");
+    expect(postExpiredLots).toHaveBeenCalledWith(request);
+  });
+
+  it("forwards stock count calls to the stock count handler", async () => {
+    const count = {
+      id: 3,
+      status: "OPEN" as const,
+      countedOn: "2026-07-20",
+      frozenPostingSequence: 12,
+      createdAtMs: 1_700_000_000_000,
+      updatedAtMs: 1_700_000_000_001,
+      lines: [
+        {
+          id: 8,
+          itemId: 10,
+          expectedQuantityAtomic: 1_000,
+          expectedValueMicro: 5_000_000,
+          observedQuantityAtomic: 900,
+          varianceAtomic: -100,
+          varianceValueMicro: -500_000,
+          changedSinceFreeze: false,
+        },
+      ],
+    };
+    const closing = {
+      count: { ...count, status: "CLOSED" as const, adjustmentDocumentId: 41 },
+      adjustment: { id: 41, reasonCode: "PHYSICAL_COUNT", lines: [{ quantityAtomic: 100 }] },
+    };
+    const startStockCount = vi.fn().mockResolvedValue(count);
+    const recordStockCount = vi.fn().mockResolvedValue(count);
+    const closeStockCount = vi.fn().mockResolvedValue(closing);
+    window.go = {
+      service: {
+        StockCountHandler: {
+          StartStockCount: startStockCount,
+          RecordStockCount: recordStockCount,
+          CloseStockCount: closeStockCount,
+        },
+      },
+    };
+
+    const startRequest = { countedOn: "2026-07-20", items: [{ itemId: 10, byLot: true }] };
+    const recordRequest = {
+      expectedUpdatedAtMs: 1_700_000_000_000,
+      observations: [{ lineId: 8, observedQuantityAtomic: 900 }],
+    };
+    const closeRequest = { expectedUpdatedAtMs: 1_700_000_000_001, idempotencyKey: "count-3" };
+    await expect(stockCountGateway.startStockCount(startRequest)).resolves.toEqual(count);
+    await expect(stockCountGateway.recordStockCount(3, recordRequest)).resolves.toEqual(count);
+    await expect(stockCountGateway.closeStockCount(3, closeRequest)).resolves.toEqual(closing);
+
+    expect(startStockCount).toHaveBeenCalledWith(startRequest);
+    expect(recordStockCount).toHaveBeenCalledWith(3, recordRequest);
+    expect(closeStockCount).toHaveBeenCalledWith(3, closeRequest);
+  });
+
   it("forwards reversal posting calls to the V2 reversal handler", async () => {
     const response = {
       id: 42,
@@ -480,6 +653,198 @@ describe("desktop bridge", () => {
     expect(postReversal).toHaveBeenCalledWith(request);
   });
 
+  it("forwards customer return calls to the return handler", async () => {
+    const response = {
+      id: 44,
+      idempotencyKey: "return-1",
+      postingSequence: 5,
+      saleDocumentId: 43,
+      occurredOn: "2026-07-19",
+      postedAtMs: 1_700_000_000_400,
+      currencyCode: "BRL",
+      currencyMinorDigits: 2,
+      reasonCode: "CUSTOMER_RETURN" as const,
+      lines: [
+        {
+          id: 56,
+          lineOrder: 1,
+          itemId: 11,
+          quantityAtomic: 5,
+          enteredUnitCode: "g",
+          conversionNumeratorAtomic: 1_000,
+          conversionDenominator: 1,
+          inventoryValueMicro: 150_000,
+          refundMinor: 250,
+          returnsLineId: 55,
+          allocations: [{ id: 73, lotId: 61, quantityAtomic: 5, restoresAllocationId: 72 }],
+        },
+      ],
+    };
+    const postReturn = vi.fn().mockResolvedValue(response);
+    const listSaleReturns = vi.fn().mockResolvedValue([response]);
+    window.go = {
+      service: {
+        ReturnHandler: {
+          ListSaleReturns: listSaleReturns,
+          PostReturn: postReturn,
+        },
+      },
+    };
+
+    const request = {
+      idempotencyKey: "return-1",
+      saleDocumentId: 43,
+      occurredOn: "2026-07-19",
+      lines: [{ saleLineId: 55, quantityAtomic: 5 }],
+    };
+
+    await expect(returnGateway.postReturn(request)).resolves.toEqual(response);
+    expect(postReturn).toHaveBeenCalledWith(request);
+    await expect(returnGateway.listSaleReturns(43)).resolves.toEqual([response]);
+    expect(listSaleReturns).toHaveBeenCalledWith(43);
+  });
+
+  it("forwards supplier return calls to the supplier return handler", async () => {
+    const response = {
+      id: 45,
+      idempotencyKey: "supplier-return-1",
+      postingSequence: 6,
+      purchaseDocumentId: 40,
+      counterpartyId: 21,
+      occurredOn: "2026-07-19",
+      postedAtMs: 1_700_000_000_500,
+      currencyCode: "BRL",
+      currencyMinorDigits: 2,
+      reasonCode: "SUPPLIER_RETURN" as const,
+      lines: [
+        {
+          id: 57,
+          lineOrder: 1,
+          itemId: 10,
+          quantityAtomic: 100,
+          enteredUnitCode: "g",
+          conversionNumeratorAtomic: 1_000,
+          conversionDenominator: 1,
+          inventoryValueMicro: 500_000,
+          creditMinor: 50,
+          returnsLineId: 50,
+          lotId: 60,
+          allocationId: 74,
+        },
+      ],
+    };
+    const postSupplierReturn = vi.fn().mockResolvedValue(response);
+    const listPurchaseReturns = vi.fn().mockResolvedValue([response]);
+    window.go = {
+      service: {
+        SupplierReturnHandler: {
+          ListPurchaseReturns: listPurchaseReturns,
+          PostSupplierReturn: postSupplierReturn,
+        },
+      },
+    };
+
+    const request = {
+      idempotencyKey: "supplier-return-1",
+      purchaseDocumentId: 40,
+      occurredOn: "2026-07-19",
+      lines: [{ purchaseLineId: 50, quantityAtomic: 100 }],
+    };
+
+    await expect(supplierReturnGateway.postSupplierReturn(request)).resolves.toEqual(response);
+    expect(postSupplierReturn).toHaveBeenCalledWith(request);
+    await expect(supplierReturnGateway.listPurchaseReturns(40)).resolves.toEqual([response]);
+    expect(listPurchaseReturns).toHaveBeenCalledWith(40);
+  });
+
+  it("forwards location calls to the location handler", async () => {
+    const location = {
+      id: 2,
+      name: "Storefront fridge",
+      isDefault: false,
+      createdAtMs: 1_700_000_000_000,
+      updatedAtMs: 1_700_000_000_000,
+    };
+    const listLocations = vi.fn().mockResolvedValue([location]);
+    const createLocation = vi.fn().mockResolvedValue(location);
+    const archiveLocation = vi
+      .fn()
+      .mockResolvedValue({ ...location, archivedAtMs: 1_700_000_000_001 });
+    window.go = {
+      service: {
+        LocationHandler: {
+          ListLocations: listLocations,
+          CreateLocation: createLocation,
+          ArchiveLocation: archiveLocation,
+        },
+      },
+    };
+
+    const versioned = { expectedUpdatedAtMs: 1_700_000_000_000 };
+    await expect(locationGateway.listLocations({ archiveFilter: "ACTIVE" })).resolves.toEqual([
+      location,
+    ]);
+    await expect(locationGateway.createLocation({ name: "Storefront fridge" })).resolves.toEqual(
+      location,
+    );
+    await expect(locationGateway.archiveLocation(2, versioned)).resolves.toMatchObject({
+      archivedAtMs: 1_700_000_000_001,
+    });
+
+    expect(listLocations).toHaveBeenCalledWith({ archiveFilter: "ACTIVE" });
+    expect(createLocation).toHaveBeenCalledWith({ name: "Storefront fridge" });
+    expect(archiveLocation).toHaveBeenCalledWith(2, versioned);
+  });
+
+  it("forwards transfer calls to the transfer handler", async () => {
+    const response = {
+      id: 46,
+      idempotencyKey: "transfer-1",
+      postingSequence: 7,
+      occurredOn: "2026-07-20",
+      postedAtMs: 1_700_000_000_600,
+      lines: [
+        {
+          outLineId: 58,
+          inLineId: 59,
+          lineOrder: 1,
+          itemId: 10,
+          quantityAtomic: 100,
+          enteredUnitCode: "g",
+          conversionNumeratorAtomic: 1_000,
+          conversionDenominator: 1,
+          inventoryValueMicro: 500_000,
+          sourceLotId: 60,
+          allocationId: 75,
+          fromLocationId: 1,
+          lotId: 61,
+          toLocationId: 2,
+        },
+      ],
+    };
+    const getTransfer = vi.fn().mockResolvedValue(response);
+    const postTransfer = vi.fn().mockResolvedValue(response);
+    window.go = {
+      service: {
+        TransferHandler: {
+          GetTransfer: getTransfer,
+          PostTransfer: postTransfer,
+        },
+      },
+    };
+
+    const request = {
+      idempotencyKey: "transfer-1",
+      occurredOn: "2026-07-20",
+      lines: [{ lotId: 60, quantityAtomic: 100, toLocationId: 2 }],
+    };
+
+    await expect(transferGateway.postTransfer(request)).resolves.toEqual(response);
+    expect(postTransfer).toHaveBeenCalledWith(request);
+    await expect(transferGateway.getTransfer(46)).resolves.toEqual(response);
+    expect(getTransfer).toHaveBeenCalledWith(46);
+  });
+
   it("forwards sale posting calls to the V2 sale handler", async () => {
     const response = {
       id: 43,
@@ -548,6 +913,377 @@ describe("desktop bridge", () => {
     expect(postSale).toHaveBeenCalledWith(request);
   });
 
+  it("forwards customer order calls to the customer order handler", async () => {
+    const order = {
+      id: 6,
+      customerId: 5,
+      status: "OPEN",
+      orderedOn: "2026-07-18",
+      dueOn: "2026-07-19",
+      depositMinor: 200,
+      agreedTotalMinor: 700,
+      balanceDueMinor: 500,
+      createdAtMs: 1_700_000_000_000,
+      updatedAtMs: 1_700_000_000_000,
+      lines: [
+        {
+          id: 11,
+          lineOrder: 1,
+          itemId: 7,
+          quantityAtomic: 10,
+          enteredUnitCode: "g",
+          conversionNumeratorAtomic: 1_000,
+          conversionDenominator: 1,
+          agreedTotalMinor: 700,
+        },
+      ],
+    };
+    const calendar = [{ dueOn: "2026-07-19", orders: [order] }];
+    const fulfilment = {
+      order: { ...order, status: "FULFILLED", saleDocumentId: 30 },
+      sale: { id: 30, counterpartyId: 5, lines: [{ commercialTotalMinor: 700 }] },
+    };
+    const listCustomerOrderCalendar = vi.fn().mockResolvedValue(calendar);
+    const fulfilCustomerOrder = vi.fn().mockResolvedValue(fulfilment);
+    window.go = {
+      service: {
+        CustomerOrderHandler: {
+          ListCustomerOrderCalendar: listCustomerOrderCalendar,
+          FulfilCustomerOrder: fulfilCustomerOrder,
+        },
+      },
+    };
+
+    const calendarRequest = { from: "2026-07-18", to: "2026-07-31", status: "OPEN" as const };
+    const fulfilRequest = {
+      expectedUpdatedAtMs: 1_700_000_000_000,
+      idempotencyKey: "customer-order-6-fulfil",
+      occurredOn: "2026-07-19",
+    };
+    await expect(customerOrderGateway.listCustomerOrderCalendar(calendarRequest)).resolves.toEqual(
+      calendar,
+    );
+    await expect(customerOrderGateway.fulfilCustomerOrder(6, fulfilRequest)).resolves.toEqual(
+      fulfilment,
+    );
+
+    expect(listCustomerOrderCalendar).toHaveBeenCalledWith(calendarRequest);
+    expect(fulfilCustomerOrder).toHaveBeenCalledWith(6, fulfilRequest);
+  });
+
+  it("forwards sale payment calls to the payment handler", async () => {
+    const payment = {
+      id: 4,
+      idempotencyKey: "sale-30-payment-1",
+      saleDocumentId: 30,
+      method: "PIX",
+      amountMinor: 300,
+      receivedOn: "2026-07-19",
+      recordedAtMs: 1_700_000_000_000,
+    };
+    const aging = {
+      asOf: "2026-08-25",
+      customers: [
+        {
+          customerId: 5,
+          saleCount: 1,
+          buckets: { days0To30Minor: 0, days31To60Minor: 400, over60DaysMinor: 0, totalMinor: 400 },
+        },
+      ],
+      totals: { days0To30Minor: 0, days31To60Minor: 400, over60DaysMinor: 0, totalMinor: 400 },
+    };
+    const recordSalePayment = vi.fn().mockResolvedValue(payment);
+    const getReceivablesAging = vi.fn().mockResolvedValue(aging);
+    window.go = {
+      service: {
+        PaymentHandler: {
+          RecordSalePayment: recordSalePayment,
+          GetReceivablesAging: getReceivablesAging,
+        },
+      },
+    };
+
+    const request = {
+      idempotencyKey: "sale-30-payment-1",
+      saleDocumentId: 30,
+      method: "PIX" as const,
+      amountMinor: 300,
+      receivedOn: "2026-07-19",
+    };
+    await expect(paymentGateway.recordSalePayment(request)).resolves.toEqual(payment);
+    await expect(paymentGateway.getReceivablesAging("2026-08-25")).resolves.toEqual(aging);
+
+    expect(recordSalePayment).toHaveBeenCalledWith(request);
+    expect(getReceivablesAging).toHaveBeenCalledWith("2026-08-25");
+  });
+
+  it("forwards purchase payable calls to the payable handler", async () => {
+    const due = {
+      asOf: "2026-08-26",
+      suppliers: [
+        {
+          supplierId: 2,
+          installments: [
+            {
+              purchaseDocumentId: 10,
+              number: 1,
+              dueOn: "2026-07-31",
+              amountMinor: 200,
+              openMinor: 150,
+              overdue: true,
+            },
+          ],
+          overdueMinor: 150,
+          dueThisWeekMinor: 0,
+        },
+      ],
+      overdueMinor: 150,
+      dueThisWeekMinor: 0,
+    };
+    const payment = {
+      id: 3,
+      idempotencyKey: "purchase-10-payment-1",
+      purchaseDocumentId: 10,
+      method: "PIX",
+      amountMinor: 300,
+      paidOn: "2026-08-01",
+      recordedAtMs: 1_700_000_000_000,
+    };
+    const listDuePayables = vi.fn().mockResolvedValue(due);
+    const recordSupplierPayment = vi.fn().mockResolvedValue(payment);
+    window.go = {
+      service: {
+        PayableHandler: {
+          ListDuePayables: listDuePayables,
+          RecordSupplierPayment: recordSupplierPayment,
+        },
+      },
+    };
+
+    const request = {
+      idempotencyKey: "purchase-10-payment-1",
+      purchaseDocumentId: 10,
+      method: "PIX" as const,
+      amountMinor: 300,
+      paidOn: "2026-08-01",
+    };
+    await expect(payableGateway.listDuePayables("2026-08-26")).resolves.toEqual(due);
+    await expect(payableGateway.recordSupplierPayment(request)).resolves.toEqual(payment);
+
+    expect(listDuePayables).toHaveBeenCalledWith("2026-08-26", null);
+    expect(recordSupplierPayment).toHaveBeenCalledWith(request);
+  });
+
+  it("forwards register calls to the register handler", async () => {
+    const closed = {
+      id: 2,
+      idempotencyKey: "register-open-2",
+      businessDate: "2026-07-15",
+      openingFloatMinor: 10_000,
+      openedAtMs: 1_700_000_000_000,
+      open: false,
+      totals: {
+        openingFloatMinor: 10_000,
+        cashMinor: 2_500,
+        pixMinor: 1_200,
+        cardMinor: 0,
+        onAccountMinor: 0,
+        cashInMinor: 0,
+        cashOutMinor: 500,
+        expectedCashMinor: 12_000,
+      },
+      payments: [],
+      movements: [],
+    };
+    const closeRegisterSession = vi.fn().mockResolvedValue(closed);
+    const printRegisterClosing = vi.fn().mockResolvedValue("Z report - register session 2");
+    window.go = {
+      service: {
+        RegisterHandler: {
+          CloseRegisterSession: closeRegisterSession,
+          PrintRegisterClosing: printRegisterClosing,
+        },
+      },
+    };
+
+    const request = { countedCashMinor: 11_950 };
+    await expect(registerGateway.closeRegisterSession(2, request)).resolves.toEqual(closed);
+    await expect(registerGateway.printRegisterClosing(2)).resolves.toContain("Z report");
+
+    expect(closeRegisterSession).toHaveBeenCalledWith(2, request);
+    expect(printRegisterClosing).toHaveBeenCalledWith(2);
+  });
+
+  it("forwards expense calls and the profit and loss report", async () => {
+    const rent = {
+      id: 7,
+      idempotencyKey: "expense-rent-1",
+      category: "RENT",
+      amountMinor: 120_000,
+      occurredOn: "2026-07-01",
+      recordedAtMs: 1_700_000_000_000,
+    };
+    const correction = {
+      ...rent,
+      id: 8,
+      idempotencyKey: "expense-rent-1-fix",
+      correctsExpenseId: 7,
+    };
+    const report = {
+      period: { fromOccurredOn: "2026-07-01", toOccurredOn: "2026-07-31", granularity: "MONTH" },
+      currencyCode: "BRL",
+      currencyMinorDigits: 2,
+      rows: [],
+      totals: { bucket: "", label: "", expenseMinor: 110_000, netProfitInventoryValueMicro: 0 },
+      writeOffsByReason: [],
+      expensesByCategory: [{ category: "RENT", expenseCount: 1, amountMinor: 110_000 }],
+    };
+    const recordExpense = vi.fn().mockResolvedValue(rent);
+    const correctExpense = vi.fn().mockResolvedValue(correction);
+    const getProfitAndLossReport = vi.fn().mockResolvedValue(report);
+    window.go = {
+      service: {
+        ExpenseHandler: {
+          RecordExpense: recordExpense,
+          CorrectExpense: correctExpense,
+        },
+        ReportingHandler: {
+          GetProfitAndLossReport: getProfitAndLossReport,
+        },
+      },
+    };
+
+    const request = {
+      idempotencyKey: "expense-rent-1",
+      category: "RENT" as const,
+      amountMinor: 120_000,
+      occurredOn: "2026-07-01",
+    };
+    const fix = { ...request, idempotencyKey: "expense-rent-1-fix", amountMinor: 110_000 };
+    const period = { fromOccurredOn: "2026-07-01", toOccurredOn: "2026-07-31" };
+    await expect(expenseGateway.recordExpense(request)).resolves.toEqual(rent);
+    await expect(expenseGateway.correctExpense(7, fix)).resolves.toEqual(correction);
+    await expect(reportingGateway.getProfitAndLossReport(period)).resolves.toEqual(report);
+
+    expect(recordExpense).toHaveBeenCalledWith(request);
+    expect(correctExpense).toHaveBeenCalledWith(7, fix);
+    expect(getProfitAndLossReport).toHaveBeenCalledWith(period);
+  });
+
+  it("forwards price list and sale quote calls to their handlers", async () => {
+    const wholesale = {
+      id: 3,
+      name: "Wholesale",
+      validFrom: "2026-09-01",
+      prices: [
+        {
+          itemId: 9,
+          pricedUnitNumeratorAtomic: 1_000,
+          pricedUnitDenominator: 1,
+          minQuantityAtomic: 0,
+          unitPriceMinor: 900,
+        },
+      ],
+      createdAtMs: 1_700_000_000_000,
+      updatedAtMs: 1_700_000_000_000,
+    };
+    const quotes = [
+      {
+        source: "PRICE_LIST",
+        priceListId: 3,
+        minQuantityAtomic: 0,
+        unitPriceMinor: 900,
+        commercialTotalMinor: 1_800,
+      },
+    ];
+    const createPriceList = vi.fn().mockResolvedValue(wholesale);
+    const assignCustomerPriceList = vi.fn().mockResolvedValue(wholesale);
+    const quoteSale = vi.fn().mockResolvedValue(quotes);
+    window.go = {
+      service: {
+        PriceListHandler: {
+          CreatePriceList: createPriceList,
+          AssignCustomerPriceList: assignCustomerPriceList,
+        },
+        SaleHandler: {
+          QuoteSale: quoteSale,
+        },
+      },
+    };
+
+    const request = {
+      name: "Wholesale",
+      validFrom: "2026-09-01",
+      prices: [{ itemId: 9, minQuantityAtomic: 0, unitPriceMinor: 900 }],
+    };
+    const assignment = { priceListId: 3 };
+    const quote = {
+      counterpartyId: 5,
+      occurredOn: "2026-09-02",
+      lines: [
+        {
+          itemId: 9,
+          quantityAtomic: 2_000,
+          enteredUnitCode: "g",
+          conversionNumeratorAtomic: 1_000,
+          conversionDenominator: 1,
+          commercialTotalMinor: 0,
+        },
+      ],
+    };
+    await expect(priceListGateway.createPriceList(request)).resolves.toEqual(wholesale);
+    await expect(priceListGateway.assignCustomerPriceList(5, assignment)).resolves.toEqual(
+      wholesale,
+    );
+    await expect(saleGateway.quoteSale(quote)).resolves.toEqual(quotes);
+
+    expect(createPriceList).toHaveBeenCalledWith(request);
+    expect(assignCustomerPriceList).toHaveBeenCalledWith(5, assignment);
+    expect(quoteSale).toHaveBeenCalledWith(quote);
+  });
+
+  it("forwards draft calls to the draft handler", async () => {
+    const draft = {
+      kind: "PURCHASE",
+      draftId: "purchase-draft-1",
+      payload: '{"occurredOn":"2026-07-19","lines":[]}',
+      createdAtMs: 1_700_000_000_000,
+      updatedAtMs: 1_700_000_000_500,
+    };
+    const posted = { kind: "PURCHASE", draftId: "purchase-draft-1", documentId: 40 };
+    const listDrafts = vi.fn().mockResolvedValue([draft]);
+    const saveDraft = vi.fn().mockResolvedValue(draft);
+    const postDraft = vi.fn().mockResolvedValue(posted);
+    window.go = {
+      service: {
+        DraftHandler: {
+          ListDrafts: listDrafts,
+          SaveDraft: saveDraft,
+          PostDraft: postDraft,
+        },
+      },
+    };
+
+    const saveRequest = {
+      kind: "PURCHASE" as const,
+      draftId: "purchase-draft-1",
+      payload: draft.payload,
+      expectedUpdatedAtMs: 1_700_000_000_000,
+    };
+    const postRequest = {
+      kind: "PURCHASE" as const,
+      draftId: "purchase-draft-1",
+      expectedUpdatedAtMs: draft.updatedAtMs,
+    };
+    await expect(draftGateway.listDrafts()).resolves.toEqual([draft]);
+    await expect(draftGateway.saveDraft(saveRequest)).resolves.toEqual(draft);
+    await expect(draftGateway.postDraft(postRequest)).resolves.toEqual(posted);
+
+    expect(listDrafts).toHaveBeenCalledWith(null);
+    expect(saveDraft).toHaveBeenCalledWith(saveRequest);
+    expect(postDraft).toHaveBeenCalledWith(postRequest);
+  });
+
   it("forwards recipe calls to the V2 recipe handler", async () => {
     const revision = {
       id: 81,
@@ -600,6 +1336,32 @@ describe("desktop bridge", () => {
     const getRecipe = vi.fn().mockResolvedValue(recipe);
     const getRecipeRevision = vi.fn().mockResolvedValue(revision);
     const listRecipeRevisions = vi.fn().mockResolvedValue([revision]);
+    const cost = {
+      revisionId: revision.id,
+      recipeId: recipe.id,
+      outputItemId: recipe.outputItemId,
+      standardYieldQuantityAtomic: 1_000,
+      yieldBaseUnitCode: "g",
+      totalValueMicro: 2_500_000,
+      costPerYieldBaseUnitMicro: 2_500_000,
+      complete: true,
+      components: [
+        {
+          componentId: 82,
+          order: 1,
+          itemId: 10,
+          quantityAtomic: 500,
+          enteredUnitCode: "g",
+          enteredQuantityNumerator: 1,
+          enteredQuantityDenominator: 2,
+          costSource: "AVERAGE_VALUE" as const,
+          unitValueNumeratorMicro: 5_000,
+          unitValueDenominatorAtomic: 1,
+          valueMicro: 2_500_000,
+        },
+      ],
+    };
+    const costRecipeRevision = vi.fn().mockResolvedValue(cost);
     const listRecipes = vi.fn().mockResolvedValue(page);
     const createRecipe = vi.fn().mockResolvedValue(recipe);
     const publishRecipeRevision = vi.fn().mockResolvedValue({ ...revision, id: 83, number: 2 });
@@ -612,6 +1374,7 @@ describe("desktop bridge", () => {
           GetRecipe: getRecipe,
           GetRecipeRevision: getRecipeRevision,
           ListRecipeRevisions: listRecipeRevisions,
+          CostRecipeRevision: costRecipeRevision,
           ListRecipes: listRecipes,
           CreateRecipe: createRecipe,
           PublishRecipeRevision: publishRecipeRevision,
@@ -651,6 +1414,7 @@ describe("desktop bridge", () => {
     await expect(recipeGateway.getRecipe(recipe.id)).resolves.toEqual(recipe);
     await expect(recipeGateway.getRecipeRevision(revision.id)).resolves.toEqual(revision);
     await expect(recipeGateway.listRecipeRevisions(recipe.id)).resolves.toEqual([revision]);
+    await expect(recipeGateway.costRecipeRevision(revision.id)).resolves.toEqual(cost);
     await expect(recipeGateway.listRecipes({ pageSize: 25 })).resolves.toEqual(page);
     await expect(recipeGateway.createRecipe(createRequest)).resolves.toEqual(recipe);
     await expect(recipeGateway.publishRecipeRevision(recipe.id, publishRequest)).resolves.toEqual({
@@ -673,6 +1437,7 @@ describe("desktop bridge", () => {
     expect(getRecipe).toHaveBeenCalledWith(recipe.id);
     expect(getRecipeRevision).toHaveBeenCalledWith(revision.id);
     expect(listRecipeRevisions).toHaveBeenCalledWith(recipe.id);
+    expect(costRecipeRevision).toHaveBeenCalledWith(revision.id);
     expect(listRecipes).toHaveBeenCalledWith({ pageSize: 25 });
     expect(createRecipe).toHaveBeenCalledWith(createRequest);
     expect(publishRecipeRevision).toHaveBeenCalledWith(recipe.id, publishRequest);
@@ -684,6 +1449,133 @@ describe("desktop bridge", () => {
     expect(restoreRecipe).toHaveBeenCalledWith(recipe.id, versionedRequest);
   });
 
+  it("forwards recipe pricing calls to the pricing handler", async () => {
+    const suggestion = {
+      recipeId: 7,
+      recipeName: "Cake recipe",
+      revisionId: 82,
+      currentRevisionId: 82,
+      outputItemId: 11,
+      outputItemName: "Cake",
+      currencyCode: "BRL",
+      currencyMinorDigits: 2,
+      yieldBaseUnitCode: "g",
+      preparationTimeMinutes: 50,
+      materialValueMicro: 2_750_000,
+      laborCostConfigured: true,
+      laborValueMicro: 104_166_667,
+      batchValueMicro: 106_916_667,
+      costPerYieldBaseUnitMicro: 106_916_667,
+      grossMarginBasisPoints: 2_500,
+      suggestedPriceMinor: 14_256,
+      currentPriceMinor: 1_250,
+      underpriced: true,
+      costComplete: true,
+    };
+    const suggestRecipePrice = vi.fn().mockResolvedValue(suggestion);
+    const report = { items: [suggestion], costIncomplete: [] };
+    const listUnderpricedItems = vi.fn().mockResolvedValue(report);
+    window.go = {
+      service: {
+        PricingHandler: {
+          SuggestRecipePrice: suggestRecipePrice,
+          ListUnderpricedItems: listUnderpricedItems,
+        },
+      },
+    };
+
+    await expect(
+      pricingGateway.suggestRecipePrice({ revisionId: 82, grossMarginBasisPoints: 2_500 }),
+    ).resolves.toEqual(suggestion);
+    await expect(pricingGateway.listUnderpricedItems()).resolves.toEqual(report);
+
+    expect(suggestRecipePrice).toHaveBeenCalledWith({ revisionId: 82, grossMarginBasisPoints: 2_500 });
+    expect(listUnderpricedItems).toHaveBeenCalledWith({});
+  });
+
+  it("forwards shopping list calls to the shopping list handler", async () => {
+    const request = {
+      businessDate: "2026-10-20",
+      runs: [{ recipeRevisionId: 82, quantityAtomic: 2_000 }],
+    };
+    const shoppingList = {
+      businessDate: "2026-10-20",
+      groups: [
+        {
+          supplierId: 3,
+          supplierName: "Mill",
+          lines: [
+            {
+              itemId: 10,
+              itemName: "Flour",
+              baseUnitCode: "g",
+              baseConversionNumeratorAtomic: 1_000,
+              baseConversionDenominator: 1,
+              requiredQuantityAtomic: 700,
+              availableQuantityAtomic: 150,
+              reorderQuantityAtomic: 100,
+              toBuyQuantityAtomic: 650,
+              packagingName: "Bag",
+              packagingUnitCode: "g",
+              packages: 3,
+            },
+          ],
+        },
+      ],
+    };
+    const csv = "supplier_id,supplier\n3,Mill\n";
+    const buildShoppingList = vi.fn().mockResolvedValue(shoppingList);
+    const exportShoppingListCSV = vi.fn().mockResolvedValue(csv);
+    window.go = {
+      service: {
+        ShoppingListHandler: {
+          BuildShoppingList: buildShoppingList,
+          ExportShoppingListCSV: exportShoppingListCSV,
+        },
+      },
+    };
+
+    await expect(shoppingListGateway.buildShoppingList(request)).resolves.toEqual(shoppingList);
+    await expect(shoppingListGateway.exportShoppingListCSV(request)).resolves.toBe(csv);
+
+    expect(buildShoppingList).toHaveBeenCalledWith(request);
+    expect(exportShoppingListCSV).toHaveBeenCalledWith(request);
+  });
+
+  it("forwards production plan calls to the production plan handler", async () => {
+    const request = { itemId: 12, quantityAtomic: 2_000, recipeIds: [7] };
+    const plan = {
+      itemId: 12,
+      quantityAtomic: 2_000,
+      runs: [
+        {
+          recipeId: 7,
+          recipeRevisionId: 82,
+          outputItemId: 12,
+          level: 0,
+          requiredQuantityAtomic: 2_000,
+          fromStockQuantityAtomic: 500,
+          quantityAtomic: 1_500,
+          inputs: [{ itemId: 10, quantityAtomic: 825 }],
+        },
+      ],
+      purchases: [
+        {
+          itemId: 10,
+          requiredQuantityAtomic: 825,
+          fromStockQuantityAtomic: 150,
+          quantityAtomic: 675,
+        },
+      ],
+    };
+    const planProduction = vi.fn().mockResolvedValue(plan);
+    window.go = { service: { ProductionPlanHandler: { PlanProduction: planProduction } } };
+
+    await expect(productionPlanGateway.planProduction(request)).resolves.toEqual(plan);
+
+    expect(planProduction).toHaveBeenCalledWith(request);
+  });
+
   it("forwards inventory read calls to the V2 inventory handler", async () => {
     const balancePage = {
       items: [
@@ -714,6 +1606,7 @@ describe("desktop bridge", () => {
         sourceDocumentId: 40,
         sourceKind: "PURCHASE",
         sourceOccurredOn: "2026-07-15",
+        locationId: 1,
       },
     ];
     const ledger = {
@@ -756,19 +1649,23 @@ describe("desktop bridge", () => {
       },
     };
 
-    const balanceRequest = { includeArchived: true, pageSize: 25 };
+    const balanceRequest = { includeArchived: true, locationId: 2, pageSize: 25 };
     await expect(inventoryGateway.listInventoryBalances(balanceRequest)).resolves.toEqual(
       balancePage,
     );
     await expect(inventoryGateway.listItemLotFacts(10)).resolves.toEqual(lots);
     await expect(inventoryGateway.listEligibleFefoLots(10, "2026-07-15")).resolves.toEqual(lots);
+    await expect(inventoryGateway.listEligibleFefoLots(10, "2026-07-15", 2)).resolves.toEqual(
+      lots,
+    );
     await expect(
       inventoryGateway.listItemLedgerPage({ itemId: 10, pageSize: 10 }),
     ).resolves.toEqual(ledger);
 
     expect(listInventoryBalances).toHaveBeenCalledWith(balanceRequest);
     expect(listItemLotFacts).toHaveBeenCalledWith(10);
-    expect(listEligibleFEFOLots).toHaveBeenCalledWith(10, "2026-07-15");
+    expect(listEligibleFEFOLots).toHaveBeenCalledWith(10, "2026-07-15", null);
+    expect(listEligibleFEFOLots).toHaveBeenCalledWith(10, "2026-07-15", 2);
     expect(listItemLedgerPage).toHaveBeenCalledWith({ itemId: 10, pageSize: 10 });
   });
 
@@ -789,6 +1686,8 @@ describe("desktop bridge", () => {
       currencyCode: "BRL",
       currencyMinorDigits: 2,
       totalSalesCount: 1,
+      returnCount: 0,
+      refundMinor: 0,
       commercialTotalMinor: 1_000,
       cogsInventoryValueMicro: 600_000,
       grossMarginInventoryValueMicro: 9_400_000,
@@ -844,6 +1743,7 @@ describe("desktop bridge", () => {
       ],
       topSuppliersBySpend: [],
       freeStockEntries: [],
+      payables: { payableCount: 0, owedMinor: 0, paidMinor: 0, outstandingMinor: 0 },
     };
     const productionReport = {
       period: request,
@@ -930,12 +1830,17 @@ describe("desktop bridge", () => {
 
     await expect(reportingGateway.getSalesReport(request)).resolves.toEqual(salesReport);
     await expect(reportingGateway.getInventoryReport(request)).resolves.toEqual(inventoryReport);
+    const fridgeRequest = { ...request, locationId: 2 };
+    await expect(reportingGateway.getInventoryReport(fridgeRequest)).resolves.toEqual(
+      inventoryReport,
+    );
     await expect(reportingGateway.getPurchaseReport(request)).resolves.toEqual(purchaseReport);
     await expect(reportingGateway.getProductionReport(request)).resolves.toEqual(productionReport);
     await expect(reportingGateway.getAdjustmentReport(request)).resolves.toEqual(adjustmentReport);
     await expect(reportingGateway.getCategoryMixReport(request)).resolves.toEqual(categoryMix);
     expect(getSalesReport).toHaveBeenCalledWith(request);
     expect(getInventoryReport).toHaveBeenCalledWith(request);
+    expect(getInventoryReport).toHaveBeenCalledWith(fridgeRequest);
     expect(getPurchaseReport).toHaveBeenCalledWith(request);
     expect(getProductionReport).toHaveBeenCalledWith(request);
     expect(getAdjustmentReport).toHaveBeenCalledWith(request);
@@ -17,6 +17,52 @@ export type ArchiveFilter = "ACTIVE" | "ARCHIVED" | "ALL";
 export type CounterpartyRole = "SUPPLIER" | "CUSTOMER";
 export type MeasurementDimension = "MASS" | "VOLUME" | "COUNT";
 
+export interface BackupPolicy {
+  enabled: boolean;
+  intervalMinutes: number;
+  keepDaily: number;
+  keepWeekly: number;
+}
+
+export interface BackupMigration {
+  version: number;
+  name: string;
+  checksum: string;
+}
+
+export interface BackupManifest {
+  appVersion: string;
+  createdAtMs: number;
+  schemaVersion: number;
+  migrations: BackupMigration[];
+  fileSha256: string;
+  fileSizeBytes: number;
+  lastPostingSequence: number;
+  documentCounts: Record<string, number>;
+}
+
+export interface BackupResponse {
+  name: string;
+  path: string;
+  createdAtMs: number;
+  sizeBytes: number;
+  manifest?: BackupManifest;
+  manifestError?: string;
+}
+
+export type BackupCompatibility = "CURRENT" | "UPGRADABLE" | "TOO_NEW" | "INVALID";
+
+export interface BackupVerificationResponse {
+  path: string;
+  compatibility: BackupCompatibility;
+  problem?: string;
+  supportedSchemaVersion: number;
+  contents: BackupManifest;
+  manifestFound: boolean;
+  manifestMatches: boolean;
+  manifest?: BackupManifest;
+}
+
 export interface SettingsResponse {
   businessName: string;
   locale: string;
@@ -25,6 +71,7 @@ export interface SettingsResponse {
   currencyMinorDigits: number;
   hourlyLaborCost?: number | null;
   defaultGrossMargin?: number | null;
+  backup: BackupPolicy;
   createdAtMs: number;
   updatedAtMs: number;
 }
@@ -37,6 +84,7 @@ export interface SettingsUpdateRequest {
   currencyMinorDigits: number;
   hourlyLaborCost?: number | null;
   defaultGrossMargin?: number | null;
+  backup: BackupPolicy;
   expectedUpdatedAtMs: number;
 }
 
@@ -93,6 +141,7 @@ export interface ItemSummaryResponse {
   description?: string | null;
   baseUnitCode: string;
   capabilities: CapabilitiesResponse;
+  categoryId?: number | null;
   defaultSalePrice?: number | null;
   reorderQuantityAtomic?: number | null;
   createdAtMs: number;
@@ -111,6 +160,7 @@ export interface ItemWriteRequest {
   description?: string | null;
   baseUnitCode: string;
   capabilities: CapabilitiesRequest;
+  categoryId?: number | null;
   defaultSalePrice?: number | null;
   reorderQuantityAtomic?: number | null;
 }
@@ -119,6 +169,47 @@ export interface ItemUpdateRequest extends ItemWriteRequest {
   expectedUpdatedAtMs: number;
 }
 
+export interface CategoryListRequest {
+  archiveFilter?: ArchiveFilter;
+}
+
+export interface CategoryResponse {
+  id: number;
+  name: string;
+  createdAtMs: number;
+  updatedAtMs: number;
+  archivedAtMs?: number | null;
+}
+
+export interface CategoryWriteRequest {
+  name: string;
+}
+
+export interface CategoryUpdateRequest extends CategoryWriteRequest {
+  expectedUpdatedAtMs: number;
+}
+
+export interface LocationListRequest {
+  archiveFilter?: ArchiveFilter;
+}
+
+export interface LocationResponse {
+  id: number;
+  name: string;
+  isDefault: boolean;
+  createdAtMs: number;
+  updatedAtMs: number;
+  archivedAtMs?: number | null;
+}
+
+export interface LocationWriteRequest {
+  name: string;
+}
+
+export interface LocationUpdateRequest extends LocationWriteRequest {
+  expectedUpdatedAtMs: number;
+}
+
 export interface VersionedRequest {
   expectedUpdatedAtMs: number;
 }
@@ -271,6 +362,93 @@ export interface PurchaseLineResponse {
   expiresOn?: string | null;
 }
 
+export type PurchaseOrderStatus =
+  | "DRAFT"
+  | "SENT"
+  | "PARTIALLY_RECEIVED"
+  | "CLOSED"
+  | "CANCELLED";
+
+export interface PurchaseOrderWriteRequest {
+  supplierId: number;
+  orderedOn: string;
+  expectedOn?: string | null;
+  notes?: string | null;
+  lines: PurchaseOrderLineRequest[];
+}
+
+export interface PurchaseOrderUpdateRequest extends PurchaseOrderWriteRequest {
+  expectedUpdatedAtMs: number;
+}
+
+export interface PurchaseOrderLineRequest {
+  itemId: number;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  expectedTotalMinor: number;
+}
+
+export interface PurchaseOrderReceiveRequest {
+  expectedUpdatedAtMs: number;
+  idempotencyKey: string;
+  occurredOn: string;
+  notes?: string | null;
+  lines: PurchaseOrderReceiptLineRequest[];
+}
+
+export interface PurchaseOrderReceiptLineRequest {
+  lineId: number;
+  quantityAtomic: number;
+  commercialTotalMinor?: number | null;
+  lotCode?: string | null;
+  expiresOn?: string | null;
+}
+
+export interface PurchaseOrderResponse {
+  id: number;
+  supplierId: number;
+  status: PurchaseOrderStatus;
+  orderedOn: string;
+  expectedOn?: string | null;
+  notes?: string | null;
+  createdAtMs: number;
+  updatedAtMs: number;
+  lines: PurchaseOrderLineResponse[];
+}
+
+export interface PurchaseOrderLineResponse {
+  id: number;
+  lineOrder: number;
+  itemId: number;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  expectedTotalMinor: number;
+  receivedQuantityAtomic: number;
+  outstandingQuantityAtomic: number;
+}
+
+export interface PurchaseOrderReceiptResponse {
+  order: PurchaseOrderResponse;
+  purchase: PurchaseDocumentResponse;
+}
+
+export interface PurchaseOrderListRequest {
+  status?: PurchaseOrderStatus | null;
+  after?: number | null;
+  pageSize?: number;
+}
+
+export interface PurchaseOrderPageResponse {
+  items: PurchaseOrderResponse[];
+  next?: number | null;
+}
+
 export interface InventoryBalanceCursorRequest {
   itemName: string;
   itemId: number;
@@ -284,6 +462,7 @@ export interface InventoryBalanceCursorResponse {
 export interface InventoryBalanceListRequest {
   includeArchived?: boolean;
   search?: string | null;
+  locationId?: number | null;
   after?: InventoryBalanceCursorRequest | null;
   pageSize?: number;
 }
@@ -322,6 +501,8 @@ export interface LotResponse {
   sourceDocumentId: number;
   sourceKind: string;
   sourceOccurredOn: string;
+  locationId: number;
+  transferredFromLotId?: number | null;
 }
 
 export interface LedgerCursorRequest {
@@ -423,6 +604,23 @@ export interface AdjustmentLineRequest {
   expiresOn?: string | null;
 }
 
+export interface ExpiredLotsPostRequest {
+  idempotencyKey: string;
+  occurredOn: string;
+  notes?: string | null;
+  excludedLotIds?: number[];
+}
+
+export interface ExpiredLotResponse {
+  lotId: number;
+  itemId: number;
+  itemName: string;
+  lotCode?: string | null;
+  expiresOn: string;
+  remainingQuantityAtomic: number;
+  inventoryValueMicro: number;
+}
+
 export interface AdjustmentDocumentResponse {
   id: number;
   idempotencyKey: string;
@@ -480,6 +678,76 @@ export interface AdjustmentAllocationResponse {
   quantityAtomic: number;
 }
 
+export type StockCountStatus = "OPEN" | "CLOSED" | "CANCELLED";
+
+export interface StockCountItemRequest {
+  itemId: number;
+  byLot?: boolean;
+}
+
+export interface StockCountStartRequest {
+  countedOn: string;
+  notes?: string | null;
+  items?: StockCountItemRequest[];
+}
+
+export interface StockCountObservationRequest {
+  lineId: number;
+  observedQuantityAtomic?: number | null;
+}
+
+export interface StockCountRecordRequest {
+  expectedUpdatedAtMs: number;
+  observations: StockCountObservationRequest[];
+}
+
+export interface StockCountCloseRequest {
+  expectedUpdatedAtMs: number;
+  idempotencyKey: string;
+}
+
+export interface StockCountResponse {
+  id: number;
+  status: StockCountStatus;
+  countedOn: string;
+  frozenPostingSequence: number;
+  notes?: string | null;
+  adjustmentDocumentId?: number | null;
+  createdAtMs: number;
+  updatedAtMs: number;
+  lines: StockCountLineResponse[];
+}
+
+export interface StockCountLineResponse {
+  id: number;
+  itemId: number;
+  lotId?: number | null;
+  lotCode?: string | null;
+  expiresOn?: string | null;
+  expectedQuantityAtomic: number;
+  expectedValueMicro: number;
+  observedQuantityAtomic?: number | null;
+  varianceAtomic?: number | null;
+  varianceValueMicro?: number | null;
+  changedSinceFreeze: boolean;
+}
+
+export interface StockCountClosingResponse {
+  count: StockCountResponse;
+  adjustment?: AdjustmentDocumentResponse | null;
+}
+
+export interface StockCountListRequest {
+  status?: StockCountStatus | null;
+  after?: number | null;
+  pageSize?: number;
+}
+
+export interface StockCountPageResponse {
+  items: StockCountResponse[];
+  next?: number | null;
+}
+
 export interface ReversalPostRequest {
   idempotencyKey: string;
   targetDocumentId: number;
@@ -524,154 +792,766 @@ export interface ReversalAllocationResponse {
   restoresAllocationId?: number | null;
 }
 
-export interface ProductionPostRequest {
+export interface ReturnPostRequest {
   idempotencyKey: string;
-  recipeRevisionId: number;
+  saleDocumentId: number;
   occurredOn: string;
-  directCostMicro: number;
   notes?: string | null;
-  output: ProductionOutputRequest;
-  inputs: ProductionComponentRequest[];
-}
-
-export interface ProductionOutputRequest {
-  quantityAtomic: number;
-  enteredUnitCode: string;
-  enteredPackagingName?: string | null;
-  conversionNumeratorAtomic: number;
-  conversionDenominator: number;
-  lotCode?: string | null;
-  expiresOn?: string | null;
+  lines: ReturnLineRequest[];
 }
 
-export interface ProductionComponentRequest {
-  itemId: number;
+export interface ReturnLineRequest {
+  saleLineId: number;
   quantityAtomic: number;
-  enteredUnitCode: string;
-  enteredPackagingName?: string | null;
-  conversionNumeratorAtomic: number;
-  conversionDenominator: number;
-  lotId?: number | null;
+  refundMinor?: number | null;
 }
 
-export interface ProductionDocumentResponse {
+export interface ReturnDocumentResponse {
   id: number;
   idempotencyKey: string;
   postingSequence: number;
-  recipeRevisionId: number;
-  outputItemId: number;
+  saleDocumentId: number;
+  counterpartyId?: number | null;
   occurredOn: string;
   postedAtMs: number;
   currencyCode: string;
   currencyMinorDigits: number;
-  directCostMicro: number;
+  reasonCode: "CUSTOMER_RETURN";
   notes?: string | null;
-  outputLine: ProductionLineResponse;
-  inputLines: ProductionLineResponse[];
+  lines: ReturnLineResponse[];
 }
 
-export interface ProductionLineResponse {
+export interface ReturnLineResponse {
   id: number;
   lineOrder: number;
   itemId: number;
-  direction: StockDirection;
   quantityAtomic: number;
   enteredUnitCode: string;
   enteredPackagingName?: string | null;
   conversionNumeratorAtomic: number;
   conversionDenominator: number;
   inventoryValueMicro: number;
-  lotId?: number | null;
-  lotCode?: string | null;
-  originatedOn?: string | null;
-  expiresOn?: string | null;
-  allocations: ProductionAllocationResponse[];
+  refundMinor: number;
+  returnsLineId: number;
+  allocations: ReturnAllocationResponse[];
 }
 
-export interface ProductionAllocationResponse {
+export interface ReturnAllocationResponse {
   id: number;
   lotId: number;
   quantityAtomic: number;
+  restoresAllocationId: number;
 }
 
-export type SaleReason = "PROMOTION" | "SAMPLE";
-
-export interface SalePostRequest {
+export interface SupplierReturnPostRequest {
   idempotencyKey: string;
-  counterpartyId?: number | null;
+  purchaseDocumentId: number;
   occurredOn: string;
-  reasonCode?: SaleReason | null;
   notes?: string | null;
-  lines: SaleLineRequest[];
+  lines: SupplierReturnLineRequest[];
 }
 
-export interface SaleLineRequest {
-  itemId: number;
+export interface SupplierReturnLineRequest {
+  purchaseLineId: number;
   quantityAtomic: number;
-  enteredUnitCode: string;
-  enteredPackagingName?: string | null;
-  conversionNumeratorAtomic: number;
-  conversionDenominator: number;
-  commercialTotalMinor: number;
-  lotId?: number | null;
+  creditMinor?: number | null;
 }
 
-export interface SaleDocumentResponse {
+export interface SupplierReturnDocumentResponse {
   id: number;
   idempotencyKey: string;
   postingSequence: number;
+  purchaseDocumentId: number;
   counterpartyId?: number | null;
   occurredOn: string;
   postedAtMs: number;
   currencyCode: string;
   currencyMinorDigits: number;
-  reasonCode?: SaleReason | null;
+  reasonCode: "SUPPLIER_RETURN";
   notes?: string | null;
-  lines: SaleLineResponse[];
+  lines: SupplierReturnLineResponse[];
 }
 
-export interface SaleLineResponse {
+export interface SupplierReturnLineResponse {
   id: number;
   lineOrder: number;
   itemId: number;
-  direction: StockDirection;
   quantityAtomic: number;
   enteredUnitCode: string;
   enteredPackagingName?: string | null;
   conversionNumeratorAtomic: number;
   conversionDenominator: number;
   inventoryValueMicro: number;
-  commercialTotalMinor: number;
-  allocations: SaleAllocationResponse[];
+  creditMinor: number;
+  returnsLineId: number;
+  lotId: number;
+  allocationId: number;
 }
 
-export interface SaleAllocationResponse {
-  id: number;
+export interface TransferPostRequest {
+  idempotencyKey: string;
+  occurredOn: string;
+  notes?: string | null;
+  lines: TransferLineRequest[];
+}
+
+export interface TransferLineRequest {
   lotId: number;
   quantityAtomic: number;
+  toLocationId: number;
 }
 
-export interface SaleCursorRequest {
-  postingSequence: number;
+export interface TransferDocumentResponse {
   id: number;
+  idempotencyKey: string;
+  postingSequence: number;
+  occurredOn: string;
+  postedAtMs: number;
+  notes?: string | null;
+  lines: TransferLineResponse[];
 }
 
-export interface SaleCursorResponse {
-  postingSequence: number;
-  id: number;
+export interface TransferLineResponse {
+  outLineId: number;
+  inLineId: number;
+  lineOrder: number;
+  itemId: number;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  inventoryValueMicro: number;
+  sourceLotId: number;
+  allocationId: number;
+  fromLocationId: number;
+  lotId: number;
+  toLocationId: number;
 }
 
-export interface SaleListRequest {
-  after?: SaleCursorRequest | null;
-  pageSize?: number;
+export interface ProductionPostRequest {
+  idempotencyKey: string;
+  recipeRevisionId: number;
+  occurredOn: string;
+  directCostMicro: number;
+  notes?: string | null;
+  output: ProductionOutputRequest;
+  inputs: ProductionComponentRequest[];
 }
 
-export interface SalePageResponse {
-  items: SaleDocumentResponse[];
-  next?: SaleCursorResponse | null;
+export interface ProductionOutputRequest {
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  lotCode?: string | null;
+  expiresOn?: string | null;
 }
 
-export interface RecipeCursorRequest {
+export interface ProductionComponentRequest {
+  itemId: number;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  lotId?: number | null;
+}
+
+export interface ProductionScaleRequest {
+  recipeRevisionId: number;
+  multiplier?: string | null;
+  outputQuantityAtomic?: number | null;
+}
+
+export interface ScaledProductionInputResponse {
+  input: ProductionComponentRequest;
+  exactNumerator: number;
+  exactDenominator: number;
+  residueNumerator: number;
+  residueDenominator: number;
+}
+
+export interface ScaledProductionResponse {
+  recipeRevisionId: number;
+  outputItemId: number;
+  multiplierNumerator: number;
+  multiplierDenominator: number;
+  outputQuantityAtomic: number;
+  outputExactNumerator: number;
+  outputExactDenominator: number;
+  outputResidueNumerator: number;
+  outputResidueDenominator: number;
+  inputs: ScaledProductionInputResponse[];
+}
+
+export interface ProductionCursorRequest {
+  postingSequence: number;
+  id: number;
+}
+
+export interface ProductionCursorResponse {
+  postingSequence: number;
+  id: number;
+}
+
+export interface ProductionListRequest {
+  recipeId?: number | null;
+  outputItemId?: number | null;
+  fromOccurredOn?: string | null;
+  toOccurredOn?: string | null;
+  after?: ProductionCursorRequest | null;
+  pageSize?: number;
+}
+
+export interface ProductionPageResponse {
+  items: ProductionDocumentResponse[];
+  next?: ProductionCursorResponse | null;
+}
+
+export interface ProductionDocumentResponse {
+  id: number;
+  idempotencyKey: string;
+  postingSequence: number;
+  recipeRevisionId: number;
+  outputItemId: number;
+  occurredOn: string;
+  postedAtMs: number;
+  currencyCode: string;
+  currencyMinorDigits: number;
+  directCostMicro: number;
+  notes?: string | null;
+  outputLine: ProductionLineResponse;
+  inputLines: ProductionLineResponse[];
+}
+
+export interface ProductionLineResponse {
+  id: number;
+  lineOrder: number;
+  itemId: number;
+  direction: StockDirection;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  inventoryValueMicro: number;
+  lotId?: number | null;
+  lotCode?: string | null;
+  originatedOn?: string | null;
+  expiresOn?: string | null;
+  allocations: ProductionAllocationResponse[];
+}
+
+export interface ProductionAllocationResponse {
+  id: number;
+  lotId: number;
+  quantityAtomic: number;
+}
+
+export type SaleReason = "PROMOTION" | "SAMPLE";
+
+export interface SalePostRequest {
+  idempotencyKey: string;
+  counterpartyId?: number | null;
+  occurredOn: string;
+  reasonCode?: SaleReason | null;
+  notes?: string | null;
+  lines: SaleLineRequest[];
+}
+
+export interface SaleLineRequest {
+  itemId: number;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  commercialTotalMinor: number;
+  lotId?: number | null;
+}
+
+export interface SaleDocumentResponse {
+  id: number;
+  idempotencyKey: string;
+  postingSequence: number;
+  counterpartyId?: number | null;
+  occurredOn: string;
+  postedAtMs: number;
+  currencyCode: string;
+  currencyMinorDigits: number;
+  reasonCode?: SaleReason | null;
+  notes?: string | null;
+  lines: SaleLineResponse[];
+}
+
+export interface SaleLineResponse {
+  id: number;
+  lineOrder: number;
+  itemId: number;
+  direction: StockDirection;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  inventoryValueMicro: number;
+  commercialTotalMinor: number;
+  allocations: SaleAllocationResponse[];
+}
+
+export interface SaleAllocationResponse {
+  id: number;
+  lotId: number;
+  quantityAtomic: number;
+}
+
+export interface SaleCursorRequest {
+  postingSequence: number;
+  id: number;
+}
+
+export interface SaleCursorResponse {
+  postingSequence: number;
+  id: number;
+}
+
+export interface SaleListRequest {
+  after?: SaleCursorRequest | null;
+  pageSize?: number;
+}
+
+export interface SalePageResponse {
+  items: SaleDocumentResponse[];
+  next?: SaleCursorResponse | null;
+}
+
+export type SalePriceSource = "PRICE_LIST" | "DEFAULT_PRICE" | "NONE";
+
+export interface SaleQuoteRequest {
+  counterpartyId?: number | null;
+  occurredOn: string;
+  lines: SaleLineRequest[];
+}
+
+export interface SaleLineQuoteResponse {
+  source: SalePriceSource;
+  priceListId?: number | null;
+  packagingId?: number | null;
+  minQuantityAtomic: number;
+  unitPriceMinor?: number | null;
+  commercialTotalMinor?: number | null;
+}
+
+export type CustomerOrderStatus = "OPEN" | "FULFILLED" | "CANCELLED";
+
+export interface CustomerOrderWriteRequest {
+  customerId: number;
+  orderedOn: string;
+  dueOn: string;
+  depositMinor: number;
+  notes?: string | null;
+  lines: CustomerOrderLineRequest[];
+}
+
+export interface CustomerOrderUpdateRequest extends CustomerOrderWriteRequest {
+  expectedUpdatedAtMs: number;
+}
+
+export interface CustomerOrderLineRequest {
+  itemId: number;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  agreedTotalMinor: number;
+}
+
+export interface CustomerOrderFulfilRequest {
+  expectedUpdatedAtMs: number;
+  idempotencyKey: string;
+  occurredOn: string;
+  notes?: string | null;
+}
+
+export interface CustomerOrderCalendarRequest {
+  from: string;
+  to: string;
+  status?: CustomerOrderStatus | null;
+}
+
+export interface CustomerOrderResponse {
+  id: number;
+  customerId: number;
+  status: CustomerOrderStatus;
+  orderedOn: string;
+  dueOn: string;
+  depositMinor: number;
+  agreedTotalMinor: number;
+  balanceDueMinor: number;
+  notes?: string | null;
+  saleDocumentId?: number | null;
+  createdAtMs: number;
+  updatedAtMs: number;
+  lines: CustomerOrderLineResponse[];
+}
+
+export interface CustomerOrderLineResponse {
+  id: number;
+  lineOrder: number;
+  itemId: number;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  conversionNumeratorAtomic: number;
+  conversionDenominator: number;
+  agreedTotalMinor: number;
+}
+
+export interface CustomerOrderCalendarDayResponse {
+  dueOn: string;
+  orders: CustomerOrderResponse[];
+}
+
+export interface CustomerOrderFulfilmentResponse {
+  order: CustomerOrderResponse;
+  sale: SaleDocumentResponse;
+}
+
+export type PaymentMethod = "CASH" | "PIX" | "CARD" | "ON_ACCOUNT";
+
+export interface SalePaymentRecordRequest {
+  idempotencyKey: string;
+  saleDocumentId: number;
+  method: PaymentMethod;
+  amountMinor: number;
+  receivedOn: string;
+  notes?: string | null;
+}
+
+export interface SalePaymentReverseRequest {
+  idempotencyKey: string;
+  receivedOn: string;
+  notes?: string | null;
+}
+
+export interface SalePaymentResponse {
+  id: number;
+  idempotencyKey: string;
+  saleDocumentId: number;
+  method: PaymentMethod;
+  amountMinor: number;
+  receivedOn: string;
+  notes?: string | null;
+  reversesPaymentId?: number | null;
+  recordedAtMs: number;
+}
+
+export interface SaleBalanceResponse {
+  saleDocumentId: number;
+  customerId?: number | null;
+  occurredOn: string;
+  saleTotalMinor: number;
+  returnedMinor: number;
+  reversed: boolean;
+  receivableMinor: number;
+  settledMinor: number;
+  onAccountMinor: number;
+  outstandingMinor: number;
+  payments: SalePaymentResponse[];
+}
+
+export interface CustomerBalanceResponse {
+  customerId: number;
+  outstandingMinor: number;
+  sales: SaleBalanceResponse[];
+}
+
+export interface AgingBucketsResponse {
+  days0To30Minor: number;
+  days31To60Minor: number;
+  over60DaysMinor: number;
+  totalMinor: number;
+}
+
+export interface CustomerAgingResponse {
+  customerId?: number | null;
+  saleCount: number;
+  buckets: AgingBucketsResponse;
+}
+
+export interface ReceivablesAgingResponse {
+  asOf: string;
+  customers: CustomerAgingResponse[];
+  totals: AgingBucketsResponse;
+}
+
+export type SupplierPaymentMethod = Exclude<PaymentMethod, "ON_ACCOUNT">;
+
+export interface InstallmentRequest {
+  dueOn: string;
+  amountMinor: number;
+}
+
+export interface PurchasePayableScheduleRequest {
+  installments: InstallmentRequest[];
+}
+
+export interface SupplierPaymentRecordRequest {
+  idempotencyKey: string;
+  purchaseDocumentId: number;
+  method: SupplierPaymentMethod;
+  amountMinor: number;
+  paidOn: string;
+  notes?: string | null;
+}
+
+export interface SupplierPaymentReverseRequest {
+  idempotencyKey: string;
+  paidOn: string;
+  notes?: string | null;
+}
+
+export interface SupplierPaymentResponse {
+  id: number;
+  idempotencyKey: string;
+  purchaseDocumentId: number;
+  method: SupplierPaymentMethod;
+  amountMinor: number;
+  paidOn: string;
+  notes?: string | null;
+  reversesPaymentId?: number | null;
+  recordedAtMs: number;
+}
+
+export interface InstallmentResponse {
+  number: number;
+  dueOn: string;
+  amountMinor: number;
+  openMinor: number;
+}
+
+export interface PurchasePayableResponse {
+  purchaseDocumentId: number;
+  supplierId: number;
+  occurredOn: string;
+  purchaseTotalMinor: number;
+  creditedMinor: number;
+  reversed: boolean;
+  owedMinor: number;
+  paidMinor: number;
+  outstandingMinor: number;
+  installments: InstallmentResponse[];
+  payments: SupplierPaymentResponse[];
+  createdAtMs: number;
+}
+
+export interface DueInstallmentResponse {
+  purchaseDocumentId: number;
+  number: number;
+  dueOn: string;
+  amountMinor: number;
+  openMinor: number;
+  overdue: boolean;
+}
+
+export interface SupplierDueResponse {
+  supplierId: number;
+  installments: DueInstallmentResponse[];
+  overdueMinor: number;
+  dueThisWeekMinor: number;
+}
+
+export interface PayablesDueResponse {
+  asOf: string;
+  suppliers: SupplierDueResponse[];
+  overdueMinor: number;
+  dueThisWeekMinor: number;
+}
+
+export type CashMovementKind = "CASH_IN" | "CASH_OUT";
+
+export interface RegisterOpenRequest {
+  idempotencyKey: string;
+  openingFloatMinor: number;
+  notes?: string | null;
+}
+
+export interface CashMovementRecordRequest {
+  idempotencyKey: string;
+  registerSessionId: number;
+  kind: CashMovementKind;
+  amountMinor: number;
+  notes: string;
+}
+
+export interface RegisterCloseRequest {
+  countedCashMinor: number;
+  notes?: string | null;
+}
+
+export interface CashMovementResponse {
+  id: number;
+  idempotencyKey: string;
+  registerSessionId: number;
+  kind: CashMovementKind;
+  amountMinor: number;
+  notes: string;
+  recordedAtMs: number;
+}
+
+export interface RegisterTotalsResponse {
+  openingFloatMinor: number;
+  cashMinor: number;
+  pixMinor: number;
+  cardMinor: number;
+  onAccountMinor: number;
+  cashInMinor: number;
+  cashOutMinor: number;
+  expectedCashMinor: number;
+}
+
+export interface RegisterClosingResponse {
+  closedOn: string;
+  totals: RegisterTotalsResponse;
+  countedCashMinor: number;
+  differenceMinor: number;
+  notes?: string | null;
+  closedAtMs: number;
+}
+
+export interface RegisterSessionResponse {
+  id: number;
+  idempotencyKey: string;
+  businessDate: string;
+  openingFloatMinor: number;
+  notes?: string | null;
+  openedAtMs: number;
+  open: boolean;
+  totals: RegisterTotalsResponse;
+  payments: SalePaymentResponse[];
+  movements: CashMovementResponse[];
+  closing?: RegisterClosingResponse | null;
+}
+
+export type ExpenseCategory =
+  | "RENT"
+  | "GAS"
+  | "ELECTRICITY"
+  | "WATER"
+  | "PACKAGING"
+  | "WAGES"
+  | "OTHER";
+
+export interface ExpenseRecordRequest {
+  idempotencyKey: string;
+  category: ExpenseCategory;
+  amountMinor: number;
+  occurredOn: string;
+  supplierId?: number | null;
+  notes?: string | null;
+}
+
+export type ExpenseCorrectRequest = ExpenseRecordRequest;
+
+export interface ExpenseListRequest {
+  fromOccurredOn: string;
+  toOccurredOn: string;
+  category?: ExpenseCategory | null;
+}
+
+export interface ExpenseResponse {
+  id: number;
+  idempotencyKey: string;
+  category: ExpenseCategory;
+  amountMinor: number;
+  occurredOn: string;
+  supplierId?: number | null;
+  notes?: string | null;
+  correctsExpenseId?: number | null;
+  correctedById?: number | null;
+  recordedAtMs: number;
+}
+
+export interface PriceListListRequest {
+  archiveFilter?: ArchiveFilter;
+}
+
+export interface PriceListPriceRequest {
+  itemId: number;
+  packagingId?: number | null;
+  minQuantityAtomic: number;
+  unitPriceMinor: number;
+}
+
+export interface PriceListWriteRequest {
+  name: string;
+  validFrom?: string | null;
+  validTo?: string | null;
+  prices: PriceListPriceRequest[];
+}
+
+export interface PriceListUpdateRequest extends PriceListWriteRequest {
+  expectedUpdatedAtMs: number;
+}
+
+export interface CustomerPriceListAssignRequest {
+  priceListId?: number | null;
+}
+
+export interface PriceListResponse {
+  id: number;
+  name: string;
+  validFrom?: string | null;
+  validTo?: string | null;
+  prices: PriceListPriceResponse[];
+  createdAtMs: number;
+  updatedAtMs: number;
+  archivedAtMs?: number | null;
+}
+
+export interface PriceListPriceResponse {
+  itemId: number;
+  packagingId?: number | null;
+  packagingName?: string | null;
+  pricedUnitNumeratorAtomic: number;
+  pricedUnitDenominator: number;
+  minQuantityAtomic: number;
+  unitPriceMinor: number;
+}
+
+export type DraftKind = "PURCHASE" | "SALE" | "ADJUSTMENT" | "PRODUCTION";
+
+export interface DraftSaveRequest {
+  kind: DraftKind;
+  draftId: string;
+  payload: string;
+  expectedUpdatedAtMs?: number | null;
+}
+
+export interface VersionedDraftRequest {
+  kind: DraftKind;
+  draftId: string;
+  expectedUpdatedAtMs: number;
+}
+
+export interface DraftResponse {
+  kind: DraftKind;
+  draftId: string;
+  payload: string;
+  createdAtMs: number;
+  updatedAtMs: number;
+}
+
+export interface DraftPostResponse {
+  kind: DraftKind;
+  draftId: string;
+  documentId: number;
+}
+
+export interface RecipeCursorRequest {
   name: string;
   id: number;
 }
@@ -781,6 +1661,145 @@ export interface RecipeComponentResponse {
   createdAtMs: number;
 }
 
+export type CostSource = "AVERAGE_VALUE" | "LAST_PURCHASE" | "UNAVAILABLE";
+
+export interface RecipeRevisionCostResponse {
+  revisionId: number;
+  recipeId: number;
+  outputItemId: number;
+  standardYieldQuantityAtomic: number;
+  yieldBaseUnitCode: string;
+  totalValueMicro: number;
+  costPerYieldBaseUnitMicro: number;
+  estimatedDirectCostMicro?: number | null;
+  complete: boolean;
+  components: RecipeComponentCostResponse[];
+}
+
+export interface RecipeComponentCostResponse {
+  componentId: number;
+  order: number;
+  itemId: number;
+  quantityAtomic: number;
+  enteredUnitCode: string;
+  enteredPackagingName?: string | null;
+  enteredQuantityNumerator: number;
+  enteredQuantityDenominator: number;
+  costSource: CostSource;
+  unitValueNumeratorMicro?: number | null;
+  unitValueDenominatorAtomic?: number | null;
+  valueMicro?: number | null;
+}
+
+export interface PriceSuggestionRequest {
+  revisionId: number;
+  grossMarginBasisPoints?: number | null;
+}
+
+export interface UnderpricedItemsRequest {
+  grossMarginBasisPoints?: number | null;
+}
+
+export interface PriceSuggestionResponse {
+  recipeId: number;
+  recipeName: string;
+  revisionId: number;
+  currentRevisionId: number;
+  outputItemId: number;
+  outputItemName: string;
+  currencyCode: string;
+  currencyMinorDigits: number;
+  yieldBaseUnitCode: string;
+  preparationTimeMinutes: number;
+  materialValueMicro: number;
+  laborCostConfigured: boolean;
+  laborValueMicro: number;
+  batchValueMicro: number;
+  costPerYieldBaseUnitMicro: number;
+  grossMarginBasisPoints: number;
+  suggestedPriceMinor: number;
+  currentPriceMinor?: number | null;
+  underpriced: boolean;
+  costComplete: boolean;
+}
+
+export interface UnderpricedItemsResponse {
+  items: PriceSuggestionResponse[];
+  costIncomplete: PriceSuggestionResponse[];
+}
+
+export interface ShoppingListRunRequest {
+  recipeRevisionId: number;
+  quantityAtomic: number;
+}
+
+export interface ShoppingListRequest {
+  businessDate: string;
+  runs: ShoppingListRunRequest[];
+}
+
+export interface ShoppingListLineResponse {
+  itemId: number;
+  itemName: string;
+  baseUnitCode: string;
+  baseConversionNumeratorAtomic: number;
+  baseConversionDenominator: number;
+  requiredQuantityAtomic: number;
+  availableQuantityAtomic: number;
+  reorderQuantityAtomic: number;
+  toBuyQuantityAtomic: number;
+  packagingName?: string | null;
+  packagingUnitCode?: string | null;
+  packages?: number | null;
+}
+
+export interface ShoppingListGroupResponse {
+  supplierId?: number | null;
+  supplierName?: string | null;
+  lines: ShoppingListLineResponse[];
+}
+
+export interface ShoppingListResponse {
+  businessDate: string;
+  groups: ShoppingListGroupResponse[];
+}
+
+export interface ProductionPlanRequest {
+  itemId: number;
+  quantityAtomic: number;
+  recipeIds?: number[];
+}
+
+export interface PlannedRunInputResponse {
+  itemId: number;
+  quantityAtomic: number;
+}
+
+export interface PlannedProductionRunResponse {
+  recipeId: number;
+  recipeRevisionId: number;
+  outputItemId: number;
+  level: number;
+  requiredQuantityAtomic: number;
+  fromStockQuantityAtomic: number;
+  quantityAtomic: number;
+  inputs: PlannedRunInputResponse[];
+}
+
+export interface PlannedPurchaseResponse {
+  itemId: number;
+  requiredQuantityAtomic: number;
+  fromStockQuantityAtomic: number;
+  quantityAtomic: number;
+}
+
+export interface ProductionPlanResponse {
+  itemId: number;
+  quantityAtomic: number;
+  runs: PlannedProductionRunResponse[];
+  purchases: PlannedPurchaseResponse[];
+}
+
 export type ReportingGranularity = "DAY" | "MONTH";
 
 export interface ReportingPeriodRequest {
@@ -789,6 +1808,10 @@ export interface ReportingPeriodRequest {
   granularity?: ReportingGranularity;
 }
 
+export interface InventoryReportRequest extends ReportingPeriodRequest {
+  locationId?: number | null;
+}
+
 export interface ReportingPeriodResponse {
   fromOccurredOn: string;
   toOccurredOn: string;
@@ -800,6 +1823,8 @@ export interface SalesReportResponse {
   currencyCode: string;
   currencyMinorDigits: number;
   totalSalesCount: number;
+  returnCount: number;
+  refundMinor: number;
   commercialTotalMinor: number;
   cogsInventoryValueMicro: number;
   grossMarginInventoryValueMicro: number;
@@ -818,6 +1843,7 @@ export interface SalesReportResponse {
 
 export interface InventoryReportResponse {
   period: ReportingPeriodResponse;
+  locationId?: number | null;
   currencyCode: string;
   currencyMinorDigits: number;
   totalInventoryValueMicro: number;
@@ -837,6 +1863,14 @@ export interface PurchaseReportResponse {
   purchaseSpendSeries: ReportingSeriesResponse[];
   topSuppliersBySpend: ReportingCounterpartyMetricResponse[];
   freeStockEntries: ReportingSeriesResponse[];
+  payables: PurchasePayableTotalsResponse;
+}
+
+export interface PurchasePayableTotalsResponse {
+  payableCount: number;
+  owedMinor: number;
+  paidMinor: number;
+  outstandingMinor: number;
 }
 
 export interface ProductionReportResponse {
@@ -859,18 +1893,52 @@ export interface AdjustmentReportResponse {
 
 export interface CategoryMixReportResponse {
   period: ReportingPeriodResponse;
+  currencyCode: string;
+  currencyMinorDigits: number;
   available: boolean;
   unavailableReason?: string | null;
+  commercialTotalMinor: number;
   rows: CategoryMixRowResponse[];
 }
 
 export interface CategoryMixRowResponse {
+  categoryId?: number | null;
   categoryName: string;
   quantityAtomic: number;
   commercialTotalMinor: number;
   shareBasisPoints: number;
 }
 
+export interface ProfitAndLossReportResponse {
+  period: ReportingPeriodResponse;
+  currencyCode: string;
+  currencyMinorDigits: number;
+  rows: ProfitAndLossRowResponse[];
+  totals: ProfitAndLossRowResponse;
+  writeOffsByReason: ReportingReasonMetricResponse[];
+  expensesByCategory: ExpenseCategoryMetricResponse[];
+}
+
+export interface ProfitAndLossRowResponse {
+  bucket: string;
+  label: string;
+  commercialTotalMinor: number;
+  cogsInventoryValueMicro: number;
+  grossMarginInventoryValueMicro: number;
+  directCostInventoryValueMicro: number;
+  writeOffInventoryValueMicro: number;
+  expenseMinor: number;
+  expenseInventoryValueMicro: number;
+  netProfitInventoryValueMicro: number;
+  netMarginBasisPoints?: number | null;
+}
+
+export interface ExpenseCategoryMetricResponse {
+  category: ExpenseCategory;
+  expenseCount: number;
+  amountMinor: number;
+}
+
 export interface ReportingSeriesResponse {
   bucket: string;
   label: string;
@@ -925,6 +1993,64 @@ export interface ReportingLotMetricResponse {
   inventoryValueMicro: number;
 }
 
+export type StockDocumentKind =
+  | "PURCHASE"
+  | "SALE"
+  | "PRODUCTION"
+  | "ADJUSTMENT"
+  | "REVERSAL"
+  | "RETURN"
+  | "TRANSFER";
+
+export type ReversalFilter = "ALL" | "REVERSED" | "NOT_REVERSED";
+
+export interface StockDocumentCursorRequest {
+  postingSequence: number;
+  id: number;
+}
+
+export interface StockDocumentCursorResponse {
+  postingSequence: number;
+  id: number;
+}
+
+export interface StockDocumentListRequest {
+  kind?: StockDocumentKind | null;
+  reasonCode?: string | null;
+  counterpartyId?: number | null;
+  fromOccurredOn?: string | null;
+  toOccurredOn?: string | null;
+  reversal?: ReversalFilter;
+  notesSearch?: string | null;
+  after?: StockDocumentCursorRequest | null;
+  pageSize?: number;
+}
+
+export interface StockDocumentSummaryResponse {
+  id: number;
+  kind: StockDocumentKind;
+  idempotencyKey: string;
+  postingSequence: number;
+  counterpartyId?: number | null;
+  occurredOn: string;
+  postedAtMs: number;
+  currencyCode: string;
+  currencyMinorDigits: number;
+  reasonCode?: string | null;
+  notes?: string | null;
+  lineCount: number;
+  inventoryValueInMicro: number;
+  inventoryValueOutMicro: number;
+  commercialTotalMinor?: number | null;
+  reversesDocumentId?: number | null;
+  reversedByDocumentId?: number | null;
+}
+
+export interface StockDocumentPageResponse {
+  items: StockDocumentSummaryResponse[];
+  next?: StockDocumentCursorResponse | null;
+}
+
 async function invoke<T>(service: string, method: string, ...args: unknown[]): Promise<T> {
   const bridgeMethod =
     window.go?.service?.[service]?.[method] ??
@@ -975,6 +2101,17 @@ export const catalogGateway = {
     invoke<PackagingResponse>("CatalogHandler", "ReconfigureArchivedItemPackaging", id, request),
   restoreItemPackaging: (id: number, request: VersionedRequest) =>
     invoke<PackagingResponse>("CatalogHandler", "RestoreItemPackaging", id, request),
+  getCategory: (id: number) => invoke<CategoryResponse>("CatalogHandler", "GetCategory", id),
+  listCategories: (request: CategoryListRequest) =>
+    invoke<CategoryResponse[]>("CatalogHandler", "ListCategories", request),
+  createCategory: (request: CategoryWriteRequest) =>
+    invoke<CategoryResponse>("CatalogHandler", "CreateCategory", request),
+  updateCategory: (id: number, request: CategoryUpdateRequest) =>
+    invoke<CategoryResponse>("CatalogHandler", "UpdateCategory", id, request),
+  archiveCategory: (id: number, request: VersionedRequest) =>
+    invoke<CategoryResponse>("CatalogHandler", "ArchiveCategory", id, request),
+  restoreCategory: (id: number, request: VersionedRequest) =>
+    invoke<CategoryResponse>("CatalogHandler", "RestoreCategory", id, request),
 };
 
 export const counterpartyGateway = {
@@ -1001,11 +2138,51 @@ export const purchaseGateway = {
     invoke<PurchaseDocumentResponse>("PurchaseHandler", "PostPurchase", request),
 };
 
+export const purchaseOrderGateway = {
+  getPurchaseOrder: (id: number) =>
+    invoke<PurchaseOrderResponse>("PurchaseOrderHandler", "GetPurchaseOrder", id),
+  listPurchaseOrders: (request: PurchaseOrderListRequest = {}) =>
+    invoke<PurchaseOrderPageResponse>("PurchaseOrderHandler", "ListPurchaseOrders", request),
+  listPurchaseOrderReceipts: (id: number) =>
+    invoke<PurchaseDocumentResponse[]>("PurchaseOrderHandler", "ListPurchaseOrderReceipts", id),
+  createPurchaseOrder: (request: PurchaseOrderWriteRequest) =>
+    invoke<PurchaseOrderResponse>("PurchaseOrderHandler", "CreatePurchaseOrder", request),
+  updatePurchaseOrder: (id: number, request: PurchaseOrderUpdateRequest) =>
+    invoke<PurchaseOrderResponse>("PurchaseOrderHandler", "UpdatePurchaseOrder", id, request),
+  sendPurchaseOrder: (id: number, request: VersionedRequest) =>
+    invoke<PurchaseOrderResponse>("PurchaseOrderHandler", "SendPurchaseOrder", id, request),
+  cancelPurchaseOrder: (id: number, request: VersionedRequest) =>
+    invoke<PurchaseOrderResponse>("PurchaseOrderHandler", "CancelPurchaseOrder", id, request),
+  closePurchaseOrder: (id: number, request: VersionedRequest) =>
+    invoke<PurchaseOrderResponse>("PurchaseOrderHandler", "ClosePurchaseOrder", id, request),
+  receivePurchaseOrder: (id: number, request: PurchaseOrderReceiveRequest) =>
+    invoke<PurchaseOrderReceiptResponse>("PurchaseOrderHandler", "ReceivePurchaseOrder", id, request),
+};
+
 export const adjustmentGateway = {
   listAdjustments: (request: AdjustmentListRequest) =>
     invoke<AdjustmentPageResponse>("AdjustmentHandler", "ListAdjustments", request),
   postAdjustment: (request: AdjustmentPostRequest) =>
     invoke<AdjustmentDocumentResponse>("AdjustmentHandler", "PostAdjustment", request),
+  listExpiredLots: (before: string) =>
+    invoke<ExpiredLotResponse[]>("AdjustmentHandler", "ListExpiredLots", before),
+  postExpiredLots: (request: ExpiredLotsPostRequest) =>
+    invoke<AdjustmentDocumentResponse>("AdjustmentHandler", "PostExpiredLots", request),
+};
+
+export const stockCountGateway = {
+  getStockCount: (id: number) =>
+    invoke<StockCountResponse>("StockCountHandler", "GetStockCount", id),
+  listStockCounts: (request: StockCountListRequest = {}) =>
+    invoke<StockCountPageResponse>("StockCountHandler", "ListStockCounts", request),
+  startStockCount: (request: StockCountStartRequest) =>
+    invoke<StockCountResponse>("StockCountHandler", "StartStockCount", request),
+  recordStockCount: (id: number, request: StockCountRecordRequest) =>
+    invoke<StockCountResponse>("StockCountHandler", "RecordStockCount", id, request),
+  cancelStockCount: (id: number, request: VersionedRequest) =>
+    invoke<StockCountResponse>("StockCountHandler", "CancelStockCount", id, request),
+  closeStockCount: (id: number, request: StockCountCloseRequest) =>
+    invoke<StockCountClosingResponse>("StockCountHandler", "CloseStockCount", id, request),
 };
 
 export const reversalGateway = {
@@ -1014,8 +2191,14 @@ export const reversalGateway = {
 };
 
 export const productionGateway = {
+  getProduction: (id: number) =>
+    invoke<ProductionDocumentResponse>("ProductionHandler", "GetProduction", id),
+  listProductions: (request: ProductionListRequest) =>
+    invoke<ProductionPageResponse>("ProductionHandler", "ListProductions", request),
   postProduction: (request: ProductionPostRequest) =>
     invoke<ProductionDocumentResponse>("ProductionHandler", "PostProduction", request),
+  scaleProduction: (request: ProductionScaleRequest) =>
+    invoke<ScaledProductionResponse>("ProductionHandler", "ScaleProduction", request),
 };
 
 export const saleGateway = {
@@ -1024,6 +2207,174 @@ export const saleGateway = {
     invoke<SalePageResponse>("SaleHandler", "ListSales", request),
   postSale: (request: SalePostRequest) =>
     invoke<SaleDocumentResponse>("SaleHandler", "PostSale", request),
+  quoteSale: (request: SaleQuoteRequest) =>
+    invoke<SaleLineQuoteResponse[]>("SaleHandler", "QuoteSale", request),
+};
+
+export const customerOrderGateway = {
+  getCustomerOrder: (id: number) =>
+    invoke<CustomerOrderResponse>("CustomerOrderHandler", "GetCustomerOrder", id),
+  listCustomerOrderCalendar: (request: CustomerOrderCalendarRequest) =>
+    invoke<CustomerOrderCalendarDayResponse[]>(
+      "CustomerOrderHandler",
+      "ListCustomerOrderCalendar",
+      request,
+    ),
+  createCustomerOrder: (request: CustomerOrderWriteRequest) =>
+    invoke<CustomerOrderResponse>("CustomerOrderHandler", "CreateCustomerOrder", request),
+  updateCustomerOrder: (id: number, request: CustomerOrderUpdateRequest) =>
+    invoke<CustomerOrderResponse>("CustomerOrderHandler", "UpdateCustomerOrder", id, request),
+  cancelCustomerOrder: (id: number, request: VersionedRequest) =>
+    invoke<CustomerOrderResponse>("CustomerOrderHandler", "CancelCustomerOrder", id, request),
+  fulfilCustomerOrder: (id: number, request: CustomerOrderFulfilRequest) =>
+    invoke<CustomerOrderFulfilmentResponse>(
+      "CustomerOrderHandler",
+      "FulfilCustomerOrder",
+      id,
+      request,
+    ),
+};
+
+export const paymentGateway = {
+  getSaleBalance: (saleDocumentId: number) =>
+    invoke<SaleBalanceResponse>("PaymentHandler", "GetSaleBalance", saleDocumentId),
+  getCustomerBalance: (customerId: number) =>
+    invoke<CustomerBalanceResponse>("PaymentHandler", "GetCustomerBalance", customerId),
+  getReceivablesAging: (asOf: string) =>
+    invoke<ReceivablesAgingResponse>("PaymentHandler", "GetReceivablesAging", asOf),
+  recordSalePayment: (request: SalePaymentRecordRequest) =>
+    invoke<SalePaymentResponse>("PaymentHandler", "RecordSalePayment", request),
+  reverseSalePayment: (id: number, request: SalePaymentReverseRequest) =>
+    invoke<SalePaymentResponse>("PaymentHandler", "ReverseSalePayment", id, request),
+};
+
+export const payableGateway = {
+  schedulePurchasePayable: (purchaseDocumentId: number, request: PurchasePayableScheduleRequest) =>
+    invoke<PurchasePayableResponse>(
+      "PayableHandler",
+      "SchedulePurchasePayable",
+      purchaseDocumentId,
+      request,
+    ),
+  getPurchasePayable: (purchaseDocumentId: number) =>
+    invoke<PurchasePayableResponse>("PayableHandler", "GetPurchasePayable", purchaseDocumentId),
+  listDuePayables: (asOf: string, supplierId: number | null = null) =>
+    invoke<PayablesDueResponse>("PayableHandler", "ListDuePayables", asOf, supplierId),
+  recordSupplierPayment: (request: SupplierPaymentRecordRequest) =>
+    invoke<SupplierPaymentResponse>("PayableHandler", "RecordSupplierPayment", request),
+  reverseSupplierPayment: (id: number, request: SupplierPaymentReverseRequest) =>
+    invoke<SupplierPaymentResponse>("PayableHandler", "ReverseSupplierPayment", id, request),
+};
+
+export const registerGateway = {
+  openRegisterSession: (request: RegisterOpenRequest) =>
+    invoke<RegisterSessionResponse>("RegisterHandler", "OpenRegisterSession", request),
+  getOpenRegisterSession: () =>
+    invoke<RegisterSessionResponse>("RegisterHandler", "GetOpenRegisterSession"),
+  getRegisterSession: (id: number) =>
+    invoke<RegisterSessionResponse>("RegisterHandler", "GetRegisterSession", id),
+  recordCashMovement: (request: CashMovementRecordRequest) =>
+    invoke<CashMovementResponse>("RegisterHandler", "RecordCashMovement", request),
+  closeRegisterSession: (id: number, request: RegisterCloseRequest) =>
+    invoke<RegisterSessionResponse>("RegisterHandler", "CloseRegisterSession", id, request),
+  listClosedRegisterSessions: (fromBusinessDate: string, toBusinessDate: string) =>
+    invoke<RegisterSessionResponse[]>(
+      "RegisterHandler",
+      "ListClosedRegisterSessions",
+      fromBusinessDate,
+      toBusinessDate,
+    ),
+  printRegisterClosing: (id: number) =>
+    invoke<string>("RegisterHandler", "PrintRegisterClosing", id),
+};
+
+export const expenseGateway = {
+  recordExpense: (request: ExpenseRecordRequest) =>
+    invoke<ExpenseResponse>("ExpenseHandler", "RecordExpense", request),
+  correctExpense: (id: number, request: ExpenseCorrectRequest) =>
+    invoke<ExpenseResponse>("ExpenseHandler", "CorrectExpense", id, request),
+  getExpense: (id: number) => invoke<ExpenseResponse>("ExpenseHandler", "GetExpense", id),
+  listExpenses: (request: ExpenseListRequest) =>
+    invoke<ExpenseResponse[]>("ExpenseHandler", "ListExpenses", request),
+};
+
+export const priceListGateway = {
+  getPriceList: (id: number) =>
+    invoke<PriceListResponse>("PriceListHandler", "GetPriceList", id),
+  listPriceLists: (request: PriceListListRequest = {}) =>
+    invoke<PriceListResponse[]>("PriceListHandler", "ListPriceLists", request),
+  createPriceList: (request: PriceListWriteRequest) =>
+    invoke<PriceListResponse>("PriceListHandler", "CreatePriceList", request),
+  updatePriceList: (id: number, request: PriceListUpdateRequest) =>
+    invoke<PriceListResponse>("PriceListHandler", "UpdatePriceList", id, request),
+  archivePriceList: (id: number, request: VersionedRequest) =>
+    invoke<PriceListResponse>("PriceListHandler", "ArchivePriceList", id, request),
+  restorePriceList: (id: number, request: VersionedRequest) =>
+    invoke<PriceListResponse>("PriceListHandler", "RestorePriceList", id, request),
+  assignCustomerPriceList: (customerId: number, request: CustomerPriceListAssignRequest) =>
+    invoke<PriceListResponse | null>(
+      "PriceListHandler",
+      "AssignCustomerPriceList",
+      customerId,
+      request,
+    ),
+  getCustomerPriceList: (customerId: number) =>
+    invoke<PriceListResponse | null>("PriceListHandler", "GetCustomerPriceList", customerId),
+};
+
+export const draftGateway = {
+  getDraft: (kind: DraftKind, draftId: string) =>
+    invoke<DraftResponse>("DraftHandler", "GetDraft", kind, draftId),
+  listDrafts: (kind: DraftKind | null = null) =>
+    invoke<DraftResponse[]>("DraftHandler", "ListDrafts", kind),
+  saveDraft: (request: DraftSaveRequest) =>
+    invoke<DraftResponse>("DraftHandler", "SaveDraft", request),
+  discardDraft: (request: VersionedDraftRequest) =>
+    invoke<void>("DraftHandler", "DiscardDraft", request),
+  postDraft: (request: VersionedDraftRequest) =>
+    invoke<DraftPostResponse>("DraftHandler", "PostDraft", request),
+};
+
+export const returnGateway = {
+  getReturn: (id: number) => invoke<ReturnDocumentResponse>("ReturnHandler", "GetReturn", id),
+  listSaleReturns: (saleId: number) =>
+    invoke<ReturnDocumentResponse[]>("ReturnHandler", "ListSaleReturns", saleId),
+  postReturn: (request: ReturnPostRequest) =>
+    invoke<ReturnDocumentResponse>("ReturnHandler", "PostReturn", request),
+};
+
+export const supplierReturnGateway = {
+  getSupplierReturn: (id: number) =>
+    invoke<SupplierReturnDocumentResponse>("SupplierReturnHandler", "GetSupplierReturn", id),
+  listPurchaseReturns: (purchaseId: number) =>
+    invoke<SupplierReturnDocumentResponse[]>(
+      "SupplierReturnHandler",
+      "ListPurchaseReturns",
+      purchaseId,
+    ),
+  postSupplierReturn: (request: SupplierReturnPostRequest) =>
+    invoke<SupplierReturnDocumentResponse>("SupplierReturnHandler", "PostSupplierReturn", request),
+};
+
+export const locationGateway = {
+  getLocation: (id: number) => invoke<LocationResponse>("LocationHandler", "GetLocation", id),
+  listLocations: (request: LocationListRequest) =>
+    invoke<LocationResponse[]>("LocationHandler", "ListLocations", request),
+  createLocation: (request: LocationWriteRequest) =>
+    invoke<LocationResponse>("LocationHandler", "CreateLocation", request),
+  updateLocation: (id: number, request: LocationUpdateRequest) =>
+    invoke<LocationResponse>("LocationHandler", "UpdateLocation", id, request),
+  archiveLocation: (id: number, request: VersionedRequest) =>
+    invoke<LocationResponse>("LocationHandler", "ArchiveLocation", id, request),
+  restoreLocation: (id: number, request: VersionedRequest) =>
+    invoke<LocationResponse>("LocationHandler", "RestoreLocation", id, request),
+};
+
+export const transferGateway = {
+  getTransfer: (id: number) =>
+    invoke<TransferDocumentResponse>("TransferHandler", "GetTransfer", id),
+  postTransfer: (request: TransferPostRequest) =>
+    invoke<TransferDocumentResponse>("TransferHandler", "PostTransfer", request),
 };
 
 export const recipeGateway = {
@@ -1032,6 +2383,8 @@ export const recipeGateway = {
     invoke<RecipeRevisionResponse>("RecipeHandler", "GetRecipeRevision", id),
   listRecipeRevisions: (recipeId: number) =>
     invoke<RecipeRevisionResponse[]>("RecipeHandler", "ListRecipeRevisions", recipeId),
+  costRecipeRevision: (id: number) =>
+    invoke<RecipeRevisionCostResponse>("RecipeHandler", "CostRecipeRevision", id),
   listRecipes: (request: RecipeListRequest) =>
     invoke<RecipePageResponse>("RecipeHandler", "ListRecipes", request),
   createRecipe: (request: RecipeCreateRequest) =>
@@ -1046,6 +2399,25 @@ export const recipeGateway = {
     invoke<RecipeResponse>("RecipeHandler", "RestoreRecipe", id, request),
 };
 
+export const pricingGateway = {
+  suggestRecipePrice: (request: PriceSuggestionRequest) =>
+    invoke<PriceSuggestionResponse>("PricingHandler", "SuggestRecipePrice", request),
+  listUnderpricedItems: (request: UnderpricedItemsRequest = {}) =>
+    invoke<UnderpricedItemsResponse>("PricingHandler", "ListUnderpricedItems", request),
+};
+
+export const shoppingListGateway = {
+  buildShoppingList: (request: ShoppingListRequest) =>
+    invoke<ShoppingListResponse>("ShoppingListHandler", "BuildShoppingList", request),
+  exportShoppingListCSV: (request: ShoppingListRequest) =>
+    invoke<string>("ShoppingListHandler", "ExportShoppingListCSV", request),
+};
+
+export const productionPlanGateway = {
+  planProduction: (request: ProductionPlanRequest) =>
+    invoke<ProductionPlanResponse>("ProductionPlanHandler", "PlanProduction", request),
+};
+
 export const inventoryGateway = {
   getInventoryBalance: (itemId: number) =>
     invoke<InventoryBalanceResponse>("InventoryHandler", "GetInventoryBalance", itemId),
@@ -1053,8 +2425,14 @@ export const inventoryGateway = {
     invoke<InventoryBalancePageResponse>("InventoryHandler", "ListInventoryBalances", request),
   listItemLotFacts: (itemId: number) =>
     invoke<LotResponse[]>("InventoryHandler", "ListItemLotFacts", itemId),
-  listEligibleFefoLots: (itemId: number, businessDate: string) =>
-    invoke<LotResponse[]>("InventoryHandler", "ListEligibleFEFOLots", itemId, businessDate),
+  listEligibleFefoLots: (itemId: number, businessDate: string, locationId: number | null = null) =>
+    invoke<LotResponse[]>(
+      "InventoryHandler",
+      "ListEligibleFEFOLots",
+      itemId,
+      businessDate,
+      locationId,
+    ),
   listItemLedgerPage: (request: ItemLedgerPageRequest) =>
     invoke<LedgerEntryPageResponse>("InventoryHandler", "ListItemLedgerPage", request),
   listLineAllocations: (lineId: number) =>
@@ -1064,7 +2442,7 @@ export const inventoryGateway = {
 export const reportingGateway = {
   getSalesReport: (request: ReportingPeriodRequest) =>
     invoke<SalesReportResponse>("ReportingHandler", "GetSalesReport", request),
-  getInventoryReport: (request: ReportingPeriodRequest) =>
+  getInventoryReport: (request: InventoryReportRequest) =>
     invoke<InventoryReportResponse>("ReportingHandler", "GetInventoryReport", request),
   getPurchaseReport: (request: ReportingPeriodRequest) =>
     invoke<PurchaseReportResponse>("ReportingHandler", "GetPurchaseReport", request),
@@ -1074,6 +2452,17 @@ export const reportingGateway = {
     invoke<AdjustmentReportResponse>("ReportingHandler", "GetAdjustmentReport", request),
   getCategoryMixReport: (request: ReportingPeriodRequest) =>
     invoke<CategoryMixReportResponse>("ReportingHandler", "GetCategoryMixReport", request),
+  getProfitAndLossReport: (request: ReportingPeriodRequest) =>
+    invoke<ProfitAndLossReportResponse>("ReportingHandler", "GetProfitAndLossReport", request),
+};
+
+export const stockDocumentGateway = {
+  listDocuments: (request: StockDocumentListRequest) =>
+    invoke<StockDocumentPageResponse>("StockDocumentHandler", "ListDocuments", request),
 };
 
 export const ExportDatabase = () => invoke<void>("DatabaseService", "Export");
+export const ImportDatabase = () => invoke<void>("DatabaseService", "Import");
+export const ListBackups = () => invoke<BackupResponse[]>("DatabaseService", "ListBackups");
+export const VerifyBackup = (path: string) =>
+  invoke<BackupVerificationResponse>("DatabaseService", "VerifyBackup", path);
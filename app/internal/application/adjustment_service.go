@@ -10,6 +10,8 @@ import (
 type AdjustmentStore interface {
 	ListAdjustments(ctx context.Context, input AdjustmentListInput) (AdjustmentPage, error)
 	PostAdjustment(ctx context.Context, input adjustmentPostStoreInput) (AdjustmentDocument, error)
+	ListExpiredLots(ctx context.Context, before domain.BusinessDate) ([]ExpiredLot, error)
+	PostExpiredLots(ctx context.Context, input expiredLotsPostStoreInput) (AdjustmentDocument, error)
 }
 
 type AdjustmentCursor struct {
@@ -66,6 +68,32 @@ type adjustmentPostStoreInput struct {
 	PostedAt domain.UTCInstant
 }
 
+// ExpiredLot is a lot that expired before a business date and still holds
+// stock, with the weighted-average value its write-off would post.
+type ExpiredLot struct {
+	LotID               domain.InventoryLotID
+	ItemID              domain.ItemID
+	ItemName            string
+	LotCode             domain.Option[string]
+	ExpiresOn           domain.BusinessDate
+	RemainingQuantity   domain.AtomicQuantity
+	InventoryValueMicro int64
+}
+
+// ExpiredLotsPostInput writes off, on OccurredOn, every lot that expired
+// before that date except the excluded ones.
+type ExpiredLotsPostInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	OccurredOn     domain.BusinessDate
+	Notes          domain.Option[domain.NonEmptyText]
+	ExcludedLotIDs []domain.InventoryLotID
+}
+
+type expiredLotsPostStoreInput struct {
+	ExpiredLotsPostInput
+	PostedAt domain.UTCInstant
+}
+
 type AdjustmentDocument struct {
 	id              domain.StockDocumentID
 	idempotencyKey  domain.IdempotencyKey
@@ -295,3 +323,35 @@ func (s *AdjustmentService) PostAdjustment(ctx context.Context, input Adjustment
 	}
 	return document, nil
 }
+
+func (s *AdjustmentService) ListExpiredLots(ctx context.Context, before domain.BusinessDate) ([]ExpiredLot, error) {
+	lots, err := s.store.ListExpiredLots(ctx, before)
+	if err != nil {
+		return nil, fmt.Errorf("list expired lots: %w", err)
+	}
+	return lots, nil
+}
+
+// PostExpiredLots posts one EXPIRY adjustment that consumes exactly the lots
+// ListExpiredLots returns for OccurredOn, less the excluded ones, each at
+// weighted-average value.
+func (s *AdjustmentService) PostExpiredLots(ctx context.Context, input ExpiredLotsPostInput) (AdjustmentDocument, error) {
+	postedAt, err := s.clock.Now()
+	if err != nil {
+		return AdjustmentDocument{}, fmt.Errorf("read clock: %w", err)
+	}
+	document, err := s.store.PostExpiredLots(ctx, expiredLotsPostStoreInput{
+		ExpiredLotsPostInput: input,
+		PostedAt:             postedAt,
+	})
+	if err != nil {
+		return AdjustmentDocument{}, fmt.Errorf("post expired lots: %w", err)
+	}
+	if document.Reason() != domain.ReasonExpiry {
+		return AdjustmentDocument{}, domain.ErrInvariant
+	}
+	if err := ensurePostingClockCompatible(document.PostedAt(), postedAt); err != nil {
+		return AdjustmentDocument{}, err
+	}
+	return document, nil
+}
@@ -81,6 +81,40 @@ func (s *sqliteAdjustmentStore) PostAdjustment(ctx context.Context, input adjust
 	return mapSQLitePostedAdjustment(posted)
 }
 
+func (s *sqliteAdjustmentStore) ListExpiredLots(ctx context.Context, before domain.BusinessDate) ([]ExpiredLot, error) {
+	lots, err := s.store.ListExpiredLots(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	mapped := make([]ExpiredLot, 0, len(lots))
+	for _, lot := range lots {
+		mapped = append(mapped, ExpiredLot{
+			LotID:               lot.LotID,
+			ItemID:              lot.ItemID,
+			ItemName:            lot.ItemName,
+			LotCode:             lot.LotCode,
+			ExpiresOn:           lot.ExpiresOn,
+			RemainingQuantity:   lot.RemainingQuantity,
+			InventoryValueMicro: lot.InventoryValueMicro,
+		})
+	}
+	return mapped, nil
+}
+
+func (s *sqliteAdjustmentStore) PostExpiredLots(ctx context.Context, input expiredLotsPostStoreInput) (AdjustmentDocument, error) {
+	posted, err := s.store.PostExpiredLots(ctx, sqlite.PostExpiredLotsInput{
+		IdempotencyKey: input.IdempotencyKey,
+		OccurredOn:     input.OccurredOn,
+		PostedAt:       input.PostedAt,
+		Notes:          input.Notes,
+		ExcludedLotIDs: input.ExcludedLotIDs,
+	})
+	if err != nil {
+		return AdjustmentDocument{}, err
+	}
+	return mapSQLitePostedAdjustment(posted)
+}
+
 func mapSQLitePostedAdjustment(posted sqlite.PostedAdjustmentDocument) (AdjustmentDocument, error) {
 	sourceLines := posted.Lines()
 	lines := make([]PostedAdjustmentLine, 0, len(sourceLines))
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/settings"
+)
+
+// backupPollInterval bounds how late a due backup can start and how quickly a
+// changed interval takes effect; the policy itself decides whether one is due.
+const backupPollInterval = time.Minute
+
+// BackupFile is one automatic backup kept in the backup directory.
+type BackupFile struct {
+	Name      string
+	CreatedAt domain.UTCInstant
+	SizeBytes int64
+}
+
+type BackupStore interface {
+	GetSettings(ctx context.Context) (settings.Settings, error)
+	// ListBackups returns the automatic backups, newest first.
+	ListBackups(ctx context.Context) ([]BackupFile, error)
+	WriteBackup(ctx context.Context, createdAt domain.UTCInstant) (BackupFile, error)
+	RemoveBackup(ctx context.Context, name string) error
+}
+
+// BackupScheduler takes automatic snapshots according to the backup policy in
+// settings and prunes the ones its retention no longer keeps. Runs are
+// serialized so a shutdown backup never races a scheduled one.
+type BackupScheduler struct {
+	store BackupStore
+	clock Clock
+	mu    sync.Mutex
+}
+
+func NewBackupScheduler(store BackupStore, clock Clock) *BackupScheduler {
+	if store == nil {
+		panic("backup scheduler requires a store")
+	}
+	if clock == nil {
+		panic("backup scheduler requires a clock")
+	}
+	return &BackupScheduler{store: store, clock: clock}
+}
+
+// Run checks the policy on start and then every poll interval until ctx is
+// cancelled. Failures are reported and retried on the next poll rather than
+// stopping the scheduler.
+func (s *BackupScheduler) Run(ctx context.Context, report func(error)) {
+	ticker := time.NewTicker(backupPollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.RunDue(ctx); err != nil && ctx.Err() == nil && report != nil {
+			report(err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunDue writes a backup when the policy interval has elapsed since the newest
+// one, then applies retention.
+func (s *BackupScheduler) RunDue(ctx context.Context) (domain.Option[BackupFile], error) {
+	return s.run(ctx, false)
+}
+
+// RunShutdown writes a backup regardless of the interval so the last session
+// is always captured, then applies retention. It does nothing when automatic
+// backups are disabled.
+func (s *BackupScheduler) RunShutdown(ctx context.Context) (domain.Option[BackupFile], error) {
+	return s.run(ctx, true)
+}
+
+func (s *BackupScheduler) run(ctx context.Context, force bool) (domain.Option[BackupFile], error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, err := s.store.GetSettings(ctx)
+	if err != nil {
+		return domain.None[BackupFile](), fmt.Errorf("read backup policy: %w", err)
+	}
+	policy := current.Backup()
+	if !policy.Enabled() {
+		return domain.None[BackupFile](), nil
+	}
+	backups, err := s.store.ListBackups(ctx)
+	if err != nil {
+		return domain.None[BackupFile](), fmt.Errorf("list backups: %w", err)
+	}
+	now, err := s.clock.Now()
+	if err != nil {
+		return domain.None[BackupFile](), fmt.Errorf("read clock: %w", err)
+	}
+	latest := domain.None[domain.UTCInstant]()
+	if len(backups) > 0 {
+		latest = domain.Some(backups[0].CreatedAt)
+	}
+	if !force && !policy.Due(latest, now) {
+		return domain.None[BackupFile](), nil
+	}
+	// A shutdown right after a scheduled run can land on the same
+	// millisecond; the newest backup already covers that instant.
+	if last, ok := latest.Get(); ok && now.Compare(last) <= 0 {
+		return domain.None[BackupFile](), nil
+	}
+
+	written, err := s.store.WriteBackup(ctx, now)
+	if err != nil {
+		return domain.None[BackupFile](), fmt.Errorf("write backup: %w", err)
+	}
+	if err := s.prune(ctx, policy, current.Timezone()); err != nil {
+		return domain.Some(written), err
+	}
+	return domain.Some(written), nil
+}
+
+func (s *BackupScheduler) prune(ctx context.Context, policy settings.BackupPolicy, timezone domain.BusinessTimezone) error {
+	backups, err := s.store.ListBackups(ctx)
+	if err != nil {
+		return fmt.Errorf("list backups: %w", err)
+	}
+	instants := make([]domain.UTCInstant, len(backups))
+	for index, backup := range backups {
+		instants[index] = backup.CreatedAt
+	}
+	keep := policy.Retain(instants, timezone)
+	for index, backup := range backups {
+		if keep[index] {
+			continue
+		}
+		if err := s.store.RemoveBackup(ctx, backup.Name); err != nil {
+			return fmt.Errorf("prune backup %s: %w", backup.Name, err)
+		}
+	}
+	return nil
+}
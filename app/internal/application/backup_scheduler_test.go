@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/settings"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+func TestBackupSchedulerFollowsEditablePolicyAndRetention(t *testing.T) {
+	db := newApplicationTestDatabase(t)
+	store := sqlite.NewStore(db)
+	start := time.Date(2026, 10, 10, 15, 0, 0, 0, time.UTC)
+	clock := &mutableClock{now: must(domain.NewUTCInstant(start))}
+	settingsService := NewSettingsService(NewSQLiteSettingsStore(store), clock)
+	backupStore := NewSQLiteBackupStore(store, db, filepath.Join(t.TempDir(), "backups"))
+	scheduler := NewBackupScheduler(backupStore, clock)
+	ctx := context.Background()
+	at := func(offset time.Duration) domain.UTCInstant {
+		return must(domain.NewUTCInstant(start.Add(offset)))
+	}
+
+	first, err := scheduler.RunDue(ctx)
+	if err != nil || first.IsNone() {
+		t.Fatalf("first scheduled backup = %#v, %v", first, err)
+	}
+	clock.now = at(time.Hour)
+	if skipped, err := scheduler.RunDue(ctx); err != nil || skipped.IsSome() {
+		t.Fatalf("backup inside the daily interval = %#v, %v", skipped, err)
+	}
+	clock.now = at(2 * time.Hour)
+	if final, err := scheduler.RunShutdown(ctx); err != nil || final.IsNone() {
+		t.Fatalf("shutdown backup = %#v, %v", final, err)
+	}
+
+	current, err := settingsService.GetSettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.now = at(3 * time.Hour)
+	if _, err := settingsService.UpdateSettings(ctx, SettingsUpdateInput{
+		BusinessName:       current.BusinessName(),
+		Locale:             current.Locale(),
+		Timezone:           current.Timezone(),
+		Currency:           current.Currency(),
+		HourlyLaborCost:    current.HourlyLaborCost(),
+		DefaultGrossMargin: current.DefaultGrossMargin(),
+		Backup: must(settings.NewBackupPolicy(settings.BackupPolicyParams{
+			Enabled: true, IntervalMinutes: 60, KeepDaily: 2, KeepWeekly: 0,
+		})),
+		ExpectedUpdatedAt: current.UpdatedAt(),
+	}); err != nil {
+		t.Fatalf("update backup policy: %v", err)
+	}
+
+	clock.now = at(24 * time.Hour)
+	next, err := scheduler.RunDue(ctx)
+	if err != nil || next.IsNone() {
+		t.Fatalf("next-day backup = %#v, %v", next, err)
+	}
+	backups, err := backupStore.ListBackups(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 || !backups[0].CreatedAt.Equal(at(24*time.Hour)) || !backups[1].CreatedAt.Equal(at(2*time.Hour)) {
+		t.Fatalf("retained backups = %#v, want newest of the last two days", backups)
+	}
+
+	updated, err := settingsService.GetSettings(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock.now = at(25 * time.Hour)
+	if _, err := settingsService.UpdateSettings(ctx, SettingsUpdateInput{
+		BusinessName:       updated.BusinessName(),
+		Locale:             updated.Locale(),
+		Timezone:           updated.Timezone(),
+		Currency:           updated.Currency(),
+		HourlyLaborCost:    updated.HourlyLaborCost(),
+		DefaultGrossMargin: updated.DefaultGrossMargin(),
+		Backup: must(settings.NewBackupPolicy(settings.BackupPolicyParams{
+			Enabled: false, IntervalMinutes: 60, KeepDaily: 2, KeepWeekly: 0,
+		})),
+		ExpectedUpdatedAt: updated.UpdatedAt(),
+	}); err != nil {
+		t.Fatalf("disable backups: %v", err)
+	}
+	if disabled, err := scheduler.RunShutdown(ctx); err != nil || disabled.IsSome() {
+		t.Fatalf("disabled shutdown backup = %#v, %v", disabled, err)
+	}
+}
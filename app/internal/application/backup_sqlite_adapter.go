@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/settings"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+// sqliteBackupStore reads the policy through the settings store and writes
+// snapshots of the same database file into one backup directory.
+type sqliteBackupStore struct {
+	store     *sqlite.Store
+	database  *database.Database
+	directory string
+}
+
+func NewSQLiteBackupStore(store *sqlite.Store, db *database.Database, directory string) BackupStore {
+	if store == nil {
+		panic("sqlite backup store requires a store")
+	}
+	if db == nil {
+		panic("sqlite backup store requires a database")
+	}
+	if directory == "" {
+		panic("sqlite backup store requires a directory")
+	}
+	return &sqliteBackupStore{store: store, database: db, directory: directory}
+}
+
+func (s *sqliteBackupStore) GetSettings(ctx context.Context) (settings.Settings, error) {
+	return s.store.GetSettings(ctx)
+}
+
+func (s *sqliteBackupStore) ListBackups(context.Context) ([]BackupFile, error) {
+	files, err := database.ListBackupFiles(s.directory)
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]BackupFile, 0, len(files))
+	for _, file := range files {
+		backup, err := mapBackupFile(file)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+	return backups, nil
+}
+
+func (s *sqliteBackupStore) WriteBackup(_ context.Context, createdAt domain.UTCInstant) (BackupFile, error) {
+	file, err := s.database.WriteBackup(s.directory, createdAt.Time())
+	if err != nil {
+		return BackupFile{}, err
+	}
+	return mapBackupFile(file)
+}
+
+func (s *sqliteBackupStore) RemoveBackup(_ context.Context, name string) error {
+	return database.RemoveBackupFile(s.directory, name)
+}
+
+func mapBackupFile(file database.BackupFile) (BackupFile, error) {
+	createdAt, err := domain.NewUTCInstant(file.CreatedAt)
+	if err != nil {
+		return BackupFile{}, err
+	}
+	return BackupFile{Name: file.Name, CreatedAt: createdAt, SizeBytes: file.SizeBytes}, nil
+}
@@ -21,6 +21,12 @@ type CatalogStore interface {
 	ArchivePackaging(ctx context.Context, input packagingArchiveStoreInput) (PackagingAggregate, error)
 	ReconfigureArchivedPackaging(ctx context.Context, input packagingReconfigureStoreInput) (PackagingAggregate, error)
 	RestorePackaging(ctx context.Context, input packagingRestoreStoreInput) (PackagingAggregate, error)
+	GetCategory(ctx context.Context, id domain.CategoryID) (catalog.Category, error)
+	ListCategories(ctx context.Context, archive domain.ArchiveFilter) ([]catalog.Category, error)
+	CreateCategory(ctx context.Context, input categoryCreateStoreInput) (catalog.Category, error)
+	UpdateCategory(ctx context.Context, input categoryUpdateStoreInput) (catalog.Category, error)
+	ArchiveCategory(ctx context.Context, input categoryArchiveStoreInput) (catalog.Category, error)
+	RestoreCategory(ctx context.Context, input categoryRestoreStoreInput) (catalog.Category, error)
 }
 
 type ItemCursor struct {
@@ -95,6 +101,7 @@ type ItemWriteInput struct {
 	Description      domain.Option[domain.NonEmptyText]
 	BaseUnit         domain.UnitCode
 	Capabilities     catalog.Capabilities
+	Category         domain.Option[domain.CategoryID]
 	DefaultSalePrice domain.Option[domain.MinorAmount]
 	ReorderQuantity  domain.Option[domain.AtomicQuantity]
 }
@@ -199,6 +206,46 @@ type packagingRestoreStoreInput struct {
 	UpdatedAt domain.UTCInstant
 }
 
+type CategoryCreateInput struct {
+	Name domain.UniqueName
+}
+
+type CategoryUpdateInput struct {
+	ID                domain.CategoryID
+	Name              domain.UniqueName
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type CategoryArchiveInput struct {
+	ID                domain.CategoryID
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type CategoryRestoreInput struct {
+	ID                domain.CategoryID
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type categoryCreateStoreInput struct {
+	CategoryCreateInput
+	CreatedAt domain.UTCInstant
+}
+
+type categoryUpdateStoreInput struct {
+	CategoryUpdateInput
+	UpdatedAt domain.UTCInstant
+}
+
+type categoryArchiveStoreInput struct {
+	CategoryArchiveInput
+	ArchivedAt domain.UTCInstant
+}
+
+type categoryRestoreStoreInput struct {
+	CategoryRestoreInput
+	UpdatedAt domain.UTCInstant
+}
+
 type CatalogService struct {
 	store CatalogStore
 	clock Clock
@@ -386,3 +433,80 @@ func (s *CatalogService) RestorePackaging(ctx context.Context, input PackagingRe
 	}
 	return packaging, nil
 }
+
+func (s *CatalogService) GetCategory(ctx context.Context, id domain.CategoryID) (catalog.Category, error) {
+	category, err := s.store.GetCategory(ctx, id)
+	if err != nil {
+		return catalog.Category{}, fmt.Errorf("get category: %w", err)
+	}
+	return category, nil
+}
+
+func (s *CatalogService) ListCategories(ctx context.Context, archive domain.ArchiveFilter) ([]catalog.Category, error) {
+	categories, err := s.store.ListCategories(ctx, archive)
+	if err != nil {
+		return nil, fmt.Errorf("list categories: %w", err)
+	}
+	return categories, nil
+}
+
+func (s *CatalogService) CreateCategory(ctx context.Context, input CategoryCreateInput) (catalog.Category, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return catalog.Category{}, fmt.Errorf("read clock: %w", err)
+	}
+	category, err := s.store.CreateCategory(ctx, categoryCreateStoreInput{CategoryCreateInput: input, CreatedAt: now})
+	if err != nil {
+		return catalog.Category{}, fmt.Errorf("create category: %w", err)
+	}
+	if !category.CreatedAt().Equal(now) || !category.UpdatedAt().Equal(now) {
+		return catalog.Category{}, domain.ErrInvariant
+	}
+	return category, nil
+}
+
+func (s *CatalogService) UpdateCategory(ctx context.Context, input CategoryUpdateInput) (catalog.Category, error) {
+	now, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return catalog.Category{}, fmt.Errorf("read clock: %w", err)
+	}
+	category, err := s.store.UpdateCategory(ctx, categoryUpdateStoreInput{CategoryUpdateInput: input, UpdatedAt: now})
+	if err != nil {
+		return catalog.Category{}, fmt.Errorf("update category: %w", err)
+	}
+	if !category.UpdatedAt().Equal(now) {
+		return catalog.Category{}, domain.ErrInvariant
+	}
+	return category, nil
+}
+
+func (s *CatalogService) ArchiveCategory(ctx context.Context, input CategoryArchiveInput) (catalog.Category, error) {
+	now, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return catalog.Category{}, fmt.Errorf("read clock: %w", err)
+	}
+	category, err := s.store.ArchiveCategory(ctx, categoryArchiveStoreInput{CategoryArchiveInput: input, ArchivedAt: now})
+	if err != nil {
+		return catalog.Category{}, fmt.Errorf("archive category: %w", err)
+	}
+	archivedAt, ok := category.ArchivedAt().Get()
+	if !ok || !archivedAt.Equal(now) {
+		return catalog.Category{}, domain.ErrInvariant
+	}
+	return category, nil
+}
+
+func (s *CatalogService) RestoreCategory(ctx context.Context, input CategoryRestoreInput) (catalog.Category, error) {
+	now, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return catalog.Category{}, fmt.Errorf("read clock: %w", err)
+	}
+	category, err := s.store.RestoreCategory(ctx, categoryRestoreStoreInput{CategoryRestoreInput: input, UpdatedAt: now})
+	if err != nil {
+		return catalog.Category{}, fmt.Errorf("restore category: %w", err)
+	}
+	if category.IsArchived() || !category.UpdatedAt().Equal(now) {
+		return catalog.Category{}, domain.ErrInvariant
+	}
+	return category, nil
+}
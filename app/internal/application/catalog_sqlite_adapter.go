@@ -145,6 +145,7 @@ func (s *sqliteCatalogStore) CreateItem(ctx context.Context, input itemCreateSto
 		Description:      input.Description,
 		BaseUnit:         input.BaseUnit,
 		Capabilities:     input.Capabilities,
+		Category:         input.Category,
 		DefaultSalePrice: input.DefaultSalePrice,
 		ReorderQuantity:  input.ReorderQuantity,
 		CreatedAt:        input.CreatedAt,
@@ -164,6 +165,7 @@ func (s *sqliteCatalogStore) UpdateItem(ctx context.Context, input itemUpdateSto
 		Description:       input.Description,
 		BaseUnit:          input.BaseUnit,
 		Capabilities:      input.Capabilities,
+		Category:          input.Category,
 		DefaultSalePrice:  input.DefaultSalePrice,
 		ReorderQuantity:   input.ReorderQuantity,
 		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
@@ -276,6 +278,46 @@ func (s *sqliteCatalogStore) RestorePackaging(ctx context.Context, input packagi
 	return mapSQLitePackagingAggregate(packaging), nil
 }
 
+func (s *sqliteCatalogStore) GetCategory(ctx context.Context, id domain.CategoryID) (catalog.Category, error) {
+	return s.store.GetCategory(ctx, id)
+}
+
+func (s *sqliteCatalogStore) ListCategories(ctx context.Context, archive domain.ArchiveFilter) ([]catalog.Category, error) {
+	return s.store.ListCategories(ctx, archive)
+}
+
+func (s *sqliteCatalogStore) CreateCategory(ctx context.Context, input categoryCreateStoreInput) (catalog.Category, error) {
+	return s.store.CreateCategory(ctx, sqlite.CreateCategoryInput{
+		Name:      input.Name,
+		CreatedAt: input.CreatedAt,
+	})
+}
+
+func (s *sqliteCatalogStore) UpdateCategory(ctx context.Context, input categoryUpdateStoreInput) (catalog.Category, error) {
+	return s.store.UpdateCategory(ctx, sqlite.UpdateCategoryInput{
+		ID:                input.ID,
+		Name:              input.Name,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqliteCatalogStore) ArchiveCategory(ctx context.Context, input categoryArchiveStoreInput) (catalog.Category, error) {
+	return s.store.ArchiveCategory(ctx, sqlite.ArchiveCategoryInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		ArchivedAt:        input.ArchivedAt,
+	})
+}
+
+func (s *sqliteCatalogStore) RestoreCategory(ctx context.Context, input categoryRestoreStoreInput) (catalog.Category, error) {
+	return s.store.RestoreCategory(ctx, sqlite.RestoreCategoryInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
 func mapSQLiteItemAggregate(item sqlite.ItemAggregate) ItemAggregate {
 	packagings := item.Packagings()
 	mappedPackagings := make([]PackagingAggregate, 0, len(packagings))
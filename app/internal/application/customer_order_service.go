@@ -0,0 +1,293 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+type CustomerOrderStore interface {
+	GetCustomerOrder(ctx context.Context, id domain.CustomerOrderID) (sales.Order, error)
+	ListCustomerOrdersDue(ctx context.Context, input CustomerOrderCalendarInput) ([]sales.Order, error)
+	FindCustomerOrderSale(ctx context.Context, id domain.CustomerOrderID, key domain.IdempotencyKey) (domain.Option[SaleDocument], error)
+	CreateCustomerOrder(ctx context.Context, input customerOrderCreateStoreInput) (sales.Order, error)
+	UpdateCustomerOrder(ctx context.Context, input customerOrderUpdateStoreInput) (sales.Order, error)
+	CancelCustomerOrder(ctx context.Context, input customerOrderCancelStoreInput) (sales.Order, error)
+	FulfilCustomerOrder(ctx context.Context, input customerOrderFulfilStoreInput) (CustomerOrderFulfilment, error)
+}
+
+// CustomerOrderCalendarInput selects the orders due between From and To,
+// inclusive, optionally by status.
+type CustomerOrderCalendarInput struct {
+	From   domain.BusinessDate
+	To     domain.BusinessDate
+	Status domain.Option[domain.CustomerOrderStatus]
+}
+
+// CustomerOrderCalendarDay is one business date of the calendar with the
+// orders due on it, oldest first.
+type CustomerOrderCalendarDay struct {
+	DueOn  domain.BusinessDate
+	Orders []sales.Order
+}
+
+type CustomerOrderLineInput struct {
+	ItemID               domain.ItemID
+	Quantity             domain.AtomicQuantity
+	EnteredUnit          domain.UnitCode
+	EnteredPackagingName domain.Option[domain.NonEmptyText]
+	Conversion           domain.UnitConversion
+	AgreedTotal          domain.MinorAmount
+}
+
+type CustomerOrderCreateInput struct {
+	CustomerID domain.CounterpartyID
+	OrderedOn  domain.BusinessDate
+	DueOn      domain.BusinessDate
+	Deposit    domain.MinorAmount
+	Notes      domain.Option[domain.NonEmptyText]
+	Lines      []CustomerOrderLineInput
+}
+
+type CustomerOrderUpdateInput struct {
+	ID                domain.CustomerOrderID
+	CustomerID        domain.CounterpartyID
+	OrderedOn         domain.BusinessDate
+	DueOn             domain.BusinessDate
+	Deposit           domain.MinorAmount
+	Notes             domain.Option[domain.NonEmptyText]
+	Lines             []CustomerOrderLineInput
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type CustomerOrderCancelInput struct {
+	ID                domain.CustomerOrderID
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+// CustomerOrderFulfilInput fulfils a whole open order on OccurredOn. Items
+// without a lot are sold FEFO like any other sale.
+type CustomerOrderFulfilInput struct {
+	ID                domain.CustomerOrderID
+	ExpectedUpdatedAt domain.UTCInstant
+	IdempotencyKey    domain.IdempotencyKey
+	OccurredOn        domain.BusinessDate
+	Notes             domain.Option[domain.NonEmptyText]
+}
+
+type customerOrderCreateStoreInput struct {
+	CustomerOrderCreateInput
+	CreatedAt domain.UTCInstant
+}
+
+type customerOrderUpdateStoreInput struct {
+	CustomerOrderUpdateInput
+	UpdatedAt domain.UTCInstant
+}
+
+type customerOrderCancelStoreInput struct {
+	CustomerOrderCancelInput
+	UpdatedAt domain.UTCInstant
+}
+
+type customerOrderFulfilStoreInput struct {
+	ID                domain.CustomerOrderID
+	ExpectedUpdatedAt domain.UTCInstant
+	Sale              salePostStoreInput
+}
+
+// CustomerOrderFulfilment is a fulfilled order together with the sale that
+// fulfilled it.
+type CustomerOrderFulfilment struct {
+	order sales.Order
+	sale  SaleDocument
+}
+
+func NewCustomerOrderFulfilment(order sales.Order, sale SaleDocument) CustomerOrderFulfilment {
+	return CustomerOrderFulfilment{order: order, sale: sale}
+}
+
+func (f CustomerOrderFulfilment) Order() sales.Order { return f.order }
+func (f CustomerOrderFulfilment) Sale() SaleDocument { return f.sale }
+
+// NewCustomerOrderSalePostInput builds the sale that fulfils order: one line
+// per order line, to the order's customer, at the agreed totals and with each
+// line's unit or packaging snapshot.
+func NewCustomerOrderSalePostInput(order sales.Order, input CustomerOrderFulfilInput) (SalePostInput, error) {
+	if !order.IsOpen() {
+		return SalePostInput{}, fmt.Errorf("%w: customer order is %s and cannot be fulfilled", domain.ErrConflict, order.Status())
+	}
+	if input.OccurredOn.IsZero() {
+		return SalePostInput{}, domain.Invalid("occurred_on", domain.ViolationRequired, "")
+	}
+	if input.OccurredOn.Before(order.OrderedOn()) {
+		return SalePostInput{}, domain.Invalid("occurred_on", domain.ViolationOutOfRange, "COR-003")
+	}
+	orderLines := order.Lines()
+	lines := make([]SaleLineInput, 0, len(orderLines))
+	for _, line := range orderLines {
+		lines = append(lines, SaleLineInput{
+			ItemID:               line.ItemID(),
+			Quantity:             line.Quantity(),
+			EnteredUnit:          line.EnteredUnit(),
+			EnteredPackagingName: line.EnteredPackagingName(),
+			Conversion:           line.Conversion(),
+			CommercialTotal:      line.AgreedTotal(),
+			LotID:                domain.None[domain.InventoryLotID](),
+		})
+	}
+	return SalePostInput{
+		IdempotencyKey: input.IdempotencyKey,
+		CounterpartyID: domain.Some(order.CustomerID()),
+		OccurredOn:     input.OccurredOn,
+		Notes:          input.Notes,
+		Lines:          lines,
+	}, nil
+}
+
+// CustomerOrderCalendar groups orders already sorted by due date into one day
+// per distinct due date.
+func CustomerOrderCalendar(orders []sales.Order) []CustomerOrderCalendarDay {
+	days := make([]CustomerOrderCalendarDay, 0)
+	for _, order := range orders {
+		if last := len(days) - 1; last >= 0 && days[last].DueOn.Equal(order.DueOn()) {
+			days[last].Orders = append(days[last].Orders, order)
+			continue
+		}
+		days = append(days, CustomerOrderCalendarDay{DueOn: order.DueOn(), Orders: []sales.Order{order}})
+	}
+	return days
+}
+
+type CustomerOrderService struct {
+	store CustomerOrderStore
+	clock Clock
+}
+
+func NewCustomerOrderService(store CustomerOrderStore, clock Clock) *CustomerOrderService {
+	if store == nil {
+		panic("customer order service requires a store")
+	}
+	if clock == nil {
+		panic("customer order service requires a clock")
+	}
+	return &CustomerOrderService{store: store, clock: clock}
+}
+
+func (s *CustomerOrderService) GetCustomerOrder(ctx context.Context, id domain.CustomerOrderID) (sales.Order, error) {
+	order, err := s.store.GetCustomerOrder(ctx, id)
+	if err != nil {
+		return sales.Order{}, fmt.Errorf("get customer order: %w", err)
+	}
+	return order, nil
+}
+
+// ListCustomerOrderCalendar returns the days in the range that have orders
+// due, each with its orders.
+func (s *CustomerOrderService) ListCustomerOrderCalendar(ctx context.Context, input CustomerOrderCalendarInput) ([]CustomerOrderCalendarDay, error) {
+	orders, err := s.store.ListCustomerOrdersDue(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("list customer order calendar: %w", err)
+	}
+	return CustomerOrderCalendar(orders), nil
+}
+
+func (s *CustomerOrderService) CreateCustomerOrder(ctx context.Context, input CustomerOrderCreateInput) (sales.Order, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.Order{}, fmt.Errorf("read clock: %w", err)
+	}
+	created, err := s.store.CreateCustomerOrder(ctx, customerOrderCreateStoreInput{
+		CustomerOrderCreateInput: input,
+		CreatedAt:                now,
+	})
+	if err != nil {
+		return sales.Order{}, fmt.Errorf("create customer order: %w", err)
+	}
+	if !created.CreatedAt().Equal(now) || !created.IsOpen() {
+		return sales.Order{}, domain.ErrInvariant
+	}
+	return created, nil
+}
+
+func (s *CustomerOrderService) UpdateCustomerOrder(ctx context.Context, input CustomerOrderUpdateInput) (sales.Order, error) {
+	now, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return sales.Order{}, fmt.Errorf("read clock: %w", err)
+	}
+	updated, err := s.store.UpdateCustomerOrder(ctx, customerOrderUpdateStoreInput{
+		CustomerOrderUpdateInput: input,
+		UpdatedAt:                now,
+	})
+	if err != nil {
+		return sales.Order{}, fmt.Errorf("update customer order: %w", err)
+	}
+	if !updated.UpdatedAt().Equal(now) {
+		return sales.Order{}, domain.ErrInvariant
+	}
+	return updated, nil
+}
+
+func (s *CustomerOrderService) CancelCustomerOrder(ctx context.Context, input CustomerOrderCancelInput) (sales.Order, error) {
+	now, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return sales.Order{}, fmt.Errorf("read clock: %w", err)
+	}
+	cancelled, err := s.store.CancelCustomerOrder(ctx, customerOrderCancelStoreInput{
+		CustomerOrderCancelInput: input,
+		UpdatedAt:                now,
+	})
+	if err != nil {
+		return sales.Order{}, fmt.Errorf("cancel customer order: %w", err)
+	}
+	if cancelled.Status() != domain.CustomerOrderCancelled || !cancelled.UpdatedAt().Equal(now) {
+		return sales.Order{}, domain.ErrInvariant
+	}
+	return cancelled, nil
+}
+
+// FulfilCustomerOrder posts the sale built by NewCustomerOrderSalePostInput
+// and marks the order fulfilled in the same transaction. The sale obeys the
+// no-negative-stock rule, so made-to-order items must be produced first. A
+// retry with the same idempotency key returns the first fulfilment.
+func (s *CustomerOrderService) FulfilCustomerOrder(ctx context.Context, input CustomerOrderFulfilInput) (CustomerOrderFulfilment, error) {
+	replayed, err := s.store.FindCustomerOrderSale(ctx, input.ID, input.IdempotencyKey)
+	if err != nil {
+		return CustomerOrderFulfilment{}, fmt.Errorf("fulfil customer order: %w", err)
+	}
+	order, err := s.store.GetCustomerOrder(ctx, input.ID)
+	if err != nil {
+		return CustomerOrderFulfilment{}, fmt.Errorf("fulfil customer order: %w", err)
+	}
+	if sale, ok := replayed.Get(); ok {
+		return NewCustomerOrderFulfilment(order, sale), nil
+	}
+	if !order.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+		return CustomerOrderFulfilment{}, fmt.Errorf("fulfil customer order: %w", domain.ErrStale)
+	}
+	sale, err := NewCustomerOrderSalePostInput(order, input)
+	if err != nil {
+		return CustomerOrderFulfilment{}, fmt.Errorf("fulfil customer order: %w", err)
+	}
+	postedAt, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return CustomerOrderFulfilment{}, fmt.Errorf("read clock: %w", err)
+	}
+	fulfilment, err := s.store.FulfilCustomerOrder(ctx, customerOrderFulfilStoreInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		Sale:              salePostStoreInput{SalePostInput: sale, PostedAt: postedAt},
+	})
+	if err != nil {
+		return CustomerOrderFulfilment{}, fmt.Errorf("fulfil customer order: %w", err)
+	}
+	if err := ensurePostingClockCompatible(fulfilment.Sale().PostedAt(), postedAt); err != nil {
+		return CustomerOrderFulfilment{}, err
+	}
+	if fulfilment.Order().Status() != domain.CustomerOrderFulfilled {
+		return CustomerOrderFulfilment{}, domain.ErrInvariant
+	}
+	return fulfilment, nil
+}
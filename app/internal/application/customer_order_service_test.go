@@ -0,0 +1,158 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+type fulfilmentStore struct {
+	CustomerOrderStore
+	order     sales.Order
+	replay    domain.Option[SaleDocument]
+	fulfilled []customerOrderFulfilStoreInput
+}
+
+func (s *fulfilmentStore) GetCustomerOrder(_ context.Context, id domain.CustomerOrderID) (sales.Order, error) {
+	if id != s.order.ID() {
+		return sales.Order{}, domain.ErrNotFound
+	}
+	return s.order, nil
+}
+
+func (s *fulfilmentStore) FindCustomerOrderSale(context.Context, domain.CustomerOrderID, domain.IdempotencyKey) (domain.Option[SaleDocument], error) {
+	return s.replay, nil
+}
+
+func (s *fulfilmentStore) FulfilCustomerOrder(_ context.Context, input customerOrderFulfilStoreInput) (CustomerOrderFulfilment, error) {
+	s.fulfilled = append(s.fulfilled, input)
+	order := customerOrder("2026-10-25", domain.CustomerOrderFulfilled)
+	return NewCustomerOrderFulfilment(order, fulfilmentSale(input.Sale.PostedAt)), nil
+}
+
+func TestCustomerOrderSaleInputSellsEveryLineAtTheAgreedTotal(t *testing.T) {
+	order := customerOrder("2026-10-25", domain.CustomerOrderOpen)
+	sale, err := NewCustomerOrderSalePostInput(order, CustomerOrderFulfilInput{
+		IdempotencyKey: must(domain.NewIdempotencyKey("fulfil")),
+		OccurredOn:     must(domain.ParseBusinessDate("2026-10-25")),
+	})
+	if err != nil {
+		t.Fatalf("build fulfilment sale: %v", err)
+	}
+	if customer, ok := sale.CounterpartyID.Get(); !ok || customer != order.CustomerID() {
+		t.Fatalf("sale counterparty = %#v", sale.CounterpartyID)
+	}
+	line := sale.Lines[0]
+	orderLine := order.Lines()[0]
+	if len(sale.Lines) != 1 || line.ItemID != orderLine.ItemID() || line.Quantity != orderLine.Quantity() ||
+		line.EnteredPackagingName != orderLine.EnteredPackagingName() || line.CommercialTotal.Int64() != 6_000 ||
+		line.LotID.IsSome() {
+		t.Fatalf("sale lines = %#v", sale.Lines)
+	}
+
+	_, err = NewCustomerOrderSalePostInput(order, CustomerOrderFulfilInput{
+		OccurredOn: must(domain.ParseBusinessDate("2026-10-17")),
+	})
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) || validation.Violations()[0].InvariantID != "COR-003" {
+		t.Fatalf("backdated fulfilment error = %v, want COR-003", err)
+	}
+	cancelled := customerOrder("2026-10-25", domain.CustomerOrderCancelled)
+	if _, err := NewCustomerOrderSalePostInput(cancelled, CustomerOrderFulfilInput{}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("cancelled fulfilment error = %v, want conflict", err)
+	}
+}
+
+func TestCustomerOrderCalendarGroupsOrdersByDueDate(t *testing.T) {
+	days := CustomerOrderCalendar([]sales.Order{
+		customerOrder("2026-10-20", domain.CustomerOrderOpen),
+		customerOrder("2026-10-20", domain.CustomerOrderOpen),
+		customerOrder("2026-10-22", domain.CustomerOrderOpen),
+	})
+	if len(days) != 2 || days[0].DueOn.String() != "2026-10-20" || len(days[0].Orders) != 2 ||
+		days[1].DueOn.String() != "2026-10-22" || len(days[1].Orders) != 1 {
+		t.Fatalf("calendar = %#v", days)
+	}
+	if days := CustomerOrderCalendar(nil); len(days) != 0 {
+		t.Fatalf("empty calendar = %#v", days)
+	}
+}
+
+func TestCustomerOrderServiceFulfilsOnceAndReplaysByKey(t *testing.T) {
+	store := &fulfilmentStore{
+		order:  customerOrder("2026-10-25", domain.CustomerOrderOpen),
+		replay: domain.None[SaleDocument](),
+	}
+	service := NewCustomerOrderService(store, &mutableClock{now: mustInstant(1_000)})
+	input := CustomerOrderFulfilInput{
+		ID: store.order.ID(), ExpectedUpdatedAt: store.order.UpdatedAt(),
+		IdempotencyKey: must(domain.NewIdempotencyKey("fulfil")),
+		OccurredOn:     must(domain.ParseBusinessDate("2026-10-25")),
+	}
+
+	stale := input
+	stale.ExpectedUpdatedAt = mustInstant(100)
+	if _, err := service.FulfilCustomerOrder(context.Background(), stale); !errors.Is(err, domain.ErrStale) {
+		t.Fatalf("stale fulfilment error = %v, want stale", err)
+	}
+	fulfilment, err := service.FulfilCustomerOrder(context.Background(), input)
+	if err != nil {
+		t.Fatalf("fulfil customer order: %v", err)
+	}
+	if len(store.fulfilled) != 1 || fulfilment.Order().Status() != domain.CustomerOrderFulfilled {
+		t.Fatalf("fulfilments = %#v, order = %#v", store.fulfilled, fulfilment.Order())
+	}
+	if posted := store.fulfilled[0].Sale; !posted.PostedAt.Equal(mustInstant(1_000)) ||
+		posted.Lines[0].CommercialTotal.Int64() != 6_000 {
+		t.Fatalf("store fulfilment sale = %#v", posted)
+	}
+
+	store.replay = domain.Some(fulfilmentSale(mustInstant(1_000)))
+	if _, err := service.FulfilCustomerOrder(context.Background(), stale); err != nil || len(store.fulfilled) != 1 {
+		t.Fatalf("replayed fulfilment error = %v after %d fulfilments", err, len(store.fulfilled))
+	}
+}
+
+// customerOrder is a one-line order ordered on 2026-10-18 for three units
+// agreed at 6,000 minor units with a 1,000 deposit.
+func customerOrder(dueOn string, status domain.CustomerOrderStatus) sales.Order {
+	line := must(sales.NewOrderLine(sales.OrderLineParams{
+		ID: must(domain.NewCustomerOrderLineID(7)), OrderID: must(domain.NewCustomerOrderID(1)),
+		LineOrder: must(domain.NewLineOrder(1)), ItemID: must(domain.NewItemID(4)),
+		Quantity:             must(domain.NewPositiveAtomicQuantity(3)),
+		EnteredUnit:          must(domain.NewUnitCode("un")),
+		EnteredPackagingName: domain.Some(must(domain.NewNonEmptyText("Box"))),
+		Conversion:           must(domain.NewUnitConversion(1, 1)),
+		AgreedTotal:          must(domain.NewMinorAmount(6_000)),
+	}))
+	saleDocumentID := domain.None[domain.StockDocumentID]()
+	if status == domain.CustomerOrderFulfilled {
+		saleDocumentID = domain.Some(must(domain.NewStockDocumentID(1)))
+	}
+	return must(sales.NewOrder(sales.OrderParams{
+		ID: must(domain.NewCustomerOrderID(1)), CustomerID: must(domain.NewCounterpartyID(9)),
+		Status: status, OrderedOn: must(domain.ParseBusinessDate("2026-10-18")),
+		DueOn: must(domain.ParseBusinessDate(dueOn)), Deposit: must(domain.NewMinorAmount(1_000)),
+		SaleDocumentID: saleDocumentID, CreatedAt: mustInstant(500), UpdatedAt: mustInstant(500),
+		Lines: []sales.OrderLine{line},
+	}))
+}
+
+func fulfilmentSale(postedAt domain.UTCInstant) SaleDocument {
+	line := must(NewPostedSaleLine(
+		must(domain.NewStockDocumentLineID(1)), must(domain.NewLineOrder(1)), must(domain.NewItemID(4)),
+		must(domain.NewPositiveAtomicQuantity(3)), must(domain.NewUnitCode("un")),
+		domain.None[domain.NonEmptyText](), must(domain.NewUnitConversion(1, 1)),
+		domain.InventoryValue{}, must(domain.NewMinorAmount(6_000)), nil,
+	))
+	return must(NewSaleDocument(
+		must(domain.NewStockDocumentID(1)), must(domain.NewIdempotencyKey("fulfil")),
+		must(domain.NewPostingSequence(1)), domain.Some(must(domain.NewCounterpartyID(9))),
+		must(domain.ParseBusinessDate("2026-10-25")), postedAt, must(domain.NewCurrency("BRL")),
+		domain.None[domain.DocumentReason](), domain.None[domain.NonEmptyText](),
+		[]PostedSaleLine{line},
+	))
+}
@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteCustomerOrderStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteCustomerOrderStore(store *sqlite.Store) CustomerOrderStore {
+	if store == nil {
+		panic("sqlite customer order store requires a store")
+	}
+	return &sqliteCustomerOrderStore{store: store}
+}
+
+func (s *sqliteCustomerOrderStore) GetCustomerOrder(ctx context.Context, id domain.CustomerOrderID) (sales.Order, error) {
+	return s.store.GetCustomerOrder(ctx, id)
+}
+
+func (s *sqliteCustomerOrderStore) ListCustomerOrdersDue(ctx context.Context, input CustomerOrderCalendarInput) ([]sales.Order, error) {
+	return s.store.ListCustomerOrdersDue(ctx, sqlite.CustomerOrderDueFilter{
+		From:   input.From,
+		To:     input.To,
+		Status: input.Status,
+	})
+}
+
+func (s *sqliteCustomerOrderStore) FindCustomerOrderSale(
+	ctx context.Context,
+	id domain.CustomerOrderID,
+	key domain.IdempotencyKey,
+) (domain.Option[SaleDocument], error) {
+	found, err := s.store.FindCustomerOrderSale(ctx, id, key)
+	if err != nil {
+		return domain.None[SaleDocument](), err
+	}
+	posted, ok := found.Get()
+	if !ok {
+		return domain.None[SaleDocument](), nil
+	}
+	mapped, err := mapSQLitePostedSale(posted)
+	if err != nil {
+		return domain.None[SaleDocument](), err
+	}
+	return domain.Some(mapped), nil
+}
+
+func (s *sqliteCustomerOrderStore) CreateCustomerOrder(ctx context.Context, input customerOrderCreateStoreInput) (sales.Order, error) {
+	return s.store.CreateCustomerOrder(ctx, sqlite.CreateCustomerOrderInput{
+		CustomerID: input.CustomerID,
+		OrderedOn:  input.OrderedOn,
+		DueOn:      input.DueOn,
+		Deposit:    input.Deposit,
+		Notes:      input.Notes,
+		Lines:      mapSQLiteCustomerOrderLines(input.Lines),
+		CreatedAt:  input.CreatedAt,
+	})
+}
+
+func (s *sqliteCustomerOrderStore) UpdateCustomerOrder(ctx context.Context, input customerOrderUpdateStoreInput) (sales.Order, error) {
+	return s.store.UpdateCustomerOrder(ctx, sqlite.UpdateCustomerOrderInput{
+		ID:                input.ID,
+		CustomerID:        input.CustomerID,
+		OrderedOn:         input.OrderedOn,
+		DueOn:             input.DueOn,
+		Deposit:           input.Deposit,
+		Notes:             input.Notes,
+		Lines:             mapSQLiteCustomerOrderLines(input.Lines),
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqliteCustomerOrderStore) CancelCustomerOrder(ctx context.Context, input customerOrderCancelStoreInput) (sales.Order, error) {
+	return s.store.CancelCustomerOrder(ctx, sqlite.CancelCustomerOrderInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqliteCustomerOrderStore) FulfilCustomerOrder(ctx context.Context, input customerOrderFulfilStoreInput) (CustomerOrderFulfilment, error) {
+	order, posted, err := s.store.FulfilCustomerOrder(ctx, sqlite.FulfilCustomerOrderInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		Sale:              mapSQLitePostSaleInput(input.Sale),
+	})
+	if err != nil {
+		return CustomerOrderFulfilment{}, err
+	}
+	sale, err := mapSQLitePostedSale(posted)
+	if err != nil {
+		return CustomerOrderFulfilment{}, err
+	}
+	return NewCustomerOrderFulfilment(order, sale), nil
+}
+
+func mapSQLiteCustomerOrderLines(lines []CustomerOrderLineInput) []sqlite.CustomerOrderLineInput {
+	mapped := make([]sqlite.CustomerOrderLineInput, 0, len(lines))
+	for _, line := range lines {
+		mapped = append(mapped, sqlite.CustomerOrderLineInput{
+			ItemID:               line.ItemID,
+			Quantity:             line.Quantity,
+			EnteredUnit:          line.EnteredUnit,
+			EnteredPackagingName: line.EnteredPackagingName,
+			Conversion:           line.Conversion,
+			AgreedTotal:          line.AgreedTotal,
+		})
+	}
+	return mapped
+}
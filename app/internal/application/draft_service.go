@@ -0,0 +1,152 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type DraftStore interface {
+	GetDraft(ctx context.Context, kind domain.DraftKind, id domain.IdempotencyKey) (Draft, error)
+	ListDrafts(ctx context.Context, kind domain.Option[domain.DraftKind]) ([]Draft, error)
+	SaveDraft(ctx context.Context, input draftSaveStoreInput) (Draft, error)
+	DiscardDraft(ctx context.Context, input DraftDiscardInput) error
+	DeletePostedDraft(ctx context.Context, kind domain.DraftKind, id domain.IdempotencyKey) error
+}
+
+// DraftPoster posts the saved payload of one kind of draft as a stock
+// document whose idempotency key is key. Posting the same key again must
+// replay the first document, as every posting service does.
+type DraftPoster interface {
+	PostDraft(ctx context.Context, key domain.IdempotencyKey, payload string) (domain.StockDocumentID, error)
+}
+
+// Draft is a posting form saved before it was posted. Payload is the form's
+// request as a JSON object; only the poster of its kind reads it.
+type Draft struct {
+	Kind      domain.DraftKind
+	ID        domain.IdempotencyKey
+	Payload   string
+	CreatedAt domain.UTCInstant
+	UpdatedAt domain.UTCInstant
+}
+
+// DraftSaveInput creates a draft when ExpectedUpdatedAt is None and otherwise
+// replaces the payload of the draft saved at that version.
+type DraftSaveInput struct {
+	Kind              domain.DraftKind
+	ID                domain.IdempotencyKey
+	Payload           string
+	ExpectedUpdatedAt domain.Option[domain.UTCInstant]
+}
+
+type DraftDiscardInput struct {
+	Kind              domain.DraftKind
+	ID                domain.IdempotencyKey
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+// DraftPostInput posts the draft exactly as it was saved at
+// ExpectedUpdatedAt.
+type DraftPostInput struct {
+	Kind              domain.DraftKind
+	ID                domain.IdempotencyKey
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type draftSaveStoreInput struct {
+	DraftSaveInput
+	SavedAt domain.UTCInstant
+}
+
+type DraftService struct {
+	store   DraftStore
+	clock   Clock
+	posters map[domain.DraftKind]DraftPoster
+}
+
+// NewDraftService requires a poster for every draft kind.
+func NewDraftService(store DraftStore, clock Clock, posters map[domain.DraftKind]DraftPoster) *DraftService {
+	if store == nil {
+		panic("draft service requires a store")
+	}
+	if clock == nil {
+		panic("draft service requires a clock")
+	}
+	for _, kind := range []domain.DraftKind{
+		domain.DraftPurchase, domain.DraftSale, domain.DraftAdjustment, domain.DraftProduction,
+	} {
+		if posters[kind] == nil {
+			panic("draft service requires a " + kind.String() + " poster")
+		}
+	}
+	return &DraftService{store: store, clock: clock, posters: posters}
+}
+
+func (s *DraftService) GetDraft(ctx context.Context, kind domain.DraftKind, id domain.IdempotencyKey) (Draft, error) {
+	draft, err := s.store.GetDraft(ctx, kind, id)
+	if err != nil {
+		return Draft{}, fmt.Errorf("get draft: %w", err)
+	}
+	return draft, nil
+}
+
+// ListDrafts returns the saved drafts, optionally of one kind, most recently
+// saved first.
+func (s *DraftService) ListDrafts(ctx context.Context, kind domain.Option[domain.DraftKind]) ([]Draft, error) {
+	drafts, err := s.store.ListDrafts(ctx, kind)
+	if err != nil {
+		return nil, fmt.Errorf("list drafts: %w", err)
+	}
+	return drafts, nil
+}
+
+func (s *DraftService) SaveDraft(ctx context.Context, input DraftSaveInput) (Draft, error) {
+	expected, _ := input.ExpectedUpdatedAt.Get()
+	now, err := nextMutationInstant(s.clock, expected)
+	if err != nil {
+		return Draft{}, fmt.Errorf("read clock: %w", err)
+	}
+	saved, err := s.store.SaveDraft(ctx, draftSaveStoreInput{DraftSaveInput: input, SavedAt: now})
+	if err != nil {
+		return Draft{}, fmt.Errorf("save draft: %w", err)
+	}
+	if !saved.UpdatedAt.Equal(now) || saved.Kind != input.Kind || saved.ID != input.ID {
+		return Draft{}, domain.ErrInvariant
+	}
+	return saved, nil
+}
+
+func (s *DraftService) DiscardDraft(ctx context.Context, input DraftDiscardInput) error {
+	if err := s.store.DiscardDraft(ctx, input); err != nil {
+		return fmt.Errorf("discard draft: %w", err)
+	}
+	return nil
+}
+
+// PostDraft posts the draft with its id as idempotency key and then deletes
+// it. If the process stops between the two, the draft is still listed and
+// posting it again replays the same document before deleting the draft, so a
+// draft never posts twice.
+func (s *DraftService) PostDraft(ctx context.Context, input DraftPostInput) (domain.StockDocumentID, error) {
+	draft, err := s.store.GetDraft(ctx, input.Kind, input.ID)
+	if err != nil {
+		return domain.StockDocumentID{}, fmt.Errorf("post draft: %w", err)
+	}
+	if !draft.UpdatedAt.Equal(input.ExpectedUpdatedAt) {
+		return domain.StockDocumentID{}, fmt.Errorf("post draft: %w", domain.ErrStale)
+	}
+	poster, ok := s.posters[draft.Kind]
+	if !ok {
+		return domain.StockDocumentID{}, domain.Invalid("draft_kind", domain.ViolationInvalidEnum, "DRF-001")
+	}
+	documentID, err := poster.PostDraft(ctx, draft.ID, draft.Payload)
+	if err != nil {
+		return domain.StockDocumentID{}, fmt.Errorf("post draft: %w", err)
+	}
+	if err := s.store.DeletePostedDraft(ctx, draft.Kind, draft.ID); err != nil {
+		return domain.StockDocumentID{}, fmt.Errorf("post draft: %w", err)
+	}
+	return documentID, nil
+}
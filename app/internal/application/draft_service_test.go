@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type memoryDraftStore struct {
+	DraftStore
+	drafts  map[domain.IdempotencyKey]Draft
+	deleted []domain.IdempotencyKey
+}
+
+func (s *memoryDraftStore) GetDraft(_ context.Context, _ domain.DraftKind, id domain.IdempotencyKey) (Draft, error) {
+	draft, ok := s.drafts[id]
+	if !ok {
+		return Draft{}, domain.ErrNotFound
+	}
+	return draft, nil
+}
+
+func (s *memoryDraftStore) SaveDraft(_ context.Context, input draftSaveStoreInput) (Draft, error) {
+	draft := Draft{Kind: input.Kind, ID: input.ID, Payload: input.Payload, CreatedAt: input.SavedAt, UpdatedAt: input.SavedAt}
+	if current, ok := s.drafts[input.ID]; ok {
+		draft.CreatedAt = current.CreatedAt
+	}
+	s.drafts[input.ID] = draft
+	return draft, nil
+}
+
+func (s *memoryDraftStore) DeletePostedDraft(_ context.Context, _ domain.DraftKind, id domain.IdempotencyKey) error {
+	delete(s.drafts, id)
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+type recordingDraftPoster struct {
+	keys []domain.IdempotencyKey
+	err  error
+}
+
+func (p *recordingDraftPoster) PostDraft(_ context.Context, key domain.IdempotencyKey, _ string) (domain.StockDocumentID, error) {
+	p.keys = append(p.keys, key)
+	if p.err != nil {
+		return domain.StockDocumentID{}, p.err
+	}
+	return domain.NewStockDocumentID(42)
+}
+
+func TestDraftServiceSavesNewVersionsEvenWhenTheClockLags(t *testing.T) {
+	store := &memoryDraftStore{drafts: map[domain.IdempotencyKey]Draft{}}
+	service := NewDraftService(store, &mutableClock{now: mustInstant(1_000)}, draftPosters(&recordingDraftPoster{}))
+	input := DraftSaveInput{
+		Kind: domain.DraftSale, ID: must(domain.NewIdempotencyKey("sale-draft")),
+		Payload: `{"lines": []}`, ExpectedUpdatedAt: domain.None[domain.UTCInstant](),
+	}
+	created, err := service.SaveDraft(context.Background(), input)
+	if err != nil || !created.UpdatedAt.Equal(mustInstant(1_000)) {
+		t.Fatalf("created draft = %#v, %v", created, err)
+	}
+	input.ExpectedUpdatedAt = domain.Some(created.UpdatedAt)
+	saved, err := service.SaveDraft(context.Background(), input)
+	if err != nil || !saved.UpdatedAt.Equal(mustInstant(1_001)) || !saved.CreatedAt.Equal(created.CreatedAt) {
+		t.Fatalf("saved draft = %#v, %v", saved, err)
+	}
+}
+
+func TestDraftServicePostsWithTheDraftIDAsIdempotencyKey(t *testing.T) {
+	id := must(domain.NewIdempotencyKey("purchase-draft"))
+	store := &memoryDraftStore{drafts: map[domain.IdempotencyKey]Draft{
+		id: {Kind: domain.DraftPurchase, ID: id, Payload: `{}`, CreatedAt: mustInstant(500), UpdatedAt: mustInstant(700)},
+	}}
+	poster := &recordingDraftPoster{err: domain.ErrValidation}
+	service := NewDraftService(store, &mutableClock{now: mustInstant(1_000)}, draftPosters(poster))
+	input := DraftPostInput{Kind: domain.DraftPurchase, ID: id, ExpectedUpdatedAt: mustInstant(500)}
+
+	if _, err := service.PostDraft(context.Background(), input); !errors.Is(err, domain.ErrStale) || len(poster.keys) != 0 {
+		t.Fatalf("stale post error = %v after %d posts", err, len(poster.keys))
+	}
+	input.ExpectedUpdatedAt = mustInstant(700)
+	if _, err := service.PostDraft(context.Background(), input); !errors.Is(err, domain.ErrValidation) || len(store.deleted) != 0 {
+		t.Fatalf("rejected post error = %v after deleting %v", err, store.deleted)
+	}
+	poster.err = nil
+	documentID, err := service.PostDraft(context.Background(), input)
+	if err != nil || documentID.Int64() != 42 {
+		t.Fatalf("posted draft = %v, %v", documentID, err)
+	}
+	if len(poster.keys) != 2 || poster.keys[1] != id || len(store.deleted) != 1 || store.deleted[0] != id {
+		t.Fatalf("posted keys = %v, deleted drafts = %v", poster.keys, store.deleted)
+	}
+	if _, err := service.PostDraft(context.Background(), input); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("repost error = %v, want not found", err)
+	}
+}
+
+func draftPosters(poster DraftPoster) map[domain.DraftKind]DraftPoster {
+	return map[domain.DraftKind]DraftPoster{
+		domain.DraftPurchase:   poster,
+		domain.DraftSale:       poster,
+		domain.DraftAdjustment: poster,
+		domain.DraftProduction: poster,
+	}
+}
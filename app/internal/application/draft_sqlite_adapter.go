@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteDraftStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteDraftStore(store *sqlite.Store) DraftStore {
+	if store == nil {
+		panic("sqlite draft store requires a store")
+	}
+	return &sqliteDraftStore{store: store}
+}
+
+func (s *sqliteDraftStore) GetDraft(ctx context.Context, kind domain.DraftKind, id domain.IdempotencyKey) (Draft, error) {
+	draft, err := s.store.GetDraft(ctx, kind, id)
+	if err != nil {
+		return Draft{}, err
+	}
+	return mapSQLiteDraft(draft), nil
+}
+
+func (s *sqliteDraftStore) ListDrafts(ctx context.Context, kind domain.Option[domain.DraftKind]) ([]Draft, error) {
+	drafts, err := s.store.ListDrafts(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	mapped := make([]Draft, 0, len(drafts))
+	for _, draft := range drafts {
+		mapped = append(mapped, mapSQLiteDraft(draft))
+	}
+	return mapped, nil
+}
+
+func (s *sqliteDraftStore) SaveDraft(ctx context.Context, input draftSaveStoreInput) (Draft, error) {
+	draft, err := s.store.SaveDraft(ctx, sqlite.SaveDraftInput{
+		Kind:              input.Kind,
+		ID:                input.ID,
+		Payload:           input.Payload,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		SavedAt:           input.SavedAt,
+	})
+	if err != nil {
+		return Draft{}, err
+	}
+	return mapSQLiteDraft(draft), nil
+}
+
+func (s *sqliteDraftStore) DiscardDraft(ctx context.Context, input DraftDiscardInput) error {
+	return s.store.DiscardDraft(ctx, sqlite.DiscardDraftInput{
+		Kind:              input.Kind,
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+	})
+}
+
+func (s *sqliteDraftStore) DeletePostedDraft(ctx context.Context, kind domain.DraftKind, id domain.IdempotencyKey) error {
+	return s.store.DeletePostedDraft(ctx, kind, id)
+}
+
+func mapSQLiteDraft(draft sqlite.StoredDraft) Draft {
+	return Draft{
+		Kind:      draft.Kind,
+		ID:        draft.ID,
+		Payload:   draft.Payload,
+		CreatedAt: draft.CreatedAt,
+		UpdatedAt: draft.UpdatedAt,
+	}
+}
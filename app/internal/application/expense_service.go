@@ -0,0 +1,134 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/expense"
+)
+
+type ExpenseStore interface {
+	GetExpense(ctx context.Context, id domain.ExpenseID) (expense.Expense, error)
+	ListExpenses(ctx context.Context, input ExpenseListInput) ([]expense.Expense, error)
+	RecordExpense(ctx context.Context, input expenseRecordStoreInput) (expense.Expense, error)
+	CorrectExpense(ctx context.Context, input expenseCorrectStoreInput) (expense.Expense, error)
+}
+
+type ExpenseRecordInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	Category       domain.ExpenseCategory
+	Amount         domain.MinorAmount
+	OccurredOn     domain.BusinessDate
+	SupplierID     domain.Option[domain.CounterpartyID]
+	Notes          domain.Option[domain.NonEmptyText]
+}
+
+// ExpenseCorrectInput restates every field of the replaced expense. A zero
+// Amount voids it.
+type ExpenseCorrectInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	ExpenseID      domain.ExpenseID
+	Category       domain.ExpenseCategory
+	Amount         domain.MinorAmount
+	OccurredOn     domain.BusinessDate
+	SupplierID     domain.Option[domain.CounterpartyID]
+	Notes          domain.Option[domain.NonEmptyText]
+}
+
+type ExpenseListInput struct {
+	FromOccurredOn domain.BusinessDate
+	ToOccurredOn   domain.BusinessDate
+	Category       domain.Option[domain.ExpenseCategory]
+}
+
+type expenseRecordStoreInput struct {
+	ExpenseRecordInput
+	RecordedAt domain.UTCInstant
+}
+
+type expenseCorrectStoreInput struct {
+	ExpenseCorrectInput
+	RecordedAt domain.UTCInstant
+}
+
+type ExpenseService struct {
+	store ExpenseStore
+	clock Clock
+}
+
+func NewExpenseService(store ExpenseStore, clock Clock) *ExpenseService {
+	if store == nil {
+		panic("expense service requires a store")
+	}
+	if clock == nil {
+		panic("expense service requires a clock")
+	}
+	return &ExpenseService{store: store, clock: clock}
+}
+
+func (s *ExpenseService) RecordExpense(ctx context.Context, input ExpenseRecordInput) (expense.Expense, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return expense.Expense{}, fmt.Errorf("read clock: %w", err)
+	}
+	recorded, err := s.store.RecordExpense(ctx, expenseRecordStoreInput{
+		ExpenseRecordInput: input,
+		RecordedAt:         now,
+	})
+	if err != nil {
+		return expense.Expense{}, fmt.Errorf("record expense: %w", err)
+	}
+	if recorded.IdempotencyKey() != input.IdempotencyKey || recorded.Amount() != input.Amount ||
+		recorded.CorrectsExpenseID().IsSome() {
+		return expense.Expense{}, domain.ErrInvariant
+	}
+	return recorded, nil
+}
+
+// CorrectExpense replaces a current expense with a new entry. The original
+// stays in the ledger, linked to its correction.
+func (s *ExpenseService) CorrectExpense(ctx context.Context, input ExpenseCorrectInput) (expense.Expense, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return expense.Expense{}, fmt.Errorf("read clock: %w", err)
+	}
+	correction, err := s.store.CorrectExpense(ctx, expenseCorrectStoreInput{
+		ExpenseCorrectInput: input,
+		RecordedAt:          now,
+	})
+	if err != nil {
+		return expense.Expense{}, fmt.Errorf("correct expense: %w", err)
+	}
+	if target, ok := correction.CorrectsExpenseID().Get(); !ok || target != input.ExpenseID ||
+		correction.IdempotencyKey() != input.IdempotencyKey || correction.Amount() != input.Amount {
+		return expense.Expense{}, domain.ErrInvariant
+	}
+	return correction, nil
+}
+
+func (s *ExpenseService) GetExpense(ctx context.Context, id domain.ExpenseID) (expense.Expense, error) {
+	loaded, err := s.store.GetExpense(ctx, id)
+	if err != nil {
+		return expense.Expense{}, fmt.Errorf("get expense: %w", err)
+	}
+	return loaded, nil
+}
+
+// ListExpenses returns the current, non-void expenses dated within the
+// input's range, newest first.
+func (s *ExpenseService) ListExpenses(ctx context.Context, input ExpenseListInput) ([]expense.Expense, error) {
+	if input.ToOccurredOn.Before(input.FromOccurredOn) {
+		return nil, domain.Invalid("to_occurred_on", domain.ViolationOutOfRange, "EXP-001")
+	}
+	expenses, err := s.store.ListExpenses(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("list expenses: %w", err)
+	}
+	for _, item := range expenses {
+		if !item.IsCurrent() || item.IsVoid() {
+			return nil, domain.ErrInvariant
+		}
+	}
+	return expenses, nil
+}
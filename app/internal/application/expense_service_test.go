@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/expense"
+)
+
+type memoryExpenseStore struct {
+	ExpenseStore
+	recorded []expenseRecordStoreInput
+}
+
+func (s *memoryExpenseStore) RecordExpense(
+	_ context.Context,
+	input expenseRecordStoreInput,
+) (expense.Expense, error) {
+	s.recorded = append(s.recorded, input)
+	return expense.New(expense.Params{
+		ID:             must(domain.NewExpenseID(int64(len(s.recorded)))),
+		IdempotencyKey: input.IdempotencyKey,
+		Category:       input.Category,
+		Amount:         input.Amount,
+		OccurredOn:     input.OccurredOn,
+		SupplierID:     input.SupplierID,
+		Notes:          input.Notes,
+		RecordedAt:     input.RecordedAt,
+	})
+}
+
+func TestExpenseServiceStampsRecordingsWithTheClock(t *testing.T) {
+	store := &memoryExpenseStore{}
+	clock := &mutableClock{now: mustInstant(42_000)}
+	service := NewExpenseService(store, clock)
+	ctx := context.Background()
+
+	recorded, err := service.RecordExpense(ctx, ExpenseRecordInput{
+		IdempotencyKey: must(domain.NewIdempotencyKey("expense-rent")),
+		Category:       domain.ExpenseRent,
+		Amount:         must(domain.NewMinorAmount(250_000)),
+		OccurredOn:     must(domain.ParseBusinessDate("2026-07-01")),
+	})
+	if err != nil {
+		t.Fatalf("record expense: %v", err)
+	}
+	if len(store.recorded) != 1 || store.recorded[0].RecordedAt != clock.now ||
+		recorded.RecordedAt() != clock.now || recorded.Category() != domain.ExpenseRent {
+		t.Fatalf("recorded expense = %#v via %#v", recorded, store.recorded)
+	}
+
+	_, err = service.ListExpenses(ctx, ExpenseListInput{
+		FromOccurredOn: must(domain.ParseBusinessDate("2026-07-31")),
+		ToOccurredOn:   must(domain.ParseBusinessDate("2026-07-01")),
+	})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("inverted list range error = %v, want validation", err)
+	}
+}
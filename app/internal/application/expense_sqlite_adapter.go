@@ -0,0 +1,63 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/expense"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteExpenseStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteExpenseStore(store *sqlite.Store) ExpenseStore {
+	if store == nil {
+		panic("sqlite expense store requires a store")
+	}
+	return &sqliteExpenseStore{store: store}
+}
+
+func (s *sqliteExpenseStore) GetExpense(ctx context.Context, id domain.ExpenseID) (expense.Expense, error) {
+	return s.store.GetExpense(ctx, id)
+}
+
+func (s *sqliteExpenseStore) ListExpenses(ctx context.Context, input ExpenseListInput) ([]expense.Expense, error) {
+	return s.store.ListExpenses(ctx, sqlite.ExpenseFilter{
+		FromOccurredOn: input.FromOccurredOn,
+		ToOccurredOn:   input.ToOccurredOn,
+		Category:       input.Category,
+	})
+}
+
+func (s *sqliteExpenseStore) RecordExpense(
+	ctx context.Context,
+	input expenseRecordStoreInput,
+) (expense.Expense, error) {
+	return s.store.RecordExpense(ctx, sqlite.RecordExpenseInput{
+		IdempotencyKey: input.IdempotencyKey,
+		Category:       input.Category,
+		Amount:         input.Amount,
+		OccurredOn:     input.OccurredOn,
+		SupplierID:     input.SupplierID,
+		Notes:          input.Notes,
+		RecordedAt:     input.RecordedAt,
+	})
+}
+
+func (s *sqliteExpenseStore) CorrectExpense(
+	ctx context.Context,
+	input expenseCorrectStoreInput,
+) (expense.Expense, error) {
+	return s.store.CorrectExpense(ctx, sqlite.CorrectExpenseInput{
+		IdempotencyKey: input.IdempotencyKey,
+		ExpenseID:      input.ExpenseID,
+		Category:       input.Category,
+		Amount:         input.Amount,
+		OccurredOn:     input.OccurredOn,
+		SupplierID:     input.SupplierID,
+		Notes:          input.Notes,
+		RecordedAt:     input.RecordedAt,
+	})
+}
@@ -12,7 +12,7 @@ type InventoryStore interface {
 	GetInventoryBalance(ctx context.Context, itemID domain.ItemID) (inventory.BalanceSnapshot, error)
 	ListInventoryBalances(ctx context.Context, input InventoryBalanceListInput) (InventoryBalancePage, error)
 	ListItemLotFacts(ctx context.Context, itemID domain.ItemID) ([]inventory.LotView, error)
-	ListEligibleFEFOLots(ctx context.Context, itemID domain.ItemID, on domain.BusinessDate) ([]inventory.LotView, error)
+	ListEligibleFEFOLots(ctx context.Context, itemID domain.ItemID, on domain.BusinessDate, location domain.Option[domain.StockLocationID]) ([]inventory.LotView, error)
 	ListItemLedgerPage(ctx context.Context, input ItemLedgerPageInput) (ItemLedgerPage, error)
 	ListLineAllocations(ctx context.Context, lineID domain.StockDocumentLineID) ([]inventory.AllocationView, error)
 }
@@ -22,10 +22,13 @@ type InventoryBalanceCursor struct {
 	ItemID   domain.ItemID
 }
 
+// InventoryBalanceListInput lists item balances, or only the stock held at
+// Location when one is given.
 type InventoryBalanceListInput struct {
 	IncludeArchived bool
 	Search          domain.Option[domain.NonEmptyText]
 	After           domain.Option[InventoryBalanceCursor]
+	Location        domain.Option[domain.StockLocationID]
 	PageSize        int
 }
 
@@ -108,8 +111,13 @@ func (s *InventoryService) ListItemLotFacts(ctx context.Context, itemID domain.I
 	return lots, nil
 }
 
-func (s *InventoryService) ListEligibleFEFOLots(ctx context.Context, itemID domain.ItemID, on domain.BusinessDate) ([]inventory.LotView, error) {
-	lots, err := s.store.ListEligibleFEFOLots(ctx, itemID, on)
+func (s *InventoryService) ListEligibleFEFOLots(
+	ctx context.Context,
+	itemID domain.ItemID,
+	on domain.BusinessDate,
+	location domain.Option[domain.StockLocationID],
+) ([]inventory.LotView, error) {
+	lots, err := s.store.ListEligibleFEFOLots(ctx, itemID, on, location)
 	if err != nil {
 		return nil, fmt.Errorf("list eligible FEFO lots: %w", err)
 	}
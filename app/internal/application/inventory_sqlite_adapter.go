@@ -43,6 +43,7 @@ func (s *sqliteInventoryStore) ListInventoryBalances(ctx context.Context, input
 		IncludeArchived: input.IncludeArchived,
 		Search:          input.Search,
 		After:           after,
+		Location:        input.Location,
 		Limit:           limit,
 	})
 	if err != nil {
@@ -61,8 +62,13 @@ func (s *sqliteInventoryStore) ListItemLotFacts(ctx context.Context, itemID doma
 	return s.store.ListItemLotFacts(ctx, itemID)
 }
 
-func (s *sqliteInventoryStore) ListEligibleFEFOLots(ctx context.Context, itemID domain.ItemID, on domain.BusinessDate) ([]inventory.LotView, error) {
-	return s.store.ListEligibleFEFOLots(ctx, itemID, on)
+func (s *sqliteInventoryStore) ListEligibleFEFOLots(
+	ctx context.Context,
+	itemID domain.ItemID,
+	on domain.BusinessDate,
+	location domain.Option[domain.StockLocationID],
+) ([]inventory.LotView, error) {
+	return s.store.ListEligibleFEFOLots(ctx, itemID, on, location)
 }
 
 func (s *sqliteInventoryStore) ListItemLedgerPage(ctx context.Context, input ItemLedgerPageInput) (ItemLedgerPage, error) {
@@ -0,0 +1,150 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+)
+
+type LocationStore interface {
+	GetLocation(ctx context.Context, id domain.StockLocationID) (inventory.Location, error)
+	ListLocations(ctx context.Context, archive domain.ArchiveFilter) ([]inventory.Location, error)
+	CreateLocation(ctx context.Context, input locationCreateStoreInput) (inventory.Location, error)
+	UpdateLocation(ctx context.Context, input locationUpdateStoreInput) (inventory.Location, error)
+	ArchiveLocation(ctx context.Context, input locationArchiveStoreInput) (inventory.Location, error)
+	RestoreLocation(ctx context.Context, input locationRestoreStoreInput) (inventory.Location, error)
+}
+
+type LocationCreateInput struct {
+	Name domain.UniqueName
+}
+
+type LocationUpdateInput struct {
+	ID                domain.StockLocationID
+	Name              domain.UniqueName
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type LocationArchiveInput struct {
+	ID                domain.StockLocationID
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type LocationRestoreInput struct {
+	ID                domain.StockLocationID
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type locationCreateStoreInput struct {
+	LocationCreateInput
+	CreatedAt domain.UTCInstant
+}
+
+type locationUpdateStoreInput struct {
+	LocationUpdateInput
+	UpdatedAt domain.UTCInstant
+}
+
+type locationArchiveStoreInput struct {
+	LocationArchiveInput
+	ArchivedAt domain.UTCInstant
+}
+
+type locationRestoreStoreInput struct {
+	LocationRestoreInput
+	UpdatedAt domain.UTCInstant
+}
+
+type LocationService struct {
+	store LocationStore
+	clock Clock
+}
+
+func NewLocationService(store LocationStore, clock Clock) *LocationService {
+	if store == nil {
+		panic("location service requires a store")
+	}
+	if clock == nil {
+		panic("location service requires a clock")
+	}
+	return &LocationService{store: store, clock: clock}
+}
+
+func (s *LocationService) GetLocation(ctx context.Context, id domain.StockLocationID) (inventory.Location, error) {
+	location, err := s.store.GetLocation(ctx, id)
+	if err != nil {
+		return inventory.Location{}, fmt.Errorf("get location: %w", err)
+	}
+	return location, nil
+}
+
+func (s *LocationService) ListLocations(ctx context.Context, archive domain.ArchiveFilter) ([]inventory.Location, error) {
+	locations, err := s.store.ListLocations(ctx, archive)
+	if err != nil {
+		return nil, fmt.Errorf("list locations: %w", err)
+	}
+	return locations, nil
+}
+
+func (s *LocationService) CreateLocation(ctx context.Context, input LocationCreateInput) (inventory.Location, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return inventory.Location{}, fmt.Errorf("read clock: %w", err)
+	}
+	location, err := s.store.CreateLocation(ctx, locationCreateStoreInput{LocationCreateInput: input, CreatedAt: now})
+	if err != nil {
+		return inventory.Location{}, fmt.Errorf("create location: %w", err)
+	}
+	if !location.CreatedAt().Equal(now) || !location.UpdatedAt().Equal(now) {
+		return inventory.Location{}, domain.ErrInvariant
+	}
+	return location, nil
+}
+
+func (s *LocationService) UpdateLocation(ctx context.Context, input LocationUpdateInput) (inventory.Location, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return inventory.Location{}, fmt.Errorf("read clock: %w", err)
+	}
+	location, err := s.store.UpdateLocation(ctx, locationUpdateStoreInput{LocationUpdateInput: input, UpdatedAt: now})
+	if err != nil {
+		return inventory.Location{}, fmt.Errorf("update location: %w", err)
+	}
+	if !location.UpdatedAt().Equal(now) {
+		return inventory.Location{}, domain.ErrInvariant
+	}
+	return location, nil
+}
+
+func (s *LocationService) ArchiveLocation(ctx context.Context, input LocationArchiveInput) (inventory.Location, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return inventory.Location{}, fmt.Errorf("read clock: %w", err)
+	}
+	location, err := s.store.ArchiveLocation(ctx, locationArchiveStoreInput{LocationArchiveInput: input, ArchivedAt: now})
+	if err != nil {
+		return inventory.Location{}, fmt.Errorf("archive location: %w", err)
+	}
+	archivedAt, ok := location.ArchivedAt().Get()
+	if !ok || !archivedAt.Equal(now) {
+		return inventory.Location{}, domain.ErrInvariant
+	}
+	return location, nil
+}
+
+func (s *LocationService) RestoreLocation(ctx context.Context, input LocationRestoreInput) (inventory.Location, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return inventory.Location{}, fmt.Errorf("read clock: %w", err)
+	}
+	location, err := s.store.RestoreLocation(ctx, locationRestoreStoreInput{LocationRestoreInput: input, UpdatedAt: now})
+	if err != nil {
+		return inventory.Location{}, fmt.Errorf("restore location: %w", err)
+	}
+	if location.IsArchived() || !location.UpdatedAt().Equal(now) {
+		return inventory.Location{}, domain.ErrInvariant
+	}
+	return location, nil
+}
@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteLocationStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteLocationStore(store *sqlite.Store) LocationStore {
+	if store == nil {
+		panic("sqlite location store requires a store")
+	}
+	return &sqliteLocationStore{store: store}
+}
+
+func (s *sqliteLocationStore) GetLocation(ctx context.Context, id domain.StockLocationID) (inventory.Location, error) {
+	return s.store.GetLocation(ctx, id)
+}
+
+func (s *sqliteLocationStore) ListLocations(ctx context.Context, archive domain.ArchiveFilter) ([]inventory.Location, error) {
+	return s.store.ListLocations(ctx, archive)
+}
+
+func (s *sqliteLocationStore) CreateLocation(ctx context.Context, input locationCreateStoreInput) (inventory.Location, error) {
+	return s.store.CreateLocation(ctx, sqlite.CreateLocationInput{
+		Name:      input.Name,
+		CreatedAt: input.CreatedAt,
+	})
+}
+
+func (s *sqliteLocationStore) UpdateLocation(ctx context.Context, input locationUpdateStoreInput) (inventory.Location, error) {
+	return s.store.UpdateLocation(ctx, sqlite.UpdateLocationInput{
+		ID:                input.ID,
+		Name:              input.Name,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqliteLocationStore) ArchiveLocation(ctx context.Context, input locationArchiveStoreInput) (inventory.Location, error) {
+	return s.store.ArchiveLocation(ctx, sqlite.ArchiveLocationInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		ArchivedAt:        input.ArchivedAt,
+	})
+}
+
+func (s *sqliteLocationStore) RestoreLocation(ctx context.Context, input locationRestoreStoreInput) (inventory.Location, error) {
+	return s.store.RestoreLocation(ctx, sqlite.RestoreLocationInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
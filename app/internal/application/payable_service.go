@@ -0,0 +1,177 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+)
+
+type PayableStore interface {
+	GetPurchasePayable(ctx context.Context, purchaseID domain.StockDocumentID) (purchasing.Payable, error)
+	ListOpenPurchasePayables(ctx context.Context, supplierID domain.Option[domain.CounterpartyID]) ([]purchasing.Payable, error)
+	SchedulePurchasePayable(ctx context.Context, input purchasePayableScheduleStoreInput) (purchasing.Payable, error)
+	RecordSupplierPayment(ctx context.Context, input supplierPaymentRecordStoreInput) (purchasing.SupplierPayment, error)
+	ReverseSupplierPayment(ctx context.Context, input supplierPaymentReverseStoreInput) (purchasing.SupplierPayment, error)
+}
+
+// PurchasePayableScheduleInput splits a supplier purchase into installments
+// that add up to its commercial total.
+type PurchasePayableScheduleInput struct {
+	PurchaseDocumentID domain.StockDocumentID
+	Installments       []purchasing.Installment
+}
+
+type SupplierPaymentRecordInput struct {
+	IdempotencyKey     domain.IdempotencyKey
+	PurchaseDocumentID domain.StockDocumentID
+	Method             domain.PaymentMethod
+	Amount             domain.MinorAmount
+	PaidOn             domain.BusinessDate
+	Notes              domain.Option[domain.NonEmptyText]
+}
+
+// SupplierPaymentReverseInput reverses one supplier payment in full on PaidOn.
+type SupplierPaymentReverseInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	PaymentID      domain.SupplierPaymentID
+	PaidOn         domain.BusinessDate
+	Notes          domain.Option[domain.NonEmptyText]
+}
+
+type purchasePayableScheduleStoreInput struct {
+	PurchasePayableScheduleInput
+	CreatedAt domain.UTCInstant
+}
+
+type supplierPaymentRecordStoreInput struct {
+	SupplierPaymentRecordInput
+	RecordedAt domain.UTCInstant
+}
+
+type supplierPaymentReverseStoreInput struct {
+	SupplierPaymentReverseInput
+	RecordedAt domain.UTCInstant
+}
+
+type PayableService struct {
+	store PayableStore
+	clock Clock
+}
+
+func NewPayableService(store PayableStore, clock Clock) *PayableService {
+	if store == nil {
+		panic("payable service requires a store")
+	}
+	if clock == nil {
+		panic("payable service requires a clock")
+	}
+	return &PayableService{store: store, clock: clock}
+}
+
+// SchedulePurchasePayable records when a purchase is due. Scheduling the same
+// installments again returns the existing payable.
+func (s *PayableService) SchedulePurchasePayable(
+	ctx context.Context,
+	input PurchasePayableScheduleInput,
+) (purchasing.Payable, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return purchasing.Payable{}, fmt.Errorf("read clock: %w", err)
+	}
+	payable, err := s.store.SchedulePurchasePayable(ctx, purchasePayableScheduleStoreInput{
+		PurchasePayableScheduleInput: input,
+		CreatedAt:                    now,
+	})
+	if err != nil {
+		return purchasing.Payable{}, fmt.Errorf("schedule purchase payable: %w", err)
+	}
+	if payable.PurchaseDocumentID() != input.PurchaseDocumentID ||
+		len(payable.Installments()) != len(input.Installments) {
+		return purchasing.Payable{}, domain.ErrInvariant
+	}
+	return payable, nil
+}
+
+func (s *PayableService) GetPurchasePayable(
+	ctx context.Context,
+	purchaseID domain.StockDocumentID,
+) (purchasing.Payable, error) {
+	payable, err := s.store.GetPurchasePayable(ctx, purchaseID)
+	if err != nil {
+		return purchasing.Payable{}, fmt.Errorf("get purchase payable: %w", err)
+	}
+	return payable, nil
+}
+
+// ListDuePayables lists the open installments that are overdue or due within
+// the week starting on asOf, optionally for one supplier.
+func (s *PayableService) ListDuePayables(
+	ctx context.Context,
+	asOf domain.BusinessDate,
+	supplierID domain.Option[domain.CounterpartyID],
+) (purchasing.PayablesDue, error) {
+	payables, err := s.store.ListOpenPurchasePayables(ctx, supplierID)
+	if err != nil {
+		return purchasing.PayablesDue{}, fmt.Errorf("list due payables: %w", err)
+	}
+	if supplier, ok := supplierID.Get(); ok {
+		for _, payable := range payables {
+			if payable.SupplierID() != supplier {
+				return purchasing.PayablesDue{}, domain.ErrInvariant
+			}
+		}
+	}
+	due, err := purchasing.DuePayables(asOf, payables)
+	if err != nil {
+		return purchasing.PayablesDue{}, fmt.Errorf("list due payables: %w", err)
+	}
+	return due, nil
+}
+
+func (s *PayableService) RecordSupplierPayment(
+	ctx context.Context,
+	input SupplierPaymentRecordInput,
+) (purchasing.SupplierPayment, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return purchasing.SupplierPayment{}, fmt.Errorf("read clock: %w", err)
+	}
+	recorded, err := s.store.RecordSupplierPayment(ctx, supplierPaymentRecordStoreInput{
+		SupplierPaymentRecordInput: input,
+		RecordedAt:                 now,
+	})
+	if err != nil {
+		return purchasing.SupplierPayment{}, fmt.Errorf("record supplier payment: %w", err)
+	}
+	if recorded.IdempotencyKey() != input.IdempotencyKey || recorded.IsReversal() ||
+		recorded.PurchaseDocumentID() != input.PurchaseDocumentID {
+		return purchasing.SupplierPayment{}, domain.ErrInvariant
+	}
+	return recorded, nil
+}
+
+// ReverseSupplierPayment appends the reversal of a supplier payment. A retry
+// with the same idempotency key returns the first reversal.
+func (s *PayableService) ReverseSupplierPayment(
+	ctx context.Context,
+	input SupplierPaymentReverseInput,
+) (purchasing.SupplierPayment, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return purchasing.SupplierPayment{}, fmt.Errorf("read clock: %w", err)
+	}
+	reversal, err := s.store.ReverseSupplierPayment(ctx, supplierPaymentReverseStoreInput{
+		SupplierPaymentReverseInput: input,
+		RecordedAt:                  now,
+	})
+	if err != nil {
+		return purchasing.SupplierPayment{}, fmt.Errorf("reverse supplier payment: %w", err)
+	}
+	if target, ok := reversal.ReversesPaymentID().Get(); !ok || target != input.PaymentID ||
+		reversal.IdempotencyKey() != input.IdempotencyKey {
+		return purchasing.SupplierPayment{}, domain.ErrInvariant
+	}
+	return reversal, nil
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+)
+
+type openPayablesStore struct {
+	PayableStore
+	payables []purchasing.Payable
+	filters  []domain.Option[domain.CounterpartyID]
+}
+
+func (s *openPayablesStore) ListOpenPurchasePayables(
+	_ context.Context,
+	supplierID domain.Option[domain.CounterpartyID],
+) ([]purchasing.Payable, error) {
+	s.filters = append(s.filters, supplierID)
+	return s.payables, nil
+}
+
+func TestPayableServiceListsDueInstallmentsPerSupplier(t *testing.T) {
+	supplier := must(domain.NewCounterpartyID(3))
+	store := &openPayablesStore{payables: []purchasing.Payable{
+		openPayable(1, supplier, "2026-10-01", "2026-10-15", 4_000),
+		openPayable(2, supplier, "2026-10-10", "2026-10-20", 2_500),
+		openPayable(3, supplier, "2026-10-10", "2026-11-30", 900),
+	}}
+	service := NewPayableService(store, &mutableClock{now: mustInstant(1_000)})
+	asOf := must(domain.ParseBusinessDate("2026-10-18"))
+
+	due, err := service.ListDuePayables(context.Background(), asOf, domain.Some(supplier))
+	if err != nil {
+		t.Fatalf("list due payables: %v", err)
+	}
+	if due.OverdueMinor() != 4_000 || due.DueThisWeekMinor() != 2_500 || len(due.Suppliers()) != 1 ||
+		store.filters[0] != domain.Some(supplier) {
+		t.Fatalf("due payables = %#v, filters = %#v", due, store.filters)
+	}
+
+	other := must(domain.NewCounterpartyID(4))
+	store.payables = append(store.payables, openPayable(4, other, "2026-10-10", "2026-10-20", 100))
+	if _, err := service.ListDuePayables(context.Background(), asOf, domain.Some(supplier)); !errors.Is(err, domain.ErrInvariant) {
+		t.Fatalf("foreign payable error = %v, want invariant", err)
+	}
+}
+
+// openPayable is an unpaid purchase due in one installment.
+func openPayable(
+	id int64,
+	supplier domain.CounterpartyID,
+	occurredOn string,
+	dueOn string,
+	total int64,
+) purchasing.Payable {
+	return must(purchasing.NewPayable(purchasing.PayableParams{
+		PurchaseDocumentID: must(domain.NewStockDocumentID(id)),
+		SupplierID:         supplier,
+		OccurredOn:         must(domain.ParseBusinessDate(occurredOn)),
+		PurchaseTotal:      must(domain.NewMinorAmount(total)),
+		Installments: []purchasing.Installment{{
+			Number: 1,
+			DueOn:  must(domain.ParseBusinessDate(dueOn)),
+			Amount: must(domain.NewMinorAmount(total)),
+		}},
+		CreatedAt: mustInstant(1_000),
+	}))
+}
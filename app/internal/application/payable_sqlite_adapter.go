@@ -0,0 +1,73 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqlitePayableStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLitePayableStore(store *sqlite.Store) PayableStore {
+	if store == nil {
+		panic("sqlite payable store requires a store")
+	}
+	return &sqlitePayableStore{store: store}
+}
+
+func (s *sqlitePayableStore) GetPurchasePayable(
+	ctx context.Context,
+	purchaseID domain.StockDocumentID,
+) (purchasing.Payable, error) {
+	return s.store.GetPurchasePayable(ctx, purchaseID)
+}
+
+func (s *sqlitePayableStore) ListOpenPurchasePayables(
+	ctx context.Context,
+	supplierID domain.Option[domain.CounterpartyID],
+) ([]purchasing.Payable, error) {
+	return s.store.ListOpenPurchasePayables(ctx, supplierID)
+}
+
+func (s *sqlitePayableStore) SchedulePurchasePayable(
+	ctx context.Context,
+	input purchasePayableScheduleStoreInput,
+) (purchasing.Payable, error) {
+	return s.store.SchedulePurchasePayable(ctx, sqlite.SchedulePurchasePayableInput{
+		PurchaseDocumentID: input.PurchaseDocumentID,
+		Installments:       input.Installments,
+		CreatedAt:          input.CreatedAt,
+	})
+}
+
+func (s *sqlitePayableStore) RecordSupplierPayment(
+	ctx context.Context,
+	input supplierPaymentRecordStoreInput,
+) (purchasing.SupplierPayment, error) {
+	return s.store.RecordSupplierPayment(ctx, sqlite.RecordSupplierPaymentInput{
+		IdempotencyKey:     input.IdempotencyKey,
+		PurchaseDocumentID: input.PurchaseDocumentID,
+		Method:             input.Method,
+		Amount:             input.Amount,
+		PaidOn:             input.PaidOn,
+		Notes:              input.Notes,
+		RecordedAt:         input.RecordedAt,
+	})
+}
+
+func (s *sqlitePayableStore) ReverseSupplierPayment(
+	ctx context.Context,
+	input supplierPaymentReverseStoreInput,
+) (purchasing.SupplierPayment, error) {
+	return s.store.ReverseSupplierPayment(ctx, sqlite.ReverseSupplierPaymentInput{
+		IdempotencyKey: input.IdempotencyKey,
+		PaymentID:      input.PaymentID,
+		PaidOn:         input.PaidOn,
+		Notes:          input.Notes,
+		RecordedAt:     input.RecordedAt,
+	})
+}
@@ -0,0 +1,146 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+type PaymentStore interface {
+	GetSaleBalance(ctx context.Context, saleID domain.StockDocumentID) (sales.SaleBalance, error)
+	ListOpenSaleBalances(ctx context.Context, customerID domain.Option[domain.CounterpartyID]) ([]sales.SaleBalance, error)
+	RecordSalePayment(ctx context.Context, input salePaymentRecordStoreInput) (sales.Payment, error)
+	ReverseSalePayment(ctx context.Context, input salePaymentReverseStoreInput) (sales.Payment, error)
+}
+
+type SalePaymentRecordInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	SaleDocumentID domain.StockDocumentID
+	Method         domain.PaymentMethod
+	Amount         domain.MinorAmount
+	ReceivedOn     domain.BusinessDate
+	Notes          domain.Option[domain.NonEmptyText]
+}
+
+// SalePaymentReverseInput reverses one payment in full on ReceivedOn.
+type SalePaymentReverseInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	PaymentID      domain.SalePaymentID
+	ReceivedOn     domain.BusinessDate
+	Notes          domain.Option[domain.NonEmptyText]
+}
+
+type salePaymentRecordStoreInput struct {
+	SalePaymentRecordInput
+	RecordedAt domain.UTCInstant
+}
+
+type salePaymentReverseStoreInput struct {
+	SalePaymentReverseInput
+	RecordedAt domain.UTCInstant
+}
+
+// CustomerBalance is what one customer owes across the sales that are not
+// exactly settled. A negative outstanding total is a credit owed to them.
+type CustomerBalance struct {
+	CustomerID  domain.CounterpartyID
+	Outstanding int64
+	Sales       []sales.SaleBalance
+}
+
+type PaymentService struct {
+	store PaymentStore
+	clock Clock
+}
+
+func NewPaymentService(store PaymentStore, clock Clock) *PaymentService {
+	if store == nil {
+		panic("payment service requires a store")
+	}
+	if clock == nil {
+		panic("payment service requires a clock")
+	}
+	return &PaymentService{store: store, clock: clock}
+}
+
+func (s *PaymentService) GetSaleBalance(ctx context.Context, saleID domain.StockDocumentID) (sales.SaleBalance, error) {
+	balance, err := s.store.GetSaleBalance(ctx, saleID)
+	if err != nil {
+		return sales.SaleBalance{}, fmt.Errorf("get sale balance: %w", err)
+	}
+	return balance, nil
+}
+
+func (s *PaymentService) GetCustomerBalance(ctx context.Context, customerID domain.CounterpartyID) (CustomerBalance, error) {
+	if customerID.IsZero() {
+		return CustomerBalance{}, domain.Invalid("customer_id", domain.ViolationRequired, "PAY-004")
+	}
+	balances, err := s.store.ListOpenSaleBalances(ctx, domain.Some(customerID))
+	if err != nil {
+		return CustomerBalance{}, fmt.Errorf("get customer balance: %w", err)
+	}
+	customer := CustomerBalance{CustomerID: customerID, Sales: balances}
+	for _, balance := range balances {
+		if balance.CustomerID() != domain.Some(customerID) {
+			return CustomerBalance{}, domain.ErrInvariant
+		}
+		customer.Outstanding += balance.Outstanding()
+	}
+	return customer, nil
+}
+
+// GetReceivablesAging ages the current outstanding balances of the sales that
+// occurred on or before asOf.
+func (s *PaymentService) GetReceivablesAging(ctx context.Context, asOf domain.BusinessDate) (sales.ReceivablesAging, error) {
+	balances, err := s.store.ListOpenSaleBalances(ctx, domain.None[domain.CounterpartyID]())
+	if err != nil {
+		return sales.ReceivablesAging{}, fmt.Errorf("get receivables aging: %w", err)
+	}
+	aging, err := sales.AgeReceivables(asOf, balances)
+	if err != nil {
+		return sales.ReceivablesAging{}, fmt.Errorf("get receivables aging: %w", err)
+	}
+	return aging, nil
+}
+
+func (s *PaymentService) RecordSalePayment(ctx context.Context, input SalePaymentRecordInput) (sales.Payment, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.Payment{}, fmt.Errorf("read clock: %w", err)
+	}
+	recorded, err := s.store.RecordSalePayment(ctx, salePaymentRecordStoreInput{
+		SalePaymentRecordInput: input,
+		RecordedAt:             now,
+	})
+	if err != nil {
+		return sales.Payment{}, fmt.Errorf("record sale payment: %w", err)
+	}
+	if recorded.IdempotencyKey() != input.IdempotencyKey || recorded.IsReversal() ||
+		recorded.SaleDocumentID() != input.SaleDocumentID {
+		return sales.Payment{}, domain.ErrInvariant
+	}
+	return recorded, nil
+}
+
+// ReverseSalePayment appends the reversal of a payment. A retry with the same
+// idempotency key returns the first reversal.
+func (s *PaymentService) ReverseSalePayment(ctx context.Context, input SalePaymentReverseInput) (sales.Payment, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.Payment{}, fmt.Errorf("read clock: %w", err)
+	}
+	reversal, err := s.store.ReverseSalePayment(ctx, salePaymentReverseStoreInput{
+		SalePaymentReverseInput: input,
+		RecordedAt:              now,
+	})
+	if err != nil {
+		return sales.Payment{}, fmt.Errorf("reverse sale payment: %w", err)
+	}
+	if target, ok := reversal.ReversesPaymentID().Get(); !ok || target != input.PaymentID ||
+		reversal.IdempotencyKey() != input.IdempotencyKey {
+		return sales.Payment{}, domain.ErrInvariant
+	}
+	return reversal, nil
+}
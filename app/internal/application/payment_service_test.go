@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+type openBalancesPaymentStore struct {
+	PaymentStore
+	balances []sales.SaleBalance
+	filters  []domain.Option[domain.CounterpartyID]
+}
+
+func (s *openBalancesPaymentStore) ListOpenSaleBalances(
+	_ context.Context,
+	customerID domain.Option[domain.CounterpartyID],
+) ([]sales.SaleBalance, error) {
+	s.filters = append(s.filters, customerID)
+	return s.balances, nil
+}
+
+func TestPaymentServiceTotalsCustomerBalancesAndAgesReceivables(t *testing.T) {
+	customer := must(domain.NewCounterpartyID(3))
+	store := &openBalancesPaymentStore{balances: []sales.SaleBalance{
+		openSaleBalance(1, domain.Some(customer), "2026-08-01", 7_000),
+		openSaleBalance(2, domain.Some(customer), "2026-10-10", 2_500),
+	}}
+	service := NewPaymentService(store, &mutableClock{now: mustInstant(1_000)})
+
+	balance, err := service.GetCustomerBalance(context.Background(), customer)
+	if err != nil {
+		t.Fatalf("get customer balance: %v", err)
+	}
+	if balance.Outstanding != 9_500 || len(balance.Sales) != 2 || store.filters[0] != domain.Some(customer) {
+		t.Fatalf("customer balance = %#v, filters = %#v", balance, store.filters)
+	}
+
+	aging, err := service.GetReceivablesAging(context.Background(), must(domain.ParseBusinessDate("2026-10-18")))
+	if err != nil {
+		t.Fatalf("get receivables aging: %v", err)
+	}
+	if store.filters[1].IsSome() || aging.Totals() != (sales.AgingBuckets{Days0To30: 2_500, Over60Days: 7_000}) {
+		t.Fatalf("aging totals = %#v, filters = %#v", aging.Totals(), store.filters)
+	}
+
+	store.balances = append(store.balances, openSaleBalance(3, domain.None[domain.CounterpartyID](), "2026-10-10", 100))
+	if _, err := service.GetCustomerBalance(context.Background(), customer); !errors.Is(err, domain.ErrInvariant) {
+		t.Fatalf("foreign sale error = %v, want invariant", err)
+	}
+}
+
+// openSaleBalance is an unpaid sale whose whole total is outstanding.
+func openSaleBalance(
+	id int64,
+	customer domain.Option[domain.CounterpartyID],
+	occurredOn string,
+	total int64,
+) sales.SaleBalance {
+	return must(sales.NewSaleBalance(sales.BalanceParams{
+		SaleDocumentID: must(domain.NewStockDocumentID(id)),
+		CustomerID:     customer,
+		OccurredOn:     must(domain.ParseBusinessDate(occurredOn)),
+		SaleTotal:      must(domain.NewMinorAmount(total)),
+	}))
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqlitePaymentStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLitePaymentStore(store *sqlite.Store) PaymentStore {
+	if store == nil {
+		panic("sqlite payment store requires a store")
+	}
+	return &sqlitePaymentStore{store: store}
+}
+
+func (s *sqlitePaymentStore) GetSaleBalance(ctx context.Context, saleID domain.StockDocumentID) (sales.SaleBalance, error) {
+	return s.store.GetSaleBalance(ctx, saleID)
+}
+
+func (s *sqlitePaymentStore) ListOpenSaleBalances(
+	ctx context.Context,
+	customerID domain.Option[domain.CounterpartyID],
+) ([]sales.SaleBalance, error) {
+	return s.store.ListOpenSaleBalances(ctx, customerID)
+}
+
+func (s *sqlitePaymentStore) RecordSalePayment(ctx context.Context, input salePaymentRecordStoreInput) (sales.Payment, error) {
+	return s.store.RecordSalePayment(ctx, sqlite.RecordSalePaymentInput{
+		IdempotencyKey: input.IdempotencyKey,
+		SaleDocumentID: input.SaleDocumentID,
+		Method:         input.Method,
+		Amount:         input.Amount,
+		ReceivedOn:     input.ReceivedOn,
+		Notes:          input.Notes,
+		RecordedAt:     input.RecordedAt,
+	})
+}
+
+func (s *sqlitePaymentStore) ReverseSalePayment(ctx context.Context, input salePaymentReverseStoreInput) (sales.Payment, error) {
+	return s.store.ReverseSalePayment(ctx, sqlite.ReverseSalePaymentInput{
+		IdempotencyKey: input.IdempotencyKey,
+		PaymentID:      input.PaymentID,
+		ReceivedOn:     input.ReceivedOn,
+		Notes:          input.Notes,
+		RecordedAt:     input.RecordedAt,
+	})
+}
@@ -2,6 +2,7 @@ package application
 
 import (
 	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/settings"
 )
 
 type SettingsUpdateInput struct {
@@ -11,5 +12,6 @@ type SettingsUpdateInput struct {
 	Currency           domain.Currency
 	HourlyLaborCost    domain.Option[domain.MinorAmount]
 	DefaultGrossMargin domain.Option[domain.BasisPoints]
+	Backup             settings.BackupPolicy
 	ExpectedUpdatedAt  domain.UTCInstant
 }
@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+type PriceListStore interface {
+	GetPriceList(ctx context.Context, id domain.PriceListID) (sales.PriceList, error)
+	ListPriceLists(ctx context.Context, archive domain.ArchiveFilter) ([]sales.PriceList, error)
+	CreatePriceList(ctx context.Context, input priceListCreateStoreInput) (sales.PriceList, error)
+	UpdatePriceList(ctx context.Context, input priceListUpdateStoreInput) (sales.PriceList, error)
+	ArchivePriceList(ctx context.Context, input priceListArchiveStoreInput) (sales.PriceList, error)
+	RestorePriceList(ctx context.Context, input priceListRestoreStoreInput) (sales.PriceList, error)
+	AssignCustomerPriceList(ctx context.Context, input customerPriceListAssignStoreInput) (domain.Option[sales.PriceList], error)
+	GetCustomerPriceList(ctx context.Context, customerID domain.CounterpartyID) (domain.Option[sales.PriceList], error)
+}
+
+// PriceListPriceInput prices one base unit of ItemID, or one of its
+// packagings when PackagingID is set, from MinQuantity upward.
+type PriceListPriceInput struct {
+	ItemID      domain.ItemID
+	PackagingID domain.Option[domain.PackagingID]
+	MinQuantity domain.AtomicQuantity
+	UnitPrice   domain.MinorAmount
+}
+
+type PriceListCreateInput struct {
+	Name      domain.UniqueName
+	ValidFrom domain.Option[domain.BusinessDate]
+	ValidTo   domain.Option[domain.BusinessDate]
+	Prices    []PriceListPriceInput
+}
+
+// PriceListUpdateInput replaces the name, validity window, and every price of
+// an active list.
+type PriceListUpdateInput struct {
+	ID                domain.PriceListID
+	Name              domain.UniqueName
+	ValidFrom         domain.Option[domain.BusinessDate]
+	ValidTo           domain.Option[domain.BusinessDate]
+	Prices            []PriceListPriceInput
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type PriceListArchiveInput struct {
+	ID                domain.PriceListID
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type PriceListRestoreInput struct {
+	ID                domain.PriceListID
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+// CustomerPriceListAssignInput replaces the list assigned to a customer. A
+// None PriceListID returns the customer to default prices.
+type CustomerPriceListAssignInput struct {
+	CustomerID  domain.CounterpartyID
+	PriceListID domain.Option[domain.PriceListID]
+}
+
+type priceListCreateStoreInput struct {
+	PriceListCreateInput
+	CreatedAt domain.UTCInstant
+}
+
+type priceListUpdateStoreInput struct {
+	PriceListUpdateInput
+	UpdatedAt domain.UTCInstant
+}
+
+type priceListArchiveStoreInput struct {
+	PriceListArchiveInput
+	ArchivedAt domain.UTCInstant
+}
+
+type priceListRestoreStoreInput struct {
+	PriceListRestoreInput
+	UpdatedAt domain.UTCInstant
+}
+
+type customerPriceListAssignStoreInput struct {
+	CustomerPriceListAssignInput
+	AssignedAt domain.UTCInstant
+}
+
+type PriceListService struct {
+	store PriceListStore
+	clock Clock
+}
+
+func NewPriceListService(store PriceListStore, clock Clock) *PriceListService {
+	if store == nil {
+		panic("price list service requires a store")
+	}
+	if clock == nil {
+		panic("price list service requires a clock")
+	}
+	return &PriceListService{store: store, clock: clock}
+}
+
+func (s *PriceListService) GetPriceList(ctx context.Context, id domain.PriceListID) (sales.PriceList, error) {
+	list, err := s.store.GetPriceList(ctx, id)
+	if err != nil {
+		return sales.PriceList{}, fmt.Errorf("get price list: %w", err)
+	}
+	return list, nil
+}
+
+func (s *PriceListService) ListPriceLists(ctx context.Context, archive domain.ArchiveFilter) ([]sales.PriceList, error) {
+	lists, err := s.store.ListPriceLists(ctx, archive)
+	if err != nil {
+		return nil, fmt.Errorf("list price lists: %w", err)
+	}
+	return lists, nil
+}
+
+func (s *PriceListService) CreatePriceList(ctx context.Context, input PriceListCreateInput) (sales.PriceList, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.PriceList{}, fmt.Errorf("read clock: %w", err)
+	}
+	list, err := s.store.CreatePriceList(ctx, priceListCreateStoreInput{PriceListCreateInput: input, CreatedAt: now})
+	if err != nil {
+		return sales.PriceList{}, fmt.Errorf("create price list: %w", err)
+	}
+	if !list.CreatedAt().Equal(now) || !list.UpdatedAt().Equal(now) || len(list.Prices()) != len(input.Prices) {
+		return sales.PriceList{}, domain.ErrInvariant
+	}
+	return list, nil
+}
+
+func (s *PriceListService) UpdatePriceList(ctx context.Context, input PriceListUpdateInput) (sales.PriceList, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.PriceList{}, fmt.Errorf("read clock: %w", err)
+	}
+	list, err := s.store.UpdatePriceList(ctx, priceListUpdateStoreInput{PriceListUpdateInput: input, UpdatedAt: now})
+	if err != nil {
+		return sales.PriceList{}, fmt.Errorf("update price list: %w", err)
+	}
+	if !list.UpdatedAt().Equal(now) || len(list.Prices()) != len(input.Prices) {
+		return sales.PriceList{}, domain.ErrInvariant
+	}
+	return list, nil
+}
+
+func (s *PriceListService) ArchivePriceList(ctx context.Context, input PriceListArchiveInput) (sales.PriceList, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.PriceList{}, fmt.Errorf("read clock: %w", err)
+	}
+	list, err := s.store.ArchivePriceList(ctx, priceListArchiveStoreInput{PriceListArchiveInput: input, ArchivedAt: now})
+	if err != nil {
+		return sales.PriceList{}, fmt.Errorf("archive price list: %w", err)
+	}
+	archivedAt, ok := list.ArchivedAt().Get()
+	if !ok || !archivedAt.Equal(now) {
+		return sales.PriceList{}, domain.ErrInvariant
+	}
+	return list, nil
+}
+
+func (s *PriceListService) RestorePriceList(ctx context.Context, input PriceListRestoreInput) (sales.PriceList, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.PriceList{}, fmt.Errorf("read clock: %w", err)
+	}
+	list, err := s.store.RestorePriceList(ctx, priceListRestoreStoreInput{PriceListRestoreInput: input, UpdatedAt: now})
+	if err != nil {
+		return sales.PriceList{}, fmt.Errorf("restore price list: %w", err)
+	}
+	if list.IsArchived() || !list.UpdatedAt().Equal(now) {
+		return sales.PriceList{}, domain.ErrInvariant
+	}
+	return list, nil
+}
+
+// AssignCustomerPriceList returns the list now assigned to the customer, if
+// any. Sales already posted for the customer keep their totals.
+func (s *PriceListService) AssignCustomerPriceList(
+	ctx context.Context,
+	input CustomerPriceListAssignInput,
+) (domain.Option[sales.PriceList], error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return domain.None[sales.PriceList](), fmt.Errorf("read clock: %w", err)
+	}
+	assigned, err := s.store.AssignCustomerPriceList(ctx, customerPriceListAssignStoreInput{
+		CustomerPriceListAssignInput: input,
+		AssignedAt:                   now,
+	})
+	if err != nil {
+		return domain.None[sales.PriceList](), fmt.Errorf("assign customer price list: %w", err)
+	}
+	list, ok := assigned.Get()
+	want, wantOK := input.PriceListID.Get()
+	if ok != wantOK || (ok && list.ID() != want) {
+		return domain.None[sales.PriceList](), domain.ErrInvariant
+	}
+	return assigned, nil
+}
+
+func (s *PriceListService) GetCustomerPriceList(
+	ctx context.Context,
+	customerID domain.CounterpartyID,
+) (domain.Option[sales.PriceList], error) {
+	assigned, err := s.store.GetCustomerPriceList(ctx, customerID)
+	if err != nil {
+		return domain.None[sales.PriceList](), fmt.Errorf("get customer price list: %w", err)
+	}
+	return assigned, nil
+}
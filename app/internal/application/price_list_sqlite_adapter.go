@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqlitePriceListStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLitePriceListStore(store *sqlite.Store) PriceListStore {
+	if store == nil {
+		panic("sqlite price list store requires a store")
+	}
+	return &sqlitePriceListStore{store: store}
+}
+
+func (s *sqlitePriceListStore) GetPriceList(ctx context.Context, id domain.PriceListID) (sales.PriceList, error) {
+	return s.store.GetPriceList(ctx, id)
+}
+
+func (s *sqlitePriceListStore) ListPriceLists(ctx context.Context, archive domain.ArchiveFilter) ([]sales.PriceList, error) {
+	return s.store.ListPriceLists(ctx, archive)
+}
+
+func (s *sqlitePriceListStore) CreatePriceList(ctx context.Context, input priceListCreateStoreInput) (sales.PriceList, error) {
+	return s.store.CreatePriceList(ctx, sqlite.CreatePriceListInput{
+		Name:      input.Name,
+		ValidFrom: input.ValidFrom,
+		ValidTo:   input.ValidTo,
+		Prices:    mapSQLitePriceListPrices(input.Prices),
+		CreatedAt: input.CreatedAt,
+	})
+}
+
+func (s *sqlitePriceListStore) UpdatePriceList(ctx context.Context, input priceListUpdateStoreInput) (sales.PriceList, error) {
+	return s.store.UpdatePriceList(ctx, sqlite.UpdatePriceListInput{
+		ID:                input.ID,
+		Name:              input.Name,
+		ValidFrom:         input.ValidFrom,
+		ValidTo:           input.ValidTo,
+		Prices:            mapSQLitePriceListPrices(input.Prices),
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqlitePriceListStore) ArchivePriceList(ctx context.Context, input priceListArchiveStoreInput) (sales.PriceList, error) {
+	return s.store.ArchivePriceList(ctx, sqlite.ArchivePriceListInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		ArchivedAt:        input.ArchivedAt,
+	})
+}
+
+func (s *sqlitePriceListStore) RestorePriceList(ctx context.Context, input priceListRestoreStoreInput) (sales.PriceList, error) {
+	return s.store.RestorePriceList(ctx, sqlite.RestorePriceListInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqlitePriceListStore) AssignCustomerPriceList(
+	ctx context.Context,
+	input customerPriceListAssignStoreInput,
+) (domain.Option[sales.PriceList], error) {
+	return s.store.AssignCustomerPriceList(ctx, sqlite.AssignCustomerPriceListInput{
+		CustomerID:  input.CustomerID,
+		PriceListID: input.PriceListID,
+		AssignedAt:  input.AssignedAt,
+	})
+}
+
+func (s *sqlitePriceListStore) GetCustomerPriceList(
+	ctx context.Context,
+	customerID domain.CounterpartyID,
+) (domain.Option[sales.PriceList], error) {
+	return s.store.GetCustomerPriceList(ctx, customerID)
+}
+
+func mapSQLitePriceListPrices(prices []PriceListPriceInput) []sqlite.PriceListPriceInput {
+	mapped := make([]sqlite.PriceListPriceInput, 0, len(prices))
+	for _, price := range prices {
+		mapped = append(mapped, sqlite.PriceListPriceInput{
+			ItemID:      price.ItemID,
+			PackagingID: price.PackagingID,
+			MinQuantity: price.MinQuantity,
+			UnitPrice:   price.UnitPrice,
+		})
+	}
+	return mapped
+}
@@ -0,0 +1,277 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
+	"github.com/jerobas/saas/internal/domain/settings"
+)
+
+const grossMarginScale int64 = 10_000
+
+type PricingStore interface {
+	GetSettings(ctx context.Context) (settings.Settings, error)
+	GetRecipeRevision(ctx context.Context, id domain.RecipeRevisionID) (recipedomain.Revision, error)
+	GetRecipePricingTarget(ctx context.Context, recipeID domain.RecipeID) (RecipePricingTarget, error)
+	ListSellableRecipePricingTargets(ctx context.Context) ([]RecipePricingTarget, error)
+	ListItemCostBases(ctx context.Context, itemIDs []domain.ItemID) ([]inventory.CostBasis, error)
+}
+
+// RecipePricingTarget is the output item of a recipe with its current
+// revision and the default sale price it carries today.
+type RecipePricingTarget struct {
+	RecipeID          domain.RecipeID
+	RecipeName        domain.UniqueName
+	CurrentRevisionID domain.RecipeRevisionID
+	OutputItemID      domain.ItemID
+	OutputItemName    domain.UniqueName
+	DefaultSalePrice  domain.Option[domain.MinorAmount]
+}
+
+// PriceSuggestionInput prices one revision. GrossMargin overrides the
+// settings default for this calculation only.
+type PriceSuggestionInput struct {
+	RevisionID  domain.RecipeRevisionID
+	GrossMargin domain.Option[domain.BasisPoints]
+}
+
+// PriceSuggestion prices one yield base unit of a recipe output at material
+// cost plus labor, grossed up so the margin is the given share of the price.
+// It is a forecast only and never changes inventory value.
+type PriceSuggestion struct {
+	target               RecipePricingTarget
+	cost                 RecipeRevisionCost
+	currency             domain.Currency
+	laborCostConfigured  bool
+	laborValue           domain.InventoryValue
+	batchValue           domain.InventoryValue
+	costPerYieldBaseUnit domain.InventoryValue
+	grossMargin          domain.BasisPoints
+	suggestedPrice       domain.MinorAmount
+}
+
+func (p PriceSuggestion) Target() RecipePricingTarget        { return p.target }
+func (p PriceSuggestion) Cost() RecipeRevisionCost           { return p.cost }
+func (p PriceSuggestion) Currency() domain.Currency          { return p.currency }
+func (p PriceSuggestion) LaborCostConfigured() bool          { return p.laborCostConfigured }
+func (p PriceSuggestion) LaborValue() domain.InventoryValue  { return p.laborValue }
+func (p PriceSuggestion) BatchValue() domain.InventoryValue  { return p.batchValue }
+func (p PriceSuggestion) GrossMargin() domain.BasisPoints    { return p.grossMargin }
+func (p PriceSuggestion) SuggestedPrice() domain.MinorAmount { return p.suggestedPrice }
+func (p PriceSuggestion) CostPerYieldBaseUnit() domain.InventoryValue {
+	return p.costPerYieldBaseUnit
+}
+func (p PriceSuggestion) CurrentPrice() domain.Option[domain.MinorAmount] {
+	return p.target.DefaultSalePrice
+}
+
+// Underpriced reports whether the output has a default sale price below the
+// suggested price. A suggestion whose cost rollup misses a component is never
+// underpriced, since the missing cost would only raise it.
+func (p PriceSuggestion) Underpriced() bool {
+	current, ok := p.target.DefaultSalePrice.Get()
+	return ok && p.cost.Complete() && current.Int64() < p.suggestedPrice.Int64()
+}
+
+// UnderpricedItems lists the outputs priced below their suggestion. Outputs
+// whose cost rollup misses a component are listed apart in CostIncomplete:
+// their suggestion counts the missing cost as zero, so it cannot tell whether
+// the current price is too low.
+type UnderpricedItems struct {
+	Items          []PriceSuggestion
+	CostIncomplete []PriceSuggestion
+}
+
+type PricingService struct {
+	store PricingStore
+}
+
+func NewPricingService(store PricingStore) *PricingService {
+	if store == nil {
+		panic("pricing service requires a store")
+	}
+	return &PricingService{store: store}
+}
+
+// SuggestPrice prices the output of one recipe revision from its current
+// cost rollup, the configured hourly labor cost, and a gross margin.
+func (s *PricingService) SuggestPrice(ctx context.Context, input PriceSuggestionInput) (PriceSuggestion, error) {
+	current, err := s.store.GetSettings(ctx)
+	if err != nil {
+		return PriceSuggestion{}, fmt.Errorf("suggest recipe price: %w", err)
+	}
+	margin, err := resolveGrossMargin(current, input.GrossMargin)
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	revision, err := s.store.GetRecipeRevision(ctx, input.RevisionID)
+	if err != nil {
+		return PriceSuggestion{}, fmt.Errorf("suggest recipe price: %w", err)
+	}
+	target, err := s.store.GetRecipePricingTarget(ctx, revision.RecipeID())
+	if err != nil {
+		return PriceSuggestion{}, fmt.Errorf("suggest recipe price: %w", err)
+	}
+	suggestion, err := s.suggest(ctx, current, margin, target, revision)
+	if err != nil {
+		return PriceSuggestion{}, fmt.Errorf("suggest recipe price: %w", err)
+	}
+	return suggestion, nil
+}
+
+// ListUnderpricedItems prices the current revision of every active recipe
+// with an active sellable output and keeps those whose default sale price is
+// below the suggestion. Outputs without a default sale price are skipped, and
+// outputs with an incomplete cost rollup are returned apart whatever their
+// price.
+func (s *PricingService) ListUnderpricedItems(ctx context.Context, grossMargin domain.Option[domain.BasisPoints]) (UnderpricedItems, error) {
+	current, err := s.store.GetSettings(ctx)
+	if err != nil {
+		return UnderpricedItems{}, fmt.Errorf("list underpriced items: %w", err)
+	}
+	margin, err := resolveGrossMargin(current, grossMargin)
+	if err != nil {
+		return UnderpricedItems{}, err
+	}
+	targets, err := s.store.ListSellableRecipePricingTargets(ctx)
+	if err != nil {
+		return UnderpricedItems{}, fmt.Errorf("list underpriced items: %w", err)
+	}
+	result := UnderpricedItems{Items: make([]PriceSuggestion, 0), CostIncomplete: make([]PriceSuggestion, 0)}
+	for _, target := range targets {
+		if target.DefaultSalePrice.IsNone() {
+			continue
+		}
+		revision, err := s.store.GetRecipeRevision(ctx, target.CurrentRevisionID)
+		if err != nil {
+			return UnderpricedItems{}, fmt.Errorf("list underpriced items: %w", err)
+		}
+		suggestion, err := s.suggest(ctx, current, margin, target, revision)
+		if err != nil {
+			return UnderpricedItems{}, fmt.Errorf("list underpriced items: %w", err)
+		}
+		switch {
+		case !suggestion.Cost().Complete():
+			result.CostIncomplete = append(result.CostIncomplete, suggestion)
+		case suggestion.Underpriced():
+			result.Items = append(result.Items, suggestion)
+		}
+	}
+	return result, nil
+}
+
+func (s *PricingService) suggest(
+	ctx context.Context,
+	current settings.Settings,
+	margin domain.BasisPoints,
+	target RecipePricingTarget,
+	revision recipedomain.Revision,
+) (PriceSuggestion, error) {
+	if revision.RecipeID() != target.RecipeID {
+		return PriceSuggestion{}, domain.ErrInvariant
+	}
+	components := revision.Components()
+	itemIDs := make([]domain.ItemID, 0, len(components)+1)
+	for _, component := range components {
+		itemIDs = append(itemIDs, component.ItemID())
+	}
+	itemIDs = append(itemIDs, target.OutputItemID)
+	bases, err := s.store.ListItemCostBases(ctx, itemIDs)
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	cost, err := costRecipeRevision(revision, target.OutputItemID, bases)
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	return priceRecipeRevision(current, margin, target, cost, bases[len(components)])
+}
+
+// priceRecipeRevision adds prorated labor to the material cost, spreads the
+// batch over the standard yield, and grosses the base-unit cost up by the
+// margin: price = cost / (1 - margin), rounded half up to minor units.
+func priceRecipeRevision(
+	current settings.Settings,
+	margin domain.BasisPoints,
+	target RecipePricingTarget,
+	cost RecipeRevisionCost,
+	output inventory.CostBasis,
+) (PriceSuggestion, error) {
+	result := PriceSuggestion{
+		target: target, cost: cost, currency: current.Currency(), grossMargin: margin,
+	}
+	if hourly, ok := current.HourlyLaborCost().Get(); ok {
+		hourlyValue, err := hourly.ToInventoryValue(current.Currency())
+		if err != nil {
+			return PriceSuggestion{}, err
+		}
+		perHour, err := domain.NewFraction(hourlyValue.Int64(), 1)
+		if err != nil {
+			return PriceSuggestion{}, err
+		}
+		labor, err := roundedValue(perHour, cost.Revision().PreparationTime().Int64(), 60)
+		if err != nil {
+			return PriceSuggestion{}, err
+		}
+		result.laborCostConfigured = true
+		result.laborValue = labor
+	}
+	batch, err := cost.TotalValue().Add(result.laborValue)
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	perAtomicUnit, ok := batch.Per(cost.Revision().StandardYield())
+	if !ok {
+		return PriceSuggestion{}, domain.ErrInvariant
+	}
+	conversion := output.BaseConversion()
+	perBaseUnit, err := roundedValue(perAtomicUnit, conversion.NumeratorAtomic(), conversion.Denominator())
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	one, err := domain.NewMinorAmount(1)
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	microPerMinor, err := one.ToInventoryValue(current.Currency())
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	grossUp, err := domain.NewFraction(grossMarginScale, (grossMarginScale-margin.Int64())*microPerMinor.Int64())
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	perBaseUnitFraction, err := domain.NewFraction(perBaseUnit.Int64(), 1)
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	priced, err := perBaseUnitFraction.Multiply(grossUp)
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	rounded, err := priced.RoundHalfUp()
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	suggested, err := domain.NewMinorAmount(rounded)
+	if err != nil {
+		return PriceSuggestion{}, err
+	}
+	result.batchValue = batch
+	result.costPerYieldBaseUnit = perBaseUnit
+	result.suggestedPrice = suggested
+	return result, nil
+}
+
+func resolveGrossMargin(current settings.Settings, override domain.Option[domain.BasisPoints]) (domain.BasisPoints, error) {
+	if margin, ok := override.Get(); ok {
+		return margin, nil
+	}
+	if margin, ok := current.DefaultGrossMargin().Get(); ok {
+		return margin, nil
+	}
+	return domain.BasisPoints{}, domain.Invalid("gross_margin_basis_points", domain.ViolationRequired, "PRC-001")
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
+	"github.com/jerobas/saas/internal/domain/settings"
+)
+
+type memoryPricingStore struct {
+	PricingStore
+	settings settings.Settings
+	recipes  []recipedomain.Recipe
+	targets  []RecipePricingTarget
+	bases    map[domain.ItemID]inventory.CostBasis
+}
+
+func (s *memoryPricingStore) GetSettings(context.Context) (settings.Settings, error) {
+	return s.settings, nil
+}
+
+func (s *memoryPricingStore) GetRecipeRevision(_ context.Context, id domain.RecipeRevisionID) (recipedomain.Revision, error) {
+	for _, recipe := range s.recipes {
+		if recipe.CurrentRevision().ID() == id {
+			return recipe.CurrentRevision(), nil
+		}
+	}
+	return recipedomain.Revision{}, domain.ErrNotFound
+}
+
+func (s *memoryPricingStore) GetRecipePricingTarget(_ context.Context, recipeID domain.RecipeID) (RecipePricingTarget, error) {
+	for _, target := range s.targets {
+		if target.RecipeID == recipeID {
+			return target, nil
+		}
+	}
+	return RecipePricingTarget{}, domain.ErrNotFound
+}
+
+func (s *memoryPricingStore) ListSellableRecipePricingTargets(context.Context) ([]RecipePricingTarget, error) {
+	return s.targets, nil
+}
+
+func (s *memoryPricingStore) ListItemCostBases(_ context.Context, itemIDs []domain.ItemID) ([]inventory.CostBasis, error) {
+	bases := make([]inventory.CostBasis, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		bases = append(bases, s.bases[itemID])
+	}
+	return bases, nil
+}
+
+func TestPricingServiceListsRecipesWithUnpricedComponentsApart(t *testing.T) {
+	// Flour is in stock at 1 000 micro per atomic unit; glaze was never
+	// bought, so the cake rollup counts it as zero.
+	complete := planRecipe(t, planRecipeSpec{
+		id: 1, output: planDough, yield: 1_000, components: [][2]int64{{planFlour, 100}},
+	})
+	incomplete := planRecipe(t, planRecipeSpec{
+		id: 2, output: planCake, yield: 1_000, components: [][2]int64{{planFlour, 100}, {planGlaze, 50}},
+	})
+	basis := func(itemID int64, quantity, value int64) inventory.CostBasis {
+		return must(inventory.NewCostBasis(inventory.CostBasisParams{
+			Balance: must(inventory.NewBalance(inventory.BalanceParams{
+				ItemID: planItemID(t, itemID), Quantity: planQuantity(t, quantity),
+				Value: must(domain.NewInventoryValue(value)), UpdatedAt: mustInstant(1_000),
+			})),
+			BaseUnit:       must(domain.NewUnitCode("g")),
+			BaseConversion: must(domain.NewUnitConversion(1_000, 1)),
+		}))
+	}
+	target := func(recipe recipedomain.Recipe, name string) RecipePricingTarget {
+		return RecipePricingTarget{
+			RecipeID: recipe.ID(), RecipeName: recipe.Name(), CurrentRevisionID: recipe.CurrentRevision().ID(),
+			OutputItemID: recipe.OutputItemID(), OutputItemName: must(domain.NewUniqueName(name)),
+			DefaultSalePrice: domain.Some(must(domain.NewMinorAmount(1))),
+		}
+	}
+	store := &memoryPricingStore{
+		settings: must(settings.New(settings.Params{
+			BusinessName: must(domain.NewDisplayName("Padaria Central")),
+			Locale:       must(domain.NewLocale("pt-BR")),
+			Timezone:     must(domain.NewBusinessTimezone("America/Sao_Paulo")),
+			Currency:     must(domain.NewCurrency("BRL")),
+			Backup: must(settings.NewBackupPolicy(settings.BackupPolicyParams{
+				IntervalMinutes: 60, KeepDaily: 7,
+			})),
+			DefaultGrossMargin: domain.Some(must(domain.NewBasisPoints(5_000))),
+			CreatedAt:          mustInstant(1_000),
+			UpdatedAt:          mustInstant(1_000),
+		})),
+		recipes: []recipedomain.Recipe{complete, incomplete},
+		targets: []RecipePricingTarget{target(incomplete, "Cake"), target(complete, "Dough")},
+		bases: map[domain.ItemID]inventory.CostBasis{
+			planItemID(t, planFlour): basis(planFlour, 1_000, 1_000_000),
+			planItemID(t, planGlaze): basis(planGlaze, 0, 0),
+			planItemID(t, planCake):  basis(planCake, 0, 0),
+			planItemID(t, planDough): basis(planDough, 0, 0),
+		},
+	}
+	service := NewPricingService(store)
+	ctx := context.Background()
+
+	cake, err := service.SuggestPrice(ctx, PriceSuggestionInput{RevisionID: incomplete.CurrentRevision().ID()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cake.Cost().Complete() || cake.SuggestedPrice().Int64() != 20 || cake.Underpriced() {
+		t.Fatalf("incomplete suggestion = %#v", cake)
+	}
+
+	report, err := service.ListUnderpricedItems(ctx, domain.None[domain.BasisPoints]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Items) != 1 || report.Items[0].Target().RecipeID != complete.ID() ||
+		!report.Items[0].Cost().Complete() || report.Items[0].SuggestedPrice().Int64() != 20 {
+		t.Fatalf("underpriced items = %#v", report.Items)
+	}
+	if len(report.CostIncomplete) != 1 || report.CostIncomplete[0].Target().RecipeID != incomplete.ID() {
+		t.Fatalf("cost incomplete items = %#v", report.CostIncomplete)
+	}
+}
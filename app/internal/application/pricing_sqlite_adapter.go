@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	"github.com/jerobas/saas/internal/domain/recipe"
+	"github.com/jerobas/saas/internal/domain/settings"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqlitePricingStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLitePricingStore(store *sqlite.Store) PricingStore {
+	if store == nil {
+		panic("sqlite pricing store requires a store")
+	}
+	return &sqlitePricingStore{store: store}
+}
+
+func (s *sqlitePricingStore) GetSettings(ctx context.Context) (settings.Settings, error) {
+	return s.store.GetSettings(ctx)
+}
+
+func (s *sqlitePricingStore) GetRecipeRevision(ctx context.Context, id domain.RecipeRevisionID) (recipe.Revision, error) {
+	return s.store.GetRecipeRevision(ctx, id)
+}
+
+func (s *sqlitePricingStore) GetRecipePricingTarget(ctx context.Context, recipeID domain.RecipeID) (RecipePricingTarget, error) {
+	target, err := s.store.GetRecipePricingTarget(ctx, recipeID)
+	if err != nil {
+		return RecipePricingTarget{}, err
+	}
+	return mapRecipePricingTarget(target), nil
+}
+
+func (s *sqlitePricingStore) ListSellableRecipePricingTargets(ctx context.Context) ([]RecipePricingTarget, error) {
+	targets, err := s.store.ListSellableRecipePricingTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]RecipePricingTarget, 0, len(targets))
+	for _, target := range targets {
+		result = append(result, mapRecipePricingTarget(target))
+	}
+	return result, nil
+}
+
+func (s *sqlitePricingStore) ListItemCostBases(ctx context.Context, itemIDs []domain.ItemID) ([]inventory.CostBasis, error) {
+	return s.store.ListItemCostBases(ctx, itemIDs)
+}
+
+func mapRecipePricingTarget(value sqlite.RecipePricingTarget) RecipePricingTarget {
+	return RecipePricingTarget{
+		RecipeID:          value.RecipeID,
+		RecipeName:        value.RecipeName,
+		CurrentRevisionID: value.CurrentRevisionID,
+		OutputItemID:      value.OutputItemID,
+		OutputItemName:    value.OutputItemName,
+		DefaultSalePrice:  value.DefaultSalePrice,
+	}
+}
@@ -0,0 +1,309 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jerobas/saas/internal/domain"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
+)
+
+// ProductionPlanInput asks for Quantity atomic units of ItemID. Recipes picks
+// the recipe of any item produced by more than one active recipe.
+type ProductionPlanInput struct {
+	ItemID   domain.ItemID
+	Quantity domain.AtomicQuantity
+	Recipes  []domain.RecipeID
+}
+
+type PlannedRunInput struct {
+	ItemID   domain.ItemID
+	Quantity domain.AtomicQuantity
+}
+
+// PlannedProductionRun produces the part of an item's requirement that stock
+// does not cover with the current revision of one recipe. Level is the
+// deepest position of the item below the target, which is level zero.
+type PlannedProductionRun struct {
+	recipeID     domain.RecipeID
+	revisionID   domain.RecipeRevisionID
+	outputItemID domain.ItemID
+	level        int
+	required     domain.AtomicQuantity
+	fromStock    domain.AtomicQuantity
+	quantity     domain.AtomicQuantity
+	inputs       []PlannedRunInput
+}
+
+func (r PlannedProductionRun) RecipeID() domain.RecipeID           { return r.recipeID }
+func (r PlannedProductionRun) RevisionID() domain.RecipeRevisionID { return r.revisionID }
+func (r PlannedProductionRun) OutputItemID() domain.ItemID         { return r.outputItemID }
+func (r PlannedProductionRun) Level() int                          { return r.level }
+func (r PlannedProductionRun) Required() domain.AtomicQuantity     { return r.required }
+func (r PlannedProductionRun) FromStock() domain.AtomicQuantity    { return r.fromStock }
+func (r PlannedProductionRun) Quantity() domain.AtomicQuantity     { return r.quantity }
+func (r PlannedProductionRun) Inputs() []PlannedRunInput {
+	inputs := make([]PlannedRunInput, len(r.inputs))
+	copy(inputs, r.inputs)
+	return inputs
+}
+
+// PlannedPurchase is the shortfall of an item that no active recipe produces.
+type PlannedPurchase struct {
+	itemID    domain.ItemID
+	required  domain.AtomicQuantity
+	fromStock domain.AtomicQuantity
+	quantity  domain.AtomicQuantity
+}
+
+func (p PlannedPurchase) ItemID() domain.ItemID            { return p.itemID }
+func (p PlannedPurchase) Required() domain.AtomicQuantity  { return p.required }
+func (p PlannedPurchase) FromStock() domain.AtomicQuantity { return p.fromStock }
+func (p PlannedPurchase) Quantity() domain.AtomicQuantity  { return p.quantity }
+
+// ProductionPlan lists runs so that every run follows the runs producing its
+// inputs, and the raw materials to buy. Both follow recipe component order,
+// deepest first.
+type ProductionPlan struct {
+	itemID    domain.ItemID
+	quantity  domain.AtomicQuantity
+	runs      []PlannedProductionRun
+	purchases []PlannedPurchase
+}
+
+func (p ProductionPlan) ItemID() domain.ItemID           { return p.itemID }
+func (p ProductionPlan) Quantity() domain.AtomicQuantity { return p.quantity }
+func (p ProductionPlan) Runs() []PlannedProductionRun {
+	runs := make([]PlannedProductionRun, len(p.runs))
+	copy(runs, p.runs)
+	return runs
+}
+func (p ProductionPlan) Purchases() []PlannedPurchase {
+	purchases := make([]PlannedPurchase, len(p.purchases))
+	copy(purchases, p.purchases)
+	return purchases
+}
+
+// ProductionPlanService expands nested recipes into production runs. It only
+// reads recipes and balances and never posts documents.
+type ProductionPlanService struct {
+	recipes   RecipeStore
+	inventory InventoryStore
+}
+
+func NewProductionPlanService(recipes RecipeStore, inventory InventoryStore) *ProductionPlanService {
+	if recipes == nil {
+		panic("production plan service requires a recipe store")
+	}
+	if inventory == nil {
+		panic("production plan service requires an inventory store")
+	}
+	return &ProductionPlanService{recipes: recipes, inventory: inventory}
+}
+
+// Plan expands the recipe tree below the target through the current revision
+// of each output's active recipe, then nets each item's total requirement
+// against its balance from the target downwards. Component requirements scale
+// exactly with the quantity to produce and round up to whole atomic units.
+func (s *ProductionPlanService) Plan(ctx context.Context, input ProductionPlanInput) (ProductionPlan, error) {
+	if input.ItemID.IsZero() {
+		return ProductionPlan{}, domain.Invalid("item_id", domain.ViolationRequired, "")
+	}
+	if input.Quantity.Int64() <= 0 {
+		return ProductionPlan{}, domain.Invalid("quantity_atomic", domain.ViolationNotPositive, "")
+	}
+	planner := productionPlanner{
+		ctx: ctx, store: s.recipes,
+		preferred: make(map[domain.RecipeID]bool, len(input.Recipes)),
+		recipes:   make(map[domain.ItemID]domain.Option[recipedomain.Recipe]),
+		visits:    make(map[domain.ItemID]planVisit),
+	}
+	for _, recipeID := range input.Recipes {
+		planner.preferred[recipeID] = true
+	}
+	if err := planner.visit(input.ItemID); err != nil {
+		return ProductionPlan{}, fmt.Errorf("plan production: %w", err)
+	}
+	if planner.recipes[input.ItemID].IsNone() {
+		return ProductionPlan{}, domain.Invalid("item_id", domain.ViolationInvariant, "PLN-001")
+	}
+	plan, err := s.net(ctx, input, planner.topologicalOrder(), planner.recipes)
+	if err != nil {
+		return ProductionPlan{}, fmt.Errorf("plan production: %w", err)
+	}
+	return plan, nil
+}
+
+func (s *ProductionPlanService) net(
+	ctx context.Context,
+	input ProductionPlanInput,
+	order []domain.ItemID,
+	recipes map[domain.ItemID]domain.Option[recipedomain.Recipe],
+) (ProductionPlan, error) {
+	levels := make(map[domain.ItemID]int, len(order))
+	for _, itemID := range order {
+		if recipe, ok := recipes[itemID].Get(); ok {
+			for _, component := range recipe.CurrentRevision().Components() {
+				levels[component.ItemID()] = max(levels[component.ItemID()], levels[itemID]+1)
+			}
+		}
+	}
+	demand := map[domain.ItemID]domain.AtomicQuantity{input.ItemID: input.Quantity}
+	plan := ProductionPlan{itemID: input.ItemID, quantity: input.Quantity}
+	runs := make([]PlannedProductionRun, 0)
+	purchases := make([]PlannedPurchase, 0)
+	for _, itemID := range order {
+		required := demand[itemID]
+		if required.IsZero() {
+			continue
+		}
+		balance, err := s.inventory.GetInventoryBalance(ctx, itemID)
+		if err != nil {
+			return ProductionPlan{}, err
+		}
+		fromStock := balance.Balance().Quantity()
+		if fromStock.Int64() > required.Int64() {
+			fromStock = required
+		}
+		shortfall, err := required.Sub(fromStock)
+		if err != nil {
+			return ProductionPlan{}, err
+		}
+		if shortfall.IsZero() {
+			continue
+		}
+		recipe, ok := recipes[itemID].Get()
+		if !ok {
+			purchases = append(purchases, PlannedPurchase{
+				itemID: itemID, required: required, fromStock: fromStock, quantity: shortfall,
+			})
+			continue
+		}
+		revision := recipe.CurrentRevision()
+		run := PlannedProductionRun{
+			recipeID: recipe.ID(), revisionID: revision.ID(), outputItemID: itemID,
+			level: levels[itemID], required: required, fromStock: fromStock, quantity: shortfall,
+		}
+		scale, err := domain.NewFraction(shortfall.Int64(), revision.StandardYield().Int64())
+		if err != nil {
+			return ProductionPlan{}, err
+		}
+		for _, component := range revision.Components() {
+			quantity, err := scaleAtomicQuantity(component.Quantity(), scale)
+			if err != nil {
+				return ProductionPlan{}, err
+			}
+			if demand[component.ItemID()], err = demand[component.ItemID()].Add(quantity.rounded); err != nil {
+				return ProductionPlan{}, err
+			}
+			run.inputs = append(run.inputs, PlannedRunInput{ItemID: component.ItemID(), Quantity: quantity.rounded})
+		}
+		runs = append(runs, run)
+	}
+	plan.runs = make([]PlannedProductionRun, 0, len(runs))
+	for index := len(runs) - 1; index >= 0; index-- {
+		plan.runs = append(plan.runs, runs[index])
+	}
+	plan.purchases = make([]PlannedPurchase, 0, len(purchases))
+	for index := len(purchases) - 1; index >= 0; index-- {
+		plan.purchases = append(plan.purchases, purchases[index])
+	}
+	return plan, nil
+}
+
+type planVisit int
+
+const (
+	planUnvisited planVisit = iota
+	planVisiting
+	planVisited
+)
+
+// productionPlanner walks the recipe graph depth first, resolving each item's
+// recipe once and rejecting cycles.
+type productionPlanner struct {
+	ctx       context.Context
+	store     RecipeStore
+	preferred map[domain.RecipeID]bool
+	recipes   map[domain.ItemID]domain.Option[recipedomain.Recipe]
+	visits    map[domain.ItemID]planVisit
+	path      []domain.ItemID
+	postorder []domain.ItemID
+}
+
+func (p *productionPlanner) visit(itemID domain.ItemID) error {
+	switch p.visits[itemID] {
+	case planVisited:
+		return nil
+	case planVisiting:
+		return p.cycleError(itemID)
+	}
+	p.visits[itemID] = planVisiting
+	p.path = append(p.path, itemID)
+	recipe, err := p.resolve(itemID)
+	if err != nil {
+		return err
+	}
+	p.recipes[itemID] = recipe
+	if value, ok := recipe.Get(); ok {
+		for _, component := range value.CurrentRevision().Components() {
+			if err := p.visit(component.ItemID()); err != nil {
+				return err
+			}
+		}
+	}
+	p.path = p.path[:len(p.path)-1]
+	p.visits[itemID] = planVisited
+	p.postorder = append(p.postorder, itemID)
+	return nil
+}
+
+func (p *productionPlanner) resolve(itemID domain.ItemID) (domain.Option[recipedomain.Recipe], error) {
+	candidates, err := p.store.ListActiveRecipesByOutputItem(p.ctx, itemID)
+	if err != nil {
+		return domain.None[recipedomain.Recipe](), err
+	}
+	switch len(candidates) {
+	case 0:
+		return domain.None[recipedomain.Recipe](), nil
+	case 1:
+		return domain.Some(candidates[0]), nil
+	}
+	chosen := make([]recipedomain.Recipe, 0, 1)
+	for _, candidate := range candidates {
+		if p.preferred[candidate.ID()] {
+			chosen = append(chosen, candidate)
+		}
+	}
+	if len(chosen) != 1 {
+		return domain.None[recipedomain.Recipe](), domain.Invalid("recipe_ids", domain.ViolationRequired, "PLN-003")
+	}
+	return domain.Some(chosen[0]), nil
+}
+
+func (p *productionPlanner) cycleError(itemID domain.ItemID) error {
+	start := 0
+	for index, pathItemID := range p.path {
+		if pathItemID == itemID {
+			start = index
+			break
+		}
+	}
+	labels := make([]string, 0, len(p.path)-start+1)
+	for _, pathItemID := range append(p.path[start:], itemID) {
+		labels = append(labels, fmt.Sprint(pathItemID.Int64()))
+	}
+	return fmt.Errorf("recipe cycle through items %s: %w",
+		strings.Join(labels, " -> "),
+		domain.Invalid("item_id", domain.ViolationInvariant, "PLN-002"))
+}
+
+// topologicalOrder places every item after all items whose recipes use it.
+func (p *productionPlanner) topologicalOrder() []domain.ItemID {
+	order := make([]domain.ItemID, 0, len(p.postorder))
+	for index := len(p.postorder) - 1; index >= 0; index-- {
+		order = append(order, p.postorder[index])
+	}
+	return order
+}
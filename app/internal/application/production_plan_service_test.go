@@ -0,0 +1,362 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
+)
+
+const (
+	planCake int64 = iota + 1
+	planDough
+	planFilling
+	planFlour
+	planButter
+	planSugar
+	planCream
+	planGlaze
+)
+
+type planRecipeSpec struct {
+	id         int64
+	output     int64
+	yield      int64
+	components [][2]int64
+}
+
+type planRunWant struct {
+	output, level, required, fromStock, quantity int64
+	inputs                                       [][2]int64
+}
+
+type planPurchaseWant struct {
+	item, required, fromStock, quantity int64
+}
+
+func TestProductionPlanServiceExpandsNestedRecipes(t *testing.T) {
+	cake := planRecipeSpec{id: 1, output: planCake, yield: 1_000, components: [][2]int64{
+		{planDough, 600}, {planFilling, 400}, {planSugar, 100},
+	}}
+	dough := planRecipeSpec{id: 2, output: planDough, yield: 500, components: [][2]int64{
+		{planFlour, 400}, {planButter, 100},
+	}}
+	filling := planRecipeSpec{id: 3, output: planFilling, yield: 200, components: [][2]int64{
+		{planSugar, 150}, {planCream, 100},
+	}}
+
+	for _, tc := range []struct {
+		name      string
+		recipes   []planRecipeSpec
+		balances  map[int64]int64
+		quantity  int64
+		preferred []int64
+		runs      []planRunWant
+		purchases []planPurchaseWant
+	}{
+		{
+			name:     "single level buys every component",
+			recipes:  []planRecipeSpec{dough},
+			quantity: 1_000,
+			runs: []planRunWant{
+				{output: planDough, level: 0, required: 1_000, quantity: 1_000, inputs: [][2]int64{{planFlour, 800}, {planButter, 200}}},
+			},
+			purchases: []planPurchaseWant{
+				{item: planFlour, required: 800, quantity: 800},
+				{item: planButter, required: 200, quantity: 200},
+			},
+		},
+		{
+			name:     "nested recipes run deepest first and aggregate shared raw materials",
+			recipes:  []planRecipeSpec{cake, dough, filling},
+			balances: map[int64]int64{planSugar: 50},
+			quantity: 2_000,
+			runs: []planRunWant{
+				{output: planDough, level: 1, required: 1_200, quantity: 1_200, inputs: [][2]int64{{planFlour, 960}, {planButter, 240}}},
+				{output: planFilling, level: 1, required: 800, quantity: 800, inputs: [][2]int64{{planSugar, 600}, {planCream, 400}}},
+				{output: planCake, level: 0, required: 2_000, quantity: 2_000, inputs: [][2]int64{{planDough, 1_200}, {planFilling, 800}, {planSugar, 200}}},
+			},
+			purchases: []planPurchaseWant{
+				{item: planFlour, required: 960, quantity: 960},
+				{item: planButter, required: 240, quantity: 240},
+				{item: planSugar, required: 800, fromStock: 50, quantity: 750},
+				{item: planCream, required: 400, quantity: 400},
+			},
+		},
+		{
+			name:     "intermediate stock nets before expanding",
+			recipes:  []planRecipeSpec{cake, dough, filling},
+			balances: map[int64]int64{planDough: 700, planFilling: 400, planSugar: 1_000, planCream: 1_000},
+			quantity: 1_000,
+			runs: []planRunWant{
+				{output: planCake, level: 0, required: 1_000, quantity: 1_000, inputs: [][2]int64{{planDough, 600}, {planFilling, 400}, {planSugar, 100}}},
+			},
+			purchases: []planPurchaseWant{},
+		},
+		{
+			name:      "target stock covers the request",
+			recipes:   []planRecipeSpec{cake, dough, filling},
+			balances:  map[int64]int64{planCake: 5_000},
+			quantity:  1_000,
+			runs:      []planRunWant{},
+			purchases: []planPurchaseWant{},
+		},
+		{
+			name:     "partial target stock scales the run and rounds inputs up",
+			recipes:  []planRecipeSpec{filling},
+			balances: map[int64]int64{planFilling: 97, planSugar: 1_000, planCream: 1_000},
+			quantity: 100,
+			runs: []planRunWant{
+				{output: planFilling, level: 0, required: 100, fromStock: 97, quantity: 3, inputs: [][2]int64{{planSugar, 3}, {planCream, 2}}},
+			},
+			purchases: []planPurchaseWant{},
+		},
+		{
+			name: "shared sub-recipe runs once at its deepest level",
+			recipes: []planRecipeSpec{
+				{id: 1, output: planCake, yield: 100, components: [][2]int64{{planDough, 100}, {planFilling, 100}}},
+				{id: 3, output: planFilling, yield: 100, components: [][2]int64{{planDough, 50}, {planCream, 50}}},
+				{id: 2, output: planDough, yield: 100, components: [][2]int64{{planFlour, 100}}},
+			},
+			balances: map[int64]int64{planFlour: 1_000, planCream: 1_000},
+			quantity: 100,
+			runs: []planRunWant{
+				{output: planDough, level: 2, required: 150, quantity: 150, inputs: [][2]int64{{planFlour, 150}}},
+				{output: planFilling, level: 1, required: 100, quantity: 100, inputs: [][2]int64{{planDough, 50}, {planCream, 50}}},
+				{output: planCake, level: 0, required: 100, quantity: 100, inputs: [][2]int64{{planDough, 100}, {planFilling, 100}}},
+			},
+			purchases: []planPurchaseWant{},
+		},
+		{
+			name: "preferred recipe resolves several active recipes",
+			recipes: []planRecipeSpec{
+				{id: 4, output: planGlaze, yield: 100, components: [][2]int64{{planSugar, 80}}},
+				{id: 5, output: planGlaze, yield: 100, components: [][2]int64{{planCream, 60}}},
+			},
+			quantity:  100,
+			preferred: []int64{5},
+			runs: []planRunWant{
+				{output: planGlaze, level: 0, required: 100, quantity: 100, inputs: [][2]int64{{planCream, 60}}},
+			},
+			purchases: []planPurchaseWant{{item: planCream, required: 60, quantity: 60}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newPlanTestService(t, tc.recipes, tc.balances)
+			target := tc.recipes[0].output
+			plan, err := service.Plan(context.Background(), ProductionPlanInput{
+				ItemID:   planItemID(t, target),
+				Quantity: planQuantity(t, tc.quantity),
+				Recipes:  planRecipeIDs(t, tc.preferred),
+			})
+			if err != nil {
+				t.Fatalf("plan: %v", err)
+			}
+			runs := make([]planRunWant, 0, len(plan.Runs()))
+			for _, run := range plan.Runs() {
+				got := planRunWant{
+					output: run.OutputItemID().Int64(), level: int64(run.Level()),
+					required: run.Required().Int64(), fromStock: run.FromStock().Int64(),
+					quantity: run.Quantity().Int64(),
+				}
+				for _, input := range run.Inputs() {
+					got.inputs = append(got.inputs, [2]int64{input.ItemID.Int64(), input.Quantity.Int64()})
+				}
+				runs = append(runs, got)
+			}
+			if !reflect.DeepEqual(runs, tc.runs) {
+				t.Fatalf("runs = %+v, want %+v", runs, tc.runs)
+			}
+			purchases := make([]planPurchaseWant, 0, len(plan.Purchases()))
+			for _, purchase := range plan.Purchases() {
+				purchases = append(purchases, planPurchaseWant{
+					item: purchase.ItemID().Int64(), required: purchase.Required().Int64(),
+					fromStock: purchase.FromStock().Int64(), quantity: purchase.Quantity().Int64(),
+				})
+			}
+			if !reflect.DeepEqual(purchases, tc.purchases) {
+				t.Fatalf("purchases = %+v, want %+v", purchases, tc.purchases)
+			}
+		})
+	}
+}
+
+func TestProductionPlanServiceRejectsUnplannableRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		recipes     []planRecipeSpec
+		target      int64
+		quantity    int64
+		invariantID string
+	}{
+		{
+			name:     "zero quantity",
+			recipes:  []planRecipeSpec{{id: 1, output: planCake, yield: 100, components: [][2]int64{{planFlour, 10}}}},
+			target:   planCake,
+			quantity: 0,
+		},
+		{
+			name:        "target without an active recipe",
+			target:      planFlour,
+			quantity:    100,
+			invariantID: "PLN-001",
+		},
+		{
+			name: "recipe cycle",
+			recipes: []planRecipeSpec{
+				{id: 1, output: planCake, yield: 100, components: [][2]int64{{planDough, 10}}},
+				{id: 2, output: planDough, yield: 100, components: [][2]int64{{planFilling, 10}}},
+				{id: 3, output: planFilling, yield: 100, components: [][2]int64{{planCake, 10}}},
+			},
+			target:      planCake,
+			quantity:    100,
+			invariantID: "PLN-002",
+		},
+		{
+			name: "several active recipes without a preference",
+			recipes: []planRecipeSpec{
+				{id: 4, output: planGlaze, yield: 100, components: [][2]int64{{planSugar, 80}}},
+				{id: 5, output: planGlaze, yield: 100, components: [][2]int64{{planCream, 60}}},
+			},
+			target:      planGlaze,
+			quantity:    100,
+			invariantID: "PLN-003",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			service := newPlanTestService(t, tc.recipes, nil)
+			_, err := service.Plan(context.Background(), ProductionPlanInput{
+				ItemID:   planItemID(t, tc.target),
+				Quantity: planQuantity(t, tc.quantity),
+			})
+			if !errors.Is(err, domain.ErrValidation) {
+				t.Fatalf("plan error = %v, want validation", err)
+			}
+			var validation *domain.ValidationError
+			if !errors.As(err, &validation) || validation.Violations()[0].InvariantID != tc.invariantID {
+				t.Fatalf("plan error = %v, want invariant %q", err, tc.invariantID)
+			}
+		})
+	}
+}
+
+type planRecipeStore struct {
+	RecipeStore
+	byOutput map[domain.ItemID][]recipedomain.Recipe
+}
+
+func (s *planRecipeStore) ListActiveRecipesByOutputItem(_ context.Context, itemID domain.ItemID) ([]recipedomain.Recipe, error) {
+	return s.byOutput[itemID], nil
+}
+
+type planInventoryStore struct {
+	InventoryStore
+	balances map[domain.ItemID]int64
+}
+
+func (s *planInventoryStore) GetInventoryBalance(_ context.Context, itemID domain.ItemID) (inventory.BalanceSnapshot, error) {
+	balance, err := inventory.NewBalance(inventory.BalanceParams{
+		ItemID:    itemID,
+		Quantity:  must(domain.NewAtomicQuantity(s.balances[itemID])),
+		UpdatedAt: mustInstant(1_000),
+	})
+	if err != nil {
+		return inventory.BalanceSnapshot{}, err
+	}
+	return inventory.NewBalanceSnapshot(inventory.BalanceSnapshotParams{
+		Balance:  balance,
+		ItemName: must(domain.NewUniqueName(fmt.Sprintf("Item %d", itemID.Int64()))),
+		BaseUnit: must(domain.NewUnitCode("g")),
+	})
+}
+
+func newPlanTestService(t *testing.T, specs []planRecipeSpec, balances map[int64]int64) *ProductionPlanService {
+	t.Helper()
+	recipes := &planRecipeStore{byOutput: make(map[domain.ItemID][]recipedomain.Recipe)}
+	for _, spec := range specs {
+		value := planRecipe(t, spec)
+		recipes.byOutput[value.OutputItemID()] = append(recipes.byOutput[value.OutputItemID()], value)
+	}
+	stock := &planInventoryStore{balances: make(map[domain.ItemID]int64)}
+	for itemID, quantity := range balances {
+		stock.balances[planItemID(t, itemID)] = quantity
+	}
+	return NewProductionPlanService(recipes, stock)
+}
+
+func planRecipe(t *testing.T, spec planRecipeSpec) recipedomain.Recipe {
+	t.Helper()
+	recipeID := must(domain.NewRecipeID(spec.id))
+	revisionID := must(domain.NewRecipeRevisionID(spec.id * 10))
+	components := make([]recipedomain.Component, 0, len(spec.components))
+	for index, line := range spec.components {
+		component, err := recipedomain.NewComponent(recipedomain.ComponentParams{
+			ID:          must(domain.NewRecipeComponentID(spec.id*100 + int64(index) + 1)),
+			RevisionID:  revisionID,
+			Order:       must(domain.NewComponentOrder(int64(index) + 1)),
+			ItemID:      planItemID(t, line[0]),
+			Quantity:    planQuantity(t, line[1]),
+			EnteredUnit: must(domain.NewUnitCode("g")),
+			Conversion:  must(domain.NewUnitConversion(1_000, 1)),
+			CreatedAt:   mustInstant(1_000),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		components = append(components, component)
+	}
+	revision, err := recipedomain.NewRevision(recipedomain.RevisionParams{
+		ID: revisionID, RecipeID: recipeID,
+		Number:        must(domain.NewRevisionNumber(1)),
+		StandardYield: planQuantity(t, spec.yield),
+		CreatedAt:     mustInstant(1_000),
+		Components:    components,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := recipedomain.New(recipedomain.Params{
+		ID:              recipeID,
+		Name:            must(domain.NewUniqueName(fmt.Sprintf("Recipe %d", spec.id))),
+		OutputItemID:    planItemID(t, spec.output),
+		CreatedAt:       mustInstant(1_000),
+		UpdatedAt:       mustInstant(1_000),
+		CurrentRevision: revision,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
+func planItemID(t *testing.T, value int64) domain.ItemID {
+	t.Helper()
+	id, err := domain.NewItemID(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func planQuantity(t *testing.T, value int64) domain.AtomicQuantity {
+	t.Helper()
+	quantity, err := domain.NewAtomicQuantity(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return quantity
+}
+
+func planRecipeIDs(t *testing.T, values []int64) []domain.RecipeID {
+	t.Helper()
+	ids := make([]domain.RecipeID, 0, len(values))
+	for _, value := range values {
+		ids = append(ids, must(domain.NewRecipeID(value)))
+	}
+	return ids
+}
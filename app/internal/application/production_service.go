@@ -5,12 +5,52 @@ import (
 	"fmt"
 
 	"github.com/jerobas/saas/internal/domain"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
 )
 
 type ProductionStore interface {
+	GetProduction(ctx context.Context, id domain.StockDocumentID) (ProductionDocument, error)
+	ListProductions(ctx context.Context, input ProductionListInput) (ProductionPage, error)
 	PostProduction(ctx context.Context, input productionPostStoreInput) (ProductionDocument, error)
+	GetRecipe(ctx context.Context, id domain.RecipeID) (recipedomain.Recipe, error)
+	GetRecipeRevision(ctx context.Context, id domain.RecipeRevisionID) (recipedomain.Revision, error)
 }
 
+type ProductionCursor struct {
+	PostingSequence domain.PostingSequence
+	ID              domain.StockDocumentID
+}
+
+// ProductionListInput filters production runs by recipe (any revision),
+// output item, and an inclusive occurred-on range.
+type ProductionListInput struct {
+	RecipeID       domain.Option[domain.RecipeID]
+	OutputItemID   domain.Option[domain.ItemID]
+	FromOccurredOn domain.Option[domain.BusinessDate]
+	ToOccurredOn   domain.Option[domain.BusinessDate]
+	After          domain.Option[ProductionCursor]
+	PageSize       int
+}
+
+type ProductionPage struct {
+	items []ProductionDocument
+	next  domain.Option[ProductionCursor]
+}
+
+func NewProductionPage(items []ProductionDocument, next domain.Option[ProductionCursor]) ProductionPage {
+	cloned := make([]ProductionDocument, len(items))
+	copy(cloned, items)
+	return ProductionPage{items: cloned, next: next}
+}
+
+func (p ProductionPage) Items() []ProductionDocument {
+	items := make([]ProductionDocument, len(p.items))
+	copy(items, p.items)
+	return items
+}
+
+func (p ProductionPage) Next() domain.Option[ProductionCursor] { return p.next }
+
 type ProductionOutputInput struct {
 	Quantity             domain.AtomicQuantity
 	EnteredUnit          domain.UnitCode
@@ -254,6 +294,47 @@ func (a ProductionAllocation) ID() domain.LotAllocationID      { return a.id }
 func (a ProductionAllocation) LotID() domain.InventoryLotID    { return a.lotID }
 func (a ProductionAllocation) Quantity() domain.AtomicQuantity { return a.quantity }
 
+// ProductionScaleInput sizes a run of one recipe revision by exactly one of
+// a batch multiplier or a desired output quantity in atomic units.
+type ProductionScaleInput struct {
+	RevisionID     domain.RecipeRevisionID
+	Multiplier     domain.Option[domain.Fraction]
+	OutputQuantity domain.Option[domain.AtomicQuantity]
+}
+
+// ScaledProductionInput is one recipe component sized for the run. Input is
+// ready to edit and post; Exact is the unrounded atomic quantity and Residue
+// is what rounding up to Input.Quantity added to it.
+type ScaledProductionInput struct {
+	Input   ProductionComponentInput
+	Exact   domain.Fraction
+	Residue domain.Fraction
+}
+
+// ScaledProduction is a production draft derived from a recipe revision. It
+// never posts anything and callers may edit every line before posting.
+type ScaledProduction struct {
+	revisionID     domain.RecipeRevisionID
+	outputItemID   domain.ItemID
+	multiplier     domain.Fraction
+	outputQuantity domain.AtomicQuantity
+	outputExact    domain.Fraction
+	outputResidue  domain.Fraction
+	inputs         []ScaledProductionInput
+}
+
+func (p ScaledProduction) RevisionID() domain.RecipeRevisionID   { return p.revisionID }
+func (p ScaledProduction) OutputItemID() domain.ItemID           { return p.outputItemID }
+func (p ScaledProduction) Multiplier() domain.Fraction           { return p.multiplier }
+func (p ScaledProduction) OutputQuantity() domain.AtomicQuantity { return p.outputQuantity }
+func (p ScaledProduction) OutputExact() domain.Fraction          { return p.outputExact }
+func (p ScaledProduction) OutputResidue() domain.Fraction        { return p.outputResidue }
+func (p ScaledProduction) Inputs() []ScaledProductionInput {
+	inputs := make([]ScaledProductionInput, len(p.inputs))
+	copy(inputs, p.inputs)
+	return inputs
+}
+
 type ProductionService struct {
 	store ProductionStore
 	clock Clock
@@ -269,6 +350,22 @@ func NewProductionService(store ProductionStore, clock Clock) *ProductionService
 	return &ProductionService{store: store, clock: clock}
 }
 
+func (s *ProductionService) GetProduction(ctx context.Context, id domain.StockDocumentID) (ProductionDocument, error) {
+	document, err := s.store.GetProduction(ctx, id)
+	if err != nil {
+		return ProductionDocument{}, fmt.Errorf("get production: %w", err)
+	}
+	return document, nil
+}
+
+func (s *ProductionService) ListProductions(ctx context.Context, input ProductionListInput) (ProductionPage, error) {
+	page, err := s.store.ListProductions(ctx, input)
+	if err != nil {
+		return ProductionPage{}, fmt.Errorf("list productions: %w", err)
+	}
+	return page, nil
+}
+
 func (s *ProductionService) PostProduction(ctx context.Context, input ProductionPostInput) (ProductionDocument, error) {
 	if len(input.Inputs) == 0 {
 		return ProductionDocument{}, domain.Invalid("inputs", domain.ViolationRequired, "PRO-001")
@@ -289,3 +386,118 @@ func (s *ProductionService) PostProduction(ctx context.Context, input Production
 	}
 	return document, nil
 }
+
+// ScaleProduction multiplies every component of a revision by the batch
+// multiplier, or by the desired output over the standard yield, with exact
+// rational arithmetic. Quantities that do not land on a whole atomic unit
+// round up and report the residue instead of truncating.
+func (s *ProductionService) ScaleProduction(ctx context.Context, input ProductionScaleInput) (ScaledProduction, error) {
+	if input.RevisionID.IsZero() {
+		return ScaledProduction{}, domain.Invalid("recipe_revision_id", domain.ViolationRequired, "PRO-001")
+	}
+	if input.Multiplier.IsSome() == input.OutputQuantity.IsSome() {
+		return ScaledProduction{}, domain.Invalid("multiplier", domain.ViolationInvariant, "PRO-006")
+	}
+	revision, err := s.store.GetRecipeRevision(ctx, input.RevisionID)
+	if err != nil {
+		return ScaledProduction{}, fmt.Errorf("scale production: %w", err)
+	}
+	recipe, err := s.store.GetRecipe(ctx, revision.RecipeID())
+	if err != nil {
+		return ScaledProduction{}, fmt.Errorf("scale production: %w", err)
+	}
+	multiplier, err := productionMultiplier(input, revision.StandardYield())
+	if err != nil {
+		return ScaledProduction{}, err
+	}
+	scaled, err := scaleProductionRevision(revision, recipe.OutputItemID(), multiplier)
+	if err != nil {
+		return ScaledProduction{}, fmt.Errorf("scale production: %w", err)
+	}
+	return scaled, nil
+}
+
+func productionMultiplier(input ProductionScaleInput, standardYield domain.AtomicQuantity) (domain.Fraction, error) {
+	if multiplier, ok := input.Multiplier.Get(); ok {
+		if multiplier.IsZero() || !multiplier.IsValid() {
+			return domain.Fraction{}, domain.Invalid("multiplier", domain.ViolationNotPositive, "PRO-006")
+		}
+		return multiplier, nil
+	}
+	output, _ := input.OutputQuantity.Get()
+	if output.Int64() <= 0 {
+		return domain.Fraction{}, domain.Invalid("output_quantity_atomic", domain.ViolationNotPositive, "PRO-006")
+	}
+	return domain.NewFraction(output.Int64(), standardYield.Int64())
+}
+
+func scaleProductionRevision(
+	revision recipedomain.Revision,
+	outputItemID domain.ItemID,
+	multiplier domain.Fraction,
+) (ScaledProduction, error) {
+	output, err := scaleAtomicQuantity(revision.StandardYield(), multiplier)
+	if err != nil {
+		return ScaledProduction{}, err
+	}
+	components := revision.Components()
+	scaled := ScaledProduction{
+		revisionID:     revision.ID(),
+		outputItemID:   outputItemID,
+		multiplier:     multiplier,
+		outputQuantity: output.rounded,
+		outputExact:    output.exact,
+		outputResidue:  output.residue,
+		inputs:         make([]ScaledProductionInput, 0, len(components)),
+	}
+	for _, component := range components {
+		quantity, err := scaleAtomicQuantity(component.Quantity(), multiplier)
+		if err != nil {
+			return ScaledProduction{}, err
+		}
+		scaled.inputs = append(scaled.inputs, ScaledProductionInput{
+			Input: ProductionComponentInput{
+				ItemID:               component.ItemID(),
+				Quantity:             quantity.rounded,
+				EnteredUnit:          component.EnteredUnit(),
+				EnteredPackagingName: component.EnteredPackagingName(),
+				Conversion:           component.Conversion(),
+				LotID:                domain.None[domain.InventoryLotID](),
+			},
+			Exact:   quantity.exact,
+			Residue: quantity.residue,
+		})
+	}
+	return scaled, nil
+}
+
+type scaledAtomicQuantity struct {
+	exact   domain.Fraction
+	rounded domain.AtomicQuantity
+	residue domain.Fraction
+}
+
+func scaleAtomicQuantity(quantity domain.AtomicQuantity, multiplier domain.Fraction) (scaledAtomicQuantity, error) {
+	base, err := domain.NewFraction(quantity.Int64(), 1)
+	if err != nil {
+		return scaledAtomicQuantity{}, err
+	}
+	exact, err := base.Multiply(multiplier)
+	if err != nil {
+		return scaledAtomicQuantity{}, err
+	}
+	ceiling, err := exact.Ceil()
+	if err != nil {
+		return scaledAtomicQuantity{}, err
+	}
+	rounded, err := domain.NewAtomicQuantity(ceiling)
+	if err != nil {
+		return scaledAtomicQuantity{}, err
+	}
+	remainder := exact.Numerator() % exact.Denominator()
+	residue, err := domain.NewFraction((exact.Denominator()-remainder)%exact.Denominator(), exact.Denominator())
+	if err != nil {
+		return scaledAtomicQuantity{}, err
+	}
+	return scaledAtomicQuantity{exact: exact, rounded: rounded, residue: residue}, nil
+}
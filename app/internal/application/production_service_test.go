@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
+)
+
+type scaleStore struct {
+	ProductionStore
+	recipe recipedomain.Recipe
+}
+
+func (s *scaleStore) GetRecipe(_ context.Context, id domain.RecipeID) (recipedomain.Recipe, error) {
+	if id != s.recipe.ID() {
+		return recipedomain.Recipe{}, domain.ErrNotFound
+	}
+	return s.recipe, nil
+}
+
+func (s *scaleStore) GetRecipeRevision(_ context.Context, id domain.RecipeRevisionID) (recipedomain.Revision, error) {
+	if id != s.recipe.CurrentRevision().ID() {
+		return recipedomain.Revision{}, domain.ErrNotFound
+	}
+	return s.recipe.CurrentRevision(), nil
+}
+
+// scaleWant holds rounded atomic quantity, exact numerator, exact denominator,
+// residue numerator, and residue denominator.
+type scaleWant [5]int64
+
+func TestProductionServiceScalesRevisionWithExactResidue(t *testing.T) {
+	recipe := planRecipe(t, planRecipeSpec{
+		id: 1, output: planCake, yield: 3,
+		components: [][2]int64{{planFlour, 250}, {planButter, 100}, {planSugar, 7}},
+	})
+	service := NewProductionService(&scaleStore{recipe: recipe}, &mutableClock{now: mustInstant(1_000)})
+	revisionID := recipe.CurrentRevision().ID()
+
+	tests := []struct {
+		name       string
+		multiplier domain.Option[domain.Fraction]
+		output     domain.Option[domain.AtomicQuantity]
+		wantScale  [2]int64
+		wantOutput scaleWant
+		wantInputs []scaleWant
+	}{
+		{
+			name:       "whole multiplier has no residue",
+			multiplier: domain.Some(must(domain.NewFraction(2, 1))),
+			wantScale:  [2]int64{2, 1},
+			wantOutput: scaleWant{6, 6, 1, 0, 1},
+			wantInputs: []scaleWant{{500, 500, 1, 0, 1}, {200, 200, 1, 0, 1}, {14, 14, 1, 0, 1}},
+		},
+		{
+			name:       "fractional multiplier rounds output and inputs up",
+			multiplier: domain.Some(must(domain.ParseDecimalFraction("1.5"))),
+			wantScale:  [2]int64{3, 2},
+			wantOutput: scaleWant{5, 9, 2, 1, 2},
+			wantInputs: []scaleWant{{375, 375, 1, 0, 1}, {150, 150, 1, 0, 1}, {11, 21, 2, 1, 2}},
+		},
+		{
+			name:       "desired output derives the multiplier from the yield",
+			output:     domain.Some(planQuantity(t, 5)),
+			wantScale:  [2]int64{5, 3},
+			wantOutput: scaleWant{5, 5, 1, 0, 1},
+			wantInputs: []scaleWant{{417, 1250, 3, 1, 3}, {167, 500, 3, 1, 3}, {12, 35, 3, 1, 3}},
+		},
+		{
+			name:       "output below one batch",
+			output:     domain.Some(planQuantity(t, 1)),
+			wantScale:  [2]int64{1, 3},
+			wantOutput: scaleWant{1, 1, 1, 0, 1},
+			wantInputs: []scaleWant{{84, 250, 3, 2, 3}, {34, 100, 3, 2, 3}, {3, 7, 3, 2, 3}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scaled, err := service.ScaleProduction(context.Background(), ProductionScaleInput{
+				RevisionID: revisionID, Multiplier: test.multiplier, OutputQuantity: test.output,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if scaled.RevisionID() != revisionID || scaled.OutputItemID() != planItemID(t, planCake) {
+				t.Fatalf("unexpected scaled target: %#v", scaled)
+			}
+			if got := [2]int64{scaled.Multiplier().Numerator(), scaled.Multiplier().Denominator()}; got != test.wantScale {
+				t.Fatalf("multiplier = %v, want %v", got, test.wantScale)
+			}
+			output := scaleWant{
+				scaled.OutputQuantity().Int64(),
+				scaled.OutputExact().Numerator(), scaled.OutputExact().Denominator(),
+				scaled.OutputResidue().Numerator(), scaled.OutputResidue().Denominator(),
+			}
+			if output != test.wantOutput {
+				t.Fatalf("output = %v, want %v", output, test.wantOutput)
+			}
+			inputs := make([]scaleWant, 0, len(scaled.Inputs()))
+			for _, line := range scaled.Inputs() {
+				if line.Input.EnteredUnit.String() != "g" || line.Input.Conversion.NumeratorAtomic() != 1_000 || line.Input.LotID.IsSome() {
+					t.Fatalf("component entry not carried over: %#v", line.Input)
+				}
+				inputs = append(inputs, scaleWant{
+					line.Input.Quantity.Int64(),
+					line.Exact.Numerator(), line.Exact.Denominator(),
+					line.Residue.Numerator(), line.Residue.Denominator(),
+				})
+			}
+			if !reflect.DeepEqual(inputs, test.wantInputs) {
+				t.Fatalf("inputs = %v, want %v", inputs, test.wantInputs)
+			}
+		})
+	}
+}
+
+func TestProductionServiceRejectsAmbiguousScale(t *testing.T) {
+	recipe := planRecipe(t, planRecipeSpec{
+		id: 1, output: planCake, yield: 3, components: [][2]int64{{planFlour, 250}},
+	})
+	service := NewProductionService(&scaleStore{recipe: recipe}, &mutableClock{now: mustInstant(1_000)})
+	revisionID := recipe.CurrentRevision().ID()
+
+	tests := []struct {
+		name  string
+		input ProductionScaleInput
+	}{
+		{name: "neither", input: ProductionScaleInput{RevisionID: revisionID}},
+		{name: "both", input: ProductionScaleInput{
+			RevisionID:     revisionID,
+			Multiplier:     domain.Some(must(domain.NewFraction(1, 1))),
+			OutputQuantity: domain.Some(planQuantity(t, 3)),
+		}},
+		{name: "zero multiplier", input: ProductionScaleInput{
+			RevisionID: revisionID, Multiplier: domain.Some(must(domain.NewFraction(0, 1))),
+		}},
+		{name: "zero output", input: ProductionScaleInput{
+			RevisionID: revisionID, OutputQuantity: domain.Some(planQuantity(t, 0)),
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := service.ScaleProduction(context.Background(), test.input)
+			var validation *domain.ValidationError
+			if !errors.As(err, &validation) || validation.Violations()[0].InvariantID != "PRO-006" {
+				t.Fatalf("expected PRO-006, got %v", err)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package application
 import (
 	"context"
 
+	"github.com/jerobas/saas/internal/domain"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
 	"github.com/jerobas/saas/internal/infrastructure/sqlite"
 )
 
@@ -17,6 +19,60 @@ func NewSQLiteProductionStore(store *sqlite.Store) ProductionStore {
 	return &sqliteProductionStore{store: store}
 }
 
+func (s *sqliteProductionStore) GetProduction(ctx context.Context, id domain.StockDocumentID) (ProductionDocument, error) {
+	posted, err := s.store.GetPostedProduction(ctx, id)
+	if err != nil {
+		return ProductionDocument{}, err
+	}
+	return mapSQLitePostedProduction(posted)
+}
+
+func (s *sqliteProductionStore) GetRecipe(ctx context.Context, id domain.RecipeID) (recipedomain.Recipe, error) {
+	return s.store.GetRecipe(ctx, id)
+}
+
+func (s *sqliteProductionStore) GetRecipeRevision(ctx context.Context, id domain.RecipeRevisionID) (recipedomain.Revision, error) {
+	return s.store.GetRecipeRevision(ctx, id)
+}
+
+func (s *sqliteProductionStore) ListProductions(ctx context.Context, input ProductionListInput) (ProductionPage, error) {
+	after := domain.None[sqlite.ProductionCursor]()
+	if cursor, ok := input.After.Get(); ok {
+		after = domain.Some(sqlite.ProductionCursor{
+			PostingSequence: cursor.PostingSequence,
+			ID:              cursor.ID,
+		})
+	}
+	page, err := s.store.ListPostedProductions(ctx, sqlite.ProductionListFilter{
+		RecipeID:       input.RecipeID,
+		OutputItemID:   input.OutputItemID,
+		FromOccurredOn: input.FromOccurredOn,
+		ToOccurredOn:   input.ToOccurredOn,
+		After:          after,
+		PageSize:       input.PageSize,
+	})
+	if err != nil {
+		return ProductionPage{}, err
+	}
+	sourceItems := page.Items()
+	items := make([]ProductionDocument, 0, len(sourceItems))
+	for _, posted := range sourceItems {
+		mapped, err := mapSQLitePostedProduction(posted)
+		if err != nil {
+			return ProductionPage{}, err
+		}
+		items = append(items, mapped)
+	}
+	next := domain.None[ProductionCursor]()
+	if cursor, ok := page.Next().Get(); ok {
+		next = domain.Some(ProductionCursor{
+			PostingSequence: cursor.PostingSequence,
+			ID:              cursor.ID,
+		})
+	}
+	return NewProductionPage(items, next), nil
+}
+
 func (s *sqliteProductionStore) PostProduction(ctx context.Context, input productionPostStoreInput) (ProductionDocument, error) {
 	inputs := make([]sqlite.PostProductionComponentInput, 0, len(input.Inputs))
 	for _, line := range input.Inputs {
@@ -0,0 +1,390 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+)
+
+type PurchaseOrderStore interface {
+	GetPurchaseOrder(ctx context.Context, id domain.PurchaseOrderID) (purchasing.Order, error)
+	ListPurchaseOrders(ctx context.Context, input PurchaseOrderListInput) (PurchaseOrderPage, error)
+	ListPurchaseOrderReceipts(ctx context.Context, id domain.PurchaseOrderID) ([]PurchaseDocument, error)
+	FindPurchaseOrderReceipt(ctx context.Context, id domain.PurchaseOrderID, key domain.IdempotencyKey) (domain.Option[PurchaseDocument], error)
+	CreatePurchaseOrder(ctx context.Context, input purchaseOrderCreateStoreInput) (purchasing.Order, error)
+	UpdatePurchaseOrder(ctx context.Context, input purchaseOrderUpdateStoreInput) (purchasing.Order, error)
+	ChangePurchaseOrderStatus(ctx context.Context, input purchaseOrderStatusStoreInput) (purchasing.Order, error)
+	ReceivePurchaseOrder(ctx context.Context, input purchaseOrderReceiveStoreInput) (PurchaseOrderReceipt, error)
+}
+
+type PurchaseOrderListInput struct {
+	Status   domain.Option[domain.PurchaseOrderStatus]
+	After    domain.Option[domain.PurchaseOrderID]
+	PageSize int
+}
+
+type PurchaseOrderPage struct {
+	items []purchasing.Order
+	next  domain.Option[domain.PurchaseOrderID]
+}
+
+func NewPurchaseOrderPage(items []purchasing.Order, next domain.Option[domain.PurchaseOrderID]) PurchaseOrderPage {
+	cloned := make([]purchasing.Order, len(items))
+	copy(cloned, items)
+	return PurchaseOrderPage{items: cloned, next: next}
+}
+
+func (p PurchaseOrderPage) Items() []purchasing.Order {
+	items := make([]purchasing.Order, len(p.items))
+	copy(items, p.items)
+	return items
+}
+
+func (p PurchaseOrderPage) Next() domain.Option[domain.PurchaseOrderID] { return p.next }
+
+type PurchaseOrderLineInput struct {
+	ItemID               domain.ItemID
+	Quantity             domain.AtomicQuantity
+	EnteredUnit          domain.UnitCode
+	EnteredPackagingName domain.Option[domain.NonEmptyText]
+	Conversion           domain.UnitConversion
+	ExpectedTotal        domain.MinorAmount
+}
+
+type PurchaseOrderCreateInput struct {
+	SupplierID domain.CounterpartyID
+	OrderedOn  domain.BusinessDate
+	ExpectedOn domain.Option[domain.BusinessDate]
+	Notes      domain.Option[domain.NonEmptyText]
+	Lines      []PurchaseOrderLineInput
+}
+
+type PurchaseOrderUpdateInput struct {
+	ID                domain.PurchaseOrderID
+	SupplierID        domain.CounterpartyID
+	OrderedOn         domain.BusinessDate
+	ExpectedOn        domain.Option[domain.BusinessDate]
+	Notes             domain.Option[domain.NonEmptyText]
+	Lines             []PurchaseOrderLineInput
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+// PurchaseOrderTransitionInput sends, cancels, or closes an order.
+type PurchaseOrderTransitionInput struct {
+	ID                domain.PurchaseOrderID
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+// PurchaseOrderReceiptLineInput receives part or all of an order line. The
+// commercial total defaults to the expected price prorated over the received
+// quantity; lot code and expiry are only known on arrival.
+type PurchaseOrderReceiptLineInput struct {
+	LineID          domain.PurchaseOrderLineID
+	Quantity        domain.AtomicQuantity
+	CommercialTotal domain.Option[domain.MinorAmount]
+	LotCode         domain.Option[domain.NonEmptyText]
+	ExpiresOn       domain.Option[domain.BusinessDate]
+}
+
+type PurchaseOrderReceiveInput struct {
+	ID                domain.PurchaseOrderID
+	ExpectedUpdatedAt domain.UTCInstant
+	IdempotencyKey    domain.IdempotencyKey
+	OccurredOn        domain.BusinessDate
+	Notes             domain.Option[domain.NonEmptyText]
+	Lines             []PurchaseOrderReceiptLineInput
+}
+
+type purchaseOrderCreateStoreInput struct {
+	PurchaseOrderCreateInput
+	CreatedAt domain.UTCInstant
+}
+
+type purchaseOrderUpdateStoreInput struct {
+	PurchaseOrderUpdateInput
+	UpdatedAt domain.UTCInstant
+}
+
+type purchaseOrderStatusStoreInput struct {
+	PurchaseOrderTransitionInput
+	Status    domain.PurchaseOrderStatus
+	UpdatedAt domain.UTCInstant
+}
+
+type purchaseOrderReceiveStoreInput struct {
+	ID                domain.PurchaseOrderID
+	ExpectedUpdatedAt domain.UTCInstant
+	Lines             []PurchaseOrderReceiptLineInput
+	Purchase          purchasePostStoreInput
+}
+
+// PurchaseOrderReceipt is an order after a receipt together with the purchase
+// the receipt posted.
+type PurchaseOrderReceipt struct {
+	order    purchasing.Order
+	purchase PurchaseDocument
+}
+
+func NewPurchaseOrderReceipt(order purchasing.Order, purchase PurchaseDocument) PurchaseOrderReceipt {
+	return PurchaseOrderReceipt{order: order, purchase: purchase}
+}
+
+func (r PurchaseOrderReceipt) Order() purchasing.Order    { return r.order }
+func (r PurchaseOrderReceipt) Purchase() PurchaseDocument { return r.purchase }
+
+// NewPurchaseOrderReceiptPostInput builds the purchase that receives the given
+// lines of order. The purchase is from the order's supplier and keeps each
+// line's unit or packaging snapshot. Without an explicit commercial total a
+// line is priced at its share of the expected total, rounded so that the
+// receipts of a fully received line add up to exactly the expected total.
+func NewPurchaseOrderReceiptPostInput(order purchasing.Order, input PurchaseOrderReceiveInput) (PurchasePostInput, error) {
+	if !order.CanReceive() {
+		return PurchasePostInput{}, fmt.Errorf("%w: purchase order is %s and cannot be received", domain.ErrConflict, order.Status())
+	}
+	if len(input.Lines) == 0 {
+		return PurchasePostInput{}, domain.Invalid("lines", domain.ViolationRequired, "POR-003")
+	}
+	if input.OccurredOn.Before(order.OrderedOn()) {
+		return PurchasePostInput{}, domain.Invalid("occurred_on", domain.ViolationOutOfRange, "POR-003")
+	}
+	orderLines := make(map[domain.PurchaseOrderLineID]purchasing.OrderLine, len(order.Lines()))
+	for _, line := range order.Lines() {
+		orderLines[line.ID()] = line
+	}
+	seen := make(map[domain.PurchaseOrderLineID]struct{}, len(input.Lines))
+	lines := make([]PurchaseLineInput, 0, len(input.Lines))
+	for index, receipt := range input.Lines {
+		field := fmt.Sprintf("lines[%d]", index)
+		line, ok := orderLines[receipt.LineID]
+		if !ok {
+			return PurchasePostInput{}, fmt.Errorf("%w: purchase order line %s is not on this order", domain.ErrInvalidReference, receipt.LineID)
+		}
+		if _, duplicate := seen[receipt.LineID]; duplicate {
+			return PurchasePostInput{}, domain.Invalid(field+".line_id", domain.ViolationDuplicate, "POR-003")
+		}
+		seen[receipt.LineID] = struct{}{}
+		if receipt.Quantity.Int64() <= 0 || receipt.Quantity.Int64() > line.OutstandingQuantity().Int64() {
+			return PurchasePostInput{}, domain.Invalid(field+".quantity_atomic", domain.ViolationOutOfRange, "POR-003")
+		}
+		total, ok := receipt.CommercialTotal.Get()
+		if !ok {
+			prorated, err := proratedReceiptTotal(line, receipt.Quantity)
+			if err != nil {
+				return PurchasePostInput{}, fmt.Errorf("prorate %s: %w", field, err)
+			}
+			total = prorated
+		}
+		lines = append(lines, PurchaseLineInput{
+			ItemID:               line.ItemID(),
+			Quantity:             receipt.Quantity,
+			EnteredUnit:          line.EnteredUnit(),
+			EnteredPackagingName: line.EnteredPackagingName(),
+			Conversion:           line.Conversion(),
+			CommercialTotal:      total,
+			LotCode:              receipt.LotCode,
+			ExpiresOn:            receipt.ExpiresOn,
+		})
+	}
+	return PurchasePostInput{
+		IdempotencyKey: input.IdempotencyKey,
+		CounterpartyID: domain.Some(order.SupplierID()),
+		OccurredOn:     input.OccurredOn,
+		Notes:          input.Notes,
+		Lines:          lines,
+	}, nil
+}
+
+// proratedReceiptTotal is the expected total of received plus quantity less
+// the expected total of what was already received, each rounded half up.
+func proratedReceiptTotal(line purchasing.OrderLine, quantity domain.AtomicQuantity) (domain.MinorAmount, error) {
+	after, err := line.ReceivedQuantity().Add(quantity)
+	if err != nil {
+		return domain.MinorAmount{}, err
+	}
+	through, err := expectedTotalThrough(line, after)
+	if err != nil {
+		return domain.MinorAmount{}, err
+	}
+	before, err := expectedTotalThrough(line, line.ReceivedQuantity())
+	if err != nil {
+		return domain.MinorAmount{}, err
+	}
+	return domain.NewMinorAmount(through - before)
+}
+
+func expectedTotalThrough(line purchasing.OrderLine, received domain.AtomicQuantity) (int64, error) {
+	share, err := domain.NewFraction(received.Int64(), line.Quantity().Int64())
+	if err != nil {
+		return 0, err
+	}
+	expected, err := domain.NewFraction(line.ExpectedTotal().Int64(), 1)
+	if err != nil {
+		return 0, err
+	}
+	total, err := expected.Multiply(share)
+	if err != nil {
+		return 0, err
+	}
+	return total.RoundHalfUp()
+}
+
+type PurchaseOrderService struct {
+	store PurchaseOrderStore
+	clock Clock
+}
+
+func NewPurchaseOrderService(store PurchaseOrderStore, clock Clock) *PurchaseOrderService {
+	if store == nil {
+		panic("purchase order service requires a store")
+	}
+	if clock == nil {
+		panic("purchase order service requires a clock")
+	}
+	return &PurchaseOrderService{store: store, clock: clock}
+}
+
+func (s *PurchaseOrderService) GetPurchaseOrder(ctx context.Context, id domain.PurchaseOrderID) (purchasing.Order, error) {
+	order, err := s.store.GetPurchaseOrder(ctx, id)
+	if err != nil {
+		return purchasing.Order{}, fmt.Errorf("get purchase order: %w", err)
+	}
+	return order, nil
+}
+
+func (s *PurchaseOrderService) ListPurchaseOrders(ctx context.Context, input PurchaseOrderListInput) (PurchaseOrderPage, error) {
+	page, err := s.store.ListPurchaseOrders(ctx, input)
+	if err != nil {
+		return PurchaseOrderPage{}, fmt.Errorf("list purchase orders: %w", err)
+	}
+	return page, nil
+}
+
+func (s *PurchaseOrderService) ListPurchaseOrderReceipts(ctx context.Context, id domain.PurchaseOrderID) ([]PurchaseDocument, error) {
+	receipts, err := s.store.ListPurchaseOrderReceipts(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("list purchase order receipts: %w", err)
+	}
+	return receipts, nil
+}
+
+func (s *PurchaseOrderService) CreatePurchaseOrder(ctx context.Context, input PurchaseOrderCreateInput) (purchasing.Order, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return purchasing.Order{}, fmt.Errorf("read clock: %w", err)
+	}
+	created, err := s.store.CreatePurchaseOrder(ctx, purchaseOrderCreateStoreInput{
+		PurchaseOrderCreateInput: input,
+		CreatedAt:                now,
+	})
+	if err != nil {
+		return purchasing.Order{}, fmt.Errorf("create purchase order: %w", err)
+	}
+	if !created.CreatedAt().Equal(now) || created.Status() != domain.PurchaseOrderDraft {
+		return purchasing.Order{}, domain.ErrInvariant
+	}
+	return created, nil
+}
+
+func (s *PurchaseOrderService) UpdatePurchaseOrder(ctx context.Context, input PurchaseOrderUpdateInput) (purchasing.Order, error) {
+	now, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return purchasing.Order{}, fmt.Errorf("read clock: %w", err)
+	}
+	updated, err := s.store.UpdatePurchaseOrder(ctx, purchaseOrderUpdateStoreInput{
+		PurchaseOrderUpdateInput: input,
+		UpdatedAt:                now,
+	})
+	if err != nil {
+		return purchasing.Order{}, fmt.Errorf("update purchase order: %w", err)
+	}
+	if !updated.UpdatedAt().Equal(now) {
+		return purchasing.Order{}, domain.ErrInvariant
+	}
+	return updated, nil
+}
+
+func (s *PurchaseOrderService) SendPurchaseOrder(ctx context.Context, input PurchaseOrderTransitionInput) (purchasing.Order, error) {
+	return s.changeStatus(ctx, "send", input, domain.PurchaseOrderSent)
+}
+
+func (s *PurchaseOrderService) CancelPurchaseOrder(ctx context.Context, input PurchaseOrderTransitionInput) (purchasing.Order, error) {
+	return s.changeStatus(ctx, "cancel", input, domain.PurchaseOrderCancelled)
+}
+
+// ClosePurchaseOrder accepts a short delivery: a partially received order
+// stops expecting its outstanding quantities.
+func (s *PurchaseOrderService) ClosePurchaseOrder(ctx context.Context, input PurchaseOrderTransitionInput) (purchasing.Order, error) {
+	return s.changeStatus(ctx, "close", input, domain.PurchaseOrderClosed)
+}
+
+func (s *PurchaseOrderService) changeStatus(
+	ctx context.Context,
+	verb string,
+	input PurchaseOrderTransitionInput,
+	status domain.PurchaseOrderStatus,
+) (purchasing.Order, error) {
+	now, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return purchasing.Order{}, fmt.Errorf("read clock: %w", err)
+	}
+	changed, err := s.store.ChangePurchaseOrderStatus(ctx, purchaseOrderStatusStoreInput{
+		PurchaseOrderTransitionInput: input,
+		Status:                       status,
+		UpdatedAt:                    now,
+	})
+	if err != nil {
+		return purchasing.Order{}, fmt.Errorf("%s purchase order: %w", verb, err)
+	}
+	if changed.Status() != status || !changed.UpdatedAt().Equal(now) {
+		return purchasing.Order{}, domain.ErrInvariant
+	}
+	return changed, nil
+}
+
+// ReceivePurchaseOrder posts the purchase built by
+// NewPurchaseOrderReceiptPostInput and advances the order in the same
+// transaction. A retry with the same idempotency key returns the first
+// receipt, even after that receipt closed the order.
+func (s *PurchaseOrderService) ReceivePurchaseOrder(ctx context.Context, input PurchaseOrderReceiveInput) (PurchaseOrderReceipt, error) {
+	replayed, err := s.store.FindPurchaseOrderReceipt(ctx, input.ID, input.IdempotencyKey)
+	if err != nil {
+		return PurchaseOrderReceipt{}, fmt.Errorf("receive purchase order: %w", err)
+	}
+	if purchase, ok := replayed.Get(); ok {
+		order, err := s.store.GetPurchaseOrder(ctx, input.ID)
+		if err != nil {
+			return PurchaseOrderReceipt{}, fmt.Errorf("receive purchase order: %w", err)
+		}
+		return NewPurchaseOrderReceipt(order, purchase), nil
+	}
+	order, err := s.store.GetPurchaseOrder(ctx, input.ID)
+	if err != nil {
+		return PurchaseOrderReceipt{}, fmt.Errorf("receive purchase order: %w", err)
+	}
+	if !order.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+		return PurchaseOrderReceipt{}, fmt.Errorf("receive purchase order: %w", domain.ErrStale)
+	}
+	purchase, err := NewPurchaseOrderReceiptPostInput(order, input)
+	if err != nil {
+		return PurchaseOrderReceipt{}, fmt.Errorf("receive purchase order: %w", err)
+	}
+	postedAt, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return PurchaseOrderReceipt{}, fmt.Errorf("read clock: %w", err)
+	}
+	receipt, err := s.store.ReceivePurchaseOrder(ctx, purchaseOrderReceiveStoreInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		Lines:             input.Lines,
+		Purchase:          purchasePostStoreInput{PurchasePostInput: purchase, PostedAt: postedAt},
+	})
+	if err != nil {
+		return PurchaseOrderReceipt{}, fmt.Errorf("receive purchase order: %w", err)
+	}
+	if err := ensurePostingClockCompatible(receipt.Purchase().PostedAt(), postedAt); err != nil {
+		return PurchaseOrderReceipt{}, err
+	}
+	return receipt, nil
+}
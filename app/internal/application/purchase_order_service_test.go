@@ -0,0 +1,159 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+)
+
+type receiptStore struct {
+	PurchaseOrderStore
+	order    purchasing.Order
+	replay   domain.Option[PurchaseDocument]
+	received []purchaseOrderReceiveStoreInput
+}
+
+func (s *receiptStore) GetPurchaseOrder(_ context.Context, id domain.PurchaseOrderID) (purchasing.Order, error) {
+	if id != s.order.ID() {
+		return purchasing.Order{}, domain.ErrNotFound
+	}
+	return s.order, nil
+}
+
+func (s *receiptStore) FindPurchaseOrderReceipt(context.Context, domain.PurchaseOrderID, domain.IdempotencyKey) (domain.Option[PurchaseDocument], error) {
+	return s.replay, nil
+}
+
+func (s *receiptStore) ReceivePurchaseOrder(_ context.Context, input purchaseOrderReceiveStoreInput) (PurchaseOrderReceipt, error) {
+	s.received = append(s.received, input)
+	return NewPurchaseOrderReceipt(s.order, receiptDocument(input.Purchase.PostedAt)), nil
+}
+
+func TestPurchaseOrderReceiptProratesExpectedTotalAcrossReceipts(t *testing.T) {
+	order := receiptOrder(t, domain.PurchaseOrderSent, 0)
+	receive := func(order purchasing.Order, quantity int64) PurchasePostInput {
+		t.Helper()
+		purchase, err := NewPurchaseOrderReceiptPostInput(order, PurchaseOrderReceiveInput{
+			ID: order.ID(), IdempotencyKey: must(domain.NewIdempotencyKey("receipt")),
+			OccurredOn: must(domain.ParseBusinessDate("2026-10-20")),
+			Lines: []PurchaseOrderReceiptLineInput{{
+				LineID: order.Lines()[0].ID(), Quantity: must(domain.NewPositiveAtomicQuantity(quantity)),
+			}},
+		})
+		if err != nil {
+			t.Fatalf("receive %d: %v", quantity, err)
+		}
+		return purchase
+	}
+
+	first := receive(order, 1)
+	if supplier, ok := first.CounterpartyID.Get(); !ok || supplier != order.SupplierID() {
+		t.Fatalf("receipt counterparty = %#v", first.CounterpartyID)
+	}
+	line := first.Lines[0]
+	if line.ItemID != order.Lines()[0].ItemID() || line.Conversion != order.Lines()[0].Conversion() ||
+		line.EnteredUnit != order.Lines()[0].EnteredUnit() || line.CommercialTotal.Int64() != 333 {
+		t.Fatalf("first receipt line = %#v", line)
+	}
+	second := receive(receiptOrder(t, domain.PurchaseOrderPartiallyReceived, 1), 1)
+	third := receive(receiptOrder(t, domain.PurchaseOrderPartiallyReceived, 2), 1)
+	total := first.Lines[0].CommercialTotal.Int64() + second.Lines[0].CommercialTotal.Int64() + third.Lines[0].CommercialTotal.Int64()
+	if second.Lines[0].CommercialTotal.Int64() != 334 || total != 1_000 {
+		t.Fatalf("prorated totals = %d, %d, %d", first.Lines[0].CommercialTotal.Int64(), second.Lines[0].CommercialTotal.Int64(), third.Lines[0].CommercialTotal.Int64())
+	}
+
+	_, err := NewPurchaseOrderReceiptPostInput(receiptOrder(t, domain.PurchaseOrderPartiallyReceived, 2), PurchaseOrderReceiveInput{
+		OccurredOn: must(domain.ParseBusinessDate("2026-10-20")),
+		Lines: []PurchaseOrderReceiptLineInput{{
+			LineID: order.Lines()[0].ID(), Quantity: must(domain.NewPositiveAtomicQuantity(2)),
+		}},
+	})
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) || validation.Violations()[0].InvariantID != "POR-003" {
+		t.Fatalf("over receipt error = %v, want POR-003", err)
+	}
+	if _, err := NewPurchaseOrderReceiptPostInput(receiptOrder(t, domain.PurchaseOrderDraft, 0), PurchaseOrderReceiveInput{}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("draft receipt error = %v, want conflict", err)
+	}
+}
+
+func TestPurchaseOrderServiceReceivesOnceAndReplaysByKey(t *testing.T) {
+	store := &receiptStore{order: receiptOrder(t, domain.PurchaseOrderSent, 0), replay: domain.None[PurchaseDocument]()}
+	service := NewPurchaseOrderService(store, &mutableClock{now: mustInstant(1_000)})
+	input := PurchaseOrderReceiveInput{
+		ID: store.order.ID(), ExpectedUpdatedAt: store.order.UpdatedAt(),
+		IdempotencyKey: must(domain.NewIdempotencyKey("receipt")),
+		OccurredOn:     must(domain.ParseBusinessDate("2026-10-20")),
+		Lines: []PurchaseOrderReceiptLineInput{{
+			LineID: store.order.Lines()[0].ID(), Quantity: must(domain.NewPositiveAtomicQuantity(3)),
+			CommercialTotal: domain.Some(must(domain.NewMinorAmount(900))),
+		}},
+	}
+
+	stale := input
+	stale.ExpectedUpdatedAt = mustInstant(100)
+	if _, err := service.ReceivePurchaseOrder(context.Background(), stale); !errors.Is(err, domain.ErrStale) {
+		t.Fatalf("stale receipt error = %v, want stale", err)
+	}
+	receipt, err := service.ReceivePurchaseOrder(context.Background(), input)
+	if err != nil {
+		t.Fatalf("receive purchase order: %v", err)
+	}
+	if len(store.received) != 1 || !receipt.Purchase().PostedAt().Equal(mustInstant(1_000)) {
+		t.Fatalf("receipts = %#v, purchase = %#v", store.received, receipt.Purchase())
+	}
+	posted := store.received[0].Purchase
+	if !posted.PostedAt.Equal(mustInstant(1_000)) || posted.Lines[0].CommercialTotal.Int64() != 900 ||
+		store.received[0].Lines[0].Quantity.Int64() != 3 {
+		t.Fatalf("store receipt input = %#v", store.received[0])
+	}
+
+	store.replay = domain.Some(receiptDocument(mustInstant(1_000)))
+	stale.ExpectedUpdatedAt = mustInstant(100)
+	if _, err := service.ReceivePurchaseOrder(context.Background(), stale); err != nil || len(store.received) != 1 {
+		t.Fatalf("replayed receipt error = %v after %d receipts", err, len(store.received))
+	}
+}
+
+// receiptOrder is a one-line order for three atomic units expected at 1,000
+// minor units, with received units already received.
+func receiptOrder(t *testing.T, status domain.PurchaseOrderStatus, received int64) purchasing.Order {
+	t.Helper()
+	line := must(purchasing.NewOrderLine(purchasing.OrderLineParams{
+		ID: must(domain.NewPurchaseOrderLineID(7)), OrderID: must(domain.NewPurchaseOrderID(1)),
+		LineOrder: must(domain.NewLineOrder(1)), ItemID: must(domain.NewItemID(4)),
+		Quantity:             must(domain.NewPositiveAtomicQuantity(3)),
+		EnteredUnit:          must(domain.NewUnitCode("kg")),
+		EnteredPackagingName: domain.Some(must(domain.NewNonEmptyText("Sack"))),
+		Conversion:           must(domain.NewUnitConversion(1, 1)),
+		ExpectedTotal:        must(domain.NewMinorAmount(1_000)),
+		ReceivedQuantity:     must(domain.NewAtomicQuantity(received)),
+	}))
+	return must(purchasing.NewOrder(purchasing.OrderParams{
+		ID: must(domain.NewPurchaseOrderID(1)), SupplierID: must(domain.NewCounterpartyID(9)),
+		Status: status, OrderedOn: must(domain.ParseBusinessDate("2026-10-18")),
+		CreatedAt: mustInstant(500), UpdatedAt: mustInstant(500),
+		Lines: []purchasing.OrderLine{line},
+	}))
+}
+
+func receiptDocument(postedAt domain.UTCInstant) PurchaseDocument {
+	line := must(NewPostedPurchaseLine(
+		must(domain.NewStockDocumentLineID(1)), must(domain.NewLineOrder(1)), must(domain.NewItemID(4)),
+		must(domain.NewPositiveAtomicQuantity(3)), must(domain.NewUnitCode("kg")),
+		domain.None[domain.NonEmptyText](), must(domain.NewUnitConversion(1, 1)),
+		domain.InventoryValue{}, must(domain.NewMinorAmount(900)),
+		must(domain.NewInventoryLotID(1)), domain.None[domain.NonEmptyText](),
+		must(domain.ParseBusinessDate("2026-10-20")), domain.None[domain.BusinessDate](),
+	))
+	return must(NewPurchaseDocument(
+		must(domain.NewStockDocumentID(1)), must(domain.NewIdempotencyKey("receipt")),
+		must(domain.NewPostingSequence(1)), domain.Some(must(domain.NewCounterpartyID(9))),
+		must(domain.ParseBusinessDate("2026-10-20")), postedAt, must(domain.NewCurrency("BRL")),
+		domain.None[domain.DocumentReason](), domain.None[domain.NonEmptyText](),
+		[]PostedPurchaseLine{line},
+	))
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqlitePurchaseOrderStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLitePurchaseOrderStore(store *sqlite.Store) PurchaseOrderStore {
+	if store == nil {
+		panic("sqlite purchase order store requires a store")
+	}
+	return &sqlitePurchaseOrderStore{store: store}
+}
+
+func (s *sqlitePurchaseOrderStore) GetPurchaseOrder(ctx context.Context, id domain.PurchaseOrderID) (purchasing.Order, error) {
+	return s.store.GetPurchaseOrder(ctx, id)
+}
+
+func (s *sqlitePurchaseOrderStore) ListPurchaseOrders(ctx context.Context, input PurchaseOrderListInput) (PurchaseOrderPage, error) {
+	page, err := s.store.ListPurchaseOrders(ctx, sqlite.PurchaseOrderListFilter{
+		Status:   input.Status,
+		After:    input.After,
+		PageSize: input.PageSize,
+	})
+	if err != nil {
+		return PurchaseOrderPage{}, err
+	}
+	return NewPurchaseOrderPage(page.Items(), page.Next()), nil
+}
+
+func (s *sqlitePurchaseOrderStore) ListPurchaseOrderReceipts(ctx context.Context, id domain.PurchaseOrderID) ([]PurchaseDocument, error) {
+	receipts, err := s.store.ListPurchaseOrderReceipts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	documents := make([]PurchaseDocument, 0, len(receipts))
+	for _, posted := range receipts {
+		mapped, err := mapSQLitePostedPurchase(posted)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, mapped)
+	}
+	return documents, nil
+}
+
+func (s *sqlitePurchaseOrderStore) FindPurchaseOrderReceipt(
+	ctx context.Context,
+	id domain.PurchaseOrderID,
+	key domain.IdempotencyKey,
+) (domain.Option[PurchaseDocument], error) {
+	found, err := s.store.FindPurchaseOrderReceipt(ctx, id, key)
+	if err != nil {
+		return domain.None[PurchaseDocument](), err
+	}
+	posted, ok := found.Get()
+	if !ok {
+		return domain.None[PurchaseDocument](), nil
+	}
+	mapped, err := mapSQLitePostedPurchase(posted)
+	if err != nil {
+		return domain.None[PurchaseDocument](), err
+	}
+	return domain.Some(mapped), nil
+}
+
+func (s *sqlitePurchaseOrderStore) CreatePurchaseOrder(ctx context.Context, input purchaseOrderCreateStoreInput) (purchasing.Order, error) {
+	return s.store.CreatePurchaseOrder(ctx, sqlite.CreatePurchaseOrderInput{
+		SupplierID: input.SupplierID,
+		OrderedOn:  input.OrderedOn,
+		ExpectedOn: input.ExpectedOn,
+		Notes:      input.Notes,
+		Lines:      mapSQLitePurchaseOrderLines(input.Lines),
+		CreatedAt:  input.CreatedAt,
+	})
+}
+
+func (s *sqlitePurchaseOrderStore) UpdatePurchaseOrder(ctx context.Context, input purchaseOrderUpdateStoreInput) (purchasing.Order, error) {
+	return s.store.UpdatePurchaseOrder(ctx, sqlite.UpdatePurchaseOrderInput{
+		ID:                input.ID,
+		SupplierID:        input.SupplierID,
+		OrderedOn:         input.OrderedOn,
+		ExpectedOn:        input.ExpectedOn,
+		Notes:             input.Notes,
+		Lines:             mapSQLitePurchaseOrderLines(input.Lines),
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqlitePurchaseOrderStore) ChangePurchaseOrderStatus(ctx context.Context, input purchaseOrderStatusStoreInput) (purchasing.Order, error) {
+	return s.store.ChangePurchaseOrderStatus(ctx, sqlite.ChangePurchaseOrderStatusInput{
+		ID:                input.ID,
+		Status:            input.Status,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqlitePurchaseOrderStore) ReceivePurchaseOrder(ctx context.Context, input purchaseOrderReceiveStoreInput) (PurchaseOrderReceipt, error) {
+	receiptLines := make([]sqlite.PurchaseOrderReceiptLineInput, 0, len(input.Lines))
+	for _, line := range input.Lines {
+		receiptLines = append(receiptLines, sqlite.PurchaseOrderReceiptLineInput{
+			LineID:   line.LineID,
+			Quantity: line.Quantity,
+		})
+	}
+	order, posted, err := s.store.ReceivePurchaseOrder(ctx, sqlite.ReceivePurchaseOrderInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		Lines:             receiptLines,
+		Purchase:          mapSQLitePostPurchaseInput(input.Purchase),
+	})
+	if err != nil {
+		return PurchaseOrderReceipt{}, err
+	}
+	purchase, err := mapSQLitePostedPurchase(posted)
+	if err != nil {
+		return PurchaseOrderReceipt{}, err
+	}
+	return NewPurchaseOrderReceipt(order, purchase), nil
+}
+
+func mapSQLitePurchaseOrderLines(lines []PurchaseOrderLineInput) []sqlite.PurchaseOrderLineInput {
+	mapped := make([]sqlite.PurchaseOrderLineInput, 0, len(lines))
+	for _, line := range lines {
+		mapped = append(mapped, sqlite.PurchaseOrderLineInput{
+			ItemID:               line.ItemID,
+			Quantity:             line.Quantity,
+			EnteredUnit:          line.EnteredUnit,
+			EnteredPackagingName: line.EnteredPackagingName,
+			Conversion:           line.Conversion,
+			ExpectedTotal:        line.ExpectedTotal,
+		})
+	}
+	return mapped
+}
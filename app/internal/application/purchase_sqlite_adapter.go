@@ -61,6 +61,14 @@ func (s *sqlitePurchaseStore) ListPurchases(ctx context.Context, input PurchaseL
 }
 
 func (s *sqlitePurchaseStore) PostPurchase(ctx context.Context, input purchasePostStoreInput) (PurchaseDocument, error) {
+	posted, err := s.store.PostPurchase(ctx, mapSQLitePostPurchaseInput(input))
+	if err != nil {
+		return PurchaseDocument{}, err
+	}
+	return mapSQLitePostedPurchase(posted)
+}
+
+func mapSQLitePostPurchaseInput(input purchasePostStoreInput) sqlite.PostPurchaseInput {
 	lines := make([]sqlite.PostPurchaseLineInput, 0, len(input.Lines))
 	for _, line := range input.Lines {
 		lines = append(lines, sqlite.PostPurchaseLineInput{
@@ -74,7 +82,7 @@ func (s *sqlitePurchaseStore) PostPurchase(ctx context.Context, input purchasePo
 			ExpiresOn:            line.ExpiresOn,
 		})
 	}
-	posted, err := s.store.PostPurchase(ctx, sqlite.PostPurchaseInput{
+	return sqlite.PostPurchaseInput{
 		IdempotencyKey: input.IdempotencyKey,
 		CounterpartyID: input.CounterpartyID,
 		OccurredOn:     input.OccurredOn,
@@ -82,11 +90,7 @@ func (s *sqlitePurchaseStore) PostPurchase(ctx context.Context, input purchasePo
 		Reason:         input.Reason,
 		Notes:          input.Notes,
 		Lines:          lines,
-	})
-	if err != nil {
-		return PurchaseDocument{}, err
 	}
-	return mapSQLitePostedPurchase(posted)
 }
 
 func mapSQLitePostedPurchase(posted sqlite.PostedPurchaseDocument) (PurchaseDocument, error) {
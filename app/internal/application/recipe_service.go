@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
 	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
 )
 
@@ -13,11 +14,13 @@ type RecipeStore interface {
 	GetRecipeRevision(ctx context.Context, id domain.RecipeRevisionID) (recipedomain.Revision, error)
 	ListRecipeRevisions(ctx context.Context, recipeID domain.RecipeID) ([]recipedomain.Revision, error)
 	ListRecipes(ctx context.Context, input RecipeListInput) (RecipePage, error)
+	ListActiveRecipesByOutputItem(ctx context.Context, itemID domain.ItemID) ([]recipedomain.Recipe, error)
 	CreateRecipe(ctx context.Context, input recipeCreateStoreInput) (recipedomain.Recipe, error)
 	PublishRecipeRevision(ctx context.Context, input recipePublishStoreInput) (recipedomain.Revision, error)
 	RenameRecipe(ctx context.Context, input recipeRenameStoreInput) (recipedomain.Recipe, error)
 	ArchiveRecipe(ctx context.Context, input recipeArchiveStoreInput) (recipedomain.Recipe, error)
 	RestoreRecipe(ctx context.Context, input recipeRestoreStoreInput) (recipedomain.Recipe, error)
+	ListItemCostBases(ctx context.Context, itemIDs []domain.ItemID) ([]inventory.CostBasis, error)
 }
 
 type RecipeCursor struct {
@@ -141,6 +144,59 @@ type recipeRestoreStoreInput struct {
 	UpdatedAt domain.UTCInstant
 }
 
+// RecipeComponentCost prices one revision component at today's cost basis of
+// its item. Value is absent when the item has neither stock nor a purchase.
+type RecipeComponentCost struct {
+	component       recipedomain.Component
+	enteredQuantity domain.Fraction
+	source          inventory.CostSource
+	unitValue       domain.Option[domain.Fraction]
+	value           domain.Option[domain.InventoryValue]
+}
+
+func (c RecipeComponentCost) Component() recipedomain.Component         { return c.component }
+func (c RecipeComponentCost) EnteredQuantity() domain.Fraction          { return c.enteredQuantity }
+func (c RecipeComponentCost) Source() inventory.CostSource              { return c.source }
+func (c RecipeComponentCost) UnitValue() domain.Option[domain.Fraction] { return c.unitValue }
+func (c RecipeComponentCost) Value() domain.Option[domain.InventoryValue] {
+	return c.value
+}
+
+// RecipeRevisionCost is the current cost rollup of one revision. Totals only
+// include priced components; Complete reports whether every component was
+// priced.
+type RecipeRevisionCost struct {
+	revision               recipedomain.Revision
+	outputItemID           domain.ItemID
+	components             []RecipeComponentCost
+	totalValue             domain.InventoryValue
+	yieldBaseUnit          domain.UnitCode
+	costPerYieldAtomicUnit domain.Fraction
+	costPerYieldBaseUnit   domain.InventoryValue
+}
+
+func (c RecipeRevisionCost) Revision() recipedomain.Revision { return c.revision }
+func (c RecipeRevisionCost) OutputItemID() domain.ItemID     { return c.outputItemID }
+func (c RecipeRevisionCost) Components() []RecipeComponentCost {
+	components := make([]RecipeComponentCost, len(c.components))
+	copy(components, c.components)
+	return components
+}
+func (c RecipeRevisionCost) TotalValue() domain.InventoryValue       { return c.totalValue }
+func (c RecipeRevisionCost) YieldBaseUnit() domain.UnitCode          { return c.yieldBaseUnit }
+func (c RecipeRevisionCost) CostPerYieldAtomicUnit() domain.Fraction { return c.costPerYieldAtomicUnit }
+func (c RecipeRevisionCost) CostPerYieldBaseUnit() domain.InventoryValue {
+	return c.costPerYieldBaseUnit
+}
+func (c RecipeRevisionCost) Complete() bool {
+	for _, component := range c.components {
+		if component.value.IsNone() {
+			return false
+		}
+	}
+	return true
+}
+
 type RecipeService struct {
 	store RecipeStore
 	clock Clock
@@ -269,3 +325,106 @@ func (s *RecipeService) RestoreRecipe(ctx context.Context, input RecipeRestoreIn
 	}
 	return value, nil
 }
+
+// CostRevision prices every component of a revision at its item's current
+// weighted average, or its last purchase when the item has no stock, and
+// spreads the total over the revision's standard yield.
+func (s *RecipeService) CostRevision(ctx context.Context, id domain.RecipeRevisionID) (RecipeRevisionCost, error) {
+	revision, err := s.store.GetRecipeRevision(ctx, id)
+	if err != nil {
+		return RecipeRevisionCost{}, fmt.Errorf("cost recipe revision: %w", err)
+	}
+	recipe, err := s.store.GetRecipe(ctx, revision.RecipeID())
+	if err != nil {
+		return RecipeRevisionCost{}, fmt.Errorf("cost recipe revision: %w", err)
+	}
+	components := revision.Components()
+	itemIDs := make([]domain.ItemID, 0, len(components)+1)
+	for _, component := range components {
+		itemIDs = append(itemIDs, component.ItemID())
+	}
+	itemIDs = append(itemIDs, recipe.OutputItemID())
+	bases, err := s.store.ListItemCostBases(ctx, itemIDs)
+	if err != nil {
+		return RecipeRevisionCost{}, fmt.Errorf("cost recipe revision: %w", err)
+	}
+	cost, err := costRecipeRevision(revision, recipe.OutputItemID(), bases)
+	if err != nil {
+		return RecipeRevisionCost{}, fmt.Errorf("cost recipe revision: %w", err)
+	}
+	return cost, nil
+}
+
+// costRecipeRevision expects one cost basis per component in component order
+// followed by the basis of the output item.
+func costRecipeRevision(revision recipedomain.Revision, outputItemID domain.ItemID, bases []inventory.CostBasis) (RecipeRevisionCost, error) {
+	components := revision.Components()
+	if len(bases) != len(components)+1 || bases[len(components)].ItemID() != outputItemID {
+		return RecipeRevisionCost{}, domain.ErrInvariant
+	}
+	result := RecipeRevisionCost{
+		revision: revision, outputItemID: outputItemID,
+		components: make([]RecipeComponentCost, 0, len(components)),
+	}
+	for index, component := range components {
+		basis := bases[index]
+		if basis.ItemID() != component.ItemID() {
+			return RecipeRevisionCost{}, domain.ErrInvariant
+		}
+		entered, err := component.Conversion().FromAtomic(component.Quantity())
+		if err != nil {
+			return RecipeRevisionCost{}, err
+		}
+		line := RecipeComponentCost{
+			component: component, enteredQuantity: entered,
+			unitValue: domain.None[domain.Fraction](),
+			value:     domain.None[domain.InventoryValue](),
+		}
+		unitValue, source := basis.UnitValue()
+		line.source = source
+		if source != inventory.CostSourceUnavailable {
+			value, err := roundedValue(unitValue, component.Quantity().Int64(), 1)
+			if err != nil {
+				return RecipeRevisionCost{}, err
+			}
+			if result.totalValue, err = result.totalValue.Add(value); err != nil {
+				return RecipeRevisionCost{}, err
+			}
+			line.unitValue = domain.Some(unitValue)
+			line.value = domain.Some(value)
+		}
+		result.components = append(result.components, line)
+	}
+	output := bases[len(components)]
+	perAtomicUnit, ok := result.totalValue.Per(revision.StandardYield())
+	if !ok {
+		return RecipeRevisionCost{}, domain.ErrInvariant
+	}
+	conversion := output.BaseConversion()
+	perBaseUnit, err := roundedValue(perAtomicUnit, conversion.NumeratorAtomic(), conversion.Denominator())
+	if err != nil {
+		return RecipeRevisionCost{}, err
+	}
+	result.yieldBaseUnit = output.BaseUnit()
+	result.costPerYieldAtomicUnit = perAtomicUnit
+	result.costPerYieldBaseUnit = perBaseUnit
+	return result, nil
+}
+
+// roundedValue multiplies a value per atomic unit by a factor and rounds the
+// result to whole microcurrency.
+func roundedValue(unitValue domain.Fraction, numerator, denominator int64) (domain.InventoryValue, error) {
+	factor, err := domain.NewFraction(numerator, denominator)
+	if err != nil {
+		return domain.InventoryValue{}, err
+	}
+	product, err := unitValue.Multiply(factor)
+	if err != nil {
+		return domain.InventoryValue{}, err
+	}
+	rounded, err := product.RoundHalfUp()
+	if err != nil {
+		return domain.InventoryValue{}, err
+	}
+	return domain.NewInventoryValue(rounded)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
 	"github.com/jerobas/saas/internal/domain/recipe"
 	"github.com/jerobas/saas/internal/infrastructure/sqlite"
 )
@@ -32,6 +33,10 @@ func (s *sqliteRecipeStore) ListRecipeRevisions(ctx context.Context, recipeID do
 	return s.store.ListRecipeRevisions(ctx, recipeID)
 }
 
+func (s *sqliteRecipeStore) ListActiveRecipesByOutputItem(ctx context.Context, itemID domain.ItemID) ([]recipe.Recipe, error) {
+	return s.store.ListActiveRecipesByOutputItem(ctx, itemID)
+}
+
 func (s *sqliteRecipeStore) ListRecipes(ctx context.Context, input RecipeListInput) (RecipePage, error) {
 	pageSize, err := sqlite.NewRecipePageSize(input.PageSize)
 	if err != nil {
@@ -141,3 +146,7 @@ func mapRecipeComponentSource(source RecipeComponentSource) (sqlite.RecipeCompon
 		return sqlite.RecipeComponentSource{}, fmt.Errorf("%w: component source kind %q", domain.ErrValidation, source.Kind)
 	}
 }
+
+func (s *sqliteRecipeStore) ListItemCostBases(ctx context.Context, itemIDs []domain.ItemID) ([]inventory.CostBasis, error) {
+	return s.store.ListItemCostBases(ctx, itemIDs)
+}
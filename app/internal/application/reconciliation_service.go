@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain/inventory"
+)
+
+type ReconciliationStore interface {
+	ReconcileInventory(ctx context.Context) (inventory.Reconciliation, error)
+}
+
+// ReconciliationService runs the diagnostic ledger replay described in the
+// inventory ledger. It only reads; repairing a projection is a separate,
+// deliberate operation.
+type ReconciliationService struct {
+	store ReconciliationStore
+}
+
+func NewReconciliationService(store ReconciliationStore) *ReconciliationService {
+	if store == nil {
+		panic("reconciliation service requires a store")
+	}
+	return &ReconciliationService{store: store}
+}
+
+func (s *ReconciliationService) ReconcileInventory(ctx context.Context) (inventory.Reconciliation, error) {
+	reconciliation, err := s.store.ReconcileInventory(ctx)
+	if err != nil {
+		return inventory.Reconciliation{}, fmt.Errorf("reconcile inventory: %w", err)
+	}
+	return reconciliation, nil
+}
@@ -0,0 +1,23 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain/inventory"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteReconciliationStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteReconciliationStore(store *sqlite.Store) ReconciliationStore {
+	if store == nil {
+		panic("sqlite reconciliation store requires a store")
+	}
+	return &sqliteReconciliationStore{store: store}
+}
+
+func (s *sqliteReconciliationStore) ReconcileInventory(ctx context.Context) (inventory.Reconciliation, error) {
+	return s.store.ReconcileInventory(ctx)
+}
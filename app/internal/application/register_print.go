@@ -0,0 +1,97 @@
+package application
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+// RegisterClosingReport is the Z report of one closed register session with
+// the settings it prints under.
+type RegisterClosingReport struct {
+	BusinessName domain.DisplayName
+	Currency     domain.Currency
+	Timezone     domain.BusinessTimezone
+	Session      sales.RegisterSession
+}
+
+const registerPrintTimeLayout = "2006-01-02 15:04"
+
+// WriteText writes the report as aligned plain text for a receipt printer.
+// Times are local to the business timezone and amounts use the currency's
+// minor digits.
+func (r RegisterClosingReport) WriteText(w io.Writer) error {
+	closing, ok := r.Session.Closing().Get()
+	if !ok {
+		return fmt.Errorf("%w: the register session is still open", domain.ErrConflict)
+	}
+	totals := closing.Totals()
+	local := func(instant domain.UTCInstant) string {
+		if r.Timezone.IsZero() {
+			return instant.Time().UTC().Format(registerPrintTimeLayout)
+		}
+		return instant.Time().In(r.Timezone.Location()).Format(registerPrintTimeLayout)
+	}
+	amount := func(minor int64) string {
+		return formatMinorAmount(minor, r.Currency.MinorDigits().Int()) + " " + r.Currency.Code().String()
+	}
+
+	if _, err := fmt.Fprintf(w, "%s\nZ report - register session %s\n\n", r.BusinessName.String(), r.Session.ID().String()); err != nil {
+		return err
+	}
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	rows := [][2]string{
+		{"Business date", r.Session.BusinessDate().String()},
+		{"Opened", local(r.Session.OpenedAt())},
+		{"Closed", local(closing.ClosedAt())},
+		{"", ""},
+		{"Cash", amount(totals.CashMinor)},
+		{"Pix", amount(totals.PixMinor)},
+		{"Card", amount(totals.CardMinor)},
+		{"On account", amount(totals.OnAccountMinor)},
+		{"", ""},
+		{"Opening float", amount(totals.OpeningFloatMinor)},
+		{"Cash sales", amount(totals.CashMinor)},
+		{"Cash in", amount(totals.CashInMinor)},
+		{"Cash out", amount(-totals.CashOutMinor)},
+		{"Expected cash", amount(totals.ExpectedCashMinor)},
+		{"Counted cash", amount(closing.CountedCash().Int64())},
+		{"Difference", amount(closing.Difference())},
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(writer, "%s\t%s\t\n", row[0], row[1]); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	if notes, ok := closing.Notes().Get(); ok {
+		if _, err := fmt.Fprintf(w, "\n%s\n", notes.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatMinorAmount writes a signed minor amount as a dot decimal with the
+// currency's minor digits.
+func formatMinorAmount(minor int64, digits int) string {
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	text := strconv.FormatInt(minor, 10)
+	if digits <= 0 {
+		return sign + text
+	}
+	if len(text) <= digits {
+		text = strings.Repeat("0", digits-len(text)+1) + text
+	}
+	return sign + text[:len(text)-digits] + "." + text[len(text)-digits:]
+}
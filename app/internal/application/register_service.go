@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/domain/settings"
+)
+
+type RegisterStore interface {
+	GetSettings(ctx context.Context) (settings.Settings, error)
+	GetRegisterSession(ctx context.Context, id domain.RegisterSessionID) (sales.RegisterSession, error)
+	GetOpenRegisterSession(ctx context.Context) (sales.RegisterSession, error)
+	ListClosedRegisterSessions(ctx context.Context, from, to domain.BusinessDate) ([]sales.RegisterSession, error)
+	OpenRegisterSession(ctx context.Context, input registerOpenStoreInput) (sales.RegisterSession, error)
+	RecordCashMovement(ctx context.Context, input cashMovementRecordStoreInput) (sales.CashMovement, error)
+	CloseRegisterSession(ctx context.Context, input registerCloseStoreInput) (sales.RegisterSession, error)
+}
+
+type RegisterOpenInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	OpeningFloat   domain.MinorAmount
+	Notes          domain.Option[domain.NonEmptyText]
+}
+
+type CashMovementRecordInput struct {
+	IdempotencyKey    domain.IdempotencyKey
+	RegisterSessionID domain.RegisterSessionID
+	Kind              domain.CashMovementKind
+	Amount            domain.MinorAmount
+	Notes             domain.NonEmptyText
+}
+
+type RegisterCloseInput struct {
+	RegisterSessionID domain.RegisterSessionID
+	CountedCash       domain.MinorAmount
+	Notes             domain.Option[domain.NonEmptyText]
+}
+
+type registerOpenStoreInput struct {
+	RegisterOpenInput
+	BusinessDate domain.BusinessDate
+	OpenedAt     domain.UTCInstant
+}
+
+type cashMovementRecordStoreInput struct {
+	CashMovementRecordInput
+	RecordedAt domain.UTCInstant
+}
+
+type registerCloseStoreInput struct {
+	RegisterCloseInput
+	ClosedAt domain.UTCInstant
+	Timezone domain.BusinessTimezone
+}
+
+type RegisterService struct {
+	store RegisterStore
+	clock Clock
+}
+
+func NewRegisterService(store RegisterStore, clock Clock) *RegisterService {
+	if store == nil {
+		panic("register service requires a store")
+	}
+	if clock == nil {
+		panic("register service requires a clock")
+	}
+	return &RegisterService{store: store, clock: clock}
+}
+
+// OpenRegisterSession opens the drawer with a float. The session's business
+// date is today in the business timezone.
+func (s *RegisterService) OpenRegisterSession(ctx context.Context, input RegisterOpenInput) (sales.RegisterSession, error) {
+	current, err := s.store.GetSettings(ctx)
+	if err != nil {
+		return sales.RegisterSession{}, fmt.Errorf("open register session: %w", err)
+	}
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.RegisterSession{}, fmt.Errorf("read clock: %w", err)
+	}
+	session, err := s.store.OpenRegisterSession(ctx, registerOpenStoreInput{
+		RegisterOpenInput: input,
+		BusinessDate:      current.Timezone().DateOf(now),
+		OpenedAt:          now,
+	})
+	if err != nil {
+		return sales.RegisterSession{}, fmt.Errorf("open register session: %w", err)
+	}
+	if session.IdempotencyKey() != input.IdempotencyKey || session.OpeningFloat() != input.OpeningFloat {
+		return sales.RegisterSession{}, domain.ErrInvariant
+	}
+	return session, nil
+}
+
+func (s *RegisterService) GetRegisterSession(
+	ctx context.Context,
+	id domain.RegisterSessionID,
+) (sales.RegisterSession, error) {
+	session, err := s.store.GetRegisterSession(ctx, id)
+	if err != nil {
+		return sales.RegisterSession{}, fmt.Errorf("get register session: %w", err)
+	}
+	return session, nil
+}
+
+// GetOpenRegisterSession returns the open session with its running totals,
+// or ErrNotFound when the register is closed.
+func (s *RegisterService) GetOpenRegisterSession(ctx context.Context) (sales.RegisterSession, error) {
+	session, err := s.store.GetOpenRegisterSession(ctx)
+	if err != nil {
+		return sales.RegisterSession{}, fmt.Errorf("get open register session: %w", err)
+	}
+	if !session.IsOpen() {
+		return sales.RegisterSession{}, domain.ErrInvariant
+	}
+	return session, nil
+}
+
+// ListClosedRegisterSessions lists the closed sessions opened from one
+// business date through another, newest first.
+func (s *RegisterService) ListClosedRegisterSessions(
+	ctx context.Context,
+	from, to domain.BusinessDate,
+) ([]sales.RegisterSession, error) {
+	sessions, err := s.store.ListClosedRegisterSessions(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list closed register sessions: %w", err)
+	}
+	for _, session := range sessions {
+		if session.IsOpen() || session.BusinessDate().Before(from) || session.BusinessDate().After(to) {
+			return nil, domain.ErrInvariant
+		}
+	}
+	return sessions, nil
+}
+
+func (s *RegisterService) RecordCashMovement(
+	ctx context.Context,
+	input CashMovementRecordInput,
+) (sales.CashMovement, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.CashMovement{}, fmt.Errorf("read clock: %w", err)
+	}
+	movement, err := s.store.RecordCashMovement(ctx, cashMovementRecordStoreInput{
+		CashMovementRecordInput: input,
+		RecordedAt:              now,
+	})
+	if err != nil {
+		return sales.CashMovement{}, fmt.Errorf("record cash movement: %w", err)
+	}
+	if movement.IdempotencyKey() != input.IdempotencyKey || movement.RegisterSessionID() != input.RegisterSessionID {
+		return sales.CashMovement{}, domain.ErrInvariant
+	}
+	return movement, nil
+}
+
+// CloseRegisterSession closes the session with the cash counted in the
+// drawer. The closing date is today in the business timezone.
+func (s *RegisterService) CloseRegisterSession(ctx context.Context, input RegisterCloseInput) (sales.RegisterSession, error) {
+	current, err := s.store.GetSettings(ctx)
+	if err != nil {
+		return sales.RegisterSession{}, fmt.Errorf("close register session: %w", err)
+	}
+	now, err := s.clock.Now()
+	if err != nil {
+		return sales.RegisterSession{}, fmt.Errorf("read clock: %w", err)
+	}
+	session, err := s.store.CloseRegisterSession(ctx, registerCloseStoreInput{
+		RegisterCloseInput: input,
+		ClosedAt:           now,
+		Timezone:           current.Timezone(),
+	})
+	if err != nil {
+		return sales.RegisterSession{}, fmt.Errorf("close register session: %w", err)
+	}
+	if closing, ok := session.Closing().Get(); !ok || session.ID() != input.RegisterSessionID ||
+		closing.CountedCash() != input.CountedCash {
+		return sales.RegisterSession{}, domain.ErrInvariant
+	}
+	return session, nil
+}
+
+// GetRegisterClosingReport returns the printable Z report of a closed
+// session.
+func (s *RegisterService) GetRegisterClosingReport(
+	ctx context.Context,
+	id domain.RegisterSessionID,
+) (RegisterClosingReport, error) {
+	session, err := s.store.GetRegisterSession(ctx, id)
+	if err != nil {
+		return RegisterClosingReport{}, fmt.Errorf("get register closing report: %w", err)
+	}
+	if session.IsOpen() {
+		return RegisterClosingReport{}, fmt.Errorf("%w: the register session is still open", domain.ErrConflict)
+	}
+	current, err := s.store.GetSettings(ctx)
+	if err != nil {
+		return RegisterClosingReport{}, fmt.Errorf("get register closing report: %w", err)
+	}
+	return RegisterClosingReport{
+		BusinessName: current.BusinessName(),
+		Currency:     current.Currency(),
+		Timezone:     current.Timezone(),
+		Session:      session,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/domain/settings"
+)
+
+type memoryRegisterStore struct {
+	RegisterStore
+	settings settings.Settings
+	session  sales.RegisterSession
+}
+
+func (s *memoryRegisterStore) GetSettings(context.Context) (settings.Settings, error) {
+	return s.settings, nil
+}
+
+func (s *memoryRegisterStore) GetRegisterSession(context.Context, domain.RegisterSessionID) (sales.RegisterSession, error) {
+	return s.session, nil
+}
+
+func (s *memoryRegisterStore) OpenRegisterSession(
+	_ context.Context,
+	input registerOpenStoreInput,
+) (sales.RegisterSession, error) {
+	session, err := sales.NewRegisterSession(sales.RegisterSessionParams{
+		ID:             must(domain.NewRegisterSessionID(1)),
+		IdempotencyKey: input.IdempotencyKey,
+		BusinessDate:   input.BusinessDate,
+		OpeningFloat:   input.OpeningFloat,
+		Notes:          input.Notes,
+		OpenedAt:       input.OpenedAt,
+	})
+	s.session = session
+	return session, err
+}
+
+func (s *memoryRegisterStore) CloseRegisterSession(
+	_ context.Context,
+	input registerCloseStoreInput,
+) (sales.RegisterSession, error) {
+	closing, err := s.session.Close(input.CountedCash, input.Notes, input.ClosedAt, input.Timezone)
+	if err != nil {
+		return sales.RegisterSession{}, err
+	}
+	session, err := sales.NewRegisterSession(sales.RegisterSessionParams{
+		ID:             s.session.ID(),
+		IdempotencyKey: s.session.IdempotencyKey(),
+		BusinessDate:   s.session.BusinessDate(),
+		OpeningFloat:   s.session.OpeningFloat(),
+		Notes:          s.session.Notes(),
+		OpenedAt:       s.session.OpenedAt(),
+		Closing:        domain.Some(closing),
+	})
+	s.session = session
+	return session, err
+}
+
+func TestRegisterServiceDatesSessionsInTheBusinessTimezoneAndPrintsTheClosing(t *testing.T) {
+	timezone := must(domain.NewBusinessTimezone("America/Sao_Paulo"))
+	store := &memoryRegisterStore{settings: must(settings.New(settings.Params{
+		BusinessName: must(domain.NewDisplayName("Padaria Central")),
+		Locale:       must(domain.NewLocale("pt-BR")),
+		Timezone:     timezone,
+		Currency:     must(domain.NewCurrency("BRL")),
+		Backup: must(settings.NewBackupPolicy(settings.BackupPolicyParams{
+			IntervalMinutes: 60, KeepDaily: 7,
+		})),
+		CreatedAt: mustInstant(1_000),
+		UpdatedAt: mustInstant(1_000),
+	}))}
+	// 2026-07-16 01:30 UTC is still the evening of the 15th in São Paulo.
+	clock := &mutableClock{now: mustInstant(1_784_165_400_000)}
+	service := NewRegisterService(store, clock)
+	ctx := context.Background()
+
+	session, err := service.OpenRegisterSession(ctx, RegisterOpenInput{
+		IdempotencyKey: must(domain.NewIdempotencyKey("register-open-1")),
+		OpeningFloat:   must(domain.NewMinorAmount(10_000)),
+	})
+	if err != nil {
+		t.Fatalf("open register session: %v", err)
+	}
+	if session.BusinessDate().String() != "2026-07-15" {
+		t.Fatalf("business date = %s, want the São Paulo day", session.BusinessDate())
+	}
+	if _, err := service.GetRegisterClosingReport(ctx, session.ID()); err == nil {
+		t.Fatal("open session printed a closing report")
+	}
+
+	clock.now = mustInstant(1_784_169_000_000)
+	closed, err := service.CloseRegisterSession(ctx, RegisterCloseInput{
+		RegisterSessionID: session.ID(),
+		CountedCash:       must(domain.NewMinorAmount(9_950)),
+	})
+	if err != nil {
+		t.Fatalf("close register session: %v", err)
+	}
+	closing, _ := closed.Closing().Get()
+	if closing.ClosedOn().String() != "2026-07-15" || closing.Difference() != -50 {
+		t.Fatalf("closing = %#v", closing)
+	}
+
+	report, err := service.GetRegisterClosingReport(ctx, session.ID())
+	if err != nil {
+		t.Fatalf("get register closing report: %v", err)
+	}
+	var text strings.Builder
+	if err := report.WriteText(&text); err != nil {
+		t.Fatalf("write closing report: %v", err)
+	}
+	for _, want := range []string{
+		"Padaria Central", "2026-07-15 22:30", "2026-07-15 23:30",
+		"100.00 BRL", "99.50 BRL", "-0.50 BRL",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Fatalf("closing report is missing %q:\n%s", want, text.String())
+		}
+	}
+}
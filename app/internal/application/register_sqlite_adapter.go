@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/domain/settings"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteRegisterStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteRegisterStore(store *sqlite.Store) RegisterStore {
+	if store == nil {
+		panic("sqlite register store requires a store")
+	}
+	return &sqliteRegisterStore{store: store}
+}
+
+func (s *sqliteRegisterStore) GetSettings(ctx context.Context) (settings.Settings, error) {
+	return s.store.GetSettings(ctx)
+}
+
+func (s *sqliteRegisterStore) GetRegisterSession(
+	ctx context.Context,
+	id domain.RegisterSessionID,
+) (sales.RegisterSession, error) {
+	return s.store.GetRegisterSession(ctx, id)
+}
+
+func (s *sqliteRegisterStore) GetOpenRegisterSession(ctx context.Context) (sales.RegisterSession, error) {
+	return s.store.GetOpenRegisterSession(ctx)
+}
+
+func (s *sqliteRegisterStore) ListClosedRegisterSessions(
+	ctx context.Context,
+	from, to domain.BusinessDate,
+) ([]sales.RegisterSession, error) {
+	return s.store.ListClosedRegisterSessions(ctx, sqlite.RegisterSessionFilter{
+		FromBusinessDate: from,
+		ToBusinessDate:   to,
+	})
+}
+
+func (s *sqliteRegisterStore) OpenRegisterSession(
+	ctx context.Context,
+	input registerOpenStoreInput,
+) (sales.RegisterSession, error) {
+	return s.store.OpenRegisterSession(ctx, sqlite.OpenRegisterSessionInput{
+		IdempotencyKey: input.IdempotencyKey,
+		BusinessDate:   input.BusinessDate,
+		OpeningFloat:   input.OpeningFloat,
+		Notes:          input.Notes,
+		OpenedAt:       input.OpenedAt,
+	})
+}
+
+func (s *sqliteRegisterStore) RecordCashMovement(
+	ctx context.Context,
+	input cashMovementRecordStoreInput,
+) (sales.CashMovement, error) {
+	return s.store.RecordCashMovement(ctx, sqlite.RecordCashMovementInput{
+		IdempotencyKey:    input.IdempotencyKey,
+		RegisterSessionID: input.RegisterSessionID,
+		Kind:              input.Kind,
+		Amount:            input.Amount,
+		Notes:             input.Notes,
+		RecordedAt:        input.RecordedAt,
+	})
+}
+
+func (s *sqliteRegisterStore) CloseRegisterSession(
+	ctx context.Context,
+	input registerCloseStoreInput,
+) (sales.RegisterSession, error) {
+	return s.store.CloseRegisterSession(ctx, sqlite.CloseRegisterSessionInput{
+		RegisterSessionID: input.RegisterSessionID,
+		CountedCash:       input.CountedCash,
+		Notes:             input.Notes,
+		ClosedAt:          input.ClosedAt,
+		Timezone:          input.Timezone,
+	})
+}
@@ -2,12 +2,17 @@ package application
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jerobas/saas/internal/domain"
 )
 
+// uncategorizedLabel names the category mix bucket for items without a
+// category. The presentation layer may localize it by the absent CategoryID.
+const uncategorizedLabel = "Uncategorized"
+
 type ReportingGranularity string
 
 const (
@@ -59,6 +64,8 @@ type SalesReport struct {
 	Period                         ReportingPeriodInput
 	Currency                       domain.Currency
 	TotalSalesCount                int64
+	ReturnCount                    int64
+	RefundMinor                    int64
 	CommercialTotalMinor           int64
 	COGSInventoryValueMicro        int64
 	GrossMarginInventoryValueMicro int64
@@ -75,8 +82,12 @@ type SalesReport struct {
 	AnonymousSales                 ReportingCounterpartyMetric
 }
 
+// InventoryReport covers every location unless Location is set, in which
+// case quantities are the lots at that location valued at the item's
+// weighted average.
 type InventoryReport struct {
 	Period                   ReportingPeriodInput
+	Location                 domain.Option[domain.StockLocationID]
 	Currency                 domain.Currency
 	TotalInventoryValueMicro int64
 	LowStockItemCount        int64
@@ -94,6 +105,16 @@ type PurchaseReport struct {
 	PurchaseSpendSeries []ReportingSeries
 	TopSuppliersBySpend []ReportingCounterpartyMetric
 	FreeStockEntries    []ReportingSeries
+	Payables            PurchasePayableTotals
+}
+
+// PurchasePayableTotals sums the scheduled payables of the period's purchases.
+// Outstanding is owed less paid; a negative value is credit with suppliers.
+type PurchasePayableTotals struct {
+	PayableCount     int64
+	OwedMinor        int64
+	PaidMinor        int64
+	OutstandingMinor int64
 }
 
 type ProductionReport struct {
@@ -112,26 +133,72 @@ type AdjustmentReport struct {
 	ExactReversals   []ReportingSeries
 }
 
+// CategoryMixReport splits active sales revenue by item category. Items keep
+// no category history, so every sale is attributed to its item's current
+// category.
 type CategoryMixReport struct {
-	Period            ReportingPeriodInput
-	Available         bool
-	UnavailableReason string
-	Rows              []CategoryMixRow
+	Period               ReportingPeriodInput
+	Currency             domain.Currency
+	Available            bool
+	UnavailableReason    string
+	CommercialTotalMinor int64
+	Rows                 []CategoryMixRow
 }
 
+// CategoryMixRow is one category's share of the period. A None CategoryID is
+// the uncategorized bucket.
 type CategoryMixRow struct {
+	CategoryID           domain.Option[domain.CategoryID]
 	CategoryName         string
 	QuantityAtomic       int64
 	CommercialTotalMinor int64
 	ShareBasisPoints     int64
 }
 
+// ProfitAndLossReport nets revenue against COGS, stock write-offs, and
+// expenses for each bucket of the period. Production direct cost is
+// capitalized into output lots and reaches COGS when the product sells, so it
+// is a memo line and is not subtracted again.
+type ProfitAndLossReport struct {
+	Period             ReportingPeriodInput
+	Currency           domain.Currency
+	Rows               []ProfitAndLossRow
+	Totals             ProfitAndLossRow
+	WriteOffsByReason  []ReportingReasonMetric
+	ExpensesByCategory []ExpenseCategoryMetric
+}
+
+// ProfitAndLossRow is one bucket, or the period totals with an empty Bucket.
+// Amounts in minor units are also converted to micro units so the net profit
+// can be computed at inventory precision.
+type ProfitAndLossRow struct {
+	Bucket                         string
+	Label                          string
+	CommercialTotalMinor           int64
+	COGSInventoryValueMicro        int64
+	GrossMarginInventoryValueMicro int64
+	DirectCostInventoryValueMicro  int64
+	WriteOffInventoryValueMicro    int64
+	ExpenseMinor                   int64
+	ExpenseInventoryValueMicro     int64
+	NetProfitInventoryValueMicro   int64
+	NetMarginBasisPoints           domain.Option[int64]
+}
+
+type ExpenseCategoryMetric struct {
+	Category     string
+	ExpenseCount int64
+	AmountMinor  int64
+}
+
 type ReportingStore interface {
 	GetSalesReportData(ctx context.Context, current ReportingPeriodInput, previous ReportingPeriodInput, topLimit int) (SalesReportData, error)
-	GetInventoryReportData(ctx context.Context, input ReportingPeriodInput, rowLimit int) (InventoryReportData, error)
+	GetInventoryReportData(ctx context.Context, input ReportingPeriodInput, location domain.Option[domain.StockLocationID], rowLimit int) (InventoryReportData, error)
 	GetPurchaseReportData(ctx context.Context, input ReportingPeriodInput, rowLimit int) (PurchaseReportData, error)
 	GetProductionReportData(ctx context.Context, input ReportingPeriodInput, rowLimit int) (ProductionReportData, error)
 	GetAdjustmentReportData(ctx context.Context, input ReportingPeriodInput) (AdjustmentReportData, error)
+	GetCategoryMixReportData(ctx context.Context, input ReportingPeriodInput) (CategoryMixReportData, error)
+	GetProfitAndLossReportData(ctx context.Context, input ReportingPeriodInput) (ProfitAndLossReportData, error)
 }
 
 type SalesReportData struct {
@@ -152,6 +219,8 @@ type SalesReportTotals struct {
 	QuantityAtomic          int64
 	CommercialTotalMinor    int64
 	COGSInventoryValueMicro int64
+	ReturnCount             int64
+	RefundMinor             int64
 }
 
 type InventoryReportData struct {
@@ -171,6 +240,7 @@ type PurchaseReportData struct {
 	PurchaseSpendSeries  []ReportingSeries
 	TopSuppliersBySpend  []ReportingCounterpartyMetric
 	FreeStockEntrySeries []ReportingSeries
+	Payables             PurchasePayableTotals
 }
 
 type ProductionReportData struct {
@@ -187,6 +257,29 @@ type AdjustmentReportData struct {
 	ExactReversals   []ReportingSeries
 }
 
+type CategoryMixReportData struct {
+	Currency domain.Currency
+	Rows     []CategoryMixRowData
+}
+
+type CategoryMixRowData struct {
+	CategoryID           domain.Option[domain.CategoryID]
+	CategoryName         domain.Option[string]
+	QuantityAtomic       int64
+	CommercialTotalMinor int64
+}
+
+// ProfitAndLossReportData holds the series behind a profit and loss report.
+// Write-offs and expenses have one row per bucket and reason code or expense
+// category.
+type ProfitAndLossReportData struct {
+	Currency         domain.Currency
+	SalesSeries      []ReportingSeries
+	DirectCostSeries []ReportingSeries
+	WriteOffs        []ReportingBucketReasonMetric
+	Expenses         []ReportingBucketReasonMetric
+}
+
 type ReportingSeries struct {
 	Bucket                         string
 	Label                          string
@@ -242,6 +335,16 @@ type ReportingReasonMetric struct {
 	InventoryValueMicro  int64
 }
 
+type ReportingBucketReasonMetric struct {
+	Bucket               string
+	Label                string
+	ReasonCode           string
+	DocumentCount        int64
+	QuantityAtomic       int64
+	CommercialTotalMinor int64
+	InventoryValueMicro  int64
+}
+
 type ReportingService struct {
 	store ReportingStore
 }
@@ -271,6 +374,8 @@ func (s *ReportingService) GetSalesReport(ctx context.Context, input ReportingPe
 		Period:                         input,
 		Currency:                       data.Currency,
 		TotalSalesCount:                data.CurrentTotals.SalesCount,
+		ReturnCount:                    data.CurrentTotals.ReturnCount,
+		RefundMinor:                    data.CurrentTotals.RefundMinor,
 		CommercialTotalMinor:           data.CurrentTotals.CommercialTotalMinor,
 		COGSInventoryValueMicro:        data.CurrentTotals.COGSInventoryValueMicro,
 		GrossMarginInventoryValueMicro: grossMarginInventoryValueMicro,
@@ -288,13 +393,14 @@ func (s *ReportingService) GetSalesReport(ctx context.Context, input ReportingPe
 	}, nil
 }
 
-func (s *ReportingService) GetInventoryReport(ctx context.Context, input ReportingPeriodInput) (InventoryReport, error) {
-	data, err := s.store.GetInventoryReportData(ctx, input, 10)
+func (s *ReportingService) GetInventoryReport(ctx context.Context, input ReportingPeriodInput, location domain.Option[domain.StockLocationID]) (InventoryReport, error) {
+	data, err := s.store.GetInventoryReportData(ctx, input, location, 10)
 	if err != nil {
 		return InventoryReport{}, err
 	}
 	return InventoryReport{
 		Period:                   input,
+		Location:                 location,
 		Currency:                 data.Currency,
 		TotalInventoryValueMicro: data.TotalInventoryValueMicro,
 		LowStockItemCount:        data.LowStockItemCount,
@@ -318,6 +424,12 @@ func (s *ReportingService) GetPurchaseReport(ctx context.Context, input Reportin
 		PurchaseSpendSeries: data.PurchaseSpendSeries,
 		TopSuppliersBySpend: data.TopSuppliersBySpend,
 		FreeStockEntries:    data.FreeStockEntrySeries,
+		Payables: PurchasePayableTotals{
+			PayableCount:     data.Payables.PayableCount,
+			OwedMinor:        data.Payables.OwedMinor,
+			PaidMinor:        data.Payables.PaidMinor,
+			OutstandingMinor: data.Payables.OwedMinor - data.Payables.PaidMinor,
+		},
 	}, nil
 }
 
@@ -349,15 +461,151 @@ func (s *ReportingService) GetAdjustmentReport(ctx context.Context, input Report
 	}, nil
 }
 
-func (s *ReportingService) GetCategoryMixReport(_ context.Context, input ReportingPeriodInput) (CategoryMixReport, error) {
+func (s *ReportingService) GetCategoryMixReport(ctx context.Context, input ReportingPeriodInput) (CategoryMixReport, error) {
+	data, err := s.store.GetCategoryMixReportData(ctx, input)
+	if err != nil {
+		return CategoryMixReport{}, err
+	}
+	var totalMinor int64
+	for _, row := range data.Rows {
+		totalMinor += row.CommercialTotalMinor
+	}
+	rows := make([]CategoryMixRow, 0, len(data.Rows))
+	for _, row := range data.Rows {
+		name, ok := row.CategoryName.Get()
+		if !ok {
+			name = uncategorizedLabel
+		}
+		share, _ := ratioBasisPoints(row.CommercialTotalMinor, totalMinor).Get()
+		rows = append(rows, CategoryMixRow{
+			CategoryID:           row.CategoryID,
+			CategoryName:         name,
+			QuantityAtomic:       row.QuantityAtomic,
+			CommercialTotalMinor: row.CommercialTotalMinor,
+			ShareBasisPoints:     share,
+		})
+	}
 	return CategoryMixReport{
-		Period:            input,
-		Available:         false,
-		UnavailableReason: "Catalog categories/tags are not modeled in V2 yet.",
-		Rows:              []CategoryMixRow{},
+		Period:               input,
+		Currency:             data.Currency,
+		Available:            true,
+		CommercialTotalMinor: totalMinor,
+		Rows:                 rows,
 	}, nil
 }
 
+func (s *ReportingService) GetProfitAndLossReport(ctx context.Context, input ReportingPeriodInput) (ProfitAndLossReport, error) {
+	data, err := s.store.GetProfitAndLossReportData(ctx, input)
+	if err != nil {
+		return ProfitAndLossReport{}, err
+	}
+	rows := map[string]*ProfitAndLossRow{}
+	row := func(bucket, label string) *ProfitAndLossRow {
+		if existing, ok := rows[bucket]; ok {
+			return existing
+		}
+		created := &ProfitAndLossRow{Bucket: bucket, Label: label}
+		rows[bucket] = created
+		return created
+	}
+	for _, item := range data.SalesSeries {
+		bucket := row(item.Bucket, item.Label)
+		bucket.CommercialTotalMinor += item.CommercialTotalMinor
+		bucket.COGSInventoryValueMicro += item.COGSInventoryValueMicro
+	}
+	for _, item := range data.DirectCostSeries {
+		row(item.Bucket, item.Label).DirectCostInventoryValueMicro += item.DirectCostInventoryValueMicro
+	}
+	writeOffs := map[string]*ReportingReasonMetric{}
+	var writeOffOrder []string
+	for _, item := range data.WriteOffs {
+		row(item.Bucket, item.Label).WriteOffInventoryValueMicro += item.InventoryValueMicro
+		metric, ok := writeOffs[item.ReasonCode]
+		if !ok {
+			metric = &ReportingReasonMetric{ReasonCode: item.ReasonCode}
+			writeOffs[item.ReasonCode] = metric
+			writeOffOrder = append(writeOffOrder, item.ReasonCode)
+		}
+		metric.DocumentCount += item.DocumentCount
+		metric.QuantityAtomic += item.QuantityAtomic
+		metric.InventoryValueMicro += item.InventoryValueMicro
+	}
+	expenses := map[string]*ExpenseCategoryMetric{}
+	var expenseOrder []string
+	for _, item := range data.Expenses {
+		row(item.Bucket, item.Label).ExpenseMinor += item.CommercialTotalMinor
+		metric, ok := expenses[item.ReasonCode]
+		if !ok {
+			metric = &ExpenseCategoryMetric{Category: item.ReasonCode}
+			expenses[item.ReasonCode] = metric
+			expenseOrder = append(expenseOrder, item.ReasonCode)
+		}
+		metric.ExpenseCount += item.DocumentCount
+		metric.AmountMinor += item.CommercialTotalMinor
+	}
+
+	buckets := make([]string, 0, len(rows))
+	for bucket := range rows {
+		buckets = append(buckets, bucket)
+	}
+	sort.Strings(buckets)
+	report := ProfitAndLossReport{
+		Period:             input,
+		Currency:           data.Currency,
+		Rows:               make([]ProfitAndLossRow, 0, len(buckets)),
+		WriteOffsByReason:  make([]ReportingReasonMetric, 0, len(writeOffOrder)),
+		ExpensesByCategory: make([]ExpenseCategoryMetric, 0, len(expenseOrder)),
+	}
+	for _, bucket := range buckets {
+		item := *rows[bucket]
+		if err := completeProfitAndLossRow(&item, data.Currency); err != nil {
+			return ProfitAndLossReport{}, err
+		}
+		report.Rows = append(report.Rows, item)
+		report.Totals.CommercialTotalMinor += item.CommercialTotalMinor
+		report.Totals.COGSInventoryValueMicro += item.COGSInventoryValueMicro
+		report.Totals.DirectCostInventoryValueMicro += item.DirectCostInventoryValueMicro
+		report.Totals.WriteOffInventoryValueMicro += item.WriteOffInventoryValueMicro
+		report.Totals.ExpenseMinor += item.ExpenseMinor
+	}
+	if err := completeProfitAndLossRow(&report.Totals, data.Currency); err != nil {
+		return ProfitAndLossReport{}, err
+	}
+	sort.SliceStable(writeOffOrder, func(i, j int) bool {
+		return writeOffs[writeOffOrder[i]].InventoryValueMicro > writeOffs[writeOffOrder[j]].InventoryValueMicro
+	})
+	for _, code := range writeOffOrder {
+		report.WriteOffsByReason = append(report.WriteOffsByReason, *writeOffs[code])
+	}
+	sort.SliceStable(expenseOrder, func(i, j int) bool {
+		return expenses[expenseOrder[i]].AmountMinor > expenses[expenseOrder[j]].AmountMinor
+	})
+	for _, category := range expenseOrder {
+		report.ExpensesByCategory = append(report.ExpensesByCategory, *expenses[category])
+	}
+	return report, nil
+}
+
+// completeProfitAndLossRow derives the margin and net profit from the row's
+// summed inputs. Revenue can be negative in a bucket where refunds exceed
+// sales.
+func completeProfitAndLossRow(row *ProfitAndLossRow, currency domain.Currency) error {
+	revenueMicro, err := signedMinorToMicro(row.CommercialTotalMinor, currency)
+	if err != nil {
+		return err
+	}
+	expenseMicro, err := minorToMicro(row.ExpenseMinor, currency)
+	if err != nil {
+		return err
+	}
+	row.GrossMarginInventoryValueMicro = revenueMicro - row.COGSInventoryValueMicro
+	row.ExpenseInventoryValueMicro = expenseMicro
+	row.NetProfitInventoryValueMicro = row.GrossMarginInventoryValueMicro -
+		row.WriteOffInventoryValueMicro - expenseMicro
+	row.NetMarginBasisPoints = ratioBasisPoints(row.NetProfitInventoryValueMicro, revenueMicro)
+	return nil
+}
+
 func previousReportingPeriod(input ReportingPeriodInput) (ReportingPeriodInput, error) {
 	from, err := time.Parse("2006-01-02", input.FromOccurredOn.String())
 	if err != nil {
@@ -393,6 +641,14 @@ func minorToMicro(value int64, currency domain.Currency) (int64, error) {
 	return converted.Int64(), nil
 }
 
+func signedMinorToMicro(value int64, currency domain.Currency) (int64, error) {
+	if value >= 0 {
+		return minorToMicro(value, currency)
+	}
+	converted, err := minorToMicro(-value, currency)
+	return -converted, err
+}
+
 func enrichSeries(items []ReportingSeries, currency domain.Currency) []ReportingSeries {
 	enriched := make([]ReportingSeries, 0, len(items))
 	for _, item := range items {
@@ -57,11 +57,114 @@ func TestReportingServiceUsesDefaultMonthGranularityAndPreviousPeriod(t *testing
 	}
 }
 
+func TestReportingServiceSharesCategoryMixRevenue(t *testing.T) {
+	input, err := NewReportingPeriodInput(
+		mustReportingBusinessDate(t, "2026-07-01"),
+		mustReportingBusinessDate(t, "2026-07-31"),
+		ReportingGranularityMonth,
+	)
+	if err != nil {
+		t.Fatalf("new reporting period: %v", err)
+	}
+	categoryID, err := domain.NewCategoryID(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := &recordingReportingStore{
+		currency: mustReportingCurrency(t),
+		categoryRows: []CategoryMixRowData{
+			{CategoryID: domain.Some(categoryID), CategoryName: domain.Some("Bolos"), QuantityAtomic: 4, CommercialTotalMinor: 2_000},
+			{QuantityAtomic: 1, CommercialTotalMinor: 1_000},
+		},
+	}
+	report, err := NewReportingService(store).GetCategoryMixReport(context.Background(), input)
+	if err != nil {
+		t.Fatalf("get category mix report: %v", err)
+	}
+	if !report.Available || report.CommercialTotalMinor != 3_000 || len(report.Rows) != 2 {
+		t.Fatalf("category mix report = %#v", report)
+	}
+	if id, ok := report.Rows[0].CategoryID.Get(); !ok || id != categoryID ||
+		report.Rows[0].CategoryName != "Bolos" || report.Rows[0].ShareBasisPoints != 6_666 {
+		t.Fatalf("categorized row = %#v", report.Rows[0])
+	}
+	if report.Rows[1].CategoryID.IsSome() || report.Rows[1].CategoryName != uncategorizedLabel ||
+		report.Rows[1].ShareBasisPoints != 3_333 {
+		t.Fatalf("uncategorized row = %#v", report.Rows[1])
+	}
+}
+
+func TestReportingServiceNetsProfitAndLossWithoutSubtractingDirectCostTwice(t *testing.T) {
+	input, err := NewReportingPeriodInput(
+		mustReportingBusinessDate(t, "2026-07-01"),
+		mustReportingBusinessDate(t, "2026-08-31"),
+		ReportingGranularityMonth,
+	)
+	if err != nil {
+		t.Fatalf("new reporting period: %v", err)
+	}
+	store := &recordingReportingStore{
+		currency: mustReportingCurrency(t),
+		profitAndLoss: ProfitAndLossReportData{
+			SalesSeries: []ReportingSeries{
+				{Bucket: "2026-07", Label: "2026-07", CommercialTotalMinor: 3_000, COGSInventoryValueMicro: 1_000_000},
+			},
+			DirectCostSeries: []ReportingSeries{
+				{Bucket: "2026-07", Label: "2026-07", DirectCostInventoryValueMicro: 2_000_000},
+			},
+			WriteOffs: []ReportingBucketReasonMetric{
+				{Bucket: "2026-07", Label: "2026-07", ReasonCode: "WASTE", DocumentCount: 1, InventoryValueMicro: 400_000},
+				{Bucket: "2026-07", Label: "2026-07", ReasonCode: "EXPIRY", DocumentCount: 1, InventoryValueMicro: 600_000},
+			},
+			Expenses: []ReportingBucketReasonMetric{
+				{Bucket: "2026-07", Label: "2026-07", ReasonCode: "RENT", DocumentCount: 1, CommercialTotalMinor: 1_000},
+				{Bucket: "2026-08", Label: "2026-08", ReasonCode: "WATER", DocumentCount: 1, CommercialTotalMinor: 150},
+			},
+		},
+	}
+	report, err := NewReportingService(store).GetProfitAndLossReport(context.Background(), input)
+	if err != nil {
+		t.Fatalf("get profit and loss report: %v", err)
+	}
+	if len(report.Rows) != 2 {
+		t.Fatalf("profit and loss rows = %#v", report.Rows)
+	}
+	july := report.Rows[0]
+	if july.Bucket != "2026-07" ||
+		july.GrossMarginInventoryValueMicro != 29_000_000 ||
+		july.DirectCostInventoryValueMicro != 2_000_000 ||
+		july.WriteOffInventoryValueMicro != 1_000_000 ||
+		july.ExpenseInventoryValueMicro != 10_000_000 ||
+		july.NetProfitInventoryValueMicro != 18_000_000 {
+		t.Fatalf("july row = %#v", july)
+	}
+	if margin, ok := july.NetMarginBasisPoints.Get(); !ok || margin != 6_000 {
+		t.Fatalf("july net margin = %#v", july.NetMarginBasisPoints)
+	}
+	august := report.Rows[1]
+	if august.Bucket != "2026-08" || august.NetProfitInventoryValueMicro != -1_500_000 ||
+		august.NetMarginBasisPoints.IsSome() {
+		t.Fatalf("august row = %#v", august)
+	}
+	if report.Totals.ExpenseMinor != 1_150 || report.Totals.NetProfitInventoryValueMicro != 16_500_000 {
+		t.Fatalf("totals = %#v", report.Totals)
+	}
+	if len(report.WriteOffsByReason) != 2 || report.WriteOffsByReason[0].ReasonCode != "EXPIRY" {
+		t.Fatalf("write-offs by reason = %#v", report.WriteOffsByReason)
+	}
+	if len(report.ExpensesByCategory) != 2 || report.ExpensesByCategory[0].Category != "RENT" ||
+		report.ExpensesByCategory[0].AmountMinor != 1_000 {
+		t.Fatalf("expenses by category = %#v", report.ExpensesByCategory)
+	}
+}
+
 type recordingReportingStore struct {
-	currency   domain.Currency
-	salesCalls int
-	current    ReportingPeriodInput
-	previous   ReportingPeriodInput
+	currency      domain.Currency
+	categoryRows  []CategoryMixRowData
+	profitAndLoss ProfitAndLossReportData
+	salesCalls    int
+	current       ReportingPeriodInput
+	previous      ReportingPeriodInput
 }
 
 func (s *recordingReportingStore) GetSalesReportData(
@@ -83,6 +186,7 @@ func (s *recordingReportingStore) GetSalesReportData(
 func (s *recordingReportingStore) GetInventoryReportData(
 	context.Context,
 	ReportingPeriodInput,
+	domain.Option[domain.StockLocationID],
 	int,
 ) (InventoryReportData, error) {
 	return InventoryReportData{Currency: s.currency}, nil
@@ -111,6 +215,22 @@ func (s *recordingReportingStore) GetAdjustmentReportData(
 	return AdjustmentReportData{Currency: s.currency}, nil
 }
 
+func (s *recordingReportingStore) GetCategoryMixReportData(
+	context.Context,
+	ReportingPeriodInput,
+) (CategoryMixReportData, error) {
+	return CategoryMixReportData{Currency: s.currency, Rows: s.categoryRows}, nil
+}
+
+func (s *recordingReportingStore) GetProfitAndLossReportData(
+	context.Context,
+	ReportingPeriodInput,
+) (ProfitAndLossReportData, error) {
+	data := s.profitAndLoss
+	data.Currency = s.currency
+	return data, nil
+}
+
 func mustReportingBusinessDate(t *testing.T, raw string) domain.BusinessDate {
 	t.Helper()
 	value, err := domain.ParseBusinessDate(raw)
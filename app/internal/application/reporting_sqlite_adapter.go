@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 
+	"github.com/jerobas/saas/internal/domain"
 	"github.com/jerobas/saas/internal/infrastructure/sqlite"
 )
 
@@ -52,13 +53,14 @@ func (s *sqliteReportingStore) GetSalesReportData(
 func (s *sqliteReportingStore) GetInventoryReportData(
 	ctx context.Context,
 	input ReportingPeriodInput,
+	location domain.Option[domain.StockLocationID],
 	rowLimit int,
 ) (InventoryReportData, error) {
 	data, err := s.store.GetInventoryReportData(ctx, sqlite.ReportingPeriodFilter{
 		FromOccurredOn: input.FromOccurredOn.String(),
 		ToOccurredOn:   input.ToOccurredOn.String(),
 		Granularity:    string(input.Granularity),
-	}, rowLimit)
+	}, location, rowLimit)
 	if err != nil {
 		return InventoryReportData{}, err
 	}
@@ -93,6 +95,11 @@ func (s *sqliteReportingStore) GetPurchaseReportData(
 		PurchaseSpendSeries:  mapReportingSeries(data.PurchaseSpendSeries),
 		TopSuppliersBySpend:  mapReportingCounterpartyMetrics(data.TopSuppliersBySpend),
 		FreeStockEntrySeries: mapReportingSeries(data.FreeStockEntrySeries),
+		Payables: PurchasePayableTotals{
+			PayableCount: data.Payables.PayableCount,
+			OwedMinor:    data.Payables.OwedMinor,
+			PaidMinor:    data.Payables.PaidMinor,
+		},
 	}, nil
 }
 
@@ -137,12 +144,59 @@ func (s *sqliteReportingStore) GetAdjustmentReportData(
 	}, nil
 }
 
+func (s *sqliteReportingStore) GetCategoryMixReportData(
+	ctx context.Context,
+	input ReportingPeriodInput,
+) (CategoryMixReportData, error) {
+	data, err := s.store.GetCategoryMixReportData(ctx, sqlite.ReportingPeriodFilter{
+		FromOccurredOn: input.FromOccurredOn.String(),
+		ToOccurredOn:   input.ToOccurredOn.String(),
+		Granularity:    string(input.Granularity),
+	})
+	if err != nil {
+		return CategoryMixReportData{}, err
+	}
+	rows := make([]CategoryMixRowData, 0, len(data.Rows))
+	for _, row := range data.Rows {
+		rows = append(rows, CategoryMixRowData{
+			CategoryID:           row.CategoryID,
+			CategoryName:         row.CategoryName,
+			QuantityAtomic:       row.QuantityAtomic,
+			CommercialTotalMinor: row.RevenueMinor,
+		})
+	}
+	return CategoryMixReportData{Currency: data.Currency, Rows: rows}, nil
+}
+
+func (s *sqliteReportingStore) GetProfitAndLossReportData(
+	ctx context.Context,
+	input ReportingPeriodInput,
+) (ProfitAndLossReportData, error) {
+	data, err := s.store.GetProfitAndLossReportData(ctx, sqlite.ReportingPeriodFilter{
+		FromOccurredOn: input.FromOccurredOn.String(),
+		ToOccurredOn:   input.ToOccurredOn.String(),
+		Granularity:    string(input.Granularity),
+	})
+	if err != nil {
+		return ProfitAndLossReportData{}, err
+	}
+	return ProfitAndLossReportData{
+		Currency:         data.Currency,
+		SalesSeries:      mapReportingSeries(data.SalesSeries),
+		DirectCostSeries: mapReportingSeries(data.DirectCostSeries),
+		WriteOffs:        mapReportingBucketReasonMetrics(data.WriteOffs),
+		Expenses:         mapReportingBucketReasonMetrics(data.Expenses),
+	}, nil
+}
+
 func mapSalesReportTotals(value sqlite.SalesReportTotals) SalesReportTotals {
 	return SalesReportTotals{
 		SalesCount:              value.SalesCount,
 		QuantityAtomic:          value.QuantityAtomic,
 		CommercialTotalMinor:    value.RevenueMinor,
 		COGSInventoryValueMicro: value.COGSMicro,
+		ReturnCount:             value.ReturnCount,
+		RefundMinor:             value.RefundMinor,
 	}
 }
 
@@ -238,6 +292,22 @@ func reportingReasonInventoryValueMicro(item sqlite.ReportingReasonMetric) int64
 	return item.COGSMicro
 }
 
+func mapReportingBucketReasonMetrics(items []sqlite.ReportingBucketReasonMetric) []ReportingBucketReasonMetric {
+	mapped := make([]ReportingBucketReasonMetric, 0, len(items))
+	for _, item := range items {
+		mapped = append(mapped, ReportingBucketReasonMetric{
+			Bucket:               item.Bucket,
+			Label:                item.Label,
+			ReasonCode:           item.ReasonCode,
+			DocumentCount:        item.DocumentCount,
+			QuantityAtomic:       item.QuantityAtomic,
+			CommercialTotalMinor: item.AmountMinor,
+			InventoryValueMicro:  item.InventoryValueMicro,
+		})
+	}
+	return mapped
+}
+
 func mapReportingCounterpartyMetrics(items []sqlite.ReportingCounterpartyMetric) []ReportingCounterpartyMetric {
 	mapped := make([]ReportingCounterpartyMetric, 0, len(items))
 	for _, item := range items {
@@ -0,0 +1,280 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type ReturnStore interface {
+	PostReturn(ctx context.Context, input returnPostStoreInput) (ReturnDocument, error)
+	GetReturn(ctx context.Context, id domain.StockDocumentID) (ReturnDocument, error)
+	ListSaleReturns(ctx context.Context, saleID domain.StockDocumentID) ([]ReturnDocument, error)
+}
+
+type ReturnPostInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	SaleDocumentID domain.StockDocumentID
+	OccurredOn     domain.BusinessDate
+	Notes          domain.Option[domain.NonEmptyText]
+	Lines          []ReturnLineInput
+}
+
+// ReturnLineInput returns part of one sale line. Without an explicit refund
+// the line refunds its proportional share of the sale line total.
+type ReturnLineInput struct {
+	SaleLineID domain.StockDocumentLineID
+	Quantity   domain.AtomicQuantity
+	Refund     domain.Option[domain.MinorAmount]
+}
+
+type returnPostStoreInput struct {
+	ReturnPostInput
+	PostedAt domain.UTCInstant
+}
+
+type ReturnDocument struct {
+	id              domain.StockDocumentID
+	idempotencyKey  domain.IdempotencyKey
+	postingSequence domain.PostingSequence
+	saleDocumentID  domain.StockDocumentID
+	counterpartyID  domain.Option[domain.CounterpartyID]
+	occurredOn      domain.BusinessDate
+	postedAt        domain.UTCInstant
+	currency        domain.Currency
+	reason          domain.DocumentReason
+	notes           domain.Option[domain.NonEmptyText]
+	lines           []PostedReturnLine
+}
+
+func NewReturnDocument(
+	id domain.StockDocumentID,
+	idempotencyKey domain.IdempotencyKey,
+	postingSequence domain.PostingSequence,
+	saleDocumentID domain.StockDocumentID,
+	counterpartyID domain.Option[domain.CounterpartyID],
+	occurredOn domain.BusinessDate,
+	postedAt domain.UTCInstant,
+	currency domain.Currency,
+	reason domain.DocumentReason,
+	notes domain.Option[domain.NonEmptyText],
+	lines []PostedReturnLine,
+) (ReturnDocument, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if id.IsZero() {
+		violations = append(violations, domain.Violation{Field: "document_id", Code: domain.ViolationRequired})
+	}
+	if idempotencyKey.String() == "" {
+		violations = append(violations, domain.Violation{Field: "idempotency_key", Code: domain.ViolationRequired})
+	}
+	if postingSequence.IsZero() {
+		violations = append(violations, domain.Violation{Field: "posting_sequence", Code: domain.ViolationRequired})
+	}
+	if saleDocumentID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "sale_document_id", Code: domain.ViolationRequired})
+	}
+	if occurredOn.IsZero() {
+		violations = append(violations, domain.Violation{Field: "occurred_on", Code: domain.ViolationRequired})
+	}
+	if postedAt.IsZero() {
+		violations = append(violations, domain.Violation{Field: "posted_at", Code: domain.ViolationRequired})
+	}
+	if currency.IsZero() {
+		violations = append(violations, domain.Violation{Field: "currency", Code: domain.ViolationRequired})
+	}
+	if len(lines) == 0 {
+		violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationRequired})
+	}
+	if reason != domain.ReasonCustomerReturn {
+		violations = append(violations, domain.Violation{Field: "reason", Code: domain.ViolationInvalidEnum})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return ReturnDocument{}, err
+	}
+	cloned := make([]PostedReturnLine, len(lines))
+	copy(cloned, lines)
+	return ReturnDocument{
+		id: id, idempotencyKey: idempotencyKey, postingSequence: postingSequence,
+		saleDocumentID: saleDocumentID, counterpartyID: counterpartyID, occurredOn: occurredOn,
+		postedAt: postedAt, currency: currency, reason: reason, notes: notes, lines: cloned,
+	}, nil
+}
+
+func (d ReturnDocument) ID() domain.StockDocumentID              { return d.id }
+func (d ReturnDocument) IdempotencyKey() domain.IdempotencyKey   { return d.idempotencyKey }
+func (d ReturnDocument) PostingSequence() domain.PostingSequence { return d.postingSequence }
+func (d ReturnDocument) SaleDocumentID() domain.StockDocumentID  { return d.saleDocumentID }
+func (d ReturnDocument) CounterpartyID() domain.Option[domain.CounterpartyID] {
+	return d.counterpartyID
+}
+func (d ReturnDocument) OccurredOn() domain.BusinessDate           { return d.occurredOn }
+func (d ReturnDocument) PostedAt() domain.UTCInstant               { return d.postedAt }
+func (d ReturnDocument) Currency() domain.Currency                 { return d.currency }
+func (d ReturnDocument) Reason() domain.DocumentReason             { return d.reason }
+func (d ReturnDocument) Notes() domain.Option[domain.NonEmptyText] { return d.notes }
+func (d ReturnDocument) Lines() []PostedReturnLine {
+	lines := make([]PostedReturnLine, len(d.lines))
+	copy(lines, d.lines)
+	return lines
+}
+
+type PostedReturnLine struct {
+	id                   domain.StockDocumentLineID
+	lineOrder            domain.LineOrder
+	itemID               domain.ItemID
+	quantity             domain.AtomicQuantity
+	enteredUnit          domain.UnitCode
+	enteredPackagingName domain.Option[domain.NonEmptyText]
+	conversion           domain.UnitConversion
+	inventoryValue       domain.InventoryValue
+	refund               domain.MinorAmount
+	returnsLineID        domain.StockDocumentLineID
+	allocations          []ReturnAllocation
+}
+
+func NewPostedReturnLine(
+	id domain.StockDocumentLineID,
+	lineOrder domain.LineOrder,
+	itemID domain.ItemID,
+	quantity domain.AtomicQuantity,
+	enteredUnit domain.UnitCode,
+	enteredPackagingName domain.Option[domain.NonEmptyText],
+	conversion domain.UnitConversion,
+	inventoryValue domain.InventoryValue,
+	refund domain.MinorAmount,
+	returnsLineID domain.StockDocumentLineID,
+	allocations []ReturnAllocation,
+) (PostedReturnLine, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if id.IsZero() {
+		violations = append(violations, domain.Violation{Field: "line_id", Code: domain.ViolationRequired})
+	}
+	if lineOrder.IsZero() {
+		violations = append(violations, domain.Violation{Field: "line_order", Code: domain.ViolationRequired})
+	}
+	if itemID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "item_id", Code: domain.ViolationRequired})
+	}
+	if quantity.Int64() <= 0 {
+		violations = append(violations, domain.Violation{Field: "quantity_atomic", Code: domain.ViolationNotPositive})
+	}
+	if enteredUnit.String() == "" {
+		violations = append(violations, domain.Violation{Field: "entered_unit_code", Code: domain.ViolationRequired})
+	}
+	if conversion.IsZero() {
+		violations = append(violations, domain.Violation{Field: "conversion", Code: domain.ViolationRequired})
+	}
+	if returnsLineID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "returns_line_id", Code: domain.ViolationRequired})
+	}
+	if len(allocations) == 0 {
+		violations = append(violations, domain.Violation{Field: "allocations", Code: domain.ViolationRequired})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return PostedReturnLine{}, err
+	}
+	cloned := make([]ReturnAllocation, len(allocations))
+	copy(cloned, allocations)
+	return PostedReturnLine{
+		id: id, lineOrder: lineOrder, itemID: itemID, quantity: quantity,
+		enteredUnit: enteredUnit, enteredPackagingName: enteredPackagingName,
+		conversion: conversion, inventoryValue: inventoryValue, refund: refund,
+		returnsLineID: returnsLineID, allocations: cloned,
+	}, nil
+}
+
+func (l PostedReturnLine) ID() domain.StockDocumentLineID  { return l.id }
+func (l PostedReturnLine) LineOrder() domain.LineOrder     { return l.lineOrder }
+func (l PostedReturnLine) ItemID() domain.ItemID           { return l.itemID }
+func (l PostedReturnLine) Quantity() domain.AtomicQuantity { return l.quantity }
+func (l PostedReturnLine) EnteredUnit() domain.UnitCode    { return l.enteredUnit }
+func (l PostedReturnLine) EnteredPackagingName() domain.Option[domain.NonEmptyText] {
+	return l.enteredPackagingName
+}
+func (l PostedReturnLine) Conversion() domain.UnitConversion         { return l.conversion }
+func (l PostedReturnLine) InventoryValue() domain.InventoryValue     { return l.inventoryValue }
+func (l PostedReturnLine) Refund() domain.MinorAmount                { return l.refund }
+func (l PostedReturnLine) ReturnsLineID() domain.StockDocumentLineID { return l.returnsLineID }
+func (l PostedReturnLine) Allocations() []ReturnAllocation {
+	allocations := make([]ReturnAllocation, len(l.allocations))
+	copy(allocations, l.allocations)
+	return allocations
+}
+
+type ReturnAllocation struct {
+	id                   domain.LotAllocationID
+	lotID                domain.InventoryLotID
+	quantity             domain.AtomicQuantity
+	restoresAllocationID domain.LotAllocationID
+}
+
+func NewReturnAllocation(
+	id domain.LotAllocationID,
+	lotID domain.InventoryLotID,
+	quantity domain.AtomicQuantity,
+	restoresAllocationID domain.LotAllocationID,
+) (ReturnAllocation, error) {
+	if id.IsZero() || lotID.IsZero() || quantity.Int64() <= 0 || restoresAllocationID.IsZero() {
+		return ReturnAllocation{}, domain.ErrInvariant
+	}
+	return ReturnAllocation{
+		id: id, lotID: lotID, quantity: quantity, restoresAllocationID: restoresAllocationID,
+	}, nil
+}
+
+func (a ReturnAllocation) ID() domain.LotAllocationID      { return a.id }
+func (a ReturnAllocation) LotID() domain.InventoryLotID    { return a.lotID }
+func (a ReturnAllocation) Quantity() domain.AtomicQuantity { return a.quantity }
+func (a ReturnAllocation) RestoresAllocationID() domain.LotAllocationID {
+	return a.restoresAllocationID
+}
+
+type ReturnService struct {
+	store ReturnStore
+	clock Clock
+}
+
+func NewReturnService(store ReturnStore, clock Clock) *ReturnService {
+	if store == nil {
+		panic("return service requires a store")
+	}
+	if clock == nil {
+		panic("return service requires a clock")
+	}
+	return &ReturnService{store: store, clock: clock}
+}
+
+func (s *ReturnService) PostReturn(ctx context.Context, input ReturnPostInput) (ReturnDocument, error) {
+	postedAt, err := s.clock.Now()
+	if err != nil {
+		return ReturnDocument{}, fmt.Errorf("read clock: %w", err)
+	}
+	document, err := s.store.PostReturn(ctx, returnPostStoreInput{
+		ReturnPostInput: input,
+		PostedAt:        postedAt,
+	})
+	if err != nil {
+		return ReturnDocument{}, fmt.Errorf("post return: %w", err)
+	}
+	if err := ensurePostingClockCompatible(document.PostedAt(), postedAt); err != nil {
+		return ReturnDocument{}, err
+	}
+	return document, nil
+}
+
+func (s *ReturnService) GetReturn(ctx context.Context, id domain.StockDocumentID) (ReturnDocument, error) {
+	document, err := s.store.GetReturn(ctx, id)
+	if err != nil {
+		return ReturnDocument{}, fmt.Errorf("get return: %w", err)
+	}
+	return document, nil
+}
+
+func (s *ReturnService) ListSaleReturns(ctx context.Context, saleID domain.StockDocumentID) ([]ReturnDocument, error) {
+	documents, err := s.store.ListSaleReturns(ctx, saleID)
+	if err != nil {
+		return nil, fmt.Errorf("list sale returns: %w", err)
+	}
+	return documents, nil
+}
@@ -0,0 +1,124 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteReturnStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteReturnStore(store *sqlite.Store) ReturnStore {
+	if store == nil {
+		panic("sqlite return store requires a store")
+	}
+	return &sqliteReturnStore{store: store}
+}
+
+func (s *sqliteReturnStore) PostReturn(ctx context.Context, input returnPostStoreInput) (ReturnDocument, error) {
+	lines := make([]sqlite.PostReturnLineInput, 0, len(input.Lines))
+	for _, line := range input.Lines {
+		lines = append(lines, sqlite.PostReturnLineInput{
+			SaleLineID: line.SaleLineID,
+			Quantity:   line.Quantity,
+			Refund:     line.Refund,
+		})
+	}
+	posted, err := s.store.PostReturn(ctx, sqlite.PostReturnInput{
+		IdempotencyKey: input.IdempotencyKey,
+		SaleDocumentID: input.SaleDocumentID,
+		OccurredOn:     input.OccurredOn,
+		PostedAt:       input.PostedAt,
+		Notes:          input.Notes,
+		Lines:          lines,
+	})
+	if err != nil {
+		return ReturnDocument{}, err
+	}
+	return mapSQLitePostedReturn(posted)
+}
+
+func (s *sqliteReturnStore) GetReturn(ctx context.Context, id domain.StockDocumentID) (ReturnDocument, error) {
+	posted, err := s.store.GetPostedReturn(ctx, id)
+	if err != nil {
+		return ReturnDocument{}, err
+	}
+	return mapSQLitePostedReturn(posted)
+}
+
+func (s *sqliteReturnStore) ListSaleReturns(ctx context.Context, saleID domain.StockDocumentID) ([]ReturnDocument, error) {
+	posted, err := s.store.ListPostedReturnsForSale(ctx, saleID)
+	if err != nil {
+		return nil, err
+	}
+	documents := make([]ReturnDocument, 0, len(posted))
+	for _, document := range posted {
+		mapped, err := mapSQLitePostedReturn(document)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, mapped)
+	}
+	return documents, nil
+}
+
+func mapSQLitePostedReturn(posted sqlite.PostedReturnDocument) (ReturnDocument, error) {
+	sourceLines := posted.Lines()
+	lines := make([]PostedReturnLine, 0, len(sourceLines))
+	for _, line := range sourceLines {
+		allocations, err := mapSQLiteReturnAllocations(line.Allocations())
+		if err != nil {
+			return ReturnDocument{}, err
+		}
+		mapped, err := NewPostedReturnLine(
+			line.ID(),
+			line.LineOrder(),
+			line.ItemID(),
+			line.Quantity(),
+			line.EnteredUnit(),
+			line.EnteredPackagingName(),
+			line.Conversion(),
+			line.InventoryValue(),
+			line.Refund(),
+			line.ReturnsLineID(),
+			allocations,
+		)
+		if err != nil {
+			return ReturnDocument{}, err
+		}
+		lines = append(lines, mapped)
+	}
+	return NewReturnDocument(
+		posted.ID(),
+		posted.IdempotencyKey(),
+		posted.PostingSequence(),
+		posted.SaleDocumentID(),
+		posted.CounterpartyID(),
+		posted.OccurredOn(),
+		posted.PostedAt(),
+		posted.Currency(),
+		domain.ReasonCustomerReturn,
+		posted.Notes(),
+		lines,
+	)
+}
+
+func mapSQLiteReturnAllocations(source []sqlite.ReturnAllocation) ([]ReturnAllocation, error) {
+	allocations := make([]ReturnAllocation, 0, len(source))
+	for _, allocation := range source {
+		mapped, err := NewReturnAllocation(
+			allocation.ID(),
+			allocation.LotID(),
+			allocation.Quantity(),
+			allocation.RestoresAllocationID(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, mapped)
+	}
+	return allocations, nil
+}
@@ -5,12 +5,14 @@ import (
 	"fmt"
 
 	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
 )
 
 type SaleStore interface {
 	GetSale(ctx context.Context, id domain.StockDocumentID) (SaleDocument, error)
 	ListSales(ctx context.Context, input SaleListInput) (SalePage, error)
 	PostSale(ctx context.Context, input salePostStoreInput) (SaleDocument, error)
+	GetSaleQuoteData(ctx context.Context, customerID domain.Option[domain.CounterpartyID], itemIDs []domain.ItemID) (SaleQuoteData, error)
 }
 
 type SaleCursor struct {
@@ -61,6 +63,49 @@ type SalePostInput struct {
 	Lines          []SaleLineInput
 }
 
+// SaleQuoteInput asks for suggested totals of sale lines before posting. Each
+// line's CommercialTotal and LotID are ignored.
+type SaleQuoteInput struct {
+	CounterpartyID domain.Option[domain.CounterpartyID]
+	OccurredOn     domain.BusinessDate
+	Lines          []SaleLineInput
+}
+
+// SaleQuoteData holds the price list assigned to the customer, whatever its
+// validity, and each quoted item once.
+type SaleQuoteData struct {
+	PriceList domain.Option[sales.PriceList]
+	Items     []SaleQuoteItem
+}
+
+// SaleQuoteItem carries the conversion of one base unit and the item's default
+// price for it.
+type SaleQuoteItem struct {
+	ItemID           domain.ItemID
+	BaseUnit         domain.UnitConversion
+	DefaultSalePrice domain.Option[domain.MinorAmount]
+}
+
+type SalePriceSource string
+
+const (
+	SalePriceFromPriceList SalePriceSource = "PRICE_LIST"
+	SalePriceFromDefault   SalePriceSource = "DEFAULT_PRICE"
+	SalePriceUnavailable   SalePriceSource = "NONE"
+)
+
+// SaleLineQuote is the suggested total of one sale line. PackagingID names the
+// priced packaging and is None for a base-unit price. UnitPrice and
+// CommercialTotal are None when Source is SalePriceUnavailable.
+type SaleLineQuote struct {
+	Source          SalePriceSource
+	PriceListID     domain.Option[domain.PriceListID]
+	PackagingID     domain.Option[domain.PackagingID]
+	MinQuantity     domain.AtomicQuantity
+	UnitPrice       domain.Option[domain.MinorAmount]
+	CommercialTotal domain.Option[domain.MinorAmount]
+}
+
 type salePostStoreInput struct {
 	SalePostInput
 	PostedAt domain.UTCInstant
@@ -284,3 +329,97 @@ func (s *SaleService) PostSale(ctx context.Context, input SalePostInput) (SaleDo
 	}
 	return document, nil
 }
+
+// QuoteSale suggests each line's commercial total. The customer's price list
+// applies when it is valid on OccurredOn and prices the item; otherwise the
+// item's default sale price per base unit does. Quoting writes nothing, and
+// posting keeps whatever totals the caller sends, so a later change to a list
+// or a default price never alters a posted sale.
+func (s *SaleService) QuoteSale(ctx context.Context, input SaleQuoteInput) ([]SaleLineQuote, error) {
+	if len(input.Lines) == 0 {
+		return nil, domain.Invalid("lines", domain.ViolationRequired, "DOC-002")
+	}
+	if input.OccurredOn.IsZero() {
+		return nil, domain.Invalid("occurred_on", domain.ViolationRequired, "PRL-004")
+	}
+	itemIDs := make([]domain.ItemID, 0, len(input.Lines))
+	seen := make(map[domain.ItemID]struct{}, len(input.Lines))
+	for _, line := range input.Lines {
+		if line.ItemID.IsZero() {
+			return nil, domain.Invalid("item_id", domain.ViolationRequired, "")
+		}
+		if line.Quantity.Int64() <= 0 {
+			return nil, domain.Invalid("quantity_atomic", domain.ViolationNotPositive, "")
+		}
+		if _, ok := seen[line.ItemID]; !ok {
+			seen[line.ItemID] = struct{}{}
+			itemIDs = append(itemIDs, line.ItemID)
+		}
+	}
+	data, err := s.store.GetSaleQuoteData(ctx, input.CounterpartyID, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("quote sale: %w", err)
+	}
+	items := make(map[domain.ItemID]SaleQuoteItem, len(data.Items))
+	for _, item := range data.Items {
+		items[item.ItemID] = item
+	}
+	list, hasList := data.PriceList.Get()
+	hasList = hasList && list.IsValidOn(input.OccurredOn)
+	quotes := make([]SaleLineQuote, 0, len(input.Lines))
+	for _, line := range input.Lines {
+		item, ok := items[line.ItemID]
+		if !ok {
+			return nil, domain.ErrInvariant
+		}
+		quote, err := quoteSaleLine(list, hasList, item, line)
+		if err != nil {
+			return nil, fmt.Errorf("quote sale: %w", err)
+		}
+		quotes = append(quotes, quote)
+	}
+	return quotes, nil
+}
+
+func quoteSaleLine(list sales.PriceList, hasList bool, item SaleQuoteItem, line SaleLineInput) (SaleLineQuote, error) {
+	quote := SaleLineQuote{
+		Source:          SalePriceUnavailable,
+		PriceListID:     domain.None[domain.PriceListID](),
+		PackagingID:     domain.None[domain.PackagingID](),
+		UnitPrice:       domain.None[domain.MinorAmount](),
+		CommercialTotal: domain.None[domain.MinorAmount](),
+	}
+	price := domain.None[sales.PriceListPrice]()
+	if hasList {
+		price = list.PriceFor(line.ItemID, line.EnteredPackagingName, line.Quantity)
+	}
+	applied, ok := price.Get()
+	if ok {
+		quote.Source = SalePriceFromPriceList
+		quote.PriceListID = domain.Some(list.ID())
+	} else {
+		defaultPrice, ok := item.DefaultSalePrice.Get()
+		if !ok {
+			return quote, nil
+		}
+		var err error
+		applied, err = sales.NewPriceListPrice(sales.PriceListPriceParams{
+			ItemID:     item.ItemID,
+			PricedUnit: item.BaseUnit,
+			UnitPrice:  defaultPrice,
+		})
+		if err != nil {
+			return SaleLineQuote{}, err
+		}
+		quote.Source = SalePriceFromDefault
+	}
+	total, err := applied.Total(line.Quantity)
+	if err != nil {
+		return SaleLineQuote{}, err
+	}
+	quote.PackagingID = applied.PackagingID()
+	quote.MinQuantity = applied.MinQuantity()
+	quote.UnitPrice = domain.Some(applied.UnitPrice())
+	quote.CommercialTotal = domain.Some(total)
+	return quote, nil
+}
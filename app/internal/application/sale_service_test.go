@@ -0,0 +1,106 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+type memorySaleQuoteStore struct {
+	SaleStore
+	data     SaleQuoteData
+	customer domain.Option[domain.CounterpartyID]
+	itemIDs  []domain.ItemID
+}
+
+func (s *memorySaleQuoteStore) GetSaleQuoteData(
+	_ context.Context,
+	customerID domain.Option[domain.CounterpartyID],
+	itemIDs []domain.ItemID,
+) (SaleQuoteData, error) {
+	s.customer = customerID
+	s.itemIDs = append([]domain.ItemID(nil), itemIDs...)
+	return s.data, nil
+}
+
+func TestSaleServiceQuotesListPricesWithinTheirWindowAndFallsBackToDefaults(t *testing.T) {
+	grams := must(domain.NewUnitConversion(1_000, 1))
+	cake := must(domain.NewItemID(1))
+	bread := must(domain.NewItemID(2))
+	sample := must(domain.NewItemID(3))
+	tiered := func(minQuantity, unitPrice int64) sales.PriceListPrice {
+		return must(sales.NewPriceListPrice(sales.PriceListPriceParams{
+			ItemID:      cake,
+			PricedUnit:  grams,
+			MinQuantity: must(domain.NewAtomicQuantity(minQuantity)),
+			UnitPrice:   must(domain.NewMinorAmount(unitPrice)),
+		}))
+	}
+	list := must(sales.NewPriceList(sales.PriceListParams{
+		ID:        must(domain.NewPriceListID(7)),
+		Name:      must(domain.NewUniqueName("Cafés")),
+		ValidFrom: domain.Some(must(domain.ParseBusinessDate("2026-10-01"))),
+		Prices:    []sales.PriceListPrice{tiered(0, 6), tiered(1_000_000, 5)},
+		CreatedAt: mustInstant(1_000),
+		UpdatedAt: mustInstant(1_000),
+	}))
+	store := &memorySaleQuoteStore{data: SaleQuoteData{
+		PriceList: domain.Some(list),
+		Items: []SaleQuoteItem{
+			{ItemID: cake, BaseUnit: grams, DefaultSalePrice: domain.Some(must(domain.NewMinorAmount(8)))},
+			{ItemID: bread, BaseUnit: grams, DefaultSalePrice: domain.Some(must(domain.NewMinorAmount(3)))},
+			{ItemID: sample, BaseUnit: grams, DefaultSalePrice: domain.None[domain.MinorAmount]()},
+		},
+	}}
+	service := NewSaleService(store, &mutableClock{now: mustInstant(2_000)})
+	line := func(itemID domain.ItemID, quantity int64) SaleLineInput {
+		return SaleLineInput{
+			ItemID:      itemID,
+			Quantity:    must(domain.NewPositiveAtomicQuantity(quantity)),
+			EnteredUnit: must(domain.NewUnitCode("g")),
+			Conversion:  grams,
+		}
+	}
+	customer := domain.Some(must(domain.NewCounterpartyID(4)))
+	input := SaleQuoteInput{
+		CounterpartyID: customer,
+		OccurredOn:     must(domain.ParseBusinessDate("2026-10-18")),
+		Lines:          []SaleLineInput{line(cake, 1_500_000), line(cake, 200_000), line(bread, 500_000), line(sample, 1_000)},
+	}
+	quotes, err := service.QuoteSale(context.Background(), input)
+	if err != nil {
+		t.Fatalf("quote sale: %v", err)
+	}
+	if len(store.itemIDs) != 3 || store.customer != customer {
+		t.Fatalf("quote data request = %v for %v", store.itemIDs, store.customer)
+	}
+	want := []struct {
+		source SalePriceSource
+		total  int64
+	}{
+		{SalePriceFromPriceList, 7_500},
+		{SalePriceFromPriceList, 1_200},
+		{SalePriceFromDefault, 1_500},
+		{SalePriceUnavailable, -1},
+	}
+	for index, quote := range quotes {
+		total, ok := quote.CommercialTotal.Get()
+		if quote.Source != want[index].source || (ok && total.Int64() != want[index].total) || ok != (want[index].total >= 0) {
+			t.Fatalf("quote %d = %#v, want %v %d", index+1, quote, want[index].source, want[index].total)
+		}
+	}
+	if listID, ok := quotes[0].PriceListID.Get(); !ok || listID != list.ID() || quotes[0].MinQuantity.Int64() != 1_000_000 {
+		t.Fatalf("tiered quote = %#v", quotes[0])
+	}
+
+	input.OccurredOn = must(domain.ParseBusinessDate("2026-09-30"))
+	quotes, err = service.QuoteSale(context.Background(), input)
+	if err != nil {
+		t.Fatalf("quote sale before the list starts: %v", err)
+	}
+	if quotes[0].Source != SalePriceFromDefault || quotes[0].PriceListID.IsSome() {
+		t.Fatalf("quote before the list starts = %#v", quotes[0])
+	}
+}
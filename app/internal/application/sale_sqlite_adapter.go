@@ -61,6 +61,34 @@ func (s *sqliteSaleStore) ListSales(ctx context.Context, input SaleListInput) (S
 }
 
 func (s *sqliteSaleStore) PostSale(ctx context.Context, input salePostStoreInput) (SaleDocument, error) {
+	posted, err := s.store.PostSale(ctx, mapSQLitePostSaleInput(input))
+	if err != nil {
+		return SaleDocument{}, err
+	}
+	return mapSQLitePostedSale(posted)
+}
+
+func (s *sqliteSaleStore) GetSaleQuoteData(
+	ctx context.Context,
+	customerID domain.Option[domain.CounterpartyID],
+	itemIDs []domain.ItemID,
+) (SaleQuoteData, error) {
+	data, err := s.store.GetSaleQuoteData(ctx, customerID, itemIDs)
+	if err != nil {
+		return SaleQuoteData{}, err
+	}
+	items := make([]SaleQuoteItem, 0, len(data.Items))
+	for _, item := range data.Items {
+		items = append(items, SaleQuoteItem{
+			ItemID:           item.ItemID,
+			BaseUnit:         item.BaseUnit,
+			DefaultSalePrice: item.DefaultSalePrice,
+		})
+	}
+	return SaleQuoteData{PriceList: data.PriceList, Items: items}, nil
+}
+
+func mapSQLitePostSaleInput(input salePostStoreInput) sqlite.PostSaleInput {
 	lines := make([]sqlite.PostSaleLineInput, 0, len(input.Lines))
 	for _, line := range input.Lines {
 		lines = append(lines, sqlite.PostSaleLineInput{
@@ -73,7 +101,7 @@ func (s *sqliteSaleStore) PostSale(ctx context.Context, input salePostStoreInput
 			LotID:                line.LotID,
 		})
 	}
-	posted, err := s.store.PostSale(ctx, sqlite.PostSaleInput{
+	return sqlite.PostSaleInput{
 		IdempotencyKey: input.IdempotencyKey,
 		CounterpartyID: input.CounterpartyID,
 		OccurredOn:     input.OccurredOn,
@@ -81,11 +109,7 @@ func (s *sqliteSaleStore) PostSale(ctx context.Context, input salePostStoreInput
 		Reason:         input.Reason,
 		Notes:          input.Notes,
 		Lines:          lines,
-	})
-	if err != nil {
-		return SaleDocument{}, err
 	}
-	return mapSQLitePostedSale(posted)
 }
 
 func mapSQLitePostedSale(posted sqlite.PostedSaleDocument) (SaleDocument, error) {
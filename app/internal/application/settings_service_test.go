@@ -52,6 +52,7 @@ func TestSettingsServiceReadsAndUpdatesSettings(t *testing.T) {
 		Currency:           currency,
 		HourlyLaborCost:    domain.None[domain.MinorAmount](),
 		DefaultGrossMargin: domain.None[domain.BasisPoints](),
+		Backup:             current.Backup(),
 		ExpectedUpdatedAt:  current.UpdatedAt(),
 	})
 	if err != nil {
@@ -87,6 +88,7 @@ func TestSettingsServiceAdvancesSameMillisecondClock(t *testing.T) {
 		Currency:           current.Currency(),
 		HourlyLaborCost:    current.HourlyLaborCost(),
 		DefaultGrossMargin: current.DefaultGrossMargin(),
+		Backup:             current.Backup(),
 		ExpectedUpdatedAt:  current.UpdatedAt(),
 	})
 	if err != nil {
@@ -30,6 +30,7 @@ func (s *sqliteSettingsStore) UpdateSettings(ctx context.Context, input settings
 		Currency:           input.Currency,
 		HourlyLaborCost:    input.HourlyLaborCost,
 		DefaultGrossMargin: input.DefaultGrossMargin,
+		Backup:             input.Backup,
 		ExpectedUpdatedAt:  input.ExpectedUpdatedAt,
 		UpdatedAt:          input.UpdatedAt,
 	})
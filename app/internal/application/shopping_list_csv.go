@@ -0,0 +1,103 @@
+package application
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+var shoppingListCSVHeader = []string{
+	"supplier_id", "supplier", "item_id", "item", "base_unit",
+	"required", "available", "reorder_buffer", "to_buy",
+	"packaging", "packaging_unit", "packages",
+}
+
+// WriteCSV writes one row per line in group order. Quantities are in the
+// item's base unit as exact decimals; packaging columns are empty for items
+// without a last-purchase packaging, and supplier columns for items never
+// bought from a supplier.
+func (l ShoppingList) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(shoppingListCSVHeader); err != nil {
+		return err
+	}
+	for _, group := range l.groups {
+		supplierID, supplierName := "", ""
+		if supplier, ok := group.supplier.Get(); ok {
+			supplierID, supplierName = supplier.ID.String(), supplier.Name.String()
+		}
+		for _, line := range group.lines {
+			record := []string{supplierID, supplierName, line.ItemID().String(), line.ItemName().Display(), line.BaseUnit().String()}
+			for _, quantity := range []domain.AtomicQuantity{line.required, line.available, line.reorderBuffer, line.toBuy} {
+				value, err := line.BaseConversion().FromAtomic(quantity)
+				if err != nil {
+					return err
+				}
+				formatted, err := formatExactDecimal(value)
+				if err != nil {
+					return err
+				}
+				record = append(record, formatted)
+			}
+			if packaging, ok := line.Packaging().Get(); ok {
+				record = append(record, packaging.Name.Display(), packaging.EnteredUnit.String(), strconv.FormatInt(line.packages, 10))
+			} else {
+				record = append(record, "", "", "")
+			}
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formatExactDecimal writes a fraction as a plain dot decimal when its
+// denominator only has the factors two and five, and as numerator/denominator
+// otherwise, so no digits are lost.
+func formatExactDecimal(value domain.Fraction) (string, error) {
+	denominator, places := value.Denominator(), 0
+	for denominator%2 == 0 || denominator%5 == 0 {
+		switch {
+		case denominator%10 == 0:
+			denominator /= 10
+		case denominator%2 == 0:
+			denominator /= 2
+		default:
+			denominator /= 5
+		}
+		places++
+	}
+	if denominator != 1 || places > 18 {
+		return value.String(), nil
+	}
+	scale := int64(1)
+	for range places {
+		scale *= 10
+	}
+	factor, err := domain.NewFraction(scale, 1)
+	if err != nil {
+		return "", err
+	}
+	scaled, err := value.Multiply(factor)
+	if err != nil {
+		return "", err
+	}
+	digits, err := scaled.Int64Exact()
+	if err != nil {
+		return "", err
+	}
+	if places == 0 {
+		return strconv.FormatInt(digits, 10), nil
+	}
+	text := strconv.FormatInt(digits, 10)
+	if len(text) <= places {
+		text = strings.Repeat("0", places-len(text)+1) + text
+	}
+	text = text[:len(text)-places] + "." + text[len(text)-places:]
+	return strings.TrimRight(strings.TrimRight(text, "0"), "."), nil
+}
@@ -0,0 +1,303 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
+)
+
+type ShoppingListStore interface {
+	GetRecipe(ctx context.Context, id domain.RecipeID) (recipedomain.Recipe, error)
+	GetRecipeRevision(ctx context.Context, id domain.RecipeRevisionID) (recipedomain.Revision, error)
+	ListShoppingItemSources(ctx context.Context, itemIDs []domain.ItemID) ([]ShoppingItemSource, error)
+	ListEligibleFEFOLots(ctx context.Context, itemID domain.ItemID, on domain.BusinessDate, location domain.Option[domain.StockLocationID]) ([]inventory.LotView, error)
+}
+
+// ShoppingItemSource is an item's base unit, reorder buffer, and the supplier
+// and packaging of its latest purchase that was not reversed.
+type ShoppingItemSource struct {
+	ItemID          domain.ItemID
+	ItemName        domain.UniqueName
+	BaseUnit        domain.UnitCode
+	BaseConversion  domain.UnitConversion
+	Purchasable     bool
+	ReorderQuantity domain.Option[domain.AtomicQuantity]
+	LastSupplier    domain.Option[ShoppingSupplier]
+	Packaging       domain.Option[ShoppingPackaging]
+}
+
+type ShoppingSupplier struct {
+	ID   domain.CounterpartyID
+	Name domain.DisplayName
+}
+
+// ShoppingPackaging is the active packaging named on the latest purchase.
+type ShoppingPackaging struct {
+	Name        domain.UniqueName
+	EnteredUnit domain.UnitCode
+	Conversion  domain.UnitConversion
+}
+
+// ShoppingRunInput is one planned production of Quantity atomic units of the
+// revision's output.
+type ShoppingRunInput struct {
+	RevisionID domain.RecipeRevisionID
+	Quantity   domain.AtomicQuantity
+}
+
+// ShoppingListInput nets the planned runs against stock usable on On.
+type ShoppingListInput struct {
+	On   domain.BusinessDate
+	Runs []ShoppingRunInput
+}
+
+// ShoppingListLine is one purchasable item to buy. Required is what the runs
+// consume after planned outputs of the same item; ToBuy is Required less the
+// available quantity above the reorder buffer.
+type ShoppingListLine struct {
+	source        ShoppingItemSource
+	required      domain.AtomicQuantity
+	available     domain.AtomicQuantity
+	reorderBuffer domain.AtomicQuantity
+	toBuy         domain.AtomicQuantity
+	packages      int64
+}
+
+func (l ShoppingListLine) ItemID() domain.ItemID                 { return l.source.ItemID }
+func (l ShoppingListLine) ItemName() domain.UniqueName           { return l.source.ItemName }
+func (l ShoppingListLine) BaseUnit() domain.UnitCode             { return l.source.BaseUnit }
+func (l ShoppingListLine) BaseConversion() domain.UnitConversion { return l.source.BaseConversion }
+func (l ShoppingListLine) Required() domain.AtomicQuantity       { return l.required }
+func (l ShoppingListLine) Available() domain.AtomicQuantity      { return l.available }
+func (l ShoppingListLine) ReorderBuffer() domain.AtomicQuantity  { return l.reorderBuffer }
+func (l ShoppingListLine) ToBuy() domain.AtomicQuantity          { return l.toBuy }
+func (l ShoppingListLine) Packaging() domain.Option[ShoppingPackaging] {
+	return l.source.Packaging
+}
+
+// Packages is ToBuy in whole packagings, rounded up. It is zero without a
+// packaging.
+func (l ShoppingListLine) Packages() int64 { return l.packages }
+
+// ShoppingListGroup holds the lines last bought from one supplier, or the
+// lines never bought from a supplier when Supplier is none.
+type ShoppingListGroup struct {
+	supplier domain.Option[ShoppingSupplier]
+	lines    []ShoppingListLine
+}
+
+func (g ShoppingListGroup) Supplier() domain.Option[ShoppingSupplier] { return g.supplier }
+func (g ShoppingListGroup) Lines() []ShoppingListLine {
+	lines := make([]ShoppingListLine, len(g.lines))
+	copy(lines, g.lines)
+	return lines
+}
+
+type ShoppingList struct {
+	on     domain.BusinessDate
+	groups []ShoppingListGroup
+}
+
+func (l ShoppingList) On() domain.BusinessDate { return l.on }
+func (l ShoppingList) Groups() []ShoppingListGroup {
+	groups := make([]ShoppingListGroup, len(l.groups))
+	copy(groups, l.groups)
+	return groups
+}
+
+// ShoppingListService turns planned production runs into purchases. It only
+// reads recipes, stock, and purchase history.
+type ShoppingListService struct {
+	store ShoppingListStore
+}
+
+func NewShoppingListService(store ShoppingListStore) *ShoppingListService {
+	if store == nil {
+		panic("shopping list service requires a store")
+	}
+	return &ShoppingListService{store: store}
+}
+
+// BuildShoppingList scales each run's components exactly, rounding up to
+// whole atomic units, and totals them per item. Planned outputs cover the
+// same item's requirement first. Each purchasable item is then netted against
+// the available quantity of its non-expired FEFO lots above the reorder
+// buffer, and lines are grouped by the supplier of the latest purchase.
+func (s *ShoppingListService) BuildShoppingList(ctx context.Context, input ShoppingListInput) (ShoppingList, error) {
+	if input.On.IsZero() {
+		return ShoppingList{}, domain.Invalid("business_date", domain.ViolationRequired, "")
+	}
+	if len(input.Runs) == 0 {
+		return ShoppingList{}, domain.Invalid("runs", domain.ViolationRequired, "SHP-001")
+	}
+	required, order, err := s.requirements(ctx, input.Runs)
+	if err != nil {
+		return ShoppingList{}, fmt.Errorf("build shopping list: %w", err)
+	}
+	sources, err := s.store.ListShoppingItemSources(ctx, order)
+	if err != nil {
+		return ShoppingList{}, fmt.Errorf("build shopping list: %w", err)
+	}
+	lines := make([]ShoppingListLine, 0, len(sources))
+	for _, source := range sources {
+		if !source.Purchasable {
+			continue
+		}
+		line, err := s.net(ctx, input.On, source, required[source.ItemID])
+		if err != nil {
+			return ShoppingList{}, fmt.Errorf("build shopping list: %w", err)
+		}
+		if !line.toBuy.IsZero() {
+			lines = append(lines, line)
+		}
+	}
+	return ShoppingList{on: input.On, groups: groupShoppingLines(lines)}, nil
+}
+
+// requirements returns the net component requirement per item and the items
+// with a positive requirement in first-use order.
+func (s *ShoppingListService) requirements(
+	ctx context.Context,
+	runs []ShoppingRunInput,
+) (map[domain.ItemID]domain.AtomicQuantity, []domain.ItemID, error) {
+	consumed := make(map[domain.ItemID]domain.AtomicQuantity)
+	produced := make(map[domain.ItemID]domain.AtomicQuantity)
+	firstUse := make([]domain.ItemID, 0)
+	for index, run := range runs {
+		if run.Quantity.Int64() <= 0 {
+			return nil, nil, domain.Invalid(fmt.Sprintf("runs[%d].quantity_atomic", index), domain.ViolationNotPositive, "SHP-001")
+		}
+		revision, err := s.store.GetRecipeRevision(ctx, run.RevisionID)
+		if err != nil {
+			return nil, nil, err
+		}
+		recipe, err := s.store.GetRecipe(ctx, revision.RecipeID())
+		if err != nil {
+			return nil, nil, err
+		}
+		outputItemID := recipe.OutputItemID()
+		if produced[outputItemID], err = produced[outputItemID].Add(run.Quantity); err != nil {
+			return nil, nil, err
+		}
+		multiplier, err := domain.NewFraction(run.Quantity.Int64(), revision.StandardYield().Int64())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, component := range revision.Components() {
+			quantity, err := scaleAtomicQuantity(component.Quantity(), multiplier)
+			if err != nil {
+				return nil, nil, err
+			}
+			itemID := component.ItemID()
+			if _, seen := consumed[itemID]; !seen {
+				firstUse = append(firstUse, itemID)
+			}
+			if consumed[itemID], err = consumed[itemID].Add(quantity.rounded); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	required := make(map[domain.ItemID]domain.AtomicQuantity, len(consumed))
+	order := make([]domain.ItemID, 0, len(firstUse))
+	for _, itemID := range firstUse {
+		net, err := consumed[itemID].Sub(minAtomicQuantity(consumed[itemID], produced[itemID]))
+		if err != nil {
+			return nil, nil, err
+		}
+		if net.IsZero() {
+			continue
+		}
+		required[itemID] = net
+		order = append(order, itemID)
+	}
+	return required, order, nil
+}
+
+func (s *ShoppingListService) net(
+	ctx context.Context,
+	on domain.BusinessDate,
+	source ShoppingItemSource,
+	required domain.AtomicQuantity,
+) (ShoppingListLine, error) {
+	lots, err := s.store.ListEligibleFEFOLots(ctx, source.ItemID, on, domain.None[domain.StockLocationID]())
+	if err != nil {
+		return ShoppingListLine{}, err
+	}
+	available := domain.AtomicQuantity{}
+	for _, lot := range lots {
+		if available, err = available.Add(lot.Lot().AvailableQuantity()); err != nil {
+			return ShoppingListLine{}, err
+		}
+	}
+	reorderBuffer, _ := source.ReorderQuantity.Get()
+	usable, err := available.Sub(minAtomicQuantity(available, reorderBuffer))
+	if err != nil {
+		return ShoppingListLine{}, err
+	}
+	toBuy, err := required.Sub(minAtomicQuantity(required, usable))
+	if err != nil {
+		return ShoppingListLine{}, err
+	}
+	line := ShoppingListLine{
+		source: source, required: required, available: available,
+		reorderBuffer: reorderBuffer, toBuy: toBuy,
+	}
+	if packaging, ok := source.Packaging.Get(); ok {
+		entered, err := packaging.Conversion.FromAtomic(toBuy)
+		if err != nil {
+			return ShoppingListLine{}, err
+		}
+		if line.packages, err = entered.Ceil(); err != nil {
+			return ShoppingListLine{}, err
+		}
+	}
+	return line, nil
+}
+
+// groupShoppingLines orders suppliers by name with never-supplied items last,
+// and lines by item name within each group.
+func groupShoppingLines(lines []ShoppingListLine) []ShoppingListGroup {
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].ItemName().Key() < lines[j].ItemName().Key()
+	})
+	groups := make([]ShoppingListGroup, 0)
+	indexes := make(map[domain.CounterpartyID]int)
+	unsupplied := ShoppingListGroup{supplier: domain.None[ShoppingSupplier]()}
+	for _, line := range lines {
+		supplier, ok := line.source.LastSupplier.Get()
+		if !ok {
+			unsupplied.lines = append(unsupplied.lines, line)
+			continue
+		}
+		index, seen := indexes[supplier.ID]
+		if !seen {
+			index = len(groups)
+			indexes[supplier.ID] = index
+			groups = append(groups, ShoppingListGroup{supplier: domain.Some(supplier)})
+		}
+		groups[index].lines = append(groups[index].lines, line)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		left, _ := groups[i].supplier.Get()
+		right, _ := groups[j].supplier.Get()
+		if left.Name.String() != right.Name.String() {
+			return left.Name.String() < right.Name.String()
+		}
+		return left.ID.Int64() < right.ID.Int64()
+	})
+	if len(unsupplied.lines) > 0 {
+		groups = append(groups, unsupplied)
+	}
+	return groups
+}
+
+func minAtomicQuantity(a, b domain.AtomicQuantity) domain.AtomicQuantity {
+	if b.Int64() < a.Int64() {
+		return b
+	}
+	return a
+}
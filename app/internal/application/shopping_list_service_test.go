@@ -0,0 +1,205 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
+)
+
+type shoppingStore struct {
+	ShoppingListStore
+	recipes map[domain.RecipeRevisionID]recipedomain.Recipe
+	sources map[domain.ItemID]ShoppingItemSource
+	lots    map[domain.ItemID][]int64
+}
+
+func (s *shoppingStore) GetRecipeRevision(_ context.Context, id domain.RecipeRevisionID) (recipedomain.Revision, error) {
+	recipe, ok := s.recipes[id]
+	if !ok {
+		return recipedomain.Revision{}, domain.ErrNotFound
+	}
+	return recipe.CurrentRevision(), nil
+}
+
+func (s *shoppingStore) GetRecipe(_ context.Context, id domain.RecipeID) (recipedomain.Recipe, error) {
+	for _, recipe := range s.recipes {
+		if recipe.ID() == id {
+			return recipe, nil
+		}
+	}
+	return recipedomain.Recipe{}, domain.ErrNotFound
+}
+
+func (s *shoppingStore) ListShoppingItemSources(_ context.Context, itemIDs []domain.ItemID) ([]ShoppingItemSource, error) {
+	sources := make([]ShoppingItemSource, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		sources = append(sources, s.sources[itemID])
+	}
+	return sources, nil
+}
+
+func (s *shoppingStore) ListEligibleFEFOLots(
+	_ context.Context,
+	itemID domain.ItemID,
+	on domain.BusinessDate,
+	_ domain.Option[domain.StockLocationID],
+) ([]inventory.LotView, error) {
+	views := make([]inventory.LotView, 0)
+	for index, available := range s.lots[itemID] {
+		lot, err := inventory.NewLot(inventory.LotParams{
+			ID:                    must(domain.NewInventoryLotID(itemID.Int64()*10 + int64(index) + 1)),
+			ItemID:                itemID,
+			SourceLineID:          must(domain.NewStockDocumentLineID(1)),
+			SourcePostingSequence: must(domain.NewPostingSequence(1)),
+			InitialQuantity:       must(domain.NewPositiveAtomicQuantity(available)),
+			AvailableQuantity:     must(domain.NewAtomicQuantity(available)),
+			OriginatedOn:          on,
+			CreatedAt:             mustInstant(1_000),
+		})
+		if err != nil {
+			return nil, err
+		}
+		view, err := inventory.NewLotView(inventory.LotViewParams{
+			Lot: lot, SourceDocumentID: must(domain.NewStockDocumentID(1)),
+			SourceKind: domain.DocumentPurchase, SourceOccurredOn: on,
+			LocationID: must(domain.NewStockLocationID(inventory.DefaultLocationID)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+func TestShoppingListServiceNetsRunsAgainstUsableStockBySupplier(t *testing.T) {
+	cake := planRecipe(t, planRecipeSpec{
+		id: 1, output: planCake, yield: 1_000,
+		components: [][2]int64{{planDough, 500}, {planFlour, 200}, {planSugar, 100}, {planGlaze, 50}},
+	})
+	dough := planRecipe(t, planRecipeSpec{
+		id: 2, output: planDough, yield: 1_000,
+		components: [][2]int64{{planFlour, 600}, {planButter, 300}},
+	})
+	mill := ShoppingSupplier{ID: must(domain.NewCounterpartyID(1)), Name: must(domain.NewDisplayName("Mill"))}
+	acme := ShoppingSupplier{ID: must(domain.NewCounterpartyID(2)), Name: must(domain.NewDisplayName("Acme"))}
+	source := func(itemID int64, name string, purchasable bool, reorder int64, supplier domain.Option[ShoppingSupplier]) ShoppingItemSource {
+		value := ShoppingItemSource{
+			ItemID: planItemID(t, itemID), ItemName: must(domain.NewUniqueName(name)),
+			BaseUnit: must(domain.NewUnitCode("g")), BaseConversion: must(domain.NewUnitConversion(1_000, 1)),
+			Purchasable: purchasable, ReorderQuantity: domain.None[domain.AtomicQuantity](),
+			LastSupplier: supplier, Packaging: domain.None[ShoppingPackaging](),
+		}
+		if reorder > 0 {
+			value.ReorderQuantity = domain.Some(planQuantity(t, reorder))
+		}
+		return value
+	}
+	flour := source(planFlour, "Flour", true, 100, domain.Some(mill))
+	flour.Packaging = domain.Some(ShoppingPackaging{
+		Name: must(domain.NewUniqueName("Bag")), EnteredUnit: must(domain.NewUnitCode("g")),
+		Conversion: must(domain.NewUnitConversion(250, 1)),
+	})
+	store := &shoppingStore{
+		recipes: map[domain.RecipeRevisionID]recipedomain.Recipe{
+			cake.CurrentRevision().ID():  cake,
+			dough.CurrentRevision().ID(): dough,
+		},
+		sources: map[domain.ItemID]ShoppingItemSource{
+			planItemID(t, planDough):  source(planDough, "Dough", false, 0, domain.None[ShoppingSupplier]()),
+			planItemID(t, planFlour):  flour,
+			planItemID(t, planSugar):  source(planSugar, "Sugar", true, 0, domain.Some(acme)),
+			planItemID(t, planGlaze):  source(planGlaze, "Glaze", true, 40, domain.Some(acme)),
+			planItemID(t, planButter): source(planButter, "Butter", true, 0, domain.None[ShoppingSupplier]()),
+		},
+		lots: map[domain.ItemID][]int64{
+			planItemID(t, planFlour): {100, 50},
+			planItemID(t, planSugar): {500},
+			planItemID(t, planGlaze): {30},
+		},
+	}
+	service := NewShoppingListService(store)
+
+	list, err := service.BuildShoppingList(context.Background(), ShoppingListInput{
+		On: must(domain.ParseBusinessDate("2026-10-20")),
+		Runs: []ShoppingRunInput{
+			{RevisionID: cake.CurrentRevision().ID(), Quantity: planQuantity(t, 2_000)},
+			{RevisionID: dough.CurrentRevision().ID(), Quantity: planQuantity(t, 500)},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	groups := list.Groups()
+	if len(groups) != 3 {
+		t.Fatalf("groups = %#v", groups)
+	}
+	if supplier, ok := groups[0].Supplier().Get(); !ok || supplier.ID != acme.ID {
+		t.Fatalf("first group supplier = %#v", groups[0].Supplier())
+	}
+	if supplier, ok := groups[1].Supplier().Get(); !ok || supplier.ID != mill.ID {
+		t.Fatalf("second group supplier = %#v", groups[1].Supplier())
+	}
+	if groups[2].Supplier().IsSome() {
+		t.Fatalf("last group supplier = %#v", groups[2].Supplier())
+	}
+	flourLines := groups[1].Lines()
+	if len(flourLines) != 1 || flourLines[0].Required().Int64() != 700 || flourLines[0].Available().Int64() != 150 ||
+		flourLines[0].ReorderBuffer().Int64() != 100 || flourLines[0].ToBuy().Int64() != 650 || flourLines[0].Packages() != 3 {
+		t.Fatalf("flour lines = %#v", flourLines)
+	}
+
+	var csv strings.Builder
+	if err := list.WriteCSV(&csv); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"supplier_id,supplier,item_id,item,base_unit,required,available,reorder_buffer,to_buy,packaging,packaging_unit,packages",
+		"2,Acme,8,Glaze,g,0.1,0.03,0.04,0.1,,,",
+		"1,Mill,4,Flour,g,0.7,0.15,0.1,0.65,Bag,g,3",
+		",,5,Butter,g,0.15,0,0,0.15,,,",
+		"",
+	}, "\n")
+	if csv.String() != want {
+		t.Fatalf("csv =\n%s\nwant\n%s", csv.String(), want)
+	}
+}
+
+func TestShoppingListServiceRequiresPlannedRuns(t *testing.T) {
+	service := NewShoppingListService(&shoppingStore{})
+	on := must(domain.ParseBusinessDate("2026-10-20"))
+	for _, input := range []ShoppingListInput{
+		{On: on},
+		{On: on, Runs: []ShoppingRunInput{{RevisionID: must(domain.NewRecipeRevisionID(10))}}},
+	} {
+		_, err := service.BuildShoppingList(context.Background(), input)
+		var validation *domain.ValidationError
+		if !errors.As(err, &validation) || validation.Violations()[0].InvariantID != "SHP-001" {
+			t.Fatalf("shopping list error = %v, want SHP-001", err)
+		}
+	}
+}
+
+func TestFormatExactDecimalKeepsEveryDigit(t *testing.T) {
+	tests := []struct {
+		numerator, denominator int64
+		want                   string
+	}{
+		{7, 1, "7"},
+		{1, 4, "0.25"},
+		{1_234, 1_000, "1.234"},
+		{3, 40, "0.075"},
+		{1, 3, "1/3"},
+	}
+	for _, tc := range tests {
+		got, err := formatExactDecimal(must(domain.NewFraction(tc.numerator, tc.denominator)))
+		if err != nil || got != tc.want {
+			t.Fatalf("format %d/%d = %q, %v; want %q", tc.numerator, tc.denominator, got, err, tc.want)
+		}
+	}
+}
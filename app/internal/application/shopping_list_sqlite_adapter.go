@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteShoppingListStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteShoppingListStore(store *sqlite.Store) ShoppingListStore {
+	if store == nil {
+		panic("sqlite shopping list store requires a store")
+	}
+	return &sqliteShoppingListStore{store: store}
+}
+
+func (s *sqliteShoppingListStore) GetRecipe(ctx context.Context, id domain.RecipeID) (recipedomain.Recipe, error) {
+	return s.store.GetRecipe(ctx, id)
+}
+
+func (s *sqliteShoppingListStore) GetRecipeRevision(ctx context.Context, id domain.RecipeRevisionID) (recipedomain.Revision, error) {
+	return s.store.GetRecipeRevision(ctx, id)
+}
+
+func (s *sqliteShoppingListStore) ListShoppingItemSources(ctx context.Context, itemIDs []domain.ItemID) ([]ShoppingItemSource, error) {
+	sources, err := s.store.ListShoppingItemSources(ctx, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	mapped := make([]ShoppingItemSource, 0, len(sources))
+	for _, source := range sources {
+		mapped = append(mapped, mapShoppingItemSource(source))
+	}
+	return mapped, nil
+}
+
+func (s *sqliteShoppingListStore) ListEligibleFEFOLots(
+	ctx context.Context,
+	itemID domain.ItemID,
+	on domain.BusinessDate,
+	location domain.Option[domain.StockLocationID],
+) ([]inventory.LotView, error) {
+	return s.store.ListEligibleFEFOLots(ctx, itemID, on, location)
+}
+
+func mapShoppingItemSource(source sqlite.ShoppingItemSource) ShoppingItemSource {
+	mapped := ShoppingItemSource{
+		ItemID:          source.ItemID,
+		ItemName:        source.ItemName,
+		BaseUnit:        source.BaseUnit,
+		BaseConversion:  source.BaseConversion,
+		Purchasable:     source.Purchasable,
+		ReorderQuantity: source.ReorderQuantity,
+		LastSupplier:    domain.None[ShoppingSupplier](),
+		Packaging:       domain.None[ShoppingPackaging](),
+	}
+	if supplier, ok := source.LastSupplier.Get(); ok {
+		mapped.LastSupplier = domain.Some(ShoppingSupplier{ID: supplier.ID, Name: supplier.Name})
+	}
+	if packaging, ok := source.Packaging.Get(); ok {
+		mapped.Packaging = domain.Some(ShoppingPackaging{
+			Name: packaging.Name, EnteredUnit: packaging.EnteredUnit, Conversion: packaging.Conversion,
+		})
+	}
+	return mapped
+}
@@ -0,0 +1,226 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+)
+
+type StockCountStore interface {
+	GetStockCount(ctx context.Context, id domain.StockCountID) (inventory.Count, error)
+	ListStockCounts(ctx context.Context, input StockCountListInput) (StockCountPage, error)
+	StartStockCount(ctx context.Context, input stockCountStartStoreInput) (inventory.Count, error)
+	RecordStockCount(ctx context.Context, input stockCountRecordStoreInput) (inventory.Count, error)
+	CancelStockCount(ctx context.Context, input stockCountCancelStoreInput) (inventory.Count, error)
+	CloseStockCount(ctx context.Context, input stockCountCloseStoreInput) (StockCountClosing, error)
+}
+
+type StockCountListInput struct {
+	Status   domain.Option[domain.StockCountStatus]
+	After    domain.Option[domain.StockCountID]
+	PageSize int
+}
+
+type StockCountPage struct {
+	items []inventory.Count
+	next  domain.Option[domain.StockCountID]
+}
+
+func NewStockCountPage(items []inventory.Count, next domain.Option[domain.StockCountID]) StockCountPage {
+	cloned := make([]inventory.Count, len(items))
+	copy(cloned, items)
+	return StockCountPage{items: cloned, next: next}
+}
+
+func (p StockCountPage) Items() []inventory.Count {
+	items := make([]inventory.Count, len(p.items))
+	copy(items, p.items)
+	return items
+}
+
+func (p StockCountPage) Next() domain.Option[domain.StockCountID] { return p.next }
+
+// StockCountItemInput counts an item as a whole or, with ByLot, one line per
+// lot that still holds stock.
+type StockCountItemInput struct {
+	ItemID domain.ItemID
+	ByLot  bool
+}
+
+// StockCountStartInput freezes the listed items, or every item with stock
+// when Items is empty, at the latest posting sequence.
+type StockCountStartInput struct {
+	CountedOn domain.BusinessDate
+	Notes     domain.Option[domain.NonEmptyText]
+	Items     []StockCountItemInput
+}
+
+// StockCountObservationInput sets the observed quantity of a line, or clears
+// it with None.
+type StockCountObservationInput struct {
+	LineID   domain.StockCountLineID
+	Observed domain.Option[domain.AtomicQuantity]
+}
+
+type StockCountRecordInput struct {
+	ID                domain.StockCountID
+	Observations      []StockCountObservationInput
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type StockCountCancelInput struct {
+	ID                domain.StockCountID
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type StockCountCloseInput struct {
+	ID                domain.StockCountID
+	IdempotencyKey    domain.IdempotencyKey
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+type stockCountStartStoreInput struct {
+	StockCountStartInput
+	CreatedAt domain.UTCInstant
+}
+
+type stockCountRecordStoreInput struct {
+	StockCountRecordInput
+	UpdatedAt domain.UTCInstant
+}
+
+type stockCountCancelStoreInput struct {
+	StockCountCancelInput
+	UpdatedAt domain.UTCInstant
+}
+
+type stockCountCloseStoreInput struct {
+	StockCountCloseInput
+	ClosedAt domain.UTCInstant
+}
+
+// StockCountClosing is a closed count together with the PHYSICAL_COUNT
+// adjustment that posted its variances, absent when nothing differed.
+type StockCountClosing struct {
+	count      inventory.Count
+	adjustment domain.Option[AdjustmentDocument]
+}
+
+func NewStockCountClosing(count inventory.Count, adjustment domain.Option[AdjustmentDocument]) StockCountClosing {
+	return StockCountClosing{count: count, adjustment: adjustment}
+}
+
+func (c StockCountClosing) Count() inventory.Count                        { return c.count }
+func (c StockCountClosing) Adjustment() domain.Option[AdjustmentDocument] { return c.adjustment }
+
+type StockCountService struct {
+	store StockCountStore
+	clock Clock
+}
+
+func NewStockCountService(store StockCountStore, clock Clock) *StockCountService {
+	if store == nil {
+		panic("stock count service requires a store")
+	}
+	if clock == nil {
+		panic("stock count service requires a clock")
+	}
+	return &StockCountService{store: store, clock: clock}
+}
+
+func (s *StockCountService) GetStockCount(ctx context.Context, id domain.StockCountID) (inventory.Count, error) {
+	count, err := s.store.GetStockCount(ctx, id)
+	if err != nil {
+		return inventory.Count{}, fmt.Errorf("get stock count: %w", err)
+	}
+	return count, nil
+}
+
+func (s *StockCountService) ListStockCounts(ctx context.Context, input StockCountListInput) (StockCountPage, error) {
+	page, err := s.store.ListStockCounts(ctx, input)
+	if err != nil {
+		return StockCountPage{}, fmt.Errorf("list stock counts: %w", err)
+	}
+	return page, nil
+}
+
+func (s *StockCountService) StartStockCount(ctx context.Context, input StockCountStartInput) (inventory.Count, error) {
+	now, err := s.clock.Now()
+	if err != nil {
+		return inventory.Count{}, fmt.Errorf("read clock: %w", err)
+	}
+	started, err := s.store.StartStockCount(ctx, stockCountStartStoreInput{
+		StockCountStartInput: input,
+		CreatedAt:            now,
+	})
+	if err != nil {
+		return inventory.Count{}, fmt.Errorf("start stock count: %w", err)
+	}
+	if !started.CreatedAt().Equal(now) || !started.IsOpen() {
+		return inventory.Count{}, domain.ErrInvariant
+	}
+	return started, nil
+}
+
+func (s *StockCountService) RecordStockCount(ctx context.Context, input StockCountRecordInput) (inventory.Count, error) {
+	now, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return inventory.Count{}, fmt.Errorf("read clock: %w", err)
+	}
+	recorded, err := s.store.RecordStockCount(ctx, stockCountRecordStoreInput{
+		StockCountRecordInput: input,
+		UpdatedAt:             now,
+	})
+	if err != nil {
+		return inventory.Count{}, fmt.Errorf("record stock count: %w", err)
+	}
+	if !recorded.UpdatedAt().Equal(now) {
+		return inventory.Count{}, domain.ErrInvariant
+	}
+	return recorded, nil
+}
+
+func (s *StockCountService) CancelStockCount(ctx context.Context, input StockCountCancelInput) (inventory.Count, error) {
+	now, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return inventory.Count{}, fmt.Errorf("read clock: %w", err)
+	}
+	cancelled, err := s.store.CancelStockCount(ctx, stockCountCancelStoreInput{
+		StockCountCancelInput: input,
+		UpdatedAt:             now,
+	})
+	if err != nil {
+		return inventory.Count{}, fmt.Errorf("cancel stock count: %w", err)
+	}
+	if cancelled.Status() != domain.StockCountCancelled || !cancelled.UpdatedAt().Equal(now) {
+		return inventory.Count{}, domain.ErrInvariant
+	}
+	return cancelled, nil
+}
+
+// CloseStockCount posts the count's non-zero variances as one PHYSICAL_COUNT
+// adjustment and closes the count in the same transaction. A retry with the
+// same idempotency key returns the first closing.
+func (s *StockCountService) CloseStockCount(ctx context.Context, input StockCountCloseInput) (StockCountClosing, error) {
+	closedAt, err := nextMutationInstant(s.clock, input.ExpectedUpdatedAt)
+	if err != nil {
+		return StockCountClosing{}, fmt.Errorf("read clock: %w", err)
+	}
+	closing, err := s.store.CloseStockCount(ctx, stockCountCloseStoreInput{
+		StockCountCloseInput: input,
+		ClosedAt:             closedAt,
+	})
+	if err != nil {
+		return StockCountClosing{}, fmt.Errorf("close stock count: %w", err)
+	}
+	count := closing.Count()
+	if key, ok := count.ClosingKey().Get(); !ok || key != input.IdempotencyKey || count.Status() != domain.StockCountClosed {
+		return StockCountClosing{}, domain.ErrInvariant
+	}
+	if err := ensurePostingClockCompatible(count.UpdatedAt(), closedAt); err != nil {
+		return StockCountClosing{}, err
+	}
+	return closing, nil
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+)
+
+type closingStockCountStore struct {
+	StockCountStore
+	key    string
+	closed []stockCountCloseStoreInput
+}
+
+func (s *closingStockCountStore) CloseStockCount(_ context.Context, input stockCountCloseStoreInput) (StockCountClosing, error) {
+	s.closed = append(s.closed, input)
+	count := closedStockCount(must(domain.NewIdempotencyKey(s.key)), input.ClosedAt)
+	return NewStockCountClosing(count, domain.None[AdjustmentDocument]()), nil
+}
+
+func TestStockCountServiceClosesAfterTheCountVersion(t *testing.T) {
+	store := &closingStockCountStore{key: "count-1"}
+	service := NewStockCountService(store, &mutableClock{now: mustInstant(1_000)})
+	input := StockCountCloseInput{
+		ID:                must(domain.NewStockCountID(1)),
+		IdempotencyKey:    must(domain.NewIdempotencyKey("count-1")),
+		ExpectedUpdatedAt: mustInstant(2_000),
+	}
+
+	closing, err := service.CloseStockCount(context.Background(), input)
+	if err != nil {
+		t.Fatalf("close stock count: %v", err)
+	}
+	if len(store.closed) != 1 || !store.closed[0].ClosedAt.Equal(mustInstant(2_001)) ||
+		!closing.Count().UpdatedAt().Equal(mustInstant(2_001)) || closing.Adjustment().IsSome() {
+		t.Fatalf("closed inputs = %#v, closing = %#v", store.closed, closing)
+	}
+
+	store.key = "another-count"
+	if _, err := service.CloseStockCount(context.Background(), input); !errors.Is(err, domain.ErrInvariant) {
+		t.Fatalf("foreign closing key error = %v, want invariant", err)
+	}
+}
+
+// closedStockCount is a closed one-line count of item 4 that expected 10
+// atomic units and was never counted.
+func closedStockCount(key domain.IdempotencyKey, closedAt domain.UTCInstant) inventory.Count {
+	line := must(inventory.NewCountLine(inventory.CountLineParams{
+		ID: must(domain.NewStockCountLineID(3)), CountID: must(domain.NewStockCountID(1)),
+		ItemID:           must(domain.NewItemID(4)),
+		ExpectedQuantity: must(domain.NewAtomicQuantity(10)),
+		ExpectedValue:    must(domain.NewInventoryValue(1_000)),
+	}))
+	return must(inventory.NewCount(inventory.CountParams{
+		ID: must(domain.NewStockCountID(1)), Status: domain.StockCountClosed,
+		CountedOn:             must(domain.ParseBusinessDate("2026-10-18")),
+		FrozenPostingSequence: 2,
+		ClosingKey:            domain.Some(key),
+		CreatedAt:             mustInstant(500),
+		UpdatedAt:             closedAt,
+		Lines:                 []inventory.CountLine{line},
+	}))
+}
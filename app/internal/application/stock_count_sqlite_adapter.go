@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteStockCountStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteStockCountStore(store *sqlite.Store) StockCountStore {
+	if store == nil {
+		panic("sqlite stock count store requires a store")
+	}
+	return &sqliteStockCountStore{store: store}
+}
+
+func (s *sqliteStockCountStore) GetStockCount(ctx context.Context, id domain.StockCountID) (inventory.Count, error) {
+	return s.store.GetStockCount(ctx, id)
+}
+
+func (s *sqliteStockCountStore) ListStockCounts(ctx context.Context, input StockCountListInput) (StockCountPage, error) {
+	page, err := s.store.ListStockCounts(ctx, sqlite.StockCountListFilter{
+		Status:   input.Status,
+		After:    input.After,
+		PageSize: input.PageSize,
+	})
+	if err != nil {
+		return StockCountPage{}, err
+	}
+	return NewStockCountPage(page.Items(), page.Next()), nil
+}
+
+func (s *sqliteStockCountStore) StartStockCount(ctx context.Context, input stockCountStartStoreInput) (inventory.Count, error) {
+	items := make([]sqlite.StockCountItemInput, 0, len(input.Items))
+	for _, item := range input.Items {
+		items = append(items, sqlite.StockCountItemInput{ItemID: item.ItemID, ByLot: item.ByLot})
+	}
+	return s.store.StartStockCount(ctx, sqlite.StartStockCountInput{
+		CountedOn: input.CountedOn,
+		Notes:     input.Notes,
+		Items:     items,
+		CreatedAt: input.CreatedAt,
+	})
+}
+
+func (s *sqliteStockCountStore) RecordStockCount(ctx context.Context, input stockCountRecordStoreInput) (inventory.Count, error) {
+	observations := make([]sqlite.StockCountObservationInput, 0, len(input.Observations))
+	for _, observation := range input.Observations {
+		observations = append(observations, sqlite.StockCountObservationInput{
+			LineID:   observation.LineID,
+			Observed: observation.Observed,
+		})
+	}
+	return s.store.RecordStockCount(ctx, sqlite.RecordStockCountInput{
+		ID:                input.ID,
+		Observations:      observations,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqliteStockCountStore) CancelStockCount(ctx context.Context, input stockCountCancelStoreInput) (inventory.Count, error) {
+	return s.store.CancelStockCount(ctx, sqlite.CancelStockCountInput{
+		ID:                input.ID,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		UpdatedAt:         input.UpdatedAt,
+	})
+}
+
+func (s *sqliteStockCountStore) CloseStockCount(ctx context.Context, input stockCountCloseStoreInput) (StockCountClosing, error) {
+	count, posted, err := s.store.CloseStockCount(ctx, sqlite.CloseStockCountInput{
+		ID:                input.ID,
+		IdempotencyKey:    input.IdempotencyKey,
+		ExpectedUpdatedAt: input.ExpectedUpdatedAt,
+		ClosedAt:          input.ClosedAt,
+	})
+	if err != nil {
+		return StockCountClosing{}, err
+	}
+	adjustment := domain.None[AdjustmentDocument]()
+	if document, ok := posted.Get(); ok {
+		mapped, err := mapSQLitePostedAdjustment(document)
+		if err != nil {
+			return StockCountClosing{}, err
+		}
+		adjustment = domain.Some(mapped)
+	}
+	return NewStockCountClosing(count, adjustment), nil
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type StockDocumentStore interface {
+	ListDocuments(ctx context.Context, input StockDocumentListInput) (StockDocumentPage, error)
+}
+
+type StockDocumentCursor struct {
+	PostingSequence domain.PostingSequence
+	ID              domain.StockDocumentID
+}
+
+// StockDocumentListInput filters the ledger across every document kind. The
+// occurred-on range is inclusive and NotesSearch is a substring match that
+// ignores ASCII case.
+type StockDocumentListInput struct {
+	Kind           domain.Option[domain.DocumentKind]
+	Reason         domain.Option[domain.DocumentReason]
+	CounterpartyID domain.Option[domain.CounterpartyID]
+	FromOccurredOn domain.Option[domain.BusinessDate]
+	ToOccurredOn   domain.Option[domain.BusinessDate]
+	Reversal       domain.ReversalFilter
+	NotesSearch    domain.Option[domain.NonEmptyText]
+	After          domain.Option[StockDocumentCursor]
+	PageSize       int
+}
+
+// StockDocumentSummary is the common header of any posted document. Inventory
+// values are split by line direction; CommercialTotal is present only for
+// documents whose lines carry commercial totals.
+type StockDocumentSummary struct {
+	ID                   domain.StockDocumentID
+	Kind                 domain.DocumentKind
+	IdempotencyKey       domain.IdempotencyKey
+	PostingSequence      domain.PostingSequence
+	CounterpartyID       domain.Option[domain.CounterpartyID]
+	OccurredOn           domain.BusinessDate
+	PostedAt             domain.UTCInstant
+	Currency             domain.Currency
+	Reason               domain.Option[domain.DocumentReason]
+	Notes                domain.Option[domain.NonEmptyText]
+	LineCount            int64
+	InventoryValueIn     domain.InventoryValue
+	InventoryValueOut    domain.InventoryValue
+	CommercialTotal      domain.Option[domain.MinorAmount]
+	ReversesDocumentID   domain.Option[domain.StockDocumentID]
+	ReversedByDocumentID domain.Option[domain.StockDocumentID]
+}
+
+type StockDocumentPage struct {
+	items []StockDocumentSummary
+	next  domain.Option[StockDocumentCursor]
+}
+
+func NewStockDocumentPage(items []StockDocumentSummary, next domain.Option[StockDocumentCursor]) StockDocumentPage {
+	cloned := make([]StockDocumentSummary, len(items))
+	copy(cloned, items)
+	return StockDocumentPage{items: cloned, next: next}
+}
+
+func (p StockDocumentPage) Items() []StockDocumentSummary {
+	items := make([]StockDocumentSummary, len(p.items))
+	copy(items, p.items)
+	return items
+}
+
+func (p StockDocumentPage) Next() domain.Option[StockDocumentCursor] { return p.next }
+
+// StockDocumentService browses posted documents of every kind. Posting stays
+// with the per-kind services.
+type StockDocumentService struct {
+	store StockDocumentStore
+}
+
+func NewStockDocumentService(store StockDocumentStore) *StockDocumentService {
+	if store == nil {
+		panic("stock document service requires a store")
+	}
+	return &StockDocumentService{store: store}
+}
+
+func (s *StockDocumentService) ListDocuments(ctx context.Context, input StockDocumentListInput) (StockDocumentPage, error) {
+	page, err := s.store.ListDocuments(ctx, input)
+	if err != nil {
+		return StockDocumentPage{}, fmt.Errorf("list documents: %w", err)
+	}
+	return page, nil
+}
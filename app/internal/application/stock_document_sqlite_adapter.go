@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteStockDocumentStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteStockDocumentStore(store *sqlite.Store) StockDocumentStore {
+	if store == nil {
+		panic("sqlite stock document store requires a store")
+	}
+	return &sqliteStockDocumentStore{store: store}
+}
+
+func (s *sqliteStockDocumentStore) ListDocuments(ctx context.Context, input StockDocumentListInput) (StockDocumentPage, error) {
+	after := domain.None[sqlite.StockDocumentCursor]()
+	if cursor, ok := input.After.Get(); ok {
+		after = domain.Some(sqlite.StockDocumentCursor{
+			PostingSequence: cursor.PostingSequence,
+			ID:              cursor.ID,
+		})
+	}
+	page, err := s.store.ListStockDocuments(ctx, sqlite.StockDocumentListFilter{
+		Kind:           input.Kind,
+		Reason:         input.Reason,
+		CounterpartyID: input.CounterpartyID,
+		FromOccurredOn: input.FromOccurredOn,
+		ToOccurredOn:   input.ToOccurredOn,
+		Reversal:       input.Reversal,
+		NotesSearch:    input.NotesSearch,
+		After:          after,
+		PageSize:       input.PageSize,
+	})
+	if err != nil {
+		return StockDocumentPage{}, err
+	}
+	sourceItems := page.Items()
+	items := make([]StockDocumentSummary, 0, len(sourceItems))
+	for _, summary := range sourceItems {
+		items = append(items, mapSQLiteStockDocumentSummary(summary))
+	}
+	next := domain.None[StockDocumentCursor]()
+	if cursor, ok := page.Next().Get(); ok {
+		next = domain.Some(StockDocumentCursor{
+			PostingSequence: cursor.PostingSequence,
+			ID:              cursor.ID,
+		})
+	}
+	return NewStockDocumentPage(items, next), nil
+}
+
+func mapSQLiteStockDocumentSummary(summary sqlite.StockDocumentSummary) StockDocumentSummary {
+	return StockDocumentSummary{
+		ID:                   summary.ID,
+		Kind:                 summary.Kind,
+		IdempotencyKey:       summary.IdempotencyKey,
+		PostingSequence:      summary.PostingSequence,
+		CounterpartyID:       summary.CounterpartyID,
+		OccurredOn:           summary.OccurredOn,
+		PostedAt:             summary.PostedAt,
+		Currency:             summary.Currency,
+		Reason:               summary.Reason,
+		Notes:                summary.Notes,
+		LineCount:            summary.LineCount,
+		InventoryValueIn:     summary.InventoryValueIn,
+		InventoryValueOut:    summary.InventoryValueOut,
+		CommercialTotal:      summary.CommercialTotal,
+		ReversesDocumentID:   summary.ReversesDocumentID,
+		ReversedByDocumentID: summary.ReversedByDocumentID,
+	}
+}
@@ -0,0 +1,255 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type SupplierReturnStore interface {
+	PostSupplierReturn(ctx context.Context, input supplierReturnPostStoreInput) (SupplierReturnDocument, error)
+	GetSupplierReturn(ctx context.Context, id domain.StockDocumentID) (SupplierReturnDocument, error)
+	ListPurchaseReturns(ctx context.Context, purchaseID domain.StockDocumentID) ([]SupplierReturnDocument, error)
+}
+
+type SupplierReturnPostInput struct {
+	IdempotencyKey     domain.IdempotencyKey
+	PurchaseDocumentID domain.StockDocumentID
+	OccurredOn         domain.BusinessDate
+	Notes              domain.Option[domain.NonEmptyText]
+	Lines              []SupplierReturnLineInput
+}
+
+// SupplierReturnLineInput sends part of one purchase line back. Without an
+// explicit credit the line credits its proportional share of the purchase
+// line total.
+type SupplierReturnLineInput struct {
+	PurchaseLineID domain.StockDocumentLineID
+	Quantity       domain.AtomicQuantity
+	Credit         domain.Option[domain.MinorAmount]
+}
+
+type supplierReturnPostStoreInput struct {
+	SupplierReturnPostInput
+	PostedAt domain.UTCInstant
+}
+
+type SupplierReturnDocument struct {
+	id                 domain.StockDocumentID
+	idempotencyKey     domain.IdempotencyKey
+	postingSequence    domain.PostingSequence
+	purchaseDocumentID domain.StockDocumentID
+	counterpartyID     domain.Option[domain.CounterpartyID]
+	occurredOn         domain.BusinessDate
+	postedAt           domain.UTCInstant
+	currency           domain.Currency
+	reason             domain.DocumentReason
+	notes              domain.Option[domain.NonEmptyText]
+	lines              []PostedSupplierReturnLine
+}
+
+func NewSupplierReturnDocument(
+	id domain.StockDocumentID,
+	idempotencyKey domain.IdempotencyKey,
+	postingSequence domain.PostingSequence,
+	purchaseDocumentID domain.StockDocumentID,
+	counterpartyID domain.Option[domain.CounterpartyID],
+	occurredOn domain.BusinessDate,
+	postedAt domain.UTCInstant,
+	currency domain.Currency,
+	reason domain.DocumentReason,
+	notes domain.Option[domain.NonEmptyText],
+	lines []PostedSupplierReturnLine,
+) (SupplierReturnDocument, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if id.IsZero() {
+		violations = append(violations, domain.Violation{Field: "document_id", Code: domain.ViolationRequired})
+	}
+	if idempotencyKey.String() == "" {
+		violations = append(violations, domain.Violation{Field: "idempotency_key", Code: domain.ViolationRequired})
+	}
+	if postingSequence.IsZero() {
+		violations = append(violations, domain.Violation{Field: "posting_sequence", Code: domain.ViolationRequired})
+	}
+	if purchaseDocumentID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "purchase_document_id", Code: domain.ViolationRequired})
+	}
+	if occurredOn.IsZero() {
+		violations = append(violations, domain.Violation{Field: "occurred_on", Code: domain.ViolationRequired})
+	}
+	if postedAt.IsZero() {
+		violations = append(violations, domain.Violation{Field: "posted_at", Code: domain.ViolationRequired})
+	}
+	if currency.IsZero() {
+		violations = append(violations, domain.Violation{Field: "currency", Code: domain.ViolationRequired})
+	}
+	if len(lines) == 0 {
+		violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationRequired})
+	}
+	if reason != domain.ReasonSupplierReturn {
+		violations = append(violations, domain.Violation{Field: "reason", Code: domain.ViolationInvalidEnum})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return SupplierReturnDocument{}, err
+	}
+	cloned := make([]PostedSupplierReturnLine, len(lines))
+	copy(cloned, lines)
+	return SupplierReturnDocument{
+		id: id, idempotencyKey: idempotencyKey, postingSequence: postingSequence,
+		purchaseDocumentID: purchaseDocumentID, counterpartyID: counterpartyID, occurredOn: occurredOn,
+		postedAt: postedAt, currency: currency, reason: reason, notes: notes, lines: cloned,
+	}, nil
+}
+
+func (d SupplierReturnDocument) ID() domain.StockDocumentID              { return d.id }
+func (d SupplierReturnDocument) IdempotencyKey() domain.IdempotencyKey   { return d.idempotencyKey }
+func (d SupplierReturnDocument) PostingSequence() domain.PostingSequence { return d.postingSequence }
+func (d SupplierReturnDocument) PurchaseDocumentID() domain.StockDocumentID {
+	return d.purchaseDocumentID
+}
+func (d SupplierReturnDocument) CounterpartyID() domain.Option[domain.CounterpartyID] {
+	return d.counterpartyID
+}
+func (d SupplierReturnDocument) OccurredOn() domain.BusinessDate           { return d.occurredOn }
+func (d SupplierReturnDocument) PostedAt() domain.UTCInstant               { return d.postedAt }
+func (d SupplierReturnDocument) Currency() domain.Currency                 { return d.currency }
+func (d SupplierReturnDocument) Reason() domain.DocumentReason             { return d.reason }
+func (d SupplierReturnDocument) Notes() domain.Option[domain.NonEmptyText] { return d.notes }
+func (d SupplierReturnDocument) Lines() []PostedSupplierReturnLine {
+	lines := make([]PostedSupplierReturnLine, len(d.lines))
+	copy(lines, d.lines)
+	return lines
+}
+
+type PostedSupplierReturnLine struct {
+	id                   domain.StockDocumentLineID
+	lineOrder            domain.LineOrder
+	itemID               domain.ItemID
+	quantity             domain.AtomicQuantity
+	enteredUnit          domain.UnitCode
+	enteredPackagingName domain.Option[domain.NonEmptyText]
+	conversion           domain.UnitConversion
+	inventoryValue       domain.InventoryValue
+	credit               domain.MinorAmount
+	returnsLineID        domain.StockDocumentLineID
+	lotID                domain.InventoryLotID
+	allocationID         domain.LotAllocationID
+}
+
+func NewPostedSupplierReturnLine(
+	id domain.StockDocumentLineID,
+	lineOrder domain.LineOrder,
+	itemID domain.ItemID,
+	quantity domain.AtomicQuantity,
+	enteredUnit domain.UnitCode,
+	enteredPackagingName domain.Option[domain.NonEmptyText],
+	conversion domain.UnitConversion,
+	inventoryValue domain.InventoryValue,
+	credit domain.MinorAmount,
+	returnsLineID domain.StockDocumentLineID,
+	lotID domain.InventoryLotID,
+	allocationID domain.LotAllocationID,
+) (PostedSupplierReturnLine, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if id.IsZero() {
+		violations = append(violations, domain.Violation{Field: "line_id", Code: domain.ViolationRequired})
+	}
+	if lineOrder.IsZero() {
+		violations = append(violations, domain.Violation{Field: "line_order", Code: domain.ViolationRequired})
+	}
+	if itemID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "item_id", Code: domain.ViolationRequired})
+	}
+	if quantity.Int64() <= 0 {
+		violations = append(violations, domain.Violation{Field: "quantity_atomic", Code: domain.ViolationNotPositive})
+	}
+	if enteredUnit.String() == "" {
+		violations = append(violations, domain.Violation{Field: "entered_unit_code", Code: domain.ViolationRequired})
+	}
+	if conversion.IsZero() {
+		violations = append(violations, domain.Violation{Field: "conversion", Code: domain.ViolationRequired})
+	}
+	if returnsLineID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "returns_line_id", Code: domain.ViolationRequired})
+	}
+	if lotID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "lot_id", Code: domain.ViolationRequired})
+	}
+	if allocationID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "allocation_id", Code: domain.ViolationRequired})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return PostedSupplierReturnLine{}, err
+	}
+	return PostedSupplierReturnLine{
+		id: id, lineOrder: lineOrder, itemID: itemID, quantity: quantity,
+		enteredUnit: enteredUnit, enteredPackagingName: enteredPackagingName,
+		conversion: conversion, inventoryValue: inventoryValue, credit: credit,
+		returnsLineID: returnsLineID, lotID: lotID, allocationID: allocationID,
+	}, nil
+}
+
+func (l PostedSupplierReturnLine) ID() domain.StockDocumentLineID  { return l.id }
+func (l PostedSupplierReturnLine) LineOrder() domain.LineOrder     { return l.lineOrder }
+func (l PostedSupplierReturnLine) ItemID() domain.ItemID           { return l.itemID }
+func (l PostedSupplierReturnLine) Quantity() domain.AtomicQuantity { return l.quantity }
+func (l PostedSupplierReturnLine) EnteredUnit() domain.UnitCode    { return l.enteredUnit }
+func (l PostedSupplierReturnLine) EnteredPackagingName() domain.Option[domain.NonEmptyText] {
+	return l.enteredPackagingName
+}
+func (l PostedSupplierReturnLine) Conversion() domain.UnitConversion         { return l.conversion }
+func (l PostedSupplierReturnLine) InventoryValue() domain.InventoryValue     { return l.inventoryValue }
+func (l PostedSupplierReturnLine) Credit() domain.MinorAmount                { return l.credit }
+func (l PostedSupplierReturnLine) ReturnsLineID() domain.StockDocumentLineID { return l.returnsLineID }
+func (l PostedSupplierReturnLine) LotID() domain.InventoryLotID              { return l.lotID }
+func (l PostedSupplierReturnLine) AllocationID() domain.LotAllocationID      { return l.allocationID }
+
+type SupplierReturnService struct {
+	store SupplierReturnStore
+	clock Clock
+}
+
+func NewSupplierReturnService(store SupplierReturnStore, clock Clock) *SupplierReturnService {
+	if store == nil {
+		panic("supplier return service requires a store")
+	}
+	if clock == nil {
+		panic("supplier return service requires a clock")
+	}
+	return &SupplierReturnService{store: store, clock: clock}
+}
+
+func (s *SupplierReturnService) PostSupplierReturn(ctx context.Context, input SupplierReturnPostInput) (SupplierReturnDocument, error) {
+	postedAt, err := s.clock.Now()
+	if err != nil {
+		return SupplierReturnDocument{}, fmt.Errorf("read clock: %w", err)
+	}
+	document, err := s.store.PostSupplierReturn(ctx, supplierReturnPostStoreInput{
+		SupplierReturnPostInput: input,
+		PostedAt:                postedAt,
+	})
+	if err != nil {
+		return SupplierReturnDocument{}, fmt.Errorf("post supplier return: %w", err)
+	}
+	if err := ensurePostingClockCompatible(document.PostedAt(), postedAt); err != nil {
+		return SupplierReturnDocument{}, err
+	}
+	return document, nil
+}
+
+func (s *SupplierReturnService) GetSupplierReturn(ctx context.Context, id domain.StockDocumentID) (SupplierReturnDocument, error) {
+	document, err := s.store.GetSupplierReturn(ctx, id)
+	if err != nil {
+		return SupplierReturnDocument{}, fmt.Errorf("get supplier return: %w", err)
+	}
+	return document, nil
+}
+
+func (s *SupplierReturnService) ListPurchaseReturns(ctx context.Context, purchaseID domain.StockDocumentID) ([]SupplierReturnDocument, error) {
+	documents, err := s.store.ListPurchaseReturns(ctx, purchaseID)
+	if err != nil {
+		return nil, fmt.Errorf("list purchase returns: %w", err)
+	}
+	return documents, nil
+}
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteSupplierReturnStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteSupplierReturnStore(store *sqlite.Store) SupplierReturnStore {
+	if store == nil {
+		panic("sqlite supplier return store requires a store")
+	}
+	return &sqliteSupplierReturnStore{store: store}
+}
+
+func (s *sqliteSupplierReturnStore) PostSupplierReturn(ctx context.Context, input supplierReturnPostStoreInput) (SupplierReturnDocument, error) {
+	lines := make([]sqlite.PostSupplierReturnLineInput, 0, len(input.Lines))
+	for _, line := range input.Lines {
+		lines = append(lines, sqlite.PostSupplierReturnLineInput{
+			PurchaseLineID: line.PurchaseLineID,
+			Quantity:       line.Quantity,
+			Credit:         line.Credit,
+		})
+	}
+	posted, err := s.store.PostSupplierReturn(ctx, sqlite.PostSupplierReturnInput{
+		IdempotencyKey:     input.IdempotencyKey,
+		PurchaseDocumentID: input.PurchaseDocumentID,
+		OccurredOn:         input.OccurredOn,
+		PostedAt:           input.PostedAt,
+		Notes:              input.Notes,
+		Lines:              lines,
+	})
+	if err != nil {
+		return SupplierReturnDocument{}, err
+	}
+	return mapSQLitePostedSupplierReturn(posted)
+}
+
+func (s *sqliteSupplierReturnStore) GetSupplierReturn(ctx context.Context, id domain.StockDocumentID) (SupplierReturnDocument, error) {
+	posted, err := s.store.GetPostedSupplierReturn(ctx, id)
+	if err != nil {
+		return SupplierReturnDocument{}, err
+	}
+	return mapSQLitePostedSupplierReturn(posted)
+}
+
+func (s *sqliteSupplierReturnStore) ListPurchaseReturns(ctx context.Context, purchaseID domain.StockDocumentID) ([]SupplierReturnDocument, error) {
+	posted, err := s.store.ListPostedSupplierReturnsForPurchase(ctx, purchaseID)
+	if err != nil {
+		return nil, err
+	}
+	documents := make([]SupplierReturnDocument, 0, len(posted))
+	for _, document := range posted {
+		mapped, err := mapSQLitePostedSupplierReturn(document)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, mapped)
+	}
+	return documents, nil
+}
+
+func mapSQLitePostedSupplierReturn(posted sqlite.PostedSupplierReturnDocument) (SupplierReturnDocument, error) {
+	sourceLines := posted.Lines()
+	lines := make([]PostedSupplierReturnLine, 0, len(sourceLines))
+	for _, line := range sourceLines {
+		mapped, err := NewPostedSupplierReturnLine(
+			line.ID(),
+			line.LineOrder(),
+			line.ItemID(),
+			line.Quantity(),
+			line.EnteredUnit(),
+			line.EnteredPackagingName(),
+			line.Conversion(),
+			line.InventoryValue(),
+			line.Credit(),
+			line.ReturnsLineID(),
+			line.LotID(),
+			line.AllocationID(),
+		)
+		if err != nil {
+			return SupplierReturnDocument{}, err
+		}
+		lines = append(lines, mapped)
+	}
+	return NewSupplierReturnDocument(
+		posted.ID(),
+		posted.IdempotencyKey(),
+		posted.PostingSequence(),
+		posted.PurchaseDocumentID(),
+		posted.CounterpartyID(),
+		posted.OccurredOn(),
+		posted.PostedAt(),
+		posted.Currency(),
+		domain.ReasonSupplierReturn,
+		posted.Notes(),
+		lines,
+	)
+}
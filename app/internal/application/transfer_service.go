@@ -0,0 +1,239 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type TransferStore interface {
+	PostTransfer(ctx context.Context, input transferPostStoreInput) (TransferDocument, error)
+	GetTransfer(ctx context.Context, id domain.StockDocumentID) (TransferDocument, error)
+}
+
+type TransferPostInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	OccurredOn     domain.BusinessDate
+	Notes          domain.Option[domain.NonEmptyText]
+	Lines          []TransferLineInput
+}
+
+// TransferLineInput moves part of one lot to another location. The moved
+// quantity keeps the lot's code and dates at the destination.
+type TransferLineInput struct {
+	LotID        domain.InventoryLotID
+	Quantity     domain.AtomicQuantity
+	ToLocationID domain.StockLocationID
+}
+
+type transferPostStoreInput struct {
+	TransferPostInput
+	PostedAt domain.UTCInstant
+}
+
+type TransferDocument struct {
+	id              domain.StockDocumentID
+	idempotencyKey  domain.IdempotencyKey
+	postingSequence domain.PostingSequence
+	occurredOn      domain.BusinessDate
+	postedAt        domain.UTCInstant
+	notes           domain.Option[domain.NonEmptyText]
+	lines           []TransferLine
+}
+
+func NewTransferDocument(
+	id domain.StockDocumentID,
+	idempotencyKey domain.IdempotencyKey,
+	postingSequence domain.PostingSequence,
+	occurredOn domain.BusinessDate,
+	postedAt domain.UTCInstant,
+	notes domain.Option[domain.NonEmptyText],
+	lines []TransferLine,
+) (TransferDocument, error) {
+	violations := make([]domain.Violation, 0, 6)
+	if id.IsZero() {
+		violations = append(violations, domain.Violation{Field: "document_id", Code: domain.ViolationRequired})
+	}
+	if idempotencyKey.String() == "" {
+		violations = append(violations, domain.Violation{Field: "idempotency_key", Code: domain.ViolationRequired})
+	}
+	if postingSequence.IsZero() {
+		violations = append(violations, domain.Violation{Field: "posting_sequence", Code: domain.ViolationRequired})
+	}
+	if occurredOn.IsZero() {
+		violations = append(violations, domain.Violation{Field: "occurred_on", Code: domain.ViolationRequired})
+	}
+	if postedAt.IsZero() {
+		violations = append(violations, domain.Violation{Field: "posted_at", Code: domain.ViolationRequired})
+	}
+	if len(lines) == 0 {
+		violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationRequired})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return TransferDocument{}, err
+	}
+	cloned := make([]TransferLine, len(lines))
+	copy(cloned, lines)
+	return TransferDocument{
+		id: id, idempotencyKey: idempotencyKey, postingSequence: postingSequence,
+		occurredOn: occurredOn, postedAt: postedAt, notes: notes, lines: cloned,
+	}, nil
+}
+
+func (d TransferDocument) ID() domain.StockDocumentID                { return d.id }
+func (d TransferDocument) IdempotencyKey() domain.IdempotencyKey     { return d.idempotencyKey }
+func (d TransferDocument) PostingSequence() domain.PostingSequence   { return d.postingSequence }
+func (d TransferDocument) OccurredOn() domain.BusinessDate           { return d.occurredOn }
+func (d TransferDocument) PostedAt() domain.UTCInstant               { return d.postedAt }
+func (d TransferDocument) Notes() domain.Option[domain.NonEmptyText] { return d.notes }
+func (d TransferDocument) Lines() []TransferLine {
+	lines := make([]TransferLine, len(d.lines))
+	copy(lines, d.lines)
+	return lines
+}
+
+// TransferLine is one moved lot quantity: the OUT line consuming the source
+// lot and the IN line creating the destination lot, both at the same value.
+type TransferLine struct {
+	outLineID            domain.StockDocumentLineID
+	inLineID             domain.StockDocumentLineID
+	lineOrder            domain.LineOrder
+	itemID               domain.ItemID
+	quantity             domain.AtomicQuantity
+	enteredUnit          domain.UnitCode
+	enteredPackagingName domain.Option[domain.NonEmptyText]
+	conversion           domain.UnitConversion
+	inventoryValue       domain.InventoryValue
+	sourceLotID          domain.InventoryLotID
+	allocationID         domain.LotAllocationID
+	fromLocationID       domain.StockLocationID
+	lotID                domain.InventoryLotID
+	toLocationID         domain.StockLocationID
+}
+
+type TransferLineParams struct {
+	OutLineID            domain.StockDocumentLineID
+	InLineID             domain.StockDocumentLineID
+	LineOrder            domain.LineOrder
+	ItemID               domain.ItemID
+	Quantity             domain.AtomicQuantity
+	EnteredUnit          domain.UnitCode
+	EnteredPackagingName domain.Option[domain.NonEmptyText]
+	Conversion           domain.UnitConversion
+	InventoryValue       domain.InventoryValue
+	SourceLotID          domain.InventoryLotID
+	AllocationID         domain.LotAllocationID
+	FromLocationID       domain.StockLocationID
+	LotID                domain.InventoryLotID
+	ToLocationID         domain.StockLocationID
+}
+
+func NewTransferLine(params TransferLineParams) (TransferLine, error) {
+	violations := make([]domain.Violation, 0, 12)
+	if params.OutLineID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "out_line_id", Code: domain.ViolationRequired})
+	}
+	if params.InLineID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "in_line_id", Code: domain.ViolationRequired})
+	}
+	if params.LineOrder.IsZero() {
+		violations = append(violations, domain.Violation{Field: "line_order", Code: domain.ViolationRequired})
+	}
+	if params.ItemID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "item_id", Code: domain.ViolationRequired})
+	}
+	if params.Quantity.Int64() <= 0 {
+		violations = append(violations, domain.Violation{Field: "quantity_atomic", Code: domain.ViolationNotPositive})
+	}
+	if params.EnteredUnit.String() == "" {
+		violations = append(violations, domain.Violation{Field: "entered_unit_code", Code: domain.ViolationRequired})
+	}
+	if params.Conversion.IsZero() {
+		violations = append(violations, domain.Violation{Field: "conversion", Code: domain.ViolationRequired})
+	}
+	if params.SourceLotID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "source_lot_id", Code: domain.ViolationRequired})
+	}
+	if params.AllocationID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "allocation_id", Code: domain.ViolationRequired})
+	}
+	if params.LotID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "lot_id", Code: domain.ViolationRequired})
+	}
+	if params.FromLocationID.IsZero() || params.ToLocationID.IsZero() || params.FromLocationID == params.ToLocationID {
+		violations = append(violations, domain.Violation{Field: "to_location_id", Code: domain.ViolationInvariant})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return TransferLine{}, err
+	}
+	return TransferLine{
+		outLineID: params.OutLineID, inLineID: params.InLineID, lineOrder: params.LineOrder,
+		itemID: params.ItemID, quantity: params.Quantity, enteredUnit: params.EnteredUnit,
+		enteredPackagingName: params.EnteredPackagingName, conversion: params.Conversion,
+		inventoryValue: params.InventoryValue, sourceLotID: params.SourceLotID,
+		allocationID: params.AllocationID, fromLocationID: params.FromLocationID,
+		lotID: params.LotID, toLocationID: params.ToLocationID,
+	}, nil
+}
+
+func (l TransferLine) OutLineID() domain.StockDocumentLineID { return l.outLineID }
+func (l TransferLine) InLineID() domain.StockDocumentLineID  { return l.inLineID }
+func (l TransferLine) LineOrder() domain.LineOrder           { return l.lineOrder }
+func (l TransferLine) ItemID() domain.ItemID                 { return l.itemID }
+func (l TransferLine) Quantity() domain.AtomicQuantity       { return l.quantity }
+func (l TransferLine) EnteredUnit() domain.UnitCode          { return l.enteredUnit }
+func (l TransferLine) EnteredPackagingName() domain.Option[domain.NonEmptyText] {
+	return l.enteredPackagingName
+}
+func (l TransferLine) Conversion() domain.UnitConversion      { return l.conversion }
+func (l TransferLine) InventoryValue() domain.InventoryValue  { return l.inventoryValue }
+func (l TransferLine) SourceLotID() domain.InventoryLotID     { return l.sourceLotID }
+func (l TransferLine) AllocationID() domain.LotAllocationID   { return l.allocationID }
+func (l TransferLine) FromLocationID() domain.StockLocationID { return l.fromLocationID }
+func (l TransferLine) LotID() domain.InventoryLotID           { return l.lotID }
+func (l TransferLine) ToLocationID() domain.StockLocationID   { return l.toLocationID }
+
+type TransferService struct {
+	store TransferStore
+	clock Clock
+}
+
+func NewTransferService(store TransferStore, clock Clock) *TransferService {
+	if store == nil {
+		panic("transfer service requires a store")
+	}
+	if clock == nil {
+		panic("transfer service requires a clock")
+	}
+	return &TransferService{store: store, clock: clock}
+}
+
+func (s *TransferService) PostTransfer(ctx context.Context, input TransferPostInput) (TransferDocument, error) {
+	if len(input.Lines) == 0 {
+		return TransferDocument{}, domain.Invalid("lines", domain.ViolationRequired, "DOC-002")
+	}
+	postedAt, err := s.clock.Now()
+	if err != nil {
+		return TransferDocument{}, fmt.Errorf("read clock: %w", err)
+	}
+	document, err := s.store.PostTransfer(ctx, transferPostStoreInput{
+		TransferPostInput: input,
+		PostedAt:          postedAt,
+	})
+	if err != nil {
+		return TransferDocument{}, fmt.Errorf("post transfer: %w", err)
+	}
+	if err := ensurePostingClockCompatible(document.PostedAt(), postedAt); err != nil {
+		return TransferDocument{}, err
+	}
+	return document, nil
+}
+
+func (s *TransferService) GetTransfer(ctx context.Context, id domain.StockDocumentID) (TransferDocument, error) {
+	document, err := s.store.GetTransfer(ctx, id)
+	if err != nil {
+		return TransferDocument{}, fmt.Errorf("get transfer: %w", err)
+	}
+	return document, nil
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite"
+)
+
+type sqliteTransferStore struct {
+	store *sqlite.Store
+}
+
+func NewSQLiteTransferStore(store *sqlite.Store) TransferStore {
+	if store == nil {
+		panic("sqlite transfer store requires a store")
+	}
+	return &sqliteTransferStore{store: store}
+}
+
+func (s *sqliteTransferStore) PostTransfer(ctx context.Context, input transferPostStoreInput) (TransferDocument, error) {
+	lines := make([]sqlite.PostTransferLineInput, 0, len(input.Lines))
+	for _, line := range input.Lines {
+		lines = append(lines, sqlite.PostTransferLineInput{
+			LotID:        line.LotID,
+			Quantity:     line.Quantity,
+			ToLocationID: line.ToLocationID,
+		})
+	}
+	posted, err := s.store.PostTransfer(ctx, sqlite.PostTransferInput{
+		IdempotencyKey: input.IdempotencyKey,
+		OccurredOn:     input.OccurredOn,
+		PostedAt:       input.PostedAt,
+		Notes:          input.Notes,
+		Lines:          lines,
+	})
+	if err != nil {
+		return TransferDocument{}, err
+	}
+	return mapSQLitePostedTransfer(posted)
+}
+
+func (s *sqliteTransferStore) GetTransfer(ctx context.Context, id domain.StockDocumentID) (TransferDocument, error) {
+	posted, err := s.store.GetPostedTransfer(ctx, id)
+	if err != nil {
+		return TransferDocument{}, err
+	}
+	return mapSQLitePostedTransfer(posted)
+}
+
+func mapSQLitePostedTransfer(posted sqlite.PostedTransferDocument) (TransferDocument, error) {
+	sourceLines := posted.Lines()
+	lines := make([]TransferLine, 0, len(sourceLines))
+	for _, line := range sourceLines {
+		mapped, err := NewTransferLine(TransferLineParams{
+			OutLineID:            line.OutLineID(),
+			InLineID:             line.InLineID(),
+			LineOrder:            line.LineOrder(),
+			ItemID:               line.ItemID(),
+			Quantity:             line.Quantity(),
+			EnteredUnit:          line.EnteredUnit(),
+			EnteredPackagingName: line.EnteredPackagingName(),
+			Conversion:           line.Conversion(),
+			InventoryValue:       line.InventoryValue(),
+			SourceLotID:          line.SourceLotID(),
+			AllocationID:         line.AllocationID(),
+			FromLocationID:       line.FromLocationID(),
+			LotID:                line.LotID(),
+			ToLocationID:         line.ToLocationID(),
+		})
+		if err != nil {
+			return TransferDocument{}, err
+		}
+		lines = append(lines, mapped)
+	}
+	return NewTransferDocument(
+		posted.ID(),
+		posted.IdempotencyKey(),
+		posted.PostingSequence(),
+		posted.OccurredOn(),
+		posted.PostedAt(),
+		posted.Notes(),
+		lines,
+	)
+}
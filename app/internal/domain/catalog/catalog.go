@@ -141,6 +141,7 @@ type ItemParams struct {
 	Description      domain.Option[domain.NonEmptyText]
 	BaseUnit         domain.UnitCode
 	Capabilities     Capabilities
+	Category         domain.Option[domain.CategoryID]
 	DefaultSalePrice domain.Option[domain.MinorAmount]
 	ReorderQuantity  domain.Option[domain.AtomicQuantity]
 	CreatedAt        domain.UTCInstant
@@ -158,6 +159,7 @@ type Item struct {
 	description      domain.Option[domain.NonEmptyText]
 	baseUnit         domain.UnitCode
 	capabilities     Capabilities
+	category         domain.Option[domain.CategoryID]
 	defaultSalePrice domain.Option[domain.MinorAmount]
 	reorderQuantity  domain.Option[domain.AtomicQuantity]
 	createdAt        domain.UTCInstant
@@ -183,6 +185,9 @@ func NewItem(params ItemParams) (Item, error) {
 	if params.BaseUnit.String() == "" {
 		violations = append(violations, required("base_unit_code"))
 	}
+	if category, ok := params.Category.Get(); ok && category.IsZero() {
+		violations = append(violations, required("category_id"))
+	}
 	if params.ArchivedAt.IsNone() && !params.Capabilities.Any() {
 		violations = append(violations, domain.Violation{Field: "capabilities", Code: domain.ViolationRequired, InvariantID: "CAT-002"})
 	}
@@ -217,7 +222,7 @@ func NewItem(params ItemParams) (Item, error) {
 	return Item{
 		id: params.ID, name: params.Name, sku: params.SKU,
 		description: params.Description, baseUnit: params.BaseUnit,
-		capabilities:     params.Capabilities,
+		capabilities: params.Capabilities, category: params.Category,
 		defaultSalePrice: params.DefaultSalePrice, reorderQuantity: params.ReorderQuantity,
 		createdAt: params.CreatedAt, updatedAt: params.UpdatedAt,
 		archivedAt: params.ArchivedAt,
@@ -231,6 +236,7 @@ func (i Item) SKU() domain.Option[domain.SKU]                        { return i.
 func (i Item) Description() domain.Option[domain.NonEmptyText]       { return i.description }
 func (i Item) BaseUnit() domain.UnitCode                             { return i.baseUnit }
 func (i Item) Capabilities() Capabilities                            { return i.capabilities }
+func (i Item) Category() domain.Option[domain.CategoryID]            { return i.category }
 func (i Item) DefaultSalePrice() domain.Option[domain.MinorAmount]   { return i.defaultSalePrice }
 func (i Item) ReorderQuantity() domain.Option[domain.AtomicQuantity] { return i.reorderQuantity }
 func (i Item) CreatedAt() domain.UTCInstant                          { return i.createdAt }
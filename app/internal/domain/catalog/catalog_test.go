@@ -107,11 +107,35 @@ func TestItemSummaryDoesNotRequirePackagingAggregate(t *testing.T) {
 	summary, err := catalog.NewItemSummary(catalog.ItemSummaryParams{
 		ID: must(domain.NewItemID(3)), Name: must(domain.NewUniqueName("Cake")),
 		BaseUnit: must(domain.NewUnitCode("each")), Capabilities: catalog.NewCapabilities(false, true, true),
+		Category:  domain.Some(must(domain.NewCategoryID(4))),
 		CreatedAt: instant, UpdatedAt: instant,
 	})
 	if err != nil || summary.ID().Int64() != 3 || !summary.Capabilities().Sellable() {
 		t.Fatalf("item summary = %#v, %v", summary, err)
 	}
+	if category, ok := summary.Category().Get(); !ok || category.Int64() != 4 {
+		t.Fatalf("item summary category = %#v", summary.Category())
+	}
+}
+
+func TestCategoryValidatesIdentityAndArchiveOrder(t *testing.T) {
+	created := must(domain.UTCInstantFromUnixMilli(1000))
+	updated := must(domain.UTCInstantFromUnixMilli(2000))
+	category, err := catalog.NewCategory(catalog.CategoryParams{
+		ID: must(domain.NewCategoryID(1)), Name: must(domain.NewUniqueName("Cakes")),
+		CreatedAt: created, UpdatedAt: updated, ArchivedAt: domain.Some(updated),
+	})
+	if err != nil || category.Name().Display() != "Cakes" || !category.IsArchived() {
+		t.Fatalf("category = %#v, %v", category, err)
+	}
+
+	_, err = catalog.NewCategory(catalog.CategoryParams{
+		CreatedAt: updated, UpdatedAt: created, ArchivedAt: domain.None[domain.UTCInstant](),
+	})
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) || len(validation.Violations()) < 3 {
+		t.Fatalf("expected identity, name, and timestamp violations: %v", err)
+	}
 }
 
 func must[T any](value T, err error) T {
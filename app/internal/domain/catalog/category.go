@@ -0,0 +1,49 @@
+package catalog
+
+import "github.com/jerobas/saas/internal/domain"
+
+type CategoryParams struct {
+	ID         domain.CategoryID
+	Name       domain.UniqueName
+	CreatedAt  domain.UTCInstant
+	UpdatedAt  domain.UTCInstant
+	ArchivedAt domain.Option[domain.UTCInstant]
+}
+
+// Category groups items for reporting. It carries no stock meaning: changing
+// an item's category never rewrites posted documents, and reports read the
+// category the item has when the report runs.
+type Category struct {
+	id         domain.CategoryID
+	name       domain.UniqueName
+	createdAt  domain.UTCInstant
+	updatedAt  domain.UTCInstant
+	archivedAt domain.Option[domain.UTCInstant]
+}
+
+func NewCategory(params CategoryParams) (Category, error) {
+	violations := make([]domain.Violation, 0, 3)
+	if params.ID.IsZero() {
+		violations = append(violations, required("category_id"))
+	}
+	if params.Name.Display() == "" || params.Name.Key() == "" {
+		violations = append(violations, required("name"))
+	}
+	if err := domain.ValidateTimestampOrder(params.CreatedAt, params.UpdatedAt, params.ArchivedAt); err != nil {
+		violations = append(violations, validationViolations(err)...)
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Category{}, err
+	}
+	return Category{
+		id: params.ID, name: params.Name, createdAt: params.CreatedAt,
+		updatedAt: params.UpdatedAt, archivedAt: params.ArchivedAt,
+	}, nil
+}
+
+func (c Category) ID() domain.CategoryID                        { return c.id }
+func (c Category) Name() domain.UniqueName                      { return c.name }
+func (c Category) CreatedAt() domain.UTCInstant                 { return c.createdAt }
+func (c Category) UpdatedAt() domain.UTCInstant                 { return c.updatedAt }
+func (c Category) ArchivedAt() domain.Option[domain.UTCInstant] { return c.archivedAt }
+func (c Category) IsArchived() bool                             { return c.archivedAt.IsSome() }
@@ -9,6 +9,7 @@ type ItemSummaryParams struct {
 	Description      domain.Option[domain.NonEmptyText]
 	BaseUnit         domain.UnitCode
 	Capabilities     Capabilities
+	Category         domain.Option[domain.CategoryID]
 	DefaultSalePrice domain.Option[domain.MinorAmount]
 	ReorderQuantity  domain.Option[domain.AtomicQuantity]
 	CreatedAt        domain.UTCInstant
@@ -24,8 +25,9 @@ func NewItemSummary(params ItemSummaryParams) (ItemSummary, error) {
 	item, err := NewItem(ItemParams{
 		ID: params.ID, Name: params.Name, SKU: params.SKU,
 		Description: params.Description, BaseUnit: params.BaseUnit,
-		Capabilities: params.Capabilities, DefaultSalePrice: params.DefaultSalePrice,
-		ReorderQuantity: params.ReorderQuantity, CreatedAt: params.CreatedAt,
+		Capabilities: params.Capabilities, Category: params.Category,
+		DefaultSalePrice: params.DefaultSalePrice,
+		ReorderQuantity:  params.ReorderQuantity, CreatedAt: params.CreatedAt,
 		UpdatedAt: params.UpdatedAt, ArchivedAt: params.ArchivedAt,
 	})
 	if err != nil {
@@ -40,6 +42,7 @@ func (s ItemSummary) SKU() domain.Option[domain.SKU]                  { return s
 func (s ItemSummary) Description() domain.Option[domain.NonEmptyText] { return s.item.Description() }
 func (s ItemSummary) BaseUnit() domain.UnitCode                       { return s.item.BaseUnit() }
 func (s ItemSummary) Capabilities() Capabilities                      { return s.item.Capabilities() }
+func (s ItemSummary) Category() domain.Option[domain.CategoryID]      { return s.item.Category() }
 func (s ItemSummary) DefaultSalePrice() domain.Option[domain.MinorAmount] {
 	return s.item.DefaultSalePrice()
 }
@@ -45,12 +45,15 @@ const (
 	DocumentProduction DocumentKind = "PRODUCTION"
 	DocumentAdjustment DocumentKind = "ADJUSTMENT"
 	DocumentReversal   DocumentKind = "REVERSAL"
+	DocumentReturn     DocumentKind = "RETURN"
+	DocumentTransfer   DocumentKind = "TRANSFER"
 )
 
 func ParseDocumentKind(raw string) (DocumentKind, error) {
 	value := DocumentKind(raw)
 	switch value {
-	case DocumentPurchase, DocumentSale, DocumentProduction, DocumentAdjustment, DocumentReversal:
+	case DocumentPurchase, DocumentSale, DocumentProduction, DocumentAdjustment, DocumentReversal,
+		DocumentReturn, DocumentTransfer:
 		return value, nil
 	default:
 		return "", Invalid("document_kind", ViolationInvalidEnum, "DOC-001")
@@ -89,6 +92,8 @@ const (
 	ReasonDamage               DocumentReason = "DAMAGE"
 	ReasonDocumentedCorrection DocumentReason = "DOCUMENTED_CORRECTION"
 	ReasonExactReversal        DocumentReason = "EXACT_REVERSAL"
+	ReasonCustomerReturn       DocumentReason = "CUSTOMER_RETURN"
+	ReasonSupplierReturn       DocumentReason = "SUPPLIER_RETURN"
 )
 
 func ParseDocumentReason(kind DocumentKind, raw string) (Option[DocumentReason], error) {
@@ -96,7 +101,7 @@ func ParseDocumentReason(kind DocumentKind, raw string) (Option[DocumentReason],
 		return None[DocumentReason](), err
 	}
 	if raw == "" {
-		if kind == DocumentAdjustment || kind == DocumentReversal {
+		if kind == DocumentAdjustment || kind == DocumentReversal || kind == DocumentReturn {
 			return None[DocumentReason](), Invalid("document_reason", ViolationRequired, "ADJ-001")
 		}
 		return None[DocumentReason](), nil
@@ -108,7 +113,7 @@ func ParseDocumentReason(kind DocumentKind, raw string) (Option[DocumentReason],
 		valid = reason == ReasonFreeStock
 	case DocumentSale:
 		valid = reason == ReasonPromotion || reason == ReasonSample
-	case DocumentProduction:
+	case DocumentProduction, DocumentTransfer:
 		valid = false
 	case DocumentAdjustment:
 		switch reason {
@@ -118,6 +123,8 @@ func ParseDocumentReason(kind DocumentKind, raw string) (Option[DocumentReason],
 		}
 	case DocumentReversal:
 		valid = reason == ReasonExactReversal
+	case DocumentReturn:
+		valid = reason == ReasonCustomerReturn || reason == ReasonSupplierReturn
 	}
 	if !valid {
 		return None[DocumentReason](), Invalid("document_reason", ViolationInvalidEnum, "ADJ-001")
@@ -125,6 +132,20 @@ func ParseDocumentReason(kind DocumentKind, raw string) (Option[DocumentReason],
 	return Some(reason), nil
 }
 
+// ParseDocumentReasonCode accepts any known reason without checking it against
+// a document kind. Use ParseDocumentReason when the kind is known.
+func ParseDocumentReasonCode(raw string) (DocumentReason, error) {
+	reason := DocumentReason(raw)
+	switch reason {
+	case ReasonFreeStock, ReasonPromotion, ReasonSample, ReasonOpeningBalance, ReasonPhysicalCount,
+		ReasonWaste, ReasonExpiry, ReasonDamage, ReasonDocumentedCorrection, ReasonExactReversal,
+		ReasonCustomerReturn, ReasonSupplierReturn:
+		return reason, nil
+	default:
+		return "", Invalid("document_reason", ViolationInvalidEnum, "ADJ-001")
+	}
+}
+
 func (r DocumentReason) String() string { return string(r) }
 
 type AllocationEffect string
@@ -164,6 +185,28 @@ func ParseArchiveFilter(raw string) (ArchiveFilter, error) {
 
 func (f ArchiveFilter) String() string { return string(f) }
 
+// ReversalFilter selects documents by whether an exact reversal points at
+// them. Reversal documents themselves are never reversed.
+type ReversalFilter string
+
+const (
+	ReversalFilterAll         ReversalFilter = "ALL"
+	ReversalFilterReversed    ReversalFilter = "REVERSED"
+	ReversalFilterNotReversed ReversalFilter = "NOT_REVERSED"
+)
+
+func ParseReversalFilter(raw string) (ReversalFilter, error) {
+	value := ReversalFilter(raw)
+	switch value {
+	case ReversalFilterAll, ReversalFilterReversed, ReversalFilterNotReversed:
+		return value, nil
+	default:
+		return "", Invalid("reversal_filter", ViolationInvalidEnum, "")
+	}
+}
+
+func (f ReversalFilter) String() string { return string(f) }
+
 type LotState string
 
 const (
@@ -183,3 +226,172 @@ func ParseLotState(raw string) (LotState, error) {
 }
 
 func (s LotState) String() string { return string(s) }
+
+// PurchaseOrderStatus is the lifecycle of a supplier order. Only receipts
+// move an order to PARTIALLY_RECEIVED, and CLOSED and CANCELLED are final.
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderDraft             PurchaseOrderStatus = "DRAFT"
+	PurchaseOrderSent              PurchaseOrderStatus = "SENT"
+	PurchaseOrderPartiallyReceived PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderClosed            PurchaseOrderStatus = "CLOSED"
+	PurchaseOrderCancelled         PurchaseOrderStatus = "CANCELLED"
+)
+
+func ParsePurchaseOrderStatus(raw string) (PurchaseOrderStatus, error) {
+	value := PurchaseOrderStatus(raw)
+	switch value {
+	case PurchaseOrderDraft, PurchaseOrderSent, PurchaseOrderPartiallyReceived, PurchaseOrderClosed, PurchaseOrderCancelled:
+		return value, nil
+	default:
+		return "", Invalid("purchase_order_status", ViolationInvalidEnum, "POR-002")
+	}
+}
+
+func (s PurchaseOrderStatus) String() string { return string(s) }
+
+// CustomerOrderStatus is the lifecycle of a customer order. An open order is
+// fulfilled by exactly one sale or cancelled; both are final.
+type CustomerOrderStatus string
+
+const (
+	CustomerOrderOpen      CustomerOrderStatus = "OPEN"
+	CustomerOrderFulfilled CustomerOrderStatus = "FULFILLED"
+	CustomerOrderCancelled CustomerOrderStatus = "CANCELLED"
+)
+
+func ParseCustomerOrderStatus(raw string) (CustomerOrderStatus, error) {
+	value := CustomerOrderStatus(raw)
+	switch value {
+	case CustomerOrderOpen, CustomerOrderFulfilled, CustomerOrderCancelled:
+		return value, nil
+	default:
+		return "", Invalid("customer_order_status", ViolationInvalidEnum, "COR-002")
+	}
+}
+
+func (s CustomerOrderStatus) String() string { return string(s) }
+
+// DraftKind names the posting form a draft belongs to. Posting a draft posts
+// one stock document of the matching kind.
+type DraftKind string
+
+const (
+	DraftPurchase   DraftKind = "PURCHASE"
+	DraftSale       DraftKind = "SALE"
+	DraftAdjustment DraftKind = "ADJUSTMENT"
+	DraftProduction DraftKind = "PRODUCTION"
+)
+
+func ParseDraftKind(raw string) (DraftKind, error) {
+	value := DraftKind(raw)
+	switch value {
+	case DraftPurchase, DraftSale, DraftAdjustment, DraftProduction:
+		return value, nil
+	default:
+		return "", Invalid("draft_kind", ViolationInvalidEnum, "DRF-001")
+	}
+}
+
+func (k DraftKind) String() string { return string(k) }
+
+// DocumentKind is the kind of stock document a draft of this kind posts.
+func (k DraftKind) DocumentKind() DocumentKind { return DocumentKind(k) }
+
+// StockCountStatus is the lifecycle of a physical count. Observations change
+// only while a count is OPEN; CLOSED and CANCELLED are final.
+type StockCountStatus string
+
+const (
+	StockCountOpen      StockCountStatus = "OPEN"
+	StockCountClosed    StockCountStatus = "CLOSED"
+	StockCountCancelled StockCountStatus = "CANCELLED"
+)
+
+func ParseStockCountStatus(raw string) (StockCountStatus, error) {
+	value := StockCountStatus(raw)
+	switch value {
+	case StockCountOpen, StockCountClosed, StockCountCancelled:
+		return value, nil
+	default:
+		return "", Invalid("stock_count_status", ViolationInvalidEnum, "SCT-002")
+	}
+}
+
+func (s StockCountStatus) String() string { return string(s) }
+
+// PaymentMethod is how a payment was made. CASH, PIX, and CARD settle the
+// sale or purchase; ON_ACCOUNT records the part of a sale charged to the
+// customer's account, which stays outstanding until it is settled.
+type PaymentMethod string
+
+const (
+	PaymentCash      PaymentMethod = "CASH"
+	PaymentPix       PaymentMethod = "PIX"
+	PaymentCard      PaymentMethod = "CARD"
+	PaymentOnAccount PaymentMethod = "ON_ACCOUNT"
+)
+
+func ParsePaymentMethod(raw string) (PaymentMethod, error) {
+	value := PaymentMethod(raw)
+	switch value {
+	case PaymentCash, PaymentPix, PaymentCard, PaymentOnAccount:
+		return value, nil
+	default:
+		return "", Invalid("payment_method", ViolationInvalidEnum, "PAY-001")
+	}
+}
+
+func (m PaymentMethod) String() string { return string(m) }
+
+// Settles reports whether a payment with this method reduces the outstanding
+// balance of its sale or purchase. Supplier payments only use settling methods.
+func (m PaymentMethod) Settles() bool { return m != PaymentOnAccount }
+
+// CashMovementKind is a manual movement of cash into or out of the register
+// drawer, such as change brought in or money taken to the bank.
+type CashMovementKind string
+
+const (
+	CashIn  CashMovementKind = "CASH_IN"
+	CashOut CashMovementKind = "CASH_OUT"
+)
+
+func ParseCashMovementKind(raw string) (CashMovementKind, error) {
+	value := CashMovementKind(raw)
+	switch value {
+	case CashIn, CashOut:
+		return value, nil
+	default:
+		return "", Invalid("cash_movement_kind", ViolationInvalidEnum, "REG-002")
+	}
+}
+
+func (k CashMovementKind) String() string { return string(k) }
+
+// ExpenseCategory classifies money spent on running the business outside
+// inventory.
+type ExpenseCategory string
+
+const (
+	ExpenseRent        ExpenseCategory = "RENT"
+	ExpenseGas         ExpenseCategory = "GAS"
+	ExpenseElectricity ExpenseCategory = "ELECTRICITY"
+	ExpenseWater       ExpenseCategory = "WATER"
+	ExpensePackaging   ExpenseCategory = "PACKAGING"
+	ExpenseWages       ExpenseCategory = "WAGES"
+	ExpenseOther       ExpenseCategory = "OTHER"
+)
+
+func ParseExpenseCategory(raw string) (ExpenseCategory, error) {
+	value := ExpenseCategory(raw)
+	switch value {
+	case ExpenseRent, ExpenseGas, ExpenseElectricity, ExpenseWater, ExpensePackaging, ExpenseWages, ExpenseOther:
+		return value, nil
+	default:
+		return "", Invalid("expense_category", ViolationInvalidEnum, "EXP-001")
+	}
+}
+
+func (c ExpenseCategory) String() string { return string(c) }
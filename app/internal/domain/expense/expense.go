@@ -0,0 +1,114 @@
+// Package expense holds the append-only ledger of money spent on running the
+// business outside inventory.
+package expense
+
+import "github.com/jerobas/saas/internal/domain"
+
+type Params struct {
+	ID                domain.ExpenseID
+	IdempotencyKey    domain.IdempotencyKey
+	Category          domain.ExpenseCategory
+	Amount            domain.MinorAmount
+	OccurredOn        domain.BusinessDate
+	SupplierID        domain.Option[domain.CounterpartyID]
+	Notes             domain.Option[domain.NonEmptyText]
+	CorrectsExpenseID domain.Option[domain.ExpenseID]
+	CorrectedByID     domain.Option[domain.ExpenseID]
+	RecordedAt        domain.UTCInstant
+}
+
+// Expense is one immutable ledger entry. A correction replaces the expense it
+// corrects with its own category, amount, date, supplier, and notes; a zero
+// correction voids it. An expense that a later correction replaced is no
+// longer current and counts nowhere.
+type Expense struct {
+	id                domain.ExpenseID
+	idempotencyKey    domain.IdempotencyKey
+	category          domain.ExpenseCategory
+	amount            domain.MinorAmount
+	occurredOn        domain.BusinessDate
+	supplierID        domain.Option[domain.CounterpartyID]
+	notes             domain.Option[domain.NonEmptyText]
+	correctsExpenseID domain.Option[domain.ExpenseID]
+	correctedByID     domain.Option[domain.ExpenseID]
+	recordedAt        domain.UTCInstant
+}
+
+func New(params Params) (Expense, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.ID.IsZero() {
+		violations = append(violations, required("expense_id"))
+	}
+	if params.IdempotencyKey.String() == "" {
+		violations = append(violations, domain.Violation{Field: "idempotency_key", Code: domain.ViolationRequired, InvariantID: "DOC-003"})
+	}
+	if _, err := domain.ParseExpenseCategory(params.Category.String()); err != nil {
+		violations = append(violations, domain.Violation{Field: "category", Code: domain.ViolationInvalidEnum, InvariantID: "EXP-001"})
+	}
+	if params.Amount.IsZero() && params.CorrectsExpenseID.IsNone() {
+		violations = append(violations, domain.Violation{Field: "amount_minor", Code: domain.ViolationNotPositive, InvariantID: "EXP-001"})
+	}
+	if params.OccurredOn.IsZero() {
+		violations = append(violations, domain.Violation{Field: "occurred_on", Code: domain.ViolationRequired, InvariantID: "EXP-001"})
+	}
+	if supplier, ok := params.SupplierID.Get(); ok && supplier.IsZero() {
+		violations = append(violations, required("supplier_id"))
+	}
+	if notes, ok := params.Notes.Get(); ok && notes.String() == "" {
+		violations = append(violations, required("notes"))
+	}
+	if corrects, ok := params.CorrectsExpenseID.Get(); ok && (corrects.IsZero() || corrects == params.ID) {
+		violations = append(violations, domain.Violation{Field: "corrects_expense_id", Code: domain.ViolationInvariant, InvariantID: "EXP-002"})
+	}
+	if correctedBy, ok := params.CorrectedByID.Get(); ok && (correctedBy.IsZero() || correctedBy == params.ID) {
+		violations = append(violations, domain.Violation{Field: "corrected_by_id", Code: domain.ViolationInvariant, InvariantID: "EXP-002"})
+	}
+	if params.RecordedAt.IsZero() {
+		violations = append(violations, required("recorded_at"))
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Expense{}, err
+	}
+	return Expense{
+		id: params.ID, idempotencyKey: params.IdempotencyKey, category: params.Category,
+		amount: params.Amount, occurredOn: params.OccurredOn, supplierID: params.SupplierID,
+		notes: params.Notes, correctsExpenseID: params.CorrectsExpenseID,
+		correctedByID: params.CorrectedByID, recordedAt: params.RecordedAt,
+	}, nil
+}
+
+func (e Expense) ID() domain.ExpenseID                             { return e.id }
+func (e Expense) IdempotencyKey() domain.IdempotencyKey            { return e.idempotencyKey }
+func (e Expense) Category() domain.ExpenseCategory                 { return e.category }
+func (e Expense) Amount() domain.MinorAmount                       { return e.amount }
+func (e Expense) OccurredOn() domain.BusinessDate                  { return e.occurredOn }
+func (e Expense) SupplierID() domain.Option[domain.CounterpartyID] { return e.supplierID }
+func (e Expense) Notes() domain.Option[domain.NonEmptyText]        { return e.notes }
+func (e Expense) CorrectsExpenseID() domain.Option[domain.ExpenseID] {
+	return e.correctsExpenseID
+}
+func (e Expense) CorrectedByID() domain.Option[domain.ExpenseID] { return e.correctedByID }
+func (e Expense) RecordedAt() domain.UTCInstant                  { return e.recordedAt }
+
+// IsCurrent reports whether no correction has replaced the expense.
+func (e Expense) IsCurrent() bool { return e.correctedByID.IsNone() }
+
+// IsVoid reports whether the expense is a correction that cancels the one it
+// replaced.
+func (e Expense) IsVoid() bool { return e.amount.IsZero() }
+
+// CanCorrect reports why a correction recorded at recordedAt may not replace
+// the expense: it was already corrected, or the correction predates it.
+func (e Expense) CanCorrect(recordedAt domain.UTCInstant) error {
+	if !e.IsCurrent() {
+		return domain.Invalid("corrects_expense_id", domain.ViolationInvariant, "EXP-002")
+	}
+	if recordedAt.Before(e.recordedAt) {
+		return domain.Invalid("recorded_at", domain.ViolationOutOfRange, "EXP-002")
+	}
+	return nil
+}
+
+func required(field string) domain.Violation {
+	return domain.Violation{Field: field, Code: domain.ViolationRequired}
+}
@@ -0,0 +1,65 @@
+package expense_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/expense"
+)
+
+func TestExpenseCorrectionsReplaceTheCurrentEntryOnce(t *testing.T) {
+	params := expense.Params{
+		ID:             must(domain.NewExpenseID(1)),
+		IdempotencyKey: must(domain.NewIdempotencyKey("expense-1")),
+		Category:       domain.ExpenseRent,
+		Amount:         must(domain.NewMinorAmount(150_000)),
+		OccurredOn:     must(domain.ParseBusinessDate("2026-10-01")),
+		RecordedAt:     must(domain.UTCInstantFromUnixMilli(1_000)),
+	}
+	rent, err := expense.New(params)
+	if err != nil {
+		t.Fatalf("new expense: %v", err)
+	}
+	if !rent.IsCurrent() || rent.IsVoid() {
+		t.Fatalf("rent = %#v, want a current entry", rent)
+	}
+	if err := rent.CanCorrect(must(domain.UTCInstantFromUnixMilli(999))); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("early correction error = %v", err)
+	}
+
+	zero := params
+	zero.Amount = must(domain.NewMinorAmount(0))
+	if _, err := expense.New(zero); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("zero expense error = %v", err)
+	}
+	void := zero
+	void.ID = must(domain.NewExpenseID(2))
+	void.CorrectsExpenseID = domain.Some(params.ID)
+	voided, err := expense.New(void)
+	if err != nil || !voided.IsVoid() {
+		t.Fatalf("void correction = %#v, %v", voided, err)
+	}
+
+	corrected := params
+	corrected.CorrectedByID = domain.Some(void.ID)
+	replaced, err := expense.New(corrected)
+	if err != nil {
+		t.Fatalf("corrected expense: %v", err)
+	}
+	if replaced.IsCurrent() || !errors.Is(replaced.CanCorrect(params.RecordedAt), domain.ErrValidation) {
+		t.Fatalf("replaced expense = %#v, want a closed entry", replaced)
+	}
+	invalid := params
+	invalid.Category = "TRAVEL"
+	if _, err := expense.New(invalid); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("invalid category error = %v", err)
+	}
+}
+
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
@@ -18,6 +18,7 @@ func (id positiveID) String() string { return strconv.FormatInt(id.value, 10) }
 type ItemID struct{ positiveID }
 type PackagingID struct{ positiveID }
 type CounterpartyID struct{ positiveID }
+type CategoryID struct{ positiveID }
 type RecipeID struct{ positiveID }
 type RecipeRevisionID struct{ positiveID }
 type RecipeComponentID struct{ positiveID }
@@ -25,6 +26,19 @@ type StockDocumentID struct{ positiveID }
 type StockDocumentLineID struct{ positiveID }
 type InventoryLotID struct{ positiveID }
 type LotAllocationID struct{ positiveID }
+type StockLocationID struct{ positiveID }
+type PurchaseOrderID struct{ positiveID }
+type PurchaseOrderLineID struct{ positiveID }
+type CustomerOrderID struct{ positiveID }
+type CustomerOrderLineID struct{ positiveID }
+type StockCountID struct{ positiveID }
+type StockCountLineID struct{ positiveID }
+type SalePaymentID struct{ positiveID }
+type SupplierPaymentID struct{ positiveID }
+type RegisterSessionID struct{ positiveID }
+type CashMovementID struct{ positiveID }
+type ExpenseID struct{ positiveID }
+type PriceListID struct{ positiveID }
 
 func NewItemID(value int64) (ItemID, error) {
 	id, err := newPositiveID("item_id", value)
@@ -38,6 +52,10 @@ func NewCounterpartyID(value int64) (CounterpartyID, error) {
 	id, err := newPositiveID("counterparty_id", value)
 	return CounterpartyID{id}, err
 }
+func NewCategoryID(value int64) (CategoryID, error) {
+	id, err := newPositiveID("category_id", value)
+	return CategoryID{id}, err
+}
 func NewRecipeID(value int64) (RecipeID, error) {
 	id, err := newPositiveID("recipe_id", value)
 	return RecipeID{id}, err
@@ -66,6 +84,58 @@ func NewLotAllocationID(value int64) (LotAllocationID, error) {
 	id, err := newPositiveID("lot_allocation_id", value)
 	return LotAllocationID{id}, err
 }
+func NewStockLocationID(value int64) (StockLocationID, error) {
+	id, err := newPositiveID("stock_location_id", value)
+	return StockLocationID{id}, err
+}
+func NewPurchaseOrderID(value int64) (PurchaseOrderID, error) {
+	id, err := newPositiveID("purchase_order_id", value)
+	return PurchaseOrderID{id}, err
+}
+func NewPurchaseOrderLineID(value int64) (PurchaseOrderLineID, error) {
+	id, err := newPositiveID("purchase_order_line_id", value)
+	return PurchaseOrderLineID{id}, err
+}
+func NewCustomerOrderID(value int64) (CustomerOrderID, error) {
+	id, err := newPositiveID("customer_order_id", value)
+	return CustomerOrderID{id}, err
+}
+func NewCustomerOrderLineID(value int64) (CustomerOrderLineID, error) {
+	id, err := newPositiveID("customer_order_line_id", value)
+	return CustomerOrderLineID{id}, err
+}
+func NewStockCountID(value int64) (StockCountID, error) {
+	id, err := newPositiveID("stock_count_id", value)
+	return StockCountID{id}, err
+}
+func NewStockCountLineID(value int64) (StockCountLineID, error) {
+	id, err := newPositiveID("stock_count_line_id", value)
+	return StockCountLineID{id}, err
+}
+func NewSalePaymentID(value int64) (SalePaymentID, error) {
+	id, err := newPositiveID("sale_payment_id", value)
+	return SalePaymentID{id}, err
+}
+func NewSupplierPaymentID(value int64) (SupplierPaymentID, error) {
+	id, err := newPositiveID("supplier_payment_id", value)
+	return SupplierPaymentID{id}, err
+}
+func NewRegisterSessionID(value int64) (RegisterSessionID, error) {
+	id, err := newPositiveID("register_session_id", value)
+	return RegisterSessionID{id}, err
+}
+func NewCashMovementID(value int64) (CashMovementID, error) {
+	id, err := newPositiveID("cash_movement_id", value)
+	return CashMovementID{id}, err
+}
+func NewExpenseID(value int64) (ExpenseID, error) {
+	id, err := newPositiveID("expense_id", value)
+	return ExpenseID{id}, err
+}
+func NewPriceListID(value int64) (PriceListID, error) {
+	id, err := newPositiveID("price_list_id", value)
+	return PriceListID{id}, err
+}
 
 type PostingSequence struct{ positiveID }
 type RevisionNumber struct{ positiveID }
@@ -0,0 +1,71 @@
+package inventory
+
+import "github.com/jerobas/saas/internal/domain"
+
+type CostSource string
+
+const (
+	CostSourceAverageValue CostSource = "AVERAGE_VALUE"
+	CostSourceLastPurchase CostSource = "LAST_PURCHASE"
+	CostSourceUnavailable  CostSource = "UNAVAILABLE"
+)
+
+type CostBasisParams struct {
+	Balance               Balance
+	BaseUnit              domain.UnitCode
+	BaseConversion        domain.UnitConversion
+	LastPurchaseUnitValue domain.Option[domain.Fraction]
+}
+
+// CostBasis holds what is known about the current cost of one item: its
+// balance, its base unit, and the value per atomic unit of its latest
+// unreversed paid purchase.
+type CostBasis struct {
+	balance               Balance
+	baseUnit              domain.UnitCode
+	baseConversion        domain.UnitConversion
+	lastPurchaseUnitValue domain.Option[domain.Fraction]
+}
+
+func NewCostBasis(params CostBasisParams) (CostBasis, error) {
+	violations := make([]domain.Violation, 0, 4)
+	if params.Balance.ItemID().IsZero() {
+		violations = append(violations, required("balance"))
+	}
+	if params.BaseUnit.String() == "" {
+		violations = append(violations, required("base_unit_code"))
+	}
+	if params.BaseConversion.IsZero() {
+		violations = append(violations, required("base_conversion"))
+	}
+	if value, ok := params.LastPurchaseUnitValue.Get(); ok && !value.IsValid() {
+		violations = append(violations, domain.Violation{Field: "last_purchase_unit_value", Code: domain.ViolationInvariant})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return CostBasis{}, err
+	}
+	return CostBasis{
+		balance: params.Balance, baseUnit: params.BaseUnit, baseConversion: params.BaseConversion,
+		lastPurchaseUnitValue: params.LastPurchaseUnitValue,
+	}, nil
+}
+
+func (c CostBasis) Balance() Balance                      { return c.balance }
+func (c CostBasis) ItemID() domain.ItemID                 { return c.balance.ItemID() }
+func (c CostBasis) BaseUnit() domain.UnitCode             { return c.baseUnit }
+func (c CostBasis) BaseConversion() domain.UnitConversion { return c.baseConversion }
+func (c CostBasis) LastPurchaseUnitValue() domain.Option[domain.Fraction] {
+	return c.lastPurchaseUnitValue
+}
+
+// UnitValue prices one atomic unit at the current weighted average while the
+// item has stock, and otherwise at its last purchase.
+func (c CostBasis) UnitValue() (domain.Fraction, CostSource) {
+	if average, ok := c.balance.AverageValuePerAtomicUnit(); ok {
+		return average, CostSourceAverageValue
+	}
+	if value, ok := c.lastPurchaseUnitValue.Get(); ok {
+		return value, CostSourceLastPurchase
+	}
+	return domain.Fraction{}, CostSourceUnavailable
+}
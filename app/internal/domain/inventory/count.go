@@ -0,0 +1,322 @@
+package inventory
+
+import "github.com/jerobas/saas/internal/domain"
+
+type CountLineParams struct {
+	ID                 domain.StockCountLineID
+	CountID            domain.StockCountID
+	ItemID             domain.ItemID
+	LotID              domain.Option[domain.InventoryLotID]
+	LotCode            domain.Option[domain.NonEmptyText]
+	ExpiresOn          domain.Option[domain.BusinessDate]
+	ExpectedQuantity   domain.AtomicQuantity
+	ExpectedValue      domain.InventoryValue
+	ObservedQuantity   domain.Option[domain.AtomicQuantity]
+	ChangedSinceFreeze bool
+}
+
+// CountLine is one item, or one lot of an item, in a physical count. The
+// expected quantity and value were frozen when the count started; the
+// observed quantity is None until the line is counted. ChangedSinceFreeze
+// reports that a document posted after the freeze moved the item, so the
+// frozen expectation may no longer match the shelf.
+type CountLine struct {
+	id                 domain.StockCountLineID
+	countID            domain.StockCountID
+	itemID             domain.ItemID
+	lotID              domain.Option[domain.InventoryLotID]
+	lotCode            domain.Option[domain.NonEmptyText]
+	expiresOn          domain.Option[domain.BusinessDate]
+	expectedQuantity   domain.AtomicQuantity
+	expectedValue      domain.InventoryValue
+	observedQuantity   domain.Option[domain.AtomicQuantity]
+	changedSinceFreeze bool
+}
+
+func NewCountLine(params CountLineParams) (CountLine, error) {
+	violations := make([]domain.Violation, 0, 4)
+	if params.ID.IsZero() {
+		violations = append(violations, required("stock_count_line_id"))
+	}
+	if params.CountID.IsZero() {
+		violations = append(violations, required("stock_count_id"))
+	}
+	if params.ItemID.IsZero() {
+		violations = append(violations, required("item_id"))
+	}
+	if params.ExpectedQuantity.IsZero() && !params.ExpectedValue.IsZero() {
+		violations = append(violations, domain.Violation{Field: "expected_value_micro", Code: domain.ViolationInvariant, InvariantID: "SCT-001"})
+	}
+	if params.LotID.IsNone() && (params.LotCode.IsSome() || params.ExpiresOn.IsSome()) {
+		violations = append(violations, domain.Violation{Field: "lot_id", Code: domain.ViolationRequired, InvariantID: "SCT-001"})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return CountLine{}, err
+	}
+	return CountLine{
+		id: params.ID, countID: params.CountID, itemID: params.ItemID, lotID: params.LotID,
+		lotCode: params.LotCode, expiresOn: params.ExpiresOn,
+		expectedQuantity: params.ExpectedQuantity, expectedValue: params.ExpectedValue,
+		observedQuantity: params.ObservedQuantity, changedSinceFreeze: params.ChangedSinceFreeze,
+	}, nil
+}
+
+func (l CountLine) ID() domain.StockCountLineID                   { return l.id }
+func (l CountLine) CountID() domain.StockCountID                  { return l.countID }
+func (l CountLine) ItemID() domain.ItemID                         { return l.itemID }
+func (l CountLine) LotID() domain.Option[domain.InventoryLotID]   { return l.lotID }
+func (l CountLine) LotCode() domain.Option[domain.NonEmptyText]   { return l.lotCode }
+func (l CountLine) ExpiresOn() domain.Option[domain.BusinessDate] { return l.expiresOn }
+func (l CountLine) ExpectedQuantity() domain.AtomicQuantity       { return l.expectedQuantity }
+func (l CountLine) ExpectedValue() domain.InventoryValue          { return l.expectedValue }
+func (l CountLine) ObservedQuantity() domain.Option[domain.AtomicQuantity] {
+	return l.observedQuantity
+}
+func (l CountLine) ChangedSinceFreeze() bool { return l.changedSinceFreeze }
+func (l CountLine) IsCounted() bool          { return l.observedQuantity.IsSome() }
+
+// Variance is the observed less the expected quantity in atomic units:
+// positive for a surplus, negative for a shortage. It is None until the line
+// is counted.
+func (l CountLine) Variance() domain.Option[int64] {
+	observed, ok := l.observedQuantity.Get()
+	if !ok {
+		return domain.None[int64]()
+	}
+	return domain.Some(observed.Int64() - l.expectedQuantity.Int64())
+}
+
+// VarianceValue is the variance valued at the frozen unit value, rounded half
+// up, in signed micro units. It is None for an uncounted line and for a
+// surplus on a line that expected nothing, which has no frozen unit value.
+func (l CountLine) VarianceValue() domain.Option[int64] {
+	variance, ok := l.Variance().Get()
+	if !ok {
+		return domain.None[int64]()
+	}
+	if variance == 0 {
+		return domain.Some(int64(0))
+	}
+	unitValue, ok := l.expectedValue.Per(l.expectedQuantity)
+	if !ok {
+		return domain.None[int64]()
+	}
+	magnitude := variance
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+	quantity, err := domain.NewFraction(magnitude, 1)
+	if err != nil {
+		return domain.None[int64]()
+	}
+	value, err := unitValue.Multiply(quantity)
+	if err != nil {
+		return domain.None[int64]()
+	}
+	rounded, err := value.RoundHalfUp()
+	if err != nil {
+		return domain.None[int64]()
+	}
+	if variance < 0 {
+		rounded = -rounded
+	}
+	return domain.Some(rounded)
+}
+
+type CountParams struct {
+	ID                    domain.StockCountID
+	Status                domain.StockCountStatus
+	CountedOn             domain.BusinessDate
+	FrozenPostingSequence int64
+	Notes                 domain.Option[domain.NonEmptyText]
+	ClosingKey            domain.Option[domain.IdempotencyKey]
+	AdjustmentDocumentID  domain.Option[domain.StockDocumentID]
+	CreatedAt             domain.UTCInstant
+	UpdatedAt             domain.UTCInstant
+	Lines                 []CountLine
+}
+
+// Count is a physical stock count. Expected quantities were frozen from the
+// balances after FrozenPostingSequence; closing posts the counted variances
+// as one PHYSICAL_COUNT adjustment, or nothing when every counted line
+// matched.
+type Count struct {
+	id                    domain.StockCountID
+	status                domain.StockCountStatus
+	countedOn             domain.BusinessDate
+	frozenPostingSequence int64
+	notes                 domain.Option[domain.NonEmptyText]
+	closingKey            domain.Option[domain.IdempotencyKey]
+	adjustmentDocumentID  domain.Option[domain.StockDocumentID]
+	createdAt             domain.UTCInstant
+	updatedAt             domain.UTCInstant
+	lines                 []CountLine
+}
+
+func NewCount(params CountParams) (Count, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.ID.IsZero() {
+		violations = append(violations, required("stock_count_id"))
+	}
+	if _, err := domain.ParseStockCountStatus(params.Status.String()); err != nil {
+		violations = append(violations, domain.Violation{Field: "status", Code: domain.ViolationInvalidEnum, InvariantID: "SCT-002"})
+	}
+	if params.CountedOn.IsZero() {
+		violations = append(violations, required("counted_on"))
+	}
+	if params.FrozenPostingSequence < 0 {
+		violations = append(violations, domain.Violation{Field: "frozen_posting_sequence", Code: domain.ViolationOutOfRange, InvariantID: "SCT-001"})
+	}
+	if notes, ok := params.Notes.Get(); ok && notes.String() == "" {
+		violations = append(violations, required("notes"))
+	}
+	if params.ClosingKey.IsSome() != (params.Status == domain.StockCountClosed) {
+		violations = append(violations, domain.Violation{Field: "closing_idempotency_key", Code: domain.ViolationInvariant, InvariantID: "SCT-003"})
+	}
+	if params.AdjustmentDocumentID.IsSome() && params.Status != domain.StockCountClosed {
+		violations = append(violations, domain.Violation{Field: "adjustment_document_id", Code: domain.ViolationInvariant, InvariantID: "SCT-003"})
+	}
+	if err := domain.ValidateTimestampOrder(params.CreatedAt, params.UpdatedAt, domain.None[domain.UTCInstant]()); err != nil {
+		if validation, ok := err.(*domain.ValidationError); ok {
+			violations = append(violations, validation.Violations()...)
+		} else {
+			violations = append(violations, domain.Violation{Field: "timestamps", Code: domain.ViolationInvariant})
+		}
+	}
+	if len(params.Lines) == 0 {
+		violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationRequired, InvariantID: "SCT-001"})
+	}
+	itemLevel := make(map[domain.ItemID]bool, len(params.Lines))
+	lots := make(map[domain.InventoryLotID]struct{}, len(params.Lines))
+	for _, line := range params.Lines {
+		if line.ID().IsZero() || line.CountID() != params.ID {
+			violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationInvariant, InvariantID: "SCT-001"})
+			continue
+		}
+		previousItemLevel, seen := itemLevel[line.ItemID()]
+		lotID, byLot := line.LotID().Get()
+		switch {
+		case seen && previousItemLevel && !byLot:
+			violations = append(violations, domain.Violation{Field: "item_id", Code: domain.ViolationDuplicate, InvariantID: "SCT-001"})
+		case seen && previousItemLevel == byLot:
+			violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationInvariant, InvariantID: "SCT-001"})
+		}
+		if byLot {
+			if _, duplicate := lots[lotID]; duplicate {
+				violations = append(violations, domain.Violation{Field: "lot_id", Code: domain.ViolationDuplicate, InvariantID: "SCT-001"})
+			}
+			lots[lotID] = struct{}{}
+		}
+		itemLevel[line.ItemID()] = !byLot
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Count{}, err
+	}
+	lines := make([]CountLine, len(params.Lines))
+	copy(lines, params.Lines)
+	return Count{
+		id: params.ID, status: params.Status, countedOn: params.CountedOn,
+		frozenPostingSequence: params.FrozenPostingSequence, notes: params.Notes,
+		closingKey: params.ClosingKey, adjustmentDocumentID: params.AdjustmentDocumentID,
+		createdAt: params.CreatedAt, updatedAt: params.UpdatedAt, lines: lines,
+	}, nil
+}
+
+func (c Count) ID() domain.StockCountID                          { return c.id }
+func (c Count) Status() domain.StockCountStatus                  { return c.status }
+func (c Count) CountedOn() domain.BusinessDate                   { return c.countedOn }
+func (c Count) FrozenPostingSequence() int64                     { return c.frozenPostingSequence }
+func (c Count) Notes() domain.Option[domain.NonEmptyText]        { return c.notes }
+func (c Count) ClosingKey() domain.Option[domain.IdempotencyKey] { return c.closingKey }
+func (c Count) AdjustmentDocumentID() domain.Option[domain.StockDocumentID] {
+	return c.adjustmentDocumentID
+}
+func (c Count) CreatedAt() domain.UTCInstant { return c.createdAt }
+func (c Count) UpdatedAt() domain.UTCInstant { return c.updatedAt }
+func (c Count) IsOpen() bool                 { return c.status == domain.StockCountOpen }
+func (c Count) Lines() []CountLine {
+	lines := make([]CountLine, len(c.lines))
+	copy(lines, c.lines)
+	return lines
+}
+
+// VarianceLines are the counted lines whose observed quantity differs from
+// the expected one, in line order.
+func (c Count) VarianceLines() []CountLine {
+	lines := make([]CountLine, 0, len(c.lines))
+	for _, line := range c.lines {
+		if variance, ok := line.Variance().Get(); ok && variance != 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// VariancePostings are the lines closing posts, in line order. A document
+// moves an item in one direction only, so an item whose lots show both a
+// shortage and a surplus posts its net variance as one line that sums their
+// expected and observed quantities and values: a net surplus keeps the first
+// surplus lot's code and expiry, and a net shortage names no lot.
+func (c Count) VariancePostings() ([]CountLine, error) {
+	variances := c.VarianceLines()
+	order := make([]domain.ItemID, 0, len(variances))
+	groups := make(map[domain.ItemID][]CountLine, len(variances))
+	for _, line := range variances {
+		if _, ok := groups[line.ItemID()]; !ok {
+			order = append(order, line.ItemID())
+		}
+		groups[line.ItemID()] = append(groups[line.ItemID()], line)
+	}
+	postings := make([]CountLine, 0, len(variances))
+	for _, itemID := range order {
+		group := groups[itemID]
+		var shortage, surplus bool
+		for _, line := range group {
+			variance, _ := line.Variance().Get()
+			shortage = shortage || variance < 0
+			surplus = surplus || variance > 0
+		}
+		if !shortage || !surplus {
+			postings = append(postings, group...)
+			continue
+		}
+		merged, err := mergeCountLines(group)
+		if err != nil {
+			return nil, err
+		}
+		if variance, _ := merged.Variance().Get(); variance != 0 {
+			postings = append(postings, merged)
+		}
+	}
+	return postings, nil
+}
+
+func mergeCountLines(lines []CountLine) (CountLine, error) {
+	params := CountLineParams{ID: lines[0].id, CountID: lines[0].countID, ItemID: lines[0].itemID}
+	var expected, observed domain.AtomicQuantity
+	var value domain.InventoryValue
+	var surplus domain.Option[CountLine]
+	for _, line := range lines {
+		var err error
+		if expected, err = expected.Add(line.expectedQuantity); err != nil {
+			return CountLine{}, err
+		}
+		if value, err = value.Add(line.expectedValue); err != nil {
+			return CountLine{}, err
+		}
+		counted, _ := line.observedQuantity.Get()
+		if observed, err = observed.Add(counted); err != nil {
+			return CountLine{}, err
+		}
+		if variance, _ := line.Variance().Get(); variance > 0 && surplus.IsNone() {
+			surplus = domain.Some(line)
+		}
+		params.ChangedSinceFreeze = params.ChangedSinceFreeze || line.changedSinceFreeze
+	}
+	params.ExpectedQuantity, params.ExpectedValue = expected, value
+	params.ObservedQuantity = domain.Some(observed)
+	if first, ok := surplus.Get(); ok && observed.Int64() > expected.Int64() {
+		params.LotID, params.LotCode, params.ExpiresOn = first.lotID, first.lotCode, first.expiresOn
+	}
+	return NewCountLine(params)
+}
@@ -61,10 +61,26 @@ func TestLotTotalsSourceSnapshotAndInclusiveExpiry(t *testing.T) {
 	view := must(inventory.NewLotView(inventory.LotViewParams{
 		Lot: lot, SourceDocumentID: must(domain.NewStockDocumentID(5)),
 		SourceKind: domain.DocumentPurchase, SourceOccurredOn: origin,
+		LocationID: must(domain.NewStockLocationID(inventory.DefaultLocationID)),
 	}))
 	if view.SourceKind() != domain.DocumentPurchase || view.Lot().ConsumedQuantity().Int64() != 7 {
 		t.Fatalf("lot view = %#v", view)
 	}
+	if _, err := inventory.NewLotView(inventory.LotViewParams{
+		Lot: lot, SourceDocumentID: must(domain.NewStockDocumentID(6)),
+		SourceKind: domain.DocumentTransfer, SourceOccurredOn: origin,
+		LocationID: must(domain.NewStockLocationID(2)),
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("transfer lot without source lot error = %v", err)
+	}
+	if _, err := inventory.NewLotView(inventory.LotViewParams{
+		Lot: lot, SourceDocumentID: must(domain.NewStockDocumentID(5)),
+		SourceKind: domain.DocumentPurchase, SourceOccurredOn: origin,
+		LocationID:           must(domain.NewStockLocationID(2)),
+		TransferredFromLotID: domain.Some(must(domain.NewInventoryLotID(9))),
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("purchase lot with transfer source error = %v", err)
+	}
 	if lot.IsExpired(expiry) || lot.State(expiry) != domain.LotAvailable {
 		t.Fatal("lot was not usable through inclusive expiry date")
 	}
@@ -169,9 +185,153 @@ func TestLedgerViewPreservesEveryHistoricalSnapshot(t *testing.T) {
 	}
 }
 
+func TestReconciliationDiscrepanciesRequireTheirSubject(t *testing.T) {
+	itemID := must(domain.NewItemID(1))
+	_, err := inventory.NewDiscrepancy(inventory.DiscrepancyParams{
+		Kind: inventory.DiscrepancyLotAvailable, ItemID: itemID, Expected: 5, Stored: 4,
+	})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("lot discrepancy without lot error = %v", err)
+	}
+	_, err = inventory.NewDiscrepancy(inventory.DiscrepancyParams{
+		Kind: inventory.DiscrepancyBalanceQuantity, ItemID: itemID, Expected: 5, Stored: 5,
+	})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("equal discrepancy error = %v", err)
+	}
+
+	discrepancy := must(inventory.NewDiscrepancy(inventory.DiscrepancyParams{
+		Kind: inventory.DiscrepancyLineAllocation, ItemID: itemID,
+		LineID: domain.Some(must(domain.NewStockDocumentLineID(7))), Expected: 5, Stored: 3,
+	}))
+	if discrepancy.Kind().InvariantID() != "LOT-003" || discrepancy.LotID().IsSome() {
+		t.Fatalf("discrepancy = %#v", discrepancy)
+	}
+
+	discrepancies := []inventory.Discrepancy{discrepancy}
+	reconciliation := must(inventory.NewReconciliation(inventory.ReconciliationParams{
+		LastPostingSequence: domain.Some(must(domain.NewPostingSequence(3))),
+		DocumentCount:       3, LineCount: 4, LotCount: 2, ItemCount: 1,
+		Discrepancies: discrepancies,
+	}))
+	discrepancies[0] = inventory.Discrepancy{}
+	if reconciliation.Consistent() || reconciliation.Discrepancies()[0].Stored() != 3 {
+		t.Fatalf("reconciliation did not own its discrepancies: %#v", reconciliation)
+	}
+	_, err = inventory.NewReconciliation(inventory.ReconciliationParams{DocumentCount: 1})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("reconciliation without high-water error = %v", err)
+	}
+}
+
 func must[T any](value T, err error) T {
 	if err != nil {
 		panic(err)
 	}
 	return value
 }
+
+func TestCostBasisPrefersAverageThenLastPurchase(t *testing.T) {
+	instant := must(domain.UTCInstantFromUnixMilli(1000))
+	itemID := must(domain.NewItemID(1))
+	lastPurchase := must(domain.NewFraction(7, 2))
+	params := inventory.CostBasisParams{
+		Balance: must(inventory.NewBalance(inventory.BalanceParams{
+			ItemID: itemID, Quantity: must(domain.NewAtomicQuantity(4)),
+			Value: must(domain.NewInventoryValue(10)), UpdatedAt: instant,
+		})),
+		BaseUnit:              must(domain.NewUnitCode("g")),
+		BaseConversion:        must(domain.NewUnitConversion(1000, 1)),
+		LastPurchaseUnitValue: domain.Some(lastPurchase),
+	}
+	stocked := must(inventory.NewCostBasis(params))
+	if value, source := stocked.UnitValue(); source != inventory.CostSourceAverageValue || value.String() != "5/2" {
+		t.Fatalf("stocked unit value = %v, %s", value, source)
+	}
+
+	params.Balance = must(inventory.NewBalance(inventory.BalanceParams{ItemID: itemID, UpdatedAt: instant}))
+	empty := must(inventory.NewCostBasis(params))
+	if value, source := empty.UnitValue(); source != inventory.CostSourceLastPurchase || value != lastPurchase {
+		t.Fatalf("empty unit value = %v, %s", value, source)
+	}
+
+	params.LastPurchaseUnitValue = domain.None[domain.Fraction]()
+	unknown := must(inventory.NewCostBasis(params))
+	if _, source := unknown.UnitValue(); source != inventory.CostSourceUnavailable {
+		t.Fatalf("unknown unit value source = %s", source)
+	}
+	if _, err := inventory.NewCostBasis(inventory.CostBasisParams{}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("empty cost basis error = %v", err)
+	}
+}
+
+func TestCountVariancesAreValuedAtTheFrozenUnitValue(t *testing.T) {
+	countID := must(domain.NewStockCountID(1))
+	line := func(id, itemID, lotID, expected, value int64, observed domain.Option[domain.AtomicQuantity]) inventory.CountLine {
+		params := inventory.CountLineParams{
+			ID: must(domain.NewStockCountLineID(id)), CountID: countID, ItemID: must(domain.NewItemID(itemID)),
+			ExpectedQuantity: must(domain.NewAtomicQuantity(expected)), ExpectedValue: must(domain.NewInventoryValue(value)),
+			ObservedQuantity: observed,
+		}
+		if lotID > 0 {
+			params.LotID = domain.Some(must(domain.NewInventoryLotID(lotID)))
+		}
+		return must(inventory.NewCountLine(params))
+	}
+	observed := func(quantity int64) domain.Option[domain.AtomicQuantity] {
+		return domain.Some(must(domain.NewAtomicQuantity(quantity)))
+	}
+	shortage := line(1, 1, 0, 3, 1_000, observed(2))
+	if variance, _ := shortage.Variance().Get(); variance != -1 {
+		t.Fatalf("shortage variance = %d", variance)
+	}
+	if value, ok := shortage.VarianceValue().Get(); !ok || value != -333 {
+		t.Fatalf("shortage value = %d, %t", value, ok)
+	}
+	if value, ok := line(2, 2, 0, 3, 1_000, observed(5)).VarianceValue().Get(); !ok || value != 667 {
+		t.Fatalf("surplus value = %d, %t", value, ok)
+	}
+	if _, ok := line(3, 3, 0, 0, 0, observed(5)).VarianceValue().Get(); ok {
+		t.Fatal("surplus over nothing expected has no frozen value")
+	}
+	uncounted := line(4, 4, 0, 3, 1_000, domain.None[domain.AtomicQuantity]())
+	if uncounted.IsCounted() || uncounted.Variance().IsSome() {
+		t.Fatalf("uncounted line = %#v", uncounted)
+	}
+
+	params := inventory.CountParams{
+		ID: countID, Status: domain.StockCountOpen, CountedOn: must(domain.ParseBusinessDate("2026-10-18")),
+		CreatedAt: must(domain.UTCInstantFromUnixMilli(1_000)), UpdatedAt: must(domain.UTCInstantFromUnixMilli(1_000)),
+		Lines: []inventory.CountLine{shortage, line(2, 2, 0, 3, 1_000, observed(3)), uncounted},
+	}
+	count := must(inventory.NewCount(params))
+	if variances := count.VarianceLines(); len(variances) != 1 || variances[0].ID() != shortage.ID() {
+		t.Fatalf("variance lines = %#v", variances)
+	}
+	params.Lines = []inventory.CountLine{line(5, 5, 7, 3, 0, observed(3)), line(6, 5, 8, 1, 0, observed(1))}
+	if _, err := inventory.NewCount(params); err != nil {
+		t.Fatalf("lot lines of one item: %v", err)
+	}
+	params.Lines = []inventory.CountLine{line(5, 5, 7, 3, 300, observed(1)), shortage, line(6, 5, 8, 1, 100, observed(2))}
+	postings, err := must(inventory.NewCount(params)).VariancePostings()
+	if err != nil || len(postings) != 2 {
+		t.Fatalf("variance postings = %#v, %v", postings, err)
+	}
+	if variance, _ := postings[0].Variance().Get(); variance != -1 || postings[0].LotID().IsSome() || postings[0].ExpectedValue().Int64() != 400 {
+		t.Fatalf("netted lot posting = %#v", postings[0])
+	}
+	params.Lines = append(params.Lines, line(7, 5, 0, 4, 0, observed(4)))
+	if _, err := inventory.NewCount(params); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("mixed item and lot lines error = %v", err)
+	}
+	params.Lines = []inventory.CountLine{shortage}
+	params.AdjustmentDocumentID = domain.Some(must(domain.NewStockDocumentID(9)))
+	if _, err := inventory.NewCount(params); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("open count with adjustment error = %v", err)
+	}
+	params.Status = domain.StockCountClosed
+	params.ClosingKey = domain.Some(must(domain.NewIdempotencyKey("count-1")))
+	if _, err := inventory.NewCount(params); err != nil {
+		t.Fatalf("closed count: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package inventory
+
+import "github.com/jerobas/saas/internal/domain"
+
+// DefaultLocationID is the location seeded by migration 0007. Lots created
+// before locations existed, and every inbound posting that does not name a
+// location, are kept there.
+const DefaultLocationID int64 = 1
+
+type LocationParams struct {
+	ID         domain.StockLocationID
+	Name       domain.UniqueName
+	CreatedAt  domain.UTCInstant
+	UpdatedAt  domain.UTCInstant
+	ArchivedAt domain.Option[domain.UTCInstant]
+}
+
+// Location is a physical place that holds lots. Valuation stays per item:
+// a location only partitions lot quantities, and transfers between locations
+// never change inventory value.
+type Location struct {
+	id         domain.StockLocationID
+	name       domain.UniqueName
+	createdAt  domain.UTCInstant
+	updatedAt  domain.UTCInstant
+	archivedAt domain.Option[domain.UTCInstant]
+}
+
+func NewLocation(params LocationParams) (Location, error) {
+	violations := make([]domain.Violation, 0, 3)
+	if params.ID.IsZero() {
+		violations = append(violations, required("location_id"))
+	}
+	if params.Name.Display() == "" || params.Name.Key() == "" {
+		violations = append(violations, required("name"))
+	}
+	if err := domain.ValidateTimestampOrder(params.CreatedAt, params.UpdatedAt, params.ArchivedAt); err != nil {
+		if validation, ok := err.(*domain.ValidationError); ok {
+			violations = append(violations, validation.Violations()...)
+		} else {
+			violations = append(violations, domain.Violation{Field: "timestamps", Code: domain.ViolationInvariant})
+		}
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Location{}, err
+	}
+	return Location{
+		id: params.ID, name: params.Name, createdAt: params.CreatedAt,
+		updatedAt: params.UpdatedAt, archivedAt: params.ArchivedAt,
+	}, nil
+}
+
+func (l Location) ID() domain.StockLocationID                   { return l.id }
+func (l Location) Name() domain.UniqueName                      { return l.name }
+func (l Location) CreatedAt() domain.UTCInstant                 { return l.createdAt }
+func (l Location) UpdatedAt() domain.UTCInstant                 { return l.updatedAt }
+func (l Location) ArchivedAt() domain.Option[domain.UTCInstant] { return l.archivedAt }
+func (l Location) IsArchived() bool                             { return l.archivedAt.IsSome() }
+func (l Location) IsDefault() bool                              { return l.id.Int64() == DefaultLocationID }
@@ -0,0 +1,175 @@
+package inventory
+
+import "github.com/jerobas/saas/internal/domain"
+
+// DiscrepancyKind names the projection fact a ledger replay disagreed with.
+// Each kind maps to the invariant that replay is meant to prove.
+type DiscrepancyKind string
+
+const (
+	DiscrepancyBalanceQuantity     DiscrepancyKind = "BALANCE_QUANTITY"
+	DiscrepancyBalanceValue        DiscrepancyKind = "BALANCE_VALUE"
+	DiscrepancyBalanceLastDocument DiscrepancyKind = "BALANCE_LAST_DOCUMENT"
+	DiscrepancyLotInitialQuantity  DiscrepancyKind = "LOT_INITIAL_QUANTITY"
+	DiscrepancyLotAvailable        DiscrepancyKind = "LOT_AVAILABLE_QUANTITY"
+	DiscrepancyLotItemQuantity     DiscrepancyKind = "LOT_ITEM_QUANTITY"
+	DiscrepancyLineAllocation      DiscrepancyKind = "LINE_ALLOCATED_QUANTITY"
+)
+
+func ParseDiscrepancyKind(raw string) (DiscrepancyKind, error) {
+	value := DiscrepancyKind(raw)
+	switch value {
+	case DiscrepancyBalanceQuantity, DiscrepancyBalanceValue, DiscrepancyBalanceLastDocument,
+		DiscrepancyLotInitialQuantity, DiscrepancyLotAvailable, DiscrepancyLotItemQuantity,
+		DiscrepancyLineAllocation:
+		return value, nil
+	default:
+		return "", domain.Invalid("discrepancy_kind", domain.ViolationInvalidEnum, "")
+	}
+}
+
+func (k DiscrepancyKind) String() string { return string(k) }
+
+// InvariantID returns the catalogued invariant the discrepancy violates.
+func (k DiscrepancyKind) InvariantID() string {
+	switch k {
+	case DiscrepancyBalanceQuantity, DiscrepancyBalanceValue, DiscrepancyBalanceLastDocument:
+		return "INV-008"
+	case DiscrepancyLotInitialQuantity:
+		return "LOT-001"
+	case DiscrepancyLineAllocation:
+		return "LOT-003"
+	case DiscrepancyLotAvailable:
+		return "LOT-004"
+	default:
+		return "LOT-010"
+	}
+}
+
+func (k DiscrepancyKind) isLotFact() bool {
+	return k == DiscrepancyLotAvailable
+}
+
+type DiscrepancyParams struct {
+	Kind     DiscrepancyKind
+	ItemID   domain.ItemID
+	LotID    domain.Option[domain.InventoryLotID]
+	LineID   domain.Option[domain.StockDocumentLineID]
+	Expected int64
+	Stored   int64
+}
+
+// Discrepancy is one difference between a replayed ledger fact and the stored
+// projection. Expected and Stored share the unit implied by Kind: atomic
+// quantity, microcurrency value, or a document ID where zero means none.
+type Discrepancy struct {
+	kind     DiscrepancyKind
+	itemID   domain.ItemID
+	lotID    domain.Option[domain.InventoryLotID]
+	lineID   domain.Option[domain.StockDocumentLineID]
+	expected int64
+	stored   int64
+}
+
+func NewDiscrepancy(params DiscrepancyParams) (Discrepancy, error) {
+	violations := make([]domain.Violation, 0, 4)
+	if _, err := ParseDiscrepancyKind(params.Kind.String()); err != nil {
+		violations = append(violations, domain.Violation{Field: "kind", Code: domain.ViolationInvalidEnum})
+	}
+	if params.ItemID.IsZero() {
+		violations = append(violations, required("item_id"))
+	}
+	if params.Kind.isLotFact() && params.LotID.IsNone() {
+		violations = append(violations, required("lot_id"))
+	}
+	if (params.Kind == DiscrepancyLotInitialQuantity || params.Kind == DiscrepancyLineAllocation) && params.LineID.IsNone() {
+		violations = append(violations, required("line_id"))
+	}
+	if params.Expected == params.Stored {
+		violations = append(violations, domain.Violation{Field: "stored", Code: domain.ViolationInvariant})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Discrepancy{}, err
+	}
+	return Discrepancy{
+		kind: params.Kind, itemID: params.ItemID, lotID: params.LotID, lineID: params.LineID,
+		expected: params.Expected, stored: params.Stored,
+	}, nil
+}
+
+func (d Discrepancy) Kind() DiscrepancyKind                             { return d.kind }
+func (d Discrepancy) ItemID() domain.ItemID                             { return d.itemID }
+func (d Discrepancy) LotID() domain.Option[domain.InventoryLotID]       { return d.lotID }
+func (d Discrepancy) LineID() domain.Option[domain.StockDocumentLineID] { return d.lineID }
+func (d Discrepancy) Expected() int64                                   { return d.expected }
+func (d Discrepancy) Stored() int64                                     { return d.stored }
+
+type ReconciliationParams struct {
+	LastPostingSequence domain.Option[domain.PostingSequence]
+	DocumentCount       int64
+	LineCount           int64
+	LotCount            int64
+	ItemCount           int64
+	Discrepancies       []Discrepancy
+}
+
+// Reconciliation is the result of one diagnostic ledger replay. It is read
+// from a single snapshot, so LastPostingSequence identifies exactly which
+// history was compared.
+type Reconciliation struct {
+	lastPostingSequence domain.Option[domain.PostingSequence]
+	documentCount       int64
+	lineCount           int64
+	lotCount            int64
+	itemCount           int64
+	discrepancies       []Discrepancy
+}
+
+func NewReconciliation(params ReconciliationParams) (Reconciliation, error) {
+	violations := make([]domain.Violation, 0, 5)
+	counts := []struct {
+		field string
+		value int64
+	}{
+		{"document_count", params.DocumentCount},
+		{"line_count", params.LineCount},
+		{"lot_count", params.LotCount},
+		{"item_count", params.ItemCount},
+	}
+	for _, count := range counts {
+		if count.value < 0 {
+			violations = append(violations, domain.Violation{Field: count.field, Code: domain.ViolationOutOfRange})
+		}
+	}
+	if params.DocumentCount > 0 && params.LastPostingSequence.IsNone() {
+		violations = append(violations, required("last_posting_sequence"))
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Reconciliation{}, err
+	}
+	discrepancies := make([]Discrepancy, len(params.Discrepancies))
+	copy(discrepancies, params.Discrepancies)
+	return Reconciliation{
+		lastPostingSequence: params.LastPostingSequence,
+		documentCount:       params.DocumentCount,
+		lineCount:           params.LineCount,
+		lotCount:            params.LotCount,
+		itemCount:           params.ItemCount,
+		discrepancies:       discrepancies,
+	}, nil
+}
+
+func (r Reconciliation) LastPostingSequence() domain.Option[domain.PostingSequence] {
+	return r.lastPostingSequence
+}
+func (r Reconciliation) DocumentCount() int64 { return r.documentCount }
+func (r Reconciliation) LineCount() int64     { return r.lineCount }
+func (r Reconciliation) LotCount() int64      { return r.lotCount }
+func (r Reconciliation) ItemCount() int64     { return r.itemCount }
+func (r Reconciliation) Consistent() bool     { return len(r.discrepancies) == 0 }
+
+func (r Reconciliation) Discrepancies() []Discrepancy {
+	discrepancies := make([]Discrepancy, len(r.discrepancies))
+	copy(discrepancies, r.discrepancies)
+	return discrepancies
+}
@@ -82,19 +82,24 @@ func (i BalanceListItem) ReorderQuantity() domain.Option[domain.AtomicQuantity]
 }
 
 type LotViewParams struct {
-	Lot              Lot
-	SourceDocumentID domain.StockDocumentID
-	SourceKind       domain.DocumentKind
-	SourceOccurredOn domain.BusinessDate
+	Lot                  Lot
+	SourceDocumentID     domain.StockDocumentID
+	SourceKind           domain.DocumentKind
+	SourceOccurredOn     domain.BusinessDate
+	LocationID           domain.StockLocationID
+	TransferredFromLotID domain.Option[domain.InventoryLotID]
 }
 
 // LotView is the query-facing lot fact including its immutable source
-// document snapshot. Lot holds the allocation-derived quantity totals.
+// document snapshot and the location holding it. Lot holds the
+// allocation-derived quantity totals.
 type LotView struct {
-	lot              Lot
-	sourceDocumentID domain.StockDocumentID
-	sourceKind       domain.DocumentKind
-	sourceOccurredOn domain.BusinessDate
+	lot                  Lot
+	sourceDocumentID     domain.StockDocumentID
+	sourceKind           domain.DocumentKind
+	sourceOccurredOn     domain.BusinessDate
+	locationID           domain.StockLocationID
+	transferredFromLotID domain.Option[domain.InventoryLotID]
 }
 
 func NewLotView(params LotViewParams) (LotView, error) {
@@ -111,12 +116,23 @@ func NewLotView(params LotViewParams) (LotView, error) {
 	if params.SourceOccurredOn.IsZero() {
 		violations = append(violations, required("source_occurred_on"))
 	}
+	if params.LocationID.IsZero() {
+		violations = append(violations, required("location_id"))
+	}
+	if from, ok := params.TransferredFromLotID.Get(); ok {
+		if from.IsZero() || params.SourceKind != domain.DocumentTransfer {
+			violations = append(violations, domain.Violation{Field: "transferred_from_lot_id", Code: domain.ViolationInvariant, InvariantID: "LOC-004"})
+		}
+	} else if params.SourceKind == domain.DocumentTransfer {
+		violations = append(violations, domain.Violation{Field: "transferred_from_lot_id", Code: domain.ViolationRequired, InvariantID: "LOC-004"})
+	}
 	if err := domain.NewValidationError(violations...); err != nil {
 		return LotView{}, err
 	}
 	return LotView{
 		lot: params.Lot, sourceDocumentID: params.SourceDocumentID,
 		sourceKind: params.SourceKind, sourceOccurredOn: params.SourceOccurredOn,
+		locationID: params.LocationID, transferredFromLotID: params.TransferredFromLotID,
 	}, nil
 }
 
@@ -124,6 +140,10 @@ func (v LotView) Lot() Lot                                 { return v.lot }
 func (v LotView) SourceDocumentID() domain.StockDocumentID { return v.sourceDocumentID }
 func (v LotView) SourceKind() domain.DocumentKind          { return v.sourceKind }
 func (v LotView) SourceOccurredOn() domain.BusinessDate    { return v.sourceOccurredOn }
+func (v LotView) LocationID() domain.StockLocationID       { return v.locationID }
+func (v LotView) TransferredFromLotID() domain.Option[domain.InventoryLotID] {
+	return v.transferredFromLotID
+}
 
 type AllocationViewParams struct {
 	Allocation         Allocation
@@ -0,0 +1,241 @@
+package purchasing
+
+import "github.com/jerobas/saas/internal/domain"
+
+type OrderLineParams struct {
+	ID                   domain.PurchaseOrderLineID
+	OrderID              domain.PurchaseOrderID
+	LineOrder            domain.LineOrder
+	ItemID               domain.ItemID
+	Quantity             domain.AtomicQuantity
+	EnteredUnit          domain.UnitCode
+	EnteredPackagingName domain.Option[domain.NonEmptyText]
+	Conversion           domain.UnitConversion
+	ExpectedTotal        domain.MinorAmount
+	ReceivedQuantity     domain.AtomicQuantity
+}
+
+// OrderLine is an ordered quantity in the entered unit or packaging snapshot,
+// the expected commercial total for all of it, and how much has been received.
+type OrderLine struct {
+	id                   domain.PurchaseOrderLineID
+	orderID              domain.PurchaseOrderID
+	lineOrder            domain.LineOrder
+	itemID               domain.ItemID
+	quantity             domain.AtomicQuantity
+	enteredUnit          domain.UnitCode
+	enteredPackagingName domain.Option[domain.NonEmptyText]
+	conversion           domain.UnitConversion
+	expectedTotal        domain.MinorAmount
+	receivedQuantity     domain.AtomicQuantity
+}
+
+func NewOrderLine(params OrderLineParams) (OrderLine, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.ID.IsZero() {
+		violations = append(violations, required("purchase_order_line_id"))
+	}
+	if params.OrderID.IsZero() {
+		violations = append(violations, required("purchase_order_id"))
+	}
+	if params.LineOrder.IsZero() {
+		violations = append(violations, required("line_order"))
+	}
+	if params.ItemID.IsZero() {
+		violations = append(violations, required("item_id"))
+	}
+	if params.Quantity.Int64() <= 0 {
+		violations = append(violations, domain.Violation{Field: "quantity_atomic", Code: domain.ViolationNotPositive, InvariantID: "POR-001"})
+	}
+	if params.EnteredUnit.String() == "" {
+		violations = append(violations, required("entered_unit_code"))
+	}
+	if name, ok := params.EnteredPackagingName.Get(); ok && name.String() == "" {
+		violations = append(violations, required("entered_packaging_name"))
+	}
+	if params.Conversion.IsZero() {
+		violations = append(violations, required("conversion"))
+	}
+	if params.ReceivedQuantity.Int64() > params.Quantity.Int64() {
+		violations = append(violations, domain.Violation{Field: "received_quantity_atomic", Code: domain.ViolationOutOfRange, InvariantID: "POR-003"})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return OrderLine{}, err
+	}
+	return OrderLine{
+		id: params.ID, orderID: params.OrderID, lineOrder: params.LineOrder,
+		itemID: params.ItemID, quantity: params.Quantity, enteredUnit: params.EnteredUnit,
+		enteredPackagingName: params.EnteredPackagingName, conversion: params.Conversion,
+		expectedTotal: params.ExpectedTotal, receivedQuantity: params.ReceivedQuantity,
+	}, nil
+}
+
+func (l OrderLine) ID() domain.PurchaseOrderLineID    { return l.id }
+func (l OrderLine) OrderID() domain.PurchaseOrderID   { return l.orderID }
+func (l OrderLine) LineOrder() domain.LineOrder       { return l.lineOrder }
+func (l OrderLine) ItemID() domain.ItemID             { return l.itemID }
+func (l OrderLine) Quantity() domain.AtomicQuantity   { return l.quantity }
+func (l OrderLine) EnteredUnit() domain.UnitCode      { return l.enteredUnit }
+func (l OrderLine) Conversion() domain.UnitConversion { return l.conversion }
+func (l OrderLine) EnteredPackagingName() domain.Option[domain.NonEmptyText] {
+	return l.enteredPackagingName
+}
+func (l OrderLine) ExpectedTotal() domain.MinorAmount       { return l.expectedTotal }
+func (l OrderLine) ReceivedQuantity() domain.AtomicQuantity { return l.receivedQuantity }
+func (l OrderLine) IsFullyReceived() bool                   { return l.receivedQuantity == l.quantity }
+
+// OutstandingQuantity is the ordered quantity not yet received.
+func (l OrderLine) OutstandingQuantity() domain.AtomicQuantity {
+	outstanding, _ := l.quantity.Sub(l.receivedQuantity)
+	return outstanding
+}
+
+type OrderParams struct {
+	ID         domain.PurchaseOrderID
+	SupplierID domain.CounterpartyID
+	Status     domain.PurchaseOrderStatus
+	OrderedOn  domain.BusinessDate
+	ExpectedOn domain.Option[domain.BusinessDate]
+	Notes      domain.Option[domain.NonEmptyText]
+	CreatedAt  domain.UTCInstant
+	UpdatedAt  domain.UTCInstant
+	Lines      []OrderLine
+}
+
+// Order is a supplier order placed before goods arrive. It never affects
+// stock: each receipt posts a separate PURCHASE document and only advances the
+// received quantities and status recorded here.
+type Order struct {
+	id         domain.PurchaseOrderID
+	supplierID domain.CounterpartyID
+	status     domain.PurchaseOrderStatus
+	orderedOn  domain.BusinessDate
+	expectedOn domain.Option[domain.BusinessDate]
+	notes      domain.Option[domain.NonEmptyText]
+	createdAt  domain.UTCInstant
+	updatedAt  domain.UTCInstant
+	lines      []OrderLine
+}
+
+func NewOrder(params OrderParams) (Order, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.ID.IsZero() {
+		violations = append(violations, required("purchase_order_id"))
+	}
+	if params.SupplierID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "supplier_id", Code: domain.ViolationRequired, InvariantID: "POR-001"})
+	}
+	if _, err := domain.ParsePurchaseOrderStatus(params.Status.String()); err != nil {
+		violations = append(violations, domain.Violation{Field: "status", Code: domain.ViolationInvalidEnum, InvariantID: "POR-002"})
+	}
+	if params.OrderedOn.IsZero() {
+		violations = append(violations, required("ordered_on"))
+	}
+	if expectedOn, ok := params.ExpectedOn.Get(); ok && expectedOn.Before(params.OrderedOn) {
+		violations = append(violations, domain.Violation{Field: "expected_on", Code: domain.ViolationOutOfRange, InvariantID: "POR-001"})
+	}
+	if name, ok := params.Notes.Get(); ok && name.String() == "" {
+		violations = append(violations, required("notes"))
+	}
+	if err := domain.ValidateTimestampOrder(params.CreatedAt, params.UpdatedAt, domain.None[domain.UTCInstant]()); err != nil {
+		if validation, ok := err.(*domain.ValidationError); ok {
+			violations = append(violations, validation.Violations()...)
+		} else {
+			violations = append(violations, domain.Violation{Field: "timestamps", Code: domain.ViolationInvariant})
+		}
+	}
+	if len(params.Lines) == 0 {
+		violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationRequired, InvariantID: "POR-001"})
+	}
+	seenOrders := make(map[int64]struct{}, len(params.Lines))
+	for _, line := range params.Lines {
+		if line.ID().IsZero() || line.OrderID() != params.ID {
+			violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationInvariant, InvariantID: "POR-001"})
+			continue
+		}
+		if _, duplicate := seenOrders[line.LineOrder().Int64()]; duplicate {
+			violations = append(violations, domain.Violation{Field: "line_order", Code: domain.ViolationDuplicate, InvariantID: "POR-001"})
+		}
+		seenOrders[line.LineOrder().Int64()] = struct{}{}
+	}
+	if len(params.Lines) > 0 && !statusMatchesReceipts(params.Status, params.Lines) {
+		violations = append(violations, domain.Violation{Field: "status", Code: domain.ViolationInvariant, InvariantID: "POR-002"})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Order{}, err
+	}
+	lines := make([]OrderLine, len(params.Lines))
+	copy(lines, params.Lines)
+	return Order{
+		id: params.ID, supplierID: params.SupplierID, status: params.Status,
+		orderedOn: params.OrderedOn, expectedOn: params.ExpectedOn, notes: params.Notes,
+		createdAt: params.CreatedAt, updatedAt: params.UpdatedAt, lines: lines,
+	}, nil
+}
+
+func (o Order) ID() domain.PurchaseOrderID                     { return o.id }
+func (o Order) SupplierID() domain.CounterpartyID              { return o.supplierID }
+func (o Order) Status() domain.PurchaseOrderStatus             { return o.status }
+func (o Order) OrderedOn() domain.BusinessDate                 { return o.orderedOn }
+func (o Order) ExpectedOn() domain.Option[domain.BusinessDate] { return o.expectedOn }
+func (o Order) Notes() domain.Option[domain.NonEmptyText]      { return o.notes }
+func (o Order) CreatedAt() domain.UTCInstant                   { return o.createdAt }
+func (o Order) UpdatedAt() domain.UTCInstant                   { return o.updatedAt }
+func (o Order) Lines() []OrderLine {
+	lines := make([]OrderLine, len(o.lines))
+	copy(lines, o.lines)
+	return lines
+}
+
+// IsEditable reports whether supplier, dates, notes, and lines may change.
+func (o Order) IsEditable() bool { return o.status == domain.PurchaseOrderDraft }
+
+// CanSend, CanReceive, CanCancel, and CanClose are the only status moves.
+func (o Order) CanSend() bool { return o.status == domain.PurchaseOrderDraft }
+func (o Order) CanReceive() bool {
+	return o.status == domain.PurchaseOrderSent || o.status == domain.PurchaseOrderPartiallyReceived
+}
+func (o Order) CanCancel() bool {
+	return o.status == domain.PurchaseOrderDraft || o.status == domain.PurchaseOrderSent
+}
+func (o Order) CanClose() bool { return o.status == domain.PurchaseOrderPartiallyReceived }
+
+// ReceiptStatus is the status after a receipt left lines at their received
+// quantities: CLOSED once every line is fully received.
+func ReceiptStatus(lines []OrderLine) domain.PurchaseOrderStatus {
+	for _, line := range lines {
+		if !line.IsFullyReceived() {
+			return domain.PurchaseOrderPartiallyReceived
+		}
+	}
+	return domain.PurchaseOrderClosed
+}
+
+// statusMatchesReceipts keeps received quantities and status consistent: only
+// receipts leave DRAFT or SENT, a cancelled order received nothing, and a
+// closed order received something, in full or as an accepted short delivery.
+func statusMatchesReceipts(status domain.PurchaseOrderStatus, lines []OrderLine) bool {
+	received, complete := false, true
+	for _, line := range lines {
+		if !line.ReceivedQuantity().IsZero() {
+			received = true
+		}
+		if !line.IsFullyReceived() {
+			complete = false
+		}
+	}
+	switch status {
+	case domain.PurchaseOrderDraft, domain.PurchaseOrderSent, domain.PurchaseOrderCancelled:
+		return !received
+	case domain.PurchaseOrderPartiallyReceived:
+		return received && !complete
+	case domain.PurchaseOrderClosed:
+		return received
+	default:
+		return false
+	}
+}
+
+func required(field string) domain.Violation {
+	return domain.Violation{Field: field, Code: domain.ViolationRequired}
+}
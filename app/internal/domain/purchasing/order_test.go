@@ -0,0 +1,100 @@
+package purchasing_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+)
+
+func TestOrderStatusFollowsReceivedQuantities(t *testing.T) {
+	line := func(id, ordered, received int64) purchasing.OrderLine {
+		return must(purchasing.NewOrderLine(purchasing.OrderLineParams{
+			ID: must(domain.NewPurchaseOrderLineID(id)), OrderID: must(domain.NewPurchaseOrderID(1)),
+			LineOrder: must(domain.NewLineOrder(id)), ItemID: must(domain.NewItemID(id)),
+			Quantity:    must(domain.NewPositiveAtomicQuantity(ordered)),
+			EnteredUnit: must(domain.NewUnitCode("kg")), Conversion: must(domain.NewUnitConversion(1_000_000, 1)),
+			ExpectedTotal:    must(domain.NewMinorAmount(1_000)),
+			ReceivedQuantity: must(domain.NewAtomicQuantity(received)),
+		}))
+	}
+	params := purchasing.OrderParams{
+		ID: must(domain.NewPurchaseOrderID(1)), SupplierID: must(domain.NewCounterpartyID(3)),
+		OrderedOn: must(domain.ParseBusinessDate("2026-10-18")),
+		CreatedAt: must(domain.UTCInstantFromUnixMilli(1_000)), UpdatedAt: must(domain.UTCInstantFromUnixMilli(2_000)),
+	}
+	tests := []struct {
+		status domain.PurchaseOrderStatus
+		lines  []purchasing.OrderLine
+		valid  bool
+	}{
+		{domain.PurchaseOrderDraft, []purchasing.OrderLine{line(1, 10, 0)}, true},
+		{domain.PurchaseOrderSent, []purchasing.OrderLine{line(1, 10, 4)}, false},
+		{domain.PurchaseOrderPartiallyReceived, []purchasing.OrderLine{line(1, 10, 10), line(2, 5, 0)}, true},
+		{domain.PurchaseOrderPartiallyReceived, []purchasing.OrderLine{line(1, 10, 10)}, false},
+		{domain.PurchaseOrderClosed, []purchasing.OrderLine{line(1, 10, 6)}, true},
+		{domain.PurchaseOrderClosed, []purchasing.OrderLine{line(1, 10, 0)}, false},
+		{domain.PurchaseOrderCancelled, []purchasing.OrderLine{line(1, 10, 1)}, false},
+	}
+	for _, tc := range tests {
+		params.Status, params.Lines = tc.status, tc.lines
+		_, err := purchasing.NewOrder(params)
+		if tc.valid != (err == nil) {
+			t.Fatalf("%s with %d lines error = %v", tc.status, len(tc.lines), err)
+		}
+	}
+
+	if status := purchasing.ReceiptStatus([]purchasing.OrderLine{line(1, 10, 10), line(2, 5, 2)}); status != domain.PurchaseOrderPartiallyReceived {
+		t.Fatalf("partial receipt status = %s", status)
+	}
+	if status := purchasing.ReceiptStatus([]purchasing.OrderLine{line(1, 10, 10), line(2, 5, 5)}); status != domain.PurchaseOrderClosed {
+		t.Fatalf("complete receipt status = %s", status)
+	}
+	if outstanding := line(1, 10, 4).OutstandingQuantity(); outstanding.Int64() != 6 {
+		t.Fatalf("outstanding = %d", outstanding.Int64())
+	}
+	if _, err := purchasing.NewOrderLine(purchasing.OrderLineParams{
+		ID: must(domain.NewPurchaseOrderLineID(1)), OrderID: must(domain.NewPurchaseOrderID(1)),
+		LineOrder: must(domain.NewLineOrder(1)), ItemID: must(domain.NewItemID(1)),
+		Quantity:    must(domain.NewPositiveAtomicQuantity(10)),
+		EnteredUnit: must(domain.NewUnitCode("kg")), Conversion: must(domain.NewUnitConversion(1_000_000, 1)),
+		ReceivedQuantity: must(domain.NewAtomicQuantity(11)),
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("over-received line error = %v", err)
+	}
+}
+
+func TestOrderTransitionsAreLimitedByStatus(t *testing.T) {
+	line := must(purchasing.NewOrderLine(purchasing.OrderLineParams{
+		ID: must(domain.NewPurchaseOrderLineID(1)), OrderID: must(domain.NewPurchaseOrderID(1)),
+		LineOrder: must(domain.NewLineOrder(1)), ItemID: must(domain.NewItemID(1)),
+		Quantity:    must(domain.NewPositiveAtomicQuantity(10)),
+		EnteredUnit: must(domain.NewUnitCode("kg")), Conversion: must(domain.NewUnitConversion(1_000_000, 1)),
+	}))
+	order := must(purchasing.NewOrder(purchasing.OrderParams{
+		ID: must(domain.NewPurchaseOrderID(1)), SupplierID: must(domain.NewCounterpartyID(3)),
+		Status: domain.PurchaseOrderSent, OrderedOn: must(domain.ParseBusinessDate("2026-10-18")),
+		CreatedAt: must(domain.UTCInstantFromUnixMilli(1_000)), UpdatedAt: must(domain.UTCInstantFromUnixMilli(1_000)),
+		Lines: []purchasing.OrderLine{line},
+	}))
+	if order.IsEditable() || order.CanSend() || !order.CanReceive() || !order.CanCancel() || order.CanClose() {
+		t.Fatalf("sent order transitions = %#v", order)
+	}
+	if _, err := purchasing.NewOrder(purchasing.OrderParams{
+		ID: must(domain.NewPurchaseOrderID(1)), SupplierID: must(domain.NewCounterpartyID(3)),
+		Status: domain.PurchaseOrderDraft, OrderedOn: must(domain.ParseBusinessDate("2026-10-18")),
+		ExpectedOn: domain.Some(must(domain.ParseBusinessDate("2026-10-17"))),
+		CreatedAt:  must(domain.UTCInstantFromUnixMilli(1_000)), UpdatedAt: must(domain.UTCInstantFromUnixMilli(1_000)),
+		Lines: []purchasing.OrderLine{line},
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("expected before ordered error = %v", err)
+	}
+}
+
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
@@ -0,0 +1,332 @@
+package purchasing
+
+import (
+	"sort"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+// Installment is one dated part of a purchase payable. Number counts from 1 in
+// due order.
+type Installment struct {
+	Number int
+	DueOn  domain.BusinessDate
+	Amount domain.MinorAmount
+}
+
+type SupplierPaymentParams struct {
+	ID                 domain.SupplierPaymentID
+	IdempotencyKey     domain.IdempotencyKey
+	PurchaseDocumentID domain.StockDocumentID
+	Method             domain.PaymentMethod
+	Amount             domain.MinorAmount
+	PaidOn             domain.BusinessDate
+	Notes              domain.Option[domain.NonEmptyText]
+	ReversesPaymentID  domain.Option[domain.SupplierPaymentID]
+	RecordedAt         domain.UTCInstant
+}
+
+// SupplierPayment is an immutable record of money paid toward a purchase
+// payable. A reversal repeats the payment it reverses and counts against it.
+type SupplierPayment struct {
+	id                 domain.SupplierPaymentID
+	idempotencyKey     domain.IdempotencyKey
+	purchaseDocumentID domain.StockDocumentID
+	method             domain.PaymentMethod
+	amount             domain.MinorAmount
+	paidOn             domain.BusinessDate
+	notes              domain.Option[domain.NonEmptyText]
+	reversesPaymentID  domain.Option[domain.SupplierPaymentID]
+	recordedAt         domain.UTCInstant
+}
+
+func NewSupplierPayment(params SupplierPaymentParams) (SupplierPayment, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.ID.IsZero() {
+		violations = append(violations, required("supplier_payment_id"))
+	}
+	if params.IdempotencyKey.String() == "" {
+		violations = append(violations, domain.Violation{Field: "idempotency_key", Code: domain.ViolationRequired, InvariantID: "DOC-003"})
+	}
+	if params.PurchaseDocumentID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "purchase_document_id", Code: domain.ViolationRequired, InvariantID: "PAB-002"})
+	}
+	if _, err := domain.ParsePaymentMethod(params.Method.String()); err != nil || !params.Method.Settles() {
+		violations = append(violations, domain.Violation{Field: "method", Code: domain.ViolationInvalidEnum, InvariantID: "PAB-002"})
+	}
+	if params.Amount.Int64() <= 0 {
+		violations = append(violations, domain.Violation{Field: "amount_minor", Code: domain.ViolationNotPositive, InvariantID: "PAB-002"})
+	}
+	if params.PaidOn.IsZero() {
+		violations = append(violations, required("paid_on"))
+	}
+	if notes, ok := params.Notes.Get(); ok && notes.String() == "" {
+		violations = append(violations, required("notes"))
+	}
+	if reverses, ok := params.ReversesPaymentID.Get(); ok && (reverses.IsZero() || reverses == params.ID) {
+		violations = append(violations, domain.Violation{Field: "reverses_payment_id", Code: domain.ViolationInvariant, InvariantID: "PAB-002"})
+	}
+	if params.RecordedAt.IsZero() {
+		violations = append(violations, required("recorded_at"))
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return SupplierPayment{}, err
+	}
+	return SupplierPayment{
+		id: params.ID, idempotencyKey: params.IdempotencyKey, purchaseDocumentID: params.PurchaseDocumentID,
+		method: params.Method, amount: params.Amount, paidOn: params.PaidOn, notes: params.Notes,
+		reversesPaymentID: params.ReversesPaymentID, recordedAt: params.RecordedAt,
+	}, nil
+}
+
+func (p SupplierPayment) ID() domain.SupplierPaymentID               { return p.id }
+func (p SupplierPayment) IdempotencyKey() domain.IdempotencyKey      { return p.idempotencyKey }
+func (p SupplierPayment) PurchaseDocumentID() domain.StockDocumentID { return p.purchaseDocumentID }
+func (p SupplierPayment) Method() domain.PaymentMethod               { return p.method }
+func (p SupplierPayment) Amount() domain.MinorAmount                 { return p.amount }
+func (p SupplierPayment) PaidOn() domain.BusinessDate                { return p.paidOn }
+func (p SupplierPayment) Notes() domain.Option[domain.NonEmptyText]  { return p.notes }
+func (p SupplierPayment) RecordedAt() domain.UTCInstant              { return p.recordedAt }
+func (p SupplierPayment) ReversesPaymentID() domain.Option[domain.SupplierPaymentID] {
+	return p.reversesPaymentID
+}
+
+// IsReversal reports whether the payment reverses an earlier one.
+func (p SupplierPayment) IsReversal() bool { return p.reversesPaymentID.IsSome() }
+
+// SignedAmount is the amount, negated for a reversal.
+func (p SupplierPayment) SignedAmount() int64 {
+	if p.IsReversal() {
+		return -p.amount.Int64()
+	}
+	return p.amount.Int64()
+}
+
+type PayableParams struct {
+	PurchaseDocumentID domain.StockDocumentID
+	SupplierID         domain.CounterpartyID
+	OccurredOn         domain.BusinessDate
+	PurchaseTotal      domain.MinorAmount
+	Credited           domain.MinorAmount
+	Reversed           bool
+	Installments       []Installment
+	Payments           []SupplierPayment
+	CreatedAt          domain.UTCInstant
+}
+
+// Payable is what a supplier is owed for one purchase, split into dated
+// installments that add up to the purchase's commercial total. Supplier
+// return credits and payments cover the installments in due order; a
+// reversed purchase is owed nothing, and a negative outstanding balance is a
+// credit owed by the supplier.
+type Payable struct {
+	purchaseDocumentID domain.StockDocumentID
+	supplierID         domain.CounterpartyID
+	occurredOn         domain.BusinessDate
+	purchaseTotal      domain.MinorAmount
+	credited           domain.MinorAmount
+	reversed           bool
+	installments       []Installment
+	payments           []SupplierPayment
+	paid               int64
+	createdAt          domain.UTCInstant
+}
+
+func NewPayable(params PayableParams) (Payable, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.PurchaseDocumentID.IsZero() {
+		violations = append(violations, required("purchase_document_id"))
+	}
+	if params.SupplierID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "supplier_id", Code: domain.ViolationRequired, InvariantID: "PAB-001"})
+	}
+	if params.OccurredOn.IsZero() {
+		violations = append(violations, required("occurred_on"))
+	}
+	if params.Credited.Int64() > params.PurchaseTotal.Int64() {
+		violations = append(violations, domain.Violation{Field: "credited_minor", Code: domain.ViolationOutOfRange, InvariantID: "PAB-004"})
+	}
+	if len(params.Installments) == 0 {
+		violations = append(violations, domain.Violation{Field: "installments", Code: domain.ViolationRequired, InvariantID: "PAB-001"})
+	}
+	var scheduled int64
+	previous := params.OccurredOn
+	for index, installment := range params.Installments {
+		if installment.Number != index+1 {
+			violations = append(violations, domain.Violation{Field: "installment_number", Code: domain.ViolationInvariant, InvariantID: "PAB-001"})
+		}
+		if installment.DueOn.IsZero() || installment.DueOn.Before(previous) {
+			violations = append(violations, domain.Violation{Field: "due_on", Code: domain.ViolationOutOfRange, InvariantID: "PAB-001"})
+		}
+		if installment.Amount.Int64() <= 0 {
+			violations = append(violations, domain.Violation{Field: "amount_minor", Code: domain.ViolationNotPositive, InvariantID: "PAB-001"})
+		}
+		previous = installment.DueOn
+		scheduled += installment.Amount.Int64()
+	}
+	if len(params.Installments) > 0 && scheduled != params.PurchaseTotal.Int64() {
+		violations = append(violations, domain.Violation{Field: "installments", Code: domain.ViolationInvariant, InvariantID: "PAB-001"})
+	}
+	payments := make(map[domain.SupplierPaymentID]SupplierPayment, len(params.Payments))
+	reversed := make(map[domain.SupplierPaymentID]struct{}, len(params.Payments))
+	var paid int64
+	for _, payment := range params.Payments {
+		if payment.PurchaseDocumentID() != params.PurchaseDocumentID {
+			violations = append(violations, domain.Violation{Field: "payments", Code: domain.ViolationInvariant, InvariantID: "PAB-002"})
+			continue
+		}
+		if target, ok := payment.ReversesPaymentID().Get(); ok {
+			original, found := payments[target]
+			_, duplicate := reversed[target]
+			if !found || duplicate || original.IsReversal() ||
+				original.Method() != payment.Method() || original.Amount() != payment.Amount() {
+				violations = append(violations, domain.Violation{Field: "reverses_payment_id", Code: domain.ViolationInvariant, InvariantID: "PAB-002"})
+				continue
+			}
+			reversed[target] = struct{}{}
+		}
+		payments[payment.ID()] = payment
+		paid += payment.SignedAmount()
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Payable{}, err
+	}
+	return Payable{
+		purchaseDocumentID: params.PurchaseDocumentID, supplierID: params.SupplierID, occurredOn: params.OccurredOn,
+		purchaseTotal: params.PurchaseTotal, credited: params.Credited, reversed: params.Reversed,
+		installments: append([]Installment(nil), params.Installments...),
+		payments:     append([]SupplierPayment(nil), params.Payments...),
+		paid:         paid, createdAt: params.CreatedAt,
+	}, nil
+}
+
+func (p Payable) PurchaseDocumentID() domain.StockDocumentID { return p.purchaseDocumentID }
+func (p Payable) SupplierID() domain.CounterpartyID          { return p.supplierID }
+func (p Payable) OccurredOn() domain.BusinessDate            { return p.occurredOn }
+func (p Payable) PurchaseTotal() domain.MinorAmount          { return p.purchaseTotal }
+func (p Payable) Credited() domain.MinorAmount               { return p.credited }
+func (p Payable) Reversed() bool                             { return p.reversed }
+func (p Payable) Paid() int64                                { return p.paid }
+func (p Payable) CreatedAt() domain.UTCInstant               { return p.createdAt }
+func (p Payable) Installments() []Installment {
+	return append([]Installment(nil), p.installments...)
+}
+func (p Payable) Payments() []SupplierPayment {
+	return append([]SupplierPayment(nil), p.payments...)
+}
+
+// Owed is what the purchase is owed in total: its commercial total less its
+// supplier return credits, or nothing once the purchase is reversed.
+func (p Payable) Owed() int64 {
+	if p.reversed {
+		return 0
+	}
+	return p.purchaseTotal.Int64() - p.credited.Int64()
+}
+
+// Outstanding is what is owed and not yet paid.
+func (p Payable) Outstanding() int64 { return p.Owed() - p.paid }
+
+// OpenInstallment is an installment with the part of it still unpaid.
+type OpenInstallment struct {
+	Installment
+	OpenMinor int64
+}
+
+// OpenInstallments returns the installments not yet covered. Everything the
+// purchase is no longer owed, and every payment, covers installments from the
+// first due onward.
+func (p Payable) OpenInstallments() []OpenInstallment {
+	covered := p.purchaseTotal.Int64() - p.Outstanding()
+	var open []OpenInstallment
+	for _, installment := range p.installments {
+		amount := installment.Amount.Int64()
+		if covered >= amount {
+			covered -= amount
+			continue
+		}
+		open = append(open, OpenInstallment{Installment: installment, OpenMinor: amount - max(covered, 0)})
+		covered = 0
+	}
+	return open
+}
+
+// DueInstallment is an open installment of one purchase that is overdue or
+// due within the week.
+type DueInstallment struct {
+	PurchaseDocumentID domain.StockDocumentID
+	OpenInstallment
+	Overdue bool
+}
+
+// SupplierDue lists the overdue and this week's installments of one supplier.
+type SupplierDue struct {
+	SupplierID       domain.CounterpartyID
+	Installments     []DueInstallment
+	OverdueMinor     int64
+	DueThisWeekMinor int64
+}
+
+type PayablesDue struct {
+	asOf             domain.BusinessDate
+	suppliers        []SupplierDue
+	overdueMinor     int64
+	dueThisWeekMinor int64
+}
+
+// DuePayables lists, per supplier, every open installment due before asOf as
+// overdue and every one due from asOf through the following six days as due
+// this week. Suppliers are ordered by id and installments by due date.
+func DuePayables(asOf domain.BusinessDate, payables []Payable) (PayablesDue, error) {
+	if asOf.IsZero() {
+		return PayablesDue{}, domain.Invalid("as_of", domain.ViolationRequired, "PAB-004")
+	}
+	indexes := make(map[domain.CounterpartyID]int)
+	var suppliers []SupplierDue
+	var result PayablesDue
+	for _, payable := range payables {
+		for _, installment := range payable.OpenInstallments() {
+			days := installment.DueOn.DaysSince(asOf)
+			if days > 6 {
+				continue
+			}
+			index, ok := indexes[payable.SupplierID()]
+			if !ok {
+				index = len(suppliers)
+				indexes[payable.SupplierID()] = index
+				suppliers = append(suppliers, SupplierDue{SupplierID: payable.SupplierID()})
+			}
+			due := DueInstallment{
+				PurchaseDocumentID: payable.PurchaseDocumentID(),
+				OpenInstallment:    installment,
+				Overdue:            days < 0,
+			}
+			suppliers[index].Installments = append(suppliers[index].Installments, due)
+			if due.Overdue {
+				suppliers[index].OverdueMinor += installment.OpenMinor
+				result.overdueMinor += installment.OpenMinor
+			} else {
+				suppliers[index].DueThisWeekMinor += installment.OpenMinor
+				result.dueThisWeekMinor += installment.OpenMinor
+			}
+		}
+	}
+	sort.Slice(suppliers, func(i, j int) bool {
+		return suppliers[i].SupplierID.Int64() < suppliers[j].SupplierID.Int64()
+	})
+	for _, supplier := range suppliers {
+		sort.SliceStable(supplier.Installments, func(i, j int) bool {
+			return supplier.Installments[i].DueOn.Before(supplier.Installments[j].DueOn)
+		})
+	}
+	result.asOf = asOf
+	result.suppliers = suppliers
+	return result, nil
+}
+
+func (d PayablesDue) AsOf() domain.BusinessDate { return d.asOf }
+func (d PayablesDue) Suppliers() []SupplierDue {
+	return append([]SupplierDue(nil), d.suppliers...)
+}
+func (d PayablesDue) OverdueMinor() int64     { return d.overdueMinor }
+func (d PayablesDue) DueThisWeekMinor() int64 { return d.dueThisWeekMinor }
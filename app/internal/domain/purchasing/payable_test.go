@@ -0,0 +1,145 @@
+package purchasing_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+)
+
+func TestPayableInstallmentsMustAddUpToThePurchaseTotal(t *testing.T) {
+	params := purchasing.PayableParams{
+		PurchaseDocumentID: must(domain.NewStockDocumentID(7)),
+		SupplierID:         must(domain.NewCounterpartyID(3)),
+		OccurredOn:         must(domain.ParseBusinessDate("2026-10-01")),
+		PurchaseTotal:      must(domain.NewMinorAmount(9_000)),
+		Installments: []purchasing.Installment{
+			installment(1, "2026-10-15", 3_000),
+			installment(2, "2026-11-15", 3_000),
+			installment(3, "2026-12-15", 3_000),
+		},
+		CreatedAt: must(domain.UTCInstantFromUnixMilli(1_000)),
+	}
+	if _, err := purchasing.NewPayable(params); err != nil {
+		t.Fatalf("new payable: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		installments []purchasing.Installment
+	}{
+		{"no installments", nil},
+		{"short of the total", []purchasing.Installment{installment(1, "2026-10-15", 8_999)}},
+		{"above the total", []purchasing.Installment{
+			installment(1, "2026-10-15", 9_000), installment(2, "2026-10-16", 1),
+		}},
+		{"due before the purchase", []purchasing.Installment{installment(1, "2026-09-30", 9_000)}},
+		{"due out of order", []purchasing.Installment{
+			installment(1, "2026-10-15", 4_500), installment(2, "2026-10-14", 4_500),
+		}},
+		{"skipped number", []purchasing.Installment{
+			installment(1, "2026-10-15", 4_500), installment(3, "2026-10-16", 4_500),
+		}},
+	}
+	for _, tc := range tests {
+		invalid := params
+		invalid.Installments = tc.installments
+		if _, err := purchasing.NewPayable(invalid); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("%s error = %v", tc.name, err)
+		}
+	}
+
+	cash := supplierPayment(1, domain.PaymentCash, 100, domain.None[domain.SupplierPaymentID]())
+	reversedTwice := params
+	reversedTwice.Payments = []purchasing.SupplierPayment{
+		cash,
+		supplierPayment(2, domain.PaymentCash, 100, domain.Some(cash.ID())),
+		supplierPayment(3, domain.PaymentCash, 100, domain.Some(cash.ID())),
+	}
+	if _, err := purchasing.NewPayable(reversedTwice); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("payment reversed twice error = %v", err)
+	}
+	if _, err := purchasing.NewSupplierPayment(purchasing.SupplierPaymentParams{
+		ID:                 must(domain.NewSupplierPaymentID(4)),
+		IdempotencyKey:     must(domain.NewIdempotencyKey("supplier-payment-4")),
+		PurchaseDocumentID: params.PurchaseDocumentID,
+		Method:             domain.PaymentOnAccount,
+		Amount:             must(domain.NewMinorAmount(100)),
+		PaidOn:             must(domain.ParseBusinessDate("2026-10-02")),
+		RecordedAt:         must(domain.UTCInstantFromUnixMilli(1_000)),
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("on-account supplier payment error = %v", err)
+	}
+}
+
+func TestDuePayablesListsOverdueAndThisWeeksOpenInstallments(t *testing.T) {
+	asOf := must(domain.ParseBusinessDate("2026-11-16"))
+	pix := supplierPayment(1, domain.PaymentPix, 4_000, domain.None[domain.SupplierPaymentID]())
+	payable := must(purchasing.NewPayable(purchasing.PayableParams{
+		PurchaseDocumentID: must(domain.NewStockDocumentID(7)),
+		SupplierID:         must(domain.NewCounterpartyID(3)),
+		OccurredOn:         must(domain.ParseBusinessDate("2026-10-01")),
+		PurchaseTotal:      must(domain.NewMinorAmount(9_000)),
+		Credited:           must(domain.NewMinorAmount(500)),
+		Installments: []purchasing.Installment{
+			installment(1, "2026-10-15", 3_000),
+			installment(2, "2026-11-15", 3_000),
+			installment(3, "2026-11-22", 2_000),
+			installment(4, "2026-11-23", 1_000),
+		},
+		Payments:  []purchasing.SupplierPayment{pix},
+		CreatedAt: must(domain.UTCInstantFromUnixMilli(1_000)),
+	}))
+	if payable.Owed() != 8_500 || payable.Paid() != 4_000 || payable.Outstanding() != 4_500 {
+		t.Fatalf("payable = %d owed, %d paid, %d outstanding", payable.Owed(), payable.Paid(), payable.Outstanding())
+	}
+	open := payable.OpenInstallments()
+	if len(open) != 3 || open[0].Number != 2 || open[0].OpenMinor != 1_500 {
+		t.Fatalf("open installments = %#v", open)
+	}
+
+	due, err := purchasing.DuePayables(asOf, []purchasing.Payable{payable})
+	if err != nil {
+		t.Fatalf("due payables: %v", err)
+	}
+	suppliers := due.Suppliers()
+	if len(suppliers) != 1 || len(suppliers[0].Installments) != 2 ||
+		!suppliers[0].Installments[0].Overdue || suppliers[0].Installments[1].Overdue ||
+		suppliers[0].OverdueMinor != 1_500 || suppliers[0].DueThisWeekMinor != 2_000 {
+		t.Fatalf("due suppliers = %#v", suppliers)
+	}
+	if due.OverdueMinor() != 1_500 || due.DueThisWeekMinor() != 2_000 {
+		t.Fatalf("due totals = %d overdue, %d this week", due.OverdueMinor(), due.DueThisWeekMinor())
+	}
+	if _, err := purchasing.DuePayables(domain.BusinessDate{}, nil); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("missing as-of error = %v", err)
+	}
+}
+
+func installment(number int, dueOn string, amount int64) purchasing.Installment {
+	return purchasing.Installment{
+		Number: number,
+		DueOn:  must(domain.ParseBusinessDate(dueOn)),
+		Amount: must(domain.NewMinorAmount(amount)),
+	}
+}
+
+func supplierPayment(
+	id int64,
+	method domain.PaymentMethod,
+	amount int64,
+	reverses domain.Option[domain.SupplierPaymentID],
+) purchasing.SupplierPayment {
+	return must(purchasing.NewSupplierPayment(purchasing.SupplierPaymentParams{
+		ID:                 must(domain.NewSupplierPaymentID(id)),
+		IdempotencyKey:     must(domain.NewIdempotencyKey(fmt.Sprintf("supplier-payment-%d", id))),
+		PurchaseDocumentID: must(domain.NewStockDocumentID(7)),
+		Method:             method,
+		Amount:             must(domain.NewMinorAmount(amount)),
+		PaidOn:             must(domain.ParseBusinessDate("2026-10-02")),
+		ReversesPaymentID:  reverses,
+		RecordedAt:         must(domain.UTCInstantFromUnixMilli(1_000)),
+	}))
+}
@@ -94,6 +94,31 @@ func (f Fraction) Int64Exact() (int64, error) {
 	return f.numerator, nil
 }
 
+// RoundHalfUp returns the nearest integer, rounding exact halves away from
+// zero like the weighted-average valuation.
+func (f Fraction) RoundHalfUp() (int64, error) {
+	if !f.IsValid() {
+		return 0, ErrInvariant
+	}
+	quotient, remainder := f.numerator/f.denominator, f.numerator%f.denominator
+	if remainder >= f.denominator-remainder {
+		quotient++
+	}
+	return quotient, nil
+}
+
+// Ceil returns the smallest integer not below a nonnegative fraction.
+func (f Fraction) Ceil() (int64, error) {
+	if !f.IsValid() {
+		return 0, ErrInvariant
+	}
+	quotient := f.numerator / f.denominator
+	if f.numerator%f.denominator != 0 {
+		quotient++
+	}
+	return quotient, nil
+}
+
 // ParseDecimalFraction accepts a plain, locale-independent decimal using a
 // dot separator. Signs other than an optional leading plus, exponents,
 // commas, and incomplete decimal forms are rejected.
@@ -0,0 +1,217 @@
+package sales
+
+import "github.com/jerobas/saas/internal/domain"
+
+type OrderLineParams struct {
+	ID                   domain.CustomerOrderLineID
+	OrderID              domain.CustomerOrderID
+	LineOrder            domain.LineOrder
+	ItemID               domain.ItemID
+	Quantity             domain.AtomicQuantity
+	EnteredUnit          domain.UnitCode
+	EnteredPackagingName domain.Option[domain.NonEmptyText]
+	Conversion           domain.UnitConversion
+	AgreedTotal          domain.MinorAmount
+}
+
+// OrderLine is an ordered quantity in the entered unit or packaging snapshot
+// and the price agreed with the customer for all of it.
+type OrderLine struct {
+	id                   domain.CustomerOrderLineID
+	orderID              domain.CustomerOrderID
+	lineOrder            domain.LineOrder
+	itemID               domain.ItemID
+	quantity             domain.AtomicQuantity
+	enteredUnit          domain.UnitCode
+	enteredPackagingName domain.Option[domain.NonEmptyText]
+	conversion           domain.UnitConversion
+	agreedTotal          domain.MinorAmount
+}
+
+func NewOrderLine(params OrderLineParams) (OrderLine, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.ID.IsZero() {
+		violations = append(violations, required("customer_order_line_id"))
+	}
+	if params.OrderID.IsZero() {
+		violations = append(violations, required("customer_order_id"))
+	}
+	if params.LineOrder.IsZero() {
+		violations = append(violations, required("line_order"))
+	}
+	if params.ItemID.IsZero() {
+		violations = append(violations, required("item_id"))
+	}
+	if params.Quantity.Int64() <= 0 {
+		violations = append(violations, domain.Violation{Field: "quantity_atomic", Code: domain.ViolationNotPositive, InvariantID: "COR-001"})
+	}
+	if params.EnteredUnit.String() == "" {
+		violations = append(violations, required("entered_unit_code"))
+	}
+	if name, ok := params.EnteredPackagingName.Get(); ok && name.String() == "" {
+		violations = append(violations, required("entered_packaging_name"))
+	}
+	if params.Conversion.IsZero() {
+		violations = append(violations, required("conversion"))
+	}
+	if params.AgreedTotal.Int64() <= 0 {
+		violations = append(violations, domain.Violation{Field: "agreed_total_minor", Code: domain.ViolationNotPositive, InvariantID: "COR-001"})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return OrderLine{}, err
+	}
+	return OrderLine{
+		id: params.ID, orderID: params.OrderID, lineOrder: params.LineOrder,
+		itemID: params.ItemID, quantity: params.Quantity, enteredUnit: params.EnteredUnit,
+		enteredPackagingName: params.EnteredPackagingName, conversion: params.Conversion,
+		agreedTotal: params.AgreedTotal,
+	}, nil
+}
+
+func (l OrderLine) ID() domain.CustomerOrderLineID    { return l.id }
+func (l OrderLine) OrderID() domain.CustomerOrderID   { return l.orderID }
+func (l OrderLine) LineOrder() domain.LineOrder       { return l.lineOrder }
+func (l OrderLine) ItemID() domain.ItemID             { return l.itemID }
+func (l OrderLine) Quantity() domain.AtomicQuantity   { return l.quantity }
+func (l OrderLine) EnteredUnit() domain.UnitCode      { return l.enteredUnit }
+func (l OrderLine) Conversion() domain.UnitConversion { return l.conversion }
+func (l OrderLine) EnteredPackagingName() domain.Option[domain.NonEmptyText] {
+	return l.enteredPackagingName
+}
+func (l OrderLine) AgreedTotal() domain.MinorAmount { return l.agreedTotal }
+
+type OrderParams struct {
+	ID             domain.CustomerOrderID
+	CustomerID     domain.CounterpartyID
+	Status         domain.CustomerOrderStatus
+	OrderedOn      domain.BusinessDate
+	DueOn          domain.BusinessDate
+	Deposit        domain.MinorAmount
+	Notes          domain.Option[domain.NonEmptyText]
+	SaleDocumentID domain.Option[domain.StockDocumentID]
+	CreatedAt      domain.UTCInstant
+	UpdatedAt      domain.UTCInstant
+	Lines          []OrderLine
+}
+
+// Order is a customer order taken ahead of its due date. It never affects
+// stock: fulfilling it posts one SALE at the agreed prices and records that
+// sale here.
+type Order struct {
+	id             domain.CustomerOrderID
+	customerID     domain.CounterpartyID
+	status         domain.CustomerOrderStatus
+	orderedOn      domain.BusinessDate
+	dueOn          domain.BusinessDate
+	deposit        domain.MinorAmount
+	agreedTotal    domain.MinorAmount
+	notes          domain.Option[domain.NonEmptyText]
+	saleDocumentID domain.Option[domain.StockDocumentID]
+	createdAt      domain.UTCInstant
+	updatedAt      domain.UTCInstant
+	lines          []OrderLine
+}
+
+func NewOrder(params OrderParams) (Order, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.ID.IsZero() {
+		violations = append(violations, required("customer_order_id"))
+	}
+	if params.CustomerID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "customer_id", Code: domain.ViolationRequired, InvariantID: "COR-001"})
+	}
+	if _, err := domain.ParseCustomerOrderStatus(params.Status.String()); err != nil {
+		violations = append(violations, domain.Violation{Field: "status", Code: domain.ViolationInvalidEnum, InvariantID: "COR-002"})
+	}
+	if params.OrderedOn.IsZero() {
+		violations = append(violations, required("ordered_on"))
+	}
+	if params.DueOn.IsZero() {
+		violations = append(violations, required("due_on"))
+	} else if params.DueOn.Before(params.OrderedOn) {
+		violations = append(violations, domain.Violation{Field: "due_on", Code: domain.ViolationOutOfRange, InvariantID: "COR-001"})
+	}
+	if name, ok := params.Notes.Get(); ok && name.String() == "" {
+		violations = append(violations, required("notes"))
+	}
+	if (params.Status == domain.CustomerOrderFulfilled) != params.SaleDocumentID.IsSome() {
+		violations = append(violations, domain.Violation{Field: "sale_document_id", Code: domain.ViolationInvariant, InvariantID: "COR-003"})
+	}
+	if err := domain.ValidateTimestampOrder(params.CreatedAt, params.UpdatedAt, domain.None[domain.UTCInstant]()); err != nil {
+		if validation, ok := err.(*domain.ValidationError); ok {
+			violations = append(violations, validation.Violations()...)
+		} else {
+			violations = append(violations, domain.Violation{Field: "timestamps", Code: domain.ViolationInvariant})
+		}
+	}
+	if len(params.Lines) == 0 {
+		violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationRequired, InvariantID: "COR-001"})
+	}
+	seenOrders := make(map[int64]struct{}, len(params.Lines))
+	total := domain.MinorAmount{}
+	for _, line := range params.Lines {
+		if line.ID().IsZero() || line.OrderID() != params.ID {
+			violations = append(violations, domain.Violation{Field: "lines", Code: domain.ViolationInvariant, InvariantID: "COR-001"})
+			continue
+		}
+		if _, duplicate := seenOrders[line.LineOrder().Int64()]; duplicate {
+			violations = append(violations, domain.Violation{Field: "line_order", Code: domain.ViolationDuplicate, InvariantID: "COR-001"})
+		}
+		seenOrders[line.LineOrder().Int64()] = struct{}{}
+		sum, err := total.Add(line.AgreedTotal())
+		if err != nil {
+			violations = append(violations, domain.Violation{Field: "agreed_total_minor", Code: domain.ViolationOutOfRange, InvariantID: "COR-001"})
+			continue
+		}
+		total = sum
+	}
+	if params.Deposit.Int64() > total.Int64() {
+		violations = append(violations, domain.Violation{Field: "deposit_minor", Code: domain.ViolationOutOfRange, InvariantID: "COR-001"})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Order{}, err
+	}
+	lines := make([]OrderLine, len(params.Lines))
+	copy(lines, params.Lines)
+	return Order{
+		id: params.ID, customerID: params.CustomerID, status: params.Status,
+		orderedOn: params.OrderedOn, dueOn: params.DueOn, deposit: params.Deposit, agreedTotal: total,
+		notes: params.Notes, saleDocumentID: params.SaleDocumentID,
+		createdAt: params.CreatedAt, updatedAt: params.UpdatedAt, lines: lines,
+	}, nil
+}
+
+func (o Order) ID() domain.CustomerOrderID                { return o.id }
+func (o Order) CustomerID() domain.CounterpartyID         { return o.customerID }
+func (o Order) Status() domain.CustomerOrderStatus        { return o.status }
+func (o Order) OrderedOn() domain.BusinessDate            { return o.orderedOn }
+func (o Order) DueOn() domain.BusinessDate                { return o.dueOn }
+func (o Order) Deposit() domain.MinorAmount               { return o.deposit }
+func (o Order) Notes() domain.Option[domain.NonEmptyText] { return o.notes }
+func (o Order) SaleDocumentID() domain.Option[domain.StockDocumentID] {
+	return o.saleDocumentID
+}
+func (o Order) CreatedAt() domain.UTCInstant { return o.createdAt }
+func (o Order) UpdatedAt() domain.UTCInstant { return o.updatedAt }
+func (o Order) Lines() []OrderLine {
+	lines := make([]OrderLine, len(o.lines))
+	copy(lines, o.lines)
+	return lines
+}
+
+// AgreedTotal is the sum of the agreed line totals.
+func (o Order) AgreedTotal() domain.MinorAmount { return o.agreedTotal }
+
+// BalanceDue is the agreed total not covered by the deposit.
+func (o Order) BalanceDue() domain.MinorAmount {
+	balance, _ := o.agreedTotal.Sub(o.deposit)
+	return balance
+}
+
+// IsOpen reports whether the order may still be edited, fulfilled, or
+// cancelled.
+func (o Order) IsOpen() bool { return o.status == domain.CustomerOrderOpen }
+
+func required(field string) domain.Violation {
+	return domain.Violation{Field: field, Code: domain.ViolationRequired}
+}
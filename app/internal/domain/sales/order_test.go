@@ -0,0 +1,71 @@
+package sales_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+func TestOrderKeepsDepositWithinAgreedTotal(t *testing.T) {
+	line := func(id, total int64) sales.OrderLine {
+		return must(sales.NewOrderLine(sales.OrderLineParams{
+			ID: must(domain.NewCustomerOrderLineID(id)), OrderID: must(domain.NewCustomerOrderID(1)),
+			LineOrder: must(domain.NewLineOrder(id)), ItemID: must(domain.NewItemID(id)),
+			Quantity:    must(domain.NewPositiveAtomicQuantity(1)),
+			EnteredUnit: must(domain.NewUnitCode("un")), Conversion: must(domain.NewUnitConversion(1, 1)),
+			AgreedTotal: must(domain.NewMinorAmount(total)),
+		}))
+	}
+	params := sales.OrderParams{
+		ID: must(domain.NewCustomerOrderID(1)), CustomerID: must(domain.NewCounterpartyID(3)),
+		Status:    domain.CustomerOrderOpen,
+		OrderedOn: must(domain.ParseBusinessDate("2026-10-18")), DueOn: must(domain.ParseBusinessDate("2026-10-25")),
+		Deposit:   must(domain.NewMinorAmount(5_000)),
+		CreatedAt: must(domain.UTCInstantFromUnixMilli(1_000)), UpdatedAt: must(domain.UTCInstantFromUnixMilli(1_000)),
+		Lines: []sales.OrderLine{line(1, 8_000), line(2, 4_500)},
+	}
+	order, err := sales.NewOrder(params)
+	if err != nil {
+		t.Fatalf("new order: %v", err)
+	}
+	if order.AgreedTotal().Int64() != 12_500 || order.BalanceDue().Int64() != 7_500 || !order.IsOpen() {
+		t.Fatalf("order totals = %d, %d", order.AgreedTotal().Int64(), order.BalanceDue().Int64())
+	}
+
+	tests := []struct {
+		name   string
+		change func(*sales.OrderParams)
+	}{
+		{"deposit above agreed total", func(p *sales.OrderParams) { p.Deposit = must(domain.NewMinorAmount(12_501)) }},
+		{"due before ordered", func(p *sales.OrderParams) { p.DueOn = must(domain.ParseBusinessDate("2026-10-17")) }},
+		{"fulfilled without sale", func(p *sales.OrderParams) { p.Status = domain.CustomerOrderFulfilled }},
+		{"open with sale", func(p *sales.OrderParams) {
+			p.SaleDocumentID = domain.Some(must(domain.NewStockDocumentID(9)))
+		}},
+		{"no lines", func(p *sales.OrderParams) { p.Lines = nil }},
+	}
+	for _, tc := range tests {
+		invalid := params
+		tc.change(&invalid)
+		if _, err := sales.NewOrder(invalid); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("%s error = %v, want validation", tc.name, err)
+		}
+	}
+	if _, err := sales.NewOrderLine(sales.OrderLineParams{
+		ID: must(domain.NewCustomerOrderLineID(1)), OrderID: must(domain.NewCustomerOrderID(1)),
+		LineOrder: must(domain.NewLineOrder(1)), ItemID: must(domain.NewItemID(1)),
+		Quantity:    must(domain.NewPositiveAtomicQuantity(1)),
+		EnteredUnit: must(domain.NewUnitCode("un")), Conversion: must(domain.NewUnitConversion(1, 1)),
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("free line error = %v, want validation", err)
+	}
+}
+
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
@@ -0,0 +1,273 @@
+package sales
+
+import (
+	"sort"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type PaymentParams struct {
+	ID                domain.SalePaymentID
+	IdempotencyKey    domain.IdempotencyKey
+	SaleDocumentID    domain.StockDocumentID
+	Method            domain.PaymentMethod
+	Amount            domain.MinorAmount
+	ReceivedOn        domain.BusinessDate
+	Notes             domain.Option[domain.NonEmptyText]
+	ReversesPaymentID domain.Option[domain.SalePaymentID]
+	RecordedAt        domain.UTCInstant
+}
+
+// Payment is an immutable record of money received for a sale, or of the part
+// charged to the customer's account. A reversal repeats the payment it
+// reverses and counts against it.
+type Payment struct {
+	id                domain.SalePaymentID
+	idempotencyKey    domain.IdempotencyKey
+	saleDocumentID    domain.StockDocumentID
+	method            domain.PaymentMethod
+	amount            domain.MinorAmount
+	receivedOn        domain.BusinessDate
+	notes             domain.Option[domain.NonEmptyText]
+	reversesPaymentID domain.Option[domain.SalePaymentID]
+	recordedAt        domain.UTCInstant
+}
+
+func NewPayment(params PaymentParams) (Payment, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.ID.IsZero() {
+		violations = append(violations, required("sale_payment_id"))
+	}
+	if params.IdempotencyKey.String() == "" {
+		violations = append(violations, domain.Violation{Field: "idempotency_key", Code: domain.ViolationRequired, InvariantID: "DOC-003"})
+	}
+	if params.SaleDocumentID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "sale_document_id", Code: domain.ViolationRequired, InvariantID: "PAY-001"})
+	}
+	if _, err := domain.ParsePaymentMethod(params.Method.String()); err != nil {
+		violations = append(violations, domain.Violation{Field: "method", Code: domain.ViolationInvalidEnum, InvariantID: "PAY-001"})
+	}
+	if params.Amount.Int64() <= 0 {
+		violations = append(violations, domain.Violation{Field: "amount_minor", Code: domain.ViolationNotPositive, InvariantID: "PAY-001"})
+	}
+	if params.ReceivedOn.IsZero() {
+		violations = append(violations, required("received_on"))
+	}
+	if notes, ok := params.Notes.Get(); ok && notes.String() == "" {
+		violations = append(violations, required("notes"))
+	}
+	if reverses, ok := params.ReversesPaymentID.Get(); ok && (reverses.IsZero() || reverses == params.ID) {
+		violations = append(violations, domain.Violation{Field: "reverses_payment_id", Code: domain.ViolationInvariant, InvariantID: "PAY-002"})
+	}
+	if params.RecordedAt.IsZero() {
+		violations = append(violations, required("recorded_at"))
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return Payment{}, err
+	}
+	return Payment{
+		id: params.ID, idempotencyKey: params.IdempotencyKey, saleDocumentID: params.SaleDocumentID,
+		method: params.Method, amount: params.Amount, receivedOn: params.ReceivedOn, notes: params.Notes,
+		reversesPaymentID: params.ReversesPaymentID, recordedAt: params.RecordedAt,
+	}, nil
+}
+
+func (p Payment) ID() domain.SalePaymentID                  { return p.id }
+func (p Payment) IdempotencyKey() domain.IdempotencyKey     { return p.idempotencyKey }
+func (p Payment) SaleDocumentID() domain.StockDocumentID    { return p.saleDocumentID }
+func (p Payment) Method() domain.PaymentMethod              { return p.method }
+func (p Payment) Amount() domain.MinorAmount                { return p.amount }
+func (p Payment) ReceivedOn() domain.BusinessDate           { return p.receivedOn }
+func (p Payment) Notes() domain.Option[domain.NonEmptyText] { return p.notes }
+func (p Payment) ReversesPaymentID() domain.Option[domain.SalePaymentID] {
+	return p.reversesPaymentID
+}
+func (p Payment) RecordedAt() domain.UTCInstant { return p.recordedAt }
+
+// IsReversal reports whether the payment reverses an earlier one.
+func (p Payment) IsReversal() bool { return p.reversesPaymentID.IsSome() }
+
+// SignedAmount is the amount, negated for a reversal.
+func (p Payment) SignedAmount() int64 {
+	if p.IsReversal() {
+		return -p.amount.Int64()
+	}
+	return p.amount.Int64()
+}
+
+type BalanceParams struct {
+	SaleDocumentID domain.StockDocumentID
+	CustomerID     domain.Option[domain.CounterpartyID]
+	OccurredOn     domain.BusinessDate
+	SaleTotal      domain.MinorAmount
+	Returned       domain.MinorAmount
+	Reversed       bool
+	Payments       []Payment
+}
+
+// SaleBalance is what a customer still owes on one sale. The receivable is
+// the sale's commercial total less its unreversed returns, or nothing once the
+// sale is reversed; CASH, PIX, and CARD payments settle it. A negative
+// outstanding balance is a credit owed to the customer.
+type SaleBalance struct {
+	saleDocumentID domain.StockDocumentID
+	customerID     domain.Option[domain.CounterpartyID]
+	occurredOn     domain.BusinessDate
+	saleTotal      domain.MinorAmount
+	returned       domain.MinorAmount
+	reversed       bool
+	settled        int64
+	onAccount      int64
+	payments       []Payment
+}
+
+func NewSaleBalance(params BalanceParams) (SaleBalance, error) {
+	violations := make([]domain.Violation, 0, 4)
+	if params.SaleDocumentID.IsZero() {
+		violations = append(violations, required("sale_document_id"))
+	}
+	if params.OccurredOn.IsZero() {
+		violations = append(violations, required("occurred_on"))
+	}
+	if params.Returned.Int64() > params.SaleTotal.Int64() {
+		violations = append(violations, domain.Violation{Field: "returned_minor", Code: domain.ViolationOutOfRange, InvariantID: "PAY-004"})
+	}
+	payments := make(map[domain.SalePaymentID]Payment, len(params.Payments))
+	reversed := make(map[domain.SalePaymentID]struct{}, len(params.Payments))
+	var settled, onAccount int64
+	for _, payment := range params.Payments {
+		if payment.SaleDocumentID() != params.SaleDocumentID {
+			violations = append(violations, domain.Violation{Field: "payments", Code: domain.ViolationInvariant, InvariantID: "PAY-002"})
+			continue
+		}
+		if target, ok := payment.ReversesPaymentID().Get(); ok {
+			original, found := payments[target]
+			_, duplicate := reversed[target]
+			if !found || duplicate || original.IsReversal() ||
+				original.Method() != payment.Method() || original.Amount() != payment.Amount() {
+				violations = append(violations, domain.Violation{Field: "reverses_payment_id", Code: domain.ViolationInvariant, InvariantID: "PAY-002"})
+				continue
+			}
+			reversed[target] = struct{}{}
+		}
+		payments[payment.ID()] = payment
+		if payment.Method().Settles() {
+			settled += payment.SignedAmount()
+		} else {
+			onAccount += payment.SignedAmount()
+		}
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return SaleBalance{}, err
+	}
+	cloned := make([]Payment, len(params.Payments))
+	copy(cloned, params.Payments)
+	return SaleBalance{
+		saleDocumentID: params.SaleDocumentID, customerID: params.CustomerID, occurredOn: params.OccurredOn,
+		saleTotal: params.SaleTotal, returned: params.Returned, reversed: params.Reversed,
+		settled: settled, onAccount: onAccount, payments: cloned,
+	}, nil
+}
+
+func (b SaleBalance) SaleDocumentID() domain.StockDocumentID           { return b.saleDocumentID }
+func (b SaleBalance) CustomerID() domain.Option[domain.CounterpartyID] { return b.customerID }
+func (b SaleBalance) OccurredOn() domain.BusinessDate                  { return b.occurredOn }
+func (b SaleBalance) SaleTotal() domain.MinorAmount                    { return b.saleTotal }
+func (b SaleBalance) Returned() domain.MinorAmount                     { return b.returned }
+func (b SaleBalance) Reversed() bool                                   { return b.reversed }
+func (b SaleBalance) Settled() int64                                   { return b.settled }
+func (b SaleBalance) OnAccount() int64                                 { return b.onAccount }
+func (b SaleBalance) Payments() []Payment {
+	payments := make([]Payment, len(b.payments))
+	copy(payments, b.payments)
+	return payments
+}
+
+// Receivable is what the sale is owed in total.
+func (b SaleBalance) Receivable() int64 {
+	if b.reversed {
+		return 0
+	}
+	return b.saleTotal.Int64() - b.returned.Int64()
+}
+
+// Outstanding is the receivable not yet settled.
+func (b SaleBalance) Outstanding() int64 { return b.Receivable() - b.settled }
+
+// AgingBuckets splits outstanding balances by the days from the sale's
+// business date: up to 30, 31 to 60, and over 60.
+type AgingBuckets struct {
+	Days0To30  int64
+	Days31To60 int64
+	Over60Days int64
+}
+
+func (b AgingBuckets) Total() int64 { return b.Days0To30 + b.Days31To60 + b.Over60Days }
+
+func (b *AgingBuckets) add(days int, amount int64) {
+	switch {
+	case days <= 30:
+		b.Days0To30 += amount
+	case days <= 60:
+		b.Days31To60 += amount
+	default:
+		b.Over60Days += amount
+	}
+}
+
+// CustomerAging is the aged receivable of one customer, or of sales without a
+// customer when CustomerID is None.
+type CustomerAging struct {
+	CustomerID domain.Option[domain.CounterpartyID]
+	SaleCount  int
+	Buckets    AgingBuckets
+}
+
+type ReceivablesAging struct {
+	asOf      domain.BusinessDate
+	customers []CustomerAging
+	totals    AgingBuckets
+}
+
+// AgeReceivables ages every positive outstanding balance of a sale that
+// occurred on or before asOf. Customers are ordered by id, with sales without
+// a customer last.
+func AgeReceivables(asOf domain.BusinessDate, balances []SaleBalance) (ReceivablesAging, error) {
+	if asOf.IsZero() {
+		return ReceivablesAging{}, domain.Invalid("as_of", domain.ViolationRequired, "PAY-004")
+	}
+	indexes := make(map[domain.Option[domain.CounterpartyID]]int)
+	var customers []CustomerAging
+	var totals AgingBuckets
+	for _, balance := range balances {
+		outstanding := balance.Outstanding()
+		if outstanding <= 0 || balance.OccurredOn().After(asOf) {
+			continue
+		}
+		index, ok := indexes[balance.CustomerID()]
+		if !ok {
+			index = len(customers)
+			indexes[balance.CustomerID()] = index
+			customers = append(customers, CustomerAging{CustomerID: balance.CustomerID()})
+		}
+		days := asOf.DaysSince(balance.OccurredOn())
+		customers[index].SaleCount++
+		customers[index].Buckets.add(days, outstanding)
+		totals.add(days, outstanding)
+	}
+	sort.Slice(customers, func(i, j int) bool {
+		left, leftOK := customers[i].CustomerID.Get()
+		right, rightOK := customers[j].CustomerID.Get()
+		if leftOK != rightOK {
+			return leftOK
+		}
+		return left.Int64() < right.Int64()
+	})
+	return ReceivablesAging{asOf: asOf, customers: customers, totals: totals}, nil
+}
+
+func (a ReceivablesAging) AsOf() domain.BusinessDate { return a.asOf }
+func (a ReceivablesAging) Customers() []CustomerAging {
+	return append([]CustomerAging(nil), a.customers...)
+}
+func (a ReceivablesAging) Totals() AgingBuckets { return a.totals }
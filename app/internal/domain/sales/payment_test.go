@@ -0,0 +1,129 @@
+package sales_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+func TestSaleBalanceSettlesOnlyWithCashPixAndCard(t *testing.T) {
+	cash := salePayment(1, 7, domain.PaymentCash, 3_000, domain.None[domain.SalePaymentID]())
+	onAccount := salePayment(2, 7, domain.PaymentOnAccount, 5_000, domain.None[domain.SalePaymentID]())
+	pix := salePayment(3, 7, domain.PaymentPix, 2_000, domain.None[domain.SalePaymentID]())
+	pixReversal := salePayment(4, 7, domain.PaymentPix, 2_000, domain.Some(pix.ID()))
+	params := sales.BalanceParams{
+		SaleDocumentID: must(domain.NewStockDocumentID(7)),
+		CustomerID:     domain.Some(must(domain.NewCounterpartyID(3))),
+		OccurredOn:     must(domain.ParseBusinessDate("2026-10-01")),
+		SaleTotal:      must(domain.NewMinorAmount(10_000)),
+		Returned:       must(domain.NewMinorAmount(1_000)),
+		Payments:       []sales.Payment{cash, onAccount, pix, pixReversal},
+	}
+	balance, err := sales.NewSaleBalance(params)
+	if err != nil {
+		t.Fatalf("new sale balance: %v", err)
+	}
+	if balance.Receivable() != 9_000 || balance.Settled() != 3_000 || balance.OnAccount() != 5_000 ||
+		balance.Outstanding() != 6_000 || len(balance.Payments()) != 4 {
+		t.Fatalf("balance = %d receivable, %d settled, %d on account, %d outstanding",
+			balance.Receivable(), balance.Settled(), balance.OnAccount(), balance.Outstanding())
+	}
+
+	params.Reversed = true
+	reversed := must(sales.NewSaleBalance(params))
+	if reversed.Receivable() != 0 || reversed.Outstanding() != -3_000 {
+		t.Fatalf("reversed sale outstanding = %d", reversed.Outstanding())
+	}
+
+	tests := []struct {
+		name     string
+		payments []sales.Payment
+	}{
+		{"payment of another sale", []sales.Payment{salePayment(1, 8, domain.PaymentCash, 1, domain.None[domain.SalePaymentID]())}},
+		{"reversal before its payment", []sales.Payment{pixReversal, pix}},
+		{"reversal with another amount", []sales.Payment{
+			pix, salePayment(4, 7, domain.PaymentPix, 1_999, domain.Some(pix.ID())),
+		}},
+		{"payment reversed twice", []sales.Payment{
+			pix, pixReversal, salePayment(5, 7, domain.PaymentPix, 2_000, domain.Some(pix.ID())),
+		}},
+	}
+	for _, tc := range tests {
+		invalid := params
+		invalid.Payments = tc.payments
+		if _, err := sales.NewSaleBalance(invalid); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("%s error = %v", tc.name, err)
+		}
+	}
+}
+
+func TestAgeReceivablesBucketsOutstandingBalancesByCustomer(t *testing.T) {
+	asOf := must(domain.ParseBusinessDate("2026-10-31"))
+	ana := domain.Some(must(domain.NewCounterpartyID(3)))
+	bruno := domain.Some(must(domain.NewCounterpartyID(2)))
+	anonymous := domain.None[domain.CounterpartyID]()
+	balances := []sales.SaleBalance{
+		saleBalance(1, ana, "2026-10-01", 1_000),
+		saleBalance(2, ana, "2026-09-30", 2_000),
+		saleBalance(3, ana, "2026-09-01", 4_000),
+		saleBalance(4, ana, "2026-08-31", 8_000),
+		saleBalance(5, anonymous, "2026-10-31", 500),
+		saleBalance(6, bruno, "2026-10-20", 0),
+		saleBalance(7, bruno, "2026-11-01", 300),
+	}
+
+	aging, err := sales.AgeReceivables(asOf, balances)
+	if err != nil {
+		t.Fatalf("age receivables: %v", err)
+	}
+	customers := aging.Customers()
+	if len(customers) != 2 || customers[0].CustomerID != ana || customers[1].CustomerID != anonymous {
+		t.Fatalf("aged customers = %#v", customers)
+	}
+	want := sales.AgingBuckets{Days0To30: 1_000, Days31To60: 6_000, Over60Days: 8_000}
+	if customers[0].Buckets != want || customers[0].SaleCount != 4 {
+		t.Fatalf("ana aging = %#v", customers[0])
+	}
+	if aging.Totals().Days0To30 != 1_500 || aging.Totals().Total() != 15_500 {
+		t.Fatalf("aging totals = %#v", aging.Totals())
+	}
+	if _, err := sales.AgeReceivables(domain.BusinessDate{}, balances); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("missing as-of error = %v", err)
+	}
+}
+
+func salePayment(
+	id, saleID int64,
+	method domain.PaymentMethod,
+	amount int64,
+	reverses domain.Option[domain.SalePaymentID],
+) sales.Payment {
+	return must(sales.NewPayment(sales.PaymentParams{
+		ID:                must(domain.NewSalePaymentID(id)),
+		IdempotencyKey:    must(domain.NewIdempotencyKey(fmt.Sprintf("payment-%d", id))),
+		SaleDocumentID:    must(domain.NewStockDocumentID(saleID)),
+		Method:            method,
+		Amount:            must(domain.NewMinorAmount(amount)),
+		ReceivedOn:        must(domain.ParseBusinessDate("2026-10-01")),
+		ReversesPaymentID: reverses,
+		RecordedAt:        must(domain.UTCInstantFromUnixMilli(1_000)),
+	}))
+}
+
+// saleBalance is an unpaid sale whose whole total is outstanding.
+func saleBalance(
+	id int64,
+	customer domain.Option[domain.CounterpartyID],
+	occurredOn string,
+	total int64,
+) sales.SaleBalance {
+	return must(sales.NewSaleBalance(sales.BalanceParams{
+		SaleDocumentID: must(domain.NewStockDocumentID(id)),
+		CustomerID:     customer,
+		OccurredOn:     must(domain.ParseBusinessDate(occurredOn)),
+		SaleTotal:      must(domain.NewMinorAmount(total)),
+	}))
+}
@@ -0,0 +1,222 @@
+package sales
+
+import "github.com/jerobas/saas/internal/domain"
+
+type PriceListPriceParams struct {
+	ItemID        domain.ItemID
+	PackagingID   domain.Option[domain.PackagingID]
+	PackagingName domain.Option[domain.UniqueName]
+	PricedUnit    domain.UnitConversion
+	MinQuantity   domain.AtomicQuantity
+	UnitPrice     domain.MinorAmount
+}
+
+// PriceListPrice is the price of one base unit of an item, or of one of its
+// packagings, from a minimum quantity upward. PricedUnit converts one priced
+// unit to atomic units: the base unit's conversion, or the packaging's.
+type PriceListPrice struct {
+	itemID        domain.ItemID
+	packagingID   domain.Option[domain.PackagingID]
+	packagingName domain.Option[domain.UniqueName]
+	pricedUnit    domain.UnitConversion
+	minQuantity   domain.AtomicQuantity
+	unitPrice     domain.MinorAmount
+}
+
+func NewPriceListPrice(params PriceListPriceParams) (PriceListPrice, error) {
+	violations := make([]domain.Violation, 0, 4)
+	if params.ItemID.IsZero() {
+		violations = append(violations, required("item_id"))
+	}
+	packagingID, hasPackagingID := params.PackagingID.Get()
+	name, hasName := params.PackagingName.Get()
+	if hasPackagingID != hasName || (hasPackagingID && (packagingID.IsZero() || name.Key() == "")) {
+		violations = append(violations, domain.Violation{Field: "packaging_id", Code: domain.ViolationInvariant, InvariantID: "PRL-002"})
+	}
+	if params.PricedUnit.IsZero() {
+		violations = append(violations, required("priced_unit"))
+	}
+	if params.MinQuantity.Int64() < 0 {
+		violations = append(violations, domain.Violation{Field: "min_quantity_atomic", Code: domain.ViolationOutOfRange, InvariantID: "PRL-002"})
+	}
+	if params.UnitPrice.Int64() < 0 {
+		violations = append(violations, domain.Violation{Field: "unit_price_minor", Code: domain.ViolationOutOfRange, InvariantID: "PRL-002"})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return PriceListPrice{}, err
+	}
+	return PriceListPrice{
+		itemID: params.ItemID, packagingID: params.PackagingID, packagingName: params.PackagingName,
+		pricedUnit: params.PricedUnit, minQuantity: params.MinQuantity, unitPrice: params.UnitPrice,
+	}, nil
+}
+
+func (p PriceListPrice) ItemID() domain.ItemID                           { return p.itemID }
+func (p PriceListPrice) PackagingID() domain.Option[domain.PackagingID]  { return p.packagingID }
+func (p PriceListPrice) PackagingName() domain.Option[domain.UniqueName] { return p.packagingName }
+func (p PriceListPrice) PricedUnit() domain.UnitConversion               { return p.pricedUnit }
+func (p PriceListPrice) MinQuantity() domain.AtomicQuantity              { return p.minQuantity }
+func (p PriceListPrice) UnitPrice() domain.MinorAmount                   { return p.unitPrice }
+func (p PriceListPrice) packagingKey() string {
+	if name, ok := p.packagingName.Get(); ok {
+		return name.Key()
+	}
+	return ""
+}
+
+// Total prices quantity at this price, rounding half up to the minor unit.
+func (p PriceListPrice) Total(quantity domain.AtomicQuantity) (domain.MinorAmount, error) {
+	priced, err := p.pricedUnit.FromAtomic(quantity)
+	if err != nil {
+		return domain.MinorAmount{}, err
+	}
+	price, err := domain.NewFraction(p.unitPrice.Int64(), 1)
+	if err != nil {
+		return domain.MinorAmount{}, err
+	}
+	total, err := priced.Multiply(price)
+	if err != nil {
+		return domain.MinorAmount{}, err
+	}
+	rounded, err := total.RoundHalfUp()
+	if err != nil {
+		return domain.MinorAmount{}, err
+	}
+	return domain.NewMinorAmount(rounded)
+}
+
+type PriceListParams struct {
+	ID         domain.PriceListID
+	Name       domain.UniqueName
+	ValidFrom  domain.Option[domain.BusinessDate]
+	ValidTo    domain.Option[domain.BusinessDate]
+	Prices     []PriceListPrice
+	CreatedAt  domain.UTCInstant
+	UpdatedAt  domain.UTCInstant
+	ArchivedAt domain.Option[domain.UTCInstant]
+}
+
+// PriceList holds the prices agreed with a group of customers, such as cafés
+// buying wholesale. It only suggests line totals; a posted sale keeps the
+// totals it was posted with, whatever happens to the list afterwards.
+type PriceList struct {
+	id         domain.PriceListID
+	name       domain.UniqueName
+	validFrom  domain.Option[domain.BusinessDate]
+	validTo    domain.Option[domain.BusinessDate]
+	prices     []PriceListPrice
+	createdAt  domain.UTCInstant
+	updatedAt  domain.UTCInstant
+	archivedAt domain.Option[domain.UTCInstant]
+}
+
+func NewPriceList(params PriceListParams) (PriceList, error) {
+	violations := make([]domain.Violation, 0, 4)
+	if params.ID.IsZero() {
+		violations = append(violations, required("price_list_id"))
+	}
+	if params.Name.Display() == "" || params.Name.Key() == "" {
+		violations = append(violations, domain.Violation{Field: "name", Code: domain.ViolationRequired, InvariantID: "PRL-001"})
+	}
+	from, hasFrom := params.ValidFrom.Get()
+	to, hasTo := params.ValidTo.Get()
+	if (hasFrom && from.IsZero()) || (hasTo && to.IsZero()) || (hasFrom && hasTo && to.Before(from)) {
+		violations = append(violations, domain.Violation{Field: "valid_to", Code: domain.ViolationOutOfRange, InvariantID: "PRL-001"})
+	}
+	if err := domain.ValidateTimestampOrder(params.CreatedAt, params.UpdatedAt, params.ArchivedAt); err != nil {
+		if validation, ok := err.(*domain.ValidationError); ok {
+			violations = append(violations, validation.Violations()...)
+		} else {
+			violations = append(violations, domain.Violation{Field: "timestamps", Code: domain.ViolationInvariant})
+		}
+	}
+	type priceKey struct {
+		itemID      int64
+		packaging   string
+		minQuantity int64
+	}
+	seen := make(map[priceKey]struct{}, len(params.Prices))
+	for _, price := range params.Prices {
+		if price.itemID.IsZero() || price.pricedUnit.IsZero() {
+			violations = append(violations, domain.Violation{Field: "prices", Code: domain.ViolationInvariant, InvariantID: "PRL-002"})
+			continue
+		}
+		key := priceKey{itemID: price.itemID.Int64(), packaging: price.packagingKey(), minQuantity: price.minQuantity.Int64()}
+		if _, duplicate := seen[key]; duplicate {
+			violations = append(violations, domain.Violation{Field: "prices", Code: domain.ViolationDuplicate, InvariantID: "PRL-002"})
+		}
+		seen[key] = struct{}{}
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return PriceList{}, err
+	}
+	prices := make([]PriceListPrice, len(params.Prices))
+	copy(prices, params.Prices)
+	return PriceList{
+		id: params.ID, name: params.Name, validFrom: params.ValidFrom, validTo: params.ValidTo,
+		prices: prices, createdAt: params.CreatedAt, updatedAt: params.UpdatedAt,
+		archivedAt: params.ArchivedAt,
+	}, nil
+}
+
+func (l PriceList) ID() domain.PriceListID                        { return l.id }
+func (l PriceList) Name() domain.UniqueName                       { return l.name }
+func (l PriceList) ValidFrom() domain.Option[domain.BusinessDate] { return l.validFrom }
+func (l PriceList) ValidTo() domain.Option[domain.BusinessDate]   { return l.validTo }
+func (l PriceList) CreatedAt() domain.UTCInstant                  { return l.createdAt }
+func (l PriceList) UpdatedAt() domain.UTCInstant                  { return l.updatedAt }
+func (l PriceList) ArchivedAt() domain.Option[domain.UTCInstant]  { return l.archivedAt }
+func (l PriceList) IsArchived() bool                              { return l.archivedAt.IsSome() }
+func (l PriceList) Prices() []PriceListPrice {
+	prices := make([]PriceListPrice, len(l.prices))
+	copy(prices, l.prices)
+	return prices
+}
+
+// IsValidOn reports whether the list is active and date falls within its
+// validity window. An open bound never excludes a date.
+func (l PriceList) IsValidOn(date domain.BusinessDate) bool {
+	if l.IsArchived() {
+		return false
+	}
+	if from, ok := l.validFrom.Get(); ok && date.Before(from) {
+		return false
+	}
+	if to, ok := l.validTo.Get(); ok && date.After(to) {
+		return false
+	}
+	return true
+}
+
+// PriceFor returns the price that applies to quantity of item entered in
+// packagingName, or in a plain unit when it is None. A price for that
+// packaging wins over a base-unit price; among the matching prices the one
+// with the largest minimum quantity not above quantity applies.
+func (l PriceList) PriceFor(
+	itemID domain.ItemID,
+	packagingName domain.Option[domain.NonEmptyText],
+	quantity domain.AtomicQuantity,
+) domain.Option[PriceListPrice] {
+	if name, ok := packagingName.Get(); ok {
+		if key, err := domain.NewUniqueName(name.String()); err == nil {
+			if price, ok := l.tierFor(itemID, key.Key(), quantity).Get(); ok {
+				return domain.Some(price)
+			}
+		}
+	}
+	return l.tierFor(itemID, "", quantity)
+}
+
+func (l PriceList) tierFor(itemID domain.ItemID, packaging string, quantity domain.AtomicQuantity) domain.Option[PriceListPrice] {
+	found := domain.None[PriceListPrice]()
+	for _, price := range l.prices {
+		if price.itemID != itemID || price.packagingKey() != packaging || price.minQuantity.Int64() > quantity.Int64() {
+			continue
+		}
+		if current, ok := found.Get(); ok && current.minQuantity.Int64() >= price.minQuantity.Int64() {
+			continue
+		}
+		found = domain.Some(price)
+	}
+	return found
+}
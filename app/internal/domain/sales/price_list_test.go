@@ -0,0 +1,98 @@
+package sales_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+func TestPriceListPrefersPackagingPricesAndTheHighestReachedTier(t *testing.T) {
+	flour := must(domain.NewItemID(1))
+	grams := must(domain.NewUnitConversion(1_000, 1))
+	bag := must(domain.NewUnitConversion(1_000_000, 1))
+	bagName := must(domain.NewUniqueName("Bag 1kg"))
+	perGram := priceListPrice(t, flour, domain.None[domain.PackagingID](), domain.None[domain.UniqueName](), grams, 0, 5)
+	perBag := priceListPrice(t, flour, domain.Some(must(domain.NewPackagingID(3))), domain.Some(bagName), bag, 0, 4_000)
+	wholesaleBag := priceListPrice(t, flour, domain.Some(must(domain.NewPackagingID(3))), domain.Some(bagName), bag, 5_000_000, 3_500)
+	params := sales.PriceListParams{
+		ID:        must(domain.NewPriceListID(1)),
+		Name:      must(domain.NewUniqueName("Cafés")),
+		ValidFrom: domain.Some(must(domain.ParseBusinessDate("2026-10-01"))),
+		ValidTo:   domain.Some(must(domain.ParseBusinessDate("2026-10-31"))),
+		Prices:    []sales.PriceListPrice{perGram, perBag, wholesaleBag},
+		CreatedAt: must(domain.UTCInstantFromUnixMilli(1_000)),
+		UpdatedAt: must(domain.UTCInstantFromUnixMilli(1_000)),
+	}
+	list, err := sales.NewPriceList(params)
+	if err != nil {
+		t.Fatalf("new price list: %v", err)
+	}
+	if list.IsValidOn(must(domain.ParseBusinessDate("2026-09-30"))) || !list.IsValidOn(must(domain.ParseBusinessDate("2026-10-31"))) {
+		t.Fatalf("validity window = %v..%v", list.ValidFrom(), list.ValidTo())
+	}
+
+	bagEntry := domain.Some(must(domain.NewNonEmptyText("bag 1KG")))
+	cases := []struct {
+		name      string
+		packaging domain.Option[domain.NonEmptyText]
+		quantity  int64
+		unitPrice int64
+		total     int64
+	}{
+		{name: "retail bags", packaging: bagEntry, quantity: 2_000_000, unitPrice: 4_000, total: 8_000},
+		{name: "wholesale bags", packaging: bagEntry, quantity: 6_000_000, unitPrice: 3_500, total: 21_000},
+		{name: "grams", packaging: domain.None[domain.NonEmptyText](), quantity: 300_500, unitPrice: 5, total: 1_503},
+		{name: "unpriced packaging", packaging: domain.Some(must(domain.NewNonEmptyText("Box"))), quantity: 1_000, unitPrice: 5, total: 5},
+	}
+	for _, tc := range cases {
+		quantity := must(domain.NewPositiveAtomicQuantity(tc.quantity))
+		price, ok := list.PriceFor(flour, tc.packaging, quantity).Get()
+		if !ok || price.UnitPrice().Int64() != tc.unitPrice {
+			t.Fatalf("%s price = %#v, %v", tc.name, price, ok)
+		}
+		if total := must(price.Total(quantity)); total.Int64() != tc.total {
+			t.Fatalf("%s total = %d, want %d", tc.name, total.Int64(), tc.total)
+		}
+	}
+	if _, ok := list.PriceFor(must(domain.NewItemID(2)), domain.None[domain.NonEmptyText](), must(domain.NewPositiveAtomicQuantity(1))).Get(); ok {
+		t.Fatal("unlisted item got a price")
+	}
+
+	duplicate := params
+	duplicate.Prices = []sales.PriceListPrice{perGram, perGram}
+	if _, err := sales.NewPriceList(duplicate); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("duplicate price error = %v", err)
+	}
+	inverted := params
+	inverted.ValidTo = domain.Some(must(domain.ParseBusinessDate("2026-09-01")))
+	if _, err := sales.NewPriceList(inverted); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("inverted window error = %v", err)
+	}
+	if _, err := sales.NewPriceListPrice(sales.PriceListPriceParams{
+		ItemID: flour, PackagingID: domain.Some(must(domain.NewPackagingID(3))), PricedUnit: bag,
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("unnamed packaging price error = %v", err)
+	}
+}
+
+func priceListPrice(
+	t *testing.T,
+	itemID domain.ItemID,
+	packagingID domain.Option[domain.PackagingID],
+	packagingName domain.Option[domain.UniqueName],
+	pricedUnit domain.UnitConversion,
+	minQuantity int64,
+	unitPrice int64,
+) sales.PriceListPrice {
+	t.Helper()
+	return must(sales.NewPriceListPrice(sales.PriceListPriceParams{
+		ItemID:        itemID,
+		PackagingID:   packagingID,
+		PackagingName: packagingName,
+		PricedUnit:    pricedUnit,
+		MinQuantity:   must(domain.NewAtomicQuantity(minQuantity)),
+		UnitPrice:     must(domain.NewMinorAmount(unitPrice)),
+	}))
+}
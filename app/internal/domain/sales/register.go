@@ -0,0 +1,268 @@
+package sales
+
+import (
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type CashMovementParams struct {
+	ID                domain.CashMovementID
+	IdempotencyKey    domain.IdempotencyKey
+	RegisterSessionID domain.RegisterSessionID
+	Kind              domain.CashMovementKind
+	Amount            domain.MinorAmount
+	Notes             domain.NonEmptyText
+	RecordedAt        domain.UTCInstant
+}
+
+// CashMovement is cash put into or taken out of the drawer by hand during a
+// register session. Its notes say why.
+type CashMovement struct {
+	id                domain.CashMovementID
+	idempotencyKey    domain.IdempotencyKey
+	registerSessionID domain.RegisterSessionID
+	kind              domain.CashMovementKind
+	amount            domain.MinorAmount
+	notes             domain.NonEmptyText
+	recordedAt        domain.UTCInstant
+}
+
+func NewCashMovement(params CashMovementParams) (CashMovement, error) {
+	violations := make([]domain.Violation, 0, 7)
+	if params.ID.IsZero() {
+		violations = append(violations, required("cash_movement_id"))
+	}
+	if params.IdempotencyKey.String() == "" {
+		violations = append(violations, domain.Violation{Field: "idempotency_key", Code: domain.ViolationRequired, InvariantID: "DOC-003"})
+	}
+	if params.RegisterSessionID.IsZero() {
+		violations = append(violations, domain.Violation{Field: "register_session_id", Code: domain.ViolationRequired, InvariantID: "REG-002"})
+	}
+	if _, err := domain.ParseCashMovementKind(params.Kind.String()); err != nil {
+		violations = append(violations, domain.Violation{Field: "kind", Code: domain.ViolationInvalidEnum, InvariantID: "REG-002"})
+	}
+	if params.Amount.Int64() <= 0 {
+		violations = append(violations, domain.Violation{Field: "amount_minor", Code: domain.ViolationNotPositive, InvariantID: "REG-002"})
+	}
+	if params.Notes.String() == "" {
+		violations = append(violations, domain.Violation{Field: "notes", Code: domain.ViolationRequired, InvariantID: "REG-002"})
+	}
+	if params.RecordedAt.IsZero() {
+		violations = append(violations, required("recorded_at"))
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return CashMovement{}, err
+	}
+	return CashMovement{
+		id: params.ID, idempotencyKey: params.IdempotencyKey, registerSessionID: params.RegisterSessionID,
+		kind: params.Kind, amount: params.Amount, notes: params.Notes, recordedAt: params.RecordedAt,
+	}, nil
+}
+
+func (m CashMovement) ID() domain.CashMovementID                   { return m.id }
+func (m CashMovement) IdempotencyKey() domain.IdempotencyKey       { return m.idempotencyKey }
+func (m CashMovement) RegisterSessionID() domain.RegisterSessionID { return m.registerSessionID }
+func (m CashMovement) Kind() domain.CashMovementKind               { return m.kind }
+func (m CashMovement) Amount() domain.MinorAmount                  { return m.amount }
+func (m CashMovement) Notes() domain.NonEmptyText                  { return m.notes }
+func (m CashMovement) RecordedAt() domain.UTCInstant               { return m.recordedAt }
+
+// RegisterTotals is what passed through the register in one session: sale
+// payments net of reversals per method, and the manual cash movements.
+// Expected cash is the opening float plus cash payments and cash-in, less
+// cash-out.
+type RegisterTotals struct {
+	OpeningFloatMinor int64
+	CashMinor         int64
+	PixMinor          int64
+	CardMinor         int64
+	OnAccountMinor    int64
+	CashInMinor       int64
+	CashOutMinor      int64
+	ExpectedCashMinor int64
+}
+
+// SumRegister totals a session's payments and cash movements.
+func SumRegister(openingFloat domain.MinorAmount, payments []Payment, movements []CashMovement) RegisterTotals {
+	totals := RegisterTotals{OpeningFloatMinor: openingFloat.Int64()}
+	for _, payment := range payments {
+		switch payment.Method() {
+		case domain.PaymentCash:
+			totals.CashMinor += payment.SignedAmount()
+		case domain.PaymentPix:
+			totals.PixMinor += payment.SignedAmount()
+		case domain.PaymentCard:
+			totals.CardMinor += payment.SignedAmount()
+		case domain.PaymentOnAccount:
+			totals.OnAccountMinor += payment.SignedAmount()
+		}
+	}
+	for _, movement := range movements {
+		if movement.Kind() == domain.CashIn {
+			totals.CashInMinor += movement.Amount().Int64()
+		} else {
+			totals.CashOutMinor += movement.Amount().Int64()
+		}
+	}
+	totals.ExpectedCashMinor = totals.OpeningFloatMinor + totals.CashMinor + totals.CashInMinor - totals.CashOutMinor
+	return totals
+}
+
+type RegisterClosingParams struct {
+	ClosedOn    domain.BusinessDate
+	Totals      RegisterTotals
+	CountedCash domain.MinorAmount
+	Notes       domain.Option[domain.NonEmptyText]
+	ClosedAt    domain.UTCInstant
+}
+
+// RegisterClosing is the immutable Z report of a session: the totals frozen
+// when it closed beside the cash counted in the drawer.
+type RegisterClosing struct {
+	closedOn    domain.BusinessDate
+	totals      RegisterTotals
+	countedCash domain.MinorAmount
+	notes       domain.Option[domain.NonEmptyText]
+	closedAt    domain.UTCInstant
+}
+
+func (c RegisterClosing) ClosedOn() domain.BusinessDate             { return c.closedOn }
+func (c RegisterClosing) Totals() RegisterTotals                    { return c.totals }
+func (c RegisterClosing) CountedCash() domain.MinorAmount           { return c.countedCash }
+func (c RegisterClosing) Notes() domain.Option[domain.NonEmptyText] { return c.notes }
+func (c RegisterClosing) ClosedAt() domain.UTCInstant               { return c.closedAt }
+
+// Difference is the counted cash less the expected cash: positive when the
+// drawer holds more than expected and negative when it is short.
+func (c RegisterClosing) Difference() int64 {
+	return c.countedCash.Int64() - c.totals.ExpectedCashMinor
+}
+
+type RegisterSessionParams struct {
+	ID             domain.RegisterSessionID
+	IdempotencyKey domain.IdempotencyKey
+	BusinessDate   domain.BusinessDate
+	OpeningFloat   domain.MinorAmount
+	Notes          domain.Option[domain.NonEmptyText]
+	OpenedAt       domain.UTCInstant
+	Payments       []Payment
+	Movements      []CashMovement
+	Closing        domain.Option[RegisterClosingParams]
+}
+
+// RegisterSession is the cash drawer from opening to closing: the opening
+// float, the sale payments recorded while it was open, and the manual cash
+// movements. Its business date is the opening day in the business timezone.
+type RegisterSession struct {
+	id             domain.RegisterSessionID
+	idempotencyKey domain.IdempotencyKey
+	businessDate   domain.BusinessDate
+	openingFloat   domain.MinorAmount
+	notes          domain.Option[domain.NonEmptyText]
+	openedAt       domain.UTCInstant
+	payments       []Payment
+	movements      []CashMovement
+	closing        domain.Option[RegisterClosing]
+}
+
+func NewRegisterSession(params RegisterSessionParams) (RegisterSession, error) {
+	violations := make([]domain.Violation, 0, 8)
+	if params.ID.IsZero() {
+		violations = append(violations, required("register_session_id"))
+	}
+	if params.IdempotencyKey.String() == "" {
+		violations = append(violations, domain.Violation{Field: "idempotency_key", Code: domain.ViolationRequired, InvariantID: "DOC-003"})
+	}
+	if params.BusinessDate.IsZero() {
+		violations = append(violations, domain.Violation{Field: "business_date", Code: domain.ViolationRequired, InvariantID: "REG-001"})
+	}
+	if notes, ok := params.Notes.Get(); ok && notes.String() == "" {
+		violations = append(violations, required("notes"))
+	}
+	if params.OpenedAt.IsZero() {
+		violations = append(violations, required("opened_at"))
+	}
+	for _, movement := range params.Movements {
+		if movement.RegisterSessionID() != params.ID || movement.RecordedAt().Before(params.OpenedAt) {
+			violations = append(violations, domain.Violation{Field: "movements", Code: domain.ViolationInvariant, InvariantID: "REG-002"})
+			break
+		}
+	}
+	var closing domain.Option[RegisterClosing]
+	if closed, ok := params.Closing.Get(); ok {
+		if closed.ClosedAt.IsZero() || closed.ClosedAt.Before(params.OpenedAt) ||
+			closed.ClosedOn.IsZero() || closed.ClosedOn.Before(params.BusinessDate) {
+			violations = append(violations, domain.Violation{Field: "closed_at", Code: domain.ViolationInvariant, InvariantID: "REG-003"})
+		}
+		if closed.Totals != SumRegister(params.OpeningFloat, params.Payments, params.Movements) {
+			violations = append(violations, domain.Violation{Field: "totals", Code: domain.ViolationInvariant, InvariantID: "REG-004"})
+		}
+		if notes, ok := closed.Notes.Get(); ok && notes.String() == "" {
+			violations = append(violations, required("closing_notes"))
+		}
+		closing = domain.Some(RegisterClosing{
+			closedOn: closed.ClosedOn, totals: closed.Totals, countedCash: closed.CountedCash,
+			notes: closed.Notes, closedAt: closed.ClosedAt,
+		})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return RegisterSession{}, err
+	}
+	return RegisterSession{
+		id: params.ID, idempotencyKey: params.IdempotencyKey, businessDate: params.BusinessDate,
+		openingFloat: params.OpeningFloat, notes: params.Notes, openedAt: params.OpenedAt,
+		payments:  append([]Payment(nil), params.Payments...),
+		movements: append([]CashMovement(nil), params.Movements...),
+		closing:   closing,
+	}, nil
+}
+
+func (s RegisterSession) ID() domain.RegisterSessionID              { return s.id }
+func (s RegisterSession) IdempotencyKey() domain.IdempotencyKey     { return s.idempotencyKey }
+func (s RegisterSession) BusinessDate() domain.BusinessDate         { return s.businessDate }
+func (s RegisterSession) OpeningFloat() domain.MinorAmount          { return s.openingFloat }
+func (s RegisterSession) Notes() domain.Option[domain.NonEmptyText] { return s.notes }
+func (s RegisterSession) OpenedAt() domain.UTCInstant               { return s.openedAt }
+func (s RegisterSession) Closing() domain.Option[RegisterClosing]   { return s.closing }
+func (s RegisterSession) IsOpen() bool                              { return s.closing.IsNone() }
+func (s RegisterSession) Payments() []Payment {
+	return append([]Payment(nil), s.payments...)
+}
+func (s RegisterSession) Movements() []CashMovement {
+	return append([]CashMovement(nil), s.movements...)
+}
+
+// Totals is the running total of an open session, or the frozen totals of a
+// closed one.
+func (s RegisterSession) Totals() RegisterTotals {
+	if closing, ok := s.closing.Get(); ok {
+		return closing.totals
+	}
+	return SumRegister(s.openingFloat, s.payments, s.movements)
+}
+
+// Close freezes the session's totals beside the counted cash. The closing
+// date is the closing instant's day in the business timezone.
+func (s RegisterSession) Close(
+	countedCash domain.MinorAmount,
+	notes domain.Option[domain.NonEmptyText],
+	closedAt domain.UTCInstant,
+	timezone domain.BusinessTimezone,
+) (RegisterClosingParams, error) {
+	if !s.IsOpen() {
+		return RegisterClosingParams{}, domain.Invalid("register_session_id", domain.ViolationInvariant, "REG-003")
+	}
+	if closedAt.IsZero() || closedAt.Before(s.openedAt) {
+		return RegisterClosingParams{}, domain.Invalid("closed_at", domain.ViolationInvariant, "REG-003")
+	}
+	closedOn := timezone.DateOf(closedAt)
+	if closedOn.Before(s.businessDate) {
+		return RegisterClosingParams{}, domain.Invalid("closed_on", domain.ViolationInvariant, "REG-003")
+	}
+	return RegisterClosingParams{
+		ClosedOn:    closedOn,
+		Totals:      SumRegister(s.openingFloat, s.payments, s.movements),
+		CountedCash: countedCash,
+		Notes:       notes,
+		ClosedAt:    closedAt,
+	}, nil
+}
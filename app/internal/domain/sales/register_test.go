@@ -0,0 +1,95 @@
+package sales_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+func TestRegisterSessionClosesWithExpectedCashAndMethodBreakdown(t *testing.T) {
+	timezone := must(domain.NewBusinessTimezone("America/Sao_Paulo"))
+	openedAt := must(domain.NewUTCInstant(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)))
+	sessionID := must(domain.NewRegisterSessionID(1))
+	cash := salePayment(1, 7, domain.PaymentCash, 2_500, domain.None[domain.SalePaymentID]())
+	params := sales.RegisterSessionParams{
+		ID:             sessionID,
+		IdempotencyKey: must(domain.NewIdempotencyKey("register-1")),
+		BusinessDate:   timezone.DateOf(openedAt),
+		OpeningFloat:   must(domain.NewMinorAmount(1_000)),
+		OpenedAt:       openedAt,
+		Payments: []sales.Payment{
+			cash,
+			salePayment(2, 7, domain.PaymentPix, 700, domain.None[domain.SalePaymentID]()),
+			salePayment(3, 7, domain.PaymentCash, 2_500, domain.Some(cash.ID())),
+			salePayment(4, 8, domain.PaymentCash, 1_200, domain.None[domain.SalePaymentID]()),
+			salePayment(5, 8, domain.PaymentOnAccount, 300, domain.None[domain.SalePaymentID]()),
+		},
+		Movements: []sales.CashMovement{
+			cashMovement(1, sessionID, domain.CashIn, 500, openedAt),
+			cashMovement(2, sessionID, domain.CashOut, 200, openedAt),
+		},
+	}
+	session := must(sales.NewRegisterSession(params))
+	if !session.IsOpen() || session.BusinessDate().String() != "2026-10-17" {
+		t.Fatalf("session = %#v", session)
+	}
+
+	// 02:30 UTC is still the evening of the opening day in São Paulo.
+	closedAt := must(domain.NewUTCInstant(time.Date(2026, 10, 18, 2, 30, 0, 0, time.UTC)))
+	closing, err := session.Close(must(domain.NewMinorAmount(2_450)), domain.None[domain.NonEmptyText](), closedAt, timezone)
+	if err != nil {
+		t.Fatalf("close session: %v", err)
+	}
+	want := sales.RegisterTotals{
+		OpeningFloatMinor: 1_000, CashMinor: 1_200, PixMinor: 700, OnAccountMinor: 300,
+		CashInMinor: 500, CashOutMinor: 200, ExpectedCashMinor: 2_500,
+	}
+	if closing.Totals != want || closing.ClosedOn.String() != "2026-10-17" {
+		t.Fatalf("closing = %#v", closing)
+	}
+	params.Closing = domain.Some(closing)
+	closed := must(sales.NewRegisterSession(params))
+	record, ok := closed.Closing().Get()
+	if !ok || closed.IsOpen() || record.Difference() != -50 {
+		t.Fatalf("closed session = %#v", closed)
+	}
+	counted := must(domain.NewMinorAmount(2_500))
+	if _, err := closed.Close(counted, domain.None[domain.NonEmptyText](), closedAt, timezone); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("second close error = %v", err)
+	}
+
+	misstated := closing
+	misstated.Totals.ExpectedCashMinor++
+	params.Closing = domain.Some(misstated)
+	if _, err := sales.NewRegisterSession(params); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("misstated closing error = %v", err)
+	}
+	params.Closing = domain.None[sales.RegisterClosingParams]()
+	otherSession := must(domain.NewRegisterSessionID(2))
+	params.Movements = append(params.Movements, cashMovement(3, otherSession, domain.CashIn, 1, openedAt))
+	if _, err := sales.NewRegisterSession(params); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("foreign movement error = %v", err)
+	}
+}
+
+func cashMovement(
+	id int64,
+	sessionID domain.RegisterSessionID,
+	kind domain.CashMovementKind,
+	amount int64,
+	recordedAt domain.UTCInstant,
+) sales.CashMovement {
+	return must(sales.NewCashMovement(sales.CashMovementParams{
+		ID:                must(domain.NewCashMovementID(id)),
+		IdempotencyKey:    must(domain.NewIdempotencyKey(fmt.Sprintf("movement-%d", id))),
+		RegisterSessionID: sessionID,
+		Kind:              kind,
+		Amount:            must(domain.NewMinorAmount(amount)),
+		Notes:             must(domain.NewNonEmptyText("Change")),
+		RecordedAt:        recordedAt,
+	}))
+}
@@ -0,0 +1,119 @@
+package settings
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jerobas/saas/internal/domain"
+)
+
+const (
+	MinBackupIntervalMinutes = 15
+	MaxBackupIntervalMinutes = 7 * 24 * 60
+	MaxBackupKeepDaily       = 366
+	MaxBackupKeepWeekly      = 520
+)
+
+type BackupPolicyParams struct {
+	Enabled         bool
+	IntervalMinutes int64
+	KeepDaily       int64
+	KeepWeekly      int64
+}
+
+// BackupPolicy controls the automatic backup scheduler. Retention keeps the
+// newest backup of each of the last KeepDaily business days and KeepWeekly ISO
+// weeks that have any backup, so a machine that was switched off for a week
+// does not lose its older history on the next start. KeepDaily is at least one,
+// which means the newest backup is never pruned.
+type BackupPolicy struct {
+	enabled         bool
+	intervalMinutes int64
+	keepDaily       int64
+	keepWeekly      int64
+}
+
+func NewBackupPolicy(params BackupPolicyParams) (BackupPolicy, error) {
+	violations := make([]domain.Violation, 0, 3)
+	if params.IntervalMinutes < MinBackupIntervalMinutes || params.IntervalMinutes > MaxBackupIntervalMinutes {
+		violations = append(violations, domain.Violation{Field: "backup_interval_minutes", Code: domain.ViolationOutOfRange, InvariantID: "SET-006"})
+	}
+	if params.KeepDaily < 1 || params.KeepDaily > MaxBackupKeepDaily {
+		violations = append(violations, domain.Violation{Field: "backup_keep_daily", Code: domain.ViolationOutOfRange, InvariantID: "SET-006"})
+	}
+	if params.KeepWeekly < 0 || params.KeepWeekly > MaxBackupKeepWeekly {
+		violations = append(violations, domain.Violation{Field: "backup_keep_weekly", Code: domain.ViolationOutOfRange, InvariantID: "SET-006"})
+	}
+	if err := domain.NewValidationError(violations...); err != nil {
+		return BackupPolicy{}, err
+	}
+	return BackupPolicy{
+		enabled: params.Enabled, intervalMinutes: params.IntervalMinutes,
+		keepDaily: params.KeepDaily, keepWeekly: params.KeepWeekly,
+	}, nil
+}
+
+func (p BackupPolicy) Enabled() bool          { return p.enabled }
+func (p BackupPolicy) IntervalMinutes() int64 { return p.intervalMinutes }
+func (p BackupPolicy) KeepDaily() int64       { return p.keepDaily }
+func (p BackupPolicy) KeepWeekly() int64      { return p.keepWeekly }
+func (p BackupPolicy) IsZero() bool           { return p.intervalMinutes == 0 }
+
+func (p BackupPolicy) Interval() time.Duration {
+	return time.Duration(p.intervalMinutes) * time.Minute
+}
+
+// Due reports whether an enabled policy needs a new backup at now given the
+// newest existing backup.
+func (p BackupPolicy) Due(latest domain.Option[domain.UTCInstant], now domain.UTCInstant) bool {
+	if !p.enabled {
+		return false
+	}
+	last, ok := latest.Get()
+	if !ok {
+		return true
+	}
+	return now.Time().Sub(last.Time()) >= p.Interval()
+}
+
+// Retain reports, for each backup instant in input order, whether the policy
+// keeps it. Days and weeks are calendar periods in the business timezone.
+func (p BackupPolicy) Retain(backups []domain.UTCInstant, timezone domain.BusinessTimezone) []bool {
+	location := time.UTC
+	if !timezone.IsZero() {
+		location = timezone.Location()
+	}
+	order := make([]int, len(backups))
+	for index := range order {
+		order[index] = index
+	}
+	sort.SliceStable(order, func(left, right int) bool {
+		return backups[order[left]].Compare(backups[order[right]]) > 0
+	})
+
+	keep := make([]bool, len(backups))
+	var lastDay, lastWeek string
+	var days, weeks int64
+	for _, index := range order {
+		local := backups[index].Time().In(location)
+		day := local.Format(time.DateOnly)
+		year, week := local.ISOWeek()
+		weekKey := fmt.Sprintf("%04d-W%02d", year, week)
+		if day != lastDay {
+			lastDay = day
+			if days < p.keepDaily {
+				days++
+				keep[index] = true
+			}
+		}
+		if weekKey != lastWeek {
+			lastWeek = weekKey
+			if weeks < p.keepWeekly {
+				weeks++
+				keep[index] = true
+			}
+		}
+	}
+	return keep
+}
@@ -9,6 +9,7 @@ type Params struct {
 	Currency           domain.Currency
 	HourlyLaborCost    domain.Option[domain.MinorAmount]
 	DefaultGrossMargin domain.Option[domain.BasisPoints]
+	Backup             BackupPolicy
 	CreatedAt          domain.UTCInstant
 	UpdatedAt          domain.UTCInstant
 }
@@ -22,12 +23,13 @@ type Settings struct {
 	currency           domain.Currency
 	hourlyLaborCost    domain.Option[domain.MinorAmount]
 	defaultGrossMargin domain.Option[domain.BasisPoints]
+	backup             BackupPolicy
 	createdAt          domain.UTCInstant
 	updatedAt          domain.UTCInstant
 }
 
 func New(params Params) (Settings, error) {
-	violations := make([]domain.Violation, 0, 6)
+	violations := make([]domain.Violation, 0, 7)
 	if params.BusinessName.String() == "" {
 		violations = append(violations, required("business_name"))
 	}
@@ -40,6 +42,9 @@ func New(params Params) (Settings, error) {
 	if params.Currency.IsZero() {
 		violations = append(violations, required("currency"))
 	}
+	if params.Backup.IsZero() {
+		violations = append(violations, required("backup_policy"))
+	}
 	if err := domain.ValidateTimestampOrder(params.CreatedAt, params.UpdatedAt, domain.None[domain.UTCInstant]()); err != nil {
 		if validation, ok := err.(*domain.ValidationError); ok {
 			violations = append(violations, validation.Violations()...)
@@ -53,6 +58,7 @@ func New(params Params) (Settings, error) {
 		timezone: params.Timezone, currency: params.Currency,
 		hourlyLaborCost:    params.HourlyLaborCost,
 		defaultGrossMargin: params.DefaultGrossMargin,
+		backup:             params.Backup,
 		createdAt:          params.CreatedAt, updatedAt: params.UpdatedAt,
 	}, nil
 }
@@ -63,6 +69,7 @@ func (s Settings) Timezone() domain.BusinessTimezone                     { retur
 func (s Settings) Currency() domain.Currency                             { return s.currency }
 func (s Settings) HourlyLaborCost() domain.Option[domain.MinorAmount]    { return s.hourlyLaborCost }
 func (s Settings) DefaultGrossMargin() domain.Option[domain.BasisPoints] { return s.defaultGrossMargin }
+func (s Settings) Backup() BackupPolicy                                  { return s.backup }
 func (s Settings) CreatedAt() domain.UTCInstant                          { return s.createdAt }
 func (s Settings) UpdatedAt() domain.UTCInstant                          { return s.updatedAt }
 
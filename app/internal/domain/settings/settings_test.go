@@ -3,6 +3,7 @@ package settings_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/jerobas/saas/internal/domain"
 	"github.com/jerobas/saas/internal/domain/settings"
@@ -17,9 +18,11 @@ func TestSettingsSnapshot(t *testing.T) {
 		Currency:           must(domain.RestoreCurrency("BRL", 2)),
 		HourlyLaborCost:    domain.Some(must(domain.NewMinorAmount(2500))),
 		DefaultGrossMargin: domain.Some(must(domain.NewBasisPoints(3000))),
+		Backup:             mustBackupPolicy(true, 1440, 7, 4),
 		CreatedAt:          created, UpdatedAt: updated,
 	})
-	if err != nil || value.Currency().Code().String() != "BRL" || value.DefaultGrossMargin().IsNone() {
+	if err != nil || value.Currency().Code().String() != "BRL" || value.DefaultGrossMargin().IsNone() ||
+		value.Backup().Interval() != 24*time.Hour {
 		t.Fatalf("settings = %#v, %v", value, err)
 	}
 }
@@ -32,11 +35,99 @@ func TestSettingsRejectsIncompleteOrBackwardsSnapshot(t *testing.T) {
 		t.Fatalf("invalid settings error = %v", err)
 	}
 	var validation *domain.ValidationError
-	if !errors.As(err, &validation) || len(validation.Violations()) < 5 {
+	if !errors.As(err, &validation) || len(validation.Violations()) < 6 {
 		t.Fatalf("expected deterministic aggregate violations: %v", err)
 	}
 }
 
+func TestBackupPolicyRejectsOutOfRangeSchedule(t *testing.T) {
+	_, err := settings.NewBackupPolicy(settings.BackupPolicyParams{
+		Enabled: true, IntervalMinutes: 5, KeepDaily: 0, KeepWeekly: -1,
+	})
+	var validation *domain.ValidationError
+	if !errors.As(err, &validation) || len(validation.Violations()) != 3 {
+		t.Fatalf("invalid backup policy error = %v", err)
+	}
+	for _, violation := range validation.Violations() {
+		if violation.InvariantID != "SET-006" {
+			t.Fatalf("violation = %#v, want SET-006", violation)
+		}
+	}
+}
+
+func TestBackupPolicyDue(t *testing.T) {
+	policy := mustBackupPolicy(true, 60, 1, 0)
+	last := mustUTC(t, "2026-10-18T10:00:00Z")
+	tests := []struct {
+		name   string
+		policy settings.BackupPolicy
+		latest domain.Option[domain.UTCInstant]
+		now    string
+		want   bool
+	}{
+		{"no backup yet", policy, domain.None[domain.UTCInstant](), "2026-10-18T10:00:00Z", true},
+		{"inside interval", policy, domain.Some(last), "2026-10-18T10:59:59Z", false},
+		{"interval elapsed", policy, domain.Some(last), "2026-10-18T11:00:00Z", true},
+		{"disabled", mustBackupPolicy(false, 60, 1, 0), domain.None[domain.UTCInstant](), "2026-10-18T12:00:00Z", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.policy.Due(test.latest, mustUTC(t, test.now)); got != test.want {
+				t.Fatalf("Due = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestBackupPolicyRetainsNewestPerDayAndWeek(t *testing.T) {
+	timezone := must(domain.NewBusinessTimezone("America/Sao_Paulo"))
+	backups := []domain.UTCInstant{
+		mustUTC(t, "2026-10-18T12:00:00Z"), // Sunday, week 42, newest
+		mustUTC(t, "2026-10-18T04:00:00Z"), // same business day
+		mustUTC(t, "2026-10-18T02:00:00Z"), // 23:00 on Saturday in Sao Paulo
+		mustUTC(t, "2026-10-16T12:00:00Z"), // Friday, week 42
+		mustUTC(t, "2026-10-10T12:00:00Z"), // Saturday, week 41
+		mustUTC(t, "2026-10-09T12:00:00Z"), // Friday, week 41
+		mustUTC(t, "2026-09-30T12:00:00Z"), // Wednesday, week 40
+	}
+	tests := []struct {
+		name          string
+		daily, weekly int64
+		want          []bool
+	}{
+		{"newest only", 1, 0, []bool{true, false, false, false, false, false, false}},
+		{"three days", 3, 0, []bool{true, false, true, true, false, false, false}},
+		{"one day and three weeks", 1, 3, []bool{true, false, false, false, true, false, true}},
+		{"everything distinct", 366, 520, []bool{true, false, true, true, true, true, true}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := mustBackupPolicy(true, 1440, test.daily, test.weekly)
+			got := policy.Retain(backups, timezone)
+			for index := range test.want {
+				if got[index] != test.want[index] {
+					t.Fatalf("Retain = %v, want %v", got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func mustBackupPolicy(enabled bool, interval, daily, weekly int64) settings.BackupPolicy {
+	return must(settings.NewBackupPolicy(settings.BackupPolicyParams{
+		Enabled: enabled, IntervalMinutes: interval, KeepDaily: daily, KeepWeekly: weekly,
+	}))
+}
+
+func mustUTC(t *testing.T, raw string) domain.UTCInstant {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return must(domain.NewUTCInstant(parsed))
+}
+
 func must[T any](value T, err error) T {
 	if err != nil {
 		panic(err)
@@ -54,6 +54,14 @@ func (d BusinessDate) Compare(other BusinessDate) int {
 	return 0
 }
 
+// DaysSince is the number of calendar days from earlier to d, negative when
+// earlier is later than d.
+func (d BusinessDate) DaysSince(earlier BusinessDate) int {
+	to := time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC)
+	from := time.Date(earlier.year, earlier.month, earlier.day, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
 type UTCInstant struct{ value time.Time }
 
 func NewUTCInstant(value time.Time) (UTCInstant, error) {
@@ -98,6 +106,17 @@ func (z BusinessTimezone) Name() string             { return z.name }
 func (z BusinessTimezone) Location() *time.Location { return z.location }
 func (z BusinessTimezone) IsZero() bool             { return z.location == nil }
 
+// DateOf is the calendar date of instant in the business timezone, or in UTC
+// for the zero timezone.
+func (z BusinessTimezone) DateOf(instant UTCInstant) BusinessDate {
+	location := time.UTC
+	if z.location != nil {
+		location = z.location
+	}
+	local := instant.Time().In(location)
+	return BusinessDate{year: local.Year(), month: local.Month(), day: local.Day()}
+}
+
 type Locale struct{ tag language.Tag }
 
 func NewLocale(raw string) (Locale, error) {
@@ -125,6 +125,25 @@ func TestFractionDecimalAndConversionAreExact(t *testing.T) {
 	if _, err := inexact.ToAtomic(one); !errors.Is(err, domain.ErrInexactConversion) {
 		t.Fatalf("inexact conversion error = %v", err)
 	}
+	for _, tc := range []struct{ numerator, denominator, want int64 }{
+		{5, 2, 3}, {7, 3, 2}, {8, 3, 3}, {0, 5, 0}, {math.MaxInt64, 1, math.MaxInt64},
+	} {
+		value, _ := domain.NewFraction(tc.numerator, tc.denominator)
+		if got, err := value.RoundHalfUp(); err != nil || got != tc.want {
+			t.Fatalf("RoundHalfUp(%d/%d) = %d, %v; want %d", tc.numerator, tc.denominator, got, err, tc.want)
+		}
+	}
+}
+
+func TestFractionCeilRoundsUpAnyRemainder(t *testing.T) {
+	for _, tc := range []struct{ numerator, denominator, want int64 }{
+		{5, 2, 3}, {6, 3, 2}, {1, 1000, 1}, {0, 7, 0}, {math.MaxInt64, 1, math.MaxInt64},
+	} {
+		value, _ := domain.NewFraction(tc.numerator, tc.denominator)
+		if got, err := value.Ceil(); err != nil || got != tc.want {
+			t.Fatalf("Ceil(%d/%d) = %d, %v; want %d", tc.numerator, tc.denominator, got, err, tc.want)
+		}
+	}
 }
 
 func TestCheckedQuantitiesAndMoney(t *testing.T) {
@@ -176,6 +195,9 @@ func TestDatesInstantsTimezoneAndLocale(t *testing.T) {
 	if !leap.Before(next) || next.Compare(leap) != 1 {
 		t.Fatal("business date ordering failed")
 	}
+	if next.DaysSince(leap) != 1 || leap.DaysSince(next) != -1 {
+		t.Fatal("business date distance failed")
+	}
 
 	instant, err := domain.NewUTCInstant(time.Date(2026, 7, 14, 12, 0, 0, 123456789, time.FixedZone("test", -3*60*60)))
 	if err != nil || instant.Time().Location() != time.UTC || instant.Time().Nanosecond()%int(time.Millisecond) != 0 {
@@ -218,9 +240,48 @@ func TestClosedEnumsAndReasonCompatibility(t *testing.T) {
 	if _, err := domain.ParseDocumentReason(domain.DocumentKind("UNKNOWN"), ""); !errors.Is(err, domain.ErrValidation) {
 		t.Fatalf("invalid kind with blank reason error = %v", err)
 	}
+	if _, err := domain.ParseDocumentReason(domain.DocumentReturn, ""); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("return without reason error = %v", err)
+	}
+	if reason, err := domain.ParseDocumentReason(domain.DocumentReturn, "CUSTOMER_RETURN"); err != nil || reason.IsNone() {
+		t.Fatalf("customer return = %#v, %v", reason, err)
+	}
+	if reason, err := domain.ParseDocumentReason(domain.DocumentReturn, "SUPPLIER_RETURN"); err != nil || reason.IsNone() {
+		t.Fatalf("supplier return = %#v, %v", reason, err)
+	}
+	if reason, err := domain.ParseDocumentReason(domain.DocumentTransfer, ""); err != nil || reason.IsSome() {
+		t.Fatalf("transfer without reason = %#v, %v", reason, err)
+	}
+	if _, err := domain.ParseDocumentReason(domain.DocumentTransfer, "DAMAGE"); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("transfer reason error = %v", err)
+	}
 	if _, err := domain.ParseArchiveFilter("ALL"); err != nil {
 		t.Fatal(err)
 	}
+	if status, err := domain.ParsePurchaseOrderStatus("PARTIALLY_RECEIVED"); err != nil || status != domain.PurchaseOrderPartiallyReceived {
+		t.Fatalf("purchase order status = %q, %v", status, err)
+	}
+	if _, err := domain.ParsePurchaseOrderStatus("RECEIVED"); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("unknown purchase order status error = %v", err)
+	}
+	if status, err := domain.ParseCustomerOrderStatus("FULFILLED"); err != nil || status != domain.CustomerOrderFulfilled {
+		t.Fatalf("customer order status = %q, %v", status, err)
+	}
+	if _, err := domain.ParseCustomerOrderStatus("DELIVERED"); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("unknown customer order status error = %v", err)
+	}
+	if kind, err := domain.ParseDraftKind("ADJUSTMENT"); err != nil || kind != domain.DraftAdjustment {
+		t.Fatalf("draft kind = %q, %v", kind, err)
+	}
+	if _, err := domain.ParseDraftKind("TRANSFER"); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("unknown draft kind error = %v", err)
+	}
+	if status, err := domain.ParseStockCountStatus("CANCELLED"); err != nil || status != domain.StockCountCancelled {
+		t.Fatalf("stock count status = %q, %v", status, err)
+	}
+	if _, err := domain.ParseStockCountStatus("POSTED"); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("unknown stock count status error = %v", err)
+	}
 }
 
 func TestArchivedTimestampIsTheOptimisticVersion(t *testing.T) {
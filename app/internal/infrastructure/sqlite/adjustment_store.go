@@ -58,6 +58,10 @@ type PostAdjustmentLineInput struct {
 	InventoryValue       domain.Option[domain.InventoryValue]
 	LotCode              domain.Option[domain.NonEmptyText]
 	ExpiresOn            domain.Option[domain.BusinessDate]
+	// LotID names the lot an OUT line consumes instead of FEFO. A named lot
+	// may already be expired: the adjustment's reason is the deliberate
+	// choice that LOT-009 allows.
+	LotID domain.Option[domain.InventoryLotID]
 }
 
 type PostedAdjustmentDocument struct {
@@ -444,7 +448,11 @@ func insertPostedAdjustmentLine(
 		}
 		return updateAdjustmentBalance(ctx, tx, documentID, postedAt, line.ItemID, line.Quantity.Int64(), inventoryValue.Int64())
 	case domain.DirectionOut:
-		if err := allocateAdjustmentFEFO(ctx, tx, lineID, line.ItemID, line.Quantity.Int64(), occurredOn, postedAt); err != nil {
+		if lotID, ok := line.LotID.Get(); ok {
+			if err := allocateAdjustmentLot(ctx, tx, lineID, line.ItemID, lotID, line.Quantity.Int64(), postedAt); err != nil {
+				return err
+			}
+		} else if err := allocateAdjustmentFEFO(ctx, tx, lineID, line.ItemID, line.Quantity.Int64(), occurredOn, postedAt); err != nil {
 			return err
 		}
 		return updateAdjustmentBalance(ctx, tx, documentID, postedAt, line.ItemID, -line.Quantity.Int64(), -inventoryValue.Int64())
@@ -480,9 +488,36 @@ func validateAdjustmentLineInput(reason domain.DocumentReason, line PostAdjustme
 	if line.Direction != domain.DirectionIn && line.Direction != domain.DirectionOut {
 		return domain.Invalid("direction", domain.ViolationInvalidEnum, "DOC-008")
 	}
+	if lotID, ok := line.LotID.Get(); ok && (line.Direction != domain.DirectionOut || lotID.IsZero()) {
+		return domain.Invalid("lot_id", domain.ViolationInvariant, "LOT-009")
+	}
 	return nil
 }
 
+// loadItemBaseUnit returns the item's base unit and its conversion, so a
+// generated adjustment line can be entered in canonical quantities.
+func loadItemBaseUnit(ctx context.Context, tx databaseWriteTx, itemID domain.ItemID) (domain.UnitCode, domain.UnitConversion, error) {
+	var baseUnitCode string
+	var atomicNumerator, atomicDenominator int64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT item.base_unit_code, unit.atomic_numerator, unit.atomic_denominator
+		FROM items item
+		JOIN measurement_units unit ON unit.code = item.base_unit_code
+		WHERE item.id = ?
+	`, itemID.Int64()).Scan(&baseUnitCode, &atomicNumerator, &atomicDenominator); err != nil {
+		return domain.UnitCode{}, domain.UnitConversion{}, err
+	}
+	baseUnit, err := domain.NewUnitCode(baseUnitCode)
+	if err != nil {
+		return domain.UnitCode{}, domain.UnitConversion{}, corruptDataError("map item base unit", err)
+	}
+	conversion, err := domain.NewUnitConversion(atomicNumerator, atomicDenominator)
+	if err != nil {
+		return domain.UnitCode{}, domain.UnitConversion{}, corruptDataError("map item base unit", err)
+	}
+	return baseUnit, conversion, nil
+}
+
 type adjustmentBalance struct {
 	quantityAtomic      int64
 	inventoryValueMicro int64
@@ -617,6 +652,50 @@ func allocateAdjustmentFEFO(
 	return nil
 }
 
+// allocateAdjustmentLot consumes quantityAtomic from one named lot of the
+// item, whether or not it has expired.
+func allocateAdjustmentLot(
+	ctx context.Context,
+	tx databaseWriteTx,
+	lineID int64,
+	itemID domain.ItemID,
+	lotID domain.InventoryLotID,
+	quantityAtomic int64,
+	postedAt domain.UTCInstant,
+) error {
+	var available int64
+	err := tx.QueryRowContext(ctx, `
+		SELECT lot.initial_quantity_atomic
+			- COALESCE(SUM(
+				CASE WHEN allocation.restores_allocation_id IS NULL
+					THEN allocation.quantity_atomic ELSE 0 END
+			), 0)
+			+ COALESCE(SUM(
+				CASE WHEN allocation.restores_allocation_id IS NOT NULL
+					THEN allocation.quantity_atomic ELSE 0 END
+			), 0)
+		FROM inventory_lots lot
+		LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
+		WHERE lot.id = ? AND lot.item_id = ?
+		GROUP BY lot.id, lot.initial_quantity_atomic
+	`, lotID.Int64(), itemID.Int64()).Scan(&available)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Invalid("lot_id", domain.ViolationInvariant, "LOT-009")
+	}
+	if err != nil {
+		return err
+	}
+	if available < quantityAtomic {
+		return domain.Invalid("quantity_atomic", domain.ViolationOutOfRange, "INV-004")
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO lot_allocations (
+			line_id, lot_id, quantity_atomic, restores_allocation_id, created_at_ms
+		) VALUES (?, ?, ?, NULL, ?)
+	`, lineID, lotID.Int64(), quantityAtomic, postedAt.UnixMilli())
+	return err
+}
+
 func updateAdjustmentBalance(
 	ctx context.Context,
 	tx databaseWriteTx,
@@ -229,6 +229,81 @@ func TestAdjustmentStoreRejectsInsufficientStock(t *testing.T) {
 	}
 }
 
+func TestAdjustmentStorePostsExpiredLotsByLot(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "adjustment-expired.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	flour := createSaleTestItem(t, store, "Flour", false)
+	sugar := createSaleTestItem(t, store, "Sugar", false)
+	first := postAdjustmentTestPurchase(t, store, flour, "flour-1", "FLOUR-1", "2026-08-01", 100, 1_000)
+	second := postAdjustmentTestPurchase(t, store, flour, "flour-2", "FLOUR-2", "2026-08-15", 50, 700)
+	postAdjustmentTestPurchase(t, store, flour, "flour-3", "FLOUR-3", "2026-12-31", 30, 300)
+	sweet := postAdjustmentTestPurchase(t, store, sugar, "sugar-1", "SUGAR-1", "2026-08-10", 20, 200)
+	before := mustPurchaseDate(t, "2026-09-01")
+
+	lots, err := store.ListExpiredLots(ctx, before)
+	if err != nil {
+		t.Fatalf("list expired lots: %v", err)
+	}
+	if len(lots) != 3 || lots[0].LotID != first.Lines()[0].LotID() || lots[1].LotID != sweet.Lines()[0].LotID() ||
+		lots[2].LotID != second.Lines()[0].LotID() || lots[2].RemainingQuantity.Int64() != 50 {
+		t.Fatalf("expired lots = %#v", lots)
+	}
+
+	input := PostExpiredLotsInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "expiry-1"),
+		OccurredOn:     before,
+		PostedAt:       mustCatalogInstant(t, 5_000),
+		ExcludedLotIDs: []domain.InventoryLotID{lots[1].LotID},
+	}
+	unknown := input
+	unknownLot, err := domain.NewInventoryLotID(999)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unknown.ExcludedLotIDs = []domain.InventoryLotID{first.Lines()[0].LotID(), unknownLot}
+	var validation *domain.ValidationError
+	if _, err := store.PostExpiredLots(ctx, unknown); !errors.As(err, &validation) || validation.Violations()[0].InvariantID != "ADJ-005" {
+		t.Fatalf("unknown excluded lot error = %v, want ADJ-005", err)
+	}
+	posted, err := store.PostExpiredLots(ctx, input)
+	if err != nil {
+		t.Fatalf("post expired lots: %v", err)
+	}
+	lines := posted.Lines()
+	if posted.Reason() != domain.ReasonExpiry || len(lines) != 2 {
+		t.Fatalf("expiry adjustment = %#v", posted)
+	}
+	for index, lot := range []ExpiredLot{lots[0], lots[2]} {
+		allocations := lines[index].Allocations()
+		if lines[index].Direction() != domain.DirectionOut || lines[index].InventoryValue().Int64() != lot.InventoryValueMicro ||
+			len(allocations) != 1 || allocations[0].LotID() != lot.LotID || allocations[0].Quantity() != lot.RemainingQuantity {
+			t.Fatalf("expiry line %d = %#v, want lot %#v", index, lines[index], lot)
+		}
+	}
+	var quantity, inventoryValue int64
+	if err := store.database.QueryRowContext(ctx, `
+		SELECT quantity_atomic, inventory_value_micro FROM inventory_balances WHERE item_id = ?
+	`, flour.Int64()).Scan(&quantity, &inventoryValue); err != nil {
+		t.Fatal(err)
+	}
+	if quantity != 30 || inventoryValue != 20_000_000-lots[0].InventoryValueMicro-lots[2].InventoryValueMicro {
+		t.Fatalf("flour balance = %d/%d", quantity, inventoryValue)
+	}
+
+	replayed, err := store.PostExpiredLots(ctx, input)
+	if err != nil || replayed.ID() != posted.ID() {
+		t.Fatalf("replayed expiry = %#v, %v", replayed, err)
+	}
+	remaining, err := store.ListExpiredLots(ctx, before)
+	if err != nil || len(remaining) != 1 || remaining[0].LotID != lots[1].LotID {
+		t.Fatalf("remaining expired lots = %#v, %v", remaining, err)
+	}
+	input.IdempotencyKey = mustPurchaseIdempotencyKey(t, "expiry-2")
+	if _, err := store.PostExpiredLots(ctx, input); !errors.As(err, &validation) || validation.Violations()[0].InvariantID != "ADJ-005" {
+		t.Fatalf("empty expiry error = %v, want ADJ-005", err)
+	}
+}
+
 func postAdjustmentTestPurchase(
 	t *testing.T,
 	store *Store,
@@ -77,6 +77,7 @@ type CreateItemInput struct {
 	Description      domain.Option[domain.NonEmptyText]
 	BaseUnit         domain.UnitCode
 	Capabilities     catalog.Capabilities
+	Category         domain.Option[domain.CategoryID]
 	DefaultSalePrice domain.Option[domain.MinorAmount]
 	ReorderQuantity  domain.Option[domain.AtomicQuantity]
 	CreatedAt        domain.UTCInstant
@@ -90,6 +91,7 @@ type UpdateItemInput struct {
 	Description       domain.Option[domain.NonEmptyText]
 	BaseUnit          domain.UnitCode
 	Capabilities      catalog.Capabilities
+	Category          domain.Option[domain.CategoryID]
 	DefaultSalePrice  domain.Option[domain.MinorAmount]
 	ReorderQuantity   domain.Option[domain.AtomicQuantity]
 	ExpectedUpdatedAt domain.UTCInstant
@@ -228,12 +230,16 @@ func (s *Store) CreateItem(ctx context.Context, input CreateItemInput) (ItemAggr
 		if !baseUnit.IsItemBase() {
 			return domain.Invalid("base_unit", domain.ViolationInvariant, "CAT-006")
 		}
+		if err := validateItemCategoryReference(ctx, queries, input.Category); err != nil {
+			return err
+		}
 		placeholderID, _ := domain.NewItemID(1)
 		if _, err := catalog.NewItem(catalog.ItemParams{
 			ID: placeholderID, Name: input.Name, SKU: input.SKU,
 			Description: input.Description, BaseUnit: input.BaseUnit,
-			Capabilities: input.Capabilities, DefaultSalePrice: input.DefaultSalePrice,
-			ReorderQuantity: input.ReorderQuantity, CreatedAt: input.CreatedAt,
+			Capabilities: input.Capabilities, Category: input.Category,
+			DefaultSalePrice: input.DefaultSalePrice,
+			ReorderQuantity:  input.ReorderQuantity, CreatedAt: input.CreatedAt,
 			UpdatedAt: input.UpdatedAt, ArchivedAt: domain.None[domain.UTCInstant](),
 			Packagings: []catalog.ItemPackaging{},
 		}); err != nil {
@@ -279,11 +285,15 @@ func (s *Store) UpdateItem(ctx context.Context, input UpdateItemInput) (ItemAggr
 		if err := validateActivePackagingDimensions(baseUnit, current.Packagings()); err != nil {
 			return err
 		}
+		if err := validateItemCategoryReference(ctx, queries, input.Category); err != nil {
+			return err
+		}
 		if _, err := catalog.NewItem(catalog.ItemParams{
 			ID: input.ID, Name: input.Name, SKU: input.SKU,
 			Description: input.Description, BaseUnit: input.BaseUnit,
-			Capabilities: input.Capabilities, DefaultSalePrice: input.DefaultSalePrice,
-			ReorderQuantity: input.ReorderQuantity, CreatedAt: current.Item().CreatedAt(),
+			Capabilities: input.Capabilities, Category: input.Category,
+			DefaultSalePrice: input.DefaultSalePrice,
+			ReorderQuantity:  input.ReorderQuantity, CreatedAt: current.Item().CreatedAt(),
 			UpdatedAt: input.UpdatedAt, ArchivedAt: domain.None[domain.UTCInstant](),
 			Packagings: current.Item().Packagings(),
 		}); err != nil {
@@ -325,8 +335,9 @@ func (s *Store) ArchiveItem(ctx context.Context, input ArchiveItemInput) (ItemAg
 		if _, err := catalog.NewItem(catalog.ItemParams{
 			ID: current.Item().ID(), Name: current.Item().Name(), SKU: current.Item().SKU(),
 			Description: current.Item().Description(), BaseUnit: current.Item().BaseUnit(),
-			Capabilities: current.Item().Capabilities(), DefaultSalePrice: current.Item().DefaultSalePrice(),
-			ReorderQuantity: current.Item().ReorderQuantity(), CreatedAt: current.Item().CreatedAt(),
+			Capabilities: current.Item().Capabilities(), Category: current.Item().Category(),
+			DefaultSalePrice: current.Item().DefaultSalePrice(),
+			ReorderQuantity:  current.Item().ReorderQuantity(), CreatedAt: current.Item().CreatedAt(),
 			UpdatedAt: input.ArchivedAt, ArchivedAt: domain.Some(input.ArchivedAt),
 			Packagings: current.Item().Packagings(),
 		}); err != nil {
@@ -373,11 +384,17 @@ func (s *Store) RestoreItem(ctx context.Context, input RestoreItemInput) (ItemAg
 		if err := validateActivePackagingDimensions(current.BaseUnit(), current.Packagings()); err != nil {
 			return err
 		}
+		if category, ok := current.Item().Category().Get(); ok {
+			if err := requireActiveCategory(ctx, queries, category); err != nil {
+				return fmt.Errorf("%w: item category is archived; restore it first", domain.ErrConflict)
+			}
+		}
 		if _, err := catalog.NewItem(catalog.ItemParams{
 			ID: current.Item().ID(), Name: current.Item().Name(), SKU: current.Item().SKU(),
 			Description: current.Item().Description(), BaseUnit: current.Item().BaseUnit(),
-			Capabilities: current.Item().Capabilities(), DefaultSalePrice: current.Item().DefaultSalePrice(),
-			ReorderQuantity: current.Item().ReorderQuantity(), CreatedAt: current.Item().CreatedAt(),
+			Capabilities: current.Item().Capabilities(), Category: current.Item().Category(),
+			DefaultSalePrice: current.Item().DefaultSalePrice(),
+			ReorderQuantity:  current.Item().ReorderQuantity(), CreatedAt: current.Item().CreatedAt(),
 			UpdatedAt: input.UpdatedAt, ArchivedAt: domain.None[domain.UTCInstant](),
 			Packagings: current.Item().Packagings(),
 		}); err != nil {
@@ -862,9 +879,14 @@ func mapItem(row sqlcgen.Item, packagings []catalog.ItemPackaging) (catalog.Item
 	if err != nil {
 		return catalog.Item{}, domain.Corrupt(err)
 	}
+	category, err := restoreOptionalCategoryID(row.CategoryID)
+	if err != nil {
+		return catalog.Item{}, domain.Corrupt(err)
+	}
 	item, err := catalog.NewItem(catalog.ItemParams{
 		ID: id, Name: name, SKU: sku, Description: description, BaseUnit: baseUnit,
 		Capabilities:     catalog.NewCapabilities(purchasable, producible, sellable),
+		Category:         category,
 		DefaultSalePrice: defaultPrice, ReorderQuantity: reorderQuantity,
 		CreatedAt: createdAt, UpdatedAt: updatedAt, ArchivedAt: archivedAt,
 		Packagings: packagings,
@@ -882,7 +904,7 @@ func mapItemSummary(row sqlcgen.Item) (catalog.ItemSummary, error) {
 	}
 	summary, err := catalog.NewItemSummary(catalog.ItemSummaryParams{
 		ID: item.ID(), Name: item.Name(), SKU: item.SKU(), Description: item.Description(),
-		BaseUnit: item.BaseUnit(), Capabilities: item.Capabilities(),
+		BaseUnit: item.BaseUnit(), Capabilities: item.Capabilities(), Category: item.Category(),
 		DefaultSalePrice: item.DefaultSalePrice(), ReorderQuantity: item.ReorderQuantity(),
 		CreatedAt: item.CreatedAt(), UpdatedAt: item.UpdatedAt(), ArchivedAt: item.ArchivedAt(),
 	})
@@ -1026,6 +1048,7 @@ func insertItemParams(input CreateItemInput) sqlcgen.InsertItemParams {
 		IsPurchasable:         boolInteger(input.Capabilities.Purchasable()),
 		IsProducible:          boolInteger(input.Capabilities.Producible()),
 		IsSellable:            boolInteger(input.Capabilities.Sellable()),
+		CategoryID:            nullableCategoryID(input.Category),
 		DefaultSalePriceMinor: nullableMinorAmount(input.DefaultSalePrice),
 		ReorderQuantityAtomic: nullableQuantity(input.ReorderQuantity),
 		CreatedAtMs:           input.CreatedAt.UnixMilli(), UpdatedAtMs: input.UpdatedAt.UnixMilli(),
@@ -1040,6 +1063,7 @@ func updateItemParams(input UpdateItemInput) sqlcgen.UpdateItemParams {
 		IsPurchasable:         boolInteger(input.Capabilities.Purchasable()),
 		IsProducible:          boolInteger(input.Capabilities.Producible()),
 		IsSellable:            boolInteger(input.Capabilities.Sellable()),
+		CategoryID:            nullableCategoryID(input.Category),
 		DefaultSalePriceMinor: nullableMinorAmount(input.DefaultSalePrice),
 		ReorderQuantityAtomic: nullableQuantity(input.ReorderQuantity),
 		UpdatedAtMs:           input.UpdatedAt.UnixMilli(), ID: input.ID.Int64(),
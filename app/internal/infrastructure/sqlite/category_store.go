@@ -0,0 +1,323 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/catalog"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite/sqlcgen"
+)
+
+type CreateCategoryInput struct {
+	Name      domain.UniqueName
+	CreatedAt domain.UTCInstant
+}
+
+type UpdateCategoryInput struct {
+	ID                domain.CategoryID
+	Name              domain.UniqueName
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+type ArchiveCategoryInput struct {
+	ID                domain.CategoryID
+	ExpectedUpdatedAt domain.UTCInstant
+	ArchivedAt        domain.UTCInstant
+}
+
+type RestoreCategoryInput struct {
+	ID                domain.CategoryID
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+func (s *Store) GetCategory(ctx context.Context, id domain.CategoryID) (catalog.Category, error) {
+	if id.IsZero() {
+		return catalog.Category{}, domain.Invalid("category_id", domain.ViolationNotPositive, "")
+	}
+	var value catalog.Category
+	err := s.withReadQueries(ctx, "get category", func(queries *sqlcgen.Queries) error {
+		var err error
+		value, err = loadCategory(ctx, queries, id)
+		return err
+	})
+	return value, err
+}
+
+// ListCategories returns every category matching archive ordered by name.
+// Categories are a short reference list, so they are not paged.
+func (s *Store) ListCategories(ctx context.Context, archive domain.ArchiveFilter) ([]catalog.Category, error) {
+	archiveFilter, err := archiveFilterValue(archive)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListItemCategories(ctx, archiveFilter)
+	if err != nil {
+		return nil, classifyError("list categories", err)
+	}
+	categories := make([]catalog.Category, 0, len(rows))
+	for _, row := range rows {
+		category, err := mapCategory(row)
+		if err != nil {
+			return nil, corruptDataError("map listed category", err)
+		}
+		categories = append(categories, category)
+	}
+	return categories, nil
+}
+
+func (s *Store) CreateCategory(ctx context.Context, input CreateCategoryInput) (catalog.Category, error) {
+	placeholderID, _ := domain.NewCategoryID(1)
+	if _, err := catalog.NewCategory(catalog.CategoryParams{
+		ID: placeholderID, Name: input.Name, CreatedAt: input.CreatedAt, UpdatedAt: input.CreatedAt,
+	}); err != nil {
+		return catalog.Category{}, err
+	}
+	var created catalog.Category
+	err := s.withWriteQueries(ctx, "create category", func(queries *sqlcgen.Queries) error {
+		idValue, err := queries.InsertItemCategory(ctx, sqlcgen.InsertItemCategoryParams{
+			Name: input.Name.Display(), NormalizedName: input.Name.Key(),
+			CreatedAtMs: input.CreatedAt.UnixMilli(), UpdatedAtMs: input.CreatedAt.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		id, err := domain.NewCategoryID(idValue)
+		if err != nil {
+			return corruptDataError("map created category id", err)
+		}
+		created, err = loadCategory(ctx, queries, id)
+		return err
+	})
+	return created, err
+}
+
+func (s *Store) UpdateCategory(ctx context.Context, input UpdateCategoryInput) (catalog.Category, error) {
+	if input.ID.IsZero() {
+		return catalog.Category{}, domain.Invalid("category_id", domain.ViolationNotPositive, "")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return catalog.Category{}, err
+	}
+	var updated catalog.Category
+	err := s.withWriteQueries(ctx, "update category", func(queries *sqlcgen.Queries) error {
+		current, err := loadCategory(ctx, queries, input.ID)
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: category version changed", domain.ErrStale)
+		}
+		if current.IsArchived() {
+			return fmt.Errorf("%w: archived category cannot be updated", domain.ErrConflict)
+		}
+		if _, err := catalog.NewCategory(catalog.CategoryParams{
+			ID: input.ID, Name: input.Name, CreatedAt: current.CreatedAt(), UpdatedAt: input.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+		rows, err := queries.UpdateItemCategory(ctx, sqlcgen.UpdateItemCategoryParams{
+			Name: input.Name.Display(), NormalizedName: input.Name.Key(),
+			UpdatedAtMs: input.UpdatedAt.UnixMilli(), ID: input.ID.Int64(),
+			ExpectedUpdatedAtMs: input.ExpectedUpdatedAt.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return classifyCategoryMutationMiss(ctx, queries, input.ID, input.ExpectedUpdatedAt, false)
+		}
+		updated, err = loadCategory(ctx, queries, input.ID)
+		return err
+	})
+	return updated, err
+}
+
+// ArchiveCategory refuses while any active item is assigned to the category;
+// those items must be moved or archived first.
+func (s *Store) ArchiveCategory(ctx context.Context, input ArchiveCategoryInput) (catalog.Category, error) {
+	if input.ID.IsZero() {
+		return catalog.Category{}, domain.Invalid("category_id", domain.ViolationNotPositive, "")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.ArchivedAt); err != nil {
+		return catalog.Category{}, err
+	}
+	var archived catalog.Category
+	err := s.withWriteQueries(ctx, "archive category", func(queries *sqlcgen.Queries) error {
+		current, err := loadCategory(ctx, queries, input.ID)
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: category version changed", domain.ErrStale)
+		}
+		if current.IsArchived() {
+			return fmt.Errorf("%w: category is already archived", domain.ErrConflict)
+		}
+		activeItems, err := queries.CountActiveItemsInCategory(ctx, sql.NullInt64{Int64: input.ID.Int64(), Valid: true})
+		if err != nil {
+			return err
+		}
+		if activeItems > 0 {
+			return fmt.Errorf("%w: category still has %d active items", domain.ErrConflict, activeItems)
+		}
+		rows, err := queries.ArchiveItemCategory(ctx, sqlcgen.ArchiveItemCategoryParams{
+			ArchivedAtMs: input.ArchivedAt.UnixMilli(), UpdatedAtMs: input.ArchivedAt.UnixMilli(),
+			ID: input.ID.Int64(), ExpectedUpdatedAtMs: input.ExpectedUpdatedAt.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return classifyCategoryMutationMiss(ctx, queries, input.ID, input.ExpectedUpdatedAt, false)
+		}
+		archived, err = loadCategory(ctx, queries, input.ID)
+		return err
+	})
+	return archived, err
+}
+
+func (s *Store) RestoreCategory(ctx context.Context, input RestoreCategoryInput) (catalog.Category, error) {
+	if input.ID.IsZero() {
+		return catalog.Category{}, domain.Invalid("category_id", domain.ViolationNotPositive, "")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return catalog.Category{}, err
+	}
+	var restored catalog.Category
+	err := s.withWriteQueries(ctx, "restore category", func(queries *sqlcgen.Queries) error {
+		current, err := loadCategory(ctx, queries, input.ID)
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: category version changed", domain.ErrStale)
+		}
+		if !current.IsArchived() {
+			return fmt.Errorf("%w: category is already active", domain.ErrConflict)
+		}
+		rows, err := queries.RestoreItemCategory(ctx, sqlcgen.RestoreItemCategoryParams{
+			UpdatedAtMs: input.UpdatedAt.UnixMilli(), ID: input.ID.Int64(),
+			ExpectedUpdatedAtMs: input.ExpectedUpdatedAt.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return classifyCategoryMutationMiss(ctx, queries, input.ID, input.ExpectedUpdatedAt, true)
+		}
+		restored, err = loadCategory(ctx, queries, input.ID)
+		return err
+	})
+	return restored, err
+}
+
+func loadCategory(ctx context.Context, queries *sqlcgen.Queries, id domain.CategoryID) (catalog.Category, error) {
+	row, err := queries.GetItemCategory(ctx, id.Int64())
+	if err != nil {
+		return catalog.Category{}, err
+	}
+	category, err := mapCategory(row)
+	if err != nil {
+		return catalog.Category{}, corruptDataError("map category", err)
+	}
+	return category, nil
+}
+
+// validateItemCategoryReference checks an item write's category before SQL so
+// a missing or archived category is reported as a bad reference rather than
+// a trigger conflict.
+func validateItemCategoryReference(ctx context.Context, queries *sqlcgen.Queries, value domain.Option[domain.CategoryID]) error {
+	id, ok := value.Get()
+	if !ok {
+		return nil
+	}
+	if id.IsZero() {
+		return domain.Invalid("category_id", domain.ViolationNotPositive, "CAT-009")
+	}
+	return requireActiveCategory(ctx, queries, id)
+}
+
+func requireActiveCategory(ctx context.Context, queries *sqlcgen.Queries, id domain.CategoryID) error {
+	category, err := loadCategory(ctx, queries, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return wrapClassifiedError("load item category", domain.ErrInvalidReference, err)
+	}
+	if err != nil {
+		return err
+	}
+	if category.IsArchived() {
+		return fmt.Errorf("%w: item category is archived", domain.ErrInvalidReference)
+	}
+	return nil
+}
+
+func classifyCategoryMutationMiss(ctx context.Context, queries *sqlcgen.Queries, id domain.CategoryID, expected domain.UTCInstant, expectedArchived bool) error {
+	row, err := queries.GetItemCategory(ctx, id.Int64())
+	if err != nil {
+		return classifyError("reload category after missed update", err)
+	}
+	if row.UpdatedAtMs != expected.UnixMilli() {
+		return fmt.Errorf("%w: category version changed", domain.ErrStale)
+	}
+	if row.ArchivedAtMs.Valid != expectedArchived {
+		return fmt.Errorf("%w: category archive state changed", domain.ErrConflict)
+	}
+	return fmt.Errorf("%w: category update matched no row", domain.ErrConflict)
+}
+
+func mapCategory(row sqlcgen.ItemCategory) (catalog.Category, error) {
+	id, err := domain.NewCategoryID(row.ID)
+	if err != nil {
+		return catalog.Category{}, domain.Corrupt(err)
+	}
+	name, err := domain.RestoreUniqueName(row.Name, row.NormalizedName)
+	if err != nil || name.Display() != row.Name {
+		if err == nil {
+			err = domain.Corrupt(domain.Invalid("name", domain.ViolationInvariant, "CAT-009"))
+		}
+		return catalog.Category{}, err
+	}
+	createdAt, err := domain.UTCInstantFromUnixMilli(row.CreatedAtMs)
+	if err != nil {
+		return catalog.Category{}, domain.Corrupt(err)
+	}
+	updatedAt, err := domain.UTCInstantFromUnixMilli(row.UpdatedAtMs)
+	if err != nil {
+		return catalog.Category{}, domain.Corrupt(err)
+	}
+	archivedAt, err := restoreOptionalInstant(row.ArchivedAtMs)
+	if err != nil {
+		return catalog.Category{}, domain.Corrupt(err)
+	}
+	category, err := catalog.NewCategory(catalog.CategoryParams{
+		ID: id, Name: name, CreatedAt: createdAt, UpdatedAt: updatedAt, ArchivedAt: archivedAt,
+	})
+	if err != nil {
+		return catalog.Category{}, domain.Corrupt(err)
+	}
+	return category, nil
+}
+
+func nullableCategoryID(value domain.Option[domain.CategoryID]) sql.NullInt64 {
+	id, ok := value.Get()
+	if !ok {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: id.Int64(), Valid: true}
+}
+
+func restoreOptionalCategoryID(value sql.NullInt64) (domain.Option[domain.CategoryID], error) {
+	if !value.Valid {
+		return domain.None[domain.CategoryID](), nil
+	}
+	id, err := domain.NewCategoryID(value.Int64)
+	if err != nil {
+		return domain.None[domain.CategoryID](), err
+	}
+	return domain.Some(id), nil
+}
@@ -0,0 +1,112 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/catalog"
+)
+
+func TestCategoryStoreArchiveSemanticsFollowAssignedItems(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "category-lifecycle.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	category, err := store.CreateCategory(ctx, CreateCategoryInput{
+		Name: mustCatalogName(t, "Bolos"), CreatedAt: mustCatalogInstant(t, 10),
+	})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	if _, err := store.CreateCategory(ctx, CreateCategoryInput{
+		Name: mustCatalogName(t, "BOLOS"), CreatedAt: mustCatalogInstant(t, 11),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("duplicate category error = %v, want conflict", err)
+	}
+	renamed, err := store.UpdateCategory(ctx, UpdateCategoryInput{
+		ID: category.ID(), Name: mustCatalogName(t, "Bolos e tortas"),
+		ExpectedUpdatedAt: category.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 20),
+	})
+	if err != nil || renamed.Name().Display() != "Bolos e tortas" {
+		t.Fatalf("rename category = %#v, %v", renamed, err)
+	}
+	if _, err := store.UpdateCategory(ctx, UpdateCategoryInput{
+		ID: category.ID(), Name: mustCatalogName(t, "Stale"),
+		ExpectedUpdatedAt: category.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 21),
+	}); !errors.Is(err, domain.ErrStale) {
+		t.Fatalf("stale category update error = %v, want stale", err)
+	}
+
+	item := createCatalogItem(t, store, CreateItemInput{
+		Name: mustCatalogName(t, "Bolo de cenoura"), BaseUnit: mustCatalogUnitCode(t, "g"),
+		Capabilities: catalog.NewCapabilities(false, true, true), Category: domain.Some(category.ID()),
+		CreatedAt: mustCatalogInstant(t, 30), UpdatedAt: mustCatalogInstant(t, 30),
+	})
+	if id, ok := item.Item().Category().Get(); !ok || id != category.ID() {
+		t.Fatalf("item category = %#v", item.Item().Category())
+	}
+	page, err := store.ListItems(ctx, ItemListFilter{Archive: domain.ArchiveActive, PageSize: mustCatalogPageSize(t, 10)})
+	if err != nil || len(page.Items()) != 1 || page.Items()[0].Category() != item.Item().Category() {
+		t.Fatalf("item summary category = %#v, %v", page.Items(), err)
+	}
+
+	if _, err := store.ArchiveCategory(ctx, ArchiveCategoryInput{
+		ID: category.ID(), ExpectedUpdatedAt: renamed.UpdatedAt(), ArchivedAt: mustCatalogInstant(t, 40),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("archive category with active item error = %v, want conflict", err)
+	}
+	archivedItem, err := store.ArchiveItem(ctx, ArchiveItemInput{
+		ID: item.Item().ID(), ExpectedUpdatedAt: item.Item().UpdatedAt(), ArchivedAt: mustCatalogInstant(t, 41),
+	})
+	if err != nil {
+		t.Fatalf("archive item: %v", err)
+	}
+	archived, err := store.ArchiveCategory(ctx, ArchiveCategoryInput{
+		ID: category.ID(), ExpectedUpdatedAt: renamed.UpdatedAt(), ArchivedAt: mustCatalogInstant(t, 42),
+	})
+	if err != nil || !archived.IsArchived() {
+		t.Fatalf("archive category = %#v, %v", archived, err)
+	}
+	if _, err := store.RestoreItem(ctx, RestoreItemInput{
+		ID: item.Item().ID(), ExpectedUpdatedAt: archivedItem.Item().UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 43),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("restore item into archived category error = %v, want conflict", err)
+	}
+	if _, err := store.CreateItem(ctx, CreateItemInput{
+		Name: mustCatalogName(t, "Torta"), BaseUnit: mustCatalogUnitCode(t, "g"),
+		Capabilities: catalog.NewCapabilities(false, true, true), Category: domain.Some(category.ID()),
+		CreatedAt: mustCatalogInstant(t, 44), UpdatedAt: mustCatalogInstant(t, 44),
+	}); !errors.Is(err, domain.ErrInvalidReference) {
+		t.Fatalf("create item in archived category error = %v, want invalid reference", err)
+	}
+
+	active, err := store.ListCategories(ctx, domain.ArchiveActive)
+	if err != nil || len(active) != 0 {
+		t.Fatalf("active categories = %#v, %v", active, err)
+	}
+	restored, err := store.RestoreCategory(ctx, RestoreCategoryInput{
+		ID: category.ID(), ExpectedUpdatedAt: archived.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 50),
+	})
+	if err != nil || restored.IsArchived() {
+		t.Fatalf("restore category = %#v, %v", restored, err)
+	}
+	if _, err := store.RestoreItem(ctx, RestoreItemInput{
+		ID: item.Item().ID(), ExpectedUpdatedAt: archivedItem.Item().UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 51),
+	}); err != nil {
+		t.Fatalf("restore item after category: %v", err)
+	}
+	if _, err := store.GetCategory(ctx, mustCategoryID(t, 999)); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("missing category error = %v, want not found", err)
+	}
+}
+
+func mustCategoryID(t *testing.T, raw int64) domain.CategoryID {
+	t.Helper()
+	id, err := domain.NewCategoryID(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
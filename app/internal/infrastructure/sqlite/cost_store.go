@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+)
+
+const listItemCostBasesOperation = "list item cost bases"
+
+// ListItemCostBases reads the cost basis of each item in the given order. The
+// last purchase ignores reversed purchases and FREE_STOCK receipts.
+func (s *Store) ListItemCostBases(ctx context.Context, itemIDs []domain.ItemID) ([]inventory.CostBasis, error) {
+	for _, itemID := range itemIDs {
+		if itemID.IsZero() {
+			return nil, domain.Invalid("item_id", domain.ViolationRequired, "")
+		}
+	}
+	bases := make([]inventory.CostBasis, 0, len(itemIDs))
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		for _, itemID := range itemIDs {
+			basis, err := loadItemCostBasis(ctx, tx, itemID.Int64())
+			if err != nil {
+				return err
+			}
+			bases = append(bases, basis)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError(listItemCostBasesOperation, err)
+	}
+	return bases, nil
+}
+
+func loadItemCostBasis(ctx context.Context, tx databaseWriteTx, itemID int64) (inventory.CostBasis, error) {
+	var rawItemID, quantityAtomic, inventoryValueMicro, updatedAtMS int64
+	var atomicNumerator, atomicDenominator int64
+	var baseUnitCode string
+	var lastDocumentID sql.NullInt64
+	err := tx.QueryRowContext(ctx, `
+		SELECT balance.item_id, item.base_unit_code, unit.atomic_numerator,
+		       unit.atomic_denominator, balance.quantity_atomic,
+		       balance.inventory_value_micro, balance.last_document_id,
+		       balance.updated_at_ms
+		FROM inventory_balances balance
+		JOIN items item ON item.id = balance.item_id
+		JOIN measurement_units unit ON unit.code = item.base_unit_code
+		WHERE balance.item_id = ?
+	`, itemID).Scan(
+		&rawItemID, &baseUnitCode, &atomicNumerator, &atomicDenominator,
+		&quantityAtomic, &inventoryValueMicro, &lastDocumentID, &updatedAtMS,
+	)
+	if err != nil {
+		return inventory.CostBasis{}, err
+	}
+	lastPurchase := domain.None[domain.Fraction]()
+	var purchaseValueMicro, purchaseQuantityAtomic int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT line.inventory_value_micro, line.quantity_atomic
+		FROM stock_document_lines line
+		JOIN stock_documents document ON document.id = line.document_id
+		WHERE line.item_id = ?
+		  AND line.direction = 'IN'
+		  AND document.kind = 'PURCHASE'
+		  AND document.reason_code IS NULL
+		  AND NOT EXISTS (
+		      SELECT 1 FROM stock_documents reversal
+		      WHERE reversal.reverses_document_id = document.id
+		  )
+		ORDER BY document.posting_sequence DESC, line.line_order DESC
+		LIMIT 1
+	`, itemID).Scan(&purchaseValueMicro, &purchaseQuantityAtomic)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return inventory.CostBasis{}, err
+	default:
+		value, err := domain.NewFraction(purchaseValueMicro, purchaseQuantityAtomic)
+		if err != nil {
+			return inventory.CostBasis{}, corruptDataError(listItemCostBasesOperation, err)
+		}
+		lastPurchase = domain.Some(value)
+	}
+	basis, err := mapItemCostBasis(itemCostBasisFields{
+		itemID: rawItemID, baseUnitCode: baseUnitCode,
+		atomicNumerator: atomicNumerator, atomicDenominator: atomicDenominator,
+		quantityAtomic: quantityAtomic, inventoryValueMicro: inventoryValueMicro,
+		lastDocumentID: lastDocumentID, updatedAtMS: updatedAtMS,
+		lastPurchaseUnitValue: lastPurchase,
+	})
+	if err != nil {
+		return inventory.CostBasis{}, corruptDataError(listItemCostBasesOperation, err)
+	}
+	return basis, nil
+}
+
+type itemCostBasisFields struct {
+	itemID                              int64
+	baseUnitCode                        string
+	atomicNumerator, atomicDenominator  int64
+	quantityAtomic, inventoryValueMicro int64
+	lastDocumentID                      sql.NullInt64
+	updatedAtMS                         int64
+	lastPurchaseUnitValue               domain.Option[domain.Fraction]
+}
+
+func mapItemCostBasis(fields itemCostBasisFields) (inventory.CostBasis, error) {
+	itemID, err := domain.NewItemID(fields.itemID)
+	if err != nil {
+		return inventory.CostBasis{}, err
+	}
+	quantity, err := domain.NewAtomicQuantity(fields.quantityAtomic)
+	if err != nil {
+		return inventory.CostBasis{}, err
+	}
+	value, err := domain.NewInventoryValue(fields.inventoryValueMicro)
+	if err != nil {
+		return inventory.CostBasis{}, err
+	}
+	lastDocumentID, err := optionalStockDocumentID(fields.lastDocumentID)
+	if err != nil {
+		return inventory.CostBasis{}, err
+	}
+	updatedAt, err := domain.UTCInstantFromUnixMilli(fields.updatedAtMS)
+	if err != nil {
+		return inventory.CostBasis{}, err
+	}
+	balance, err := inventory.NewBalance(inventory.BalanceParams{
+		ItemID: itemID, Quantity: quantity, Value: value,
+		LastDocumentID: lastDocumentID, UpdatedAt: updatedAt,
+	})
+	if err != nil {
+		return inventory.CostBasis{}, err
+	}
+	baseUnit, err := domain.NewUnitCode(fields.baseUnitCode)
+	if err != nil {
+		return inventory.CostBasis{}, err
+	}
+	conversion, err := domain.NewUnitConversion(fields.atomicNumerator, fields.atomicDenominator)
+	if err != nil {
+		return inventory.CostBasis{}, err
+	}
+	return inventory.NewCostBasis(inventory.CostBasisParams{
+		Balance: balance, BaseUnit: baseUnit, BaseConversion: conversion,
+		LastPurchaseUnitValue: fields.lastPurchaseUnitValue,
+	})
+}
@@ -0,0 +1,65 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+)
+
+func TestCostStoreFallsBackToLastUnreversedPurchase(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "cost.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	itemID := createReportingItem(t, store, "Costed flour", true, domain.None[domain.AtomicQuantity]())
+	unpurchasedID := createReportingItem(t, store, "Never bought", true, domain.None[domain.AtomicQuantity]())
+	postReportingPurchase(t, store, itemID, "cost-cheap", "2026-07-01", 1_000,
+		domain.None[domain.CounterpartyID](), domain.None[domain.DocumentReason](), 100, 1_000)
+	dear := postReportingPurchase(t, store, itemID, "cost-dear", "2026-07-02", 2_000,
+		domain.None[domain.CounterpartyID](), domain.None[domain.DocumentReason](), 100, 3_000)
+
+	bases, err := store.ListItemCostBases(ctx, []domain.ItemID{itemID, unpurchasedID})
+	if err != nil {
+		t.Fatalf("list stocked cost bases: %v", err)
+	}
+	if len(bases) != 2 || bases[0].ItemID() != itemID || bases[1].ItemID() != unpurchasedID {
+		t.Fatalf("cost bases = %#v", bases)
+	}
+	if value, source := bases[0].UnitValue(); source != inventory.CostSourceAverageValue || value.String() != "200000" {
+		t.Fatalf("stocked unit value = %v, %s", value, source)
+	}
+	if _, source := bases[1].UnitValue(); source != inventory.CostSourceUnavailable {
+		t.Fatalf("unpurchased unit value source = %s", source)
+	}
+	if bases[0].BaseUnit().String() != "g" || bases[0].BaseConversion().NumeratorAtomic() != 1_000 {
+		t.Fatalf("base unit = %s %#v", bases[0].BaseUnit(), bases[0].BaseConversion())
+	}
+
+	if _, err := store.PostReversal(ctx, PostReversalInput{
+		IdempotencyKey:   mustPurchaseIdempotencyKey(t, "cost-reverse-dear"),
+		TargetDocumentID: dear.ID(),
+		OccurredOn:       mustPurchaseDate(t, "2026-07-03"),
+		PostedAt:         mustCatalogInstant(t, 3_000),
+	}); err != nil {
+		t.Fatalf("reverse dear purchase: %v", err)
+	}
+	if _, err := store.PostSale(ctx, saleInputFixture(t, itemID, "cost-sale", 100, 2_000)); err != nil {
+		t.Fatalf("post sale: %v", err)
+	}
+
+	bases, err = store.ListItemCostBases(ctx, []domain.ItemID{itemID})
+	if err != nil {
+		t.Fatalf("list empty cost bases: %v", err)
+	}
+	if value, source := bases[0].UnitValue(); source != inventory.CostSourceLastPurchase || value.String() != "100000" {
+		t.Fatalf("empty unit value = %v, %s", value, source)
+	}
+
+	missing := mustCatalogItemID(t, 999)
+	if _, err := store.ListItemCostBases(ctx, []domain.ItemID{missing}); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("missing item error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,716 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+type CustomerOrderLineInput struct {
+	ItemID               domain.ItemID
+	Quantity             domain.AtomicQuantity
+	EnteredUnit          domain.UnitCode
+	EnteredPackagingName domain.Option[domain.NonEmptyText]
+	Conversion           domain.UnitConversion
+	AgreedTotal          domain.MinorAmount
+}
+
+type CreateCustomerOrderInput struct {
+	CustomerID domain.CounterpartyID
+	OrderedOn  domain.BusinessDate
+	DueOn      domain.BusinessDate
+	Deposit    domain.MinorAmount
+	Notes      domain.Option[domain.NonEmptyText]
+	Lines      []CustomerOrderLineInput
+	CreatedAt  domain.UTCInstant
+}
+
+// UpdateCustomerOrderInput replaces every editable field and line of an open
+// order.
+type UpdateCustomerOrderInput struct {
+	ID                domain.CustomerOrderID
+	CustomerID        domain.CounterpartyID
+	OrderedOn         domain.BusinessDate
+	DueOn             domain.BusinessDate
+	Deposit           domain.MinorAmount
+	Notes             domain.Option[domain.NonEmptyText]
+	Lines             []CustomerOrderLineInput
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+type CancelCustomerOrderInput struct {
+	ID                domain.CustomerOrderID
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+// FulfilCustomerOrderInput posts Sale for an open order. The sale is posted at
+// Sale.PostedAt, which also becomes the order's new version.
+type FulfilCustomerOrderInput struct {
+	ID                domain.CustomerOrderID
+	ExpectedUpdatedAt domain.UTCInstant
+	Sale              PostSaleInput
+}
+
+// CustomerOrderDueFilter selects orders due between two business dates,
+// inclusive, optionally by status.
+type CustomerOrderDueFilter struct {
+	From   domain.BusinessDate
+	To     domain.BusinessDate
+	Status domain.Option[domain.CustomerOrderStatus]
+}
+
+func (s *Store) GetCustomerOrder(ctx context.Context, id domain.CustomerOrderID) (sales.Order, error) {
+	if id.IsZero() {
+		return sales.Order{}, domain.Invalid("customer_order_id", domain.ViolationRequired, "")
+	}
+	var order sales.Order
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadCustomerOrder(ctx, tx, id.Int64())
+		if err != nil {
+			return err
+		}
+		order = value
+		return nil
+	})
+	if err != nil {
+		return sales.Order{}, classifyError("get customer order", err)
+	}
+	return order, nil
+}
+
+// ListCustomerOrdersDue returns the orders due in the filter's range ordered
+// by due date and then by id.
+func (s *Store) ListCustomerOrdersDue(ctx context.Context, filter CustomerOrderDueFilter) ([]sales.Order, error) {
+	if filter.From.IsZero() {
+		return nil, domain.Invalid("from", domain.ViolationRequired, "")
+	}
+	if filter.To.IsZero() {
+		return nil, domain.Invalid("to", domain.ViolationRequired, "")
+	}
+	if filter.To.Before(filter.From) {
+		return nil, domain.Invalid("to", domain.ViolationOutOfRange, "")
+	}
+	status := ""
+	if value, ok := filter.Status.Get(); ok {
+		if _, err := domain.ParseCustomerOrderStatus(value.String()); err != nil {
+			return nil, err
+		}
+		status = value.String()
+	}
+	var orders []sales.Order
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id
+			FROM customer_orders
+			WHERE due_on BETWEEN ? AND ?
+			  AND (? = '' OR status = ?)
+			ORDER BY due_on, id
+		`, filter.From.String(), filter.To.String(), status, status)
+		if err != nil {
+			return err
+		}
+		ids, err := scanInt64Rows(rows)
+		if err != nil {
+			return err
+		}
+		orders = make([]sales.Order, 0, len(ids))
+		for _, id := range ids {
+			order, err := loadCustomerOrder(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			orders = append(orders, order)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list customer orders due", err)
+	}
+	return orders, nil
+}
+
+// FindCustomerOrderSale returns the sale that fulfilled the order with key,
+// if any, so a retried fulfilment can be answered before it is rebuilt. A key
+// used by any other document is a conflict.
+func (s *Store) FindCustomerOrderSale(
+	ctx context.Context,
+	id domain.CustomerOrderID,
+	key domain.IdempotencyKey,
+) (domain.Option[PostedSaleDocument], error) {
+	if id.IsZero() {
+		return domain.None[PostedSaleDocument](), domain.Invalid("customer_order_id", domain.ViolationRequired, "")
+	}
+	if key.String() == "" {
+		return domain.None[PostedSaleDocument](), domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	found := domain.None[PostedSaleDocument]()
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		replayed, err := replayCustomerOrderSale(ctx, tx, id.Int64(), key)
+		if err != nil {
+			return err
+		}
+		if documentID, ok := replayed.Get(); ok {
+			document, err := loadPostedSaleDocument(ctx, tx, documentID)
+			if err != nil {
+				return err
+			}
+			found = domain.Some(document)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.None[PostedSaleDocument](), classifyError("find customer order sale", err)
+	}
+	return found, nil
+}
+
+func (s *Store) CreateCustomerOrder(ctx context.Context, input CreateCustomerOrderInput) (sales.Order, error) {
+	if err := validateCustomerOrderHeader(input.CustomerID, input.OrderedOn, input.DueOn, input.Deposit, input.Lines); err != nil {
+		return sales.Order{}, err
+	}
+	if input.CreatedAt.IsZero() {
+		return sales.Order{}, domain.Invalid("created_at", domain.ViolationRequired, "")
+	}
+	var created sales.Order
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		if err := requireActiveCustomer(ctx, tx, input.CustomerID.Int64()); err != nil {
+			return err
+		}
+		var id int64
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO customer_orders (
+				customer_id, status, ordered_on, due_on, deposit_minor, notes,
+				created_at_ms, updated_at_ms
+			) VALUES (?, 'OPEN', ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			input.CustomerID.Int64(),
+			input.OrderedOn.String(),
+			input.DueOn.String(),
+			input.Deposit.Int64(),
+			nullableText(input.Notes),
+			input.CreatedAt.UnixMilli(),
+			input.CreatedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		if err := insertCustomerOrderLines(ctx, tx, id, input.Lines); err != nil {
+			return err
+		}
+		value, err := loadCustomerOrder(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		created = value
+		return nil
+	})
+	if err != nil {
+		return sales.Order{}, classifyError("create customer order", err)
+	}
+	return created, nil
+}
+
+func (s *Store) UpdateCustomerOrder(ctx context.Context, input UpdateCustomerOrderInput) (sales.Order, error) {
+	if input.ID.IsZero() {
+		return sales.Order{}, domain.Invalid("customer_order_id", domain.ViolationRequired, "")
+	}
+	if err := validateCustomerOrderHeader(input.CustomerID, input.OrderedOn, input.DueOn, input.Deposit, input.Lines); err != nil {
+		return sales.Order{}, err
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return sales.Order{}, err
+	}
+	var updated sales.Order
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadCustomerOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: customer order version changed", domain.ErrStale)
+		}
+		if !current.IsOpen() {
+			return fmt.Errorf("%w: customer order is %s and can no longer be edited", domain.ErrConflict, current.Status())
+		}
+		if err := requireActiveCustomer(ctx, tx, input.CustomerID.Int64()); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE customer_orders
+			SET customer_id = ?, ordered_on = ?, due_on = ?, deposit_minor = ?, notes = ?, updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`,
+			input.CustomerID.Int64(),
+			input.OrderedOn.String(),
+			input.DueOn.String(),
+			input.Deposit.Int64(),
+			nullableText(input.Notes),
+			input.UpdatedAt.UnixMilli(),
+			input.ID.Int64(),
+			input.ExpectedUpdatedAt.UnixMilli(),
+		); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM customer_order_lines WHERE order_id = ?`, input.ID.Int64()); err != nil {
+			return err
+		}
+		if err := insertCustomerOrderLines(ctx, tx, input.ID.Int64(), input.Lines); err != nil {
+			return err
+		}
+		value, err := loadCustomerOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		updated = value
+		return nil
+	})
+	if err != nil {
+		return sales.Order{}, classifyError("update customer order", err)
+	}
+	return updated, nil
+}
+
+func (s *Store) CancelCustomerOrder(ctx context.Context, input CancelCustomerOrderInput) (sales.Order, error) {
+	if input.ID.IsZero() {
+		return sales.Order{}, domain.Invalid("customer_order_id", domain.ViolationRequired, "")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return sales.Order{}, err
+	}
+	var cancelled sales.Order
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadCustomerOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: customer order version changed", domain.ErrStale)
+		}
+		if !current.IsOpen() {
+			return fmt.Errorf("%w: customer order is %s and cannot be cancelled", domain.ErrConflict, current.Status())
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE customer_orders SET status = 'CANCELLED', updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`, input.UpdatedAt.UnixMilli(), input.ID.Int64(), input.ExpectedUpdatedAt.UnixMilli()); err != nil {
+			return err
+		}
+		value, err := loadCustomerOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		cancelled = value
+		return nil
+	})
+	if err != nil {
+		return sales.Order{}, classifyError("cancel customer order", err)
+	}
+	return cancelled, nil
+}
+
+// FulfilCustomerOrder posts the order's sale and marks the order FULFILLED in
+// one transaction, so a sale rejected for lack of stock leaves the order open.
+// Retrying with the same idempotency key returns the earlier sale unchanged.
+func (s *Store) FulfilCustomerOrder(
+	ctx context.Context,
+	input FulfilCustomerOrderInput,
+) (sales.Order, PostedSaleDocument, error) {
+	if input.ID.IsZero() {
+		return sales.Order{}, PostedSaleDocument{}, domain.Invalid("customer_order_id", domain.ViolationRequired, "")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.Sale.PostedAt); err != nil {
+		return sales.Order{}, PostedSaleDocument{}, err
+	}
+	var fulfilled sales.Order
+	var posted PostedSaleDocument
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		replayed, err := replayCustomerOrderSale(ctx, tx, input.ID.Int64(), input.Sale.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if documentID, ok := replayed.Get(); ok {
+			if posted, err = loadPostedSaleDocument(ctx, tx, documentID); err != nil {
+				return err
+			}
+			fulfilled, err = loadCustomerOrder(ctx, tx, input.ID.Int64())
+			return err
+		}
+
+		current, err := loadCustomerOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: customer order version changed", domain.ErrStale)
+		}
+		if !current.IsOpen() {
+			return fmt.Errorf("%w: customer order is %s and cannot be fulfilled", domain.ErrConflict, current.Status())
+		}
+		if err := validateCustomerOrderSale(current, input.Sale); err != nil {
+			return err
+		}
+		if posted, err = postSaleTx(ctx, tx, input.Sale); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE customer_orders
+			SET status = 'FULFILLED', sale_document_id = ?, updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`,
+			posted.ID().Int64(),
+			input.Sale.PostedAt.UnixMilli(),
+			input.ID.Int64(),
+			input.ExpectedUpdatedAt.UnixMilli(),
+		); err != nil {
+			return err
+		}
+		fulfilled, err = loadCustomerOrder(ctx, tx, input.ID.Int64())
+		return err
+	})
+	if err != nil {
+		return sales.Order{}, PostedSaleDocument{}, classifyError("fulfil customer order", err)
+	}
+	return fulfilled, posted, nil
+}
+
+func validateCustomerOrderHeader(
+	customerID domain.CounterpartyID,
+	orderedOn domain.BusinessDate,
+	dueOn domain.BusinessDate,
+	deposit domain.MinorAmount,
+	lines []CustomerOrderLineInput,
+) error {
+	if customerID.IsZero() {
+		return domain.Invalid("customer_id", domain.ViolationRequired, "COR-001")
+	}
+	if orderedOn.IsZero() {
+		return domain.Invalid("ordered_on", domain.ViolationRequired, "")
+	}
+	if dueOn.IsZero() {
+		return domain.Invalid("due_on", domain.ViolationRequired, "")
+	}
+	if dueOn.Before(orderedOn) {
+		return domain.Invalid("due_on", domain.ViolationOutOfRange, "COR-001")
+	}
+	if len(lines) == 0 {
+		return domain.Invalid("lines", domain.ViolationRequired, "COR-001")
+	}
+	total := domain.MinorAmount{}
+	for index, line := range lines {
+		field := fmt.Sprintf("lines[%d]", index)
+		switch {
+		case line.ItemID.IsZero():
+			return domain.Invalid(field+".item_id", domain.ViolationRequired, "COR-001")
+		case line.Quantity.Int64() <= 0:
+			return domain.Invalid(field+".quantity_atomic", domain.ViolationNotPositive, "COR-001")
+		case line.EnteredUnit.String() == "":
+			return domain.Invalid(field+".entered_unit_code", domain.ViolationRequired, "COR-001")
+		case line.Conversion.IsZero():
+			return domain.Invalid(field+".conversion", domain.ViolationRequired, "COR-001")
+		case line.AgreedTotal.Int64() <= 0:
+			return domain.Invalid(field+".agreed_total_minor", domain.ViolationNotPositive, "COR-001")
+		}
+		sum, err := total.Add(line.AgreedTotal)
+		if err != nil {
+			return domain.Invalid(field+".agreed_total_minor", domain.ViolationOutOfRange, "COR-001")
+		}
+		total = sum
+	}
+	if deposit.Int64() > total.Int64() {
+		return domain.Invalid("deposit_minor", domain.ViolationOutOfRange, "COR-001")
+	}
+	return nil
+}
+
+// validateCustomerOrderSale checks that the sale is to the order's customer,
+// not dated before the order, and repeats every order line at its agreed
+// total in the same position.
+func validateCustomerOrderSale(order sales.Order, sale PostSaleInput) error {
+	customerID, ok := sale.CounterpartyID.Get()
+	if !ok || customerID != order.CustomerID() {
+		return domain.Invalid("counterparty_id", domain.ViolationInvariant, "COR-003")
+	}
+	if sale.OccurredOn.Before(order.OrderedOn()) {
+		return domain.Invalid("occurred_on", domain.ViolationOutOfRange, "COR-003")
+	}
+	lines := order.Lines()
+	if len(sale.Lines) != len(lines) {
+		return domain.Invalid("lines", domain.ViolationInvariant, "COR-003")
+	}
+	for index, line := range lines {
+		saleLine := sale.Lines[index]
+		if saleLine.ItemID != line.ItemID() || saleLine.Quantity != line.Quantity() ||
+			saleLine.CommercialTotal != line.AgreedTotal() {
+			return domain.Invalid(fmt.Sprintf("lines[%d]", index), domain.ViolationInvariant, "COR-003")
+		}
+	}
+	return nil
+}
+
+// replayCustomerOrderSale finds a sale already posted with key. It is a replay
+// only when that sale fulfilled the same order.
+func replayCustomerOrderSale(
+	ctx context.Context,
+	tx databaseWriteTx,
+	orderID int64,
+	key domain.IdempotencyKey,
+) (domain.Option[int64], error) {
+	var documentID int64
+	var fulfilledOrderID sql.NullInt64
+	err := tx.QueryRowContext(ctx, `
+		SELECT document.id, customer_order.id
+		FROM stock_documents document
+		LEFT JOIN customer_orders customer_order ON customer_order.sale_document_id = document.id
+		WHERE document.idempotency_key = ?
+	`, key.String()).Scan(&documentID, &fulfilledOrderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.None[int64](), nil
+	}
+	if err != nil {
+		return domain.None[int64](), err
+	}
+	if !fulfilledOrderID.Valid || fulfilledOrderID.Int64 != orderID {
+		return domain.None[int64](), fmt.Errorf("%w: idempotency key belongs to another document", domain.ErrConflict)
+	}
+	return domain.Some(documentID), nil
+}
+
+// requireActiveCustomer reports a missing, archived, or non-customer
+// counterparty as a bad reference before the order triggers reject it.
+func requireActiveCustomer(ctx context.Context, tx databaseWriteTx, id int64) error {
+	var customer bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM counterparties counterparty
+			JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+			WHERE counterparty.id = ?
+			  AND counterparty.archived_at_ms IS NULL
+			  AND role.role = 'CUSTOMER'
+		)
+	`, id).Scan(&customer)
+	if err != nil {
+		return err
+	}
+	if !customer {
+		return fmt.Errorf("%w: counterparty is not an active customer", domain.ErrInvalidReference)
+	}
+	return nil
+}
+
+func insertCustomerOrderLines(ctx context.Context, tx databaseWriteTx, orderID int64, lines []CustomerOrderLineInput) error {
+	for index, line := range lines {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO customer_order_lines (
+				order_id, line_order, item_id, quantity_atomic, entered_unit_code,
+				entered_packaging_name, conversion_numerator_atomic, conversion_denominator,
+				agreed_total_minor
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			orderID,
+			int64(index+1),
+			line.ItemID.Int64(),
+			line.Quantity.Int64(),
+			line.EnteredUnit.String(),
+			nullableText(line.EnteredPackagingName),
+			line.Conversion.NumeratorAtomic(),
+			line.Conversion.Denominator(),
+			line.AgreedTotal.Int64(),
+		); err != nil {
+			return fmt.Errorf("line %d: %w", index+1, err)
+		}
+	}
+	return nil
+}
+
+func loadCustomerOrder(ctx context.Context, tx databaseWriteTx, id int64) (sales.Order, error) {
+	var row customerOrderRow
+	if err := tx.QueryRowContext(ctx, `
+		SELECT id, customer_id, status, ordered_on, due_on, deposit_minor, notes,
+		       sale_document_id, created_at_ms, updated_at_ms
+		FROM customer_orders
+		WHERE id = ?
+	`, id).Scan(
+		&row.id,
+		&row.customerID,
+		&row.status,
+		&row.orderedOn,
+		&row.dueOn,
+		&row.depositMinor,
+		&row.notes,
+		&row.saleDocumentID,
+		&row.createdAtMS,
+		&row.updatedAtMS,
+	); err != nil {
+		return sales.Order{}, err
+	}
+	lines, err := loadCustomerOrderLines(ctx, tx, id)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	order, err := mapCustomerOrder(row, lines)
+	if err != nil {
+		return sales.Order{}, corruptDataError("map customer order", err)
+	}
+	return order, nil
+}
+
+type customerOrderRow struct {
+	id, customerID, depositMinor, createdAtMS, updatedAtMS int64
+	status, orderedOn, dueOn                               string
+	notes                                                  sql.NullString
+	saleDocumentID                                         sql.NullInt64
+}
+
+func loadCustomerOrderLines(ctx context.Context, tx databaseWriteTx, orderID int64) ([]sales.OrderLine, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, line_order, item_id, quantity_atomic, entered_unit_code,
+		       entered_packaging_name, conversion_numerator_atomic, conversion_denominator,
+		       agreed_total_minor
+		FROM customer_order_lines
+		WHERE order_id = ?
+		ORDER BY line_order, id
+	`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []sales.OrderLine
+	for rows.Next() {
+		var row customerOrderLineRow
+		if err := rows.Scan(
+			&row.id,
+			&row.lineOrder,
+			&row.itemID,
+			&row.quantityAtomic,
+			&row.enteredUnitCode,
+			&row.enteredPackagingName,
+			&row.conversionNumeratorAtomic,
+			&row.conversionDenominator,
+			&row.agreedTotalMinor,
+		); err != nil {
+			return nil, err
+		}
+		line, err := mapCustomerOrderLine(orderID, row)
+		if err != nil {
+			return nil, corruptDataError("map customer order line", err)
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+type customerOrderLineRow struct {
+	id, lineOrder, itemID, quantityAtomic            int64
+	conversionNumeratorAtomic, conversionDenominator int64
+	agreedTotalMinor                                 int64
+	enteredUnitCode                                  string
+	enteredPackagingName                             sql.NullString
+}
+
+func mapCustomerOrder(row customerOrderRow, lines []sales.OrderLine) (sales.Order, error) {
+	id, err := domain.NewCustomerOrderID(row.id)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	customerID, err := domain.NewCounterpartyID(row.customerID)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	status, err := domain.ParseCustomerOrderStatus(row.status)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	orderedOn, err := domain.ParseBusinessDate(row.orderedOn)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	dueOn, err := domain.ParseBusinessDate(row.dueOn)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	deposit, err := domain.NewMinorAmount(row.depositMinor)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	notes, err := optionalNonEmptyText(row.notes)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	saleDocumentID := domain.None[domain.StockDocumentID]()
+	if row.saleDocumentID.Valid {
+		value, err := domain.NewStockDocumentID(row.saleDocumentID.Int64)
+		if err != nil {
+			return sales.Order{}, err
+		}
+		saleDocumentID = domain.Some(value)
+	}
+	createdAt, err := domain.UTCInstantFromUnixMilli(row.createdAtMS)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	updatedAt, err := domain.UTCInstantFromUnixMilli(row.updatedAtMS)
+	if err != nil {
+		return sales.Order{}, err
+	}
+	return sales.NewOrder(sales.OrderParams{
+		ID: id, CustomerID: customerID, Status: status, OrderedOn: orderedOn, DueOn: dueOn,
+		Deposit: deposit, Notes: notes, SaleDocumentID: saleDocumentID,
+		CreatedAt: createdAt, UpdatedAt: updatedAt, Lines: lines,
+	})
+}
+
+func mapCustomerOrderLine(orderID int64, row customerOrderLineRow) (sales.OrderLine, error) {
+	id, err := domain.NewCustomerOrderLineID(row.id)
+	if err != nil {
+		return sales.OrderLine{}, err
+	}
+	order, err := domain.NewCustomerOrderID(orderID)
+	if err != nil {
+		return sales.OrderLine{}, err
+	}
+	lineOrder, err := domain.NewLineOrder(row.lineOrder)
+	if err != nil {
+		return sales.OrderLine{}, err
+	}
+	itemID, err := domain.NewItemID(row.itemID)
+	if err != nil {
+		return sales.OrderLine{}, err
+	}
+	quantity, err := domain.NewPositiveAtomicQuantity(row.quantityAtomic)
+	if err != nil {
+		return sales.OrderLine{}, err
+	}
+	enteredUnit, err := domain.NewUnitCode(row.enteredUnitCode)
+	if err != nil {
+		return sales.OrderLine{}, err
+	}
+	enteredPackagingName, err := optionalNonEmptyText(row.enteredPackagingName)
+	if err != nil {
+		return sales.OrderLine{}, err
+	}
+	conversion, err := domain.NewUnitConversion(row.conversionNumeratorAtomic, row.conversionDenominator)
+	if err != nil {
+		return sales.OrderLine{}, err
+	}
+	agreedTotal, err := domain.NewMinorAmount(row.agreedTotalMinor)
+	if err != nil {
+		return sales.OrderLine{}, err
+	}
+	return sales.NewOrderLine(sales.OrderLineParams{
+		ID: id, OrderID: order, LineOrder: lineOrder, ItemID: itemID, Quantity: quantity,
+		EnteredUnit: enteredUnit, EnteredPackagingName: enteredPackagingName,
+		Conversion: conversion, AgreedTotal: agreedTotal,
+	})
+}
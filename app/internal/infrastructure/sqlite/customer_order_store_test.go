@@ -0,0 +1,145 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestCustomerOrderStoreFulfilsIntoSaleOnlyWhenStockExists(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "customer-orders.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+
+	cake := createSaleTestItem(t, store, "Birthday cake", true)
+	customer, err := store.CreateCounterparty(ctx, CreateCounterpartyInput{
+		Name:      counterpartyName(t, "Party client"),
+		Roles:     counterpartyRoles(t, domain.RoleCustomer),
+		CreatedAt: counterpartyInstant(t, 1_000),
+	})
+	if err != nil {
+		t.Fatalf("create customer: %v", err)
+	}
+	supplier, err := store.CreateCounterparty(ctx, CreateCounterpartyInput{
+		Name:      counterpartyName(t, "Mill"),
+		Roles:     counterpartyRoles(t, domain.RoleSupplier),
+		CreatedAt: counterpartyInstant(t, 1_000),
+	})
+	if err != nil {
+		t.Fatalf("create supplier: %v", err)
+	}
+	line := CustomerOrderLineInput{
+		ItemID:      cake,
+		Quantity:    mustPurchaseQuantity(t, 100),
+		EnteredUnit: mustCatalogUnitCode(t, "g"),
+		Conversion:  mustCatalogConversion(t, 1_000, 1),
+		AgreedTotal: mustPurchaseMinorAmount(t, 5_000),
+	}
+	input := CreateCustomerOrderInput{
+		CustomerID: supplier.ID(),
+		OrderedOn:  mustPurchaseDate(t, "2026-07-10"),
+		DueOn:      mustPurchaseDate(t, "2026-07-20"),
+		Deposit:    mustPurchaseMinorAmount(t, 1_000),
+		Lines:      []CustomerOrderLineInput{line},
+		CreatedAt:  mustCatalogInstant(t, 2_000),
+	}
+	if _, err := store.CreateCustomerOrder(ctx, input); !errors.Is(err, domain.ErrInvalidReference) {
+		t.Fatalf("supplier order error = %v, want invalid reference", err)
+	}
+	input.CustomerID = customer.ID()
+	input.Deposit = mustPurchaseMinorAmount(t, 5_001)
+	if _, err := store.CreateCustomerOrder(ctx, input); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("oversized deposit error = %v, want validation", err)
+	}
+	input.Deposit = mustPurchaseMinorAmount(t, 1_000)
+	order, err := store.CreateCustomerOrder(ctx, input)
+	if err != nil {
+		t.Fatalf("create customer order: %v", err)
+	}
+	if order.Status() != domain.CustomerOrderOpen || order.BalanceDue().Int64() != 4_000 {
+		t.Fatalf("created order = %#v", order)
+	}
+
+	fulfil := func(key, occurredOn string, at int64) FulfilCustomerOrderInput {
+		return FulfilCustomerOrderInput{
+			ID:                order.ID(),
+			ExpectedUpdatedAt: order.UpdatedAt(),
+			Sale: PostSaleInput{
+				IdempotencyKey: mustPurchaseIdempotencyKey(t, key),
+				CounterpartyID: domain.Some(customer.ID()),
+				OccurredOn:     mustPurchaseDate(t, occurredOn),
+				PostedAt:       mustCatalogInstant(t, at),
+				Lines: []PostSaleLineInput{{
+					ItemID:          cake,
+					Quantity:        line.Quantity,
+					EnteredUnit:     line.EnteredUnit,
+					Conversion:      line.Conversion,
+					CommercialTotal: line.AgreedTotal,
+				}},
+			},
+		}
+	}
+	if _, _, err := store.FulfilCustomerOrder(ctx, fulfil("early", "2026-07-20", 3_000)); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("fulfil without stock error = %v, want validation", err)
+	}
+	unchanged, err := store.GetCustomerOrder(ctx, order.ID())
+	if err != nil || !unchanged.IsOpen() || unchanged.SaleDocumentID().IsSome() {
+		t.Fatalf("order after failed fulfilment = %#v, %v", unchanged, err)
+	}
+
+	postAdjustmentTestPurchase(t, store, cake, "cake-batch", "CAKE-1", "2026-08-01", 100, 2_000)
+	if _, _, err := store.FulfilCustomerOrder(ctx, fulfil("backdated", "2026-07-09", 3_000)); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("backdated fulfilment error = %v, want validation", err)
+	}
+	first := fulfil("fulfil-1", "2026-07-20", 3_000)
+	fulfilled, sale, err := store.FulfilCustomerOrder(ctx, first)
+	if err != nil {
+		t.Fatalf("fulfil customer order: %v", err)
+	}
+	saleID, ok := fulfilled.SaleDocumentID().Get()
+	if fulfilled.Status() != domain.CustomerOrderFulfilled || !ok || saleID != sale.ID() ||
+		!fulfilled.UpdatedAt().Equal(mustCatalogInstant(t, 3_000)) {
+		t.Fatalf("fulfilled order = %#v", fulfilled)
+	}
+	if sale.Lines()[0].CommercialTotal().Int64() != 5_000 {
+		t.Fatalf("fulfilment sale = %#v", sale)
+	}
+	balance, err := store.GetInventoryBalance(ctx, cake)
+	if err != nil || !balance.Balance().Quantity().IsZero() {
+		t.Fatalf("balance after fulfilment = %#v, %v", balance, err)
+	}
+
+	replayed, replayedSale, err := store.FulfilCustomerOrder(ctx, first)
+	if err != nil || replayedSale.ID() != sale.ID() || replayed.Status() != domain.CustomerOrderFulfilled {
+		t.Fatalf("replayed fulfilment = %#v, %#v, %v", replayed, replayedSale, err)
+	}
+	found, err := store.FindCustomerOrderSale(ctx, order.ID(), first.Sale.IdempotencyKey)
+	if value, ok := found.Get(); err != nil || !ok || value.ID() != sale.ID() {
+		t.Fatalf("found fulfilment sale = %#v, %v", found, err)
+	}
+	if _, err := store.FindCustomerOrderSale(ctx, order.ID(), mustPurchaseIdempotencyKey(t, "cake-batch")); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("foreign idempotency key error = %v, want conflict", err)
+	}
+	if _, err := store.CancelCustomerOrder(ctx, CancelCustomerOrderInput{
+		ID: order.ID(), ExpectedUpdatedAt: fulfilled.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 4_000),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("cancel fulfilled order error = %v, want conflict", err)
+	}
+
+	due, err := store.ListCustomerOrdersDue(ctx, CustomerOrderDueFilter{
+		From: mustPurchaseDate(t, "2026-07-01"), To: mustPurchaseDate(t, "2026-07-31"),
+	})
+	if err != nil || len(due) != 1 || due[0].ID() != order.ID() {
+		t.Fatalf("orders due = %#v, %v", due, err)
+	}
+	due, err = store.ListCustomerOrdersDue(ctx, CustomerOrderDueFilter{
+		From: mustPurchaseDate(t, "2026-07-01"), To: mustPurchaseDate(t, "2026-07-31"),
+		Status: domain.Some(domain.CustomerOrderOpen),
+	})
+	if err != nil || len(due) != 0 {
+		t.Fatalf("open orders due = %#v, %v", due, err)
+	}
+}
@@ -0,0 +1,316 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+// maxDraftPayloadBytes bounds one saved form. A long purchase is a few
+// kilobytes; anything near this limit is not a form the user typed.
+const maxDraftPayloadBytes = 1 << 20
+
+// StoredDraft is a saved posting form. Payload is the form's request as a JSON
+// object; the store checks its shape but never its contents.
+type StoredDraft struct {
+	Kind      domain.DraftKind
+	ID        domain.IdempotencyKey
+	Payload   string
+	CreatedAt domain.UTCInstant
+	UpdatedAt domain.UTCInstant
+}
+
+// SaveDraftInput creates a draft when ExpectedUpdatedAt is None and otherwise
+// replaces the payload of the draft at that version. SavedAt becomes the new
+// version.
+type SaveDraftInput struct {
+	Kind              domain.DraftKind
+	ID                domain.IdempotencyKey
+	Payload           string
+	ExpectedUpdatedAt domain.Option[domain.UTCInstant]
+	SavedAt           domain.UTCInstant
+}
+
+type DiscardDraftInput struct {
+	Kind              domain.DraftKind
+	ID                domain.IdempotencyKey
+	ExpectedUpdatedAt domain.UTCInstant
+}
+
+func (s *Store) GetDraft(ctx context.Context, kind domain.DraftKind, id domain.IdempotencyKey) (StoredDraft, error) {
+	if err := validateDraftKey(kind, id); err != nil {
+		return StoredDraft{}, err
+	}
+	var draft StoredDraft
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadDraft(ctx, tx, kind, id)
+		if err != nil {
+			return err
+		}
+		draft = value
+		return nil
+	})
+	if err != nil {
+		return StoredDraft{}, classifyError("get draft", err)
+	}
+	return draft, nil
+}
+
+// ListDrafts returns the saved drafts, optionally of one kind, most recently
+// saved first.
+func (s *Store) ListDrafts(ctx context.Context, kind domain.Option[domain.DraftKind]) ([]StoredDraft, error) {
+	filter := ""
+	if value, ok := kind.Get(); ok {
+		if _, err := domain.ParseDraftKind(value.String()); err != nil {
+			return nil, err
+		}
+		filter = value.String()
+	}
+	var drafts []StoredDraft
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT kind, draft_id, payload_json, created_at_ms, updated_at_ms
+			FROM drafts
+			WHERE ? = '' OR kind = ?
+			ORDER BY updated_at_ms DESC, kind, draft_id
+		`, filter, filter)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		drafts = make([]StoredDraft, 0)
+		for rows.Next() {
+			var row draftRow
+			if err := rows.Scan(&row.kind, &row.id, &row.payload, &row.createdAtMS, &row.updatedAtMS); err != nil {
+				return err
+			}
+			draft, err := mapDraft(row)
+			if err != nil {
+				return corruptDataError("map draft", err)
+			}
+			drafts = append(drafts, draft)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, classifyError("list drafts", err)
+	}
+	return drafts, nil
+}
+
+// SaveDraft creates or replaces a draft. A draft whose id already keys a
+// posted stock document is a conflict: it has been posted and cannot be
+// edited back into a form.
+func (s *Store) SaveDraft(ctx context.Context, input SaveDraftInput) (StoredDraft, error) {
+	if err := validateDraftKey(input.Kind, input.ID); err != nil {
+		return StoredDraft{}, err
+	}
+	if err := validateDraftPayload(input.Payload); err != nil {
+		return StoredDraft{}, err
+	}
+	if expected, ok := input.ExpectedUpdatedAt.Get(); ok {
+		if err := validateVersionAdvance(expected, input.SavedAt); err != nil {
+			return StoredDraft{}, err
+		}
+	} else if input.SavedAt.IsZero() {
+		return StoredDraft{}, domain.Invalid("saved_at", domain.ViolationRequired, "")
+	}
+	var saved StoredDraft
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		posted, err := draftPosted(ctx, tx, input.ID)
+		if err != nil {
+			return err
+		}
+		if posted {
+			return fmt.Errorf("%w: draft %s has already been posted", domain.ErrConflict, input.ID)
+		}
+		expected, ok := input.ExpectedUpdatedAt.Get()
+		if !ok {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO drafts (kind, draft_id, payload_json, created_at_ms, updated_at_ms)
+				VALUES (?, ?, ?, ?, ?)
+			`,
+				input.Kind.String(),
+				input.ID.String(),
+				input.Payload,
+				input.SavedAt.UnixMilli(),
+				input.SavedAt.UnixMilli(),
+			); err != nil {
+				return err
+			}
+		} else {
+			result, err := tx.ExecContext(ctx, `
+				UPDATE drafts SET payload_json = ?, updated_at_ms = ?
+				WHERE kind = ? AND draft_id = ? AND updated_at_ms = ?
+			`,
+				input.Payload,
+				input.SavedAt.UnixMilli(),
+				input.Kind.String(),
+				input.ID.String(),
+				expected.UnixMilli(),
+			)
+			if err != nil {
+				return err
+			}
+			if err := requireDraftVersion(ctx, tx, input.Kind, input.ID, result); err != nil {
+				return err
+			}
+		}
+		value, err := loadDraft(ctx, tx, input.Kind, input.ID)
+		if err != nil {
+			return err
+		}
+		saved = value
+		return nil
+	})
+	if err != nil {
+		return StoredDraft{}, classifyError("save draft", err)
+	}
+	return saved, nil
+}
+
+func (s *Store) DiscardDraft(ctx context.Context, input DiscardDraftInput) error {
+	if err := validateDraftKey(input.Kind, input.ID); err != nil {
+		return err
+	}
+	if input.ExpectedUpdatedAt.IsZero() {
+		return domain.Invalid("expected_updated_at", domain.ViolationRequired, "")
+	}
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		result, err := tx.ExecContext(ctx, `
+			DELETE FROM drafts
+			WHERE kind = ? AND draft_id = ? AND updated_at_ms = ?
+		`, input.Kind.String(), input.ID.String(), input.ExpectedUpdatedAt.UnixMilli())
+		if err != nil {
+			return err
+		}
+		return requireDraftVersion(ctx, tx, input.Kind, input.ID, result)
+	})
+	return classifyError("discard draft", err)
+}
+
+// DeletePostedDraft removes a draft once a stock document of its kind has
+// been posted with the draft id as idempotency key. Deleting a draft that is
+// already gone succeeds, so a retried post can finish; deleting one that was
+// never posted is a conflict.
+func (s *Store) DeletePostedDraft(ctx context.Context, kind domain.DraftKind, id domain.IdempotencyKey) error {
+	if err := validateDraftKey(kind, id); err != nil {
+		return err
+	}
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		var documentKind string
+		err := tx.QueryRowContext(ctx, `
+			SELECT kind FROM stock_documents WHERE idempotency_key = ?
+		`, id.String()).Scan(&documentKind)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && documentKind != kind.DocumentKind().String()) {
+			return fmt.Errorf("%w: draft %s has not been posted as a %s", domain.ErrConflict, id, kind)
+		}
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `DELETE FROM drafts WHERE kind = ? AND draft_id = ?`, kind.String(), id.String())
+		return err
+	})
+	return classifyError("delete posted draft", err)
+}
+
+func validateDraftKey(kind domain.DraftKind, id domain.IdempotencyKey) error {
+	if _, err := domain.ParseDraftKind(kind.String()); err != nil {
+		return err
+	}
+	if id.String() == "" {
+		return domain.Invalid("draft_id", domain.ViolationRequired, "DRF-001")
+	}
+	return nil
+}
+
+func validateDraftPayload(payload string) error {
+	if len(payload) > maxDraftPayloadBytes {
+		return domain.Invalid("payload", domain.ViolationOutOfRange, "DRF-001")
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(payload), &object); err != nil || object == nil {
+		return domain.Invalid("payload", domain.ViolationInvalidFormat, "DRF-001")
+	}
+	return nil
+}
+
+func draftPosted(ctx context.Context, tx databaseWriteTx, id domain.IdempotencyKey) (bool, error) {
+	var posted bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM stock_documents WHERE idempotency_key = ?)
+	`, id.String()).Scan(&posted)
+	return posted, err
+}
+
+// requireDraftVersion turns a versioned write that touched no row into stale
+// when the draft still exists and not found when it does not.
+func requireDraftVersion(
+	ctx context.Context,
+	tx databaseWriteTx,
+	kind domain.DraftKind,
+	id domain.IdempotencyKey,
+	result sql.Result,
+) error {
+	affected, err := result.RowsAffected()
+	if err != nil || affected == 1 {
+		return err
+	}
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM drafts WHERE kind = ? AND draft_id = ?)
+	`, kind.String(), id.String()).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return sql.ErrNoRows
+	}
+	return fmt.Errorf("%w: draft version changed", domain.ErrStale)
+}
+
+func loadDraft(ctx context.Context, tx databaseWriteTx, kind domain.DraftKind, id domain.IdempotencyKey) (StoredDraft, error) {
+	var row draftRow
+	if err := tx.QueryRowContext(ctx, `
+		SELECT kind, draft_id, payload_json, created_at_ms, updated_at_ms
+		FROM drafts
+		WHERE kind = ? AND draft_id = ?
+	`, kind.String(), id.String()).Scan(&row.kind, &row.id, &row.payload, &row.createdAtMS, &row.updatedAtMS); err != nil {
+		return StoredDraft{}, err
+	}
+	draft, err := mapDraft(row)
+	if err != nil {
+		return StoredDraft{}, corruptDataError("map draft", err)
+	}
+	return draft, nil
+}
+
+type draftRow struct {
+	kind, id, payload        string
+	createdAtMS, updatedAtMS int64
+}
+
+func mapDraft(row draftRow) (StoredDraft, error) {
+	kind, err := domain.ParseDraftKind(row.kind)
+	if err != nil {
+		return StoredDraft{}, err
+	}
+	id, err := domain.NewIdempotencyKey(row.id)
+	if err != nil {
+		return StoredDraft{}, err
+	}
+	createdAt, err := domain.UTCInstantFromUnixMilli(row.createdAtMS)
+	if err != nil {
+		return StoredDraft{}, err
+	}
+	updatedAt, err := domain.UTCInstantFromUnixMilli(row.updatedAtMS)
+	if err != nil {
+		return StoredDraft{}, err
+	}
+	return StoredDraft{Kind: kind, ID: id, Payload: row.payload, CreatedAt: createdAt, UpdatedAt: updatedAt}, nil
+}
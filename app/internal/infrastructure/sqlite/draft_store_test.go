@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestDraftStoreSavesVersionedFormsUntilPosted(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "drafts.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	id := mustPurchaseIdempotencyKey(t, "purchase-draft")
+
+	input := SaveDraftInput{
+		Kind:              domain.DraftPurchase,
+		ID:                id,
+		Payload:           `["not", "a", "form"]`,
+		ExpectedUpdatedAt: domain.None[domain.UTCInstant](),
+		SavedAt:           mustCatalogInstant(t, 1_000),
+	}
+	if _, err := store.SaveDraft(ctx, input); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("array payload error = %v, want validation", err)
+	}
+	input.Payload = `{"lines": [{"itemId": 1}]}`
+	created, err := store.SaveDraft(ctx, input)
+	if err != nil {
+		t.Fatalf("create draft: %v", err)
+	}
+	if created.Kind != domain.DraftPurchase || created.ID != id || !created.UpdatedAt.Equal(created.CreatedAt) {
+		t.Fatalf("created draft = %#v", created)
+	}
+	if _, err := store.SaveDraft(ctx, input); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("duplicate draft error = %v, want conflict", err)
+	}
+
+	input.Payload = `{"lines": [{"itemId": 1}, {"itemId": 2}]}`
+	input.ExpectedUpdatedAt = domain.Some(created.UpdatedAt)
+	input.SavedAt = mustCatalogInstant(t, 2_000)
+	updated, err := store.SaveDraft(ctx, input)
+	if err != nil || updated.Payload != input.Payload || !updated.CreatedAt.Equal(created.CreatedAt) {
+		t.Fatalf("updated draft = %#v, %v", updated, err)
+	}
+	input.SavedAt = mustCatalogInstant(t, 3_000)
+	if _, err := store.SaveDraft(ctx, input); !errors.Is(err, domain.ErrStale) {
+		t.Fatalf("stale save error = %v, want stale", err)
+	}
+	if err := store.DiscardDraft(ctx, DiscardDraftInput{
+		Kind: domain.DraftPurchase, ID: id, ExpectedUpdatedAt: created.UpdatedAt,
+	}); !errors.Is(err, domain.ErrStale) {
+		t.Fatalf("stale discard error = %v, want stale", err)
+	}
+
+	if _, err := store.SaveDraft(ctx, SaveDraftInput{
+		Kind: domain.DraftSale, ID: mustPurchaseIdempotencyKey(t, "sale-draft"), Payload: `{}`,
+		ExpectedUpdatedAt: domain.None[domain.UTCInstant](), SavedAt: mustCatalogInstant(t, 2_500),
+	}); err != nil {
+		t.Fatalf("create sale draft: %v", err)
+	}
+	drafts, err := store.ListDrafts(ctx, domain.None[domain.DraftKind]())
+	if err != nil || len(drafts) != 2 || drafts[0].Kind != domain.DraftSale || drafts[1].ID != id {
+		t.Fatalf("drafts = %#v, %v", drafts, err)
+	}
+	drafts, err = store.ListDrafts(ctx, domain.Some(domain.DraftPurchase))
+	if err != nil || len(drafts) != 1 || drafts[0].ID != id {
+		t.Fatalf("purchase drafts = %#v, %v", drafts, err)
+	}
+	if err := store.DiscardDraft(ctx, DiscardDraftInput{
+		Kind: domain.DraftSale, ID: mustPurchaseIdempotencyKey(t, "sale-draft"), ExpectedUpdatedAt: mustCatalogInstant(t, 2_500),
+	}); err != nil {
+		t.Fatalf("discard sale draft: %v", err)
+	}
+
+	if err := store.DeletePostedDraft(ctx, domain.DraftPurchase, id); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("delete unposted draft error = %v, want conflict", err)
+	}
+	flour := createSaleTestItem(t, store, "Flour", true)
+	postAdjustmentTestPurchase(t, store, flour, id.String(), "FLOUR-1", "2026-08-01", 100, 2_000)
+	input.ExpectedUpdatedAt = domain.Some(updated.UpdatedAt)
+	if _, err := store.SaveDraft(ctx, input); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("save posted draft error = %v, want conflict", err)
+	}
+	if err := store.DeletePostedDraft(ctx, domain.DraftSale, id); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("delete draft posted as another kind error = %v, want conflict", err)
+	}
+	if err := store.DeletePostedDraft(ctx, domain.DraftPurchase, id); err != nil {
+		t.Fatalf("delete posted draft: %v", err)
+	}
+	if err := store.DeletePostedDraft(ctx, domain.DraftPurchase, id); err != nil {
+		t.Fatalf("delete posted draft again: %v", err)
+	}
+	if _, err := store.GetDraft(ctx, domain.DraftPurchase, id); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("posted draft error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,377 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/expense"
+)
+
+type RecordExpenseInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	Category       domain.ExpenseCategory
+	Amount         domain.MinorAmount
+	OccurredOn     domain.BusinessDate
+	SupplierID     domain.Option[domain.CounterpartyID]
+	Notes          domain.Option[domain.NonEmptyText]
+	RecordedAt     domain.UTCInstant
+}
+
+// CorrectExpenseInput replaces a current expense with the given fields. A zero
+// amount voids it.
+type CorrectExpenseInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	ExpenseID      domain.ExpenseID
+	Category       domain.ExpenseCategory
+	Amount         domain.MinorAmount
+	OccurredOn     domain.BusinessDate
+	SupplierID     domain.Option[domain.CounterpartyID]
+	Notes          domain.Option[domain.NonEmptyText]
+	RecordedAt     domain.UTCInstant
+}
+
+// ExpenseFilter selects current, non-void expenses by business date,
+// inclusive, and optionally by category.
+type ExpenseFilter struct {
+	FromOccurredOn domain.BusinessDate
+	ToOccurredOn   domain.BusinessDate
+	Category       domain.Option[domain.ExpenseCategory]
+}
+
+func (s *Store) GetExpense(ctx context.Context, id domain.ExpenseID) (expense.Expense, error) {
+	if id.IsZero() {
+		return expense.Expense{}, domain.Invalid("expense_id", domain.ViolationRequired, "")
+	}
+	var loaded expense.Expense
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		var err error
+		loaded, err = loadExpense(ctx, tx, id.Int64())
+		return err
+	})
+	if err != nil {
+		return expense.Expense{}, classifyError("get expense", err)
+	}
+	return loaded, nil
+}
+
+// ListExpenses returns the current expenses in the filter, newest business
+// date first. Replaced and voided entries are omitted.
+func (s *Store) ListExpenses(ctx context.Context, filter ExpenseFilter) ([]expense.Expense, error) {
+	if filter.FromOccurredOn.IsZero() || filter.ToOccurredOn.IsZero() {
+		return nil, domain.Invalid("occurred_on", domain.ViolationRequired, "EXP-001")
+	}
+	var expenses []expense.Expense
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		query := expenseSelect + `
+			WHERE correction.id IS NULL
+			  AND expense.amount_minor > 0
+			  AND expense.occurred_on >= ?
+			  AND expense.occurred_on <= ?`
+		args := []any{filter.FromOccurredOn.String(), filter.ToOccurredOn.String()}
+		if category, ok := filter.Category.Get(); ok {
+			query += ` AND expense.category = ?`
+			args = append(args, category.String())
+		}
+		rows, err := tx.QueryContext(ctx, query+` ORDER BY expense.occurred_on DESC, expense.id DESC`, args...)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			loaded, err := scanExpense(rows)
+			if err != nil {
+				return err
+			}
+			expenses = append(expenses, loaded)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, classifyError("list expenses", err)
+	}
+	return expenses, nil
+}
+
+// RecordExpense appends an expense. Retrying with the same idempotency key
+// returns the first expense.
+func (s *Store) RecordExpense(ctx context.Context, input RecordExpenseInput) (expense.Expense, error) {
+	if err := validateExpenseFields(input.IdempotencyKey, input.Category, input.OccurredOn, input.RecordedAt); err != nil {
+		return expense.Expense{}, err
+	}
+	if input.Amount.IsZero() {
+		return expense.Expense{}, domain.Invalid("amount_minor", domain.ViolationNotPositive, "EXP-001")
+	}
+	var recorded expense.Expense
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		replayed, err := replayExpense(ctx, tx, input.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if existing, ok := replayed.Get(); ok {
+			if existing.CorrectsExpenseID().IsSome() || !sameExpenseFields(existing, input.Category, input.Amount, input.OccurredOn) {
+				return fmt.Errorf("%w: idempotency key belongs to another expense", domain.ErrConflict)
+			}
+			recorded = existing
+			return nil
+		}
+		recorded, err = insertExpense(ctx, tx, input.IdempotencyKey, input.Category, input.Amount, input.OccurredOn,
+			input.SupplierID, input.Notes, domain.None[domain.ExpenseID](), input.RecordedAt)
+		return err
+	})
+	if err != nil {
+		return expense.Expense{}, classifyError("record expense", err)
+	}
+	return recorded, nil
+}
+
+// CorrectExpense appends a correction that replaces a current expense.
+// Retrying with the same idempotency key returns the first correction.
+func (s *Store) CorrectExpense(ctx context.Context, input CorrectExpenseInput) (expense.Expense, error) {
+	if err := validateExpenseFields(input.IdempotencyKey, input.Category, input.OccurredOn, input.RecordedAt); err != nil {
+		return expense.Expense{}, err
+	}
+	if input.ExpenseID.IsZero() {
+		return expense.Expense{}, domain.Invalid("expense_id", domain.ViolationRequired, "EXP-002")
+	}
+	var correction expense.Expense
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		replayed, err := replayExpense(ctx, tx, input.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if existing, ok := replayed.Get(); ok {
+			if target, ok := existing.CorrectsExpenseID().Get(); !ok || target != input.ExpenseID ||
+				!sameExpenseFields(existing, input.Category, input.Amount, input.OccurredOn) {
+				return fmt.Errorf("%w: idempotency key belongs to another expense", domain.ErrConflict)
+			}
+			correction = existing
+			return nil
+		}
+
+		target, err := loadExpense(ctx, tx, input.ExpenseID.Int64())
+		if err != nil {
+			return err
+		}
+		if !target.IsCurrent() {
+			return fmt.Errorf("%w: expense is already corrected", domain.ErrConflict)
+		}
+		if err := target.CanCorrect(input.RecordedAt); err != nil {
+			return err
+		}
+		correction, err = insertExpense(ctx, tx, input.IdempotencyKey, input.Category, input.Amount, input.OccurredOn,
+			input.SupplierID, input.Notes, domain.Some(input.ExpenseID), input.RecordedAt)
+		return err
+	})
+	if err != nil {
+		return expense.Expense{}, classifyError("correct expense", err)
+	}
+	return correction, nil
+}
+
+func validateExpenseFields(
+	key domain.IdempotencyKey,
+	category domain.ExpenseCategory,
+	occurredOn domain.BusinessDate,
+	recordedAt domain.UTCInstant,
+) error {
+	if key.String() == "" {
+		return domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if _, err := domain.ParseExpenseCategory(category.String()); err != nil {
+		return err
+	}
+	if occurredOn.IsZero() {
+		return domain.Invalid("occurred_on", domain.ViolationRequired, "EXP-001")
+	}
+	if recordedAt.IsZero() {
+		return domain.Invalid("recorded_at", domain.ViolationRequired, "")
+	}
+	return nil
+}
+
+func sameExpenseFields(
+	existing expense.Expense,
+	category domain.ExpenseCategory,
+	amount domain.MinorAmount,
+	occurredOn domain.BusinessDate,
+) bool {
+	return existing.Category() == category && existing.Amount() == amount && existing.OccurredOn().Equal(occurredOn)
+}
+
+func insertExpense(
+	ctx context.Context,
+	tx databaseWriteTx,
+	key domain.IdempotencyKey,
+	category domain.ExpenseCategory,
+	amount domain.MinorAmount,
+	occurredOn domain.BusinessDate,
+	supplierID domain.Option[domain.CounterpartyID],
+	notes domain.Option[domain.NonEmptyText],
+	correctsExpenseID domain.Option[domain.ExpenseID],
+	recordedAt domain.UTCInstant,
+) (expense.Expense, error) {
+	if supplier, ok := supplierID.Get(); ok {
+		if err := requireActiveSupplier(ctx, tx, supplier.Int64()); err != nil {
+			return expense.Expense{}, err
+		}
+	}
+	var corrects any
+	if target, ok := correctsExpenseID.Get(); ok {
+		corrects = target.Int64()
+	}
+	var id int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO expenses (
+			idempotency_key, category, amount_minor, occurred_on, supplier_id, notes,
+			corrects_expense_id, recorded_at_ms
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`,
+		key.String(),
+		category.String(),
+		amount.Int64(),
+		occurredOn.String(),
+		nullableCounterpartyID(supplierID),
+		nullableText(notes),
+		corrects,
+		recordedAt.UnixMilli(),
+	).Scan(&id); err != nil {
+		return expense.Expense{}, err
+	}
+	return loadExpense(ctx, tx, id)
+}
+
+func replayExpense(
+	ctx context.Context,
+	tx databaseWriteTx,
+	key domain.IdempotencyKey,
+) (domain.Option[expense.Expense], error) {
+	var id int64
+	err := tx.QueryRowContext(ctx, `
+		SELECT id FROM expenses WHERE idempotency_key = ?
+	`, key.String()).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.None[expense.Expense](), nil
+	}
+	if err != nil {
+		return domain.None[expense.Expense](), err
+	}
+	loaded, err := loadExpense(ctx, tx, id)
+	if err != nil {
+		return domain.None[expense.Expense](), err
+	}
+	return domain.Some(loaded), nil
+}
+
+// expenseSelect reads an expense with the correction that replaced it, if
+// any.
+const expenseSelect = `
+	SELECT expense.id, expense.idempotency_key, expense.category, expense.amount_minor,
+	       expense.occurred_on, expense.supplier_id, expense.notes,
+	       expense.corrects_expense_id, correction.id, expense.recorded_at_ms
+	FROM expenses expense
+	LEFT JOIN expenses correction ON correction.corrects_expense_id = expense.id`
+
+func loadExpense(ctx context.Context, tx databaseWriteTx, id int64) (expense.Expense, error) {
+	return scanExpense(tx.QueryRowContext(ctx, expenseSelect+` WHERE expense.id = ?`, id))
+}
+
+type expenseRow struct {
+	id, amountMinor, recordedAtMS         int64
+	idempotencyKey, category, occurredOn  string
+	supplierID, correctsID, correctedByID sql.NullInt64
+	notes                                 sql.NullString
+}
+
+func scanExpense(scanner interface{ Scan(...any) error }) (expense.Expense, error) {
+	var row expenseRow
+	if err := scanner.Scan(
+		&row.id,
+		&row.idempotencyKey,
+		&row.category,
+		&row.amountMinor,
+		&row.occurredOn,
+		&row.supplierID,
+		&row.notes,
+		&row.correctsID,
+		&row.correctedByID,
+		&row.recordedAtMS,
+	); err != nil {
+		return expense.Expense{}, err
+	}
+	loaded, err := mapExpense(row)
+	if err != nil {
+		return expense.Expense{}, corruptDataError("map expense", err)
+	}
+	return loaded, nil
+}
+
+func mapExpense(row expenseRow) (expense.Expense, error) {
+	id, err := domain.NewExpenseID(row.id)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	key, err := domain.NewIdempotencyKey(row.idempotencyKey)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	category, err := domain.ParseExpenseCategory(row.category)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	amount, err := domain.NewMinorAmount(row.amountMinor)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	occurredOn, err := domain.ParseBusinessDate(row.occurredOn)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	supplierID, err := optionalCounterpartyID(row.supplierID)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	notes, err := optionalNonEmptyText(row.notes)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	correctsID, err := optionalExpenseID(row.correctsID)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	correctedByID, err := optionalExpenseID(row.correctedByID)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	recordedAt, err := domain.UTCInstantFromUnixMilli(row.recordedAtMS)
+	if err != nil {
+		return expense.Expense{}, err
+	}
+	return expense.New(expense.Params{
+		ID:                id,
+		IdempotencyKey:    key,
+		Category:          category,
+		Amount:            amount,
+		OccurredOn:        occurredOn,
+		SupplierID:        supplierID,
+		Notes:             notes,
+		CorrectsExpenseID: correctsID,
+		CorrectedByID:     correctedByID,
+		RecordedAt:        recordedAt,
+	})
+}
+
+func optionalExpenseID(value sql.NullInt64) (domain.Option[domain.ExpenseID], error) {
+	if !value.Valid {
+		return domain.None[domain.ExpenseID](), nil
+	}
+	id, err := domain.NewExpenseID(value.Int64)
+	if err != nil {
+		return domain.None[domain.ExpenseID](), err
+	}
+	return domain.Some(id), nil
+}
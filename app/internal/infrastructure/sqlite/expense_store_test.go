@@ -0,0 +1,115 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestExpenseStoreCorrectionsReplaceTheCurrentEntry(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "expenses.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	supplier := createReportingSupplier(t, store, "Gas company")
+	customer, err := store.CreateCounterparty(ctx, CreateCounterpartyInput{
+		Name:      counterpartyName(t, "Expense customer"),
+		Roles:     counterpartyRoles(t, domain.RoleCustomer),
+		CreatedAt: counterpartyInstant(t, 1_000),
+	})
+	if err != nil {
+		t.Fatalf("create customer: %v", err)
+	}
+
+	input := RecordExpenseInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "expense-gas"),
+		Category:       domain.ExpenseGas,
+		Amount:         mustPurchaseMinorAmount(t, 12_000),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-05"),
+		SupplierID:     domain.Some(supplier),
+		Notes:          domain.Some(mustCatalogText(t, "July cylinder")),
+		RecordedAt:     mustCatalogInstant(t, 2_000),
+	}
+	recorded, err := store.RecordExpense(ctx, input)
+	if err != nil {
+		t.Fatalf("record expense: %v", err)
+	}
+	if replayed, err := store.RecordExpense(ctx, input); err != nil || replayed.ID() != recorded.ID() {
+		t.Fatalf("replayed expense = %#v, %v", replayed, err)
+	}
+	conflicting := input
+	conflicting.Amount = mustPurchaseMinorAmount(t, 13_000)
+	if _, err := store.RecordExpense(ctx, conflicting); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("conflicting replay error = %v, want conflict", err)
+	}
+	billedByCustomer := input
+	billedByCustomer.IdempotencyKey = mustPurchaseIdempotencyKey(t, "expense-customer")
+	billedByCustomer.SupplierID = domain.Some(customer.ID())
+	if _, err := store.RecordExpense(ctx, billedByCustomer); !errors.Is(err, domain.ErrInvalidReference) {
+		t.Fatalf("customer counterparty error = %v, want invalid reference", err)
+	}
+
+	correct := CorrectExpenseInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "expense-gas-fix"),
+		ExpenseID:      recorded.ID(),
+		Category:       domain.ExpenseGas,
+		Amount:         mustPurchaseMinorAmount(t, 10_500),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-06"),
+		SupplierID:     domain.Some(supplier),
+		RecordedAt:     mustCatalogInstant(t, 3_000),
+	}
+	correction, err := store.CorrectExpense(ctx, correct)
+	if err != nil {
+		t.Fatalf("correct expense: %v", err)
+	}
+	if target, ok := correction.CorrectsExpenseID().Get(); !ok || target != recorded.ID() || correction.Amount().Int64() != 10_500 {
+		t.Fatalf("correction = %#v", correction)
+	}
+	if replayed, err := store.CorrectExpense(ctx, correct); err != nil || replayed.ID() != correction.ID() {
+		t.Fatalf("replayed correction = %#v, %v", replayed, err)
+	}
+	again := correct
+	again.IdempotencyKey = mustPurchaseIdempotencyKey(t, "expense-gas-fix-2")
+	if _, err := store.CorrectExpense(ctx, again); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second correction of one expense error = %v, want conflict", err)
+	}
+	original, err := store.GetExpense(ctx, recorded.ID())
+	if err != nil {
+		t.Fatalf("get corrected expense: %v", err)
+	}
+	if corrected, ok := original.CorrectedByID().Get(); !ok || corrected != correction.ID() || original.IsCurrent() {
+		t.Fatalf("corrected expense = %#v", original)
+	}
+
+	filter := ExpenseFilter{
+		FromOccurredOn: mustPurchaseDate(t, "2026-07-01"),
+		ToOccurredOn:   mustPurchaseDate(t, "2026-07-31"),
+	}
+	listed, err := store.ListExpenses(ctx, filter)
+	if err != nil {
+		t.Fatalf("list expenses: %v", err)
+	}
+	if len(listed) != 1 || listed[0].ID() != correction.ID() {
+		t.Fatalf("listed expenses = %#v", listed)
+	}
+
+	void := CorrectExpenseInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "expense-gas-void"),
+		ExpenseID:      correction.ID(),
+		Category:       domain.ExpenseGas,
+		OccurredOn:     mustPurchaseDate(t, "2026-07-06"),
+		RecordedAt:     mustCatalogInstant(t, 4_000),
+	}
+	if _, err := store.CorrectExpense(ctx, void); err != nil {
+		t.Fatalf("void expense: %v", err)
+	}
+	listed, err = store.ListExpenses(ctx, filter)
+	if err != nil {
+		t.Fatalf("list expenses after void: %v", err)
+	}
+	if len(listed) != 0 {
+		t.Fatalf("listed expenses after void = %#v", listed)
+	}
+}
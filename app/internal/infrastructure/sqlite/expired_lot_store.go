@@ -0,0 +1,248 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+// ExpiredLot is a lot that expired before a business date and still holds
+// stock. InventoryValueMicro estimates what writing off its remaining quantity
+// costs at the item's weighted average, after any earlier-listed lots of the
+// same item, assuming every listed lot is written off. Excluding a lot changes
+// the value PostExpiredLots posts for the later lots of its item.
+type ExpiredLot struct {
+	LotID               domain.InventoryLotID
+	ItemID              domain.ItemID
+	ItemName            string
+	LotCode             domain.Option[string]
+	ExpiresOn           domain.BusinessDate
+	RemainingQuantity   domain.AtomicQuantity
+	InventoryValueMicro int64
+}
+
+// PostExpiredLotsInput writes off every lot that expired before OccurredOn,
+// except the excluded ones.
+type PostExpiredLotsInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	OccurredOn     domain.BusinessDate
+	PostedAt       domain.UTCInstant
+	Notes          domain.Option[domain.NonEmptyText]
+	ExcludedLotIDs []domain.InventoryLotID
+}
+
+// ListExpiredLots returns the lots with expires_on before the given date and
+// remaining quantity, by expiry, item name, and lot.
+func (s *Store) ListExpiredLots(ctx context.Context, before domain.BusinessDate) ([]ExpiredLot, error) {
+	if before.IsZero() {
+		return nil, domain.Invalid("before", domain.ViolationRequired, "ADJ-005")
+	}
+	var lots []ExpiredLot
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		loaded, err := listExpiredLots(ctx, tx, before)
+		if err != nil {
+			return err
+		}
+		lots = loaded
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list expired lots", err)
+	}
+	return lots, nil
+}
+
+// PostExpiredLots posts one EXPIRY adjustment with an OUT line per expired
+// lot that was not excluded. Each line consumes its named lot's whole
+// remaining quantity rather than FEFO-chosen stock and is valued at the
+// weighted average. Retrying with the same idempotency key returns the first
+// document.
+func (s *Store) PostExpiredLots(ctx context.Context, input PostExpiredLotsInput) (PostedAdjustmentDocument, error) {
+	if input.IdempotencyKey.String() == "" {
+		return PostedAdjustmentDocument{}, domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.OccurredOn.IsZero() {
+		return PostedAdjustmentDocument{}, domain.Invalid("occurred_on", domain.ViolationRequired, "DOC-004")
+	}
+	var posted PostedAdjustmentDocument
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		var existingID int64
+		var existingKind string
+		err := tx.QueryRowContext(ctx, `
+			SELECT id, kind FROM stock_documents WHERE idempotency_key = ?
+		`, input.IdempotencyKey.String()).Scan(&existingID, &existingKind)
+		if err == nil {
+			if existingKind != domain.DocumentAdjustment.String() {
+				return fmt.Errorf("%w: idempotency key belongs to %s", domain.ErrConflict, existingKind)
+			}
+			posted, err = loadPostedAdjustmentDocument(ctx, tx, existingID)
+			return err
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		lots, err := listExpiredLots(ctx, tx, input.OccurredOn)
+		if err != nil {
+			return err
+		}
+		excluded := make(map[domain.InventoryLotID]struct{}, len(input.ExcludedLotIDs))
+		for _, lotID := range input.ExcludedLotIDs {
+			excluded[lotID] = struct{}{}
+		}
+		lines := make([]PostAdjustmentLineInput, 0, len(lots))
+		for _, lot := range lots {
+			if _, skip := excluded[lot.LotID]; skip {
+				delete(excluded, lot.LotID)
+				continue
+			}
+			baseUnit, conversion, err := loadItemBaseUnit(ctx, tx, lot.ItemID)
+			if err != nil {
+				return err
+			}
+			lines = append(lines, PostAdjustmentLineInput{
+				ItemID:               lot.ItemID,
+				Direction:            domain.DirectionOut,
+				Quantity:             lot.RemainingQuantity,
+				EnteredUnit:          baseUnit,
+				EnteredPackagingName: domain.None[domain.NonEmptyText](),
+				Conversion:           conversion,
+				InventoryValue:       domain.None[domain.InventoryValue](),
+				LotCode:              domain.None[domain.NonEmptyText](),
+				ExpiresOn:            domain.None[domain.BusinessDate](),
+				LotID:                domain.Some(lot.LotID),
+			})
+		}
+		for index, lotID := range input.ExcludedLotIDs {
+			if _, unmatched := excluded[lotID]; unmatched {
+				return domain.Invalid(fmt.Sprintf("excluded_lot_ids[%d]", index), domain.ViolationInvariant, "ADJ-005")
+			}
+		}
+		if len(lines) == 0 {
+			return domain.Invalid("lines", domain.ViolationRequired, "ADJ-005")
+		}
+		posted, err = postAdjustmentTx(ctx, tx, PostAdjustmentInput{
+			IdempotencyKey: input.IdempotencyKey,
+			OccurredOn:     input.OccurredOn,
+			PostedAt:       input.PostedAt,
+			Reason:         domain.ReasonExpiry,
+			Notes:          input.Notes,
+			Lines:          lines,
+		})
+		return err
+	})
+	if err != nil {
+		return PostedAdjustmentDocument{}, classifyError("post expired lots", err)
+	}
+	return posted, nil
+}
+
+func listExpiredLots(ctx context.Context, tx databaseWriteTx, before domain.BusinessDate) ([]ExpiredLot, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			lot.id,
+			lot.item_id,
+			item.name,
+			lot.lot_code,
+			lot.expires_on,
+			lot.initial_quantity_atomic
+				- COALESCE(SUM(
+					CASE WHEN allocation.restores_allocation_id IS NULL
+						THEN allocation.quantity_atomic ELSE 0 END
+				), 0)
+				+ COALESCE(SUM(
+					CASE WHEN allocation.restores_allocation_id IS NOT NULL
+						THEN allocation.quantity_atomic ELSE 0 END
+				), 0) AS remaining_quantity_atomic
+		FROM inventory_lots lot
+		JOIN items item ON item.id = lot.item_id
+		LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
+		WHERE lot.expires_on IS NOT NULL AND lot.expires_on < ?
+		GROUP BY lot.id
+		HAVING remaining_quantity_atomic > 0
+		ORDER BY lot.expires_on, item.normalized_name, lot.id
+	`, before.String())
+	if err != nil {
+		return nil, err
+	}
+	var loaded []expiredLotRow
+	for rows.Next() {
+		var row expiredLotRow
+		if err := rows.Scan(&row.lotID, &row.itemID, &row.itemName, &row.lotCode, &row.expiresOn, &row.remaining); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		loaded = append(loaded, row)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Lines of one item are valued in order against the balance left by the
+	// lines before them, as postAdjustmentTx does.
+	remainingBalances := make(map[int64]adjustmentBalance)
+	lots := make([]ExpiredLot, 0, len(loaded))
+	for _, row := range loaded {
+		lotID, err := domain.NewInventoryLotID(row.lotID)
+		if err != nil {
+			return nil, corruptDataError("map expired lot", err)
+		}
+		itemID, err := domain.NewItemID(row.itemID)
+		if err != nil {
+			return nil, corruptDataError("map expired lot", err)
+		}
+		expiresOn, err := domain.ParseBusinessDate(row.expiresOn)
+		if err != nil {
+			return nil, corruptDataError("map expired lot", err)
+		}
+		remaining, err := domain.NewPositiveAtomicQuantity(row.remaining)
+		if err != nil {
+			return nil, corruptDataError("map expired lot", err)
+		}
+		balance, ok := remainingBalances[row.itemID]
+		if !ok {
+			balance, err = readAdjustmentBalance(ctx, tx, itemID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		value, err := weightedAverageValue(balance.inventoryValueMicro, balance.quantityAtomic, row.remaining)
+		if err != nil {
+			return nil, corruptDataError("value expired lot", err)
+		}
+		remainingBalances[row.itemID] = adjustmentBalance{
+			quantityAtomic:      balance.quantityAtomic - row.remaining,
+			inventoryValueMicro: balance.inventoryValueMicro - value.Int64(),
+		}
+		lotCode := domain.None[string]()
+		if row.lotCode.Valid {
+			lotCode = domain.Some(row.lotCode.String)
+		}
+		lots = append(lots, ExpiredLot{
+			LotID:               lotID,
+			ItemID:              itemID,
+			ItemName:            row.itemName,
+			LotCode:             lotCode,
+			ExpiresOn:           expiresOn,
+			RemainingQuantity:   remaining,
+			InventoryValueMicro: value.Int64(),
+		})
+	}
+	return lots, nil
+}
+
+type expiredLotRow struct {
+	lotID     int64
+	itemID    int64
+	itemName  string
+	lotCode   sql.NullString
+	expiresOn string
+	remaining int64
+}
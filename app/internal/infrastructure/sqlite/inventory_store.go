@@ -47,10 +47,14 @@ func InventoryBalanceCursorFor(item inventory.BalanceListItem) (InventoryBalance
 func (c InventoryBalanceCursor) ItemName() domain.UniqueName { return c.itemName }
 func (c InventoryBalanceCursor) ItemID() domain.ItemID       { return c.itemID }
 
+// InventoryBalanceListParams lists item balances. With a Location the
+// quantity is the stock held there and the value its share of the item's
+// weighted average value.
 type InventoryBalanceListParams struct {
 	IncludeArchived bool
 	Search          domain.Option[domain.NonEmptyText]
 	After           domain.Option[InventoryBalanceCursor]
+	Location        domain.Option[domain.StockLocationID]
 	Limit           int64
 }
 
@@ -106,9 +110,13 @@ func (s *Store) ListInventoryBalances(ctx context.Context, params InventoryBalan
 	if params.IncludeArchived {
 		includeArchived = 1
 	}
+	locationID, err := locationFilterValue(params.Location)
+	if err != nil {
+		return nil, err
+	}
 
 	rows, err := s.queries.ListInventoryBalances(ctx, sqlcgen.ListInventoryBalancesParams{
-		IncludeArchived: includeArchived, SearchKey: searchKey,
+		LocationID: locationID, IncludeArchived: includeArchived, SearchKey: searchKey,
 		AfterNormalizedName: afterName, AfterItemID: afterItemID, LimitCount: limit,
 	})
 	if err != nil {
@@ -117,10 +125,17 @@ func (s *Store) ListInventoryBalances(ctx context.Context, params InventoryBalan
 
 	items := make([]inventory.BalanceListItem, 0, len(rows))
 	for index, row := range rows {
+		quantityAtomic, valueMicro := row.QuantityAtomic, row.InventoryValueMicro
+		if locationID != 0 {
+			quantityAtomic, valueMicro, err = locationBalanceShare(row.QuantityAtomic, row.InventoryValueMicro, row.LocationQuantityAtomic)
+			if err != nil {
+				return nil, corruptInventoryRow(listInventoryBalancesOperation, index, err)
+			}
+		}
 		snapshot, mapErr := mapInventoryBalanceSnapshot(inventoryBalanceFields{
 			itemID: row.ItemID, itemName: row.ItemName, itemNormalizedName: row.ItemNormalizedName,
 			baseUnitCode: row.BaseUnitCode, itemArchivedAtMS: row.ItemArchivedAtMs,
-			quantityAtomic: row.QuantityAtomic, inventoryValueMicro: row.InventoryValueMicro,
+			quantityAtomic: quantityAtomic, inventoryValueMicro: valueMicro,
 			lastDocumentID: row.LastDocumentID, updatedAtMS: row.UpdatedAtMs,
 		})
 		if mapErr != nil {
@@ -172,7 +187,8 @@ func (s *Store) ListItemLotFacts(ctx context.Context, itemID domain.ItemID) ([]i
 			lotCode: row.LotCode, originatedOn: row.OriginatedOn, expiresOn: row.ExpiresOn,
 			createdAtMS: row.CreatedAtMs, sourceDocumentID: row.SourceDocumentID,
 			sourceKind: row.SourceDocumentKind, sourcePostingSequence: row.SourcePostingSequence,
-			sourceOccurredOn: row.SourceOccurredOn,
+			sourceOccurredOn: row.SourceOccurredOn, locationID: row.LocationID,
+			transferredFromLotID: row.TransferredFromLotID,
 		})
 		if mapErr != nil {
 			return nil, corruptInventoryRow(listItemLotFactsOperation, index, mapErr)
@@ -182,15 +198,27 @@ func (s *Store) ListItemLotFacts(ctx context.Context, itemID domain.ItemID) ([]i
 	return lots, nil
 }
 
-func (s *Store) ListEligibleFEFOLots(ctx context.Context, itemID domain.ItemID, on domain.BusinessDate) ([]inventory.LotView, error) {
+// ListEligibleFEFOLots lists the lots FEFO may consume on a date, limited to
+// one location when given. Postings allocate FEFO across every location; a
+// caller that must consume from one place passes the chosen lots explicitly.
+func (s *Store) ListEligibleFEFOLots(
+	ctx context.Context,
+	itemID domain.ItemID,
+	on domain.BusinessDate,
+	location domain.Option[domain.StockLocationID],
+) ([]inventory.LotView, error) {
 	if itemID.IsZero() {
 		return nil, domain.Invalid("item_id", domain.ViolationRequired, "")
 	}
 	if on.IsZero() {
 		return nil, domain.Invalid("business_date", domain.ViolationRequired, "")
 	}
+	locationID, err := locationFilterValue(location)
+	if err != nil {
+		return nil, err
+	}
 	rows, err := s.queries.ListEligibleFEFOLots(ctx, sqlcgen.ListEligibleFEFOLotsParams{
-		BusinessDate: on.String(), ItemID: itemID.Int64(),
+		BusinessDate: on.String(), ItemID: itemID.Int64(), LocationID: locationID,
 	})
 	if err != nil {
 		return nil, classifyError(listEligibleFEFOLotsOperation, err)
@@ -204,7 +232,8 @@ func (s *Store) ListEligibleFEFOLots(ctx context.Context, itemID domain.ItemID,
 			lotCode: row.LotCode, originatedOn: row.OriginatedOn, expiresOn: row.ExpiresOn,
 			createdAtMS: row.CreatedAtMs, sourceDocumentID: row.SourceDocumentID,
 			sourceKind: row.SourceDocumentKind, sourcePostingSequence: row.SourcePostingSequence,
-			sourceOccurredOn: row.SourceOccurredOn,
+			sourceOccurredOn: row.SourceOccurredOn, locationID: row.LocationID,
+			transferredFromLotID: row.TransferredFromLotID,
 		})
 		if mapErr != nil {
 			return nil, corruptInventoryRow(listEligibleFEFOLotsOperation, index, mapErr)
@@ -268,6 +297,22 @@ func (s *Store) ListLineAllocations(ctx context.Context, lineID domain.StockDocu
 	return allocations, nil
 }
 
+// locationBalanceShare values the stock held at one location at the item's
+// weighted average. Lot remainders can never exceed the item balance.
+func locationBalanceShare(itemQuantity, itemValue, locationQuantity int64) (int64, int64, error) {
+	if locationQuantity < 0 || locationQuantity > itemQuantity {
+		return 0, 0, domain.Invalid("location_quantity_atomic", domain.ViolationOutOfRange, "LOC-003")
+	}
+	if locationQuantity == itemQuantity {
+		return itemQuantity, itemValue, nil
+	}
+	value, err := weightedAverageValue(itemValue, itemQuantity, locationQuantity)
+	if err != nil {
+		return 0, 0, err
+	}
+	return locationQuantity, value.Int64(), nil
+}
+
 type inventoryBalanceFields struct {
 	itemID, quantityAtomic, inventoryValueMicro, updatedAtMS int64
 	itemName, itemNormalizedName, baseUnitCode               string
@@ -328,9 +373,10 @@ func mapInventoryBalanceSnapshot(fields inventoryBalanceFields) (inventory.Balan
 type lotViewFields struct {
 	id, itemID, sourceLineID, initialQuantity, consumedQuantity int64
 	restoredQuantity, availableQuantity, createdAtMS            int64
-	sourceDocumentID, sourcePostingSequence                     int64
+	sourceDocumentID, sourcePostingSequence, locationID         int64
 	lotCode, expiresOn                                          sql.NullString
 	originatedOn, sourceKind, sourceOccurredOn                  string
+	transferredFromLotID                                        sql.NullInt64
 }
 
 func mapLotView(fields lotViewFields) (inventory.LotView, error) {
@@ -403,9 +449,22 @@ func mapLotView(fields lotViewFields) (inventory.LotView, error) {
 	if err != nil {
 		return inventory.LotView{}, err
 	}
+	locationID, err := domain.NewStockLocationID(fields.locationID)
+	if err != nil {
+		return inventory.LotView{}, err
+	}
+	transferredFrom := domain.None[domain.InventoryLotID]()
+	if fields.transferredFromLotID.Valid {
+		sourceLotID, err := domain.NewInventoryLotID(fields.transferredFromLotID.Int64)
+		if err != nil {
+			return inventory.LotView{}, err
+		}
+		transferredFrom = domain.Some(sourceLotID)
+	}
 	return inventory.NewLotView(inventory.LotViewParams{
 		Lot: lot, SourceDocumentID: sourceDocumentID,
 		SourceKind: sourceKind, SourceOccurredOn: sourceOccurredOn,
+		LocationID: locationID, TransferredFromLotID: transferredFrom,
 	})
 }
 
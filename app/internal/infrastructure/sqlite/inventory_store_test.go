@@ -140,7 +140,7 @@ func TestInventoryStoreEligibleLotsUseInclusiveFEFOOrdering(t *testing.T) {
 		t.Fatal(err)
 	}
 	lots, err := fixture.store.ListEligibleFEFOLots(
-		context.Background(), mustItemID(t, fixture.itemID), businessDate,
+		context.Background(), mustItemID(t, fixture.itemID), businessDate, domain.None[domain.StockLocationID](),
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -276,7 +276,7 @@ func TestInventoryStoreMapsNotFoundCancellationAndCorruptData(t *testing.T) {
 			return readErr
 		},
 		func() error {
-			_, readErr := fixture.store.ListEligibleFEFOLots(context.Background(), zeroItemID, validDate)
+			_, readErr := fixture.store.ListEligibleFEFOLots(context.Background(), zeroItemID, validDate, domain.None[domain.StockLocationID]())
 			return readErr
 		},
 		func() error {
@@ -0,0 +1,312 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite/sqlcgen"
+)
+
+type CreateLocationInput struct {
+	Name      domain.UniqueName
+	CreatedAt domain.UTCInstant
+}
+
+type UpdateLocationInput struct {
+	ID                domain.StockLocationID
+	Name              domain.UniqueName
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+type ArchiveLocationInput struct {
+	ID                domain.StockLocationID
+	ExpectedUpdatedAt domain.UTCInstant
+	ArchivedAt        domain.UTCInstant
+}
+
+type RestoreLocationInput struct {
+	ID                domain.StockLocationID
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+func (s *Store) GetLocation(ctx context.Context, id domain.StockLocationID) (inventory.Location, error) {
+	if id.IsZero() {
+		return inventory.Location{}, domain.Invalid("location_id", domain.ViolationNotPositive, "LOC-001")
+	}
+	var value inventory.Location
+	err := s.withReadQueries(ctx, "get location", func(queries *sqlcgen.Queries) error {
+		var err error
+		value, err = loadLocation(ctx, queries, id)
+		return err
+	})
+	return value, err
+}
+
+// ListLocations returns every location matching archive ordered by name.
+// Locations are a short reference list, so they are not paged.
+func (s *Store) ListLocations(ctx context.Context, archive domain.ArchiveFilter) ([]inventory.Location, error) {
+	archiveFilter, err := archiveFilterValue(archive)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.queries.ListStockLocations(ctx, archiveFilter)
+	if err != nil {
+		return nil, classifyError("list locations", err)
+	}
+	locations := make([]inventory.Location, 0, len(rows))
+	for _, row := range rows {
+		location, err := mapLocation(row)
+		if err != nil {
+			return nil, corruptDataError("map listed location", err)
+		}
+		locations = append(locations, location)
+	}
+	return locations, nil
+}
+
+func (s *Store) CreateLocation(ctx context.Context, input CreateLocationInput) (inventory.Location, error) {
+	placeholderID, _ := domain.NewStockLocationID(inventory.DefaultLocationID)
+	if _, err := inventory.NewLocation(inventory.LocationParams{
+		ID: placeholderID, Name: input.Name, CreatedAt: input.CreatedAt, UpdatedAt: input.CreatedAt,
+	}); err != nil {
+		return inventory.Location{}, err
+	}
+	var created inventory.Location
+	err := s.withWriteQueries(ctx, "create location", func(queries *sqlcgen.Queries) error {
+		idValue, err := queries.InsertStockLocation(ctx, sqlcgen.InsertStockLocationParams{
+			Name: input.Name.Display(), NormalizedName: input.Name.Key(),
+			CreatedAtMs: input.CreatedAt.UnixMilli(), UpdatedAtMs: input.CreatedAt.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		id, err := domain.NewStockLocationID(idValue)
+		if err != nil {
+			return corruptDataError("map created location id", err)
+		}
+		created, err = loadLocation(ctx, queries, id)
+		return err
+	})
+	return created, err
+}
+
+func (s *Store) UpdateLocation(ctx context.Context, input UpdateLocationInput) (inventory.Location, error) {
+	if input.ID.IsZero() {
+		return inventory.Location{}, domain.Invalid("location_id", domain.ViolationNotPositive, "LOC-001")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return inventory.Location{}, err
+	}
+	var updated inventory.Location
+	err := s.withWriteQueries(ctx, "update location", func(queries *sqlcgen.Queries) error {
+		current, err := loadLocation(ctx, queries, input.ID)
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: location version changed", domain.ErrStale)
+		}
+		if current.IsArchived() {
+			return fmt.Errorf("%w: archived location cannot be updated", domain.ErrConflict)
+		}
+		if _, err := inventory.NewLocation(inventory.LocationParams{
+			ID: input.ID, Name: input.Name, CreatedAt: current.CreatedAt(), UpdatedAt: input.UpdatedAt,
+		}); err != nil {
+			return err
+		}
+		rows, err := queries.UpdateStockLocation(ctx, sqlcgen.UpdateStockLocationParams{
+			Name: input.Name.Display(), NormalizedName: input.Name.Key(),
+			UpdatedAtMs: input.UpdatedAt.UnixMilli(), ID: input.ID.Int64(),
+			ExpectedUpdatedAtMs: input.ExpectedUpdatedAt.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return classifyLocationMutationMiss(ctx, queries, input.ID, input.ExpectedUpdatedAt, false)
+		}
+		updated, err = loadLocation(ctx, queries, input.ID)
+		return err
+	})
+	return updated, err
+}
+
+// ArchiveLocation refuses for the default location and while any lot at the
+// location still holds stock; that stock must be transferred out first.
+func (s *Store) ArchiveLocation(ctx context.Context, input ArchiveLocationInput) (inventory.Location, error) {
+	if input.ID.IsZero() {
+		return inventory.Location{}, domain.Invalid("location_id", domain.ViolationNotPositive, "LOC-001")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.ArchivedAt); err != nil {
+		return inventory.Location{}, err
+	}
+	var archived inventory.Location
+	err := s.withWriteQueries(ctx, "archive location", func(queries *sqlcgen.Queries) error {
+		current, err := loadLocation(ctx, queries, input.ID)
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: location version changed", domain.ErrStale)
+		}
+		if current.IsArchived() {
+			return fmt.Errorf("%w: location is already archived", domain.ErrConflict)
+		}
+		if current.IsDefault() {
+			return fmt.Errorf("%w: the default location cannot be archived", domain.ErrConflict)
+		}
+		stockedItems, err := queries.CountItemsStockedAtLocation(ctx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if stockedItems > 0 {
+			return fmt.Errorf("%w: location still holds stock of %d items", domain.ErrConflict, stockedItems)
+		}
+		rows, err := queries.ArchiveStockLocation(ctx, sqlcgen.ArchiveStockLocationParams{
+			ArchivedAtMs: input.ArchivedAt.UnixMilli(), UpdatedAtMs: input.ArchivedAt.UnixMilli(),
+			ID: input.ID.Int64(), ExpectedUpdatedAtMs: input.ExpectedUpdatedAt.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return classifyLocationMutationMiss(ctx, queries, input.ID, input.ExpectedUpdatedAt, false)
+		}
+		archived, err = loadLocation(ctx, queries, input.ID)
+		return err
+	})
+	return archived, err
+}
+
+func (s *Store) RestoreLocation(ctx context.Context, input RestoreLocationInput) (inventory.Location, error) {
+	if input.ID.IsZero() {
+		return inventory.Location{}, domain.Invalid("location_id", domain.ViolationNotPositive, "LOC-001")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return inventory.Location{}, err
+	}
+	var restored inventory.Location
+	err := s.withWriteQueries(ctx, "restore location", func(queries *sqlcgen.Queries) error {
+		current, err := loadLocation(ctx, queries, input.ID)
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: location version changed", domain.ErrStale)
+		}
+		if !current.IsArchived() {
+			return fmt.Errorf("%w: location is already active", domain.ErrConflict)
+		}
+		rows, err := queries.RestoreStockLocation(ctx, sqlcgen.RestoreStockLocationParams{
+			UpdatedAtMs: input.UpdatedAt.UnixMilli(), ID: input.ID.Int64(),
+			ExpectedUpdatedAtMs: input.ExpectedUpdatedAt.UnixMilli(),
+		})
+		if err != nil {
+			return err
+		}
+		if rows == 0 {
+			return classifyLocationMutationMiss(ctx, queries, input.ID, input.ExpectedUpdatedAt, true)
+		}
+		restored, err = loadLocation(ctx, queries, input.ID)
+		return err
+	})
+	return restored, err
+}
+
+func loadLocation(ctx context.Context, queries *sqlcgen.Queries, id domain.StockLocationID) (inventory.Location, error) {
+	row, err := queries.GetStockLocation(ctx, id.Int64())
+	if err != nil {
+		return inventory.Location{}, err
+	}
+	location, err := mapLocation(row)
+	if err != nil {
+		return inventory.Location{}, corruptDataError("map location", err)
+	}
+	return location, nil
+}
+
+// requireActiveLocation checks a posting's location before SQL so a missing
+// or archived location is reported as a bad reference rather than a trigger
+// conflict.
+func requireActiveLocation(ctx context.Context, tx databaseWriteTx, id int64) error {
+	var archivedAt sql.NullInt64
+	err := tx.QueryRowContext(ctx, `
+		SELECT archived_at_ms FROM stock_locations WHERE id = ?
+	`, id).Scan(&archivedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return wrapClassifiedError("load stock location", domain.ErrInvalidReference, err)
+	}
+	if err != nil {
+		return err
+	}
+	if archivedAt.Valid {
+		return fmt.Errorf("%w: stock location is archived", domain.ErrInvalidReference)
+	}
+	return nil
+}
+
+func classifyLocationMutationMiss(ctx context.Context, queries *sqlcgen.Queries, id domain.StockLocationID, expected domain.UTCInstant, expectedArchived bool) error {
+	row, err := queries.GetStockLocation(ctx, id.Int64())
+	if err != nil {
+		return classifyError("reload location after missed update", err)
+	}
+	if row.UpdatedAtMs != expected.UnixMilli() {
+		return fmt.Errorf("%w: location version changed", domain.ErrStale)
+	}
+	if row.ArchivedAtMs.Valid != expectedArchived {
+		return fmt.Errorf("%w: location archive state changed", domain.ErrConflict)
+	}
+	return fmt.Errorf("%w: location update matched no row", domain.ErrConflict)
+}
+
+func mapLocation(row sqlcgen.StockLocation) (inventory.Location, error) {
+	id, err := domain.NewStockLocationID(row.ID)
+	if err != nil {
+		return inventory.Location{}, domain.Corrupt(err)
+	}
+	name, err := domain.RestoreUniqueName(row.Name, row.NormalizedName)
+	if err != nil || name.Display() != row.Name {
+		if err == nil {
+			err = domain.Corrupt(domain.Invalid("name", domain.ViolationInvariant, "LOC-001"))
+		}
+		return inventory.Location{}, err
+	}
+	createdAt, err := domain.UTCInstantFromUnixMilli(row.CreatedAtMs)
+	if err != nil {
+		return inventory.Location{}, domain.Corrupt(err)
+	}
+	updatedAt, err := domain.UTCInstantFromUnixMilli(row.UpdatedAtMs)
+	if err != nil {
+		return inventory.Location{}, domain.Corrupt(err)
+	}
+	archivedAt, err := restoreOptionalInstant(row.ArchivedAtMs)
+	if err != nil {
+		return inventory.Location{}, domain.Corrupt(err)
+	}
+	location, err := inventory.NewLocation(inventory.LocationParams{
+		ID: id, Name: name, CreatedAt: createdAt, UpdatedAt: updatedAt, ArchivedAt: archivedAt,
+	})
+	if err != nil {
+		return inventory.Location{}, domain.Corrupt(err)
+	}
+	return location, nil
+}
+
+// locationFilterValue maps an optional location filter to the 0-means-all
+// query argument shared by the location-aware inventory queries.
+func locationFilterValue(value domain.Option[domain.StockLocationID]) (int64, error) {
+	id, ok := value.Get()
+	if !ok {
+		return 0, nil
+	}
+	if id.IsZero() {
+		return 0, domain.Invalid("location_id", domain.ViolationNotPositive, "LOC-001")
+	}
+	return id.Int64(), nil
+}
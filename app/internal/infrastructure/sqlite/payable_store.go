@@ -0,0 +1,702 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+)
+
+// SchedulePurchasePayableInput splits a posted purchase into installments
+// numbered from 1 in due order.
+type SchedulePurchasePayableInput struct {
+	PurchaseDocumentID domain.StockDocumentID
+	Installments       []purchasing.Installment
+	CreatedAt          domain.UTCInstant
+}
+
+type RecordSupplierPaymentInput struct {
+	IdempotencyKey     domain.IdempotencyKey
+	PurchaseDocumentID domain.StockDocumentID
+	Method             domain.PaymentMethod
+	Amount             domain.MinorAmount
+	PaidOn             domain.BusinessDate
+	Notes              domain.Option[domain.NonEmptyText]
+	RecordedAt         domain.UTCInstant
+}
+
+// ReverseSupplierPaymentInput reverses one supplier payment in full on PaidOn.
+type ReverseSupplierPaymentInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	PaymentID      domain.SupplierPaymentID
+	PaidOn         domain.BusinessDate
+	Notes          domain.Option[domain.NonEmptyText]
+	RecordedAt     domain.UTCInstant
+}
+
+func (s *Store) GetPurchasePayable(ctx context.Context, purchaseID domain.StockDocumentID) (purchasing.Payable, error) {
+	if purchaseID.IsZero() {
+		return purchasing.Payable{}, domain.Invalid("purchase_document_id", domain.ViolationRequired, "PAB-001")
+	}
+	var payable purchasing.Payable
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadPurchasePayable(ctx, tx, purchaseID.Int64())
+		if err != nil {
+			return err
+		}
+		payable = value
+		return nil
+	})
+	if err != nil {
+		return purchasing.Payable{}, classifyError("get purchase payable", err)
+	}
+	return payable, nil
+}
+
+// ListOpenPurchasePayables returns the payables that are not exactly paid,
+// optionally for one supplier, by purchase business date and posting order.
+func (s *Store) ListOpenPurchasePayables(
+	ctx context.Context,
+	supplierID domain.Option[domain.CounterpartyID],
+) ([]purchasing.Payable, error) {
+	var payables []purchasing.Payable
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		filter := purchasePayableFilter{openOnly: true}
+		if supplier, ok := supplierID.Get(); ok {
+			filter.supplierID = supplier.Int64()
+		}
+		loaded, err := queryPurchasePayables(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		payables = loaded
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list open purchase payables", err)
+	}
+	return payables, nil
+}
+
+// SchedulePurchasePayable records the installments of a supplier purchase
+// once. The installments must add up to the purchase's commercial total.
+// Scheduling the same installments again returns the existing payable.
+func (s *Store) SchedulePurchasePayable(
+	ctx context.Context,
+	input SchedulePurchasePayableInput,
+) (purchasing.Payable, error) {
+	if input.PurchaseDocumentID.IsZero() {
+		return purchasing.Payable{}, domain.Invalid("purchase_document_id", domain.ViolationRequired, "PAB-001")
+	}
+	if input.CreatedAt.IsZero() {
+		return purchasing.Payable{}, domain.Invalid("created_at", domain.ViolationRequired, "")
+	}
+	var scheduled purchasing.Payable
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		existing, err := loadPurchasePayable(ctx, tx, input.PurchaseDocumentID.Int64())
+		if err == nil {
+			if !sameInstallments(existing.Installments(), input.Installments) {
+				return fmt.Errorf("%w: purchase payable is already scheduled", domain.ErrConflict)
+			}
+			scheduled = existing
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		var row purchasePayableRow
+		err = tx.QueryRowContext(ctx, `
+			SELECT
+				purchase.id,
+				purchase.counterparty_id,
+				purchase.occurred_on,
+				(
+					SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+					FROM stock_document_lines line
+					WHERE line.document_id = purchase.id
+				),
+				EXISTS (
+					SELECT 1 FROM stock_documents reversal
+					WHERE reversal.reverses_document_id = purchase.id
+				)
+			FROM stock_documents purchase
+			WHERE purchase.id = ? AND purchase.kind = 'PURCHASE'
+		`, input.PurchaseDocumentID.Int64()).Scan(
+			&row.id, &row.supplierID, &row.occurredOn, &row.totalMinor, &row.reversed,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: payable must belong to a purchase", domain.ErrInvalidReference)
+		}
+		if err != nil {
+			return err
+		}
+		if row.reversed {
+			return fmt.Errorf("%w: a reversed purchase has no payable", domain.ErrConflict)
+		}
+		if !row.supplierID.Valid {
+			return domain.Invalid("supplier_id", domain.ViolationRequired, "PAB-001")
+		}
+		row.createdAtMS = input.CreatedAt.UnixMilli()
+		if _, err := mapPurchasePayable(row, input.Installments, nil); err != nil {
+			return err
+		}
+
+		for _, installment := range input.Installments {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO purchase_payable_installments (
+					purchase_document_id, installment_number, due_on, amount_minor
+				) VALUES (?, ?, ?, ?)
+			`,
+				input.PurchaseDocumentID.Int64(),
+				installment.Number,
+				installment.DueOn.String(),
+				installment.Amount.Int64(),
+			); err != nil {
+				return err
+			}
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO purchase_payables (
+				purchase_document_id, installment_count, total_minor, created_at_ms
+			) VALUES (?, ?, ?, ?)
+		`,
+			input.PurchaseDocumentID.Int64(),
+			len(input.Installments),
+			row.totalMinor,
+			row.createdAtMS,
+		); err != nil {
+			return err
+		}
+		scheduled, err = loadPurchasePayable(ctx, tx, input.PurchaseDocumentID.Int64())
+		return err
+	})
+	if err != nil {
+		return purchasing.Payable{}, classifyError("schedule purchase payable", err)
+	}
+	return scheduled, nil
+}
+
+// RecordSupplierPayment records one payment toward a scheduled payable. The
+// paid total cannot exceed what the purchase is owed. Retrying with the same
+// idempotency key returns the first payment.
+func (s *Store) RecordSupplierPayment(
+	ctx context.Context,
+	input RecordSupplierPaymentInput,
+) (purchasing.SupplierPayment, error) {
+	if err := validateRecordSupplierPaymentInput(input); err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	var recorded purchasing.SupplierPayment
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		replayed, err := replaySupplierPayment(ctx, tx, input.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if payment, ok := replayed.Get(); ok {
+			if payment.PurchaseDocumentID() != input.PurchaseDocumentID || payment.IsReversal() {
+				return fmt.Errorf("%w: idempotency key belongs to another payment", domain.ErrConflict)
+			}
+			recorded = payment
+			return nil
+		}
+
+		payable, err := loadPurchasePayable(ctx, tx, input.PurchaseDocumentID.Int64())
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: payment must belong to a scheduled purchase payable", domain.ErrInvalidReference)
+		}
+		if err != nil {
+			return err
+		}
+		if payable.Reversed() {
+			return fmt.Errorf("%w: a reversed purchase accepts no payments", domain.ErrConflict)
+		}
+		if input.PaidOn.Before(payable.OccurredOn()) {
+			return domain.Invalid("paid_on", domain.ViolationOutOfRange, "PAB-002")
+		}
+		if input.Amount.Int64() > payable.Outstanding() {
+			return domain.Invalid("amount_minor", domain.ViolationOutOfRange, "PAB-003")
+		}
+
+		var id int64
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO supplier_payments (
+				idempotency_key, purchase_document_id, method, amount_minor, paid_on,
+				notes, recorded_at_ms
+			) VALUES (?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			input.IdempotencyKey.String(),
+			input.PurchaseDocumentID.Int64(),
+			input.Method.String(),
+			input.Amount.Int64(),
+			input.PaidOn.String(),
+			nullableText(input.Notes),
+			input.RecordedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		recorded, err = loadSupplierPayment(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return purchasing.SupplierPayment{}, classifyError("record supplier payment", err)
+	}
+	return recorded, nil
+}
+
+// ReverseSupplierPayment appends the reversal of an unreversed supplier
+// payment. Retrying with the same idempotency key returns the first reversal.
+func (s *Store) ReverseSupplierPayment(
+	ctx context.Context,
+	input ReverseSupplierPaymentInput,
+) (purchasing.SupplierPayment, error) {
+	if input.IdempotencyKey.String() == "" {
+		return purchasing.SupplierPayment{}, domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.PaymentID.IsZero() {
+		return purchasing.SupplierPayment{}, domain.Invalid("supplier_payment_id", domain.ViolationRequired, "PAB-002")
+	}
+	if input.PaidOn.IsZero() {
+		return purchasing.SupplierPayment{}, domain.Invalid("paid_on", domain.ViolationRequired, "PAB-002")
+	}
+	if input.RecordedAt.IsZero() {
+		return purchasing.SupplierPayment{}, domain.Invalid("recorded_at", domain.ViolationRequired, "")
+	}
+	var reversal purchasing.SupplierPayment
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		replayed, err := replaySupplierPayment(ctx, tx, input.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if payment, ok := replayed.Get(); ok {
+			if target, ok := payment.ReversesPaymentID().Get(); !ok || target != input.PaymentID {
+				return fmt.Errorf("%w: idempotency key belongs to another payment", domain.ErrConflict)
+			}
+			reversal = payment
+			return nil
+		}
+
+		target, err := loadSupplierPayment(ctx, tx, input.PaymentID.Int64())
+		if err != nil {
+			return err
+		}
+		if target.IsReversal() {
+			return domain.Invalid("supplier_payment_id", domain.ViolationInvariant, "PAB-002")
+		}
+		if input.PaidOn.Before(target.PaidOn()) {
+			return domain.Invalid("paid_on", domain.ViolationOutOfRange, "PAB-002")
+		}
+		var reversed bool
+		if err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM supplier_payments WHERE reverses_payment_id = ?)
+		`, input.PaymentID.Int64()).Scan(&reversed); err != nil {
+			return err
+		}
+		if reversed {
+			return fmt.Errorf("%w: supplier payment is already reversed", domain.ErrConflict)
+		}
+
+		var id int64
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO supplier_payments (
+				idempotency_key, purchase_document_id, method, amount_minor, paid_on,
+				notes, reverses_payment_id, recorded_at_ms
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			input.IdempotencyKey.String(),
+			target.PurchaseDocumentID().Int64(),
+			target.Method().String(),
+			target.Amount().Int64(),
+			input.PaidOn.String(),
+			nullableText(input.Notes),
+			input.PaymentID.Int64(),
+			input.RecordedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		reversal, err = loadSupplierPayment(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return purchasing.SupplierPayment{}, classifyError("reverse supplier payment", err)
+	}
+	return reversal, nil
+}
+
+func validateRecordSupplierPaymentInput(input RecordSupplierPaymentInput) error {
+	if input.IdempotencyKey.String() == "" {
+		return domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.PurchaseDocumentID.IsZero() {
+		return domain.Invalid("purchase_document_id", domain.ViolationRequired, "PAB-002")
+	}
+	if _, err := domain.ParsePaymentMethod(input.Method.String()); err != nil || !input.Method.Settles() {
+		return domain.Invalid("method", domain.ViolationInvalidEnum, "PAB-002")
+	}
+	if input.Amount.Int64() <= 0 {
+		return domain.Invalid("amount_minor", domain.ViolationNotPositive, "PAB-002")
+	}
+	if input.PaidOn.IsZero() {
+		return domain.Invalid("paid_on", domain.ViolationRequired, "PAB-002")
+	}
+	if input.RecordedAt.IsZero() {
+		return domain.Invalid("recorded_at", domain.ViolationRequired, "")
+	}
+	return nil
+}
+
+func sameInstallments(left, right []purchasing.Installment) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	for index := range left {
+		if left[index].Number != right[index].Number ||
+			!left[index].DueOn.Equal(right[index].DueOn) ||
+			left[index].Amount != right[index].Amount {
+			return false
+		}
+	}
+	return true
+}
+
+func replaySupplierPayment(
+	ctx context.Context,
+	tx databaseWriteTx,
+	key domain.IdempotencyKey,
+) (domain.Option[purchasing.SupplierPayment], error) {
+	var id int64
+	err := tx.QueryRowContext(ctx, `
+		SELECT id FROM supplier_payments WHERE idempotency_key = ?
+	`, key.String()).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.None[purchasing.SupplierPayment](), nil
+	}
+	if err != nil {
+		return domain.None[purchasing.SupplierPayment](), err
+	}
+	payment, err := loadSupplierPayment(ctx, tx, id)
+	if err != nil {
+		return domain.None[purchasing.SupplierPayment](), err
+	}
+	return domain.Some(payment), nil
+}
+
+// purchasePayableFilter selects one purchase when purchaseID is set, one
+// supplier's payables when supplierID is set, and only payables not exactly
+// paid with openOnly.
+type purchasePayableFilter struct {
+	purchaseID int64
+	supplierID int64
+	openOnly   bool
+}
+
+func loadPurchasePayable(ctx context.Context, tx databaseWriteTx, purchaseID int64) (purchasing.Payable, error) {
+	payables, err := queryPurchasePayables(ctx, tx, purchasePayableFilter{purchaseID: purchaseID})
+	if err != nil {
+		return purchasing.Payable{}, err
+	}
+	if len(payables) == 0 {
+		return purchasing.Payable{}, sql.ErrNoRows
+	}
+	return payables[0], nil
+}
+
+func queryPurchasePayables(
+	ctx context.Context,
+	tx databaseWriteTx,
+	filter purchasePayableFilter,
+) ([]purchasing.Payable, error) {
+	rows, err := tx.QueryContext(ctx, `
+		WITH owed AS (
+			SELECT
+				purchase.id,
+				purchase.counterparty_id,
+				purchase.occurred_on,
+				purchase.posting_sequence,
+				payable.total_minor,
+				payable.created_at_ms,
+				(
+					SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+					FROM stock_documents supplier_return
+					JOIN stock_document_lines line ON line.document_id = supplier_return.id
+					WHERE supplier_return.returns_document_id = purchase.id
+					  AND NOT EXISTS (
+						  SELECT 1 FROM stock_documents reversal
+						  WHERE reversal.reverses_document_id = supplier_return.id
+					  )
+				) AS credited_minor,
+				EXISTS (
+					SELECT 1 FROM stock_documents reversal
+					WHERE reversal.reverses_document_id = purchase.id
+				) AS reversed,
+				(
+					SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+						THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+					FROM supplier_payments payment
+					WHERE payment.purchase_document_id = purchase.id
+				) AS paid_minor
+			FROM purchase_payables payable
+			JOIN stock_documents purchase ON purchase.id = payable.purchase_document_id
+			WHERE (? = 0 OR purchase.id = ?)
+			  AND (? = 0 OR purchase.counterparty_id = ?)
+		)
+		SELECT id, counterparty_id, occurred_on, total_minor, credited_minor, reversed, created_at_ms
+		FROM owed
+		WHERE ? = 0
+		   OR (CASE WHEN reversed THEN 0 ELSE total_minor - credited_minor END) <> paid_minor
+		ORDER BY occurred_on, posting_sequence
+	`, filter.purchaseID, filter.purchaseID, filter.supplierID, filter.supplierID, filter.openOnly)
+	if err != nil {
+		return nil, err
+	}
+	var loaded []purchasePayableRow
+	for rows.Next() {
+		var row purchasePayableRow
+		if err := rows.Scan(
+			&row.id, &row.supplierID, &row.occurredOn, &row.totalMinor,
+			&row.creditedMinor, &row.reversed, &row.createdAtMS,
+		); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		loaded = append(loaded, row)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	payables := make([]purchasing.Payable, 0, len(loaded))
+	for _, row := range loaded {
+		installments, err := loadPayableInstallments(ctx, tx, row.id)
+		if err != nil {
+			return nil, err
+		}
+		payments, err := loadSupplierPayments(ctx, tx, row.id)
+		if err != nil {
+			return nil, err
+		}
+		payable, err := mapPurchasePayable(row, installments, payments)
+		if err != nil {
+			return nil, corruptDataError("map purchase payable", err)
+		}
+		payables = append(payables, payable)
+	}
+	return payables, nil
+}
+
+type purchasePayableRow struct {
+	id, totalMinor, creditedMinor, createdAtMS int64
+	supplierID                                 sql.NullInt64
+	occurredOn                                 string
+	reversed                                   bool
+}
+
+func loadPayableInstallments(ctx context.Context, tx databaseWriteTx, purchaseID int64) ([]purchasing.Installment, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT installment_number, due_on, amount_minor
+		FROM purchase_payable_installments
+		WHERE purchase_document_id = ?
+		ORDER BY installment_number
+	`, purchaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var installments []purchasing.Installment
+	for rows.Next() {
+		var (
+			number      int
+			dueOn       string
+			amountMinor int64
+		)
+		if err := rows.Scan(&number, &dueOn, &amountMinor); err != nil {
+			return nil, err
+		}
+		due, err := domain.ParseBusinessDate(dueOn)
+		if err != nil {
+			return nil, corruptDataError("map payable installment", err)
+		}
+		amount, err := domain.NewMinorAmount(amountMinor)
+		if err != nil {
+			return nil, corruptDataError("map payable installment", err)
+		}
+		installments = append(installments, purchasing.Installment{Number: number, DueOn: due, Amount: amount})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return installments, nil
+}
+
+func loadSupplierPayment(ctx context.Context, tx databaseWriteTx, id int64) (purchasing.SupplierPayment, error) {
+	var row supplierPaymentRow
+	if err := tx.QueryRowContext(ctx, `
+		SELECT id, idempotency_key, purchase_document_id, method, amount_minor, paid_on,
+		       notes, reverses_payment_id, recorded_at_ms
+		FROM supplier_payments
+		WHERE id = ?
+	`, id).Scan(
+		&row.id,
+		&row.idempotencyKey,
+		&row.purchaseDocumentID,
+		&row.method,
+		&row.amountMinor,
+		&row.paidOn,
+		&row.notes,
+		&row.reversesPaymentID,
+		&row.recordedAtMS,
+	); err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	payment, err := mapSupplierPayment(row)
+	if err != nil {
+		return purchasing.SupplierPayment{}, corruptDataError("map supplier payment", err)
+	}
+	return payment, nil
+}
+
+// loadSupplierPayments returns a purchase's payments in recording order, so
+// every reversal follows the payment it reverses.
+func loadSupplierPayments(ctx context.Context, tx databaseWriteTx, purchaseID int64) ([]purchasing.SupplierPayment, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, idempotency_key, purchase_document_id, method, amount_minor, paid_on,
+		       notes, reverses_payment_id, recorded_at_ms
+		FROM supplier_payments
+		WHERE purchase_document_id = ?
+		ORDER BY id
+	`, purchaseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []purchasing.SupplierPayment
+	for rows.Next() {
+		var row supplierPaymentRow
+		if err := rows.Scan(
+			&row.id,
+			&row.idempotencyKey,
+			&row.purchaseDocumentID,
+			&row.method,
+			&row.amountMinor,
+			&row.paidOn,
+			&row.notes,
+			&row.reversesPaymentID,
+			&row.recordedAtMS,
+		); err != nil {
+			return nil, err
+		}
+		payment, err := mapSupplierPayment(row)
+		if err != nil {
+			return nil, corruptDataError("map supplier payment", err)
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+type supplierPaymentRow struct {
+	id, purchaseDocumentID, amountMinor, recordedAtMS int64
+	idempotencyKey, method, paidOn                    string
+	notes                                             sql.NullString
+	reversesPaymentID                                 sql.NullInt64
+}
+
+func mapSupplierPayment(row supplierPaymentRow) (purchasing.SupplierPayment, error) {
+	id, err := domain.NewSupplierPaymentID(row.id)
+	if err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	key, err := domain.NewIdempotencyKey(row.idempotencyKey)
+	if err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	purchaseID, err := domain.NewStockDocumentID(row.purchaseDocumentID)
+	if err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	method, err := domain.ParsePaymentMethod(row.method)
+	if err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	amount, err := domain.NewMinorAmount(row.amountMinor)
+	if err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	paidOn, err := domain.ParseBusinessDate(row.paidOn)
+	if err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	notes, err := optionalNonEmptyText(row.notes)
+	if err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	reverses := domain.None[domain.SupplierPaymentID]()
+	if row.reversesPaymentID.Valid {
+		value, err := domain.NewSupplierPaymentID(row.reversesPaymentID.Int64)
+		if err != nil {
+			return purchasing.SupplierPayment{}, err
+		}
+		reverses = domain.Some(value)
+	}
+	recordedAt, err := domain.UTCInstantFromUnixMilli(row.recordedAtMS)
+	if err != nil {
+		return purchasing.SupplierPayment{}, err
+	}
+	return purchasing.NewSupplierPayment(purchasing.SupplierPaymentParams{
+		ID: id, IdempotencyKey: key, PurchaseDocumentID: purchaseID, Method: method, Amount: amount,
+		PaidOn: paidOn, Notes: notes, ReversesPaymentID: reverses, RecordedAt: recordedAt,
+	})
+}
+
+func mapPurchasePayable(
+	row purchasePayableRow,
+	installments []purchasing.Installment,
+	payments []purchasing.SupplierPayment,
+) (purchasing.Payable, error) {
+	purchaseID, err := domain.NewStockDocumentID(row.id)
+	if err != nil {
+		return purchasing.Payable{}, err
+	}
+	var supplierID domain.CounterpartyID
+	if row.supplierID.Valid {
+		supplierID, err = domain.NewCounterpartyID(row.supplierID.Int64)
+		if err != nil {
+			return purchasing.Payable{}, err
+		}
+	}
+	occurredOn, err := domain.ParseBusinessDate(row.occurredOn)
+	if err != nil {
+		return purchasing.Payable{}, err
+	}
+	total, err := domain.NewMinorAmount(row.totalMinor)
+	if err != nil {
+		return purchasing.Payable{}, err
+	}
+	credited, err := domain.NewMinorAmount(row.creditedMinor)
+	if err != nil {
+		return purchasing.Payable{}, err
+	}
+	createdAt, err := domain.UTCInstantFromUnixMilli(row.createdAtMS)
+	if err != nil {
+		return purchasing.Payable{}, err
+	}
+	return purchasing.NewPayable(purchasing.PayableParams{
+		PurchaseDocumentID: purchaseID, SupplierID: supplierID, OccurredOn: occurredOn,
+		PurchaseTotal: total, Credited: credited, Reversed: row.reversed,
+		Installments: installments, Payments: payments, CreatedAt: createdAt,
+	})
+}
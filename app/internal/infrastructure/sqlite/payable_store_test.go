@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+)
+
+func TestPayableStoreSchedulesInstallmentsAndPaysSuppliers(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "payables.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	itemID := createReportingItem(t, store, "Payable flour", true, domain.None[domain.AtomicQuantity]())
+	supplierID := createReportingSupplier(t, store, "Mill")
+	purchase := postReportingPurchase(t, store, itemID, "payable-purchase", "2026-07-01", 1_000,
+		domain.Some(supplierID), domain.None[domain.DocumentReason](), 100, 9_000)
+	anonymous := postReportingPurchase(t, store, itemID, "payable-anonymous", "2026-07-01", 2_000,
+		domain.None[domain.CounterpartyID](), domain.None[domain.DocumentReason](), 100, 1_000)
+	installment := func(number int, dueOn string, amount int64) purchasing.Installment {
+		return purchasing.Installment{
+			Number: number, DueOn: mustPurchaseDate(t, dueOn), Amount: mustPurchaseMinorAmount(t, amount),
+		}
+	}
+	schedule := func(purchaseID domain.StockDocumentID, installments ...purchasing.Installment) SchedulePurchasePayableInput {
+		return SchedulePurchasePayableInput{
+			PurchaseDocumentID: purchaseID,
+			Installments:       installments,
+			CreatedAt:          mustCatalogInstant(t, 5_000),
+		}
+	}
+
+	if _, err := store.SchedulePurchasePayable(ctx, schedule(anonymous.ID(), installment(1, "2026-07-15", 1_000))); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("anonymous purchase payable error = %v, want validation", err)
+	}
+	if _, err := store.SchedulePurchasePayable(ctx, schedule(purchase.ID(), installment(1, "2026-07-15", 8_999))); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("short payable error = %v, want validation", err)
+	}
+	input := schedule(purchase.ID(), installment(1, "2026-07-15", 3_000), installment(2, "2026-08-15", 6_000))
+	payable, err := store.SchedulePurchasePayable(ctx, input)
+	if err != nil {
+		t.Fatalf("schedule purchase payable: %v", err)
+	}
+	if payable.SupplierID() != supplierID || len(payable.Installments()) != 2 || payable.Outstanding() != 9_000 {
+		t.Fatalf("scheduled payable = %#v", payable)
+	}
+	if replayed, err := store.SchedulePurchasePayable(ctx, input); err != nil || len(replayed.Installments()) != 2 {
+		t.Fatalf("replayed payable = %#v, %v", replayed, err)
+	}
+	if _, err := store.SchedulePurchasePayable(ctx, schedule(purchase.ID(), installment(1, "2026-07-15", 9_000))); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("rescheduled payable error = %v, want conflict", err)
+	}
+
+	if _, err := store.PostSupplierReturn(ctx, supplierReturnInputFixture(
+		t, purchase.ID(), purchase.Lines()[0].ID(), "payable-return", 10, domain.Some(mustPurchaseMinorAmount(t, 300)),
+	)); err != nil {
+		t.Fatalf("post supplier return: %v", err)
+	}
+	payment := func(key string, purchaseID domain.StockDocumentID, method domain.PaymentMethod, amount int64) RecordSupplierPaymentInput {
+		return RecordSupplierPaymentInput{
+			IdempotencyKey:     mustPurchaseIdempotencyKey(t, key),
+			PurchaseDocumentID: purchaseID,
+			Method:             method,
+			Amount:             mustPurchaseMinorAmount(t, amount),
+			PaidOn:             mustPurchaseDate(t, "2026-07-20"),
+			RecordedAt:         mustCatalogInstant(t, 6_000),
+		}
+	}
+	if _, err := store.RecordSupplierPayment(ctx, payment("supplier-payment-0", anonymous.ID(), domain.PaymentCash, 100)); !errors.Is(err, domain.ErrInvalidReference) {
+		t.Fatalf("unscheduled payment error = %v, want invalid reference", err)
+	}
+	if _, err := store.RecordSupplierPayment(ctx, payment("supplier-payment-0", purchase.ID(), domain.PaymentOnAccount, 100)); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("on-account supplier payment error = %v, want validation", err)
+	}
+	pix, err := store.RecordSupplierPayment(ctx, payment("supplier-payment-1", purchase.ID(), domain.PaymentPix, 5_000))
+	if err != nil {
+		t.Fatalf("record supplier payment: %v", err)
+	}
+	if replayed, err := store.RecordSupplierPayment(ctx, payment("supplier-payment-1", purchase.ID(), domain.PaymentPix, 5_000)); err != nil || replayed.ID() != pix.ID() {
+		t.Fatalf("replayed supplier payment = %#v, %v", replayed, err)
+	}
+	if _, err := store.RecordSupplierPayment(ctx, payment("supplier-payment-2", purchase.ID(), domain.PaymentCash, 3_701)); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("overpayment error = %v, want validation", err)
+	}
+
+	reverse := ReverseSupplierPaymentInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "supplier-reversal-1"),
+		PaymentID:      pix.ID(),
+		PaidOn:         mustPurchaseDate(t, "2026-07-21"),
+		RecordedAt:     mustCatalogInstant(t, 7_000),
+	}
+	reversal, err := store.ReverseSupplierPayment(ctx, reverse)
+	if err != nil {
+		t.Fatalf("reverse supplier payment: %v", err)
+	}
+	if target, ok := reversal.ReversesPaymentID().Get(); !ok || target != pix.ID() || reversal.Amount().Int64() != 5_000 {
+		t.Fatalf("supplier payment reversal = %#v", reversal)
+	}
+	reverse.IdempotencyKey = mustPurchaseIdempotencyKey(t, "supplier-reversal-2")
+	if _, err := store.ReverseSupplierPayment(ctx, reverse); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second reversal error = %v, want conflict", err)
+	}
+
+	payable, err = store.GetPurchasePayable(ctx, purchase.ID())
+	if err != nil {
+		t.Fatalf("get purchase payable: %v", err)
+	}
+	open := payable.OpenInstallments()
+	if payable.Owed() != 8_700 || payable.Paid() != 0 || len(payable.Payments()) != 2 ||
+		len(open) != 2 || open[0].OpenMinor != 2_700 {
+		t.Fatalf("purchase payable = %#v", payable)
+	}
+	if _, err := store.GetPurchasePayable(ctx, anonymous.ID()); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("unscheduled payable error = %v, want not found", err)
+	}
+	payables, err := store.ListOpenPurchasePayables(ctx, domain.Some(supplierID))
+	if err != nil || len(payables) != 1 || payables[0].PurchaseDocumentID() != purchase.ID() {
+		t.Fatalf("open supplier payables = %#v, %v", payables, err)
+	}
+	if _, err := store.RecordSupplierPayment(ctx, payment("supplier-payment-3", purchase.ID(), domain.PaymentCash, 8_700)); err != nil {
+		t.Fatalf("record full supplier payment: %v", err)
+	}
+	if payables, err := store.ListOpenPurchasePayables(ctx, domain.None[domain.CounterpartyID]()); err != nil || len(payables) != 0 {
+		t.Fatalf("open payables after payment = %#v, %v", payables, err)
+	}
+	report, err := store.GetPurchaseReportData(ctx, ReportingPeriodFilter{
+		FromOccurredOn: "2026-07-01", ToOccurredOn: "2026-07-31", Granularity: "MONTH",
+	}, 10)
+	if err != nil {
+		t.Fatalf("purchase report data: %v", err)
+	}
+	if report.Payables != (PurchasePayableTotals{PayableCount: 1, OwedMinor: 8_700, PaidMinor: 8_700}) {
+		t.Fatalf("report payables = %#v", report.Payables)
+	}
+}
@@ -0,0 +1,532 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+type RecordSalePaymentInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	SaleDocumentID domain.StockDocumentID
+	Method         domain.PaymentMethod
+	Amount         domain.MinorAmount
+	ReceivedOn     domain.BusinessDate
+	Notes          domain.Option[domain.NonEmptyText]
+	RecordedAt     domain.UTCInstant
+}
+
+// ReverseSalePaymentInput reverses one payment in full on ReceivedOn.
+type ReverseSalePaymentInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	PaymentID      domain.SalePaymentID
+	ReceivedOn     domain.BusinessDate
+	Notes          domain.Option[domain.NonEmptyText]
+	RecordedAt     domain.UTCInstant
+}
+
+func (s *Store) GetSaleBalance(ctx context.Context, saleID domain.StockDocumentID) (sales.SaleBalance, error) {
+	if saleID.IsZero() {
+		return sales.SaleBalance{}, domain.Invalid("sale_document_id", domain.ViolationRequired, "PAY-001")
+	}
+	var balance sales.SaleBalance
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadSaleBalance(ctx, tx, saleID.Int64())
+		if err != nil {
+			return err
+		}
+		balance = value
+		return nil
+	})
+	if err != nil {
+		return sales.SaleBalance{}, classifyError("get sale balance", err)
+	}
+	return balance, nil
+}
+
+// ListOpenSaleBalances returns the balances of the sales that are not exactly
+// settled, optionally for one customer, by business date and posting order.
+func (s *Store) ListOpenSaleBalances(
+	ctx context.Context,
+	customerID domain.Option[domain.CounterpartyID],
+) ([]sales.SaleBalance, error) {
+	var balances []sales.SaleBalance
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		filter := saleBalanceFilter{openOnly: true}
+		if customer, ok := customerID.Get(); ok {
+			filter.customerID = customer.Int64()
+		}
+		loaded, err := querySaleBalances(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		balances = loaded
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list open sale balances", err)
+	}
+	return balances, nil
+}
+
+// RecordSalePayment records one payment of a sale. Settling methods cannot
+// take the settled total past the receivable and on-account entries cannot
+// exceed the outstanding balance. Retrying with the same idempotency key
+// returns the first payment.
+func (s *Store) RecordSalePayment(ctx context.Context, input RecordSalePaymentInput) (sales.Payment, error) {
+	if err := validateRecordSalePaymentInput(input); err != nil {
+		return sales.Payment{}, err
+	}
+	var recorded sales.Payment
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		replayed, err := replaySalePayment(ctx, tx, input.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if payment, ok := replayed.Get(); ok {
+			if payment.SaleDocumentID() != input.SaleDocumentID || payment.IsReversal() {
+				return fmt.Errorf("%w: idempotency key belongs to another payment", domain.ErrConflict)
+			}
+			recorded = payment
+			return nil
+		}
+
+		balance, err := loadSaleBalance(ctx, tx, input.SaleDocumentID.Int64())
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: payment must belong to a sale", domain.ErrInvalidReference)
+		}
+		if err != nil {
+			return err
+		}
+		if balance.Reversed() {
+			return fmt.Errorf("%w: a reversed sale accepts no payments", domain.ErrConflict)
+		}
+		if input.ReceivedOn.Before(balance.OccurredOn()) {
+			return domain.Invalid("received_on", domain.ViolationOutOfRange, "PAY-001")
+		}
+		if input.Method.Settles() {
+			if input.Amount.Int64() > balance.Outstanding() {
+				return domain.Invalid("amount_minor", domain.ViolationOutOfRange, "PAY-003")
+			}
+		} else {
+			if balance.CustomerID().IsNone() {
+				return domain.Invalid("method", domain.ViolationInvariant, "PAY-003")
+			}
+			if balance.OnAccount()+input.Amount.Int64() > balance.Outstanding() {
+				return domain.Invalid("amount_minor", domain.ViolationOutOfRange, "PAY-003")
+			}
+		}
+
+		var id int64
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO sale_payments (
+				idempotency_key, sale_document_id, method, amount_minor, received_on,
+				notes, recorded_at_ms
+			) VALUES (?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			input.IdempotencyKey.String(),
+			input.SaleDocumentID.Int64(),
+			input.Method.String(),
+			input.Amount.Int64(),
+			input.ReceivedOn.String(),
+			nullableText(input.Notes),
+			input.RecordedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		recorded, err = loadSalePayment(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return sales.Payment{}, classifyError("record sale payment", err)
+	}
+	return recorded, nil
+}
+
+// ReverseSalePayment appends the reversal of an unreversed payment. Retrying
+// with the same idempotency key returns the first reversal.
+func (s *Store) ReverseSalePayment(ctx context.Context, input ReverseSalePaymentInput) (sales.Payment, error) {
+	if input.IdempotencyKey.String() == "" {
+		return sales.Payment{}, domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.PaymentID.IsZero() {
+		return sales.Payment{}, domain.Invalid("sale_payment_id", domain.ViolationRequired, "PAY-002")
+	}
+	if input.ReceivedOn.IsZero() {
+		return sales.Payment{}, domain.Invalid("received_on", domain.ViolationRequired, "PAY-001")
+	}
+	if input.RecordedAt.IsZero() {
+		return sales.Payment{}, domain.Invalid("recorded_at", domain.ViolationRequired, "")
+	}
+	var reversal sales.Payment
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		replayed, err := replaySalePayment(ctx, tx, input.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if payment, ok := replayed.Get(); ok {
+			if target, ok := payment.ReversesPaymentID().Get(); !ok || target != input.PaymentID {
+				return fmt.Errorf("%w: idempotency key belongs to another payment", domain.ErrConflict)
+			}
+			reversal = payment
+			return nil
+		}
+
+		target, err := loadSalePayment(ctx, tx, input.PaymentID.Int64())
+		if err != nil {
+			return err
+		}
+		if target.IsReversal() {
+			return domain.Invalid("sale_payment_id", domain.ViolationInvariant, "PAY-002")
+		}
+		if input.ReceivedOn.Before(target.ReceivedOn()) {
+			return domain.Invalid("received_on", domain.ViolationOutOfRange, "PAY-002")
+		}
+		var reversed bool
+		if err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM sale_payments WHERE reverses_payment_id = ?)
+		`, input.PaymentID.Int64()).Scan(&reversed); err != nil {
+			return err
+		}
+		if reversed {
+			return fmt.Errorf("%w: payment is already reversed", domain.ErrConflict)
+		}
+
+		var id int64
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO sale_payments (
+				idempotency_key, sale_document_id, method, amount_minor, received_on,
+				notes, reverses_payment_id, recorded_at_ms
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			input.IdempotencyKey.String(),
+			target.SaleDocumentID().Int64(),
+			target.Method().String(),
+			target.Amount().Int64(),
+			input.ReceivedOn.String(),
+			nullableText(input.Notes),
+			input.PaymentID.Int64(),
+			input.RecordedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		reversal, err = loadSalePayment(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return sales.Payment{}, classifyError("reverse sale payment", err)
+	}
+	return reversal, nil
+}
+
+func validateRecordSalePaymentInput(input RecordSalePaymentInput) error {
+	if input.IdempotencyKey.String() == "" {
+		return domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.SaleDocumentID.IsZero() {
+		return domain.Invalid("sale_document_id", domain.ViolationRequired, "PAY-001")
+	}
+	if _, err := domain.ParsePaymentMethod(input.Method.String()); err != nil {
+		return err
+	}
+	if input.Amount.Int64() <= 0 {
+		return domain.Invalid("amount_minor", domain.ViolationNotPositive, "PAY-001")
+	}
+	if input.ReceivedOn.IsZero() {
+		return domain.Invalid("received_on", domain.ViolationRequired, "PAY-001")
+	}
+	if input.RecordedAt.IsZero() {
+		return domain.Invalid("recorded_at", domain.ViolationRequired, "")
+	}
+	return nil
+}
+
+func replaySalePayment(
+	ctx context.Context,
+	tx databaseWriteTx,
+	key domain.IdempotencyKey,
+) (domain.Option[sales.Payment], error) {
+	var id int64
+	err := tx.QueryRowContext(ctx, `
+		SELECT id FROM sale_payments WHERE idempotency_key = ?
+	`, key.String()).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.None[sales.Payment](), nil
+	}
+	if err != nil {
+		return domain.None[sales.Payment](), err
+	}
+	payment, err := loadSalePayment(ctx, tx, id)
+	if err != nil {
+		return domain.None[sales.Payment](), err
+	}
+	return domain.Some(payment), nil
+}
+
+// saleBalanceFilter selects one sale when saleID is set, one customer's
+// sales when customerID is set, and only sales not exactly settled with
+// openOnly.
+type saleBalanceFilter struct {
+	saleID     int64
+	customerID int64
+	openOnly   bool
+}
+
+func loadSaleBalance(ctx context.Context, tx databaseWriteTx, saleID int64) (sales.SaleBalance, error) {
+	balances, err := querySaleBalances(ctx, tx, saleBalanceFilter{saleID: saleID})
+	if err != nil {
+		return sales.SaleBalance{}, err
+	}
+	if len(balances) == 0 {
+		return sales.SaleBalance{}, sql.ErrNoRows
+	}
+	return balances[0], nil
+}
+
+func querySaleBalances(ctx context.Context, tx databaseWriteTx, filter saleBalanceFilter) ([]sales.SaleBalance, error) {
+	rows, err := tx.QueryContext(ctx, `
+		WITH receivable AS (
+			SELECT
+				sale.id,
+				sale.counterparty_id,
+				sale.occurred_on,
+				sale.posting_sequence,
+				(
+					SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+					FROM stock_document_lines line
+					WHERE line.document_id = sale.id
+				) AS total_minor,
+				(
+					SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+					FROM stock_documents customer_return
+					JOIN stock_document_lines line ON line.document_id = customer_return.id
+					WHERE customer_return.returns_document_id = sale.id
+					  AND NOT EXISTS (
+						  SELECT 1 FROM stock_documents reversal
+						  WHERE reversal.reverses_document_id = customer_return.id
+					  )
+				) AS returned_minor,
+				EXISTS (
+					SELECT 1 FROM stock_documents reversal
+					WHERE reversal.reverses_document_id = sale.id
+				) AS reversed,
+				(
+					SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+						THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+					FROM sale_payments payment
+					WHERE payment.sale_document_id = sale.id
+					  AND payment.method <> 'ON_ACCOUNT'
+				) AS settled_minor
+			FROM stock_documents sale
+			WHERE sale.kind = 'SALE'
+			  AND (? = 0 OR sale.id = ?)
+			  AND (? = 0 OR sale.counterparty_id = ?)
+		)
+		SELECT id, counterparty_id, occurred_on, total_minor, returned_minor, reversed
+		FROM receivable
+		WHERE ? = 0
+		   OR (CASE WHEN reversed THEN 0 ELSE total_minor - returned_minor END) <> settled_minor
+		ORDER BY occurred_on, posting_sequence
+	`, filter.saleID, filter.saleID, filter.customerID, filter.customerID, filter.openOnly)
+	if err != nil {
+		return nil, err
+	}
+	var loaded []saleBalanceRow
+	for rows.Next() {
+		var row saleBalanceRow
+		if err := rows.Scan(&row.id, &row.customerID, &row.occurredOn, &row.totalMinor, &row.returnedMinor, &row.reversed); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		loaded = append(loaded, row)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	balances := make([]sales.SaleBalance, 0, len(loaded))
+	for _, row := range loaded {
+		payments, err := loadSalePayments(ctx, tx, row.id)
+		if err != nil {
+			return nil, err
+		}
+		balance, err := mapSaleBalance(row, payments)
+		if err != nil {
+			return nil, corruptDataError("map sale balance", err)
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+type saleBalanceRow struct {
+	id, totalMinor, returnedMinor int64
+	customerID                    sql.NullInt64
+	occurredOn                    string
+	reversed                      bool
+}
+
+func loadSalePayment(ctx context.Context, tx databaseWriteTx, id int64) (sales.Payment, error) {
+	var row salePaymentRow
+	if err := tx.QueryRowContext(ctx, `
+		SELECT id, idempotency_key, sale_document_id, method, amount_minor, received_on,
+		       notes, reverses_payment_id, recorded_at_ms
+		FROM sale_payments
+		WHERE id = ?
+	`, id).Scan(
+		&row.id,
+		&row.idempotencyKey,
+		&row.saleDocumentID,
+		&row.method,
+		&row.amountMinor,
+		&row.receivedOn,
+		&row.notes,
+		&row.reversesPaymentID,
+		&row.recordedAtMS,
+	); err != nil {
+		return sales.Payment{}, err
+	}
+	payment, err := mapSalePayment(row)
+	if err != nil {
+		return sales.Payment{}, corruptDataError("map sale payment", err)
+	}
+	return payment, nil
+}
+
+// loadSalePayments returns a sale's payments in recording order, so every
+// reversal follows the payment it reverses.
+func loadSalePayments(ctx context.Context, tx databaseWriteTx, saleID int64) ([]sales.Payment, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, idempotency_key, sale_document_id, method, amount_minor, received_on,
+		       notes, reverses_payment_id, recorded_at_ms
+		FROM sale_payments
+		WHERE sale_document_id = ?
+		ORDER BY id
+	`, saleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []sales.Payment
+	for rows.Next() {
+		var row salePaymentRow
+		if err := rows.Scan(
+			&row.id,
+			&row.idempotencyKey,
+			&row.saleDocumentID,
+			&row.method,
+			&row.amountMinor,
+			&row.receivedOn,
+			&row.notes,
+			&row.reversesPaymentID,
+			&row.recordedAtMS,
+		); err != nil {
+			return nil, err
+		}
+		payment, err := mapSalePayment(row)
+		if err != nil {
+			return nil, corruptDataError("map sale payment", err)
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+type salePaymentRow struct {
+	id, saleDocumentID, amountMinor, recordedAtMS int64
+	idempotencyKey, method, receivedOn            string
+	notes                                         sql.NullString
+	reversesPaymentID                             sql.NullInt64
+}
+
+func mapSalePayment(row salePaymentRow) (sales.Payment, error) {
+	id, err := domain.NewSalePaymentID(row.id)
+	if err != nil {
+		return sales.Payment{}, err
+	}
+	key, err := domain.NewIdempotencyKey(row.idempotencyKey)
+	if err != nil {
+		return sales.Payment{}, err
+	}
+	saleID, err := domain.NewStockDocumentID(row.saleDocumentID)
+	if err != nil {
+		return sales.Payment{}, err
+	}
+	method, err := domain.ParsePaymentMethod(row.method)
+	if err != nil {
+		return sales.Payment{}, err
+	}
+	amount, err := domain.NewMinorAmount(row.amountMinor)
+	if err != nil {
+		return sales.Payment{}, err
+	}
+	receivedOn, err := domain.ParseBusinessDate(row.receivedOn)
+	if err != nil {
+		return sales.Payment{}, err
+	}
+	notes, err := optionalNonEmptyText(row.notes)
+	if err != nil {
+		return sales.Payment{}, err
+	}
+	reverses := domain.None[domain.SalePaymentID]()
+	if row.reversesPaymentID.Valid {
+		value, err := domain.NewSalePaymentID(row.reversesPaymentID.Int64)
+		if err != nil {
+			return sales.Payment{}, err
+		}
+		reverses = domain.Some(value)
+	}
+	recordedAt, err := domain.UTCInstantFromUnixMilli(row.recordedAtMS)
+	if err != nil {
+		return sales.Payment{}, err
+	}
+	return sales.NewPayment(sales.PaymentParams{
+		ID: id, IdempotencyKey: key, SaleDocumentID: saleID, Method: method, Amount: amount,
+		ReceivedOn: receivedOn, Notes: notes, ReversesPaymentID: reverses, RecordedAt: recordedAt,
+	})
+}
+
+func mapSaleBalance(row saleBalanceRow, payments []sales.Payment) (sales.SaleBalance, error) {
+	saleID, err := domain.NewStockDocumentID(row.id)
+	if err != nil {
+		return sales.SaleBalance{}, err
+	}
+	customerID := domain.None[domain.CounterpartyID]()
+	if row.customerID.Valid {
+		value, err := domain.NewCounterpartyID(row.customerID.Int64)
+		if err != nil {
+			return sales.SaleBalance{}, err
+		}
+		customerID = domain.Some(value)
+	}
+	occurredOn, err := domain.ParseBusinessDate(row.occurredOn)
+	if err != nil {
+		return sales.SaleBalance{}, err
+	}
+	total, err := domain.NewMinorAmount(row.totalMinor)
+	if err != nil {
+		return sales.SaleBalance{}, err
+	}
+	returned, err := domain.NewMinorAmount(row.returnedMinor)
+	if err != nil {
+		return sales.SaleBalance{}, err
+	}
+	return sales.NewSaleBalance(sales.BalanceParams{
+		SaleDocumentID: saleID, CustomerID: customerID, OccurredOn: occurredOn,
+		SaleTotal: total, Returned: returned, Reversed: row.reversed, Payments: payments,
+	})
+}
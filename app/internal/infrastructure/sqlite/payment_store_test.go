@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestPaymentStoreSettlesSalesAndReversesPayments(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "payments.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	cake := createSaleTestItem(t, store, "Paid cake", true)
+	purchase := postAdjustmentTestPurchase(t, store, cake, "payment-purchase", "PAY-LOT", "2026-12-31", 200, 2_000)
+	customer, err := store.CreateCounterparty(ctx, CreateCounterpartyInput{
+		Name:      counterpartyName(t, "Ana"),
+		Roles:     counterpartyRoles(t, domain.RoleCustomer),
+		CreatedAt: counterpartyInstant(t, 1_000),
+	})
+	if err != nil {
+		t.Fatalf("create customer: %v", err)
+	}
+	saleInput := saleInputFixture(t, cake, "payment-sale", 100, 9_000)
+	saleInput.CounterpartyID = domain.Some(customer.ID())
+	sale, err := store.PostSale(ctx, saleInput)
+	if err != nil {
+		t.Fatalf("post customer sale: %v", err)
+	}
+	anonymous, err := store.PostSale(ctx, saleInputFixture(t, cake, "payment-anonymous-sale", 50, 3_000))
+	if err != nil {
+		t.Fatalf("post anonymous sale: %v", err)
+	}
+	payment := func(key string, saleID domain.StockDocumentID, method domain.PaymentMethod, amount int64, receivedOn string) RecordSalePaymentInput {
+		return RecordSalePaymentInput{
+			IdempotencyKey: mustPurchaseIdempotencyKey(t, key),
+			SaleDocumentID: saleID,
+			Method:         method,
+			Amount:         mustPurchaseMinorAmount(t, amount),
+			ReceivedOn:     mustPurchaseDate(t, receivedOn),
+			RecordedAt:     mustCatalogInstant(t, 6_000),
+		}
+	}
+
+	if _, err := store.RecordSalePayment(ctx, payment("payment-0", purchase.ID(), domain.PaymentCash, 100, "2026-07-15")); !errors.Is(err, domain.ErrInvalidReference) {
+		t.Fatalf("purchase payment error = %v, want invalid reference", err)
+	}
+	if _, err := store.RecordSalePayment(ctx, payment("payment-0", anonymous.ID(), domain.PaymentOnAccount, 100, "2026-07-15")); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("anonymous on-account error = %v, want validation", err)
+	}
+	if _, err := store.RecordSalePayment(ctx, payment("payment-0", sale.ID(), domain.PaymentCash, 4_000, "2026-07-14")); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("payment before sale error = %v, want validation", err)
+	}
+	cash, err := store.RecordSalePayment(ctx, payment("payment-1", sale.ID(), domain.PaymentCash, 4_000, "2026-07-15"))
+	if err != nil {
+		t.Fatalf("record cash payment: %v", err)
+	}
+	if replayed, err := store.RecordSalePayment(ctx, payment("payment-1", sale.ID(), domain.PaymentCash, 4_000, "2026-07-15")); err != nil || replayed.ID() != cash.ID() {
+		t.Fatalf("replayed payment = %#v, %v", replayed, err)
+	}
+	if _, err := store.RecordSalePayment(ctx, payment("payment-1", anonymous.ID(), domain.PaymentCash, 100, "2026-07-15")); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("reused payment key error = %v, want conflict", err)
+	}
+	if _, err := store.RecordSalePayment(ctx, payment("payment-2", sale.ID(), domain.PaymentPix, 5_001, "2026-07-15")); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("overpayment error = %v, want validation", err)
+	}
+	if _, err := store.RecordSalePayment(ctx, payment("payment-2", sale.ID(), domain.PaymentOnAccount, 5_000, "2026-07-15")); err != nil {
+		t.Fatalf("record on-account payment: %v", err)
+	}
+	if _, err := store.RecordSalePayment(ctx, payment("payment-3", sale.ID(), domain.PaymentOnAccount, 1, "2026-07-15")); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("on-account above outstanding error = %v, want validation", err)
+	}
+
+	if _, err := store.PostReturn(ctx, returnInputFixture(
+		t, sale.ID(), sale.Lines()[0].ID(), "payment-return", 10, domain.Some(mustPurchaseMinorAmount(t, 900)),
+	)); err != nil {
+		t.Fatalf("post return: %v", err)
+	}
+	reverse := ReverseSalePaymentInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "payment-reversal-1"),
+		PaymentID:      cash.ID(),
+		ReceivedOn:     mustPurchaseDate(t, "2026-07-20"),
+		RecordedAt:     mustCatalogInstant(t, 8_000),
+	}
+	reversal, err := store.ReverseSalePayment(ctx, reverse)
+	if err != nil {
+		t.Fatalf("reverse payment: %v", err)
+	}
+	if target, ok := reversal.ReversesPaymentID().Get(); !ok || target != cash.ID() ||
+		reversal.Method() != domain.PaymentCash || reversal.Amount().Int64() != 4_000 {
+		t.Fatalf("payment reversal = %#v", reversal)
+	}
+	if replayed, err := store.ReverseSalePayment(ctx, reverse); err != nil || replayed.ID() != reversal.ID() {
+		t.Fatalf("replayed reversal = %#v, %v", replayed, err)
+	}
+	reverse.IdempotencyKey = mustPurchaseIdempotencyKey(t, "payment-reversal-2")
+	if _, err := store.ReverseSalePayment(ctx, reverse); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second reversal error = %v, want conflict", err)
+	}
+	reverse.PaymentID = reversal.ID()
+	if _, err := store.ReverseSalePayment(ctx, reverse); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("reversal of a reversal error = %v, want validation", err)
+	}
+
+	balance, err := store.GetSaleBalance(ctx, sale.ID())
+	if err != nil {
+		t.Fatalf("get sale balance: %v", err)
+	}
+	if balance.Receivable() != 8_100 || balance.Settled() != 0 || balance.OnAccount() != 5_000 ||
+		balance.Outstanding() != 8_100 || len(balance.Payments()) != 3 || balance.CustomerID() != domain.Some(customer.ID()) {
+		t.Fatalf("sale balance = %#v", balance)
+	}
+	if _, err := store.GetSaleBalance(ctx, purchase.ID()); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("purchase balance error = %v, want not found", err)
+	}
+
+	if _, err := store.RecordSalePayment(ctx, payment("payment-4", anonymous.ID(), domain.PaymentCard, 3_000, "2026-07-16")); err != nil {
+		t.Fatalf("record card payment: %v", err)
+	}
+	open, err := store.ListOpenSaleBalances(ctx, domain.None[domain.CounterpartyID]())
+	if err != nil || len(open) != 1 || open[0].SaleDocumentID() != sale.ID() {
+		t.Fatalf("open sale balances = %#v, %v", open, err)
+	}
+	customerOpen, err := store.ListOpenSaleBalances(ctx, domain.Some(customer.ID()))
+	if err != nil || len(customerOpen) != 1 || customerOpen[0].Outstanding() != 8_100 {
+		t.Fatalf("customer open sale balances = %#v, %v", customerOpen, err)
+	}
+}
@@ -0,0 +1,722 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+// PriceListPriceInput prices one base unit of ItemID, or one of its
+// packagings when PackagingID is set, from MinQuantity upward.
+type PriceListPriceInput struct {
+	ItemID      domain.ItemID
+	PackagingID domain.Option[domain.PackagingID]
+	MinQuantity domain.AtomicQuantity
+	UnitPrice   domain.MinorAmount
+}
+
+type CreatePriceListInput struct {
+	Name      domain.UniqueName
+	ValidFrom domain.Option[domain.BusinessDate]
+	ValidTo   domain.Option[domain.BusinessDate]
+	Prices    []PriceListPriceInput
+	CreatedAt domain.UTCInstant
+}
+
+// UpdatePriceListInput replaces the name, validity window, and every price of
+// an active list.
+type UpdatePriceListInput struct {
+	ID                domain.PriceListID
+	Name              domain.UniqueName
+	ValidFrom         domain.Option[domain.BusinessDate]
+	ValidTo           domain.Option[domain.BusinessDate]
+	Prices            []PriceListPriceInput
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+type ArchivePriceListInput struct {
+	ID                domain.PriceListID
+	ExpectedUpdatedAt domain.UTCInstant
+	ArchivedAt        domain.UTCInstant
+}
+
+type RestorePriceListInput struct {
+	ID                domain.PriceListID
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+// AssignCustomerPriceListInput replaces the list assigned to a customer. A
+// None PriceListID leaves the customer on default prices.
+type AssignCustomerPriceListInput struct {
+	CustomerID  domain.CounterpartyID
+	PriceListID domain.Option[domain.PriceListID]
+	AssignedAt  domain.UTCInstant
+}
+
+// SaleQuoteItem is what quoting needs from an item besides price lists: the
+// conversion of one base unit and the default price of one base unit.
+type SaleQuoteItem struct {
+	ItemID           domain.ItemID
+	BaseUnit         domain.UnitConversion
+	DefaultSalePrice domain.Option[domain.MinorAmount]
+}
+
+// SaleQuoteData holds the price list assigned to a customer, if any, whatever
+// its validity, and the quoted items in the requested order.
+type SaleQuoteData struct {
+	PriceList domain.Option[sales.PriceList]
+	Items     []SaleQuoteItem
+}
+
+func (s *Store) GetPriceList(ctx context.Context, id domain.PriceListID) (sales.PriceList, error) {
+	if id.IsZero() {
+		return sales.PriceList{}, domain.Invalid("price_list_id", domain.ViolationRequired, "")
+	}
+	var loaded sales.PriceList
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		var err error
+		loaded, err = loadPriceList(ctx, tx, id.Int64())
+		return err
+	})
+	if err != nil {
+		return sales.PriceList{}, classifyError("get price list", err)
+	}
+	return loaded, nil
+}
+
+// ListPriceLists returns every list matching archive ordered by name. Price
+// lists are a short reference list, so they are not paged.
+func (s *Store) ListPriceLists(ctx context.Context, archive domain.ArchiveFilter) ([]sales.PriceList, error) {
+	archiveFilter, err := archiveFilterValue(archive)
+	if err != nil {
+		return nil, err
+	}
+	var lists []sales.PriceList
+	err = s.database.Read(ctx, func(tx *database.ReadTx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id FROM price_lists
+			WHERE ?1 = 2
+			   OR (?1 = 0 AND archived_at_ms IS NULL)
+			   OR (?1 = 1 AND archived_at_ms IS NOT NULL)
+			ORDER BY normalized_name, id
+		`, archiveFilter)
+		if err != nil {
+			return err
+		}
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			loaded, err := loadPriceList(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			lists = append(lists, loaded)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list price lists", err)
+	}
+	return lists, nil
+}
+
+func (s *Store) CreatePriceList(ctx context.Context, input CreatePriceListInput) (sales.PriceList, error) {
+	if err := validatePriceListFields(input.Name, input.ValidFrom, input.ValidTo, input.Prices); err != nil {
+		return sales.PriceList{}, err
+	}
+	if input.CreatedAt.IsZero() {
+		return sales.PriceList{}, domain.Invalid("created_at", domain.ViolationRequired, "")
+	}
+	var created sales.PriceList
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		var id int64
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO price_lists (
+				name, normalized_name, valid_from, valid_to, created_at_ms, updated_at_ms
+			) VALUES (?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			input.Name.Display(),
+			input.Name.Key(),
+			nullableBusinessDate(input.ValidFrom),
+			nullableBusinessDate(input.ValidTo),
+			input.CreatedAt.UnixMilli(),
+			input.CreatedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		if err := insertPriceListPrices(ctx, tx, id, input.Prices); err != nil {
+			return err
+		}
+		var err error
+		created, err = loadPriceList(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return sales.PriceList{}, classifyError("create price list", err)
+	}
+	return created, nil
+}
+
+func (s *Store) UpdatePriceList(ctx context.Context, input UpdatePriceListInput) (sales.PriceList, error) {
+	if input.ID.IsZero() {
+		return sales.PriceList{}, domain.Invalid("price_list_id", domain.ViolationRequired, "")
+	}
+	if err := validatePriceListFields(input.Name, input.ValidFrom, input.ValidTo, input.Prices); err != nil {
+		return sales.PriceList{}, err
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return sales.PriceList{}, err
+	}
+	var updated sales.PriceList
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadPriceList(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: price list version changed", domain.ErrStale)
+		}
+		if current.IsArchived() {
+			return fmt.Errorf("%w: archived price list cannot be updated", domain.ErrConflict)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE price_lists
+			SET name = ?, normalized_name = ?, valid_from = ?, valid_to = ?, updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`,
+			input.Name.Display(),
+			input.Name.Key(),
+			nullableBusinessDate(input.ValidFrom),
+			nullableBusinessDate(input.ValidTo),
+			input.UpdatedAt.UnixMilli(),
+			input.ID.Int64(),
+			input.ExpectedUpdatedAt.UnixMilli(),
+		); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM price_list_prices WHERE price_list_id = ?`, input.ID.Int64()); err != nil {
+			return err
+		}
+		if err := insertPriceListPrices(ctx, tx, input.ID.Int64(), input.Prices); err != nil {
+			return err
+		}
+		updated, err = loadPriceList(ctx, tx, input.ID.Int64())
+		return err
+	})
+	if err != nil {
+		return sales.PriceList{}, classifyError("update price list", err)
+	}
+	return updated, nil
+}
+
+// ArchivePriceList refuses while any customer is assigned to the list; those
+// customers must be moved to another list or unassigned first.
+func (s *Store) ArchivePriceList(ctx context.Context, input ArchivePriceListInput) (sales.PriceList, error) {
+	if input.ID.IsZero() {
+		return sales.PriceList{}, domain.Invalid("price_list_id", domain.ViolationRequired, "")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.ArchivedAt); err != nil {
+		return sales.PriceList{}, err
+	}
+	var archived sales.PriceList
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadPriceList(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: price list version changed", domain.ErrStale)
+		}
+		if current.IsArchived() {
+			return fmt.Errorf("%w: price list is already archived", domain.ErrConflict)
+		}
+		var customers int64
+		if err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*) FROM customer_price_lists WHERE price_list_id = ?
+		`, input.ID.Int64()).Scan(&customers); err != nil {
+			return err
+		}
+		if customers > 0 {
+			return fmt.Errorf("%w: price list is still assigned to %d customers", domain.ErrConflict, customers)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE price_lists
+			SET archived_at_ms = ?, updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`,
+			input.ArchivedAt.UnixMilli(),
+			input.ArchivedAt.UnixMilli(),
+			input.ID.Int64(),
+			input.ExpectedUpdatedAt.UnixMilli(),
+		); err != nil {
+			return err
+		}
+		archived, err = loadPriceList(ctx, tx, input.ID.Int64())
+		return err
+	})
+	if err != nil {
+		return sales.PriceList{}, classifyError("archive price list", err)
+	}
+	return archived, nil
+}
+
+func (s *Store) RestorePriceList(ctx context.Context, input RestorePriceListInput) (sales.PriceList, error) {
+	if input.ID.IsZero() {
+		return sales.PriceList{}, domain.Invalid("price_list_id", domain.ViolationRequired, "")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return sales.PriceList{}, err
+	}
+	var restored sales.PriceList
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadPriceList(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: price list version changed", domain.ErrStale)
+		}
+		if !current.IsArchived() {
+			return fmt.Errorf("%w: price list is already active", domain.ErrConflict)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE price_lists
+			SET archived_at_ms = NULL, updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`,
+			input.UpdatedAt.UnixMilli(),
+			input.ID.Int64(),
+			input.ExpectedUpdatedAt.UnixMilli(),
+		); err != nil {
+			return err
+		}
+		restored, err = loadPriceList(ctx, tx, input.ID.Int64())
+		return err
+	})
+	if err != nil {
+		return sales.PriceList{}, classifyError("restore price list", err)
+	}
+	return restored, nil
+}
+
+// AssignCustomerPriceList replaces the list assigned to an active customer
+// and returns the newly assigned list, if any.
+func (s *Store) AssignCustomerPriceList(
+	ctx context.Context,
+	input AssignCustomerPriceListInput,
+) (domain.Option[sales.PriceList], error) {
+	if input.CustomerID.IsZero() {
+		return domain.None[sales.PriceList](), domain.Invalid("customer_id", domain.ViolationRequired, "PRL-003")
+	}
+	if input.AssignedAt.IsZero() {
+		return domain.None[sales.PriceList](), domain.Invalid("assigned_at", domain.ViolationRequired, "")
+	}
+	assigned := domain.None[sales.PriceList]()
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		if err := requireActiveCustomer(ctx, tx, input.CustomerID.Int64()); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			DELETE FROM customer_price_lists WHERE customer_id = ?
+		`, input.CustomerID.Int64()); err != nil {
+			return err
+		}
+		listID, ok := input.PriceListID.Get()
+		if !ok {
+			return nil
+		}
+		list, err := loadPriceList(ctx, tx, listID.Int64())
+		if err != nil {
+			return err
+		}
+		if list.IsArchived() {
+			return fmt.Errorf("%w: archived price list cannot be assigned", domain.ErrConflict)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO customer_price_lists (customer_id, price_list_id, assigned_at_ms)
+			VALUES (?, ?, ?)
+		`, input.CustomerID.Int64(), listID.Int64(), input.AssignedAt.UnixMilli()); err != nil {
+			return err
+		}
+		assigned = domain.Some(list)
+		return nil
+	})
+	if err != nil {
+		return domain.None[sales.PriceList](), classifyError("assign customer price list", err)
+	}
+	return assigned, nil
+}
+
+func (s *Store) GetCustomerPriceList(
+	ctx context.Context,
+	customerID domain.CounterpartyID,
+) (domain.Option[sales.PriceList], error) {
+	if customerID.IsZero() {
+		return domain.None[sales.PriceList](), domain.Invalid("customer_id", domain.ViolationRequired, "PRL-003")
+	}
+	assigned := domain.None[sales.PriceList]()
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		var err error
+		assigned, err = loadCustomerPriceList(ctx, tx, customerID.Int64())
+		return err
+	})
+	if err != nil {
+		return domain.None[sales.PriceList](), classifyError("get customer price list", err)
+	}
+	return assigned, nil
+}
+
+// GetSaleQuoteData reads what quoting a sale needs in one snapshot. Every
+// item must exist; it does not have to be sellable, since posting is where
+// that is enforced.
+func (s *Store) GetSaleQuoteData(
+	ctx context.Context,
+	customerID domain.Option[domain.CounterpartyID],
+	itemIDs []domain.ItemID,
+) (SaleQuoteData, error) {
+	if len(itemIDs) == 0 {
+		return SaleQuoteData{}, domain.Invalid("lines", domain.ViolationRequired, "DOC-002")
+	}
+	data := SaleQuoteData{PriceList: domain.None[sales.PriceList]()}
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		if customer, ok := customerID.Get(); ok {
+			assigned, err := loadCustomerPriceList(ctx, tx, customer.Int64())
+			if err != nil {
+				return err
+			}
+			data.PriceList = assigned
+		}
+		data.Items = make([]SaleQuoteItem, 0, len(itemIDs))
+		for _, itemID := range itemIDs {
+			var numerator, denominator int64
+			var defaultPrice sql.NullInt64
+			if err := tx.QueryRowContext(ctx, `
+				SELECT unit.atomic_numerator, unit.atomic_denominator, item.default_sale_price_minor
+				FROM items item
+				JOIN measurement_units unit ON unit.code = item.base_unit_code
+				WHERE item.id = ?
+			`, itemID.Int64()).Scan(&numerator, &denominator, &defaultPrice); err != nil {
+				return err
+			}
+			baseUnit, err := domain.NewUnitConversion(numerator, denominator)
+			if err != nil {
+				return corruptDataError("map quoted item unit", err)
+			}
+			price, err := optionalMinorAmount(defaultPrice)
+			if err != nil {
+				return corruptDataError("map quoted item price", err)
+			}
+			data.Items = append(data.Items, SaleQuoteItem{ItemID: itemID, BaseUnit: baseUnit, DefaultSalePrice: price})
+		}
+		return nil
+	})
+	if err != nil {
+		return SaleQuoteData{}, classifyError("get sale quote data", err)
+	}
+	return data, nil
+}
+
+func validatePriceListFields(
+	name domain.UniqueName,
+	validFrom domain.Option[domain.BusinessDate],
+	validTo domain.Option[domain.BusinessDate],
+	prices []PriceListPriceInput,
+) error {
+	if name.Key() == "" {
+		return domain.Invalid("name", domain.ViolationRequired, "PRL-001")
+	}
+	from, hasFrom := validFrom.Get()
+	to, hasTo := validTo.Get()
+	if (hasFrom && from.IsZero()) || (hasTo && to.IsZero()) || (hasFrom && hasTo && to.Before(from)) {
+		return domain.Invalid("valid_to", domain.ViolationOutOfRange, "PRL-001")
+	}
+	type priceKey struct {
+		itemID, packagingID, minQuantity int64
+	}
+	seen := make(map[priceKey]struct{}, len(prices))
+	for _, price := range prices {
+		if price.ItemID.IsZero() {
+			return domain.Invalid("item_id", domain.ViolationRequired, "PRL-002")
+		}
+		key := priceKey{itemID: price.ItemID.Int64(), minQuantity: price.MinQuantity.Int64()}
+		if packagingID, ok := price.PackagingID.Get(); ok {
+			if packagingID.IsZero() {
+				return domain.Invalid("packaging_id", domain.ViolationRequired, "PRL-002")
+			}
+			key.packagingID = packagingID.Int64()
+		}
+		if price.MinQuantity.Int64() < 0 {
+			return domain.Invalid("min_quantity_atomic", domain.ViolationOutOfRange, "PRL-002")
+		}
+		if price.UnitPrice.Int64() < 0 {
+			return domain.Invalid("unit_price_minor", domain.ViolationOutOfRange, "PRL-002")
+		}
+		if _, duplicate := seen[key]; duplicate {
+			return domain.Invalid("prices", domain.ViolationDuplicate, "PRL-002")
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// insertPriceListPrices requires each priced item to be active and sellable,
+// and each priced packaging to be an active packaging of that item.
+func insertPriceListPrices(ctx context.Context, tx databaseWriteTx, listID int64, prices []PriceListPriceInput) error {
+	for index, price := range prices {
+		var sellable bool
+		if err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM items
+				WHERE id = ? AND is_sellable = 1 AND archived_at_ms IS NULL
+			)
+		`, price.ItemID.Int64()).Scan(&sellable); err != nil {
+			return err
+		}
+		if !sellable {
+			return fmt.Errorf("%w: price %d: item is not an active sellable item", domain.ErrInvalidReference, index+1)
+		}
+		var packagingID any
+		if packaging, ok := price.PackagingID.Get(); ok {
+			var active bool
+			if err := tx.QueryRowContext(ctx, `
+				SELECT EXISTS (
+					SELECT 1 FROM item_packagings
+					WHERE id = ? AND item_id = ? AND archived_at_ms IS NULL
+				)
+			`, packaging.Int64(), price.ItemID.Int64()).Scan(&active); err != nil {
+				return err
+			}
+			if !active {
+				return fmt.Errorf("%w: price %d: packaging is not an active packaging of the item", domain.ErrInvalidReference, index+1)
+			}
+			packagingID = packaging.Int64()
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO price_list_prices (
+				price_list_id, item_id, packaging_id, min_quantity_atomic, unit_price_minor
+			) VALUES (?, ?, ?, ?, ?)
+		`,
+			listID,
+			price.ItemID.Int64(),
+			packagingID,
+			price.MinQuantity.Int64(),
+			price.UnitPrice.Int64(),
+		); err != nil {
+			return fmt.Errorf("price %d: %w", index+1, err)
+		}
+	}
+	return nil
+}
+
+func loadCustomerPriceList(ctx context.Context, tx databaseWriteTx, customerID int64) (domain.Option[sales.PriceList], error) {
+	var listID int64
+	err := tx.QueryRowContext(ctx, `
+		SELECT price_list_id FROM customer_price_lists WHERE customer_id = ?
+	`, customerID).Scan(&listID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.None[sales.PriceList](), nil
+	}
+	if err != nil {
+		return domain.None[sales.PriceList](), err
+	}
+	list, err := loadPriceList(ctx, tx, listID)
+	if err != nil {
+		return domain.None[sales.PriceList](), err
+	}
+	return domain.Some(list), nil
+}
+
+type priceListRow struct {
+	id, createdAtMS, updatedAtMS int64
+	name, normalizedName         string
+	validFrom, validTo           sql.NullString
+	archivedAtMS                 sql.NullInt64
+}
+
+func loadPriceList(ctx context.Context, tx databaseWriteTx, id int64) (sales.PriceList, error) {
+	var row priceListRow
+	if err := tx.QueryRowContext(ctx, `
+		SELECT id, name, normalized_name, valid_from, valid_to,
+		       created_at_ms, updated_at_ms, archived_at_ms
+		FROM price_lists
+		WHERE id = ?
+	`, id).Scan(
+		&row.id,
+		&row.name,
+		&row.normalizedName,
+		&row.validFrom,
+		&row.validTo,
+		&row.createdAtMS,
+		&row.updatedAtMS,
+		&row.archivedAtMS,
+	); err != nil {
+		return sales.PriceList{}, err
+	}
+	prices, err := loadPriceListPrices(ctx, tx, id)
+	if err != nil {
+		return sales.PriceList{}, err
+	}
+	list, err := mapPriceList(row, prices)
+	if err != nil {
+		return sales.PriceList{}, corruptDataError("map price list", err)
+	}
+	return list, nil
+}
+
+// loadPriceListPrices resolves the priced unit of each price: the packaging's
+// conversion when it names one, otherwise the item's base unit conversion.
+func loadPriceListPrices(ctx context.Context, tx databaseWriteTx, listID int64) ([]sales.PriceListPrice, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT price.item_id, price.packaging_id, packaging.name, packaging.normalized_name,
+		       COALESCE(packaging.conversion_numerator_atomic, unit.atomic_numerator),
+		       COALESCE(packaging.conversion_denominator, unit.atomic_denominator),
+		       price.min_quantity_atomic, price.unit_price_minor
+		FROM price_list_prices price
+		JOIN items item ON item.id = price.item_id
+		JOIN measurement_units unit ON unit.code = item.base_unit_code
+		LEFT JOIN item_packagings packaging ON packaging.id = price.packaging_id
+		WHERE price.price_list_id = ?
+		ORDER BY price.item_id, price.packaging_id IS NOT NULL, packaging.normalized_name,
+		         price.min_quantity_atomic
+	`, listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var prices []sales.PriceListPrice
+	for rows.Next() {
+		var itemID, numerator, denominator, minQuantity, unitPrice int64
+		var packagingID sql.NullInt64
+		var packagingName, packagingKey sql.NullString
+		if err := rows.Scan(
+			&itemID,
+			&packagingID,
+			&packagingName,
+			&packagingKey,
+			&numerator,
+			&denominator,
+			&minQuantity,
+			&unitPrice,
+		); err != nil {
+			return nil, err
+		}
+		price, err := mapPriceListPrice(itemID, packagingID, packagingName, packagingKey, numerator, denominator, minQuantity, unitPrice)
+		if err != nil {
+			return nil, corruptDataError("map price list price", err)
+		}
+		prices = append(prices, price)
+	}
+	return prices, rows.Err()
+}
+
+func mapPriceList(row priceListRow, prices []sales.PriceListPrice) (sales.PriceList, error) {
+	id, err := domain.NewPriceListID(row.id)
+	if err != nil {
+		return sales.PriceList{}, err
+	}
+	name, err := domain.RestoreUniqueName(row.name, row.normalizedName)
+	if err != nil {
+		return sales.PriceList{}, err
+	}
+	validFrom, err := optionalBusinessDate(row.validFrom)
+	if err != nil {
+		return sales.PriceList{}, err
+	}
+	validTo, err := optionalBusinessDate(row.validTo)
+	if err != nil {
+		return sales.PriceList{}, err
+	}
+	createdAt, err := domain.UTCInstantFromUnixMilli(row.createdAtMS)
+	if err != nil {
+		return sales.PriceList{}, err
+	}
+	updatedAt, err := domain.UTCInstantFromUnixMilli(row.updatedAtMS)
+	if err != nil {
+		return sales.PriceList{}, err
+	}
+	archivedAt, err := optionalInstant(row.archivedAtMS)
+	if err != nil {
+		return sales.PriceList{}, err
+	}
+	return sales.NewPriceList(sales.PriceListParams{
+		ID:         id,
+		Name:       name,
+		ValidFrom:  validFrom,
+		ValidTo:    validTo,
+		Prices:     prices,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
+		ArchivedAt: archivedAt,
+	})
+}
+
+func mapPriceListPrice(
+	itemIDValue int64,
+	packagingIDValue sql.NullInt64,
+	packagingNameValue sql.NullString,
+	packagingKeyValue sql.NullString,
+	numerator int64,
+	denominator int64,
+	minQuantityValue int64,
+	unitPriceValue int64,
+) (sales.PriceListPrice, error) {
+	itemID, err := domain.NewItemID(itemIDValue)
+	if err != nil {
+		return sales.PriceListPrice{}, err
+	}
+	packagingID := domain.None[domain.PackagingID]()
+	packagingName := domain.None[domain.UniqueName]()
+	if packagingIDValue.Valid {
+		id, err := domain.NewPackagingID(packagingIDValue.Int64)
+		if err != nil {
+			return sales.PriceListPrice{}, err
+		}
+		name, err := domain.RestoreUniqueName(packagingNameValue.String, packagingKeyValue.String)
+		if err != nil {
+			return sales.PriceListPrice{}, err
+		}
+		packagingID = domain.Some(id)
+		packagingName = domain.Some(name)
+	}
+	pricedUnit, err := domain.NewUnitConversion(numerator, denominator)
+	if err != nil {
+		return sales.PriceListPrice{}, err
+	}
+	minQuantity, err := domain.NewAtomicQuantity(minQuantityValue)
+	if err != nil {
+		return sales.PriceListPrice{}, err
+	}
+	unitPrice, err := domain.NewMinorAmount(unitPriceValue)
+	if err != nil {
+		return sales.PriceListPrice{}, err
+	}
+	return sales.NewPriceListPrice(sales.PriceListPriceParams{
+		ItemID:        itemID,
+		PackagingID:   packagingID,
+		PackagingName: packagingName,
+		PricedUnit:    pricedUnit,
+		MinQuantity:   minQuantity,
+		UnitPrice:     unitPrice,
+	})
+}
@@ -0,0 +1,163 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/catalog"
+)
+
+func TestPriceListStoreAssignsListsToCustomersAndReadsQuoteData(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "price-lists.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	flour := createCatalogItem(t, store, CreateItemInput{
+		Name:             mustCatalogName(t, "Bread flour"),
+		BaseUnit:         mustCatalogUnitCode(t, "g"),
+		Capabilities:     catalog.NewCapabilities(true, false, true),
+		DefaultSalePrice: domain.Some(mustPurchaseMinorAmount(t, 2)),
+		CreatedAt:        mustCatalogInstant(t, 1),
+		UpdatedAt:        mustCatalogInstant(t, 1),
+	})
+	bag, err := store.CreatePackaging(ctx, CreatePackagingInput{
+		ItemID:      flour.Item().ID(),
+		Name:        mustCatalogName(t, "Bag 1kg"),
+		EnteredUnit: mustCatalogUnitCode(t, "kg"),
+		Conversion:  mustCatalogConversion(t, 1_000_000, 1),
+		CreatedAt:   mustCatalogInstant(t, 2),
+		UpdatedAt:   mustCatalogInstant(t, 2),
+	})
+	if err != nil {
+		t.Fatalf("create packaging: %v", err)
+	}
+	unsellable := createNamedCatalogItem(t, store, "Yeast", 3, catalog.NewCapabilities(true, false, false))
+	cafe, err := store.CreateCounterparty(ctx, CreateCounterpartyInput{
+		Name:      counterpartyName(t, "Corner café"),
+		Roles:     counterpartyRoles(t, domain.RoleCustomer),
+		CreatedAt: counterpartyInstant(t, 4),
+	})
+	if err != nil {
+		t.Fatalf("create customer: %v", err)
+	}
+	supplier := createReportingSupplier(t, store, "Mill")
+
+	prices := []PriceListPriceInput{
+		{ItemID: flour.Item().ID(), UnitPrice: mustPurchaseMinorAmount(t, 1)},
+		{ItemID: flour.Item().ID(), PackagingID: domain.Some(bag.Packaging().ID()), UnitPrice: mustPurchaseMinorAmount(t, 900)},
+		{
+			ItemID:      flour.Item().ID(),
+			PackagingID: domain.Some(bag.Packaging().ID()),
+			MinQuantity: mustPurchaseQuantity(t, 10_000_000),
+			UnitPrice:   mustPurchaseMinorAmount(t, 800),
+		},
+	}
+	input := CreatePriceListInput{
+		Name:      mustCatalogName(t, "Wholesale"),
+		ValidFrom: domain.Some(mustPurchaseDate(t, "2026-10-01")),
+		Prices:    prices,
+		CreatedAt: mustCatalogInstant(t, 5),
+	}
+	created, err := store.CreatePriceList(ctx, input)
+	if err != nil {
+		t.Fatalf("create price list: %v", err)
+	}
+	if len(created.Prices()) != 3 || created.Prices()[1].PricedUnit().NumeratorAtomic() != 1_000_000 {
+		t.Fatalf("created prices = %#v", created.Prices())
+	}
+	if _, err := store.CreatePriceList(ctx, input); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("duplicate name error = %v, want conflict", err)
+	}
+	badItem := input
+	badItem.Name = mustCatalogName(t, "Yeast list")
+	badItem.Prices = []PriceListPriceInput{{ItemID: unsellable.Item().ID(), UnitPrice: mustPurchaseMinorAmount(t, 1)}}
+	if _, err := store.CreatePriceList(ctx, badItem); !errors.Is(err, domain.ErrInvalidReference) {
+		t.Fatalf("unsellable item error = %v, want invalid reference", err)
+	}
+
+	if _, err := store.AssignCustomerPriceList(ctx, AssignCustomerPriceListInput{
+		CustomerID: supplier, PriceListID: domain.Some(created.ID()), AssignedAt: mustCatalogInstant(t, 6),
+	}); !errors.Is(err, domain.ErrInvalidReference) {
+		t.Fatalf("supplier assignment error = %v, want invalid reference", err)
+	}
+	assigned, err := store.AssignCustomerPriceList(ctx, AssignCustomerPriceListInput{
+		CustomerID: cafe.ID(), PriceListID: domain.Some(created.ID()), AssignedAt: mustCatalogInstant(t, 6),
+	})
+	if list, ok := assigned.Get(); err != nil || !ok || list.ID() != created.ID() {
+		t.Fatalf("assigned = %#v, %v", assigned, err)
+	}
+	if _, err := store.ArchivePriceList(ctx, ArchivePriceListInput{
+		ID: created.ID(), ExpectedUpdatedAt: created.UpdatedAt(), ArchivedAt: mustCatalogInstant(t, 7),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("archive assigned list error = %v, want conflict", err)
+	}
+
+	updated, err := store.UpdatePriceList(ctx, UpdatePriceListInput{
+		ID:                created.ID(),
+		Name:              mustCatalogName(t, "Wholesale 2026"),
+		ValidFrom:         created.ValidFrom(),
+		ValidTo:           domain.Some(mustPurchaseDate(t, "2026-12-31")),
+		Prices:            prices[1:],
+		ExpectedUpdatedAt: created.UpdatedAt(),
+		UpdatedAt:         mustCatalogInstant(t, 8),
+	})
+	if err != nil {
+		t.Fatalf("update price list: %v", err)
+	}
+	if len(updated.Prices()) != 2 || updated.Name().Display() != "Wholesale 2026" {
+		t.Fatalf("updated price list = %#v", updated)
+	}
+	if _, err := store.UpdatePriceList(ctx, UpdatePriceListInput{
+		ID: created.ID(), Name: updated.Name(), ExpectedUpdatedAt: created.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 9),
+	}); !errors.Is(err, domain.ErrStale) {
+		t.Fatalf("stale update error = %v, want stale", err)
+	}
+
+	data, err := store.GetSaleQuoteData(ctx, domain.Some(cafe.ID()), []domain.ItemID{flour.Item().ID()})
+	if err != nil {
+		t.Fatalf("get sale quote data: %v", err)
+	}
+	if list, ok := data.PriceList.Get(); !ok || list.ID() != created.ID() || len(list.Prices()) != 2 {
+		t.Fatalf("quote price list = %#v", data.PriceList)
+	}
+	if len(data.Items) != 1 || data.Items[0].BaseUnit.NumeratorAtomic() != 1_000 {
+		t.Fatalf("quote items = %#v", data.Items)
+	}
+	if price, ok := data.Items[0].DefaultSalePrice.Get(); !ok || price.Int64() != 2 {
+		t.Fatalf("default sale price = %#v", data.Items[0].DefaultSalePrice)
+	}
+	if _, err := store.GetSaleQuoteData(ctx, domain.None[domain.CounterpartyID](), []domain.ItemID{mustCatalogItemID(t, 999)}); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("missing item error = %v, want not found", err)
+	}
+
+	if _, err := store.AssignCustomerPriceList(ctx, AssignCustomerPriceListInput{
+		CustomerID: cafe.ID(), PriceListID: domain.None[domain.PriceListID](), AssignedAt: mustCatalogInstant(t, 10),
+	}); err != nil {
+		t.Fatalf("unassign price list: %v", err)
+	}
+	if current, err := store.GetCustomerPriceList(ctx, cafe.ID()); err != nil || current.IsSome() {
+		t.Fatalf("customer price list after unassign = %#v, %v", current, err)
+	}
+	archived, err := store.ArchivePriceList(ctx, ArchivePriceListInput{
+		ID: created.ID(), ExpectedUpdatedAt: updated.UpdatedAt(), ArchivedAt: mustCatalogInstant(t, 11),
+	})
+	if err != nil || !archived.IsArchived() {
+		t.Fatalf("archive price list = %#v, %v", archived, err)
+	}
+	if active, err := store.ListPriceLists(ctx, domain.ArchiveActive); err != nil || len(active) != 0 {
+		t.Fatalf("active price lists = %#v, %v", active, err)
+	}
+	if _, err := store.AssignCustomerPriceList(ctx, AssignCustomerPriceListInput{
+		CustomerID: cafe.ID(), PriceListID: domain.Some(created.ID()), AssignedAt: mustCatalogInstant(t, 12),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("assign archived list error = %v, want conflict", err)
+	}
+	restored, err := store.RestorePriceList(ctx, RestorePriceListInput{
+		ID: created.ID(), ExpectedUpdatedAt: archived.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 13),
+	})
+	if err != nil || restored.IsArchived() {
+		t.Fatalf("restore price list = %#v, %v", restored, err)
+	}
+}
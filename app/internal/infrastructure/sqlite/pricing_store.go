@@ -0,0 +1,144 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+const (
+	getRecipePricingTargetOperation           = "get recipe pricing target"
+	listSellableRecipePricingTargetsOperation = "list sellable recipe pricing targets"
+	recipePricingTargetOperation              = "map recipe pricing target"
+)
+
+// RecipePricingTarget is the output item of a recipe together with its
+// current revision and the price it is sold at today.
+type RecipePricingTarget struct {
+	RecipeID          domain.RecipeID
+	RecipeName        domain.UniqueName
+	CurrentRevisionID domain.RecipeRevisionID
+	OutputItemID      domain.ItemID
+	OutputItemName    domain.UniqueName
+	DefaultSalePrice  domain.Option[domain.MinorAmount]
+}
+
+const recipePricingTargetSelect = `
+	SELECT recipe.id, recipe.name, recipe.normalized_name,
+	       (SELECT revision.id FROM recipe_revisions revision
+	        WHERE revision.recipe_id = recipe.id
+	        ORDER BY revision.revision_number DESC LIMIT 1),
+	       item.id, item.name, item.normalized_name, item.default_sale_price_minor
+	FROM recipes recipe
+	JOIN items item ON item.id = recipe.output_item_id
+`
+
+func (s *Store) GetRecipePricingTarget(ctx context.Context, recipeID domain.RecipeID) (RecipePricingTarget, error) {
+	if recipeID.IsZero() {
+		return RecipePricingTarget{}, domain.Invalid("recipe_id", domain.ViolationRequired, "")
+	}
+	var target RecipePricingTarget
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		row := tx.QueryRowContext(ctx, recipePricingTargetSelect+`WHERE recipe.id = ?`, recipeID.Int64())
+		value, err := scanRecipePricingTarget(row)
+		if err != nil {
+			return err
+		}
+		target = value
+		return nil
+	})
+	if err != nil {
+		return RecipePricingTarget{}, classifyError(getRecipePricingTargetOperation, err)
+	}
+	return target, nil
+}
+
+// ListSellableRecipePricingTargets lists active recipes whose output item is
+// active and sellable, ordered by output item name and recipe.
+func (s *Store) ListSellableRecipePricingTargets(ctx context.Context) ([]RecipePricingTarget, error) {
+	targets := make([]RecipePricingTarget, 0)
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		rows, err := tx.QueryContext(ctx, recipePricingTargetSelect+`
+			WHERE recipe.archived_at_ms IS NULL
+			  AND item.archived_at_ms IS NULL
+			  AND item.is_sellable = 1
+			ORDER BY item.normalized_name, item.id, recipe.normalized_name, recipe.id
+		`)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			target, err := scanRecipePricingTarget(rows)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, target)
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, classifyError(listSellableRecipePricingTargetsOperation, err)
+	}
+	return targets, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRecipePricingTarget(row rowScanner) (RecipePricingTarget, error) {
+	var rawRecipeID, rawRevisionID, rawItemID int64
+	var recipeName, recipeKey, itemName, itemKey string
+	var rawPrice sql.NullInt64
+	if err := row.Scan(
+		&rawRecipeID, &recipeName, &recipeKey, &rawRevisionID,
+		&rawItemID, &itemName, &itemKey, &rawPrice,
+	); err != nil {
+		return RecipePricingTarget{}, err
+	}
+	target, err := mapRecipePricingTarget(rawRecipeID, recipeName, recipeKey, rawRevisionID, rawItemID, itemName, itemKey, rawPrice)
+	if err != nil {
+		return RecipePricingTarget{}, corruptDataError(recipePricingTargetOperation, err)
+	}
+	return target, nil
+}
+
+func mapRecipePricingTarget(
+	rawRecipeID int64,
+	recipeName, recipeKey string,
+	rawRevisionID, rawItemID int64,
+	itemName, itemKey string,
+	rawPrice sql.NullInt64,
+) (RecipePricingTarget, error) {
+	recipeID, err := domain.NewRecipeID(rawRecipeID)
+	if err != nil {
+		return RecipePricingTarget{}, err
+	}
+	name, err := domain.RestoreUniqueName(recipeName, recipeKey)
+	if err != nil {
+		return RecipePricingTarget{}, err
+	}
+	revisionID, err := domain.NewRecipeRevisionID(rawRevisionID)
+	if err != nil {
+		return RecipePricingTarget{}, err
+	}
+	itemID, err := domain.NewItemID(rawItemID)
+	if err != nil {
+		return RecipePricingTarget{}, err
+	}
+	outputName, err := domain.RestoreUniqueName(itemName, itemKey)
+	if err != nil {
+		return RecipePricingTarget{}, err
+	}
+	price, err := optionalMinorAmount(rawPrice)
+	if err != nil {
+		return RecipePricingTarget{}, err
+	}
+	return RecipePricingTarget{
+		RecipeID: recipeID, RecipeName: name, CurrentRevisionID: revisionID,
+		OutputItemID: itemID, OutputItemName: outputName, DefaultSalePrice: price,
+	}, nil
+}
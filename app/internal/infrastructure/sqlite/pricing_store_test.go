@@ -0,0 +1,89 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/catalog"
+)
+
+func TestPricingStoreListsCurrentRevisionOfSellableActiveRecipes(t *testing.T) {
+	store := recipeTestStore(t, "pricing.db")
+	ctx := context.Background()
+	price, err := domain.NewMinorAmount(1_250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cake := createCatalogItem(t, store, CreateItemInput{
+		Name:             mustCatalogName(t, "Priced cake"),
+		BaseUnit:         mustCatalogUnitCode(t, "g"),
+		Capabilities:     catalog.NewCapabilities(false, true, true),
+		DefaultSalePrice: domain.Some(price),
+		CreatedAt:        mustCatalogInstant(t, 100),
+		UpdatedAt:        mustCatalogInstant(t, 100),
+	}).Item().ID()
+	dough := recipeTestItem(t, store, "Dough", false, true)
+	flour := recipeTestItem(t, store, "Flour", true, false)
+	unitSource := recipeUnitSource(t, "g")
+
+	cakeRecipe, err := store.CreateRecipe(ctx, CreateRecipeInput{
+		Name: recipeName(t, "Cake recipe"), OutputItemID: cake,
+		CreatedAt: recipeInstant(t, 500),
+		Revision: recipeRevisionInput(t, 500, "version one", []RecipeComponentInput{
+			recipeComponentInput(t, 1, flour, 500, unitSource),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.CreateRecipe(ctx, CreateRecipeInput{
+		Name: recipeName(t, "Dough recipe"), OutputItemID: dough,
+		CreatedAt: recipeInstant(t, 500),
+		Revision: recipeRevisionInput(t, 500, "knead", []RecipeComponentInput{
+			recipeComponentInput(t, 1, flour, 500, unitSource),
+		}),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	published, err := store.PublishRecipeRevision(ctx, PublishRecipeRevisionInput{
+		RecipeID: cakeRecipe.ID(), ExpectedLatestRevision: cakeRecipe.CurrentRevision().Number(),
+		ExpectedUpdatedAt: cakeRecipe.UpdatedAt(),
+		Revision: recipeRevisionInput(t, 1_000, "version two", []RecipeComponentInput{
+			recipeComponentInput(t, 1, flour, 600, unitSource),
+		}),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := store.GetRecipePricingTarget(ctx, cakeRecipe.ID())
+	if err != nil {
+		t.Fatalf("get pricing target: %v", err)
+	}
+	if target.CurrentRevisionID != published.ID() || target.OutputItemID != cake ||
+		target.OutputItemName.Display() != "Priced cake" || target.DefaultSalePrice != domain.Some(price) {
+		t.Fatalf("pricing target = %#v", target)
+	}
+	targets, err := store.ListSellableRecipePricingTargets(ctx)
+	if err != nil {
+		t.Fatalf("list pricing targets: %v", err)
+	}
+	if len(targets) != 1 || targets[0].RecipeID != cakeRecipe.ID() {
+		t.Fatalf("pricing targets = %#v", targets)
+	}
+
+	if _, err := store.ArchiveRecipe(ctx, ArchiveRecipeInput{
+		ID: cakeRecipe.ID(), ExpectedUpdatedAt: recipeInstant(t, 1_000), ArchivedAt: recipeInstant(t, 2_000),
+	}); err != nil {
+		t.Fatalf("archive recipe: %v", err)
+	}
+	targets, err = store.ListSellableRecipePricingTargets(ctx)
+	if err != nil || len(targets) != 0 {
+		t.Fatalf("pricing targets after archive = %#v, %v", targets, err)
+	}
+	if _, err := store.GetRecipePricingTarget(ctx, recipeID(t, 999)); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("missing recipe error = %v, want ErrNotFound", err)
+	}
+}
@@ -5,11 +5,46 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/jerobas/saas/database"
 	"github.com/jerobas/saas/internal/domain"
 )
 
+const (
+	productionDefaultPageSize = 50
+	productionMaximumPageSize = 100
+)
+
+type ProductionCursor struct {
+	PostingSequence domain.PostingSequence
+	ID              domain.StockDocumentID
+}
+
+// ProductionListFilter narrows posted production runs. The recipe filter
+// matches every revision of the recipe; the date range is inclusive.
+type ProductionListFilter struct {
+	RecipeID       domain.Option[domain.RecipeID]
+	OutputItemID   domain.Option[domain.ItemID]
+	FromOccurredOn domain.Option[domain.BusinessDate]
+	ToOccurredOn   domain.Option[domain.BusinessDate]
+	After          domain.Option[ProductionCursor]
+	PageSize       int
+}
+
+type ProductionPage struct {
+	items []PostedProductionDocument
+	next  domain.Option[ProductionCursor]
+}
+
+func (p ProductionPage) Items() []PostedProductionDocument {
+	items := make([]PostedProductionDocument, len(p.items))
+	copy(items, p.items)
+	return items
+}
+
+func (p ProductionPage) Next() domain.Option[ProductionCursor] { return p.next }
+
 type PostProductionInput struct {
 	IdempotencyKey   domain.IdempotencyKey
 	RecipeRevisionID domain.RecipeRevisionID
@@ -181,6 +216,65 @@ func (a ProductionAllocation) ID() domain.LotAllocationID      { return a.id }
 func (a ProductionAllocation) LotID() domain.InventoryLotID    { return a.lotID }
 func (a ProductionAllocation) Quantity() domain.AtomicQuantity { return a.quantity }
 
+func (s *Store) GetPostedProduction(ctx context.Context, id domain.StockDocumentID) (PostedProductionDocument, error) {
+	if id.IsZero() {
+		return PostedProductionDocument{}, domain.Invalid("document_id", domain.ViolationRequired, "DOC-001")
+	}
+	var document PostedProductionDocument
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadPostedProductionDocument(ctx, tx, id.Int64())
+		if err != nil {
+			return err
+		}
+		document = value
+		return nil
+	})
+	if err != nil {
+		return PostedProductionDocument{}, classifyError("get posted production", err)
+	}
+	return document, nil
+}
+
+func (s *Store) ListPostedProductions(ctx context.Context, filter ProductionListFilter) (ProductionPage, error) {
+	pageSize, err := productionPageSize(filter.PageSize)
+	if err != nil {
+		return ProductionPage{}, err
+	}
+	var page ProductionPage
+	err = s.database.Read(ctx, func(tx *database.ReadTx) error {
+		documentIDs, err := listPostedProductionIDs(ctx, tx, filter, pageSize+1)
+		if err != nil {
+			return err
+		}
+		hasMore := len(documentIDs) > pageSize
+		if hasMore {
+			documentIDs = documentIDs[:pageSize]
+		}
+		items := make([]PostedProductionDocument, 0, len(documentIDs))
+		for _, id := range documentIDs {
+			document, err := loadPostedProductionDocument(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			items = append(items, document)
+		}
+		next := domain.None[ProductionCursor]()
+		if hasMore && len(items) > 0 {
+			last := items[len(items)-1]
+			next = domain.Some(ProductionCursor{
+				PostingSequence: last.PostingSequence(),
+				ID:              last.ID(),
+			})
+		}
+		page = ProductionPage{items: items, next: next}
+		return nil
+	})
+	if err != nil {
+		return ProductionPage{}, classifyError("list posted productions", err)
+	}
+	return page, nil
+}
+
 func (s *Store) PostProduction(ctx context.Context, input PostProductionInput) (PostedProductionDocument, error) {
 	var posted PostedProductionDocument
 	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
@@ -197,6 +291,88 @@ func (s *Store) PostProduction(ctx context.Context, input PostProductionInput) (
 	return posted, nil
 }
 
+func productionPageSize(requested int) (int, error) {
+	if requested == 0 {
+		return productionDefaultPageSize, nil
+	}
+	if requested < 1 || requested > productionMaximumPageSize {
+		return 0, domain.Invalid("page_size", domain.ViolationOutOfRange, "")
+	}
+	return requested, nil
+}
+
+func listPostedProductionIDs(
+	ctx context.Context,
+	tx databaseWriteTx,
+	filter ProductionListFilter,
+	limit int,
+) ([]int64, error) {
+	conditions := []string{"document.kind = 'PRODUCTION'"}
+	var args []any
+	if recipeID, ok := filter.RecipeID.Get(); ok {
+		if recipeID.IsZero() {
+			return nil, domain.Invalid("recipe_id", domain.ViolationRequired, "")
+		}
+		conditions = append(conditions, "revision.recipe_id = ?")
+		args = append(args, recipeID.Int64())
+	}
+	if itemID, ok := filter.OutputItemID.Get(); ok {
+		if itemID.IsZero() {
+			return nil, domain.Invalid("output_item_id", domain.ViolationRequired, "")
+		}
+		conditions = append(conditions, "recipe.output_item_id = ?")
+		args = append(args, itemID.Int64())
+	}
+	from, hasFrom := filter.FromOccurredOn.Get()
+	to, hasTo := filter.ToOccurredOn.Get()
+	if hasFrom && hasTo && to.Before(from) {
+		return nil, domain.Invalid("to_occurred_on", domain.ViolationOutOfRange, "")
+	}
+	if hasFrom {
+		conditions = append(conditions, "document.occurred_on >= ?")
+		args = append(args, from.String())
+	}
+	if hasTo {
+		conditions = append(conditions, "document.occurred_on <= ?")
+		args = append(args, to.String())
+	}
+	if cursor, ok := filter.After.Get(); ok {
+		if cursor.PostingSequence.IsZero() || cursor.ID.IsZero() {
+			return nil, domain.Invalid("cursor", domain.ViolationInvalidFormat, "")
+		}
+		conditions = append(conditions, "(document.posting_sequence < ? OR (document.posting_sequence = ? AND document.id < ?))")
+		args = append(args, cursor.PostingSequence.Int64(), cursor.PostingSequence.Int64(), cursor.ID.Int64())
+	}
+	args = append(args, limit)
+	rows, err := tx.QueryContext(ctx, `
+		SELECT document.id
+		FROM stock_documents document
+		JOIN production_runs run ON run.document_id = document.id
+		JOIN recipe_revisions revision ON revision.id = run.recipe_revision_id
+		JOIN recipes recipe ON recipe.id = revision.recipe_id
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY document.posting_sequence DESC, document.id DESC
+		LIMIT ?
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func postProductionTx(ctx context.Context, tx databaseWriteTx, input PostProductionInput) (PostedProductionDocument, error) {
 	if err := validateProductionInput(input); err != nil {
 		return PostedProductionDocument{}, err
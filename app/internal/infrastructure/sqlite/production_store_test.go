@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/jerobas/saas/internal/domain"
+	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
 )
 
 func TestProductionStorePostsProductionConsumesFEFOAndCreatesOutputLot(t *testing.T) {
@@ -275,3 +276,112 @@ func productionInputFixture(
 		},
 	}
 }
+
+func TestProductionStoreListsRunsByPostingSequenceWithFilters(t *testing.T) {
+	store := recipeTestStore(t, "production-list.db")
+	ctx := context.Background()
+	cakeID := recipeTestItem(t, store, "Cake", false, true)
+	breadID := recipeTestItem(t, store, "Bread", false, true)
+	flourID := recipeTestItem(t, store, "Flour", true, false)
+	createRecipe := func(name string, outputID domain.ItemID) recipedomain.Recipe {
+		t.Helper()
+		value, err := store.CreateRecipe(ctx, CreateRecipeInput{
+			Name: recipeName(t, name), OutputItemID: outputID,
+			CreatedAt: recipeInstant(t, 1_000),
+			Revision: recipeRevisionInput(t, 1_000, "mix", []RecipeComponentInput{
+				recipeComponentInput(t, 1, flourID, 100, recipeUnitSource(t, "g")),
+			}),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+	cakeRecipe := createRecipe("Cake recipe", cakeID)
+	breadRecipe := createRecipe("Bread recipe", breadID)
+	postAdjustmentTestPurchase(t, store, flourID, "production-list-stock", "FLOUR", "2026-12-31", 1_000, 1_000)
+
+	post := func(key, occurredOn string, revisionID domain.RecipeRevisionID) PostedProductionDocument {
+		t.Helper()
+		input := productionInputFixture(t, revisionID, flourID, 100)
+		input.IdempotencyKey = mustPurchaseIdempotencyKey(t, key)
+		input.OccurredOn = mustPurchaseDate(t, occurredOn)
+		posted, err := store.PostProduction(ctx, input)
+		if err != nil {
+			t.Fatalf("post %s: %v", key, err)
+		}
+		return posted
+	}
+	firstCake := post("production-list-1", "2026-07-10", cakeRecipe.CurrentRevision().ID())
+	bread := post("production-list-2", "2026-07-12", breadRecipe.CurrentRevision().ID())
+	secondCake := post("production-list-3", "2026-07-15", cakeRecipe.CurrentRevision().ID())
+
+	loaded, err := store.GetPostedProduction(ctx, bread.ID())
+	if err != nil {
+		t.Fatalf("get production: %v", err)
+	}
+	inputs := loaded.InputLines()
+	if loaded.OutputItemID() != breadID || len(inputs) != 1 || len(inputs[0].Allocations()) != 1 ||
+		inputs[0].Allocations()[0].Quantity().Int64() != 100 {
+		t.Fatalf("loaded production = %#v", loaded)
+	}
+	missingID, err := domain.NewStockDocumentID(9_999)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.GetPostedProduction(ctx, missingID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("missing production error = %v, want not found", err)
+	}
+
+	page, err := store.ListPostedProductions(ctx, ProductionListFilter{PageSize: 2})
+	if err != nil {
+		t.Fatalf("list first page: %v", err)
+	}
+	items := page.Items()
+	cursor, ok := page.Next().Get()
+	if len(items) != 2 || items[0].ID() != secondCake.ID() || items[1].ID() != bread.ID() || !ok || cursor.ID != bread.ID() {
+		t.Fatalf("first page = items %#v next %#v", items, page.Next())
+	}
+	nextPage, err := store.ListPostedProductions(ctx, ProductionListFilter{After: domain.Some(cursor), PageSize: 2})
+	if err != nil {
+		t.Fatalf("list second page: %v", err)
+	}
+	if nextItems := nextPage.Items(); len(nextItems) != 1 || nextItems[0].ID() != firstCake.ID() || nextPage.Next().IsSome() {
+		t.Fatalf("second page = items %#v next %#v", nextItems, nextPage.Next())
+	}
+
+	filtered := func(filter ProductionListFilter) []domain.StockDocumentID {
+		t.Helper()
+		page, err := store.ListPostedProductions(ctx, filter)
+		if err != nil {
+			t.Fatalf("list filtered: %v", err)
+		}
+		var ids []domain.StockDocumentID
+		for _, item := range page.Items() {
+			ids = append(ids, item.ID())
+		}
+		return ids
+	}
+	if ids := filtered(ProductionListFilter{RecipeID: domain.Some(cakeRecipe.ID())}); len(ids) != 2 ||
+		ids[0] != secondCake.ID() || ids[1] != firstCake.ID() {
+		t.Fatalf("recipe filter = %v", ids)
+	}
+	if ids := filtered(ProductionListFilter{OutputItemID: domain.Some(breadID)}); len(ids) != 1 || ids[0] != bread.ID() {
+		t.Fatalf("output item filter = %v", ids)
+	}
+	if ids := filtered(ProductionListFilter{
+		FromOccurredOn: domain.Some(mustPurchaseDate(t, "2026-07-11")),
+		ToOccurredOn:   domain.Some(mustPurchaseDate(t, "2026-07-15")),
+		OutputItemID:   domain.Some(cakeID),
+	}); len(ids) != 1 || ids[0] != secondCake.ID() {
+		t.Fatalf("date range filter = %v", ids)
+	}
+
+	_, err = store.ListPostedProductions(ctx, ProductionListFilter{
+		FromOccurredOn: domain.Some(mustPurchaseDate(t, "2026-07-15")),
+		ToOccurredOn:   domain.Some(mustPurchaseDate(t, "2026-07-10")),
+	})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("inverted range error = %v, want validation", err)
+	}
+}
@@ -0,0 +1,824 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+)
+
+const (
+	purchaseOrderDefaultPageSize = 50
+	purchaseOrderMaximumPageSize = 100
+)
+
+type PurchaseOrderLineInput struct {
+	ItemID               domain.ItemID
+	Quantity             domain.AtomicQuantity
+	EnteredUnit          domain.UnitCode
+	EnteredPackagingName domain.Option[domain.NonEmptyText]
+	Conversion           domain.UnitConversion
+	ExpectedTotal        domain.MinorAmount
+}
+
+type CreatePurchaseOrderInput struct {
+	SupplierID domain.CounterpartyID
+	OrderedOn  domain.BusinessDate
+	ExpectedOn domain.Option[domain.BusinessDate]
+	Notes      domain.Option[domain.NonEmptyText]
+	Lines      []PurchaseOrderLineInput
+	CreatedAt  domain.UTCInstant
+}
+
+// UpdatePurchaseOrderInput replaces every editable field and line of a draft.
+type UpdatePurchaseOrderInput struct {
+	ID                domain.PurchaseOrderID
+	SupplierID        domain.CounterpartyID
+	OrderedOn         domain.BusinessDate
+	ExpectedOn        domain.Option[domain.BusinessDate]
+	Notes             domain.Option[domain.NonEmptyText]
+	Lines             []PurchaseOrderLineInput
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+// ChangePurchaseOrderStatusInput sends, cancels, or closes an order. Receipts
+// change status only through ReceivePurchaseOrder.
+type ChangePurchaseOrderStatusInput struct {
+	ID                domain.PurchaseOrderID
+	Status            domain.PurchaseOrderStatus
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+type PurchaseOrderReceiptLineInput struct {
+	LineID   domain.PurchaseOrderLineID
+	Quantity domain.AtomicQuantity
+}
+
+// ReceivePurchaseOrderInput pairs each received order line with the purchase
+// line at the same position. The purchase is posted at Purchase.PostedAt,
+// which also becomes the order's new version.
+type ReceivePurchaseOrderInput struct {
+	ID                domain.PurchaseOrderID
+	ExpectedUpdatedAt domain.UTCInstant
+	Lines             []PurchaseOrderReceiptLineInput
+	Purchase          PostPurchaseInput
+}
+
+type PurchaseOrderListFilter struct {
+	Status   domain.Option[domain.PurchaseOrderStatus]
+	After    domain.Option[domain.PurchaseOrderID]
+	PageSize int
+}
+
+type PurchaseOrderPage struct {
+	items []purchasing.Order
+	next  domain.Option[domain.PurchaseOrderID]
+}
+
+func (p PurchaseOrderPage) Items() []purchasing.Order {
+	items := make([]purchasing.Order, len(p.items))
+	copy(items, p.items)
+	return items
+}
+
+func (p PurchaseOrderPage) Next() domain.Option[domain.PurchaseOrderID] { return p.next }
+
+func (s *Store) GetPurchaseOrder(ctx context.Context, id domain.PurchaseOrderID) (purchasing.Order, error) {
+	if id.IsZero() {
+		return purchasing.Order{}, domain.Invalid("purchase_order_id", domain.ViolationRequired, "")
+	}
+	var order purchasing.Order
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadPurchaseOrder(ctx, tx, id.Int64())
+		if err != nil {
+			return err
+		}
+		order = value
+		return nil
+	})
+	if err != nil {
+		return purchasing.Order{}, classifyError("get purchase order", err)
+	}
+	return order, nil
+}
+
+// ListPurchaseOrders pages orders newest first, optionally by status.
+func (s *Store) ListPurchaseOrders(ctx context.Context, filter PurchaseOrderListFilter) (PurchaseOrderPage, error) {
+	pageSize := filter.PageSize
+	if pageSize == 0 {
+		pageSize = purchaseOrderDefaultPageSize
+	}
+	if pageSize < 1 || pageSize > purchaseOrderMaximumPageSize {
+		return PurchaseOrderPage{}, domain.Invalid("page_size", domain.ViolationOutOfRange, "")
+	}
+	status := ""
+	if value, ok := filter.Status.Get(); ok {
+		if _, err := domain.ParsePurchaseOrderStatus(value.String()); err != nil {
+			return PurchaseOrderPage{}, err
+		}
+		status = value.String()
+	}
+	var afterID int64
+	if after, ok := filter.After.Get(); ok {
+		if after.IsZero() {
+			return PurchaseOrderPage{}, domain.Invalid("cursor", domain.ViolationInvalidFormat, "")
+		}
+		afterID = after.Int64()
+	}
+	var page PurchaseOrderPage
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id
+			FROM purchase_orders
+			WHERE (? = '' OR status = ?)
+			  AND (? = 0 OR id < ?)
+			ORDER BY id DESC
+			LIMIT ?
+		`, status, status, afterID, afterID, pageSize+1)
+		if err != nil {
+			return err
+		}
+		ids, err := scanInt64Rows(rows)
+		if err != nil {
+			return err
+		}
+		hasMore := len(ids) > pageSize
+		if hasMore {
+			ids = ids[:pageSize]
+		}
+		items := make([]purchasing.Order, 0, len(ids))
+		for _, id := range ids {
+			order, err := loadPurchaseOrder(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			items = append(items, order)
+		}
+		next := domain.None[domain.PurchaseOrderID]()
+		if hasMore && len(items) > 0 {
+			next = domain.Some(items[len(items)-1].ID())
+		}
+		page = PurchaseOrderPage{items: items, next: next}
+		return nil
+	})
+	if err != nil {
+		return PurchaseOrderPage{}, classifyError("list purchase orders", err)
+	}
+	return page, nil
+}
+
+// ListPurchaseOrderReceipts returns the purchases posted by an order's
+// receipts in posting order.
+func (s *Store) ListPurchaseOrderReceipts(ctx context.Context, id domain.PurchaseOrderID) ([]PostedPurchaseDocument, error) {
+	if id.IsZero() {
+		return nil, domain.Invalid("purchase_order_id", domain.ViolationRequired, "")
+	}
+	var documents []PostedPurchaseDocument
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		if _, err := loadPurchaseOrder(ctx, tx, id.Int64()); err != nil {
+			return err
+		}
+		rows, err := tx.QueryContext(ctx, `
+			SELECT receipt.document_id
+			FROM purchase_order_receipts receipt
+			JOIN stock_documents document ON document.id = receipt.document_id
+			WHERE receipt.order_id = ?
+			ORDER BY document.posting_sequence
+		`, id.Int64())
+		if err != nil {
+			return err
+		}
+		ids, err := scanInt64Rows(rows)
+		if err != nil {
+			return err
+		}
+		documents = make([]PostedPurchaseDocument, 0, len(ids))
+		for _, documentID := range ids {
+			document, err := loadPostedPurchaseDocument(ctx, tx, documentID)
+			if err != nil {
+				return err
+			}
+			documents = append(documents, document)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list purchase order receipts", err)
+	}
+	return documents, nil
+}
+
+// FindPurchaseOrderReceipt returns the receipt of the order posted with key,
+// if any, so a retried receipt can be answered before it is rebuilt. A key
+// used by any other document is a conflict.
+func (s *Store) FindPurchaseOrderReceipt(
+	ctx context.Context,
+	id domain.PurchaseOrderID,
+	key domain.IdempotencyKey,
+) (domain.Option[PostedPurchaseDocument], error) {
+	if id.IsZero() {
+		return domain.None[PostedPurchaseDocument](), domain.Invalid("purchase_order_id", domain.ViolationRequired, "")
+	}
+	if key.String() == "" {
+		return domain.None[PostedPurchaseDocument](), domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	found := domain.None[PostedPurchaseDocument]()
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		replayed, err := replayPurchaseOrderReceipt(ctx, tx, id.Int64(), key)
+		if err != nil {
+			return err
+		}
+		if documentID, ok := replayed.Get(); ok {
+			document, err := loadPostedPurchaseDocument(ctx, tx, documentID)
+			if err != nil {
+				return err
+			}
+			found = domain.Some(document)
+		}
+		return nil
+	})
+	if err != nil {
+		return domain.None[PostedPurchaseDocument](), classifyError("find purchase order receipt", err)
+	}
+	return found, nil
+}
+
+func (s *Store) CreatePurchaseOrder(ctx context.Context, input CreatePurchaseOrderInput) (purchasing.Order, error) {
+	if err := validatePurchaseOrderHeader(input.SupplierID, input.OrderedOn, input.ExpectedOn, input.Lines); err != nil {
+		return purchasing.Order{}, err
+	}
+	if input.CreatedAt.IsZero() {
+		return purchasing.Order{}, domain.Invalid("created_at", domain.ViolationRequired, "")
+	}
+	var created purchasing.Order
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		if err := requireActiveSupplier(ctx, tx, input.SupplierID.Int64()); err != nil {
+			return err
+		}
+		var id int64
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO purchase_orders (
+				supplier_id, status, ordered_on, expected_on, notes, created_at_ms, updated_at_ms
+			) VALUES (?, 'DRAFT', ?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			input.SupplierID.Int64(),
+			input.OrderedOn.String(),
+			nullableBusinessDate(input.ExpectedOn),
+			nullableText(input.Notes),
+			input.CreatedAt.UnixMilli(),
+			input.CreatedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		if err := insertPurchaseOrderLines(ctx, tx, id, input.Lines); err != nil {
+			return err
+		}
+		value, err := loadPurchaseOrder(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		created = value
+		return nil
+	})
+	if err != nil {
+		return purchasing.Order{}, classifyError("create purchase order", err)
+	}
+	return created, nil
+}
+
+func (s *Store) UpdatePurchaseOrder(ctx context.Context, input UpdatePurchaseOrderInput) (purchasing.Order, error) {
+	if input.ID.IsZero() {
+		return purchasing.Order{}, domain.Invalid("purchase_order_id", domain.ViolationRequired, "")
+	}
+	if err := validatePurchaseOrderHeader(input.SupplierID, input.OrderedOn, input.ExpectedOn, input.Lines); err != nil {
+		return purchasing.Order{}, err
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return purchasing.Order{}, err
+	}
+	var updated purchasing.Order
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadPurchaseOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: purchase order version changed", domain.ErrStale)
+		}
+		if !current.IsEditable() {
+			return fmt.Errorf("%w: purchase order is %s and can no longer be edited", domain.ErrConflict, current.Status())
+		}
+		if err := requireActiveSupplier(ctx, tx, input.SupplierID.Int64()); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE purchase_orders
+			SET supplier_id = ?, ordered_on = ?, expected_on = ?, notes = ?, updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`,
+			input.SupplierID.Int64(),
+			input.OrderedOn.String(),
+			nullableBusinessDate(input.ExpectedOn),
+			nullableText(input.Notes),
+			input.UpdatedAt.UnixMilli(),
+			input.ID.Int64(),
+			input.ExpectedUpdatedAt.UnixMilli(),
+		); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM purchase_order_lines WHERE order_id = ?`, input.ID.Int64()); err != nil {
+			return err
+		}
+		if err := insertPurchaseOrderLines(ctx, tx, input.ID.Int64(), input.Lines); err != nil {
+			return err
+		}
+		value, err := loadPurchaseOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		updated = value
+		return nil
+	})
+	if err != nil {
+		return purchasing.Order{}, classifyError("update purchase order", err)
+	}
+	return updated, nil
+}
+
+func (s *Store) ChangePurchaseOrderStatus(ctx context.Context, input ChangePurchaseOrderStatusInput) (purchasing.Order, error) {
+	if input.ID.IsZero() {
+		return purchasing.Order{}, domain.Invalid("purchase_order_id", domain.ViolationRequired, "")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return purchasing.Order{}, err
+	}
+	var changed purchasing.Order
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadPurchaseOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: purchase order version changed", domain.ErrStale)
+		}
+		allowed := false
+		switch input.Status {
+		case domain.PurchaseOrderSent:
+			allowed = current.CanSend()
+		case domain.PurchaseOrderCancelled:
+			allowed = current.CanCancel()
+		case domain.PurchaseOrderClosed:
+			allowed = current.CanClose()
+		default:
+			return domain.Invalid("status", domain.ViolationInvalidEnum, "POR-002")
+		}
+		if !allowed {
+			return fmt.Errorf("%w: purchase order cannot move from %s to %s", domain.ErrConflict, current.Status(), input.Status)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE purchase_orders SET status = ?, updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`, input.Status.String(), input.UpdatedAt.UnixMilli(), input.ID.Int64(), input.ExpectedUpdatedAt.UnixMilli()); err != nil {
+			return err
+		}
+		value, err := loadPurchaseOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		changed = value
+		return nil
+	})
+	if err != nil {
+		return purchasing.Order{}, classifyError("change purchase order status", err)
+	}
+	return changed, nil
+}
+
+// ReceivePurchaseOrder posts the receipt purchase, links it to the order, and
+// advances received quantities and status in one transaction. Retrying with
+// the same idempotency key returns the earlier receipt unchanged.
+func (s *Store) ReceivePurchaseOrder(
+	ctx context.Context,
+	input ReceivePurchaseOrderInput,
+) (purchasing.Order, PostedPurchaseDocument, error) {
+	if input.ID.IsZero() {
+		return purchasing.Order{}, PostedPurchaseDocument{}, domain.Invalid("purchase_order_id", domain.ViolationRequired, "")
+	}
+	if len(input.Lines) == 0 || len(input.Lines) != len(input.Purchase.Lines) {
+		return purchasing.Order{}, PostedPurchaseDocument{}, domain.Invalid("lines", domain.ViolationRequired, "POR-003")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.Purchase.PostedAt); err != nil {
+		return purchasing.Order{}, PostedPurchaseDocument{}, err
+	}
+	var received purchasing.Order
+	var posted PostedPurchaseDocument
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		replayed, err := replayPurchaseOrderReceipt(ctx, tx, input.ID.Int64(), input.Purchase.IdempotencyKey)
+		if err != nil {
+			return err
+		}
+		if documentID, ok := replayed.Get(); ok {
+			if posted, err = loadPostedPurchaseDocument(ctx, tx, documentID); err != nil {
+				return err
+			}
+			received, err = loadPurchaseOrder(ctx, tx, input.ID.Int64())
+			return err
+		}
+
+		current, err := loadPurchaseOrder(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: purchase order version changed", domain.ErrStale)
+		}
+		if !current.CanReceive() {
+			return fmt.Errorf("%w: purchase order is %s and cannot be received", domain.ErrConflict, current.Status())
+		}
+		if err := validatePurchaseOrderReceipt(current, input); err != nil {
+			return err
+		}
+		if posted, err = postPurchaseTx(ctx, tx, input.Purchase); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO purchase_order_receipts (document_id, order_id) VALUES (?, ?)
+		`, posted.ID().Int64(), input.ID.Int64()); err != nil {
+			return err
+		}
+		for _, line := range input.Lines {
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE purchase_order_lines
+				SET received_quantity_atomic = received_quantity_atomic + ?
+				WHERE id = ? AND order_id = ?
+			`, line.Quantity.Int64(), line.LineID.Int64(), input.ID.Int64()); err != nil {
+				return err
+			}
+		}
+		lines, err := loadPurchaseOrderLines(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE purchase_orders SET status = ?, updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`,
+			purchasing.ReceiptStatus(lines).String(),
+			input.Purchase.PostedAt.UnixMilli(),
+			input.ID.Int64(),
+			input.ExpectedUpdatedAt.UnixMilli(),
+		); err != nil {
+			return err
+		}
+		received, err = loadPurchaseOrder(ctx, tx, input.ID.Int64())
+		return err
+	})
+	if err != nil {
+		return purchasing.Order{}, PostedPurchaseDocument{}, classifyError("receive purchase order", err)
+	}
+	return received, posted, nil
+}
+
+func validatePurchaseOrderHeader(
+	supplierID domain.CounterpartyID,
+	orderedOn domain.BusinessDate,
+	expectedOn domain.Option[domain.BusinessDate],
+	lines []PurchaseOrderLineInput,
+) error {
+	if supplierID.IsZero() {
+		return domain.Invalid("supplier_id", domain.ViolationRequired, "POR-001")
+	}
+	if orderedOn.IsZero() {
+		return domain.Invalid("ordered_on", domain.ViolationRequired, "")
+	}
+	if value, ok := expectedOn.Get(); ok && value.Before(orderedOn) {
+		return domain.Invalid("expected_on", domain.ViolationOutOfRange, "POR-001")
+	}
+	if len(lines) == 0 {
+		return domain.Invalid("lines", domain.ViolationRequired, "POR-001")
+	}
+	for index, line := range lines {
+		field := fmt.Sprintf("lines[%d]", index)
+		switch {
+		case line.ItemID.IsZero():
+			return domain.Invalid(field+".item_id", domain.ViolationRequired, "POR-001")
+		case line.Quantity.Int64() <= 0:
+			return domain.Invalid(field+".quantity_atomic", domain.ViolationNotPositive, "POR-001")
+		case line.EnteredUnit.String() == "":
+			return domain.Invalid(field+".entered_unit_code", domain.ViolationRequired, "POR-001")
+		case line.Conversion.IsZero():
+			return domain.Invalid(field+".conversion", domain.ViolationRequired, "POR-001")
+		case line.ExpectedTotal.Int64() <= 0:
+			return domain.Invalid(field+".expected_total_minor", domain.ViolationNotPositive, "POR-001")
+		}
+	}
+	return nil
+}
+
+// validatePurchaseOrderReceipt checks that every received line belongs to the
+// order, is received once, stays within its outstanding quantity, and matches
+// the item and quantity of its purchase line, and that the purchase is from
+// the order's supplier.
+func validatePurchaseOrderReceipt(order purchasing.Order, input ReceivePurchaseOrderInput) error {
+	supplierID, ok := input.Purchase.CounterpartyID.Get()
+	if !ok || supplierID != order.SupplierID() {
+		return domain.Invalid("counterparty_id", domain.ViolationInvariant, "POR-003")
+	}
+	lines := make(map[domain.PurchaseOrderLineID]purchasing.OrderLine, len(order.Lines()))
+	for _, line := range order.Lines() {
+		lines[line.ID()] = line
+	}
+	seen := make(map[domain.PurchaseOrderLineID]struct{}, len(input.Lines))
+	for index, receipt := range input.Lines {
+		field := fmt.Sprintf("lines[%d]", index)
+		line, ok := lines[receipt.LineID]
+		if !ok {
+			return fmt.Errorf("%w: purchase order line %s is not on this order", domain.ErrInvalidReference, receipt.LineID)
+		}
+		if _, duplicate := seen[receipt.LineID]; duplicate {
+			return domain.Invalid(field+".line_id", domain.ViolationDuplicate, "POR-003")
+		}
+		seen[receipt.LineID] = struct{}{}
+		if receipt.Quantity.Int64() <= 0 || receipt.Quantity.Int64() > line.OutstandingQuantity().Int64() {
+			return domain.Invalid(field+".quantity_atomic", domain.ViolationOutOfRange, "POR-003")
+		}
+		purchaseLine := input.Purchase.Lines[index]
+		if purchaseLine.ItemID != line.ItemID() || purchaseLine.Quantity != receipt.Quantity {
+			return domain.Invalid(field, domain.ViolationInvariant, "POR-003")
+		}
+	}
+	return nil
+}
+
+// replayPurchaseOrderReceipt finds a purchase already posted with key. It is
+// a replay only when that purchase is a receipt of the same order.
+func replayPurchaseOrderReceipt(
+	ctx context.Context,
+	tx databaseWriteTx,
+	orderID int64,
+	key domain.IdempotencyKey,
+) (domain.Option[int64], error) {
+	var documentID int64
+	var receiptOrderID sql.NullInt64
+	err := tx.QueryRowContext(ctx, `
+		SELECT document.id, receipt.order_id
+		FROM stock_documents document
+		LEFT JOIN purchase_order_receipts receipt ON receipt.document_id = document.id
+		WHERE document.idempotency_key = ?
+	`, key.String()).Scan(&documentID, &receiptOrderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.None[int64](), nil
+	}
+	if err != nil {
+		return domain.None[int64](), err
+	}
+	if !receiptOrderID.Valid || receiptOrderID.Int64 != orderID {
+		return domain.None[int64](), fmt.Errorf("%w: idempotency key belongs to another document", domain.ErrConflict)
+	}
+	return domain.Some(documentID), nil
+}
+
+// requireActiveSupplier reports a missing, archived, or non-supplier
+// counterparty as a bad reference before the insert trigger rejects it.
+func requireActiveSupplier(ctx context.Context, tx databaseWriteTx, id int64) error {
+	var supplier bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1
+			FROM counterparties counterparty
+			JOIN counterparty_roles role ON role.counterparty_id = counterparty.id
+			WHERE counterparty.id = ?
+			  AND counterparty.archived_at_ms IS NULL
+			  AND role.role = 'SUPPLIER'
+		)
+	`, id).Scan(&supplier)
+	if err != nil {
+		return err
+	}
+	if !supplier {
+		return fmt.Errorf("%w: counterparty is not an active supplier", domain.ErrInvalidReference)
+	}
+	return nil
+}
+
+func insertPurchaseOrderLines(ctx context.Context, tx databaseWriteTx, orderID int64, lines []PurchaseOrderLineInput) error {
+	for index, line := range lines {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO purchase_order_lines (
+				order_id, line_order, item_id, quantity_atomic, entered_unit_code,
+				entered_packaging_name, conversion_numerator_atomic, conversion_denominator,
+				expected_total_minor
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			orderID,
+			int64(index+1),
+			line.ItemID.Int64(),
+			line.Quantity.Int64(),
+			line.EnteredUnit.String(),
+			nullableText(line.EnteredPackagingName),
+			line.Conversion.NumeratorAtomic(),
+			line.Conversion.Denominator(),
+			line.ExpectedTotal.Int64(),
+		); err != nil {
+			return fmt.Errorf("line %d: %w", index+1, err)
+		}
+	}
+	return nil
+}
+
+func loadPurchaseOrder(ctx context.Context, tx databaseWriteTx, id int64) (purchasing.Order, error) {
+	var row purchaseOrderRow
+	if err := tx.QueryRowContext(ctx, `
+		SELECT id, supplier_id, status, ordered_on, expected_on, notes, created_at_ms, updated_at_ms
+		FROM purchase_orders
+		WHERE id = ?
+	`, id).Scan(
+		&row.id,
+		&row.supplierID,
+		&row.status,
+		&row.orderedOn,
+		&row.expectedOn,
+		&row.notes,
+		&row.createdAtMS,
+		&row.updatedAtMS,
+	); err != nil {
+		return purchasing.Order{}, err
+	}
+	lines, err := loadPurchaseOrderLines(ctx, tx, id)
+	if err != nil {
+		return purchasing.Order{}, err
+	}
+	order, err := mapPurchaseOrder(row, lines)
+	if err != nil {
+		return purchasing.Order{}, corruptDataError("map purchase order", err)
+	}
+	return order, nil
+}
+
+type purchaseOrderRow struct {
+	id, supplierID, createdAtMS, updatedAtMS int64
+	status, orderedOn                        string
+	expectedOn, notes                        sql.NullString
+}
+
+func loadPurchaseOrderLines(ctx context.Context, tx databaseWriteTx, orderID int64) ([]purchasing.OrderLine, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, line_order, item_id, quantity_atomic, entered_unit_code,
+		       entered_packaging_name, conversion_numerator_atomic, conversion_denominator,
+		       expected_total_minor, received_quantity_atomic
+		FROM purchase_order_lines
+		WHERE order_id = ?
+		ORDER BY line_order, id
+	`, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []purchasing.OrderLine
+	for rows.Next() {
+		var row purchaseOrderLineRow
+		if err := rows.Scan(
+			&row.id,
+			&row.lineOrder,
+			&row.itemID,
+			&row.quantityAtomic,
+			&row.enteredUnitCode,
+			&row.enteredPackagingName,
+			&row.conversionNumeratorAtomic,
+			&row.conversionDenominator,
+			&row.expectedTotalMinor,
+			&row.receivedQuantityAtomic,
+		); err != nil {
+			return nil, err
+		}
+		line, err := mapPurchaseOrderLine(orderID, row)
+		if err != nil {
+			return nil, corruptDataError("map purchase order line", err)
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+type purchaseOrderLineRow struct {
+	id, lineOrder, itemID, quantityAtomic            int64
+	conversionNumeratorAtomic, conversionDenominator int64
+	expectedTotalMinor, receivedQuantityAtomic       int64
+	enteredUnitCode                                  string
+	enteredPackagingName                             sql.NullString
+}
+
+func mapPurchaseOrder(row purchaseOrderRow, lines []purchasing.OrderLine) (purchasing.Order, error) {
+	id, err := domain.NewPurchaseOrderID(row.id)
+	if err != nil {
+		return purchasing.Order{}, err
+	}
+	supplierID, err := domain.NewCounterpartyID(row.supplierID)
+	if err != nil {
+		return purchasing.Order{}, err
+	}
+	status, err := domain.ParsePurchaseOrderStatus(row.status)
+	if err != nil {
+		return purchasing.Order{}, err
+	}
+	orderedOn, err := domain.ParseBusinessDate(row.orderedOn)
+	if err != nil {
+		return purchasing.Order{}, err
+	}
+	expectedOn, err := optionalBusinessDate(row.expectedOn)
+	if err != nil {
+		return purchasing.Order{}, err
+	}
+	notes, err := optionalNonEmptyText(row.notes)
+	if err != nil {
+		return purchasing.Order{}, err
+	}
+	createdAt, err := domain.UTCInstantFromUnixMilli(row.createdAtMS)
+	if err != nil {
+		return purchasing.Order{}, err
+	}
+	updatedAt, err := domain.UTCInstantFromUnixMilli(row.updatedAtMS)
+	if err != nil {
+		return purchasing.Order{}, err
+	}
+	return purchasing.NewOrder(purchasing.OrderParams{
+		ID: id, SupplierID: supplierID, Status: status, OrderedOn: orderedOn,
+		ExpectedOn: expectedOn, Notes: notes, CreatedAt: createdAt, UpdatedAt: updatedAt,
+		Lines: lines,
+	})
+}
+
+func mapPurchaseOrderLine(orderID int64, row purchaseOrderLineRow) (purchasing.OrderLine, error) {
+	id, err := domain.NewPurchaseOrderLineID(row.id)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	order, err := domain.NewPurchaseOrderID(orderID)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	lineOrder, err := domain.NewLineOrder(row.lineOrder)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	itemID, err := domain.NewItemID(row.itemID)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	quantity, err := domain.NewPositiveAtomicQuantity(row.quantityAtomic)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	enteredUnit, err := domain.NewUnitCode(row.enteredUnitCode)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	enteredPackagingName, err := optionalNonEmptyText(row.enteredPackagingName)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	conversion, err := domain.NewUnitConversion(row.conversionNumeratorAtomic, row.conversionDenominator)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	expectedTotal, err := domain.NewMinorAmount(row.expectedTotalMinor)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	receivedQuantity, err := domain.NewAtomicQuantity(row.receivedQuantityAtomic)
+	if err != nil {
+		return purchasing.OrderLine{}, err
+	}
+	return purchasing.NewOrderLine(purchasing.OrderLineParams{
+		ID: id, OrderID: order, LineOrder: lineOrder, ItemID: itemID, Quantity: quantity,
+		EnteredUnit: enteredUnit, EnteredPackagingName: enteredPackagingName,
+		Conversion: conversion, ExpectedTotal: expectedTotal, ReceivedQuantity: receivedQuantity,
+	})
+}
+
+func scanInt64Rows(rows *sql.Rows) ([]int64, error) {
+	defer rows.Close()
+	var values []int64
+	for rows.Next() {
+		var value int64
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/catalog"
+)
+
+func TestPurchaseOrderStoreReceivesIntoPurchaseOnlyOnReceipt(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "purchase-orders.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+
+	flour := createCatalogItem(t, store, CreateItemInput{
+		Name:         mustCatalogName(t, "Flour"),
+		BaseUnit:     mustCatalogUnitCode(t, "g"),
+		Capabilities: catalog.NewCapabilities(true, false, false),
+		CreatedAt:    mustCatalogInstant(t, 1_000),
+		UpdatedAt:    mustCatalogInstant(t, 1_000),
+	}).Item().ID()
+	supplier, err := store.CreateCounterparty(ctx, CreateCounterpartyInput{
+		Name:      counterpartyName(t, "Mill"),
+		Roles:     counterpartyRoles(t, domain.RoleSupplier),
+		CreatedAt: counterpartyInstant(t, 1_000),
+	})
+	if err != nil {
+		t.Fatalf("create supplier: %v", err)
+	}
+	customer, err := store.CreateCounterparty(ctx, CreateCounterpartyInput{
+		Name:      counterpartyName(t, "Market"),
+		Roles:     counterpartyRoles(t, domain.RoleCustomer),
+		CreatedAt: counterpartyInstant(t, 1_000),
+	})
+	if err != nil {
+		t.Fatalf("create customer: %v", err)
+	}
+	line := PurchaseOrderLineInput{
+		ItemID:               flour,
+		Quantity:             mustPurchaseQuantity(t, 10_000_000),
+		EnteredUnit:          mustCatalogUnitCode(t, "kg"),
+		EnteredPackagingName: domain.Some(counterpartyText(t, "Sack")),
+		Conversion:           mustCatalogConversion(t, 5_000_000, 1),
+		ExpectedTotal:        mustPurchaseMinorAmount(t, 4_000),
+	}
+	input := CreatePurchaseOrderInput{
+		SupplierID: customer.ID(),
+		OrderedOn:  mustPurchaseDate(t, "2026-07-10"),
+		Lines:      []PurchaseOrderLineInput{line},
+		CreatedAt:  mustCatalogInstant(t, 2_000),
+	}
+	if _, err := store.CreatePurchaseOrder(ctx, input); !errors.Is(err, domain.ErrInvalidReference) {
+		t.Fatalf("customer order error = %v, want invalid reference", err)
+	}
+	input.SupplierID = supplier.ID()
+	order, err := store.CreatePurchaseOrder(ctx, input)
+	if err != nil {
+		t.Fatalf("create purchase order: %v", err)
+	}
+	if order.Status() != domain.PurchaseOrderDraft || len(order.Lines()) != 1 {
+		t.Fatalf("created order = %#v", order)
+	}
+	balance, err := store.GetInventoryBalance(ctx, flour)
+	if err != nil || !balance.Balance().Quantity().IsZero() {
+		t.Fatalf("balance after order = %#v, %v", balance, err)
+	}
+
+	receive := func(key string, at int64, expected domain.UTCInstant, quantity int64) ReceivePurchaseOrderInput {
+		orderLine := order.Lines()[0]
+		return ReceivePurchaseOrderInput{
+			ID:                order.ID(),
+			ExpectedUpdatedAt: expected,
+			Lines:             []PurchaseOrderReceiptLineInput{{LineID: orderLine.ID(), Quantity: mustPurchaseQuantity(t, quantity)}},
+			Purchase: PostPurchaseInput{
+				IdempotencyKey: mustPurchaseIdempotencyKey(t, key),
+				CounterpartyID: domain.Some(supplier.ID()),
+				OccurredOn:     mustPurchaseDate(t, "2026-07-12"),
+				PostedAt:       mustCatalogInstant(t, at),
+				Lines: []PostPurchaseLineInput{{
+					ItemID:               flour,
+					Quantity:             mustPurchaseQuantity(t, quantity),
+					EnteredUnit:          orderLine.EnteredUnit(),
+					EnteredPackagingName: orderLine.EnteredPackagingName(),
+					Conversion:           orderLine.Conversion(),
+					CommercialTotal:      mustPurchaseMinorAmount(t, 1_600),
+				}},
+			},
+		}
+	}
+	if _, _, err := store.ReceivePurchaseOrder(ctx, receive("draft-receipt", 3_000, order.UpdatedAt(), 4_000_000)); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("draft receipt error = %v, want conflict", err)
+	}
+	order, err = store.ChangePurchaseOrderStatus(ctx, ChangePurchaseOrderStatusInput{
+		ID: order.ID(), Status: domain.PurchaseOrderSent,
+		ExpectedUpdatedAt: order.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 3_000),
+	})
+	if err != nil || order.Status() != domain.PurchaseOrderSent {
+		t.Fatalf("send order = %#v, %v", order, err)
+	}
+	if _, err := store.UpdatePurchaseOrder(ctx, UpdatePurchaseOrderInput{
+		ID: order.ID(), SupplierID: supplier.ID(), OrderedOn: input.OrderedOn, Lines: input.Lines,
+		ExpectedUpdatedAt: order.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 3_500),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("edit sent order error = %v, want conflict", err)
+	}
+	if _, _, err := store.ReceivePurchaseOrder(ctx, receive("over-receipt", 4_000, order.UpdatedAt(), 10_000_001)); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("over receipt error = %v, want validation", err)
+	}
+
+	first := receive("receipt-1", 4_000, order.UpdatedAt(), 4_000_000)
+	received, posted, err := store.ReceivePurchaseOrder(ctx, first)
+	if err != nil {
+		t.Fatalf("receive purchase order: %v", err)
+	}
+	if received.Status() != domain.PurchaseOrderPartiallyReceived ||
+		received.Lines()[0].ReceivedQuantity().Int64() != 4_000_000 ||
+		!received.UpdatedAt().Equal(mustCatalogInstant(t, 4_000)) {
+		t.Fatalf("received order = %#v", received)
+	}
+	if counterpartyID, ok := posted.CounterpartyID().Get(); !ok || counterpartyID != supplier.ID() ||
+		posted.Lines()[0].Quantity().Int64() != 4_000_000 {
+		t.Fatalf("receipt purchase = %#v", posted)
+	}
+	balance, err = store.GetInventoryBalance(ctx, flour)
+	if err != nil || balance.Balance().Quantity().Int64() != 4_000_000 {
+		t.Fatalf("balance after receipt = %#v, %v", balance, err)
+	}
+
+	replayed, replayedPurchase, err := store.ReceivePurchaseOrder(ctx, first)
+	if err != nil || replayedPurchase.ID() != posted.ID() || replayed.Lines()[0].ReceivedQuantity().Int64() != 4_000_000 {
+		t.Fatalf("replayed receipt = %#v, %#v, %v", replayed, replayedPurchase, err)
+	}
+	if _, err := store.PostPurchase(ctx, PostPurchaseInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "loose-purchase"),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-12"),
+		PostedAt:       mustCatalogInstant(t, 4_500),
+		Lines:          first.Purchase.Lines,
+	}); err != nil {
+		t.Fatalf("post loose purchase: %v", err)
+	}
+	if _, _, err := store.ReceivePurchaseOrder(ctx, receive("loose-purchase", 5_000, received.UpdatedAt(), 1_000_000)); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("foreign idempotency key error = %v, want conflict", err)
+	}
+
+	receipts, err := store.ListPurchaseOrderReceipts(ctx, order.ID())
+	if err != nil || len(receipts) != 1 || receipts[0].ID() != posted.ID() {
+		t.Fatalf("receipts = %#v, %v", receipts, err)
+	}
+	if _, err := store.ChangePurchaseOrderStatus(ctx, ChangePurchaseOrderStatusInput{
+		ID: order.ID(), Status: domain.PurchaseOrderCancelled,
+		ExpectedUpdatedAt: received.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 6_000),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("cancel received order error = %v, want conflict", err)
+	}
+	closed, err := store.ChangePurchaseOrderStatus(ctx, ChangePurchaseOrderStatusInput{
+		ID: order.ID(), Status: domain.PurchaseOrderClosed,
+		ExpectedUpdatedAt: received.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 6_000),
+	})
+	if err != nil || closed.Status() != domain.PurchaseOrderClosed {
+		t.Fatalf("close order = %#v, %v", closed, err)
+	}
+
+	page, err := store.ListPurchaseOrders(ctx, PurchaseOrderListFilter{Status: domain.Some(domain.PurchaseOrderClosed)})
+	if err != nil || len(page.Items()) != 1 || page.Items()[0].ID() != order.ID() {
+		t.Fatalf("closed orders = %#v, %v", page.Items(), err)
+	}
+	page, err = store.ListPurchaseOrders(ctx, PurchaseOrderListFilter{Status: domain.Some(domain.PurchaseOrderSent)})
+	if err != nil || len(page.Items()) != 0 {
+		t.Fatalf("sent orders = %#v, %v", page.Items(), err)
+	}
+}
@@ -14,7 +14,8 @@ SELECT
     reorder_quantity_atomic,
     created_at_ms,
     updated_at_ms,
-    archived_at_ms
+    archived_at_ms,
+    category_id
 FROM items
 WHERE id = sqlc.arg(id);
 
@@ -34,7 +35,8 @@ SELECT
     reorder_quantity_atomic,
     created_at_ms,
     updated_at_ms,
-    archived_at_ms
+    archived_at_ms,
+    category_id
 FROM items
 WHERE
     (
@@ -72,6 +74,7 @@ INSERT INTO items (
     is_purchasable,
     is_producible,
     is_sellable,
+    category_id,
     default_sale_price_minor,
     reorder_quantity_atomic,
     created_at_ms,
@@ -87,6 +90,7 @@ INSERT INTO items (
     sqlc.arg(is_purchasable),
     sqlc.arg(is_producible),
     sqlc.arg(is_sellable),
+    sqlc.narg(category_id),
     sqlc.narg(default_sale_price_minor),
     sqlc.narg(reorder_quantity_atomic),
     sqlc.arg(created_at_ms),
@@ -107,6 +111,7 @@ SET
     is_purchasable = sqlc.arg(is_purchasable),
     is_producible = sqlc.arg(is_producible),
     is_sellable = sqlc.arg(is_sellable),
+    category_id = sqlc.narg(category_id),
     default_sale_price_minor = sqlc.narg(default_sale_price_minor),
     reorder_quantity_atomic = sqlc.narg(reorder_quantity_atomic),
     updated_at_ms = sqlc.arg(updated_at_ms)
@@ -232,3 +237,79 @@ SET
 WHERE id = sqlc.arg(id)
   AND archived_at_ms IS NOT NULL
   AND updated_at_ms = sqlc.arg(expected_updated_at_ms);
+
+-- name: GetItemCategory :one
+SELECT
+    id,
+    name,
+    normalized_name,
+    created_at_ms,
+    updated_at_ms,
+    archived_at_ms
+FROM item_categories
+WHERE id = sqlc.arg(id);
+
+-- name: ListItemCategories :many
+SELECT
+    id,
+    name,
+    normalized_name,
+    created_at_ms,
+    updated_at_ms,
+    archived_at_ms
+FROM item_categories
+WHERE
+    CAST(sqlc.arg(archive_filter) AS INTEGER) = 2
+    OR (CAST(sqlc.arg(archive_filter) AS INTEGER) = 0 AND archived_at_ms IS NULL)
+    OR (CAST(sqlc.arg(archive_filter) AS INTEGER) = 1 AND archived_at_ms IS NOT NULL)
+ORDER BY normalized_name, id;
+
+-- name: InsertItemCategory :one
+INSERT INTO item_categories (
+    name,
+    normalized_name,
+    created_at_ms,
+    updated_at_ms,
+    archived_at_ms
+) VALUES (
+    sqlc.arg(name),
+    sqlc.arg(normalized_name),
+    sqlc.arg(created_at_ms),
+    sqlc.arg(updated_at_ms),
+    NULL
+)
+RETURNING id;
+
+-- name: UpdateItemCategory :execrows
+UPDATE item_categories
+SET
+    name = sqlc.arg(name),
+    normalized_name = sqlc.arg(normalized_name),
+    updated_at_ms = sqlc.arg(updated_at_ms)
+WHERE id = sqlc.arg(id)
+  AND archived_at_ms IS NULL
+  AND updated_at_ms = sqlc.arg(expected_updated_at_ms);
+
+-- name: ArchiveItemCategory :execrows
+UPDATE item_categories
+SET
+    archived_at_ms = CAST(sqlc.arg(archived_at_ms) AS INTEGER),
+    updated_at_ms = sqlc.arg(updated_at_ms)
+WHERE id = sqlc.arg(id)
+  AND archived_at_ms IS NULL
+  AND updated_at_ms = sqlc.arg(expected_updated_at_ms);
+
+-- name: RestoreItemCategory :execrows
+UPDATE item_categories
+SET
+    archived_at_ms = NULL,
+    updated_at_ms = sqlc.arg(updated_at_ms)
+WHERE id = sqlc.arg(id)
+  AND archived_at_ms IS NOT NULL
+  AND updated_at_ms = sqlc.arg(expected_updated_at_ms);
+
+-- name: CountActiveItemsInCategory :one
+SELECT CAST(COUNT(*) AS INTEGER) AS item_count
+FROM items
+WHERE category_id = sqlc.arg(category_id)
+  AND archived_at_ms IS NULL;
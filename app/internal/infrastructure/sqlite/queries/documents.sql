@@ -0,0 +1,83 @@
+-- name: ListStockDocumentSummaries :many
+SELECT
+    document.id,
+    document.kind,
+    document.idempotency_key,
+    document.posting_sequence,
+    document.counterparty_id,
+    document.occurred_on,
+    document.posted_at_ms,
+    document.currency_code,
+    document.currency_minor_digits,
+    document.reason_code,
+    document.notes,
+    document.reverses_document_id,
+    reversal.id AS reversed_by_document_id,
+    CAST((
+        SELECT COUNT(*)
+        FROM stock_document_lines line
+        WHERE line.document_id = document.id
+    ) AS INTEGER) AS line_count,
+    CAST((
+        SELECT COALESCE(SUM(line.inventory_value_micro), 0)
+        FROM stock_document_lines line
+        WHERE line.document_id = document.id AND line.direction = 'IN'
+    ) AS INTEGER) AS inventory_value_in_micro,
+    CAST((
+        SELECT COALESCE(SUM(line.inventory_value_micro), 0)
+        FROM stock_document_lines line
+        WHERE line.document_id = document.id AND line.direction = 'OUT'
+    ) AS INTEGER) AS inventory_value_out_micro,
+    CAST((
+        SELECT COUNT(line.commercial_total_minor)
+        FROM stock_document_lines line
+        WHERE line.document_id = document.id
+    ) AS INTEGER) AS commercial_line_count,
+    CAST((
+        SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+        FROM stock_document_lines line
+        WHERE line.document_id = document.id
+    ) AS INTEGER) AS commercial_total_minor
+FROM stock_documents document
+LEFT JOIN stock_documents reversal
+    ON reversal.kind = 'REVERSAL'
+   AND reversal.reverses_document_id = document.id
+WHERE
+    (CAST(sqlc.arg(kind_filter) AS TEXT) = '' OR document.kind = CAST(sqlc.arg(kind_filter) AS TEXT))
+    AND (
+        CAST(sqlc.arg(reason_filter) AS TEXT) = ''
+        OR document.reason_code = CAST(sqlc.arg(reason_filter) AS TEXT)
+    )
+    AND (
+        CAST(sqlc.arg(counterparty_id) AS INTEGER) = 0
+        OR document.counterparty_id = CAST(sqlc.arg(counterparty_id) AS INTEGER)
+    )
+    AND (
+        CAST(sqlc.arg(from_occurred_on) AS TEXT) = ''
+        OR document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
+    )
+    AND (
+        CAST(sqlc.arg(to_occurred_on) AS TEXT) = ''
+        OR document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
+    )
+    AND (
+        CAST(sqlc.arg(reversal_filter) AS INTEGER) = 0
+        OR (CAST(sqlc.arg(reversal_filter) AS INTEGER) = 1 AND reversal.id IS NOT NULL)
+        OR (CAST(sqlc.arg(reversal_filter) AS INTEGER) = 2 AND reversal.id IS NULL)
+    )
+    -- Notes are searched without regard to ASCII case; SQLite lower() leaves
+    -- accented letters as typed.
+    AND (
+        CAST(sqlc.arg(search_text) AS TEXT) = ''
+        OR instr(lower(COALESCE(document.notes, '')), lower(CAST(sqlc.arg(search_text) AS TEXT))) > 0
+    )
+    AND (
+        CAST(sqlc.arg(after_posting_sequence) AS INTEGER) = 0
+        OR document.posting_sequence < CAST(sqlc.arg(after_posting_sequence) AS INTEGER)
+        OR (
+            document.posting_sequence = CAST(sqlc.arg(after_posting_sequence) AS INTEGER)
+            AND document.id < sqlc.arg(after_id)
+        )
+    )
+ORDER BY document.posting_sequence DESC, document.id DESC
+LIMIT sqlc.arg(limit_count);
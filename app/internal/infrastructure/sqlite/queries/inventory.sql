@@ -27,9 +27,13 @@ SELECT
     balance.quantity_atomic,
     balance.inventory_value_micro,
     balance.last_document_id,
-    balance.updated_at_ms
+    balance.updated_at_ms,
+    CAST(COALESCE(location_balance.quantity_atomic, 0) AS INTEGER) AS location_quantity_atomic
 FROM inventory_balances balance
 JOIN items item ON item.id = balance.item_id
+LEFT JOIN inventory_location_balances location_balance
+  ON location_balance.item_id = balance.item_id
+ AND location_balance.location_id = CAST(sqlc.arg(location_id) AS INTEGER)
 WHERE
     (CAST(sqlc.arg(include_archived) AS INTEGER) = 1 OR item.archived_at_ms IS NULL)
     AND (
@@ -58,6 +62,8 @@ WITH lot_facts AS (
         lot.originated_on,
         lot.expires_on,
         lot.created_at_ms,
+        lot.location_id,
+        lot.transferred_from_lot_id,
         source_document.id AS source_document_id,
         source_document.kind AS source_document_kind,
         source_document.posting_sequence AS source_posting_sequence,
@@ -93,6 +99,8 @@ WITH lot_facts AS (
         lot.originated_on,
         lot.expires_on,
         lot.created_at_ms,
+        lot.location_id,
+        lot.transferred_from_lot_id,
         source_document.id,
         source_document.kind,
         source_document.posting_sequence,
@@ -110,6 +118,8 @@ SELECT
     originated_on,
     expires_on,
     created_at_ms,
+    location_id,
+    transferred_from_lot_id,
     source_document_id,
     source_document_kind,
     source_posting_sequence,
@@ -132,6 +142,8 @@ WITH lot_facts AS (
         lot.originated_on,
         lot.expires_on,
         lot.created_at_ms,
+        lot.location_id,
+        lot.transferred_from_lot_id,
         source_document.id AS source_document_id,
         source_document.kind AS source_document_kind,
         source_document.posting_sequence AS source_posting_sequence,
@@ -158,6 +170,10 @@ WITH lot_facts AS (
     JOIN stock_documents source_document ON source_document.id = source_line.document_id
     LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
     WHERE lot.item_id = sqlc.arg(item_id)
+      AND (
+          CAST(sqlc.arg(location_id) AS INTEGER) = 0
+          OR lot.location_id = CAST(sqlc.arg(location_id) AS INTEGER)
+      )
     GROUP BY
         lot.id,
         lot.item_id,
@@ -167,6 +183,8 @@ WITH lot_facts AS (
         lot.originated_on,
         lot.expires_on,
         lot.created_at_ms,
+        lot.location_id,
+        lot.transferred_from_lot_id,
         source_document.id,
         source_document.kind,
         source_document.posting_sequence,
@@ -184,6 +202,8 @@ SELECT
     originated_on,
     expires_on,
     created_at_ms,
+    location_id,
+    transferred_from_lot_id,
     source_document_id,
     source_document_kind,
     source_posting_sequence,
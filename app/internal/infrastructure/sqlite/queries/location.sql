@@ -0,0 +1,75 @@
+-- name: GetStockLocation :one
+SELECT
+    id,
+    name,
+    normalized_name,
+    created_at_ms,
+    updated_at_ms,
+    archived_at_ms
+FROM stock_locations
+WHERE id = sqlc.arg(id);
+
+-- name: ListStockLocations :many
+SELECT
+    id,
+    name,
+    normalized_name,
+    created_at_ms,
+    updated_at_ms,
+    archived_at_ms
+FROM stock_locations
+WHERE
+    CAST(sqlc.arg(archive_filter) AS INTEGER) = 2
+    OR (CAST(sqlc.arg(archive_filter) AS INTEGER) = 0 AND archived_at_ms IS NULL)
+    OR (CAST(sqlc.arg(archive_filter) AS INTEGER) = 1 AND archived_at_ms IS NOT NULL)
+ORDER BY normalized_name, id;
+
+-- name: InsertStockLocation :one
+INSERT INTO stock_locations (
+    name,
+    normalized_name,
+    created_at_ms,
+    updated_at_ms,
+    archived_at_ms
+) VALUES (
+    sqlc.arg(name),
+    sqlc.arg(normalized_name),
+    sqlc.arg(created_at_ms),
+    sqlc.arg(updated_at_ms),
+    NULL
+)
+RETURNING id;
+
+-- name: UpdateStockLocation :execrows
+UPDATE stock_locations
+SET
+    name = sqlc.arg(name),
+    normalized_name = sqlc.arg(normalized_name),
+    updated_at_ms = sqlc.arg(updated_at_ms)
+WHERE id = sqlc.arg(id)
+  AND archived_at_ms IS NULL
+  AND updated_at_ms = sqlc.arg(expected_updated_at_ms);
+
+-- name: ArchiveStockLocation :execrows
+UPDATE stock_locations
+SET
+    archived_at_ms = CAST(sqlc.arg(archived_at_ms) AS INTEGER),
+    updated_at_ms = sqlc.arg(updated_at_ms)
+WHERE id = sqlc.arg(id)
+  AND archived_at_ms IS NULL
+  AND updated_at_ms = sqlc.arg(expected_updated_at_ms);
+
+-- name: RestoreStockLocation :execrows
+UPDATE stock_locations
+SET
+    archived_at_ms = NULL,
+    updated_at_ms = sqlc.arg(updated_at_ms)
+WHERE id = sqlc.arg(id)
+  AND archived_at_ms IS NOT NULL
+  AND updated_at_ms = sqlc.arg(expected_updated_at_ms);
+
+-- name: CountItemsStockedAtLocation :one
+SELECT CAST(COUNT(*) AS INTEGER) AS item_count
+FROM inventory_location_balances
+WHERE location_id = sqlc.arg(location_id)
+  AND quantity_atomic > 0;
@@ -4,15 +4,19 @@ FROM app_settings
 WHERE id = 1;
 
 -- name: GetSalesReportTotals :one
+-- Customer returns enter every sales aggregate with negated quantity, refund
+-- and inventory value on their own occurred_on, so a period nets what it
+-- refunded. Only sale documents are counted as sales.
 WITH active_sale_lines AS (
     SELECT
         document.id AS document_id,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        document.kind,
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
       AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
       AND NOT EXISTS (
@@ -23,16 +27,21 @@ WITH active_sale_lines AS (
       )
 )
 SELECT
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS sales_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'SALE' THEN document_id END) AS INTEGER) AS sales_count,
     CAST(COALESCE(SUM(quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS revenue_minor,
-    CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS cogs_micro
+    CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS cogs_micro,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'RETURN' THEN document_id END) AS INTEGER) AS return_count,
+    CAST(COALESCE(SUM(
+        CASE WHEN kind = 'RETURN' THEN -commercial_total_minor ELSE 0 END
+    ), 0) AS INTEGER) AS refund_minor
 FROM active_sale_lines;
 
 -- name: ListSalesRevenueSeries :many
 WITH active_sale_lines AS (
     SELECT
         document.id AS document_id,
+        document.kind,
         CAST(
             CASE
                 WHEN CAST(sqlc.arg(granularity) AS TEXT) = 'DAY'
@@ -40,12 +49,12 @@ WITH active_sale_lines AS (
                 ELSE substr(document.occurred_on, 1, 7)
             END AS TEXT
         ) AS bucket,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
       AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
       AND NOT EXISTS (
@@ -58,7 +67,7 @@ WITH active_sale_lines AS (
 SELECT
     CAST(bucket AS TEXT) AS bucket,
     CAST(bucket AS TEXT) AS label,
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS sales_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'SALE' THEN document_id END) AS INTEGER) AS sales_count,
     CAST(COALESCE(SUM(quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS revenue_minor,
     CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS cogs_micro
@@ -72,13 +81,13 @@ WITH active_sale_lines AS (
         line.item_id,
         item.name AS item_name,
         item.base_unit_code,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
     JOIN items item ON item.id = line.item_id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
       AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
       AND NOT EXISTS (
@@ -106,13 +115,13 @@ WITH active_sale_lines AS (
         line.item_id,
         item.name AS item_name,
         item.base_unit_code,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
     JOIN items item ON item.id = line.item_id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
       AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
       AND NOT EXISTS (
@@ -166,13 +175,14 @@ FROM free_sale_lines;
 WITH active_sale_lines AS (
     SELECT
         document.id AS document_id,
+        document.kind,
         document.counterparty_id,
         counterparty.name AS counterparty_name,
-        line.commercial_total_minor
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
     JOIN counterparties counterparty ON counterparty.id = document.counterparty_id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.counterparty_id IS NOT NULL
       AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
       AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
@@ -186,7 +196,7 @@ WITH active_sale_lines AS (
 SELECT
     counterparty_id,
     counterparty_name,
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'SALE' THEN document_id END) AS INTEGER) AS document_count,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS revenue_minor
 FROM active_sale_lines
 GROUP BY counterparty_id, counterparty_name
@@ -197,10 +207,11 @@ LIMIT sqlc.arg(limit_count);
 WITH anonymous_sale_lines AS (
     SELECT
         document.id AS document_id,
-        line.commercial_total_minor
+        document.kind,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.counterparty_id IS NULL
       AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
       AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
@@ -212,60 +223,135 @@ WITH anonymous_sale_lines AS (
       )
 )
 SELECT
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'SALE' THEN document_id END) AS INTEGER) AS document_count,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS revenue_minor
 FROM anonymous_sale_lines;
 
 -- name: GetInventoryReportTotals :one
+WITH stock AS (
+    -- A location filter reads the remaining lot quantity at that location
+    -- and prorates the item's inventory value over it.
+    SELECT
+        item.id AS item_id,
+        item.name AS item_name,
+        item.base_unit_code,
+        item.archived_at_ms,
+        item.is_sellable,
+        item.reorder_quantity_atomic,
+        CASE
+            WHEN CAST(sqlc.arg(location_id) AS INTEGER) = 0 THEN balance.quantity_atomic
+            ELSE COALESCE(location_balance.quantity_atomic, 0)
+        END AS quantity_atomic,
+        CASE
+            WHEN CAST(sqlc.arg(location_id) AS INTEGER) = 0 THEN balance.inventory_value_micro
+            WHEN balance.quantity_atomic = 0 THEN 0
+            ELSE (balance.inventory_value_micro * COALESCE(location_balance.quantity_atomic, 0))
+                / balance.quantity_atomic
+        END AS inventory_value_micro
+    FROM inventory_balances balance
+    JOIN items item ON item.id = balance.item_id
+    LEFT JOIN inventory_location_balances location_balance
+      ON location_balance.item_id = balance.item_id
+     AND location_balance.location_id = CAST(sqlc.arg(location_id) AS INTEGER)
+)
 SELECT
-    CAST(COALESCE(SUM(balance.inventory_value_micro), 0) AS INTEGER) AS total_inventory_value_micro,
+    CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS total_inventory_value_micro,
     CAST(COALESCE(SUM(
         CASE
-            WHEN item.archived_at_ms IS NULL
-             AND item.reorder_quantity_atomic IS NOT NULL
-             AND balance.quantity_atomic <= item.reorder_quantity_atomic
+            WHEN archived_at_ms IS NULL
+             AND reorder_quantity_atomic IS NOT NULL
+             AND quantity_atomic <= reorder_quantity_atomic
                 THEN 1 ELSE 0
         END
     ), 0) AS INTEGER) AS low_stock_item_count,
     CAST(COALESCE(SUM(
         CASE
-            WHEN item.archived_at_ms IS NULL
-             AND item.is_sellable = 1
-             AND balance.quantity_atomic = 0
+            WHEN archived_at_ms IS NULL
+             AND is_sellable = 1
+             AND quantity_atomic = 0
                 THEN 1 ELSE 0
         END
     ), 0) AS INTEGER) AS zero_stock_sellable_count
-FROM inventory_balances balance
-JOIN items item ON item.id = balance.item_id;
+FROM stock;
 
 -- name: ListLowStockItems :many
+WITH stock AS (
+    -- A location filter reads the remaining lot quantity at that location
+    -- and prorates the item's inventory value over it.
+    SELECT
+        item.id AS item_id,
+        item.name AS item_name,
+        item.base_unit_code,
+        item.archived_at_ms,
+        item.is_sellable,
+        item.reorder_quantity_atomic,
+        CASE
+            WHEN CAST(sqlc.arg(location_id) AS INTEGER) = 0 THEN balance.quantity_atomic
+            ELSE COALESCE(location_balance.quantity_atomic, 0)
+        END AS quantity_atomic,
+        CASE
+            WHEN CAST(sqlc.arg(location_id) AS INTEGER) = 0 THEN balance.inventory_value_micro
+            WHEN balance.quantity_atomic = 0 THEN 0
+            ELSE (balance.inventory_value_micro * COALESCE(location_balance.quantity_atomic, 0))
+                / balance.quantity_atomic
+        END AS inventory_value_micro
+    FROM inventory_balances balance
+    JOIN items item ON item.id = balance.item_id
+    LEFT JOIN inventory_location_balances location_balance
+      ON location_balance.item_id = balance.item_id
+     AND location_balance.location_id = CAST(sqlc.arg(location_id) AS INTEGER)
+)
 SELECT
-    item.id AS item_id,
-    item.name AS item_name,
-    item.base_unit_code,
-    balance.quantity_atomic,
-    balance.inventory_value_micro,
-    item.reorder_quantity_atomic
-FROM inventory_balances balance
-JOIN items item ON item.id = balance.item_id
-WHERE item.archived_at_ms IS NULL
-  AND item.reorder_quantity_atomic IS NOT NULL
-  AND balance.quantity_atomic <= item.reorder_quantity_atomic
-ORDER BY (item.reorder_quantity_atomic - balance.quantity_atomic) DESC, item.name, item.id
+    item_id,
+    item_name,
+    base_unit_code,
+    CAST(quantity_atomic AS INTEGER) AS quantity_atomic,
+    CAST(inventory_value_micro AS INTEGER) AS inventory_value_micro,
+    reorder_quantity_atomic
+FROM stock
+WHERE archived_at_ms IS NULL
+  AND reorder_quantity_atomic IS NOT NULL
+  AND quantity_atomic <= reorder_quantity_atomic
+ORDER BY (reorder_quantity_atomic - quantity_atomic) DESC, item_name, item_id
 LIMIT sqlc.arg(limit_count);
 
 -- name: ListInventoryValueByItem :many
+WITH stock AS (
+    -- A location filter reads the remaining lot quantity at that location
+    -- and prorates the item's inventory value over it.
+    SELECT
+        item.id AS item_id,
+        item.name AS item_name,
+        item.base_unit_code,
+        item.archived_at_ms,
+        item.is_sellable,
+        item.reorder_quantity_atomic,
+        CASE
+            WHEN CAST(sqlc.arg(location_id) AS INTEGER) = 0 THEN balance.quantity_atomic
+            ELSE COALESCE(location_balance.quantity_atomic, 0)
+        END AS quantity_atomic,
+        CASE
+            WHEN CAST(sqlc.arg(location_id) AS INTEGER) = 0 THEN balance.inventory_value_micro
+            WHEN balance.quantity_atomic = 0 THEN 0
+            ELSE (balance.inventory_value_micro * COALESCE(location_balance.quantity_atomic, 0))
+                / balance.quantity_atomic
+        END AS inventory_value_micro
+    FROM inventory_balances balance
+    JOIN items item ON item.id = balance.item_id
+    LEFT JOIN inventory_location_balances location_balance
+      ON location_balance.item_id = balance.item_id
+     AND location_balance.location_id = CAST(sqlc.arg(location_id) AS INTEGER)
+)
 SELECT
-    item.id AS item_id,
-    item.name AS item_name,
-    item.base_unit_code,
-    balance.quantity_atomic,
-    balance.inventory_value_micro
-FROM inventory_balances balance
-JOIN items item ON item.id = balance.item_id
-WHERE item.archived_at_ms IS NULL
-  AND balance.inventory_value_micro > 0
-ORDER BY balance.inventory_value_micro DESC, item.name, item.id
+    item_id,
+    item_name,
+    base_unit_code,
+    CAST(quantity_atomic AS INTEGER) AS quantity_atomic,
+    CAST(inventory_value_micro AS INTEGER) AS inventory_value_micro
+FROM stock
+WHERE archived_at_ms IS NULL
+  AND inventory_value_micro > 0
+ORDER BY inventory_value_micro DESC, item_name, item_id
 LIMIT sqlc.arg(limit_count);
 
 -- name: ListExpiringLots :many
@@ -295,6 +381,10 @@ WITH lot_facts AS (
     LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
     WHERE item.archived_at_ms IS NULL
       AND lot.expires_on IS NOT NULL
+      AND (
+          CAST(sqlc.arg(location_id) AS INTEGER) = 0
+          OR lot.location_id = CAST(sqlc.arg(location_id) AS INTEGER)
+      )
     GROUP BY lot.id
 )
 SELECT
@@ -345,6 +435,10 @@ WITH lot_facts AS (
     LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
     WHERE item.archived_at_ms IS NULL
       AND lot.expires_on IS NOT NULL
+      AND (
+          CAST(sqlc.arg(location_id) AS INTEGER) = 0
+          OR lot.location_id = CAST(sqlc.arg(location_id) AS INTEGER)
+      )
     GROUP BY lot.id
 )
 SELECT
@@ -365,9 +459,12 @@ ORDER BY expires_on, item_name, id
 LIMIT sqlc.arg(limit_count);
 
 -- name: ListPurchaseSpendSeries :many
+-- Supplier returns enter purchase spend with negated quantity, credit and
+-- inventory value on their own occurred_on. Only purchases are counted.
 WITH active_purchase_lines AS (
     SELECT
         document.id AS document_id,
+        document.kind,
         CAST(
             CASE
                 WHEN CAST(sqlc.arg(granularity) AS TEXT) = 'DAY'
@@ -375,12 +472,12 @@ WITH active_purchase_lines AS (
                 ELSE substr(document.occurred_on, 1, 7)
             END AS TEXT
         ) AS bucket,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
-    WHERE document.kind = 'PURCHASE'
+    WHERE (document.kind = 'PURCHASE' OR document.reason_code = 'SUPPLIER_RETURN')
       AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
       AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
       AND NOT EXISTS (
@@ -393,7 +490,7 @@ WITH active_purchase_lines AS (
 SELECT
     CAST(bucket AS TEXT) AS bucket,
     CAST(bucket AS TEXT) AS label,
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'PURCHASE' THEN document_id END) AS INTEGER) AS document_count,
     CAST(COALESCE(SUM(quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS spend_minor,
     CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS inventory_value_micro
@@ -405,13 +502,14 @@ ORDER BY bucket;
 WITH active_purchase_lines AS (
     SELECT
         document.id AS document_id,
+        document.kind,
         document.counterparty_id,
         counterparty.name AS counterparty_name,
-        line.commercial_total_minor
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
     JOIN counterparties counterparty ON counterparty.id = document.counterparty_id
-    WHERE document.kind = 'PURCHASE'
+    WHERE (document.kind = 'PURCHASE' OR document.reason_code = 'SUPPLIER_RETURN')
       AND document.counterparty_id IS NOT NULL
       AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
       AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
@@ -425,7 +523,7 @@ WITH active_purchase_lines AS (
 SELECT
     counterparty_id,
     counterparty_name,
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'PURCHASE' THEN document_id END) AS INTEGER) AS document_count,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS spend_minor
 FROM active_purchase_lines
 GROUP BY counterparty_id, counterparty_name
@@ -470,6 +568,49 @@ FROM free_stock_lines
 GROUP BY bucket
 ORDER BY bucket;
 
+-- name: GetPurchasePayableTotals :one
+-- Payables of the scheduled purchases that occurred in the period: what they
+-- owe after supplier return credits, nothing once reversed, and what was paid
+-- net of payment reversals.
+WITH period_payables AS (
+    SELECT
+        CASE
+            WHEN EXISTS (
+                SELECT 1
+                FROM stock_documents reversal
+                WHERE reversal.kind = 'REVERSAL'
+                  AND reversal.reverses_document_id = purchase.id
+            ) THEN 0
+            ELSE payable.total_minor - (
+                SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+                FROM stock_documents supplier_return
+                JOIN stock_document_lines line ON line.document_id = supplier_return.id
+                WHERE supplier_return.returns_document_id = purchase.id
+                  AND NOT EXISTS (
+                      SELECT 1
+                      FROM stock_documents reversal
+                      WHERE reversal.kind = 'REVERSAL'
+                        AND reversal.reverses_document_id = supplier_return.id
+                  )
+            )
+        END AS owed_minor,
+        (
+            SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+                THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+            FROM supplier_payments payment
+            WHERE payment.purchase_document_id = purchase.id
+        ) AS paid_minor
+    FROM purchase_payables payable
+    JOIN stock_documents purchase ON purchase.id = payable.purchase_document_id
+    WHERE purchase.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
+      AND purchase.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
+)
+SELECT
+    CAST(COUNT(*) AS INTEGER) AS payable_count,
+    CAST(COALESCE(SUM(owed_minor), 0) AS INTEGER) AS owed_minor,
+    CAST(COALESCE(SUM(paid_minor), 0) AS INTEGER) AS paid_minor
+FROM period_payables;
+
 -- name: ListProductionByRecipeProduct :many
 WITH active_production_runs AS (
     SELECT
@@ -667,3 +808,108 @@ SELECT
 FROM exact_reversal_lines
 GROUP BY bucket
 ORDER BY bucket;
+
+-- name: ListExpenseSeries :many
+-- Only current expenses count: a corrected expense is replaced by its
+-- correction on the correction's own business date, and a void counts nowhere.
+WITH current_expenses AS (
+    SELECT
+        expense.id AS expense_id,
+        CAST(
+            CASE
+                WHEN CAST(sqlc.arg(granularity) AS TEXT) = 'DAY'
+                    THEN expense.occurred_on
+                ELSE substr(expense.occurred_on, 1, 7)
+            END AS TEXT
+        ) AS bucket,
+        expense.category,
+        expense.amount_minor
+    FROM expenses expense
+    WHERE expense.amount_minor > 0
+      AND expense.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
+      AND expense.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
+      AND NOT EXISTS (
+          SELECT 1
+          FROM expenses correction
+          WHERE correction.corrects_expense_id = expense.id
+      )
+)
+SELECT
+    CAST(bucket AS TEXT) AS bucket,
+    CAST(bucket AS TEXT) AS label,
+    CAST(category AS TEXT) AS category,
+    CAST(COUNT(DISTINCT expense_id) AS INTEGER) AS expense_count,
+    CAST(COALESCE(SUM(amount_minor), 0) AS INTEGER) AS amount_minor
+FROM current_expenses
+GROUP BY bucket, category
+ORDER BY bucket, category;
+
+-- name: ListWriteOffSeries :many
+-- Write-offs are the outbound lines of unreversed WASTE, EXPIRY, and DAMAGE
+-- adjustments at their recorded inventory value.
+WITH active_write_off_lines AS (
+    SELECT
+        document.id AS document_id,
+        CAST(
+            CASE
+                WHEN CAST(sqlc.arg(granularity) AS TEXT) = 'DAY'
+                    THEN document.occurred_on
+                ELSE substr(document.occurred_on, 1, 7)
+            END AS TEXT
+        ) AS bucket,
+        document.reason_code,
+        line.quantity_atomic,
+        line.inventory_value_micro
+    FROM stock_documents document
+    JOIN stock_document_lines line ON line.document_id = document.id
+    WHERE document.kind = 'ADJUSTMENT'
+      AND document.reason_code IN ('WASTE', 'EXPIRY', 'DAMAGE')
+      AND line.direction = 'OUT'
+      AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
+      AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
+      AND NOT EXISTS (
+          SELECT 1
+          FROM stock_documents reversal
+          WHERE reversal.kind = 'REVERSAL'
+            AND reversal.reverses_document_id = document.id
+      )
+)
+SELECT
+    CAST(bucket AS TEXT) AS bucket,
+    CAST(bucket AS TEXT) AS label,
+    CAST(reason_code AS TEXT) AS reason_code,
+    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COALESCE(SUM(quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
+    CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS inventory_value_micro
+FROM active_write_off_lines
+GROUP BY bucket, reason_code
+ORDER BY bucket, reason_code;
+
+-- name: ListSalesCategoryMix :many
+WITH active_sale_lines AS (
+    SELECT
+        item.category_id,
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor
+    FROM stock_documents document
+    JOIN stock_document_lines line ON line.document_id = document.id
+    JOIN items item ON item.id = line.item_id
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
+      AND document.occurred_on >= CAST(sqlc.arg(from_occurred_on) AS TEXT)
+      AND document.occurred_on <= CAST(sqlc.arg(to_occurred_on) AS TEXT)
+      AND NOT EXISTS (
+          SELECT 1
+          FROM stock_documents reversal
+          WHERE reversal.kind = 'REVERSAL'
+            AND reversal.reverses_document_id = document.id
+      )
+)
+SELECT
+    category.id AS category_id,
+    category.name AS category_name,
+    CAST(COALESCE(SUM(sale.quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
+    CAST(COALESCE(SUM(sale.commercial_total_minor), 0) AS INTEGER) AS revenue_minor
+FROM active_sale_lines sale
+LEFT JOIN item_categories category ON category.id = sale.category_id
+GROUP BY category.id, category.name
+ORDER BY revenue_minor DESC, quantity_atomic DESC, category.id IS NULL, category.normalized_name, category.id;
@@ -8,6 +8,10 @@ SELECT
     currency_minor_digits,
     hourly_labor_cost_minor,
     default_gross_margin_basis_points,
+    backup_enabled,
+    backup_interval_minutes,
+    backup_keep_daily,
+    backup_keep_weekly,
     created_at_ms,
     updated_at_ms
 FROM app_settings
@@ -23,6 +27,10 @@ SET
     currency_minor_digits = sqlc.arg(currency_minor_digits),
     hourly_labor_cost_minor = sqlc.narg(hourly_labor_cost_minor),
     default_gross_margin_basis_points = sqlc.narg(default_gross_margin_basis_points),
+    backup_enabled = sqlc.arg(backup_enabled),
+    backup_interval_minutes = sqlc.arg(backup_interval_minutes),
+    backup_keep_daily = sqlc.arg(backup_keep_daily),
+    backup_keep_weekly = sqlc.arg(backup_keep_weekly),
     updated_at_ms = sqlc.arg(updated_at_ms)
 WHERE id = 1
   AND updated_at_ms = sqlc.arg(expected_updated_at_ms)
@@ -35,6 +43,10 @@ RETURNING
     currency_minor_digits,
     hourly_labor_cost_minor,
     default_gross_margin_basis_points,
+    backup_enabled,
+    backup_interval_minutes,
+    backup_keep_daily,
+    backup_keep_weekly,
     created_at_ms,
     updated_at_ms;
 
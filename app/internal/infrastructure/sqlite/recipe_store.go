@@ -8,6 +8,7 @@ import (
 	"math"
 	"unicode/utf8"
 
+	"github.com/jerobas/saas/database"
 	"github.com/jerobas/saas/internal/domain"
 	"github.com/jerobas/saas/internal/domain/catalog"
 	recipedomain "github.com/jerobas/saas/internal/domain/recipe"
@@ -241,6 +242,59 @@ func (s *Store) ListRecipes(ctx context.Context, filter RecipeListFilter) (Recip
 	return RecipePage{items: items, next: next}, nil
 }
 
+// ListActiveRecipesByOutputItem loads every active recipe producing the item
+// with its current revision, ordered by name.
+func (s *Store) ListActiveRecipesByOutputItem(ctx context.Context, itemID domain.ItemID) ([]recipedomain.Recipe, error) {
+	if itemID.IsZero() {
+		return nil, domain.Invalid("item_id", domain.ViolationRequired, "")
+	}
+	recipes := make([]recipedomain.Recipe, 0)
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		ids, err := listActiveRecipeIDsByOutputItem(ctx, tx, itemID.Int64())
+		if err != nil {
+			return err
+		}
+		queries := sqlcgen.New(tx)
+		for _, rawID := range ids {
+			id, err := domain.NewRecipeID(rawID)
+			if err != nil {
+				return corruptDataError("map recipe id", err)
+			}
+			value, err := loadRecipeAggregate(ctx, queries, id)
+			if err != nil {
+				return err
+			}
+			recipes = append(recipes, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list active recipes by output item", err)
+	}
+	return recipes, nil
+}
+
+func listActiveRecipeIDsByOutputItem(ctx context.Context, tx databaseWriteTx, itemID int64) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id FROM recipes
+		WHERE output_item_id = ? AND archived_at_ms IS NULL
+		ORDER BY normalized_name, id
+	`, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ids := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 func (s *Store) CreateRecipe(ctx context.Context, input CreateRecipeInput) (recipedomain.Recipe, error) {
 	if err := validateCreateRecipeInput(input); err != nil {
 		return recipedomain.Recipe{}, err
@@ -452,12 +452,20 @@ func TestRecipeStoreArchiveRestoreAndArchivedReferenceRules(t *testing.T) {
 	if !errors.Is(err, domain.ErrStale) {
 		t.Fatalf("stale rename error = %v, want ErrStale", err)
 	}
+	producers, err := store.ListActiveRecipesByOutputItem(ctx, outputID)
+	if err != nil || len(producers) != 1 || producers[0].ID() != created.ID() ||
+		producers[0].CurrentRevision().Components()[0].ItemID() != componentID {
+		t.Fatalf("active recipes by output = %#v, %v", producers, err)
+	}
 	archived, err := store.ArchiveRecipe(ctx, ArchiveRecipeInput{
 		ID: renamed.ID(), ExpectedUpdatedAt: renamed.UpdatedAt(), ArchivedAt: recipeInstant(t, 2_000),
 	})
 	if err != nil || !archived.IsArchived() {
 		t.Fatalf("archived recipe = %#v, %v", archived, err)
 	}
+	if producers, err := store.ListActiveRecipesByOutputItem(ctx, outputID); err != nil || len(producers) != 0 {
+		t.Fatalf("active recipes by output after archive = %#v, %v", producers, err)
+	}
 	activePage, err := store.ListRecipes(ctx, RecipeListFilter{
 		Archive: domain.ArchiveActive, PageSize: recipePageSize(t, 10),
 	})
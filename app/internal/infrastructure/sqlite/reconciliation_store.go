@@ -0,0 +1,437 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+)
+
+const reconcileInventoryOperation = "reconcile inventory"
+
+// ReconcileInventory replays every stock line in posting order inside one
+// read snapshot and compares the recomputed facts with inventory_balances and
+// the remaining lot quantities served by inventory_location_balances. It
+// never repairs projections; any difference is reported for diagnosis.
+func (s *Store) ReconcileInventory(ctx context.Context) (inventory.Reconciliation, error) {
+	var reconciliation inventory.Reconciliation
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := reconcileInventoryTx(ctx, tx)
+		if err != nil {
+			return err
+		}
+		reconciliation = value
+		return nil
+	})
+	if err != nil {
+		return inventory.Reconciliation{}, classifyError(reconcileInventoryOperation, err)
+	}
+	return reconciliation, nil
+}
+
+type replayedLine struct {
+	id, documentID, itemID, quantityAtomic, inventoryValueMicro int64
+	direction                                                   string
+	// restoresLineID is the reversed or returned line whose lots this line
+	// restores, if any.
+	restoresLineID sql.NullInt64
+}
+
+type replayedAllocation struct {
+	lotID, quantityAtomic int64
+	restoration           bool
+}
+
+type replayedLot struct {
+	id, itemID, sourceLineID, initialQuantity, remainingQuantity int64
+}
+
+type replayedBalance struct {
+	quantityAtomic, inventoryValueMicro, lastDocumentID int64
+}
+
+func reconcileInventoryTx(ctx context.Context, tx databaseWriteTx) (inventory.Reconciliation, error) {
+	var documentCount int64
+	var lastPostingSequence sql.NullInt64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*), MAX(posting_sequence) FROM stock_documents
+	`).Scan(&documentCount, &lastPostingSequence); err != nil {
+		return inventory.Reconciliation{}, err
+	}
+
+	lines, err := loadReplayedLines(ctx, tx)
+	if err != nil {
+		return inventory.Reconciliation{}, err
+	}
+	allocations, err := loadReplayedAllocations(ctx, tx)
+	if err != nil {
+		return inventory.Reconciliation{}, err
+	}
+	lots, lotsBySourceLine, err := loadReplayedLots(ctx, tx)
+	if err != nil {
+		return inventory.Reconciliation{}, err
+	}
+	stored, err := loadStoredBalances(ctx, tx)
+	if err != nil {
+		return inventory.Reconciliation{}, err
+	}
+	lotQuantities, err := loadStoredLotQuantities(ctx, tx)
+	if err != nil {
+		return inventory.Reconciliation{}, err
+	}
+
+	var discrepancies []inventory.Discrepancy
+	report := func(params inventory.DiscrepancyParams) error {
+		discrepancy, err := inventory.NewDiscrepancy(params)
+		if err != nil {
+			return corruptDataError("", err)
+		}
+		discrepancies = append(discrepancies, discrepancy)
+		return nil
+	}
+
+	expected := make(map[int64]replayedBalance, len(stored))
+	for _, line := range lines {
+		itemID, err := domain.NewItemID(line.itemID)
+		if err != nil {
+			return inventory.Reconciliation{}, corruptDataError("", err)
+		}
+		lineID, err := domain.NewStockDocumentLineID(line.id)
+		if err != nil {
+			return inventory.Reconciliation{}, corruptDataError("", err)
+		}
+
+		balance := expected[line.itemID]
+		switch line.direction {
+		case domain.DirectionIn.String():
+			balance.quantityAtomic += line.quantityAtomic
+			balance.inventoryValueMicro += line.inventoryValueMicro
+		case domain.DirectionOut.String():
+			balance.quantityAtomic -= line.quantityAtomic
+			balance.inventoryValueMicro -= line.inventoryValueMicro
+		default:
+			return inventory.Reconciliation{}, corruptDataError("",
+				domain.Invalid("direction", domain.ViolationInvalidEnum, "DOC-008"))
+		}
+		balance.lastDocumentID = line.documentID
+		expected[line.itemID] = balance
+
+		var consumed, restored int64
+		for _, allocation := range allocations[line.id] {
+			if _, ok := lots[allocation.lotID]; !ok {
+				return inventory.Reconciliation{}, corruptDataError("",
+					domain.Invalid("lot_id", domain.ViolationRequired, "LOT-010"))
+			}
+			if allocation.restoration {
+				restored += allocation.quantityAtomic
+			} else {
+				consumed += allocation.quantityAtomic
+			}
+		}
+
+		switch {
+		case line.direction == domain.DirectionOut.String():
+			if consumed != line.quantityAtomic {
+				if err := report(inventory.DiscrepancyParams{
+					Kind: inventory.DiscrepancyLineAllocation, ItemID: itemID, LineID: domain.Some(lineID),
+					Expected: line.quantityAtomic, Stored: consumed,
+				}); err != nil {
+					return inventory.Reconciliation{}, err
+				}
+			}
+		case line.restoresLineID.Valid:
+			if restored != line.quantityAtomic {
+				if err := report(inventory.DiscrepancyParams{
+					Kind: inventory.DiscrepancyLineAllocation, ItemID: itemID, LineID: domain.Some(lineID),
+					Expected: line.quantityAtomic, Stored: restored,
+				}); err != nil {
+					return inventory.Reconciliation{}, err
+				}
+			}
+		default:
+			lotID := domain.None[domain.InventoryLotID]()
+			var initial int64
+			if lot, ok := lotsBySourceLine[line.id]; ok {
+				id, err := domain.NewInventoryLotID(lot.id)
+				if err != nil {
+					return inventory.Reconciliation{}, corruptDataError("", err)
+				}
+				lotID = domain.Some(id)
+				initial = lot.initialQuantity
+			}
+			if initial != line.quantityAtomic {
+				if err := report(inventory.DiscrepancyParams{
+					Kind: inventory.DiscrepancyLotInitialQuantity, ItemID: itemID, LotID: lotID,
+					LineID: domain.Some(lineID), Expected: line.quantityAtomic, Stored: initial,
+				}); err != nil {
+					return inventory.Reconciliation{}, err
+				}
+			}
+		}
+	}
+
+	// Allocations alone cannot vouch for themselves, so each lot is held to
+	// its own bounds and each item's lots to the quantity replayed from its
+	// lines, which never reads lot_allocations.
+	lotIDs := make([]int64, 0, len(lots))
+	for id := range lots {
+		lotIDs = append(lotIDs, id)
+	}
+	sort.Slice(lotIDs, func(i, j int) bool { return lotIDs[i] < lotIDs[j] })
+	for _, id := range lotIDs {
+		lot := lots[id]
+		itemID, err := domain.NewItemID(lot.itemID)
+		if err != nil {
+			return inventory.Reconciliation{}, corruptDataError("", err)
+		}
+		lotID, err := domain.NewInventoryLotID(lot.id)
+		if err != nil {
+			return inventory.Reconciliation{}, corruptDataError("", err)
+		}
+		bound := lot.remainingQuantity
+		if bound < 0 {
+			bound = 0
+		} else if bound > lot.initialQuantity {
+			bound = lot.initialQuantity
+		}
+		if bound != lot.remainingQuantity {
+			if err := report(inventory.DiscrepancyParams{
+				Kind: inventory.DiscrepancyLotAvailable, ItemID: itemID, LotID: domain.Some(lotID),
+				Expected: bound, Stored: lot.remainingQuantity,
+			}); err != nil {
+				return inventory.Reconciliation{}, err
+			}
+		}
+	}
+
+	itemIDs := make([]int64, 0, len(stored))
+	for id := range stored {
+		itemIDs = append(itemIDs, id)
+	}
+	for id := range expected {
+		if _, ok := stored[id]; !ok {
+			itemIDs = append(itemIDs, id)
+		}
+	}
+	for id := range lotQuantities {
+		if _, ok := stored[id]; ok {
+			continue
+		}
+		if _, ok := expected[id]; !ok {
+			itemIDs = append(itemIDs, id)
+		}
+	}
+	sort.Slice(itemIDs, func(i, j int) bool { return itemIDs[i] < itemIDs[j] })
+	for _, id := range itemIDs {
+		itemID, err := domain.NewItemID(id)
+		if err != nil {
+			return inventory.Reconciliation{}, corruptDataError("", err)
+		}
+		want, have := expected[id], stored[id]
+		checks := []struct {
+			kind             inventory.DiscrepancyKind
+			expected, stored int64
+		}{
+			{inventory.DiscrepancyBalanceQuantity, want.quantityAtomic, have.quantityAtomic},
+			{inventory.DiscrepancyBalanceValue, want.inventoryValueMicro, have.inventoryValueMicro},
+			{inventory.DiscrepancyBalanceLastDocument, want.lastDocumentID, have.lastDocumentID},
+			{inventory.DiscrepancyLotItemQuantity, want.quantityAtomic, lotQuantities[id]},
+		}
+		for _, check := range checks {
+			if check.expected == check.stored {
+				continue
+			}
+			if err := report(inventory.DiscrepancyParams{
+				Kind: check.kind, ItemID: itemID, Expected: check.expected, Stored: check.stored,
+			}); err != nil {
+				return inventory.Reconciliation{}, err
+			}
+		}
+	}
+
+	sort.SliceStable(discrepancies, func(i, j int) bool {
+		return discrepancies[i].ItemID().Int64() < discrepancies[j].ItemID().Int64()
+	})
+
+	params := inventory.ReconciliationParams{
+		DocumentCount: documentCount,
+		LineCount:     int64(len(lines)),
+		LotCount:      int64(len(lots)),
+		ItemCount:     int64(len(stored)),
+		Discrepancies: discrepancies,
+	}
+	if lastPostingSequence.Valid {
+		sequence, err := domain.NewPostingSequence(lastPostingSequence.Int64)
+		if err != nil {
+			return inventory.Reconciliation{}, corruptDataError("", err)
+		}
+		params.LastPostingSequence = domain.Some(sequence)
+	}
+	reconciliation, err := inventory.NewReconciliation(params)
+	if err != nil {
+		return inventory.Reconciliation{}, corruptDataError("", err)
+	}
+	return reconciliation, nil
+}
+
+func loadReplayedLines(ctx context.Context, tx databaseWriteTx) ([]replayedLine, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT line.id, line.document_id, line.item_id, line.direction,
+		       line.quantity_atomic, line.inventory_value_micro,
+		       COALESCE(line.reverses_line_id, line.returns_line_id)
+		FROM stock_document_lines line
+		JOIN stock_documents document ON document.id = line.document_id
+		ORDER BY document.posting_sequence, line.line_order, line.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []replayedLine
+	for rows.Next() {
+		var line replayedLine
+		if err := rows.Scan(
+			&line.id,
+			&line.documentID,
+			&line.itemID,
+			&line.direction,
+			&line.quantityAtomic,
+			&line.inventoryValueMicro,
+			&line.restoresLineID,
+		); err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func loadReplayedAllocations(ctx context.Context, tx databaseWriteTx) (map[int64][]replayedAllocation, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT line_id, lot_id, quantity_atomic, restores_allocation_id IS NOT NULL
+		FROM lot_allocations
+		ORDER BY line_id, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	allocations := make(map[int64][]replayedAllocation)
+	for rows.Next() {
+		var lineID int64
+		var allocation replayedAllocation
+		if err := rows.Scan(&lineID, &allocation.lotID, &allocation.quantityAtomic, &allocation.restoration); err != nil {
+			return nil, err
+		}
+		allocations[lineID] = append(allocations[lineID], allocation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+// loadReplayedLots reads lot sources together with their remaining quantity,
+// initial quantity less net consumption as the lot views compute it.
+func loadReplayedLots(ctx context.Context, tx databaseWriteTx) (map[int64]*replayedLot, map[int64]*replayedLot, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			lot.id,
+			lot.item_id,
+			lot.source_line_id,
+			lot.initial_quantity_atomic,
+			lot.initial_quantity_atomic - COALESCE(SUM(
+				CASE WHEN allocation.restores_allocation_id IS NULL
+					THEN allocation.quantity_atomic ELSE -allocation.quantity_atomic END
+			), 0) AS remaining_quantity_atomic
+		FROM inventory_lots lot
+		LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
+		GROUP BY lot.id, lot.item_id, lot.source_line_id, lot.initial_quantity_atomic
+		ORDER BY lot.id
+	`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	lots := make(map[int64]*replayedLot)
+	bySourceLine := make(map[int64]*replayedLot)
+	for rows.Next() {
+		lot := &replayedLot{}
+		if err := rows.Scan(
+			&lot.id,
+			&lot.itemID,
+			&lot.sourceLineID,
+			&lot.initialQuantity,
+			&lot.remainingQuantity,
+		); err != nil {
+			return nil, nil, err
+		}
+		lots[lot.id] = lot
+		bySourceLine[lot.sourceLineID] = lot
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return lots, bySourceLine, nil
+}
+
+func loadStoredBalances(ctx context.Context, tx databaseWriteTx) (map[int64]replayedBalance, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT item_id, quantity_atomic, inventory_value_micro, COALESCE(last_document_id, 0)
+		FROM inventory_balances
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balances := make(map[int64]replayedBalance)
+	for rows.Next() {
+		var itemID int64
+		var balance replayedBalance
+		if err := rows.Scan(&itemID, &balance.quantityAtomic, &balance.inventoryValueMicro, &balance.lastDocumentID); err != nil {
+			return nil, err
+		}
+		balances[itemID] = balance
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// loadStoredLotQuantities sums the remaining lot quantity of each item across
+// locations, as served by inventory_location_balances.
+func loadStoredLotQuantities(ctx context.Context, tx databaseWriteTx) (map[int64]int64, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT item_id, SUM(quantity_atomic)
+		FROM inventory_location_balances
+		GROUP BY item_id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	quantities := make(map[int64]int64)
+	for rows.Next() {
+		var itemID, quantity int64
+		if err := rows.Scan(&itemID, &quantity); err != nil {
+			return nil, err
+		}
+		quantities[itemID] = quantity
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return quantities, nil
+}
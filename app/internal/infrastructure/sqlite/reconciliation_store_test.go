@@ -0,0 +1,207 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/catalog"
+	"github.com/jerobas/saas/internal/domain/inventory"
+)
+
+func TestReconciliationStoreReplaysConsistentLedger(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "reconcile-clean.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+
+	empty, err := store.ReconcileInventory(ctx)
+	if err != nil {
+		t.Fatalf("reconcile empty ledger: %v", err)
+	}
+	if !empty.Consistent() || empty.DocumentCount() != 0 || empty.LastPostingSequence().IsSome() {
+		t.Fatalf("empty reconciliation = %#v", empty)
+	}
+
+	item := createCatalogItem(t, store, CreateItemInput{
+		Name:         mustCatalogName(t, "Sugar"),
+		BaseUnit:     mustCatalogUnitCode(t, "g"),
+		Capabilities: catalog.NewCapabilities(true, false, false),
+		CreatedAt:    mustCatalogInstant(t, 1_000),
+		UpdatedAt:    mustCatalogInstant(t, 1_000),
+	})
+	postAdjustmentTestPurchase(t, store, item.Item().ID(), "reconcile-purchase-1", "A", "2026-08-01", 100, 1_000)
+	postAdjustmentTestPurchase(t, store, item.Item().ID(), "reconcile-purchase-2", "B", "2026-09-01", 300, 4_500)
+	adjustment, err := store.PostAdjustment(ctx, PostAdjustmentInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "reconcile-waste"),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-10"),
+		PostedAt:       mustCatalogInstant(t, 5_000),
+		Reason:         domain.ReasonWaste,
+		Lines: []PostAdjustmentLineInput{
+			{
+				ItemID:      item.Item().ID(),
+				Direction:   domain.DirectionOut,
+				Quantity:    mustPurchaseQuantity(t, 150),
+				EnteredUnit: mustCatalogUnitCode(t, "g"),
+				Conversion:  mustCatalogConversion(t, 1_000, 1),
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("post adjustment: %v", err)
+	}
+	if _, err := store.PostReversal(ctx, PostReversalInput{
+		IdempotencyKey:   mustPurchaseIdempotencyKey(t, "reconcile-reversal"),
+		TargetDocumentID: adjustment.ID(),
+		OccurredOn:       mustPurchaseDate(t, "2026-07-11"),
+		PostedAt:         mustCatalogInstant(t, 6_000),
+	}); err != nil {
+		t.Fatalf("post reversal: %v", err)
+	}
+
+	reconciliation, err := store.ReconcileInventory(ctx)
+	if err != nil {
+		t.Fatalf("reconcile ledger: %v", err)
+	}
+	sequence, ok := reconciliation.LastPostingSequence().Get()
+	if !reconciliation.Consistent() || !ok || sequence.Int64() != 4 ||
+		reconciliation.DocumentCount() != 4 || reconciliation.LineCount() != 4 ||
+		reconciliation.LotCount() != 2 || reconciliation.ItemCount() != 1 {
+		t.Fatalf("reconciliation = %#v, discrepancies = %#v", reconciliation, reconciliation.Discrepancies())
+	}
+}
+
+func TestReconciliationStoreReportsDriftedBalanceProjection(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "reconcile-drift.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+
+	item := createCatalogItem(t, store, CreateItemInput{
+		Name:         mustCatalogName(t, "Cocoa"),
+		BaseUnit:     mustCatalogUnitCode(t, "g"),
+		Capabilities: catalog.NewCapabilities(true, false, false),
+		CreatedAt:    mustCatalogInstant(t, 1_000),
+		UpdatedAt:    mustCatalogInstant(t, 1_000),
+	})
+	untouched := createCatalogItem(t, store, CreateItemInput{
+		Name:         mustCatalogName(t, "Vanilla"),
+		BaseUnit:     mustCatalogUnitCode(t, "g"),
+		Capabilities: catalog.NewCapabilities(true, false, false),
+		CreatedAt:    mustCatalogInstant(t, 1_000),
+		UpdatedAt:    mustCatalogInstant(t, 1_000),
+	})
+	purchase := postAdjustmentTestPurchase(t, store, item.Item().ID(), "drift-purchase", "A", "2026-08-01", 100, 1_000)
+
+	if _, err := store.database.ExecContext(ctx, `
+		UPDATE inventory_balances
+		SET quantity_atomic = 90, inventory_value_micro = 9_500_000
+		WHERE item_id = ?
+	`, item.Item().ID().Int64()); err != nil {
+		t.Fatalf("drift balance: %v", err)
+	}
+	if _, err := store.database.ExecContext(ctx, `
+		UPDATE inventory_balances
+		SET last_document_id = ?
+		WHERE item_id = ?
+	`, purchase.ID().Int64(), untouched.Item().ID().Int64()); err != nil {
+		t.Fatalf("drift last document: %v", err)
+	}
+
+	reconciliation, err := store.ReconcileInventory(ctx)
+	if err != nil {
+		t.Fatalf("reconcile ledger: %v", err)
+	}
+	if reconciliation.Consistent() {
+		t.Fatal("drifted projection reconciled as consistent")
+	}
+	discrepancies := reconciliation.Discrepancies()
+	want := []struct {
+		kind             inventory.DiscrepancyKind
+		itemID           domain.ItemID
+		expected, stored int64
+	}{
+		{inventory.DiscrepancyBalanceQuantity, item.Item().ID(), 100, 90},
+		{inventory.DiscrepancyBalanceValue, item.Item().ID(), 10_000_000, 9_500_000},
+		{inventory.DiscrepancyBalanceLastDocument, untouched.Item().ID(), 0, purchase.ID().Int64()},
+	}
+	if len(discrepancies) != len(want) {
+		t.Fatalf("discrepancies = %#v, want %d", discrepancies, len(want))
+	}
+	for index, expected := range want {
+		got := discrepancies[index]
+		if got.Kind() != expected.kind || got.ItemID() != expected.itemID ||
+			got.Expected() != expected.expected || got.Stored() != expected.stored ||
+			got.Kind().InvariantID() != "INV-008" {
+			t.Fatalf("discrepancy %d = %#v, want %#v", index, got, expected)
+		}
+	}
+}
+
+func TestReconciliationStoreReportsLotsThatDisagreeWithTheirLedger(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "reconcile-lots.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+
+	item := createCatalogItem(t, store, CreateItemInput{
+		Name:         mustCatalogName(t, "Butter"),
+		BaseUnit:     mustCatalogUnitCode(t, "g"),
+		Capabilities: catalog.NewCapabilities(true, false, false),
+		CreatedAt:    mustCatalogInstant(t, 1_000),
+		UpdatedAt:    mustCatalogInstant(t, 1_000),
+	})
+	early := postAdjustmentTestPurchase(t, store, item.Item().ID(), "lots-purchase-1", "A", "2026-08-01", 100, 1_000)
+	late := postAdjustmentTestPurchase(t, store, item.Item().ID(), "lots-purchase-2", "B", "2026-09-01", 120, 1_200)
+	if _, err := store.PostAdjustment(ctx, PostAdjustmentInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "lots-waste"),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-10"),
+		PostedAt:       mustCatalogInstant(t, 5_000),
+		Reason:         domain.ReasonWaste,
+		Lines: []PostAdjustmentLineInput{
+			{
+				ItemID:      item.Item().ID(),
+				Direction:   domain.DirectionOut,
+				Quantity:    mustPurchaseQuantity(t, 150),
+				EnteredUnit: mustCatalogUnitCode(t, "g"),
+				Conversion:  mustCatalogConversion(t, 1_000, 1),
+			},
+		},
+	}); err != nil {
+		t.Fatalf("post adjustment: %v", err)
+	}
+
+	// An allocation hung on an inbound line consumes the later lot without
+	// any outbound line accounting for it, leaving that lot at -20.
+	if _, err := store.database.ExecContext(ctx, `DROP TRIGGER lot_allocations_validate_insert`); err != nil {
+		t.Fatalf("drop allocation trigger: %v", err)
+	}
+	if _, err := store.database.ExecContext(ctx, `
+		INSERT INTO lot_allocations (line_id, lot_id, quantity_atomic, created_at_ms)
+		VALUES (?, ?, 90, 6000)
+	`, early.Lines()[0].ID().Int64(), late.Lines()[0].LotID().Int64()); err != nil {
+		t.Fatalf("insert stray allocation: %v", err)
+	}
+
+	reconciliation, err := store.ReconcileInventory(ctx)
+	if err != nil {
+		t.Fatalf("reconcile ledger: %v", err)
+	}
+	discrepancies := reconciliation.Discrepancies()
+	want := []struct {
+		kind             inventory.DiscrepancyKind
+		lotID            domain.Option[domain.InventoryLotID]
+		expected, stored int64
+		invariantID      string
+	}{
+		{inventory.DiscrepancyLotAvailable, domain.Some(late.Lines()[0].LotID()), 0, -20, "LOT-004"},
+		{inventory.DiscrepancyLotItemQuantity, domain.None[domain.InventoryLotID](), 70, -20, "LOT-010"},
+	}
+	if len(discrepancies) != len(want) {
+		t.Fatalf("discrepancies = %#v, want %d", discrepancies, len(want))
+	}
+	for index, expected := range want {
+		got := discrepancies[index]
+		if got.Kind() != expected.kind || got.ItemID() != item.Item().ID() || got.LotID() != expected.lotID ||
+			got.Expected() != expected.expected || got.Stored() != expected.stored ||
+			got.Kind().InvariantID() != expected.invariantID {
+			t.Fatalf("discrepancy %d = %#v, want %#v", index, got, expected)
+		}
+	}
+}
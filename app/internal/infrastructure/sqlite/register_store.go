@@ -0,0 +1,631 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+)
+
+// OpenRegisterSessionInput opens the drawer with a float. BusinessDate is the
+// opening day in the business timezone.
+type OpenRegisterSessionInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	BusinessDate   domain.BusinessDate
+	OpeningFloat   domain.MinorAmount
+	Notes          domain.Option[domain.NonEmptyText]
+	OpenedAt       domain.UTCInstant
+}
+
+type RecordCashMovementInput struct {
+	IdempotencyKey    domain.IdempotencyKey
+	RegisterSessionID domain.RegisterSessionID
+	Kind              domain.CashMovementKind
+	Amount            domain.MinorAmount
+	Notes             domain.NonEmptyText
+	RecordedAt        domain.UTCInstant
+}
+
+// CloseRegisterSessionInput closes a session with the cash counted in the
+// drawer. The closing date is ClosedAt's day in Timezone.
+type CloseRegisterSessionInput struct {
+	RegisterSessionID domain.RegisterSessionID
+	CountedCash       domain.MinorAmount
+	Notes             domain.Option[domain.NonEmptyText]
+	ClosedAt          domain.UTCInstant
+	Timezone          domain.BusinessTimezone
+}
+
+// RegisterSessionFilter selects closed sessions by their opening business
+// date, inclusive.
+type RegisterSessionFilter struct {
+	FromBusinessDate domain.BusinessDate
+	ToBusinessDate   domain.BusinessDate
+}
+
+func (s *Store) GetRegisterSession(ctx context.Context, id domain.RegisterSessionID) (sales.RegisterSession, error) {
+	if id.IsZero() {
+		return sales.RegisterSession{}, domain.Invalid("register_session_id", domain.ViolationRequired, "REG-001")
+	}
+	var session sales.RegisterSession
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadRegisterSession(ctx, tx, id.Int64())
+		if err != nil {
+			return err
+		}
+		session = value
+		return nil
+	})
+	if err != nil {
+		return sales.RegisterSession{}, classifyError("get register session", err)
+	}
+	return session, nil
+}
+
+// GetOpenRegisterSession returns the session without a closing, or
+// ErrNotFound when the register is closed.
+func (s *Store) GetOpenRegisterSession(ctx context.Context) (sales.RegisterSession, error) {
+	var session sales.RegisterSession
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadOpenRegisterSession(ctx, tx)
+		if err != nil {
+			return err
+		}
+		session = value
+		return nil
+	})
+	if err != nil {
+		return sales.RegisterSession{}, classifyError("get open register session", err)
+	}
+	return session, nil
+}
+
+// ListClosedRegisterSessions returns the closed sessions opened within the
+// filter's business dates, newest first.
+func (s *Store) ListClosedRegisterSessions(
+	ctx context.Context,
+	filter RegisterSessionFilter,
+) ([]sales.RegisterSession, error) {
+	if filter.FromBusinessDate.IsZero() || filter.ToBusinessDate.IsZero() ||
+		filter.ToBusinessDate.Before(filter.FromBusinessDate) {
+		return nil, domain.Invalid("business_date", domain.ViolationOutOfRange, "REG-001")
+	}
+	var sessions []sales.RegisterSession
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT session.id
+			FROM register_sessions session
+			JOIN register_closings closing ON closing.register_session_id = session.id
+			WHERE session.business_date >= ? AND session.business_date <= ?
+			ORDER BY session.business_date DESC, session.id DESC
+		`, filter.FromBusinessDate.String(), filter.ToBusinessDate.String())
+		if err != nil {
+			return err
+		}
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			session, err := loadRegisterSession(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			sessions = append(sessions, session)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list closed register sessions", err)
+	}
+	return sessions, nil
+}
+
+// OpenRegisterSession opens the register when no session is open. Retrying
+// with the same idempotency key returns the first session.
+func (s *Store) OpenRegisterSession(ctx context.Context, input OpenRegisterSessionInput) (sales.RegisterSession, error) {
+	if input.IdempotencyKey.String() == "" {
+		return sales.RegisterSession{}, domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.BusinessDate.IsZero() {
+		return sales.RegisterSession{}, domain.Invalid("business_date", domain.ViolationRequired, "REG-001")
+	}
+	if input.OpenedAt.IsZero() {
+		return sales.RegisterSession{}, domain.Invalid("opened_at", domain.ViolationRequired, "")
+	}
+	var session sales.RegisterSession
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			SELECT id FROM register_sessions WHERE idempotency_key = ?
+		`, input.IdempotencyKey.String()).Scan(&id)
+		if err == nil {
+			replayed, err := loadRegisterSession(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			if replayed.OpeningFloat() != input.OpeningFloat {
+				return fmt.Errorf("%w: idempotency key belongs to another register session", domain.ErrConflict)
+			}
+			session = replayed
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if _, err := loadOpenRegisterSession(ctx, tx); err == nil {
+			return fmt.Errorf("%w: a register session is already open", domain.ErrConflict)
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO register_sessions (
+				idempotency_key, business_date, opening_float_minor, notes, opened_at_ms
+			) VALUES (?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			input.IdempotencyKey.String(),
+			input.BusinessDate.String(),
+			input.OpeningFloat.Int64(),
+			nullableText(input.Notes),
+			input.OpenedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		session, err = loadRegisterSession(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return sales.RegisterSession{}, classifyError("open register session", err)
+	}
+	return session, nil
+}
+
+// RecordCashMovement records cash put into or taken out of the drawer of an
+// open session. Retrying with the same idempotency key returns the first
+// movement.
+func (s *Store) RecordCashMovement(ctx context.Context, input RecordCashMovementInput) (sales.CashMovement, error) {
+	if err := validateRecordCashMovementInput(input); err != nil {
+		return sales.CashMovement{}, err
+	}
+	var movement sales.CashMovement
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		var id int64
+		err := tx.QueryRowContext(ctx, `
+			SELECT id FROM register_cash_movements WHERE idempotency_key = ?
+		`, input.IdempotencyKey.String()).Scan(&id)
+		if err == nil {
+			replayed, err := loadCashMovement(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			if replayed.RegisterSessionID() != input.RegisterSessionID || replayed.Kind() != input.Kind ||
+				replayed.Amount() != input.Amount {
+				return fmt.Errorf("%w: idempotency key belongs to another cash movement", domain.ErrConflict)
+			}
+			movement = replayed
+			return nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		session, err := loadRegisterSession(ctx, tx, input.RegisterSessionID.Int64())
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: cash movement must belong to a register session", domain.ErrInvalidReference)
+		}
+		if err != nil {
+			return err
+		}
+		if !session.IsOpen() {
+			return fmt.Errorf("%w: the register session is closed", domain.ErrConflict)
+		}
+		if input.RecordedAt.Before(session.OpenedAt()) {
+			return domain.Invalid("recorded_at", domain.ViolationOutOfRange, "REG-002")
+		}
+
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO register_cash_movements (
+				idempotency_key, register_session_id, kind, amount_minor, notes, recorded_at_ms
+			) VALUES (?, ?, ?, ?, ?, ?)
+			RETURNING id
+		`,
+			input.IdempotencyKey.String(),
+			input.RegisterSessionID.Int64(),
+			input.Kind.String(),
+			input.Amount.Int64(),
+			input.Notes.String(),
+			input.RecordedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		movement, err = loadCashMovement(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return sales.CashMovement{}, classifyError("record cash movement", err)
+	}
+	return movement, nil
+}
+
+// CloseRegisterSession freezes the session's totals beside the counted cash.
+// Closing an already closed session with the same count returns it; any
+// other count conflicts.
+func (s *Store) CloseRegisterSession(ctx context.Context, input CloseRegisterSessionInput) (sales.RegisterSession, error) {
+	if input.RegisterSessionID.IsZero() {
+		return sales.RegisterSession{}, domain.Invalid("register_session_id", domain.ViolationRequired, "REG-003")
+	}
+	if input.ClosedAt.IsZero() {
+		return sales.RegisterSession{}, domain.Invalid("closed_at", domain.ViolationRequired, "REG-003")
+	}
+	var session sales.RegisterSession
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadRegisterSession(ctx, tx, input.RegisterSessionID.Int64())
+		if err != nil {
+			return err
+		}
+		if closing, ok := current.Closing().Get(); ok {
+			if closing.CountedCash() != input.CountedCash {
+				return fmt.Errorf("%w: the register session is already closed", domain.ErrConflict)
+			}
+			session = current
+			return nil
+		}
+		closing, err := current.Close(input.CountedCash, input.Notes, input.ClosedAt, input.Timezone)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO register_closings (
+				register_session_id, closed_on, cash_minor, pix_minor, card_minor, on_account_minor,
+				cash_in_minor, cash_out_minor, expected_cash_minor, counted_cash_minor, notes, closed_at_ms
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`,
+			input.RegisterSessionID.Int64(),
+			closing.ClosedOn.String(),
+			closing.Totals.CashMinor,
+			closing.Totals.PixMinor,
+			closing.Totals.CardMinor,
+			closing.Totals.OnAccountMinor,
+			closing.Totals.CashInMinor,
+			closing.Totals.CashOutMinor,
+			closing.Totals.ExpectedCashMinor,
+			closing.CountedCash.Int64(),
+			nullableText(closing.Notes),
+			closing.ClosedAt.UnixMilli(),
+		); err != nil {
+			return err
+		}
+		session, err = loadRegisterSession(ctx, tx, input.RegisterSessionID.Int64())
+		return err
+	})
+	if err != nil {
+		return sales.RegisterSession{}, classifyError("close register session", err)
+	}
+	return session, nil
+}
+
+func validateRecordCashMovementInput(input RecordCashMovementInput) error {
+	if input.IdempotencyKey.String() == "" {
+		return domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.RegisterSessionID.IsZero() {
+		return domain.Invalid("register_session_id", domain.ViolationRequired, "REG-002")
+	}
+	if _, err := domain.ParseCashMovementKind(input.Kind.String()); err != nil {
+		return err
+	}
+	if input.Amount.Int64() <= 0 {
+		return domain.Invalid("amount_minor", domain.ViolationNotPositive, "REG-002")
+	}
+	if input.Notes.String() == "" {
+		return domain.Invalid("notes", domain.ViolationRequired, "REG-002")
+	}
+	if input.RecordedAt.IsZero() {
+		return domain.Invalid("recorded_at", domain.ViolationRequired, "")
+	}
+	return nil
+}
+
+func loadOpenRegisterSession(ctx context.Context, tx databaseWriteTx) (sales.RegisterSession, error) {
+	var id int64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT session.id
+		FROM register_sessions session
+		WHERE NOT EXISTS (
+			SELECT 1 FROM register_closings closing
+			WHERE closing.register_session_id = session.id
+		)
+	`).Scan(&id); err != nil {
+		return sales.RegisterSession{}, err
+	}
+	return loadRegisterSession(ctx, tx, id)
+}
+
+func loadRegisterSession(ctx context.Context, tx databaseWriteTx, id int64) (sales.RegisterSession, error) {
+	var row registerSessionRow
+	if err := tx.QueryRowContext(ctx, `
+		SELECT session.id, session.idempotency_key, session.business_date, session.opening_float_minor,
+		       session.notes, session.opened_at_ms,
+		       closing.closed_on, closing.cash_minor, closing.pix_minor, closing.card_minor,
+		       closing.on_account_minor, closing.cash_in_minor, closing.cash_out_minor,
+		       closing.expected_cash_minor, closing.counted_cash_minor, closing.notes, closing.closed_at_ms
+		FROM register_sessions session
+		LEFT JOIN register_closings closing ON closing.register_session_id = session.id
+		WHERE session.id = ?
+	`, id).Scan(
+		&row.id,
+		&row.idempotencyKey,
+		&row.businessDate,
+		&row.openingFloatMinor,
+		&row.notes,
+		&row.openedAtMS,
+		&row.closedOn,
+		&row.cashMinor,
+		&row.pixMinor,
+		&row.cardMinor,
+		&row.onAccountMinor,
+		&row.cashInMinor,
+		&row.cashOutMinor,
+		&row.expectedCashMinor,
+		&row.countedCashMinor,
+		&row.closingNotes,
+		&row.closedAtMS,
+	); err != nil {
+		return sales.RegisterSession{}, err
+	}
+	payments, err := loadRegisterSessionPayments(ctx, tx, id)
+	if err != nil {
+		return sales.RegisterSession{}, err
+	}
+	movements, err := loadCashMovements(ctx, tx, id)
+	if err != nil {
+		return sales.RegisterSession{}, err
+	}
+	session, err := mapRegisterSession(row, payments, movements)
+	if err != nil {
+		return sales.RegisterSession{}, corruptDataError("map register session", err)
+	}
+	return session, nil
+}
+
+// loadRegisterSessionPayments returns the sale payments recorded while the
+// session was open, in recording order.
+func loadRegisterSessionPayments(ctx context.Context, tx databaseWriteTx, sessionID int64) ([]sales.Payment, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT payment.id, payment.idempotency_key, payment.sale_document_id, payment.method,
+		       payment.amount_minor, payment.received_on, payment.notes, payment.reverses_payment_id,
+		       payment.recorded_at_ms
+		FROM register_session_payments link
+		JOIN sale_payments payment ON payment.id = link.sale_payment_id
+		WHERE link.register_session_id = ?
+		ORDER BY payment.id
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var payments []sales.Payment
+	for rows.Next() {
+		var row salePaymentRow
+		if err := rows.Scan(
+			&row.id,
+			&row.idempotencyKey,
+			&row.saleDocumentID,
+			&row.method,
+			&row.amountMinor,
+			&row.receivedOn,
+			&row.notes,
+			&row.reversesPaymentID,
+			&row.recordedAtMS,
+		); err != nil {
+			return nil, err
+		}
+		payment, err := mapSalePayment(row)
+		if err != nil {
+			return nil, corruptDataError("map sale payment", err)
+		}
+		payments = append(payments, payment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func loadCashMovement(ctx context.Context, tx databaseWriteTx, id int64) (sales.CashMovement, error) {
+	var row cashMovementRow
+	if err := tx.QueryRowContext(ctx, `
+		SELECT id, idempotency_key, register_session_id, kind, amount_minor, notes, recorded_at_ms
+		FROM register_cash_movements
+		WHERE id = ?
+	`, id).Scan(
+		&row.id,
+		&row.idempotencyKey,
+		&row.registerSessionID,
+		&row.kind,
+		&row.amountMinor,
+		&row.notes,
+		&row.recordedAtMS,
+	); err != nil {
+		return sales.CashMovement{}, err
+	}
+	movement, err := mapCashMovement(row)
+	if err != nil {
+		return sales.CashMovement{}, corruptDataError("map cash movement", err)
+	}
+	return movement, nil
+}
+
+func loadCashMovements(ctx context.Context, tx databaseWriteTx, sessionID int64) ([]sales.CashMovement, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, idempotency_key, register_session_id, kind, amount_minor, notes, recorded_at_ms
+		FROM register_cash_movements
+		WHERE register_session_id = ?
+		ORDER BY id
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var movements []sales.CashMovement
+	for rows.Next() {
+		var row cashMovementRow
+		if err := rows.Scan(
+			&row.id,
+			&row.idempotencyKey,
+			&row.registerSessionID,
+			&row.kind,
+			&row.amountMinor,
+			&row.notes,
+			&row.recordedAtMS,
+		); err != nil {
+			return nil, err
+		}
+		movement, err := mapCashMovement(row)
+		if err != nil {
+			return nil, corruptDataError("map cash movement", err)
+		}
+		movements = append(movements, movement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+type registerSessionRow struct {
+	id, openingFloatMinor, openedAtMS              int64
+	idempotencyKey, businessDate                   string
+	notes, closedOn, closingNotes                  sql.NullString
+	cashMinor, pixMinor, cardMinor, onAccountMinor sql.NullInt64
+	cashInMinor, cashOutMinor, expectedCashMinor   sql.NullInt64
+	countedCashMinor, closedAtMS                   sql.NullInt64
+}
+
+type cashMovementRow struct {
+	id, registerSessionID, amountMinor, recordedAtMS int64
+	idempotencyKey, kind, notes                      string
+}
+
+func mapRegisterSession(
+	row registerSessionRow,
+	payments []sales.Payment,
+	movements []sales.CashMovement,
+) (sales.RegisterSession, error) {
+	id, err := domain.NewRegisterSessionID(row.id)
+	if err != nil {
+		return sales.RegisterSession{}, err
+	}
+	key, err := domain.NewIdempotencyKey(row.idempotencyKey)
+	if err != nil {
+		return sales.RegisterSession{}, err
+	}
+	businessDate, err := domain.ParseBusinessDate(row.businessDate)
+	if err != nil {
+		return sales.RegisterSession{}, err
+	}
+	openingFloat, err := domain.NewMinorAmount(row.openingFloatMinor)
+	if err != nil {
+		return sales.RegisterSession{}, err
+	}
+	notes, err := optionalNonEmptyText(row.notes)
+	if err != nil {
+		return sales.RegisterSession{}, err
+	}
+	openedAt, err := domain.UTCInstantFromUnixMilli(row.openedAtMS)
+	if err != nil {
+		return sales.RegisterSession{}, err
+	}
+	closing := domain.None[sales.RegisterClosingParams]()
+	if row.closedAtMS.Valid {
+		closedOn, err := domain.ParseBusinessDate(row.closedOn.String)
+		if err != nil {
+			return sales.RegisterSession{}, err
+		}
+		counted, err := domain.NewMinorAmount(row.countedCashMinor.Int64)
+		if err != nil {
+			return sales.RegisterSession{}, err
+		}
+		closingNotes, err := optionalNonEmptyText(row.closingNotes)
+		if err != nil {
+			return sales.RegisterSession{}, err
+		}
+		closedAt, err := domain.UTCInstantFromUnixMilli(row.closedAtMS.Int64)
+		if err != nil {
+			return sales.RegisterSession{}, err
+		}
+		closing = domain.Some(sales.RegisterClosingParams{
+			ClosedOn: closedOn,
+			Totals: sales.RegisterTotals{
+				OpeningFloatMinor: row.openingFloatMinor,
+				CashMinor:         row.cashMinor.Int64,
+				PixMinor:          row.pixMinor.Int64,
+				CardMinor:         row.cardMinor.Int64,
+				OnAccountMinor:    row.onAccountMinor.Int64,
+				CashInMinor:       row.cashInMinor.Int64,
+				CashOutMinor:      row.cashOutMinor.Int64,
+				ExpectedCashMinor: row.expectedCashMinor.Int64,
+			},
+			CountedCash: counted,
+			Notes:       closingNotes,
+			ClosedAt:    closedAt,
+		})
+	}
+	return sales.NewRegisterSession(sales.RegisterSessionParams{
+		ID: id, IdempotencyKey: key, BusinessDate: businessDate, OpeningFloat: openingFloat,
+		Notes: notes, OpenedAt: openedAt, Payments: payments, Movements: movements, Closing: closing,
+	})
+}
+
+func mapCashMovement(row cashMovementRow) (sales.CashMovement, error) {
+	id, err := domain.NewCashMovementID(row.id)
+	if err != nil {
+		return sales.CashMovement{}, err
+	}
+	key, err := domain.NewIdempotencyKey(row.idempotencyKey)
+	if err != nil {
+		return sales.CashMovement{}, err
+	}
+	sessionID, err := domain.NewRegisterSessionID(row.registerSessionID)
+	if err != nil {
+		return sales.CashMovement{}, err
+	}
+	kind, err := domain.ParseCashMovementKind(row.kind)
+	if err != nil {
+		return sales.CashMovement{}, err
+	}
+	amount, err := domain.NewMinorAmount(row.amountMinor)
+	if err != nil {
+		return sales.CashMovement{}, err
+	}
+	notes, err := domain.NewNonEmptyText(row.notes)
+	if err != nil {
+		return sales.CashMovement{}, err
+	}
+	recordedAt, err := domain.UTCInstantFromUnixMilli(row.recordedAtMS)
+	if err != nil {
+		return sales.CashMovement{}, err
+	}
+	return sales.NewCashMovement(sales.CashMovementParams{
+		ID: id, IdempotencyKey: key, RegisterSessionID: sessionID, Kind: kind,
+		Amount: amount, Notes: notes, RecordedAt: recordedAt,
+	})
+}
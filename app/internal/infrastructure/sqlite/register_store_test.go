@@ -0,0 +1,135 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestRegisterStoreClosesSessionsWithTheirPaymentsAndMovements(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "register.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	// Register instants are offsets from 2026-07-15 12:00 UTC.
+	const noon int64 = 1_784_116_800_000
+	cake := createSaleTestItem(t, store, "Register cake", true)
+	postAdjustmentTestPurchase(t, store, cake, "register-purchase", "REG-LOT", "2026-12-31", 200, 2_000)
+	sale, err := store.PostSale(ctx, saleInputFixture(t, cake, "register-sale", 100, 9_000))
+	if err != nil {
+		t.Fatalf("post sale: %v", err)
+	}
+	payment := func(key string, method domain.PaymentMethod, amount, recordedAt int64) RecordSalePaymentInput {
+		return RecordSalePaymentInput{
+			IdempotencyKey: mustPurchaseIdempotencyKey(t, key),
+			SaleDocumentID: sale.ID(),
+			Method:         method,
+			Amount:         mustPurchaseMinorAmount(t, amount),
+			ReceivedOn:     mustPurchaseDate(t, "2026-07-15"),
+			RecordedAt:     mustCatalogInstant(t, noon+recordedAt),
+		}
+	}
+	if _, err := store.RecordSalePayment(ctx, payment("before-register", domain.PaymentCash, 100, 5_000)); err != nil {
+		t.Fatalf("record payment before opening: %v", err)
+	}
+	if _, err := store.GetOpenRegisterSession(ctx); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("open session before opening error = %v, want not found", err)
+	}
+
+	open := OpenRegisterSessionInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "register-1"),
+		BusinessDate:   mustPurchaseDate(t, "2026-07-15"),
+		OpeningFloat:   mustPurchaseMinorAmount(t, 1_000),
+		OpenedAt:       mustCatalogInstant(t, noon+6_000),
+	}
+	session, err := store.OpenRegisterSession(ctx, open)
+	if err != nil {
+		t.Fatalf("open register session: %v", err)
+	}
+	if replayed, err := store.OpenRegisterSession(ctx, open); err != nil || replayed.ID() != session.ID() {
+		t.Fatalf("replayed register session = %#v, %v", replayed, err)
+	}
+	second := open
+	second.IdempotencyKey = mustPurchaseIdempotencyKey(t, "register-2")
+	if _, err := store.OpenRegisterSession(ctx, second); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second open session error = %v, want conflict", err)
+	}
+
+	cash, err := store.RecordSalePayment(ctx, payment("register-cash", domain.PaymentCash, 2_500, 7_000))
+	if err != nil {
+		t.Fatalf("record cash payment: %v", err)
+	}
+	if _, err := store.RecordSalePayment(ctx, payment("register-card", domain.PaymentCard, 800, 7_000)); err != nil {
+		t.Fatalf("record card payment: %v", err)
+	}
+	movement := func(key string, kind domain.CashMovementKind, amount int64) RecordCashMovementInput {
+		return RecordCashMovementInput{
+			IdempotencyKey:    mustPurchaseIdempotencyKey(t, key),
+			RegisterSessionID: session.ID(),
+			Kind:              kind,
+			Amount:            mustPurchaseMinorAmount(t, amount),
+			Notes:             mustCatalogText(t, "Bank deposit"),
+			RecordedAt:        mustCatalogInstant(t, noon+8_000),
+		}
+	}
+	if _, err := store.RecordCashMovement(ctx, movement("cash-out-1", domain.CashOut, 1_500)); err != nil {
+		t.Fatalf("record cash out: %v", err)
+	}
+	early := movement("cash-in-1", domain.CashIn, 200)
+	early.RecordedAt = mustCatalogInstant(t, noon+5_999)
+	if _, err := store.RecordCashMovement(ctx, early); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("movement before opening error = %v, want validation", err)
+	}
+
+	current, err := store.GetOpenRegisterSession(ctx)
+	if err != nil || current.ID() != session.ID() || len(current.Payments()) != 2 || current.Totals().ExpectedCashMinor != 2_000 {
+		t.Fatalf("open register session = %#v, %v", current, err)
+	}
+	closeInput := CloseRegisterSessionInput{
+		RegisterSessionID: session.ID(),
+		CountedCash:       mustPurchaseMinorAmount(t, 1_990),
+		ClosedAt:          mustCatalogInstant(t, noon+9_000),
+		Timezone:          mustSettingsTimezone(t, "America/Sao_Paulo"),
+	}
+	closed, err := store.CloseRegisterSession(ctx, closeInput)
+	if err != nil {
+		t.Fatalf("close register session: %v", err)
+	}
+	closing, ok := closed.Closing().Get()
+	if !ok || closing.Totals().CashMinor != 2_500 || closing.Totals().CardMinor != 800 ||
+		closing.Totals().CashOutMinor != 1_500 || closing.Difference() != -10 {
+		t.Fatalf("register closing = %#v", closed)
+	}
+	if replayed, err := store.CloseRegisterSession(ctx, closeInput); err != nil || replayed.ID() != session.ID() {
+		t.Fatalf("replayed closing = %#v, %v", replayed, err)
+	}
+	closeInput.CountedCash = mustPurchaseMinorAmount(t, 2_000)
+	if _, err := store.CloseRegisterSession(ctx, closeInput); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("recount error = %v, want conflict", err)
+	}
+	if _, err := store.RecordCashMovement(ctx, movement("cash-in-2", domain.CashIn, 200)); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("movement on closed session error = %v, want conflict", err)
+	}
+	if _, err := store.ReverseSalePayment(ctx, ReverseSalePaymentInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "register-cash-reversal"),
+		PaymentID:      cash.ID(),
+		ReceivedOn:     mustPurchaseDate(t, "2026-07-16"),
+		RecordedAt:     mustCatalogInstant(t, noon+10_000),
+	}); err != nil {
+		t.Fatalf("reverse payment after closing: %v", err)
+	}
+	reloaded, err := store.GetRegisterSession(ctx, session.ID())
+	if err != nil || len(reloaded.Payments()) != 2 || reloaded.Totals() != closing.Totals() {
+		t.Fatalf("reloaded register session = %#v, %v", reloaded, err)
+	}
+
+	sessions, err := store.ListClosedRegisterSessions(ctx, RegisterSessionFilter{
+		FromBusinessDate: mustPurchaseDate(t, "2026-07-01"),
+		ToBusinessDate:   mustPurchaseDate(t, "2026-07-31"),
+	})
+	if err != nil || len(sessions) != 1 || sessions[0].ID() != session.ID() {
+		t.Fatalf("closed register sessions = %#v, %v", sessions, err)
+	}
+}
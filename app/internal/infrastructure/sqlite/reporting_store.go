@@ -44,6 +44,15 @@ type PurchaseReportData struct {
 	PurchaseSpendSeries  []ReportingSeries
 	TopSuppliersBySpend  []ReportingCounterpartyMetric
 	FreeStockEntrySeries []ReportingSeries
+	Payables             PurchasePayableTotals
+}
+
+// PurchasePayableTotals sums the scheduled payables of the period's purchases.
+// Owed is net of supplier return credits and zero for reversed purchases.
+type PurchasePayableTotals struct {
+	PayableCount int64
+	OwedMinor    int64
+	PaidMinor    int64
 }
 
 type ProductionReportData struct {
@@ -60,11 +69,40 @@ type AdjustmentReportData struct {
 	ExactReversals   []ReportingSeries
 }
 
+// CategoryMixReportData groups active sales lines by the current category of
+// each sold item. Uncategorized sales form one row with no category ID.
+type CategoryMixReportData struct {
+	Currency domain.Currency
+	Rows     []CategoryMixRow
+}
+
+type CategoryMixRow struct {
+	CategoryID     domain.Option[domain.CategoryID]
+	CategoryName   domain.Option[string]
+	QuantityAtomic int64
+	RevenueMinor   int64
+}
+
+// ProfitAndLossReportData collects the series a profit and loss statement
+// combines. Write-offs and expenses keep one row per bucket and reason code or
+// expense category so callers can total them either way.
+type ProfitAndLossReportData struct {
+	Currency         domain.Currency
+	SalesSeries      []ReportingSeries
+	DirectCostSeries []ReportingSeries
+	WriteOffs        []ReportingBucketReasonMetric
+	Expenses         []ReportingBucketReasonMetric
+}
+
+// SalesReportTotals nets customer returns into quantity, revenue and COGS.
+// ReturnCount and RefundMinor report the returns on their own.
 type SalesReportTotals struct {
 	SalesCount     int64
 	QuantityAtomic int64
 	RevenueMinor   int64
 	COGSMicro      int64
+	ReturnCount    int64
+	RefundMinor    int64
 }
 
 type ReportingSeries struct {
@@ -125,6 +163,16 @@ type ReportingReasonMetric struct {
 	COGSMicro           int64
 }
 
+type ReportingBucketReasonMetric struct {
+	Bucket              string
+	Label               string
+	ReasonCode          string
+	DocumentCount       int64
+	QuantityAtomic      int64
+	AmountMinor         int64
+	InventoryValueMicro int64
+}
+
 func (s *Store) GetSalesReportData(
 	ctx context.Context,
 	current ReportingPeriodFilter,
@@ -204,16 +252,24 @@ func (s *Store) GetSalesReportData(
 	return data, nil
 }
 
+// GetInventoryReportData reads the whole inventory, or only the lots at
+// location when one is given. Location quantities carry the item's weighted
+// average value.
 func (s *Store) GetInventoryReportData(
 	ctx context.Context,
 	filter ReportingPeriodFilter,
+	location domain.Option[domain.StockLocationID],
 	rowLimit int,
 ) (InventoryReportData, error) {
 	if rowLimit <= 0 {
 		rowLimit = 10
 	}
+	locationID, err := locationFilterValue(location)
+	if err != nil {
+		return InventoryReportData{}, err
+	}
 	var data InventoryReportData
-	err := s.withReadQueries(ctx, "get inventory report data", func(queries *sqlcgen.Queries) error {
+	err = s.withReadQueries(ctx, "get inventory report data", func(queries *sqlcgen.Queries) error {
 		currencyRow, err := queries.GetReportingCurrency(ctx)
 		if err != nil {
 			return err
@@ -222,21 +278,28 @@ func (s *Store) GetInventoryReportData(
 		if err != nil {
 			return err
 		}
-		totals, err := queries.GetInventoryReportTotals(ctx)
+		totals, err := queries.GetInventoryReportTotals(ctx, locationID)
 		if err != nil {
 			return err
 		}
-		lowStock, err := queries.ListLowStockItems(ctx, int64(rowLimit))
+		lowStock, err := queries.ListLowStockItems(ctx, sqlcgen.ListLowStockItemsParams{
+			LocationID: locationID,
+			LimitCount: int64(rowLimit),
+		})
 		if err != nil {
 			return err
 		}
-		valueByItem, err := queries.ListInventoryValueByItem(ctx, int64(rowLimit))
+		valueByItem, err := queries.ListInventoryValueByItem(ctx, sqlcgen.ListInventoryValueByItemParams{
+			LocationID: locationID,
+			LimitCount: int64(rowLimit),
+		})
 		if err != nil {
 			return err
 		}
 		expiring7, err := queries.ListExpiringLots(ctx, sqlcgen.ListExpiringLotsParams{
 			ReferenceDate: filter.ToOccurredOn,
 			DaysAhead:     7,
+			LocationID:    locationID,
 			LimitCount:    int64(rowLimit),
 		})
 		if err != nil {
@@ -245,6 +308,7 @@ func (s *Store) GetInventoryReportData(
 		expiring30, err := queries.ListExpiringLots(ctx, sqlcgen.ListExpiringLotsParams{
 			ReferenceDate: filter.ToOccurredOn,
 			DaysAhead:     30,
+			LocationID:    locationID,
 			LimitCount:    int64(rowLimit),
 		})
 		if err != nil {
@@ -252,6 +316,7 @@ func (s *Store) GetInventoryReportData(
 		}
 		expired, err := queries.ListExpiredLotsWithStock(ctx, sqlcgen.ListExpiredLotsWithStockParams{
 			ReferenceDate: filter.ToOccurredOn,
+			LocationID:    locationID,
 			LimitCount:    int64(rowLimit),
 		})
 		if err != nil {
@@ -307,12 +372,24 @@ func (s *Store) GetPurchaseReportData(
 		if err != nil {
 			return err
 		}
+		payables, err := queries.GetPurchasePayableTotals(ctx, sqlcgen.GetPurchasePayableTotalsParams{
+			FromOccurredOn: filter.FromOccurredOn,
+			ToOccurredOn:   filter.ToOccurredOn,
+		})
+		if err != nil {
+			return err
+		}
 
 		data = PurchaseReportData{
 			Currency:             currency,
 			PurchaseSpendSeries:  mapPurchaseSpendSeriesRows(spendSeries),
 			TopSuppliersBySpend:  mapTopSuppliersBySpendRows(topSuppliers),
 			FreeStockEntrySeries: mapFreeStockEntrySeriesRows(freeStock),
+			Payables: PurchasePayableTotals{
+				PayableCount: payables.PayableCount,
+				OwedMinor:    payables.OwedMinor,
+				PaidMinor:    payables.PaidMinor,
+			},
 		}
 		return nil
 	})
@@ -408,6 +485,94 @@ func (s *Store) GetAdjustmentReportData(
 	return data, nil
 }
 
+func (s *Store) GetCategoryMixReportData(
+	ctx context.Context,
+	filter ReportingPeriodFilter,
+) (CategoryMixReportData, error) {
+	var data CategoryMixReportData
+	err := s.withReadQueries(ctx, "get category mix report data", func(queries *sqlcgen.Queries) error {
+		currencyRow, err := queries.GetReportingCurrency(ctx)
+		if err != nil {
+			return err
+		}
+		currency, err := domain.RestoreCurrency(currencyRow.CurrencyCode, int(currencyRow.CurrencyMinorDigits))
+		if err != nil {
+			return err
+		}
+		rows, err := queries.ListSalesCategoryMix(ctx, sqlcgen.ListSalesCategoryMixParams{
+			FromOccurredOn: filter.FromOccurredOn,
+			ToOccurredOn:   filter.ToOccurredOn,
+		})
+		if err != nil {
+			return err
+		}
+		mapped, err := mapCategoryMixRows(rows)
+		if err != nil {
+			return corruptDataError("map category mix", err)
+		}
+		data = CategoryMixReportData{Currency: currency, Rows: mapped}
+		return nil
+	})
+	if err != nil {
+		return CategoryMixReportData{}, err
+	}
+	return data, nil
+}
+
+func (s *Store) GetProfitAndLossReportData(
+	ctx context.Context,
+	filter ReportingPeriodFilter,
+) (ProfitAndLossReportData, error) {
+	var data ProfitAndLossReportData
+	err := s.withReadQueries(ctx, "get profit and loss report data", func(queries *sqlcgen.Queries) error {
+		currencyRow, err := queries.GetReportingCurrency(ctx)
+		if err != nil {
+			return err
+		}
+		currency, err := domain.RestoreCurrency(currencyRow.CurrencyCode, int(currencyRow.CurrencyMinorDigits))
+		if err != nil {
+			return err
+		}
+		salesSeries, err := queries.ListSalesRevenueSeries(ctx, salesSeriesParams(filter))
+		if err != nil {
+			return err
+		}
+		directCostSeries, err := queries.ListProductionDirectCostSeries(ctx, productionDirectCostSeriesParams(filter))
+		if err != nil {
+			return err
+		}
+		writeOffs, err := queries.ListWriteOffSeries(ctx, sqlcgen.ListWriteOffSeriesParams{
+			Granularity:    filter.Granularity,
+			FromOccurredOn: filter.FromOccurredOn,
+			ToOccurredOn:   filter.ToOccurredOn,
+		})
+		if err != nil {
+			return err
+		}
+		expenses, err := queries.ListExpenseSeries(ctx, sqlcgen.ListExpenseSeriesParams{
+			Granularity:    filter.Granularity,
+			FromOccurredOn: filter.FromOccurredOn,
+			ToOccurredOn:   filter.ToOccurredOn,
+		})
+		if err != nil {
+			return err
+		}
+
+		data = ProfitAndLossReportData{
+			Currency:         currency,
+			SalesSeries:      mapSalesSeriesRows(salesSeries),
+			DirectCostSeries: mapProductionDirectCostSeriesRows(directCostSeries),
+			WriteOffs:        mapWriteOffSeriesRows(writeOffs),
+			Expenses:         mapExpenseSeriesRows(expenses),
+		}
+		return nil
+	})
+	if err != nil {
+		return ProfitAndLossReportData{}, err
+	}
+	return data, nil
+}
+
 func salesTotalsParams(filter ReportingPeriodFilter) sqlcgen.GetSalesReportTotalsParams {
 	return sqlcgen.GetSalesReportTotalsParams{
 		FromOccurredOn: filter.FromOccurredOn,
@@ -531,6 +696,8 @@ func mapSalesTotalsRow(row sqlcgen.GetSalesReportTotalsRow) SalesReportTotals {
 		QuantityAtomic: row.QuantityAtomic,
 		RevenueMinor:   row.RevenueMinor,
 		COGSMicro:      row.CogsMicro,
+		ReturnCount:    row.ReturnCount,
+		RefundMinor:    row.RefundMinor,
 	}
 }
 
@@ -826,6 +993,35 @@ func mapAdjustmentReasonMetricRows(rows []sqlcgen.ListAdjustmentReasonMetricsRow
 	return items
 }
 
+func mapWriteOffSeriesRows(rows []sqlcgen.ListWriteOffSeriesRow) []ReportingBucketReasonMetric {
+	items := make([]ReportingBucketReasonMetric, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ReportingBucketReasonMetric{
+			Bucket:              row.Bucket,
+			Label:               row.Label,
+			ReasonCode:          row.ReasonCode,
+			DocumentCount:       row.DocumentCount,
+			QuantityAtomic:      row.QuantityAtomic,
+			InventoryValueMicro: row.InventoryValueMicro,
+		})
+	}
+	return items
+}
+
+func mapExpenseSeriesRows(rows []sqlcgen.ListExpenseSeriesRow) []ReportingBucketReasonMetric {
+	items := make([]ReportingBucketReasonMetric, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, ReportingBucketReasonMetric{
+			Bucket:        row.Bucket,
+			Label:         row.Label,
+			ReasonCode:    row.Category,
+			DocumentCount: row.ExpenseCount,
+			AmountMinor:   row.AmountMinor,
+		})
+	}
+	return items
+}
+
 func mapSalesByCustomerRows(rows []sqlcgen.ListSalesByCustomerRow) []ReportingCounterpartyMetric {
 	items := make([]ReportingCounterpartyMetric, 0, len(rows))
 	for _, row := range rows {
@@ -873,6 +1069,23 @@ func mapAnonymousSalesTotals(row sqlcgen.GetAnonymousSalesTotalsRow) ReportingCo
 	}
 }
 
+func mapCategoryMixRows(rows []sqlcgen.ListSalesCategoryMixRow) ([]CategoryMixRow, error) {
+	items := make([]CategoryMixRow, 0, len(rows))
+	for _, row := range rows {
+		categoryID, err := restoreOptionalCategoryID(row.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, CategoryMixRow{
+			CategoryID:     categoryID,
+			CategoryName:   optionSQLString(row.CategoryName),
+			QuantityAtomic: row.QuantityAtomic,
+			RevenueMinor:   row.RevenueMinor,
+		})
+	}
+	return items, nil
+}
+
 func optionWhenValid[T any](value T, err error) domain.Option[T] {
 	if err != nil {
 		return domain.None[T]()
@@ -119,7 +119,7 @@ func TestReportingStoreInventoryReportSummarizesBalancesLowStockAndLotRisk(t *te
 		FromOccurredOn: "2026-07-01",
 		ToOccurredOn:   "2026-07-15",
 		Granularity:    "DAY",
-	}, 10)
+	}, domain.None[domain.StockLocationID](), 10)
 	if err != nil {
 		t.Fatalf("get inventory report data: %v", err)
 	}
@@ -357,6 +357,148 @@ func TestReportingStoreAdjustmentReportAggregatesReasonsAndExactReversals(t *tes
 	}
 }
 
+func TestReportingStoreCategoryMixGroupsActiveSalesByItemCategory(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "reporting-category-mix.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	cakes, err := store.CreateCategory(ctx, CreateCategoryInput{Name: mustCatalogName(t, "Bolos"), CreatedAt: mustCatalogInstant(t, 500)})
+	if err != nil {
+		t.Fatalf("create category: %v", err)
+	}
+	cakeID := createCatalogItem(t, store, CreateItemInput{
+		Name: mustCatalogName(t, "Categorized cake"), BaseUnit: mustCatalogUnitCode(t, "g"),
+		Capabilities: catalog.NewCapabilities(true, false, true), Category: domain.Some(cakes.ID()),
+		CreatedAt: mustCatalogInstant(t, 1_000), UpdatedAt: mustCatalogInstant(t, 1_000),
+	}).Item().ID()
+	looseID := createSaleTestItem(t, store, "Uncategorized cookie", true)
+	postAdjustmentTestPurchase(t, store, cakeID, "mix-cake-stock", "MIX-CAKE", "2026-12-31", 100, 1_000)
+	postAdjustmentTestPurchase(t, store, looseID, "mix-loose-stock", "MIX-LOOSE", "2026-12-31", 101, 1_000)
+
+	if _, err := store.PostSale(ctx, reportSaleInput(t, cakeID, "mix-cake-sale", "2026-07-10", 3, 3_000, domain.None[domain.CounterpartyID](), domain.None[domain.DocumentReason]())); err != nil {
+		t.Fatalf("post categorized sale: %v", err)
+	}
+	if _, err := store.PostSale(ctx, reportSaleInput(t, looseID, "mix-loose-sale", "2026-07-11", 4, 1_000, domain.None[domain.CounterpartyID](), domain.None[domain.DocumentReason]())); err != nil {
+		t.Fatalf("post uncategorized sale: %v", err)
+	}
+	reversed, err := store.PostSale(ctx, reportSaleInput(t, cakeID, "mix-reversed-sale", "2026-07-12", 5, 9_000, domain.None[domain.CounterpartyID](), domain.None[domain.DocumentReason]()))
+	if err != nil {
+		t.Fatalf("post sale to reverse: %v", err)
+	}
+	if _, err := store.PostReversal(ctx, PostReversalInput{
+		IdempotencyKey:   mustPurchaseIdempotencyKey(t, "reverse-mix-sale"),
+		TargetDocumentID: reversed.ID(),
+		OccurredOn:       mustPurchaseDate(t, "2026-07-12"),
+		PostedAt:         mustCatalogInstant(t, 8_000),
+	}); err != nil {
+		t.Fatalf("reverse sale: %v", err)
+	}
+
+	report, err := store.GetCategoryMixReportData(ctx, ReportingPeriodFilter{
+		FromOccurredOn: "2026-07-01", ToOccurredOn: "2026-07-31", Granularity: "MONTH",
+	})
+	if err != nil {
+		t.Fatalf("get category mix report data: %v", err)
+	}
+	if len(report.Rows) != 2 {
+		t.Fatalf("category mix rows = %#v", report.Rows)
+	}
+	if id, ok := report.Rows[0].CategoryID.Get(); !ok || id != cakes.ID() ||
+		report.Rows[0].CategoryName != domain.Some("Bolos") ||
+		report.Rows[0].QuantityAtomic != 3 || report.Rows[0].RevenueMinor != 3_000 {
+		t.Fatalf("categorized row = %#v", report.Rows[0])
+	}
+	if report.Rows[1].CategoryID.IsSome() || report.Rows[1].CategoryName.IsSome() ||
+		report.Rows[1].QuantityAtomic != 4 || report.Rows[1].RevenueMinor != 1_000 {
+		t.Fatalf("uncategorized row = %#v", report.Rows[1])
+	}
+}
+
+func TestReportingStoreProfitAndLossCombinesSalesWriteOffsAndCurrentExpenses(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "reporting-profit.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	itemID := createSaleTestItem(t, store, "Profit cake", true)
+	postAdjustmentTestPurchase(t, store, itemID, "profit-stock", "PROFIT-LOT", "2026-12-31", 100, 1_000)
+	if _, err := store.PostSale(ctx, reportSaleInput(t, itemID, "profit-sale", "2026-07-10", 10, 2_000, domain.None[domain.CounterpartyID](), domain.None[domain.DocumentReason]())); err != nil {
+		t.Fatalf("post sale: %v", err)
+	}
+	postReportingAdjustment(t, store, "profit-waste", "2026-07-11", 3_000, domain.ReasonWaste, itemID, domain.DirectionOut, 4, domain.None[domain.InventoryValue]())
+	reversed := postReportingAdjustment(t, store, "profit-damage", "2026-07-12", 4_000, domain.ReasonDamage, itemID, domain.DirectionOut, 5, domain.None[domain.InventoryValue]())
+	if _, err := store.PostReversal(ctx, PostReversalInput{
+		IdempotencyKey:   mustPurchaseIdempotencyKey(t, "profit-damage-reversal"),
+		TargetDocumentID: reversed.ID(),
+		OccurredOn:       mustPurchaseDate(t, "2026-07-12"),
+		PostedAt:         mustCatalogInstant(t, 5_000),
+	}); err != nil {
+		t.Fatalf("reverse damage: %v", err)
+	}
+	postReportingAdjustment(t, store, "profit-found", "2026-07-13", 6_000, domain.ReasonOpeningBalance, itemID, domain.DirectionIn, 2, domain.Some(mustInventoryValue(t, 200_000)))
+
+	rent, err := store.RecordExpense(ctx, RecordExpenseInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "profit-rent"),
+		Category:       domain.ExpenseRent,
+		Amount:         mustPurchaseMinorAmount(t, 900),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-01"),
+		RecordedAt:     mustCatalogInstant(t, 7_000),
+	})
+	if err != nil {
+		t.Fatalf("record rent: %v", err)
+	}
+	if _, err := store.CorrectExpense(ctx, CorrectExpenseInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "profit-rent-fix"),
+		ExpenseID:      rent.ID(),
+		Category:       domain.ExpenseRent,
+		Amount:         mustPurchaseMinorAmount(t, 800),
+		OccurredOn:     mustPurchaseDate(t, "2026-08-01"),
+		RecordedAt:     mustCatalogInstant(t, 8_000),
+	}); err != nil {
+		t.Fatalf("correct rent: %v", err)
+	}
+	if _, err := store.RecordExpense(ctx, RecordExpenseInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "profit-water"),
+		Category:       domain.ExpenseWater,
+		Amount:         mustPurchaseMinorAmount(t, 150),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-20"),
+		RecordedAt:     mustCatalogInstant(t, 9_000),
+	}); err != nil {
+		t.Fatalf("record water: %v", err)
+	}
+
+	report, err := store.GetProfitAndLossReportData(ctx, ReportingPeriodFilter{
+		FromOccurredOn: "2026-07-01",
+		ToOccurredOn:   "2026-08-31",
+		Granularity:    "MONTH",
+	})
+	if err != nil {
+		t.Fatalf("get profit and loss report data: %v", err)
+	}
+
+	if len(report.SalesSeries) != 1 ||
+		report.SalesSeries[0].Bucket != "2026-07" ||
+		report.SalesSeries[0].RevenueMinor != 2_000 ||
+		report.SalesSeries[0].COGSMicro != 1_000_000 {
+		t.Fatalf("sales series = %#v", report.SalesSeries)
+	}
+	if len(report.WriteOffs) != 1 ||
+		report.WriteOffs[0].Bucket != "2026-07" ||
+		report.WriteOffs[0].ReasonCode != domain.ReasonWaste.String() ||
+		report.WriteOffs[0].QuantityAtomic != 4 ||
+		report.WriteOffs[0].InventoryValueMicro != 400_000 {
+		t.Fatalf("write-offs = %#v", report.WriteOffs)
+	}
+	if len(report.Expenses) != 2 ||
+		report.Expenses[0].Bucket != "2026-07" ||
+		report.Expenses[0].ReasonCode != domain.ExpenseWater.String() ||
+		report.Expenses[0].AmountMinor != 150 ||
+		report.Expenses[1].Bucket != "2026-08" ||
+		report.Expenses[1].ReasonCode != domain.ExpenseRent.String() ||
+		report.Expenses[1].DocumentCount != 1 ||
+		report.Expenses[1].AmountMinor != 800 {
+		t.Fatalf("expenses = %#v", report.Expenses)
+	}
+	if len(report.DirectCostSeries) != 0 {
+		t.Fatalf("direct cost series = %#v", report.DirectCostSeries)
+	}
+}
+
 func reportSaleInput(
 	t *testing.T,
 	itemID domain.ItemID,
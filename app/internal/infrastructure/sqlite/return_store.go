@@ -0,0 +1,811 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type PostReturnInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	SaleDocumentID domain.StockDocumentID
+	OccurredOn     domain.BusinessDate
+	PostedAt       domain.UTCInstant
+	Notes          domain.Option[domain.NonEmptyText]
+	Lines          []PostReturnLineInput
+}
+
+// PostReturnLineInput returns part of one sale line. Without an explicit
+// refund the line refunds its proportional share of the sale line total.
+type PostReturnLineInput struct {
+	SaleLineID domain.StockDocumentLineID
+	Quantity   domain.AtomicQuantity
+	Refund     domain.Option[domain.MinorAmount]
+}
+
+type PostedReturnDocument struct {
+	id              domain.StockDocumentID
+	idempotencyKey  domain.IdempotencyKey
+	postingSequence domain.PostingSequence
+	saleDocumentID  domain.StockDocumentID
+	counterpartyID  domain.Option[domain.CounterpartyID]
+	occurredOn      domain.BusinessDate
+	postedAt        domain.UTCInstant
+	currency        domain.Currency
+	notes           domain.Option[domain.NonEmptyText]
+	lines           []PostedReturnLine
+}
+
+func NewPostedReturnDocument(
+	id domain.StockDocumentID,
+	idempotencyKey domain.IdempotencyKey,
+	postingSequence domain.PostingSequence,
+	saleDocumentID domain.StockDocumentID,
+	counterpartyID domain.Option[domain.CounterpartyID],
+	occurredOn domain.BusinessDate,
+	postedAt domain.UTCInstant,
+	currency domain.Currency,
+	notes domain.Option[domain.NonEmptyText],
+	lines []PostedReturnLine,
+) PostedReturnDocument {
+	cloned := make([]PostedReturnLine, len(lines))
+	copy(cloned, lines)
+	return PostedReturnDocument{
+		id: id, idempotencyKey: idempotencyKey, postingSequence: postingSequence,
+		saleDocumentID: saleDocumentID, counterpartyID: counterpartyID, occurredOn: occurredOn,
+		postedAt: postedAt, currency: currency, notes: notes, lines: cloned,
+	}
+}
+
+func (d PostedReturnDocument) ID() domain.StockDocumentID              { return d.id }
+func (d PostedReturnDocument) IdempotencyKey() domain.IdempotencyKey   { return d.idempotencyKey }
+func (d PostedReturnDocument) PostingSequence() domain.PostingSequence { return d.postingSequence }
+func (d PostedReturnDocument) SaleDocumentID() domain.StockDocumentID  { return d.saleDocumentID }
+func (d PostedReturnDocument) CounterpartyID() domain.Option[domain.CounterpartyID] {
+	return d.counterpartyID
+}
+func (d PostedReturnDocument) OccurredOn() domain.BusinessDate           { return d.occurredOn }
+func (d PostedReturnDocument) PostedAt() domain.UTCInstant               { return d.postedAt }
+func (d PostedReturnDocument) Currency() domain.Currency                 { return d.currency }
+func (d PostedReturnDocument) Notes() domain.Option[domain.NonEmptyText] { return d.notes }
+func (d PostedReturnDocument) Lines() []PostedReturnLine {
+	lines := make([]PostedReturnLine, len(d.lines))
+	copy(lines, d.lines)
+	return lines
+}
+
+type PostedReturnLine struct {
+	id                   domain.StockDocumentLineID
+	lineOrder            domain.LineOrder
+	itemID               domain.ItemID
+	quantity             domain.AtomicQuantity
+	enteredUnit          domain.UnitCode
+	enteredPackagingName domain.Option[domain.NonEmptyText]
+	conversion           domain.UnitConversion
+	inventoryValue       domain.InventoryValue
+	refund               domain.MinorAmount
+	returnsLineID        domain.StockDocumentLineID
+	allocations          []ReturnAllocation
+}
+
+func NewPostedReturnLine(
+	id domain.StockDocumentLineID,
+	lineOrder domain.LineOrder,
+	itemID domain.ItemID,
+	quantity domain.AtomicQuantity,
+	enteredUnit domain.UnitCode,
+	enteredPackagingName domain.Option[domain.NonEmptyText],
+	conversion domain.UnitConversion,
+	inventoryValue domain.InventoryValue,
+	refund domain.MinorAmount,
+	returnsLineID domain.StockDocumentLineID,
+	allocations []ReturnAllocation,
+) PostedReturnLine {
+	cloned := make([]ReturnAllocation, len(allocations))
+	copy(cloned, allocations)
+	return PostedReturnLine{
+		id: id, lineOrder: lineOrder, itemID: itemID, quantity: quantity,
+		enteredUnit: enteredUnit, enteredPackagingName: enteredPackagingName,
+		conversion: conversion, inventoryValue: inventoryValue, refund: refund,
+		returnsLineID: returnsLineID, allocations: cloned,
+	}
+}
+
+func (l PostedReturnLine) ID() domain.StockDocumentLineID  { return l.id }
+func (l PostedReturnLine) LineOrder() domain.LineOrder     { return l.lineOrder }
+func (l PostedReturnLine) ItemID() domain.ItemID           { return l.itemID }
+func (l PostedReturnLine) Quantity() domain.AtomicQuantity { return l.quantity }
+func (l PostedReturnLine) EnteredUnit() domain.UnitCode    { return l.enteredUnit }
+func (l PostedReturnLine) EnteredPackagingName() domain.Option[domain.NonEmptyText] {
+	return l.enteredPackagingName
+}
+func (l PostedReturnLine) Conversion() domain.UnitConversion         { return l.conversion }
+func (l PostedReturnLine) InventoryValue() domain.InventoryValue     { return l.inventoryValue }
+func (l PostedReturnLine) Refund() domain.MinorAmount                { return l.refund }
+func (l PostedReturnLine) ReturnsLineID() domain.StockDocumentLineID { return l.returnsLineID }
+func (l PostedReturnLine) Allocations() []ReturnAllocation {
+	allocations := make([]ReturnAllocation, len(l.allocations))
+	copy(allocations, l.allocations)
+	return allocations
+}
+
+type ReturnAllocation struct {
+	id                   domain.LotAllocationID
+	lotID                domain.InventoryLotID
+	quantity             domain.AtomicQuantity
+	restoresAllocationID domain.LotAllocationID
+}
+
+func NewReturnAllocation(
+	id domain.LotAllocationID,
+	lotID domain.InventoryLotID,
+	quantity domain.AtomicQuantity,
+	restoresAllocationID domain.LotAllocationID,
+) ReturnAllocation {
+	return ReturnAllocation{
+		id: id, lotID: lotID, quantity: quantity, restoresAllocationID: restoresAllocationID,
+	}
+}
+
+func (a ReturnAllocation) ID() domain.LotAllocationID      { return a.id }
+func (a ReturnAllocation) LotID() domain.InventoryLotID    { return a.lotID }
+func (a ReturnAllocation) Quantity() domain.AtomicQuantity { return a.quantity }
+func (a ReturnAllocation) RestoresAllocationID() domain.LotAllocationID {
+	return a.restoresAllocationID
+}
+
+func (s *Store) PostReturn(ctx context.Context, input PostReturnInput) (PostedReturnDocument, error) {
+	var posted PostedReturnDocument
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		value, err := postReturnTx(ctx, tx, input)
+		if err != nil {
+			return err
+		}
+		posted = value
+		return nil
+	})
+	if err != nil {
+		return PostedReturnDocument{}, classifyError("post return", err)
+	}
+	return posted, nil
+}
+
+func (s *Store) GetPostedReturn(ctx context.Context, id domain.StockDocumentID) (PostedReturnDocument, error) {
+	if id.IsZero() {
+		return PostedReturnDocument{}, domain.Invalid("document_id", domain.ViolationRequired, "DOC-001")
+	}
+	var document PostedReturnDocument
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadPostedReturnDocument(ctx, tx, id.Int64())
+		if err != nil {
+			return err
+		}
+		document = value
+		return nil
+	})
+	if err != nil {
+		return PostedReturnDocument{}, classifyError("get posted return", err)
+	}
+	return document, nil
+}
+
+// ListPostedReturnsForSale returns every return of one sale in posting order.
+func (s *Store) ListPostedReturnsForSale(ctx context.Context, saleID domain.StockDocumentID) ([]PostedReturnDocument, error) {
+	if saleID.IsZero() {
+		return nil, domain.Invalid("sale_document_id", domain.ViolationRequired, "RET-001")
+	}
+	documents := []PostedReturnDocument{}
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		var kind string
+		if err := tx.QueryRowContext(ctx, `
+			SELECT kind FROM stock_documents WHERE id = ?
+		`, saleID.Int64()).Scan(&kind); err != nil {
+			return err
+		}
+		if kind != domain.DocumentSale.String() {
+			return domain.Invalid("sale_document_id", domain.ViolationInvalidEnum, "RET-001")
+		}
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id
+			FROM stock_documents
+			WHERE kind = 'RETURN' AND returns_document_id = ?
+			ORDER BY posting_sequence, id
+		`, saleID.Int64())
+		if err != nil {
+			return err
+		}
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			document, err := loadPostedReturnDocument(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			documents = append(documents, document)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list posted returns", err)
+	}
+	return documents, nil
+}
+
+func postReturnTx(ctx context.Context, tx databaseWriteTx, input PostReturnInput) (PostedReturnDocument, error) {
+	if err := validateReturnInput(input); err != nil {
+		return PostedReturnDocument{}, err
+	}
+
+	var existingID int64
+	var existingKind string
+	var existingReason sql.NullString
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, kind, reason_code FROM stock_documents WHERE idempotency_key = ?
+	`, input.IdempotencyKey.String()).Scan(&existingID, &existingKind, &existingReason)
+	if err == nil {
+		if existingKind != domain.DocumentReturn.String() || existingReason.String != domain.ReasonCustomerReturn.String() {
+			return PostedReturnDocument{}, fmt.Errorf("%w: idempotency key belongs to %s", domain.ErrConflict, existingKind)
+		}
+		return loadPostedReturnDocument(ctx, tx, existingID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return PostedReturnDocument{}, err
+	}
+
+	sale, err := loadReturnableSale(ctx, tx, input.SaleDocumentID.Int64())
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	if input.OccurredOn.Before(sale.occurredOn) {
+		return PostedReturnDocument{}, domain.Invalid("occurred_on", domain.ViolationOutOfRange, "RET-001")
+	}
+	lines := make([]returnableSaleLine, 0, len(input.Lines))
+	for index, requested := range input.Lines {
+		line, err := loadReturnableSaleLine(ctx, tx, sale.id, requested.SaleLineID.Int64())
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostedReturnDocument{}, domain.Invalid(fmt.Sprintf("lines[%d].sale_line_id", index), domain.ViolationInvariant, "RET-002")
+		}
+		if err != nil {
+			return PostedReturnDocument{}, err
+		}
+		lines = append(lines, line)
+	}
+
+	currency, err := loadDocumentCurrency(ctx, tx)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+
+	var postingSequence int64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(posting_sequence), 0) + 1 FROM stock_documents
+	`).Scan(&postingSequence); err != nil {
+		return PostedReturnDocument{}, err
+	}
+
+	var documentID int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO stock_documents (
+			kind, idempotency_key, posting_sequence, counterparty_id, occurred_on,
+			posted_at_ms, currency_code, currency_minor_digits, reason_code, notes,
+			returns_document_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`,
+		domain.DocumentReturn.String(),
+		input.IdempotencyKey.String(),
+		postingSequence,
+		nullableSQLInt64(sale.counterpartyID),
+		input.OccurredOn.String(),
+		input.PostedAt.UnixMilli(),
+		currency.Code().String(),
+		int64(currency.MinorDigits().Int()),
+		domain.ReasonCustomerReturn.String(),
+		nullableText(input.Notes),
+		sale.id,
+	).Scan(&documentID); err != nil {
+		return PostedReturnDocument{}, err
+	}
+
+	for index, requested := range input.Lines {
+		if err := insertReturnLine(ctx, tx, documentID, int64(index+1), input.PostedAt, lines[index], requested); err != nil {
+			return PostedReturnDocument{}, fmt.Errorf("line %d: %w", index+1, err)
+		}
+	}
+
+	return loadPostedReturnDocument(ctx, tx, documentID)
+}
+
+func validateReturnInput(input PostReturnInput) error {
+	if input.IdempotencyKey.String() == "" {
+		return domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.SaleDocumentID.IsZero() {
+		return domain.Invalid("sale_document_id", domain.ViolationRequired, "RET-001")
+	}
+	if input.OccurredOn.IsZero() {
+		return domain.Invalid("occurred_on", domain.ViolationRequired, "DOC-004")
+	}
+	if input.PostedAt.IsZero() {
+		return domain.Invalid("posted_at", domain.ViolationRequired, "DOC-004")
+	}
+	if len(input.Lines) == 0 {
+		return domain.Invalid("lines", domain.ViolationRequired, "DOC-002")
+	}
+	seen := make(map[domain.StockDocumentLineID]struct{}, len(input.Lines))
+	for index, line := range input.Lines {
+		if line.SaleLineID.IsZero() {
+			return domain.Invalid(fmt.Sprintf("lines[%d].sale_line_id", index), domain.ViolationRequired, "RET-002")
+		}
+		if _, ok := seen[line.SaleLineID]; ok {
+			return domain.Invalid(fmt.Sprintf("lines[%d].sale_line_id", index), domain.ViolationDuplicate, "RET-002")
+		}
+		seen[line.SaleLineID] = struct{}{}
+		if line.Quantity.Int64() <= 0 {
+			return domain.Invalid(fmt.Sprintf("lines[%d].quantity_atomic", index), domain.ViolationNotPositive, "DOC-008")
+		}
+	}
+	return nil
+}
+
+type returnableSale struct {
+	id             int64
+	occurredOn     domain.BusinessDate
+	counterpartyID sql.NullInt64
+}
+
+// loadReturnableSale accepts only a sale that has not been exactly reversed.
+func loadReturnableSale(ctx context.Context, tx databaseWriteTx, saleID int64) (returnableSale, error) {
+	var sale returnableSale
+	var kind, occurredOn string
+	var reversed int
+	err := tx.QueryRowContext(ctx, `
+		SELECT document.id, document.kind, document.occurred_on, document.counterparty_id,
+		       EXISTS (
+		           SELECT 1 FROM stock_documents reversal
+		           WHERE reversal.reverses_document_id = document.id
+		       )
+		FROM stock_documents document
+		WHERE document.id = ?
+	`, saleID).Scan(&sale.id, &kind, &occurredOn, &sale.counterpartyID, &reversed)
+	if err != nil {
+		return returnableSale{}, err
+	}
+	if kind != domain.DocumentSale.String() {
+		return returnableSale{}, domain.Invalid("sale_document_id", domain.ViolationInvalidEnum, "RET-001")
+	}
+	if reversed != 0 {
+		return returnableSale{}, domain.Invalid("sale_document_id", domain.ViolationInvariant, "RET-001")
+	}
+	sale.occurredOn, err = domain.ParseBusinessDate(occurredOn)
+	if err != nil {
+		return returnableSale{}, corruptDataError("", err)
+	}
+	return sale, nil
+}
+
+type returnableSaleLine struct {
+	id, itemID, quantityAtomic                       int64
+	conversionNumeratorAtomic, conversionDenominator int64
+	inventoryValueMicro, commercialTotalMinor        int64
+	returnedQuantityAtomic, returnedValueMicro       int64
+	refundedMinor                                    int64
+	enteredUnitCode                                  string
+	enteredPackagingName                             sql.NullString
+}
+
+func loadReturnableSaleLine(ctx context.Context, tx databaseWriteTx, saleID, lineID int64) (returnableSaleLine, error) {
+	var line returnableSaleLine
+	err := tx.QueryRowContext(ctx, `
+		SELECT line.id, line.item_id, line.quantity_atomic,
+		       line.entered_unit_code, line.entered_packaging_name,
+		       line.conversion_numerator_atomic, line.conversion_denominator,
+		       line.inventory_value_micro, line.commercial_total_minor,
+		       COALESCE(SUM(returned.quantity_atomic), 0),
+		       COALESCE(SUM(returned.inventory_value_micro), 0),
+		       COALESCE(SUM(returned.commercial_total_minor), 0)
+		FROM stock_document_lines line
+		LEFT JOIN stock_document_lines returned ON returned.returns_line_id = line.id
+		WHERE line.id = ? AND line.document_id = ?
+		GROUP BY line.id
+	`, lineID, saleID).Scan(
+		&line.id,
+		&line.itemID,
+		&line.quantityAtomic,
+		&line.enteredUnitCode,
+		&line.enteredPackagingName,
+		&line.conversionNumeratorAtomic,
+		&line.conversionDenominator,
+		&line.inventoryValueMicro,
+		&line.commercialTotalMinor,
+		&line.returnedQuantityAtomic,
+		&line.returnedValueMicro,
+		&line.refundedMinor,
+	)
+	if err != nil {
+		return returnableSaleLine{}, err
+	}
+	return line, nil
+}
+
+// returnLineAmounts values a partial return as the cumulative share of the
+// sale line, so the line's last return restores its remaining value and
+// refund exactly. An explicit refund may differ from the share but never
+// exceeds what the sale line still has to refund.
+func returnLineAmounts(line returnableSaleLine, requested PostReturnLineInput) (int64, int64, error) {
+	returnedThrough := line.returnedQuantityAtomic + requested.Quantity.Int64()
+	if returnedThrough > line.quantityAtomic {
+		return 0, 0, domain.Invalid("quantity_atomic", domain.ViolationOutOfRange, "RET-002")
+	}
+	valueThrough, err := weightedAverageValue(line.inventoryValueMicro, line.quantityAtomic, returnedThrough)
+	if err != nil {
+		return 0, 0, err
+	}
+	value := max(valueThrough.Int64()-line.returnedValueMicro, 0)
+	remainingRefund := line.commercialTotalMinor - line.refundedMinor
+	if refund, ok := requested.Refund.Get(); ok {
+		if refund.Int64() > remainingRefund {
+			return 0, 0, domain.Invalid("refund_minor", domain.ViolationOutOfRange, "RET-002")
+		}
+		return value, refund.Int64(), nil
+	}
+	refundThrough, err := weightedAverageValue(line.commercialTotalMinor, line.quantityAtomic, returnedThrough)
+	if err != nil {
+		return 0, 0, err
+	}
+	refund := min(max(refundThrough.Int64()-line.refundedMinor, 0), remainingRefund)
+	return value, refund, nil
+}
+
+func insertReturnLine(
+	ctx context.Context,
+	tx databaseWriteTx,
+	documentID int64,
+	lineOrder int64,
+	postedAt domain.UTCInstant,
+	sale returnableSaleLine,
+	requested PostReturnLineInput,
+) error {
+	value, refund, err := returnLineAmounts(sale, requested)
+	if err != nil {
+		return err
+	}
+
+	var lineID int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO stock_document_lines (
+			document_id, line_order, item_id, direction, quantity_atomic,
+			entered_unit_code, entered_packaging_name, conversion_numerator_atomic,
+			conversion_denominator, inventory_value_micro, commercial_total_minor,
+			returns_line_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`,
+		documentID,
+		lineOrder,
+		sale.itemID,
+		domain.DirectionIn.String(),
+		requested.Quantity.Int64(),
+		sale.enteredUnitCode,
+		nullableSQLString(sale.enteredPackagingName),
+		sale.conversionNumeratorAtomic,
+		sale.conversionDenominator,
+		value,
+		refund,
+		sale.id,
+	).Scan(&lineID); err != nil {
+		return err
+	}
+
+	if err := restoreReturnedAllocations(ctx, tx, lineID, sale.id, requested.Quantity.Int64(), postedAt); err != nil {
+		return err
+	}
+	itemID, err := domain.NewItemID(sale.itemID)
+	if err != nil {
+		return corruptDataError("", err)
+	}
+	return updateAdjustmentBalance(ctx, tx, documentID, postedAt, itemID, requested.Quantity.Int64(), value)
+}
+
+// restoreReturnedAllocations puts the returned quantity back into the lots
+// the sale line consumed, filling its allocations in allocation order and
+// never restoring more than an allocation consumed.
+func restoreReturnedAllocations(
+	ctx context.Context,
+	tx databaseWriteTx,
+	lineID int64,
+	saleLineID int64,
+	quantityAtomic int64,
+	postedAt domain.UTCInstant,
+) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT original.id, original.lot_id,
+		       original.quantity_atomic - COALESCE((
+		           SELECT SUM(restoration.quantity_atomic)
+		           FROM lot_allocations restoration
+		           WHERE restoration.restores_allocation_id = original.id
+		       ), 0)
+		FROM lot_allocations original
+		WHERE original.line_id = ? AND original.restores_allocation_id IS NULL
+		ORDER BY original.id
+	`, saleLineID)
+	if err != nil {
+		return err
+	}
+	type restorable struct{ id, lotID, quantityAtomic int64 }
+	var allocations []restorable
+	for rows.Next() {
+		var allocation restorable
+		if err := rows.Scan(&allocation.id, &allocation.lotID, &allocation.quantityAtomic); err != nil {
+			rows.Close()
+			return err
+		}
+		allocations = append(allocations, allocation)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	remaining := quantityAtomic
+	for _, allocation := range allocations {
+		if remaining == 0 {
+			break
+		}
+		restored := min(remaining, allocation.quantityAtomic)
+		if restored <= 0 {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO lot_allocations (
+				line_id, lot_id, quantity_atomic, restores_allocation_id, created_at_ms
+			) VALUES (?, ?, ?, ?, ?)
+		`, lineID, allocation.lotID, restored, allocation.id, postedAt.UnixMilli()); err != nil {
+			return err
+		}
+		remaining -= restored
+	}
+	if remaining != 0 {
+		return domain.Invalid("quantity_atomic", domain.ViolationInvariant, "RET-003")
+	}
+	return nil
+}
+
+func loadPostedReturnDocument(ctx context.Context, tx databaseWriteTx, id int64) (PostedReturnDocument, error) {
+	var row postedReturnDocumentRow
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, idempotency_key, posting_sequence, returns_document_id, counterparty_id,
+		       occurred_on, posted_at_ms, currency_code, currency_minor_digits, notes
+		FROM stock_documents
+		WHERE id = ? AND kind = 'RETURN' AND reason_code = 'CUSTOMER_RETURN'
+	`, id).Scan(
+		&row.id,
+		&row.idempotencyKey,
+		&row.postingSequence,
+		&row.returnsDocumentID,
+		&row.counterpartyID,
+		&row.occurredOn,
+		&row.postedAtMS,
+		&row.currencyCode,
+		&row.currencyMinorDigits,
+		&row.notes,
+	)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	lines, err := loadPostedReturnLines(ctx, tx, id)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	return mapPostedReturnDocument(row, lines)
+}
+
+type postedReturnDocumentRow struct {
+	id, postingSequence, returnsDocumentID, postedAtMS, currencyMinorDigits int64
+	idempotencyKey, occurredOn, currencyCode                                string
+	counterpartyID                                                          sql.NullInt64
+	notes                                                                   sql.NullString
+}
+
+func loadPostedReturnLines(ctx context.Context, tx databaseWriteTx, documentID int64) ([]PostedReturnLine, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, line_order, item_id, quantity_atomic,
+		       entered_unit_code, entered_packaging_name,
+		       conversion_numerator_atomic, conversion_denominator,
+		       inventory_value_micro, commercial_total_minor, returns_line_id
+		FROM stock_document_lines
+		WHERE document_id = ?
+		ORDER BY line_order, id
+	`, documentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []PostedReturnLine
+	for rows.Next() {
+		var row postedReturnLineRow
+		if err := rows.Scan(
+			&row.id,
+			&row.lineOrder,
+			&row.itemID,
+			&row.quantityAtomic,
+			&row.enteredUnitCode,
+			&row.enteredPackagingName,
+			&row.conversionNumeratorAtomic,
+			&row.conversionDenominator,
+			&row.inventoryValueMicro,
+			&row.commercialTotalMinor,
+			&row.returnsLineID,
+		); err != nil {
+			return nil, err
+		}
+		allocations, err := loadReturnAllocations(ctx, tx, row.id)
+		if err != nil {
+			return nil, err
+		}
+		line, err := mapPostedReturnLine(row, allocations)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+type postedReturnLineRow struct {
+	id, lineOrder, itemID, quantityAtomic            int64
+	conversionNumeratorAtomic, conversionDenominator int64
+	inventoryValueMicro, commercialTotalMinor        int64
+	returnsLineID                                    int64
+	enteredUnitCode                                  string
+	enteredPackagingName                             sql.NullString
+}
+
+func loadReturnAllocations(ctx context.Context, tx databaseWriteTx, lineID int64) ([]ReturnAllocation, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, lot_id, quantity_atomic, restores_allocation_id
+		FROM lot_allocations
+		WHERE line_id = ?
+		ORDER BY id
+	`, lineID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var allocations []ReturnAllocation
+	for rows.Next() {
+		var rawID, rawLotID, rawQuantity, rawRestores int64
+		if err := rows.Scan(&rawID, &rawLotID, &rawQuantity, &rawRestores); err != nil {
+			return nil, err
+		}
+		id, err := domain.NewLotAllocationID(rawID)
+		if err != nil {
+			return nil, err
+		}
+		lotID, err := domain.NewInventoryLotID(rawLotID)
+		if err != nil {
+			return nil, err
+		}
+		quantity, err := domain.NewPositiveAtomicQuantity(rawQuantity)
+		if err != nil {
+			return nil, err
+		}
+		restores, err := domain.NewLotAllocationID(rawRestores)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, NewReturnAllocation(id, lotID, quantity, restores))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return allocations, nil
+}
+
+func mapPostedReturnDocument(row postedReturnDocumentRow, lines []PostedReturnLine) (PostedReturnDocument, error) {
+	id, err := domain.NewStockDocumentID(row.id)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	idempotencyKey, err := domain.NewIdempotencyKey(row.idempotencyKey)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	postingSequence, err := domain.NewPostingSequence(row.postingSequence)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	saleDocumentID, err := domain.NewStockDocumentID(row.returnsDocumentID)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	counterpartyID, err := optionalCounterpartyID(row.counterpartyID)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	occurredOn, err := domain.ParseBusinessDate(row.occurredOn)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	postedAt, err := domain.UTCInstantFromUnixMilli(row.postedAtMS)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	currency, err := domain.RestoreCurrency(row.currencyCode, int(row.currencyMinorDigits))
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	notes, err := optionalNonEmptyText(row.notes)
+	if err != nil {
+		return PostedReturnDocument{}, err
+	}
+	return NewPostedReturnDocument(
+		id, idempotencyKey, postingSequence, saleDocumentID, counterpartyID, occurredOn,
+		postedAt, currency, notes, lines,
+	), nil
+}
+
+func mapPostedReturnLine(row postedReturnLineRow, allocations []ReturnAllocation) (PostedReturnLine, error) {
+	id, err := domain.NewStockDocumentLineID(row.id)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	lineOrder, err := domain.NewLineOrder(row.lineOrder)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	itemID, err := domain.NewItemID(row.itemID)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	quantity, err := domain.NewPositiveAtomicQuantity(row.quantityAtomic)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	enteredUnit, err := domain.NewUnitCode(row.enteredUnitCode)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	enteredPackagingName, err := optionalNonEmptyText(row.enteredPackagingName)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	conversion, err := domain.NewUnitConversion(row.conversionNumeratorAtomic, row.conversionDenominator)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	inventoryValue, err := domain.NewInventoryValue(row.inventoryValueMicro)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	refund, err := domain.NewMinorAmount(row.commercialTotalMinor)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	returnsLineID, err := domain.NewStockDocumentLineID(row.returnsLineID)
+	if err != nil {
+		return PostedReturnLine{}, err
+	}
+	return NewPostedReturnLine(
+		id, lineOrder, itemID, quantity, enteredUnit, enteredPackagingName,
+		conversion, inventoryValue, refund, returnsLineID, allocations,
+	), nil
+}
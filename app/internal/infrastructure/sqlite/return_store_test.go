@@ -0,0 +1,161 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestReturnStoreRestoresPartialReturnsIntoSaleLots(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "return.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	itemID := createSaleTestItem(t, store, "Returned cake", true)
+	late := postAdjustmentTestPurchase(t, store, itemID, "return-late", "RET-LATE", "2026-12-31", 100, 1_000)
+	early := postAdjustmentTestPurchase(t, store, itemID, "return-early", "RET-EARLY", "2026-08-01", 100, 1_000)
+	sale, err := store.PostSale(ctx, saleInputFixture(t, itemID, "return-sale", 150, 4_500))
+	if err != nil {
+		t.Fatalf("post sale: %v", err)
+	}
+	saleLine := sale.Lines()[0]
+	saleAllocations := saleLine.Allocations()
+
+	first, err := store.PostReturn(ctx, returnInputFixture(t, sale.ID(), saleLine.ID(), "return-1", 120, domain.None[domain.MinorAmount]()))
+	if err != nil {
+		t.Fatalf("post first return: %v", err)
+	}
+	firstLine := first.Lines()[0]
+	if first.SaleDocumentID() != sale.ID() || firstLine.ReturnsLineID() != saleLine.ID() ||
+		firstLine.Quantity().Int64() != 120 || firstLine.InventoryValue().Int64() != 12_000_000 ||
+		firstLine.Refund().Int64() != 3_600 {
+		t.Fatalf("first return line = %#v", firstLine)
+	}
+	restored := firstLine.Allocations()
+	if len(restored) != 2 ||
+		restored[0].LotID() != early.Lines()[0].LotID() || restored[0].Quantity().Int64() != 100 ||
+		restored[0].RestoresAllocationID() != saleAllocations[0].ID() ||
+		restored[1].LotID() != late.Lines()[0].LotID() || restored[1].Quantity().Int64() != 20 ||
+		restored[1].RestoresAllocationID() != saleAllocations[1].ID() {
+		t.Fatalf("first return allocations = %#v", restored)
+	}
+
+	overRefund := returnInputFixture(t, sale.ID(), saleLine.ID(), "return-over-refund", 30, domain.Some(mustPurchaseMinorAmount(t, 901)))
+	if _, err := store.PostReturn(ctx, overRefund); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("over refund error = %v, want ErrValidation", err)
+	}
+
+	second, err := store.PostReturn(ctx, returnInputFixture(t, sale.ID(), saleLine.ID(), "return-2", 30, domain.Some(mustPurchaseMinorAmount(t, 800))))
+	if err != nil {
+		t.Fatalf("post second return: %v", err)
+	}
+	secondLine := second.Lines()[0]
+	if secondLine.InventoryValue().Int64() != 3_000_000 || secondLine.Refund().Int64() != 800 ||
+		len(secondLine.Allocations()) != 1 || secondLine.Allocations()[0].Quantity().Int64() != 30 {
+		t.Fatalf("second return line = %#v", secondLine)
+	}
+	assertInventoryBalance(t, store, itemID, 200, 20_000_000, second.ID().Int64())
+
+	exhausted := returnInputFixture(t, sale.ID(), saleLine.ID(), "return-3", 1, domain.None[domain.MinorAmount]())
+	if _, err := store.PostReturn(ctx, exhausted); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("exhausted return error = %v, want ErrValidation", err)
+	}
+
+	replayed, err := store.PostReturn(ctx, returnInputFixture(t, sale.ID(), saleLine.ID(), "return-1", 120, domain.None[domain.MinorAmount]()))
+	if err != nil || replayed.ID() != first.ID() {
+		t.Fatalf("replayed return = %#v, %v", replayed, err)
+	}
+	listed, err := store.ListPostedReturnsForSale(ctx, sale.ID())
+	if err != nil || len(listed) != 2 || listed[0].ID() != first.ID() || listed[1].ID() != second.ID() {
+		t.Fatalf("listed returns = %#v, %v", listed, err)
+	}
+
+	for name, target := range map[string]domain.StockDocumentID{"sale": sale.ID(), "return": second.ID()} {
+		_, err := store.PostReversal(ctx, PostReversalInput{
+			IdempotencyKey:   mustPurchaseIdempotencyKey(t, "reverse-"+name),
+			TargetDocumentID: target,
+			OccurredOn:       mustPurchaseDate(t, "2026-07-20"),
+			PostedAt:         mustCatalogInstant(t, 9_000),
+		})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("reverse %s error = %v, want ErrValidation", name, err)
+		}
+	}
+
+	reconciliation, err := store.ReconcileInventory(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if !reconciliation.Consistent() {
+		t.Fatalf("reconciliation discrepancies = %#v", reconciliation.Discrepancies())
+	}
+
+	report, err := store.GetSalesReportData(ctx, ReportingPeriodFilter{
+		FromOccurredOn: "2026-07-01",
+		ToOccurredOn:   "2026-07-31",
+		Granularity:    "MONTH",
+	}, ReportingPeriodFilter{
+		FromOccurredOn: "2026-06-01",
+		ToOccurredOn:   "2026-06-30",
+		Granularity:    "MONTH",
+	}, 5)
+	if err != nil {
+		t.Fatalf("get sales report data: %v", err)
+	}
+	totals := report.CurrentTotals
+	if totals.SalesCount != 1 || totals.ReturnCount != 2 || totals.QuantityAtomic != 0 ||
+		totals.RevenueMinor != 100 || totals.RefundMinor != 4_400 || totals.COGSMicro != 0 {
+		t.Fatalf("netted totals = %#v", totals)
+	}
+}
+
+func TestReturnStoreRejectsNonSaleAndForeignLines(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "return-reject.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	itemID := createSaleTestItem(t, store, "Rejected return", true)
+	purchase := postAdjustmentTestPurchase(t, store, itemID, "return-reject-stock", "REJ", "2026-12-31", 100, 1_000)
+	first, err := store.PostSale(ctx, saleInputFixture(t, itemID, "return-reject-sale-1", 10, 300))
+	if err != nil {
+		t.Fatalf("post first sale: %v", err)
+	}
+	second, err := store.PostSale(ctx, saleInputFixture(t, itemID, "return-reject-sale-2", 10, 300))
+	if err != nil {
+		t.Fatalf("post second sale: %v", err)
+	}
+
+	tooEarly := returnInputFixture(t, first.ID(), first.Lines()[0].ID(), "return-early", 1, domain.None[domain.MinorAmount]())
+	tooEarly.OccurredOn = mustPurchaseDate(t, "2026-07-14")
+	cases := map[string]PostReturnInput{
+		"purchase":     returnInputFixture(t, purchase.ID(), purchase.Lines()[0].ID(), "return-purchase", 1, domain.None[domain.MinorAmount]()),
+		"foreign line": returnInputFixture(t, first.ID(), second.Lines()[0].ID(), "return-foreign", 1, domain.None[domain.MinorAmount]()),
+		"too early":    tooEarly,
+	}
+	for name, input := range cases {
+		if _, err := store.PostReturn(ctx, input); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("%s return error = %v, want ErrValidation", name, err)
+		}
+	}
+	assertInventoryBalance(t, store, itemID, 80, 8_000_000, second.ID().Int64())
+}
+
+func returnInputFixture(
+	t *testing.T,
+	saleID domain.StockDocumentID,
+	saleLineID domain.StockDocumentLineID,
+	idempotencyKey string,
+	quantityAtomic int64,
+	refund domain.Option[domain.MinorAmount],
+) PostReturnInput {
+	t.Helper()
+	return PostReturnInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, idempotencyKey),
+		SaleDocumentID: saleID,
+		OccurredOn:     mustPurchaseDate(t, "2026-07-18"),
+		PostedAt:       mustCatalogInstant(t, 7_000),
+		Lines: []PostReturnLineInput{
+			{SaleLineID: saleLineID, Quantity: mustPurchaseQuantity(t, quantityAtomic), Refund: refund},
+		},
+	}
+}
@@ -283,6 +283,12 @@ func loadReversalTarget(ctx context.Context, tx databaseWriteTx, targetDocumentI
 	if target.kind == domain.DocumentReversal.String() {
 		return reversalTarget{}, domain.Invalid("target_document_id", domain.ViolationInvalidEnum, "REV-006")
 	}
+	if target.kind == domain.DocumentReturn.String() {
+		return reversalTarget{}, domain.Invalid("target_document_id", domain.ViolationInvalidEnum, "RET-005")
+	}
+	if target.kind == domain.DocumentTransfer.String() {
+		return reversalTarget{}, domain.Invalid("target_document_id", domain.ViolationInvalidEnum, "LOC-005")
+	}
 
 	rows, err := tx.QueryContext(ctx, `
 		SELECT line.id, line.line_order, line.item_id, line.direction, line.quantity_atomic,
@@ -370,6 +376,15 @@ func validateReversalEligibility(ctx context.Context, tx databaseWriteTx, target
 	if alreadyReversed != 0 {
 		return domain.Invalid("target_document_id", domain.ViolationInvariant, "REV-006")
 	}
+	var returned int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM stock_documents WHERE returns_document_id = ?
+	`, target.id).Scan(&returned); err != nil {
+		return err
+	}
+	if returned != 0 {
+		return domain.Invalid("target_document_id", domain.ViolationInvariant, "RET-005")
+	}
 
 	for _, line := range target.lines {
 		var lastDocumentID sql.NullInt64
@@ -19,6 +19,7 @@ type UpdateSettingsInput struct {
 	Currency           domain.Currency
 	HourlyLaborCost    domain.Option[domain.MinorAmount]
 	DefaultGrossMargin domain.Option[domain.BasisPoints]
+	Backup             domainsettings.BackupPolicy
 	ExpectedUpdatedAt  domain.UTCInstant
 	UpdatedAt          domain.UTCInstant
 }
@@ -70,6 +71,7 @@ func (s *Store) UpdateSettings(ctx context.Context, input UpdateSettingsInput) (
 			Currency:           input.Currency,
 			HourlyLaborCost:    input.HourlyLaborCost,
 			DefaultGrossMargin: input.DefaultGrossMargin,
+			Backup:             input.Backup,
 			CreatedAt:          current.CreatedAt(),
 			UpdatedAt:          input.UpdatedAt,
 		})
@@ -85,6 +87,10 @@ func (s *Store) UpdateSettings(ctx context.Context, input UpdateSettingsInput) (
 			CurrencyMinorDigits:           int64(desired.Currency().MinorDigits().Int()),
 			HourlyLaborCostMinor:          nullableMinorAmount(desired.HourlyLaborCost()),
 			DefaultGrossMarginBasisPoints: nullableBasisPoints(desired.DefaultGrossMargin()),
+			BackupEnabled:                 boolInteger(desired.Backup().Enabled()),
+			BackupIntervalMinutes:         desired.Backup().IntervalMinutes(),
+			BackupKeepDaily:               desired.Backup().KeepDaily(),
+			BackupKeepWeekly:              desired.Backup().KeepWeekly(),
 			UpdatedAtMs:                   desired.UpdatedAt().UnixMilli(),
 			ExpectedUpdatedAtMs:           input.ExpectedUpdatedAt.UnixMilli(),
 		})
@@ -94,7 +100,7 @@ func (s *Store) UpdateSettings(ctx context.Context, input UpdateSettingsInput) (
 		if err != nil {
 			return err
 		}
-		updated, err = mapSettings(row)
+		updated, err = mapSettings(sqlcgen.GetAppSettingsRow(row))
 		if err != nil {
 			return corruptDataError("map updated settings", err)
 		}
@@ -134,7 +140,7 @@ func (s *Store) ListMeasurementUnits(ctx context.Context) ([]catalog.Measurement
 	return units, nil
 }
 
-func mapSettings(row sqlcgen.AppSetting) (domainsettings.Settings, error) {
+func mapSettings(row sqlcgen.GetAppSettingsRow) (domainsettings.Settings, error) {
 	businessName, err := domain.NewDisplayName(row.BusinessName)
 	if err != nil || businessName.String() != row.BusinessName {
 		if err == nil {
@@ -168,6 +174,19 @@ func mapSettings(row sqlcgen.AppSetting) (domainsettings.Settings, error) {
 	if err != nil {
 		return domainsettings.Settings{}, err
 	}
+	backupEnabled, err := restoreBoolean("backup_enabled", row.BackupEnabled)
+	if err != nil {
+		return domainsettings.Settings{}, err
+	}
+	backup, err := domainsettings.NewBackupPolicy(domainsettings.BackupPolicyParams{
+		Enabled:         backupEnabled,
+		IntervalMinutes: row.BackupIntervalMinutes,
+		KeepDaily:       row.BackupKeepDaily,
+		KeepWeekly:      row.BackupKeepWeekly,
+	})
+	if err != nil {
+		return domainsettings.Settings{}, err
+	}
 	createdAt, err := domain.UTCInstantFromUnixMilli(row.CreatedAtMs)
 	if err != nil {
 		return domainsettings.Settings{}, err
@@ -183,6 +202,7 @@ func mapSettings(row sqlcgen.AppSetting) (domainsettings.Settings, error) {
 		Currency:           currency,
 		HourlyLaborCost:    hourlyLaborCost,
 		DefaultGrossMargin: defaultMargin,
+		Backup:             backup,
 		CreatedAt:          createdAt,
 		UpdatedAt:          updatedAt,
 	})
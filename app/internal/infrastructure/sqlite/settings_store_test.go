@@ -8,6 +8,7 @@ import (
 
 	"github.com/jerobas/saas/database"
 	"github.com/jerobas/saas/internal/domain"
+	domainsettings "github.com/jerobas/saas/internal/domain/settings"
 )
 
 func TestSettingsStoreReadsBaselineAndSeededUnits(t *testing.T) {
@@ -33,6 +34,10 @@ func TestSettingsStoreReadsBaselineAndSeededUnits(t *testing.T) {
 	if settings.HourlyLaborCost().IsSome() || settings.DefaultGrossMargin().IsSome() {
 		t.Fatal("optional baseline settings must be absent")
 	}
+	if backup := settings.Backup(); !backup.Enabled() || backup.IntervalMinutes() != 1440 ||
+		backup.KeepDaily() != 7 || backup.KeepWeekly() != 4 {
+		t.Fatalf("baseline backup policy = %#v, want enabled daily 7/4", backup)
+	}
 	if settings.CreatedAt().UnixMilli() != 0 || settings.UpdatedAt().UnixMilli() != 0 {
 		t.Fatalf("settings timestamps = %d/%d, want 0/0", settings.CreatedAt().UnixMilli(), settings.UpdatedAt().UnixMilli())
 	}
@@ -82,6 +87,7 @@ func TestSettingsStoreUpdatesAtomicallyAndRejectsStaleOrNonAdvancingVersions(t *
 		Currency:           mustSettingsCurrency(t, "USD"),
 		HourlyLaborCost:    domain.Some(hourlyCost),
 		DefaultGrossMargin: domain.Some(margin),
+		Backup:             mustSettingsBackupPolicy(t, false, 60, 3, 2),
 		ExpectedUpdatedAt:  expected,
 		UpdatedAt:          updatedAt,
 	}
@@ -101,6 +107,10 @@ func TestSettingsStoreUpdatesAtomicallyAndRejectsStaleOrNonAdvancingVersions(t *
 	if value, ok := updated.DefaultGrossMargin().Get(); !ok || value.Int64() != 2_500 {
 		t.Fatalf("margin = %#v/%t, want 2500/present", value, ok)
 	}
+	if backup := updated.Backup(); backup.Enabled() || backup.IntervalMinutes() != 60 ||
+		backup.KeepDaily() != 3 || backup.KeepWeekly() != 2 {
+		t.Fatalf("backup policy = %#v, want disabled hourly 3/2", backup)
+	}
 	if !updated.UpdatedAt().Equal(updatedAt) || !updated.CreatedAt().Equal(expected) {
 		t.Fatalf("timestamps = %d/%d, want 0/10", updated.CreatedAt().UnixMilli(), updated.UpdatedAt().UnixMilli())
 	}
@@ -140,6 +150,7 @@ func TestSettingsStoreRejectsChangedCurrencyWithWrongRegistryScale(t *testing.T)
 		Locale:            mustSettingsLocale(t, "pt-BR"),
 		Timezone:          mustSettingsTimezone(t, "America/Sao_Paulo"),
 		Currency:          spoofedCurrency,
+		Backup:            mustSettingsBackupPolicy(t, true, 1440, 7, 4),
 		ExpectedUpdatedAt: mustSettingsInstant(t, 0),
 		UpdatedAt:         mustSettingsInstant(t, 10),
 	})
@@ -168,6 +179,28 @@ func TestSettingsStoreRejectsNoncanonicalPersistedText(t *testing.T) {
 	}
 }
 
+func TestSettingsStoreRejectsOutOfRangePersistedBackupPolicy(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "settings-backup-range.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	if _, err := store.database.ExecContext(ctx, "UPDATE app_settings SET backup_keep_daily = 0"); err == nil {
+		t.Fatal("SQLite accepted a backup policy that prunes every backup")
+	}
+	if _, err := store.database.ExecContext(ctx, "UPDATE app_settings SET backup_interval_minutes = 1"); err == nil {
+		t.Fatal("SQLite accepted a one-minute backup interval")
+	}
+}
+
+func mustSettingsBackupPolicy(t *testing.T, enabled bool, interval, daily, weekly int64) domainsettings.BackupPolicy {
+	t.Helper()
+	value, err := domainsettings.NewBackupPolicy(domainsettings.BackupPolicyParams{
+		Enabled: enabled, IntervalMinutes: interval, KeepDaily: daily, KeepWeekly: weekly,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return value
+}
+
 func mustSettingsDisplayName(t *testing.T, raw string) domain.DisplayName {
 	t.Helper()
 	value, err := domain.NewDisplayName(raw)
@@ -0,0 +1,185 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+const listShoppingItemSourcesOperation = "list shopping item sources"
+
+// ShoppingItemSource is what a shopping list needs to know about one item:
+// its base unit, reorder buffer, and the supplier and packaging of its most
+// recent purchase that was not reversed.
+type ShoppingItemSource struct {
+	ItemID          domain.ItemID
+	ItemName        domain.UniqueName
+	BaseUnit        domain.UnitCode
+	BaseConversion  domain.UnitConversion
+	Purchasable     bool
+	ReorderQuantity domain.Option[domain.AtomicQuantity]
+	LastSupplier    domain.Option[ShoppingSupplier]
+	Packaging       domain.Option[ShoppingPackaging]
+}
+
+type ShoppingSupplier struct {
+	ID   domain.CounterpartyID
+	Name domain.DisplayName
+}
+
+// ShoppingPackaging is the active item packaging named on the last purchase
+// line, with its current conversion.
+type ShoppingPackaging struct {
+	Name        domain.UniqueName
+	EnteredUnit domain.UnitCode
+	Conversion  domain.UnitConversion
+}
+
+// ListShoppingItemSources returns one source per requested item, in request
+// order.
+func (s *Store) ListShoppingItemSources(ctx context.Context, itemIDs []domain.ItemID) ([]ShoppingItemSource, error) {
+	for _, itemID := range itemIDs {
+		if itemID.IsZero() {
+			return nil, domain.Invalid("item_id", domain.ViolationRequired, "")
+		}
+	}
+	sources := make([]ShoppingItemSource, 0, len(itemIDs))
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		for _, itemID := range itemIDs {
+			source, err := loadShoppingItemSource(ctx, tx, itemID.Int64())
+			if err != nil {
+				return err
+			}
+			sources = append(sources, source)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError(listShoppingItemSourcesOperation, err)
+	}
+	return sources, nil
+}
+
+func loadShoppingItemSource(ctx context.Context, tx databaseWriteTx, itemID int64) (ShoppingItemSource, error) {
+	var fields shoppingItemSourceFields
+	err := tx.QueryRowContext(ctx, `
+		SELECT item.id, item.name, item.normalized_name, item.base_unit_code,
+		       unit.atomic_numerator, unit.atomic_denominator,
+		       item.is_purchasable, item.reorder_quantity_atomic
+		FROM items item
+		JOIN measurement_units unit ON unit.code = item.base_unit_code
+		WHERE item.id = ?
+	`, itemID).Scan(
+		&fields.itemID, &fields.itemName, &fields.itemKey, &fields.baseUnitCode,
+		&fields.atomicNumerator, &fields.atomicDenominator,
+		&fields.purchasable, &fields.reorderQuantity,
+	)
+	if err != nil {
+		return ShoppingItemSource{}, err
+	}
+	err = tx.QueryRowContext(ctx, `
+		SELECT counterparty.id, counterparty.name,
+		       packaging.name, packaging.normalized_name, packaging.entered_unit_code,
+		       packaging.conversion_numerator_atomic, packaging.conversion_denominator
+		FROM stock_document_lines line
+		JOIN stock_documents document ON document.id = line.document_id
+		JOIN counterparties counterparty ON counterparty.id = document.counterparty_id
+		LEFT JOIN item_packagings packaging
+		       ON packaging.item_id = line.item_id
+		      AND packaging.name = line.entered_packaging_name
+		      AND packaging.archived_at_ms IS NULL
+		WHERE line.item_id = ?
+		  AND line.direction = 'IN'
+		  AND document.kind = 'PURCHASE'
+		  AND NOT EXISTS (
+		      SELECT 1 FROM stock_documents reversal
+		      WHERE reversal.reverses_document_id = document.id
+		  )
+		ORDER BY document.posting_sequence DESC, line.line_order DESC
+		LIMIT 1
+	`, itemID).Scan(
+		&fields.supplierID, &fields.supplierName,
+		&fields.packagingName, &fields.packagingKey, &fields.packagingUnitCode,
+		&fields.packagingNumerator, &fields.packagingDenominator,
+	)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return ShoppingItemSource{}, err
+	}
+	source, err := mapShoppingItemSource(fields)
+	if err != nil {
+		return ShoppingItemSource{}, corruptDataError(listShoppingItemSourcesOperation, err)
+	}
+	return source, nil
+}
+
+type shoppingItemSourceFields struct {
+	itemID                                   int64
+	itemName, itemKey, baseUnitCode          string
+	atomicNumerator, atomicDenominator       int64
+	purchasable                              bool
+	reorderQuantity                          sql.NullInt64
+	supplierID                               sql.NullInt64
+	supplierName                             sql.NullString
+	packagingName, packagingKey              sql.NullString
+	packagingUnitCode                        sql.NullString
+	packagingNumerator, packagingDenominator sql.NullInt64
+}
+
+func mapShoppingItemSource(fields shoppingItemSourceFields) (ShoppingItemSource, error) {
+	itemID, err := domain.NewItemID(fields.itemID)
+	if err != nil {
+		return ShoppingItemSource{}, err
+	}
+	name, err := domain.RestoreUniqueName(fields.itemName, fields.itemKey)
+	if err != nil {
+		return ShoppingItemSource{}, err
+	}
+	baseUnit, err := domain.NewUnitCode(fields.baseUnitCode)
+	if err != nil {
+		return ShoppingItemSource{}, err
+	}
+	baseConversion, err := domain.NewUnitConversion(fields.atomicNumerator, fields.atomicDenominator)
+	if err != nil {
+		return ShoppingItemSource{}, err
+	}
+	reorder, err := optionalAtomicQuantity(fields.reorderQuantity)
+	if err != nil {
+		return ShoppingItemSource{}, err
+	}
+	source := ShoppingItemSource{
+		ItemID: itemID, ItemName: name, BaseUnit: baseUnit, BaseConversion: baseConversion,
+		Purchasable: fields.purchasable, ReorderQuantity: reorder,
+		LastSupplier: domain.None[ShoppingSupplier](),
+		Packaging:    domain.None[ShoppingPackaging](),
+	}
+	if fields.supplierID.Valid {
+		supplierID, err := domain.NewCounterpartyID(fields.supplierID.Int64)
+		if err != nil {
+			return ShoppingItemSource{}, err
+		}
+		supplierName, err := domain.NewDisplayName(fields.supplierName.String)
+		if err != nil {
+			return ShoppingItemSource{}, err
+		}
+		source.LastSupplier = domain.Some(ShoppingSupplier{ID: supplierID, Name: supplierName})
+	}
+	if fields.packagingName.Valid {
+		packagingName, err := domain.RestoreUniqueName(fields.packagingName.String, fields.packagingKey.String)
+		if err != nil {
+			return ShoppingItemSource{}, err
+		}
+		unit, err := domain.NewUnitCode(fields.packagingUnitCode.String)
+		if err != nil {
+			return ShoppingItemSource{}, err
+		}
+		conversion, err := domain.NewUnitConversion(fields.packagingNumerator.Int64, fields.packagingDenominator.Int64)
+		if err != nil {
+			return ShoppingItemSource{}, err
+		}
+		source.Packaging = domain.Some(ShoppingPackaging{Name: packagingName, EnteredUnit: unit, Conversion: conversion})
+	}
+	return source, nil
+}
@@ -0,0 +1,106 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/catalog"
+)
+
+func TestShoppingStoreReadsReorderBufferAndLastSupplierPackaging(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "shopping.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+
+	flour := createCatalogItem(t, store, CreateItemInput{
+		Name:            mustCatalogName(t, "Flour"),
+		BaseUnit:        mustCatalogUnitCode(t, "g"),
+		Capabilities:    catalog.NewCapabilities(true, false, false),
+		ReorderQuantity: domain.Some(mustPurchaseQuantity(t, 200_000)),
+		CreatedAt:       mustCatalogInstant(t, 1_000),
+		UpdatedAt:       mustCatalogInstant(t, 1_000),
+	}).Item().ID()
+	sugar := createCatalogItem(t, store, CreateItemInput{
+		Name:         mustCatalogName(t, "Sugar"),
+		BaseUnit:     mustCatalogUnitCode(t, "g"),
+		Capabilities: catalog.NewCapabilities(true, false, false),
+		CreatedAt:    mustCatalogInstant(t, 1_000),
+		UpdatedAt:    mustCatalogInstant(t, 1_000),
+	}).Item().ID()
+	if _, err := store.CreatePackaging(ctx, CreatePackagingInput{
+		ItemID:      flour,
+		Name:        mustCatalogName(t, "Kilogram bag"),
+		EnteredUnit: mustCatalogUnitCode(t, "kg"),
+		Conversion:  mustCatalogConversion(t, 1_000_000, 1),
+		CreatedAt:   mustCatalogInstant(t, 1_100),
+		UpdatedAt:   mustCatalogInstant(t, 1_100),
+	}); err != nil {
+		t.Fatalf("create packaging: %v", err)
+	}
+	supplier, err := store.CreateCounterparty(ctx, CreateCounterpartyInput{
+		Name:      counterpartyName(t, "Mill"),
+		Roles:     counterpartyRoles(t, domain.RoleSupplier),
+		CreatedAt: counterpartyInstant(t, 1_200),
+	})
+	if err != nil {
+		t.Fatalf("create supplier: %v", err)
+	}
+	if _, err := store.PostPurchase(ctx, PostPurchaseInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "shopping-purchase-1"),
+		CounterpartyID: domain.Some(supplier.ID()),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-15"),
+		PostedAt:       mustCatalogInstant(t, 2_000),
+		Lines: []PostPurchaseLineInput{{
+			ItemID:               flour,
+			Quantity:             mustPurchaseQuantity(t, 2_000_000),
+			EnteredUnit:          mustCatalogUnitCode(t, "kg"),
+			EnteredPackagingName: domain.Some(counterpartyText(t, "Kilogram bag")),
+			Conversion:           mustCatalogConversion(t, 1_000_000, 1),
+			CommercialTotal:      mustPurchaseMinorAmount(t, 1_000),
+		}},
+	}); err != nil {
+		t.Fatalf("post supplier purchase: %v", err)
+	}
+	if _, err := store.PostPurchase(ctx, PostPurchaseInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, "shopping-purchase-2"),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-16"),
+		PostedAt:       mustCatalogInstant(t, 3_000),
+		Lines: []PostPurchaseLineInput{{
+			ItemID:          flour,
+			Quantity:        mustPurchaseQuantity(t, 500_000),
+			EnteredUnit:     mustCatalogUnitCode(t, "g"),
+			Conversion:      mustCatalogConversion(t, 1_000, 1),
+			CommercialTotal: mustPurchaseMinorAmount(t, 250),
+		}},
+	}); err != nil {
+		t.Fatalf("post anonymous purchase: %v", err)
+	}
+
+	sources, err := store.ListShoppingItemSources(ctx, []domain.ItemID{sugar, flour})
+	if err != nil {
+		t.Fatalf("list shopping item sources: %v", err)
+	}
+	if len(sources) != 2 || sources[0].ItemID != sugar || sources[1].ItemID != flour {
+		t.Fatalf("sources = %#v", sources)
+	}
+	if !sources[0].Purchasable || sources[0].ReorderQuantity.IsSome() ||
+		sources[0].LastSupplier.IsSome() || sources[0].Packaging.IsSome() {
+		t.Fatalf("sugar source = %#v", sources[0])
+	}
+	flourSource := sources[1]
+	if flourSource.BaseUnit.String() != "g" || flourSource.BaseConversion.NumeratorAtomic() != 1_000 ||
+		flourSource.ReorderQuantity != domain.Some(mustPurchaseQuantity(t, 200_000)) {
+		t.Fatalf("flour source = %#v", flourSource)
+	}
+	lastSupplier, ok := flourSource.LastSupplier.Get()
+	if !ok || lastSupplier.ID != supplier.ID() || lastSupplier.Name.String() != "Mill" {
+		t.Fatalf("flour last supplier = %#v", flourSource.LastSupplier)
+	}
+	packaging, ok := flourSource.Packaging.Get()
+	if !ok || packaging.Name.Display() != "Kilogram bag" || packaging.EnteredUnit.String() != "kg" ||
+		packaging.Conversion.NumeratorAtomic() != 1_000_000 {
+		t.Fatalf("flour packaging = %#v", flourSource.Packaging)
+	}
+}
@@ -40,6 +40,36 @@ func (q *Queries) ArchiveItem(ctx context.Context, arg ArchiveItemParams) (int64
 	return result.RowsAffected()
 }
 
+const archiveItemCategory = `-- name: ArchiveItemCategory :execrows
+UPDATE item_categories
+SET
+    archived_at_ms = CAST(?1 AS INTEGER),
+    updated_at_ms = ?2
+WHERE id = ?3
+  AND archived_at_ms IS NULL
+  AND updated_at_ms = ?4
+`
+
+type ArchiveItemCategoryParams struct {
+	ArchivedAtMs        int64
+	UpdatedAtMs         int64
+	ID                  int64
+	ExpectedUpdatedAtMs int64
+}
+
+func (q *Queries) ArchiveItemCategory(ctx context.Context, arg ArchiveItemCategoryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, archiveItemCategory,
+		arg.ArchivedAtMs,
+		arg.UpdatedAtMs,
+		arg.ID,
+		arg.ExpectedUpdatedAtMs,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const archiveItemPackaging = `-- name: ArchiveItemPackaging :execrows
 UPDATE item_packagings
 SET
@@ -70,6 +100,20 @@ func (q *Queries) ArchiveItemPackaging(ctx context.Context, arg ArchiveItemPacka
 	return result.RowsAffected()
 }
 
+const countActiveItemsInCategory = `-- name: CountActiveItemsInCategory :one
+SELECT CAST(COUNT(*) AS INTEGER) AS item_count
+FROM items
+WHERE category_id = ?1
+  AND archived_at_ms IS NULL
+`
+
+func (q *Queries) CountActiveItemsInCategory(ctx context.Context, categoryID sql.NullInt64) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countActiveItemsInCategory, categoryID)
+	var item_count int64
+	err := row.Scan(&item_count)
+	return item_count, err
+}
+
 const getItem = `-- name: GetItem :one
 SELECT
     id,
@@ -86,7 +130,8 @@ SELECT
     reorder_quantity_atomic,
     created_at_ms,
     updated_at_ms,
-    archived_at_ms
+    archived_at_ms,
+    category_id
 FROM items
 WHERE id = ?1
 `
@@ -110,6 +155,33 @@ func (q *Queries) GetItem(ctx context.Context, id int64) (Item, error) {
 		&i.CreatedAtMs,
 		&i.UpdatedAtMs,
 		&i.ArchivedAtMs,
+		&i.CategoryID,
+	)
+	return i, err
+}
+
+const getItemCategory = `-- name: GetItemCategory :one
+SELECT
+    id,
+    name,
+    normalized_name,
+    created_at_ms,
+    updated_at_ms,
+    archived_at_ms
+FROM item_categories
+WHERE id = ?1
+`
+
+func (q *Queries) GetItemCategory(ctx context.Context, id int64) (ItemCategory, error) {
+	row := q.db.QueryRowContext(ctx, getItemCategory, id)
+	var i ItemCategory
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.NormalizedName,
+		&i.CreatedAtMs,
+		&i.UpdatedAtMs,
+		&i.ArchivedAtMs,
 	)
 	return i, err
 }
@@ -159,6 +231,7 @@ INSERT INTO items (
     is_purchasable,
     is_producible,
     is_sellable,
+    category_id,
     default_sale_price_minor,
     reorder_quantity_atomic,
     created_at_ms,
@@ -178,6 +251,7 @@ INSERT INTO items (
     ?11,
     ?12,
     ?13,
+    ?14,
     NULL
 )
 RETURNING id
@@ -193,6 +267,7 @@ type InsertItemParams struct {
 	IsPurchasable         int64
 	IsProducible          int64
 	IsSellable            int64
+	CategoryID            sql.NullInt64
 	DefaultSalePriceMinor sql.NullInt64
 	ReorderQuantityAtomic sql.NullInt64
 	CreatedAtMs           int64
@@ -210,6 +285,7 @@ func (q *Queries) InsertItem(ctx context.Context, arg InsertItemParams) (int64,
 		arg.IsPurchasable,
 		arg.IsProducible,
 		arg.IsSellable,
+		arg.CategoryID,
 		arg.DefaultSalePriceMinor,
 		arg.ReorderQuantityAtomic,
 		arg.CreatedAtMs,
@@ -220,6 +296,42 @@ func (q *Queries) InsertItem(ctx context.Context, arg InsertItemParams) (int64,
 	return id, err
 }
 
+const insertItemCategory = `-- name: InsertItemCategory :one
+INSERT INTO item_categories (
+    name,
+    normalized_name,
+    created_at_ms,
+    updated_at_ms,
+    archived_at_ms
+) VALUES (
+    ?1,
+    ?2,
+    ?3,
+    ?4,
+    NULL
+)
+RETURNING id
+`
+
+type InsertItemCategoryParams struct {
+	Name           string
+	NormalizedName string
+	CreatedAtMs    int64
+	UpdatedAtMs    int64
+}
+
+func (q *Queries) InsertItemCategory(ctx context.Context, arg InsertItemCategoryParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, insertItemCategory,
+		arg.Name,
+		arg.NormalizedName,
+		arg.CreatedAtMs,
+		arg.UpdatedAtMs,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const insertItemPackaging = `-- name: InsertItemPackaging :one
 INSERT INTO item_packagings (
     item_id,
@@ -272,6 +384,52 @@ func (q *Queries) InsertItemPackaging(ctx context.Context, arg InsertItemPackagi
 	return id, err
 }
 
+const listItemCategories = `-- name: ListItemCategories :many
+SELECT
+    id,
+    name,
+    normalized_name,
+    created_at_ms,
+    updated_at_ms,
+    archived_at_ms
+FROM item_categories
+WHERE
+    CAST(?1 AS INTEGER) = 2
+    OR (CAST(?1 AS INTEGER) = 0 AND archived_at_ms IS NULL)
+    OR (CAST(?1 AS INTEGER) = 1 AND archived_at_ms IS NOT NULL)
+ORDER BY normalized_name, id
+`
+
+func (q *Queries) ListItemCategories(ctx context.Context, archiveFilter int64) ([]ItemCategory, error) {
+	rows, err := q.db.QueryContext(ctx, listItemCategories, archiveFilter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ItemCategory{}
+	for rows.Next() {
+		var i ItemCategory
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.NormalizedName,
+			&i.CreatedAtMs,
+			&i.UpdatedAtMs,
+			&i.ArchivedAtMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listItemPackagings = `-- name: ListItemPackagings :many
 SELECT
     id,
@@ -345,7 +503,8 @@ SELECT
     reorder_quantity_atomic,
     created_at_ms,
     updated_at_ms,
-    archived_at_ms
+    archived_at_ms,
+    category_id
 FROM items
 WHERE
     (
@@ -418,6 +577,7 @@ func (q *Queries) ListItems(ctx context.Context, arg ListItemsParams) ([]Item, e
 			&i.CreatedAtMs,
 			&i.UpdatedAtMs,
 			&i.ArchivedAtMs,
+			&i.CategoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -499,6 +659,30 @@ func (q *Queries) RestoreItem(ctx context.Context, arg RestoreItemParams) (int64
 	return result.RowsAffected()
 }
 
+const restoreItemCategory = `-- name: RestoreItemCategory :execrows
+UPDATE item_categories
+SET
+    archived_at_ms = NULL,
+    updated_at_ms = ?1
+WHERE id = ?2
+  AND archived_at_ms IS NOT NULL
+  AND updated_at_ms = ?3
+`
+
+type RestoreItemCategoryParams struct {
+	UpdatedAtMs         int64
+	ID                  int64
+	ExpectedUpdatedAtMs int64
+}
+
+func (q *Queries) RestoreItemCategory(ctx context.Context, arg RestoreItemCategoryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreItemCategory, arg.UpdatedAtMs, arg.ID, arg.ExpectedUpdatedAtMs)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const restoreItemPackaging = `-- name: RestoreItemPackaging :execrows
 UPDATE item_packagings
 SET
@@ -535,12 +719,13 @@ SET
     is_purchasable = ?7,
     is_producible = ?8,
     is_sellable = ?9,
-    default_sale_price_minor = ?10,
-    reorder_quantity_atomic = ?11,
-    updated_at_ms = ?12
-WHERE id = ?13
+    category_id = ?10,
+    default_sale_price_minor = ?11,
+    reorder_quantity_atomic = ?12,
+    updated_at_ms = ?13
+WHERE id = ?14
   AND archived_at_ms IS NULL
-  AND updated_at_ms = ?14
+  AND updated_at_ms = ?15
 `
 
 type UpdateItemParams struct {
@@ -553,6 +738,7 @@ type UpdateItemParams struct {
 	IsPurchasable         int64
 	IsProducible          int64
 	IsSellable            int64
+	CategoryID            sql.NullInt64
 	DefaultSalePriceMinor sql.NullInt64
 	ReorderQuantityAtomic sql.NullInt64
 	UpdatedAtMs           int64
@@ -571,6 +757,7 @@ func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) (int64,
 		arg.IsPurchasable,
 		arg.IsProducible,
 		arg.IsSellable,
+		arg.CategoryID,
 		arg.DefaultSalePriceMinor,
 		arg.ReorderQuantityAtomic,
 		arg.UpdatedAtMs,
@@ -583,6 +770,39 @@ func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) (int64,
 	return result.RowsAffected()
 }
 
+const updateItemCategory = `-- name: UpdateItemCategory :execrows
+UPDATE item_categories
+SET
+    name = ?1,
+    normalized_name = ?2,
+    updated_at_ms = ?3
+WHERE id = ?4
+  AND archived_at_ms IS NULL
+  AND updated_at_ms = ?5
+`
+
+type UpdateItemCategoryParams struct {
+	Name                string
+	NormalizedName      string
+	UpdatedAtMs         int64
+	ID                  int64
+	ExpectedUpdatedAtMs int64
+}
+
+func (q *Queries) UpdateItemCategory(ctx context.Context, arg UpdateItemCategoryParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateItemCategory,
+		arg.Name,
+		arg.NormalizedName,
+		arg.UpdatedAtMs,
+		arg.ID,
+		arg.ExpectedUpdatedAtMs,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateItemPackaging = `-- name: UpdateItemPackaging :execrows
 UPDATE item_packagings
 SET
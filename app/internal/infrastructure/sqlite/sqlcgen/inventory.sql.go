@@ -66,6 +66,8 @@ WITH lot_facts AS (
         lot.originated_on,
         lot.expires_on,
         lot.created_at_ms,
+        lot.location_id,
+        lot.transferred_from_lot_id,
         source_document.id AS source_document_id,
         source_document.kind AS source_document_kind,
         source_document.posting_sequence AS source_posting_sequence,
@@ -92,6 +94,10 @@ WITH lot_facts AS (
     JOIN stock_documents source_document ON source_document.id = source_line.document_id
     LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
     WHERE lot.item_id = ?2
+      AND (
+          CAST(?3 AS INTEGER) = 0
+          OR lot.location_id = CAST(?3 AS INTEGER)
+      )
     GROUP BY
         lot.id,
         lot.item_id,
@@ -101,6 +107,8 @@ WITH lot_facts AS (
         lot.originated_on,
         lot.expires_on,
         lot.created_at_ms,
+        lot.location_id,
+        lot.transferred_from_lot_id,
         source_document.id,
         source_document.kind,
         source_document.posting_sequence,
@@ -118,6 +126,8 @@ SELECT
     originated_on,
     expires_on,
     created_at_ms,
+    location_id,
+    transferred_from_lot_id,
     source_document_id,
     source_document_kind,
     source_posting_sequence,
@@ -135,6 +145,7 @@ ORDER BY
 type ListEligibleFEFOLotsParams struct {
 	BusinessDate string
 	ItemID       int64
+	LocationID   int64
 }
 
 type ListEligibleFEFOLotsRow struct {
@@ -149,6 +160,8 @@ type ListEligibleFEFOLotsRow struct {
 	OriginatedOn            string
 	ExpiresOn               sql.NullString
 	CreatedAtMs             int64
+	LocationID              int64
+	TransferredFromLotID    sql.NullInt64
 	SourceDocumentID        int64
 	SourceDocumentKind      string
 	SourcePostingSequence   int64
@@ -156,7 +169,7 @@ type ListEligibleFEFOLotsRow struct {
 }
 
 func (q *Queries) ListEligibleFEFOLots(ctx context.Context, arg ListEligibleFEFOLotsParams) ([]ListEligibleFEFOLotsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listEligibleFEFOLots, arg.BusinessDate, arg.ItemID)
+	rows, err := q.db.QueryContext(ctx, listEligibleFEFOLots, arg.BusinessDate, arg.ItemID, arg.LocationID)
 	if err != nil {
 		return nil, err
 	}
@@ -176,6 +189,8 @@ func (q *Queries) ListEligibleFEFOLots(ctx context.Context, arg ListEligibleFEFO
 			&i.OriginatedOn,
 			&i.ExpiresOn,
 			&i.CreatedAtMs,
+			&i.LocationID,
+			&i.TransferredFromLotID,
 			&i.SourceDocumentID,
 			&i.SourceDocumentKind,
 			&i.SourcePostingSequence,
@@ -208,28 +223,33 @@ SELECT
     balance.quantity_atomic,
     balance.inventory_value_micro,
     balance.last_document_id,
-    balance.updated_at_ms
+    balance.updated_at_ms,
+    CAST(COALESCE(location_balance.quantity_atomic, 0) AS INTEGER) AS location_quantity_atomic
 FROM inventory_balances balance
 JOIN items item ON item.id = balance.item_id
+LEFT JOIN inventory_location_balances location_balance
+  ON location_balance.item_id = balance.item_id
+ AND location_balance.location_id = CAST(?1 AS INTEGER)
 WHERE
-    (CAST(?1 AS INTEGER) = 1 OR item.archived_at_ms IS NULL)
+    (CAST(?2 AS INTEGER) = 1 OR item.archived_at_ms IS NULL)
     AND (
-        CAST(?2 AS TEXT) = ''
-        OR instr(item.normalized_name, CAST(?2 AS TEXT)) > 0
+        CAST(?3 AS TEXT) = ''
+        OR instr(item.normalized_name, CAST(?3 AS TEXT)) > 0
     )
     AND (
-        CAST(?3 AS TEXT) = ''
-        OR item.normalized_name > CAST(?3 AS TEXT)
+        CAST(?4 AS TEXT) = ''
+        OR item.normalized_name > CAST(?4 AS TEXT)
         OR (
-            item.normalized_name = CAST(?3 AS TEXT)
-            AND item.id > ?4
+            item.normalized_name = CAST(?4 AS TEXT)
+            AND item.id > ?5
         )
     )
 ORDER BY item.normalized_name, item.id
-LIMIT ?5
+LIMIT ?6
 `
 
 type ListInventoryBalancesParams struct {
+	LocationID          int64
 	IncludeArchived     int64
 	SearchKey           string
 	AfterNormalizedName string
@@ -238,23 +258,25 @@ type ListInventoryBalancesParams struct {
 }
 
 type ListInventoryBalancesRow struct {
-	ItemID                int64
-	ItemName              string
-	ItemNormalizedName    string
-	BaseUnitCode          string
-	IsPurchasable         int64
-	IsProducible          int64
-	IsSellable            int64
-	ReorderQuantityAtomic sql.NullInt64
-	ItemArchivedAtMs      sql.NullInt64
-	QuantityAtomic        int64
-	InventoryValueMicro   int64
-	LastDocumentID        sql.NullInt64
-	UpdatedAtMs           int64
+	ItemID                 int64
+	ItemName               string
+	ItemNormalizedName     string
+	BaseUnitCode           string
+	IsPurchasable          int64
+	IsProducible           int64
+	IsSellable             int64
+	ReorderQuantityAtomic  sql.NullInt64
+	ItemArchivedAtMs       sql.NullInt64
+	QuantityAtomic         int64
+	InventoryValueMicro    int64
+	LastDocumentID         sql.NullInt64
+	UpdatedAtMs            int64
+	LocationQuantityAtomic int64
 }
 
 func (q *Queries) ListInventoryBalances(ctx context.Context, arg ListInventoryBalancesParams) ([]ListInventoryBalancesRow, error) {
 	rows, err := q.db.QueryContext(ctx, listInventoryBalances,
+		arg.LocationID,
 		arg.IncludeArchived,
 		arg.SearchKey,
 		arg.AfterNormalizedName,
@@ -282,6 +304,7 @@ func (q *Queries) ListInventoryBalances(ctx context.Context, arg ListInventoryBa
 			&i.InventoryValueMicro,
 			&i.LastDocumentID,
 			&i.UpdatedAtMs,
+			&i.LocationQuantityAtomic,
 		); err != nil {
 			return nil, err
 		}
@@ -446,6 +469,8 @@ WITH lot_facts AS (
         lot.originated_on,
         lot.expires_on,
         lot.created_at_ms,
+        lot.location_id,
+        lot.transferred_from_lot_id,
         source_document.id AS source_document_id,
         source_document.kind AS source_document_kind,
         source_document.posting_sequence AS source_posting_sequence,
@@ -481,6 +506,8 @@ WITH lot_facts AS (
         lot.originated_on,
         lot.expires_on,
         lot.created_at_ms,
+        lot.location_id,
+        lot.transferred_from_lot_id,
         source_document.id,
         source_document.kind,
         source_document.posting_sequence,
@@ -498,6 +525,8 @@ SELECT
     originated_on,
     expires_on,
     created_at_ms,
+    location_id,
+    transferred_from_lot_id,
     source_document_id,
     source_document_kind,
     source_posting_sequence,
@@ -522,6 +551,8 @@ type ListItemLotFactsRow struct {
 	OriginatedOn            string
 	ExpiresOn               sql.NullString
 	CreatedAtMs             int64
+	LocationID              int64
+	TransferredFromLotID    sql.NullInt64
 	SourceDocumentID        int64
 	SourceDocumentKind      string
 	SourcePostingSequence   int64
@@ -549,6 +580,8 @@ func (q *Queries) ListItemLotFacts(ctx context.Context, itemID int64) ([]ListIte
 			&i.OriginatedOn,
 			&i.ExpiresOn,
 			&i.CreatedAtMs,
+			&i.LocationID,
+			&i.TransferredFromLotID,
 			&i.SourceDocumentID,
 			&i.SourceDocumentKind,
 			&i.SourcePostingSequence,
@@ -8,19 +8,6 @@ import (
 	"database/sql"
 )
 
-type AppSetting struct {
-	ID                            int64
-	BusinessName                  string
-	LocaleCode                    string
-	TimezoneName                  string
-	CurrencyCode                  string
-	CurrencyMinorDigits           int64
-	HourlyLaborCostMinor          sql.NullInt64
-	DefaultGrossMarginBasisPoints sql.NullInt64
-	CreatedAtMs                   int64
-	UpdatedAtMs                   int64
-}
-
 type CounterpartyRole struct {
 	CounterpartyID int64
 	Role           string
@@ -43,6 +30,16 @@ type Item struct {
 	CreatedAtMs           int64
 	UpdatedAtMs           int64
 	ArchivedAtMs          sql.NullInt64
+	CategoryID            sql.NullInt64
+}
+
+type ItemCategory struct {
+	ID             int64
+	Name           string
+	NormalizedName string
+	CreatedAtMs    int64
+	UpdatedAtMs    int64
+	ArchivedAtMs   sql.NullInt64
 }
 
 type ItemPackaging struct {
@@ -102,3 +99,12 @@ type RecipeRevisionComponent struct {
 	ConversionDenominator     int64
 	CreatedAtMs               int64
 }
+
+type StockLocation struct {
+	ID             int64
+	Name           string
+	NormalizedName string
+	CreatedAtMs    int64
+	UpdatedAtMs    int64
+	ArchivedAtMs   sql.NullInt64
+}
@@ -6,76 +6,104 @@ package sqlcgen
 
 import (
 	"context"
+	"database/sql"
 )
 
 type Querier interface {
 	AdvanceRecipeVersion(ctx context.Context, arg AdvanceRecipeVersionParams) (int64, error)
 	ArchiveCounterparty(ctx context.Context, arg ArchiveCounterpartyParams) (int64, error)
 	ArchiveItem(ctx context.Context, arg ArchiveItemParams) (int64, error)
+	ArchiveItemCategory(ctx context.Context, arg ArchiveItemCategoryParams) (int64, error)
 	ArchiveItemPackaging(ctx context.Context, arg ArchiveItemPackagingParams) (int64, error)
 	ArchiveRecipe(ctx context.Context, arg ArchiveRecipeParams) (int64, error)
+	ArchiveStockLocation(ctx context.Context, arg ArchiveStockLocationParams) (int64, error)
+	CountActiveItemsInCategory(ctx context.Context, categoryID sql.NullInt64) (int64, error)
+	CountItemsStockedAtLocation(ctx context.Context, locationID int64) (int64, error)
 	DeleteCounterpartyRoles(ctx context.Context, counterpartyID int64) (int64, error)
 	GetAnonymousSalesTotals(ctx context.Context, arg GetAnonymousSalesTotalsParams) (GetAnonymousSalesTotalsRow, error)
-	GetAppSettings(ctx context.Context) (AppSetting, error)
+	GetAppSettings(ctx context.Context) (GetAppSettingsRow, error)
 	GetCounterparty(ctx context.Context, id int64) (GetCounterpartyRow, error)
 	GetCurrentRecipe(ctx context.Context, targetRecipeID int64) (GetCurrentRecipeRow, error)
 	GetFreeSalesTotals(ctx context.Context, arg GetFreeSalesTotalsParams) (GetFreeSalesTotalsRow, error)
 	GetInventoryBalance(ctx context.Context, itemID int64) (GetInventoryBalanceRow, error)
-	GetInventoryReportTotals(ctx context.Context) (GetInventoryReportTotalsRow, error)
+	GetInventoryReportTotals(ctx context.Context, locationID int64) (GetInventoryReportTotalsRow, error)
 	GetItem(ctx context.Context, id int64) (Item, error)
+	GetItemCategory(ctx context.Context, id int64) (ItemCategory, error)
 	GetItemPackaging(ctx context.Context, id int64) (ItemPackaging, error)
 	GetLatestRecipeRevisionNumber(ctx context.Context, recipeID int64) (int64, error)
 	GetMeasurementUnit(ctx context.Context, code string) (MeasurementUnit, error)
+	// Payables of the scheduled purchases that occurred in the period: what they
+	// owe after supplier return credits, nothing once reversed, and what was paid
+	// net of payment reversals.
+	GetPurchasePayableTotals(ctx context.Context, arg GetPurchasePayableTotalsParams) (GetPurchasePayableTotalsRow, error)
 	GetRecipe(ctx context.Context, id int64) (Recipe, error)
 	GetRecipeRevision(ctx context.Context, id int64) (GetRecipeRevisionRow, error)
 	GetReportingCurrency(ctx context.Context) (GetReportingCurrencyRow, error)
+	// Customer returns enter every sales aggregate with negated quantity, refund
+	// and inventory value on their own occurred_on, so a period nets what it
+	// refunded. Only sale documents are counted as sales.
 	GetSalesReportTotals(ctx context.Context, arg GetSalesReportTotalsParams) (GetSalesReportTotalsRow, error)
+	GetStockLocation(ctx context.Context, id int64) (StockLocation, error)
 	InsertCounterparty(ctx context.Context, arg InsertCounterpartyParams) (int64, error)
 	InsertCounterpartyRole(ctx context.Context, arg InsertCounterpartyRoleParams) error
 	InsertItem(ctx context.Context, arg InsertItemParams) (int64, error)
+	InsertItemCategory(ctx context.Context, arg InsertItemCategoryParams) (int64, error)
 	InsertItemPackaging(ctx context.Context, arg InsertItemPackagingParams) (int64, error)
 	InsertRecipe(ctx context.Context, arg InsertRecipeParams) (int64, error)
 	InsertRecipeRevision(ctx context.Context, arg InsertRecipeRevisionParams) (int64, error)
 	InsertRecipeRevisionComponent(ctx context.Context, arg InsertRecipeRevisionComponentParams) (int64, error)
+	InsertStockLocation(ctx context.Context, arg InsertStockLocationParams) (int64, error)
 	ListAdjustmentReasonMetrics(ctx context.Context, arg ListAdjustmentReasonMetricsParams) ([]ListAdjustmentReasonMetricsRow, error)
 	ListCounterparties(ctx context.Context, arg ListCounterpartiesParams) ([]ListCounterpartiesRow, error)
 	ListCounterpartyRoles(ctx context.Context, counterpartyID int64) ([]CounterpartyRole, error)
 	ListEligibleFEFOLots(ctx context.Context, arg ListEligibleFEFOLotsParams) ([]ListEligibleFEFOLotsRow, error)
 	ListExactReversalSeries(ctx context.Context, arg ListExactReversalSeriesParams) ([]ListExactReversalSeriesRow, error)
+	ListExpenseSeries(ctx context.Context, arg ListExpenseSeriesParams) ([]ListExpenseSeriesRow, error)
 	ListExpiredLotsWithStock(ctx context.Context, arg ListExpiredLotsWithStockParams) ([]ListExpiredLotsWithStockRow, error)
 	ListExpiringLots(ctx context.Context, arg ListExpiringLotsParams) ([]ListExpiringLotsRow, error)
 	ListFreeStockEntrySeries(ctx context.Context, arg ListFreeStockEntrySeriesParams) ([]ListFreeStockEntrySeriesRow, error)
 	ListInventoryBalances(ctx context.Context, arg ListInventoryBalancesParams) ([]ListInventoryBalancesRow, error)
-	ListInventoryValueByItem(ctx context.Context, limitCount int64) ([]ListInventoryValueByItemRow, error)
+	ListInventoryValueByItem(ctx context.Context, arg ListInventoryValueByItemParams) ([]ListInventoryValueByItemRow, error)
+	ListItemCategories(ctx context.Context, archiveFilter int64) ([]ItemCategory, error)
 	ListItemLedgerPage(ctx context.Context, arg ListItemLedgerPageParams) ([]ListItemLedgerPageRow, error)
 	ListItemLotFacts(ctx context.Context, itemID int64) ([]ListItemLotFactsRow, error)
 	ListItemPackagings(ctx context.Context, arg ListItemPackagingsParams) ([]ItemPackaging, error)
 	ListItems(ctx context.Context, arg ListItemsParams) ([]Item, error)
 	ListLineAllocations(ctx context.Context, lineID int64) ([]ListLineAllocationsRow, error)
-	ListLowStockItems(ctx context.Context, limitCount int64) ([]ListLowStockItemsRow, error)
+	ListLowStockItems(ctx context.Context, arg ListLowStockItemsParams) ([]ListLowStockItemsRow, error)
 	ListMeasurementUnits(ctx context.Context) ([]MeasurementUnit, error)
 	ListProductionByRecipeProduct(ctx context.Context, arg ListProductionByRecipeProductParams) ([]ListProductionByRecipeProductRow, error)
 	ListProductionDirectCostSeries(ctx context.Context, arg ListProductionDirectCostSeriesParams) ([]ListProductionDirectCostSeriesRow, error)
 	ListProductionYieldVariance(ctx context.Context, arg ListProductionYieldVarianceParams) ([]ListProductionYieldVarianceRow, error)
+	// Supplier returns enter purchase spend with negated quantity, credit and
+	// inventory value on their own occurred_on. Only purchases are counted.
 	ListPurchaseSpendSeries(ctx context.Context, arg ListPurchaseSpendSeriesParams) ([]ListPurchaseSpendSeriesRow, error)
 	ListRecipeRevisionComponents(ctx context.Context, recipeRevisionID int64) ([]RecipeRevisionComponent, error)
 	ListRecipeRevisions(ctx context.Context, recipeID int64) ([]RecipeRevision, error)
 	ListRecipes(ctx context.Context, arg ListRecipesParams) ([]ListRecipesRow, error)
 	ListSalesByCustomer(ctx context.Context, arg ListSalesByCustomerParams) ([]ListSalesByCustomerRow, error)
+	ListSalesCategoryMix(ctx context.Context, arg ListSalesCategoryMixParams) ([]ListSalesCategoryMixRow, error)
 	ListSalesRevenueSeries(ctx context.Context, arg ListSalesRevenueSeriesParams) ([]ListSalesRevenueSeriesRow, error)
+	ListStockDocumentSummaries(ctx context.Context, arg ListStockDocumentSummariesParams) ([]ListStockDocumentSummariesRow, error)
+	ListStockLocations(ctx context.Context, archiveFilter int64) ([]StockLocation, error)
 	ListTopSalesProductsByQuantity(ctx context.Context, arg ListTopSalesProductsByQuantityParams) ([]ListTopSalesProductsByQuantityRow, error)
 	ListTopSalesProductsByRevenue(ctx context.Context, arg ListTopSalesProductsByRevenueParams) ([]ListTopSalesProductsByRevenueRow, error)
 	ListTopSuppliersBySpend(ctx context.Context, arg ListTopSuppliersBySpendParams) ([]ListTopSuppliersBySpendRow, error)
+	ListWriteOffSeries(ctx context.Context, arg ListWriteOffSeriesParams) ([]ListWriteOffSeriesRow, error)
 	ReconfigureArchivedItemPackaging(ctx context.Context, arg ReconfigureArchivedItemPackagingParams) (int64, error)
 	RenameRecipe(ctx context.Context, arg RenameRecipeParams) (int64, error)
 	RestoreCounterparty(ctx context.Context, arg RestoreCounterpartyParams) (int64, error)
 	RestoreItem(ctx context.Context, arg RestoreItemParams) (int64, error)
+	RestoreItemCategory(ctx context.Context, arg RestoreItemCategoryParams) (int64, error)
 	RestoreItemPackaging(ctx context.Context, arg RestoreItemPackagingParams) (int64, error)
 	RestoreRecipe(ctx context.Context, arg RestoreRecipeParams) (int64, error)
-	UpdateAppSettings(ctx context.Context, arg UpdateAppSettingsParams) (AppSetting, error)
+	RestoreStockLocation(ctx context.Context, arg RestoreStockLocationParams) (int64, error)
+	UpdateAppSettings(ctx context.Context, arg UpdateAppSettingsParams) (UpdateAppSettingsRow, error)
 	UpdateCounterparty(ctx context.Context, arg UpdateCounterpartyParams) (int64, error)
 	UpdateItem(ctx context.Context, arg UpdateItemParams) (int64, error)
+	UpdateItemCategory(ctx context.Context, arg UpdateItemCategoryParams) (int64, error)
 	UpdateItemPackaging(ctx context.Context, arg UpdateItemPackagingParams) (int64, error)
+	UpdateStockLocation(ctx context.Context, arg UpdateStockLocationParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -14,10 +14,11 @@ const getAnonymousSalesTotals = `-- name: GetAnonymousSalesTotals :one
 WITH anonymous_sale_lines AS (
     SELECT
         document.id AS document_id,
-        line.commercial_total_minor
+        document.kind,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.counterparty_id IS NULL
       AND document.occurred_on >= CAST(?1 AS TEXT)
       AND document.occurred_on <= CAST(?2 AS TEXT)
@@ -29,7 +30,7 @@ WITH anonymous_sale_lines AS (
       )
 )
 SELECT
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'SALE' THEN document_id END) AS INTEGER) AS document_count,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS revenue_minor
 FROM anonymous_sale_lines
 `
@@ -105,26 +106,51 @@ func (q *Queries) GetFreeSalesTotals(ctx context.Context, arg GetFreeSalesTotals
 }
 
 const getInventoryReportTotals = `-- name: GetInventoryReportTotals :one
+WITH stock AS (
+    -- A location filter reads the remaining lot quantity at that location
+    -- and prorates the item's inventory value over it.
+    SELECT
+        item.id AS item_id,
+        item.name AS item_name,
+        item.base_unit_code,
+        item.archived_at_ms,
+        item.is_sellable,
+        item.reorder_quantity_atomic,
+        CASE
+            WHEN CAST(?1 AS INTEGER) = 0 THEN balance.quantity_atomic
+            ELSE COALESCE(location_balance.quantity_atomic, 0)
+        END AS quantity_atomic,
+        CASE
+            WHEN CAST(?1 AS INTEGER) = 0 THEN balance.inventory_value_micro
+            WHEN balance.quantity_atomic = 0 THEN 0
+            ELSE (balance.inventory_value_micro * COALESCE(location_balance.quantity_atomic, 0))
+                / balance.quantity_atomic
+        END AS inventory_value_micro
+    FROM inventory_balances balance
+    JOIN items item ON item.id = balance.item_id
+    LEFT JOIN inventory_location_balances location_balance
+      ON location_balance.item_id = balance.item_id
+     AND location_balance.location_id = CAST(?1 AS INTEGER)
+)
 SELECT
-    CAST(COALESCE(SUM(balance.inventory_value_micro), 0) AS INTEGER) AS total_inventory_value_micro,
+    CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS total_inventory_value_micro,
     CAST(COALESCE(SUM(
         CASE
-            WHEN item.archived_at_ms IS NULL
-             AND item.reorder_quantity_atomic IS NOT NULL
-             AND balance.quantity_atomic <= item.reorder_quantity_atomic
+            WHEN archived_at_ms IS NULL
+             AND reorder_quantity_atomic IS NOT NULL
+             AND quantity_atomic <= reorder_quantity_atomic
                 THEN 1 ELSE 0
         END
     ), 0) AS INTEGER) AS low_stock_item_count,
     CAST(COALESCE(SUM(
         CASE
-            WHEN item.archived_at_ms IS NULL
-             AND item.is_sellable = 1
-             AND balance.quantity_atomic = 0
+            WHEN archived_at_ms IS NULL
+             AND is_sellable = 1
+             AND quantity_atomic = 0
                 THEN 1 ELSE 0
         END
     ), 0) AS INTEGER) AS zero_stock_sellable_count
-FROM inventory_balances balance
-JOIN items item ON item.id = balance.item_id
+FROM stock
 `
 
 type GetInventoryReportTotalsRow struct {
@@ -133,13 +159,78 @@ type GetInventoryReportTotalsRow struct {
 	ZeroStockSellableCount   int64
 }
 
-func (q *Queries) GetInventoryReportTotals(ctx context.Context) (GetInventoryReportTotalsRow, error) {
-	row := q.db.QueryRowContext(ctx, getInventoryReportTotals)
+func (q *Queries) GetInventoryReportTotals(ctx context.Context, locationID int64) (GetInventoryReportTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getInventoryReportTotals, locationID)
 	var i GetInventoryReportTotalsRow
 	err := row.Scan(&i.TotalInventoryValueMicro, &i.LowStockItemCount, &i.ZeroStockSellableCount)
 	return i, err
 }
 
+const getPurchasePayableTotals = `-- name: GetPurchasePayableTotals :one
+-- Payables of the scheduled purchases that occurred in the period: what they
+-- owe after supplier return credits, nothing once reversed, and what was paid
+-- net of payment reversals.
+WITH period_payables AS (
+    SELECT
+        CASE
+            WHEN EXISTS (
+                SELECT 1
+                FROM stock_documents reversal
+                WHERE reversal.kind = 'REVERSAL'
+                  AND reversal.reverses_document_id = purchase.id
+            ) THEN 0
+            ELSE payable.total_minor - (
+                SELECT COALESCE(SUM(line.commercial_total_minor), 0)
+                FROM stock_documents supplier_return
+                JOIN stock_document_lines line ON line.document_id = supplier_return.id
+                WHERE supplier_return.returns_document_id = purchase.id
+                  AND NOT EXISTS (
+                      SELECT 1
+                      FROM stock_documents reversal
+                      WHERE reversal.kind = 'REVERSAL'
+                        AND reversal.reverses_document_id = supplier_return.id
+                  )
+            )
+        END AS owed_minor,
+        (
+            SELECT COALESCE(SUM(CASE WHEN payment.reverses_payment_id IS NULL
+                THEN payment.amount_minor ELSE -payment.amount_minor END), 0)
+            FROM supplier_payments payment
+            WHERE payment.purchase_document_id = purchase.id
+        ) AS paid_minor
+    FROM purchase_payables payable
+    JOIN stock_documents purchase ON purchase.id = payable.purchase_document_id
+    WHERE purchase.occurred_on >= CAST(?1 AS TEXT)
+      AND purchase.occurred_on <= CAST(?2 AS TEXT)
+)
+SELECT
+    CAST(COUNT(*) AS INTEGER) AS payable_count,
+    CAST(COALESCE(SUM(owed_minor), 0) AS INTEGER) AS owed_minor,
+    CAST(COALESCE(SUM(paid_minor), 0) AS INTEGER) AS paid_minor
+FROM period_payables
+`
+
+type GetPurchasePayableTotalsParams struct {
+	FromOccurredOn string
+	ToOccurredOn   string
+}
+
+type GetPurchasePayableTotalsRow struct {
+	PayableCount int64
+	OwedMinor    int64
+	PaidMinor    int64
+}
+
+// Payables of the scheduled purchases that occurred in the period: what they
+// owe after supplier return credits, nothing once reversed, and what was paid
+// net of payment reversals.
+func (q *Queries) GetPurchasePayableTotals(ctx context.Context, arg GetPurchasePayableTotalsParams) (GetPurchasePayableTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, getPurchasePayableTotals, arg.FromOccurredOn, arg.ToOccurredOn)
+	var i GetPurchasePayableTotalsRow
+	err := row.Scan(&i.PayableCount, &i.OwedMinor, &i.PaidMinor)
+	return i, err
+}
+
 const getReportingCurrency = `-- name: GetReportingCurrency :one
 SELECT currency_code, currency_minor_digits
 FROM app_settings
@@ -162,12 +253,13 @@ const getSalesReportTotals = `-- name: GetSalesReportTotals :one
 WITH active_sale_lines AS (
     SELECT
         document.id AS document_id,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        document.kind,
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.occurred_on >= CAST(?1 AS TEXT)
       AND document.occurred_on <= CAST(?2 AS TEXT)
       AND NOT EXISTS (
@@ -178,10 +270,14 @@ WITH active_sale_lines AS (
       )
 )
 SELECT
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS sales_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'SALE' THEN document_id END) AS INTEGER) AS sales_count,
     CAST(COALESCE(SUM(quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS revenue_minor,
-    CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS cogs_micro
+    CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS cogs_micro,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'RETURN' THEN document_id END) AS INTEGER) AS return_count,
+    CAST(COALESCE(SUM(
+        CASE WHEN kind = 'RETURN' THEN -commercial_total_minor ELSE 0 END
+    ), 0) AS INTEGER) AS refund_minor
 FROM active_sale_lines
 `
 
@@ -195,8 +291,13 @@ type GetSalesReportTotalsRow struct {
 	QuantityAtomic int64
 	RevenueMinor   int64
 	CogsMicro      int64
+	ReturnCount    int64
+	RefundMinor    int64
 }
 
+// Customer returns enter every sales aggregate with negated quantity, refund
+// and inventory value on their own occurred_on, so a period nets what it
+// refunded. Only sale documents are counted as sales.
 func (q *Queries) GetSalesReportTotals(ctx context.Context, arg GetSalesReportTotalsParams) (GetSalesReportTotalsRow, error) {
 	row := q.db.QueryRowContext(ctx, getSalesReportTotals, arg.FromOccurredOn, arg.ToOccurredOn)
 	var i GetSalesReportTotalsRow
@@ -205,6 +306,8 @@ func (q *Queries) GetSalesReportTotals(ctx context.Context, arg GetSalesReportTo
 		&i.QuantityAtomic,
 		&i.RevenueMinor,
 		&i.CogsMicro,
+		&i.ReturnCount,
+		&i.RefundMinor,
 	)
 	return i, err
 }
@@ -355,6 +458,85 @@ func (q *Queries) ListExactReversalSeries(ctx context.Context, arg ListExactReve
 	return items, nil
 }
 
+const listExpenseSeries = `-- name: ListExpenseSeries :many
+-- Only current expenses count: a corrected expense is replaced by its
+-- correction on the correction's own business date, and a void counts nowhere.
+WITH current_expenses AS (
+    SELECT
+        expense.id AS expense_id,
+        CAST(
+            CASE
+                WHEN CAST(?1 AS TEXT) = 'DAY'
+                    THEN expense.occurred_on
+                ELSE substr(expense.occurred_on, 1, 7)
+            END AS TEXT
+        ) AS bucket,
+        expense.category,
+        expense.amount_minor
+    FROM expenses expense
+    WHERE expense.amount_minor > 0
+      AND expense.occurred_on >= CAST(?2 AS TEXT)
+      AND expense.occurred_on <= CAST(?3 AS TEXT)
+      AND NOT EXISTS (
+          SELECT 1
+          FROM expenses correction
+          WHERE correction.corrects_expense_id = expense.id
+      )
+)
+SELECT
+    CAST(bucket AS TEXT) AS bucket,
+    CAST(bucket AS TEXT) AS label,
+    CAST(category AS TEXT) AS category,
+    CAST(COUNT(DISTINCT expense_id) AS INTEGER) AS expense_count,
+    CAST(COALESCE(SUM(amount_minor), 0) AS INTEGER) AS amount_minor
+FROM current_expenses
+GROUP BY bucket, category
+ORDER BY bucket, category
+`
+
+type ListExpenseSeriesParams struct {
+	Granularity    string
+	FromOccurredOn string
+	ToOccurredOn   string
+}
+
+type ListExpenseSeriesRow struct {
+	Bucket       string
+	Label        string
+	Category     string
+	ExpenseCount int64
+	AmountMinor  int64
+}
+
+func (q *Queries) ListExpenseSeries(ctx context.Context, arg ListExpenseSeriesParams) ([]ListExpenseSeriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExpenseSeries, arg.Granularity, arg.FromOccurredOn, arg.ToOccurredOn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListExpenseSeriesRow{}
+	for rows.Next() {
+		var i ListExpenseSeriesRow
+		if err := rows.Scan(
+			&i.Bucket,
+			&i.Label,
+			&i.Category,
+			&i.ExpenseCount,
+			&i.AmountMinor,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listExpiredLotsWithStock = `-- name: ListExpiredLotsWithStock :many
 WITH lot_facts AS (
     SELECT
@@ -382,6 +564,10 @@ WITH lot_facts AS (
     LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
     WHERE item.archived_at_ms IS NULL
       AND lot.expires_on IS NOT NULL
+      AND (
+          CAST(?3 AS INTEGER) = 0
+          OR lot.location_id = CAST(?3 AS INTEGER)
+      )
     GROUP BY lot.id
 )
 SELECT
@@ -405,6 +591,7 @@ LIMIT ?2
 type ListExpiredLotsWithStockParams struct {
 	ReferenceDate string
 	LimitCount    int64
+	LocationID    int64
 }
 
 type ListExpiredLotsWithStockRow struct {
@@ -418,7 +605,7 @@ type ListExpiredLotsWithStockRow struct {
 }
 
 func (q *Queries) ListExpiredLotsWithStock(ctx context.Context, arg ListExpiredLotsWithStockParams) ([]ListExpiredLotsWithStockRow, error) {
-	rows, err := q.db.QueryContext(ctx, listExpiredLotsWithStock, arg.ReferenceDate, arg.LimitCount)
+	rows, err := q.db.QueryContext(ctx, listExpiredLotsWithStock, arg.ReferenceDate, arg.LimitCount, arg.LocationID)
 	if err != nil {
 		return nil, err
 	}
@@ -475,6 +662,10 @@ WITH lot_facts AS (
     LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
     WHERE item.archived_at_ms IS NULL
       AND lot.expires_on IS NOT NULL
+      AND (
+          CAST(?4 AS INTEGER) = 0
+          OR lot.location_id = CAST(?4 AS INTEGER)
+      )
     GROUP BY lot.id
 )
 SELECT
@@ -503,6 +694,7 @@ type ListExpiringLotsParams struct {
 	ReferenceDate string
 	DaysAhead     int64
 	LimitCount    int64
+	LocationID    int64
 }
 
 type ListExpiringLotsRow struct {
@@ -516,7 +708,12 @@ type ListExpiringLotsRow struct {
 }
 
 func (q *Queries) ListExpiringLots(ctx context.Context, arg ListExpiringLotsParams) ([]ListExpiringLotsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listExpiringLots, arg.ReferenceDate, arg.DaysAhead, arg.LimitCount)
+	rows, err := q.db.QueryContext(ctx, listExpiringLots,
+		arg.ReferenceDate,
+		arg.DaysAhead,
+		arg.LimitCount,
+		arg.LocationID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -631,20 +828,50 @@ func (q *Queries) ListFreeStockEntrySeries(ctx context.Context, arg ListFreeStoc
 }
 
 const listInventoryValueByItem = `-- name: ListInventoryValueByItem :many
+WITH stock AS (
+    -- A location filter reads the remaining lot quantity at that location
+    -- and prorates the item's inventory value over it.
+    SELECT
+        item.id AS item_id,
+        item.name AS item_name,
+        item.base_unit_code,
+        item.archived_at_ms,
+        item.is_sellable,
+        item.reorder_quantity_atomic,
+        CASE
+            WHEN CAST(?2 AS INTEGER) = 0 THEN balance.quantity_atomic
+            ELSE COALESCE(location_balance.quantity_atomic, 0)
+        END AS quantity_atomic,
+        CASE
+            WHEN CAST(?2 AS INTEGER) = 0 THEN balance.inventory_value_micro
+            WHEN balance.quantity_atomic = 0 THEN 0
+            ELSE (balance.inventory_value_micro * COALESCE(location_balance.quantity_atomic, 0))
+                / balance.quantity_atomic
+        END AS inventory_value_micro
+    FROM inventory_balances balance
+    JOIN items item ON item.id = balance.item_id
+    LEFT JOIN inventory_location_balances location_balance
+      ON location_balance.item_id = balance.item_id
+     AND location_balance.location_id = CAST(?2 AS INTEGER)
+)
 SELECT
-    item.id AS item_id,
-    item.name AS item_name,
-    item.base_unit_code,
-    balance.quantity_atomic,
-    balance.inventory_value_micro
-FROM inventory_balances balance
-JOIN items item ON item.id = balance.item_id
-WHERE item.archived_at_ms IS NULL
-  AND balance.inventory_value_micro > 0
-ORDER BY balance.inventory_value_micro DESC, item.name, item.id
+    item_id,
+    item_name,
+    base_unit_code,
+    CAST(quantity_atomic AS INTEGER) AS quantity_atomic,
+    CAST(inventory_value_micro AS INTEGER) AS inventory_value_micro
+FROM stock
+WHERE archived_at_ms IS NULL
+  AND inventory_value_micro > 0
+ORDER BY inventory_value_micro DESC, item_name, item_id
 LIMIT ?1
 `
 
+type ListInventoryValueByItemParams struct {
+	LimitCount int64
+	LocationID int64
+}
+
 type ListInventoryValueByItemRow struct {
 	ItemID              int64
 	ItemName            string
@@ -653,8 +880,8 @@ type ListInventoryValueByItemRow struct {
 	InventoryValueMicro int64
 }
 
-func (q *Queries) ListInventoryValueByItem(ctx context.Context, limitCount int64) ([]ListInventoryValueByItemRow, error) {
-	rows, err := q.db.QueryContext(ctx, listInventoryValueByItem, limitCount)
+func (q *Queries) ListInventoryValueByItem(ctx context.Context, arg ListInventoryValueByItemParams) ([]ListInventoryValueByItemRow, error) {
+	rows, err := q.db.QueryContext(ctx, listInventoryValueByItem, arg.LimitCount, arg.LocationID)
 	if err != nil {
 		return nil, err
 	}
@@ -683,22 +910,52 @@ func (q *Queries) ListInventoryValueByItem(ctx context.Context, limitCount int64
 }
 
 const listLowStockItems = `-- name: ListLowStockItems :many
+WITH stock AS (
+    -- A location filter reads the remaining lot quantity at that location
+    -- and prorates the item's inventory value over it.
+    SELECT
+        item.id AS item_id,
+        item.name AS item_name,
+        item.base_unit_code,
+        item.archived_at_ms,
+        item.is_sellable,
+        item.reorder_quantity_atomic,
+        CASE
+            WHEN CAST(?2 AS INTEGER) = 0 THEN balance.quantity_atomic
+            ELSE COALESCE(location_balance.quantity_atomic, 0)
+        END AS quantity_atomic,
+        CASE
+            WHEN CAST(?2 AS INTEGER) = 0 THEN balance.inventory_value_micro
+            WHEN balance.quantity_atomic = 0 THEN 0
+            ELSE (balance.inventory_value_micro * COALESCE(location_balance.quantity_atomic, 0))
+                / balance.quantity_atomic
+        END AS inventory_value_micro
+    FROM inventory_balances balance
+    JOIN items item ON item.id = balance.item_id
+    LEFT JOIN inventory_location_balances location_balance
+      ON location_balance.item_id = balance.item_id
+     AND location_balance.location_id = CAST(?2 AS INTEGER)
+)
 SELECT
-    item.id AS item_id,
-    item.name AS item_name,
-    item.base_unit_code,
-    balance.quantity_atomic,
-    balance.inventory_value_micro,
-    item.reorder_quantity_atomic
-FROM inventory_balances balance
-JOIN items item ON item.id = balance.item_id
-WHERE item.archived_at_ms IS NULL
-  AND item.reorder_quantity_atomic IS NOT NULL
-  AND balance.quantity_atomic <= item.reorder_quantity_atomic
-ORDER BY (item.reorder_quantity_atomic - balance.quantity_atomic) DESC, item.name, item.id
+    item_id,
+    item_name,
+    base_unit_code,
+    CAST(quantity_atomic AS INTEGER) AS quantity_atomic,
+    CAST(inventory_value_micro AS INTEGER) AS inventory_value_micro,
+    reorder_quantity_atomic
+FROM stock
+WHERE archived_at_ms IS NULL
+  AND reorder_quantity_atomic IS NOT NULL
+  AND quantity_atomic <= reorder_quantity_atomic
+ORDER BY (reorder_quantity_atomic - quantity_atomic) DESC, item_name, item_id
 LIMIT ?1
 `
 
+type ListLowStockItemsParams struct {
+	LimitCount int64
+	LocationID int64
+}
+
 type ListLowStockItemsRow struct {
 	ItemID                int64
 	ItemName              string
@@ -708,8 +965,8 @@ type ListLowStockItemsRow struct {
 	ReorderQuantityAtomic sql.NullInt64
 }
 
-func (q *Queries) ListLowStockItems(ctx context.Context, limitCount int64) ([]ListLowStockItemsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listLowStockItems, limitCount)
+func (q *Queries) ListLowStockItems(ctx context.Context, arg ListLowStockItemsParams) ([]ListLowStockItemsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listLowStockItems, arg.LimitCount, arg.LocationID)
 	if err != nil {
 		return nil, err
 	}
@@ -1038,6 +1295,7 @@ const listPurchaseSpendSeries = `-- name: ListPurchaseSpendSeries :many
 WITH active_purchase_lines AS (
     SELECT
         document.id AS document_id,
+        document.kind,
         CAST(
             CASE
                 WHEN CAST(?1 AS TEXT) = 'DAY'
@@ -1045,12 +1303,12 @@ WITH active_purchase_lines AS (
                 ELSE substr(document.occurred_on, 1, 7)
             END AS TEXT
         ) AS bucket,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
-    WHERE document.kind = 'PURCHASE'
+    WHERE (document.kind = 'PURCHASE' OR document.reason_code = 'SUPPLIER_RETURN')
       AND document.occurred_on >= CAST(?2 AS TEXT)
       AND document.occurred_on <= CAST(?3 AS TEXT)
       AND NOT EXISTS (
@@ -1063,7 +1321,7 @@ WITH active_purchase_lines AS (
 SELECT
     CAST(bucket AS TEXT) AS bucket,
     CAST(bucket AS TEXT) AS label,
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'PURCHASE' THEN document_id END) AS INTEGER) AS document_count,
     CAST(COALESCE(SUM(quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS spend_minor,
     CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS inventory_value_micro
@@ -1087,6 +1345,8 @@ type ListPurchaseSpendSeriesRow struct {
 	InventoryValueMicro int64
 }
 
+// Supplier returns enter purchase spend with negated quantity, credit and
+// inventory value on their own occurred_on. Only purchases are counted.
 func (q *Queries) ListPurchaseSpendSeries(ctx context.Context, arg ListPurchaseSpendSeriesParams) ([]ListPurchaseSpendSeriesRow, error) {
 	rows, err := q.db.QueryContext(ctx, listPurchaseSpendSeries, arg.Granularity, arg.FromOccurredOn, arg.ToOccurredOn)
 	if err != nil {
@@ -1121,13 +1381,14 @@ const listSalesByCustomer = `-- name: ListSalesByCustomer :many
 WITH active_sale_lines AS (
     SELECT
         document.id AS document_id,
+        document.kind,
         document.counterparty_id,
         counterparty.name AS counterparty_name,
-        line.commercial_total_minor
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
     JOIN counterparties counterparty ON counterparty.id = document.counterparty_id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.counterparty_id IS NOT NULL
       AND document.occurred_on >= CAST(?2 AS TEXT)
       AND document.occurred_on <= CAST(?3 AS TEXT)
@@ -1141,7 +1402,7 @@ WITH active_sale_lines AS (
 SELECT
     counterparty_id,
     counterparty_name,
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'SALE' THEN document_id END) AS INTEGER) AS document_count,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS revenue_minor
 FROM active_sale_lines
 GROUP BY counterparty_id, counterparty_name
@@ -1190,10 +1451,81 @@ func (q *Queries) ListSalesByCustomer(ctx context.Context, arg ListSalesByCustom
 	return items, nil
 }
 
+const listSalesCategoryMix = `-- name: ListSalesCategoryMix :many
+WITH active_sale_lines AS (
+    SELECT
+        item.category_id,
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor
+    FROM stock_documents document
+    JOIN stock_document_lines line ON line.document_id = document.id
+    JOIN items item ON item.id = line.item_id
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
+      AND document.occurred_on >= CAST(?1 AS TEXT)
+      AND document.occurred_on <= CAST(?2 AS TEXT)
+      AND NOT EXISTS (
+          SELECT 1
+          FROM stock_documents reversal
+          WHERE reversal.kind = 'REVERSAL'
+            AND reversal.reverses_document_id = document.id
+      )
+)
+SELECT
+    category.id AS category_id,
+    category.name AS category_name,
+    CAST(COALESCE(SUM(sale.quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
+    CAST(COALESCE(SUM(sale.commercial_total_minor), 0) AS INTEGER) AS revenue_minor
+FROM active_sale_lines sale
+LEFT JOIN item_categories category ON category.id = sale.category_id
+GROUP BY category.id, category.name
+ORDER BY revenue_minor DESC, quantity_atomic DESC, category.id IS NULL, category.normalized_name, category.id
+`
+
+type ListSalesCategoryMixParams struct {
+	FromOccurredOn string
+	ToOccurredOn   string
+}
+
+type ListSalesCategoryMixRow struct {
+	CategoryID     sql.NullInt64
+	CategoryName   sql.NullString
+	QuantityAtomic int64
+	RevenueMinor   int64
+}
+
+func (q *Queries) ListSalesCategoryMix(ctx context.Context, arg ListSalesCategoryMixParams) ([]ListSalesCategoryMixRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSalesCategoryMix, arg.FromOccurredOn, arg.ToOccurredOn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSalesCategoryMixRow{}
+	for rows.Next() {
+		var i ListSalesCategoryMixRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.QuantityAtomic,
+			&i.RevenueMinor,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSalesRevenueSeries = `-- name: ListSalesRevenueSeries :many
 WITH active_sale_lines AS (
     SELECT
         document.id AS document_id,
+        document.kind,
         CAST(
             CASE
                 WHEN CAST(?1 AS TEXT) = 'DAY'
@@ -1201,12 +1533,12 @@ WITH active_sale_lines AS (
                 ELSE substr(document.occurred_on, 1, 7)
             END AS TEXT
         ) AS bucket,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.occurred_on >= CAST(?2 AS TEXT)
       AND document.occurred_on <= CAST(?3 AS TEXT)
       AND NOT EXISTS (
@@ -1219,7 +1551,7 @@ WITH active_sale_lines AS (
 SELECT
     CAST(bucket AS TEXT) AS bucket,
     CAST(bucket AS TEXT) AS label,
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS sales_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'SALE' THEN document_id END) AS INTEGER) AS sales_count,
     CAST(COALESCE(SUM(quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS revenue_minor,
     CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS cogs_micro
@@ -1279,13 +1611,13 @@ WITH active_sale_lines AS (
         line.item_id,
         item.name AS item_name,
         item.base_unit_code,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
     JOIN items item ON item.id = line.item_id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.occurred_on >= CAST(?2 AS TEXT)
       AND document.occurred_on <= CAST(?3 AS TEXT)
       AND NOT EXISTS (
@@ -1359,13 +1691,13 @@ WITH active_sale_lines AS (
         line.item_id,
         item.name AS item_name,
         item.base_unit_code,
-        line.quantity_atomic,
-        line.commercial_total_minor,
-        line.inventory_value_micro
+        line.quantity_atomic * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS quantity_atomic,
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor,
+        line.inventory_value_micro * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS inventory_value_micro
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
     JOIN items item ON item.id = line.item_id
-    WHERE document.kind = 'SALE'
+    WHERE (document.kind = 'SALE' OR document.reason_code = 'CUSTOMER_RETURN')
       AND document.occurred_on >= CAST(?2 AS TEXT)
       AND document.occurred_on <= CAST(?3 AS TEXT)
       AND NOT EXISTS (
@@ -1437,13 +1769,14 @@ const listTopSuppliersBySpend = `-- name: ListTopSuppliersBySpend :many
 WITH active_purchase_lines AS (
     SELECT
         document.id AS document_id,
+        document.kind,
         document.counterparty_id,
         counterparty.name AS counterparty_name,
-        line.commercial_total_minor
+        line.commercial_total_minor * (CASE document.kind WHEN 'RETURN' THEN -1 ELSE 1 END) AS commercial_total_minor
     FROM stock_documents document
     JOIN stock_document_lines line ON line.document_id = document.id
     JOIN counterparties counterparty ON counterparty.id = document.counterparty_id
-    WHERE document.kind = 'PURCHASE'
+    WHERE (document.kind = 'PURCHASE' OR document.reason_code = 'SUPPLIER_RETURN')
       AND document.counterparty_id IS NOT NULL
       AND document.occurred_on >= CAST(?2 AS TEXT)
       AND document.occurred_on <= CAST(?3 AS TEXT)
@@ -1457,7 +1790,7 @@ WITH active_purchase_lines AS (
 SELECT
     counterparty_id,
     counterparty_name,
-    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COUNT(DISTINCT CASE WHEN kind = 'PURCHASE' THEN document_id END) AS INTEGER) AS document_count,
     CAST(COALESCE(SUM(commercial_total_minor), 0) AS INTEGER) AS spend_minor
 FROM active_purchase_lines
 GROUP BY counterparty_id, counterparty_name
@@ -1505,3 +1838,90 @@ func (q *Queries) ListTopSuppliersBySpend(ctx context.Context, arg ListTopSuppli
 	}
 	return items, nil
 }
+
+const listWriteOffSeries = `-- name: ListWriteOffSeries :many
+-- Write-offs are the outbound lines of unreversed WASTE, EXPIRY, and DAMAGE
+-- adjustments at their recorded inventory value.
+WITH active_write_off_lines AS (
+    SELECT
+        document.id AS document_id,
+        CAST(
+            CASE
+                WHEN CAST(?1 AS TEXT) = 'DAY'
+                    THEN document.occurred_on
+                ELSE substr(document.occurred_on, 1, 7)
+            END AS TEXT
+        ) AS bucket,
+        document.reason_code,
+        line.quantity_atomic,
+        line.inventory_value_micro
+    FROM stock_documents document
+    JOIN stock_document_lines line ON line.document_id = document.id
+    WHERE document.kind = 'ADJUSTMENT'
+      AND document.reason_code IN ('WASTE', 'EXPIRY', 'DAMAGE')
+      AND line.direction = 'OUT'
+      AND document.occurred_on >= CAST(?2 AS TEXT)
+      AND document.occurred_on <= CAST(?3 AS TEXT)
+      AND NOT EXISTS (
+          SELECT 1
+          FROM stock_documents reversal
+          WHERE reversal.kind = 'REVERSAL'
+            AND reversal.reverses_document_id = document.id
+      )
+)
+SELECT
+    CAST(bucket AS TEXT) AS bucket,
+    CAST(bucket AS TEXT) AS label,
+    CAST(reason_code AS TEXT) AS reason_code,
+    CAST(COUNT(DISTINCT document_id) AS INTEGER) AS document_count,
+    CAST(COALESCE(SUM(quantity_atomic), 0) AS INTEGER) AS quantity_atomic,
+    CAST(COALESCE(SUM(inventory_value_micro), 0) AS INTEGER) AS inventory_value_micro
+FROM active_write_off_lines
+GROUP BY bucket, reason_code
+ORDER BY bucket, reason_code
+`
+
+type ListWriteOffSeriesParams struct {
+	Granularity    string
+	FromOccurredOn string
+	ToOccurredOn   string
+}
+
+type ListWriteOffSeriesRow struct {
+	Bucket              string
+	Label               string
+	ReasonCode          string
+	DocumentCount       int64
+	QuantityAtomic      int64
+	InventoryValueMicro int64
+}
+
+func (q *Queries) ListWriteOffSeries(ctx context.Context, arg ListWriteOffSeriesParams) ([]ListWriteOffSeriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWriteOffSeries, arg.Granularity, arg.FromOccurredOn, arg.ToOccurredOn)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListWriteOffSeriesRow{}
+	for rows.Next() {
+		var i ListWriteOffSeriesRow
+		if err := rows.Scan(
+			&i.Bucket,
+			&i.Label,
+			&i.ReasonCode,
+			&i.DocumentCount,
+			&i.QuantityAtomic,
+			&i.InventoryValueMicro,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -20,15 +20,36 @@ SELECT
     currency_minor_digits,
     hourly_labor_cost_minor,
     default_gross_margin_basis_points,
+    backup_enabled,
+    backup_interval_minutes,
+    backup_keep_daily,
+    backup_keep_weekly,
     created_at_ms,
     updated_at_ms
 FROM app_settings
 WHERE id = 1
 `
 
-func (q *Queries) GetAppSettings(ctx context.Context) (AppSetting, error) {
+type GetAppSettingsRow struct {
+	ID                            int64
+	BusinessName                  string
+	LocaleCode                    string
+	TimezoneName                  string
+	CurrencyCode                  string
+	CurrencyMinorDigits           int64
+	HourlyLaborCostMinor          sql.NullInt64
+	DefaultGrossMarginBasisPoints sql.NullInt64
+	BackupEnabled                 int64
+	BackupIntervalMinutes         int64
+	BackupKeepDaily               int64
+	BackupKeepWeekly              int64
+	CreatedAtMs                   int64
+	UpdatedAtMs                   int64
+}
+
+func (q *Queries) GetAppSettings(ctx context.Context) (GetAppSettingsRow, error) {
 	row := q.db.QueryRowContext(ctx, getAppSettings)
-	var i AppSetting
+	var i GetAppSettingsRow
 	err := row.Scan(
 		&i.ID,
 		&i.BusinessName,
@@ -38,6 +59,10 @@ func (q *Queries) GetAppSettings(ctx context.Context) (AppSetting, error) {
 		&i.CurrencyMinorDigits,
 		&i.HourlyLaborCostMinor,
 		&i.DefaultGrossMarginBasisPoints,
+		&i.BackupEnabled,
+		&i.BackupIntervalMinutes,
+		&i.BackupKeepDaily,
+		&i.BackupKeepWeekly,
 		&i.CreatedAtMs,
 		&i.UpdatedAtMs,
 	)
@@ -138,9 +163,13 @@ SET
     currency_minor_digits = ?5,
     hourly_labor_cost_minor = ?6,
     default_gross_margin_basis_points = ?7,
-    updated_at_ms = ?8
+    backup_enabled = ?8,
+    backup_interval_minutes = ?9,
+    backup_keep_daily = ?10,
+    backup_keep_weekly = ?11,
+    updated_at_ms = ?12
 WHERE id = 1
-  AND updated_at_ms = ?9
+  AND updated_at_ms = ?13
 RETURNING
     id,
     business_name,
@@ -150,6 +179,10 @@ RETURNING
     currency_minor_digits,
     hourly_labor_cost_minor,
     default_gross_margin_basis_points,
+    backup_enabled,
+    backup_interval_minutes,
+    backup_keep_daily,
+    backup_keep_weekly,
     created_at_ms,
     updated_at_ms
 `
@@ -162,11 +195,32 @@ type UpdateAppSettingsParams struct {
 	CurrencyMinorDigits           int64
 	HourlyLaborCostMinor          sql.NullInt64
 	DefaultGrossMarginBasisPoints sql.NullInt64
+	BackupEnabled                 int64
+	BackupIntervalMinutes         int64
+	BackupKeepDaily               int64
+	BackupKeepWeekly              int64
 	UpdatedAtMs                   int64
 	ExpectedUpdatedAtMs           int64
 }
 
-func (q *Queries) UpdateAppSettings(ctx context.Context, arg UpdateAppSettingsParams) (AppSetting, error) {
+type UpdateAppSettingsRow struct {
+	ID                            int64
+	BusinessName                  string
+	LocaleCode                    string
+	TimezoneName                  string
+	CurrencyCode                  string
+	CurrencyMinorDigits           int64
+	HourlyLaborCostMinor          sql.NullInt64
+	DefaultGrossMarginBasisPoints sql.NullInt64
+	BackupEnabled                 int64
+	BackupIntervalMinutes         int64
+	BackupKeepDaily               int64
+	BackupKeepWeekly              int64
+	CreatedAtMs                   int64
+	UpdatedAtMs                   int64
+}
+
+func (q *Queries) UpdateAppSettings(ctx context.Context, arg UpdateAppSettingsParams) (UpdateAppSettingsRow, error) {
 	row := q.db.QueryRowContext(ctx, updateAppSettings,
 		arg.BusinessName,
 		arg.LocaleCode,
@@ -175,10 +229,14 @@ func (q *Queries) UpdateAppSettings(ctx context.Context, arg UpdateAppSettingsPa
 		arg.CurrencyMinorDigits,
 		arg.HourlyLaborCostMinor,
 		arg.DefaultGrossMarginBasisPoints,
+		arg.BackupEnabled,
+		arg.BackupIntervalMinutes,
+		arg.BackupKeepDaily,
+		arg.BackupKeepWeekly,
 		arg.UpdatedAtMs,
 		arg.ExpectedUpdatedAtMs,
 	)
-	var i AppSetting
+	var i UpdateAppSettingsRow
 	err := row.Scan(
 		&i.ID,
 		&i.BusinessName,
@@ -188,6 +246,10 @@ func (q *Queries) UpdateAppSettings(ctx context.Context, arg UpdateAppSettingsPa
 		&i.CurrencyMinorDigits,
 		&i.HourlyLaborCostMinor,
 		&i.DefaultGrossMarginBasisPoints,
+		&i.BackupEnabled,
+		&i.BackupIntervalMinutes,
+		&i.BackupKeepDaily,
+		&i.BackupKeepWeekly,
 		&i.CreatedAtMs,
 		&i.UpdatedAtMs,
 	)
@@ -0,0 +1,875 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+)
+
+const (
+	stockCountDefaultPageSize = 50
+	stockCountMaximumPageSize = 100
+)
+
+// StockCountItemInput counts an item as a whole or, with ByLot, one line per
+// lot that still holds stock.
+type StockCountItemInput struct {
+	ItemID domain.ItemID
+	ByLot  bool
+}
+
+// StartStockCountInput counts the listed items, or every item with stock
+// when Items is empty.
+type StartStockCountInput struct {
+	CountedOn domain.BusinessDate
+	Notes     domain.Option[domain.NonEmptyText]
+	Items     []StockCountItemInput
+	CreatedAt domain.UTCInstant
+}
+
+// StockCountObservationInput sets the observed quantity of a line, or clears
+// it with None.
+type StockCountObservationInput struct {
+	LineID   domain.StockCountLineID
+	Observed domain.Option[domain.AtomicQuantity]
+}
+
+type RecordStockCountInput struct {
+	ID                domain.StockCountID
+	Observations      []StockCountObservationInput
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+type CancelStockCountInput struct {
+	ID                domain.StockCountID
+	ExpectedUpdatedAt domain.UTCInstant
+	UpdatedAt         domain.UTCInstant
+}
+
+// CloseStockCountInput closes an open count. The adjustment, if any, is
+// posted at ClosedAt, which also becomes the count's new version.
+type CloseStockCountInput struct {
+	ID                domain.StockCountID
+	IdempotencyKey    domain.IdempotencyKey
+	ExpectedUpdatedAt domain.UTCInstant
+	ClosedAt          domain.UTCInstant
+}
+
+type StockCountListFilter struct {
+	Status   domain.Option[domain.StockCountStatus]
+	After    domain.Option[domain.StockCountID]
+	PageSize int
+}
+
+type StockCountPage struct {
+	items []inventory.Count
+	next  domain.Option[domain.StockCountID]
+}
+
+func (p StockCountPage) Items() []inventory.Count {
+	items := make([]inventory.Count, len(p.items))
+	copy(items, p.items)
+	return items
+}
+
+func (p StockCountPage) Next() domain.Option[domain.StockCountID] { return p.next }
+
+func (s *Store) GetStockCount(ctx context.Context, id domain.StockCountID) (inventory.Count, error) {
+	if id.IsZero() {
+		return inventory.Count{}, domain.Invalid("stock_count_id", domain.ViolationRequired, "")
+	}
+	var count inventory.Count
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadStockCount(ctx, tx, id.Int64())
+		if err != nil {
+			return err
+		}
+		count = value
+		return nil
+	})
+	if err != nil {
+		return inventory.Count{}, classifyError("get stock count", err)
+	}
+	return count, nil
+}
+
+// ListStockCounts pages counts newest first, optionally by status.
+func (s *Store) ListStockCounts(ctx context.Context, filter StockCountListFilter) (StockCountPage, error) {
+	pageSize := filter.PageSize
+	if pageSize == 0 {
+		pageSize = stockCountDefaultPageSize
+	}
+	if pageSize < 1 || pageSize > stockCountMaximumPageSize {
+		return StockCountPage{}, domain.Invalid("page_size", domain.ViolationOutOfRange, "")
+	}
+	status := ""
+	if value, ok := filter.Status.Get(); ok {
+		if _, err := domain.ParseStockCountStatus(value.String()); err != nil {
+			return StockCountPage{}, err
+		}
+		status = value.String()
+	}
+	var afterID int64
+	if after, ok := filter.After.Get(); ok {
+		if after.IsZero() {
+			return StockCountPage{}, domain.Invalid("cursor", domain.ViolationInvalidFormat, "")
+		}
+		afterID = after.Int64()
+	}
+	var page StockCountPage
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id
+			FROM stock_counts
+			WHERE (? = '' OR status = ?)
+			  AND (? = 0 OR id < ?)
+			ORDER BY id DESC
+			LIMIT ?
+		`, status, status, afterID, afterID, pageSize+1)
+		if err != nil {
+			return err
+		}
+		ids, err := scanInt64Rows(rows)
+		if err != nil {
+			return err
+		}
+		hasMore := len(ids) > pageSize
+		if hasMore {
+			ids = ids[:pageSize]
+		}
+		items := make([]inventory.Count, 0, len(ids))
+		for _, id := range ids {
+			count, err := loadStockCount(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			items = append(items, count)
+		}
+		next := domain.None[domain.StockCountID]()
+		if hasMore && len(items) > 0 {
+			next = domain.Some(items[len(items)-1].ID())
+		}
+		page = StockCountPage{items: items, next: next}
+		return nil
+	})
+	if err != nil {
+		return StockCountPage{}, classifyError("list stock counts", err)
+	}
+	return page, nil
+}
+
+// StartStockCount freezes the expected stock of the counted items at the
+// highest posting sequence. Item lines expect the item's balance; lot lines
+// expect each lot's remaining quantity and a share of the item's value,
+// rounded cumulatively so the lots of an item add up to its balance value.
+// An item counted by lot that has no lot left is counted as a whole.
+func (s *Store) StartStockCount(ctx context.Context, input StartStockCountInput) (inventory.Count, error) {
+	if input.CountedOn.IsZero() {
+		return inventory.Count{}, domain.Invalid("counted_on", domain.ViolationRequired, "")
+	}
+	if input.CreatedAt.IsZero() {
+		return inventory.Count{}, domain.Invalid("created_at", domain.ViolationRequired, "")
+	}
+	seen := make(map[domain.ItemID]struct{}, len(input.Items))
+	for index, item := range input.Items {
+		field := fmt.Sprintf("items[%d].item_id", index)
+		if item.ItemID.IsZero() {
+			return inventory.Count{}, domain.Invalid(field, domain.ViolationRequired, "SCT-001")
+		}
+		if _, duplicate := seen[item.ItemID]; duplicate {
+			return inventory.Count{}, domain.Invalid(field, domain.ViolationDuplicate, "SCT-001")
+		}
+		seen[item.ItemID] = struct{}{}
+	}
+	var started inventory.Count
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		var open bool
+		if err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM stock_counts WHERE status = 'OPEN')
+		`).Scan(&open); err != nil {
+			return err
+		}
+		if open {
+			return fmt.Errorf("%w: another stock count is still open", domain.ErrConflict)
+		}
+		items := input.Items
+		if len(items) == 0 {
+			stocked, err := listStockedItems(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if len(stocked) == 0 {
+				return domain.Invalid("items", domain.ViolationRequired, "SCT-001")
+			}
+			items = stocked
+		}
+		var id int64
+		if err := tx.QueryRowContext(ctx, `
+			INSERT INTO stock_counts (
+				status, counted_on, frozen_posting_sequence, notes, created_at_ms, updated_at_ms
+			)
+			SELECT 'OPEN', ?, COALESCE(MAX(posting_sequence), 0), ?, ?, ?
+			FROM stock_documents
+			RETURNING id
+		`,
+			input.CountedOn.String(),
+			nullableText(input.Notes),
+			input.CreatedAt.UnixMilli(),
+			input.CreatedAt.UnixMilli(),
+		).Scan(&id); err != nil {
+			return err
+		}
+		for index, item := range items {
+			if err := insertStockCountItemLines(ctx, tx, id, item); err != nil {
+				return fmt.Errorf("item %d: %w", index+1, err)
+			}
+		}
+		value, err := loadStockCount(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		started = value
+		return nil
+	})
+	if err != nil {
+		return inventory.Count{}, classifyError("start stock count", err)
+	}
+	return started, nil
+}
+
+func (s *Store) RecordStockCount(ctx context.Context, input RecordStockCountInput) (inventory.Count, error) {
+	if input.ID.IsZero() {
+		return inventory.Count{}, domain.Invalid("stock_count_id", domain.ViolationRequired, "")
+	}
+	if len(input.Observations) == 0 {
+		return inventory.Count{}, domain.Invalid("observations", domain.ViolationRequired, "SCT-002")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return inventory.Count{}, err
+	}
+	var recorded inventory.Count
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadOpenStockCount(ctx, tx, input.ID.Int64(), input.ExpectedUpdatedAt)
+		if err != nil {
+			return err
+		}
+		lines := make(map[domain.StockCountLineID]inventory.CountLine, len(current.Lines()))
+		for _, line := range current.Lines() {
+			lines[line.ID()] = line
+		}
+		seen := make(map[domain.StockCountLineID]struct{}, len(input.Observations))
+		for index, observation := range input.Observations {
+			line, ok := lines[observation.LineID]
+			if !ok {
+				return fmt.Errorf("%w: stock count line %s is not on this count", domain.ErrInvalidReference, observation.LineID)
+			}
+			if _, duplicate := seen[observation.LineID]; duplicate {
+				return domain.Invalid(fmt.Sprintf("observations[%d].line_id", index), domain.ViolationDuplicate, "SCT-002")
+			}
+			seen[observation.LineID] = struct{}{}
+			var observed any
+			if quantity, ok := observation.Observed.Get(); ok {
+				valued, err := stockCountObservationValued(ctx, tx, line, quantity)
+				if err != nil {
+					return err
+				}
+				if !valued {
+					return domain.Invalid(fmt.Sprintf("observations[%d].observed_quantity_atomic", index), domain.ViolationInvariant, "SCT-005")
+				}
+				observed = quantity.Int64()
+			}
+			if _, err := tx.ExecContext(ctx, `
+				UPDATE stock_count_lines SET observed_quantity_atomic = ?
+				WHERE id = ? AND count_id = ?
+			`, observed, observation.LineID.Int64(), input.ID.Int64()); err != nil {
+				return err
+			}
+		}
+		if err := advanceStockCount(ctx, tx, input.ID.Int64(), "OPEN", input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+			return err
+		}
+		recorded, err = loadStockCount(ctx, tx, input.ID.Int64())
+		return err
+	})
+	if err != nil {
+		return inventory.Count{}, classifyError("record stock count", err)
+	}
+	return recorded, nil
+}
+
+// stockCountObservationValued reports whether closing could value the
+// observation. A surplus on a line that expected nothing has no frozen unit
+// value and is valued at the item's current average, which needs stock.
+func stockCountObservationValued(
+	ctx context.Context,
+	tx databaseWriteTx,
+	line inventory.CountLine,
+	observed domain.AtomicQuantity,
+) (bool, error) {
+	if line.ExpectedQuantity().Int64() > 0 || observed.Int64() == 0 {
+		return true, nil
+	}
+	var quantity int64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT quantity_atomic FROM inventory_balances WHERE item_id = ?
+	`, line.ItemID().Int64()).Scan(&quantity); err != nil {
+		return false, err
+	}
+	return quantity > 0, nil
+}
+
+// CancelStockCount abandons an open count without posting anything.
+func (s *Store) CancelStockCount(ctx context.Context, input CancelStockCountInput) (inventory.Count, error) {
+	if input.ID.IsZero() {
+		return inventory.Count{}, domain.Invalid("stock_count_id", domain.ViolationRequired, "")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+		return inventory.Count{}, err
+	}
+	var cancelled inventory.Count
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		if _, err := loadOpenStockCount(ctx, tx, input.ID.Int64(), input.ExpectedUpdatedAt); err != nil {
+			return err
+		}
+		if err := advanceStockCount(ctx, tx, input.ID.Int64(), "CANCELLED", input.ExpectedUpdatedAt, input.UpdatedAt); err != nil {
+			return err
+		}
+		value, err := loadStockCount(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		cancelled = value
+		return nil
+	})
+	if err != nil {
+		return inventory.Count{}, classifyError("cancel stock count", err)
+	}
+	return cancelled, nil
+}
+
+// CloseStockCount posts the count's variance postings as one PHYSICAL_COUNT
+// adjustment, each line with its expected and observed quantity, and closes
+// the count in the same transaction. Uncounted lines post nothing, and a
+// count without variances closes with no document. Variances apply to current
+// stock, so postings made during the count are kept. Retrying with the same
+// idempotency key returns the closed count.
+func (s *Store) CloseStockCount(
+	ctx context.Context,
+	input CloseStockCountInput,
+) (inventory.Count, domain.Option[PostedAdjustmentDocument], error) {
+	noDocument := domain.None[PostedAdjustmentDocument]()
+	if input.ID.IsZero() {
+		return inventory.Count{}, noDocument, domain.Invalid("stock_count_id", domain.ViolationRequired, "")
+	}
+	if input.IdempotencyKey.String() == "" {
+		return inventory.Count{}, noDocument, domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if err := validateVersionAdvance(input.ExpectedUpdatedAt, input.ClosedAt); err != nil {
+		return inventory.Count{}, noDocument, err
+	}
+	var closed inventory.Count
+	adjustment := noDocument
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		current, err := loadStockCount(ctx, tx, input.ID.Int64())
+		if err != nil {
+			return err
+		}
+		if key, ok := current.ClosingKey().Get(); ok && key == input.IdempotencyKey {
+			closed = current
+			if documentID, ok := current.AdjustmentDocumentID().Get(); ok {
+				document, err := loadPostedAdjustmentDocument(ctx, tx, documentID.Int64())
+				if err != nil {
+					return err
+				}
+				adjustment = domain.Some(document)
+			}
+			return nil
+		}
+		if !current.UpdatedAt().Equal(input.ExpectedUpdatedAt) {
+			return fmt.Errorf("%w: stock count version changed", domain.ErrStale)
+		}
+		if !current.IsOpen() {
+			return fmt.Errorf("%w: stock count is %s and can no longer be closed", domain.ErrConflict, current.Status())
+		}
+		var used bool
+		if err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS (SELECT 1 FROM stock_documents WHERE idempotency_key = ?)
+		`, input.IdempotencyKey.String()).Scan(&used); err != nil {
+			return err
+		}
+		if used {
+			return fmt.Errorf("%w: idempotency key belongs to another document", domain.ErrConflict)
+		}
+
+		var documentID any
+		variances, err := current.VariancePostings()
+		if err != nil {
+			return err
+		}
+		if len(variances) > 0 {
+			lines := make([]PostAdjustmentLineInput, 0, len(variances))
+			for index, line := range variances {
+				adjustmentLine, err := stockCountAdjustmentLine(ctx, tx, line)
+				if err != nil {
+					return fmt.Errorf("line %d: %w", index+1, err)
+				}
+				lines = append(lines, adjustmentLine)
+			}
+			posted, err := postAdjustmentTx(ctx, tx, PostAdjustmentInput{
+				IdempotencyKey: input.IdempotencyKey,
+				OccurredOn:     current.CountedOn(),
+				PostedAt:       input.ClosedAt,
+				Reason:         domain.ReasonPhysicalCount,
+				Notes:          current.Notes(),
+				Lines:          lines,
+			})
+			if err != nil {
+				return err
+			}
+			postedLines := posted.Lines()
+			if len(postedLines) != len(variances) {
+				return domain.ErrInvariant
+			}
+			for index, line := range variances {
+				observed, _ := line.ObservedQuantity().Get()
+				if _, err := tx.ExecContext(ctx, `
+					INSERT INTO adjustment_line_details (
+						line_id, expected_quantity_atomic, observed_quantity_atomic
+					) VALUES (?, ?, ?)
+				`, postedLines[index].ID().Int64(), line.ExpectedQuantity().Int64(), observed.Int64()); err != nil {
+					return err
+				}
+			}
+			documentID = posted.ID().Int64()
+			adjustment = domain.Some(posted)
+		}
+		result, err := tx.ExecContext(ctx, `
+			UPDATE stock_counts
+			SET status = 'CLOSED', closing_idempotency_key = ?, adjustment_document_id = ?, updated_at_ms = ?
+			WHERE id = ? AND updated_at_ms = ?
+		`,
+			input.IdempotencyKey.String(),
+			documentID,
+			input.ClosedAt.UnixMilli(),
+			input.ID.Int64(),
+			input.ExpectedUpdatedAt.UnixMilli(),
+		)
+		if err != nil {
+			return err
+		}
+		if affected, err := result.RowsAffected(); err != nil || affected != 1 {
+			return fmt.Errorf("%w: stock count version changed", domain.ErrStale)
+		}
+		closed, err = loadStockCount(ctx, tx, input.ID.Int64())
+		return err
+	})
+	if err != nil {
+		return inventory.Count{}, noDocument, classifyError("close stock count", err)
+	}
+	return closed, adjustment, nil
+}
+
+// stockCountAdjustmentLine turns a variance into an adjustment line in the
+// item's base unit. A surplus is valued at the frozen unit value when the
+// line expected stock and at the current average otherwise; a surplus on a
+// lot line becomes a new lot with the counted lot's code and expiry. A
+// shortage on a lot line consumes that lot, even if expired; an item-level
+// shortage consumes lots FEFO.
+func stockCountAdjustmentLine(ctx context.Context, tx databaseWriteTx, line inventory.CountLine) (PostAdjustmentLineInput, error) {
+	baseUnit, conversion, err := loadItemBaseUnit(ctx, tx, line.ItemID())
+	if err != nil {
+		return PostAdjustmentLineInput{}, err
+	}
+	variance, _ := line.Variance().Get()
+	adjustmentLine := PostAdjustmentLineInput{
+		ItemID:               line.ItemID(),
+		Direction:            domain.DirectionIn,
+		EnteredUnit:          baseUnit,
+		EnteredPackagingName: domain.None[domain.NonEmptyText](),
+		Conversion:           conversion,
+		InventoryValue:       domain.None[domain.InventoryValue](),
+		LotCode:              line.LotCode(),
+		ExpiresOn:            line.ExpiresOn(),
+		LotID:                domain.None[domain.InventoryLotID](),
+	}
+	if variance < 0 {
+		adjustmentLine.Direction = domain.DirectionOut
+		adjustmentLine.LotCode = domain.None[domain.NonEmptyText]()
+		adjustmentLine.ExpiresOn = domain.None[domain.BusinessDate]()
+		adjustmentLine.LotID = line.LotID()
+		variance = -variance
+	} else if value, ok := line.VarianceValue().Get(); ok && value > 0 {
+		inventoryValue, err := domain.NewInventoryValue(value)
+		if err != nil {
+			return PostAdjustmentLineInput{}, err
+		}
+		adjustmentLine.InventoryValue = domain.Some(inventoryValue)
+	}
+	if adjustmentLine.Quantity, err = domain.NewPositiveAtomicQuantity(variance); err != nil {
+		return PostAdjustmentLineInput{}, err
+	}
+	return adjustmentLine, nil
+}
+
+// listStockedItems returns every item holding stock, counted as a whole, in
+// name order.
+func listStockedItems(ctx context.Context, tx databaseWriteTx) ([]StockCountItemInput, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT balance.item_id
+		FROM inventory_balances balance
+		JOIN items item ON item.id = balance.item_id
+		WHERE balance.quantity_atomic > 0
+		ORDER BY item.normalized_name, item.id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := scanInt64Rows(rows)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]StockCountItemInput, 0, len(ids))
+	for _, id := range ids {
+		itemID, err := domain.NewItemID(id)
+		if err != nil {
+			return nil, corruptDataError("map stocked item", err)
+		}
+		items = append(items, StockCountItemInput{ItemID: itemID})
+	}
+	return items, nil
+}
+
+func insertStockCountItemLines(ctx context.Context, tx databaseWriteTx, countID int64, item StockCountItemInput) error {
+	var balance adjustmentBalance
+	err := tx.QueryRowContext(ctx, `
+		SELECT quantity_atomic, inventory_value_micro
+		FROM inventory_balances
+		WHERE item_id = ?
+	`, item.ItemID.Int64()).Scan(&balance.quantityAtomic, &balance.inventoryValueMicro)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: item %s has no inventory balance", domain.ErrInvalidReference, item.ItemID)
+	}
+	if err != nil {
+		return err
+	}
+	if item.ByLot {
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id, remaining_quantity_atomic
+			FROM (
+				SELECT
+					lot.id,
+					lot.expires_on,
+					lot.initial_quantity_atomic
+						- COALESCE(SUM(
+							CASE WHEN allocation.restores_allocation_id IS NULL
+								THEN allocation.quantity_atomic ELSE 0 END
+						), 0)
+						+ COALESCE(SUM(
+							CASE WHEN allocation.restores_allocation_id IS NOT NULL
+								THEN allocation.quantity_atomic ELSE 0 END
+						), 0) AS remaining_quantity_atomic
+				FROM inventory_lots lot
+				LEFT JOIN lot_allocations allocation ON allocation.lot_id = lot.id
+				WHERE lot.item_id = ?
+				GROUP BY lot.id, lot.expires_on, lot.initial_quantity_atomic
+			)
+			WHERE remaining_quantity_atomic > 0
+			ORDER BY expires_on IS NULL, expires_on, id
+		`, item.ItemID.Int64())
+		if err != nil {
+			return err
+		}
+		type stockCountLot struct{ id, remaining int64 }
+		var lots []stockCountLot
+		for rows.Next() {
+			var lot stockCountLot
+			if err := rows.Scan(&lot.id, &lot.remaining); err != nil {
+				rows.Close()
+				return err
+			}
+			lots = append(lots, lot)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		if len(lots) > 0 {
+			var cumulativeQuantity, allocatedValue int64
+			for _, lot := range lots {
+				cumulativeQuantity += lot.remaining
+				cumulativeValue, err := weightedAverageValue(balance.inventoryValueMicro, balance.quantityAtomic, cumulativeQuantity)
+				if err != nil {
+					return err
+				}
+				if _, err := tx.ExecContext(ctx, `
+					INSERT INTO stock_count_lines (
+						count_id, item_id, lot_id, expected_quantity_atomic, expected_value_micro
+					) VALUES (?, ?, ?, ?, ?)
+				`,
+					countID,
+					item.ItemID.Int64(),
+					lot.id,
+					lot.remaining,
+					cumulativeValue.Int64()-allocatedValue,
+				); err != nil {
+					return err
+				}
+				allocatedValue = cumulativeValue.Int64()
+			}
+			return nil
+		}
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO stock_count_lines (
+			count_id, item_id, lot_id, expected_quantity_atomic, expected_value_micro
+		) VALUES (?, ?, NULL, ?, ?)
+	`, countID, item.ItemID.Int64(), balance.quantityAtomic, balance.inventoryValueMicro)
+	return err
+}
+
+func loadOpenStockCount(
+	ctx context.Context,
+	tx databaseWriteTx,
+	id int64,
+	expectedUpdatedAt domain.UTCInstant,
+) (inventory.Count, error) {
+	current, err := loadStockCount(ctx, tx, id)
+	if err != nil {
+		return inventory.Count{}, err
+	}
+	if !current.UpdatedAt().Equal(expectedUpdatedAt) {
+		return inventory.Count{}, fmt.Errorf("%w: stock count version changed", domain.ErrStale)
+	}
+	if !current.IsOpen() {
+		return inventory.Count{}, fmt.Errorf("%w: stock count is %s and can no longer change", domain.ErrConflict, current.Status())
+	}
+	return current, nil
+}
+
+func advanceStockCount(
+	ctx context.Context,
+	tx databaseWriteTx,
+	id int64,
+	status string,
+	expectedUpdatedAt domain.UTCInstant,
+	updatedAt domain.UTCInstant,
+) error {
+	result, err := tx.ExecContext(ctx, `
+		UPDATE stock_counts SET status = ?, updated_at_ms = ?
+		WHERE id = ? AND updated_at_ms = ?
+	`, status, updatedAt.UnixMilli(), id, expectedUpdatedAt.UnixMilli())
+	if err != nil {
+		return err
+	}
+	if affected, err := result.RowsAffected(); err != nil || affected != 1 {
+		return fmt.Errorf("%w: stock count version changed", domain.ErrStale)
+	}
+	return nil
+}
+
+func loadStockCount(ctx context.Context, tx databaseWriteTx, id int64) (inventory.Count, error) {
+	var row stockCountRow
+	if err := tx.QueryRowContext(ctx, `
+		SELECT id, status, counted_on, frozen_posting_sequence, notes,
+		       closing_idempotency_key, adjustment_document_id, created_at_ms, updated_at_ms
+		FROM stock_counts
+		WHERE id = ?
+	`, id).Scan(
+		&row.id,
+		&row.status,
+		&row.countedOn,
+		&row.frozenPostingSequence,
+		&row.notes,
+		&row.closingKey,
+		&row.adjustmentDocumentID,
+		&row.createdAtMS,
+		&row.updatedAtMS,
+	); err != nil {
+		return inventory.Count{}, err
+	}
+	lines, err := loadStockCountLines(ctx, tx, id)
+	if err != nil {
+		return inventory.Count{}, err
+	}
+	count, err := mapStockCount(row, lines)
+	if err != nil {
+		return inventory.Count{}, corruptDataError("map stock count", err)
+	}
+	return count, nil
+}
+
+type stockCountRow struct {
+	id, frozenPostingSequence, createdAtMS, updatedAtMS int64
+	status, countedOn                                   string
+	notes, closingKey                                   sql.NullString
+	adjustmentDocumentID                                sql.NullInt64
+}
+
+// loadStockCountLines flags a line as changed when a document other than the
+// count's own adjustment moved its item after the freeze and before the
+// count was closed or cancelled.
+func loadStockCountLines(ctx context.Context, tx databaseWriteTx, countID int64) ([]inventory.CountLine, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT line.id, line.item_id, line.lot_id, lot.lot_code, lot.expires_on,
+		       line.expected_quantity_atomic, line.expected_value_micro,
+		       line.observed_quantity_atomic,
+		       EXISTS (
+		           SELECT 1
+		           FROM stock_document_lines moved
+		           JOIN stock_documents document ON document.id = moved.document_id
+		           WHERE moved.item_id = line.item_id
+		             AND document.posting_sequence > stock_count.frozen_posting_sequence
+		             AND document.id IS NOT stock_count.adjustment_document_id
+		             AND (stock_count.status = 'OPEN' OR document.posted_at_ms <= stock_count.updated_at_ms)
+		       )
+		FROM stock_count_lines line
+		JOIN stock_counts stock_count ON stock_count.id = line.count_id
+		LEFT JOIN inventory_lots lot ON lot.id = line.lot_id
+		WHERE line.count_id = ?
+		ORDER BY line.id
+	`, countID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []inventory.CountLine
+	for rows.Next() {
+		var row stockCountLineRow
+		if err := rows.Scan(
+			&row.id,
+			&row.itemID,
+			&row.lotID,
+			&row.lotCode,
+			&row.expiresOn,
+			&row.expectedQuantityAtomic,
+			&row.expectedValueMicro,
+			&row.observedQuantityAtomic,
+			&row.changed,
+		); err != nil {
+			return nil, err
+		}
+		line, err := mapStockCountLine(countID, row)
+		if err != nil {
+			return nil, corruptDataError("map stock count line", err)
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+type stockCountLineRow struct {
+	id, itemID, expectedQuantityAtomic, expectedValueMicro int64
+	lotID, observedQuantityAtomic                          sql.NullInt64
+	lotCode, expiresOn                                     sql.NullString
+	changed                                                bool
+}
+
+func mapStockCount(row stockCountRow, lines []inventory.CountLine) (inventory.Count, error) {
+	id, err := domain.NewStockCountID(row.id)
+	if err != nil {
+		return inventory.Count{}, err
+	}
+	status, err := domain.ParseStockCountStatus(row.status)
+	if err != nil {
+		return inventory.Count{}, err
+	}
+	countedOn, err := domain.ParseBusinessDate(row.countedOn)
+	if err != nil {
+		return inventory.Count{}, err
+	}
+	notes, err := optionalNonEmptyText(row.notes)
+	if err != nil {
+		return inventory.Count{}, err
+	}
+	closingKey := domain.None[domain.IdempotencyKey]()
+	if row.closingKey.Valid {
+		key, err := domain.NewIdempotencyKey(row.closingKey.String)
+		if err != nil {
+			return inventory.Count{}, err
+		}
+		closingKey = domain.Some(key)
+	}
+	adjustmentDocumentID := domain.None[domain.StockDocumentID]()
+	if row.adjustmentDocumentID.Valid {
+		documentID, err := domain.NewStockDocumentID(row.adjustmentDocumentID.Int64)
+		if err != nil {
+			return inventory.Count{}, err
+		}
+		adjustmentDocumentID = domain.Some(documentID)
+	}
+	createdAt, err := domain.UTCInstantFromUnixMilli(row.createdAtMS)
+	if err != nil {
+		return inventory.Count{}, err
+	}
+	updatedAt, err := domain.UTCInstantFromUnixMilli(row.updatedAtMS)
+	if err != nil {
+		return inventory.Count{}, err
+	}
+	return inventory.NewCount(inventory.CountParams{
+		ID: id, Status: status, CountedOn: countedOn,
+		FrozenPostingSequence: row.frozenPostingSequence, Notes: notes,
+		ClosingKey: closingKey, AdjustmentDocumentID: adjustmentDocumentID,
+		CreatedAt: createdAt, UpdatedAt: updatedAt, Lines: lines,
+	})
+}
+
+func mapStockCountLine(countID int64, row stockCountLineRow) (inventory.CountLine, error) {
+	id, err := domain.NewStockCountLineID(row.id)
+	if err != nil {
+		return inventory.CountLine{}, err
+	}
+	count, err := domain.NewStockCountID(countID)
+	if err != nil {
+		return inventory.CountLine{}, err
+	}
+	itemID, err := domain.NewItemID(row.itemID)
+	if err != nil {
+		return inventory.CountLine{}, err
+	}
+	lotID := domain.None[domain.InventoryLotID]()
+	if row.lotID.Valid {
+		value, err := domain.NewInventoryLotID(row.lotID.Int64)
+		if err != nil {
+			return inventory.CountLine{}, err
+		}
+		lotID = domain.Some(value)
+	}
+	lotCode, err := optionalNonEmptyText(row.lotCode)
+	if err != nil {
+		return inventory.CountLine{}, err
+	}
+	expiresOn, err := optionalBusinessDate(row.expiresOn)
+	if err != nil {
+		return inventory.CountLine{}, err
+	}
+	expectedQuantity, err := domain.NewAtomicQuantity(row.expectedQuantityAtomic)
+	if err != nil {
+		return inventory.CountLine{}, err
+	}
+	expectedValue, err := domain.NewInventoryValue(row.expectedValueMicro)
+	if err != nil {
+		return inventory.CountLine{}, err
+	}
+	observed := domain.None[domain.AtomicQuantity]()
+	if row.observedQuantityAtomic.Valid {
+		value, err := domain.NewAtomicQuantity(row.observedQuantityAtomic.Int64)
+		if err != nil {
+			return inventory.CountLine{}, err
+		}
+		observed = domain.Some(value)
+	}
+	return inventory.NewCountLine(inventory.CountLineParams{
+		ID: id, CountID: count, ItemID: itemID, LotID: lotID, LotCode: lotCode,
+		ExpiresOn: expiresOn, ExpectedQuantity: expectedQuantity, ExpectedValue: expectedValue,
+		ObservedQuantity: observed, ChangedSinceFreeze: row.changed,
+	})
+}
@@ -0,0 +1,239 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestStockCountStoreFreezesCountsAndPostsVariancesOnClose(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "stock-counts.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	flour := createSaleTestItem(t, store, "Flour", false)
+	sugar := createSaleTestItem(t, store, "Sugar", false)
+	salt := createSaleTestItem(t, store, "Salt", false)
+	postAdjustmentTestPurchase(t, store, flour, "flour-1", "FLOUR-1", "2026-08-01", 100, 2_000)
+	postAdjustmentTestPurchase(t, store, flour, "flour-2", "FLOUR-2", "2026-12-31", 50, 1_500)
+	postAdjustmentTestPurchase(t, store, sugar, "sugar-1", "SUGAR-1", "2026-12-31", 30, 900)
+	postAdjustmentTestPurchase(t, store, salt, "salt-1", "SALT-1", "2026-12-31", 20, 200)
+
+	started, err := store.StartStockCount(ctx, StartStockCountInput{
+		CountedOn: mustPurchaseDate(t, "2026-10-18"),
+		Items:     []StockCountItemInput{{ItemID: flour, ByLot: true}, {ItemID: sugar}, {ItemID: salt}},
+		CreatedAt: mustCatalogInstant(t, 10_000),
+	})
+	if err != nil {
+		t.Fatalf("start stock count: %v", err)
+	}
+	lines := started.Lines()
+	if started.FrozenPostingSequence() != 4 || len(lines) != 4 || lines[2].LotID().IsSome() {
+		t.Fatalf("started count = %#v", started)
+	}
+	var flourValue int64
+	if err := store.database.QueryRowContext(ctx, `
+		SELECT inventory_value_micro FROM inventory_balances WHERE item_id = ?
+	`, flour.Int64()).Scan(&flourValue); err != nil {
+		t.Fatal(err)
+	}
+	if lines[0].ExpectedQuantity().Int64() != 100 || lines[0].ExpectedValue().Int64()+lines[1].ExpectedValue().Int64() != flourValue {
+		t.Fatalf("lot lines = %#v, flour value %d", lines[:2], flourValue)
+	}
+	if code, _ := lines[1].LotCode().Get(); code.String() != "FLOUR-2" {
+		t.Fatalf("second lot code = %q", code.String())
+	}
+	if _, err := store.StartStockCount(ctx, StartStockCountInput{
+		CountedOn: mustPurchaseDate(t, "2026-10-18"), CreatedAt: mustCatalogInstant(t, 10_000),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second open count error = %v, want conflict", err)
+	}
+
+	postAdjustmentTestPurchase(t, store, sugar, "sugar-2", "SUGAR-2", "2026-12-31", 10, 300)
+	observe := func(line int, quantity int64) StockCountObservationInput {
+		return StockCountObservationInput{
+			LineID:   lines[line].ID(),
+			Observed: domain.Some(mustPurchaseQuantity(t, quantity)),
+		}
+	}
+	recorded, err := store.RecordStockCount(ctx, RecordStockCountInput{
+		ID:                started.ID(),
+		Observations:      []StockCountObservationInput{observe(0, 90), observe(1, 45), observe(2, 33)},
+		ExpectedUpdatedAt: started.UpdatedAt(),
+		UpdatedAt:         mustCatalogInstant(t, 11_000),
+	})
+	if err != nil {
+		t.Fatalf("record stock count: %v", err)
+	}
+	recordedLines := recorded.Lines()
+	if recordedLines[0].ChangedSinceFreeze() || !recordedLines[2].ChangedSinceFreeze() || recordedLines[3].IsCounted() {
+		t.Fatalf("recorded lines = %#v", recordedLines)
+	}
+	if _, err := store.RecordStockCount(ctx, RecordStockCountInput{
+		ID: started.ID(), Observations: []StockCountObservationInput{observe(3, 20)},
+		ExpectedUpdatedAt: started.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 12_000),
+	}); !errors.Is(err, domain.ErrStale) {
+		t.Fatalf("stale record error = %v, want stale", err)
+	}
+
+	closeInput := CloseStockCountInput{
+		ID:                started.ID(),
+		IdempotencyKey:    mustPurchaseIdempotencyKey(t, "count-1"),
+		ExpectedUpdatedAt: recorded.UpdatedAt(),
+		ClosedAt:          mustCatalogInstant(t, 12_000),
+	}
+	closed, adjustment, err := store.CloseStockCount(ctx, closeInput)
+	if err != nil {
+		t.Fatalf("close stock count: %v", err)
+	}
+	posted, ok := adjustment.Get()
+	if !ok || closed.Status() != domain.StockCountClosed || posted.Reason() != domain.ReasonPhysicalCount {
+		t.Fatalf("closed count = %#v, adjustment = %#v", closed, adjustment)
+	}
+	if documentID, _ := closed.AdjustmentDocumentID().Get(); documentID != posted.ID() {
+		t.Fatalf("closed count document = %v, want %v", documentID, posted.ID())
+	}
+	postedLines := posted.Lines()
+	if len(postedLines) != 3 || postedLines[0].Direction() != domain.DirectionOut || postedLines[0].Quantity().Int64() != 10 {
+		t.Fatalf("posted lines = %#v", postedLines)
+	}
+	for index := range 2 {
+		allocations := postedLines[index].Allocations()
+		if len(allocations) != 1 || allocations[0].LotID() != mustStockCountLot(t, lines[index]) {
+			t.Fatalf("shortage %d allocations = %#v", index, allocations)
+		}
+	}
+	surplusValue, _ := recordedLines[2].VarianceValue().Get()
+	if postedLines[2].Direction() != domain.DirectionIn || postedLines[2].InventoryValue().Int64() != surplusValue {
+		t.Fatalf("surplus line = %#v, want value %d", postedLines[2], surplusValue)
+	}
+	var details int
+	if err := store.database.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM adjustment_line_details detail
+		JOIN stock_document_lines line ON line.id = detail.line_id
+		WHERE line.document_id = ?
+	`, posted.ID().Int64()).Scan(&details); err != nil || details != 3 {
+		t.Fatalf("physical-count details = %d, %v", details, err)
+	}
+
+	replayed, replayedAdjustment, err := store.CloseStockCount(ctx, closeInput)
+	if replayedDocument, ok := replayedAdjustment.Get(); err != nil || !ok || replayedDocument.ID() != posted.ID() || !replayed.UpdatedAt().Equal(closed.UpdatedAt()) {
+		t.Fatalf("replayed close = %#v, %#v, %v", replayed, replayedAdjustment, err)
+	}
+	if _, err := store.CancelStockCount(ctx, CancelStockCountInput{
+		ID: closed.ID(), ExpectedUpdatedAt: closed.UpdatedAt(), UpdatedAt: mustCatalogInstant(t, 13_000),
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("cancel closed count error = %v, want conflict", err)
+	}
+
+	matching, err := store.StartStockCount(ctx, StartStockCountInput{
+		CountedOn: mustPurchaseDate(t, "2026-10-19"), CreatedAt: mustCatalogInstant(t, 14_000),
+	})
+	if err != nil || len(matching.Lines()) != 3 {
+		t.Fatalf("count of every stocked item = %#v, %v", matching, err)
+	}
+	closedWithoutVariance, adjustment, err := store.CloseStockCount(ctx, CloseStockCountInput{
+		ID: matching.ID(), IdempotencyKey: mustPurchaseIdempotencyKey(t, "count-2"),
+		ExpectedUpdatedAt: matching.UpdatedAt(), ClosedAt: mustCatalogInstant(t, 15_000),
+	})
+	if err != nil || adjustment.IsSome() || closedWithoutVariance.Status() != domain.StockCountClosed {
+		t.Fatalf("close uncounted count = %#v, %#v, %v", closedWithoutVariance, adjustment, err)
+	}
+	page, err := store.ListStockCounts(ctx, StockCountListFilter{Status: domain.Some(domain.StockCountClosed)})
+	if err != nil || len(page.Items()) != 2 || page.Items()[0].ID() != matching.ID() {
+		t.Fatalf("closed counts = %#v, %v", page, err)
+	}
+}
+
+func TestStockCountStoreRefusesSurplusItCannotValue(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "stock-count-surplus.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	flour := createSaleTestItem(t, store, "Flour", false)
+	salt := createSaleTestItem(t, store, "Salt", false)
+	postAdjustmentTestPurchase(t, store, flour, "flour-1", "FLOUR-1", "2026-12-31", 100, 2_000)
+
+	started, err := store.StartStockCount(ctx, StartStockCountInput{
+		CountedOn: mustPurchaseDate(t, "2026-10-18"),
+		Items:     []StockCountItemInput{{ItemID: flour}, {ItemID: salt}},
+		CreatedAt: mustCatalogInstant(t, 10_000),
+	})
+	if err != nil {
+		t.Fatalf("start stock count: %v", err)
+	}
+	lines := started.Lines()
+	if len(lines) != 2 || lines[1].ItemID() != salt || lines[1].ExpectedQuantity().Int64() != 0 {
+		t.Fatalf("started count = %#v", started)
+	}
+	observe := func(line int, quantity int64) StockCountObservationInput {
+		return StockCountObservationInput{
+			LineID:   lines[line].ID(),
+			Observed: domain.Some(mustPurchaseQuantity(t, quantity)),
+		}
+	}
+	var validation *domain.ValidationError
+	if _, err := store.RecordStockCount(ctx, RecordStockCountInput{
+		ID:                started.ID(),
+		Observations:      []StockCountObservationInput{observe(0, 100), observe(1, 5)},
+		ExpectedUpdatedAt: started.UpdatedAt(),
+		UpdatedAt:         mustCatalogInstant(t, 11_000),
+	}); !errors.As(err, &validation) || validation.Violations()[0].InvariantID != "SCT-005" ||
+		validation.Violations()[0].Field != "observations[1].observed_quantity_atomic" {
+		t.Fatalf("unvalued surplus error = %v, want SCT-005", err)
+	}
+	unchanged, err := store.GetStockCount(ctx, started.ID())
+	if err != nil || unchanged.Lines()[0].IsCounted() || !unchanged.UpdatedAt().Equal(started.UpdatedAt()) {
+		t.Fatalf("count after refused record = %#v, %v", unchanged, err)
+	}
+
+	postAdjustmentTestPurchase(t, store, salt, "salt-1", "SALT-1", "2026-12-31", 20, 200)
+	recorded, err := store.RecordStockCount(ctx, RecordStockCountInput{
+		ID:                started.ID(),
+		Observations:      []StockCountObservationInput{observe(0, 100), observe(1, 5)},
+		ExpectedUpdatedAt: started.UpdatedAt(),
+		UpdatedAt:         mustCatalogInstant(t, 12_000),
+	})
+	if err != nil {
+		t.Fatalf("record surplus with stock to value it: %v", err)
+	}
+	var saltQuantity, saltValue int64
+	if err := store.database.QueryRowContext(ctx, `
+		SELECT quantity_atomic, inventory_value_micro FROM inventory_balances WHERE item_id = ?
+	`, salt.Int64()).Scan(&saltQuantity, &saltValue); err != nil {
+		t.Fatal(err)
+	}
+	wantValue, err := weightedAverageValue(saltValue, saltQuantity, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, adjustment, err := store.CloseStockCount(ctx, CloseStockCountInput{
+		ID:                started.ID(),
+		IdempotencyKey:    mustPurchaseIdempotencyKey(t, "count-1"),
+		ExpectedUpdatedAt: recorded.UpdatedAt(),
+		ClosedAt:          mustCatalogInstant(t, 13_000),
+	})
+	if err != nil {
+		t.Fatalf("close stock count: %v", err)
+	}
+	posted, ok := adjustment.Get()
+	if !ok || len(posted.Lines()) != 1 {
+		t.Fatalf("adjustment = %#v", adjustment)
+	}
+	surplus := posted.Lines()[0]
+	if surplus.ItemID() != salt || surplus.Direction() != domain.DirectionIn || surplus.Quantity().Int64() != 5 ||
+		surplus.InventoryValue() != wantValue {
+		t.Fatalf("surplus line = %#v, want value %d", surplus, wantValue.Int64())
+	}
+}
+
+func mustStockCountLot(t *testing.T, line interface {
+	LotID() domain.Option[domain.InventoryLotID]
+}) domain.InventoryLotID {
+	t.Helper()
+	lotID, ok := line.LotID().Get()
+	if !ok {
+		t.Fatal("count line has no lot")
+	}
+	return lotID
+}
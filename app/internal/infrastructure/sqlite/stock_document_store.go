@@ -0,0 +1,249 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/infrastructure/sqlite/sqlcgen"
+)
+
+const (
+	stockDocumentDefaultPageSize = 50
+	stockDocumentMaximumPageSize = 100
+)
+
+type StockDocumentCursor struct {
+	PostingSequence domain.PostingSequence
+	ID              domain.StockDocumentID
+}
+
+// StockDocumentListFilter narrows the ledger across every document kind. The
+// occurred-on range is inclusive; NotesSearch matches a substring of notes.
+type StockDocumentListFilter struct {
+	Kind           domain.Option[domain.DocumentKind]
+	Reason         domain.Option[domain.DocumentReason]
+	CounterpartyID domain.Option[domain.CounterpartyID]
+	FromOccurredOn domain.Option[domain.BusinessDate]
+	ToOccurredOn   domain.Option[domain.BusinessDate]
+	Reversal       domain.ReversalFilter
+	NotesSearch    domain.Option[domain.NonEmptyText]
+	After          domain.Option[StockDocumentCursor]
+	PageSize       int
+}
+
+// StockDocumentSummary is the kind-independent header of a posted document
+// with its line totals. CommercialTotal is present only when at least one
+// line carries a commercial total.
+type StockDocumentSummary struct {
+	ID                   domain.StockDocumentID
+	Kind                 domain.DocumentKind
+	IdempotencyKey       domain.IdempotencyKey
+	PostingSequence      domain.PostingSequence
+	CounterpartyID       domain.Option[domain.CounterpartyID]
+	OccurredOn           domain.BusinessDate
+	PostedAt             domain.UTCInstant
+	Currency             domain.Currency
+	Reason               domain.Option[domain.DocumentReason]
+	Notes                domain.Option[domain.NonEmptyText]
+	LineCount            int64
+	InventoryValueIn     domain.InventoryValue
+	InventoryValueOut    domain.InventoryValue
+	CommercialTotal      domain.Option[domain.MinorAmount]
+	ReversesDocumentID   domain.Option[domain.StockDocumentID]
+	ReversedByDocumentID domain.Option[domain.StockDocumentID]
+}
+
+type StockDocumentPage struct {
+	items []StockDocumentSummary
+	next  domain.Option[StockDocumentCursor]
+}
+
+func (p StockDocumentPage) Items() []StockDocumentSummary {
+	items := make([]StockDocumentSummary, len(p.items))
+	copy(items, p.items)
+	return items
+}
+
+func (p StockDocumentPage) Next() domain.Option[StockDocumentCursor] { return p.next }
+
+func (s *Store) ListStockDocuments(ctx context.Context, filter StockDocumentListFilter) (StockDocumentPage, error) {
+	params, pageSize, err := stockDocumentListQuery(filter)
+	if err != nil {
+		return StockDocumentPage{}, err
+	}
+	var rows []sqlcgen.ListStockDocumentSummariesRow
+	err = s.withReadQueries(ctx, "list stock documents", func(queries *sqlcgen.Queries) error {
+		var err error
+		rows, err = queries.ListStockDocumentSummaries(ctx, params)
+		return err
+	})
+	if err != nil {
+		return StockDocumentPage{}, err
+	}
+	hasMore := len(rows) > pageSize
+	if hasMore {
+		rows = rows[:pageSize]
+	}
+	items := make([]StockDocumentSummary, 0, len(rows))
+	for _, row := range rows {
+		summary, err := mapStockDocumentSummary(row)
+		if err != nil {
+			return StockDocumentPage{}, corruptDataError("map stock document summary", err)
+		}
+		items = append(items, summary)
+	}
+	next := domain.None[StockDocumentCursor]()
+	if hasMore && len(items) > 0 {
+		last := items[len(items)-1]
+		next = domain.Some(StockDocumentCursor{PostingSequence: last.PostingSequence, ID: last.ID})
+	}
+	return StockDocumentPage{items: items, next: next}, nil
+}
+
+func stockDocumentListQuery(filter StockDocumentListFilter) (sqlcgen.ListStockDocumentSummariesParams, int, error) {
+	pageSize := filter.PageSize
+	if pageSize == 0 {
+		pageSize = stockDocumentDefaultPageSize
+	}
+	if pageSize < 1 || pageSize > stockDocumentMaximumPageSize {
+		return sqlcgen.ListStockDocumentSummariesParams{}, 0, domain.Invalid("page_size", domain.ViolationOutOfRange, "")
+	}
+	params := sqlcgen.ListStockDocumentSummariesParams{LimitCount: int64(pageSize) + 1}
+	if kind, ok := filter.Kind.Get(); ok {
+		if _, err := domain.ParseDocumentKind(kind.String()); err != nil {
+			return sqlcgen.ListStockDocumentSummariesParams{}, 0, err
+		}
+		params.KindFilter = kind.String()
+	}
+	if reason, ok := filter.Reason.Get(); ok {
+		if kind, hasKind := filter.Kind.Get(); hasKind {
+			if _, err := domain.ParseDocumentReason(kind, reason.String()); err != nil {
+				return sqlcgen.ListStockDocumentSummariesParams{}, 0, err
+			}
+		}
+		params.ReasonFilter = reason.String()
+	}
+	if counterpartyID, ok := filter.CounterpartyID.Get(); ok {
+		if counterpartyID.IsZero() {
+			return sqlcgen.ListStockDocumentSummariesParams{}, 0, domain.Invalid("counterparty_id", domain.ViolationRequired, "")
+		}
+		params.CounterpartyID = counterpartyID.Int64()
+	}
+	from, hasFrom := filter.FromOccurredOn.Get()
+	to, hasTo := filter.ToOccurredOn.Get()
+	if hasFrom && hasTo && to.Before(from) {
+		return sqlcgen.ListStockDocumentSummariesParams{}, 0, domain.Invalid("to_occurred_on", domain.ViolationOutOfRange, "")
+	}
+	if hasFrom {
+		params.FromOccurredOn = from.String()
+	}
+	if hasTo {
+		params.ToOccurredOn = to.String()
+	}
+	switch filter.Reversal {
+	case "", domain.ReversalFilterAll:
+		params.ReversalFilter = 0
+	case domain.ReversalFilterReversed:
+		params.ReversalFilter = 1
+	case domain.ReversalFilterNotReversed:
+		params.ReversalFilter = 2
+	default:
+		return sqlcgen.ListStockDocumentSummariesParams{}, 0, domain.Invalid("reversal_filter", domain.ViolationInvalidEnum, "")
+	}
+	if search, ok := filter.NotesSearch.Get(); ok {
+		params.SearchText = search.String()
+	}
+	if cursor, ok := filter.After.Get(); ok {
+		if cursor.PostingSequence.IsZero() || cursor.ID.IsZero() {
+			return sqlcgen.ListStockDocumentSummariesParams{}, 0, domain.Invalid("cursor", domain.ViolationInvalidFormat, "")
+		}
+		params.AfterPostingSequence = cursor.PostingSequence.Int64()
+		params.AfterID = cursor.ID.Int64()
+	}
+	return params, pageSize, nil
+}
+
+func mapStockDocumentSummary(row sqlcgen.ListStockDocumentSummariesRow) (StockDocumentSummary, error) {
+	id, err := domain.NewStockDocumentID(row.ID)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	kind, err := domain.ParseDocumentKind(row.Kind)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	idempotencyKey, err := domain.NewIdempotencyKey(row.IdempotencyKey)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	postingSequence, err := domain.NewPostingSequence(row.PostingSequence)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	counterpartyID, err := optionalCounterpartyID(row.CounterpartyID)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	occurredOn, err := domain.ParseBusinessDate(row.OccurredOn)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	postedAt, err := domain.UTCInstantFromUnixMilli(row.PostedAtMs)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	currency, err := domain.RestoreCurrency(row.CurrencyCode, int(row.CurrencyMinorDigits))
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	reason, err := optionalDocumentReason(kind, row.ReasonCode)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	notes, err := optionalNonEmptyText(row.Notes)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	valueIn, err := domain.NewInventoryValue(row.InventoryValueInMicro)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	valueOut, err := domain.NewInventoryValue(row.InventoryValueOutMicro)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	commercialTotal := domain.None[domain.MinorAmount]()
+	if row.CommercialLineCount > 0 {
+		total, err := domain.NewMinorAmount(row.CommercialTotalMinor)
+		if err != nil {
+			return StockDocumentSummary{}, err
+		}
+		commercialTotal = domain.Some(total)
+	}
+	reverses, err := optionalStockDocumentID(row.ReversesDocumentID)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	reversedBy, err := optionalStockDocumentID(row.ReversedByDocumentID)
+	if err != nil {
+		return StockDocumentSummary{}, err
+	}
+	return StockDocumentSummary{
+		ID:                   id,
+		Kind:                 kind,
+		IdempotencyKey:       idempotencyKey,
+		PostingSequence:      postingSequence,
+		CounterpartyID:       counterpartyID,
+		OccurredOn:           occurredOn,
+		PostedAt:             postedAt,
+		Currency:             currency,
+		Reason:               reason,
+		Notes:                notes,
+		LineCount:            row.LineCount,
+		InventoryValueIn:     valueIn,
+		InventoryValueOut:    valueOut,
+		CommercialTotal:      commercialTotal,
+		ReversesDocumentID:   reverses,
+		ReversedByDocumentID: reversedBy,
+	}, nil
+}
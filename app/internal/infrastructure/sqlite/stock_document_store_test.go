@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestStockDocumentStoreListsEveryKindWithSummariesAndFilters(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "documents.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	itemID := createSaleTestItem(t, store, "Brigadeiro", true)
+	customer, err := store.CreateCounterparty(ctx, CreateCounterpartyInput{
+		Name: counterpartyName(t, "Ana"), Roles: counterpartyRoles(t, domain.RoleCustomer),
+		CreatedAt: counterpartyInstant(t, 1_000),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	purchase := postAdjustmentTestPurchase(t, store, itemID, "documents-purchase", "BRIG-1", "2026-12-31", 100, 1_000)
+
+	saleInput := saleInputFixture(t, itemID, "documents-sale", 20, 500)
+	saleInput.CounterpartyID = domain.Some(customer.ID())
+	saleInput.Notes = domain.Some(counterpartyText(t, "Encomenda de aniversário"))
+	sale, err := store.PostSale(ctx, saleInput)
+	if err != nil {
+		t.Fatalf("post sale: %v", err)
+	}
+	reversal, err := store.PostReversal(ctx, PostReversalInput{
+		IdempotencyKey:   mustPurchaseIdempotencyKey(t, "documents-reversal"),
+		TargetDocumentID: sale.ID(),
+		OccurredOn:       mustPurchaseDate(t, "2026-07-16"),
+		PostedAt:         mustCatalogInstant(t, 6_000),
+	})
+	if err != nil {
+		t.Fatalf("reverse sale: %v", err)
+	}
+
+	page, err := store.ListStockDocuments(ctx, StockDocumentListFilter{PageSize: 2})
+	if err != nil {
+		t.Fatalf("list first page: %v", err)
+	}
+	items := page.Items()
+	cursor, ok := page.Next().Get()
+	if len(items) != 2 || items[0].ID != reversal.ID() || items[1].ID != sale.ID() || !ok || cursor.ID != sale.ID() {
+		t.Fatalf("first page = items %#v next %#v", items, page.Next())
+	}
+	reversalSummary, saleSummary := items[0], items[1]
+	if reversalSummary.Kind != domain.DocumentReversal || reversalSummary.ReversesDocumentID != domain.Some(sale.ID()) ||
+		reversalSummary.LineCount != 1 || reversalSummary.InventoryValueIn.Int64() != 2_000_000 {
+		t.Fatalf("reversal summary = %#v", reversalSummary)
+	}
+	total, hasTotal := saleSummary.CommercialTotal.Get()
+	if saleSummary.Kind != domain.DocumentSale || saleSummary.ReversedByDocumentID != domain.Some(reversal.ID()) ||
+		saleSummary.CounterpartyID != domain.Some(customer.ID()) || saleSummary.LineCount != 1 ||
+		saleSummary.InventoryValueOut.Int64() != 2_000_000 || saleSummary.InventoryValueIn.Int64() != 0 ||
+		!hasTotal || total.Int64() != 500 {
+		t.Fatalf("sale summary = %#v", saleSummary)
+	}
+	nextPage, err := store.ListStockDocuments(ctx, StockDocumentListFilter{After: domain.Some(cursor), PageSize: 2})
+	if err != nil {
+		t.Fatalf("list second page: %v", err)
+	}
+	if nextItems := nextPage.Items(); len(nextItems) != 1 || nextItems[0].ID != purchase.ID() || nextPage.Next().IsSome() {
+		t.Fatalf("second page = items %#v next %#v", nextItems, nextPage.Next())
+	}
+
+	filtered := func(filter StockDocumentListFilter) []domain.StockDocumentID {
+		t.Helper()
+		page, err := store.ListStockDocuments(ctx, filter)
+		if err != nil {
+			t.Fatalf("list filtered: %v", err)
+		}
+		var ids []domain.StockDocumentID
+		for _, item := range page.Items() {
+			ids = append(ids, item.ID)
+		}
+		return ids
+	}
+	if ids := filtered(StockDocumentListFilter{Kind: domain.Some(domain.DocumentPurchase)}); len(ids) != 1 || ids[0] != purchase.ID() {
+		t.Fatalf("kind filter = %v", ids)
+	}
+	if ids := filtered(StockDocumentListFilter{Reason: domain.Some(domain.ReasonExactReversal)}); len(ids) != 1 || ids[0] != reversal.ID() {
+		t.Fatalf("reason filter = %v", ids)
+	}
+	if ids := filtered(StockDocumentListFilter{CounterpartyID: domain.Some(customer.ID())}); len(ids) != 1 || ids[0] != sale.ID() {
+		t.Fatalf("counterparty filter = %v", ids)
+	}
+	if ids := filtered(StockDocumentListFilter{Reversal: domain.ReversalFilterReversed}); len(ids) != 1 || ids[0] != sale.ID() {
+		t.Fatalf("reversed filter = %v", ids)
+	}
+	if ids := filtered(StockDocumentListFilter{Reversal: domain.ReversalFilterNotReversed}); len(ids) != 2 ||
+		ids[0] != reversal.ID() || ids[1] != purchase.ID() {
+		t.Fatalf("not reversed filter = %v", ids)
+	}
+	if ids := filtered(StockDocumentListFilter{
+		FromOccurredOn: domain.Some(mustPurchaseDate(t, "2026-07-10")),
+		ToOccurredOn:   domain.Some(mustPurchaseDate(t, "2026-07-15")),
+	}); len(ids) != 1 || ids[0] != sale.ID() {
+		t.Fatalf("date range filter = %v", ids)
+	}
+	if ids := filtered(StockDocumentListFilter{NotesSearch: domain.Some(counterpartyText(t, "aniversário"))}); len(ids) != 1 || ids[0] != sale.ID() {
+		t.Fatalf("notes search = %v", ids)
+	}
+	if ids := filtered(StockDocumentListFilter{NotesSearch: domain.Some(counterpartyText(t, "ENCOMENDA DE"))}); len(ids) != 1 || ids[0] != sale.ID() {
+		t.Fatalf("case-insensitive notes search = %v", ids)
+	}
+	if ids := filtered(StockDocumentListFilter{NotesSearch: domain.Some(counterpartyText(t, "casamento"))}); len(ids) != 0 {
+		t.Fatalf("unmatched notes search = %v", ids)
+	}
+
+	_, err = store.ListStockDocuments(ctx, StockDocumentListFilter{
+		Kind:   domain.Some(domain.DocumentPurchase),
+		Reason: domain.Some(domain.ReasonWaste),
+	})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("reason outside kind error = %v, want validation", err)
+	}
+}
@@ -0,0 +1,628 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type PostSupplierReturnInput struct {
+	IdempotencyKey     domain.IdempotencyKey
+	PurchaseDocumentID domain.StockDocumentID
+	OccurredOn         domain.BusinessDate
+	PostedAt           domain.UTCInstant
+	Notes              domain.Option[domain.NonEmptyText]
+	Lines              []PostSupplierReturnLineInput
+}
+
+// PostSupplierReturnLineInput sends part of one purchase line back. Without
+// an explicit credit the line credits its proportional share of the purchase
+// line total.
+type PostSupplierReturnLineInput struct {
+	PurchaseLineID domain.StockDocumentLineID
+	Quantity       domain.AtomicQuantity
+	Credit         domain.Option[domain.MinorAmount]
+}
+
+type PostedSupplierReturnDocument struct {
+	id                 domain.StockDocumentID
+	idempotencyKey     domain.IdempotencyKey
+	postingSequence    domain.PostingSequence
+	purchaseDocumentID domain.StockDocumentID
+	counterpartyID     domain.Option[domain.CounterpartyID]
+	occurredOn         domain.BusinessDate
+	postedAt           domain.UTCInstant
+	currency           domain.Currency
+	notes              domain.Option[domain.NonEmptyText]
+	lines              []PostedSupplierReturnLine
+}
+
+func NewPostedSupplierReturnDocument(
+	id domain.StockDocumentID,
+	idempotencyKey domain.IdempotencyKey,
+	postingSequence domain.PostingSequence,
+	purchaseDocumentID domain.StockDocumentID,
+	counterpartyID domain.Option[domain.CounterpartyID],
+	occurredOn domain.BusinessDate,
+	postedAt domain.UTCInstant,
+	currency domain.Currency,
+	notes domain.Option[domain.NonEmptyText],
+	lines []PostedSupplierReturnLine,
+) PostedSupplierReturnDocument {
+	cloned := make([]PostedSupplierReturnLine, len(lines))
+	copy(cloned, lines)
+	return PostedSupplierReturnDocument{
+		id: id, idempotencyKey: idempotencyKey, postingSequence: postingSequence,
+		purchaseDocumentID: purchaseDocumentID, counterpartyID: counterpartyID, occurredOn: occurredOn,
+		postedAt: postedAt, currency: currency, notes: notes, lines: cloned,
+	}
+}
+
+func (d PostedSupplierReturnDocument) ID() domain.StockDocumentID            { return d.id }
+func (d PostedSupplierReturnDocument) IdempotencyKey() domain.IdempotencyKey { return d.idempotencyKey }
+func (d PostedSupplierReturnDocument) PostingSequence() domain.PostingSequence {
+	return d.postingSequence
+}
+func (d PostedSupplierReturnDocument) PurchaseDocumentID() domain.StockDocumentID {
+	return d.purchaseDocumentID
+}
+func (d PostedSupplierReturnDocument) CounterpartyID() domain.Option[domain.CounterpartyID] {
+	return d.counterpartyID
+}
+func (d PostedSupplierReturnDocument) OccurredOn() domain.BusinessDate           { return d.occurredOn }
+func (d PostedSupplierReturnDocument) PostedAt() domain.UTCInstant               { return d.postedAt }
+func (d PostedSupplierReturnDocument) Currency() domain.Currency                 { return d.currency }
+func (d PostedSupplierReturnDocument) Notes() domain.Option[domain.NonEmptyText] { return d.notes }
+func (d PostedSupplierReturnDocument) Lines() []PostedSupplierReturnLine {
+	lines := make([]PostedSupplierReturnLine, len(d.lines))
+	copy(lines, d.lines)
+	return lines
+}
+
+// PostedSupplierReturnLine consumes the lot of the purchase line it returns,
+// so it always has exactly one allocation.
+type PostedSupplierReturnLine struct {
+	id                   domain.StockDocumentLineID
+	lineOrder            domain.LineOrder
+	itemID               domain.ItemID
+	quantity             domain.AtomicQuantity
+	enteredUnit          domain.UnitCode
+	enteredPackagingName domain.Option[domain.NonEmptyText]
+	conversion           domain.UnitConversion
+	inventoryValue       domain.InventoryValue
+	credit               domain.MinorAmount
+	returnsLineID        domain.StockDocumentLineID
+	lotID                domain.InventoryLotID
+	allocationID         domain.LotAllocationID
+}
+
+func NewPostedSupplierReturnLine(
+	id domain.StockDocumentLineID,
+	lineOrder domain.LineOrder,
+	itemID domain.ItemID,
+	quantity domain.AtomicQuantity,
+	enteredUnit domain.UnitCode,
+	enteredPackagingName domain.Option[domain.NonEmptyText],
+	conversion domain.UnitConversion,
+	inventoryValue domain.InventoryValue,
+	credit domain.MinorAmount,
+	returnsLineID domain.StockDocumentLineID,
+	lotID domain.InventoryLotID,
+	allocationID domain.LotAllocationID,
+) PostedSupplierReturnLine {
+	return PostedSupplierReturnLine{
+		id: id, lineOrder: lineOrder, itemID: itemID, quantity: quantity,
+		enteredUnit: enteredUnit, enteredPackagingName: enteredPackagingName,
+		conversion: conversion, inventoryValue: inventoryValue, credit: credit,
+		returnsLineID: returnsLineID, lotID: lotID, allocationID: allocationID,
+	}
+}
+
+func (l PostedSupplierReturnLine) ID() domain.StockDocumentLineID  { return l.id }
+func (l PostedSupplierReturnLine) LineOrder() domain.LineOrder     { return l.lineOrder }
+func (l PostedSupplierReturnLine) ItemID() domain.ItemID           { return l.itemID }
+func (l PostedSupplierReturnLine) Quantity() domain.AtomicQuantity { return l.quantity }
+func (l PostedSupplierReturnLine) EnteredUnit() domain.UnitCode    { return l.enteredUnit }
+func (l PostedSupplierReturnLine) EnteredPackagingName() domain.Option[domain.NonEmptyText] {
+	return l.enteredPackagingName
+}
+func (l PostedSupplierReturnLine) Conversion() domain.UnitConversion         { return l.conversion }
+func (l PostedSupplierReturnLine) InventoryValue() domain.InventoryValue     { return l.inventoryValue }
+func (l PostedSupplierReturnLine) Credit() domain.MinorAmount                { return l.credit }
+func (l PostedSupplierReturnLine) ReturnsLineID() domain.StockDocumentLineID { return l.returnsLineID }
+func (l PostedSupplierReturnLine) LotID() domain.InventoryLotID              { return l.lotID }
+func (l PostedSupplierReturnLine) AllocationID() domain.LotAllocationID      { return l.allocationID }
+
+func (s *Store) PostSupplierReturn(ctx context.Context, input PostSupplierReturnInput) (PostedSupplierReturnDocument, error) {
+	var posted PostedSupplierReturnDocument
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		value, err := postSupplierReturnTx(ctx, tx, input)
+		if err != nil {
+			return err
+		}
+		posted = value
+		return nil
+	})
+	if err != nil {
+		return PostedSupplierReturnDocument{}, classifyError("post supplier return", err)
+	}
+	return posted, nil
+}
+
+func (s *Store) GetPostedSupplierReturn(ctx context.Context, id domain.StockDocumentID) (PostedSupplierReturnDocument, error) {
+	if id.IsZero() {
+		return PostedSupplierReturnDocument{}, domain.Invalid("document_id", domain.ViolationRequired, "DOC-001")
+	}
+	var document PostedSupplierReturnDocument
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadPostedSupplierReturnDocument(ctx, tx, id.Int64())
+		if err != nil {
+			return err
+		}
+		document = value
+		return nil
+	})
+	if err != nil {
+		return PostedSupplierReturnDocument{}, classifyError("get posted supplier return", err)
+	}
+	return document, nil
+}
+
+// ListPostedSupplierReturnsForPurchase returns every supplier return of one
+// purchase in posting order.
+func (s *Store) ListPostedSupplierReturnsForPurchase(ctx context.Context, purchaseID domain.StockDocumentID) ([]PostedSupplierReturnDocument, error) {
+	if purchaseID.IsZero() {
+		return nil, domain.Invalid("purchase_document_id", domain.ViolationRequired, "SRT-001")
+	}
+	documents := []PostedSupplierReturnDocument{}
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		var kind string
+		if err := tx.QueryRowContext(ctx, `
+			SELECT kind FROM stock_documents WHERE id = ?
+		`, purchaseID.Int64()).Scan(&kind); err != nil {
+			return err
+		}
+		if kind != domain.DocumentPurchase.String() {
+			return domain.Invalid("purchase_document_id", domain.ViolationInvalidEnum, "SRT-001")
+		}
+		rows, err := tx.QueryContext(ctx, `
+			SELECT id
+			FROM stock_documents
+			WHERE kind = 'RETURN' AND reason_code = 'SUPPLIER_RETURN' AND returns_document_id = ?
+			ORDER BY posting_sequence, id
+		`, purchaseID.Int64())
+		if err != nil {
+			return err
+		}
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return err
+			}
+			ids = append(ids, id)
+		}
+		if err := rows.Close(); err != nil {
+			return err
+		}
+		for _, id := range ids {
+			document, err := loadPostedSupplierReturnDocument(ctx, tx, id)
+			if err != nil {
+				return err
+			}
+			documents = append(documents, document)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, classifyError("list posted supplier returns", err)
+	}
+	return documents, nil
+}
+
+func postSupplierReturnTx(ctx context.Context, tx databaseWriteTx, input PostSupplierReturnInput) (PostedSupplierReturnDocument, error) {
+	if err := validateSupplierReturnInput(input); err != nil {
+		return PostedSupplierReturnDocument{}, err
+	}
+
+	var existingID int64
+	var existingKind string
+	var existingReason sql.NullString
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, kind, reason_code FROM stock_documents WHERE idempotency_key = ?
+	`, input.IdempotencyKey.String()).Scan(&existingID, &existingKind, &existingReason)
+	if err == nil {
+		if existingKind != domain.DocumentReturn.String() || existingReason.String != domain.ReasonSupplierReturn.String() {
+			return PostedSupplierReturnDocument{}, fmt.Errorf("%w: idempotency key belongs to %s", domain.ErrConflict, existingKind)
+		}
+		return loadPostedSupplierReturnDocument(ctx, tx, existingID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return PostedSupplierReturnDocument{}, err
+	}
+
+	purchase, err := loadReturnablePurchase(ctx, tx, input.PurchaseDocumentID.Int64())
+	if err != nil {
+		return PostedSupplierReturnDocument{}, err
+	}
+	if input.OccurredOn.Before(purchase.occurredOn) {
+		return PostedSupplierReturnDocument{}, domain.Invalid("occurred_on", domain.ViolationOutOfRange, "SRT-001")
+	}
+	lines := make([]returnablePurchaseLine, 0, len(input.Lines))
+	for index, requested := range input.Lines {
+		line, err := loadReturnablePurchaseLine(ctx, tx, purchase.id, requested.PurchaseLineID.Int64())
+		if errors.Is(err, sql.ErrNoRows) {
+			return PostedSupplierReturnDocument{}, domain.Invalid(fmt.Sprintf("lines[%d].purchase_line_id", index), domain.ViolationInvariant, "SRT-002")
+		}
+		if err != nil {
+			return PostedSupplierReturnDocument{}, err
+		}
+		lines = append(lines, line)
+	}
+
+	currency, err := loadDocumentCurrency(ctx, tx)
+	if err != nil {
+		return PostedSupplierReturnDocument{}, err
+	}
+
+	var postingSequence int64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(posting_sequence), 0) + 1 FROM stock_documents
+	`).Scan(&postingSequence); err != nil {
+		return PostedSupplierReturnDocument{}, err
+	}
+
+	var documentID int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO stock_documents (
+			kind, idempotency_key, posting_sequence, counterparty_id, occurred_on,
+			posted_at_ms, currency_code, currency_minor_digits, reason_code, notes,
+			returns_document_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`,
+		domain.DocumentReturn.String(),
+		input.IdempotencyKey.String(),
+		postingSequence,
+		nullableSQLInt64(purchase.counterpartyID),
+		input.OccurredOn.String(),
+		input.PostedAt.UnixMilli(),
+		currency.Code().String(),
+		int64(currency.MinorDigits().Int()),
+		domain.ReasonSupplierReturn.String(),
+		nullableText(input.Notes),
+		purchase.id,
+	).Scan(&documentID); err != nil {
+		return PostedSupplierReturnDocument{}, err
+	}
+
+	for index, requested := range input.Lines {
+		if err := insertSupplierReturnLine(ctx, tx, documentID, int64(index+1), input.PostedAt, lines[index], requested); err != nil {
+			return PostedSupplierReturnDocument{}, fmt.Errorf("line %d: %w", index+1, err)
+		}
+	}
+
+	return loadPostedSupplierReturnDocument(ctx, tx, documentID)
+}
+
+func validateSupplierReturnInput(input PostSupplierReturnInput) error {
+	if input.IdempotencyKey.String() == "" {
+		return domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.PurchaseDocumentID.IsZero() {
+		return domain.Invalid("purchase_document_id", domain.ViolationRequired, "SRT-001")
+	}
+	if input.OccurredOn.IsZero() {
+		return domain.Invalid("occurred_on", domain.ViolationRequired, "DOC-004")
+	}
+	if input.PostedAt.IsZero() {
+		return domain.Invalid("posted_at", domain.ViolationRequired, "DOC-004")
+	}
+	if len(input.Lines) == 0 {
+		return domain.Invalid("lines", domain.ViolationRequired, "DOC-002")
+	}
+	seen := make(map[domain.StockDocumentLineID]struct{}, len(input.Lines))
+	for index, line := range input.Lines {
+		if line.PurchaseLineID.IsZero() {
+			return domain.Invalid(fmt.Sprintf("lines[%d].purchase_line_id", index), domain.ViolationRequired, "SRT-002")
+		}
+		if _, ok := seen[line.PurchaseLineID]; ok {
+			return domain.Invalid(fmt.Sprintf("lines[%d].purchase_line_id", index), domain.ViolationDuplicate, "SRT-002")
+		}
+		seen[line.PurchaseLineID] = struct{}{}
+		if line.Quantity.Int64() <= 0 {
+			return domain.Invalid(fmt.Sprintf("lines[%d].quantity_atomic", index), domain.ViolationNotPositive, "DOC-008")
+		}
+	}
+	return nil
+}
+
+type returnablePurchase struct {
+	id             int64
+	occurredOn     domain.BusinessDate
+	counterpartyID sql.NullInt64
+}
+
+// loadReturnablePurchase accepts only a purchase that has not been exactly
+// reversed.
+func loadReturnablePurchase(ctx context.Context, tx databaseWriteTx, purchaseID int64) (returnablePurchase, error) {
+	var purchase returnablePurchase
+	var kind, occurredOn string
+	var reversed int
+	err := tx.QueryRowContext(ctx, `
+		SELECT document.id, document.kind, document.occurred_on, document.counterparty_id,
+		       EXISTS (
+		           SELECT 1 FROM stock_documents reversal
+		           WHERE reversal.reverses_document_id = document.id
+		       )
+		FROM stock_documents document
+		WHERE document.id = ?
+	`, purchaseID).Scan(&purchase.id, &kind, &occurredOn, &purchase.counterpartyID, &reversed)
+	if err != nil {
+		return returnablePurchase{}, err
+	}
+	if kind != domain.DocumentPurchase.String() {
+		return returnablePurchase{}, domain.Invalid("purchase_document_id", domain.ViolationInvalidEnum, "SRT-001")
+	}
+	if reversed != 0 {
+		return returnablePurchase{}, domain.Invalid("purchase_document_id", domain.ViolationInvariant, "SRT-001")
+	}
+	purchase.occurredOn, err = domain.ParseBusinessDate(occurredOn)
+	if err != nil {
+		return returnablePurchase{}, corruptDataError("", err)
+	}
+	return purchase, nil
+}
+
+type returnablePurchaseLine struct {
+	id, itemID, lotID, quantityAtomic                int64
+	conversionNumeratorAtomic, conversionDenominator int64
+	inventoryValueMicro, commercialTotalMinor        int64
+	returnedQuantityAtomic, returnedValueMicro       int64
+	creditedMinor                                    int64
+	enteredUnitCode                                  string
+	enteredPackagingName                             sql.NullString
+}
+
+func loadReturnablePurchaseLine(ctx context.Context, tx databaseWriteTx, purchaseID, lineID int64) (returnablePurchaseLine, error) {
+	var line returnablePurchaseLine
+	err := tx.QueryRowContext(ctx, `
+		SELECT line.id, line.item_id, lot.id, line.quantity_atomic,
+		       line.entered_unit_code, line.entered_packaging_name,
+		       line.conversion_numerator_atomic, line.conversion_denominator,
+		       line.inventory_value_micro, line.commercial_total_minor,
+		       COALESCE(SUM(returned.quantity_atomic), 0),
+		       COALESCE(SUM(returned.inventory_value_micro), 0),
+		       COALESCE(SUM(returned.commercial_total_minor), 0)
+		FROM stock_document_lines line
+		JOIN inventory_lots lot ON lot.source_line_id = line.id
+		LEFT JOIN stock_document_lines returned ON returned.returns_line_id = line.id
+		WHERE line.id = ? AND line.document_id = ?
+		GROUP BY line.id, lot.id
+	`, lineID, purchaseID).Scan(
+		&line.id,
+		&line.itemID,
+		&line.lotID,
+		&line.quantityAtomic,
+		&line.enteredUnitCode,
+		&line.enteredPackagingName,
+		&line.conversionNumeratorAtomic,
+		&line.conversionDenominator,
+		&line.inventoryValueMicro,
+		&line.commercialTotalMinor,
+		&line.returnedQuantityAtomic,
+		&line.returnedValueMicro,
+		&line.creditedMinor,
+	)
+	if err != nil {
+		return returnablePurchaseLine{}, err
+	}
+	return line, nil
+}
+
+// supplierReturnLineAmounts values a partial supplier return as the
+// cumulative share of the purchase line, that is at the line's unit cost. The
+// item balance bounds the value: a return that empties the item takes its
+// remaining value, so quantity and value reach zero together.
+func supplierReturnLineAmounts(
+	line returnablePurchaseLine,
+	balance adjustmentBalance,
+	requested PostSupplierReturnLineInput,
+) (int64, int64, error) {
+	returnedThrough := line.returnedQuantityAtomic + requested.Quantity.Int64()
+	if returnedThrough > line.quantityAtomic {
+		return 0, 0, domain.Invalid("quantity_atomic", domain.ViolationOutOfRange, "SRT-002")
+	}
+	if requested.Quantity.Int64() > balance.quantityAtomic {
+		return 0, 0, domain.Invalid("quantity_atomic", domain.ViolationOutOfRange, "INV-004")
+	}
+	valueThrough, err := weightedAverageValue(line.inventoryValueMicro, line.quantityAtomic, returnedThrough)
+	if err != nil {
+		return 0, 0, err
+	}
+	value := min(max(valueThrough.Int64()-line.returnedValueMicro, 0), balance.inventoryValueMicro)
+	if requested.Quantity.Int64() == balance.quantityAtomic {
+		value = balance.inventoryValueMicro
+	}
+	remainingCredit := line.commercialTotalMinor - line.creditedMinor
+	if credit, ok := requested.Credit.Get(); ok {
+		if credit.Int64() > remainingCredit {
+			return 0, 0, domain.Invalid("credit_minor", domain.ViolationOutOfRange, "SRT-002")
+		}
+		return value, credit.Int64(), nil
+	}
+	creditThrough, err := weightedAverageValue(line.commercialTotalMinor, line.quantityAtomic, returnedThrough)
+	if err != nil {
+		return 0, 0, err
+	}
+	credit := min(max(creditThrough.Int64()-line.creditedMinor, 0), remainingCredit)
+	return value, credit, nil
+}
+
+func insertSupplierReturnLine(
+	ctx context.Context,
+	tx databaseWriteTx,
+	documentID int64,
+	lineOrder int64,
+	postedAt domain.UTCInstant,
+	purchase returnablePurchaseLine,
+	requested PostSupplierReturnLineInput,
+) error {
+	available, err := lotAvailableQuantity(ctx, tx, purchase.lotID)
+	if err != nil {
+		return err
+	}
+	if requested.Quantity.Int64() > available {
+		return domain.Invalid("quantity_atomic", domain.ViolationInvariant, "SRT-003")
+	}
+	itemID, err := domain.NewItemID(purchase.itemID)
+	if err != nil {
+		return corruptDataError("", err)
+	}
+	balance, err := readAdjustmentBalance(ctx, tx, itemID)
+	if err != nil {
+		return err
+	}
+	value, credit, err := supplierReturnLineAmounts(purchase, balance, requested)
+	if err != nil {
+		return err
+	}
+
+	var lineID int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO stock_document_lines (
+			document_id, line_order, item_id, direction, quantity_atomic,
+			entered_unit_code, entered_packaging_name, conversion_numerator_atomic,
+			conversion_denominator, inventory_value_micro, commercial_total_minor,
+			returns_line_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`,
+		documentID,
+		lineOrder,
+		purchase.itemID,
+		domain.DirectionOut.String(),
+		requested.Quantity.Int64(),
+		purchase.enteredUnitCode,
+		nullableSQLString(purchase.enteredPackagingName),
+		purchase.conversionNumeratorAtomic,
+		purchase.conversionDenominator,
+		value,
+		credit,
+		purchase.id,
+	).Scan(&lineID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO lot_allocations (line_id, lot_id, quantity_atomic, created_at_ms)
+		VALUES (?, ?, ?, ?)
+	`, lineID, purchase.lotID, requested.Quantity.Int64(), postedAt.UnixMilli()); err != nil {
+		return err
+	}
+	return updateAdjustmentBalance(ctx, tx, documentID, postedAt, itemID, -requested.Quantity.Int64(), -value)
+}
+
+func loadPostedSupplierReturnDocument(ctx context.Context, tx databaseWriteTx, id int64) (PostedSupplierReturnDocument, error) {
+	var row postedReturnDocumentRow
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, idempotency_key, posting_sequence, returns_document_id, counterparty_id,
+		       occurred_on, posted_at_ms, currency_code, currency_minor_digits, notes
+		FROM stock_documents
+		WHERE id = ? AND kind = 'RETURN' AND reason_code = 'SUPPLIER_RETURN'
+	`, id).Scan(
+		&row.id,
+		&row.idempotencyKey,
+		&row.postingSequence,
+		&row.returnsDocumentID,
+		&row.counterpartyID,
+		&row.occurredOn,
+		&row.postedAtMS,
+		&row.currencyCode,
+		&row.currencyMinorDigits,
+		&row.notes,
+	)
+	if err != nil {
+		return PostedSupplierReturnDocument{}, err
+	}
+	lines, err := loadPostedSupplierReturnLines(ctx, tx, id)
+	if err != nil {
+		return PostedSupplierReturnDocument{}, err
+	}
+	header, err := mapPostedReturnDocument(row, nil)
+	if err != nil {
+		return PostedSupplierReturnDocument{}, err
+	}
+	return NewPostedSupplierReturnDocument(
+		header.ID(), header.IdempotencyKey(), header.PostingSequence(), header.SaleDocumentID(),
+		header.CounterpartyID(), header.OccurredOn(), header.PostedAt(), header.Currency(),
+		header.Notes(), lines,
+	), nil
+}
+
+func loadPostedSupplierReturnLines(ctx context.Context, tx databaseWriteTx, documentID int64) ([]PostedSupplierReturnLine, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT line.id, line.line_order, line.item_id, line.quantity_atomic,
+		       line.entered_unit_code, line.entered_packaging_name,
+		       line.conversion_numerator_atomic, line.conversion_denominator,
+		       line.inventory_value_micro, line.commercial_total_minor, line.returns_line_id,
+		       allocation.lot_id, allocation.id
+		FROM stock_document_lines line
+		JOIN lot_allocations allocation ON allocation.line_id = line.id
+		WHERE line.document_id = ?
+		ORDER BY line.line_order, line.id
+	`, documentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []PostedSupplierReturnLine
+	for rows.Next() {
+		var row postedReturnLineRow
+		var rawLotID, rawAllocationID int64
+		if err := rows.Scan(
+			&row.id,
+			&row.lineOrder,
+			&row.itemID,
+			&row.quantityAtomic,
+			&row.enteredUnitCode,
+			&row.enteredPackagingName,
+			&row.conversionNumeratorAtomic,
+			&row.conversionDenominator,
+			&row.inventoryValueMicro,
+			&row.commercialTotalMinor,
+			&row.returnsLineID,
+			&rawLotID,
+			&rawAllocationID,
+		); err != nil {
+			return nil, err
+		}
+		line, err := mapPostedReturnLine(row, nil)
+		if err != nil {
+			return nil, err
+		}
+		lotID, err := domain.NewInventoryLotID(rawLotID)
+		if err != nil {
+			return nil, err
+		}
+		allocationID, err := domain.NewLotAllocationID(rawAllocationID)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, NewPostedSupplierReturnLine(
+			line.ID(), line.LineOrder(), line.ItemID(), line.Quantity(), line.EnteredUnit(),
+			line.EnteredPackagingName(), line.Conversion(), line.InventoryValue(), line.Refund(),
+			line.ReturnsLineID(), lotID, allocationID,
+		))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
@@ -0,0 +1,171 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestSupplierReturnStoreReducesPurchaseLotAtUnitCost(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "supplier-return.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	itemID := createReportingItem(t, store, "Damaged flour", true, domain.None[domain.AtomicQuantity]())
+	supplierID := createReportingSupplier(t, store, "Mill")
+	cheap := postReportingPurchase(t, store, itemID, "srt-cheap", "2026-07-01", 1_000,
+		domain.Some(supplierID), domain.None[domain.DocumentReason](), 100, 1_000)
+	postReportingPurchase(t, store, itemID, "srt-dear", "2026-07-02", 2_000,
+		domain.None[domain.CounterpartyID](), domain.None[domain.DocumentReason](), 100, 3_000)
+	cheapLine := cheap.Lines()[0]
+
+	first, err := store.PostSupplierReturn(ctx, supplierReturnInputFixture(t, cheap.ID(), cheapLine.ID(), "srt-1", 30, domain.None[domain.MinorAmount]()))
+	if err != nil {
+		t.Fatalf("post first supplier return: %v", err)
+	}
+	firstLine := first.Lines()[0]
+	if first.PurchaseDocumentID() != cheap.ID() || first.CounterpartyID() != domain.Some(supplierID) ||
+		firstLine.ReturnsLineID() != cheapLine.ID() || firstLine.LotID() != cheapLine.LotID() ||
+		firstLine.Quantity().Int64() != 30 || firstLine.InventoryValue().Int64() != 3_000_000 ||
+		firstLine.Credit().Int64() != 300 || firstLine.AllocationID().IsZero() {
+		t.Fatalf("first supplier return line = %#v", firstLine)
+	}
+	assertInventoryBalance(t, store, itemID, 170, 37_000_000, first.ID().Int64())
+
+	overCredit := supplierReturnInputFixture(t, cheap.ID(), cheapLine.ID(), "srt-over-credit", 10, domain.Some(mustPurchaseMinorAmount(t, 701)))
+	if _, err := store.PostSupplierReturn(ctx, overCredit); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("over credit error = %v, want ErrValidation", err)
+	}
+
+	sale, err := store.PostSale(ctx, saleInputFixture(t, itemID, "srt-sale", 60, 1_200))
+	if err != nil {
+		t.Fatalf("post sale: %v", err)
+	}
+	if sale.Lines()[0].Allocations()[0].LotID() != cheapLine.LotID() {
+		t.Fatalf("sale allocations = %#v", sale.Lines()[0].Allocations())
+	}
+	unavailable := supplierReturnInputFixture(t, cheap.ID(), cheapLine.ID(), "srt-unavailable", 11, domain.None[domain.MinorAmount]())
+	if _, err := store.PostSupplierReturn(ctx, unavailable); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("unavailable lot error = %v, want ErrValidation", err)
+	}
+
+	second, err := store.PostSupplierReturn(ctx, supplierReturnInputFixture(t, cheap.ID(), cheapLine.ID(), "srt-2", 10, domain.Some(mustPurchaseMinorAmount(t, 50))))
+	if err != nil {
+		t.Fatalf("post second supplier return: %v", err)
+	}
+	secondLine := second.Lines()[0]
+	if secondLine.InventoryValue().Int64() != 1_000_000 || secondLine.Credit().Int64() != 50 {
+		t.Fatalf("second supplier return line = %#v", secondLine)
+	}
+
+	replayed, err := store.PostSupplierReturn(ctx, supplierReturnInputFixture(t, cheap.ID(), cheapLine.ID(), "srt-1", 30, domain.None[domain.MinorAmount]()))
+	if err != nil || replayed.ID() != first.ID() {
+		t.Fatalf("replayed supplier return = %#v, %v", replayed, err)
+	}
+	listed, err := store.ListPostedSupplierReturnsForPurchase(ctx, cheap.ID())
+	if err != nil || len(listed) != 2 || listed[0].ID() != first.ID() || listed[1].ID() != second.ID() {
+		t.Fatalf("listed supplier returns = %#v, %v", listed, err)
+	}
+	if _, err := store.GetPostedReturn(ctx, first.ID()); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("customer return lookup error = %v, want ErrNotFound", err)
+	}
+
+	for name, target := range map[string]domain.StockDocumentID{"purchase": cheap.ID(), "return": second.ID()} {
+		_, err := store.PostReversal(ctx, PostReversalInput{
+			IdempotencyKey:   mustPurchaseIdempotencyKey(t, "srt-reverse-"+name),
+			TargetDocumentID: target,
+			OccurredOn:       mustPurchaseDate(t, "2026-07-20"),
+			PostedAt:         mustCatalogInstant(t, 9_000),
+		})
+		if !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("reverse %s error = %v, want ErrValidation", name, err)
+		}
+	}
+
+	reconciliation, err := store.ReconcileInventory(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if !reconciliation.Consistent() {
+		t.Fatalf("reconciliation discrepancies = %#v", reconciliation.Discrepancies())
+	}
+
+	report, err := store.GetPurchaseReportData(ctx, ReportingPeriodFilter{
+		FromOccurredOn: "2026-07-01",
+		ToOccurredOn:   "2026-07-31",
+		Granularity:    "MONTH",
+	}, 5)
+	if err != nil {
+		t.Fatalf("get purchase report data: %v", err)
+	}
+	if len(report.PurchaseSpendSeries) != 1 ||
+		report.PurchaseSpendSeries[0].DocumentCount != 2 ||
+		report.PurchaseSpendSeries[0].QuantityAtomic != 160 ||
+		report.PurchaseSpendSeries[0].SpendMinor != 3_650 ||
+		report.PurchaseSpendSeries[0].InventoryValueMicro != 36_000_000 {
+		t.Fatalf("purchase spend series = %#v", report.PurchaseSpendSeries)
+	}
+	if len(report.TopSuppliersBySpend) != 1 ||
+		report.TopSuppliersBySpend[0].DocumentCount != 1 ||
+		report.TopSuppliersBySpend[0].SpendMinor != 650 {
+		t.Fatalf("top suppliers = %#v", report.TopSuppliersBySpend)
+	}
+}
+
+func TestSupplierReturnStoreEmptyingItemRemovesRemainingValue(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "supplier-return-empty.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	itemID := createSaleTestItem(t, store, "Emptied flour", true)
+	consumed := postAdjustmentTestPurchase(t, store, itemID, "srt-consumed", "SRT-A", "2026-12-31", 100, 1_000)
+	kept := postAdjustmentTestPurchase(t, store, itemID, "srt-kept", "SRT-B", "2026-12-31", 100, 3_000)
+	sale, err := store.PostSale(ctx, saleInputFixture(t, itemID, "srt-empty-sale", 100, 2_000))
+	if err != nil {
+		t.Fatalf("post sale: %v", err)
+	}
+
+	tooEarly := supplierReturnInputFixture(t, kept.ID(), kept.Lines()[0].ID(), "srt-early", 1, domain.None[domain.MinorAmount]())
+	tooEarly.OccurredOn = mustPurchaseDate(t, "2026-06-30")
+	cases := map[string]PostSupplierReturnInput{
+		"sale":         supplierReturnInputFixture(t, sale.ID(), sale.Lines()[0].ID(), "srt-sale-target", 1, domain.None[domain.MinorAmount]()),
+		"foreign line": supplierReturnInputFixture(t, kept.ID(), consumed.Lines()[0].ID(), "srt-foreign", 1, domain.None[domain.MinorAmount]()),
+		"consumed lot": supplierReturnInputFixture(t, consumed.ID(), consumed.Lines()[0].ID(), "srt-consumed-lot", 1, domain.None[domain.MinorAmount]()),
+		"too early":    tooEarly,
+	}
+	for name, input := range cases {
+		if _, err := store.PostSupplierReturn(ctx, input); !errors.Is(err, domain.ErrValidation) {
+			t.Fatalf("%s supplier return error = %v, want ErrValidation", name, err)
+		}
+	}
+	assertInventoryBalance(t, store, itemID, 100, 20_000_000, sale.ID().Int64())
+
+	emptied, err := store.PostSupplierReturn(ctx, supplierReturnInputFixture(t, kept.ID(), kept.Lines()[0].ID(), "srt-empty", 100, domain.None[domain.MinorAmount]()))
+	if err != nil {
+		t.Fatalf("post emptying supplier return: %v", err)
+	}
+	if line := emptied.Lines()[0]; line.InventoryValue().Int64() != 20_000_000 || line.Credit().Int64() != 3_000 {
+		t.Fatalf("emptying supplier return line = %#v", line)
+	}
+	assertInventoryBalance(t, store, itemID, 0, 0, emptied.ID().Int64())
+}
+
+func supplierReturnInputFixture(
+	t *testing.T,
+	purchaseID domain.StockDocumentID,
+	purchaseLineID domain.StockDocumentLineID,
+	idempotencyKey string,
+	quantityAtomic int64,
+	credit domain.Option[domain.MinorAmount],
+) PostSupplierReturnInput {
+	t.Helper()
+	return PostSupplierReturnInput{
+		IdempotencyKey:     mustPurchaseIdempotencyKey(t, idempotencyKey),
+		PurchaseDocumentID: purchaseID,
+		OccurredOn:         mustPurchaseDate(t, "2026-07-18"),
+		PostedAt:           mustCatalogInstant(t, 7_000),
+		Lines: []PostSupplierReturnLineInput{
+			{PurchaseLineID: purchaseLineID, Quantity: mustPurchaseQuantity(t, quantityAtomic), Credit: credit},
+		},
+	}
+}
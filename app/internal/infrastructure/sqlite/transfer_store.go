@@ -0,0 +1,577 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+type PostTransferInput struct {
+	IdempotencyKey domain.IdempotencyKey
+	OccurredOn     domain.BusinessDate
+	PostedAt       domain.UTCInstant
+	Notes          domain.Option[domain.NonEmptyText]
+	Lines          []PostTransferLineInput
+}
+
+// PostTransferLineInput moves part of one lot to another location. The moved
+// quantity keeps the lot's code and dates at the destination.
+type PostTransferLineInput struct {
+	LotID        domain.InventoryLotID
+	Quantity     domain.AtomicQuantity
+	ToLocationID domain.StockLocationID
+}
+
+type PostedTransferDocument struct {
+	id              domain.StockDocumentID
+	idempotencyKey  domain.IdempotencyKey
+	postingSequence domain.PostingSequence
+	occurredOn      domain.BusinessDate
+	postedAt        domain.UTCInstant
+	notes           domain.Option[domain.NonEmptyText]
+	lines           []PostedTransferLine
+}
+
+func NewPostedTransferDocument(
+	id domain.StockDocumentID,
+	idempotencyKey domain.IdempotencyKey,
+	postingSequence domain.PostingSequence,
+	occurredOn domain.BusinessDate,
+	postedAt domain.UTCInstant,
+	notes domain.Option[domain.NonEmptyText],
+	lines []PostedTransferLine,
+) PostedTransferDocument {
+	cloned := make([]PostedTransferLine, len(lines))
+	copy(cloned, lines)
+	return PostedTransferDocument{
+		id: id, idempotencyKey: idempotencyKey, postingSequence: postingSequence,
+		occurredOn: occurredOn, postedAt: postedAt, notes: notes, lines: cloned,
+	}
+}
+
+func (d PostedTransferDocument) ID() domain.StockDocumentID                { return d.id }
+func (d PostedTransferDocument) IdempotencyKey() domain.IdempotencyKey     { return d.idempotencyKey }
+func (d PostedTransferDocument) PostingSequence() domain.PostingSequence   { return d.postingSequence }
+func (d PostedTransferDocument) OccurredOn() domain.BusinessDate           { return d.occurredOn }
+func (d PostedTransferDocument) PostedAt() domain.UTCInstant               { return d.postedAt }
+func (d PostedTransferDocument) Notes() domain.Option[domain.NonEmptyText] { return d.notes }
+func (d PostedTransferDocument) Lines() []PostedTransferLine {
+	lines := make([]PostedTransferLine, len(d.lines))
+	copy(lines, d.lines)
+	return lines
+}
+
+// PostedTransferLine is one OUT/IN line pair: the OUT line consumes the
+// source lot and the IN line creates the destination lot at the same value.
+type PostedTransferLine struct {
+	outLineID            domain.StockDocumentLineID
+	inLineID             domain.StockDocumentLineID
+	lineOrder            domain.LineOrder
+	itemID               domain.ItemID
+	quantity             domain.AtomicQuantity
+	enteredUnit          domain.UnitCode
+	enteredPackagingName domain.Option[domain.NonEmptyText]
+	conversion           domain.UnitConversion
+	inventoryValue       domain.InventoryValue
+	sourceLotID          domain.InventoryLotID
+	allocationID         domain.LotAllocationID
+	fromLocationID       domain.StockLocationID
+	lotID                domain.InventoryLotID
+	toLocationID         domain.StockLocationID
+}
+
+func NewPostedTransferLine(
+	outLineID domain.StockDocumentLineID,
+	inLineID domain.StockDocumentLineID,
+	lineOrder domain.LineOrder,
+	itemID domain.ItemID,
+	quantity domain.AtomicQuantity,
+	enteredUnit domain.UnitCode,
+	enteredPackagingName domain.Option[domain.NonEmptyText],
+	conversion domain.UnitConversion,
+	inventoryValue domain.InventoryValue,
+	sourceLotID domain.InventoryLotID,
+	allocationID domain.LotAllocationID,
+	fromLocationID domain.StockLocationID,
+	lotID domain.InventoryLotID,
+	toLocationID domain.StockLocationID,
+) PostedTransferLine {
+	return PostedTransferLine{
+		outLineID: outLineID, inLineID: inLineID, lineOrder: lineOrder, itemID: itemID,
+		quantity: quantity, enteredUnit: enteredUnit, enteredPackagingName: enteredPackagingName,
+		conversion: conversion, inventoryValue: inventoryValue, sourceLotID: sourceLotID,
+		allocationID: allocationID, fromLocationID: fromLocationID, lotID: lotID,
+		toLocationID: toLocationID,
+	}
+}
+
+func (l PostedTransferLine) OutLineID() domain.StockDocumentLineID { return l.outLineID }
+func (l PostedTransferLine) InLineID() domain.StockDocumentLineID  { return l.inLineID }
+func (l PostedTransferLine) LineOrder() domain.LineOrder           { return l.lineOrder }
+func (l PostedTransferLine) ItemID() domain.ItemID                 { return l.itemID }
+func (l PostedTransferLine) Quantity() domain.AtomicQuantity       { return l.quantity }
+func (l PostedTransferLine) EnteredUnit() domain.UnitCode          { return l.enteredUnit }
+func (l PostedTransferLine) EnteredPackagingName() domain.Option[domain.NonEmptyText] {
+	return l.enteredPackagingName
+}
+func (l PostedTransferLine) Conversion() domain.UnitConversion      { return l.conversion }
+func (l PostedTransferLine) InventoryValue() domain.InventoryValue  { return l.inventoryValue }
+func (l PostedTransferLine) SourceLotID() domain.InventoryLotID     { return l.sourceLotID }
+func (l PostedTransferLine) AllocationID() domain.LotAllocationID   { return l.allocationID }
+func (l PostedTransferLine) FromLocationID() domain.StockLocationID { return l.fromLocationID }
+func (l PostedTransferLine) LotID() domain.InventoryLotID           { return l.lotID }
+func (l PostedTransferLine) ToLocationID() domain.StockLocationID   { return l.toLocationID }
+
+func (s *Store) PostTransfer(ctx context.Context, input PostTransferInput) (PostedTransferDocument, error) {
+	var posted PostedTransferDocument
+	err := s.database.Write(ctx, func(tx *database.WriteTx) error {
+		value, err := postTransferTx(ctx, tx, input)
+		if err != nil {
+			return err
+		}
+		posted = value
+		return nil
+	})
+	if err != nil {
+		return PostedTransferDocument{}, classifyError("post transfer", err)
+	}
+	return posted, nil
+}
+
+func (s *Store) GetPostedTransfer(ctx context.Context, id domain.StockDocumentID) (PostedTransferDocument, error) {
+	if id.IsZero() {
+		return PostedTransferDocument{}, domain.Invalid("document_id", domain.ViolationRequired, "DOC-001")
+	}
+	var document PostedTransferDocument
+	err := s.database.Read(ctx, func(tx *database.ReadTx) error {
+		value, err := loadPostedTransferDocument(ctx, tx, id.Int64())
+		if err != nil {
+			return err
+		}
+		document = value
+		return nil
+	})
+	if err != nil {
+		return PostedTransferDocument{}, classifyError("get posted transfer", err)
+	}
+	return document, nil
+}
+
+func postTransferTx(ctx context.Context, tx databaseWriteTx, input PostTransferInput) (PostedTransferDocument, error) {
+	if err := validateTransferInput(input); err != nil {
+		return PostedTransferDocument{}, err
+	}
+
+	var existingID int64
+	var existingKind string
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, kind FROM stock_documents WHERE idempotency_key = ?
+	`, input.IdempotencyKey.String()).Scan(&existingID, &existingKind)
+	if err == nil {
+		if existingKind != domain.DocumentTransfer.String() {
+			return PostedTransferDocument{}, fmt.Errorf("%w: idempotency key belongs to %s", domain.ErrConflict, existingKind)
+		}
+		return loadPostedTransferDocument(ctx, tx, existingID)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return PostedTransferDocument{}, err
+	}
+
+	for index, line := range input.Lines {
+		if err := requireActiveLocation(ctx, tx, line.ToLocationID.Int64()); err != nil {
+			return PostedTransferDocument{}, fmt.Errorf("line %d: %w", index+1, err)
+		}
+	}
+
+	currency, err := loadDocumentCurrency(ctx, tx)
+	if err != nil {
+		return PostedTransferDocument{}, err
+	}
+
+	var postingSequence int64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(posting_sequence), 0) + 1 FROM stock_documents
+	`).Scan(&postingSequence); err != nil {
+		return PostedTransferDocument{}, err
+	}
+
+	var documentID int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO stock_documents (
+			kind, idempotency_key, posting_sequence, counterparty_id, occurred_on,
+			posted_at_ms, currency_code, currency_minor_digits, reason_code, notes
+		) VALUES (?, ?, ?, NULL, ?, ?, ?, ?, NULL, ?)
+		RETURNING id
+	`,
+		domain.DocumentTransfer.String(),
+		input.IdempotencyKey.String(),
+		postingSequence,
+		input.OccurredOn.String(),
+		input.PostedAt.UnixMilli(),
+		currency.Code().String(),
+		int64(currency.MinorDigits().Int()),
+		nullableText(input.Notes),
+	).Scan(&documentID); err != nil {
+		return PostedTransferDocument{}, err
+	}
+
+	for index, line := range input.Lines {
+		if err := insertTransferLinePair(ctx, tx, documentID, int64(2*index+1), input.PostedAt, line); err != nil {
+			return PostedTransferDocument{}, fmt.Errorf("line %d: %w", index+1, err)
+		}
+	}
+
+	return loadPostedTransferDocument(ctx, tx, documentID)
+}
+
+func validateTransferInput(input PostTransferInput) error {
+	if input.IdempotencyKey.String() == "" {
+		return domain.Invalid("idempotency_key", domain.ViolationRequired, "DOC-003")
+	}
+	if input.OccurredOn.IsZero() {
+		return domain.Invalid("occurred_on", domain.ViolationRequired, "DOC-004")
+	}
+	if input.PostedAt.IsZero() {
+		return domain.Invalid("posted_at", domain.ViolationRequired, "DOC-004")
+	}
+	if len(input.Lines) == 0 {
+		return domain.Invalid("lines", domain.ViolationRequired, "DOC-002")
+	}
+	for index, line := range input.Lines {
+		if line.LotID.IsZero() {
+			return domain.Invalid(fmt.Sprintf("lines[%d].lot_id", index), domain.ViolationRequired, "LOC-003")
+		}
+		if line.ToLocationID.IsZero() {
+			return domain.Invalid(fmt.Sprintf("lines[%d].to_location_id", index), domain.ViolationRequired, "LOC-001")
+		}
+		if line.Quantity.Int64() <= 0 {
+			return domain.Invalid(fmt.Sprintf("lines[%d].quantity_atomic", index), domain.ViolationNotPositive, "DOC-008")
+		}
+	}
+	return nil
+}
+
+type transferSourceLot struct {
+	id, itemID, locationID                           int64
+	lotCode, expiresOn                               sql.NullString
+	originatedOn, enteredUnitCode                    string
+	enteredPackagingName                             sql.NullString
+	conversionNumeratorAtomic, conversionDenominator int64
+}
+
+// loadTransferSourceLot reads the lot being moved together with the unit
+// snapshot of the line that created it; transfer lines repeat that snapshot.
+func loadTransferSourceLot(ctx context.Context, tx databaseWriteTx, lotID int64) (transferSourceLot, error) {
+	var lot transferSourceLot
+	err := tx.QueryRowContext(ctx, `
+		SELECT lot.id, lot.item_id, lot.location_id, lot.lot_code, lot.originated_on,
+		       lot.expires_on, line.entered_unit_code, line.entered_packaging_name,
+		       line.conversion_numerator_atomic, line.conversion_denominator
+		FROM inventory_lots lot
+		JOIN stock_document_lines line ON line.id = lot.source_line_id
+		WHERE lot.id = ?
+	`, lotID).Scan(
+		&lot.id,
+		&lot.itemID,
+		&lot.locationID,
+		&lot.lotCode,
+		&lot.originatedOn,
+		&lot.expiresOn,
+		&lot.enteredUnitCode,
+		&lot.enteredPackagingName,
+		&lot.conversionNumeratorAtomic,
+		&lot.conversionDenominator,
+	)
+	if err != nil {
+		return transferSourceLot{}, err
+	}
+	return lot, nil
+}
+
+// insertTransferLinePair values the moved quantity at the item's weighted
+// average, so the OUT and IN lines cancel and the item balance is unchanged.
+func insertTransferLinePair(
+	ctx context.Context,
+	tx databaseWriteTx,
+	documentID int64,
+	outLineOrder int64,
+	postedAt domain.UTCInstant,
+	requested PostTransferLineInput,
+) error {
+	source, err := loadTransferSourceLot(ctx, tx, requested.LotID.Int64())
+	if errors.Is(err, sql.ErrNoRows) {
+		return wrapClassifiedError("load transfer lot", domain.ErrInvalidReference, err)
+	}
+	if err != nil {
+		return err
+	}
+	if source.locationID == requested.ToLocationID.Int64() {
+		return domain.Invalid("to_location_id", domain.ViolationInvariant, "LOC-003")
+	}
+	available, err := lotAvailableQuantity(ctx, tx, source.id)
+	if err != nil {
+		return err
+	}
+	if requested.Quantity.Int64() > available {
+		return domain.Invalid("quantity_atomic", domain.ViolationOutOfRange, "LOC-003")
+	}
+	itemID, err := domain.NewItemID(source.itemID)
+	if err != nil {
+		return corruptDataError("", err)
+	}
+	balance, err := readAdjustmentBalance(ctx, tx, itemID)
+	if err != nil {
+		return err
+	}
+	value, err := weightedAverageValue(balance.inventoryValueMicro, balance.quantityAtomic, requested.Quantity.Int64())
+	if err != nil {
+		return err
+	}
+
+	var outLineID int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO stock_document_lines (
+			document_id, line_order, item_id, direction, quantity_atomic,
+			entered_unit_code, entered_packaging_name, conversion_numerator_atomic,
+			conversion_denominator, inventory_value_micro
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`,
+		documentID,
+		outLineOrder,
+		source.itemID,
+		domain.DirectionOut.String(),
+		requested.Quantity.Int64(),
+		source.enteredUnitCode,
+		nullableSQLString(source.enteredPackagingName),
+		source.conversionNumeratorAtomic,
+		source.conversionDenominator,
+		value.Int64(),
+	).Scan(&outLineID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO lot_allocations (line_id, lot_id, quantity_atomic, created_at_ms)
+		VALUES (?, ?, ?, ?)
+	`, outLineID, source.id, requested.Quantity.Int64(), postedAt.UnixMilli()); err != nil {
+		return err
+	}
+
+	var inLineID int64
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO stock_document_lines (
+			document_id, line_order, item_id, direction, quantity_atomic,
+			entered_unit_code, entered_packaging_name, conversion_numerator_atomic,
+			conversion_denominator, inventory_value_micro
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING id
+	`,
+		documentID,
+		outLineOrder+1,
+		source.itemID,
+		domain.DirectionIn.String(),
+		requested.Quantity.Int64(),
+		source.enteredUnitCode,
+		nullableSQLString(source.enteredPackagingName),
+		source.conversionNumeratorAtomic,
+		source.conversionDenominator,
+		value.Int64(),
+	).Scan(&inLineID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO inventory_lots (
+			item_id, source_line_id, initial_quantity_atomic, lot_code,
+			originated_on, expires_on, created_at_ms, location_id, transferred_from_lot_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		source.itemID,
+		inLineID,
+		requested.Quantity.Int64(),
+		nullableSQLString(source.lotCode),
+		source.originatedOn,
+		nullableSQLString(source.expiresOn),
+		postedAt.UnixMilli(),
+		requested.ToLocationID.Int64(),
+		source.id,
+	); err != nil {
+		return err
+	}
+	return updateAdjustmentBalance(ctx, tx, documentID, postedAt, itemID, 0, 0)
+}
+
+func loadPostedTransferDocument(ctx context.Context, tx databaseWriteTx, id int64) (PostedTransferDocument, error) {
+	var rawID, rawPostingSequence, postedAtMS int64
+	var rawIdempotencyKey, rawOccurredOn string
+	var rawNotes sql.NullString
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, idempotency_key, posting_sequence, occurred_on, posted_at_ms, notes
+		FROM stock_documents
+		WHERE id = ? AND kind = 'TRANSFER'
+	`, id).Scan(&rawID, &rawIdempotencyKey, &rawPostingSequence, &rawOccurredOn, &postedAtMS, &rawNotes)
+	if err != nil {
+		return PostedTransferDocument{}, err
+	}
+	lines, err := loadPostedTransferLines(ctx, tx, id)
+	if err != nil {
+		return PostedTransferDocument{}, err
+	}
+	documentID, err := domain.NewStockDocumentID(rawID)
+	if err != nil {
+		return PostedTransferDocument{}, corruptDataError("", err)
+	}
+	idempotencyKey, err := domain.NewIdempotencyKey(rawIdempotencyKey)
+	if err != nil {
+		return PostedTransferDocument{}, corruptDataError("", err)
+	}
+	postingSequence, err := domain.NewPostingSequence(rawPostingSequence)
+	if err != nil {
+		return PostedTransferDocument{}, corruptDataError("", err)
+	}
+	occurredOn, err := domain.ParseBusinessDate(rawOccurredOn)
+	if err != nil {
+		return PostedTransferDocument{}, corruptDataError("", err)
+	}
+	postedAt, err := domain.UTCInstantFromUnixMilli(postedAtMS)
+	if err != nil {
+		return PostedTransferDocument{}, corruptDataError("", err)
+	}
+	notes, err := optionalNonEmptyText(rawNotes)
+	if err != nil {
+		return PostedTransferDocument{}, corruptDataError("", err)
+	}
+	return NewPostedTransferDocument(documentID, idempotencyKey, postingSequence, occurredOn, postedAt, notes, lines), nil
+}
+
+type postedTransferLineRow struct {
+	outLineID, inLineID, lineOrder, itemID, quantityAtomic int64
+	conversionNumeratorAtomic, conversionDenominator       int64
+	inventoryValueMicro, sourceLotID, allocationID         int64
+	fromLocationID, lotID, toLocationID                    int64
+	enteredUnitCode                                        string
+	enteredPackagingName                                   sql.NullString
+}
+
+func loadPostedTransferLines(ctx context.Context, tx databaseWriteTx, documentID int64) ([]PostedTransferLine, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT out_line.id, in_line.id, out_line.line_order, out_line.item_id,
+		       out_line.quantity_atomic, out_line.entered_unit_code,
+		       out_line.entered_packaging_name, out_line.conversion_numerator_atomic,
+		       out_line.conversion_denominator, out_line.inventory_value_micro,
+		       allocation.lot_id, allocation.id, source_lot.location_id,
+		       lot.id, lot.location_id
+		FROM stock_document_lines out_line
+		JOIN lot_allocations allocation ON allocation.line_id = out_line.id
+		JOIN inventory_lots source_lot ON source_lot.id = allocation.lot_id
+		JOIN stock_document_lines in_line
+		  ON in_line.document_id = out_line.document_id
+		 AND in_line.line_order = out_line.line_order + 1
+		JOIN inventory_lots lot ON lot.source_line_id = in_line.id
+		WHERE out_line.document_id = ? AND out_line.direction = 'OUT'
+		ORDER BY out_line.line_order, out_line.id
+	`, documentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lines []PostedTransferLine
+	for rows.Next() {
+		var row postedTransferLineRow
+		if err := rows.Scan(
+			&row.outLineID,
+			&row.inLineID,
+			&row.lineOrder,
+			&row.itemID,
+			&row.quantityAtomic,
+			&row.enteredUnitCode,
+			&row.enteredPackagingName,
+			&row.conversionNumeratorAtomic,
+			&row.conversionDenominator,
+			&row.inventoryValueMicro,
+			&row.sourceLotID,
+			&row.allocationID,
+			&row.fromLocationID,
+			&row.lotID,
+			&row.toLocationID,
+		); err != nil {
+			return nil, err
+		}
+		line, err := mapPostedTransferLine(row)
+		if err != nil {
+			return nil, corruptDataError("", err)
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func mapPostedTransferLine(row postedTransferLineRow) (PostedTransferLine, error) {
+	outLineID, err := domain.NewStockDocumentLineID(row.outLineID)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	inLineID, err := domain.NewStockDocumentLineID(row.inLineID)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	lineOrder, err := domain.NewLineOrder(row.lineOrder)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	itemID, err := domain.NewItemID(row.itemID)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	quantity, err := domain.NewPositiveAtomicQuantity(row.quantityAtomic)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	enteredUnit, err := domain.NewUnitCode(row.enteredUnitCode)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	enteredPackagingName, err := optionalNonEmptyText(row.enteredPackagingName)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	conversion, err := domain.NewUnitConversion(row.conversionNumeratorAtomic, row.conversionDenominator)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	inventoryValue, err := domain.NewInventoryValue(row.inventoryValueMicro)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	sourceLotID, err := domain.NewInventoryLotID(row.sourceLotID)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	allocationID, err := domain.NewLotAllocationID(row.allocationID)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	fromLocationID, err := domain.NewStockLocationID(row.fromLocationID)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	lotID, err := domain.NewInventoryLotID(row.lotID)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	toLocationID, err := domain.NewStockLocationID(row.toLocationID)
+	if err != nil {
+		return PostedTransferLine{}, err
+	}
+	return NewPostedTransferLine(
+		outLineID, inLineID, lineOrder, itemID, quantity, enteredUnit, enteredPackagingName,
+		conversion, inventoryValue, sourceLotID, allocationID, fromLocationID, lotID, toLocationID,
+	), nil
+}
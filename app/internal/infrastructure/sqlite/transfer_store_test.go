@@ -0,0 +1,125 @@
+package sqlite
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/domain"
+)
+
+func TestTransferStoreMovesLotQuantityBetweenLocations(t *testing.T) {
+	store := newAdapterTestStore(t, filepath.Join(t.TempDir(), "transfer.db"), database.DefaultOpenOptions())
+	ctx := context.Background()
+	itemID := createReportingItem(t, store, "Moved cream", true, domain.None[domain.AtomicQuantity]())
+	purchase := postReportingPurchase(t, store, itemID, "transfer-purchase", "2026-07-01", 1_000,
+		domain.None[domain.CounterpartyID](), domain.None[domain.DocumentReason](), 100, 1_000)
+	sourceLotID := purchase.Lines()[0].LotID()
+
+	fridge, err := store.CreateLocation(ctx, CreateLocationInput{
+		Name:      mustCatalogName(t, "Storefront fridge"),
+		CreatedAt: mustCatalogInstant(t, 2_000),
+	})
+	if err != nil {
+		t.Fatalf("create location: %v", err)
+	}
+
+	input := transferInputFixture(t, "transfer-1", sourceLotID, 40, fridge.ID())
+	transfer, err := store.PostTransfer(ctx, input)
+	if err != nil {
+		t.Fatalf("post transfer: %v", err)
+	}
+	line := transfer.Lines()[0]
+	if line.ItemID() != itemID || line.SourceLotID() != sourceLotID || line.Quantity().Int64() != 40 ||
+		line.InventoryValue().Int64() != 4_000_000 || line.FromLocationID().Int64() != 1 ||
+		line.ToLocationID() != fridge.ID() || line.LotID() == sourceLotID || line.AllocationID().IsZero() {
+		t.Fatalf("transfer line = %#v", line)
+	}
+	assertInventoryBalance(t, store, itemID, 100, 10_000_000, transfer.ID().Int64())
+
+	replayed, err := store.PostTransfer(ctx, input)
+	if err != nil || replayed.ID() != transfer.ID() {
+		t.Fatalf("replayed transfer = %#v, %v", replayed, err)
+	}
+	loaded, err := store.GetPostedTransfer(ctx, transfer.ID())
+	if err != nil || len(loaded.Lines()) != 1 || loaded.Lines()[0].LotID() != line.LotID() {
+		t.Fatalf("loaded transfer = %#v, %v", loaded, err)
+	}
+
+	fridgeLots, err := store.ListEligibleFEFOLots(ctx, itemID, mustPurchaseDate(t, "2026-07-02"), domain.Some(fridge.ID()))
+	if err != nil {
+		t.Fatalf("list fridge lots: %v", err)
+	}
+	if len(fridgeLots) != 1 || fridgeLots[0].Lot().ID() != line.LotID() ||
+		fridgeLots[0].LocationID() != fridge.ID() ||
+		fridgeLots[0].TransferredFromLotID() != domain.Some(sourceLotID) ||
+		fridgeLots[0].Lot().AvailableQuantity().Int64() != 40 {
+		t.Fatalf("fridge lots = %#v", fridgeLots)
+	}
+	balances, err := store.ListInventoryBalances(ctx, InventoryBalanceListParams{
+		Location: domain.Some(fridge.ID()),
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatalf("list fridge balances: %v", err)
+	}
+	if len(balances) != 1 || balances[0].Snapshot().Balance().Quantity().Int64() != 40 ||
+		balances[0].Snapshot().Balance().Value().Int64() != 4_000_000 {
+		t.Fatalf("fridge balances = %#v", balances)
+	}
+
+	sameLocation := transferInputFixture(t, "transfer-same", line.LotID(), 10, fridge.ID())
+	if _, err := store.PostTransfer(ctx, sameLocation); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("same location error = %v, want ErrValidation", err)
+	}
+	overdrawn := transferInputFixture(t, "transfer-over", sourceLotID, 61, fridge.ID())
+	if _, err := store.PostTransfer(ctx, overdrawn); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("overdrawn transfer error = %v, want ErrValidation", err)
+	}
+	_, err = store.PostReversal(ctx, PostReversalInput{
+		IdempotencyKey:   mustPurchaseIdempotencyKey(t, "transfer-reverse"),
+		TargetDocumentID: transfer.ID(),
+		OccurredOn:       mustPurchaseDate(t, "2026-07-03"),
+		PostedAt:         mustCatalogInstant(t, 4_000),
+	})
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("reverse transfer error = %v, want ErrValidation", err)
+	}
+
+	_, err = store.ArchiveLocation(ctx, ArchiveLocationInput{
+		ID:                fridge.ID(),
+		ExpectedUpdatedAt: fridge.UpdatedAt(),
+		ArchivedAt:        mustCatalogInstant(t, 5_000),
+	})
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("archive stocked location error = %v, want ErrConflict", err)
+	}
+
+	reconciliation, err := store.ReconcileInventory(ctx)
+	if err != nil {
+		t.Fatalf("reconcile: %v", err)
+	}
+	if !reconciliation.Consistent() {
+		t.Fatalf("reconciliation discrepancies = %#v", reconciliation.Discrepancies())
+	}
+}
+
+func transferInputFixture(
+	t *testing.T,
+	idempotencyKey string,
+	lotID domain.InventoryLotID,
+	quantityAtomic int64,
+	toLocationID domain.StockLocationID,
+) PostTransferInput {
+	t.Helper()
+	return PostTransferInput{
+		IdempotencyKey: mustPurchaseIdempotencyKey(t, idempotencyKey),
+		OccurredOn:     mustPurchaseDate(t, "2026-07-02"),
+		PostedAt:       mustCatalogInstant(t, 3_000),
+		Lines: []PostTransferLineInput{
+			{LotID: lotID, Quantity: mustPurchaseQuantity(t, quantityAtomic), ToLocationID: toLocationID},
+		},
+	}
+}
@@ -43,6 +43,65 @@ func (h *AdjustmentHandler) PostAdjustment(req dto.AdjustmentPostRequest) (dto.A
 	return mapAdjustmentDocument(posted), nil
 }
 
+// ListExpiredLots lists the lots an expiry write-off on the given business
+// date would consume.
+func (h *AdjustmentHandler) ListExpiredLots(before string) ([]dto.ExpiredLotResponse, error) {
+	date, err := domain.ParseBusinessDate(before)
+	if err != nil {
+		return nil, fmt.Errorf("before: %w", err)
+	}
+	lots, err := h.service.ListExpiredLots(handlerContext(), date)
+	if err != nil {
+		return nil, fmt.Errorf("list expired lots: %w", err)
+	}
+	response := make([]dto.ExpiredLotResponse, 0, len(lots))
+	for _, lot := range lots {
+		response = append(response, dto.ExpiredLotResponse{
+			LotID:                   lot.LotID.Int64(),
+			ItemID:                  lot.ItemID.Int64(),
+			ItemName:                lot.ItemName,
+			LotCode:                 optionalStringOption(lot.LotCode),
+			ExpiresOn:               lot.ExpiresOn.String(),
+			RemainingQuantityAtomic: lot.RemainingQuantity.Int64(),
+			InventoryValueMicro:     lot.InventoryValueMicro,
+		})
+	}
+	return response, nil
+}
+
+func (h *AdjustmentHandler) PostExpiredLots(req dto.ExpiredLotsPostRequest) (dto.AdjustmentDocumentResponse, error) {
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.AdjustmentDocumentResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	occurredOn, err := domain.ParseBusinessDate(req.OccurredOn)
+	if err != nil {
+		return dto.AdjustmentDocumentResponse{}, fmt.Errorf("occurred on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return dto.AdjustmentDocumentResponse{}, fmt.Errorf("notes: %w", err)
+	}
+	excluded := make([]domain.InventoryLotID, 0, len(req.ExcludedLotIDs))
+	for index, raw := range req.ExcludedLotIDs {
+		lotID, err := domain.NewInventoryLotID(raw)
+		if err != nil {
+			return dto.AdjustmentDocumentResponse{}, fmt.Errorf("excluded lot %d: %w", index+1, err)
+		}
+		excluded = append(excluded, lotID)
+	}
+	posted, err := h.service.PostExpiredLots(handlerContext(), application.ExpiredLotsPostInput{
+		IdempotencyKey: idempotencyKey,
+		OccurredOn:     occurredOn,
+		Notes:          notes,
+		ExcludedLotIDs: excluded,
+	})
+	if err != nil {
+		return dto.AdjustmentDocumentResponse{}, fmt.Errorf("post expired lots: %w", err)
+	}
+	return mapAdjustmentDocument(posted), nil
+}
+
 func parseAdjustmentPostRequest(req dto.AdjustmentPostRequest) (application.AdjustmentPostInput, error) {
 	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
 	if err != nil {
@@ -1,6 +1,9 @@
 package wails
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,25 +34,37 @@ func TestPhase5BackendSurfaceForSettingsUnitsCatalogAndCounterparties(t *testing
 		application.NewSQLiteCounterpartyStore(store),
 		clock,
 	))
-	purchaseHandler := NewPurchaseHandler(application.NewPurchaseService(
+	purchaseService := application.NewPurchaseService(
 		application.NewSQLitePurchaseStore(store),
 		clock,
-	))
-	adjustmentHandler := NewAdjustmentHandler(application.NewAdjustmentService(
+	)
+	purchaseHandler := NewPurchaseHandler(purchaseService)
+	adjustmentService := application.NewAdjustmentService(
 		application.NewSQLiteAdjustmentStore(store),
 		clock,
-	))
+	)
+	adjustmentHandler := NewAdjustmentHandler(adjustmentService)
 	reversalHandler := NewReversalHandler(application.NewReversalService(
 		application.NewSQLiteReversalStore(store),
 		clock,
 	))
-	productionHandler := NewProductionHandler(application.NewProductionService(
+	productionService := application.NewProductionService(
 		application.NewSQLiteProductionStore(store),
 		clock,
-	))
-	saleHandler := NewSaleHandler(application.NewSaleService(
+	)
+	productionHandler := NewProductionHandler(productionService)
+	saleService := application.NewSaleService(
 		application.NewSQLiteSaleStore(store),
 		clock,
+	)
+	saleHandler := NewSaleHandler(saleService)
+	returnHandler := NewReturnHandler(application.NewReturnService(
+		application.NewSQLiteReturnStore(store),
+		clock,
+	))
+	supplierReturnHandler := NewSupplierReturnHandler(application.NewSupplierReturnService(
+		application.NewSQLiteSupplierReturnStore(store),
+		clock,
 	))
 	recipeHandler := NewRecipeHandler(application.NewRecipeService(
 		application.NewSQLiteRecipeStore(store),
@@ -61,6 +76,67 @@ func TestPhase5BackendSurfaceForSettingsUnitsCatalogAndCounterparties(t *testing
 	reportingHandler := NewReportingHandler(application.NewReportingService(
 		application.NewSQLiteReportingStore(store),
 	))
+	reconciliationHandler := NewReconciliationHandler(application.NewReconciliationService(
+		application.NewSQLiteReconciliationStore(store),
+	))
+	stockDocumentHandler := NewStockDocumentHandler(application.NewStockDocumentService(
+		application.NewSQLiteStockDocumentStore(store),
+	))
+	locationHandler := NewLocationHandler(application.NewLocationService(
+		application.NewSQLiteLocationStore(store),
+		clock,
+	))
+	transferHandler := NewTransferHandler(application.NewTransferService(
+		application.NewSQLiteTransferStore(store),
+		clock,
+	))
+	pricingHandler := NewPricingHandler(application.NewPricingService(
+		application.NewSQLitePricingStore(store),
+	))
+	shoppingListHandler := NewShoppingListHandler(application.NewShoppingListService(
+		application.NewSQLiteShoppingListStore(store),
+	))
+	productionPlanHandler := NewProductionPlanHandler(application.NewProductionPlanService(
+		application.NewSQLiteRecipeStore(store),
+		application.NewSQLiteInventoryStore(store),
+	))
+	purchaseOrderHandler := NewPurchaseOrderHandler(application.NewPurchaseOrderService(
+		application.NewSQLitePurchaseOrderStore(store),
+		clock,
+	))
+	customerOrderHandler := NewCustomerOrderHandler(application.NewCustomerOrderService(
+		application.NewSQLiteCustomerOrderStore(store),
+		clock,
+	))
+	stockCountHandler := NewStockCountHandler(application.NewStockCountService(
+		application.NewSQLiteStockCountStore(store),
+		clock,
+	))
+	paymentHandler := NewPaymentHandler(application.NewPaymentService(
+		application.NewSQLitePaymentStore(store),
+		clock,
+	))
+	payableHandler := NewPayableHandler(application.NewPayableService(
+		application.NewSQLitePayableStore(store),
+		clock,
+	))
+	registerHandler := NewRegisterHandler(application.NewRegisterService(
+		application.NewSQLiteRegisterStore(store),
+		clock,
+	))
+	expenseHandler := NewExpenseHandler(application.NewExpenseService(
+		application.NewSQLiteExpenseStore(store),
+		clock,
+	))
+	priceListHandler := NewPriceListHandler(application.NewPriceListService(
+		application.NewSQLitePriceListStore(store),
+		clock,
+	))
+	draftHandler := NewDraftHandler(application.NewDraftService(
+		application.NewSQLiteDraftStore(store),
+		clock,
+		NewDraftPosters(purchaseService, saleService, adjustmentService, productionService),
+	))
 
 	settingsValue, err := settingsHandler.GetSettings()
 	if err != nil {
@@ -76,6 +152,12 @@ func TestPhase5BackendSurfaceForSettingsUnitsCatalogAndCounterparties(t *testing
 		CurrencyMinorDigits: settingsValue.CurrencyMinorDigits,
 		HourlyLaborCost:     &hourlyLaborCost,
 		DefaultGrossMargin:  &defaultGrossMargin,
+		Backup: dto.BackupPolicyRequest{
+			Enabled:         settingsValue.Backup.Enabled,
+			IntervalMinutes: 720,
+			KeepDaily:       settingsValue.Backup.KeepDaily,
+			KeepWeekly:      settingsValue.Backup.KeepWeekly,
+		},
 		ExpectedUpdatedAtMs: settingsValue.UpdatedAtMs,
 	})
 	if err != nil {
@@ -84,6 +166,9 @@ func TestPhase5BackendSurfaceForSettingsUnitsCatalogAndCounterparties(t *testing
 	if updatedSettings.HourlyLaborCost == nil || *updatedSettings.HourlyLaborCost != hourlyLaborCost {
 		t.Fatalf("hourly labor cost = %#v", updatedSettings.HourlyLaborCost)
 	}
+	if updatedSettings.Backup.IntervalMinutes != 720 {
+		t.Fatalf("backup interval = %d, want 720", updatedSettings.Backup.IntervalMinutes)
+	}
 	if updatedSettings.UpdatedAtMs != clock.now.UnixMilli() {
 		t.Fatalf("settings updated at = %d, want %d", updatedSettings.UpdatedAtMs, clock.now.UnixMilli())
 	}
@@ -583,6 +668,108 @@ func TestPhase5BackendSurfaceForSettingsUnitsCatalogAndCounterparties(t *testing
 		t.Fatalf("restored balance = %#v", restoredBalance)
 	}
 
+	recipeCost, err := recipeHandler.CostRecipeRevision(recipeValue.CurrentRevision.ID)
+	if err != nil {
+		t.Fatalf("cost recipe revision: %v", err)
+	}
+	if !recipeCost.Complete || recipeCost.TotalValueMicro != 2_750_000 ||
+		recipeCost.YieldBaseUnitCode != "g" || recipeCost.CostPerYieldBaseUnitMicro != 2_750_000 ||
+		len(recipeCost.Components) != 1 || recipeCost.Components[0].CostSource != "AVERAGE_VALUE" ||
+		recipeCost.Components[0].EnteredQuantityNumerator != 11 ||
+		recipeCost.Components[0].EnteredQuantityDenominator != 20 {
+		t.Fatalf("recipe cost = %#v", recipeCost)
+	}
+
+	suggestion, err := pricingHandler.SuggestRecipePrice(dto.PriceSuggestionRequest{
+		RevisionID: recipeValue.CurrentRevision.ID,
+	})
+	if err != nil {
+		t.Fatalf("suggest recipe price: %v", err)
+	}
+	if suggestion.MaterialValueMicro != 2_750_000 || !suggestion.LaborCostConfigured ||
+		suggestion.LaborValueMicro != 104_166_667 || suggestion.BatchValueMicro != 106_916_667 ||
+		suggestion.CostPerYieldBaseUnitMicro != 106_916_667 || suggestion.GrossMarginBasisPoints != 2_500 ||
+		suggestion.SuggestedPriceMinor != 14_256 || suggestion.CurrentPriceMinor == nil ||
+		*suggestion.CurrentPriceMinor != defaultSalePrice || !suggestion.Underpriced || !suggestion.CostComplete {
+		t.Fatalf("price suggestion = %#v", suggestion)
+	}
+	zeroMargin := int64(0)
+	overridden, err := pricingHandler.SuggestRecipePrice(dto.PriceSuggestionRequest{
+		RevisionID:             recipeValue.CurrentRevision.ID,
+		GrossMarginBasisPoints: &zeroMargin,
+	})
+	if err != nil || overridden.GrossMarginBasisPoints != 0 || overridden.SuggestedPriceMinor != 10_692 {
+		t.Fatalf("overridden price suggestion = %#v, %v", overridden, err)
+	}
+	underpriced, err := pricingHandler.ListUnderpricedItems(dto.UnderpricedItemsRequest{})
+	if err != nil {
+		t.Fatalf("list underpriced items: %v", err)
+	}
+	if len(underpriced.Items) != 1 || underpriced.Items[0].OutputItemID != outputItem.ID ||
+		underpriced.Items[0].RevisionID != recipeValue.CurrentRevision.ID || len(underpriced.CostIncomplete) != 0 {
+		t.Fatalf("underpriced items = %#v", underpriced)
+	}
+
+	scaledOutput := int64(333)
+	scaled, err := productionHandler.ScaleProduction(dto.ProductionScaleRequest{
+		RecipeRevisionID:     recipeValue.CurrentRevision.ID,
+		OutputQuantityAtomic: &scaledOutput,
+	})
+	if err != nil {
+		t.Fatalf("scale production: %v", err)
+	}
+	if scaled.OutputItemID != outputItem.ID || scaled.MultiplierNumerator != 333 || scaled.MultiplierDenominator != 1_000 ||
+		scaled.OutputQuantityAtomic != 333 || scaled.OutputResidueNumerator != 0 || len(scaled.Inputs) != 1 ||
+		scaled.Inputs[0].Input.ItemID != restoredItem.ID || scaled.Inputs[0].Input.QuantityAtomic != 184 ||
+		scaled.Inputs[0].ExactNumerator != 3_663 || scaled.Inputs[0].ExactDenominator != 20 ||
+		scaled.Inputs[0].ResidueNumerator != 17 || scaled.Inputs[0].ResidueDenominator != 20 {
+		t.Fatalf("scaled production = %#v", scaled)
+	}
+
+	shoppingRequest := dto.ShoppingListRequest{
+		BusinessDate: "2026-07-17",
+		Runs: []dto.ShoppingListRunRequest{
+			{RecipeRevisionID: recipeValue.CurrentRevision.ID, QuantityAtomic: 1_000},
+		},
+	}
+	shoppingList, err := shoppingListHandler.BuildShoppingList(shoppingRequest)
+	if err != nil {
+		t.Fatalf("build shopping list: %v", err)
+	}
+	if len(shoppingList.Groups) != 1 || shoppingList.Groups[0].SupplierID == nil ||
+		*shoppingList.Groups[0].SupplierID != restored.ID || len(shoppingList.Groups[0].Lines) != 1 ||
+		shoppingList.Groups[0].Lines[0].ItemID != restoredItem.ID ||
+		shoppingList.Groups[0].Lines[0].RequiredQuantityAtomic != 550 ||
+		shoppingList.Groups[0].Lines[0].AvailableQuantityAtomic != 1_000 ||
+		shoppingList.Groups[0].Lines[0].ReorderQuantityAtomic != 5_000 ||
+		shoppingList.Groups[0].Lines[0].ToBuyQuantityAtomic != 550 ||
+		shoppingList.Groups[0].Lines[0].Packages != nil {
+		t.Fatalf("shopping list = %#v", shoppingList)
+	}
+	shoppingCSV, err := shoppingListHandler.ExportShoppingListCSV(shoppingRequest)
+	if err != nil {
+		t.Fatalf("export shopping list: %v", err)
+	}
+	if rows := strings.Split(strings.TrimSuffix(shoppingCSV, "\n"), "\n"); len(rows) != 2 ||
+		!strings.HasPrefix(rows[0], "supplier_id,supplier,") || !strings.HasSuffix(rows[1], ",g,0.55,1,5,0.55,,,") {
+		t.Fatalf("shopping list csv = %q", shoppingCSV)
+	}
+
+	plan, err := productionPlanHandler.PlanProduction(dto.ProductionPlanRequest{
+		ItemID:         outputItem.ID,
+		QuantityAtomic: 1_000,
+	})
+	if err != nil {
+		t.Fatalf("plan production: %v", err)
+	}
+	if len(plan.Runs) != 1 || plan.Runs[0].RecipeID != recipeValue.ID ||
+		plan.Runs[0].RecipeRevisionID != recipeValue.CurrentRevision.ID || plan.Runs[0].OutputItemID != outputItem.ID ||
+		plan.Runs[0].Level != 0 || plan.Runs[0].FromStockQuantityAtomic != 0 || plan.Runs[0].QuantityAtomic != 1_000 ||
+		len(plan.Runs[0].Inputs) != 1 || plan.Runs[0].Inputs[0].ItemID != restoredItem.ID ||
+		plan.Runs[0].Inputs[0].QuantityAtomic != 550 || len(plan.Purchases) != 0 {
+		t.Fatalf("production plan = %#v", plan)
+	}
+
 	clock.now = must(domain.UTCInstantFromUnixMilli(18_000))
 	outputExpiresOn := "2026-07-20"
 	production, err := productionHandler.PostProduction(dto.ProductionPostRequest{
@@ -626,6 +813,25 @@ func TestPhase5BackendSurfaceForSettingsUnitsCatalogAndCounterparties(t *testing
 		t.Fatalf("production output line = %#v", production.OutputLine)
 	}
 
+	loadedProduction, err := productionHandler.GetProduction(production.ID)
+	if err != nil {
+		t.Fatalf("get production: %v", err)
+	}
+	if loadedProduction.ID != production.ID || len(loadedProduction.InputLines) != 1 ||
+		len(loadedProduction.InputLines[0].Allocations) != 1 {
+		t.Fatalf("loaded production = %#v", loadedProduction)
+	}
+	productionPage, err := productionHandler.ListProductions(dto.ProductionListRequest{
+		OutputItemID: &outputItem.ID,
+		PageSize:     10,
+	})
+	if err != nil {
+		t.Fatalf("list productions: %v", err)
+	}
+	if len(productionPage.Items) != 1 || productionPage.Items[0].ID != production.ID || productionPage.Next != nil {
+		t.Fatalf("production page = %#v", productionPage)
+	}
+
 	componentBalance, err := inventoryHandler.GetInventoryBalance(restoredItem.ID)
 	if err != nil {
 		t.Fatalf("get component balance after production: %v", err)
@@ -688,6 +894,16 @@ func TestPhase5BackendSurfaceForSettingsUnitsCatalogAndCounterparties(t *testing
 		t.Fatalf("sale page = %#v", salePage)
 	}
 
+	saleKind := "SALE"
+	documentPage, err := stockDocumentHandler.ListDocuments(dto.StockDocumentListRequest{Kind: &saleKind, Reversal: "NOT_REVERSED"})
+	if err != nil {
+		t.Fatalf("list documents: %v", err)
+	}
+	if len(documentPage.Items) != 1 || documentPage.Items[0].ID != sale.ID || documentPage.Items[0].LineCount != 1 ||
+		documentPage.Items[0].CommercialTotalMinor == nil || *documentPage.Items[0].CommercialTotalMinor != 1_000 {
+		t.Fatalf("document page = %#v", documentPage)
+	}
+
 	soldOutputBalance, err := inventoryHandler.GetInventoryBalance(outputItem.ID)
 	if err != nil {
 		t.Fatalf("get output balance after sale: %v", err)
@@ -715,10 +931,12 @@ func TestPhase5BackendSurfaceForSettingsUnitsCatalogAndCounterparties(t *testing
 		t.Fatalf("sales report = %#v", salesReport)
 	}
 
-	inventoryReport, err := reportingHandler.GetInventoryReport(dto.ReportingPeriodRequest{
-		FromOccurredOn: "2026-07-01",
-		ToOccurredOn:   "2026-07-18",
-		Granularity:    "MONTH",
+	inventoryReport, err := reportingHandler.GetInventoryReport(dto.InventoryReportRequest{
+		ReportingPeriodRequest: dto.ReportingPeriodRequest{
+			FromOccurredOn: "2026-07-01",
+			ToOccurredOn:   "2026-07-18",
+			Granularity:    "MONTH",
+		},
 	})
 	if err != nil {
 		t.Fatalf("get inventory report: %v", err)
@@ -796,9 +1014,631 @@ func TestPhase5BackendSurfaceForSettingsUnitsCatalogAndCounterparties(t *testing
 	if err != nil {
 		t.Fatalf("get category mix report: %v", err)
 	}
-	if categoryMix.Available || categoryMix.UnavailableReason == nil || len(categoryMix.Rows) != 0 {
+	if !categoryMix.Available || categoryMix.CommercialTotalMinor != 1_000 || len(categoryMix.Rows) != 1 ||
+		categoryMix.Rows[0].CategoryID != nil || categoryMix.Rows[0].ShareBasisPoints != 10_000 {
 		t.Fatalf("category mix = %#v", categoryMix)
 	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(20_000))
+	customerReturn, err := returnHandler.PostReturn(dto.ReturnPostRequest{
+		IdempotencyKey: "return-cake-1",
+		SaleDocumentID: sale.ID,
+		OccurredOn:     "2026-07-19",
+		Lines:          []dto.ReturnLineRequest{{SaleLineID: sale.Lines[0].ID, QuantityAtomic: 5}},
+	})
+	if err != nil {
+		t.Fatalf("post return: %v", err)
+	}
+	if customerReturn.ID == 0 || customerReturn.SaleDocumentID != sale.ID ||
+		customerReturn.ReasonCode != "CUSTOMER_RETURN" || customerReturn.PostedAtMs != clock.now.UnixMilli() ||
+		len(customerReturn.Lines) != 1 ||
+		customerReturn.Lines[0].QuantityAtomic != 5 ||
+		customerReturn.Lines[0].InventoryValueMicro != 150_000 ||
+		customerReturn.Lines[0].RefundMinor != 250 ||
+		len(customerReturn.Lines[0].Allocations) != 1 ||
+		customerReturn.Lines[0].Allocations[0].RestoresAllocationID != sale.Lines[0].Allocations[0].ID {
+		t.Fatalf("customer return = %#v", customerReturn)
+	}
+	saleReturns, err := returnHandler.ListSaleReturns(sale.ID)
+	if err != nil {
+		t.Fatalf("list sale returns: %v", err)
+	}
+	if len(saleReturns) != 1 || saleReturns[0].ID != customerReturn.ID {
+		t.Fatalf("sale returns = %#v", saleReturns)
+	}
+	nettedSalesReport, err := reportingHandler.GetSalesReport(dto.ReportingPeriodRequest{
+		FromOccurredOn: "2026-07-01",
+		ToOccurredOn:   "2026-07-31",
+		Granularity:    "MONTH",
+	})
+	if err != nil {
+		t.Fatalf("get netted sales report: %v", err)
+	}
+	if nettedSalesReport.TotalSalesCount != 1 || nettedSalesReport.ReturnCount != 1 ||
+		nettedSalesReport.RefundMinor != 250 ||
+		nettedSalesReport.CommercialTotalMinor != 750 ||
+		nettedSalesReport.COGSInventoryValueMicro != 450_000 {
+		t.Fatalf("netted sales report = %#v", nettedSalesReport)
+	}
+
+	supplierReturn, err := supplierReturnHandler.PostSupplierReturn(dto.SupplierReturnPostRequest{
+		IdempotencyKey:     "supplier-return-flour-1",
+		PurchaseDocumentID: purchase.ID,
+		OccurredOn:         "2026-07-19",
+		Lines:              []dto.SupplierReturnLineRequest{{PurchaseLineID: purchase.Lines[0].ID, QuantityAtomic: 100}},
+	})
+	if err != nil {
+		t.Fatalf("post supplier return: %v", err)
+	}
+	if supplierReturn.ID == 0 || supplierReturn.PurchaseDocumentID != purchase.ID ||
+		supplierReturn.ReasonCode != "SUPPLIER_RETURN" || supplierReturn.CounterpartyID == nil ||
+		*supplierReturn.CounterpartyID != restored.ID || len(supplierReturn.Lines) != 1 ||
+		supplierReturn.Lines[0].InventoryValueMicro != 500_000 ||
+		supplierReturn.Lines[0].CreditMinor != 50 ||
+		supplierReturn.Lines[0].LotID != purchase.Lines[0].LotID {
+		t.Fatalf("supplier return = %#v", supplierReturn)
+	}
+	purchaseReturns, err := supplierReturnHandler.ListPurchaseReturns(purchase.ID)
+	if err != nil {
+		t.Fatalf("list purchase returns: %v", err)
+	}
+	if len(purchaseReturns) != 1 || purchaseReturns[0].ID != supplierReturn.ID {
+		t.Fatalf("purchase returns = %#v", purchaseReturns)
+	}
+	nettedPurchaseReport, err := reportingHandler.GetPurchaseReport(dto.ReportingPeriodRequest{
+		FromOccurredOn: "2026-07-01",
+		ToOccurredOn:   "2026-07-31",
+		Granularity:    "MONTH",
+	})
+	if err != nil {
+		t.Fatalf("get netted purchase report: %v", err)
+	}
+	if len(nettedPurchaseReport.PurchaseSpendSeries) != 1 ||
+		nettedPurchaseReport.PurchaseSpendSeries[0].DocumentCount != 1 ||
+		nettedPurchaseReport.PurchaseSpendSeries[0].CommercialTotalMinor != 450 ||
+		len(nettedPurchaseReport.TopSuppliersBySpend) != 1 ||
+		nettedPurchaseReport.TopSuppliersBySpend[0].CommercialTotalMinor != 450 {
+		t.Fatalf("netted purchase report = %#v", nettedPurchaseReport)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(21_000))
+	fridge, err := locationHandler.CreateLocation(dto.LocationWriteRequest{Name: "Storefront fridge"})
+	if err != nil {
+		t.Fatalf("create location: %v", err)
+	}
+	if fridge.ID == 0 || fridge.IsDefault || fridge.CreatedAtMs != clock.now.UnixMilli() {
+		t.Fatalf("location = %#v", fridge)
+	}
+	sourceLots, err := inventoryHandler.ListEligibleFEFOLots(restoredItem.ID, "2026-07-19", nil)
+	if err != nil {
+		t.Fatalf("list eligible lots before transfer: %v", err)
+	}
+	if len(sourceLots) == 0 || sourceLots[0].LocationID != 1 {
+		t.Fatalf("eligible lots before transfer = %#v", sourceLots)
+	}
+	transfer, err := transferHandler.PostTransfer(dto.TransferPostRequest{
+		IdempotencyKey: "transfer-flour-fridge",
+		OccurredOn:     "2026-07-19",
+		Lines: []dto.TransferLineRequest{{
+			LotID: sourceLots[0].ID, QuantityAtomic: 10, ToLocationID: fridge.ID,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("post transfer: %v", err)
+	}
+	if transfer.ID == 0 || len(transfer.Lines) != 1 || transfer.Lines[0].SourceLotID != sourceLots[0].ID ||
+		transfer.Lines[0].FromLocationID != 1 || transfer.Lines[0].ToLocationID != fridge.ID {
+		t.Fatalf("transfer = %#v", transfer)
+	}
+	fridgeLots, err := inventoryHandler.ListEligibleFEFOLots(restoredItem.ID, "2026-07-19", &fridge.ID)
+	if err != nil {
+		t.Fatalf("list fridge lots: %v", err)
+	}
+	if len(fridgeLots) != 1 || fridgeLots[0].ID != transfer.Lines[0].LotID ||
+		fridgeLots[0].AvailableQuantity != 10 || fridgeLots[0].TransferredFromLotID == nil ||
+		*fridgeLots[0].TransferredFromLotID != sourceLots[0].ID {
+		t.Fatalf("fridge lots = %#v", fridgeLots)
+	}
+	fridgeBalances, err := inventoryHandler.ListInventoryBalances(dto.InventoryBalanceListRequest{
+		LocationID: &fridge.ID, PageSize: 10,
+	})
+	if err != nil {
+		t.Fatalf("list fridge balances: %v", err)
+	}
+	fridgeQuantity := int64(0)
+	for _, balance := range fridgeBalances.Items {
+		fridgeQuantity += balance.QuantityAtomic
+	}
+	if fridgeQuantity != 10 {
+		t.Fatalf("fridge balances = %#v", fridgeBalances)
+	}
+	fridgeReport, err := reportingHandler.GetInventoryReport(dto.InventoryReportRequest{
+		ReportingPeriodRequest: dto.ReportingPeriodRequest{
+			FromOccurredOn: "2026-07-01",
+			ToOccurredOn:   "2026-07-31",
+			Granularity:    "MONTH",
+		},
+		LocationID: &fridge.ID,
+	})
+	if err != nil {
+		t.Fatalf("get fridge inventory report: %v", err)
+	}
+	if fridgeReport.LocationID == nil || *fridgeReport.LocationID != fridge.ID ||
+		fridgeReport.TotalInventoryValueMicro != transfer.Lines[0].InventoryValueMicro {
+		t.Fatalf("fridge inventory report = %#v", fridgeReport)
+	}
+	clock.now = must(domain.UTCInstantFromUnixMilli(22_000))
+	if _, err := locationHandler.ArchiveLocation(fridge.ID, dto.VersionedRequest{
+		ExpectedUpdatedAtMs: fridge.UpdatedAtMs,
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("archive stocked location error = %v", err)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(23_000))
+	order, err := purchaseOrderHandler.CreatePurchaseOrder(dto.PurchaseOrderWriteRequest{
+		SupplierID: restored.ID,
+		OrderedOn:  "2026-07-20",
+		ExpectedOn: stringPointer("2026-07-22"),
+		Lines: []dto.PurchaseOrderLineRequest{{
+			ItemID: restoredItem.ID, QuantityAtomic: 300, EnteredUnitCode: "g",
+			ConversionNumeratorAtomic: 1, ConversionDenominator: 1, ExpectedTotalMinor: 1_000,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("create purchase order: %v", err)
+	}
+	if order.Status != "DRAFT" || len(order.Lines) != 1 || order.Lines[0].OutstandingQuantityAtomic != 300 {
+		t.Fatalf("purchase order = %#v", order)
+	}
+	clock.now = must(domain.UTCInstantFromUnixMilli(24_000))
+	order, err = purchaseOrderHandler.SendPurchaseOrder(order.ID, dto.VersionedPurchaseOrderRequest{
+		ExpectedUpdatedAtMs: order.UpdatedAtMs,
+	})
+	if err != nil || order.Status != "SENT" {
+		t.Fatalf("send purchase order = %#v, %v", order, err)
+	}
+	clock.now = must(domain.UTCInstantFromUnixMilli(25_000))
+	receipt, err := purchaseOrderHandler.ReceivePurchaseOrder(order.ID, dto.PurchaseOrderReceiveRequest{
+		ExpectedUpdatedAtMs: order.UpdatedAtMs,
+		IdempotencyKey:      "purchase-order-receipt-1",
+		OccurredOn:          "2026-07-21",
+		Lines: []dto.PurchaseOrderReceiptLineRequest{{
+			LineID: order.Lines[0].ID, QuantityAtomic: 100, LotCode: stringPointer("PO-LOT-1"),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("receive purchase order: %v", err)
+	}
+	if receipt.Order.Status != "PARTIALLY_RECEIVED" || receipt.Order.Lines[0].ReceivedQuantityAtomic != 100 ||
+		receipt.Purchase.CounterpartyID == nil || *receipt.Purchase.CounterpartyID != restored.ID ||
+		receipt.Purchase.Lines[0].CommercialTotalMinor != 333 || receipt.Purchase.PostedAtMs != clock.now.UnixMilli() {
+		t.Fatalf("purchase order receipt = %#v", receipt)
+	}
+	orderReceipts, err := purchaseOrderHandler.ListPurchaseOrderReceipts(order.ID)
+	if err != nil || len(orderReceipts) != 1 || orderReceipts[0].ID != receipt.Purchase.ID {
+		t.Fatalf("purchase order receipts = %#v, %v", orderReceipts, err)
+	}
+	clock.now = must(domain.UTCInstantFromUnixMilli(26_000))
+	closedOrder, err := purchaseOrderHandler.ClosePurchaseOrder(order.ID, dto.VersionedPurchaseOrderRequest{
+		ExpectedUpdatedAtMs: receipt.Order.UpdatedAtMs,
+	})
+	if err != nil || closedOrder.Status != "CLOSED" || closedOrder.Lines[0].OutstandingQuantityAtomic != 200 {
+		t.Fatalf("close purchase order = %#v, %v", closedOrder, err)
+	}
+	orderPage, err := purchaseOrderHandler.ListPurchaseOrders(dto.PurchaseOrderListRequest{Status: stringPointer("CLOSED")})
+	if err != nil || len(orderPage.Items) != 1 || orderPage.Items[0].ID != order.ID {
+		t.Fatalf("purchase order page = %#v, %v", orderPage, err)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(27_000))
+	orderCustomer, err := counterpartyHandler.CreateCounterparty(dto.CounterpartyWriteRequest{
+		Name:  "Party Customer",
+		Roles: []string{"CUSTOMER"},
+	})
+	if err != nil {
+		t.Fatalf("create order customer: %v", err)
+	}
+	customerOrder, err := customerOrderHandler.CreateCustomerOrder(dto.CustomerOrderWriteRequest{
+		CustomerID:   orderCustomer.ID,
+		OrderedOn:    "2026-07-18",
+		DueOn:        "2026-07-19",
+		DepositMinor: 200,
+		Lines: []dto.CustomerOrderLineRequest{{
+			ItemID: outputItem.ID, QuantityAtomic: 10, EnteredUnitCode: "g",
+			ConversionNumeratorAtomic: 1_000, ConversionDenominator: 1, AgreedTotalMinor: 700,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("create customer order: %v", err)
+	}
+	if customerOrder.Status != "OPEN" || customerOrder.BalanceDueMinor != 500 || customerOrder.SaleDocumentID != nil {
+		t.Fatalf("customer order = %#v", customerOrder)
+	}
+	calendar, err := customerOrderHandler.ListCustomerOrderCalendar(dto.CustomerOrderCalendarRequest{
+		From: "2026-07-18", To: "2026-07-31", Status: stringPointer("OPEN"),
+	})
+	if err != nil || len(calendar) != 1 || calendar[0].DueOn != "2026-07-19" ||
+		len(calendar[0].Orders) != 1 || calendar[0].Orders[0].ID != customerOrder.ID {
+		t.Fatalf("customer order calendar = %#v, %v", calendar, err)
+	}
+	clock.now = must(domain.UTCInstantFromUnixMilli(28_000))
+	fulfilment, err := customerOrderHandler.FulfilCustomerOrder(customerOrder.ID, dto.CustomerOrderFulfilRequest{
+		ExpectedUpdatedAtMs: customerOrder.UpdatedAtMs,
+		IdempotencyKey:      "customer-order-fulfil-1",
+		OccurredOn:          "2026-07-19",
+	})
+	if err != nil {
+		t.Fatalf("fulfil customer order: %v", err)
+	}
+	if fulfilment.Order.Status != "FULFILLED" || fulfilment.Order.SaleDocumentID == nil ||
+		*fulfilment.Order.SaleDocumentID != fulfilment.Sale.ID ||
+		fulfilment.Sale.CounterpartyID == nil || *fulfilment.Sale.CounterpartyID != orderCustomer.ID ||
+		fulfilment.Sale.Lines[0].CommercialTotalMinor != 700 || fulfilment.Sale.PostedAtMs != clock.now.UnixMilli() {
+		t.Fatalf("customer order fulfilment = %#v", fulfilment)
+	}
+	clock.now = must(domain.UTCInstantFromUnixMilli(29_000))
+	if _, err := customerOrderHandler.CancelCustomerOrder(customerOrder.ID, dto.VersionedCustomerOrderRequest{
+		ExpectedUpdatedAtMs: fulfilment.Order.UpdatedAtMs,
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("cancel fulfilled customer order error = %v", err)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(30_000))
+	draft, err := draftHandler.SaveDraft(dto.DraftSaveRequest{
+		Kind:    "PURCHASE",
+		DraftID: "purchase-draft-1",
+		Payload: `{"idempotencyKey": "ignored", "occurredOn": "2026-07-19", "lines": []}`,
+	})
+	if err != nil {
+		t.Fatalf("save draft: %v", err)
+	}
+	draft, err = draftHandler.SaveDraft(dto.DraftSaveRequest{
+		Kind:    "PURCHASE",
+		DraftID: "purchase-draft-1",
+		Payload: `{"occurredOn": "2026-07-19", "lines": [{"itemId": ` + strconv.FormatInt(restoredItem.ID, 10) +
+			`, "quantityAtomic": 500, "enteredUnitCode": "g", "conversionNumeratorAtomic": 1000,` +
+			` "conversionDenominator": 1, "commercialTotalMinor": 250, "lotCode": "LOT-DRAFT", "expiresOn": "2026-12-31"}]}`,
+		ExpectedUpdatedAtMs: &draft.UpdatedAtMs,
+	})
+	if err != nil || draft.UpdatedAtMs <= draft.CreatedAtMs {
+		t.Fatalf("resaved draft = %#v, %v", draft, err)
+	}
+	drafts, err := draftHandler.ListDrafts(stringPointer("PURCHASE"))
+	if err != nil || len(drafts) != 1 || drafts[0].DraftID != "purchase-draft-1" {
+		t.Fatalf("purchase drafts = %#v, %v", drafts, err)
+	}
+	postedDraft, err := draftHandler.PostDraft(dto.VersionedDraftRequest{
+		Kind: "PURCHASE", DraftID: "purchase-draft-1", ExpectedUpdatedAtMs: draft.UpdatedAtMs,
+	})
+	if err != nil {
+		t.Fatalf("post draft: %v", err)
+	}
+	draftPurchase, err := purchaseHandler.GetPurchase(postedDraft.DocumentID)
+	if err != nil || draftPurchase.IdempotencyKey != "purchase-draft-1" || draftPurchase.Lines[0].CommercialTotalMinor != 250 {
+		t.Fatalf("draft purchase = %#v, %v", draftPurchase, err)
+	}
+	if drafts, err := draftHandler.ListDrafts(nil); err != nil || len(drafts) != 0 {
+		t.Fatalf("drafts after posting = %#v, %v", drafts, err)
+	}
+	if _, err := draftHandler.SaveDraft(dto.DraftSaveRequest{
+		Kind: "PURCHASE", DraftID: "purchase-draft-1", Payload: `{}`,
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("resave posted draft error = %v", err)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(31_000))
+	stockCount, err := stockCountHandler.StartStockCount(dto.StockCountStartRequest{
+		CountedOn: "2026-07-20",
+		Items:     []dto.StockCountItemRequest{{ItemID: restoredItem.ID}},
+	})
+	if err != nil || stockCount.Status != "OPEN" || len(stockCount.Lines) != 1 {
+		t.Fatalf("start stock count = %#v, %v", stockCount, err)
+	}
+	observed := stockCount.Lines[0].ExpectedQuantityAtomic - 100
+	clock.now = must(domain.UTCInstantFromUnixMilli(32_000))
+	stockCount, err = stockCountHandler.RecordStockCount(stockCount.ID, dto.StockCountRecordRequest{
+		ExpectedUpdatedAtMs: stockCount.UpdatedAtMs,
+		Observations: []dto.StockCountObservationRequest{{
+			LineID: stockCount.Lines[0].ID, ObservedQuantityAtomic: &observed,
+		}},
+	})
+	if err != nil || stockCount.Lines[0].VarianceAtomic == nil || *stockCount.Lines[0].VarianceAtomic != -100 ||
+		stockCount.Lines[0].VarianceValueMicro == nil || *stockCount.Lines[0].VarianceValueMicro >= 0 {
+		t.Fatalf("recorded stock count = %#v, %v", stockCount, err)
+	}
+	clock.now = must(domain.UTCInstantFromUnixMilli(33_000))
+	countClosing, err := stockCountHandler.CloseStockCount(stockCount.ID, dto.StockCountCloseRequest{
+		ExpectedUpdatedAtMs: stockCount.UpdatedAtMs, IdempotencyKey: "stock-count-1",
+	})
+	if err != nil || countClosing.Count.Status != "CLOSED" || countClosing.Adjustment == nil ||
+		countClosing.Adjustment.ReasonCode != "PHYSICAL_COUNT" || len(countClosing.Adjustment.Lines) != 1 ||
+		countClosing.Adjustment.Lines[0].Direction != "OUT" || countClosing.Adjustment.Lines[0].QuantityAtomic != 100 {
+		t.Fatalf("stock count closing = %#v, %v", countClosing, err)
+	}
+	countPage, err := stockCountHandler.ListStockCounts(dto.StockCountListRequest{Status: stringPointer("CLOSED")})
+	if err != nil || len(countPage.Items) != 1 || countPage.Items[0].AdjustmentDocumentID == nil ||
+		*countPage.Items[0].AdjustmentDocumentID != countClosing.Adjustment.ID {
+		t.Fatalf("closed stock counts = %#v, %v", countPage, err)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(34_000))
+	expiredLots, err := adjustmentHandler.ListExpiredLots("2027-01-01")
+	if err != nil || len(expiredLots) == 0 {
+		t.Fatalf("expired lots = %#v, %v", expiredLots, err)
+	}
+	excludedLotIDs := make([]int64, 0, len(expiredLots)-1)
+	for _, lot := range expiredLots[1:] {
+		excludedLotIDs = append(excludedLotIDs, lot.LotID)
+	}
+	expiry, err := adjustmentHandler.PostExpiredLots(dto.ExpiredLotsPostRequest{
+		IdempotencyKey: "expiry-1", OccurredOn: "2027-01-01", ExcludedLotIDs: excludedLotIDs,
+	})
+	if err != nil || expiry.ReasonCode != "EXPIRY" || len(expiry.Lines) != 1 ||
+		expiry.Lines[0].QuantityAtomic != expiredLots[0].RemainingQuantityAtomic ||
+		expiry.Lines[0].InventoryValueMicro != expiredLots[0].InventoryValueMicro {
+		t.Fatalf("expiry write-off = %#v, %v", expiry, err)
+	}
+	if _, err := adjustmentHandler.PostExpiredLots(dto.ExpiredLotsPostRequest{
+		IdempotencyKey: "expiry-2", OccurredOn: "2027-01-01", ExcludedLotIDs: []int64{expiredLots[0].LotID},
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("written-off lot exclusion error = %v", err)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(35_000))
+	pix, err := paymentHandler.RecordSalePayment(dto.SalePaymentRecordRequest{
+		IdempotencyKey: "sale-payment-1", SaleDocumentID: fulfilment.Sale.ID,
+		Method: "PIX", AmountMinor: 300, ReceivedOn: "2026-07-19",
+	})
+	if err != nil || pix.Method != "PIX" || pix.RecordedAtMs != clock.now.UnixMilli() {
+		t.Fatalf("record pix payment = %#v, %v", pix, err)
+	}
+	card, err := paymentHandler.RecordSalePayment(dto.SalePaymentRecordRequest{
+		IdempotencyKey: "sale-payment-2", SaleDocumentID: fulfilment.Sale.ID,
+		Method: "CARD", AmountMinor: 400, ReceivedOn: "2026-07-19",
+	})
+	if err != nil {
+		t.Fatalf("record card payment: %v", err)
+	}
+	if _, err := paymentHandler.RecordSalePayment(dto.SalePaymentRecordRequest{
+		IdempotencyKey: "sale-payment-3", SaleDocumentID: fulfilment.Sale.ID,
+		Method: "CASH", AmountMinor: 1, ReceivedOn: "2026-07-19",
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("overpayment error = %v", err)
+	}
+	cardReversal, err := paymentHandler.ReverseSalePayment(card.ID, dto.SalePaymentReverseRequest{
+		IdempotencyKey: "sale-payment-reversal-1", ReceivedOn: "2026-07-20",
+	})
+	if err != nil || cardReversal.ReversesPaymentID == nil || *cardReversal.ReversesPaymentID != card.ID ||
+		cardReversal.AmountMinor != 400 {
+		t.Fatalf("card payment reversal = %#v, %v", cardReversal, err)
+	}
+	saleBalance, err := paymentHandler.GetSaleBalance(fulfilment.Sale.ID)
+	if err != nil || saleBalance.ReceivableMinor != 700 || saleBalance.SettledMinor != 300 ||
+		saleBalance.OutstandingMinor != 400 || len(saleBalance.Payments) != 3 {
+		t.Fatalf("sale balance = %#v, %v", saleBalance, err)
+	}
+	customerBalance, err := paymentHandler.GetCustomerBalance(orderCustomer.ID)
+	if err != nil || customerBalance.OutstandingMinor != 400 || len(customerBalance.Sales) != 1 {
+		t.Fatalf("customer balance = %#v, %v", customerBalance, err)
+	}
+	aging, err := paymentHandler.GetReceivablesAging("2026-08-25")
+	if err != nil {
+		t.Fatalf("receivables aging: %v", err)
+	}
+	var customerAging *dto.CustomerAgingResponse
+	for index := range aging.Customers {
+		if customer := aging.Customers[index].CustomerID; customer != nil && *customer == orderCustomer.ID {
+			customerAging = &aging.Customers[index]
+		}
+	}
+	if customerAging == nil || customerAging.SaleCount != 1 || customerAging.Buckets.Days31To60Minor != 400 ||
+		aging.Totals.TotalMinor < 400 {
+		t.Fatalf("receivables aging = %#v", aging)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(36_000))
+	payable, err := payableHandler.SchedulePurchasePayable(purchase.ID, dto.PurchasePayableScheduleRequest{
+		Installments: []dto.InstallmentRequest{
+			{DueOn: "2026-07-31", AmountMinor: 200},
+			{DueOn: "2026-08-31", AmountMinor: 300},
+		},
+	})
+	if err != nil || payable.SupplierID != restored.ID || payable.OwedMinor != 450 ||
+		len(payable.Installments) != 2 || payable.Installments[0].OpenMinor != 150 ||
+		payable.CreatedAtMs != clock.now.UnixMilli() {
+		t.Fatalf("schedule purchase payable = %#v, %v", payable, err)
+	}
+	supplierPix, err := payableHandler.RecordSupplierPayment(dto.SupplierPaymentRecordRequest{
+		IdempotencyKey: "supplier-payment-1", PurchaseDocumentID: purchase.ID,
+		Method: "PIX", AmountMinor: 300, PaidOn: "2026-08-01",
+	})
+	if err != nil || supplierPix.RecordedAtMs != clock.now.UnixMilli() {
+		t.Fatalf("record supplier payment = %#v, %v", supplierPix, err)
+	}
+	if _, err := payableHandler.RecordSupplierPayment(dto.SupplierPaymentRecordRequest{
+		IdempotencyKey: "supplier-payment-2", PurchaseDocumentID: purchase.ID,
+		Method: "CASH", AmountMinor: 151, PaidOn: "2026-08-01",
+	}); !errors.Is(err, domain.ErrValidation) {
+		t.Fatalf("supplier overpayment error = %v", err)
+	}
+	supplierReversal, err := payableHandler.ReverseSupplierPayment(supplierPix.ID, dto.SupplierPaymentReverseRequest{
+		IdempotencyKey: "supplier-payment-reversal-1", PaidOn: "2026-08-02",
+	})
+	if err != nil || supplierReversal.ReversesPaymentID == nil || *supplierReversal.ReversesPaymentID != supplierPix.ID {
+		t.Fatalf("supplier payment reversal = %#v, %v", supplierReversal, err)
+	}
+	payable, err = payableHandler.GetPurchasePayable(purchase.ID)
+	if err != nil || payable.PaidMinor != 0 || payable.OutstandingMinor != 450 || len(payable.Payments) != 2 {
+		t.Fatalf("purchase payable = %#v, %v", payable, err)
+	}
+	duePayables, err := payableHandler.ListDuePayables("2026-08-26", &restored.ID)
+	if err != nil || len(duePayables.Suppliers) != 1 || len(duePayables.Suppliers[0].Installments) != 2 ||
+		!duePayables.Suppliers[0].Installments[0].Overdue ||
+		duePayables.OverdueMinor != 150 || duePayables.DueThisWeekMinor != 300 {
+		t.Fatalf("due payables = %#v, %v", duePayables, err)
+	}
+	payablesReport, err := reportingHandler.GetPurchaseReport(dto.ReportingPeriodRequest{
+		FromOccurredOn: "2026-07-01",
+		ToOccurredOn:   "2026-07-31",
+		Granularity:    "MONTH",
+	})
+	if err != nil || payablesReport.Payables != (dto.PurchasePayableTotalsResponse{
+		PayableCount: 1, OwedMinor: 450, OutstandingMinor: 450,
+	}) {
+		t.Fatalf("purchase report payables = %#v, %v", payablesReport.Payables, err)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(37_000))
+	if _, err := registerHandler.GetOpenRegisterSession(); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("closed register error = %v", err)
+	}
+	register, err := registerHandler.OpenRegisterSession(dto.RegisterOpenRequest{
+		IdempotencyKey: "register-open-1", OpeningFloatMinor: 500,
+	})
+	if err != nil || !register.Open || register.OpenedAtMs != clock.now.UnixMilli() ||
+		register.Totals.ExpectedCashMinor != 500 {
+		t.Fatalf("open register session = %#v, %v", register, err)
+	}
+	if _, err := registerHandler.OpenRegisterSession(dto.RegisterOpenRequest{
+		IdempotencyKey: "register-open-2", OpeningFloatMinor: 0,
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second open register error = %v", err)
+	}
+	cashPayment, err := paymentHandler.RecordSalePayment(dto.SalePaymentRecordRequest{
+		IdempotencyKey: "sale-payment-4", SaleDocumentID: fulfilment.Sale.ID,
+		Method: "CASH", AmountMinor: 100, ReceivedOn: "2026-07-21",
+	})
+	if err != nil {
+		t.Fatalf("record cash payment: %v", err)
+	}
+	if _, err := registerHandler.RecordCashMovement(dto.CashMovementRecordRequest{
+		IdempotencyKey: "cash-in-1", RegisterSessionID: register.ID,
+		Kind: "CASH_IN", AmountMinor: 50, Notes: "Troco do banco",
+	}); err != nil {
+		t.Fatalf("record cash in: %v", err)
+	}
+	if _, err := registerHandler.RecordCashMovement(dto.CashMovementRecordRequest{
+		IdempotencyKey: "cash-out-1", RegisterSessionID: register.ID,
+		Kind: "CASH_OUT", AmountMinor: 30, Notes: "Gás",
+	}); err != nil {
+		t.Fatalf("record cash out: %v", err)
+	}
+	register, err = registerHandler.GetOpenRegisterSession()
+	if err != nil || len(register.Payments) != 1 || register.Payments[0].ID != cashPayment.ID ||
+		len(register.Movements) != 2 || register.Totals.ExpectedCashMinor != 620 {
+		t.Fatalf("open register session = %#v, %v", register, err)
+	}
+	if _, err := registerHandler.PrintRegisterClosing(register.ID); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("open register print error = %v", err)
+	}
+	clock.now = must(domain.UTCInstantFromUnixMilli(38_000))
+	register, err = registerHandler.CloseRegisterSession(register.ID, dto.RegisterCloseRequest{CountedCashMinor: 600})
+	if err != nil || register.Open || register.Closing == nil || register.Closing.Totals.CashMinor != 100 ||
+		register.Closing.Totals.CashOutMinor != 30 || register.Closing.DifferenceMinor != -20 ||
+		register.Closing.ClosedAtMs != clock.now.UnixMilli() {
+		t.Fatalf("close register session = %#v, %v", register, err)
+	}
+	if _, err := registerHandler.RecordCashMovement(dto.CashMovementRecordRequest{
+		IdempotencyKey: "cash-in-2", RegisterSessionID: register.ID,
+		Kind: "CASH_IN", AmountMinor: 10, Notes: "Depois do fechamento",
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("closed register movement error = %v", err)
+	}
+	closedRegisters, err := registerHandler.ListClosedRegisterSessions(register.BusinessDate, register.BusinessDate)
+	if err != nil || len(closedRegisters) != 1 || closedRegisters[0].ID != register.ID {
+		t.Fatalf("closed register sessions = %#v, %v", closedRegisters, err)
+	}
+	zReport, err := registerHandler.PrintRegisterClosing(register.ID)
+	if err != nil || !strings.Contains(zReport, "Expected cash") || !strings.Contains(zReport, register.BusinessDate) {
+		t.Fatalf("register closing report = %q, %v", zReport, err)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(39_000))
+	rent, err := expenseHandler.RecordExpense(dto.ExpenseRecordRequest{
+		IdempotencyKey: "expense-rent-1", Category: "RENT", AmountMinor: 1_200, OccurredOn: "2026-09-01",
+	})
+	if err != nil || rent.RecordedAtMs != clock.now.UnixMilli() {
+		t.Fatalf("record expense = %#v, %v", rent, err)
+	}
+	rentFix, err := expenseHandler.CorrectExpense(rent.ID, dto.ExpenseCorrectRequest{
+		IdempotencyKey: "expense-rent-1-fix", Category: "RENT", AmountMinor: 1_000, OccurredOn: "2026-09-01",
+	})
+	if err != nil || rentFix.CorrectsExpenseID == nil || *rentFix.CorrectsExpenseID != rent.ID {
+		t.Fatalf("correct expense = %#v, %v", rentFix, err)
+	}
+	if _, err := expenseHandler.CorrectExpense(rent.ID, dto.ExpenseCorrectRequest{
+		IdempotencyKey: "expense-rent-1-fix-2", Category: "RENT", AmountMinor: 900, OccurredOn: "2026-09-01",
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second correction error = %v", err)
+	}
+	expenses, err := expenseHandler.ListExpenses(dto.ExpenseListRequest{FromOccurredOn: "2026-09-01", ToOccurredOn: "2026-09-30"})
+	if err != nil || len(expenses) != 1 || expenses[0].ID != rentFix.ID {
+		t.Fatalf("expenses = %#v, %v", expenses, err)
+	}
+	profitAndLoss, err := reportingHandler.GetProfitAndLossReport(dto.ReportingPeriodRequest{
+		FromOccurredOn: "2026-09-01",
+		ToOccurredOn:   "2026-09-30",
+	})
+	if err != nil {
+		t.Fatalf("get profit and loss report: %v", err)
+	}
+	if profitAndLoss.Totals.ExpenseMinor != 1_000 || len(profitAndLoss.ExpensesByCategory) != 1 ||
+		profitAndLoss.ExpensesByCategory[0].Category != "RENT" {
+		t.Fatalf("profit and loss report = %#v", profitAndLoss)
+	}
+
+	clock.now = must(domain.UTCInstantFromUnixMilli(40_000))
+	wholesale, err := priceListHandler.CreatePriceList(dto.PriceListWriteRequest{
+		Name:      "Wholesale",
+		ValidFrom: stringPointer("2026-09-01"),
+		Prices: []dto.PriceListPriceRequest{
+			{ItemID: outputItem.ID, MinQuantityAtomic: 0, UnitPriceMinor: 900},
+			{ItemID: outputItem.ID, MinQuantityAtomic: 5_000, UnitPriceMinor: 800},
+		},
+	})
+	if err != nil || wholesale.CreatedAtMs != clock.now.UnixMilli() || len(wholesale.Prices) != 2 {
+		t.Fatalf("create price list = %#v, %v", wholesale, err)
+	}
+	assigned, err := priceListHandler.AssignCustomerPriceList(orderCustomer.ID, dto.CustomerPriceListAssignRequest{
+		PriceListID: &wholesale.ID,
+	})
+	if err != nil || assigned == nil || assigned.ID != wholesale.ID {
+		t.Fatalf("assign customer price list = %#v, %v", assigned, err)
+	}
+	clock.now = must(domain.UTCInstantFromUnixMilli(40_500))
+	if _, err := priceListHandler.ArchivePriceList(wholesale.ID, dto.VersionedRequest{
+		ExpectedUpdatedAtMs: wholesale.UpdatedAtMs,
+	}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("archive assigned price list error = %v", err)
+	}
+	quoteLines := []dto.SaleLineRequest{
+		{ItemID: outputItem.ID, QuantityAtomic: 2_000, EnteredUnitCode: "g", ConversionNumeratorAtomic: 1_000, ConversionDenominator: 1},
+		{ItemID: outputItem.ID, QuantityAtomic: 10_000, EnteredUnitCode: "g", ConversionNumeratorAtomic: 1_000, ConversionDenominator: 1},
+	}
+	quotes, err := saleHandler.QuoteSale(dto.SaleQuoteRequest{
+		CounterpartyID: &orderCustomer.ID, OccurredOn: "2026-09-02", Lines: quoteLines,
+	})
+	if err != nil || len(quotes) != 2 ||
+		quotes[0].Source != "PRICE_LIST" || quotes[0].CommercialTotalMinor == nil || *quotes[0].CommercialTotalMinor != 1_800 ||
+		quotes[1].MinQuantityAtomic != 5_000 || quotes[1].CommercialTotalMinor == nil || *quotes[1].CommercialTotalMinor != 8_000 {
+		t.Fatalf("customer sale quote = %#v, %v", quotes, err)
+	}
+	quotes, err = saleHandler.QuoteSale(dto.SaleQuoteRequest{
+		CounterpartyID: &orderCustomer.ID, OccurredOn: "2026-08-31", Lines: quoteLines[:1],
+	})
+	if err != nil || len(quotes) != 1 || quotes[0].Source != "DEFAULT_PRICE" ||
+		quotes[0].CommercialTotalMinor == nil || *quotes[0].CommercialTotalMinor != 2_500 {
+		t.Fatalf("out of window sale quote = %#v, %v", quotes, err)
+	}
+
+	reconciliation, err := reconciliationHandler.ReconcileInventory()
+	if err != nil {
+		t.Fatalf("reconcile inventory: %v", err)
+	}
+	if !reconciliation.Consistent || len(reconciliation.Discrepancies) != 0 ||
+		reconciliation.LastPostingSequence == nil || reconciliation.DocumentCount != *reconciliation.LastPostingSequence {
+		t.Fatalf("reconciliation = %#v", reconciliation)
+	}
 }
 
 func newSurfaceDatabase(t *testing.T) *database.Database {
@@ -208,6 +208,96 @@ func (h *CatalogHandler) RestoreItemPackaging(id int64, req dto.VersionedRequest
 	return mapPackaging(packaging), nil
 }
 
+func (h *CatalogHandler) GetCategory(id int64) (dto.CategoryResponse, error) {
+	categoryID, err := domain.NewCategoryID(id)
+	if err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("category id: %w", err)
+	}
+	category, err := h.service.GetCategory(handlerContext(), categoryID)
+	if err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("get category: %w", err)
+	}
+	return mapCategory(category), nil
+}
+
+func (h *CatalogHandler) ListCategories(req dto.CategoryListRequest) ([]dto.CategoryResponse, error) {
+	archive := domain.ArchiveActive
+	if req.ArchiveFilter != "" {
+		parsed, err := domain.ParseArchiveFilter(req.ArchiveFilter)
+		if err != nil {
+			return nil, err
+		}
+		archive = parsed
+	}
+	categories, err := h.service.ListCategories(handlerContext(), archive)
+	if err != nil {
+		return nil, fmt.Errorf("list categories: %w", err)
+	}
+	response := make([]dto.CategoryResponse, 0, len(categories))
+	for _, category := range categories {
+		response = append(response, mapCategory(category))
+	}
+	return response, nil
+}
+
+func (h *CatalogHandler) CreateCategory(req dto.CategoryWriteRequest) (dto.CategoryResponse, error) {
+	name, err := domain.NewUniqueName(req.Name)
+	if err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("name: %w", err)
+	}
+	category, err := h.service.CreateCategory(handlerContext(), application.CategoryCreateInput{Name: name})
+	if err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("create category: %w", err)
+	}
+	return mapCategory(category), nil
+}
+
+func (h *CatalogHandler) UpdateCategory(id int64, req dto.CategoryUpdateRequest) (dto.CategoryResponse, error) {
+	categoryID, expectedUpdatedAt, err := parseVersionedCategory(id, dto.VersionedRequest{ExpectedUpdatedAtMs: req.ExpectedUpdatedAtMs})
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+	name, err := domain.NewUniqueName(req.Name)
+	if err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("name: %w", err)
+	}
+	category, err := h.service.UpdateCategory(handlerContext(), application.CategoryUpdateInput{
+		ID: categoryID, Name: name, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("update category: %w", err)
+	}
+	return mapCategory(category), nil
+}
+
+func (h *CatalogHandler) ArchiveCategory(id int64, req dto.VersionedRequest) (dto.CategoryResponse, error) {
+	categoryID, expectedUpdatedAt, err := parseVersionedCategory(id, req)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+	category, err := h.service.ArchiveCategory(handlerContext(), application.CategoryArchiveInput{
+		ID: categoryID, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("archive category: %w", err)
+	}
+	return mapCategory(category), nil
+}
+
+func (h *CatalogHandler) RestoreCategory(id int64, req dto.VersionedRequest) (dto.CategoryResponse, error) {
+	categoryID, expectedUpdatedAt, err := parseVersionedCategory(id, req)
+	if err != nil {
+		return dto.CategoryResponse{}, err
+	}
+	category, err := h.service.RestoreCategory(handlerContext(), application.CategoryRestoreInput{
+		ID: categoryID, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.CategoryResponse{}, fmt.Errorf("restore category: %w", err)
+	}
+	return mapCategory(category), nil
+}
+
 func parseItemListRequest(req dto.ItemListRequest) (application.ItemListInput, error) {
 	archive := domain.ArchiveActive
 	if req.ArchiveFilter != "" {
@@ -271,12 +361,17 @@ func parseItemWriteRequest(req dto.ItemWriteRequest) (application.ItemWriteInput
 	if err != nil {
 		return application.ItemWriteInput{}, fmt.Errorf("reorder quantity: %w", err)
 	}
+	category, err := optionalCategoryIDInput(req.CategoryID)
+	if err != nil {
+		return application.ItemWriteInput{}, fmt.Errorf("category id: %w", err)
+	}
 	return application.ItemWriteInput{
 		Name:             name,
 		SKU:              sku,
 		Description:      description,
 		BaseUnit:         baseUnit,
 		Capabilities:     parseCapabilities(req.Capabilities),
+		Category:         category,
 		DefaultSalePrice: defaultSalePrice,
 		ReorderQuantity:  reorderQuantity,
 	}, nil
@@ -334,6 +429,18 @@ func parseVersionedPackaging(id int64, req dto.VersionedRequest) (domain.Packagi
 	return packagingID, expectedUpdatedAt, nil
 }
 
+func parseVersionedCategory(id int64, req dto.VersionedRequest) (domain.CategoryID, domain.UTCInstant, error) {
+	categoryID, err := domain.NewCategoryID(id)
+	if err != nil {
+		return domain.CategoryID{}, domain.UTCInstant{}, fmt.Errorf("category id: %w", err)
+	}
+	expectedUpdatedAt, err := domain.UTCInstantFromUnixMilli(req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return domain.CategoryID{}, domain.UTCInstant{}, fmt.Errorf("expected updated at: %w", err)
+	}
+	return categoryID, expectedUpdatedAt, nil
+}
+
 func mapItemPage(page application.ItemPage) dto.ItemPageResponse {
 	items := page.Items()
 	response := dto.ItemPageResponse{Items: make([]dto.ItemSummaryResponse, 0, len(items))}
@@ -368,6 +475,7 @@ func mapItemSummary(item catalog.ItemSummary) dto.ItemSummaryResponse {
 		Description:      optionalText(item.Description()),
 		BaseUnitCode:     item.BaseUnit().String(),
 		Capabilities:     mapCapabilities(item.Capabilities()),
+		CategoryID:       optionalCategoryID(item.Category()),
 		DefaultSalePrice: optionalMinorAmount(item.DefaultSalePrice()),
 		ReorderQuantity:  optionalAtomicQuantityValue(item.ReorderQuantity()),
 		CreatedAtMs:      item.CreatedAt().UnixMilli(),
@@ -384,6 +492,7 @@ func mapCatalogItemFields(item catalog.Item) dto.ItemSummaryResponse {
 		Description:      optionalText(item.Description()),
 		BaseUnitCode:     item.BaseUnit().String(),
 		Capabilities:     mapCapabilities(item.Capabilities()),
+		CategoryID:       optionalCategoryID(item.Category()),
 		DefaultSalePrice: optionalMinorAmount(item.DefaultSalePrice()),
 		ReorderQuantity:  optionalAtomicQuantityValue(item.ReorderQuantity()),
 		CreatedAtMs:      item.CreatedAt().UnixMilli(),
@@ -392,6 +501,16 @@ func mapCatalogItemFields(item catalog.Item) dto.ItemSummaryResponse {
 	}
 }
 
+func mapCategory(category catalog.Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:           category.ID().Int64(),
+		Name:         category.Name().Display(),
+		CreatedAtMs:  category.CreatedAt().UnixMilli(),
+		UpdatedAtMs:  category.UpdatedAt().UnixMilli(),
+		ArchivedAtMs: optionalInstant(category.ArchivedAt()),
+	}
+}
+
 func mapPackaging(packaging application.PackagingAggregate) dto.PackagingResponse {
 	value := packaging.Packaging()
 	return dto.PackagingResponse{
@@ -431,6 +550,17 @@ func optionalMinorAmountInput(value *int64) (domain.Option[domain.MinorAmount],
 	return domain.Some(amount), nil
 }
 
+func optionalCategoryIDInput(value *int64) (domain.Option[domain.CategoryID], error) {
+	if value == nil {
+		return domain.None[domain.CategoryID](), nil
+	}
+	id, err := domain.NewCategoryID(*value)
+	if err != nil {
+		return domain.None[domain.CategoryID](), err
+	}
+	return domain.Some(id), nil
+}
+
 func optionalAtomicQuantity(value *int64) (domain.Option[domain.AtomicQuantity], error) {
 	if value == nil {
 		return domain.None[domain.AtomicQuantity](), nil
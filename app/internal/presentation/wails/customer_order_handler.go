@@ -0,0 +1,271 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type CustomerOrderHandler struct {
+	service *application.CustomerOrderService
+}
+
+func NewCustomerOrderHandler(service *application.CustomerOrderService) *CustomerOrderHandler {
+	if service == nil {
+		panic("customer order handler requires a service")
+	}
+	return &CustomerOrderHandler{service: service}
+}
+
+func (h *CustomerOrderHandler) GetCustomerOrder(id int64) (dto.CustomerOrderResponse, error) {
+	orderID, err := domain.NewCustomerOrderID(id)
+	if err != nil {
+		return dto.CustomerOrderResponse{}, fmt.Errorf("customer order id: %w", err)
+	}
+	order, err := h.service.GetCustomerOrder(handlerContext(), orderID)
+	if err != nil {
+		return dto.CustomerOrderResponse{}, fmt.Errorf("get customer order: %w", err)
+	}
+	return mapCustomerOrder(order), nil
+}
+
+func (h *CustomerOrderHandler) ListCustomerOrderCalendar(req dto.CustomerOrderCalendarRequest) ([]dto.CustomerOrderCalendarDayResponse, error) {
+	input, err := parseCustomerOrderCalendarRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	days, err := h.service.ListCustomerOrderCalendar(handlerContext(), input)
+	if err != nil {
+		return nil, fmt.Errorf("list customer order calendar: %w", err)
+	}
+	response := make([]dto.CustomerOrderCalendarDayResponse, 0, len(days))
+	for _, day := range days {
+		orders := make([]dto.CustomerOrderResponse, 0, len(day.Orders))
+		for _, order := range day.Orders {
+			orders = append(orders, mapCustomerOrder(order))
+		}
+		response = append(response, dto.CustomerOrderCalendarDayResponse{DueOn: day.DueOn.String(), Orders: orders})
+	}
+	return response, nil
+}
+
+func (h *CustomerOrderHandler) CreateCustomerOrder(req dto.CustomerOrderWriteRequest) (dto.CustomerOrderResponse, error) {
+	input, err := parseCustomerOrderWriteRequest(req)
+	if err != nil {
+		return dto.CustomerOrderResponse{}, err
+	}
+	order, err := h.service.CreateCustomerOrder(handlerContext(), input)
+	if err != nil {
+		return dto.CustomerOrderResponse{}, fmt.Errorf("create customer order: %w", err)
+	}
+	return mapCustomerOrder(order), nil
+}
+
+func (h *CustomerOrderHandler) UpdateCustomerOrder(id int64, req dto.CustomerOrderUpdateRequest) (dto.CustomerOrderResponse, error) {
+	orderID, expectedUpdatedAt, err := parseVersionedCustomerOrder(id, req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return dto.CustomerOrderResponse{}, err
+	}
+	input, err := parseCustomerOrderWriteRequest(req.CustomerOrderWriteRequest)
+	if err != nil {
+		return dto.CustomerOrderResponse{}, err
+	}
+	order, err := h.service.UpdateCustomerOrder(handlerContext(), application.CustomerOrderUpdateInput{
+		ID: orderID, CustomerID: input.CustomerID, OrderedOn: input.OrderedOn, DueOn: input.DueOn,
+		Deposit: input.Deposit, Notes: input.Notes, Lines: input.Lines,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.CustomerOrderResponse{}, fmt.Errorf("update customer order: %w", err)
+	}
+	return mapCustomerOrder(order), nil
+}
+
+func (h *CustomerOrderHandler) CancelCustomerOrder(id int64, req dto.VersionedCustomerOrderRequest) (dto.CustomerOrderResponse, error) {
+	orderID, expectedUpdatedAt, err := parseVersionedCustomerOrder(id, req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return dto.CustomerOrderResponse{}, err
+	}
+	order, err := h.service.CancelCustomerOrder(handlerContext(), application.CustomerOrderCancelInput{
+		ID: orderID, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.CustomerOrderResponse{}, fmt.Errorf("cancel customer order: %w", err)
+	}
+	return mapCustomerOrder(order), nil
+}
+
+func (h *CustomerOrderHandler) FulfilCustomerOrder(id int64, req dto.CustomerOrderFulfilRequest) (dto.CustomerOrderFulfilmentResponse, error) {
+	orderID, expectedUpdatedAt, err := parseVersionedCustomerOrder(id, req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return dto.CustomerOrderFulfilmentResponse{}, err
+	}
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.CustomerOrderFulfilmentResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	occurredOn, err := domain.ParseBusinessDate(req.OccurredOn)
+	if err != nil {
+		return dto.CustomerOrderFulfilmentResponse{}, fmt.Errorf("occurred on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return dto.CustomerOrderFulfilmentResponse{}, fmt.Errorf("notes: %w", err)
+	}
+	fulfilment, err := h.service.FulfilCustomerOrder(handlerContext(), application.CustomerOrderFulfilInput{
+		ID:                orderID,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+		IdempotencyKey:    idempotencyKey,
+		OccurredOn:        occurredOn,
+		Notes:             notes,
+	})
+	if err != nil {
+		return dto.CustomerOrderFulfilmentResponse{}, fmt.Errorf("fulfil customer order: %w", err)
+	}
+	return dto.CustomerOrderFulfilmentResponse{
+		Order: mapCustomerOrder(fulfilment.Order()),
+		Sale:  mapSaleDocument(fulfilment.Sale()),
+	}, nil
+}
+
+func parseVersionedCustomerOrder(id int64, expectedUpdatedAtMs int64) (domain.CustomerOrderID, domain.UTCInstant, error) {
+	orderID, err := domain.NewCustomerOrderID(id)
+	if err != nil {
+		return domain.CustomerOrderID{}, domain.UTCInstant{}, fmt.Errorf("customer order id: %w", err)
+	}
+	expectedUpdatedAt, err := domain.UTCInstantFromUnixMilli(expectedUpdatedAtMs)
+	if err != nil {
+		return domain.CustomerOrderID{}, domain.UTCInstant{}, fmt.Errorf("expected updated at: %w", err)
+	}
+	return orderID, expectedUpdatedAt, nil
+}
+
+func parseCustomerOrderCalendarRequest(req dto.CustomerOrderCalendarRequest) (application.CustomerOrderCalendarInput, error) {
+	from, err := domain.ParseBusinessDate(req.From)
+	if err != nil {
+		return application.CustomerOrderCalendarInput{}, fmt.Errorf("from: %w", err)
+	}
+	to, err := domain.ParseBusinessDate(req.To)
+	if err != nil {
+		return application.CustomerOrderCalendarInput{}, fmt.Errorf("to: %w", err)
+	}
+	status := domain.None[domain.CustomerOrderStatus]()
+	if req.Status != nil {
+		parsed, err := domain.ParseCustomerOrderStatus(*req.Status)
+		if err != nil {
+			return application.CustomerOrderCalendarInput{}, fmt.Errorf("status: %w", err)
+		}
+		status = domain.Some(parsed)
+	}
+	return application.CustomerOrderCalendarInput{From: from, To: to, Status: status}, nil
+}
+
+func parseCustomerOrderWriteRequest(req dto.CustomerOrderWriteRequest) (application.CustomerOrderCreateInput, error) {
+	customerID, err := domain.NewCounterpartyID(req.CustomerID)
+	if err != nil {
+		return application.CustomerOrderCreateInput{}, fmt.Errorf("customer id: %w", err)
+	}
+	orderedOn, err := domain.ParseBusinessDate(req.OrderedOn)
+	if err != nil {
+		return application.CustomerOrderCreateInput{}, fmt.Errorf("ordered on: %w", err)
+	}
+	dueOn, err := domain.ParseBusinessDate(req.DueOn)
+	if err != nil {
+		return application.CustomerOrderCreateInput{}, fmt.Errorf("due on: %w", err)
+	}
+	deposit, err := domain.NewMinorAmount(req.DepositMinor)
+	if err != nil {
+		return application.CustomerOrderCreateInput{}, fmt.Errorf("deposit: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return application.CustomerOrderCreateInput{}, fmt.Errorf("notes: %w", err)
+	}
+	lines := make([]application.CustomerOrderLineInput, 0, len(req.Lines))
+	for index, line := range req.Lines {
+		parsed, err := parseCustomerOrderLineRequest(line)
+		if err != nil {
+			return application.CustomerOrderCreateInput{}, fmt.Errorf("line %d: %w", index+1, err)
+		}
+		lines = append(lines, parsed)
+	}
+	return application.CustomerOrderCreateInput{
+		CustomerID: customerID,
+		OrderedOn:  orderedOn,
+		DueOn:      dueOn,
+		Deposit:    deposit,
+		Notes:      notes,
+		Lines:      lines,
+	}, nil
+}
+
+func parseCustomerOrderLineRequest(req dto.CustomerOrderLineRequest) (application.CustomerOrderLineInput, error) {
+	itemID, err := domain.NewItemID(req.ItemID)
+	if err != nil {
+		return application.CustomerOrderLineInput{}, fmt.Errorf("item id: %w", err)
+	}
+	quantity, err := domain.NewPositiveAtomicQuantity(req.QuantityAtomic)
+	if err != nil {
+		return application.CustomerOrderLineInput{}, fmt.Errorf("quantity: %w", err)
+	}
+	enteredUnit, err := domain.NewUnitCode(req.EnteredUnitCode)
+	if err != nil {
+		return application.CustomerOrderLineInput{}, fmt.Errorf("entered unit: %w", err)
+	}
+	enteredPackagingName, err := optionalNonEmptyText(req.EnteredPackagingName)
+	if err != nil {
+		return application.CustomerOrderLineInput{}, fmt.Errorf("entered packaging name: %w", err)
+	}
+	conversion, err := domain.NewUnitConversion(req.ConversionNumeratorAtomic, req.ConversionDenominator)
+	if err != nil {
+		return application.CustomerOrderLineInput{}, fmt.Errorf("conversion: %w", err)
+	}
+	agreedTotal, err := domain.NewMinorAmount(req.AgreedTotalMinor)
+	if err != nil {
+		return application.CustomerOrderLineInput{}, fmt.Errorf("agreed total: %w", err)
+	}
+	return application.CustomerOrderLineInput{
+		ItemID:               itemID,
+		Quantity:             quantity,
+		EnteredUnit:          enteredUnit,
+		EnteredPackagingName: enteredPackagingName,
+		Conversion:           conversion,
+		AgreedTotal:          agreedTotal,
+	}, nil
+}
+
+func mapCustomerOrder(order sales.Order) dto.CustomerOrderResponse {
+	lines := order.Lines()
+	response := dto.CustomerOrderResponse{
+		ID:               order.ID().Int64(),
+		CustomerID:       order.CustomerID().Int64(),
+		Status:           order.Status().String(),
+		OrderedOn:        order.OrderedOn().String(),
+		DueOn:            order.DueOn().String(),
+		DepositMinor:     order.Deposit().Int64(),
+		AgreedTotalMinor: order.AgreedTotal().Int64(),
+		BalanceDueMinor:  order.BalanceDue().Int64(),
+		Notes:            optionalText(order.Notes()),
+		SaleDocumentID:   invOptionalStockDocumentID(order.SaleDocumentID()),
+		CreatedAtMs:      order.CreatedAt().UnixMilli(),
+		UpdatedAtMs:      order.UpdatedAt().UnixMilli(),
+		Lines:            make([]dto.CustomerOrderLineResponse, 0, len(lines)),
+	}
+	for _, line := range lines {
+		response.Lines = append(response.Lines, dto.CustomerOrderLineResponse{
+			ID:                        line.ID().Int64(),
+			LineOrder:                 line.LineOrder().Int64(),
+			ItemID:                    line.ItemID().Int64(),
+			QuantityAtomic:            line.Quantity().Int64(),
+			EnteredUnitCode:           line.EnteredUnit().String(),
+			EnteredPackagingName:      optionalText(line.EnteredPackagingName()),
+			ConversionNumeratorAtomic: line.Conversion().NumeratorAtomic(),
+			ConversionDenominator:     line.Conversion().Denominator(),
+			AgreedTotalMinor:          line.AgreedTotal().Int64(),
+		})
+	}
+	return response
+}
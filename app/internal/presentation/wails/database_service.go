@@ -3,18 +3,23 @@ package wails
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 
 	"github.com/jerobas/saas/database"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 type DatabaseService struct {
-	db  *database.Database
-	ctx context.Context
+	db              *database.Database
+	backupDirectory string
+	ctx             context.Context
+
+	restartRequested atomic.Bool
 }
 
-func NewDatabaseService(db *database.Database) *DatabaseService {
-	return &DatabaseService{db: db}
+func NewDatabaseService(db *database.Database, backupDirectory string) *DatabaseService {
+	return &DatabaseService{db: db, backupDirectory: backupDirectory}
 }
 
 func (s *DatabaseService) SetContext(ctx context.Context) {
@@ -44,9 +49,118 @@ func (s *DatabaseService) Export() error {
 	return s.db.Export(destPath)
 }
 
+// Import stages a validated backup and quits the window. The composition root
+// checks RestartRequested after Wails returns, closes the live database, and
+// starts a fresh process that activates the candidate before wiring any store.
 func (s *DatabaseService) Import() error {
 	if s.ctx == nil {
 		return errors.New("context not set")
 	}
-	return s.db.Import("")
+
+	srcPath, err := runtime.OpenFileDialog(s.ctx, runtime.OpenDialogOptions{
+		Title: "Restaurar backup",
+		Filters: []runtime.FileFilter{{
+			DisplayName: "SQLite",
+			Pattern:     "*.db",
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if srcPath == "" {
+		return errors.New("import cancelled")
+	}
+
+	if err := s.db.Import(srcPath); err != nil {
+		return err
+	}
+	s.restartRequested.Store(true)
+	runtime.Quit(s.ctx)
+	return nil
+}
+
+// RestartRequested reports whether Import staged a restore that needs a fresh
+// process to activate.
+func (s *DatabaseService) RestartRequested() bool {
+	return s.restartRequested.Load()
+}
+
+// ListBackups returns the automatic backups, newest first, with their
+// manifests. A missing or unreadable manifest does not hide the backup.
+func (s *DatabaseService) ListBackups() ([]dto.BackupResponse, error) {
+	backups, err := database.ListBackupFiles(s.backupDirectory)
+	if err != nil {
+		return nil, err
+	}
+	response := make([]dto.BackupResponse, 0, len(backups))
+	for _, backup := range backups {
+		item := dto.BackupResponse{
+			Name:        backup.Name,
+			Path:        backup.Path,
+			CreatedAtMs: backup.CreatedAt.UnixMilli(),
+			SizeBytes:   backup.SizeBytes,
+		}
+		manifest, found, err := database.ReadBackupManifest(backup.Path)
+		if err != nil {
+			item.ManifestError = err.Error()
+		} else if found {
+			mapped := backupManifestResponse(manifest)
+			item.Manifest = &mapped
+		}
+		response = append(response, item)
+	}
+	return response, nil
+}
+
+// VerifyBackup reports whether the backup at path could be restored. The
+// candidate is read through a private copy; neither it nor the live database
+// is modified.
+func (s *DatabaseService) VerifyBackup(path string) (dto.BackupVerificationResponse, error) {
+	verification, err := database.VerifyBackup(path)
+	if err != nil {
+		return dto.BackupVerificationResponse{}, err
+	}
+	response := dto.BackupVerificationResponse{
+		Path:                   verification.Path,
+		Compatibility:          string(verification.Compatibility),
+		Problem:                verification.Problem,
+		SupportedSchemaVersion: verification.SupportedSchemaVersion,
+		Contents:               backupManifestResponse(verification.Contents),
+		ManifestFound:          verification.ManifestFound,
+		ManifestMatches:        verification.ManifestMatches,
+	}
+	if verification.ManifestFound {
+		manifest := backupManifestResponse(verification.Manifest)
+		response.Manifest = &manifest
+	}
+	return response, nil
+}
+
+func backupManifestResponse(manifest database.BackupManifest) dto.BackupManifestResponse {
+	migrations := make([]dto.BackupMigrationResponse, 0, len(manifest.Migrations))
+	for _, migration := range manifest.Migrations {
+		migrations = append(migrations, dto.BackupMigrationResponse{
+			Version:  migration.Version,
+			Name:     migration.Name,
+			Checksum: migration.Checksum,
+		})
+	}
+	counts := make(map[string]int64, len(manifest.DocumentCounts))
+	for kind, count := range manifest.DocumentCounts {
+		counts[kind] = count
+	}
+	var createdAtMs int64
+	if !manifest.CreatedAt.IsZero() {
+		createdAtMs = manifest.CreatedAt.UnixMilli()
+	}
+	return dto.BackupManifestResponse{
+		AppVersion:          manifest.AppVersion,
+		CreatedAtMs:         createdAtMs,
+		SchemaVersion:       manifest.SchemaVersion,
+		Migrations:          migrations,
+		FileSHA256:          manifest.FileSHA256,
+		FileSizeBytes:       manifest.FileSizeBytes,
+		LastPostingSequence: manifest.LastPostingSequence,
+		DocumentCounts:      counts,
+	}
 }
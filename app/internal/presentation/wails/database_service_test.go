@@ -0,0 +1,47 @@
+package wails
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jerobas/saas/database"
+)
+
+func TestDatabaseServiceListsAndVerifiesBackups(t *testing.T) {
+	db := newSurfaceDatabase(t)
+	directory := filepath.Join(t.TempDir(), "backups")
+	createdAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	backup, err := db.WriteBackup(directory, createdAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	orphan := filepath.Join(directory, database.BackupFileName(createdAt.Add(time.Hour)))
+	if err := os.WriteFile(orphan, []byte("not a database"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	service := NewDatabaseService(db, directory)
+
+	backups, err := service.ListBackups()
+	if err != nil {
+		t.Fatalf("list backups: %v", err)
+	}
+	if len(backups) != 2 || backups[0].Path != orphan || backups[0].Manifest != nil ||
+		backups[1].Name != backup.Name || backups[1].Manifest == nil ||
+		backups[1].Manifest.CreatedAtMs != createdAt.UnixMilli() || backups[1].CreatedAtMs != createdAt.UnixMilli() {
+		t.Fatalf("backups = %#v", backups)
+	}
+
+	verification, err := service.VerifyBackup(backup.Path)
+	if err != nil {
+		t.Fatalf("verify backup: %v", err)
+	}
+	if verification.Compatibility != string(database.BackupCurrent) || !verification.ManifestMatches ||
+		verification.Manifest == nil || verification.Contents.SchemaVersion != verification.SupportedSchemaVersion {
+		t.Fatalf("verification = %#v", verification)
+	}
+	if invalid, err := service.VerifyBackup(orphan); err != nil || invalid.Compatibility != string(database.BackupInvalid) {
+		t.Fatalf("orphan verification = %#v, %v", invalid, err)
+	}
+}
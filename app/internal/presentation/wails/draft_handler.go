@@ -0,0 +1,135 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type DraftHandler struct {
+	service *application.DraftService
+}
+
+func NewDraftHandler(service *application.DraftService) *DraftHandler {
+	if service == nil {
+		panic("draft handler requires a service")
+	}
+	return &DraftHandler{service: service}
+}
+
+func (h *DraftHandler) GetDraft(kind string, draftID string) (dto.DraftResponse, error) {
+	parsedKind, id, err := parseDraftKey(kind, draftID)
+	if err != nil {
+		return dto.DraftResponse{}, err
+	}
+	draft, err := h.service.GetDraft(handlerContext(), parsedKind, id)
+	if err != nil {
+		return dto.DraftResponse{}, fmt.Errorf("get draft: %w", err)
+	}
+	return mapDraft(draft), nil
+}
+
+func (h *DraftHandler) ListDrafts(kind *string) ([]dto.DraftResponse, error) {
+	filter := domain.None[domain.DraftKind]()
+	if kind != nil {
+		parsed, err := domain.ParseDraftKind(*kind)
+		if err != nil {
+			return nil, fmt.Errorf("kind: %w", err)
+		}
+		filter = domain.Some(parsed)
+	}
+	drafts, err := h.service.ListDrafts(handlerContext(), filter)
+	if err != nil {
+		return nil, fmt.Errorf("list drafts: %w", err)
+	}
+	response := make([]dto.DraftResponse, 0, len(drafts))
+	for _, draft := range drafts {
+		response = append(response, mapDraft(draft))
+	}
+	return response, nil
+}
+
+func (h *DraftHandler) SaveDraft(req dto.DraftSaveRequest) (dto.DraftResponse, error) {
+	kind, id, err := parseDraftKey(req.Kind, req.DraftID)
+	if err != nil {
+		return dto.DraftResponse{}, err
+	}
+	expectedUpdatedAt := domain.None[domain.UTCInstant]()
+	if req.ExpectedUpdatedAtMs != nil {
+		parsed, err := domain.UTCInstantFromUnixMilli(*req.ExpectedUpdatedAtMs)
+		if err != nil {
+			return dto.DraftResponse{}, fmt.Errorf("expected updated at: %w", err)
+		}
+		expectedUpdatedAt = domain.Some(parsed)
+	}
+	draft, err := h.service.SaveDraft(handlerContext(), application.DraftSaveInput{
+		Kind: kind, ID: id, Payload: req.Payload, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.DraftResponse{}, fmt.Errorf("save draft: %w", err)
+	}
+	return mapDraft(draft), nil
+}
+
+func (h *DraftHandler) DiscardDraft(req dto.VersionedDraftRequest) error {
+	kind, id, expectedUpdatedAt, err := parseVersionedDraftRequest(req)
+	if err != nil {
+		return err
+	}
+	if err := h.service.DiscardDraft(handlerContext(), application.DraftDiscardInput{
+		Kind: kind, ID: id, ExpectedUpdatedAt: expectedUpdatedAt,
+	}); err != nil {
+		return fmt.Errorf("discard draft: %w", err)
+	}
+	return nil
+}
+
+func (h *DraftHandler) PostDraft(req dto.VersionedDraftRequest) (dto.DraftPostResponse, error) {
+	kind, id, expectedUpdatedAt, err := parseVersionedDraftRequest(req)
+	if err != nil {
+		return dto.DraftPostResponse{}, err
+	}
+	documentID, err := h.service.PostDraft(handlerContext(), application.DraftPostInput{
+		Kind: kind, ID: id, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.DraftPostResponse{}, fmt.Errorf("post draft: %w", err)
+	}
+	return dto.DraftPostResponse{Kind: kind.String(), DraftID: id.String(), DocumentID: documentID.Int64()}, nil
+}
+
+func parseDraftKey(kind string, draftID string) (domain.DraftKind, domain.IdempotencyKey, error) {
+	parsedKind, err := domain.ParseDraftKind(kind)
+	if err != nil {
+		return "", domain.IdempotencyKey{}, fmt.Errorf("kind: %w", err)
+	}
+	id, err := domain.NewIdempotencyKey(draftID)
+	if err != nil {
+		return "", domain.IdempotencyKey{}, fmt.Errorf("draft id: %w", err)
+	}
+	return parsedKind, id, nil
+}
+
+func parseVersionedDraftRequest(req dto.VersionedDraftRequest) (domain.DraftKind, domain.IdempotencyKey, domain.UTCInstant, error) {
+	kind, id, err := parseDraftKey(req.Kind, req.DraftID)
+	if err != nil {
+		return "", domain.IdempotencyKey{}, domain.UTCInstant{}, err
+	}
+	expectedUpdatedAt, err := domain.UTCInstantFromUnixMilli(req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return "", domain.IdempotencyKey{}, domain.UTCInstant{}, fmt.Errorf("expected updated at: %w", err)
+	}
+	return kind, id, expectedUpdatedAt, nil
+}
+
+func mapDraft(draft application.Draft) dto.DraftResponse {
+	return dto.DraftResponse{
+		Kind:        draft.Kind.String(),
+		DraftID:     draft.ID.String(),
+		Payload:     draft.Payload,
+		CreatedAtMs: draft.CreatedAt.UnixMilli(),
+		UpdatedAtMs: draft.UpdatedAt.UnixMilli(),
+	}
+}
@@ -0,0 +1,117 @@
+package wails
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+// NewDraftPosters returns the poster of every draft kind. A draft payload is
+// the form's ordinary post request; its idempotency key is always replaced by
+// the draft id.
+func NewDraftPosters(
+	purchases *application.PurchaseService,
+	sales *application.SaleService,
+	adjustments *application.AdjustmentService,
+	productions *application.ProductionService,
+) map[domain.DraftKind]application.DraftPoster {
+	if purchases == nil || sales == nil || adjustments == nil || productions == nil {
+		panic("draft posters require purchase, sale, adjustment, and production services")
+	}
+	return map[domain.DraftKind]application.DraftPoster{
+		domain.DraftPurchase:   purchaseDraftPoster{service: purchases},
+		domain.DraftSale:       saleDraftPoster{service: sales},
+		domain.DraftAdjustment: adjustmentDraftPoster{service: adjustments},
+		domain.DraftProduction: productionDraftPoster{service: productions},
+	}
+}
+
+type purchaseDraftPoster struct{ service *application.PurchaseService }
+
+func (p purchaseDraftPoster) PostDraft(ctx context.Context, key domain.IdempotencyKey, payload string) (domain.StockDocumentID, error) {
+	var req dto.PurchasePostRequest
+	if err := decodeDraftPayload(payload, &req); err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	req.IdempotencyKey = key.String()
+	input, err := parsePurchasePostRequest(req)
+	if err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	posted, err := p.service.PostPurchase(ctx, input)
+	if err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	return posted.ID(), nil
+}
+
+type saleDraftPoster struct{ service *application.SaleService }
+
+func (p saleDraftPoster) PostDraft(ctx context.Context, key domain.IdempotencyKey, payload string) (domain.StockDocumentID, error) {
+	var req dto.SalePostRequest
+	if err := decodeDraftPayload(payload, &req); err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	req.IdempotencyKey = key.String()
+	input, err := parseSalePostRequest(req)
+	if err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	posted, err := p.service.PostSale(ctx, input)
+	if err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	return posted.ID(), nil
+}
+
+type adjustmentDraftPoster struct {
+	service *application.AdjustmentService
+}
+
+func (p adjustmentDraftPoster) PostDraft(ctx context.Context, key domain.IdempotencyKey, payload string) (domain.StockDocumentID, error) {
+	var req dto.AdjustmentPostRequest
+	if err := decodeDraftPayload(payload, &req); err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	req.IdempotencyKey = key.String()
+	input, err := parseAdjustmentPostRequest(req)
+	if err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	posted, err := p.service.PostAdjustment(ctx, input)
+	if err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	return posted.ID(), nil
+}
+
+type productionDraftPoster struct {
+	service *application.ProductionService
+}
+
+func (p productionDraftPoster) PostDraft(ctx context.Context, key domain.IdempotencyKey, payload string) (domain.StockDocumentID, error) {
+	var req dto.ProductionPostRequest
+	if err := decodeDraftPayload(payload, &req); err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	req.IdempotencyKey = key.String()
+	input, err := parseProductionPostRequest(req)
+	if err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	posted, err := p.service.PostProduction(ctx, input)
+	if err != nil {
+		return domain.StockDocumentID{}, err
+	}
+	return posted.ID(), nil
+}
+
+func decodeDraftPayload(payload string, req any) error {
+	if err := json.Unmarshal([]byte(payload), req); err != nil {
+		return domain.Invalid("payload", domain.ViolationInvalidFormat, "DRF-001")
+	}
+	return nil
+}
@@ -21,6 +21,23 @@ type AdjustmentLineRequest struct {
 	ExpiresOn                 *string `json:"expiresOn,omitempty"`
 }
 
+type ExpiredLotsPostRequest struct {
+	IdempotencyKey string  `json:"idempotencyKey"`
+	OccurredOn     string  `json:"occurredOn"`
+	Notes          *string `json:"notes,omitempty"`
+	ExcludedLotIDs []int64 `json:"excludedLotIds,omitempty"`
+}
+
+type ExpiredLotResponse struct {
+	LotID                   int64   `json:"lotId"`
+	ItemID                  int64   `json:"itemId"`
+	ItemName                string  `json:"itemName"`
+	LotCode                 *string `json:"lotCode,omitempty"`
+	ExpiresOn               string  `json:"expiresOn"`
+	RemainingQuantityAtomic int64   `json:"remainingQuantityAtomic"`
+	InventoryValueMicro     int64   `json:"inventoryValueMicro"`
+}
+
 type AdjustmentDocumentResponse struct {
 	ID                  int64                    `json:"id"`
 	IdempotencyKey      string                   `json:"idempotencyKey"`
@@ -0,0 +1,38 @@
+package dto
+
+type BackupMigrationResponse struct {
+	Version  int    `json:"version"`
+	Name     string `json:"name"`
+	Checksum string `json:"checksum"`
+}
+
+type BackupManifestResponse struct {
+	AppVersion          string                    `json:"appVersion"`
+	CreatedAtMs         int64                     `json:"createdAtMs"`
+	SchemaVersion       int                       `json:"schemaVersion"`
+	Migrations          []BackupMigrationResponse `json:"migrations"`
+	FileSHA256          string                    `json:"fileSha256"`
+	FileSizeBytes       int64                     `json:"fileSizeBytes"`
+	LastPostingSequence int64                     `json:"lastPostingSequence"`
+	DocumentCounts      map[string]int64          `json:"documentCounts"`
+}
+
+type BackupResponse struct {
+	Name          string                  `json:"name"`
+	Path          string                  `json:"path"`
+	CreatedAtMs   int64                   `json:"createdAtMs"`
+	SizeBytes     int64                   `json:"sizeBytes"`
+	Manifest      *BackupManifestResponse `json:"manifest,omitempty"`
+	ManifestError string                  `json:"manifestError,omitempty"`
+}
+
+type BackupVerificationResponse struct {
+	Path                   string                  `json:"path"`
+	Compatibility          string                  `json:"compatibility"`
+	Problem                string                  `json:"problem,omitempty"`
+	SupportedSchemaVersion int                     `json:"supportedSchemaVersion"`
+	Contents               BackupManifestResponse  `json:"contents"`
+	ManifestFound          bool                    `json:"manifestFound"`
+	ManifestMatches        bool                    `json:"manifestMatches"`
+	Manifest               *BackupManifestResponse `json:"manifest,omitempty"`
+}
@@ -42,6 +42,7 @@ type ItemSummaryResponse struct {
 	Description      *string              `json:"description,omitempty"`
 	BaseUnitCode     string               `json:"baseUnitCode"`
 	Capabilities     CapabilitiesResponse `json:"capabilities"`
+	CategoryID       *int64               `json:"categoryId,omitempty"`
 	DefaultSalePrice *int64               `json:"defaultSalePrice,omitempty"`
 	ReorderQuantity  *int64               `json:"reorderQuantityAtomic,omitempty"`
 	CreatedAtMs      int64                `json:"createdAtMs"`
@@ -61,6 +62,7 @@ type ItemWriteRequest struct {
 	Description      *string             `json:"description,omitempty"`
 	BaseUnitCode     string              `json:"baseUnitCode"`
 	Capabilities     CapabilitiesRequest `json:"capabilities"`
+	CategoryID       *int64              `json:"categoryId,omitempty"`
 	DefaultSalePrice *int64              `json:"defaultSalePrice,omitempty"`
 	ReorderQuantity  *int64              `json:"reorderQuantityAtomic,omitempty"`
 }
@@ -70,6 +72,27 @@ type ItemUpdateRequest struct {
 	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
 }
 
+type CategoryListRequest struct {
+	ArchiveFilter string `json:"archiveFilter,omitempty"`
+}
+
+type CategoryResponse struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	CreatedAtMs  int64  `json:"createdAtMs"`
+	UpdatedAtMs  int64  `json:"updatedAtMs"`
+	ArchivedAtMs *int64 `json:"archivedAtMs,omitempty"`
+}
+
+type CategoryWriteRequest struct {
+	Name string `json:"name"`
+}
+
+type CategoryUpdateRequest struct {
+	CategoryWriteRequest
+	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
+}
+
 type VersionedRequest struct {
 	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
 }
@@ -0,0 +1,80 @@
+package dto
+
+type CustomerOrderWriteRequest struct {
+	CustomerID   int64                      `json:"customerId"`
+	OrderedOn    string                     `json:"orderedOn"`
+	DueOn        string                     `json:"dueOn"`
+	DepositMinor int64                      `json:"depositMinor"`
+	Notes        *string                    `json:"notes,omitempty"`
+	Lines        []CustomerOrderLineRequest `json:"lines"`
+}
+
+type CustomerOrderUpdateRequest struct {
+	CustomerOrderWriteRequest
+	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
+}
+
+type VersionedCustomerOrderRequest struct {
+	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
+}
+
+type CustomerOrderLineRequest struct {
+	ItemID                    int64   `json:"itemId"`
+	QuantityAtomic            int64   `json:"quantityAtomic"`
+	EnteredUnitCode           string  `json:"enteredUnitCode"`
+	EnteredPackagingName      *string `json:"enteredPackagingName,omitempty"`
+	ConversionNumeratorAtomic int64   `json:"conversionNumeratorAtomic"`
+	ConversionDenominator     int64   `json:"conversionDenominator"`
+	AgreedTotalMinor          int64   `json:"agreedTotalMinor"`
+}
+
+type CustomerOrderFulfilRequest struct {
+	ExpectedUpdatedAtMs int64   `json:"expectedUpdatedAtMs"`
+	IdempotencyKey      string  `json:"idempotencyKey"`
+	OccurredOn          string  `json:"occurredOn"`
+	Notes               *string `json:"notes,omitempty"`
+}
+
+type CustomerOrderCalendarRequest struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Status *string `json:"status,omitempty"`
+}
+
+type CustomerOrderResponse struct {
+	ID               int64                       `json:"id"`
+	CustomerID       int64                       `json:"customerId"`
+	Status           string                      `json:"status"`
+	OrderedOn        string                      `json:"orderedOn"`
+	DueOn            string                      `json:"dueOn"`
+	DepositMinor     int64                       `json:"depositMinor"`
+	AgreedTotalMinor int64                       `json:"agreedTotalMinor"`
+	BalanceDueMinor  int64                       `json:"balanceDueMinor"`
+	Notes            *string                     `json:"notes,omitempty"`
+	SaleDocumentID   *int64                      `json:"saleDocumentId,omitempty"`
+	CreatedAtMs      int64                       `json:"createdAtMs"`
+	UpdatedAtMs      int64                       `json:"updatedAtMs"`
+	Lines            []CustomerOrderLineResponse `json:"lines"`
+}
+
+type CustomerOrderLineResponse struct {
+	ID                        int64   `json:"id"`
+	LineOrder                 int64   `json:"lineOrder"`
+	ItemID                    int64   `json:"itemId"`
+	QuantityAtomic            int64   `json:"quantityAtomic"`
+	EnteredUnitCode           string  `json:"enteredUnitCode"`
+	EnteredPackagingName      *string `json:"enteredPackagingName,omitempty"`
+	ConversionNumeratorAtomic int64   `json:"conversionNumeratorAtomic"`
+	ConversionDenominator     int64   `json:"conversionDenominator"`
+	AgreedTotalMinor          int64   `json:"agreedTotalMinor"`
+}
+
+type CustomerOrderCalendarDayResponse struct {
+	DueOn  string                  `json:"dueOn"`
+	Orders []CustomerOrderResponse `json:"orders"`
+}
+
+type CustomerOrderFulfilmentResponse struct {
+	Order CustomerOrderResponse `json:"order"`
+	Sale  SaleDocumentResponse  `json:"sale"`
+}
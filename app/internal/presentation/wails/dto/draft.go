@@ -0,0 +1,30 @@
+package dto
+
+// DraftSaveRequest saves Payload, the form's post request as a JSON object.
+// ExpectedUpdatedAtMs is omitted the first time a draft is saved.
+type DraftSaveRequest struct {
+	Kind                string `json:"kind"`
+	DraftID             string `json:"draftId"`
+	Payload             string `json:"payload"`
+	ExpectedUpdatedAtMs *int64 `json:"expectedUpdatedAtMs,omitempty"`
+}
+
+type VersionedDraftRequest struct {
+	Kind                string `json:"kind"`
+	DraftID             string `json:"draftId"`
+	ExpectedUpdatedAtMs int64  `json:"expectedUpdatedAtMs"`
+}
+
+type DraftResponse struct {
+	Kind        string `json:"kind"`
+	DraftID     string `json:"draftId"`
+	Payload     string `json:"payload"`
+	CreatedAtMs int64  `json:"createdAtMs"`
+	UpdatedAtMs int64  `json:"updatedAtMs"`
+}
+
+type DraftPostResponse struct {
+	Kind       string `json:"kind"`
+	DraftID    string `json:"draftId"`
+	DocumentID int64  `json:"documentId"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+type ExpenseRecordRequest struct {
+	IdempotencyKey string  `json:"idempotencyKey"`
+	Category       string  `json:"category"`
+	AmountMinor    int64   `json:"amountMinor"`
+	OccurredOn     string  `json:"occurredOn"`
+	SupplierID     *int64  `json:"supplierId,omitempty"`
+	Notes          *string `json:"notes,omitempty"`
+}
+
+// ExpenseCorrectRequest restates every field of the corrected expense. A zero
+// amount voids it.
+type ExpenseCorrectRequest struct {
+	IdempotencyKey string  `json:"idempotencyKey"`
+	Category       string  `json:"category"`
+	AmountMinor    int64   `json:"amountMinor"`
+	OccurredOn     string  `json:"occurredOn"`
+	SupplierID     *int64  `json:"supplierId,omitempty"`
+	Notes          *string `json:"notes,omitempty"`
+}
+
+type ExpenseListRequest struct {
+	FromOccurredOn string  `json:"fromOccurredOn"`
+	ToOccurredOn   string  `json:"toOccurredOn"`
+	Category       *string `json:"category,omitempty"`
+}
+
+type ExpenseResponse struct {
+	ID                int64   `json:"id"`
+	IdempotencyKey    string  `json:"idempotencyKey"`
+	Category          string  `json:"category"`
+	AmountMinor       int64   `json:"amountMinor"`
+	OccurredOn        string  `json:"occurredOn"`
+	SupplierID        *int64  `json:"supplierId,omitempty"`
+	Notes             *string `json:"notes,omitempty"`
+	CorrectsExpenseID *int64  `json:"correctsExpenseId,omitempty"`
+	CorrectedByID     *int64  `json:"correctedById,omitempty"`
+	RecordedAtMs      int64   `json:"recordedAtMs"`
+}
@@ -14,6 +14,7 @@ type InventoryBalanceListRequest struct {
 	IncludeArchived bool                           `json:"includeArchived,omitempty"`
 	Search          *string                        `json:"search,omitempty"`
 	After           *InventoryBalanceCursorRequest `json:"after,omitempty"`
+	LocationID      *int64                         `json:"locationId,omitempty"`
 	PageSize        int                            `json:"pageSize,omitempty"`
 }
 
@@ -51,6 +52,8 @@ type LotResponse struct {
 	SourceDocumentID      int64   `json:"sourceDocumentId"`
 	SourceKind            string  `json:"sourceKind"`
 	SourceOccurredOn      string  `json:"sourceOccurredOn"`
+	LocationID            int64   `json:"locationId"`
+	TransferredFromLotID  *int64  `json:"transferredFromLotId,omitempty"`
 }
 
 type LedgerCursorRequest struct {
@@ -0,0 +1,23 @@
+package dto
+
+type LocationListRequest struct {
+	ArchiveFilter string `json:"archiveFilter,omitempty"`
+}
+
+type LocationResponse struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	IsDefault    bool   `json:"isDefault"`
+	CreatedAtMs  int64  `json:"createdAtMs"`
+	UpdatedAtMs  int64  `json:"updatedAtMs"`
+	ArchivedAtMs *int64 `json:"archivedAtMs,omitempty"`
+}
+
+type LocationWriteRequest struct {
+	Name string `json:"name"`
+}
+
+type LocationUpdateRequest struct {
+	LocationWriteRequest
+	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
+}
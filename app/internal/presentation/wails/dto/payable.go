@@ -0,0 +1,90 @@
+package dto
+
+type InstallmentRequest struct {
+	DueOn       string `json:"dueOn"`
+	AmountMinor int64  `json:"amountMinor"`
+}
+
+// PurchasePayableScheduleRequest numbers installments in request order.
+type PurchasePayableScheduleRequest struct {
+	Installments []InstallmentRequest `json:"installments"`
+}
+
+type SupplierPaymentRecordRequest struct {
+	IdempotencyKey     string  `json:"idempotencyKey"`
+	PurchaseDocumentID int64   `json:"purchaseDocumentId"`
+	Method             string  `json:"method"`
+	AmountMinor        int64   `json:"amountMinor"`
+	PaidOn             string  `json:"paidOn"`
+	Notes              *string `json:"notes,omitempty"`
+}
+
+type SupplierPaymentReverseRequest struct {
+	IdempotencyKey string  `json:"idempotencyKey"`
+	PaidOn         string  `json:"paidOn"`
+	Notes          *string `json:"notes,omitempty"`
+}
+
+type SupplierPaymentResponse struct {
+	ID                 int64   `json:"id"`
+	IdempotencyKey     string  `json:"idempotencyKey"`
+	PurchaseDocumentID int64   `json:"purchaseDocumentId"`
+	Method             string  `json:"method"`
+	AmountMinor        int64   `json:"amountMinor"`
+	PaidOn             string  `json:"paidOn"`
+	Notes              *string `json:"notes,omitempty"`
+	ReversesPaymentID  *int64  `json:"reversesPaymentId,omitempty"`
+	RecordedAtMs       int64   `json:"recordedAtMs"`
+}
+
+// InstallmentResponse reports how much of an installment is still open once
+// supplier return credits and payments are applied in installment order.
+type InstallmentResponse struct {
+	Number      int    `json:"number"`
+	DueOn       string `json:"dueOn"`
+	AmountMinor int64  `json:"amountMinor"`
+	OpenMinor   int64  `json:"openMinor"`
+}
+
+// PurchasePayableResponse is what a purchase owes its supplier: its total less
+// unreversed supplier returns, or zero once reversed. A negative outstanding
+// balance is a credit with the supplier.
+type PurchasePayableResponse struct {
+	PurchaseDocumentID int64                     `json:"purchaseDocumentId"`
+	SupplierID         int64                     `json:"supplierId"`
+	OccurredOn         string                    `json:"occurredOn"`
+	PurchaseTotalMinor int64                     `json:"purchaseTotalMinor"`
+	CreditedMinor      int64                     `json:"creditedMinor"`
+	Reversed           bool                      `json:"reversed"`
+	OwedMinor          int64                     `json:"owedMinor"`
+	PaidMinor          int64                     `json:"paidMinor"`
+	OutstandingMinor   int64                     `json:"outstandingMinor"`
+	Installments       []InstallmentResponse     `json:"installments"`
+	Payments           []SupplierPaymentResponse `json:"payments"`
+	CreatedAtMs        int64                     `json:"createdAtMs"`
+}
+
+type DueInstallmentResponse struct {
+	PurchaseDocumentID int64  `json:"purchaseDocumentId"`
+	Number             int    `json:"number"`
+	DueOn              string `json:"dueOn"`
+	AmountMinor        int64  `json:"amountMinor"`
+	OpenMinor          int64  `json:"openMinor"`
+	Overdue            bool   `json:"overdue"`
+}
+
+type SupplierDueResponse struct {
+	SupplierID       int64                    `json:"supplierId"`
+	Installments     []DueInstallmentResponse `json:"installments"`
+	OverdueMinor     int64                    `json:"overdueMinor"`
+	DueThisWeekMinor int64                    `json:"dueThisWeekMinor"`
+}
+
+// PayablesDueResponse lists installments due before asOf as overdue and those
+// due from asOf through the following six days as due this week.
+type PayablesDueResponse struct {
+	AsOf             string                `json:"asOf"`
+	Suppliers        []SupplierDueResponse `json:"suppliers"`
+	OverdueMinor     int64                 `json:"overdueMinor"`
+	DueThisWeekMinor int64                 `json:"dueThisWeekMinor"`
+}
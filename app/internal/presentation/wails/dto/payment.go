@@ -0,0 +1,71 @@
+package dto
+
+type SalePaymentRecordRequest struct {
+	IdempotencyKey string  `json:"idempotencyKey"`
+	SaleDocumentID int64   `json:"saleDocumentId"`
+	Method         string  `json:"method"`
+	AmountMinor    int64   `json:"amountMinor"`
+	ReceivedOn     string  `json:"receivedOn"`
+	Notes          *string `json:"notes,omitempty"`
+}
+
+type SalePaymentReverseRequest struct {
+	IdempotencyKey string  `json:"idempotencyKey"`
+	ReceivedOn     string  `json:"receivedOn"`
+	Notes          *string `json:"notes,omitempty"`
+}
+
+type SalePaymentResponse struct {
+	ID                int64   `json:"id"`
+	IdempotencyKey    string  `json:"idempotencyKey"`
+	SaleDocumentID    int64   `json:"saleDocumentId"`
+	Method            string  `json:"method"`
+	AmountMinor       int64   `json:"amountMinor"`
+	ReceivedOn        string  `json:"receivedOn"`
+	Notes             *string `json:"notes,omitempty"`
+	ReversesPaymentID *int64  `json:"reversesPaymentId,omitempty"`
+	RecordedAtMs      int64   `json:"recordedAtMs"`
+}
+
+// SaleBalanceResponse is what a sale is owed: its total less unreversed
+// returns, or zero once reversed. Only CASH, PIX, and CARD settle it; a
+// negative outstanding balance is a credit owed to the customer.
+type SaleBalanceResponse struct {
+	SaleDocumentID   int64                 `json:"saleDocumentId"`
+	CustomerID       *int64                `json:"customerId,omitempty"`
+	OccurredOn       string                `json:"occurredOn"`
+	SaleTotalMinor   int64                 `json:"saleTotalMinor"`
+	ReturnedMinor    int64                 `json:"returnedMinor"`
+	Reversed         bool                  `json:"reversed"`
+	ReceivableMinor  int64                 `json:"receivableMinor"`
+	SettledMinor     int64                 `json:"settledMinor"`
+	OnAccountMinor   int64                 `json:"onAccountMinor"`
+	OutstandingMinor int64                 `json:"outstandingMinor"`
+	Payments         []SalePaymentResponse `json:"payments"`
+}
+
+type CustomerBalanceResponse struct {
+	CustomerID       int64                 `json:"customerId"`
+	OutstandingMinor int64                 `json:"outstandingMinor"`
+	Sales            []SaleBalanceResponse `json:"sales"`
+}
+
+type AgingBucketsResponse struct {
+	Days0To30Minor  int64 `json:"days0To30Minor"`
+	Days31To60Minor int64 `json:"days31To60Minor"`
+	Over60DaysMinor int64 `json:"over60DaysMinor"`
+	TotalMinor      int64 `json:"totalMinor"`
+}
+
+// CustomerAgingResponse has no customer for sales made without one.
+type CustomerAgingResponse struct {
+	CustomerID *int64               `json:"customerId,omitempty"`
+	SaleCount  int                  `json:"saleCount"`
+	Buckets    AgingBucketsResponse `json:"buckets"`
+}
+
+type ReceivablesAgingResponse struct {
+	AsOf      string                  `json:"asOf"`
+	Customers []CustomerAgingResponse `json:"customers"`
+	Totals    AgingBucketsResponse    `json:"totals"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+type PriceListListRequest struct {
+	ArchiveFilter string `json:"archiveFilter,omitempty"`
+}
+
+// PriceListPriceRequest prices one base unit of the item, or one packaging
+// when PackagingID is set, from MinQuantityAtomic upward.
+type PriceListPriceRequest struct {
+	ItemID            int64  `json:"itemId"`
+	PackagingID       *int64 `json:"packagingId,omitempty"`
+	MinQuantityAtomic int64  `json:"minQuantityAtomic"`
+	UnitPriceMinor    int64  `json:"unitPriceMinor"`
+}
+
+type PriceListWriteRequest struct {
+	Name      string                  `json:"name"`
+	ValidFrom *string                 `json:"validFrom,omitempty"`
+	ValidTo   *string                 `json:"validTo,omitempty"`
+	Prices    []PriceListPriceRequest `json:"prices"`
+}
+
+type PriceListUpdateRequest struct {
+	PriceListWriteRequest
+	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
+}
+
+// CustomerPriceListAssignRequest leaves the customer on default prices when
+// PriceListID is omitted.
+type CustomerPriceListAssignRequest struct {
+	PriceListID *int64 `json:"priceListId,omitempty"`
+}
+
+type PriceListResponse struct {
+	ID           int64                    `json:"id"`
+	Name         string                   `json:"name"`
+	ValidFrom    *string                  `json:"validFrom,omitempty"`
+	ValidTo      *string                  `json:"validTo,omitempty"`
+	Prices       []PriceListPriceResponse `json:"prices"`
+	CreatedAtMs  int64                    `json:"createdAtMs"`
+	UpdatedAtMs  int64                    `json:"updatedAtMs"`
+	ArchivedAtMs *int64                   `json:"archivedAtMs,omitempty"`
+}
+
+type PriceListPriceResponse struct {
+	ItemID                    int64   `json:"itemId"`
+	PackagingID               *int64  `json:"packagingId,omitempty"`
+	PackagingName             *string `json:"packagingName,omitempty"`
+	PricedUnitNumeratorAtomic int64   `json:"pricedUnitNumeratorAtomic"`
+	PricedUnitDenominator     int64   `json:"pricedUnitDenominator"`
+	MinQuantityAtomic         int64   `json:"minQuantityAtomic"`
+	UnitPriceMinor            int64   `json:"unitPriceMinor"`
+}
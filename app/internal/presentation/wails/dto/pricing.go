@@ -0,0 +1,40 @@
+package dto
+
+type PriceSuggestionRequest struct {
+	RevisionID             int64  `json:"revisionId"`
+	GrossMarginBasisPoints *int64 `json:"grossMarginBasisPoints,omitempty"`
+}
+
+type UnderpricedItemsRequest struct {
+	GrossMarginBasisPoints *int64 `json:"grossMarginBasisPoints,omitempty"`
+}
+
+// UnderpricedItemsResponse lists outputs with an incomplete cost rollup apart
+// from the underpriced ones, since their suggestion understates the cost.
+type UnderpricedItemsResponse struct {
+	Items          []PriceSuggestionResponse `json:"items"`
+	CostIncomplete []PriceSuggestionResponse `json:"costIncomplete"`
+}
+
+type PriceSuggestionResponse struct {
+	RecipeID                  int64  `json:"recipeId"`
+	RecipeName                string `json:"recipeName"`
+	RevisionID                int64  `json:"revisionId"`
+	CurrentRevisionID         int64  `json:"currentRevisionId"`
+	OutputItemID              int64  `json:"outputItemId"`
+	OutputItemName            string `json:"outputItemName"`
+	CurrencyCode              string `json:"currencyCode"`
+	CurrencyMinorDigits       int64  `json:"currencyMinorDigits"`
+	YieldBaseUnitCode         string `json:"yieldBaseUnitCode"`
+	PreparationTimeMinutes    int64  `json:"preparationTimeMinutes"`
+	MaterialValueMicro        int64  `json:"materialValueMicro"`
+	LaborCostConfigured       bool   `json:"laborCostConfigured"`
+	LaborValueMicro           int64  `json:"laborValueMicro"`
+	BatchValueMicro           int64  `json:"batchValueMicro"`
+	CostPerYieldBaseUnitMicro int64  `json:"costPerYieldBaseUnitMicro"`
+	GrossMarginBasisPoints    int64  `json:"grossMarginBasisPoints"`
+	SuggestedPriceMinor       int64  `json:"suggestedPriceMinor"`
+	CurrentPriceMinor         *int64 `json:"currentPriceMinor,omitempty"`
+	Underpriced               bool   `json:"underpriced"`
+	CostComplete              bool   `json:"costComplete"`
+}
@@ -30,6 +30,30 @@ type ProductionComponentRequest struct {
 	LotID                     *int64  `json:"lotId,omitempty"`
 }
 
+type ProductionCursorRequest struct {
+	PostingSequence int64 `json:"postingSequence"`
+	ID              int64 `json:"id"`
+}
+
+type ProductionCursorResponse struct {
+	PostingSequence int64 `json:"postingSequence"`
+	ID              int64 `json:"id"`
+}
+
+type ProductionListRequest struct {
+	RecipeID       *int64                   `json:"recipeId,omitempty"`
+	OutputItemID   *int64                   `json:"outputItemId,omitempty"`
+	FromOccurredOn *string                  `json:"fromOccurredOn,omitempty"`
+	ToOccurredOn   *string                  `json:"toOccurredOn,omitempty"`
+	After          *ProductionCursorRequest `json:"after,omitempty"`
+	PageSize       int                      `json:"pageSize,omitempty"`
+}
+
+type ProductionPageResponse struct {
+	Items []ProductionDocumentResponse `json:"items"`
+	Next  *ProductionCursorResponse    `json:"next,omitempty"`
+}
+
 type ProductionDocumentResponse struct {
 	ID                  int64                    `json:"id"`
 	IdempotencyKey      string                   `json:"idempotencyKey"`
@@ -69,3 +93,34 @@ type ProductionAllocationResponse struct {
 	LotID          int64 `json:"lotId"`
 	QuantityAtomic int64 `json:"quantityAtomic"`
 }
+
+// ProductionScaleRequest sets exactly one of Multiplier, a plain decimal such
+// as "1.5", or OutputQuantityAtomic.
+type ProductionScaleRequest struct {
+	RecipeRevisionID     int64   `json:"recipeRevisionId"`
+	Multiplier           *string `json:"multiplier,omitempty"`
+	OutputQuantityAtomic *int64  `json:"outputQuantityAtomic,omitempty"`
+}
+
+type ScaledProductionResponse struct {
+	RecipeRevisionID         int64                           `json:"recipeRevisionId"`
+	OutputItemID             int64                           `json:"outputItemId"`
+	MultiplierNumerator      int64                           `json:"multiplierNumerator"`
+	MultiplierDenominator    int64                           `json:"multiplierDenominator"`
+	OutputQuantityAtomic     int64                           `json:"outputQuantityAtomic"`
+	OutputExactNumerator     int64                           `json:"outputExactNumerator"`
+	OutputExactDenominator   int64                           `json:"outputExactDenominator"`
+	OutputResidueNumerator   int64                           `json:"outputResidueNumerator"`
+	OutputResidueDenominator int64                           `json:"outputResidueDenominator"`
+	Inputs                   []ScaledProductionInputResponse `json:"inputs"`
+}
+
+// ScaledProductionInputResponse carries Input in the shape PostProduction
+// accepts, so the caller can edit it and post it back.
+type ScaledProductionInputResponse struct {
+	Input              ProductionComponentRequest `json:"input"`
+	ExactNumerator     int64                      `json:"exactNumerator"`
+	ExactDenominator   int64                      `json:"exactDenominator"`
+	ResidueNumerator   int64                      `json:"residueNumerator"`
+	ResidueDenominator int64                      `json:"residueDenominator"`
+}
@@ -0,0 +1,37 @@
+package dto
+
+type ProductionPlanRequest struct {
+	ItemID         int64   `json:"itemId"`
+	QuantityAtomic int64   `json:"quantityAtomic"`
+	RecipeIDs      []int64 `json:"recipeIds,omitempty"`
+}
+
+type ProductionPlanResponse struct {
+	ItemID         int64                          `json:"itemId"`
+	QuantityAtomic int64                          `json:"quantityAtomic"`
+	Runs           []PlannedProductionRunResponse `json:"runs"`
+	Purchases      []PlannedPurchaseResponse      `json:"purchases"`
+}
+
+type PlannedProductionRunResponse struct {
+	RecipeID                int64                     `json:"recipeId"`
+	RecipeRevisionID        int64                     `json:"recipeRevisionId"`
+	OutputItemID            int64                     `json:"outputItemId"`
+	Level                   int                       `json:"level"`
+	RequiredQuantityAtomic  int64                     `json:"requiredQuantityAtomic"`
+	FromStockQuantityAtomic int64                     `json:"fromStockQuantityAtomic"`
+	QuantityAtomic          int64                     `json:"quantityAtomic"`
+	Inputs                  []PlannedRunInputResponse `json:"inputs"`
+}
+
+type PlannedRunInputResponse struct {
+	ItemID         int64 `json:"itemId"`
+	QuantityAtomic int64 `json:"quantityAtomic"`
+}
+
+type PlannedPurchaseResponse struct {
+	ItemID                  int64 `json:"itemId"`
+	RequiredQuantityAtomic  int64 `json:"requiredQuantityAtomic"`
+	FromStockQuantityAtomic int64 `json:"fromStockQuantityAtomic"`
+	QuantityAtomic          int64 `json:"quantityAtomic"`
+}
@@ -0,0 +1,86 @@
+package dto
+
+type PurchaseOrderWriteRequest struct {
+	SupplierID int64                      `json:"supplierId"`
+	OrderedOn  string                     `json:"orderedOn"`
+	ExpectedOn *string                    `json:"expectedOn,omitempty"`
+	Notes      *string                    `json:"notes,omitempty"`
+	Lines      []PurchaseOrderLineRequest `json:"lines"`
+}
+
+type PurchaseOrderUpdateRequest struct {
+	PurchaseOrderWriteRequest
+	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
+}
+
+type VersionedPurchaseOrderRequest struct {
+	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
+}
+
+type PurchaseOrderLineRequest struct {
+	ItemID                    int64   `json:"itemId"`
+	QuantityAtomic            int64   `json:"quantityAtomic"`
+	EnteredUnitCode           string  `json:"enteredUnitCode"`
+	EnteredPackagingName      *string `json:"enteredPackagingName,omitempty"`
+	ConversionNumeratorAtomic int64   `json:"conversionNumeratorAtomic"`
+	ConversionDenominator     int64   `json:"conversionDenominator"`
+	ExpectedTotalMinor        int64   `json:"expectedTotalMinor"`
+}
+
+type PurchaseOrderReceiveRequest struct {
+	ExpectedUpdatedAtMs int64                             `json:"expectedUpdatedAtMs"`
+	IdempotencyKey      string                            `json:"idempotencyKey"`
+	OccurredOn          string                            `json:"occurredOn"`
+	Notes               *string                           `json:"notes,omitempty"`
+	Lines               []PurchaseOrderReceiptLineRequest `json:"lines"`
+}
+
+type PurchaseOrderReceiptLineRequest struct {
+	LineID               int64   `json:"lineId"`
+	QuantityAtomic       int64   `json:"quantityAtomic"`
+	CommercialTotalMinor *int64  `json:"commercialTotalMinor,omitempty"`
+	LotCode              *string `json:"lotCode,omitempty"`
+	ExpiresOn            *string `json:"expiresOn,omitempty"`
+}
+
+type PurchaseOrderResponse struct {
+	ID          int64                       `json:"id"`
+	SupplierID  int64                       `json:"supplierId"`
+	Status      string                      `json:"status"`
+	OrderedOn   string                      `json:"orderedOn"`
+	ExpectedOn  *string                     `json:"expectedOn,omitempty"`
+	Notes       *string                     `json:"notes,omitempty"`
+	CreatedAtMs int64                       `json:"createdAtMs"`
+	UpdatedAtMs int64                       `json:"updatedAtMs"`
+	Lines       []PurchaseOrderLineResponse `json:"lines"`
+}
+
+type PurchaseOrderLineResponse struct {
+	ID                        int64   `json:"id"`
+	LineOrder                 int64   `json:"lineOrder"`
+	ItemID                    int64   `json:"itemId"`
+	QuantityAtomic            int64   `json:"quantityAtomic"`
+	EnteredUnitCode           string  `json:"enteredUnitCode"`
+	EnteredPackagingName      *string `json:"enteredPackagingName,omitempty"`
+	ConversionNumeratorAtomic int64   `json:"conversionNumeratorAtomic"`
+	ConversionDenominator     int64   `json:"conversionDenominator"`
+	ExpectedTotalMinor        int64   `json:"expectedTotalMinor"`
+	ReceivedQuantityAtomic    int64   `json:"receivedQuantityAtomic"`
+	OutstandingQuantityAtomic int64   `json:"outstandingQuantityAtomic"`
+}
+
+type PurchaseOrderReceiptResponse struct {
+	Order    PurchaseOrderResponse    `json:"order"`
+	Purchase PurchaseDocumentResponse `json:"purchase"`
+}
+
+type PurchaseOrderListRequest struct {
+	Status   *string `json:"status,omitempty"`
+	After    *int64  `json:"after,omitempty"`
+	PageSize int     `json:"pageSize,omitempty"`
+}
+
+type PurchaseOrderPageResponse struct {
+	Items []PurchaseOrderResponse `json:"items"`
+	Next  *int64                  `json:"next,omitempty"`
+}
@@ -107,3 +107,31 @@ type RecipeComponentResponse struct {
 	ConversionDenominator     int64   `json:"conversionDenominator"`
 	CreatedAtMs               int64   `json:"createdAtMs"`
 }
+
+type RecipeRevisionCostResponse struct {
+	RevisionID                int64                         `json:"revisionId"`
+	RecipeID                  int64                         `json:"recipeId"`
+	OutputItemID              int64                         `json:"outputItemId"`
+	StandardYieldQuantity     int64                         `json:"standardYieldQuantityAtomic"`
+	YieldBaseUnitCode         string                        `json:"yieldBaseUnitCode"`
+	TotalValueMicro           int64                         `json:"totalValueMicro"`
+	CostPerYieldBaseUnitMicro int64                         `json:"costPerYieldBaseUnitMicro"`
+	EstimatedDirectCostMicro  *int64                        `json:"estimatedDirectCostMicro,omitempty"`
+	Complete                  bool                          `json:"complete"`
+	Components                []RecipeComponentCostResponse `json:"components"`
+}
+
+type RecipeComponentCostResponse struct {
+	ComponentID                int64   `json:"componentId"`
+	Order                      int64   `json:"order"`
+	ItemID                     int64   `json:"itemId"`
+	QuantityAtomic             int64   `json:"quantityAtomic"`
+	EnteredUnitCode            string  `json:"enteredUnitCode"`
+	EnteredQuantityNumerator   int64   `json:"enteredQuantityNumerator"`
+	EnteredQuantityDenominator int64   `json:"enteredQuantityDenominator"`
+	CostSource                 string  `json:"costSource"`
+	UnitValueNumeratorMicro    *int64  `json:"unitValueNumeratorMicro,omitempty"`
+	UnitValueDenominatorAtomic *int64  `json:"unitValueDenominatorAtomic,omitempty"`
+	ValueMicro                 *int64  `json:"valueMicro,omitempty"`
+	EnteredPackagingName       *string `json:"enteredPackagingName,omitempty"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+type ReconciliationResponse struct {
+	Consistent          bool                  `json:"consistent"`
+	LastPostingSequence *int64                `json:"lastPostingSequence,omitempty"`
+	DocumentCount       int64                 `json:"documentCount"`
+	LineCount           int64                 `json:"lineCount"`
+	LotCount            int64                 `json:"lotCount"`
+	ItemCount           int64                 `json:"itemCount"`
+	Discrepancies       []DiscrepancyResponse `json:"discrepancies"`
+}
+
+type DiscrepancyResponse struct {
+	Kind        string `json:"kind"`
+	InvariantID string `json:"invariantId"`
+	ItemID      int64  `json:"itemId"`
+	LotID       *int64 `json:"lotId,omitempty"`
+	LineID      *int64 `json:"lineId,omitempty"`
+	Expected    int64  `json:"expected"`
+	Stored      int64  `json:"stored"`
+}
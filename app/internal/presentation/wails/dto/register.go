@@ -0,0 +1,70 @@
+package dto
+
+type RegisterOpenRequest struct {
+	IdempotencyKey    string  `json:"idempotencyKey"`
+	OpeningFloatMinor int64   `json:"openingFloatMinor"`
+	Notes             *string `json:"notes,omitempty"`
+}
+
+type CashMovementRecordRequest struct {
+	IdempotencyKey    string `json:"idempotencyKey"`
+	RegisterSessionID int64  `json:"registerSessionId"`
+	Kind              string `json:"kind"`
+	AmountMinor       int64  `json:"amountMinor"`
+	Notes             string `json:"notes"`
+}
+
+type RegisterCloseRequest struct {
+	CountedCashMinor int64   `json:"countedCashMinor"`
+	Notes            *string `json:"notes,omitempty"`
+}
+
+type CashMovementResponse struct {
+	ID                int64  `json:"id"`
+	IdempotencyKey    string `json:"idempotencyKey"`
+	RegisterSessionID int64  `json:"registerSessionId"`
+	Kind              string `json:"kind"`
+	AmountMinor       int64  `json:"amountMinor"`
+	Notes             string `json:"notes"`
+	RecordedAtMs      int64  `json:"recordedAtMs"`
+}
+
+// RegisterTotalsResponse nets sale payments per method; expected cash is the
+// opening float plus cash payments and cash-in, less cash-out.
+type RegisterTotalsResponse struct {
+	OpeningFloatMinor int64 `json:"openingFloatMinor"`
+	CashMinor         int64 `json:"cashMinor"`
+	PixMinor          int64 `json:"pixMinor"`
+	CardMinor         int64 `json:"cardMinor"`
+	OnAccountMinor    int64 `json:"onAccountMinor"`
+	CashInMinor       int64 `json:"cashInMinor"`
+	CashOutMinor      int64 `json:"cashOutMinor"`
+	ExpectedCashMinor int64 `json:"expectedCashMinor"`
+}
+
+// RegisterClosingResponse is the frozen Z report. A negative difference is
+// cash missing from the drawer.
+type RegisterClosingResponse struct {
+	ClosedOn         string                 `json:"closedOn"`
+	Totals           RegisterTotalsResponse `json:"totals"`
+	CountedCashMinor int64                  `json:"countedCashMinor"`
+	DifferenceMinor  int64                  `json:"differenceMinor"`
+	Notes            *string                `json:"notes,omitempty"`
+	ClosedAtMs       int64                  `json:"closedAtMs"`
+}
+
+// RegisterSessionResponse carries running totals while open and the frozen
+// closing totals once closed.
+type RegisterSessionResponse struct {
+	ID                int64                    `json:"id"`
+	IdempotencyKey    string                   `json:"idempotencyKey"`
+	BusinessDate      string                   `json:"businessDate"`
+	OpeningFloatMinor int64                    `json:"openingFloatMinor"`
+	Notes             *string                  `json:"notes,omitempty"`
+	OpenedAtMs        int64                    `json:"openedAtMs"`
+	Open              bool                     `json:"open"`
+	Totals            RegisterTotalsResponse   `json:"totals"`
+	Payments          []SalePaymentResponse    `json:"payments"`
+	Movements         []CashMovementResponse   `json:"movements"`
+	Closing           *RegisterClosingResponse `json:"closing,omitempty"`
+}
@@ -6,6 +6,13 @@ type ReportingPeriodRequest struct {
 	Granularity    string `json:"granularity,omitempty"`
 }
 
+// InventoryReportRequest limits the inventory report to the lots at one
+// location when LocationID is set.
+type InventoryReportRequest struct {
+	ReportingPeriodRequest
+	LocationID *int64 `json:"locationId,omitempty"`
+}
+
 type ReportingPeriodResponse struct {
 	FromOccurredOn string `json:"fromOccurredOn"`
 	ToOccurredOn   string `json:"toOccurredOn"`
@@ -17,6 +24,8 @@ type SalesReportResponse struct {
 	CurrencyCode                   string                                `json:"currencyCode"`
 	CurrencyMinorDigits            int64                                 `json:"currencyMinorDigits"`
 	TotalSalesCount                int64                                 `json:"totalSalesCount"`
+	ReturnCount                    int64                                 `json:"returnCount"`
+	RefundMinor                    int64                                 `json:"refundMinor"`
 	CommercialTotalMinor           int64                                 `json:"commercialTotalMinor"`
 	COGSInventoryValueMicro        int64                                 `json:"cogsInventoryValueMicro"`
 	GrossMarginInventoryValueMicro int64                                 `json:"grossMarginInventoryValueMicro"`
@@ -37,6 +46,7 @@ type InventoryReportResponse struct {
 	Period                   ReportingPeriodResponse       `json:"period"`
 	CurrencyCode             string                        `json:"currencyCode"`
 	CurrencyMinorDigits      int64                         `json:"currencyMinorDigits"`
+	LocationID               *int64                        `json:"locationId,omitempty"`
 	TotalInventoryValueMicro int64                         `json:"totalInventoryValueMicro"`
 	LowStockItemCount        int64                         `json:"lowStockItemCount"`
 	ZeroStockSellableCount   int64                         `json:"zeroStockSellableCount"`
@@ -54,6 +64,14 @@ type PurchaseReportResponse struct {
 	PurchaseSpendSeries []ReportingSeriesResponse             `json:"purchaseSpendSeries"`
 	TopSuppliersBySpend []ReportingCounterpartyMetricResponse `json:"topSuppliersBySpend"`
 	FreeStockEntries    []ReportingSeriesResponse             `json:"freeStockEntries"`
+	Payables            PurchasePayableTotalsResponse         `json:"payables"`
+}
+
+type PurchasePayableTotalsResponse struct {
+	PayableCount     int64 `json:"payableCount"`
+	OwedMinor        int64 `json:"owedMinor"`
+	PaidMinor        int64 `json:"paidMinor"`
+	OutstandingMinor int64 `json:"outstandingMinor"`
 }
 
 type ProductionReportResponse struct {
@@ -75,19 +93,55 @@ type AdjustmentReportResponse struct {
 }
 
 type CategoryMixReportResponse struct {
-	Period            ReportingPeriodResponse  `json:"period"`
-	Available         bool                     `json:"available"`
-	UnavailableReason *string                  `json:"unavailableReason,omitempty"`
-	Rows              []CategoryMixRowResponse `json:"rows"`
+	Period               ReportingPeriodResponse  `json:"period"`
+	CurrencyCode         string                   `json:"currencyCode"`
+	CurrencyMinorDigits  int64                    `json:"currencyMinorDigits"`
+	Available            bool                     `json:"available"`
+	UnavailableReason    *string                  `json:"unavailableReason,omitempty"`
+	CommercialTotalMinor int64                    `json:"commercialTotalMinor"`
+	Rows                 []CategoryMixRowResponse `json:"rows"`
 }
 
 type CategoryMixRowResponse struct {
+	CategoryID           *int64 `json:"categoryId,omitempty"`
 	CategoryName         string `json:"categoryName"`
 	QuantityAtomic       int64  `json:"quantityAtomic"`
 	CommercialTotalMinor int64  `json:"commercialTotalMinor"`
 	ShareBasisPoints     int64  `json:"shareBasisPoints"`
 }
 
+// ProfitAndLossReportResponse has one row per period bucket. Totals has an
+// empty bucket. Direct cost is a memo already included in COGS.
+type ProfitAndLossReportResponse struct {
+	Period              ReportingPeriodResponse         `json:"period"`
+	CurrencyCode        string                          `json:"currencyCode"`
+	CurrencyMinorDigits int64                           `json:"currencyMinorDigits"`
+	Rows                []ProfitAndLossRowResponse      `json:"rows"`
+	Totals              ProfitAndLossRowResponse        `json:"totals"`
+	WriteOffsByReason   []ReportingReasonMetricResponse `json:"writeOffsByReason"`
+	ExpensesByCategory  []ExpenseCategoryMetricResponse `json:"expensesByCategory"`
+}
+
+type ProfitAndLossRowResponse struct {
+	Bucket                         string `json:"bucket"`
+	Label                          string `json:"label"`
+	CommercialTotalMinor           int64  `json:"commercialTotalMinor"`
+	COGSInventoryValueMicro        int64  `json:"cogsInventoryValueMicro"`
+	GrossMarginInventoryValueMicro int64  `json:"grossMarginInventoryValueMicro"`
+	DirectCostInventoryValueMicro  int64  `json:"directCostInventoryValueMicro"`
+	WriteOffInventoryValueMicro    int64  `json:"writeOffInventoryValueMicro"`
+	ExpenseMinor                   int64  `json:"expenseMinor"`
+	ExpenseInventoryValueMicro     int64  `json:"expenseInventoryValueMicro"`
+	NetProfitInventoryValueMicro   int64  `json:"netProfitInventoryValueMicro"`
+	NetMarginBasisPoints           *int64 `json:"netMarginBasisPoints,omitempty"`
+}
+
+type ExpenseCategoryMetricResponse struct {
+	Category     string `json:"category"`
+	ExpenseCount int64  `json:"expenseCount"`
+	AmountMinor  int64  `json:"amountMinor"`
+}
+
 type ReportingSeriesResponse struct {
 	Bucket                         string `json:"bucket"`
 	Label                          string `json:"label"`
@@ -0,0 +1,52 @@
+package dto
+
+type ReturnPostRequest struct {
+	IdempotencyKey string              `json:"idempotencyKey"`
+	SaleDocumentID int64               `json:"saleDocumentId"`
+	OccurredOn     string              `json:"occurredOn"`
+	Notes          *string             `json:"notes,omitempty"`
+	Lines          []ReturnLineRequest `json:"lines"`
+}
+
+type ReturnLineRequest struct {
+	SaleLineID     int64  `json:"saleLineId"`
+	QuantityAtomic int64  `json:"quantityAtomic"`
+	RefundMinor    *int64 `json:"refundMinor,omitempty"`
+}
+
+type ReturnDocumentResponse struct {
+	ID                  int64                `json:"id"`
+	IdempotencyKey      string               `json:"idempotencyKey"`
+	PostingSequence     int64                `json:"postingSequence"`
+	SaleDocumentID      int64                `json:"saleDocumentId"`
+	CounterpartyID      *int64               `json:"counterpartyId,omitempty"`
+	OccurredOn          string               `json:"occurredOn"`
+	PostedAtMs          int64                `json:"postedAtMs"`
+	CurrencyCode        string               `json:"currencyCode"`
+	CurrencyMinorDigits int64                `json:"currencyMinorDigits"`
+	ReasonCode          string               `json:"reasonCode"`
+	Notes               *string              `json:"notes,omitempty"`
+	Lines               []ReturnLineResponse `json:"lines"`
+}
+
+type ReturnLineResponse struct {
+	ID                        int64                      `json:"id"`
+	LineOrder                 int64                      `json:"lineOrder"`
+	ItemID                    int64                      `json:"itemId"`
+	QuantityAtomic            int64                      `json:"quantityAtomic"`
+	EnteredUnitCode           string                     `json:"enteredUnitCode"`
+	EnteredPackagingName      *string                    `json:"enteredPackagingName,omitempty"`
+	ConversionNumeratorAtomic int64                      `json:"conversionNumeratorAtomic"`
+	ConversionDenominator     int64                      `json:"conversionDenominator"`
+	InventoryValueMicro       int64                      `json:"inventoryValueMicro"`
+	RefundMinor               int64                      `json:"refundMinor"`
+	ReturnsLineID             int64                      `json:"returnsLineId"`
+	Allocations               []ReturnAllocationResponse `json:"allocations"`
+}
+
+type ReturnAllocationResponse struct {
+	ID                   int64 `json:"id"`
+	LotID                int64 `json:"lotId"`
+	QuantityAtomic       int64 `json:"quantityAtomic"`
+	RestoresAllocationID int64 `json:"restoresAllocationId"`
+}
@@ -74,3 +74,20 @@ type SalePageResponse struct {
 	Items []SaleDocumentResponse `json:"items"`
 	Next  *SaleCursorResponse    `json:"next,omitempty"`
 }
+
+// SaleQuoteRequest reuses sale lines; their commercial totals and lots are
+// ignored.
+type SaleQuoteRequest struct {
+	CounterpartyID *int64            `json:"counterpartyId,omitempty"`
+	OccurredOn     string            `json:"occurredOn"`
+	Lines          []SaleLineRequest `json:"lines"`
+}
+
+type SaleLineQuoteResponse struct {
+	Source               string `json:"source"`
+	PriceListID          *int64 `json:"priceListId,omitempty"`
+	PackagingID          *int64 `json:"packagingId,omitempty"`
+	MinQuantityAtomic    int64  `json:"minQuantityAtomic"`
+	UnitPriceMinor       *int64 `json:"unitPriceMinor,omitempty"`
+	CommercialTotalMinor *int64 `json:"commercialTotalMinor,omitempty"`
+}
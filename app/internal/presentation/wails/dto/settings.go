@@ -1,24 +1,40 @@
 package dto
 
+type BackupPolicyRequest struct {
+	Enabled         bool  `json:"enabled"`
+	IntervalMinutes int64 `json:"intervalMinutes"`
+	KeepDaily       int64 `json:"keepDaily"`
+	KeepWeekly      int64 `json:"keepWeekly"`
+}
+
+type BackupPolicyResponse struct {
+	Enabled         bool  `json:"enabled"`
+	IntervalMinutes int64 `json:"intervalMinutes"`
+	KeepDaily       int64 `json:"keepDaily"`
+	KeepWeekly      int64 `json:"keepWeekly"`
+}
+
 type SettingsResponse struct {
-	BusinessName        string `json:"businessName"`
-	Locale              string `json:"locale"`
-	Timezone            string `json:"timezone"`
-	CurrencyCode        string `json:"currencyCode"`
-	CurrencyMinorDigits int64  `json:"currencyMinorDigits"`
-	HourlyLaborCost     *int64 `json:"hourlyLaborCost,omitempty"`
-	DefaultGrossMargin  *int64 `json:"defaultGrossMargin,omitempty"`
-	CreatedAtMs         int64  `json:"createdAtMs"`
-	UpdatedAtMs         int64  `json:"updatedAtMs"`
+	BusinessName        string               `json:"businessName"`
+	Locale              string               `json:"locale"`
+	Timezone            string               `json:"timezone"`
+	CurrencyCode        string               `json:"currencyCode"`
+	CurrencyMinorDigits int64                `json:"currencyMinorDigits"`
+	HourlyLaborCost     *int64               `json:"hourlyLaborCost,omitempty"`
+	DefaultGrossMargin  *int64               `json:"defaultGrossMargin,omitempty"`
+	Backup              BackupPolicyResponse `json:"backup"`
+	CreatedAtMs         int64                `json:"createdAtMs"`
+	UpdatedAtMs         int64                `json:"updatedAtMs"`
 }
 
 type SettingsUpdateRequest struct {
-	BusinessName        string `json:"businessName"`
-	Locale              string `json:"locale"`
-	Timezone            string `json:"timezone"`
-	CurrencyCode        string `json:"currencyCode"`
-	CurrencyMinorDigits int64  `json:"currencyMinorDigits"`
-	HourlyLaborCost     *int64 `json:"hourlyLaborCost,omitempty"`
-	DefaultGrossMargin  *int64 `json:"defaultGrossMargin,omitempty"`
-	ExpectedUpdatedAtMs int64  `json:"expectedUpdatedAtMs"`
+	BusinessName        string              `json:"businessName"`
+	Locale              string              `json:"locale"`
+	Timezone            string              `json:"timezone"`
+	CurrencyCode        string              `json:"currencyCode"`
+	CurrencyMinorDigits int64               `json:"currencyMinorDigits"`
+	HourlyLaborCost     *int64              `json:"hourlyLaborCost,omitempty"`
+	DefaultGrossMargin  *int64              `json:"defaultGrossMargin,omitempty"`
+	Backup              BackupPolicyRequest `json:"backup"`
+	ExpectedUpdatedAtMs int64               `json:"expectedUpdatedAtMs"`
 }
@@ -0,0 +1,37 @@
+package dto
+
+type ShoppingListRequest struct {
+	BusinessDate string                   `json:"businessDate"`
+	Runs         []ShoppingListRunRequest `json:"runs"`
+}
+
+type ShoppingListRunRequest struct {
+	RecipeRevisionID int64 `json:"recipeRevisionId"`
+	QuantityAtomic   int64 `json:"quantityAtomic"`
+}
+
+type ShoppingListResponse struct {
+	BusinessDate string                      `json:"businessDate"`
+	Groups       []ShoppingListGroupResponse `json:"groups"`
+}
+
+type ShoppingListGroupResponse struct {
+	SupplierID   *int64                     `json:"supplierId,omitempty"`
+	SupplierName *string                    `json:"supplierName,omitempty"`
+	Lines        []ShoppingListLineResponse `json:"lines"`
+}
+
+type ShoppingListLineResponse struct {
+	ItemID                    int64   `json:"itemId"`
+	ItemName                  string  `json:"itemName"`
+	BaseUnitCode              string  `json:"baseUnitCode"`
+	BaseConversionNumerator   int64   `json:"baseConversionNumeratorAtomic"`
+	BaseConversionDenominator int64   `json:"baseConversionDenominator"`
+	RequiredQuantityAtomic    int64   `json:"requiredQuantityAtomic"`
+	AvailableQuantityAtomic   int64   `json:"availableQuantityAtomic"`
+	ReorderQuantityAtomic     int64   `json:"reorderQuantityAtomic"`
+	ToBuyQuantityAtomic       int64   `json:"toBuyQuantityAtomic"`
+	PackagingName             *string `json:"packagingName,omitempty"`
+	PackagingUnitCode         *string `json:"packagingUnitCode,omitempty"`
+	Packages                  *int64  `json:"packages,omitempty"`
+}
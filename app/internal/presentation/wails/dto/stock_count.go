@@ -0,0 +1,75 @@
+package dto
+
+type StockCountItemRequest struct {
+	ItemID int64 `json:"itemId"`
+	ByLot  bool  `json:"byLot,omitempty"`
+}
+
+type StockCountStartRequest struct {
+	CountedOn string                  `json:"countedOn"`
+	Notes     *string                 `json:"notes,omitempty"`
+	Items     []StockCountItemRequest `json:"items,omitempty"`
+}
+
+type StockCountObservationRequest struct {
+	LineID                 int64  `json:"lineId"`
+	ObservedQuantityAtomic *int64 `json:"observedQuantityAtomic,omitempty"`
+}
+
+type StockCountRecordRequest struct {
+	ExpectedUpdatedAtMs int64                          `json:"expectedUpdatedAtMs"`
+	Observations        []StockCountObservationRequest `json:"observations"`
+}
+
+type VersionedStockCountRequest struct {
+	ExpectedUpdatedAtMs int64 `json:"expectedUpdatedAtMs"`
+}
+
+type StockCountCloseRequest struct {
+	ExpectedUpdatedAtMs int64  `json:"expectedUpdatedAtMs"`
+	IdempotencyKey      string `json:"idempotencyKey"`
+}
+
+type StockCountResponse struct {
+	ID                    int64                    `json:"id"`
+	Status                string                   `json:"status"`
+	CountedOn             string                   `json:"countedOn"`
+	FrozenPostingSequence int64                    `json:"frozenPostingSequence"`
+	Notes                 *string                  `json:"notes,omitempty"`
+	AdjustmentDocumentID  *int64                   `json:"adjustmentDocumentId,omitempty"`
+	CreatedAtMs           int64                    `json:"createdAtMs"`
+	UpdatedAtMs           int64                    `json:"updatedAtMs"`
+	Lines                 []StockCountLineResponse `json:"lines"`
+}
+
+// StockCountLineResponse carries the variance and its value at the frozen
+// unit value once the line is counted.
+type StockCountLineResponse struct {
+	ID                     int64   `json:"id"`
+	ItemID                 int64   `json:"itemId"`
+	LotID                  *int64  `json:"lotId,omitempty"`
+	LotCode                *string `json:"lotCode,omitempty"`
+	ExpiresOn              *string `json:"expiresOn,omitempty"`
+	ExpectedQuantityAtomic int64   `json:"expectedQuantityAtomic"`
+	ExpectedValueMicro     int64   `json:"expectedValueMicro"`
+	ObservedQuantityAtomic *int64  `json:"observedQuantityAtomic,omitempty"`
+	VarianceAtomic         *int64  `json:"varianceAtomic,omitempty"`
+	VarianceValueMicro     *int64  `json:"varianceValueMicro,omitempty"`
+	ChangedSinceFreeze     bool    `json:"changedSinceFreeze"`
+}
+
+type StockCountClosingResponse struct {
+	Count      StockCountResponse          `json:"count"`
+	Adjustment *AdjustmentDocumentResponse `json:"adjustment,omitempty"`
+}
+
+type StockCountListRequest struct {
+	Status   *string `json:"status,omitempty"`
+	After    *int64  `json:"after,omitempty"`
+	PageSize int     `json:"pageSize,omitempty"`
+}
+
+type StockCountPageResponse struct {
+	Items []StockCountResponse `json:"items"`
+	Next  *int64               `json:"next,omitempty"`
+}
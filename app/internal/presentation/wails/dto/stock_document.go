@@ -0,0 +1,48 @@
+package dto
+
+type StockDocumentCursorRequest struct {
+	PostingSequence int64 `json:"postingSequence"`
+	ID              int64 `json:"id"`
+}
+
+type StockDocumentCursorResponse struct {
+	PostingSequence int64 `json:"postingSequence"`
+	ID              int64 `json:"id"`
+}
+
+type StockDocumentListRequest struct {
+	Kind           *string                     `json:"kind,omitempty"`
+	ReasonCode     *string                     `json:"reasonCode,omitempty"`
+	CounterpartyID *int64                      `json:"counterpartyId,omitempty"`
+	FromOccurredOn *string                     `json:"fromOccurredOn,omitempty"`
+	ToOccurredOn   *string                     `json:"toOccurredOn,omitempty"`
+	Reversal       string                      `json:"reversal,omitempty"`
+	NotesSearch    *string                     `json:"notesSearch,omitempty"`
+	After          *StockDocumentCursorRequest `json:"after,omitempty"`
+	PageSize       int                         `json:"pageSize,omitempty"`
+}
+
+type StockDocumentSummaryResponse struct {
+	ID                     int64   `json:"id"`
+	Kind                   string  `json:"kind"`
+	IdempotencyKey         string  `json:"idempotencyKey"`
+	PostingSequence        int64   `json:"postingSequence"`
+	CounterpartyID         *int64  `json:"counterpartyId,omitempty"`
+	OccurredOn             string  `json:"occurredOn"`
+	PostedAtMs             int64   `json:"postedAtMs"`
+	CurrencyCode           string  `json:"currencyCode"`
+	CurrencyMinorDigits    int64   `json:"currencyMinorDigits"`
+	ReasonCode             *string `json:"reasonCode,omitempty"`
+	Notes                  *string `json:"notes,omitempty"`
+	LineCount              int64   `json:"lineCount"`
+	InventoryValueInMicro  int64   `json:"inventoryValueInMicro"`
+	InventoryValueOutMicro int64   `json:"inventoryValueOutMicro"`
+	CommercialTotalMinor   *int64  `json:"commercialTotalMinor,omitempty"`
+	ReversesDocumentID     *int64  `json:"reversesDocumentId,omitempty"`
+	ReversedByDocumentID   *int64  `json:"reversedByDocumentId,omitempty"`
+}
+
+type StockDocumentPageResponse struct {
+	Items []StockDocumentSummaryResponse `json:"items"`
+	Next  *StockDocumentCursorResponse   `json:"next,omitempty"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+type SupplierReturnPostRequest struct {
+	IdempotencyKey     string                      `json:"idempotencyKey"`
+	PurchaseDocumentID int64                       `json:"purchaseDocumentId"`
+	OccurredOn         string                      `json:"occurredOn"`
+	Notes              *string                     `json:"notes,omitempty"`
+	Lines              []SupplierReturnLineRequest `json:"lines"`
+}
+
+type SupplierReturnLineRequest struct {
+	PurchaseLineID int64  `json:"purchaseLineId"`
+	QuantityAtomic int64  `json:"quantityAtomic"`
+	CreditMinor    *int64 `json:"creditMinor,omitempty"`
+}
+
+type SupplierReturnDocumentResponse struct {
+	ID                  int64                        `json:"id"`
+	IdempotencyKey      string                       `json:"idempotencyKey"`
+	PostingSequence     int64                        `json:"postingSequence"`
+	PurchaseDocumentID  int64                        `json:"purchaseDocumentId"`
+	CounterpartyID      *int64                       `json:"counterpartyId,omitempty"`
+	OccurredOn          string                       `json:"occurredOn"`
+	PostedAtMs          int64                        `json:"postedAtMs"`
+	CurrencyCode        string                       `json:"currencyCode"`
+	CurrencyMinorDigits int64                        `json:"currencyMinorDigits"`
+	ReasonCode          string                       `json:"reasonCode"`
+	Notes               *string                      `json:"notes,omitempty"`
+	Lines               []SupplierReturnLineResponse `json:"lines"`
+}
+
+type SupplierReturnLineResponse struct {
+	ID                        int64   `json:"id"`
+	LineOrder                 int64   `json:"lineOrder"`
+	ItemID                    int64   `json:"itemId"`
+	QuantityAtomic            int64   `json:"quantityAtomic"`
+	EnteredUnitCode           string  `json:"enteredUnitCode"`
+	EnteredPackagingName      *string `json:"enteredPackagingName,omitempty"`
+	ConversionNumeratorAtomic int64   `json:"conversionNumeratorAtomic"`
+	ConversionDenominator     int64   `json:"conversionDenominator"`
+	InventoryValueMicro       int64   `json:"inventoryValueMicro"`
+	CreditMinor               int64   `json:"creditMinor"`
+	ReturnsLineID             int64   `json:"returnsLineId"`
+	LotID                     int64   `json:"lotId"`
+	AllocationID              int64   `json:"allocationId"`
+}
@@ -0,0 +1,42 @@
+package dto
+
+type TransferPostRequest struct {
+	IdempotencyKey string                `json:"idempotencyKey"`
+	OccurredOn     string                `json:"occurredOn"`
+	Notes          *string               `json:"notes,omitempty"`
+	Lines          []TransferLineRequest `json:"lines"`
+}
+
+type TransferLineRequest struct {
+	LotID          int64 `json:"lotId"`
+	QuantityAtomic int64 `json:"quantityAtomic"`
+	ToLocationID   int64 `json:"toLocationId"`
+}
+
+type TransferDocumentResponse struct {
+	ID              int64                  `json:"id"`
+	IdempotencyKey  string                 `json:"idempotencyKey"`
+	PostingSequence int64                  `json:"postingSequence"`
+	OccurredOn      string                 `json:"occurredOn"`
+	PostedAtMs      int64                  `json:"postedAtMs"`
+	Notes           *string                `json:"notes,omitempty"`
+	Lines           []TransferLineResponse `json:"lines"`
+}
+
+type TransferLineResponse struct {
+	OutLineID                 int64   `json:"outLineId"`
+	InLineID                  int64   `json:"inLineId"`
+	LineOrder                 int64   `json:"lineOrder"`
+	ItemID                    int64   `json:"itemId"`
+	QuantityAtomic            int64   `json:"quantityAtomic"`
+	EnteredUnitCode           string  `json:"enteredUnitCode"`
+	EnteredPackagingName      *string `json:"enteredPackagingName,omitempty"`
+	ConversionNumeratorAtomic int64   `json:"conversionNumeratorAtomic"`
+	ConversionDenominator     int64   `json:"conversionDenominator"`
+	InventoryValueMicro       int64   `json:"inventoryValueMicro"`
+	SourceLotID               int64   `json:"sourceLotId"`
+	AllocationID              int64   `json:"allocationId"`
+	FromLocationID            int64   `json:"fromLocationId"`
+	LotID                     int64   `json:"lotId"`
+	ToLocationID              int64   `json:"toLocationId"`
+}
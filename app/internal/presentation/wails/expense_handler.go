@@ -0,0 +1,180 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/expense"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type ExpenseHandler struct {
+	service *application.ExpenseService
+}
+
+func NewExpenseHandler(service *application.ExpenseService) *ExpenseHandler {
+	if service == nil {
+		panic("expense handler requires a service")
+	}
+	return &ExpenseHandler{service: service}
+}
+
+func (h *ExpenseHandler) RecordExpense(req dto.ExpenseRecordRequest) (dto.ExpenseResponse, error) {
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.ExpenseResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	fields, err := parseExpenseFields(req.Category, req.AmountMinor, req.OccurredOn, req.SupplierID, req.Notes)
+	if err != nil {
+		return dto.ExpenseResponse{}, err
+	}
+	recorded, err := h.service.RecordExpense(handlerContext(), application.ExpenseRecordInput{
+		IdempotencyKey: idempotencyKey,
+		Category:       fields.category,
+		Amount:         fields.amount,
+		OccurredOn:     fields.occurredOn,
+		SupplierID:     fields.supplierID,
+		Notes:          fields.notes,
+	})
+	if err != nil {
+		return dto.ExpenseResponse{}, fmt.Errorf("record expense: %w", err)
+	}
+	return mapExpense(recorded), nil
+}
+
+func (h *ExpenseHandler) CorrectExpense(id int64, req dto.ExpenseCorrectRequest) (dto.ExpenseResponse, error) {
+	expenseID, err := domain.NewExpenseID(id)
+	if err != nil {
+		return dto.ExpenseResponse{}, fmt.Errorf("expense id: %w", err)
+	}
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.ExpenseResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	fields, err := parseExpenseFields(req.Category, req.AmountMinor, req.OccurredOn, req.SupplierID, req.Notes)
+	if err != nil {
+		return dto.ExpenseResponse{}, err
+	}
+	correction, err := h.service.CorrectExpense(handlerContext(), application.ExpenseCorrectInput{
+		IdempotencyKey: idempotencyKey,
+		ExpenseID:      expenseID,
+		Category:       fields.category,
+		Amount:         fields.amount,
+		OccurredOn:     fields.occurredOn,
+		SupplierID:     fields.supplierID,
+		Notes:          fields.notes,
+	})
+	if err != nil {
+		return dto.ExpenseResponse{}, fmt.Errorf("correct expense: %w", err)
+	}
+	return mapExpense(correction), nil
+}
+
+func (h *ExpenseHandler) GetExpense(id int64) (dto.ExpenseResponse, error) {
+	expenseID, err := domain.NewExpenseID(id)
+	if err != nil {
+		return dto.ExpenseResponse{}, fmt.Errorf("expense id: %w", err)
+	}
+	loaded, err := h.service.GetExpense(handlerContext(), expenseID)
+	if err != nil {
+		return dto.ExpenseResponse{}, fmt.Errorf("get expense: %w", err)
+	}
+	return mapExpense(loaded), nil
+}
+
+func (h *ExpenseHandler) ListExpenses(req dto.ExpenseListRequest) ([]dto.ExpenseResponse, error) {
+	from, err := domain.ParseBusinessDate(req.FromOccurredOn)
+	if err != nil {
+		return nil, fmt.Errorf("from occurred on: %w", err)
+	}
+	to, err := domain.ParseBusinessDate(req.ToOccurredOn)
+	if err != nil {
+		return nil, fmt.Errorf("to occurred on: %w", err)
+	}
+	category := domain.None[domain.ExpenseCategory]()
+	if req.Category != nil {
+		parsed, err := domain.ParseExpenseCategory(*req.Category)
+		if err != nil {
+			return nil, fmt.Errorf("category: %w", err)
+		}
+		category = domain.Some(parsed)
+	}
+	expenses, err := h.service.ListExpenses(handlerContext(), application.ExpenseListInput{
+		FromOccurredOn: from,
+		ToOccurredOn:   to,
+		Category:       category,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list expenses: %w", err)
+	}
+	response := make([]dto.ExpenseResponse, 0, len(expenses))
+	for _, item := range expenses {
+		response = append(response, mapExpense(item))
+	}
+	return response, nil
+}
+
+type expenseFields struct {
+	category   domain.ExpenseCategory
+	amount     domain.MinorAmount
+	occurredOn domain.BusinessDate
+	supplierID domain.Option[domain.CounterpartyID]
+	notes      domain.Option[domain.NonEmptyText]
+}
+
+func parseExpenseFields(
+	category string,
+	amountMinor int64,
+	occurredOn string,
+	supplierID *int64,
+	notes *string,
+) (expenseFields, error) {
+	var fields expenseFields
+	var err error
+	if fields.category, err = domain.ParseExpenseCategory(category); err != nil {
+		return expenseFields{}, fmt.Errorf("category: %w", err)
+	}
+	if fields.amount, err = domain.NewMinorAmount(amountMinor); err != nil {
+		return expenseFields{}, fmt.Errorf("amount: %w", err)
+	}
+	if fields.occurredOn, err = domain.ParseBusinessDate(occurredOn); err != nil {
+		return expenseFields{}, fmt.Errorf("occurred on: %w", err)
+	}
+	fields.supplierID = domain.None[domain.CounterpartyID]()
+	if supplierID != nil {
+		parsed, err := domain.NewCounterpartyID(*supplierID)
+		if err != nil {
+			return expenseFields{}, fmt.Errorf("supplier id: %w", err)
+		}
+		fields.supplierID = domain.Some(parsed)
+	}
+	if fields.notes, err = optionalNonEmptyText(notes); err != nil {
+		return expenseFields{}, fmt.Errorf("notes: %w", err)
+	}
+	return fields, nil
+}
+
+func mapExpense(value expense.Expense) dto.ExpenseResponse {
+	return dto.ExpenseResponse{
+		ID:                value.ID().Int64(),
+		IdempotencyKey:    value.IdempotencyKey().String(),
+		Category:          value.Category().String(),
+		AmountMinor:       value.Amount().Int64(),
+		OccurredOn:        value.OccurredOn().String(),
+		SupplierID:        optionalCounterpartyIDValue(value.SupplierID()),
+		Notes:             optionalText(value.Notes()),
+		CorrectsExpenseID: optionalExpenseIDValue(value.CorrectsExpenseID()),
+		CorrectedByID:     optionalExpenseIDValue(value.CorrectedByID()),
+		RecordedAtMs:      value.RecordedAt().UnixMilli(),
+	}
+}
+
+func optionalExpenseIDValue(value domain.Option[domain.ExpenseID]) *int64 {
+	id, ok := value.Get()
+	if !ok {
+		return nil
+	}
+	raw := id.Int64()
+	return &raw
+}
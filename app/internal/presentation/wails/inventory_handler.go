@@ -56,7 +56,7 @@ func (h *InventoryHandler) ListItemLotFacts(itemID int64) ([]dto.LotResponse, er
 	return mapLots(lots), nil
 }
 
-func (h *InventoryHandler) ListEligibleFEFOLots(itemID int64, businessDate string) ([]dto.LotResponse, error) {
+func (h *InventoryHandler) ListEligibleFEFOLots(itemID int64, businessDate string, locationID *int64) ([]dto.LotResponse, error) {
 	id, err := domain.NewItemID(itemID)
 	if err != nil {
 		return nil, fmt.Errorf("item id: %w", err)
@@ -65,7 +65,11 @@ func (h *InventoryHandler) ListEligibleFEFOLots(itemID int64, businessDate strin
 	if err != nil {
 		return nil, fmt.Errorf("business date: %w", err)
 	}
-	lots, err := h.service.ListEligibleFEFOLots(handlerContext(), id, on)
+	location, err := optionalStockLocationIDInput(locationID)
+	if err != nil {
+		return nil, fmt.Errorf("location id: %w", err)
+	}
+	lots, err := h.service.ListEligibleFEFOLots(handlerContext(), id, on, location)
 	if err != nil {
 		return nil, fmt.Errorf("list eligible FEFO lots: %w", err)
 	}
@@ -117,10 +121,15 @@ func parseInventoryBalanceListRequest(req dto.InventoryBalanceListRequest) (appl
 		}
 		after = domain.Some(application.InventoryBalanceCursor{ItemName: name, ItemID: id})
 	}
+	location, err := optionalStockLocationIDInput(req.LocationID)
+	if err != nil {
+		return application.InventoryBalanceListInput{}, fmt.Errorf("location id: %w", err)
+	}
 	return application.InventoryBalanceListInput{
 		IncludeArchived: req.IncludeArchived,
 		Search:          search,
 		After:           after,
+		Location:        location,
 		PageSize:        req.PageSize,
 	}, nil
 }
@@ -213,6 +222,8 @@ func mapLot(view inventorydomain.LotView) dto.LotResponse {
 		SourceDocumentID:      view.SourceDocumentID().Int64(),
 		SourceKind:            view.SourceKind().String(),
 		SourceOccurredOn:      view.SourceOccurredOn().String(),
+		LocationID:            view.LocationID().Int64(),
+		TransferredFromLotID:  optionalInventoryLotID(view.TransferredFromLotID()),
 	}
 }
 
@@ -0,0 +1,154 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	inventorydomain "github.com/jerobas/saas/internal/domain/inventory"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type LocationHandler struct {
+	service *application.LocationService
+}
+
+func NewLocationHandler(service *application.LocationService) *LocationHandler {
+	if service == nil {
+		panic("location handler requires a service")
+	}
+	return &LocationHandler{service: service}
+}
+
+func (h *LocationHandler) GetLocation(id int64) (dto.LocationResponse, error) {
+	locationID, err := domain.NewStockLocationID(id)
+	if err != nil {
+		return dto.LocationResponse{}, fmt.Errorf("location id: %w", err)
+	}
+	location, err := h.service.GetLocation(handlerContext(), locationID)
+	if err != nil {
+		return dto.LocationResponse{}, fmt.Errorf("get location: %w", err)
+	}
+	return mapLocation(location), nil
+}
+
+func (h *LocationHandler) ListLocations(req dto.LocationListRequest) ([]dto.LocationResponse, error) {
+	archive := domain.ArchiveActive
+	if req.ArchiveFilter != "" {
+		parsed, err := domain.ParseArchiveFilter(req.ArchiveFilter)
+		if err != nil {
+			return nil, err
+		}
+		archive = parsed
+	}
+	locations, err := h.service.ListLocations(handlerContext(), archive)
+	if err != nil {
+		return nil, fmt.Errorf("list locations: %w", err)
+	}
+	response := make([]dto.LocationResponse, 0, len(locations))
+	for _, location := range locations {
+		response = append(response, mapLocation(location))
+	}
+	return response, nil
+}
+
+func (h *LocationHandler) CreateLocation(req dto.LocationWriteRequest) (dto.LocationResponse, error) {
+	name, err := domain.NewUniqueName(req.Name)
+	if err != nil {
+		return dto.LocationResponse{}, fmt.Errorf("name: %w", err)
+	}
+	location, err := h.service.CreateLocation(handlerContext(), application.LocationCreateInput{Name: name})
+	if err != nil {
+		return dto.LocationResponse{}, fmt.Errorf("create location: %w", err)
+	}
+	return mapLocation(location), nil
+}
+
+func (h *LocationHandler) UpdateLocation(id int64, req dto.LocationUpdateRequest) (dto.LocationResponse, error) {
+	locationID, expectedUpdatedAt, err := parseVersionedLocation(id, dto.VersionedRequest{ExpectedUpdatedAtMs: req.ExpectedUpdatedAtMs})
+	if err != nil {
+		return dto.LocationResponse{}, err
+	}
+	name, err := domain.NewUniqueName(req.Name)
+	if err != nil {
+		return dto.LocationResponse{}, fmt.Errorf("name: %w", err)
+	}
+	location, err := h.service.UpdateLocation(handlerContext(), application.LocationUpdateInput{
+		ID: locationID, Name: name, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.LocationResponse{}, fmt.Errorf("update location: %w", err)
+	}
+	return mapLocation(location), nil
+}
+
+func (h *LocationHandler) ArchiveLocation(id int64, req dto.VersionedRequest) (dto.LocationResponse, error) {
+	locationID, expectedUpdatedAt, err := parseVersionedLocation(id, req)
+	if err != nil {
+		return dto.LocationResponse{}, err
+	}
+	location, err := h.service.ArchiveLocation(handlerContext(), application.LocationArchiveInput{
+		ID: locationID, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.LocationResponse{}, fmt.Errorf("archive location: %w", err)
+	}
+	return mapLocation(location), nil
+}
+
+func (h *LocationHandler) RestoreLocation(id int64, req dto.VersionedRequest) (dto.LocationResponse, error) {
+	locationID, expectedUpdatedAt, err := parseVersionedLocation(id, req)
+	if err != nil {
+		return dto.LocationResponse{}, err
+	}
+	location, err := h.service.RestoreLocation(handlerContext(), application.LocationRestoreInput{
+		ID: locationID, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.LocationResponse{}, fmt.Errorf("restore location: %w", err)
+	}
+	return mapLocation(location), nil
+}
+
+func parseVersionedLocation(id int64, req dto.VersionedRequest) (domain.StockLocationID, domain.UTCInstant, error) {
+	locationID, err := domain.NewStockLocationID(id)
+	if err != nil {
+		return domain.StockLocationID{}, domain.UTCInstant{}, fmt.Errorf("location id: %w", err)
+	}
+	expectedUpdatedAt, err := domain.UTCInstantFromUnixMilli(req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return domain.StockLocationID{}, domain.UTCInstant{}, fmt.Errorf("expected updated at: %w", err)
+	}
+	return locationID, expectedUpdatedAt, nil
+}
+
+func mapLocation(location inventorydomain.Location) dto.LocationResponse {
+	return dto.LocationResponse{
+		ID:           location.ID().Int64(),
+		Name:         location.Name().Display(),
+		IsDefault:    location.IsDefault(),
+		CreatedAtMs:  location.CreatedAt().UnixMilli(),
+		UpdatedAtMs:  location.UpdatedAt().UnixMilli(),
+		ArchivedAtMs: optionalInstant(location.ArchivedAt()),
+	}
+}
+
+func optionalStockLocationIDInput(value *int64) (domain.Option[domain.StockLocationID], error) {
+	if value == nil {
+		return domain.None[domain.StockLocationID](), nil
+	}
+	id, err := domain.NewStockLocationID(*value)
+	if err != nil {
+		return domain.None[domain.StockLocationID](), err
+	}
+	return domain.Some(id), nil
+}
+
+func optionalStockLocationIDValue(value domain.Option[domain.StockLocationID]) *int64 {
+	id, ok := value.Get()
+	if !ok {
+		return nil
+	}
+	raw := id.Int64()
+	return &raw
+}
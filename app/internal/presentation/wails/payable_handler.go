@@ -0,0 +1,233 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type PayableHandler struct {
+	service *application.PayableService
+}
+
+func NewPayableHandler(service *application.PayableService) *PayableHandler {
+	if service == nil {
+		panic("payable handler requires a service")
+	}
+	return &PayableHandler{service: service}
+}
+
+func (h *PayableHandler) SchedulePurchasePayable(
+	purchaseDocumentID int64,
+	req dto.PurchasePayableScheduleRequest,
+) (dto.PurchasePayableResponse, error) {
+	purchaseID, err := domain.NewStockDocumentID(purchaseDocumentID)
+	if err != nil {
+		return dto.PurchasePayableResponse{}, fmt.Errorf("purchase document id: %w", err)
+	}
+	installments := make([]purchasing.Installment, 0, len(req.Installments))
+	for index, installment := range req.Installments {
+		dueOn, err := domain.ParseBusinessDate(installment.DueOn)
+		if err != nil {
+			return dto.PurchasePayableResponse{}, fmt.Errorf("installment %d due on: %w", index+1, err)
+		}
+		amount, err := domain.NewMinorAmount(installment.AmountMinor)
+		if err != nil {
+			return dto.PurchasePayableResponse{}, fmt.Errorf("installment %d amount: %w", index+1, err)
+		}
+		installments = append(installments, purchasing.Installment{Number: index + 1, DueOn: dueOn, Amount: amount})
+	}
+	payable, err := h.service.SchedulePurchasePayable(handlerContext(), application.PurchasePayableScheduleInput{
+		PurchaseDocumentID: purchaseID,
+		Installments:       installments,
+	})
+	if err != nil {
+		return dto.PurchasePayableResponse{}, fmt.Errorf("schedule purchase payable: %w", err)
+	}
+	return mapPurchasePayable(payable), nil
+}
+
+func (h *PayableHandler) GetPurchasePayable(purchaseDocumentID int64) (dto.PurchasePayableResponse, error) {
+	purchaseID, err := domain.NewStockDocumentID(purchaseDocumentID)
+	if err != nil {
+		return dto.PurchasePayableResponse{}, fmt.Errorf("purchase document id: %w", err)
+	}
+	payable, err := h.service.GetPurchasePayable(handlerContext(), purchaseID)
+	if err != nil {
+		return dto.PurchasePayableResponse{}, fmt.Errorf("get purchase payable: %w", err)
+	}
+	return mapPurchasePayable(payable), nil
+}
+
+func (h *PayableHandler) ListDuePayables(asOf string, supplierID *int64) (dto.PayablesDueResponse, error) {
+	date, err := domain.ParseBusinessDate(asOf)
+	if err != nil {
+		return dto.PayablesDueResponse{}, fmt.Errorf("as of: %w", err)
+	}
+	supplier := domain.None[domain.CounterpartyID]()
+	if supplierID != nil {
+		parsed, err := domain.NewCounterpartyID(*supplierID)
+		if err != nil {
+			return dto.PayablesDueResponse{}, fmt.Errorf("supplier id: %w", err)
+		}
+		supplier = domain.Some(parsed)
+	}
+	due, err := h.service.ListDuePayables(handlerContext(), date, supplier)
+	if err != nil {
+		return dto.PayablesDueResponse{}, fmt.Errorf("list due payables: %w", err)
+	}
+	suppliers := due.Suppliers()
+	response := dto.PayablesDueResponse{
+		AsOf:             due.AsOf().String(),
+		Suppliers:        make([]dto.SupplierDueResponse, 0, len(suppliers)),
+		OverdueMinor:     due.OverdueMinor(),
+		DueThisWeekMinor: due.DueThisWeekMinor(),
+	}
+	for _, supplier := range suppliers {
+		installments := make([]dto.DueInstallmentResponse, 0, len(supplier.Installments))
+		for _, installment := range supplier.Installments {
+			installments = append(installments, dto.DueInstallmentResponse{
+				PurchaseDocumentID: installment.PurchaseDocumentID.Int64(),
+				Number:             installment.Number,
+				DueOn:              installment.DueOn.String(),
+				AmountMinor:        installment.Amount.Int64(),
+				OpenMinor:          installment.OpenMinor,
+				Overdue:            installment.Overdue,
+			})
+		}
+		response.Suppliers = append(response.Suppliers, dto.SupplierDueResponse{
+			SupplierID:       supplier.SupplierID.Int64(),
+			Installments:     installments,
+			OverdueMinor:     supplier.OverdueMinor,
+			DueThisWeekMinor: supplier.DueThisWeekMinor,
+		})
+	}
+	return response, nil
+}
+
+func (h *PayableHandler) RecordSupplierPayment(req dto.SupplierPaymentRecordRequest) (dto.SupplierPaymentResponse, error) {
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	purchaseID, err := domain.NewStockDocumentID(req.PurchaseDocumentID)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("purchase document id: %w", err)
+	}
+	method, err := domain.ParsePaymentMethod(req.Method)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("method: %w", err)
+	}
+	amount, err := domain.NewMinorAmount(req.AmountMinor)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("amount: %w", err)
+	}
+	paidOn, err := domain.ParseBusinessDate(req.PaidOn)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("paid on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("notes: %w", err)
+	}
+	payment, err := h.service.RecordSupplierPayment(handlerContext(), application.SupplierPaymentRecordInput{
+		IdempotencyKey:     idempotencyKey,
+		PurchaseDocumentID: purchaseID,
+		Method:             method,
+		Amount:             amount,
+		PaidOn:             paidOn,
+		Notes:              notes,
+	})
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("record supplier payment: %w", err)
+	}
+	return mapSupplierPayment(payment), nil
+}
+
+func (h *PayableHandler) ReverseSupplierPayment(
+	id int64,
+	req dto.SupplierPaymentReverseRequest,
+) (dto.SupplierPaymentResponse, error) {
+	paymentID, err := domain.NewSupplierPaymentID(id)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("supplier payment id: %w", err)
+	}
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	paidOn, err := domain.ParseBusinessDate(req.PaidOn)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("paid on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("notes: %w", err)
+	}
+	reversal, err := h.service.ReverseSupplierPayment(handlerContext(), application.SupplierPaymentReverseInput{
+		IdempotencyKey: idempotencyKey,
+		PaymentID:      paymentID,
+		PaidOn:         paidOn,
+		Notes:          notes,
+	})
+	if err != nil {
+		return dto.SupplierPaymentResponse{}, fmt.Errorf("reverse supplier payment: %w", err)
+	}
+	return mapSupplierPayment(reversal), nil
+}
+
+func mapPurchasePayable(payable purchasing.Payable) dto.PurchasePayableResponse {
+	openByNumber := make(map[int]int64)
+	for _, open := range payable.OpenInstallments() {
+		openByNumber[open.Number] = open.OpenMinor
+	}
+	installments := payable.Installments()
+	payments := payable.Payments()
+	response := dto.PurchasePayableResponse{
+		PurchaseDocumentID: payable.PurchaseDocumentID().Int64(),
+		SupplierID:         payable.SupplierID().Int64(),
+		OccurredOn:         payable.OccurredOn().String(),
+		PurchaseTotalMinor: payable.PurchaseTotal().Int64(),
+		CreditedMinor:      payable.Credited().Int64(),
+		Reversed:           payable.Reversed(),
+		OwedMinor:          payable.Owed(),
+		PaidMinor:          payable.Paid(),
+		OutstandingMinor:   payable.Outstanding(),
+		Installments:       make([]dto.InstallmentResponse, 0, len(installments)),
+		Payments:           make([]dto.SupplierPaymentResponse, 0, len(payments)),
+		CreatedAtMs:        payable.CreatedAt().UnixMilli(),
+	}
+	for _, installment := range installments {
+		response.Installments = append(response.Installments, dto.InstallmentResponse{
+			Number:      installment.Number,
+			DueOn:       installment.DueOn.String(),
+			AmountMinor: installment.Amount.Int64(),
+			OpenMinor:   openByNumber[installment.Number],
+		})
+	}
+	for _, payment := range payments {
+		response.Payments = append(response.Payments, mapSupplierPayment(payment))
+	}
+	return response
+}
+
+func mapSupplierPayment(payment purchasing.SupplierPayment) dto.SupplierPaymentResponse {
+	response := dto.SupplierPaymentResponse{
+		ID:                 payment.ID().Int64(),
+		IdempotencyKey:     payment.IdempotencyKey().String(),
+		PurchaseDocumentID: payment.PurchaseDocumentID().Int64(),
+		Method:             payment.Method().String(),
+		AmountMinor:        payment.Amount().Int64(),
+		PaidOn:             payment.PaidOn().String(),
+		Notes:              optionalText(payment.Notes()),
+		RecordedAtMs:       payment.RecordedAt().UnixMilli(),
+	}
+	if reverses, ok := payment.ReversesPaymentID().Get(); ok {
+		raw := reverses.Int64()
+		response.ReversesPaymentID = &raw
+	}
+	return response
+}
@@ -0,0 +1,194 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type PaymentHandler struct {
+	service *application.PaymentService
+}
+
+func NewPaymentHandler(service *application.PaymentService) *PaymentHandler {
+	if service == nil {
+		panic("payment handler requires a service")
+	}
+	return &PaymentHandler{service: service}
+}
+
+func (h *PaymentHandler) GetSaleBalance(saleDocumentID int64) (dto.SaleBalanceResponse, error) {
+	saleID, err := domain.NewStockDocumentID(saleDocumentID)
+	if err != nil {
+		return dto.SaleBalanceResponse{}, fmt.Errorf("sale document id: %w", err)
+	}
+	balance, err := h.service.GetSaleBalance(handlerContext(), saleID)
+	if err != nil {
+		return dto.SaleBalanceResponse{}, fmt.Errorf("get sale balance: %w", err)
+	}
+	return mapSaleBalance(balance), nil
+}
+
+func (h *PaymentHandler) GetCustomerBalance(customerID int64) (dto.CustomerBalanceResponse, error) {
+	id, err := domain.NewCounterpartyID(customerID)
+	if err != nil {
+		return dto.CustomerBalanceResponse{}, fmt.Errorf("customer id: %w", err)
+	}
+	balance, err := h.service.GetCustomerBalance(handlerContext(), id)
+	if err != nil {
+		return dto.CustomerBalanceResponse{}, fmt.Errorf("get customer balance: %w", err)
+	}
+	response := dto.CustomerBalanceResponse{
+		CustomerID:       balance.CustomerID.Int64(),
+		OutstandingMinor: balance.Outstanding,
+		Sales:            make([]dto.SaleBalanceResponse, 0, len(balance.Sales)),
+	}
+	for _, sale := range balance.Sales {
+		response.Sales = append(response.Sales, mapSaleBalance(sale))
+	}
+	return response, nil
+}
+
+func (h *PaymentHandler) GetReceivablesAging(asOf string) (dto.ReceivablesAgingResponse, error) {
+	date, err := domain.ParseBusinessDate(asOf)
+	if err != nil {
+		return dto.ReceivablesAgingResponse{}, fmt.Errorf("as of: %w", err)
+	}
+	aging, err := h.service.GetReceivablesAging(handlerContext(), date)
+	if err != nil {
+		return dto.ReceivablesAgingResponse{}, fmt.Errorf("get receivables aging: %w", err)
+	}
+	customers := aging.Customers()
+	response := dto.ReceivablesAgingResponse{
+		AsOf:      aging.AsOf().String(),
+		Customers: make([]dto.CustomerAgingResponse, 0, len(customers)),
+		Totals:    mapAgingBuckets(aging.Totals()),
+	}
+	for _, customer := range customers {
+		response.Customers = append(response.Customers, dto.CustomerAgingResponse{
+			CustomerID: optionalCounterpartyIDValue(customer.CustomerID),
+			SaleCount:  customer.SaleCount,
+			Buckets:    mapAgingBuckets(customer.Buckets),
+		})
+	}
+	return response, nil
+}
+
+func (h *PaymentHandler) RecordSalePayment(req dto.SalePaymentRecordRequest) (dto.SalePaymentResponse, error) {
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	saleID, err := domain.NewStockDocumentID(req.SaleDocumentID)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("sale document id: %w", err)
+	}
+	method, err := domain.ParsePaymentMethod(req.Method)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("method: %w", err)
+	}
+	amount, err := domain.NewMinorAmount(req.AmountMinor)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("amount: %w", err)
+	}
+	receivedOn, err := domain.ParseBusinessDate(req.ReceivedOn)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("received on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("notes: %w", err)
+	}
+	payment, err := h.service.RecordSalePayment(handlerContext(), application.SalePaymentRecordInput{
+		IdempotencyKey: idempotencyKey,
+		SaleDocumentID: saleID,
+		Method:         method,
+		Amount:         amount,
+		ReceivedOn:     receivedOn,
+		Notes:          notes,
+	})
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("record sale payment: %w", err)
+	}
+	return mapSalePayment(payment), nil
+}
+
+func (h *PaymentHandler) ReverseSalePayment(id int64, req dto.SalePaymentReverseRequest) (dto.SalePaymentResponse, error) {
+	paymentID, err := domain.NewSalePaymentID(id)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("sale payment id: %w", err)
+	}
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	receivedOn, err := domain.ParseBusinessDate(req.ReceivedOn)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("received on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("notes: %w", err)
+	}
+	reversal, err := h.service.ReverseSalePayment(handlerContext(), application.SalePaymentReverseInput{
+		IdempotencyKey: idempotencyKey,
+		PaymentID:      paymentID,
+		ReceivedOn:     receivedOn,
+		Notes:          notes,
+	})
+	if err != nil {
+		return dto.SalePaymentResponse{}, fmt.Errorf("reverse sale payment: %w", err)
+	}
+	return mapSalePayment(reversal), nil
+}
+
+func mapSaleBalance(balance sales.SaleBalance) dto.SaleBalanceResponse {
+	payments := balance.Payments()
+	response := dto.SaleBalanceResponse{
+		SaleDocumentID:   balance.SaleDocumentID().Int64(),
+		CustomerID:       optionalCounterpartyIDValue(balance.CustomerID()),
+		OccurredOn:       balance.OccurredOn().String(),
+		SaleTotalMinor:   balance.SaleTotal().Int64(),
+		ReturnedMinor:    balance.Returned().Int64(),
+		Reversed:         balance.Reversed(),
+		ReceivableMinor:  balance.Receivable(),
+		SettledMinor:     balance.Settled(),
+		OnAccountMinor:   balance.OnAccount(),
+		OutstandingMinor: balance.Outstanding(),
+		Payments:         make([]dto.SalePaymentResponse, 0, len(payments)),
+	}
+	for _, payment := range payments {
+		response.Payments = append(response.Payments, mapSalePayment(payment))
+	}
+	return response
+}
+
+func mapSalePayment(payment sales.Payment) dto.SalePaymentResponse {
+	response := dto.SalePaymentResponse{
+		ID:             payment.ID().Int64(),
+		IdempotencyKey: payment.IdempotencyKey().String(),
+		SaleDocumentID: payment.SaleDocumentID().Int64(),
+		Method:         payment.Method().String(),
+		AmountMinor:    payment.Amount().Int64(),
+		ReceivedOn:     payment.ReceivedOn().String(),
+		Notes:          optionalText(payment.Notes()),
+		RecordedAtMs:   payment.RecordedAt().UnixMilli(),
+	}
+	if reverses, ok := payment.ReversesPaymentID().Get(); ok {
+		raw := reverses.Int64()
+		response.ReversesPaymentID = &raw
+	}
+	return response
+}
+
+func mapAgingBuckets(buckets sales.AgingBuckets) dto.AgingBucketsResponse {
+	return dto.AgingBucketsResponse{
+		Days0To30Minor:  buckets.Days0To30,
+		Days31To60Minor: buckets.Days31To60,
+		Over60DaysMinor: buckets.Over60Days,
+		TotalMinor:      buckets.Total(),
+	}
+}
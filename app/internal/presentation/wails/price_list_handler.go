@@ -0,0 +1,284 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type PriceListHandler struct {
+	service *application.PriceListService
+}
+
+func NewPriceListHandler(service *application.PriceListService) *PriceListHandler {
+	if service == nil {
+		panic("price list handler requires a service")
+	}
+	return &PriceListHandler{service: service}
+}
+
+func (h *PriceListHandler) GetPriceList(id int64) (dto.PriceListResponse, error) {
+	listID, err := domain.NewPriceListID(id)
+	if err != nil {
+		return dto.PriceListResponse{}, fmt.Errorf("price list id: %w", err)
+	}
+	list, err := h.service.GetPriceList(handlerContext(), listID)
+	if err != nil {
+		return dto.PriceListResponse{}, fmt.Errorf("get price list: %w", err)
+	}
+	return mapPriceList(list), nil
+}
+
+func (h *PriceListHandler) ListPriceLists(req dto.PriceListListRequest) ([]dto.PriceListResponse, error) {
+	archive := domain.ArchiveActive
+	if req.ArchiveFilter != "" {
+		parsed, err := domain.ParseArchiveFilter(req.ArchiveFilter)
+		if err != nil {
+			return nil, err
+		}
+		archive = parsed
+	}
+	lists, err := h.service.ListPriceLists(handlerContext(), archive)
+	if err != nil {
+		return nil, fmt.Errorf("list price lists: %w", err)
+	}
+	response := make([]dto.PriceListResponse, 0, len(lists))
+	for _, list := range lists {
+		response = append(response, mapPriceList(list))
+	}
+	return response, nil
+}
+
+func (h *PriceListHandler) CreatePriceList(req dto.PriceListWriteRequest) (dto.PriceListResponse, error) {
+	fields, err := parsePriceListWriteRequest(req)
+	if err != nil {
+		return dto.PriceListResponse{}, err
+	}
+	list, err := h.service.CreatePriceList(handlerContext(), fields)
+	if err != nil {
+		return dto.PriceListResponse{}, fmt.Errorf("create price list: %w", err)
+	}
+	return mapPriceList(list), nil
+}
+
+func (h *PriceListHandler) UpdatePriceList(id int64, req dto.PriceListUpdateRequest) (dto.PriceListResponse, error) {
+	listID, expectedUpdatedAt, err := parseVersionedPriceList(id, dto.VersionedRequest{ExpectedUpdatedAtMs: req.ExpectedUpdatedAtMs})
+	if err != nil {
+		return dto.PriceListResponse{}, err
+	}
+	fields, err := parsePriceListWriteRequest(req.PriceListWriteRequest)
+	if err != nil {
+		return dto.PriceListResponse{}, err
+	}
+	list, err := h.service.UpdatePriceList(handlerContext(), application.PriceListUpdateInput{
+		ID:                listID,
+		Name:              fields.Name,
+		ValidFrom:         fields.ValidFrom,
+		ValidTo:           fields.ValidTo,
+		Prices:            fields.Prices,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.PriceListResponse{}, fmt.Errorf("update price list: %w", err)
+	}
+	return mapPriceList(list), nil
+}
+
+func (h *PriceListHandler) ArchivePriceList(id int64, req dto.VersionedRequest) (dto.PriceListResponse, error) {
+	listID, expectedUpdatedAt, err := parseVersionedPriceList(id, req)
+	if err != nil {
+		return dto.PriceListResponse{}, err
+	}
+	list, err := h.service.ArchivePriceList(handlerContext(), application.PriceListArchiveInput{
+		ID: listID, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.PriceListResponse{}, fmt.Errorf("archive price list: %w", err)
+	}
+	return mapPriceList(list), nil
+}
+
+func (h *PriceListHandler) RestorePriceList(id int64, req dto.VersionedRequest) (dto.PriceListResponse, error) {
+	listID, expectedUpdatedAt, err := parseVersionedPriceList(id, req)
+	if err != nil {
+		return dto.PriceListResponse{}, err
+	}
+	list, err := h.service.RestorePriceList(handlerContext(), application.PriceListRestoreInput{
+		ID: listID, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.PriceListResponse{}, fmt.Errorf("restore price list: %w", err)
+	}
+	return mapPriceList(list), nil
+}
+
+// AssignCustomerPriceList returns the list now assigned to the customer, or
+// nil when the customer is back on default prices.
+func (h *PriceListHandler) AssignCustomerPriceList(
+	customerID int64,
+	req dto.CustomerPriceListAssignRequest,
+) (*dto.PriceListResponse, error) {
+	customer, err := domain.NewCounterpartyID(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("customer id: %w", err)
+	}
+	listID := domain.None[domain.PriceListID]()
+	if req.PriceListID != nil {
+		parsed, err := domain.NewPriceListID(*req.PriceListID)
+		if err != nil {
+			return nil, fmt.Errorf("price list id: %w", err)
+		}
+		listID = domain.Some(parsed)
+	}
+	assigned, err := h.service.AssignCustomerPriceList(handlerContext(), application.CustomerPriceListAssignInput{
+		CustomerID:  customer,
+		PriceListID: listID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("assign customer price list: %w", err)
+	}
+	return optionalPriceList(assigned), nil
+}
+
+func (h *PriceListHandler) GetCustomerPriceList(customerID int64) (*dto.PriceListResponse, error) {
+	customer, err := domain.NewCounterpartyID(customerID)
+	if err != nil {
+		return nil, fmt.Errorf("customer id: %w", err)
+	}
+	assigned, err := h.service.GetCustomerPriceList(handlerContext(), customer)
+	if err != nil {
+		return nil, fmt.Errorf("get customer price list: %w", err)
+	}
+	return optionalPriceList(assigned), nil
+}
+
+func parsePriceListWriteRequest(req dto.PriceListWriteRequest) (application.PriceListCreateInput, error) {
+	name, err := domain.NewUniqueName(req.Name)
+	if err != nil {
+		return application.PriceListCreateInput{}, fmt.Errorf("name: %w", err)
+	}
+	validFrom, err := optionalBusinessDateFromString(req.ValidFrom)
+	if err != nil {
+		return application.PriceListCreateInput{}, fmt.Errorf("valid from: %w", err)
+	}
+	validTo, err := optionalBusinessDateFromString(req.ValidTo)
+	if err != nil {
+		return application.PriceListCreateInput{}, fmt.Errorf("valid to: %w", err)
+	}
+	prices := make([]application.PriceListPriceInput, 0, len(req.Prices))
+	for index, price := range req.Prices {
+		parsed, err := parsePriceListPriceRequest(price)
+		if err != nil {
+			return application.PriceListCreateInput{}, fmt.Errorf("price %d: %w", index+1, err)
+		}
+		prices = append(prices, parsed)
+	}
+	return application.PriceListCreateInput{
+		Name:      name,
+		ValidFrom: validFrom,
+		ValidTo:   validTo,
+		Prices:    prices,
+	}, nil
+}
+
+func parsePriceListPriceRequest(req dto.PriceListPriceRequest) (application.PriceListPriceInput, error) {
+	itemID, err := domain.NewItemID(req.ItemID)
+	if err != nil {
+		return application.PriceListPriceInput{}, fmt.Errorf("item id: %w", err)
+	}
+	packagingID := domain.None[domain.PackagingID]()
+	if req.PackagingID != nil {
+		parsed, err := domain.NewPackagingID(*req.PackagingID)
+		if err != nil {
+			return application.PriceListPriceInput{}, fmt.Errorf("packaging id: %w", err)
+		}
+		packagingID = domain.Some(parsed)
+	}
+	minQuantity, err := domain.NewAtomicQuantity(req.MinQuantityAtomic)
+	if err != nil {
+		return application.PriceListPriceInput{}, fmt.Errorf("min quantity: %w", err)
+	}
+	unitPrice, err := domain.NewMinorAmount(req.UnitPriceMinor)
+	if err != nil {
+		return application.PriceListPriceInput{}, fmt.Errorf("unit price: %w", err)
+	}
+	return application.PriceListPriceInput{
+		ItemID:      itemID,
+		PackagingID: packagingID,
+		MinQuantity: minQuantity,
+		UnitPrice:   unitPrice,
+	}, nil
+}
+
+func parseVersionedPriceList(id int64, req dto.VersionedRequest) (domain.PriceListID, domain.UTCInstant, error) {
+	listID, err := domain.NewPriceListID(id)
+	if err != nil {
+		return domain.PriceListID{}, domain.UTCInstant{}, fmt.Errorf("price list id: %w", err)
+	}
+	expectedUpdatedAt, err := domain.UTCInstantFromUnixMilli(req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return domain.PriceListID{}, domain.UTCInstant{}, fmt.Errorf("expected updated at: %w", err)
+	}
+	return listID, expectedUpdatedAt, nil
+}
+
+func mapPriceList(list sales.PriceList) dto.PriceListResponse {
+	prices := list.Prices()
+	response := dto.PriceListResponse{
+		ID:           list.ID().Int64(),
+		Name:         list.Name().Display(),
+		ValidFrom:    optionalBusinessDateValue(list.ValidFrom()),
+		ValidTo:      optionalBusinessDateValue(list.ValidTo()),
+		Prices:       make([]dto.PriceListPriceResponse, 0, len(prices)),
+		CreatedAtMs:  list.CreatedAt().UnixMilli(),
+		UpdatedAtMs:  list.UpdatedAt().UnixMilli(),
+		ArchivedAtMs: optionalInstant(list.ArchivedAt()),
+	}
+	for _, price := range prices {
+		var packagingName *string
+		if name, ok := price.PackagingName().Get(); ok {
+			display := name.Display()
+			packagingName = &display
+		}
+		response.Prices = append(response.Prices, dto.PriceListPriceResponse{
+			ItemID:                    price.ItemID().Int64(),
+			PackagingID:               optionalPackagingIDValue(price.PackagingID()),
+			PackagingName:             packagingName,
+			PricedUnitNumeratorAtomic: price.PricedUnit().NumeratorAtomic(),
+			PricedUnitDenominator:     price.PricedUnit().Denominator(),
+			MinQuantityAtomic:         price.MinQuantity().Int64(),
+			UnitPriceMinor:            price.UnitPrice().Int64(),
+		})
+	}
+	return response
+}
+
+func optionalPriceList(value domain.Option[sales.PriceList]) *dto.PriceListResponse {
+	list, ok := value.Get()
+	if !ok {
+		return nil
+	}
+	response := mapPriceList(list)
+	return &response
+}
+
+func optionalPriceListIDValue(value domain.Option[domain.PriceListID]) *int64 {
+	id, ok := value.Get()
+	if !ok {
+		return nil
+	}
+	raw := id.Int64()
+	return &raw
+}
+
+func optionalPackagingIDValue(value domain.Option[domain.PackagingID]) *int64 {
+	id, ok := value.Get()
+	if !ok {
+		return nil
+	}
+	raw := id.Int64()
+	return &raw
+}
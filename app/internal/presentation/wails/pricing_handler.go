@@ -0,0 +1,100 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type PricingHandler struct {
+	service *application.PricingService
+}
+
+func NewPricingHandler(service *application.PricingService) *PricingHandler {
+	if service == nil {
+		panic("pricing handler requires a service")
+	}
+	return &PricingHandler{service: service}
+}
+
+func (h *PricingHandler) SuggestRecipePrice(req dto.PriceSuggestionRequest) (dto.PriceSuggestionResponse, error) {
+	revisionID, err := domain.NewRecipeRevisionID(req.RevisionID)
+	if err != nil {
+		return dto.PriceSuggestionResponse{}, fmt.Errorf("recipe revision id: %w", err)
+	}
+	margin, err := optionalBasisPointsInput(req.GrossMarginBasisPoints)
+	if err != nil {
+		return dto.PriceSuggestionResponse{}, fmt.Errorf("gross margin: %w", err)
+	}
+	value, err := h.service.SuggestPrice(handlerContext(), application.PriceSuggestionInput{
+		RevisionID:  revisionID,
+		GrossMargin: margin,
+	})
+	if err != nil {
+		return dto.PriceSuggestionResponse{}, fmt.Errorf("suggest recipe price: %w", err)
+	}
+	return mapPriceSuggestion(value), nil
+}
+
+func (h *PricingHandler) ListUnderpricedItems(req dto.UnderpricedItemsRequest) (dto.UnderpricedItemsResponse, error) {
+	margin, err := optionalBasisPointsInput(req.GrossMarginBasisPoints)
+	if err != nil {
+		return dto.UnderpricedItemsResponse{}, fmt.Errorf("gross margin: %w", err)
+	}
+	values, err := h.service.ListUnderpricedItems(handlerContext(), margin)
+	if err != nil {
+		return dto.UnderpricedItemsResponse{}, fmt.Errorf("list underpriced items: %w", err)
+	}
+	response := dto.UnderpricedItemsResponse{
+		Items:          make([]dto.PriceSuggestionResponse, 0, len(values.Items)),
+		CostIncomplete: make([]dto.PriceSuggestionResponse, 0, len(values.CostIncomplete)),
+	}
+	for _, value := range values.Items {
+		response.Items = append(response.Items, mapPriceSuggestion(value))
+	}
+	for _, value := range values.CostIncomplete {
+		response.CostIncomplete = append(response.CostIncomplete, mapPriceSuggestion(value))
+	}
+	return response, nil
+}
+
+func mapPriceSuggestion(value application.PriceSuggestion) dto.PriceSuggestionResponse {
+	target := value.Target()
+	cost := value.Cost()
+	revision := cost.Revision()
+	return dto.PriceSuggestionResponse{
+		RecipeID:                  target.RecipeID.Int64(),
+		RecipeName:                target.RecipeName.Display(),
+		RevisionID:                revision.ID().Int64(),
+		CurrentRevisionID:         target.CurrentRevisionID.Int64(),
+		OutputItemID:              target.OutputItemID.Int64(),
+		OutputItemName:            target.OutputItemName.Display(),
+		CurrencyCode:              value.Currency().Code().String(),
+		CurrencyMinorDigits:       int64(value.Currency().MinorDigits().Int()),
+		YieldBaseUnitCode:         cost.YieldBaseUnit().String(),
+		PreparationTimeMinutes:    revision.PreparationTime().Int64(),
+		MaterialValueMicro:        cost.TotalValue().Int64(),
+		LaborCostConfigured:       value.LaborCostConfigured(),
+		LaborValueMicro:           value.LaborValue().Int64(),
+		BatchValueMicro:           value.BatchValue().Int64(),
+		CostPerYieldBaseUnitMicro: value.CostPerYieldBaseUnit().Int64(),
+		GrossMarginBasisPoints:    value.GrossMargin().Int64(),
+		SuggestedPriceMinor:       value.SuggestedPrice().Int64(),
+		CurrentPriceMinor:         optionalMinorAmount(value.CurrentPrice()),
+		Underpriced:               value.Underpriced(),
+		CostComplete:              cost.Complete(),
+	}
+}
+
+func optionalBasisPointsInput(value *int64) (domain.Option[domain.BasisPoints], error) {
+	if value == nil {
+		return domain.None[domain.BasisPoints](), nil
+	}
+	basisPoints, err := domain.NewBasisPoints(*value)
+	if err != nil {
+		return domain.None[domain.BasisPoints](), err
+	}
+	return domain.Some(basisPoints), nil
+}
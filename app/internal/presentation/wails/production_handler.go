@@ -19,6 +19,30 @@ func NewProductionHandler(service *application.ProductionService) *ProductionHan
 	return &ProductionHandler{service: service}
 }
 
+func (h *ProductionHandler) GetProduction(id int64) (dto.ProductionDocumentResponse, error) {
+	documentID, err := domain.NewStockDocumentID(id)
+	if err != nil {
+		return dto.ProductionDocumentResponse{}, fmt.Errorf("production id: %w", err)
+	}
+	document, err := h.service.GetProduction(handlerContext(), documentID)
+	if err != nil {
+		return dto.ProductionDocumentResponse{}, fmt.Errorf("get production: %w", err)
+	}
+	return mapProductionDocument(document), nil
+}
+
+func (h *ProductionHandler) ListProductions(req dto.ProductionListRequest) (dto.ProductionPageResponse, error) {
+	input, err := parseProductionListRequest(req)
+	if err != nil {
+		return dto.ProductionPageResponse{}, err
+	}
+	page, err := h.service.ListProductions(handlerContext(), input)
+	if err != nil {
+		return dto.ProductionPageResponse{}, fmt.Errorf("list productions: %w", err)
+	}
+	return mapProductionPage(page), nil
+}
+
 func (h *ProductionHandler) PostProduction(req dto.ProductionPostRequest) (dto.ProductionDocumentResponse, error) {
 	input, err := parseProductionPostRequest(req)
 	if err != nil {
@@ -31,6 +55,69 @@ func (h *ProductionHandler) PostProduction(req dto.ProductionPostRequest) (dto.P
 	return mapProductionDocument(posted), nil
 }
 
+func (h *ProductionHandler) ScaleProduction(req dto.ProductionScaleRequest) (dto.ScaledProductionResponse, error) {
+	input, err := parseProductionScaleRequest(req)
+	if err != nil {
+		return dto.ScaledProductionResponse{}, err
+	}
+	scaled, err := h.service.ScaleProduction(handlerContext(), input)
+	if err != nil {
+		return dto.ScaledProductionResponse{}, fmt.Errorf("scale production: %w", err)
+	}
+	return mapScaledProduction(scaled), nil
+}
+
+func parseProductionListRequest(req dto.ProductionListRequest) (application.ProductionListInput, error) {
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 50
+	}
+	recipeID := domain.None[domain.RecipeID]()
+	if req.RecipeID != nil {
+		parsed, err := domain.NewRecipeID(*req.RecipeID)
+		if err != nil {
+			return application.ProductionListInput{}, fmt.Errorf("recipe id: %w", err)
+		}
+		recipeID = domain.Some(parsed)
+	}
+	outputItemID := domain.None[domain.ItemID]()
+	if req.OutputItemID != nil {
+		parsed, err := domain.NewItemID(*req.OutputItemID)
+		if err != nil {
+			return application.ProductionListInput{}, fmt.Errorf("output item id: %w", err)
+		}
+		outputItemID = domain.Some(parsed)
+	}
+	from, err := optionalBusinessDateFromString(req.FromOccurredOn)
+	if err != nil {
+		return application.ProductionListInput{}, fmt.Errorf("from occurred on: %w", err)
+	}
+	to, err := optionalBusinessDateFromString(req.ToOccurredOn)
+	if err != nil {
+		return application.ProductionListInput{}, fmt.Errorf("to occurred on: %w", err)
+	}
+	after := domain.None[application.ProductionCursor]()
+	if req.After != nil {
+		postingSequence, err := domain.NewPostingSequence(req.After.PostingSequence)
+		if err != nil {
+			return application.ProductionListInput{}, fmt.Errorf("cursor posting sequence: %w", err)
+		}
+		id, err := domain.NewStockDocumentID(req.After.ID)
+		if err != nil {
+			return application.ProductionListInput{}, fmt.Errorf("cursor id: %w", err)
+		}
+		after = domain.Some(application.ProductionCursor{PostingSequence: postingSequence, ID: id})
+	}
+	return application.ProductionListInput{
+		RecipeID:       recipeID,
+		OutputItemID:   outputItemID,
+		FromOccurredOn: from,
+		ToOccurredOn:   to,
+		After:          after,
+		PageSize:       pageSize,
+	}, nil
+}
+
 func parseProductionPostRequest(req dto.ProductionPostRequest) (application.ProductionPostInput, error) {
 	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
 	if err != nil {
@@ -149,6 +236,83 @@ func parseProductionComponentRequest(req dto.ProductionComponentRequest) (applic
 	}, nil
 }
 
+func parseProductionScaleRequest(req dto.ProductionScaleRequest) (application.ProductionScaleInput, error) {
+	revisionID, err := domain.NewRecipeRevisionID(req.RecipeRevisionID)
+	if err != nil {
+		return application.ProductionScaleInput{}, fmt.Errorf("recipe revision id: %w", err)
+	}
+	input := application.ProductionScaleInput{
+		RevisionID:     revisionID,
+		Multiplier:     domain.None[domain.Fraction](),
+		OutputQuantity: domain.None[domain.AtomicQuantity](),
+	}
+	if req.Multiplier != nil {
+		multiplier, err := domain.ParseDecimalFraction(*req.Multiplier)
+		if err != nil {
+			return application.ProductionScaleInput{}, fmt.Errorf("multiplier: %w", err)
+		}
+		input.Multiplier = domain.Some(multiplier)
+	}
+	if req.OutputQuantityAtomic != nil {
+		quantity, err := domain.NewPositiveAtomicQuantity(*req.OutputQuantityAtomic)
+		if err != nil {
+			return application.ProductionScaleInput{}, fmt.Errorf("output quantity: %w", err)
+		}
+		input.OutputQuantity = domain.Some(quantity)
+	}
+	return input, nil
+}
+
+func mapScaledProduction(scaled application.ScaledProduction) dto.ScaledProductionResponse {
+	inputs := scaled.Inputs()
+	response := dto.ScaledProductionResponse{
+		RecipeRevisionID:         scaled.RevisionID().Int64(),
+		OutputItemID:             scaled.OutputItemID().Int64(),
+		MultiplierNumerator:      scaled.Multiplier().Numerator(),
+		MultiplierDenominator:    scaled.Multiplier().Denominator(),
+		OutputQuantityAtomic:     scaled.OutputQuantity().Int64(),
+		OutputExactNumerator:     scaled.OutputExact().Numerator(),
+		OutputExactDenominator:   scaled.OutputExact().Denominator(),
+		OutputResidueNumerator:   scaled.OutputResidue().Numerator(),
+		OutputResidueDenominator: scaled.OutputResidue().Denominator(),
+		Inputs:                   make([]dto.ScaledProductionInputResponse, 0, len(inputs)),
+	}
+	for _, line := range inputs {
+		response.Inputs = append(response.Inputs, dto.ScaledProductionInputResponse{
+			Input: dto.ProductionComponentRequest{
+				ItemID:                    line.Input.ItemID.Int64(),
+				QuantityAtomic:            line.Input.Quantity.Int64(),
+				EnteredUnitCode:           line.Input.EnteredUnit.String(),
+				EnteredPackagingName:      optionalText(line.Input.EnteredPackagingName),
+				ConversionNumeratorAtomic: line.Input.Conversion.NumeratorAtomic(),
+				ConversionDenominator:     line.Input.Conversion.Denominator(),
+			},
+			ExactNumerator:     line.Exact.Numerator(),
+			ExactDenominator:   line.Exact.Denominator(),
+			ResidueNumerator:   line.Residue.Numerator(),
+			ResidueDenominator: line.Residue.Denominator(),
+		})
+	}
+	return response
+}
+
+func mapProductionPage(page application.ProductionPage) dto.ProductionPageResponse {
+	items := page.Items()
+	response := dto.ProductionPageResponse{
+		Items: make([]dto.ProductionDocumentResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, mapProductionDocument(item))
+	}
+	if cursor, ok := page.Next().Get(); ok {
+		response.Next = &dto.ProductionCursorResponse{
+			PostingSequence: cursor.PostingSequence.Int64(),
+			ID:              cursor.ID.Int64(),
+		}
+	}
+	return response
+}
+
 func mapProductionDocument(document application.ProductionDocument) dto.ProductionDocumentResponse {
 	inputLines := document.InputLines()
 	response := dto.ProductionDocumentResponse{
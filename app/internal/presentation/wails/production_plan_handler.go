@@ -0,0 +1,92 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type ProductionPlanHandler struct {
+	service *application.ProductionPlanService
+}
+
+func NewProductionPlanHandler(service *application.ProductionPlanService) *ProductionPlanHandler {
+	if service == nil {
+		panic("production plan handler requires a service")
+	}
+	return &ProductionPlanHandler{service: service}
+}
+
+func (h *ProductionPlanHandler) PlanProduction(req dto.ProductionPlanRequest) (dto.ProductionPlanResponse, error) {
+	input, err := parseProductionPlanRequest(req)
+	if err != nil {
+		return dto.ProductionPlanResponse{}, err
+	}
+	plan, err := h.service.Plan(handlerContext(), input)
+	if err != nil {
+		return dto.ProductionPlanResponse{}, fmt.Errorf("plan production: %w", err)
+	}
+	return mapProductionPlan(plan), nil
+}
+
+func parseProductionPlanRequest(req dto.ProductionPlanRequest) (application.ProductionPlanInput, error) {
+	itemID, err := domain.NewItemID(req.ItemID)
+	if err != nil {
+		return application.ProductionPlanInput{}, fmt.Errorf("item id: %w", err)
+	}
+	quantity, err := domain.NewPositiveAtomicQuantity(req.QuantityAtomic)
+	if err != nil {
+		return application.ProductionPlanInput{}, fmt.Errorf("quantity: %w", err)
+	}
+	recipes := make([]domain.RecipeID, 0, len(req.RecipeIDs))
+	for index, id := range req.RecipeIDs {
+		recipeID, err := domain.NewRecipeID(id)
+		if err != nil {
+			return application.ProductionPlanInput{}, fmt.Errorf("recipe %d id: %w", index+1, err)
+		}
+		recipes = append(recipes, recipeID)
+	}
+	return application.ProductionPlanInput{ItemID: itemID, Quantity: quantity, Recipes: recipes}, nil
+}
+
+func mapProductionPlan(plan application.ProductionPlan) dto.ProductionPlanResponse {
+	runs := plan.Runs()
+	purchases := plan.Purchases()
+	response := dto.ProductionPlanResponse{
+		ItemID:         plan.ItemID().Int64(),
+		QuantityAtomic: plan.Quantity().Int64(),
+		Runs:           make([]dto.PlannedProductionRunResponse, 0, len(runs)),
+		Purchases:      make([]dto.PlannedPurchaseResponse, 0, len(purchases)),
+	}
+	for _, run := range runs {
+		inputs := run.Inputs()
+		mapped := dto.PlannedProductionRunResponse{
+			RecipeID:                run.RecipeID().Int64(),
+			RecipeRevisionID:        run.RevisionID().Int64(),
+			OutputItemID:            run.OutputItemID().Int64(),
+			Level:                   run.Level(),
+			RequiredQuantityAtomic:  run.Required().Int64(),
+			FromStockQuantityAtomic: run.FromStock().Int64(),
+			QuantityAtomic:          run.Quantity().Int64(),
+			Inputs:                  make([]dto.PlannedRunInputResponse, 0, len(inputs)),
+		}
+		for _, input := range inputs {
+			mapped.Inputs = append(mapped.Inputs, dto.PlannedRunInputResponse{
+				ItemID:         input.ItemID.Int64(),
+				QuantityAtomic: input.Quantity.Int64(),
+			})
+		}
+		response.Runs = append(response.Runs, mapped)
+	}
+	for _, purchase := range purchases {
+		response.Purchases = append(response.Purchases, dto.PlannedPurchaseResponse{
+			ItemID:                  purchase.ItemID().Int64(),
+			RequiredQuantityAtomic:  purchase.Required().Int64(),
+			FromStockQuantityAtomic: purchase.FromStock().Int64(),
+			QuantityAtomic:          purchase.Quantity().Int64(),
+		})
+	}
+	return response
+}
@@ -0,0 +1,354 @@
+package wails
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/purchasing"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type PurchaseOrderHandler struct {
+	service *application.PurchaseOrderService
+}
+
+func NewPurchaseOrderHandler(service *application.PurchaseOrderService) *PurchaseOrderHandler {
+	if service == nil {
+		panic("purchase order handler requires a service")
+	}
+	return &PurchaseOrderHandler{service: service}
+}
+
+func (h *PurchaseOrderHandler) GetPurchaseOrder(id int64) (dto.PurchaseOrderResponse, error) {
+	orderID, err := domain.NewPurchaseOrderID(id)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, fmt.Errorf("purchase order id: %w", err)
+	}
+	order, err := h.service.GetPurchaseOrder(handlerContext(), orderID)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, fmt.Errorf("get purchase order: %w", err)
+	}
+	return mapPurchaseOrder(order), nil
+}
+
+func (h *PurchaseOrderHandler) ListPurchaseOrders(req dto.PurchaseOrderListRequest) (dto.PurchaseOrderPageResponse, error) {
+	input, err := parsePurchaseOrderListRequest(req)
+	if err != nil {
+		return dto.PurchaseOrderPageResponse{}, err
+	}
+	page, err := h.service.ListPurchaseOrders(handlerContext(), input)
+	if err != nil {
+		return dto.PurchaseOrderPageResponse{}, fmt.Errorf("list purchase orders: %w", err)
+	}
+	items := page.Items()
+	response := dto.PurchaseOrderPageResponse{Items: make([]dto.PurchaseOrderResponse, 0, len(items))}
+	for _, item := range items {
+		response.Items = append(response.Items, mapPurchaseOrder(item))
+	}
+	if next, ok := page.Next().Get(); ok {
+		raw := next.Int64()
+		response.Next = &raw
+	}
+	return response, nil
+}
+
+func (h *PurchaseOrderHandler) ListPurchaseOrderReceipts(id int64) ([]dto.PurchaseDocumentResponse, error) {
+	orderID, err := domain.NewPurchaseOrderID(id)
+	if err != nil {
+		return nil, fmt.Errorf("purchase order id: %w", err)
+	}
+	receipts, err := h.service.ListPurchaseOrderReceipts(handlerContext(), orderID)
+	if err != nil {
+		return nil, fmt.Errorf("list purchase order receipts: %w", err)
+	}
+	response := make([]dto.PurchaseDocumentResponse, 0, len(receipts))
+	for _, receipt := range receipts {
+		response = append(response, mapPurchaseDocument(receipt))
+	}
+	return response, nil
+}
+
+func (h *PurchaseOrderHandler) CreatePurchaseOrder(req dto.PurchaseOrderWriteRequest) (dto.PurchaseOrderResponse, error) {
+	input, err := parsePurchaseOrderWriteRequest(req)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, err
+	}
+	order, err := h.service.CreatePurchaseOrder(handlerContext(), input)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, fmt.Errorf("create purchase order: %w", err)
+	}
+	return mapPurchaseOrder(order), nil
+}
+
+func (h *PurchaseOrderHandler) UpdatePurchaseOrder(id int64, req dto.PurchaseOrderUpdateRequest) (dto.PurchaseOrderResponse, error) {
+	orderID, expectedUpdatedAt, err := parseVersionedPurchaseOrder(id, req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, err
+	}
+	input, err := parsePurchaseOrderWriteRequest(req.PurchaseOrderWriteRequest)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, err
+	}
+	order, err := h.service.UpdatePurchaseOrder(handlerContext(), application.PurchaseOrderUpdateInput{
+		ID: orderID, SupplierID: input.SupplierID, OrderedOn: input.OrderedOn,
+		ExpectedOn: input.ExpectedOn, Notes: input.Notes, Lines: input.Lines,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, fmt.Errorf("update purchase order: %w", err)
+	}
+	return mapPurchaseOrder(order), nil
+}
+
+func (h *PurchaseOrderHandler) SendPurchaseOrder(id int64, req dto.VersionedPurchaseOrderRequest) (dto.PurchaseOrderResponse, error) {
+	return h.transition(id, req, "send", h.service.SendPurchaseOrder)
+}
+
+func (h *PurchaseOrderHandler) CancelPurchaseOrder(id int64, req dto.VersionedPurchaseOrderRequest) (dto.PurchaseOrderResponse, error) {
+	return h.transition(id, req, "cancel", h.service.CancelPurchaseOrder)
+}
+
+func (h *PurchaseOrderHandler) ClosePurchaseOrder(id int64, req dto.VersionedPurchaseOrderRequest) (dto.PurchaseOrderResponse, error) {
+	return h.transition(id, req, "close", h.service.ClosePurchaseOrder)
+}
+
+func (h *PurchaseOrderHandler) ReceivePurchaseOrder(id int64, req dto.PurchaseOrderReceiveRequest) (dto.PurchaseOrderReceiptResponse, error) {
+	input, err := parsePurchaseOrderReceiveRequest(id, req)
+	if err != nil {
+		return dto.PurchaseOrderReceiptResponse{}, err
+	}
+	receipt, err := h.service.ReceivePurchaseOrder(handlerContext(), input)
+	if err != nil {
+		return dto.PurchaseOrderReceiptResponse{}, fmt.Errorf("receive purchase order: %w", err)
+	}
+	return dto.PurchaseOrderReceiptResponse{
+		Order:    mapPurchaseOrder(receipt.Order()),
+		Purchase: mapPurchaseDocument(receipt.Purchase()),
+	}, nil
+}
+
+func (h *PurchaseOrderHandler) transition(
+	id int64,
+	req dto.VersionedPurchaseOrderRequest,
+	verb string,
+	change func(ctx context.Context, input application.PurchaseOrderTransitionInput) (purchasing.Order, error),
+) (dto.PurchaseOrderResponse, error) {
+	orderID, expectedUpdatedAt, err := parseVersionedPurchaseOrder(id, req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, err
+	}
+	order, err := change(handlerContext(), application.PurchaseOrderTransitionInput{
+		ID: orderID, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.PurchaseOrderResponse{}, fmt.Errorf("%s purchase order: %w", verb, err)
+	}
+	return mapPurchaseOrder(order), nil
+}
+
+func parseVersionedPurchaseOrder(id int64, expectedUpdatedAtMs int64) (domain.PurchaseOrderID, domain.UTCInstant, error) {
+	orderID, err := domain.NewPurchaseOrderID(id)
+	if err != nil {
+		return domain.PurchaseOrderID{}, domain.UTCInstant{}, fmt.Errorf("purchase order id: %w", err)
+	}
+	expectedUpdatedAt, err := domain.UTCInstantFromUnixMilli(expectedUpdatedAtMs)
+	if err != nil {
+		return domain.PurchaseOrderID{}, domain.UTCInstant{}, fmt.Errorf("expected updated at: %w", err)
+	}
+	return orderID, expectedUpdatedAt, nil
+}
+
+func parsePurchaseOrderListRequest(req dto.PurchaseOrderListRequest) (application.PurchaseOrderListInput, error) {
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 50
+	}
+	status := domain.None[domain.PurchaseOrderStatus]()
+	if req.Status != nil {
+		parsed, err := domain.ParsePurchaseOrderStatus(*req.Status)
+		if err != nil {
+			return application.PurchaseOrderListInput{}, fmt.Errorf("status: %w", err)
+		}
+		status = domain.Some(parsed)
+	}
+	after := domain.None[domain.PurchaseOrderID]()
+	if req.After != nil {
+		parsed, err := domain.NewPurchaseOrderID(*req.After)
+		if err != nil {
+			return application.PurchaseOrderListInput{}, fmt.Errorf("cursor id: %w", err)
+		}
+		after = domain.Some(parsed)
+	}
+	return application.PurchaseOrderListInput{Status: status, After: after, PageSize: pageSize}, nil
+}
+
+func parsePurchaseOrderWriteRequest(req dto.PurchaseOrderWriteRequest) (application.PurchaseOrderCreateInput, error) {
+	supplierID, err := domain.NewCounterpartyID(req.SupplierID)
+	if err != nil {
+		return application.PurchaseOrderCreateInput{}, fmt.Errorf("supplier id: %w", err)
+	}
+	orderedOn, err := domain.ParseBusinessDate(req.OrderedOn)
+	if err != nil {
+		return application.PurchaseOrderCreateInput{}, fmt.Errorf("ordered on: %w", err)
+	}
+	expectedOn, err := optionalBusinessDateFromString(req.ExpectedOn)
+	if err != nil {
+		return application.PurchaseOrderCreateInput{}, fmt.Errorf("expected on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return application.PurchaseOrderCreateInput{}, fmt.Errorf("notes: %w", err)
+	}
+	lines := make([]application.PurchaseOrderLineInput, 0, len(req.Lines))
+	for index, line := range req.Lines {
+		parsed, err := parsePurchaseOrderLineRequest(line)
+		if err != nil {
+			return application.PurchaseOrderCreateInput{}, fmt.Errorf("line %d: %w", index+1, err)
+		}
+		lines = append(lines, parsed)
+	}
+	return application.PurchaseOrderCreateInput{
+		SupplierID: supplierID,
+		OrderedOn:  orderedOn,
+		ExpectedOn: expectedOn,
+		Notes:      notes,
+		Lines:      lines,
+	}, nil
+}
+
+func parsePurchaseOrderLineRequest(req dto.PurchaseOrderLineRequest) (application.PurchaseOrderLineInput, error) {
+	itemID, err := domain.NewItemID(req.ItemID)
+	if err != nil {
+		return application.PurchaseOrderLineInput{}, fmt.Errorf("item id: %w", err)
+	}
+	quantity, err := domain.NewPositiveAtomicQuantity(req.QuantityAtomic)
+	if err != nil {
+		return application.PurchaseOrderLineInput{}, fmt.Errorf("quantity: %w", err)
+	}
+	enteredUnit, err := domain.NewUnitCode(req.EnteredUnitCode)
+	if err != nil {
+		return application.PurchaseOrderLineInput{}, fmt.Errorf("entered unit: %w", err)
+	}
+	enteredPackagingName, err := optionalNonEmptyText(req.EnteredPackagingName)
+	if err != nil {
+		return application.PurchaseOrderLineInput{}, fmt.Errorf("entered packaging name: %w", err)
+	}
+	conversion, err := domain.NewUnitConversion(req.ConversionNumeratorAtomic, req.ConversionDenominator)
+	if err != nil {
+		return application.PurchaseOrderLineInput{}, fmt.Errorf("conversion: %w", err)
+	}
+	expectedTotal, err := domain.NewMinorAmount(req.ExpectedTotalMinor)
+	if err != nil {
+		return application.PurchaseOrderLineInput{}, fmt.Errorf("expected total: %w", err)
+	}
+	return application.PurchaseOrderLineInput{
+		ItemID:               itemID,
+		Quantity:             quantity,
+		EnteredUnit:          enteredUnit,
+		EnteredPackagingName: enteredPackagingName,
+		Conversion:           conversion,
+		ExpectedTotal:        expectedTotal,
+	}, nil
+}
+
+func parsePurchaseOrderReceiveRequest(id int64, req dto.PurchaseOrderReceiveRequest) (application.PurchaseOrderReceiveInput, error) {
+	orderID, expectedUpdatedAt, err := parseVersionedPurchaseOrder(id, req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return application.PurchaseOrderReceiveInput{}, err
+	}
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return application.PurchaseOrderReceiveInput{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	occurredOn, err := domain.ParseBusinessDate(req.OccurredOn)
+	if err != nil {
+		return application.PurchaseOrderReceiveInput{}, fmt.Errorf("occurred on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return application.PurchaseOrderReceiveInput{}, fmt.Errorf("notes: %w", err)
+	}
+	lines := make([]application.PurchaseOrderReceiptLineInput, 0, len(req.Lines))
+	for index, line := range req.Lines {
+		parsed, err := parsePurchaseOrderReceiptLineRequest(line)
+		if err != nil {
+			return application.PurchaseOrderReceiveInput{}, fmt.Errorf("line %d: %w", index+1, err)
+		}
+		lines = append(lines, parsed)
+	}
+	return application.PurchaseOrderReceiveInput{
+		ID:                orderID,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+		IdempotencyKey:    idempotencyKey,
+		OccurredOn:        occurredOn,
+		Notes:             notes,
+		Lines:             lines,
+	}, nil
+}
+
+func parsePurchaseOrderReceiptLineRequest(req dto.PurchaseOrderReceiptLineRequest) (application.PurchaseOrderReceiptLineInput, error) {
+	lineID, err := domain.NewPurchaseOrderLineID(req.LineID)
+	if err != nil {
+		return application.PurchaseOrderReceiptLineInput{}, fmt.Errorf("line id: %w", err)
+	}
+	quantity, err := domain.NewPositiveAtomicQuantity(req.QuantityAtomic)
+	if err != nil {
+		return application.PurchaseOrderReceiptLineInput{}, fmt.Errorf("quantity: %w", err)
+	}
+	commercialTotal := domain.None[domain.MinorAmount]()
+	if req.CommercialTotalMinor != nil {
+		parsed, err := domain.NewMinorAmount(*req.CommercialTotalMinor)
+		if err != nil {
+			return application.PurchaseOrderReceiptLineInput{}, fmt.Errorf("commercial total: %w", err)
+		}
+		commercialTotal = domain.Some(parsed)
+	}
+	lotCode, err := optionalNonEmptyText(req.LotCode)
+	if err != nil {
+		return application.PurchaseOrderReceiptLineInput{}, fmt.Errorf("lot code: %w", err)
+	}
+	expiresOn, err := optionalBusinessDateFromString(req.ExpiresOn)
+	if err != nil {
+		return application.PurchaseOrderReceiptLineInput{}, fmt.Errorf("expires on: %w", err)
+	}
+	return application.PurchaseOrderReceiptLineInput{
+		LineID:          lineID,
+		Quantity:        quantity,
+		CommercialTotal: commercialTotal,
+		LotCode:         lotCode,
+		ExpiresOn:       expiresOn,
+	}, nil
+}
+
+func mapPurchaseOrder(order purchasing.Order) dto.PurchaseOrderResponse {
+	lines := order.Lines()
+	response := dto.PurchaseOrderResponse{
+		ID:          order.ID().Int64(),
+		SupplierID:  order.SupplierID().Int64(),
+		Status:      order.Status().String(),
+		OrderedOn:   order.OrderedOn().String(),
+		ExpectedOn:  optionalBusinessDateValue(order.ExpectedOn()),
+		Notes:       optionalText(order.Notes()),
+		CreatedAtMs: order.CreatedAt().UnixMilli(),
+		UpdatedAtMs: order.UpdatedAt().UnixMilli(),
+		Lines:       make([]dto.PurchaseOrderLineResponse, 0, len(lines)),
+	}
+	for _, line := range lines {
+		response.Lines = append(response.Lines, dto.PurchaseOrderLineResponse{
+			ID:                        line.ID().Int64(),
+			LineOrder:                 line.LineOrder().Int64(),
+			ItemID:                    line.ItemID().Int64(),
+			QuantityAtomic:            line.Quantity().Int64(),
+			EnteredUnitCode:           line.EnteredUnit().String(),
+			EnteredPackagingName:      optionalText(line.EnteredPackagingName()),
+			ConversionNumeratorAtomic: line.Conversion().NumeratorAtomic(),
+			ConversionDenominator:     line.Conversion().Denominator(),
+			ExpectedTotalMinor:        line.ExpectedTotal().Int64(),
+			ReceivedQuantityAtomic:    line.ReceivedQuantity().Int64(),
+			OutstandingQuantityAtomic: line.OutstandingQuantity().Int64(),
+		})
+	}
+	return response
+}
@@ -44,6 +44,18 @@ func (h *RecipeHandler) GetRecipeRevision(id int64) (dto.RecipeRevisionResponse,
 	return mapRecipeRevision(value), nil
 }
 
+func (h *RecipeHandler) CostRecipeRevision(id int64) (dto.RecipeRevisionCostResponse, error) {
+	revisionID, err := domain.NewRecipeRevisionID(id)
+	if err != nil {
+		return dto.RecipeRevisionCostResponse{}, fmt.Errorf("recipe revision id: %w", err)
+	}
+	value, err := h.service.CostRevision(handlerContext(), revisionID)
+	if err != nil {
+		return dto.RecipeRevisionCostResponse{}, fmt.Errorf("cost recipe revision: %w", err)
+	}
+	return mapRecipeRevisionCost(value), nil
+}
+
 func (h *RecipeHandler) ListRecipeRevisions(recipeIDValue int64) ([]dto.RecipeRevisionResponse, error) {
 	recipeID, err := domain.NewRecipeID(recipeIDValue)
 	if err != nil {
@@ -378,6 +390,45 @@ func mapRecipeComponent(component recipedomain.Component) dto.RecipeComponentRes
 	}
 }
 
+func mapRecipeRevisionCost(value application.RecipeRevisionCost) dto.RecipeRevisionCostResponse {
+	revision := value.Revision()
+	components := value.Components()
+	response := dto.RecipeRevisionCostResponse{
+		RevisionID:                revision.ID().Int64(),
+		RecipeID:                  revision.RecipeID().Int64(),
+		OutputItemID:              value.OutputItemID().Int64(),
+		StandardYieldQuantity:     revision.StandardYield().Int64(),
+		YieldBaseUnitCode:         value.YieldBaseUnit().String(),
+		TotalValueMicro:           value.TotalValue().Int64(),
+		CostPerYieldBaseUnitMicro: value.CostPerYieldBaseUnit().Int64(),
+		EstimatedDirectCostMicro:  optionalInventoryValueMicro(revision.EstimatedDirectCost()),
+		Complete:                  value.Complete(),
+		Components:                make([]dto.RecipeComponentCostResponse, 0, len(components)),
+	}
+	for _, cost := range components {
+		component := cost.Component()
+		line := dto.RecipeComponentCostResponse{
+			ComponentID:                component.ID().Int64(),
+			Order:                      component.Order().Int64(),
+			ItemID:                     component.ItemID().Int64(),
+			QuantityAtomic:             component.Quantity().Int64(),
+			EnteredUnitCode:            component.EnteredUnit().String(),
+			EnteredPackagingName:       optionalText(component.EnteredPackagingName()),
+			EnteredQuantityNumerator:   cost.EnteredQuantity().Numerator(),
+			EnteredQuantityDenominator: cost.EnteredQuantity().Denominator(),
+			CostSource:                 string(cost.Source()),
+			ValueMicro:                 optionalInventoryValueMicro(cost.Value()),
+		}
+		if unitValue, ok := cost.UnitValue().Get(); ok {
+			numerator, denominator := unitValue.Numerator(), unitValue.Denominator()
+			line.UnitValueNumeratorMicro = &numerator
+			line.UnitValueDenominatorAtomic = &denominator
+		}
+		response.Components = append(response.Components, line)
+	}
+	return response
+}
+
 func optionalInventoryValueMicro(value domain.Option[domain.InventoryValue]) *int64 {
 	amount, ok := value.Get()
 	if !ok {
@@ -0,0 +1,56 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	inventorydomain "github.com/jerobas/saas/internal/domain/inventory"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type ReconciliationHandler struct {
+	service *application.ReconciliationService
+}
+
+func NewReconciliationHandler(service *application.ReconciliationService) *ReconciliationHandler {
+	if service == nil {
+		panic("reconciliation handler requires a service")
+	}
+	return &ReconciliationHandler{service: service}
+}
+
+func (h *ReconciliationHandler) ReconcileInventory() (dto.ReconciliationResponse, error) {
+	reconciliation, err := h.service.ReconcileInventory(handlerContext())
+	if err != nil {
+		return dto.ReconciliationResponse{}, fmt.Errorf("reconcile inventory: %w", err)
+	}
+	return mapReconciliation(reconciliation), nil
+}
+
+func mapReconciliation(reconciliation inventorydomain.Reconciliation) dto.ReconciliationResponse {
+	discrepancies := reconciliation.Discrepancies()
+	response := dto.ReconciliationResponse{
+		Consistent:    reconciliation.Consistent(),
+		DocumentCount: reconciliation.DocumentCount(),
+		LineCount:     reconciliation.LineCount(),
+		LotCount:      reconciliation.LotCount(),
+		ItemCount:     reconciliation.ItemCount(),
+		Discrepancies: make([]dto.DiscrepancyResponse, 0, len(discrepancies)),
+	}
+	if sequence, ok := reconciliation.LastPostingSequence().Get(); ok {
+		raw := sequence.Int64()
+		response.LastPostingSequence = &raw
+	}
+	for _, discrepancy := range discrepancies {
+		response.Discrepancies = append(response.Discrepancies, dto.DiscrepancyResponse{
+			Kind:        discrepancy.Kind().String(),
+			InvariantID: discrepancy.Kind().InvariantID(),
+			ItemID:      discrepancy.ItemID().Int64(),
+			LotID:       optionalInventoryLotID(discrepancy.LotID()),
+			LineID:      invOptionalStockDocumentLineID(discrepancy.LineID()),
+			Expected:    discrepancy.Expected(),
+			Stored:      discrepancy.Stored(),
+		})
+	}
+	return response
+}
@@ -0,0 +1,227 @@
+package wails
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/sales"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type RegisterHandler struct {
+	service *application.RegisterService
+}
+
+func NewRegisterHandler(service *application.RegisterService) *RegisterHandler {
+	if service == nil {
+		panic("register handler requires a service")
+	}
+	return &RegisterHandler{service: service}
+}
+
+func (h *RegisterHandler) OpenRegisterSession(req dto.RegisterOpenRequest) (dto.RegisterSessionResponse, error) {
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	openingFloat, err := domain.NewMinorAmount(req.OpeningFloatMinor)
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("opening float: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("notes: %w", err)
+	}
+	session, err := h.service.OpenRegisterSession(handlerContext(), application.RegisterOpenInput{
+		IdempotencyKey: idempotencyKey,
+		OpeningFloat:   openingFloat,
+		Notes:          notes,
+	})
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("open register session: %w", err)
+	}
+	return mapRegisterSession(session), nil
+}
+
+func (h *RegisterHandler) GetOpenRegisterSession() (dto.RegisterSessionResponse, error) {
+	session, err := h.service.GetOpenRegisterSession(handlerContext())
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("get open register session: %w", err)
+	}
+	return mapRegisterSession(session), nil
+}
+
+func (h *RegisterHandler) GetRegisterSession(id int64) (dto.RegisterSessionResponse, error) {
+	sessionID, err := domain.NewRegisterSessionID(id)
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("register session id: %w", err)
+	}
+	session, err := h.service.GetRegisterSession(handlerContext(), sessionID)
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("get register session: %w", err)
+	}
+	return mapRegisterSession(session), nil
+}
+
+func (h *RegisterHandler) RecordCashMovement(req dto.CashMovementRecordRequest) (dto.CashMovementResponse, error) {
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.CashMovementResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	sessionID, err := domain.NewRegisterSessionID(req.RegisterSessionID)
+	if err != nil {
+		return dto.CashMovementResponse{}, fmt.Errorf("register session id: %w", err)
+	}
+	kind, err := domain.ParseCashMovementKind(req.Kind)
+	if err != nil {
+		return dto.CashMovementResponse{}, fmt.Errorf("kind: %w", err)
+	}
+	amount, err := domain.NewMinorAmount(req.AmountMinor)
+	if err != nil {
+		return dto.CashMovementResponse{}, fmt.Errorf("amount: %w", err)
+	}
+	notes, err := domain.NewNonEmptyText(req.Notes)
+	if err != nil {
+		return dto.CashMovementResponse{}, fmt.Errorf("notes: %w", err)
+	}
+	movement, err := h.service.RecordCashMovement(handlerContext(), application.CashMovementRecordInput{
+		IdempotencyKey:    idempotencyKey,
+		RegisterSessionID: sessionID,
+		Kind:              kind,
+		Amount:            amount,
+		Notes:             notes,
+	})
+	if err != nil {
+		return dto.CashMovementResponse{}, fmt.Errorf("record cash movement: %w", err)
+	}
+	return mapCashMovement(movement), nil
+}
+
+func (h *RegisterHandler) CloseRegisterSession(
+	id int64,
+	req dto.RegisterCloseRequest,
+) (dto.RegisterSessionResponse, error) {
+	sessionID, err := domain.NewRegisterSessionID(id)
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("register session id: %w", err)
+	}
+	countedCash, err := domain.NewMinorAmount(req.CountedCashMinor)
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("counted cash: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("notes: %w", err)
+	}
+	session, err := h.service.CloseRegisterSession(handlerContext(), application.RegisterCloseInput{
+		RegisterSessionID: sessionID,
+		CountedCash:       countedCash,
+		Notes:             notes,
+	})
+	if err != nil {
+		return dto.RegisterSessionResponse{}, fmt.Errorf("close register session: %w", err)
+	}
+	return mapRegisterSession(session), nil
+}
+
+func (h *RegisterHandler) ListClosedRegisterSessions(
+	fromBusinessDate string,
+	toBusinessDate string,
+) ([]dto.RegisterSessionResponse, error) {
+	from, err := domain.ParseBusinessDate(fromBusinessDate)
+	if err != nil {
+		return nil, fmt.Errorf("from business date: %w", err)
+	}
+	to, err := domain.ParseBusinessDate(toBusinessDate)
+	if err != nil {
+		return nil, fmt.Errorf("to business date: %w", err)
+	}
+	sessions, err := h.service.ListClosedRegisterSessions(handlerContext(), from, to)
+	if err != nil {
+		return nil, fmt.Errorf("list closed register sessions: %w", err)
+	}
+	response := make([]dto.RegisterSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		response = append(response, mapRegisterSession(session))
+	}
+	return response, nil
+}
+
+// PrintRegisterClosing returns the Z report of a closed session as plain text
+// for the frontend to print.
+func (h *RegisterHandler) PrintRegisterClosing(id int64) (string, error) {
+	sessionID, err := domain.NewRegisterSessionID(id)
+	if err != nil {
+		return "", fmt.Errorf("register session id: %w", err)
+	}
+	report, err := h.service.GetRegisterClosingReport(handlerContext(), sessionID)
+	if err != nil {
+		return "", fmt.Errorf("print register closing: %w", err)
+	}
+	var text strings.Builder
+	if err := report.WriteText(&text); err != nil {
+		return "", fmt.Errorf("print register closing: %w", err)
+	}
+	return text.String(), nil
+}
+
+func mapRegisterSession(session sales.RegisterSession) dto.RegisterSessionResponse {
+	payments := session.Payments()
+	movements := session.Movements()
+	response := dto.RegisterSessionResponse{
+		ID:                session.ID().Int64(),
+		IdempotencyKey:    session.IdempotencyKey().String(),
+		BusinessDate:      session.BusinessDate().String(),
+		OpeningFloatMinor: session.OpeningFloat().Int64(),
+		Notes:             optionalText(session.Notes()),
+		OpenedAtMs:        session.OpenedAt().UnixMilli(),
+		Open:              session.IsOpen(),
+		Totals:            mapRegisterTotals(session.Totals()),
+		Payments:          make([]dto.SalePaymentResponse, 0, len(payments)),
+		Movements:         make([]dto.CashMovementResponse, 0, len(movements)),
+	}
+	for _, payment := range payments {
+		response.Payments = append(response.Payments, mapSalePayment(payment))
+	}
+	for _, movement := range movements {
+		response.Movements = append(response.Movements, mapCashMovement(movement))
+	}
+	if closing, ok := session.Closing().Get(); ok {
+		response.Closing = &dto.RegisterClosingResponse{
+			ClosedOn:         closing.ClosedOn().String(),
+			Totals:           mapRegisterTotals(closing.Totals()),
+			CountedCashMinor: closing.CountedCash().Int64(),
+			DifferenceMinor:  closing.Difference(),
+			Notes:            optionalText(closing.Notes()),
+			ClosedAtMs:       closing.ClosedAt().UnixMilli(),
+		}
+	}
+	return response
+}
+
+func mapRegisterTotals(totals sales.RegisterTotals) dto.RegisterTotalsResponse {
+	return dto.RegisterTotalsResponse{
+		OpeningFloatMinor: totals.OpeningFloatMinor,
+		CashMinor:         totals.CashMinor,
+		PixMinor:          totals.PixMinor,
+		CardMinor:         totals.CardMinor,
+		OnAccountMinor:    totals.OnAccountMinor,
+		CashInMinor:       totals.CashInMinor,
+		CashOutMinor:      totals.CashOutMinor,
+		ExpectedCashMinor: totals.ExpectedCashMinor,
+	}
+}
+
+func mapCashMovement(movement sales.CashMovement) dto.CashMovementResponse {
+	return dto.CashMovementResponse{
+		ID:                movement.ID().Int64(),
+		IdempotencyKey:    movement.IdempotencyKey().String(),
+		RegisterSessionID: movement.RegisterSessionID().Int64(),
+		Kind:              movement.Kind().String(),
+		AmountMinor:       movement.Amount().Int64(),
+		Notes:             movement.Notes().String(),
+		RecordedAtMs:      movement.RecordedAt().UnixMilli(),
+	}
+}
@@ -31,12 +31,18 @@ func (h *ReportingHandler) GetSalesReport(req dto.ReportingPeriodRequest) (dto.S
 	return mapSalesReport(report), nil
 }
 
-func (h *ReportingHandler) GetInventoryReport(req dto.ReportingPeriodRequest) (dto.InventoryReportResponse, error) {
-	input, err := parseReportingPeriodRequest(req)
+// GetInventoryReport covers every location, or only the lots at the request's
+// location when one is given.
+func (h *ReportingHandler) GetInventoryReport(req dto.InventoryReportRequest) (dto.InventoryReportResponse, error) {
+	input, err := parseReportingPeriodRequest(req.ReportingPeriodRequest)
 	if err != nil {
 		return dto.InventoryReportResponse{}, err
 	}
-	report, err := h.service.GetInventoryReport(handlerContext(), input)
+	location, err := optionalStockLocationIDInput(req.LocationID)
+	if err != nil {
+		return dto.InventoryReportResponse{}, fmt.Errorf("location id: %w", err)
+	}
+	report, err := h.service.GetInventoryReport(handlerContext(), input, location)
 	if err != nil {
 		return dto.InventoryReportResponse{}, fmt.Errorf("get inventory report: %w", err)
 	}
@@ -91,6 +97,18 @@ func (h *ReportingHandler) GetCategoryMixReport(req dto.ReportingPeriodRequest)
 	return mapCategoryMixReport(report), nil
 }
 
+func (h *ReportingHandler) GetProfitAndLossReport(req dto.ReportingPeriodRequest) (dto.ProfitAndLossReportResponse, error) {
+	input, err := parseReportingPeriodRequest(req)
+	if err != nil {
+		return dto.ProfitAndLossReportResponse{}, err
+	}
+	report, err := h.service.GetProfitAndLossReport(handlerContext(), input)
+	if err != nil {
+		return dto.ProfitAndLossReportResponse{}, fmt.Errorf("get profit and loss report: %w", err)
+	}
+	return mapProfitAndLossReport(report), nil
+}
+
 func parseReportingPeriodRequest(req dto.ReportingPeriodRequest) (application.ReportingPeriodInput, error) {
 	from, err := domain.ParseBusinessDate(req.FromOccurredOn)
 	if err != nil {
@@ -125,6 +143,8 @@ func mapSalesReport(report application.SalesReport) dto.SalesReportResponse {
 		CurrencyCode:                   report.Currency.Code().String(),
 		CurrencyMinorDigits:            int64(report.Currency.MinorDigits().Int()),
 		TotalSalesCount:                report.TotalSalesCount,
+		ReturnCount:                    report.ReturnCount,
+		RefundMinor:                    report.RefundMinor,
 		CommercialTotalMinor:           report.CommercialTotalMinor,
 		COGSInventoryValueMicro:        report.COGSInventoryValueMicro,
 		GrossMarginInventoryValueMicro: report.GrossMarginInventoryValueMicro,
@@ -147,6 +167,7 @@ func mapInventoryReport(report application.InventoryReport) dto.InventoryReportR
 		Period:                   mapReportingPeriod(report.Period),
 		CurrencyCode:             report.Currency.Code().String(),
 		CurrencyMinorDigits:      int64(report.Currency.MinorDigits().Int()),
+		LocationID:               optionalStockLocationIDValue(report.Location),
 		TotalInventoryValueMicro: report.TotalInventoryValueMicro,
 		LowStockItemCount:        report.LowStockItemCount,
 		ZeroStockSellableCount:   report.ZeroStockSellableCount,
@@ -166,6 +187,12 @@ func mapPurchaseReport(report application.PurchaseReport) dto.PurchaseReportResp
 		PurchaseSpendSeries: mapReportingSeries(report.PurchaseSpendSeries),
 		TopSuppliersBySpend: mapReportingCounterpartyMetrics(report.TopSuppliersBySpend),
 		FreeStockEntries:    mapReportingSeries(report.FreeStockEntries),
+		Payables: dto.PurchasePayableTotalsResponse{
+			PayableCount:     report.Payables.PayableCount,
+			OwedMinor:        report.Payables.OwedMinor,
+			PaidMinor:        report.Payables.PaidMinor,
+			OutstandingMinor: report.Payables.OutstandingMinor,
+		},
 	}
 }
 
@@ -195,6 +222,7 @@ func mapCategoryMixReport(report application.CategoryMixReport) dto.CategoryMixR
 	rows := make([]dto.CategoryMixRowResponse, 0, len(report.Rows))
 	for _, row := range report.Rows {
 		rows = append(rows, dto.CategoryMixRowResponse{
+			CategoryID:           optionalCategoryID(row.CategoryID),
 			CategoryName:         row.CategoryName,
 			QuantityAtomic:       row.QuantityAtomic,
 			CommercialTotalMinor: row.CommercialTotalMinor,
@@ -202,10 +230,53 @@ func mapCategoryMixReport(report application.CategoryMixReport) dto.CategoryMixR
 		})
 	}
 	return dto.CategoryMixReportResponse{
-		Period:            mapReportingPeriod(report.Period),
-		Available:         report.Available,
-		UnavailableReason: optionalString(report.UnavailableReason),
-		Rows:              rows,
+		Period:               mapReportingPeriod(report.Period),
+		CurrencyCode:         report.Currency.Code().String(),
+		CurrencyMinorDigits:  int64(report.Currency.MinorDigits().Int()),
+		Available:            report.Available,
+		UnavailableReason:    optionalString(report.UnavailableReason),
+		CommercialTotalMinor: report.CommercialTotalMinor,
+		Rows:                 rows,
+	}
+}
+
+func mapProfitAndLossReport(report application.ProfitAndLossReport) dto.ProfitAndLossReportResponse {
+	rows := make([]dto.ProfitAndLossRowResponse, 0, len(report.Rows))
+	for _, row := range report.Rows {
+		rows = append(rows, mapProfitAndLossRow(row))
+	}
+	expenses := make([]dto.ExpenseCategoryMetricResponse, 0, len(report.ExpensesByCategory))
+	for _, item := range report.ExpensesByCategory {
+		expenses = append(expenses, dto.ExpenseCategoryMetricResponse{
+			Category:     item.Category,
+			ExpenseCount: item.ExpenseCount,
+			AmountMinor:  item.AmountMinor,
+		})
+	}
+	return dto.ProfitAndLossReportResponse{
+		Period:              mapReportingPeriod(report.Period),
+		CurrencyCode:        report.Currency.Code().String(),
+		CurrencyMinorDigits: int64(report.Currency.MinorDigits().Int()),
+		Rows:                rows,
+		Totals:              mapProfitAndLossRow(report.Totals),
+		WriteOffsByReason:   mapReportingReasonMetrics(report.WriteOffsByReason),
+		ExpensesByCategory:  expenses,
+	}
+}
+
+func mapProfitAndLossRow(row application.ProfitAndLossRow) dto.ProfitAndLossRowResponse {
+	return dto.ProfitAndLossRowResponse{
+		Bucket:                         row.Bucket,
+		Label:                          row.Label,
+		CommercialTotalMinor:           row.CommercialTotalMinor,
+		COGSInventoryValueMicro:        row.COGSInventoryValueMicro,
+		GrossMarginInventoryValueMicro: row.GrossMarginInventoryValueMicro,
+		DirectCostInventoryValueMicro:  row.DirectCostInventoryValueMicro,
+		WriteOffInventoryValueMicro:    row.WriteOffInventoryValueMicro,
+		ExpenseMinor:                   row.ExpenseMinor,
+		ExpenseInventoryValueMicro:     row.ExpenseInventoryValueMicro,
+		NetProfitInventoryValueMicro:   row.NetProfitInventoryValueMicro,
+		NetMarginBasisPoints:           optionalInt64(row.NetMarginBasisPoints),
 	}
 }
 
@@ -342,6 +413,15 @@ func optionalItemID(value domain.Option[domain.ItemID]) *int64 {
 	return &raw
 }
 
+func optionalCategoryID(value domain.Option[domain.CategoryID]) *int64 {
+	id, ok := value.Get()
+	if !ok {
+		return nil
+	}
+	raw := id.Int64()
+	return &raw
+}
+
 func optionalRecipeID(value domain.Option[domain.RecipeID]) *int64 {
 	id, ok := value.Get()
 	if !ok {
@@ -0,0 +1,155 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type ReturnHandler struct {
+	service *application.ReturnService
+}
+
+func NewReturnHandler(service *application.ReturnService) *ReturnHandler {
+	if service == nil {
+		panic("return handler requires a service")
+	}
+	return &ReturnHandler{service: service}
+}
+
+func (h *ReturnHandler) GetReturn(id int64) (dto.ReturnDocumentResponse, error) {
+	documentID, err := domain.NewStockDocumentID(id)
+	if err != nil {
+		return dto.ReturnDocumentResponse{}, fmt.Errorf("return id: %w", err)
+	}
+	document, err := h.service.GetReturn(handlerContext(), documentID)
+	if err != nil {
+		return dto.ReturnDocumentResponse{}, fmt.Errorf("get return: %w", err)
+	}
+	return mapReturnDocument(document), nil
+}
+
+func (h *ReturnHandler) ListSaleReturns(saleID int64) ([]dto.ReturnDocumentResponse, error) {
+	documentID, err := domain.NewStockDocumentID(saleID)
+	if err != nil {
+		return nil, fmt.Errorf("sale id: %w", err)
+	}
+	documents, err := h.service.ListSaleReturns(handlerContext(), documentID)
+	if err != nil {
+		return nil, fmt.Errorf("list sale returns: %w", err)
+	}
+	response := make([]dto.ReturnDocumentResponse, 0, len(documents))
+	for _, document := range documents {
+		response = append(response, mapReturnDocument(document))
+	}
+	return response, nil
+}
+
+func (h *ReturnHandler) PostReturn(req dto.ReturnPostRequest) (dto.ReturnDocumentResponse, error) {
+	input, err := parseReturnPostRequest(req)
+	if err != nil {
+		return dto.ReturnDocumentResponse{}, err
+	}
+	posted, err := h.service.PostReturn(handlerContext(), input)
+	if err != nil {
+		return dto.ReturnDocumentResponse{}, fmt.Errorf("post return: %w", err)
+	}
+	return mapReturnDocument(posted), nil
+}
+
+func parseReturnPostRequest(req dto.ReturnPostRequest) (application.ReturnPostInput, error) {
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return application.ReturnPostInput{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	saleDocumentID, err := domain.NewStockDocumentID(req.SaleDocumentID)
+	if err != nil {
+		return application.ReturnPostInput{}, fmt.Errorf("sale document id: %w", err)
+	}
+	occurredOn, err := domain.ParseBusinessDate(req.OccurredOn)
+	if err != nil {
+		return application.ReturnPostInput{}, fmt.Errorf("occurred on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return application.ReturnPostInput{}, fmt.Errorf("notes: %w", err)
+	}
+	lines := make([]application.ReturnLineInput, 0, len(req.Lines))
+	for index, line := range req.Lines {
+		saleLineID, err := domain.NewStockDocumentLineID(line.SaleLineID)
+		if err != nil {
+			return application.ReturnPostInput{}, fmt.Errorf("line %d: sale line id: %w", index+1, err)
+		}
+		quantity, err := domain.NewPositiveAtomicQuantity(line.QuantityAtomic)
+		if err != nil {
+			return application.ReturnPostInput{}, fmt.Errorf("line %d: quantity: %w", index+1, err)
+		}
+		refund, err := optionalMinorAmountInput(line.RefundMinor)
+		if err != nil {
+			return application.ReturnPostInput{}, fmt.Errorf("line %d: refund: %w", index+1, err)
+		}
+		lines = append(lines, application.ReturnLineInput{
+			SaleLineID: saleLineID,
+			Quantity:   quantity,
+			Refund:     refund,
+		})
+	}
+	return application.ReturnPostInput{
+		IdempotencyKey: idempotencyKey,
+		SaleDocumentID: saleDocumentID,
+		OccurredOn:     occurredOn,
+		Notes:          notes,
+		Lines:          lines,
+	}, nil
+}
+
+func mapReturnDocument(document application.ReturnDocument) dto.ReturnDocumentResponse {
+	lines := document.Lines()
+	response := dto.ReturnDocumentResponse{
+		ID:                  document.ID().Int64(),
+		IdempotencyKey:      document.IdempotencyKey().String(),
+		PostingSequence:     document.PostingSequence().Int64(),
+		SaleDocumentID:      document.SaleDocumentID().Int64(),
+		CounterpartyID:      optionalCounterpartyIDValue(document.CounterpartyID()),
+		OccurredOn:          document.OccurredOn().String(),
+		PostedAtMs:          document.PostedAt().UnixMilli(),
+		CurrencyCode:        document.Currency().Code().String(),
+		CurrencyMinorDigits: int64(document.Currency().MinorDigits().Int()),
+		ReasonCode:          document.Reason().String(),
+		Notes:               optionalText(document.Notes()),
+		Lines:               make([]dto.ReturnLineResponse, 0, len(lines)),
+	}
+	for _, line := range lines {
+		response.Lines = append(response.Lines, mapReturnLine(line))
+	}
+	return response
+}
+
+func mapReturnLine(line application.PostedReturnLine) dto.ReturnLineResponse {
+	allocations := line.Allocations()
+	response := dto.ReturnLineResponse{
+		ID:                        line.ID().Int64(),
+		LineOrder:                 line.LineOrder().Int64(),
+		ItemID:                    line.ItemID().Int64(),
+		QuantityAtomic:            line.Quantity().Int64(),
+		EnteredUnitCode:           line.EnteredUnit().String(),
+		EnteredPackagingName:      optionalText(line.EnteredPackagingName()),
+		ConversionNumeratorAtomic: line.Conversion().NumeratorAtomic(),
+		ConversionDenominator:     line.Conversion().Denominator(),
+		InventoryValueMicro:       line.InventoryValue().Int64(),
+		RefundMinor:               line.Refund().Int64(),
+		ReturnsLineID:             line.ReturnsLineID().Int64(),
+		Allocations:               make([]dto.ReturnAllocationResponse, 0, len(allocations)),
+	}
+	for _, allocation := range allocations {
+		response.Allocations = append(response.Allocations, dto.ReturnAllocationResponse{
+			ID:                   allocation.ID().Int64(),
+			LotID:                allocation.LotID().Int64(),
+			QuantityAtomic:       allocation.Quantity().Int64(),
+			RestoresAllocationID: allocation.RestoresAllocationID().Int64(),
+		})
+	}
+	return response
+}
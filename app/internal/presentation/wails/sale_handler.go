@@ -55,6 +55,51 @@ func (h *SaleHandler) PostSale(req dto.SalePostRequest) (dto.SaleDocumentRespons
 	return mapSaleDocument(posted), nil
 }
 
+// QuoteSale suggests a commercial total for each line from the customer's
+// price list or the items' default prices, for the form to prefill.
+func (h *SaleHandler) QuoteSale(req dto.SaleQuoteRequest) ([]dto.SaleLineQuoteResponse, error) {
+	counterpartyID := domain.None[domain.CounterpartyID]()
+	if req.CounterpartyID != nil {
+		parsed, err := domain.NewCounterpartyID(*req.CounterpartyID)
+		if err != nil {
+			return nil, fmt.Errorf("counterparty id: %w", err)
+		}
+		counterpartyID = domain.Some(parsed)
+	}
+	occurredOn, err := domain.ParseBusinessDate(req.OccurredOn)
+	if err != nil {
+		return nil, fmt.Errorf("occurred on: %w", err)
+	}
+	lines := make([]application.SaleLineInput, 0, len(req.Lines))
+	for index, line := range req.Lines {
+		parsed, err := parseSaleLineRequest(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", index+1, err)
+		}
+		lines = append(lines, parsed)
+	}
+	quotes, err := h.service.QuoteSale(handlerContext(), application.SaleQuoteInput{
+		CounterpartyID: counterpartyID,
+		OccurredOn:     occurredOn,
+		Lines:          lines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("quote sale: %w", err)
+	}
+	response := make([]dto.SaleLineQuoteResponse, 0, len(quotes))
+	for _, quote := range quotes {
+		response = append(response, dto.SaleLineQuoteResponse{
+			Source:               string(quote.Source),
+			PriceListID:          optionalPriceListIDValue(quote.PriceListID),
+			PackagingID:          optionalPackagingIDValue(quote.PackagingID),
+			MinQuantityAtomic:    quote.MinQuantity.Int64(),
+			UnitPriceMinor:       optionalMinorAmount(quote.UnitPrice),
+			CommercialTotalMinor: optionalMinorAmount(quote.CommercialTotal),
+		})
+	}
+	return response, nil
+}
+
 func parseSaleListRequest(req dto.SaleListRequest) (application.SaleListInput, error) {
 	pageSize := req.PageSize
 	if pageSize == 0 {
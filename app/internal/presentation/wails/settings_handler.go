@@ -5,6 +5,7 @@ import (
 
 	"github.com/jerobas/saas/internal/application"
 	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/settings"
 	"github.com/jerobas/saas/internal/presentation/wails/dto"
 )
 
@@ -67,6 +68,16 @@ func (h *SettingsHandler) UpdateSettings(req dto.SettingsUpdateRequest) (dto.Set
 		defaultGrossMargin = domain.None[domain.BasisPoints]()
 	}
 
+	backup, err := settings.NewBackupPolicy(settings.BackupPolicyParams{
+		Enabled:         req.Backup.Enabled,
+		IntervalMinutes: req.Backup.IntervalMinutes,
+		KeepDaily:       req.Backup.KeepDaily,
+		KeepWeekly:      req.Backup.KeepWeekly,
+	})
+	if err != nil {
+		return dto.SettingsResponse{}, fmt.Errorf("backup policy: %w", err)
+	}
+
 	expectedUpdatedAt, err := domain.UTCInstantFromUnixMilli(req.ExpectedUpdatedAtMs)
 	if err != nil {
 		return dto.SettingsResponse{}, fmt.Errorf("expected updated at: %w", err)
@@ -79,6 +90,7 @@ func (h *SettingsHandler) UpdateSettings(req dto.SettingsUpdateRequest) (dto.Set
 		Currency:           currency,
 		HourlyLaborCost:    hourlyLaborCost,
 		DefaultGrossMargin: defaultGrossMargin,
+		Backup:             backup,
 		ExpectedUpdatedAt:  expectedUpdatedAt,
 	})
 	if err != nil {
@@ -94,6 +106,7 @@ func mapSettings(settingsValue interface {
 	Currency() domain.Currency
 	HourlyLaborCost() domain.Option[domain.MinorAmount]
 	DefaultGrossMargin() domain.Option[domain.BasisPoints]
+	Backup() settings.BackupPolicy
 	CreatedAt() domain.UTCInstant
 	UpdatedAt() domain.UTCInstant
 }) dto.SettingsResponse {
@@ -107,8 +120,14 @@ func mapSettings(settingsValue interface {
 		CurrencyMinorDigits: int64(settingsValue.Currency().MinorDigits().Int()),
 		HourlyLaborCost:     hourlyLaborCost,
 		DefaultGrossMargin:  defaultGrossMargin,
-		CreatedAtMs:         settingsValue.CreatedAt().UnixMilli(),
-		UpdatedAtMs:         settingsValue.UpdatedAt().UnixMilli(),
+		Backup: dto.BackupPolicyResponse{
+			Enabled:         settingsValue.Backup().Enabled(),
+			IntervalMinutes: settingsValue.Backup().IntervalMinutes(),
+			KeepDaily:       settingsValue.Backup().KeepDaily(),
+			KeepWeekly:      settingsValue.Backup().KeepWeekly(),
+		},
+		CreatedAtMs: settingsValue.CreatedAt().UnixMilli(),
+		UpdatedAtMs: settingsValue.UpdatedAt().UnixMilli(),
 	}
 }
 
@@ -17,6 +17,7 @@ func TestMapSettingsIncludesOptionalValues(t *testing.T) {
 		Currency:           must(domain.RestoreCurrency("BRL", 2)),
 		HourlyLaborCost:    domain.Some(must(domain.NewMinorAmount(2_500))),
 		DefaultGrossMargin: domain.Some(must(domain.NewBasisPoints(3_000))),
+		Backup:             must(settings.NewBackupPolicy(settings.BackupPolicyParams{Enabled: true, IntervalMinutes: 720, KeepDaily: 7, KeepWeekly: 4})),
 		CreatedAt:          created,
 		UpdatedAt:          updated,
 	}))
@@ -31,6 +32,10 @@ func TestMapSettingsIncludesOptionalValues(t *testing.T) {
 	if response.DefaultGrossMargin == nil || *response.DefaultGrossMargin != 3_000 {
 		t.Fatalf("default gross margin = %#v", response.DefaultGrossMargin)
 	}
+	if !response.Backup.Enabled || response.Backup.IntervalMinutes != 720 ||
+		response.Backup.KeepDaily != 7 || response.Backup.KeepWeekly != 4 {
+		t.Fatalf("backup policy = %#v", response.Backup)
+	}
 	if response.CreatedAtMs != created.UnixMilli() || response.UpdatedAtMs != updated.UnixMilli() {
 		t.Fatalf("timestamps = %d/%d", response.CreatedAtMs, response.UpdatedAtMs)
 	}
@@ -46,6 +51,7 @@ func TestMapSettingsKeepsOptionalValuesEmpty(t *testing.T) {
 		Currency:           must(domain.RestoreCurrency("BRL", 2)),
 		HourlyLaborCost:    domain.None[domain.MinorAmount](),
 		DefaultGrossMargin: domain.None[domain.BasisPoints](),
+		Backup:             must(settings.NewBackupPolicy(settings.BackupPolicyParams{Enabled: true, IntervalMinutes: 720, KeepDaily: 7, KeepWeekly: 4})),
 		CreatedAt:          created,
 		UpdatedAt:          updated,
 	}))
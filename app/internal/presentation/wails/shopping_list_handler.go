@@ -0,0 +1,110 @@
+package wails
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type ShoppingListHandler struct {
+	service *application.ShoppingListService
+}
+
+func NewShoppingListHandler(service *application.ShoppingListService) *ShoppingListHandler {
+	if service == nil {
+		panic("shopping list handler requires a service")
+	}
+	return &ShoppingListHandler{service: service}
+}
+
+func (h *ShoppingListHandler) BuildShoppingList(req dto.ShoppingListRequest) (dto.ShoppingListResponse, error) {
+	list, err := h.build(req)
+	if err != nil {
+		return dto.ShoppingListResponse{}, err
+	}
+	return mapShoppingList(list), nil
+}
+
+// ExportShoppingListCSV returns the shopping list as CSV text for the
+// frontend to save.
+func (h *ShoppingListHandler) ExportShoppingListCSV(req dto.ShoppingListRequest) (string, error) {
+	list, err := h.build(req)
+	if err != nil {
+		return "", err
+	}
+	var csv strings.Builder
+	if err := list.WriteCSV(&csv); err != nil {
+		return "", fmt.Errorf("export shopping list: %w", err)
+	}
+	return csv.String(), nil
+}
+
+func (h *ShoppingListHandler) build(req dto.ShoppingListRequest) (application.ShoppingList, error) {
+	on, err := domain.ParseBusinessDate(req.BusinessDate)
+	if err != nil {
+		return application.ShoppingList{}, fmt.Errorf("business date: %w", err)
+	}
+	runs := make([]application.ShoppingRunInput, 0, len(req.Runs))
+	for index, run := range req.Runs {
+		revisionID, err := domain.NewRecipeRevisionID(run.RecipeRevisionID)
+		if err != nil {
+			return application.ShoppingList{}, fmt.Errorf("run %d recipe revision id: %w", index+1, err)
+		}
+		quantity, err := domain.NewPositiveAtomicQuantity(run.QuantityAtomic)
+		if err != nil {
+			return application.ShoppingList{}, fmt.Errorf("run %d quantity: %w", index+1, err)
+		}
+		runs = append(runs, application.ShoppingRunInput{RevisionID: revisionID, Quantity: quantity})
+	}
+	list, err := h.service.BuildShoppingList(handlerContext(), application.ShoppingListInput{On: on, Runs: runs})
+	if err != nil {
+		return application.ShoppingList{}, fmt.Errorf("build shopping list: %w", err)
+	}
+	return list, nil
+}
+
+func mapShoppingList(list application.ShoppingList) dto.ShoppingListResponse {
+	groups := list.Groups()
+	response := dto.ShoppingListResponse{
+		BusinessDate: list.On().String(),
+		Groups:       make([]dto.ShoppingListGroupResponse, 0, len(groups)),
+	}
+	for _, group := range groups {
+		lines := group.Lines()
+		mapped := dto.ShoppingListGroupResponse{Lines: make([]dto.ShoppingListLineResponse, 0, len(lines))}
+		if supplier, ok := group.Supplier().Get(); ok {
+			id, name := supplier.ID.Int64(), supplier.Name.String()
+			mapped.SupplierID = &id
+			mapped.SupplierName = &name
+		}
+		for _, line := range lines {
+			mapped.Lines = append(mapped.Lines, mapShoppingListLine(line))
+		}
+		response.Groups = append(response.Groups, mapped)
+	}
+	return response
+}
+
+func mapShoppingListLine(line application.ShoppingListLine) dto.ShoppingListLineResponse {
+	response := dto.ShoppingListLineResponse{
+		ItemID:                    line.ItemID().Int64(),
+		ItemName:                  line.ItemName().Display(),
+		BaseUnitCode:              line.BaseUnit().String(),
+		BaseConversionNumerator:   line.BaseConversion().NumeratorAtomic(),
+		BaseConversionDenominator: line.BaseConversion().Denominator(),
+		RequiredQuantityAtomic:    line.Required().Int64(),
+		AvailableQuantityAtomic:   line.Available().Int64(),
+		ReorderQuantityAtomic:     line.ReorderBuffer().Int64(),
+		ToBuyQuantityAtomic:       line.ToBuy().Int64(),
+	}
+	if packaging, ok := line.Packaging().Get(); ok {
+		name, unit, packages := packaging.Name.Display(), packaging.EnteredUnit.String(), line.Packages()
+		response.PackagingName = &name
+		response.PackagingUnitCode = &unit
+		response.Packages = &packages
+	}
+	return response
+}
@@ -0,0 +1,228 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/domain/inventory"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type StockCountHandler struct {
+	service *application.StockCountService
+}
+
+func NewStockCountHandler(service *application.StockCountService) *StockCountHandler {
+	if service == nil {
+		panic("stock count handler requires a service")
+	}
+	return &StockCountHandler{service: service}
+}
+
+func (h *StockCountHandler) GetStockCount(id int64) (dto.StockCountResponse, error) {
+	countID, err := domain.NewStockCountID(id)
+	if err != nil {
+		return dto.StockCountResponse{}, fmt.Errorf("stock count id: %w", err)
+	}
+	count, err := h.service.GetStockCount(handlerContext(), countID)
+	if err != nil {
+		return dto.StockCountResponse{}, fmt.Errorf("get stock count: %w", err)
+	}
+	return mapStockCount(count), nil
+}
+
+func (h *StockCountHandler) ListStockCounts(req dto.StockCountListRequest) (dto.StockCountPageResponse, error) {
+	input, err := parseStockCountListRequest(req)
+	if err != nil {
+		return dto.StockCountPageResponse{}, err
+	}
+	page, err := h.service.ListStockCounts(handlerContext(), input)
+	if err != nil {
+		return dto.StockCountPageResponse{}, fmt.Errorf("list stock counts: %w", err)
+	}
+	items := page.Items()
+	response := dto.StockCountPageResponse{Items: make([]dto.StockCountResponse, 0, len(items))}
+	for _, item := range items {
+		response.Items = append(response.Items, mapStockCount(item))
+	}
+	if next, ok := page.Next().Get(); ok {
+		raw := next.Int64()
+		response.Next = &raw
+	}
+	return response, nil
+}
+
+func (h *StockCountHandler) StartStockCount(req dto.StockCountStartRequest) (dto.StockCountResponse, error) {
+	input, err := parseStockCountStartRequest(req)
+	if err != nil {
+		return dto.StockCountResponse{}, err
+	}
+	count, err := h.service.StartStockCount(handlerContext(), input)
+	if err != nil {
+		return dto.StockCountResponse{}, fmt.Errorf("start stock count: %w", err)
+	}
+	return mapStockCount(count), nil
+}
+
+func (h *StockCountHandler) RecordStockCount(id int64, req dto.StockCountRecordRequest) (dto.StockCountResponse, error) {
+	countID, expectedUpdatedAt, err := parseVersionedStockCount(id, req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return dto.StockCountResponse{}, err
+	}
+	observations := make([]application.StockCountObservationInput, 0, len(req.Observations))
+	for index, observation := range req.Observations {
+		parsed, err := parseStockCountObservationRequest(observation)
+		if err != nil {
+			return dto.StockCountResponse{}, fmt.Errorf("observation %d: %w", index+1, err)
+		}
+		observations = append(observations, parsed)
+	}
+	count, err := h.service.RecordStockCount(handlerContext(), application.StockCountRecordInput{
+		ID:                countID,
+		Observations:      observations,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.StockCountResponse{}, fmt.Errorf("record stock count: %w", err)
+	}
+	return mapStockCount(count), nil
+}
+
+func (h *StockCountHandler) CancelStockCount(id int64, req dto.VersionedStockCountRequest) (dto.StockCountResponse, error) {
+	countID, expectedUpdatedAt, err := parseVersionedStockCount(id, req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return dto.StockCountResponse{}, err
+	}
+	count, err := h.service.CancelStockCount(handlerContext(), application.StockCountCancelInput{
+		ID: countID, ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.StockCountResponse{}, fmt.Errorf("cancel stock count: %w", err)
+	}
+	return mapStockCount(count), nil
+}
+
+func (h *StockCountHandler) CloseStockCount(id int64, req dto.StockCountCloseRequest) (dto.StockCountClosingResponse, error) {
+	countID, expectedUpdatedAt, err := parseVersionedStockCount(id, req.ExpectedUpdatedAtMs)
+	if err != nil {
+		return dto.StockCountClosingResponse{}, err
+	}
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return dto.StockCountClosingResponse{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	closing, err := h.service.CloseStockCount(handlerContext(), application.StockCountCloseInput{
+		ID:                countID,
+		IdempotencyKey:    idempotencyKey,
+		ExpectedUpdatedAt: expectedUpdatedAt,
+	})
+	if err != nil {
+		return dto.StockCountClosingResponse{}, fmt.Errorf("close stock count: %w", err)
+	}
+	response := dto.StockCountClosingResponse{Count: mapStockCount(closing.Count())}
+	if adjustment, ok := closing.Adjustment().Get(); ok {
+		mapped := mapAdjustmentDocument(adjustment)
+		response.Adjustment = &mapped
+	}
+	return response, nil
+}
+
+func parseVersionedStockCount(id int64, expectedUpdatedAtMs int64) (domain.StockCountID, domain.UTCInstant, error) {
+	countID, err := domain.NewStockCountID(id)
+	if err != nil {
+		return domain.StockCountID{}, domain.UTCInstant{}, fmt.Errorf("stock count id: %w", err)
+	}
+	expectedUpdatedAt, err := domain.UTCInstantFromUnixMilli(expectedUpdatedAtMs)
+	if err != nil {
+		return domain.StockCountID{}, domain.UTCInstant{}, fmt.Errorf("expected updated at: %w", err)
+	}
+	return countID, expectedUpdatedAt, nil
+}
+
+func parseStockCountListRequest(req dto.StockCountListRequest) (application.StockCountListInput, error) {
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 50
+	}
+	status := domain.None[domain.StockCountStatus]()
+	if req.Status != nil {
+		parsed, err := domain.ParseStockCountStatus(*req.Status)
+		if err != nil {
+			return application.StockCountListInput{}, fmt.Errorf("status: %w", err)
+		}
+		status = domain.Some(parsed)
+	}
+	after := domain.None[domain.StockCountID]()
+	if req.After != nil {
+		parsed, err := domain.NewStockCountID(*req.After)
+		if err != nil {
+			return application.StockCountListInput{}, fmt.Errorf("cursor id: %w", err)
+		}
+		after = domain.Some(parsed)
+	}
+	return application.StockCountListInput{Status: status, After: after, PageSize: pageSize}, nil
+}
+
+func parseStockCountStartRequest(req dto.StockCountStartRequest) (application.StockCountStartInput, error) {
+	countedOn, err := domain.ParseBusinessDate(req.CountedOn)
+	if err != nil {
+		return application.StockCountStartInput{}, fmt.Errorf("counted on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return application.StockCountStartInput{}, fmt.Errorf("notes: %w", err)
+	}
+	items := make([]application.StockCountItemInput, 0, len(req.Items))
+	for index, item := range req.Items {
+		itemID, err := domain.NewItemID(item.ItemID)
+		if err != nil {
+			return application.StockCountStartInput{}, fmt.Errorf("item %d id: %w", index+1, err)
+		}
+		items = append(items, application.StockCountItemInput{ItemID: itemID, ByLot: item.ByLot})
+	}
+	return application.StockCountStartInput{CountedOn: countedOn, Notes: notes, Items: items}, nil
+}
+
+func parseStockCountObservationRequest(req dto.StockCountObservationRequest) (application.StockCountObservationInput, error) {
+	lineID, err := domain.NewStockCountLineID(req.LineID)
+	if err != nil {
+		return application.StockCountObservationInput{}, fmt.Errorf("line id: %w", err)
+	}
+	observed, err := optionalAtomicQuantity(req.ObservedQuantityAtomic)
+	if err != nil {
+		return application.StockCountObservationInput{}, fmt.Errorf("observed quantity: %w", err)
+	}
+	return application.StockCountObservationInput{LineID: lineID, Observed: observed}, nil
+}
+
+func mapStockCount(count inventory.Count) dto.StockCountResponse {
+	lines := count.Lines()
+	response := dto.StockCountResponse{
+		ID:                    count.ID().Int64(),
+		Status:                count.Status().String(),
+		CountedOn:             count.CountedOn().String(),
+		FrozenPostingSequence: count.FrozenPostingSequence(),
+		Notes:                 optionalText(count.Notes()),
+		AdjustmentDocumentID:  invOptionalStockDocumentID(count.AdjustmentDocumentID()),
+		CreatedAtMs:           count.CreatedAt().UnixMilli(),
+		UpdatedAtMs:           count.UpdatedAt().UnixMilli(),
+		Lines:                 make([]dto.StockCountLineResponse, 0, len(lines)),
+	}
+	for _, line := range lines {
+		response.Lines = append(response.Lines, dto.StockCountLineResponse{
+			ID:                     line.ID().Int64(),
+			ItemID:                 line.ItemID().Int64(),
+			LotID:                  optionalInventoryLotID(line.LotID()),
+			LotCode:                optionalText(line.LotCode()),
+			ExpiresOn:              optionalBusinessDateValue(line.ExpiresOn()),
+			ExpectedQuantityAtomic: line.ExpectedQuantity().Int64(),
+			ExpectedValueMicro:     line.ExpectedValue().Int64(),
+			ObservedQuantityAtomic: optionalAtomicQuantityValue(line.ObservedQuantity()),
+			VarianceAtomic:         optionalInt64(line.Variance()),
+			VarianceValueMicro:     optionalInt64(line.VarianceValue()),
+			ChangedSinceFreeze:     line.ChangedSinceFreeze(),
+		})
+	}
+	return response
+}
@@ -0,0 +1,144 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type StockDocumentHandler struct {
+	service *application.StockDocumentService
+}
+
+func NewStockDocumentHandler(service *application.StockDocumentService) *StockDocumentHandler {
+	if service == nil {
+		panic("stock document handler requires a service")
+	}
+	return &StockDocumentHandler{service: service}
+}
+
+func (h *StockDocumentHandler) ListDocuments(req dto.StockDocumentListRequest) (dto.StockDocumentPageResponse, error) {
+	input, err := parseStockDocumentListRequest(req)
+	if err != nil {
+		return dto.StockDocumentPageResponse{}, err
+	}
+	page, err := h.service.ListDocuments(handlerContext(), input)
+	if err != nil {
+		return dto.StockDocumentPageResponse{}, fmt.Errorf("list documents: %w", err)
+	}
+	return mapStockDocumentPage(page), nil
+}
+
+func parseStockDocumentListRequest(req dto.StockDocumentListRequest) (application.StockDocumentListInput, error) {
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = 50
+	}
+	kind := domain.None[domain.DocumentKind]()
+	if req.Kind != nil {
+		parsed, err := domain.ParseDocumentKind(*req.Kind)
+		if err != nil {
+			return application.StockDocumentListInput{}, fmt.Errorf("kind: %w", err)
+		}
+		kind = domain.Some(parsed)
+	}
+	reason := domain.None[domain.DocumentReason]()
+	if req.ReasonCode != nil {
+		parsed, err := domain.ParseDocumentReasonCode(*req.ReasonCode)
+		if err != nil {
+			return application.StockDocumentListInput{}, fmt.Errorf("reason code: %w", err)
+		}
+		reason = domain.Some(parsed)
+	}
+	counterpartyID := domain.None[domain.CounterpartyID]()
+	if req.CounterpartyID != nil {
+		parsed, err := domain.NewCounterpartyID(*req.CounterpartyID)
+		if err != nil {
+			return application.StockDocumentListInput{}, fmt.Errorf("counterparty id: %w", err)
+		}
+		counterpartyID = domain.Some(parsed)
+	}
+	from, err := optionalBusinessDateFromString(req.FromOccurredOn)
+	if err != nil {
+		return application.StockDocumentListInput{}, fmt.Errorf("from occurred on: %w", err)
+	}
+	to, err := optionalBusinessDateFromString(req.ToOccurredOn)
+	if err != nil {
+		return application.StockDocumentListInput{}, fmt.Errorf("to occurred on: %w", err)
+	}
+	reversal := domain.ReversalFilterAll
+	if req.Reversal != "" {
+		reversal, err = domain.ParseReversalFilter(req.Reversal)
+		if err != nil {
+			return application.StockDocumentListInput{}, fmt.Errorf("reversal: %w", err)
+		}
+	}
+	notesSearch, err := optionalNonEmptyText(req.NotesSearch)
+	if err != nil {
+		return application.StockDocumentListInput{}, fmt.Errorf("notes search: %w", err)
+	}
+	after := domain.None[application.StockDocumentCursor]()
+	if req.After != nil {
+		postingSequence, err := domain.NewPostingSequence(req.After.PostingSequence)
+		if err != nil {
+			return application.StockDocumentListInput{}, fmt.Errorf("cursor posting sequence: %w", err)
+		}
+		id, err := domain.NewStockDocumentID(req.After.ID)
+		if err != nil {
+			return application.StockDocumentListInput{}, fmt.Errorf("cursor id: %w", err)
+		}
+		after = domain.Some(application.StockDocumentCursor{PostingSequence: postingSequence, ID: id})
+	}
+	return application.StockDocumentListInput{
+		Kind:           kind,
+		Reason:         reason,
+		CounterpartyID: counterpartyID,
+		FromOccurredOn: from,
+		ToOccurredOn:   to,
+		Reversal:       reversal,
+		NotesSearch:    notesSearch,
+		After:          after,
+		PageSize:       pageSize,
+	}, nil
+}
+
+func mapStockDocumentPage(page application.StockDocumentPage) dto.StockDocumentPageResponse {
+	items := page.Items()
+	response := dto.StockDocumentPageResponse{
+		Items: make([]dto.StockDocumentSummaryResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		response.Items = append(response.Items, mapStockDocumentSummary(item))
+	}
+	if cursor, ok := page.Next().Get(); ok {
+		response.Next = &dto.StockDocumentCursorResponse{
+			PostingSequence: cursor.PostingSequence.Int64(),
+			ID:              cursor.ID.Int64(),
+		}
+	}
+	return response
+}
+
+func mapStockDocumentSummary(summary application.StockDocumentSummary) dto.StockDocumentSummaryResponse {
+	return dto.StockDocumentSummaryResponse{
+		ID:                     summary.ID.Int64(),
+		Kind:                   summary.Kind.String(),
+		IdempotencyKey:         summary.IdempotencyKey.String(),
+		PostingSequence:        summary.PostingSequence.Int64(),
+		CounterpartyID:         optionalCounterpartyIDValue(summary.CounterpartyID),
+		OccurredOn:             summary.OccurredOn.String(),
+		PostedAtMs:             summary.PostedAt.UnixMilli(),
+		CurrencyCode:           summary.Currency.Code().String(),
+		CurrencyMinorDigits:    int64(summary.Currency.MinorDigits().Int()),
+		ReasonCode:             optionalDocumentReasonValue(summary.Reason),
+		Notes:                  optionalText(summary.Notes),
+		LineCount:              summary.LineCount,
+		InventoryValueInMicro:  summary.InventoryValueIn.Int64(),
+		InventoryValueOutMicro: summary.InventoryValueOut.Int64(),
+		CommercialTotalMinor:   optionalMinorAmount(summary.CommercialTotal),
+		ReversesDocumentID:     invOptionalStockDocumentID(summary.ReversesDocumentID),
+		ReversedByDocumentID:   invOptionalStockDocumentID(summary.ReversedByDocumentID),
+	}
+}
@@ -0,0 +1,142 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type SupplierReturnHandler struct {
+	service *application.SupplierReturnService
+}
+
+func NewSupplierReturnHandler(service *application.SupplierReturnService) *SupplierReturnHandler {
+	if service == nil {
+		panic("supplier return handler requires a service")
+	}
+	return &SupplierReturnHandler{service: service}
+}
+
+func (h *SupplierReturnHandler) GetSupplierReturn(id int64) (dto.SupplierReturnDocumentResponse, error) {
+	documentID, err := domain.NewStockDocumentID(id)
+	if err != nil {
+		return dto.SupplierReturnDocumentResponse{}, fmt.Errorf("supplier return id: %w", err)
+	}
+	document, err := h.service.GetSupplierReturn(handlerContext(), documentID)
+	if err != nil {
+		return dto.SupplierReturnDocumentResponse{}, fmt.Errorf("get supplier return: %w", err)
+	}
+	return mapSupplierReturnDocument(document), nil
+}
+
+func (h *SupplierReturnHandler) ListPurchaseReturns(purchaseID int64) ([]dto.SupplierReturnDocumentResponse, error) {
+	documentID, err := domain.NewStockDocumentID(purchaseID)
+	if err != nil {
+		return nil, fmt.Errorf("purchase id: %w", err)
+	}
+	documents, err := h.service.ListPurchaseReturns(handlerContext(), documentID)
+	if err != nil {
+		return nil, fmt.Errorf("list purchase returns: %w", err)
+	}
+	response := make([]dto.SupplierReturnDocumentResponse, 0, len(documents))
+	for _, document := range documents {
+		response = append(response, mapSupplierReturnDocument(document))
+	}
+	return response, nil
+}
+
+func (h *SupplierReturnHandler) PostSupplierReturn(req dto.SupplierReturnPostRequest) (dto.SupplierReturnDocumentResponse, error) {
+	input, err := parseSupplierReturnPostRequest(req)
+	if err != nil {
+		return dto.SupplierReturnDocumentResponse{}, err
+	}
+	posted, err := h.service.PostSupplierReturn(handlerContext(), input)
+	if err != nil {
+		return dto.SupplierReturnDocumentResponse{}, fmt.Errorf("post supplier return: %w", err)
+	}
+	return mapSupplierReturnDocument(posted), nil
+}
+
+func parseSupplierReturnPostRequest(req dto.SupplierReturnPostRequest) (application.SupplierReturnPostInput, error) {
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return application.SupplierReturnPostInput{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	purchaseDocumentID, err := domain.NewStockDocumentID(req.PurchaseDocumentID)
+	if err != nil {
+		return application.SupplierReturnPostInput{}, fmt.Errorf("purchase document id: %w", err)
+	}
+	occurredOn, err := domain.ParseBusinessDate(req.OccurredOn)
+	if err != nil {
+		return application.SupplierReturnPostInput{}, fmt.Errorf("occurred on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return application.SupplierReturnPostInput{}, fmt.Errorf("notes: %w", err)
+	}
+	lines := make([]application.SupplierReturnLineInput, 0, len(req.Lines))
+	for index, line := range req.Lines {
+		purchaseLineID, err := domain.NewStockDocumentLineID(line.PurchaseLineID)
+		if err != nil {
+			return application.SupplierReturnPostInput{}, fmt.Errorf("line %d: purchase line id: %w", index+1, err)
+		}
+		quantity, err := domain.NewPositiveAtomicQuantity(line.QuantityAtomic)
+		if err != nil {
+			return application.SupplierReturnPostInput{}, fmt.Errorf("line %d: quantity: %w", index+1, err)
+		}
+		credit, err := optionalMinorAmountInput(line.CreditMinor)
+		if err != nil {
+			return application.SupplierReturnPostInput{}, fmt.Errorf("line %d: credit: %w", index+1, err)
+		}
+		lines = append(lines, application.SupplierReturnLineInput{
+			PurchaseLineID: purchaseLineID,
+			Quantity:       quantity,
+			Credit:         credit,
+		})
+	}
+	return application.SupplierReturnPostInput{
+		IdempotencyKey:     idempotencyKey,
+		PurchaseDocumentID: purchaseDocumentID,
+		OccurredOn:         occurredOn,
+		Notes:              notes,
+		Lines:              lines,
+	}, nil
+}
+
+func mapSupplierReturnDocument(document application.SupplierReturnDocument) dto.SupplierReturnDocumentResponse {
+	lines := document.Lines()
+	response := dto.SupplierReturnDocumentResponse{
+		ID:                  document.ID().Int64(),
+		IdempotencyKey:      document.IdempotencyKey().String(),
+		PostingSequence:     document.PostingSequence().Int64(),
+		PurchaseDocumentID:  document.PurchaseDocumentID().Int64(),
+		CounterpartyID:      optionalCounterpartyIDValue(document.CounterpartyID()),
+		OccurredOn:          document.OccurredOn().String(),
+		PostedAtMs:          document.PostedAt().UnixMilli(),
+		CurrencyCode:        document.Currency().Code().String(),
+		CurrencyMinorDigits: int64(document.Currency().MinorDigits().Int()),
+		ReasonCode:          document.Reason().String(),
+		Notes:               optionalText(document.Notes()),
+		Lines:               make([]dto.SupplierReturnLineResponse, 0, len(lines)),
+	}
+	for _, line := range lines {
+		response.Lines = append(response.Lines, dto.SupplierReturnLineResponse{
+			ID:                        line.ID().Int64(),
+			LineOrder:                 line.LineOrder().Int64(),
+			ItemID:                    line.ItemID().Int64(),
+			QuantityAtomic:            line.Quantity().Int64(),
+			EnteredUnitCode:           line.EnteredUnit().String(),
+			EnteredPackagingName:      optionalText(line.EnteredPackagingName()),
+			ConversionNumeratorAtomic: line.Conversion().NumeratorAtomic(),
+			ConversionDenominator:     line.Conversion().Denominator(),
+			InventoryValueMicro:       line.InventoryValue().Int64(),
+			CreditMinor:               line.Credit().Int64(),
+			ReturnsLineID:             line.ReturnsLineID().Int64(),
+			LotID:                     line.LotID().Int64(),
+			AllocationID:              line.AllocationID().Int64(),
+		})
+	}
+	return response
+}
@@ -0,0 +1,118 @@
+package wails
+
+import (
+	"fmt"
+
+	"github.com/jerobas/saas/internal/application"
+	"github.com/jerobas/saas/internal/domain"
+	"github.com/jerobas/saas/internal/presentation/wails/dto"
+)
+
+type TransferHandler struct {
+	service *application.TransferService
+}
+
+func NewTransferHandler(service *application.TransferService) *TransferHandler {
+	if service == nil {
+		panic("transfer handler requires a service")
+	}
+	return &TransferHandler{service: service}
+}
+
+func (h *TransferHandler) GetTransfer(id int64) (dto.TransferDocumentResponse, error) {
+	documentID, err := domain.NewStockDocumentID(id)
+	if err != nil {
+		return dto.TransferDocumentResponse{}, fmt.Errorf("transfer id: %w", err)
+	}
+	document, err := h.service.GetTransfer(handlerContext(), documentID)
+	if err != nil {
+		return dto.TransferDocumentResponse{}, fmt.Errorf("get transfer: %w", err)
+	}
+	return mapTransferDocument(document), nil
+}
+
+func (h *TransferHandler) PostTransfer(req dto.TransferPostRequest) (dto.TransferDocumentResponse, error) {
+	input, err := parseTransferPostRequest(req)
+	if err != nil {
+		return dto.TransferDocumentResponse{}, err
+	}
+	posted, err := h.service.PostTransfer(handlerContext(), input)
+	if err != nil {
+		return dto.TransferDocumentResponse{}, fmt.Errorf("post transfer: %w", err)
+	}
+	return mapTransferDocument(posted), nil
+}
+
+func parseTransferPostRequest(req dto.TransferPostRequest) (application.TransferPostInput, error) {
+	idempotencyKey, err := domain.NewIdempotencyKey(req.IdempotencyKey)
+	if err != nil {
+		return application.TransferPostInput{}, fmt.Errorf("idempotency key: %w", err)
+	}
+	occurredOn, err := domain.ParseBusinessDate(req.OccurredOn)
+	if err != nil {
+		return application.TransferPostInput{}, fmt.Errorf("occurred on: %w", err)
+	}
+	notes, err := optionalNonEmptyText(req.Notes)
+	if err != nil {
+		return application.TransferPostInput{}, fmt.Errorf("notes: %w", err)
+	}
+	lines := make([]application.TransferLineInput, 0, len(req.Lines))
+	for index, line := range req.Lines {
+		lotID, err := domain.NewInventoryLotID(line.LotID)
+		if err != nil {
+			return application.TransferPostInput{}, fmt.Errorf("line %d: lot id: %w", index+1, err)
+		}
+		quantity, err := domain.NewPositiveAtomicQuantity(line.QuantityAtomic)
+		if err != nil {
+			return application.TransferPostInput{}, fmt.Errorf("line %d: quantity: %w", index+1, err)
+		}
+		toLocationID, err := domain.NewStockLocationID(line.ToLocationID)
+		if err != nil {
+			return application.TransferPostInput{}, fmt.Errorf("line %d: to location id: %w", index+1, err)
+		}
+		lines = append(lines, application.TransferLineInput{
+			LotID:        lotID,
+			Quantity:     quantity,
+			ToLocationID: toLocationID,
+		})
+	}
+	return application.TransferPostInput{
+		IdempotencyKey: idempotencyKey,
+		OccurredOn:     occurredOn,
+		Notes:          notes,
+		Lines:          lines,
+	}, nil
+}
+
+func mapTransferDocument(document application.TransferDocument) dto.TransferDocumentResponse {
+	lines := document.Lines()
+	response := dto.TransferDocumentResponse{
+		ID:              document.ID().Int64(),
+		IdempotencyKey:  document.IdempotencyKey().String(),
+		PostingSequence: document.PostingSequence().Int64(),
+		OccurredOn:      document.OccurredOn().String(),
+		PostedAtMs:      document.PostedAt().UnixMilli(),
+		Notes:           optionalText(document.Notes()),
+		Lines:           make([]dto.TransferLineResponse, 0, len(lines)),
+	}
+	for _, line := range lines {
+		response.Lines = append(response.Lines, dto.TransferLineResponse{
+			OutLineID:                 line.OutLineID().Int64(),
+			InLineID:                  line.InLineID().Int64(),
+			LineOrder:                 line.LineOrder().Int64(),
+			ItemID:                    line.ItemID().Int64(),
+			QuantityAtomic:            line.Quantity().Int64(),
+			EnteredUnitCode:           line.EnteredUnit().String(),
+			EnteredPackagingName:      optionalText(line.EnteredPackagingName()),
+			ConversionNumeratorAtomic: line.Conversion().NumeratorAtomic(),
+			ConversionDenominator:     line.Conversion().Denominator(),
+			InventoryValueMicro:       line.InventoryValue().Int64(),
+			SourceLotID:               line.SourceLotID().Int64(),
+			AllocationID:              line.AllocationID().Int64(),
+			FromLocationID:            line.FromLocationID().Int64(),
+			LotID:                     line.LotID().Int64(),
+			ToLocationID:              line.ToLocationID().Int64(),
+		})
+	}
+	return response
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/jerobas/saas/database"
 	"github.com/jerobas/saas/internal/application"
@@ -22,6 +24,10 @@ var assets embed.FS
 
 var db *database.Database
 
+// appVersion is recorded in backup manifests; release builds set it with
+// -ldflags "-X main.appVersion=...".
+var appVersion = "dev"
+
 func initDat() {
 	if bindingGeneration {
 		var err error
@@ -38,8 +44,17 @@ func initDat() {
 	}
 
 	dbPath := filepath.Join(appDir, "app.db")
-	var err error
-	db, err = database.NewDatabase(dbPath)
+	// A restore staged by the previous process is activated before any
+	// connection exists, so no store can observe the replaced file.
+	activation, err := database.ActivatePendingRestore(dbPath, time.Now())
+	if err != nil {
+		log.Printf("pending database restore was not activated: %v", err)
+	} else if activation.Activated {
+		log.Printf("database restored; safety backup written to %s", activation.SafetyBackupPath)
+	}
+	options := database.DefaultOpenOptions()
+	options.AppVersion = appVersion
+	db, err = database.NewDatabaseWithOptions(dbPath, options)
 	if err != nil {
 		log.Fatalf("Erro ao inicializar banco de dados: %v", err)
 	}
@@ -54,12 +69,28 @@ func dataDirectory() string {
 	return filepath.Join(dir, "app")
 }
 
+func backupDirectory() string {
+	return filepath.Join(dataDirectory(), "backups")
+}
+
 func main() {
 	initDat()
-	defer db.Close()
+	restart := run()
+	if err := db.Close(); err != nil {
+		log.Printf("failed to close database: %v", err)
+	}
+	if restart {
+		if err := restartProcess(); err != nil {
+			log.Fatalf("failed to restart after database restore: %v", err)
+		}
+	}
+}
 
+// run wires every store and handler against the current db and blocks until
+// the window closes. It reports whether a staged restore needs a new process.
+func run() bool {
 	app := NewApp()
-	databaseService := presentationwails.NewDatabaseService(db)
+	databaseService := presentationwails.NewDatabaseService(db, backupDirectory())
 	app.DatabaseService = databaseService
 
 	sqliteStore := sqlite.NewStore(db)
@@ -82,6 +113,10 @@ func main() {
 		application.SystemClock{},
 	)
 	purchaseHandler := presentationwails.NewPurchaseHandler(purchaseService)
+	purchaseOrderHandler := presentationwails.NewPurchaseOrderHandler(application.NewPurchaseOrderService(
+		application.NewSQLitePurchaseOrderStore(sqliteStore),
+		application.SystemClock{},
+	))
 	adjustmentService := application.NewAdjustmentService(
 		application.NewSQLiteAdjustmentStore(sqliteStore),
 		application.SystemClock{},
@@ -102,17 +137,84 @@ func main() {
 		application.SystemClock{},
 	)
 	saleHandler := presentationwails.NewSaleHandler(saleService)
+	draftHandler := presentationwails.NewDraftHandler(application.NewDraftService(
+		application.NewSQLiteDraftStore(sqliteStore),
+		application.SystemClock{},
+		presentationwails.NewDraftPosters(purchaseService, saleService, adjustmentService, productionService),
+	))
+	stockCountHandler := presentationwails.NewStockCountHandler(application.NewStockCountService(
+		application.NewSQLiteStockCountStore(sqliteStore),
+		application.SystemClock{},
+	))
+	customerOrderHandler := presentationwails.NewCustomerOrderHandler(application.NewCustomerOrderService(
+		application.NewSQLiteCustomerOrderStore(sqliteStore),
+		application.SystemClock{},
+	))
+	paymentHandler := presentationwails.NewPaymentHandler(application.NewPaymentService(
+		application.NewSQLitePaymentStore(sqliteStore),
+		application.SystemClock{},
+	))
+	payableHandler := presentationwails.NewPayableHandler(application.NewPayableService(
+		application.NewSQLitePayableStore(sqliteStore),
+		application.SystemClock{},
+	))
+	registerHandler := presentationwails.NewRegisterHandler(application.NewRegisterService(
+		application.NewSQLiteRegisterStore(sqliteStore),
+		application.SystemClock{},
+	))
+	expenseHandler := presentationwails.NewExpenseHandler(application.NewExpenseService(
+		application.NewSQLiteExpenseStore(sqliteStore),
+		application.SystemClock{},
+	))
+	priceListHandler := presentationwails.NewPriceListHandler(application.NewPriceListService(
+		application.NewSQLitePriceListStore(sqliteStore),
+		application.SystemClock{},
+	))
+	returnHandler := presentationwails.NewReturnHandler(application.NewReturnService(
+		application.NewSQLiteReturnStore(sqliteStore),
+		application.SystemClock{},
+	))
+	supplierReturnHandler := presentationwails.NewSupplierReturnHandler(application.NewSupplierReturnService(
+		application.NewSQLiteSupplierReturnStore(sqliteStore),
+		application.SystemClock{},
+	))
 	recipeService := application.NewRecipeService(
 		application.NewSQLiteRecipeStore(sqliteStore),
 		application.SystemClock{},
 	)
 	recipeHandler := presentationwails.NewRecipeHandler(recipeService)
+	pricingHandler := presentationwails.NewPricingHandler(application.NewPricingService(
+		application.NewSQLitePricingStore(sqliteStore),
+	))
+	shoppingListHandler := presentationwails.NewShoppingListHandler(application.NewShoppingListService(
+		application.NewSQLiteShoppingListStore(sqliteStore),
+	))
+	productionPlanHandler := presentationwails.NewProductionPlanHandler(application.NewProductionPlanService(
+		application.NewSQLiteRecipeStore(sqliteStore),
+		application.NewSQLiteInventoryStore(sqliteStore),
+	))
 	inventoryHandler := presentationwails.NewInventoryHandler(application.NewInventoryService(
 		application.NewSQLiteInventoryStore(sqliteStore),
 	))
 	reportingHandler := presentationwails.NewReportingHandler(application.NewReportingService(
 		application.NewSQLiteReportingStore(sqliteStore),
 	))
+	reconciliationHandler := presentationwails.NewReconciliationHandler(application.NewReconciliationService(
+		application.NewSQLiteReconciliationStore(sqliteStore),
+	))
+	stockDocumentHandler := presentationwails.NewStockDocumentHandler(application.NewStockDocumentService(
+		application.NewSQLiteStockDocumentStore(sqliteStore),
+	))
+	locationHandler := presentationwails.NewLocationHandler(application.NewLocationService(
+		application.NewSQLiteLocationStore(sqliteStore),
+		application.SystemClock{},
+	))
+	transferHandler := presentationwails.NewTransferHandler(application.NewTransferService(
+		application.NewSQLiteTransferStore(sqliteStore),
+		application.SystemClock{},
+	))
+	stopBackups := startBackupScheduler(sqliteStore)
+	defer stopBackups()
 
 	err := wails.Run(&options.App{
 		Title:  "app",
@@ -130,17 +232,64 @@ func main() {
 			catalogHandler,
 			counterpartyHandler,
 			purchaseHandler,
+			purchaseOrderHandler,
 			adjustmentHandler,
+			stockCountHandler,
 			reversalHandler,
 			productionHandler,
 			saleHandler,
+			draftHandler,
+			customerOrderHandler,
+			paymentHandler,
+			payableHandler,
+			registerHandler,
+			expenseHandler,
+			priceListHandler,
+			returnHandler,
+			supplierReturnHandler,
 			recipeHandler,
+			pricingHandler,
+			shoppingListHandler,
+			productionPlanHandler,
 			inventoryHandler,
 			reportingHandler,
+			reconciliationHandler,
+			stockDocumentHandler,
+			locationHandler,
+			transferHandler,
 		},
 	})
 
 	if err != nil {
 		println(err.Error())
 	}
+	return databaseService.RestartRequested()
+}
+
+// startBackupScheduler runs automatic backups while the window is open. The
+// returned stop function waits for any backup in progress and then takes the
+// clean-shutdown backup before the database is closed.
+func startBackupScheduler(sqliteStore *sqlite.Store) func() {
+	if bindingGeneration {
+		return func() {}
+	}
+	scheduler := application.NewBackupScheduler(
+		application.NewSQLiteBackupStore(sqliteStore, db, backupDirectory()),
+		application.SystemClock{},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.Run(ctx, func(err error) {
+			log.Printf("automatic backup failed: %v", err)
+		})
+	}()
+	return func() {
+		cancel()
+		<-done
+		if _, err := scheduler.RunShutdown(context.Background()); err != nil {
+			log.Printf("shutdown backup failed: %v", err)
+		}
+	}
 }
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+)
+
+// restartProcess starts a fresh copy of the executable with the same arguments
+// and environment. The new process activates the staged restore before it
+// opens the database, so no handler from this process survives the swap.
+func restartProcess() error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	command := exec.Command(executable, os.Args[1:]...)
+	command.Env = os.Environ()
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	return command.Start()
+}